@@ -0,0 +1,253 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package management provides the client of the management HTTP API of milvus,
+// which is served on the metrics port of the proxy, 9091 by default,
+// for automating the cluster operations, e.g. draining a querynode before taking it down.
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	routeGcPause  = "/management/datacoord/garbage_collection/pause"
+	routeGcResume = "/management/datacoord/garbage_collection/resume"
+
+	routeSuspendQueryCoordBalance = "/management/querycoord/balance/suspend"
+	routeResumeQueryCoordBalance  = "/management/querycoord/balance/resume"
+	routeTransferSegment          = "/management/querycoord/transfer/segment"
+	routeTransferChannel          = "/management/querycoord/transfer/channel"
+
+	routeSuspendQueryNode           = "/management/querycoord/node/suspend"
+	routeResumeQueryNode            = "/management/querycoord/node/resume"
+	routeListQueryNode              = "/management/querycoord/node/list"
+	routeGetQueryNodeDistribution   = "/management/querycoord/distribution/get"
+	routeCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
+
+	routeClusterConfigs = "/api/v1/_cluster/configs"
+	routeQueryCoordTask = "/api/v1/_qc/tasks"
+)
+
+// Client calls the management HTTP API of a milvus cluster.
+type Client struct {
+	address    string
+	httpClient *http.Client
+}
+
+// NewClient returns the client of the management API served at the address, e.g. http://localhost:9091.
+func NewClient(address string, opts ...ClientOption) *Client {
+	c := &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+type ClientOption func(c *Client)
+
+// WithHTTPClient sets the http client sending the requests, e.g. with the TLS config or timeout.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// PauseGC pauses the garbage collection of datacoord for the duration.
+func (c *Client) PauseGC(ctx context.Context, duration time.Duration) error {
+	return c.post(ctx, routeGcPause, url.Values{
+		"pause_seconds": []string{fmt.Sprint(int64(duration.Seconds()))},
+	})
+}
+
+// ResumeGC resumes the garbage collection of datacoord paused.
+func (c *Client) ResumeGC(ctx context.Context) error {
+	return c.post(ctx, routeGcResume, nil)
+}
+
+// SuspendBalance suspends the auto balance of querycoord.
+func (c *Client) SuspendBalance(ctx context.Context) error {
+	return c.post(ctx, routeSuspendQueryCoordBalance, nil)
+}
+
+// ResumeBalance resumes the auto balance of querycoord.
+func (c *Client) ResumeBalance(ctx context.Context) error {
+	return c.post(ctx, routeResumeQueryCoordBalance, nil)
+}
+
+// QueryNodeInfo is a querynode in the cluster.
+type QueryNodeInfo struct {
+	ID      int64  `json:"ID,omitempty"`
+	Address string `json:"address,omitempty"`
+	State   string `json:"state,omitempty"`
+}
+
+// ListQueryNodes lists the querynodes of the cluster.
+func (c *Client) ListQueryNodes(ctx context.Context) ([]*QueryNodeInfo, error) {
+	resp := struct {
+		NodeInfos []*QueryNodeInfo `json:"nodeInfos,omitempty"`
+	}{}
+	if err := c.get(ctx, routeListQueryNode, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.NodeInfos, nil
+}
+
+// QueryNodeDistribution is the channels and the sealed segments loaded on a querynode.
+type QueryNodeDistribution struct {
+	ID               int64    `json:"ID,omitempty"`
+	ChannelNames     []string `json:"channel_names,omitempty"`
+	SealedSegmentIDs []int64  `json:"sealed_segmentIDs,omitempty"`
+}
+
+// GetQueryNodeDistribution returns the channels and the sealed segments loaded on the querynode.
+func (c *Client) GetQueryNodeDistribution(ctx context.Context, nodeID int64) (*QueryNodeDistribution, error) {
+	resp := &QueryNodeDistribution{}
+	if err := c.get(ctx, routeGetQueryNodeDistribution, nodeIDValues("node_id", nodeID), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CheckQueryNodeDistribution returns nil if the target querynode has loaded all the channels
+// and the sealed segments of the source querynode.
+func (c *Client) CheckQueryNodeDistribution(ctx context.Context, sourceNodeID, targetNodeID int64) error {
+	values := nodeIDValues("source_node_id", sourceNodeID)
+	values.Set("target_node_id", fmt.Sprint(targetNodeID))
+	return c.post(ctx, routeCheckQueryNodeDistribution, values)
+}
+
+// SuspendQueryNode stops querycoord assigning new channels and segments to the querynode.
+func (c *Client) SuspendQueryNode(ctx context.Context, nodeID int64) error {
+	return c.post(ctx, routeSuspendQueryNode, nodeIDValues("node_id", nodeID))
+}
+
+// ResumeQueryNode resumes the querynode suspended.
+func (c *Client) ResumeQueryNode(ctx context.Context, nodeID int64) error {
+	return c.post(ctx, routeResumeQueryNode, nodeIDValues("node_id", nodeID))
+}
+
+// TransferSegment moves the sealed segments from a querynode to the others.
+func (c *Client) TransferSegment(ctx context.Context, option TransferSegmentOption) error {
+	return c.post(ctx, routeTransferSegment, option.Values())
+}
+
+// TransferChannel moves the channels from a querynode to the others.
+func (c *Client) TransferChannel(ctx context.Context, option TransferChannelOption) error {
+	return c.post(ctx, routeTransferChannel, option.Values())
+}
+
+// DrainQueryNode suspends the querynode and moves all its channels and sealed segments to the other querynodes,
+// it returns once the transfers submitted, call GetQueryNodeDistribution to wait for the querynode emptied.
+func (c *Client) DrainQueryNode(ctx context.Context, nodeID int64) error {
+	if err := c.SuspendQueryNode(ctx, nodeID); err != nil {
+		return err
+	}
+	if err := c.TransferChannel(ctx, NewTransferChannelOption(nodeID)); err != nil {
+		return err
+	}
+	return c.TransferSegment(ctx, NewTransferSegmentOption(nodeID))
+}
+
+// ListQueryCoordTasks lists the recent tasks of querycoord, loading and releasing the channels and the segments.
+func (c *Client) ListQueryCoordTasks(ctx context.Context) ([]*QueryCoordTask, error) {
+	var tasks []*QueryCoordTask
+	if err := c.get(ctx, routeQueryCoordTask, nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetConfigs returns the configs of the proxy serving the request, the configs are updated
+// through the config source, e.g. etcd, instead of the management API.
+func (c *Client) GetConfigs(ctx context.Context) (map[string]string, error) {
+	configs := make(map[string]string)
+	if err := c.get(ctx, routeClusterConfigs, nil, &configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+func nodeIDValues(key string, nodeID int64) url.Values {
+	return url.Values{key: []string{fmt.Sprint(nodeID)}}
+}
+
+func (c *Client) get(ctx context.Context, path string, values url.Values, resp any) error {
+	target := c.address + path
+	if len(values) > 0 {
+		target += "?" + values.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	body, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return errors.Wrapf(err, "failed to decode the response of %s", path)
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, path string, values url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address+path, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = c.do(req)
+	return err
+}
+
+// do sends the request and returns the body of the response, or the error message in the body if failed.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return body, nil
+	}
+
+	msg := struct {
+		Msg     string `json:"msg"`
+		Message string `json:"message"`
+	}{}
+	reason := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &msg) == nil {
+		reason = msg.Msg + msg.Message
+	}
+	return nil, errors.Newf("%s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, reason)
+}
@@ -0,0 +1,179 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientSuite struct {
+	suite.Suite
+
+	server   *httptest.Server
+	requests []*http.Request
+	forms    []url.Values
+	handlers map[string]http.HandlerFunc
+	client   *Client
+}
+
+func (s *ClientSuite) SetupTest() {
+	s.requests = nil
+	s.forms = nil
+	s.handlers = make(map[string]http.HandlerFunc)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.NoError(req.ParseForm())
+		s.requests = append(s.requests, req)
+		s.forms = append(s.forms, req.Form)
+		if handler, ok := s.handlers[req.URL.Path]; ok {
+			handler(w, req)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"msg": "OK"}`))
+	}))
+	s.client = NewClient(s.server.URL+"/", WithHTTPClient(s.server.Client()))
+}
+
+func (s *ClientSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClientSuite) TestGC() {
+	ctx := context.Background()
+	s.NoError(s.client.PauseGC(ctx, time.Minute))
+	s.NoError(s.client.ResumeGC(ctx))
+
+	s.Require().Len(s.requests, 2)
+	s.Equal(http.MethodPost, s.requests[0].Method)
+	s.Equal(routeGcPause, s.requests[0].URL.Path)
+	s.Equal("60", s.forms[0].Get("pause_seconds"))
+	s.Equal(routeGcResume, s.requests[1].URL.Path)
+}
+
+func (s *ClientSuite) TestListQueryNodes() {
+	s.handlers[routeListQueryNode] = func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"nodeInfos":[{"ID":1,"address":"localhost:21123","state":"Healthy"}]}`))
+	}
+	nodes, err := s.client.ListQueryNodes(context.Background())
+	s.NoError(err)
+	s.Equal([]*QueryNodeInfo{{ID: 1, Address: "localhost:21123", State: "Healthy"}}, nodes)
+	s.Equal(http.MethodGet, s.requests[0].Method)
+}
+
+func (s *ClientSuite) TestGetQueryNodeDistribution() {
+	s.handlers[routeGetQueryNodeDistribution] = func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"ID":1,"channel_names":["dml_0"],"sealed_segmentIDs":[100,101]}`))
+	}
+	dist, err := s.client.GetQueryNodeDistribution(context.Background(), 1)
+	s.NoError(err)
+	s.Equal("1", s.forms[0].Get("node_id"))
+	s.Equal(&QueryNodeDistribution{ID: 1, ChannelNames: []string{"dml_0"}, SealedSegmentIDs: []int64{100, 101}}, dist)
+}
+
+func (s *ClientSuite) TestTransfer() {
+	ctx := context.Background()
+	s.NoError(s.client.TransferSegment(ctx, NewTransferSegmentOption(1).WithTargetNode(2).WithSegment(100).WithCopyMode(true)))
+	s.NoError(s.client.TransferChannel(ctx, NewTransferChannelOption(1).WithChannel("dml_0")))
+
+	s.Require().Len(s.requests, 2)
+	s.Equal(routeTransferSegment, s.requests[0].URL.Path)
+	s.Equal("1", s.forms[0].Get("source_node_id"))
+	s.Equal("2", s.forms[0].Get("target_node_id"))
+	s.Equal("100", s.forms[0].Get("segment_id"))
+	s.Equal("true", s.forms[0].Get("copy_mode"))
+
+	s.Equal(routeTransferChannel, s.requests[1].URL.Path)
+	s.Equal("1", s.forms[1].Get("source_node_id"))
+	s.False(s.forms[1].Has("target_node_id"))
+	s.Equal("dml_0", s.forms[1].Get("channel_name"))
+	s.Equal("false", s.forms[1].Get("copy_mode"))
+}
+
+func (s *ClientSuite) TestDrainQueryNode() {
+	s.NoError(s.client.DrainQueryNode(context.Background(), 1))
+
+	s.Require().Len(s.requests, 3)
+	s.Equal(routeSuspendQueryNode, s.requests[0].URL.Path)
+	s.Equal(routeTransferChannel, s.requests[1].URL.Path)
+	s.Equal(routeTransferSegment, s.requests[2].URL.Path)
+	for _, form := range s.forms[1:] {
+		s.Equal("1", form.Get("source_node_id"))
+		s.False(form.Has("target_node_id"))
+		s.Equal("false", form.Get("copy_mode"))
+	}
+
+	// stop draining once failed
+	s.SetupTest()
+	s.handlers[routeTransferChannel] = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"msg": "failed to transfer channel, no available node"}`))
+	}
+	err := s.client.DrainQueryNode(context.Background(), 1)
+	s.ErrorContains(err, "no available node")
+	s.Len(s.requests, 2)
+}
+
+func (s *ClientSuite) TestListQueryCoordTasks() {
+	s.handlers[routeQueryCoordTask] = func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`[{"task_name":"segment_task","collection_id":"1","replica_id":"2"}]`))
+	}
+	tasks, err := s.client.ListQueryCoordTasks(context.Background())
+	s.NoError(err)
+	s.Require().Len(tasks, 1)
+	s.Equal(int64(1), tasks[0].CollectionID)
+}
+
+func (s *ClientSuite) TestGetConfigs() {
+	s.handlers[routeClusterConfigs] = func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"querynode.enabledisk":"true"}`))
+	}
+	configs, err := s.client.GetConfigs(context.Background())
+	s.NoError(err)
+	s.Equal(map[string]string{"querynode.enabledisk": "true"}, configs)
+}
+
+func (s *ClientSuite) TestError() {
+	ctx := context.Background()
+	s.handlers[routeSuspendQueryCoordBalance] = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "querycoord not ready"}`))
+	}
+	s.ErrorContains(s.client.SuspendBalance(ctx), "querycoord not ready")
+
+	s.handlers[routeResumeQueryCoordBalance] = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("bad request"))
+	}
+	s.ErrorContains(s.client.ResumeBalance(ctx), "bad request")
+
+	s.handlers[routeListQueryNode] = func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("not json"))
+	}
+	_, err := s.client.ListQueryNodes(ctx)
+	s.Error(err)
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
@@ -0,0 +1,128 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+// QueryCoordTask is a task of querycoord, loading or releasing a channel or a segment.
+type QueryCoordTask = metricsinfo.QueryCoordTask
+
+type TransferSegmentOption interface {
+	Values() url.Values
+}
+
+type transferSegmentOption struct {
+	sourceNodeID int64
+	targetNodeID int64
+	segmentID    int64
+	copyMode     bool
+}
+
+func (opt *transferSegmentOption) Values() url.Values {
+	values := url.Values{}
+	values.Set("source_node_id", fmt.Sprint(opt.sourceNodeID))
+	if opt.targetNodeID != 0 {
+		values.Set("target_node_id", fmt.Sprint(opt.targetNodeID))
+	}
+	if opt.segmentID != 0 {
+		values.Set("segment_id", fmt.Sprint(opt.segmentID))
+	}
+	values.Set("copy_mode", strconv.FormatBool(opt.copyMode))
+	return values
+}
+
+// WithTargetNode moves the segments to the querynode only, instead of all the other querynodes.
+func (opt *transferSegmentOption) WithTargetNode(nodeID int64) *transferSegmentOption {
+	opt.targetNodeID = nodeID
+	return opt
+}
+
+// WithSegment moves the segment only, instead of all the segments of the source querynode.
+func (opt *transferSegmentOption) WithSegment(segmentID int64) *transferSegmentOption {
+	opt.segmentID = segmentID
+	return opt
+}
+
+// WithCopyMode keeps the segments on the source querynode if true.
+func (opt *transferSegmentOption) WithCopyMode(copyMode bool) *transferSegmentOption {
+	opt.copyMode = copyMode
+	return opt
+}
+
+// NewTransferSegmentOption moves all the sealed segments of the source querynode to the others,
+// the segments are released from the source querynode once loaded on the others.
+func NewTransferSegmentOption(sourceNodeID int64) *transferSegmentOption {
+	return &transferSegmentOption{
+		sourceNodeID: sourceNodeID,
+	}
+}
+
+type TransferChannelOption interface {
+	Values() url.Values
+}
+
+type transferChannelOption struct {
+	sourceNodeID int64
+	targetNodeID int64
+	channelName  string
+	copyMode     bool
+}
+
+func (opt *transferChannelOption) Values() url.Values {
+	values := url.Values{}
+	values.Set("source_node_id", fmt.Sprint(opt.sourceNodeID))
+	if opt.targetNodeID != 0 {
+		values.Set("target_node_id", fmt.Sprint(opt.targetNodeID))
+	}
+	if opt.channelName != "" {
+		values.Set("channel_name", opt.channelName)
+	}
+	values.Set("copy_mode", strconv.FormatBool(opt.copyMode))
+	return values
+}
+
+// WithTargetNode moves the channels to the querynode only, instead of all the other querynodes.
+func (opt *transferChannelOption) WithTargetNode(nodeID int64) *transferChannelOption {
+	opt.targetNodeID = nodeID
+	return opt
+}
+
+// WithChannel moves the channel only, instead of all the channels of the source querynode.
+func (opt *transferChannelOption) WithChannel(channelName string) *transferChannelOption {
+	opt.channelName = channelName
+	return opt
+}
+
+// WithCopyMode keeps the channels on the source querynode if true.
+func (opt *transferChannelOption) WithCopyMode(copyMode bool) *transferChannelOption {
+	opt.copyMode = copyMode
+	return opt
+}
+
+// NewTransferChannelOption moves all the channels of the source querynode to the others,
+// the channels are released from the source querynode once watched by the others.
+func NewTransferChannelOption(sourceNodeID int64) *transferChannelOption {
+	return &transferChannelOption{
+		sourceNodeID: sourceNodeID,
+	}
+}
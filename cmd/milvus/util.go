@@ -165,6 +165,11 @@ func GetMilvusRoles(args []string, flags *flag.FlagSet) *roles.MilvusRoles {
 		}
 		role.Local = true
 		role.Embedded = serverType == typeutil.EmbeddedRole
+	case typeutil.MixCoordRole:
+		role.EnableRootCoord = true
+		role.EnableQueryCoord = true
+		role.EnableDataCoord = true
+		role.EnableIndexCoord = true
 	case typeutil.MixtureRole:
 		role.EnableRootCoord = enableRootCoord
 		role.EnableQueryCoord = enableQueryCoord
@@ -293,8 +293,9 @@ func (mr *MilvusRoles) setupLogger() {
 func setupPrometheusHTTPServer(r *internalmetrics.MilvusRegistry) {
 	log.Info("setupPrometheusHTTPServer")
 	http.Register(&http.Handler{
-		Path:    http.MetricsPath,
-		Handler: promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
+		Path: http.MetricsPath,
+		// exemplars, e.g. the request tags, are only exposed in the OpenMetrics format
+		Handler: promhttp.HandlerFor(r, promhttp.HandlerOpts{EnableOpenMetrics: true}),
 	})
 	http.Register(&http.Handler{
 		Path:    http.MetricsDefaultPath,
@@ -575,6 +576,13 @@ func (mr *MilvusRoles) Run() {
 		log.Info("proxy stopped!")
 	}
 
+	// stop the management server after the components, so their states stay observable during the stop
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), http.DefaultShutdownTimeout)
+	if err := http.Stop(stopCtx); err != nil {
+		log.Warn("failed to stop management server", zap.Error(err))
+	}
+	stopCancel()
+
 	// close reused etcd client
 	kvfactory.CloseEtcdClient()
 
@@ -0,0 +1,231 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordbroker
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/retry"
+)
+
+// Broker is the collection meta lookups shared by querycoord and datacoord.
+// The results are cached for common.brokerCache.ttl and the concurrent lookups of the same collection
+// are merged into one rpc, so mass loading and recovery don't flood rootcoord and datacoord.
+// Every lookup returns a copy the caller is free to modify.
+type Broker interface {
+	// DescribeCollection describes the collection from the user's perspective, fails for dropping collections.
+	DescribeCollection(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error)
+	// DescribeCollectionInternal describes the collection including the dropping ones.
+	DescribeCollectionInternal(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error)
+	ShowPartitions(ctx context.Context, collectionID int64) ([]int64, error)
+	ShowPartitionsInternal(ctx context.Context, collectionID int64) ([]int64, error)
+	ListIndexes(ctx context.Context, collectionID int64) ([]*indexpb.IndexInfo, error)
+	// InvalidateCollection drops the cached lookups of the collections, the next lookups go to the coordinators.
+	InvalidateCollection(collectionIDs ...int64)
+}
+
+type coordinatorBroker struct {
+	rootCoord types.RootCoordClient
+	// nil for datacoord itself, which owns the index meta
+	dataCoord types.DataCoordClient
+
+	collections         *lookupCache[*milvuspb.DescribeCollectionResponse]
+	internalCollections *lookupCache[*milvuspb.DescribeCollectionResponse]
+	partitions          *lookupCache[[]int64]
+	internalPartitions  *lookupCache[[]int64]
+	indexes             *lookupCache[[]*indexpb.IndexInfo]
+}
+
+func NewCoordinatorBroker(rootCoord types.RootCoordClient, dataCoord types.DataCoordClient) Broker {
+	capacity := paramtable.Get().CommonCfg.BrokerCacheCapacity.GetAsInt()
+	ttl := paramtable.Get().CommonCfg.BrokerCacheTTL.GetAsDuration(time.Second)
+	return &coordinatorBroker{
+		rootCoord:           rootCoord,
+		dataCoord:           dataCoord,
+		collections:         newLookupCache[*milvuspb.DescribeCollectionResponse](capacity, ttl),
+		internalCollections: newLookupCache[*milvuspb.DescribeCollectionResponse](capacity, ttl),
+		partitions:          newLookupCache[[]int64](capacity, ttl),
+		internalPartitions:  newLookupCache[[]int64](capacity, ttl),
+		indexes:             newLookupCache[[]*indexpb.IndexInfo](capacity, ttl),
+	}
+}
+
+func (b *coordinatorBroker) DescribeCollection(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error) {
+	resp, err := b.collections.Get(collectionID, func() (*milvuspb.DescribeCollectionResponse, error) {
+		return b.describeCollection(ctx, collectionID, b.rootCoord.DescribeCollection)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(resp).(*milvuspb.DescribeCollectionResponse), nil
+}
+
+func (b *coordinatorBroker) DescribeCollectionInternal(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error) {
+	resp, err := b.internalCollections.Get(collectionID, func() (*milvuspb.DescribeCollectionResponse, error) {
+		return b.describeCollection(ctx, collectionID, b.rootCoord.DescribeCollectionInternal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(resp).(*milvuspb.DescribeCollectionResponse), nil
+}
+
+type describeCollectionFunc = func(context.Context, *milvuspb.DescribeCollectionRequest, ...grpc.CallOption) (*milvuspb.DescribeCollectionResponse, error)
+
+func (b *coordinatorBroker) describeCollection(ctx context.Context, collectionID int64, describe describeCollectionFunc) (*milvuspb.DescribeCollectionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+
+	resp, err := describe(ctx, &milvuspb.DescribeCollectionRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		// please do not specify the collection name alone after database feature.
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Ctx(ctx).Warn("failed to describe collection", zap.Int64("collectionID", collectionID), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *coordinatorBroker) ShowPartitions(ctx context.Context, collectionID int64) ([]int64, error) {
+	partitionIDs, err := b.partitions.Get(collectionID, func() ([]int64, error) {
+		return b.showPartitions(ctx, collectionID, b.rootCoord.ShowPartitions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Clone(partitionIDs), nil
+}
+
+func (b *coordinatorBroker) ShowPartitionsInternal(ctx context.Context, collectionID int64) ([]int64, error) {
+	partitionIDs, err := b.internalPartitions.Get(collectionID, func() ([]int64, error) {
+		return b.showPartitions(ctx, collectionID, b.rootCoord.ShowPartitionsInternal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Clone(partitionIDs), nil
+}
+
+type showPartitionsFunc = func(context.Context, *milvuspb.ShowPartitionsRequest, ...grpc.CallOption) (*milvuspb.ShowPartitionsResponse, error)
+
+func (b *coordinatorBroker) showPartitions(ctx context.Context, collectionID int64, show showPartitionsFunc) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+
+	resp, err := show(ctx, &milvuspb.ShowPartitionsRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_ShowPartitions),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		// please do not specify the collection name alone after database feature.
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Ctx(ctx).Warn("failed to show partitions", zap.Int64("collectionID", collectionID), zap.Error(err))
+		return nil, err
+	}
+	return resp.GetPartitionIDs(), nil
+}
+
+func (b *coordinatorBroker) ListIndexes(ctx context.Context, collectionID int64) ([]*indexpb.IndexInfo, error) {
+	indexes, err := b.indexes.Get(collectionID, func() ([]*indexpb.IndexInfo, error) {
+		return b.listIndexes(ctx, collectionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(indexes, func(info *indexpb.IndexInfo, _ int) *indexpb.IndexInfo {
+		return proto.Clone(info).(*indexpb.IndexInfo)
+	}), nil
+}
+
+func (b *coordinatorBroker) listIndexes(ctx context.Context, collectionID int64) ([]*indexpb.IndexInfo, error) {
+	log := log.Ctx(ctx).With(zap.Int64("collectionID", collectionID))
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+
+	resp, err := b.dataCoord.ListIndexes(ctx, &indexpb.ListIndexesRequest{
+		CollectionID: collectionID,
+	})
+
+	err = merr.CheckRPCCall(resp, err)
+	if err != nil {
+		if errors.Is(err, merr.ErrServiceUnimplemented) {
+			log.Warn("datacoord does not implement ListIndex API fallback to DescribeIndex")
+			return b.describeIndex(ctx, collectionID)
+		}
+		log.Warn("failed to fetch index meta", zap.Error(err))
+		return nil, err
+	}
+
+	return resp.GetIndexInfos(), nil
+}
+
+func (b *coordinatorBroker) describeIndex(ctx context.Context, collectionID int64) ([]*indexpb.IndexInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+
+	// during rolling upgrade, query coord may connect to datacoord with version 2.2, which will return merr.ErrServiceUnimplemented
+	// we add retry here to retry the request until context done, and if new data coord start up, it will success
+	var resp *indexpb.DescribeIndexResponse
+	var err error
+	retry.Do(ctx, func() error {
+		resp, err = b.dataCoord.DescribeIndex(ctx, &indexpb.DescribeIndexRequest{
+			CollectionID: collectionID,
+		})
+		if errors.Is(err, merr.ErrServiceUnimplemented) {
+			return err
+		}
+		return nil
+	})
+
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Error("failed to fetch index meta",
+			zap.Int64("collection", collectionID),
+			zap.Error(err))
+		return nil, err
+	}
+	return resp.GetIndexInfos(), nil
+}
+
+func (b *coordinatorBroker) InvalidateCollection(collectionIDs ...int64) {
+	b.collections.Invalidate(collectionIDs...)
+	b.internalCollections.Invalidate(collectionIDs...)
+	b.partitions.Invalidate(collectionIDs...)
+	b.internalPartitions.Invalidate(collectionIDs...)
+	b.indexes.Invalidate(collectionIDs...)
+}
@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordbroker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type BrokerSuite struct {
+	suite.Suite
+
+	rootcoord *mocks.MockRootCoordClient
+	datacoord *mocks.MockDataCoordClient
+	broker    Broker
+}
+
+func (s *BrokerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *BrokerSuite) SetupTest() {
+	s.rootcoord = mocks.NewMockRootCoordClient(s.T())
+	s.datacoord = mocks.NewMockDataCoordClient(s.T())
+	s.broker = NewCoordinatorBroker(s.rootcoord, s.datacoord)
+}
+
+func (s *BrokerSuite) TestDescribeCollectionCached() {
+	ctx := context.Background()
+	collectionID := int64(100)
+
+	s.rootcoord.EXPECT().DescribeCollection(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *milvuspb.DescribeCollectionRequest, opts ...grpc.CallOption) (*milvuspb.DescribeCollectionResponse, error) {
+			s.Equal(collectionID, req.GetCollectionID())
+			// make the concurrent lookups overlap
+			time.Sleep(10 * time.Millisecond)
+			return &milvuspb.DescribeCollectionResponse{
+				Status:         merr.Success(),
+				CollectionID:   collectionID,
+				CollectionName: "test_collection",
+			}, nil
+		}).Once()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := s.broker.DescribeCollection(ctx, collectionID)
+			s.NoError(err)
+			s.Equal("test_collection", resp.GetCollectionName())
+		}()
+	}
+	wg.Wait()
+
+	// the returned response is a copy
+	resp, err := s.broker.DescribeCollection(ctx, collectionID)
+	s.NoError(err)
+	resp.CollectionName = "modified"
+	resp, err = s.broker.DescribeCollection(ctx, collectionID)
+	s.NoError(err)
+	s.Equal("test_collection", resp.GetCollectionName())
+
+	// internal lookups are cached separately
+	s.rootcoord.EXPECT().DescribeCollectionInternal(mock.Anything, mock.Anything).
+		Return(&milvuspb.DescribeCollectionResponse{
+			Status:       merr.Success(),
+			CollectionID: collectionID,
+		}, nil).Once()
+	_, err = s.broker.DescribeCollectionInternal(ctx, collectionID)
+	s.NoError(err)
+}
+
+func (s *BrokerSuite) TestShowPartitionsInvalidate() {
+	ctx := context.Background()
+	collectionID := int64(100)
+
+	s.rootcoord.EXPECT().ShowPartitions(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+		Status:       merr.Success(),
+		PartitionIDs: []int64{1, 2},
+	}, nil).Once()
+	partitions, err := s.broker.ShowPartitions(ctx, collectionID)
+	s.NoError(err)
+	s.ElementsMatch([]int64{1, 2}, partitions)
+	partitions, err = s.broker.ShowPartitions(ctx, collectionID)
+	s.NoError(err)
+	s.ElementsMatch([]int64{1, 2}, partitions)
+
+	s.broker.InvalidateCollection(collectionID)
+	s.rootcoord.EXPECT().ShowPartitions(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+		Status:       merr.Success(),
+		PartitionIDs: []int64{1, 2, 3},
+	}, nil).Once()
+	partitions, err = s.broker.ShowPartitions(ctx, collectionID)
+	s.NoError(err)
+	s.ElementsMatch([]int64{1, 2, 3}, partitions)
+
+	// failures are not cached
+	s.rootcoord.EXPECT().ShowPartitionsInternal(mock.Anything, mock.Anything).Return(nil, errors.New("mocked")).Once()
+	_, err = s.broker.ShowPartitionsInternal(ctx, collectionID)
+	s.Error(err)
+	s.rootcoord.EXPECT().ShowPartitionsInternal(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+		Status:       merr.Success(),
+		PartitionIDs: []int64{1},
+	}, nil).Once()
+	partitions, err = s.broker.ShowPartitionsInternal(ctx, collectionID)
+	s.NoError(err)
+	s.ElementsMatch([]int64{1}, partitions)
+}
+
+func (s *BrokerSuite) TestCacheDisabled() {
+	paramtable.Get().Save(paramtable.Get().CommonCfg.BrokerCacheTTL.Key, "0")
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.BrokerCacheTTL.Key)
+	broker := NewCoordinatorBroker(s.rootcoord, s.datacoord)
+
+	s.rootcoord.EXPECT().ShowPartitions(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+		Status:       merr.Success(),
+		PartitionIDs: []int64{1},
+	}, nil).Twice()
+	for i := 0; i < 2; i++ {
+		_, err := broker.ShowPartitions(context.Background(), 100)
+		s.NoError(err)
+	}
+}
+
+func (s *BrokerSuite) TestListIndexes() {
+	ctx := context.Background()
+	collectionID := int64(100)
+	indexIDs := []int64{1, 2}
+	indexInfos := lo.Map(indexIDs, func(id int64, _ int) *indexpb.IndexInfo {
+		return &indexpb.IndexInfo{IndexID: id}
+	})
+
+	s.Run("normal_case", func() {
+		s.SetupTest()
+		s.datacoord.EXPECT().ListIndexes(mock.Anything, mock.Anything).
+			Return(&indexpb.ListIndexesResponse{
+				Status:     merr.Success(),
+				IndexInfos: indexInfos,
+			}, nil).Once()
+		for i := 0; i < 2; i++ {
+			infos, err := s.broker.ListIndexes(ctx, collectionID)
+			s.NoError(err)
+			s.ElementsMatch(indexIDs, lo.Map(infos, func(info *indexpb.IndexInfo, _ int) int64 { return info.GetIndexID() }))
+		}
+	})
+
+	s.Run("datacoord_return_failure_status", func() {
+		s.SetupTest()
+		s.datacoord.EXPECT().ListIndexes(mock.Anything, mock.Anything).
+			Return(&indexpb.ListIndexesResponse{
+				Status: merr.Status(errors.New("mocked")),
+			}, nil).Once()
+
+		_, err := s.broker.ListIndexes(ctx, collectionID)
+		s.Error(err)
+	})
+
+	s.Run("datacoord_return_unimplemented", func() {
+		s.SetupTest()
+		// mock old version datacoord return unimplemented
+		s.datacoord.EXPECT().ListIndexes(mock.Anything, mock.Anything).
+			Return(nil, merr.ErrServiceUnimplemented).Once()
+		// mock retry on old version datacoord descibe index
+		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
+			Return(nil, merr.ErrServiceUnimplemented).Once()
+		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
+			Return(&indexpb.DescribeIndexResponse{
+				Status:     merr.Success(),
+				IndexInfos: indexInfos,
+			}, nil).Once()
+
+		infos, err := s.broker.ListIndexes(ctx, collectionID)
+		s.NoError(err)
+		s.Len(infos, 2)
+	})
+
+	s.Run("describe_index_return_error", func() {
+		s.SetupTest()
+		s.datacoord.EXPECT().ListIndexes(mock.Anything, mock.Anything).
+			Return(nil, merr.ErrServiceUnimplemented).Once()
+		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
+			Return(nil, errors.New("mocked")).Once()
+
+		_, err := s.broker.ListIndexes(ctx, collectionID)
+		s.Error(err)
+	})
+}
+
+func TestBroker(t *testing.T) {
+	suite.Run(t, new(BrokerSuite))
+}
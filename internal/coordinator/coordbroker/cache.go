@@ -0,0 +1,75 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordbroker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/util/conc"
+)
+
+// lookupCache caches the successful results of one kind of lookup per collection for a ttl,
+// and merges the concurrent lookups of the same collection into one.
+type lookupCache[V any] struct {
+	// nil if the ttl is not positive, lookups are merged only
+	lru *expirable.LRU[int64, V]
+	sf  conc.Singleflight[V]
+	// bumped on invalidation, so the loads started before don't fill the cache with stale results
+	epoch atomic.Int64
+}
+
+func newLookupCache[V any](capacity int, ttl time.Duration) *lookupCache[V] {
+	c := &lookupCache[V]{}
+	if ttl > 0 {
+		c.lru = expirable.NewLRU[int64, V](capacity, nil, ttl)
+	}
+	return c
+}
+
+// Get returns the cached result of the collection, or loads it with the given function.
+func (c *lookupCache[V]) Get(collectionID int64, load func() (V, error)) (V, error) {
+	if c.lru != nil {
+		if v, ok := c.lru.Get(collectionID); ok {
+			return v, nil
+		}
+	}
+	v, err, _ := c.sf.Do(strconv.FormatInt(collectionID, 10), func() (V, error) {
+		epoch := c.epoch.Load()
+		v, err := load()
+		if err == nil && c.lru != nil && c.epoch.Load() == epoch {
+			c.lru.Add(collectionID, v)
+		}
+		return v, err
+	})
+	return v, err
+}
+
+// Invalidate removes the cached results of the collections.
+func (c *lookupCache[V]) Invalidate(collectionIDs ...int64) {
+	if c.lru == nil {
+		return
+	}
+	c.epoch.Inc()
+	for _, collectionID := range collectionIDs {
+		c.sf.Forget(strconv.FormatInt(collectionID, 10))
+		c.lru.Remove(collectionID)
+	}
+}
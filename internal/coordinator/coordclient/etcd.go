@@ -0,0 +1,72 @@
+package coordclient
+
+import (
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/pkg/util/etcd"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// sharedEtcdClient is the etcd client shared by all coordinators running in the mixcoord process.
+var sharedEtcdClient struct {
+	mu  sync.Mutex
+	cli *clientv3.Client
+	ref int
+}
+
+// CreateEtcdClient creates the etcd client for a coordinator server.
+// When running as mixcoord, all coordinators share one etcd client,
+// so the returned release func must be called to close the client instead of closing it directly.
+func CreateEtcdClient() (*clientv3.Client, func(), error) {
+	if enableLocal.ServerType != typeutil.MixCoordRole {
+		cli, err := newEtcdClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return cli, func() { cli.Close() }, nil
+	}
+
+	sharedEtcdClient.mu.Lock()
+	defer sharedEtcdClient.mu.Unlock()
+	if sharedEtcdClient.cli == nil {
+		cli, err := newEtcdClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		sharedEtcdClient.cli = cli
+	}
+	sharedEtcdClient.ref++
+	cli := sharedEtcdClient.cli
+
+	once := sync.Once{}
+	release := func() {
+		once.Do(func() {
+			sharedEtcdClient.mu.Lock()
+			defer sharedEtcdClient.mu.Unlock()
+			sharedEtcdClient.ref--
+			if sharedEtcdClient.ref == 0 {
+				sharedEtcdClient.cli.Close()
+				sharedEtcdClient.cli = nil
+			}
+		})
+	}
+	return cli, release, nil
+}
+
+func newEtcdClient() (*clientv3.Client, error) {
+	etcdConfig := &paramtable.Get().EtcdCfg
+	return etcd.CreateEtcdClient(
+		etcdConfig.UseEmbedEtcd.GetAsBool(),
+		etcdConfig.EtcdEnableAuth.GetAsBool(),
+		etcdConfig.EtcdAuthUserName.GetValue(),
+		etcdConfig.EtcdAuthPassword.GetValue(),
+		etcdConfig.EtcdUseSSL.GetAsBool(),
+		etcdConfig.Endpoints.GetAsStrings(),
+		etcdConfig.EtcdTLSCert.GetValue(),
+		etcdConfig.EtcdTLSKey.GetValue(),
+		etcdConfig.EtcdTLSCACert.GetValue(),
+		etcdConfig.EtcdTLSMinVersion.GetValue())
+}
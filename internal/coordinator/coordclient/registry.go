@@ -50,10 +50,11 @@ type LocalClientRoleConfig struct {
 
 // EnableLocalClientRole init localable roles
 func EnableLocalClientRole(cfg *LocalClientRoleConfig) {
-	if !paramtable.Get().CommonCfg.LocalRPCEnabled.GetAsBool() {
+	// the coordinators always access each other locally in mixcoord
+	if cfg.ServerType != typeutil.MixCoordRole && !paramtable.Get().CommonCfg.LocalRPCEnabled.GetAsBool() {
 		return
 	}
-	if cfg.ServerType != typeutil.StandaloneRole && cfg.ServerType != typeutil.MixtureRole {
+	if cfg.ServerType != typeutil.StandaloneRole && cfg.ServerType != typeutil.MixtureRole && cfg.ServerType != typeutil.MixCoordRole {
 		return
 	}
 	enableLocal = cfg
@@ -76,3 +76,31 @@ func TestRegistry(t *testing.T) {
 	GetDataCoordClient(context.Background()).Close()
 	GetRootCoordClient(context.Background()).Close()
 }
+
+func TestRegistryMixCoord(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().CommonCfg.LocalRPCEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.LocalRPCEnabled.Key)
+	defer func() {
+		enableLocal = &LocalClientRoleConfig{}
+	}()
+
+	EnableLocalClientRole(&LocalClientRoleConfig{
+		ServerType:       typeutil.MixtureRole,
+		EnableQueryCoord: true,
+		EnableDataCoord:  true,
+		EnableRootCoord:  true,
+	})
+	assert.False(t, enableLocal.EnableRootCoord)
+
+	// mixcoord always enables local client
+	EnableLocalClientRole(&LocalClientRoleConfig{
+		ServerType:       typeutil.MixCoordRole,
+		EnableQueryCoord: true,
+		EnableDataCoord:  true,
+		EnableRootCoord:  true,
+	})
+	assert.True(t, enableLocal.EnableDataCoord)
+	assert.True(t, enableLocal.EnableQueryCoord)
+	assert.True(t, enableLocal.EnableRootCoord)
+}
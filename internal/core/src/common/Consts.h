@@ -35,6 +35,9 @@ const milvus::FieldId TimestampFieldID = milvus::FieldId(1);
 // fill followed extra info to binlog file
 const char ORIGIN_SIZE_KEY[] = "original_size";
 const char INDEX_BUILD_ID_KEY[] = "indexBuildID";
+const char INDEX_FORMAT_VERSION_KEY[] = "indexFormatVersion";
+// index files written before the format version was recorded are version 0
+const int32_t INDEX_FORMAT_VERSION = 1;
 const char NULLABLE[] = "nullable";
 
 const char INDEX_ROOT_PATH[] = "index_files";
@@ -48,12 +51,18 @@ const char VEC_OPT_FIELDS[] = "opt_fields";
 const char PAGE_RETAIN_ORDER[] = "page_retain_order";
 const char TEXT_LOG_ROOT_PATH[] = "text_log";
 const char ITERATIVE_FILTER[] = "iterative_filter";
+const char BRUTE_FORCE_FILTER[] = "brute_force";
+const char AUTO_FILTER[] = "auto";
 const char HINTS[] = "hints";
 
 const char DEFAULT_PLANNODE_ID[] = "0";
 const char DEAFULT_QUERY_ID[] = "0";
 const char DEFAULT_TASK_ID[] = "0";
 
+// with the auto filter strategy, sealed segments whose filter keeps at most
+// this ratio of rows are searched by brute force on the raw vectors
+const float DEFAULT_AUTO_FILTER_BRUTE_FORCE_RATIO = 0.01;
+
 const int64_t DEFAULT_FIELD_MAX_MEMORY_LIMIT = 128 << 20;  // bytes
 const int64_t DEFAULT_HIGH_PRIORITY_THREAD_CORE_COEFFICIENT = 10;
 const int64_t DEFAULT_MIDDLE_PRIORITY_THREAD_CORE_COEFFICIENT = 5;
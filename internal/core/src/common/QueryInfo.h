@@ -36,6 +36,8 @@ struct SearchInfo {
     tracer::TraceContext trace_ctx_;
     bool materialized_view_involved = false;
     bool iterative_filter_execution = false;
+    bool brute_force_filter_execution = false;
+    bool auto_filter_execution = false;
 };
 
 using SearchInfoPtr = std::shared_ptr<SearchInfo>;
@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#include "common/StackDump.h"
+
+#include <atomic>
+#include <chrono>
+#include <csignal>
+#include <cstdlib>
+#include <mutex>
+#include <sstream>
+#include <thread>
+#include <unordered_map>
+#ifdef __linux__
+#include <execinfo.h>
+#include <sys/syscall.h>
+#include <unistd.h>
+#endif
+
+#include "log/Log.h"
+
+namespace milvus {
+
+#ifdef __linux__
+namespace {
+
+// a realtime signal the libraries don't use, the signals not sent by the dumper
+// are passed on to the handler installed before
+int
+DumpSignal() {
+    return SIGRTMIN + 3;
+}
+
+// the states of dumping the stack of a thread, the handler only writes the frames if requested,
+// and the dumper only cancels the request the handler hasn't taken yet
+constexpr int kDumpIdle = 0;
+constexpr int kDumpRequested = 1;
+constexpr int kDumpWriting = 2;
+constexpr int kDumpDone = 3;
+
+constexpr int kMaxStackDepth = 64;
+
+// the frames of the signaled thread, written by the thread itself in the signal handler
+void* dumped_frames[kMaxStackDepth];
+int dumped_depth = 0;
+std::atomic<int> dump_state{kDumpIdle};
+
+// written once before the handler is installed, and only read since then
+struct sigaction previous_action {};
+std::once_flag install_once;
+std::atomic<bool> installed{false};
+
+std::mutex registered_mutex;
+std::unordered_map<pid_t, std::string> registered_threads;
+
+// unregisters the thread on its exit, before its tid may be reused
+struct ThreadRegistration {
+    pid_t tid = 0;
+
+    ~ThreadRegistration() {
+        if (tid != 0) {
+            std::lock_guard<std::mutex> lock(registered_mutex);
+            registered_threads.erase(tid);
+        }
+    }
+};
+thread_local ThreadRegistration thread_registration;
+
+// The handler only touches the lock-free atomics and calls backtrace into the preallocated buffer.
+// backtrace is safe in a signal handler once libgcc is loaded, which may allocate, see backtrace(3),
+// so it's called once before the handler is installed.
+void
+DumpStackHandler(int signo, siginfo_t* info, void* context) {
+    if (info == nullptr || info->si_code != SI_QUEUE ||
+        info->si_value.sival_ptr != dumped_frames) {
+        if (previous_action.sa_flags & SA_SIGINFO) {
+            if (previous_action.sa_sigaction != nullptr) {
+                previous_action.sa_sigaction(signo, info, context);
+            }
+        } else if (previous_action.sa_handler != SIG_DFL &&
+                   previous_action.sa_handler != SIG_IGN) {
+            previous_action.sa_handler(signo);
+        }
+        return;
+    }
+    int expected = kDumpRequested;
+    if (!dump_state.compare_exchange_strong(expected, kDumpWriting)) {
+        // the request is canceled
+        return;
+    }
+    dumped_depth = backtrace(dumped_frames, kMaxStackDepth);
+    dump_state.store(kDumpDone);
+}
+
+// signals the thread to dump its stack, the signal is tagged to tell it from the others
+bool
+SignalDumpStack(pid_t tid) {
+    siginfo_t info{};
+    info.si_signo = DumpSignal();
+    info.si_code = SI_QUEUE;
+    info.si_pid = getpid();
+    info.si_uid = getuid();
+    info.si_value.sival_ptr = dumped_frames;
+    return syscall(
+               SYS_rt_tgsigqueueinfo, getpid(), tid, DumpSignal(), &info) ==
+           0;
+}
+
+}  // namespace
+
+void
+InstallStackDumpHandler() {
+    std::call_once(install_once, [] {
+        void* warmup[1];
+        backtrace(warmup, 1);
+
+        // the previous handler is read before installing the new one,
+        // so the handler never sees it half written
+        if (sigaction(DumpSignal(), nullptr, &previous_action) != 0) {
+            LOG_WARN("failed to get the handler of signal {}, errno {}",
+                     DumpSignal(),
+                     errno);
+            return;
+        }
+        struct sigaction action {};
+        action.sa_sigaction = DumpStackHandler;
+        action.sa_flags = SA_SIGINFO | SA_ONSTACK | SA_RESTART;
+        sigemptyset(&action.sa_mask);
+        if (sigaction(DumpSignal(), &action, nullptr) != 0) {
+            LOG_WARN("failed to install the stack dump handler, errno {}",
+                     errno);
+            return;
+        }
+        installed.store(true);
+    });
+}
+
+void
+RegisterStackDumpThread(const std::string& name) {
+    auto tid = static_cast<pid_t>(syscall(SYS_gettid));
+    thread_registration.tid = tid;
+    std::lock_guard<std::mutex> lock(registered_mutex);
+    registered_threads[tid] = name;
+}
+
+std::string
+DumpThreadStacks() {
+    if (!installed.load()) {
+        return "the stack dump handler is not installed";
+    }
+    static std::mutex dump_mutex;
+    std::lock_guard<std::mutex> lock(dump_mutex);
+
+    std::unordered_map<pid_t, std::string> threads;
+    {
+        std::lock_guard<std::mutex> lock(registered_mutex);
+        threads = registered_threads;
+    }
+    std::ostringstream ss;
+    auto self = static_cast<pid_t>(syscall(SYS_gettid));
+    for (const auto& [tid, name] : threads) {
+        if (tid == self) {
+            continue;
+        }
+        dump_state.store(kDumpRequested);
+        if (!SignalDumpStack(tid)) {
+            dump_state.store(kDumpIdle);
+            continue;
+        }
+        auto deadline =
+            std::chrono::steady_clock::now() + std::chrono::milliseconds(100);
+        while (dump_state.load() != kDumpDone &&
+               std::chrono::steady_clock::now() < deadline) {
+            std::this_thread::sleep_for(std::chrono::milliseconds(1));
+        }
+        int expected = kDumpRequested;
+        if (dump_state.compare_exchange_strong(expected, kDumpIdle)) {
+            // the handler hasn't taken the request, it won't write the frames later
+            ss << "thread " << tid << " (" << name
+               << "): timed out dumping the stack\n";
+            continue;
+        }
+        // the handler is writing the frames, which is short
+        while (dump_state.load() != kDumpDone) {
+            std::this_thread::yield();
+        }
+        ss << "thread " << tid << " (" << name << "):\n";
+        // formatted out of the handler, backtrace_symbols allocates
+        char** symbols = backtrace_symbols(dumped_frames, dumped_depth);
+        for (int i = 0; i < dumped_depth; i++) {
+            ss << "  " << (symbols != nullptr ? symbols[i] : "??") << "\n";
+        }
+        free(symbols);
+        dump_state.store(kDumpIdle);
+    }
+    return ss.str();
+}
+#else
+void
+InstallStackDumpHandler() {
+}
+
+void
+RegisterStackDumpThread(const std::string& name) {
+}
+
+std::string
+DumpThreadStacks() {
+    return "dumping the native stacks is only supported on linux";
+}
+#endif
+
+}  // namespace milvus
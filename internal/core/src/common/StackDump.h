@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#pragma once
+
+#include <string>
+
+namespace milvus {
+
+// Installs the handler dumping the stacks of the registered threads, once for the process.
+// It must be called before any thread is asked to dump its stack, i.e. at the init of segcore.
+void
+InstallStackDumpHandler();
+
+// Registers the calling thread as a thread running the segcore calls, e.g. the threads of the
+// cgo pools and of the future executors, the thread is unregistered once it exits.
+// Only the registered threads are signaled, the others, e.g. the ones of the go runtime, never are.
+void
+RegisterStackDumpThread(const std::string& name);
+
+// Returns the native stacks of the registered threads but the calling one.
+std::string
+DumpThreadStacks();
+
+}  // namespace milvus
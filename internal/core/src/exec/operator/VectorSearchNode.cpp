@@ -16,6 +16,8 @@
 
 #include "VectorSearchNode.h"
 
+#include "common/Consts.h"
+
 namespace milvus {
 namespace exec {
 
@@ -77,6 +79,14 @@ PhyVectorSearchNode::GetOutput() {
         return input_;
     }
 
+    // with the auto strategy, fall back to brute force when the filter is
+    // so selective that an index search would visit mostly filtered rows
+    if (search_info_.auto_filter_execution) {
+        auto pass_count = view.size() - view.count();
+        search_info_.brute_force_filter_execution =
+            pass_count <= view.size() * DEFAULT_AUTO_FILTER_BRUTE_FORCE_RATIO;
+    }
+
     // TODO: uniform knowhere BitsetView and milvus BitsetView
     milvus::BitsetView final_view((uint8_t*)col_input->GetRawData(),
                                   col_input->size());
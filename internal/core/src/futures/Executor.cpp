@@ -10,20 +10,103 @@
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
 #include <chrono>
+#include <shared_mutex>
+#include <string>
+#include <unordered_map>
+#include <folly/executors/thread_factory/NamedThreadFactory.h>
 #include "Executor.h"
 #include "common/Common.h"
+#include "common/StackDump.h"
+#include "log/Log.h"
 
 namespace milvus::futures {
 
 const int kNumPriority = 3;
 
+namespace {
+
+// names the threads of an executor, and registers them to dump their stacks when a segcore call is stuck,
+// as the searches and the queries run in them
+class StackDumpThreadFactory : public folly::NamedThreadFactory {
+ public:
+    explicit StackDumpThreadFactory(const std::string& prefix)
+        : folly::NamedThreadFactory(prefix) {
+    }
+
+    std::thread
+    newThread(folly::Func&& func) override {
+        return folly::NamedThreadFactory::newThread(
+            [func = std::move(func), prefix = getNamePrefix()]() mutable {
+                milvus::RegisterStackDumpThread(prefix);
+                func();
+            });
+    }
+};
+
+}  // namespace
+
 folly::CPUThreadPoolExecutor*
 getGlobalCPUExecutor() {
     static folly::CPUThreadPoolExecutor executor(
         std::thread::hardware_concurrency(),
         folly::CPUThreadPoolExecutor::makeDefaultPriorityQueue(kNumPriority),
-        std::make_shared<folly::NamedThreadFactory>("MILVUS_FUTURE_CPU_"));
+        std::make_shared<StackDumpThreadFactory>("MILVUS_FUTURE_CPU_"));
     return &executor;
 }
 
+namespace {
+
+struct CollectionExecutor {
+    std::unique_ptr<folly::CPUThreadPoolExecutor> executor;
+    bool enabled;
+};
+
+std::shared_mutex collection_executors_mutex;
+// The executors are never destroyed once created, since the futures running
+// on them hold the raw pointers, a disabled one is shrunk to one thread.
+std::unordered_map<int64_t, CollectionExecutor> collection_executors;
+
+}  // namespace
+
+folly::CPUThreadPoolExecutor*
+getCPUExecutor(int64_t collection_id) {
+    std::shared_lock lock(collection_executors_mutex);
+    auto it = collection_executors.find(collection_id);
+    if (it == collection_executors.end() || !it->second.enabled) {
+        return getGlobalCPUExecutor();
+    }
+    return it->second.executor.get();
+}
+
+void
+setCollectionThreadNum(int64_t collection_id, int thread_num) {
+    std::unique_lock lock(collection_executors_mutex);
+    auto it = collection_executors.find(collection_id);
+    if (thread_num <= 0) {
+        if (it != collection_executors.end() && it->second.enabled) {
+            it->second.enabled = false;
+            it->second.executor->setNumThreads(1);
+            LOG_INFO("collection {} goes back to the global cpu executor",
+                     collection_id);
+        }
+        return;
+    }
+    if (it == collection_executors.end()) {
+        auto executor = std::make_unique<folly::CPUThreadPoolExecutor>(
+            thread_num,
+            folly::CPUThreadPoolExecutor::makeDefaultPriorityQueue(
+                kNumPriority),
+            std::make_shared<StackDumpThreadFactory>(
+                "MILVUS_FUTURE_CPU_" + std::to_string(collection_id) + "_"));
+        collection_executors.emplace(
+            collection_id, CollectionExecutor{std::move(executor), true});
+    } else {
+        it->second.executor->setNumThreads(thread_num);
+        it->second.enabled = true;
+    }
+    LOG_INFO("collection {} dedicated cpu executor with thread num: {}",
+             collection_id,
+             thread_num);
+}
+
 };  // namespace milvus::futures
\ No newline at end of file
@@ -11,6 +11,7 @@
 
 #pragma once
 
+#include <cstdint>
 #include <memory>
 #include <folly/executors/CPUThreadPoolExecutor.h>
 #include <folly/executors/task_queue/PriorityLifoSemMPMCQueue.h>
@@ -27,4 +28,15 @@ const int HIGH = 0;
 folly::CPUThreadPoolExecutor*
 getGlobalCPUExecutor();
 
+// Returns the executor dedicated to the collection if any,
+// the global one otherwise. The index searches submitted by the segcore tasks
+// still run in the global search thread pool of knowhere.
+folly::CPUThreadPoolExecutor*
+getCPUExecutor(int64_t collection_id);
+
+// Dedicates an executor of thread_num threads to the collection, the
+// collection goes back to the global executor if thread_num is not positive.
+void
+setCollectionThreadNum(int64_t collection_id, int thread_num);
+
 };  // namespace milvus::futures
@@ -58,3 +58,8 @@ executor_set_thread_num(int thread_num) {
     LOG_INFO("future executor setup cpu executor with thread num: {}",
              thread_num);
 }
+
+void
+executor_set_collection_thread_num(int64_t collection_id, int thread_num) {
+    milvus::futures::setCollectionThreadNum(collection_id, thread_num);
+}
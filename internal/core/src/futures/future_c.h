@@ -42,6 +42,9 @@ future_destroy(CFuture* future);
 void
 executor_set_thread_num(int thread_num);
 
+void
+executor_set_collection_thread_num(int64_t collection_id, int thread_num);
+
 #ifdef __cplusplus
 }
 #endif
@@ -64,6 +64,9 @@ constexpr const char* MMAP_FILE_PATH = "mmap_filepath";
 constexpr const char* ENABLE_MMAP = "enable_mmap";
 constexpr const char* INDEX_FILES = "index_files";
 constexpr const char* ENABLE_OFFSET_CACHE = "indexoffsetcache.enabled";
+// build from the raw data mmap'd from the local file rather than in memory
+constexpr const char* LOW_MEMORY_BUILD = "low_memory_build";
+constexpr const char* RAW_DATA_MMAP_FILE_PATH = "raw_data_mmap_filepath";
 
 // VecIndex file metas
 constexpr const char* DISK_ANN_PREFIX_PATH = "index_prefix";
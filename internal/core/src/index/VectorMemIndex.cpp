@@ -16,6 +16,7 @@
 
 #include "index/VectorMemIndex.h"
 
+#include <sys/mman.h>
 #include <unistd.h>
 #include <cmath>
 #include <cstring>
@@ -286,13 +287,29 @@ VectorMemIndex<T>::Build(const Config& config) {
         GetValueFromConfig<std::vector<std::string>>(config, "insert_files");
     AssertInfo(insert_files.has_value(),
                "insert file paths is empty when building in memory index");
-    auto field_datas =
-        file_manager_->CacheRawDataToMemory(insert_files.value());
 
     Config build_config;
     build_config.update(config);
     build_config.erase("insert_files");
     build_config.erase(VEC_OPT_FIELDS);
+    build_config.erase(LOW_MEMORY_BUILD);
+    build_config.erase(RAW_DATA_MMAP_FILE_PATH);
+
+    auto low_memory_build =
+        GetValueFromConfig<bool>(config, LOW_MEMORY_BUILD).value_or(false);
+    if (low_memory_build && !IndexIsSparse(GetIndexType())) {
+        auto filepath =
+            GetValueFromConfig<std::string>(config, RAW_DATA_MMAP_FILE_PATH);
+        AssertInfo(filepath.has_value(),
+                   "raw data mmap filepath is empty when building index in "
+                   "low memory mode");
+        BuildWithMmapRawData(
+            insert_files.value(), filepath.value(), build_config);
+        return;
+    }
+
+    auto field_datas =
+        file_manager_->CacheRawDataToMemory(insert_files.value());
     if (!IndexIsSparse(GetIndexType())) {
         int64_t total_size = 0;
         int64_t total_num_rows = 0;
@@ -350,6 +367,88 @@ VectorMemIndex<T>::Build(const Config& config) {
     }
 }
 
+template <typename T>
+void
+VectorMemIndex<T>::BuildWithMmapRawData(
+    const std::vector<std::string>& insert_files,
+    const std::string& filepath,
+    const Config& build_config) {
+    std::filesystem::create_directories(
+        std::filesystem::path(filepath).parent_path());
+    auto file = File::Open(filepath, O_CREAT | O_TRUNC | O_RDWR);
+    Defer remove_file([&]() {
+        if (unlink(filepath.c_str()) != 0) {
+            LOG_WARN("failed to unlink raw data mmap file {}: {}",
+                     filepath,
+                     strerror(errno));
+        }
+    });
+
+    // the binlogs are sorted by the log id, the same as CacheRawDataToMemory.
+    auto remote_files = insert_files;
+    std::sort(remote_files.begin(),
+              remote_files.end(),
+              [](const std::string& a, const std::string& b) {
+                  return std::stol(a.substr(a.find_last_of("/") + 1)) <
+                         std::stol(b.substr(b.find_last_of("/") + 1));
+              });
+
+    auto parallel_degree =
+        static_cast<uint64_t>(DEFAULT_FIELD_MAX_MEMORY_LIMIT / FILE_SLICE_SIZE);
+    int64_t total_size = 0;
+    int64_t total_num_rows = 0;
+    int64_t dim = 0;
+    std::vector<std::string> batch;
+    batch.reserve(parallel_degree);
+    auto write_batch = [&]() {
+        auto field_datas = file_manager_->CacheRawDataToMemory(batch);
+        for (auto& data : field_datas) {
+            AssertInfo(dim == 0 || dim == data->get_dim(),
+                       "inconsistent dim value between field datas!");
+            dim = data->get_dim();
+            auto written = file.Write(data->Data(), data->Size());
+            AssertInfo(written == data->Size(),
+                       fmt::format("failed to write raw data to disk {}: {}",
+                                   filepath,
+                                   strerror(errno)));
+            total_size += data->Size();
+            total_num_rows += data->get_num_rows();
+        }
+        batch.clear();
+    };
+    for (auto& remote_file : remote_files) {
+        batch.push_back(remote_file);
+        if (batch.size() >= parallel_degree) {
+            write_batch();
+        }
+    }
+    if (!batch.empty()) {
+        write_batch();
+    }
+    AssertInfo(total_size > 0, "no raw data to build index");
+
+    auto data = static_cast<uint8_t*>(
+        mmap(nullptr, total_size, PROT_READ, MAP_SHARED, file.Descriptor(), 0));
+    AssertInfo(data != MAP_FAILED,
+               "failed to mmap raw data file {}: {}",
+               filepath,
+               strerror(errno));
+    Defer unmap_data([&]() {
+        if (munmap(data, total_size) != 0) {
+            LOG_WARN("failed to munmap raw data file {}: {}",
+                     filepath,
+                     strerror(errno));
+        }
+    });
+    file.Close();
+
+    LOG_INFO("build index with mmap'd raw data, rows: {}, size: {}",
+             total_num_rows,
+             total_size);
+    auto dataset = GenDataset(total_num_rows, dim, data);
+    BuildWithDataset(dataset, build_config);
+}
+
 template <typename T>
 void
 VectorMemIndex<T>::AddWithDataset(const DatasetPtr& dataset,
@@ -94,6 +94,13 @@ class VectorMemIndex : public VectorIndex {
     void
     LoadFromFile(const Config& config);
 
+    // build from the raw data written into the local file batch by batch
+    // and mmap'd, so the raw data is not held in memory along with the index.
+    void
+    BuildWithMmapRawData(const std::vector<std::string>& insert_files,
+                         const std::string& filepath,
+                         const Config& build_config);
+
  protected:
     Config config_;
     knowhere::Index<knowhere::IndexNode> index_;
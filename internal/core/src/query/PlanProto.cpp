@@ -53,16 +53,19 @@ ProtoParser::PlanNodeFromProto(const planpb::PlanNode& plan_node_proto) {
             nlohmann::json::parse(query_info_proto.search_params());
         search_info.materialized_view_involved =
             query_info_proto.materialized_view_involved();
+        auto hints = query_info_proto.hints();
+        if (hints.empty() && search_info.search_params_.contains(HINTS) &&
+            search_info.search_params_[HINTS].is_string()) {
+            hints = search_info.search_params_[HINTS].get<std::string>();
+        }
         // currently, iterative filter does not support range search
         if (!search_info.search_params_.contains(RADIUS)) {
             search_info.iterative_filter_execution =
-                (query_info_proto.hints() == ITERATIVE_FILTER);
-            if (!search_info.iterative_filter_execution &&
-                search_info.search_params_.contains(HINTS)) {
-                search_info.iterative_filter_execution =
-                    (search_info.search_params_[HINTS] == ITERATIVE_FILTER);
-            }
+                (hints == ITERATIVE_FILTER);
         }
+        search_info.brute_force_filter_execution =
+            (hints == BRUTE_FORCE_FILTER);
+        search_info.auto_filter_execution = (hints == AUTO_FILTER);
 
         if (query_info_proto.bm25_avgdl() > 0) {
             search_info.search_params_[knowhere::meta::BM25_AVGDL] =
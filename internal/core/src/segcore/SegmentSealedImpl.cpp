@@ -961,7 +961,11 @@ SegmentSealedImpl::vector_search(SearchInfo& search_info,
 
     AssertInfo(field_meta.is_vector(),
                "The meta type of vector field is not vector type");
-    if (get_bit(binlog_index_bitset_, field_id)) {
+    // the filter strategy may ask for brute force on the raw vectors, it's
+    // only honored when they are loaded
+    auto brute_force = search_info.brute_force_filter_execution &&
+                       get_bit(field_data_ready_bitset_, field_id);
+    if (!brute_force && get_bit(binlog_index_bitset_, field_id)) {
         AssertInfo(
             vec_binlog_config_.find(field_id) != vec_binlog_config_.end(),
             "The binlog params is not generate.");
@@ -980,7 +984,7 @@ SegmentSealedImpl::vector_search(SearchInfo& search_info,
                                    output);
         milvus::tracer::AddEvent(
             "finish_searching_vector_temperate_binlog_index");
-    } else if (get_bit(index_ready_bitset_, field_id)) {
+    } else if (!brute_force && get_bit(index_ready_bitset_, field_id)) {
         AssertInfo(vector_indexings_.is_ready(field_id),
                    "vector indexes isn't ready for field " +
                        std::to_string(field_id.get()));
@@ -10,6 +10,8 @@
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
 #include "pthread.h"
+#include <cstring>
+#include "common/StackDump.h"
 #include "config/ConfigKnowhere.h"
 #include "fmt/core.h"
 #include "log/Log.h"
@@ -23,6 +25,7 @@ std::once_flag close_glog_once;
 extern "C" void
 SegcoreInit(const char* conf_file) {
     milvus::config::KnowhereInitImpl(conf_file);
+    milvus::InstallStackDumpHandler();
 }
 
 // TODO merge small index config into one config map, including enable/disable small_index
@@ -110,9 +113,16 @@ extern "C" void
 SetThreadName(const char* name) {
 #ifdef __linux__
     pthread_setname_np(pthread_self(), name);
+    milvus::RegisterStackDumpThread(name);
 #elif __APPLE__
     pthread_setname_np(name);
 #endif
 }
 
+// return value must be freed by the caller
+extern "C" char*
+SegcoreDumpThreadStacks() {
+    return strdup(milvus::DumpThreadStacks().c_str());
+}
+
 }  // namespace milvus::segcore
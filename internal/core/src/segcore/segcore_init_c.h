@@ -59,9 +59,16 @@ GetCurrentIndexVersion();
 int32_t
 GetMinimalIndexVersion();
 
+// names the calling thread, and registers it as a thread running the segcore calls
 void
 SetThreadName(const char*);
 
+// dumps the native stacks of the threads running the segcore calls, i.e. the ones registered by SetThreadName
+// and the threads of the future executors running the searches and the queries, for debugging the stuck calls,
+// return value must be freed by the caller
+char*
+SegcoreDumpThreadStacks();
+
 #ifdef __cplusplus
 }
 #endif
@@ -13,6 +13,7 @@
 
 #include <memory>
 #include <limits>
+#include <numeric>
 
 #include "pb/cgo_msg.pb.h"
 #include "pb/index_cgo_msg.pb.h"
@@ -111,14 +112,15 @@ AsyncSearch(CTraceContext c_trace,
             CSegmentInterface c_segment,
             CSearchPlan c_plan,
             CPlaceholderGroup c_placeholder_group,
-            uint64_t timestamp) {
+            uint64_t timestamp,
+            int64_t collection_id) {
     auto segment = (milvus::segcore::SegmentInterface*)c_segment;
     auto plan = (milvus::query::Plan*)c_plan;
     auto phg_ptr = reinterpret_cast<const milvus::query::PlaceholderGroup*>(
         c_placeholder_group);
 
     auto future = milvus::futures::Future<milvus::SearchResult>::async(
-        milvus::futures::getGlobalCPUExecutor(),
+        milvus::futures::getCPUExecutor(collection_id),
         milvus::futures::ExecutePriority::HIGH,
         [c_trace, segment, plan, phg_ptr, timestamp](
             milvus::futures::CancellationToken cancel_token) {
@@ -179,12 +181,13 @@ AsyncRetrieve(CTraceContext c_trace,
               CRetrievePlan c_plan,
               uint64_t timestamp,
               int64_t limit_size,
-              bool ignore_non_pk) {
+              bool ignore_non_pk,
+              int64_t collection_id) {
     auto segment = static_cast<milvus::segcore::SegmentInterface*>(c_segment);
     auto plan = static_cast<const milvus::query::RetrievePlan*>(c_plan);
 
     auto future = milvus::futures::Future<CRetrieveResult>::async(
-        milvus::futures::getGlobalCPUExecutor(),
+        milvus::futures::getCPUExecutor(collection_id),
         milvus::futures::ExecutePriority::HIGH,
         [c_trace, segment, plan, timestamp, limit_size, ignore_non_pk](
             milvus::futures::CancellationToken cancel_token) {
@@ -207,12 +210,13 @@ AsyncRetrieveByOffsets(CTraceContext c_trace,
                        CSegmentInterface c_segment,
                        CRetrievePlan c_plan,
                        int64_t* offsets,
-                       int64_t len) {
+                       int64_t len,
+                       int64_t collection_id) {
     auto segment = static_cast<milvus::segcore::SegmentInterface*>(c_segment);
     auto plan = static_cast<const milvus::query::RetrievePlan*>(c_plan);
 
     auto future = milvus::futures::Future<CRetrieveResult>::async(
-        milvus::futures::getGlobalCPUExecutor(),
+        milvus::futures::getCPUExecutor(collection_id),
         milvus::futures::ExecutePriority::HIGH,
         [c_trace, segment, plan, offsets, len](
             milvus::futures::CancellationToken cancel_token) {
@@ -270,6 +274,56 @@ HasRawData(CSegmentInterface c_segment, int64_t field_id) {
     return segment->HasRawData(field_id);
 }
 
+namespace {
+// splitmix64 finalizer, std::hash of integers is the identity
+uint64_t
+MixHash(uint64_t x) {
+    x += 0x9e3779b97f4a7c15ULL;
+    x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9ULL;
+    x = (x ^ (x >> 27)) * 0x94d049bb133111ebULL;
+    return x ^ (x >> 31);
+}
+}  // namespace
+
+CStatus
+GetPrimaryKeyDigest(CSegmentInterface c_segment, uint64_t* digest) {
+    try {
+        auto segment =
+            reinterpret_cast<milvus::segcore::SegmentInternalInterface*>(
+                c_segment);
+        auto pk_field_id = segment->get_schema().get_primary_field_id();
+        AssertInfo(pk_field_id.has_value(), "primary key field not found");
+
+        constexpr int64_t batch_size = 8192;
+        auto row_count = segment->get_row_count();
+        std::vector<int64_t> offsets;
+        // the hashes are summed up, so the replicas loading the binlogs in
+        // another order get the same digest
+        uint64_t result = 0;
+        for (int64_t begin = 0; begin < row_count; begin += batch_size) {
+            auto count = std::min(batch_size, row_count - begin);
+            offsets.resize(count);
+            std::iota(offsets.begin(), offsets.end(), begin);
+            auto data = segment->bulk_subscript(
+                pk_field_id.value(), offsets.data(), count);
+            auto& scalars = data->scalars();
+            if (scalars.has_long_data()) {
+                for (auto pk : scalars.long_data().data()) {
+                    result += MixHash(static_cast<uint64_t>(pk));
+                }
+            } else {
+                for (auto& pk : scalars.string_data().data()) {
+                    result += MixHash(std::hash<std::string>{}(pk));
+                }
+            }
+        }
+        *digest = result;
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
 //////////////////////////////    interfaces for growing segment    //////////////////////////////
 CStatus
 Insert(CSegmentInterface c_segment,
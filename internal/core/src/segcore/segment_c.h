@@ -50,7 +50,8 @@ AsyncSearch(CTraceContext c_trace,
             CSegmentInterface c_segment,
             CSearchPlan c_plan,
             CPlaceholderGroup c_placeholder_group,
-            uint64_t timestamp);
+            uint64_t timestamp,
+            int64_t collection_id);
 
 void
 DeleteRetrieveResult(CRetrieveResult* retrieve_result);
@@ -61,14 +62,16 @@ AsyncRetrieve(CTraceContext c_trace,
               CRetrievePlan c_plan,
               uint64_t timestamp,
               int64_t limit_size,
-              bool ignore_non_pk);
+              bool ignore_non_pk,
+              int64_t collection_id);
 
 CFuture*  // Future<CRetrieveResult>
 AsyncRetrieveByOffsets(CTraceContext c_trace,
                        CSegmentInterface c_segment,
                        CRetrievePlan c_plan,
                        int64_t* offsets,
-                       int64_t len);
+                       int64_t len,
+                       int64_t collection_id);
 
 int64_t
 GetMemoryUsageInBytes(CSegmentInterface c_segment);
@@ -85,6 +88,10 @@ GetRealCount(CSegmentInterface c_segment);
 bool
 HasRawData(CSegmentInterface c_segment, int64_t field_id);
 
+// digest of the primary keys loaded, independent of the order of the rows
+CStatus
+GetPrimaryKeyDigest(CSegmentInterface c_segment, uint64_t* digest);
+
 //////////////////////////////    interfaces for growing segment    //////////////////////////////
 CStatus
 Insert(CSegmentInterface c_segment,
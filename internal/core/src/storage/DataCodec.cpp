@@ -92,6 +92,17 @@ DeserializeRemoteFileData(BinlogReaderPtr reader, bool is_field_data) {
                        "index build id not exist");
             index_meta.build_id = std::stol(
                 std::any_cast<std::string>(extras[INDEX_BUILD_ID_KEY]));
+            int32_t format_version = 0;
+            if (extras.find(INDEX_FORMAT_VERSION_KEY) != extras.end()) {
+                format_version = std::stoi(std::any_cast<std::string>(
+                    extras[INDEX_FORMAT_VERSION_KEY]));
+            }
+            AssertInfo(format_version <= INDEX_FORMAT_VERSION,
+                       "index file format version {} of build {} is newer "
+                       "than the supported version {}",
+                       format_version,
+                       index_meta.build_id,
+                       INDEX_FORMAT_VERSION);
             index_data->set_index_meta(index_meta);
             index_data->SetTimestamps(index_event_data.start_timestamp,
                                       index_event_data.end_timestamp);
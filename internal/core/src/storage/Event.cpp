@@ -170,6 +170,10 @@ DescriptorEventData::DescriptorEventData(BinlogReaderPtr reader) {
         extras[INDEX_BUILD_ID_KEY] =
             static_cast<std::string>(json[INDEX_BUILD_ID_KEY]);
     }
+    if (json.contains(INDEX_FORMAT_VERSION_KEY)) {
+        extras[INDEX_FORMAT_VERSION_KEY] =
+            static_cast<std::string>(json[INDEX_FORMAT_VERSION_KEY]);
+    }
     if (json.contains(NULLABLE)) {
         extras[NULLABLE] = static_cast<bool>(json[NULLABLE]);
     }
@@ -75,6 +75,8 @@ IndexData::serialize_to_remote_file() {
         std::to_string(field_data_->Size());
     des_event_data.extras[INDEX_BUILD_ID_KEY] =
         std::to_string(index_meta_->build_id);
+    des_event_data.extras[INDEX_FORMAT_VERSION_KEY] =
+        std::to_string(INDEX_FORMAT_VERSION);
 
     auto& des_event_header = descriptor_event.event_header;
     // TODO :: set timestamp
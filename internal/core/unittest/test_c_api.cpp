@@ -69,7 +69,7 @@ CRetrieve(CSegmentInterface c_segment,
           uint64_t timestamp,
           CRetrieveResult** result) {
     auto future = AsyncRetrieve(
-        {}, c_segment, c_plan, timestamp, DEFAULT_MAX_OUTPUT_SIZE, false);
+        {}, c_segment, c_plan, timestamp, DEFAULT_MAX_OUTPUT_SIZE, false, 0);
     auto futurePtr = static_cast<milvus::futures::IFuture*>(
         static_cast<void*>(static_cast<CFuture*>(future)));
 
@@ -96,7 +96,8 @@ CRetrieveByOffsets(CSegmentInterface c_segment,
                    int64_t* offsets,
                    int64_t len,
                    CRetrieveResult** result) {
-    auto future = AsyncRetrieveByOffsets({}, c_segment, c_plan, offsets, len);
+    auto future =
+        AsyncRetrieveByOffsets({}, c_segment, c_plan, offsets, len, 0);
     auto futurePtr = static_cast<milvus::futures::IFuture*>(
         static_cast<void*>(static_cast<CFuture*>(future)));
 
@@ -182,7 +182,7 @@ CSearch(CSegmentInterface c_segment,
         uint64_t timestamp,
         CSearchResult* result) {
     auto future =
-        AsyncSearch({}, c_segment, c_plan, c_placeholder_group, timestamp);
+        AsyncSearch({}, c_segment, c_plan, c_placeholder_group, timestamp, 0);
     auto futurePtr = static_cast<milvus::futures::IFuture*>(
         static_cast<void*>(static_cast<CFuture*>(future)));
 
@@ -0,0 +1,150 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/metastore"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// backupMeta keeps the backups created by CreateBackup.
+// A backup only references the binlogs of its segments, so the referenced segments
+// must not be garbage collected until the backup is dropped.
+type backupMeta struct {
+	sync.RWMutex
+
+	ctx     context.Context
+	catalog metastore.DataCoordCatalog
+
+	// backup name -> backup
+	backups map[string]*datapb.BackupInfo
+	// segmentID -> names of the backups referencing the segment
+	segmentRefs map[int64]typeutil.Set[string]
+}
+
+func newBackupMeta(ctx context.Context, catalog metastore.DataCoordCatalog) (*backupMeta, error) {
+	bm := &backupMeta{
+		ctx:         ctx,
+		catalog:     catalog,
+		backups:     make(map[string]*datapb.BackupInfo),
+		segmentRefs: make(map[int64]typeutil.Set[string]),
+	}
+	if err := bm.reloadFromKV(); err != nil {
+		return nil, err
+	}
+	return bm, nil
+}
+
+func (m *backupMeta) reloadFromKV() error {
+	record := timerecord.NewTimeRecorder("backupMeta-reloadFromKV")
+	backups, err := m.catalog.ListBackups(m.ctx)
+	if err != nil {
+		log.Warn("backupMeta reloadFromKV load backups failed", zap.Error(err))
+		return err
+	}
+	for _, backup := range backups {
+		m.addBackup(backup)
+	}
+	log.Info("backupMeta reloadFromKV done", zap.Int("backupNum", len(backups)), zap.Duration("duration", record.ElapseSpan()))
+	return nil
+}
+
+func (m *backupMeta) addBackup(backup *datapb.BackupInfo) {
+	m.backups[backup.GetName()] = backup
+	for _, segment := range backup.GetSegments() {
+		refs, ok := m.segmentRefs[segment.GetSegmentID()]
+		if !ok {
+			refs = typeutil.NewSet[string]()
+			m.segmentRefs[segment.GetSegmentID()] = refs
+		}
+		refs.Insert(backup.GetName())
+	}
+}
+
+// AddBackup persists the backup, the segments referenced by it are protected from gc since then.
+func (m *backupMeta) AddBackup(backup *datapb.BackupInfo) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.backups[backup.GetName()]; ok {
+		return merr.WrapErrBackupAlreadyExist(backup.GetName())
+	}
+	if err := m.catalog.SaveBackup(m.ctx, backup); err != nil {
+		log.Warn("failed to save backup", zap.String("backup", backup.GetName()), zap.Error(err))
+		return err
+	}
+	m.addBackup(backup)
+	return nil
+}
+
+// DropBackup removes the backup and releases the gc protection of its segments.
+func (m *backupMeta) DropBackup(name string) error {
+	m.Lock()
+	defer m.Unlock()
+	backup, ok := m.backups[name]
+	if !ok {
+		return nil
+	}
+	if err := m.catalog.DropBackup(m.ctx, name); err != nil {
+		log.Warn("failed to drop backup", zap.String("backup", name), zap.Error(err))
+		return err
+	}
+	delete(m.backups, name)
+	for _, segment := range backup.GetSegments() {
+		refs := m.segmentRefs[segment.GetSegmentID()]
+		refs.Remove(name)
+		if refs.Len() == 0 {
+			delete(m.segmentRefs, segment.GetSegmentID())
+		}
+	}
+	return nil
+}
+
+func (m *backupMeta) GetBackup(name string) *datapb.BackupInfo {
+	m.RLock()
+	defer m.RUnlock()
+	return m.backups[name]
+}
+
+// ListBackups lists the backups of the collection, or all backups if collectionID is 0.
+func (m *backupMeta) ListBackups(collectionID int64) []*datapb.BackupInfo {
+	m.RLock()
+	defer m.RUnlock()
+	backups := make([]*datapb.BackupInfo, 0, len(m.backups))
+	for _, backup := range m.backups {
+		if collectionID == 0 || backup.GetCollectionID() == collectionID {
+			backups = append(backups, backup)
+		}
+	}
+	return backups
+}
+
+// IsSegmentReferenced returns whether the segment is referenced by any backup.
+func (m *backupMeta) IsSegmentReferenced(segmentID int64) bool {
+	m.RLock()
+	defer m.RUnlock()
+	_, ok := m.segmentRefs[segmentID]
+	return ok
+}
@@ -0,0 +1,162 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestBackupMeta(t *testing.T) {
+	ctx := context.Background()
+	backup := &datapb.BackupInfo{
+		Name:         "b1",
+		CollectionID: 100,
+		Segments: []*datapb.BackupSegment{
+			{SegmentID: 1, PartitionID: 10},
+			{SegmentID: 2, PartitionID: 10},
+		},
+	}
+
+	t.Run("reload failed", func(t *testing.T) {
+		catalog := mocks.NewDataCoordCatalog(t)
+		catalog.EXPECT().ListBackups(mock.Anything).Return(nil, fmt.Errorf("mock error"))
+		_, err := newBackupMeta(ctx, catalog)
+		assert.Error(t, err)
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		catalog := mocks.NewDataCoordCatalog(t)
+		catalog.EXPECT().ListBackups(mock.Anything).Return([]*datapb.BackupInfo{backup}, nil)
+		bm, err := newBackupMeta(ctx, catalog)
+		assert.NoError(t, err)
+		assert.True(t, bm.IsSegmentReferenced(1))
+		assert.False(t, bm.IsSegmentReferenced(3))
+
+		err = bm.AddBackup(&datapb.BackupInfo{Name: "b1"})
+		assert.True(t, merr.ErrBackupAlreadyExist.Is(err))
+
+		catalog.EXPECT().SaveBackup(mock.Anything, mock.Anything).Return(nil)
+		err = bm.AddBackup(&datapb.BackupInfo{
+			Name:         "b2",
+			CollectionID: 200,
+			Segments:     []*datapb.BackupSegment{{SegmentID: 2, PartitionID: 20}, {SegmentID: 3, PartitionID: 20}},
+		})
+		assert.NoError(t, err)
+		assert.True(t, bm.IsSegmentReferenced(3))
+		assert.Len(t, bm.ListBackups(0), 2)
+		assert.Len(t, bm.ListBackups(100), 1)
+		assert.Equal(t, int64(200), bm.GetBackup("b2").GetCollectionID())
+
+		catalog.EXPECT().DropBackup(mock.Anything, "b1").Return(nil)
+		assert.NoError(t, bm.DropBackup("b1"))
+		assert.NoError(t, bm.DropBackup("not_exist"))
+		assert.False(t, bm.IsSegmentReferenced(1))
+		// segment 2 is still referenced by b2
+		assert.True(t, bm.IsSegmentReferenced(2))
+		assert.Nil(t, bm.GetBackup("b1"))
+	})
+
+	t.Run("catalog failed", func(t *testing.T) {
+		catalog := mocks.NewDataCoordCatalog(t)
+		catalog.EXPECT().ListBackups(mock.Anything).Return([]*datapb.BackupInfo{backup}, nil)
+		catalog.EXPECT().SaveBackup(mock.Anything, mock.Anything).Return(fmt.Errorf("mock error"))
+		catalog.EXPECT().DropBackup(mock.Anything, mock.Anything).Return(fmt.Errorf("mock error"))
+		bm, err := newBackupMeta(ctx, catalog)
+		assert.NoError(t, err)
+
+		assert.Error(t, bm.AddBackup(&datapb.BackupInfo{Name: "b2"}))
+		assert.Nil(t, bm.GetBackup("b2"))
+		assert.Error(t, bm.DropBackup("b1"))
+		assert.True(t, bm.IsSegmentReferenced(1))
+	})
+}
+
+func TestCheckRestoreSchema(t *testing.T) {
+	backupSchema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	assert.NoError(t, checkRestoreSchema(backupSchema, backupSchema))
+
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_BinaryVector},
+		},
+	}
+	assert.Error(t, checkRestoreSchema(backupSchema, schema))
+
+	schema.Fields = schema.Fields[:1]
+	assert.Error(t, checkRestoreSchema(backupSchema, schema))
+}
+
+func TestBuildRestoreFiles(t *testing.T) {
+	backup := &datapb.BackupInfo{
+		CollectionID: 1,
+		Segments: []*datapb.BackupSegment{
+			{SegmentID: 100, PartitionID: 10, Level: datapb.SegmentLevel_L1, TimestampFrom: 100, TimestampTo: 200},
+			{SegmentID: 101, PartitionID: 11, Level: datapb.SegmentLevel_L1, TimestampFrom: 300, TimestampTo: 400},
+			{SegmentID: 200, PartitionID: 10, Level: datapb.SegmentLevel_L0},
+			{SegmentID: 201, PartitionID: common.AllPartitionsID, Level: datapb.SegmentLevel_L0, TimestampFrom: 150, TimestampTo: 250},
+			// all the deletes are before the rows of segment 101
+			{SegmentID: 202, PartitionID: common.AllPartitionsID, Level: datapb.SegmentLevel_L0, TimestampFrom: 150, TimestampTo: 300},
+		},
+	}
+	files := buildRestoreFiles("root", backup)
+	assert.Len(t, files, 2)
+	assert.Len(t, files[10], 1)
+	assert.Equal(t, []string{
+		"root/insert_log/1/10/100/",
+		"root/delta_log/1/10/100/",
+		"root/delta_log/1/10/200/",
+		fmt.Sprintf("root/delta_log/1/%d/201/", common.AllPartitionsID),
+		fmt.Sprintf("root/delta_log/1/%d/202/", common.AllPartitionsID),
+	}, files[10][0].GetPaths())
+	assert.Equal(t, []string{
+		"root/insert_log/1/11/101/",
+		"root/delta_log/1/11/101/",
+	}, files[11][0].GetPaths())
+}
+
+func TestBinlogTimeRange(t *testing.T) {
+	tsFrom, tsTo := binlogTimeRange([]*datapb.FieldBinlog{
+		{FieldID: 100, Binlogs: []*datapb.Binlog{{TimestampFrom: 20, TimestampTo: 30}, {TimestampFrom: 10, TimestampTo: 15}}},
+		{FieldID: 101, Binlogs: []*datapb.Binlog{{TimestampFrom: 10, TimestampTo: 40}}},
+	})
+	assert.Equal(t, uint64(10), tsFrom)
+	assert.Equal(t, uint64(40), tsTo)
+
+	// unknown
+	tsFrom, tsTo = binlogTimeRange([]*datapb.FieldBinlog{
+		{FieldID: 100, Binlogs: []*datapb.Binlog{{TimestampFrom: 20, TimestampTo: 30}, {}}},
+	})
+	assert.Zero(t, tsFrom)
+	assert.Zero(t, tsTo)
+}
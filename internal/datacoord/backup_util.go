@@ -0,0 +1,238 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func (s *Server) showPartitionNames(ctx context.Context, collectionID int64) (map[int64]string, error) {
+	resp, err := s.rootCoordClient.ShowPartitionsInternal(ctx, &milvuspb.ShowPartitionsRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_ShowPartitions),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(resp.GetPartitionIDs()))
+	for i, partitionID := range resp.GetPartitionIDs() {
+		names[partitionID] = resp.GetPartitionNames()[i]
+	}
+	return names, nil
+}
+
+// buildBackup snapshots the meta of the collection.
+// The backup ts is the minimal checkpoint of the collection channels, all the data before it
+// has been persisted in the binlogs of the healthy segments, the data after it is filtered out on restore.
+func (s *Server) buildBackup(ctx context.Context, name string, collectionID int64) (*datapb.BackupInfo, error) {
+	coll, err := s.handler.GetCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if coll == nil {
+		return nil, merr.WrapErrCollectionNotFound(collectionID)
+	}
+
+	checkpoints := make([]*msgpb.MsgPosition, 0, len(coll.VChannelNames))
+	backupTs := uint64(0)
+	for _, channel := range coll.VChannelNames {
+		cp := s.meta.GetChannelCheckpoint(channel)
+		if cp == nil || cp.GetTimestamp() == 0 {
+			return nil, merr.WrapErrChannelNotAvailable(channel, "channel checkpoint is not ready")
+		}
+		checkpoints = append(checkpoints, cp)
+		if backupTs == 0 || cp.GetTimestamp() < backupTs {
+			backupTs = cp.GetTimestamp()
+		}
+	}
+
+	// the import time range is in physical time, round the backup ts down to a physical timestamp.
+	physical, _ := tsoutil.ParseHybridTs(backupTs)
+	backupTs = tsoutil.ComposeTS(physical, 0)
+
+	partitionNames, err := s.showPartitionNames(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := s.meta.SelectSegments(ctx, WithCollection(collectionID), SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		return isSegmentHealthy(segment) &&
+			(len(segment.GetBinlogs()) > 0 || len(segment.GetDeltalogs()) > 0)
+	}))
+	backupSegments := lo.Map(segments, func(segment *SegmentInfo, _ int) *datapb.BackupSegment {
+		logs := segment.GetBinlogs()
+		if segment.GetLevel() == datapb.SegmentLevel_L0 {
+			logs = segment.GetDeltalogs()
+		}
+		tsFrom, tsTo := binlogTimeRange(logs)
+		return &datapb.BackupSegment{
+			SegmentID:     segment.GetID(),
+			PartitionID:   segment.GetPartitionID(),
+			Level:         segment.GetLevel(),
+			NumOfRows:     segment.GetNumOfRows(),
+			TimestampFrom: tsFrom,
+			TimestampTo:   tsTo,
+		}
+	})
+
+	indexes := lo.Map(s.meta.indexMeta.GetIndexesForCollection(collectionID, ""), func(index *model.Index, _ int) *indexpb.FieldIndex {
+		return model.MarshalIndexModel(index)
+	})
+
+	return &datapb.BackupInfo{
+		Name:               name,
+		CollectionID:       collectionID,
+		CollectionName:     coll.Schema.GetName(),
+		DbName:             coll.DatabaseName,
+		Schema:             coll.Schema,
+		PartitionNames:     partitionNames,
+		Indexes:            indexes,
+		Segments:           backupSegments,
+		ChannelCheckpoints: checkpoints,
+		BackupTs:           backupTs,
+		CreateTime:         time.Now().Unix(),
+	}, nil
+}
+
+// binlogTimeRange returns the time range of the binlogs, zeros if unknown.
+func binlogTimeRange(fieldBinlogs []*datapb.FieldBinlog) (tsFrom, tsTo uint64) {
+	for _, fieldBinlog := range fieldBinlogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if binlog.GetTimestampFrom() == 0 || binlog.GetTimestampTo() == 0 {
+				return 0, 0
+			}
+			if tsFrom == 0 || binlog.GetTimestampFrom() < tsFrom {
+				tsFrom = binlog.GetTimestampFrom()
+			}
+			tsTo = max(tsTo, binlog.GetTimestampTo())
+		}
+	}
+	return tsFrom, tsTo
+}
+
+// checkRestoreSchema checks the binlogs of the backup could be imported into the collection,
+// the binlogs are organized by field id, so the fields must be the same as the backup.
+func checkRestoreSchema(backupSchema, schema *schemapb.CollectionSchema) error {
+	for _, backupField := range backupSchema.GetFields() {
+		field := typeutil.GetField(schema, backupField.GetFieldID())
+		if field == nil || field.GetName() != backupField.GetName() || field.GetDataType() != backupField.GetDataType() {
+			return merr.WrapErrParameterInvalidMsg(fmt.Sprintf("field %s(%d) of the backup mismatches the collection schema",
+				backupField.GetName(), backupField.GetFieldID()))
+		}
+	}
+	return nil
+}
+
+// buildRestoreFiles groups the import files of the sealed segments in the backup by partition.
+// Each file consists of the insert prefix of the segment, its delta prefix and the prefixes of
+// the l0 segments covering it, so the deletes are applied in the same time range as the rows.
+// The l0 segments whose deletes are all before the rows of the segment are skipped, the reader
+// skips the deletes out of the primary key range of the segment in the others.
+func buildRestoreFiles(rootPath string, backup *datapb.BackupInfo) map[int64][]*internalpb.ImportFile {
+	collectionID := backup.GetCollectionID()
+	deltaPrefix := func(segment *datapb.BackupSegment) string {
+		return path.Join(rootPath, common.SegmentDeltaLogPath, metautil.JoinIDPath(collectionID, segment.GetPartitionID(), segment.GetSegmentID())) + "/"
+	}
+
+	l0Segments := lo.GroupBy(lo.Filter(backup.GetSegments(), func(segment *datapb.BackupSegment, _ int) bool {
+		return segment.GetLevel() == datapb.SegmentLevel_L0
+	}), func(segment *datapb.BackupSegment) int64 {
+		return segment.GetPartitionID()
+	})
+	// a delete only applies to the rows inserted before it, the time ranges of the old backups are unknown
+	l0Prefixes := func(segment *datapb.BackupSegment, partitionID int64) []string {
+		prefixes := make([]string, 0)
+		for _, l0 := range l0Segments[partitionID] {
+			if l0.GetTimestampTo() == 0 || l0.GetTimestampTo() > segment.GetTimestampFrom() {
+				prefixes = append(prefixes, deltaPrefix(l0))
+			}
+		}
+		return prefixes
+	}
+
+	files := make(map[int64][]*internalpb.ImportFile)
+	for _, segment := range backup.GetSegments() {
+		if segment.GetLevel() == datapb.SegmentLevel_L0 {
+			continue
+		}
+		paths := []string{
+			path.Join(rootPath, common.SegmentInsertLogPath, metautil.JoinIDPath(collectionID, segment.GetPartitionID(), segment.GetSegmentID())) + "/",
+			deltaPrefix(segment),
+		}
+		paths = append(paths, l0Prefixes(segment, segment.GetPartitionID())...)
+		paths = append(paths, l0Prefixes(segment, common.AllPartitionsID)...)
+		files[segment.GetPartitionID()] = append(files[segment.GetPartitionID()], &internalpb.ImportFile{Paths: paths})
+	}
+	return files
+}
+
+// restoreIndexes creates the indexes of the backup on the collection, so the restored segments are indexed.
+func (s *Server) restoreIndexes(ctx context.Context, backup *datapb.BackupInfo, collectionID int64) error {
+	for _, fieldIndex := range backup.GetIndexes() {
+		index := fieldIndex.GetIndexInfo()
+		ts, err := s.allocator.AllocTimestamp(ctx)
+		if err != nil {
+			return err
+		}
+		status, err := s.CreateIndex(ctx, &indexpb.CreateIndexRequest{
+			CollectionID:    collectionID,
+			FieldID:         index.GetFieldID(),
+			IndexName:       index.GetIndexName(),
+			TypeParams:      index.GetTypeParams(),
+			IndexParams:     index.GetIndexParams(),
+			Timestamp:       ts,
+			IsAutoIndex:     index.GetIsAutoIndex(),
+			UserIndexParams: index.GetUserIndexParams(),
+		})
+		if err := merr.CheckRPCCall(status, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildRestoreOptions(backup *datapb.BackupInfo) []*commonpb.KeyValuePair {
+	return []*commonpb.KeyValuePair{
+		{Key: importutilv2.BackupFlag, Value: "true"},
+		{Key: importutilv2.EndTs, Value: fmt.Sprint(tsoutil.PhysicalTime(backup.GetBackupTs()).UnixMilli())},
+	}
+}
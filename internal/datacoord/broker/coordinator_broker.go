@@ -25,6 +25,8 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/coordinator/coordbroker"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
@@ -38,61 +40,33 @@ type Broker interface {
 	ShowPartitionsInternal(ctx context.Context, collectionID int64) ([]int64, error)
 	ShowCollections(ctx context.Context, dbName string) (*milvuspb.ShowCollectionsResponse, error)
 	ListDatabases(ctx context.Context) (*milvuspb.ListDatabasesResponse, error)
+	DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error)
 	HasCollection(ctx context.Context, collectionID int64) (bool, error)
+	// AlterCollectionProperties sets the properties of the collection, the other properties are kept.
+	AlterCollectionProperties(ctx context.Context, dbName string, collectionName string, properties []*commonpb.KeyValuePair) error
+	// InvalidateCollection drops the cached collection info and partitions of the collections.
+	InvalidateCollection(collectionIDs ...int64)
 }
 
 type coordinatorBroker struct {
 	rootCoord types.RootCoordClient
+	// cached collection and partition lookups
+	shared coordbroker.Broker
 }
 
 func NewCoordinatorBroker(rootCoord types.RootCoordClient) *coordinatorBroker {
 	return &coordinatorBroker{
 		rootCoord: rootCoord,
+		shared:    coordbroker.NewCoordinatorBroker(rootCoord, nil),
 	}
 }
 
 func (b *coordinatorBroker) DescribeCollectionInternal(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
-	defer cancel()
-	log := log.Ctx(ctx).With(zap.Int64("collectionID", collectionID))
-
-	resp, err := b.rootCoord.DescribeCollectionInternal(ctx, &milvuspb.DescribeCollectionRequest{
-		Base: commonpbutil.NewMsgBase(
-			commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection),
-			commonpbutil.WithSourceID(paramtable.GetNodeID()),
-		),
-		// please do not specify the collection name alone after database feature.
-		CollectionID: collectionID,
-	})
-	if err := merr.CheckRPCCall(resp, err); err != nil {
-		log.Warn("DescribeCollectionInternal failed", zap.Error(err))
-		return nil, err
-	}
-
-	return resp, nil
+	return b.shared.DescribeCollectionInternal(ctx, collectionID)
 }
 
 func (b *coordinatorBroker) ShowPartitionsInternal(ctx context.Context, collectionID int64) ([]int64, error) {
-	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
-	defer cancel()
-	log := log.Ctx(ctx).With(zap.Int64("collectionID", collectionID))
-
-	resp, err := b.rootCoord.ShowPartitionsInternal(ctx, &milvuspb.ShowPartitionsRequest{
-		Base: commonpbutil.NewMsgBase(
-			commonpbutil.WithMsgType(commonpb.MsgType_ShowPartitions),
-			commonpbutil.WithSourceID(paramtable.GetNodeID()),
-		),
-		// please do not specify the collection name alone after database feature.
-		CollectionID: collectionID,
-	})
-	if err := merr.CheckRPCCall(resp, err); err != nil {
-		log.Warn("ShowPartitionsInternal failed",
-			zap.Int64("collectionID", collectionID),
-			zap.Error(err))
-		return nil, err
-	}
-
-	return resp.GetPartitionIDs(), nil
+	return b.shared.ShowPartitionsInternal(ctx, collectionID)
 }
 
 func (b *coordinatorBroker) ShowCollections(ctx context.Context, dbName string) (*milvuspb.ShowCollectionsResponse, error) {
@@ -130,6 +104,23 @@ func (b *coordinatorBroker) ListDatabases(ctx context.Context) (*milvuspb.ListDa
 	return resp, nil
 }
 
+func (b *coordinatorBroker) DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+	resp, err := b.rootCoord.DescribeDatabase(ctx, &rootcoordpb.DescribeDatabaseRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_DescribeDatabase),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		DbName: dbName,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Ctx(ctx).Warn("failed to DescribeDatabase", zap.String("dbName", dbName), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
 // HasCollection communicates with RootCoord and check whether this collection exist from the user's perspective.
 func (b *coordinatorBroker) HasCollection(ctx context.Context, collectionID int64) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
@@ -151,3 +142,29 @@ func (b *coordinatorBroker) HasCollection(ctx context.Context, collectionID int6
 	}
 	return err == nil, err
 }
+
+func (b *coordinatorBroker) AlterCollectionProperties(ctx context.Context, dbName string, collectionName string, properties []*commonpb.KeyValuePair) error {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+	resp, err := b.rootCoord.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_AlterCollection),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties:     properties,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Ctx(ctx).Warn("failed to alter collection properties",
+			zap.String("dbName", dbName),
+			zap.String("collectionName", collectionName),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (b *coordinatorBroker) InvalidateCollection(collectionIDs ...int64) {
+	b.shared.InvalidateCollection(collectionIDs...)
+}
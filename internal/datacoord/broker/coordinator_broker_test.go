@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -268,6 +269,36 @@ func (s *BrokerSuite) TestHasCollection() {
 	})
 }
 
+func (s *BrokerSuite) TestAlterCollectionProperties() {
+	s.Run("return_success", func() {
+		s.SetupTest()
+
+		s.rootCoordClient.EXPECT().AlterCollection(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.AlterCollectionRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			s.Equal("db", req.GetDbName())
+			s.Equal("test_collection", req.GetCollectionName())
+			s.Equal("value", req.GetProperties()[0].GetValue())
+			return merr.Success(), nil
+		})
+
+		err := s.broker.AlterCollectionProperties(context.Background(), "db", "test_collection",
+			[]*commonpb.KeyValuePair{{Key: "key", Value: "value"}})
+		s.NoError(err)
+
+		s.TearDownTest()
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+
+		s.rootCoordClient.EXPECT().AlterCollection(mock.Anything, mock.Anything).Return(nil, errors.New("mocked"))
+
+		err := s.broker.AlterCollectionProperties(context.Background(), "db", "test_collection", nil)
+		s.Error(err)
+
+		s.TearDownTest()
+	})
+}
+
 func TestBrokerSuite(t *testing.T) {
 	suite.Run(t, new(BrokerSuite))
 }
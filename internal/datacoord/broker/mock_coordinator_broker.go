@@ -5,8 +5,12 @@ package broker
 import (
 	context "context"
 
+	commonpb "github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+
 	milvuspb "github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	mock "github.com/stretchr/testify/mock"
+
+	rootcoordpb "github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 )
 
 // MockBroker is an autogenerated mock type for the Broker type
@@ -22,6 +26,55 @@ func (_m *MockBroker) EXPECT() *MockBroker_Expecter {
 	return &MockBroker_Expecter{mock: &_m.Mock}
 }
 
+// AlterCollectionProperties provides a mock function with given fields: ctx, dbName, collectionName, properties
+func (_m *MockBroker) AlterCollectionProperties(ctx context.Context, dbName string, collectionName string, properties []*commonpb.KeyValuePair) error {
+	ret := _m.Called(ctx, dbName, collectionName, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlterCollectionProperties")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []*commonpb.KeyValuePair) error); ok {
+		r0 = rf(ctx, dbName, collectionName, properties)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBroker_AlterCollectionProperties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlterCollectionProperties'
+type MockBroker_AlterCollectionProperties_Call struct {
+	*mock.Call
+}
+
+// AlterCollectionProperties is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbName string
+//   - collectionName string
+//   - properties []*commonpb.KeyValuePair
+func (_e *MockBroker_Expecter) AlterCollectionProperties(ctx interface{}, dbName interface{}, collectionName interface{}, properties interface{}) *MockBroker_AlterCollectionProperties_Call {
+	return &MockBroker_AlterCollectionProperties_Call{Call: _e.mock.On("AlterCollectionProperties", ctx, dbName, collectionName, properties)}
+}
+
+func (_c *MockBroker_AlterCollectionProperties_Call) Run(run func(ctx context.Context, dbName string, collectionName string, properties []*commonpb.KeyValuePair)) *MockBroker_AlterCollectionProperties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]*commonpb.KeyValuePair))
+	})
+	return _c
+}
+
+func (_c *MockBroker_AlterCollectionProperties_Call) Return(_a0 error) *MockBroker_AlterCollectionProperties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBroker_AlterCollectionProperties_Call) RunAndReturn(run func(context.Context, string, string, []*commonpb.KeyValuePair) error) *MockBroker_AlterCollectionProperties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DescribeCollectionInternal provides a mock function with given fields: ctx, collectionID
 func (_m *MockBroker) DescribeCollectionInternal(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error) {
 	ret := _m.Called(ctx, collectionID)
@@ -81,6 +134,65 @@ func (_c *MockBroker_DescribeCollectionInternal_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// DescribeDatabase provides a mock function with given fields: ctx, dbName
+func (_m *MockBroker) DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
+	ret := _m.Called(ctx, dbName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeDatabase")
+	}
+
+	var r0 *rootcoordpb.DescribeDatabaseResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*rootcoordpb.DescribeDatabaseResponse, error)); ok {
+		return rf(ctx, dbName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *rootcoordpb.DescribeDatabaseResponse); ok {
+		r0 = rf(ctx, dbName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rootcoordpb.DescribeDatabaseResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, dbName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBroker_DescribeDatabase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeDatabase'
+type MockBroker_DescribeDatabase_Call struct {
+	*mock.Call
+}
+
+// DescribeDatabase is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbName string
+func (_e *MockBroker_Expecter) DescribeDatabase(ctx interface{}, dbName interface{}) *MockBroker_DescribeDatabase_Call {
+	return &MockBroker_DescribeDatabase_Call{Call: _e.mock.On("DescribeDatabase", ctx, dbName)}
+}
+
+func (_c *MockBroker_DescribeDatabase_Call) Run(run func(ctx context.Context, dbName string)) *MockBroker_DescribeDatabase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBroker_DescribeDatabase_Call) Return(_a0 *rootcoordpb.DescribeDatabaseResponse, _a1 error) *MockBroker_DescribeDatabase_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBroker_DescribeDatabase_Call) RunAndReturn(run func(context.Context, string) (*rootcoordpb.DescribeDatabaseResponse, error)) *MockBroker_DescribeDatabase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HasCollection provides a mock function with given fields: ctx, collectionID
 func (_m *MockBroker) HasCollection(ctx context.Context, collectionID int64) (bool, error) {
 	ret := _m.Called(ctx, collectionID)
@@ -138,6 +250,52 @@ func (_c *MockBroker_HasCollection_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// InvalidateCollection provides a mock function with given fields: collectionIDs
+func (_m *MockBroker) InvalidateCollection(collectionIDs ...int64) {
+	_va := make([]interface{}, len(collectionIDs))
+	for _i := range collectionIDs {
+		_va[_i] = collectionIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// MockBroker_InvalidateCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateCollection'
+type MockBroker_InvalidateCollection_Call struct {
+	*mock.Call
+}
+
+// InvalidateCollection is a helper method to define mock.On call
+//   - collectionIDs ...int64
+func (_e *MockBroker_Expecter) InvalidateCollection(collectionIDs ...interface{}) *MockBroker_InvalidateCollection_Call {
+	return &MockBroker_InvalidateCollection_Call{Call: _e.mock.On("InvalidateCollection",
+		append([]interface{}{}, collectionIDs...)...)}
+}
+
+func (_c *MockBroker_InvalidateCollection_Call) Run(run func(collectionIDs ...int64)) *MockBroker_InvalidateCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]int64, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(int64)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockBroker_InvalidateCollection_Call) Return() *MockBroker_InvalidateCollection_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBroker_InvalidateCollection_Call) RunAndReturn(run func(...int64)) *MockBroker_InvalidateCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDatabases provides a mock function with given fields: ctx
 func (_m *MockBroker) ListDatabases(ctx context.Context) (*milvuspb.ListDatabasesResponse, error) {
 	ret := _m.Called(ctx)
@@ -0,0 +1,103 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+// inspectChannelCheckpoint collects the checkpoint of the channel and the positions of its segments,
+// the checkpoint is checked against the seal positions of the flushed segments.
+func inspectChannelCheckpoint(ctx context.Context, meta *meta, collectionID int64, channel string, now time.Time) *datapb.ChannelCheckpointInfo {
+	info := &datapb.ChannelCheckpointInfo{
+		Channel:    channel,
+		Checkpoint: meta.GetChannelCheckpoint(channel),
+	}
+
+	segments := meta.SelectSegments(ctx, WithCollection(collectionID), WithChannel(channel), SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		// the positions of the imported segments are not from the channel
+		return isSegmentHealthy(segment) && !segment.GetIsImporting()
+	}))
+	for _, segment := range segments {
+		switch segment.GetState() {
+		case commonpb.SegmentState_Flushed:
+			pos := segment.GetDmlPosition()
+			if pos != nil && pos.GetTimestamp() > info.GetMaxFlushedPosition().GetTimestamp() {
+				info.MaxFlushedPosition = pos
+				info.MaxFlushedSegmentID = segment.GetID()
+			}
+		case commonpb.SegmentState_Growing, commonpb.SegmentState_Sealed, commonpb.SegmentState_Flushing:
+			// the data after the dml position is not persisted yet
+			pos := segment.GetDmlPosition()
+			if pos == nil {
+				pos = segment.GetStartPosition()
+			}
+			if pos != nil && (info.GetMinUnflushedPosition() == nil || pos.GetTimestamp() < info.GetMinUnflushedPosition().GetTimestamp()) {
+				info.MinUnflushedPosition = pos
+				info.MinUnflushedSegmentID = segment.GetID()
+			}
+		}
+	}
+
+	cp := info.GetCheckpoint()
+	switch {
+	case cp == nil:
+		info.Issues = append(info.Issues, "channel checkpoint not found")
+	case cp.GetTimestamp() == math.MaxUint64:
+		info.Issues = append(info.Issues, "channel is dropped")
+	default:
+		info.LagMs = now.Sub(tsoutil.PhysicalTime(cp.GetTimestamp())).Milliseconds()
+		info.Issues = append(info.Issues, checkFlushedPosition(info, cp)...)
+		maxLag := paramtable.Get().DataCoordCfg.ChannelCheckpointMaxLag.GetAsDuration(time.Second)
+		if info.GetLagMs() > maxLag.Milliseconds() {
+			info.Issues = append(info.Issues, fmt.Sprintf("checkpoint lags behind for %s, more than %s", time.Duration(info.GetLagMs())*time.Millisecond, maxLag))
+		}
+	}
+	return info
+}
+
+// checkFlushedPosition checks the position is not before the seal position of the flushed segments.
+func checkFlushedPosition(info *datapb.ChannelCheckpointInfo, pos *msgpb.MsgPosition) []string {
+	if info.GetMaxFlushedPosition() != nil && pos.GetTimestamp() < info.GetMaxFlushedPosition().GetTimestamp() {
+		return []string{fmt.Sprintf("position ts %d is before the dml position ts %d of flushed segment %d, the flushed data will be consumed again",
+			pos.GetTimestamp(), info.GetMaxFlushedPosition().GetTimestamp(), info.GetMaxFlushedSegmentID())}
+	}
+	return nil
+}
+
+// checkRepairTarget returns the consistency issues of moving the checkpoint to the target position.
+func checkRepairTarget(info *datapb.ChannelCheckpointInfo, target *msgpb.MsgPosition) []string {
+	issues := checkFlushedPosition(info, target)
+	if info.GetMinUnflushedPosition() != nil && target.GetTimestamp() > info.GetMinUnflushedPosition().GetTimestamp() {
+		issues = append(issues, fmt.Sprintf("position ts %d is after the position ts %d of unflushed segment %d, the data not persisted in between will be lost",
+			target.GetTimestamp(), info.GetMinUnflushedPosition().GetTimestamp(), info.GetMinUnflushedSegmentID()))
+	}
+	if cp := info.GetCheckpoint(); cp != nil && cp.GetTimestamp() != math.MaxUint64 && target.GetTimestamp() > cp.GetTimestamp() {
+		issues = append(issues, fmt.Sprintf("position ts %d is after the checkpoint ts %d, the messages in between will be skipped",
+			target.GetTimestamp(), cp.GetTimestamp()))
+	}
+	return issues
+}
@@ -0,0 +1,185 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+func TestChannelCheckpointRepair(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	handler := NewNMockHandler(t)
+	handler.EXPECT().GetCollection(mock.Anything, int64(1)).Return(&collectionInfo{ID: 1, VChannelNames: []string{"ch1"}}, nil).Maybe()
+	handler.EXPECT().GetCollection(mock.Anything, int64(2)).Return(nil, nil).Maybe()
+	channelManager := NewMockChannelManager(t)
+	channelManager.EXPECT().FindWatcher("ch1").Return(0, errChannelInBuffer).Maybe()
+	s := &Server{meta: meta, handler: handler, channelManager: channelManager}
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+
+	base := tsoutil.ComposeTSByTime(time.Now().Add(-time.Minute), 0)
+	newPosition := func(ts uint64) *msgpb.MsgPosition {
+		return &msgpb.MsgPosition{ChannelName: "ch1", MsgID: []byte{byte(ts)}, Timestamp: ts}
+	}
+	require.NoError(t, meta.AddSegment(ctx, NewSegmentInfo(&datapb.SegmentInfo{
+		ID:            1,
+		CollectionID:  1,
+		InsertChannel: "ch1",
+		State:         commonpb.SegmentState_Flushed,
+		DmlPosition:   newPosition(base + 10),
+	})))
+	require.NoError(t, meta.AddSegment(ctx, NewSegmentInfo(&datapb.SegmentInfo{
+		ID:            2,
+		CollectionID:  1,
+		InsertChannel: "ch1",
+		State:         commonpb.SegmentState_Growing,
+		StartPosition: newPosition(base + 30),
+	})))
+	require.NoError(t, meta.UpdateChannelCheckpoint(ctx, "ch1", newPosition(base+20)))
+
+	t.Run("inspect", func(t *testing.T) {
+		resp, err := s.InspectChannelCheckpoints(ctx, &datapb.InspectChannelCheckpointsRequest{CollectionID: 1})
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+		require.Equal(t, 1, len(resp.GetCheckpoints()))
+		info := resp.GetCheckpoints()[0]
+		assert.Equal(t, "ch1", info.GetChannel())
+		assert.Equal(t, base+20, info.GetCheckpoint().GetTimestamp())
+		assert.Equal(t, int64(1), info.GetMaxFlushedSegmentID())
+		assert.Equal(t, int64(2), info.GetMinUnflushedSegmentID())
+		assert.GreaterOrEqual(t, info.GetLagMs(), time.Minute.Milliseconds())
+		assert.Empty(t, info.GetIssues())
+
+		resp, err = s.InspectChannelCheckpoints(ctx, &datapb.InspectChannelCheckpointsRequest{CollectionID: 1, Channel: "ch2"})
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrChannelNotFound)
+		resp, err = s.InspectChannelCheckpoints(ctx, &datapb.InspectChannelCheckpointsRequest{CollectionID: 2})
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrCollectionNotFound)
+	})
+
+	t.Run("reset_to_flushed", func(t *testing.T) {
+		req := &datapb.RepairChannelCheckpointRequest{
+			CollectionID: 1,
+			Channel:      "ch1",
+			Action:       datapb.ChannelCheckpointRepairAction_ResetToFlushed,
+		}
+		resp, err := s.RepairChannelCheckpoint(ctx, req)
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+		assert.False(t, resp.GetApplied())
+		assert.Equal(t, base+10, resp.GetNewCheckpoint().GetTimestamp())
+		assert.Empty(t, resp.GetIssues())
+		assert.Equal(t, base+20, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+
+		req.Confirm = true
+		resp, err = s.RepairChannelCheckpoint(ctx, req)
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+		assert.True(t, resp.GetApplied())
+		assert.Equal(t, base+10, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+	})
+
+	t.Run("set_position", func(t *testing.T) {
+		req := &datapb.RepairChannelCheckpointRequest{
+			CollectionID: 1,
+			Channel:      "ch1",
+			Action:       datapb.ChannelCheckpointRepairAction_SetPosition,
+			Position:     newPosition(base + 40),
+			Confirm:      true,
+		}
+		// skips the unflushed data
+		resp, err := s.RepairChannelCheckpoint(ctx, req)
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+		assert.False(t, resp.GetApplied())
+		assert.Equal(t, 2, len(resp.GetIssues()))
+		assert.Equal(t, base+10, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+
+		req.Force = true
+		resp, err = s.RepairChannelCheckpoint(ctx, req)
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+		assert.True(t, resp.GetApplied())
+		assert.Equal(t, base+40, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+
+		req.Position = &msgpb.MsgPosition{Timestamp: base}
+		resp, err = s.RepairChannelCheckpoint(ctx, req)
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+
+		req.Position = newPosition(tsoutil.ComposeTSByTime(time.Now().Add(time.Hour), 0))
+		resp, err = s.RepairChannelCheckpoint(ctx, req)
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+	})
+
+	t.Run("watched", func(t *testing.T) {
+		channelManager := NewMockChannelManager(t)
+		channelManager.EXPECT().FindWatcher("ch1").Return(1, nil)
+		s := &Server{meta: meta, handler: handler, channelManager: channelManager}
+		s.stateCode.Store(commonpb.StateCode_Healthy)
+		resp, err := s.RepairChannelCheckpoint(ctx, &datapb.RepairChannelCheckpointRequest{
+			CollectionID: 1,
+			Channel:      "ch1",
+			Action:       datapb.ChannelCheckpointRepairAction_SetPosition,
+			Position:     newPosition(base + 50),
+			Confirm:      true,
+			Force:        true,
+		})
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrChannelNotAvailable)
+		assert.False(t, resp.GetApplied())
+		assert.Equal(t, base+40, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp, err := s.RepairChannelCheckpoint(ctx, &datapb.RepairChannelCheckpointRequest{CollectionID: 1})
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+		// the action is unspecified
+		resp, err = s.RepairChannelCheckpoint(ctx, &datapb.RepairChannelCheckpointRequest{CollectionID: 1, Channel: "ch1", Confirm: true})
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+		assert.Equal(t, base+40, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+		resp, err = s.RepairChannelCheckpoint(ctx, &datapb.RepairChannelCheckpointRequest{CollectionID: 1, Channel: "ch2"})
+		assert.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrChannelNotFound)
+
+		s := &Server{}
+		s.stateCode.Store(commonpb.StateCode_Abnormal)
+		inspectResp, err := s.InspectChannelCheckpoints(ctx, &datapb.InspectChannelCheckpointsRequest{})
+		assert.Error(t, merr.CheckRPCCall(inspectResp, err))
+		resp, err = s.RepairChannelCheckpoint(ctx, &datapb.RepairChannelCheckpointRequest{})
+		assert.Error(t, merr.CheckRPCCall(resp, err))
+	})
+}
+
+func TestCheckRepairTarget(t *testing.T) {
+	info := &datapb.ChannelCheckpointInfo{
+		Checkpoint:           &msgpb.MsgPosition{Timestamp: 20},
+		MaxFlushedPosition:   &msgpb.MsgPosition{Timestamp: 10},
+		MinUnflushedPosition: &msgpb.MsgPosition{Timestamp: 30},
+	}
+	assert.Empty(t, checkRepairTarget(info, &msgpb.MsgPosition{Timestamp: 15}))
+	assert.Equal(t, 1, len(checkRepairTarget(info, &msgpb.MsgPosition{Timestamp: 5})))
+	assert.Equal(t, 1, len(checkRepairTarget(info, &msgpb.MsgPosition{Timestamp: 25})))
+	assert.Equal(t, 2, len(checkRepairTarget(info, &msgpb.MsgPosition{Timestamp: 35})))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/datacoord/session"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/decisionjournal"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/conc"
@@ -615,6 +617,18 @@ func (c *compactionPlanHandler) enqueueCompaction(task *datapb.CompactionTask) e
 		return err
 	}
 	log.Info("Compaction plan submitted")
+	decisionjournal.Record(typeutil.DataCoordRole, &decisionjournal.Event{
+		Type:         decisionjournal.TypeCompactionTrigger,
+		CollectionID: task.GetCollectionID(),
+		PartitionID:  task.GetPartitionID(),
+		Channel:      task.GetChannel(),
+		SegmentIDs:   task.GetInputSegments(),
+		Reason:       task.GetType().String(),
+		Detail: map[string]string{
+			"plan_id":    strconv.FormatInt(task.GetPlanID(), 10),
+			"trigger_id": strconv.FormatInt(task.GetTriggerID(), 10),
+		},
+	})
 	return nil
 }
 
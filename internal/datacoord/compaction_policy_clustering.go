@@ -107,6 +107,10 @@ func (policy *clusteringCompactionPolicy) triggerOneCollection(ctx context.Conte
 		log.Info("the collection has no clustering key, skip tigger clustering compaction")
 		return nil, 0, nil
 	}
+	if !manual && !isCollectionInMaintenanceWindow(collection, time.Now()) {
+		log.Info("collection out of maintenance window, skip clustering compaction")
+		return nil, 0, nil
+	}
 
 	compacting, triggerID := policy.collectionIsClusteringCompacting(collection.ID)
 	if compacting {
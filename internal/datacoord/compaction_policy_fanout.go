@@ -0,0 +1,198 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/fanoutfeedback"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// fanOutCompactionPolicy merges small segments of a shard when the searches on the shard fan out to too many segments.
+// The fan out is observed by the querynodes after the segment pruning and reported by fanOutFeedback,
+// it's the search amplification, which size based policies do not bound when data arrives slowly.
+// The shards not searched are left alone.
+type fanOutCompactionPolicy struct {
+	meta      *meta
+	allocator allocator.Allocator
+	handler   Handler
+	// nil if the reports of the querynodes are not available
+	feedback *fanOutFeedback
+}
+
+func newFanOutCompactionPolicy(meta *meta, allocator allocator.Allocator, handler Handler) *fanOutCompactionPolicy {
+	return &fanOutCompactionPolicy{meta: meta, allocator: allocator, handler: handler}
+}
+
+func (policy *fanOutCompactionPolicy) Enable() bool {
+	return policy.feedback != nil &&
+		Params.DataCoordCfg.EnableAutoCompaction.GetAsBool() &&
+		Params.DataCoordCfg.FanOutCompactionEnable.GetAsBool()
+}
+
+func (policy *fanOutCompactionPolicy) Trigger() (map[CompactionTriggerType][]CompactionView, error) {
+	ctx := context.Background()
+	shards, err := policy.feedback.refresh(ctx)
+	if err != nil {
+		log.Warn("fail to load the search fan out reported by querynodes", zap.Error(err))
+		return nil, err
+	}
+	collections := typeutil.NewUniqueSet()
+	for _, shard := range shards {
+		collections.Insert(shard.CollectionID)
+	}
+
+	events := make(map[CompactionTriggerType][]CompactionView, 0)
+	views := make([]CompactionView, 0)
+	for _, collectionID := range collections.Collect() {
+		collectionViews, _, err := policy.triggerOneCollection(ctx, collectionID, shards)
+		if err != nil {
+			// not throw this error because no need to fail because of one collection
+			log.Warn("fail to trigger fan out compaction", zap.Int64("collectionID", collectionID), zap.Error(err))
+		}
+		views = append(views, collectionViews...)
+	}
+	events[TriggerTypeFanOut] = views
+	return events, nil
+}
+
+// triggerOneCollection merges the small segments of the shards of the collection,
+// of which the fan out reported exceeds the threshold.
+func (policy *fanOutCompactionPolicy) triggerOneCollection(ctx context.Context, collectionID int64, shards map[string]*fanoutfeedback.ShardFanOut) ([]CompactionView, int64, error) {
+	log := log.With(zap.Int64("collectionID", collectionID))
+	collection, err := policy.handler.GetCollection(ctx, collectionID)
+	if err != nil {
+		log.Warn("fail to apply fanOutCompactionPolicy, unable to get collection from handler",
+			zap.Error(err))
+		return nil, 0, err
+	}
+	if collection == nil {
+		log.Warn("fail to apply fanOutCompactionPolicy, collection not exist")
+		return nil, 0, nil
+	}
+	if !isCollectionAutoCompactionEnabled(collection) {
+		log.RatedInfo(20, "collection auto compaction disabled")
+		return nil, 0, nil
+	}
+	if !isCollectionInMaintenanceWindow(collection, time.Now()) {
+		log.RatedInfo(20, "collection out of maintenance window, skip compaction")
+		return nil, 0, nil
+	}
+
+	partSegments := policy.meta.GetSegmentsChanPart(func(segment *SegmentInfo) bool {
+		return segment.CollectionID == collectionID &&
+			isSegmentHealthy(segment) &&
+			isFlush(segment) &&
+			!segment.isCompacting && // not compacting now
+			!segment.GetIsImporting() && // not importing now
+			segment.GetLevel() != datapb.SegmentLevel_L0 &&
+			!segment.GetIsInvisible()
+	})
+
+	collectionTTL, err := getCollectionTTL(collection.Properties)
+	if err != nil {
+		log.Warn("failed to apply fanOutCompactionPolicy, get collection ttl failed")
+		return nil, 0, err
+	}
+
+	maxSegmentNum := Params.DataCoordCfg.FanOutCompactionMaxSegmentsPerShard.GetAsFloat()
+	expectedSize := float64(getExpectedSegmentSize(policy.meta, collection))
+	smallSize := expectedSize * Params.DataCoordCfg.SegmentSmallProportion.GetAsFloat()
+
+	var newTriggerID int64
+	views := make([]CompactionView, 0)
+	for _, group := range partSegments {
+		// search fans out to the segments of all the partitions of the shard
+		shard, ok := shards[group.channelName]
+		if !ok || shard.Average() <= maxSegmentNum {
+			continue
+		}
+
+		buckets := bucketSmallSegments(GetViewsByInfo(group.segments...), smallSize, expectedSize)
+		if len(buckets) == 0 {
+			continue
+		}
+		if newTriggerID == 0 {
+			newTriggerID, err = policy.allocator.AllocID(ctx)
+			if err != nil {
+				log.Warn("fail to apply fanOutCompactionPolicy, unable to allocate triggerID", zap.Error(err))
+				return nil, 0, err
+			}
+		}
+		for _, bucket := range buckets {
+			views = append(views, &MixSegmentView{
+				label:         bucket[0].label,
+				segments:      bucket,
+				collectionTTL: collectionTTL,
+				triggerID:     newTriggerID,
+			})
+		}
+		log.Info("search fan out of shard exceeds threshold, merge small segments",
+			zap.String("channel", group.channelName),
+			zap.Int64("partitionID", group.partitionID),
+			zap.Int64("searches", shard.Searches),
+			zap.Float64("averageFanOut", shard.Average()),
+			zap.Int("bucketNum", len(buckets)))
+	}
+
+	if len(views) > 0 {
+		log.Info("succeeded to apply fanOutCompactionPolicy",
+			zap.Int64("triggerID", newTriggerID),
+			zap.Int("triggered view num", len(views)))
+	}
+	return views, newTriggerID, nil
+}
+
+// bucketSmallSegments groups segments smaller than smallSize into buckets,
+// smallest first, each bucket holds at most expectedSize bytes and at least two segments.
+func bucketSmallSegments(segments []*SegmentView, smallSize float64, expectedSize float64) [][]*SegmentView {
+	small := make([]*SegmentView, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Size < smallSize {
+			small = append(small, segment)
+		}
+	}
+	sort.Slice(small, func(i, j int) bool {
+		return small[i].Size < small[j].Size
+	})
+
+	buckets := make([][]*SegmentView, 0)
+	var bucket []*SegmentView
+	var bucketSize float64
+	for _, segment := range small {
+		if bucketSize+segment.Size > expectedSize {
+			if len(bucket) > 1 {
+				buckets = append(buckets, bucket)
+			}
+			bucket, bucketSize = nil, 0
+		}
+		bucket = append(bucket, segment)
+		bucketSize += segment.Size
+	}
+	if len(bucket) > 1 {
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/fanoutfeedback"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestFanOutCompactionPolicySuite(t *testing.T) {
+	suite.Run(t, new(FanOutCompactionPolicySuite))
+}
+
+type FanOutCompactionPolicySuite struct {
+	suite.Suite
+
+	mockAlloc *allocator.MockAllocator
+	handler   *NMockHandler
+
+	fanOutPolicy *fanOutCompactionPolicy
+}
+
+func (s *FanOutCompactionPolicySuite) SetupTest() {
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.FanOutCompactionMaxSegmentsPerShard.Key, "3")
+
+	s.mockAlloc = allocator.NewMockAllocator(s.T())
+	s.handler = NewNMockHandler(s.T())
+	s.fanOutPolicy = newFanOutCompactionPolicy(&meta{
+		segments: NewSegmentsInfo(),
+		indexMeta: &indexMeta{
+			segmentIndexes: map[UniqueID]map[UniqueID]*model.SegmentIndex{},
+			indexes:        map[UniqueID]map[UniqueID]*model.Index{},
+		},
+	}, s.mockAlloc, s.handler)
+}
+
+func (s *FanOutCompactionPolicySuite) TearDownTest() {
+	paramtable.Get().Reset(paramtable.Get().DataCoordCfg.FanOutCompactionMaxSegmentsPerShard.Key)
+}
+
+func (s *FanOutCompactionPolicySuite) buildSegment(id int64, channel string, size int64) *SegmentInfo {
+	return NewSegmentInfo(&datapb.SegmentInfo{
+		ID:            id,
+		CollectionID:  1,
+		PartitionID:   10,
+		InsertChannel: channel,
+		Level:         datapb.SegmentLevel_L1,
+		State:         commonpb.SegmentState_Flushed,
+		NumOfRows:     100,
+		Binlogs: []*datapb.FieldBinlog{
+			{FieldID: 100, Binlogs: []*datapb.Binlog{{MemorySize: size}}},
+		},
+	})
+}
+
+func (s *FanOutCompactionPolicySuite) TestBucketSmallSegments() {
+	segments := lo.Map([]float64{10, 60, 20, 30, 500}, func(size float64, i int) *SegmentView {
+		return &SegmentView{ID: int64(i), Size: size}
+	})
+	buckets := bucketSmallSegments(segments, 100, 70)
+	s.Require().Len(buckets, 1)
+	s.Equal([]int64{0, 2, 3}, lo.Map(buckets[0], func(view *SegmentView, _ int) int64 { return view.ID }))
+
+	// single small segment is not merged
+	s.Empty(bucketSmallSegments(segments[:2], 100, 70))
+}
+
+func (s *FanOutCompactionPolicySuite) TestTriggerOneCollection() {
+	s.handler.EXPECT().GetCollection(mock.Anything, int64(1)).Return(&collectionInfo{
+		ID:     1,
+		Schema: newTestSchema(),
+	}, nil)
+	s.mockAlloc.EXPECT().AllocID(mock.Anything).Return(1000, nil).Once()
+
+	for i := int64(1); i <= 4; i++ {
+		s.fanOutPolicy.meta.segments.SetSegment(i, s.buildSegment(i, "ch-1", 1024))
+	}
+	// ch-2 is under the threshold
+	for i := int64(5); i <= 6; i++ {
+		s.fanOutPolicy.meta.segments.SetSegment(i, s.buildSegment(i, "ch-2", 1024))
+	}
+	// ch-3 is not searched
+	for i := int64(7); i <= 10; i++ {
+		s.fanOutPolicy.meta.segments.SetSegment(i, s.buildSegment(i, "ch-3", 1024))
+	}
+
+	// searches on ch-1 fan out to four segments on average, exceeding the threshold
+	shards := map[string]*fanoutfeedback.ShardFanOut{
+		"ch-1": {CollectionID: 1, Channel: "ch-1", Searches: 10, Segments: 40},
+		"ch-2": {CollectionID: 1, Channel: "ch-2", Searches: 10, Segments: 20},
+	}
+	views, triggerID, err := s.fanOutPolicy.triggerOneCollection(context.Background(), 1, shards)
+	s.NoError(err)
+	s.Equal(int64(1000), triggerID)
+	s.Require().Len(views, 1)
+	s.Equal("ch-1", views[0].GetGroupLabel().Channel)
+	s.Len(views[0].GetSegmentsView(), 4)
+}
+
+func (s *FanOutCompactionPolicySuite) TestTriggerUnderThreshold() {
+	s.handler.EXPECT().GetCollection(mock.Anything, int64(1)).Return(&collectionInfo{
+		ID:     1,
+		Schema: newTestSchema(),
+	}, nil)
+
+	for i := int64(1); i <= 4; i++ {
+		s.fanOutPolicy.meta.segments.SetSegment(i, s.buildSegment(i, "ch-1", 1024))
+	}
+	// the segments are pruned by the searches
+	shards := map[string]*fanoutfeedback.ShardFanOut{
+		"ch-1": {CollectionID: 1, Channel: "ch-1", Searches: 10, Segments: 15},
+	}
+	views, _, err := s.fanOutPolicy.triggerOneCollection(context.Background(), 1, shards)
+	s.NoError(err)
+	s.Empty(views)
+}
+
+func (s *FanOutCompactionPolicySuite) TestTrigger() {
+	ctx := context.Background()
+	metaKV := memkv.NewMemoryKV()
+	s.fanOutPolicy.feedback = newFanOutFeedback(metaKV, func() (typeutil.UniqueSet, error) {
+		return typeutil.NewUniqueSet(1), nil
+	})
+	s.NoError(fanoutfeedback.NewStore(metaKV).Save(ctx, &fanoutfeedback.Report{NodeID: 1, Seq: 1, Items: []*fanoutfeedback.ShardFanOut{
+		{CollectionID: 1, Channel: "ch-1", Searches: 10, Segments: 40},
+	}}))
+	s.handler.EXPECT().GetCollection(mock.Anything, int64(1)).Return(&collectionInfo{
+		ID:     1,
+		Schema: newTestSchema(),
+	}, nil)
+	s.mockAlloc.EXPECT().AllocID(mock.Anything).Return(1000, nil).Once()
+	for i := int64(1); i <= 4; i++ {
+		s.fanOutPolicy.meta.segments.SetSegment(i, s.buildSegment(i, "ch-1", 1024))
+	}
+
+	events, err := s.fanOutPolicy.Trigger()
+	s.NoError(err)
+	s.Len(events[TriggerTypeFanOut], 1)
+}
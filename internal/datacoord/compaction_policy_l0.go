@@ -153,3 +153,18 @@ func (policy *l0CompactionPolicy) generateEventForLevelZeroViewIDLE() map[Compac
 
 	return events
 }
+
+// channelViews returns the views of the compactable L0 segments of the channel.
+func (policy *l0CompactionPolicy) channelViews(collectionID int64, channel string) []CompactionView {
+	segments := policy.meta.SelectSegments(policy.meta.ctx, WithCollection(collectionID), WithChannel(channel),
+		SegmentFilterFunc(func(segment *SegmentInfo) bool {
+			return segment.GetLevel() == datapb.SegmentLevel_L0 &&
+				isSegmentHealthy(segment) &&
+				isFlush(segment) &&
+				!segment.isCompacting
+		}))
+	grouped := policy.groupL0ViewsByPartChan(collectionID, GetViewsByInfo(segments...))
+	return lo.Map(lo.Values(grouped), func(view *LevelZeroSegmentsView, _ int) CompactionView {
+		return view
+	})
+}
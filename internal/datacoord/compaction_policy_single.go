@@ -80,6 +80,10 @@ func (policy *singleCompactionPolicy) triggerOneCollection(ctx context.Context,
 		log.RatedInfo(20, "collection auto compaction disabled")
 		return nil, 0, nil
 	}
+	if !isCollectionInMaintenanceWindow(collection, time.Now()) {
+		log.RatedInfo(20, "collection out of maintenance window, skip compaction")
+		return nil, 0, nil
+	}
 
 	newTriggerID, err := policy.allocator.AllocID(ctx)
 	if err != nil {
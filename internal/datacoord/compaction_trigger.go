@@ -80,6 +80,10 @@ type compactionTrigger struct {
 	closeWaiter       sync.WaitGroup
 
 	indexEngineVersionManager IndexEngineVersionManager
+	// the deleted rows reported by the querynodes, nil if not available
+	deleteFeedback *deleteFeedback
+	// requests the L0 compaction of a channel, nil if not available
+	requestL0Compaction func(collectionID int64, channel string)
 
 	estimateNonDiskSegmentPolicy calUpperLimitPolicy
 	estimateDiskSegmentPolicy    calUpperLimitPolicy
@@ -110,7 +114,7 @@ func newCompactionTrigger(
 
 func (t *compactionTrigger) start() {
 	t.globalTrigger = time.NewTicker(Params.DataCoordCfg.MixCompactionTriggerInterval.GetAsDuration(time.Second))
-	t.closeWaiter.Add(2)
+	t.closeWaiter.Add(3)
 	go func() {
 		defer logutil.LogPanic()
 		defer t.closeWaiter.Done()
@@ -138,6 +142,7 @@ func (t *compactionTrigger) start() {
 	}()
 
 	go t.startGlobalCompactionLoop()
+	go t.startDeleteFeedbackLoop()
 }
 
 func (t *compactionTrigger) startGlobalCompactionLoop() {
@@ -164,6 +169,75 @@ func (t *compactionTrigger) startGlobalCompactionLoop() {
 	}
 }
 
+// startDeleteFeedbackLoop signals the compaction of the segments once the querynodes report them beyond the deleted ratio threshold,
+// rather than waiting for the global compaction loop.
+func (t *compactionTrigger) startDeleteFeedbackLoop() {
+	defer logutil.LogPanic()
+	defer t.closeWaiter.Done()
+
+	if t.deleteFeedback == nil || !Params.DataCoordCfg.EnableAutoCompaction.GetAsBool() {
+		return
+	}
+	timer := time.NewTimer(paramtable.Get().QueryNodeCfg.DeleteFeedbackInterval.GetAsDuration(time.Second))
+	defer timer.Stop()
+	for {
+		select {
+		case <-t.closeCh.CloseCh():
+			log.Info("delete feedback loop exit")
+			return
+		case <-timer.C:
+			if Params.DataCoordCfg.DeleteFeedbackCompactionEnable.GetAsBool() {
+				t.handleDeleteFeedback(context.Background())
+			}
+			timer.Reset(paramtable.Get().QueryNodeCfg.DeleteFeedbackInterval.GetAsDuration(time.Second))
+		}
+	}
+}
+
+// handleDeleteFeedback compacts the segments reported by the querynodes beyond the deleted ratio threshold.
+// The deletes still in the L0 segments are applied into the deltalogs by the L0 compaction of the channel first,
+// otherwise the single compaction would keep the deleted rows.
+func (t *compactionTrigger) handleDeleteFeedback(ctx context.Context) {
+	reported, err := t.deleteFeedback.refresh(ctx)
+	if err != nil {
+		log.Warn("failed to load the delete feedback of querynodes", zap.Error(err))
+		return
+	}
+	l0Requested := typeutil.NewSet[string]()
+	for _, deletes := range reported {
+		segment := t.meta.GetHealthySegment(ctx, deletes.SegmentID)
+		if segment == nil || !isFlush(segment) || segment.isCompacting || segment.GetLevel() == datapb.SegmentLevel_L0 {
+			continue
+		}
+		log := log.With(zap.Int64("collectionID", segment.GetCollectionID()), zap.Int64("segmentID", segment.GetID()),
+			zap.String("channel", segment.GetInsertChannel()), zap.Int64("reportedDeletes", deletes.DeletedCount))
+		if deltalogDeletedRows(segment) < deletes.DeletedCount {
+			if t.requestL0Compaction != nil && !l0Requested.Contain(segment.GetInsertChannel()) {
+				log.Info("deleted ratio reported by querynodes is too high, but the deletes are not in the deltalogs yet, request L0 compaction")
+				t.requestL0Compaction(segment.GetCollectionID(), segment.GetInsertChannel())
+				l0Requested.Insert(segment.GetInsertChannel())
+			}
+			continue
+		}
+		log.Info("deleted ratio reported by querynodes is too high, signal compaction")
+		if err := t.triggerSingleCompaction(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID(),
+			segment.GetInsertChannel(), false); err != nil {
+			log.Warn("failed to signal compaction", zap.Error(err))
+		}
+	}
+}
+
+// deltalogDeletedRows returns the deletes applied into the deltalogs of the segment.
+func deltalogDeletedRows(segment *SegmentInfo) int64 {
+	var deleted int64
+	for _, deltaLogs := range segment.GetDeltalogs() {
+		for _, l := range deltaLogs.GetBinlogs() {
+			deleted += l.GetEntriesNum()
+		}
+	}
+	return deleted
+}
+
 func (t *compactionTrigger) stop() {
 	t.closeCh.Close()
 	t.closeWaiter.Wait()
@@ -331,6 +405,11 @@ func (t *compactionTrigger) handleGlobalSignal(signal *compactionSignal) error {
 			return nil
 		}
 
+		if !signal.isForce && !isCollectionInMaintenanceWindow(coll, time.Now()) {
+			log.RatedInfo(20, "collection out of maintenance window, skip compaction")
+			return nil
+		}
+
 		ct, err := getCompactTime(tsoutil.ComposeTSByTime(time.Now(), 0), coll)
 		if err != nil {
 			log.Warn("get compact time failed, skip to handle compaction")
@@ -439,6 +518,13 @@ func (t *compactionTrigger) handleSignal(signal *compactionSignal) {
 		)
 		return
 	}
+
+	if !signal.isForce && !isCollectionInMaintenanceWindow(coll, time.Now()) {
+		log.RatedInfo(20, "collection out of maintenance window, skip compaction",
+			zap.Int64("collectionID", collectionID),
+		)
+		return
+	}
 	ts := tsoutil.ComposeTSByTime(time.Now(), 0)
 	ct, err := getCompactTime(ts, coll)
 	if err != nil {
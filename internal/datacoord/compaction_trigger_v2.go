@@ -39,6 +39,7 @@ const (
 	TriggerTypeSegmentSizeViewChange
 	TriggerTypeClustering
 	TriggerTypeSingle
+	TriggerTypeFanOut
 )
 
 type TriggerManager interface {
@@ -72,11 +73,20 @@ type CompactionTriggerManager struct {
 	l0Policy         *l0CompactionPolicy
 	clusteringPolicy *clusteringCompactionPolicy
 	singlePolicy     *singleCompactionPolicy
+	fanOutPolicy     *fanOutCompactionPolicy
+
+	// the channels requested to compact the L0 segments, see RequestL0Compaction
+	l0Requests chan l0CompactionRequest
 
 	closeSig chan struct{}
 	closeWg  sync.WaitGroup
 }
 
+type l0CompactionRequest struct {
+	collectionID int64
+	channel      string
+}
+
 func NewCompactionTriggerManager(alloc allocator.Allocator, handler Handler, compactionHandler compactionPlanContext, meta *meta) *CompactionTriggerManager {
 	m := &CompactionTriggerManager{
 		allocator:         alloc,
@@ -85,12 +95,14 @@ func NewCompactionTriggerManager(alloc allocator.Allocator, handler Handler, com
 		view: &FullViews{
 			collections: make(map[int64][]*SegmentView),
 		},
-		meta:     meta,
-		closeSig: make(chan struct{}),
+		meta:       meta,
+		l0Requests: make(chan l0CompactionRequest, 1024),
+		closeSig:   make(chan struct{}),
 	}
 	m.l0Policy = newL0CompactionPolicy(meta)
 	m.clusteringPolicy = newClusteringCompactionPolicy(meta, m.allocator, m.handler)
 	m.singlePolicy = newSingleCompactionPolicy(meta, m.allocator, m.handler)
+	m.fanOutPolicy = newFanOutCompactionPolicy(meta, m.allocator, m.handler)
 	return m
 }
 
@@ -177,10 +189,40 @@ func (m *CompactionTriggerManager) startLoop() {
 					m.notify(ctx, triggerType, views)
 				}
 			}
+			m.triggerFanOut(ctx)
+		case req := <-m.l0Requests:
+			if !m.l0Policy.Enable() || m.compactionHandler.isFull() {
+				continue
+			}
+			m.notify(context.Background(), TriggerTypeLevelZeroViewIDLE, m.l0Policy.channelViews(req.collectionID, req.channel))
 		}
 	}
 }
 
+// RequestL0Compaction requests compacting the L0 segments of the channel regardless of their size,
+// so the deletes are applied into the deltalogs of the sealed segments.
+// The request is dropped if too many are pending, it's expected to be requested again.
+func (m *CompactionTriggerManager) RequestL0Compaction(collectionID int64, channel string) {
+	select {
+	case m.l0Requests <- l0CompactionRequest{collectionID: collectionID, channel: channel}:
+	default:
+	}
+}
+
+func (m *CompactionTriggerManager) triggerFanOut(ctx context.Context) {
+	if !m.fanOutPolicy.Enable() || m.compactionHandler.isFull() {
+		return
+	}
+	events, err := m.fanOutPolicy.Trigger()
+	if err != nil {
+		log.Warn("Fail to trigger fan out policy", zap.Error(err))
+		return
+	}
+	for triggerType, views := range events {
+		m.notify(ctx, triggerType, views)
+	}
+}
+
 func (m *CompactionTriggerManager) ManualTrigger(ctx context.Context, collectionID int64, clusteringCompaction bool) (UniqueID, error) {
 	log.Info("receive manual trigger", zap.Int64("collectionID", collectionID))
 	views, triggerID, err := m.clusteringPolicy.triggerOneCollection(context.Background(), collectionID, true)
@@ -241,6 +283,15 @@ func (m *CompactionTriggerManager) notify(ctx context.Context, eventType Compact
 					zap.String("output view", outView.String()))
 				m.SubmitSingleViewToScheduler(ctx, outView)
 			}
+		case TriggerTypeFanOut:
+			log.Debug("Start to trigger a mix compaction by TriggerTypeFanOut")
+			outView, reason := view.Trigger()
+			if outView != nil {
+				log.Info("Success to trigger a fan out MixCompaction output view, try to submit",
+					zap.String("reason", reason),
+					zap.String("output view", outView.String()))
+				m.SubmitSingleViewToScheduler(ctx, outView)
+			}
 		}
 	}
 }
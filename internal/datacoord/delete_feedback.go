@@ -0,0 +1,67 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/util/deletefeedback"
+	"github.com/milvus-io/milvus/internal/util/nodefeedback"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// deleteFeedback keeps the deleted rows of the sealed segments reported by the querynodes, see deletefeedback.
+// The deletes of a segment are counted by the querynodes once applied, earlier than they are in its deltalogs.
+type deleteFeedback struct {
+	receiver *nodefeedback.Receiver[*deletefeedback.SegmentDeletes]
+}
+
+func newDeleteFeedback(metaKV kv.BaseKV, liveNodes func() (typeutil.UniqueSet, error)) *deleteFeedback {
+	return &deleteFeedback{
+		receiver: nodefeedback.NewReceiver(deletefeedback.NewStore(metaKV), liveNodes),
+	}
+}
+
+// refresh reloads the reports of the querynodes and returns the segments beyond the single compaction threshold,
+// with the max deleted rows reported of each.
+func (f *deleteFeedback) refresh(ctx context.Context) ([]*deletefeedback.SegmentDeletes, error) {
+	reports, err := f.receiver.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := paramtable.Get().DataCoordCfg.SingleCompactionRatioThreshold.GetAsFloat()
+	segments := make(map[int64]*deletefeedback.SegmentDeletes)
+	for _, report := range reports {
+		for _, segment := range report.Items {
+			if segment.Ratio() < threshold {
+				continue
+			}
+			if prev, ok := segments[segment.SegmentID]; !ok || prev.DeletedCount < segment.DeletedCount {
+				segments[segment.SegmentID] = segment
+			}
+		}
+	}
+
+	ret := make([]*deletefeedback.SegmentDeletes, 0, len(segments))
+	for _, segment := range segments {
+		ret = append(ret, segment)
+	}
+	return ret, nil
+}
@@ -0,0 +1,128 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/deletefeedback"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestDeleteFeedback(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	metaKV := memkv.NewMemoryKV()
+	live := typeutil.NewUniqueSet(1, 2)
+	feedback := newDeleteFeedback(metaKV, func() (typeutil.UniqueSet, error) { return live, nil })
+
+	save := func(nodeID, seq int64, segments ...*deletefeedback.SegmentDeletes) {
+		require.NoError(t, deletefeedback.NewStore(metaKV).Save(ctx, &deletefeedback.Report{NodeID: nodeID, Seq: seq, Items: segments}))
+	}
+	reportedIDs := func() []int64 {
+		reported, err := feedback.refresh(ctx)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(reported))
+		for _, segment := range reported {
+			ids = append(ids, segment.SegmentID)
+		}
+		return ids
+	}
+
+	save(1, 1, &deletefeedback.SegmentDeletes{SegmentID: 1, RowCount: 100, DeletedCount: 30},
+		&deletefeedback.SegmentDeletes{SegmentID: 2, RowCount: 100, DeletedCount: 10})
+	save(2, 1, &deletefeedback.SegmentDeletes{SegmentID: 2, RowCount: 100, DeletedCount: 25})
+	reported, err := feedback.refresh(ctx)
+	require.NoError(t, err)
+	require.Len(t, reported, 2)
+	for _, segment := range reported {
+		// the max deleted rows reported of the segment is kept
+		if segment.SegmentID == 2 {
+			assert.EqualValues(t, 25, segment.DeletedCount)
+		}
+	}
+
+	// the report of which the seq stops advancing is ignored
+	save(2, 2)
+	assert.ElementsMatch(t, []int64{1}, reportedIDs())
+	assert.ElementsMatch(t, []int64{1}, reportedIDs())
+	assert.Empty(t, reportedIDs())
+	save(1, 2, &deletefeedback.SegmentDeletes{SegmentID: 1, RowCount: 100, DeletedCount: 30})
+	assert.ElementsMatch(t, []int64{1}, reportedIDs())
+
+	// the report of the querynode gone is removed
+	live = typeutil.NewUniqueSet(2)
+	assert.Empty(t, reportedIDs())
+	reports, err := deletefeedback.NewStore(metaKV).LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.EqualValues(t, 2, reports[0].NodeID)
+}
+
+func TestHandleDeleteFeedback(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	metaKV := memkv.NewMemoryKV()
+	feedback := newDeleteFeedback(metaKV, func() (typeutil.UniqueSet, error) { return typeutil.NewUniqueSet(1), nil })
+	require.NoError(t, deletefeedback.NewStore(metaKV).Save(ctx, &deletefeedback.Report{NodeID: 1, Seq: 1, Items: []*deletefeedback.SegmentDeletes{
+		{SegmentID: 1, RowCount: 100, DeletedCount: 30},
+		{SegmentID: 2, RowCount: 100, DeletedCount: 30},
+		{SegmentID: 3, RowCount: 100, DeletedCount: 30},
+	}}))
+
+	newSegment := func(id int64, channel string, deleted int64) *SegmentInfo {
+		return NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            id,
+			CollectionID:  100,
+			InsertChannel: channel,
+			State:         commonpb.SegmentState_Flushed,
+			Level:         datapb.SegmentLevel_L1,
+			NumOfRows:     100,
+			Deltalogs:     []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{{EntriesNum: deleted}}}},
+		})
+	}
+	segments := NewSegmentsInfo()
+	// the deletes of segment 1 and 3 are still in the L0 segments of ch1
+	segments.SetSegment(1, newSegment(1, "ch1", 0))
+	segments.SetSegment(2, newSegment(2, "ch2", 30))
+	segments.SetSegment(3, newSegment(3, "ch1", 10))
+
+	var l0Requested []string
+	trigger := &compactionTrigger{
+		meta:           &meta{segments: segments},
+		allocator:      newMockAllocator(t),
+		signals:        make(chan *compactionSignal, 10),
+		deleteFeedback: feedback,
+		requestL0Compaction: func(collectionID int64, channel string) {
+			l0Requested = append(l0Requested, channel)
+		},
+	}
+	trigger.handleDeleteFeedback(ctx)
+
+	assert.Equal(t, []string{"ch1"}, l0Requested)
+	require.Len(t, trigger.signals, 1)
+	signal := <-trigger.signals
+	assert.EqualValues(t, 2, signal.segmentID)
+}
@@ -0,0 +1,64 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/util/fanoutfeedback"
+	"github.com/milvus-io/milvus/internal/util/nodefeedback"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// fanOutFeedback keeps the search fan out of the shards reported by the querynodes, see fanoutfeedback.
+type fanOutFeedback struct {
+	receiver *nodefeedback.Receiver[*fanoutfeedback.ShardFanOut]
+}
+
+func newFanOutFeedback(metaKV kv.BaseKV, liveNodes func() (typeutil.UniqueSet, error)) *fanOutFeedback {
+	return &fanOutFeedback{
+		receiver: nodefeedback.NewReceiver(fanoutfeedback.NewStore(metaKV), liveNodes),
+	}
+}
+
+// refresh reloads the reports of the querynodes and returns the fan out of each shard by channel,
+// the searches of the replicas of a shard are summed up.
+func (f *fanOutFeedback) refresh(ctx context.Context) (map[string]*fanoutfeedback.ShardFanOut, error) {
+	reports, err := f.receiver.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make(map[string]*fanoutfeedback.ShardFanOut)
+	for _, report := range reports {
+		for _, shard := range report.Items {
+			if prev, ok := shards[shard.Channel]; ok {
+				prev.Searches += shard.Searches
+				prev.Segments += shard.Segments
+				continue
+			}
+			shards[shard.Channel] = &fanoutfeedback.ShardFanOut{
+				CollectionID: shard.CollectionID,
+				Channel:      shard.Channel,
+				Searches:     shard.Searches,
+				Segments:     shard.Segments,
+			}
+		}
+	}
+	return shards, nil
+}
@@ -0,0 +1,71 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/util/fanoutfeedback"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestFanOutFeedback(t *testing.T) {
+	ctx := context.Background()
+	metaKV := memkv.NewMemoryKV()
+	live := typeutil.NewUniqueSet(1, 2)
+	feedback := newFanOutFeedback(metaKV, func() (typeutil.UniqueSet, error) { return live, nil })
+
+	save := func(nodeID, seq int64, shards ...*fanoutfeedback.ShardFanOut) {
+		require.NoError(t, fanoutfeedback.NewStore(metaKV).Save(ctx, &fanoutfeedback.Report{NodeID: nodeID, Seq: seq, Items: shards}))
+	}
+	refresh := func() map[string]*fanoutfeedback.ShardFanOut {
+		shards, err := feedback.refresh(ctx)
+		require.NoError(t, err)
+		return shards
+	}
+
+	// the replicas of ch1 are summed up
+	save(1, 1, &fanoutfeedback.ShardFanOut{CollectionID: 1, Channel: "ch1", Searches: 2, Segments: 20},
+		&fanoutfeedback.ShardFanOut{CollectionID: 1, Channel: "ch2", Searches: 1, Segments: 2})
+	save(2, 1, &fanoutfeedback.ShardFanOut{CollectionID: 1, Channel: "ch1", Searches: 2, Segments: 40})
+	shards := refresh()
+	require.Len(t, shards, 2)
+	assert.EqualValues(t, 4, shards["ch1"].Searches)
+	assert.InDelta(t, 15, shards["ch1"].Average(), 1e-9)
+	assert.InDelta(t, 2, shards["ch2"].Average(), 1e-9)
+
+	// the report of which the seq stops advancing is ignored
+	save(1, 2, &fanoutfeedback.ShardFanOut{CollectionID: 1, Channel: "ch1", Searches: 1, Segments: 10})
+	refresh()
+	refresh()
+	shards = refresh()
+	require.Len(t, shards, 1)
+	assert.EqualValues(t, 1, shards["ch1"].Searches)
+
+	// the report of the querynode gone is removed
+	live = typeutil.NewUniqueSet(2)
+	assert.Empty(t, refresh())
+	reports, err := fanoutfeedback.NewStore(metaKV).LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.EqualValues(t, 2, reports[0].NodeID)
+}
@@ -73,6 +73,10 @@ type garbageCollector struct {
 	wg         sync.WaitGroup
 	cmdCh      chan gcCmd
 	pauseUntil atomic.Time
+
+	// the dropped collections whose log prefixes are removed and to be verified by the next gc,
+	// accessed by the meta gc only
+	droppingCollections typeutil.UniqueSet
 }
 type gcCmd struct {
 	cmdType  datapb.GcCommand
@@ -97,6 +101,8 @@ func newGarbageCollector(meta *meta, handler Handler, opt GcOption) *garbageColl
 		handler: handler,
 		option:  opt,
 		cmdCh:   make(chan gcCmd),
+
+		droppingCollections: make(typeutil.UniqueSet),
 	}
 }
 
@@ -380,6 +386,12 @@ func (gc *garbageCollector) checkDroppedSegmentGC(segment *SegmentInfo,
 	if !gc.isExpire(segment.GetDroppedAt()) {
 		return false
 	}
+	// the binlogs of the segment are referenced by backups, keep them until the backups are dropped.
+	if gc.meta.backupMeta.IsSegmentReferenced(segment.GetID()) {
+		log.WithRateGroup("GC_FAIL_REFERENCED_BY_BACKUP", 1, 60).
+			RatedInfo(60, "skipping GC when segment is referenced by backup")
+		return false
+	}
 	isCompacted := childSegment != nil || segment.GetCompacted()
 	if isCompacted {
 		// For compact A, B -> C, don't GC A or B if C is not indexed,
@@ -452,12 +464,17 @@ func (gc *garbageCollector) recycleDroppedSegments(ctx context.Context) {
 		channelCPs[channel] = pos.GetTimestamp()
 	}
 
+	fastDropped := gc.recycleDroppedCollections(ctx, all, drops)
+
 	log.Info("start to GC segments", zap.Int("drop_num", len(drops)))
 	for segmentID, segment := range drops {
 		if ctx.Err() != nil {
 			// process canceled, stop.
 			return
 		}
+		if fastDropped.Contain(segment.GetCollectionID()) {
+			continue
+		}
 
 		log := log.With(zap.Int64("segmentID", segmentID))
 		segInsertChannel := segment.GetInsertChannel()
@@ -488,6 +505,113 @@ func (gc *garbageCollector) recycleDroppedSegments(ctx context.Context) {
 	}
 }
 
+// recycleDroppedCollections removes the logs of the dropped collections by their object key prefixes,
+// instead of listing and removing the logs of each segment. The prefixes are removed at a gc,
+// and the segment meta is dropped at the next gc if nothing is left under the prefixes.
+// Returns the collections recycled this way, whose segments are skipped by the segment gc.
+func (gc *garbageCollector) recycleDroppedCollections(ctx context.Context, all []*SegmentInfo, drops map[int64]*SegmentInfo) typeutil.UniqueSet {
+	recycled := make(typeutil.UniqueSet)
+	if !Params.DataCoordCfg.GCFastDropCollection.GetAsBool() {
+		return recycled
+	}
+
+	collections := make(map[int64][]*SegmentInfo)
+	for _, segment := range drops {
+		collections[segment.GetCollectionID()] = append(collections[segment.GetCollectionID()], segment)
+	}
+	for _, segment := range all {
+		if segment.GetState() != commonpb.SegmentState_Dropped {
+			delete(collections, segment.GetCollectionID())
+		}
+	}
+
+	for collectionID, segments := range collections {
+		if ctx.Err() != nil {
+			return recycled
+		}
+		if !gc.checkDroppedCollectionGC(ctx, collectionID, segments) {
+			continue
+		}
+		recycled.Insert(collectionID)
+		log := log.With(zap.Int64("collectionID", collectionID), zap.Int("segments", len(segments)))
+		if !gc.droppingCollections.Contain(collectionID) {
+			if err := gc.removeCollectionLogs(ctx, collectionID, segments); err != nil {
+				log.Warn("GC collection remove logs failed", zap.Error(err))
+				continue
+			}
+			gc.droppingCollections.Insert(collectionID)
+			log.Info("GC collection logs removed, to be verified by the next gc")
+			continue
+		}
+
+		left, err := gc.hasCollectionLogs(ctx, collectionID)
+		if err != nil || left {
+			// remove the prefixes again at the next gc
+			gc.droppingCollections.Remove(collectionID)
+			log.Warn("GC collection logs are left after removal", zap.Error(err))
+			continue
+		}
+		dropped := 0
+		for _, segment := range segments {
+			if err := gc.meta.DropSegment(ctx, segment.GetID()); err != nil {
+				log.Warn("GC collection meta failed to drop segment", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+				continue
+			}
+			dropped++
+		}
+		if dropped == len(segments) {
+			gc.droppingCollections.Remove(collectionID)
+		}
+		log.Info("GC collection meta drop segments done", zap.Int("dropped", dropped))
+	}
+	return recycled
+}
+
+// checkDroppedCollectionGC returns true if the logs of the collection, whose segments are all dropped, can be removed by prefixes.
+func (gc *garbageCollector) checkDroppedCollectionGC(ctx context.Context, collectionID int64, segments []*SegmentInfo) bool {
+	for _, segment := range segments {
+		if !gc.isExpire(segment.GetDroppedAt()) ||
+			gc.meta.backupMeta.IsSegmentReferenced(segment.GetID()) ||
+			gc.meta.catalog.ChannelExists(ctx, segment.GetInsertChannel()) {
+			return false
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	has, err := gc.option.broker.HasCollection(ctx, collectionID)
+	return err == nil && !has
+}
+
+func (gc *garbageCollector) removeCollectionLogs(ctx context.Context, collectionID int64, segments []*SegmentInfo) error {
+	for _, prefix := range metautil.BuildCollectionLogPrefixes(gc.option.cli.RootPath(), collectionID) {
+		if err := gc.option.cli.RemoveWithPrefix(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	// text indexes are not placed under the collection prefixes
+	textLogs := make(map[string]struct{})
+	for _, segment := range segments {
+		for key := range getTextLogs(segment) {
+			textLogs[key] = struct{}{}
+		}
+	}
+	return gc.removeObjectFiles(ctx, textLogs)
+}
+
+func (gc *garbageCollector) hasCollectionLogs(ctx context.Context, collectionID int64) (bool, error) {
+	left := false
+	for _, prefix := range metautil.BuildCollectionLogPrefixes(gc.option.cli.RootPath(), collectionID) {
+		err := gc.option.cli.WalkWithPrefix(ctx, prefix, true, func(*storage.ChunkObjectInfo) bool {
+			left = true
+			return false
+		})
+		if err != nil || left {
+			return left, err
+		}
+	}
+	return false, nil
+}
+
 func (gc *garbageCollector) recycleChannelCPMeta(ctx context.Context) {
 	channelCPs, err := gc.meta.catalog.ListChannelCheckpoint(ctx)
 	if err != nil {
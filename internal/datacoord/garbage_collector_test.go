@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -53,7 +54,9 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/lock"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 func Test_garbageCollector_basic(t *testing.T) {
@@ -1047,6 +1050,7 @@ func TestGarbageCollector_clearETCD(t *testing.T) {
 		catalog:    catalog,
 		channelCPs: channelCPs,
 		segments:   NewSegmentsInfo(),
+		backupMeta: &backupMeta{},
 		indexMeta: &indexMeta{
 			catalog: catalog,
 			segmentIndexes: map[UniqueID]map[UniqueID]*model.SegmentIndex{
@@ -1535,6 +1539,82 @@ func TestGarbageCollector_recycleChannelMeta(t *testing.T) {
 	})
 }
 
+func TestGarbageCollector_recycleDroppedCollections(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.GCFastDropCollection.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.GCFastDropCollection.Key)
+
+	catalog := catalogmocks.NewDataCoordCatalog(t)
+	catalog.EXPECT().ChannelExists(mock.Anything, mock.Anything).Return(false)
+	catalog.EXPECT().DropSegment(mock.Anything, mock.Anything).Return(nil)
+	m := &meta{
+		catalog:    catalog,
+		segments:   NewSegmentsInfo(),
+		backupMeta: &backupMeta{},
+	}
+	// collection 1 is dropped, collection 2 still has a flushed segment
+	for _, info := range []*datapb.SegmentInfo{
+		{ID: 1, CollectionID: 1, InsertChannel: "ch1", State: commonpb.SegmentState_Dropped},
+		{ID: 2, CollectionID: 1, InsertChannel: "ch1", State: commonpb.SegmentState_Dropped},
+		{ID: 3, CollectionID: 2, InsertChannel: "ch2", State: commonpb.SegmentState_Dropped},
+		{ID: 4, CollectionID: 2, InsertChannel: "ch2", State: commonpb.SegmentState_Flushed},
+	} {
+		m.segments.SetSegment(info.GetID(), NewSegmentInfo(info))
+	}
+	selectDrops := func() ([]*SegmentInfo, map[int64]*SegmentInfo) {
+		all := m.SelectSegments(context.TODO())
+		drops := make(map[int64]*SegmentInfo)
+		for _, segment := range all {
+			if segment.GetState() == commonpb.SegmentState_Dropped {
+				drops[segment.GetID()] = segment
+			}
+		}
+		return all, drops
+	}
+
+	broker := broker2.NewMockBroker(t)
+	broker.EXPECT().HasCollection(mock.Anything, int64(1)).Return(false, nil)
+	cm := mocks.NewChunkManager(t)
+	cm.EXPECT().RootPath().Return("files")
+	removed := typeutil.NewSet[string]()
+	cm.EXPECT().RemoveWithPrefix(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, prefix string) error {
+		removed.Insert(prefix)
+		return nil
+	})
+	gc := newGarbageCollector(m, newMockHandlerWithMeta(m), GcOption{cli: cm, broker: broker})
+
+	// the prefixes are removed at the first gc
+	all, drops := selectDrops()
+	recycled := gc.recycleDroppedCollections(context.TODO(), all, drops)
+	assert.ElementsMatch(t, []int64{1}, recycled.Collect())
+	assert.ElementsMatch(t, metautil.BuildCollectionLogPrefixes("files", 1), removed.Collect())
+	assert.Len(t, m.SelectSegments(context.TODO()), 4)
+
+	// the logs left are removed again
+	walk := cm.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, true, mock.Anything).RunAndReturn(
+		func(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+			walkFunc(&storage.ChunkObjectInfo{FilePath: prefix + "100/1/1/1"})
+			return nil
+		})
+	all, drops = selectDrops()
+	gc.recycleDroppedCollections(context.TODO(), all, drops)
+	assert.False(t, gc.droppingCollections.Contain(1))
+	assert.Len(t, m.SelectSegments(context.TODO()), 4)
+	all, drops = selectDrops()
+	gc.recycleDroppedCollections(context.TODO(), all, drops)
+	assert.True(t, gc.droppingCollections.Contain(1))
+
+	// the segment meta is dropped after verified
+	walk.Unset()
+	cm.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, true, mock.Anything).Return(nil)
+	all, drops = selectDrops()
+	gc.recycleDroppedCollections(context.TODO(), all, drops)
+	assert.False(t, gc.droppingCollections.Contain(1))
+	assert.ElementsMatch(t, []int64{3, 4}, lo.Map(m.SelectSegments(context.TODO()), func(segment *SegmentInfo, _ int) int64 {
+		return segment.GetID()
+	}))
+}
+
 func TestGarbageCollector_removeObjectPool(t *testing.T) {
 	paramtable.Init()
 	cm := mocks.NewChunkManager(t)
@@ -221,7 +221,7 @@ func (c *importChecker) checkPreImportingJob(job ImportJob) {
 		return
 	}
 
-	requestSize, err := CheckDiskQuota(job, c.meta, c.imeta)
+	requestSize, err := CheckDiskQuota(context.TODO(), job, c.meta, c.imeta, c.broker)
 	if err != nil {
 		log.Warn("import failed, disk quota exceeded", zap.Error(err))
 		err = c.imeta.UpdateJob(context.TODO(), job.GetJobID(), UpdateJobState(internalpb.ImportJobState_Failed), UpdateJobReason(err.Error()))
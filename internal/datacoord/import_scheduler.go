@@ -329,11 +329,15 @@ func (s *importScheduler) processInProgressImport(task ImportTask) {
 			}
 		}
 		completeTime := time.Now().Format("2006-01-02T15:04:05Z07:00")
-		err = s.imeta.UpdateTask(context.TODO(), task.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_Completed), UpdateCompleteTime(completeTime))
+		err = s.imeta.UpdateTask(context.TODO(), task.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_Completed),
+			UpdateCompleteTime(completeTime), UpdateDuplicatedRows(resp.GetDuplicatedRows()))
 		if err != nil {
 			log.Warn("update import task failed", WrapTaskLog(task, zap.Error(err))...)
 			return
 		}
+		if len(resp.GetDuplicatedRows()) > 0 {
+			log.Info("import skipped duplicated rows", WrapTaskLog(task, zap.Any("duplicatedRows", resp.GetDuplicatedRows()))...)
+		}
 		importDuration := task.GetTR().RecordSpan()
 		metrics.ImportTaskLatency.WithLabelValues(metrics.ImportStageImport).Observe(float64(importDuration.Milliseconds()))
 		log.Info("import done", WrapTaskLog(task, zap.Duration("taskTimeCost/import", importDuration))...)
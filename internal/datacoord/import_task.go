@@ -103,6 +103,14 @@ func UpdateCompleteTime(completeTime string) UpdateAction {
 	}
 }
 
+func UpdateDuplicatedRows(duplicatedRows map[string]int64) UpdateAction {
+	return func(t ImportTask) {
+		if task, ok := t.(*importTask); ok {
+			task.ImportTaskV2.DuplicatedRows = duplicatedRows
+		}
+	}
+}
+
 func UpdateNodeID(nodeID int64) UpdateAction {
 	return func(t ImportTask) {
 		switch t.GetType() {
@@ -22,24 +22,31 @@ import (
 	"math"
 	"path"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 func WrapTaskLog(task ImportTask, fields ...zap.Field) []zap.Field {
@@ -283,6 +290,22 @@ func AssembleImportRequest(task ImportTask, job ImportJob, meta *meta, alloc all
 	importFiles := lo.Map(task.GetFileStats(), func(fileStat *datapb.ImportFileStats, _ int) *internalpb.ImportFile {
 		return fileStat.GetImportFile()
 	})
+
+	var dedupSegments, dedupL0Segments []*datapb.ImportDedupSegment
+	mode, err := importutilv2.GetDedupMode(job.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	if mode == importutilv2.DedupSkip {
+		pkField, err := typeutil.GetPrimaryFieldSchema(job.GetSchema())
+		if err != nil {
+			return nil, err
+		}
+		dedupSegments, dedupL0Segments, err = AssembleDedupSegments(task.GetCollectionID(), pkField.GetFieldID(), meta)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &datapb.ImportRequest{
 		JobID:           task.GetJobID(),
 		TaskID:          task.GetTaskID(),
@@ -295,9 +318,83 @@ func AssembleImportRequest(task ImportTask, job ImportJob, meta *meta, alloc all
 		Ts:              ts,
 		IDRange:         &datapb.IDRange{Begin: idBegin, End: idEnd},
 		RequestSegments: requestSegments,
+		DedupSegments:   dedupSegments,
+		DedupL0Segments: dedupL0Segments,
 	}, nil
 }
 
+// AssembleDedupSegments returns the pk statslogs, the pk and timestamp binlogs and the deltalogs of the growing
+// and flushed segments in the collection, against which the imported rows are de-duplicated, and the deltalogs of
+// the L0 segments, which apply to the segments of the same channel.
+// Note that the rows and the deletes which are not synced yet are not visible to the import.
+func AssembleDedupSegments(collectionID int64, pkFieldID int64, meta *meta) ([]*datapb.ImportDedupSegment, []*datapb.ImportDedupSegment, error) {
+	segments := meta.SelectSegments(context.TODO(), WithCollection(collectionID), SegmentFilterFunc(func(info *SegmentInfo) bool {
+		return isSegmentHealthy(info) &&
+			(isFlushState(info.GetState()) || info.GetState() == commonpb.SegmentState_Growing || info.GetState() == commonpb.SegmentState_Sealed) &&
+			!info.GetIsImporting()
+	}))
+
+	// the binlogs in meta only keep the log ids, which are decompressed into the paths on clones
+	decompress := func(segment *SegmentInfo, binlogType storage.BinlogType, fieldBinlogs []*datapb.FieldBinlog) ([]*datapb.FieldBinlog, error) {
+		cloned := lo.Map(fieldBinlogs, func(fieldBinlog *datapb.FieldBinlog, _ int) *datapb.FieldBinlog {
+			return proto.Clone(fieldBinlog).(*datapb.FieldBinlog)
+		})
+		err := binlog.DecompressBinLog(binlogType, segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID(), cloned)
+		return cloned, err
+	}
+	ofField := func(fieldID int64) func(fieldBinlog *datapb.FieldBinlog, _ int) bool {
+		return func(fieldBinlog *datapb.FieldBinlog, _ int) bool {
+			return fieldBinlog.GetFieldID() == fieldID
+		}
+	}
+
+	dedupSegments := make([]*datapb.ImportDedupSegment, 0)
+	l0Segments := make([]*datapb.ImportDedupSegment, 0)
+	for _, segment := range segments {
+		deltalogs, err := decompress(segment, storage.DeleteBinlog, segment.GetDeltalogs())
+		if err != nil {
+			return nil, nil, err
+		}
+		if segment.GetLevel() == datapb.SegmentLevel_L0 {
+			if len(deltalogs) > 0 {
+				l0Segments = append(l0Segments, &datapb.ImportDedupSegment{
+					SegmentID:   segment.GetID(),
+					PartitionID: segment.GetPartitionID(),
+					Vchannel:    segment.GetInsertChannel(),
+					Deltalogs:   deltalogs,
+				})
+			}
+			continue
+		}
+		pkBinlogs, err := decompress(segment, storage.InsertBinlog, lo.Filter(segment.GetBinlogs(), ofField(pkFieldID)))
+		if err != nil {
+			return nil, nil, err
+		}
+		// growing segments without any synced data have nothing to check against
+		if len(pkBinlogs) == 0 {
+			continue
+		}
+		tsBinlogs, err := decompress(segment, storage.InsertBinlog, lo.Filter(segment.GetBinlogs(), ofField(common.TimeStampField)))
+		if err != nil {
+			return nil, nil, err
+		}
+		statslogs, err := decompress(segment, storage.StatsBinlog, segment.GetStatslogs())
+		if err != nil {
+			return nil, nil, err
+		}
+		dedupSegments = append(dedupSegments, &datapb.ImportDedupSegment{
+			SegmentID:   segment.GetID(),
+			PartitionID: segment.GetPartitionID(),
+			Vchannel:    segment.GetInsertChannel(),
+			Statslogs:   statslogs,
+			PkBinlogs:   pkBinlogs,
+			TsBinlogs:   tsBinlogs,
+			Deltalogs:   deltalogs,
+		})
+	}
+	return dedupSegments, l0Segments, nil
+}
+
 func RegroupImportFiles(job ImportJob, files []*datapb.ImportFileStats, allDiskIndex bool) [][]*datapb.ImportFileStats {
 	if len(files) == 0 {
 		return nil
@@ -347,7 +444,7 @@ func RegroupImportFiles(job ImportJob, files []*datapb.ImportFileStats, allDiskI
 	return fileGroups
 }
 
-func CheckDiskQuota(job ImportJob, meta *meta, imeta ImportMeta) (int64, error) {
+func CheckDiskQuota(ctx context.Context, job ImportJob, meta *meta, imeta ImportMeta, broker broker.Broker) (int64, error) {
 	if !Params.QuotaConfig.DiskProtectionEnabled.GetAsBool() {
 		return 0, nil
 	}
@@ -360,7 +457,7 @@ func CheckDiskQuota(job ImportJob, meta *meta, imeta ImportMeta) (int64, error)
 		requestedTotal       int64
 		requestedCollections = make(map[int64]int64)
 	)
-	for _, j := range imeta.GetJobBy(context.TODO()) {
+	for _, j := range imeta.GetJobBy(ctx) {
 		requested := j.GetRequestedDiskSize()
 		requestedTotal += requested
 		requestedCollections[j.GetCollectionID()] += requested
@@ -369,8 +466,15 @@ func CheckDiskQuota(job ImportJob, meta *meta, imeta ImportMeta) (int64, error)
 	err := merr.WrapErrServiceQuotaExceeded("disk quota exceeded, please allocate more resources")
 	quotaInfo := meta.GetQuotaInfo()
 	totalUsage, collectionsUsage := quotaInfo.TotalBinlogSize, quotaInfo.CollectionBinlogSize
+	if Params.QuotaConfig.DiskQuotaIncludeIndexSize.GetAsBool() {
+		totalUsage += quotaInfo.TotalIndexSize
+		collectionsUsage = make(map[int64]int64, len(quotaInfo.CollectionBinlogSize))
+		for collectionID, size := range quotaInfo.CollectionBinlogSize {
+			collectionsUsage[collectionID] = size + quotaInfo.CollectionIndexSize[collectionID]
+		}
+	}
 
-	tasks := imeta.GetTaskBy(context.TODO(), WithJob(job.GetJobID()), WithType(PreImportTaskType))
+	tasks := imeta.GetTaskBy(ctx, WithJob(job.GetJobID()), WithType(PreImportTaskType))
 	files := make([]*datapb.ImportFileStats, 0)
 	for _, task := range tasks {
 		files = append(files, task.GetFileStats()...)
@@ -389,8 +493,10 @@ func CheckDiskQuota(job ImportJob, meta *meta, imeta ImportMeta) (int64, error)
 			zap.Float64("totalDiskQuota", totalDiskQuota))
 		return 0, err
 	}
-	collectionDiskQuota := Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat()
+
 	colID := job.GetCollectionID()
+	collection := meta.GetCollection(colID)
+	collectionDiskQuota := getCollectionDiskQuota(collection)
 	if float64(collectionsUsage[colID]+requestedCollections[colID]+requestSize) > collectionDiskQuota {
 		log.Warn("collection disk quota exceeded", zap.Int64("jobID", job.GetJobID()),
 			zap.Bool("enabled", Params.QuotaConfig.DiskProtectionEnabled.GetAsBool()),
@@ -400,9 +506,67 @@ func CheckDiskQuota(job ImportJob, meta *meta, imeta ImportMeta) (int64, error)
 			zap.Float64("collectionDiskQuota", collectionDiskQuota))
 		return 0, err
 	}
+
+	if collection == nil {
+		return requestSize, nil
+	}
+	// the usage of the database includes the sizes requested by the imports in flight,
+	// even for the collections without any data yet
+	var dbUsage, dbRequested int64
+	for collectionID := range typeutil.NewSet(append(lo.Keys(collectionsUsage), lo.Keys(requestedCollections)...)...) {
+		if coll := meta.GetCollection(collectionID); coll != nil && coll.DatabaseID == collection.DatabaseID {
+			dbUsage += collectionsUsage[collectionID]
+			dbRequested += requestedCollections[collectionID]
+		}
+	}
+	dbDiskQuota := getDatabaseDiskQuota(ctx, broker, collection.DatabaseName)
+	if float64(dbUsage+dbRequested+requestSize) > dbDiskQuota {
+		log.Warn("database disk quota exceeded", zap.Int64("jobID", job.GetJobID()),
+			zap.Int64("dbID", collection.DatabaseID),
+			zap.Int64("dbUsage", dbUsage),
+			zap.Int64("dbRequested", dbRequested),
+			zap.Int64("requestSize", requestSize),
+			zap.Float64("dbDiskQuota", dbDiskQuota))
+		return 0, err
+	}
 	return requestSize, nil
 }
 
+// getDatabaseDiskQuota returns the disk quota of the database in bytes,
+// the database property takes precedence over the config, as QuotaCenter does.
+func getDatabaseDiskQuota(ctx context.Context, broker broker.Broker, dbName string) float64 {
+	quota := Params.QuotaConfig.DiskQuotaPerDB.GetAsFloat()
+	resp, err := broker.DescribeDatabase(ctx, dbName)
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to describe database, use the disk quota config", zap.String("dbName", dbName), zap.Error(err))
+		return quota
+	}
+	for _, kv := range resp.GetProperties() {
+		if kv.GetKey() != common.DatabaseDiskQuotaKey {
+			continue
+		}
+		if mb, err := strconv.ParseFloat(kv.GetValue(), 64); err == nil && mb >= 0 {
+			return mb * 1024 * 1024
+		}
+	}
+	return quota
+}
+
+// getCollectionDiskQuota returns the disk quota of the collection in bytes,
+// the collection property takes precedence over the config.
+func getCollectionDiskQuota(collection *collectionInfo) float64 {
+	quota := Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat()
+	if collection == nil {
+		return quota
+	}
+	if v, ok := collection.Properties[common.CollectionDiskQuotaKey]; ok {
+		if mb, err := strconv.ParseFloat(v, 64); err == nil && mb >= 0 {
+			return mb * 1024 * 1024
+		}
+	}
+	return quota
+}
+
 func getPendingProgress(jobID int64, imeta ImportMeta) float32 {
 	tasks := imeta.GetTaskBy(context.TODO(), WithJob(jobID), WithType(PreImportTaskType))
 	preImportingFiles := lo.SumBy(tasks, func(task ImportTask) int {
@@ -542,8 +706,9 @@ func GetTaskProgresses(jobID int64, imeta ImportMeta, meta *meta) []*internalpb.
 	progresses := make([]*internalpb.ImportTaskProgress, 0)
 	tasks := imeta.GetTaskBy(context.TODO(), WithJob(jobID), WithType(ImportTaskType))
 	for _, task := range tasks {
+		duplicatedRows := task.(*importTask).GetDuplicatedRows()
 		totalRows := lo.SumBy(task.GetFileStats(), func(file *datapb.ImportFileStats) int64 {
-			return file.GetTotalRows()
+			return file.GetTotalRows() - duplicatedRows[strings.Join(file.GetImportFile().GetPaths(), ",")]
 		})
 		importedRows := meta.GetSegmentsTotalCurrentRows(task.(*importTask).GetSegmentIDs())
 		progress := int64(100)
@@ -551,15 +716,17 @@ func GetTaskProgresses(jobID int64, imeta ImportMeta, meta *meta) []*internalpb.
 			progress = int64(float32(importedRows) / float32(totalRows) * 100)
 		}
 		for _, fileStat := range task.GetFileStats() {
+			duplicated := duplicatedRows[strings.Join(fileStat.GetImportFile().GetPaths(), ",")]
 			progresses = append(progresses, &internalpb.ImportTaskProgress{
-				FileName:     fmt.Sprintf("%v", fileStat.GetImportFile().GetPaths()),
-				FileSize:     fileStat.GetFileSize(),
-				Reason:       task.GetReason(),
-				Progress:     progress,
-				CompleteTime: task.(*importTask).GetCompleteTime(),
-				State:        task.GetState().String(),
-				ImportedRows: progress * fileStat.GetTotalRows() / 100,
-				TotalRows:    fileStat.GetTotalRows(),
+				FileName:       fmt.Sprintf("%v", fileStat.GetImportFile().GetPaths()),
+				FileSize:       fileStat.GetFileSize(),
+				Reason:         task.GetReason(),
+				Progress:       progress,
+				CompleteTime:   task.(*importTask).GetCompleteTime(),
+				State:          task.GetState().String(),
+				ImportedRows:   progress * (fileStat.GetTotalRows() - duplicated) / 100,
+				TotalRows:      fileStat.GetTotalRows(),
+				DuplicatedRows: duplicated,
 			})
 		}
 	}
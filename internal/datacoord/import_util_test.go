@@ -32,14 +32,18 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/metastore/model"
 	mocks2 "github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -115,6 +119,7 @@ func TestImportUtil_NewImportTasks(t *testing.T) {
 	catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
 
 	meta, err := newMeta(context.TODO(), catalog, nil)
 	assert.NoError(t, err)
@@ -167,6 +172,7 @@ func TestImportUtil_AssembleRequest(t *testing.T) {
 	catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
 
 	alloc := allocator.NewMockAllocator(t)
 	alloc.EXPECT().AllocN(mock.Anything).RunAndReturn(func(n int64) (int64, int64, error) {
@@ -251,6 +257,9 @@ func TestImportUtil_CheckDiskQuota(t *testing.T) {
 	catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().CreateSegmentIndex(mock.Anything, mock.Anything).Return(nil)
+	catalog.EXPECT().DropImportJob(mock.Anything, mock.Anything).Return(nil)
 
 	imeta, err := NewImportMeta(context.TODO(), catalog)
 	assert.NoError(t, err)
@@ -258,6 +267,12 @@ func TestImportUtil_CheckDiskQuota(t *testing.T) {
 	meta, err := newMeta(context.TODO(), catalog, nil)
 	assert.NoError(t, err)
 
+	var dbProperties []*commonpb.KeyValuePair
+	b := broker.NewMockBroker(t)
+	b.EXPECT().DescribeDatabase(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
+		return &rootcoordpb.DescribeDatabaseResponse{Status: merr.Success(), Properties: dbProperties}, nil
+	}).Maybe()
+
 	job := &importJob{
 		ImportJob: &datapb.ImportJob{
 			JobID:        0,
@@ -282,7 +297,7 @@ func TestImportUtil_CheckDiskQuota(t *testing.T) {
 
 	Params.Save(Params.QuotaConfig.DiskProtectionEnabled.Key, "false")
 	defer Params.Reset(Params.QuotaConfig.DiskProtectionEnabled.Key)
-	_, err = CheckDiskQuota(job, meta, imeta)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
 	assert.NoError(t, err)
 
 	segment := &SegmentInfo{
@@ -297,7 +312,7 @@ func TestImportUtil_CheckDiskQuota(t *testing.T) {
 		{Key: importutilv2.BackupFlag, Value: "true"},
 		{Key: importutilv2.SkipDQC, Value: "true"},
 	}
-	_, err = CheckDiskQuota(job, meta, imeta)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
 	assert.NoError(t, err)
 
 	job.Options = nil
@@ -305,17 +320,68 @@ func TestImportUtil_CheckDiskQuota(t *testing.T) {
 	Params.Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, "10000")
 	defer Params.Reset(Params.QuotaConfig.DiskQuota.Key)
 	defer Params.Reset(Params.QuotaConfig.DiskQuotaPerCollection.Key)
-	requestSize, err := CheckDiskQuota(job, meta, imeta)
+	requestSize, err := CheckDiskQuota(context.TODO(), job, meta, imeta, b)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3000*1024*1024), requestSize)
 
 	Params.Save(Params.QuotaConfig.DiskQuota.Key, "5000")
-	_, err = CheckDiskQuota(job, meta, imeta)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
 	assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
 
 	Params.Save(Params.QuotaConfig.DiskQuota.Key, "10000")
 	Params.Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, "5000")
-	_, err = CheckDiskQuota(job, meta, imeta)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
+	assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
+
+	// collection property takes precedence over config
+	meta.AddCollection(&collectionInfo{
+		ID:         100,
+		DatabaseID: 1,
+		Properties: map[string]string{common.CollectionDiskQuotaKey: "8000"},
+	})
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
+	assert.NoError(t, err)
+
+	Params.Save(Params.QuotaConfig.DiskQuotaPerDB.Key, "5000")
+	defer Params.Reset(Params.QuotaConfig.DiskQuotaPerDB.Key)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
+	assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
+
+	// database property takes precedence over config
+	dbProperties = []*commonpb.KeyValuePair{{Key: common.DatabaseDiskQuotaKey, Value: "8000"}}
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
+	assert.NoError(t, err)
+
+	// the imports in flight into another collection of the database are counted
+	meta.AddCollection(&collectionInfo{ID: 101, DatabaseID: 1})
+	err = imeta.AddJob(context.TODO(), &importJob{
+		ImportJob: &datapb.ImportJob{
+			JobID:             2,
+			CollectionID:      101,
+			RequestedDiskSize: 3000 * 1024 * 1024,
+		},
+	})
+	assert.NoError(t, err)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
+	assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
+	err = imeta.RemoveJob(context.TODO(), 2)
+	assert.NoError(t, err)
+	dbProperties = nil
+	Params.Reset(Params.QuotaConfig.DiskQuotaPerDB.Key)
+
+	// index files are counted only if enabled
+	err = meta.indexMeta.AddSegmentIndex(context.TODO(), &model.SegmentIndex{
+		SegmentID:    5,
+		CollectionID: 100,
+		BuildID:      1,
+		IndexSize:    3000 * 1024 * 1024,
+	})
+	assert.NoError(t, err)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
+	assert.NoError(t, err)
+	Params.Save(Params.QuotaConfig.DiskQuotaIncludeIndexSize.Key, "true")
+	defer Params.Reset(Params.QuotaConfig.DiskQuotaIncludeIndexSize.Key)
+	_, err = CheckDiskQuota(context.TODO(), job, meta, imeta, b)
 	assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
 }
 
@@ -437,6 +503,7 @@ func TestImportUtil_GetImportProgress(t *testing.T) {
 	catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
 
 	imeta, err := NewImportMeta(context.TODO(), catalog)
 	assert.NoError(t, err)
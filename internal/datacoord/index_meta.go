@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,6 +45,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/indexparams"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
@@ -342,15 +344,66 @@ func (m *indexMeta) CanCreateIndex(req *indexpb.CreateIndexRequest) (UniqueID, e
 			return 0, fmt.Errorf("CreateIndex failed: %s", errMsg)
 		}
 		if req.FieldID == index.FieldID {
-			// creating multiple indexes on same field is not supported
-			errMsg := "CreateIndex failed: creating multiple indexes on same field is not supported"
-			log.Warn(errMsg)
-			return 0, fmt.Errorf(errMsg)
+			// creating multiple indexes on same field is only supported for the vector index bound to resource groups
+			if err := checkIndexOnSameField(index, req); err != nil {
+				log.Warn("CreateIndex failed", zap.String("index", index.IndexName), zap.Error(err))
+				return 0, fmt.Errorf("CreateIndex failed: %w", err)
+			}
 		}
 	}
 	return 0, nil
 }
 
+// checkIndexOnSameField checks whether the requested index can coexist with the index on the same field.
+// Each replica serves only one index of the field, the index bound to its resource group or the default one,
+// so the additional index must be a vector index bound to resource groups, and agree on the metric type.
+func checkIndexOnSameField(index *model.Index, req *indexpb.CreateIndexRequest) error {
+	if len(common.GetIndexResourceGroups(req.GetUserIndexParams()...)) == 0 {
+		return fmt.Errorf("creating multiple indexes on same field is not supported, unless the vector index is bound to resource groups by %s",
+			common.IndexResourceGroupsKey)
+	}
+	return checkIndexesCoexist(req.GetIndexParams(), req.GetUserIndexParams(), index)
+}
+
+// checkIndexesCoexist checks whether the index of the params and the index on the same field can coexist,
+// they must be vector indexes of the same metric type, at most one of them is not bound to resource groups,
+// and no resource group is bound to both of them.
+func checkIndexesCoexist(indexParams, userIndexParams []*commonpb.KeyValuePair, index *model.Index) error {
+	resourceGroups := common.GetIndexResourceGroups(userIndexParams...)
+	existResourceGroups := common.GetIndexResourceGroups(index.UserIndexParams...)
+	if len(resourceGroups) == 0 && len(existResourceGroups) == 0 {
+		return fmt.Errorf("index %s on the same field is not bound to resource groups by %s either, only one default index is allowed",
+			index.IndexName, common.IndexResourceGroupsKey)
+	}
+	if !vecindexmgr.GetVecIndexMgrInstance().IsVecIndex(GetIndexType(indexParams)) {
+		return fmt.Errorf("multiple indexes on same field are only supported for vector indexes")
+	}
+	metricType := funcutil.KeyValuePair2Map(indexParams)[common.MetricTypeKey]
+	if existMetricType := funcutil.KeyValuePair2Map(index.IndexParams)[common.MetricTypeKey]; !strings.EqualFold(metricType, existMetricType) {
+		return fmt.Errorf("metric type %s is different from the metric type %s of index %s on the same field", metricType, existMetricType, index.IndexName)
+	}
+	if overlapped := lo.Intersect(resourceGroups, existResourceGroups); len(overlapped) > 0 {
+		return fmt.Errorf("resource groups %v are already bound to index %s on the same field", overlapped, index.IndexName)
+	}
+	return nil
+}
+
+// CheckAlteredIndex checks whether the altered index can still coexist with the other indexes on the same field.
+func (m *indexMeta) CheckAlteredIndex(index *model.Index) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, other := range m.indexes[index.CollectionID] {
+		if other.IsDeleted || other.FieldID != index.FieldID || other.IndexID == index.IndexID {
+			continue
+		}
+		if err := checkIndexesCoexist(index.IndexParams, index.UserIndexParams, other); err != nil {
+			return merr.WrapErrParameterInvalidMsg("failed to alter index %s, %s", index.IndexName, err.Error())
+		}
+	}
+	return nil
+}
+
 // HasSameReq determine whether there are same indexing tasks.
 func (m *indexMeta) HasSameReq(req *indexpb.CreateIndexRequest) (bool, UniqueID) {
 	m.RLock()
@@ -892,6 +945,22 @@ func (m *indexMeta) SetStoredIndexFileSizeMetric(collections map[UniqueID]*colle
 	return total
 }
 
+// GetSegmentIndexSizes returns the total index files size of each segment.
+func (m *indexMeta) GetSegmentIndexSizes() map[UniqueID]int64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	sizes := make(map[UniqueID]int64)
+	for segmentID, segIndexes := range m.segmentIndexes {
+		for _, segIdx := range segIndexes {
+			if !segIdx.IsDeleted {
+				sizes[segmentID] += int64(segIdx.IndexSize)
+			}
+		}
+	}
+	return sizes
+}
+
 func (m *indexMeta) RemoveSegmentIndex(ctx context.Context, collID, partID, segID, indexID, buildID UniqueID) error {
 	m.Lock()
 	defer m.Unlock()
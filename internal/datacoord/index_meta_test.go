@@ -19,6 +19,7 @@ package datacoord
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/workerpb"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
@@ -354,6 +356,25 @@ func TestMeta_CanCreateIndex(t *testing.T) {
 		tmpIndexID, err := m.CanCreateIndex(req)
 		assert.Error(t, err)
 		assert.Equal(t, int64(0), tmpIndexID)
+
+		// the additional vector index bound to resource groups
+		req.UserIndexParams = append(indexParams, &commonpb.KeyValuePair{Key: common.IndexResourceGroupsKey, Value: "rg1"})
+		tmpIndexID, err = m.CanCreateIndex(req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), tmpIndexID)
+
+		req.IndexParams = []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: "FLAT"}, {Key: common.MetricTypeKey, Value: "IP"}}
+		tmpIndexID, err = m.CanCreateIndex(req)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), tmpIndexID)
+
+		req.IndexParams = indexParams
+		m.indexes[collID][indexID].UserIndexParams = append(userIndexParams, &commonpb.KeyValuePair{Key: common.IndexResourceGroupsKey, Value: "rg1,rg2"})
+		tmpIndexID, err = m.CanCreateIndex(req)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), tmpIndexID)
+		m.indexes[collID][indexID].UserIndexParams = userIndexParams
+		req.UserIndexParams = indexParams
 	})
 
 	t.Run("index has been deleted", func(t *testing.T) {
@@ -364,6 +385,43 @@ func TestMeta_CanCreateIndex(t *testing.T) {
 	})
 }
 
+func TestMeta_CheckAlteredIndex(t *testing.T) {
+	newIndex := func(indexID int64, resourceGroups string) *model.Index {
+		userIndexParams := []*commonpb.KeyValuePair{
+			{Key: common.IndexTypeKey, Value: "HNSW"},
+			{Key: common.MetricTypeKey, Value: "L2"},
+		}
+		if resourceGroups != "" {
+			userIndexParams = append(userIndexParams, &commonpb.KeyValuePair{Key: common.IndexResourceGroupsKey, Value: resourceGroups})
+		}
+		return &model.Index{
+			CollectionID:    1,
+			FieldID:         100,
+			IndexID:         indexID,
+			IndexName:       fmt.Sprintf("idx%d", indexID),
+			IndexParams:     userIndexParams,
+			UserIndexParams: userIndexParams,
+		}
+	}
+
+	m := newSegmentIndexMeta(catalogmocks.NewDataCoordCatalog(t))
+	m.indexes[1] = map[UniqueID]*model.Index{
+		10: newIndex(10, ""),
+		11: newIndex(11, "rg1"),
+	}
+
+	assert.NoError(t, m.CheckAlteredIndex(newIndex(11, "rg2")))
+	assert.NoError(t, m.CheckAlteredIndex(newIndex(10, "rg2")))
+	// both indexes of the field are unbound
+	assert.ErrorIs(t, m.CheckAlteredIndex(newIndex(11, "")), merr.ErrParameterInvalid)
+	// the resource group is bound to the other index
+	assert.ErrorIs(t, m.CheckAlteredIndex(newIndex(10, "rg1")), merr.ErrParameterInvalid)
+
+	// the other index is deleted
+	m.indexes[1][10].IsDeleted = true
+	assert.NoError(t, m.CheckAlteredIndex(newIndex(11, "")))
+}
+
 func TestMeta_HasSameReq(t *testing.T) {
 	var (
 		collID = UniqueID(1)
@@ -402,6 +402,12 @@ func (s *Server) AlterIndex(ctx context.Context, req *indexpb.AlterIndexRequest)
 		if err := ValidateIndexParams(index); err != nil {
 			return merr.Status(err), nil
 		}
+
+		// the resource groups binding may be changed
+		if err := s.meta.indexMeta.CheckAlteredIndex(index); err != nil {
+			log.Warn("failed to alter index", zap.Error(err))
+			return merr.Status(err), nil
+		}
 	}
 
 	err := s.meta.indexMeta.AlterIndex(ctx, indexes...)
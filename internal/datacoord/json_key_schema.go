@@ -0,0 +1,172 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// JSONFieldKeySchema is the key schema of a JSON field saved in the collection property, keyed by the field name.
+type JSONFieldKeySchema struct {
+	// JSON pointer of the key => value types
+	Keys      map[string][]string `json:"keys"`
+	Truncated bool                `json:"truncated,omitempty"`
+}
+
+// jsonKeySchemaRegistry merges the JSON key schemas reported by datanodes at sync,
+// and saves them into the collection property periodically.
+type jsonKeySchemaRegistry struct {
+	broker broker.Broker
+
+	mu sync.Mutex
+	// collectionID => fieldID => keys learned and not saved yet
+	pending map[int64]map[int64]*typeutil.JSONKeySchema
+}
+
+func newJSONKeySchemaRegistry(broker broker.Broker) *jsonKeySchemaRegistry {
+	return &jsonKeySchemaRegistry{
+		broker:  broker,
+		pending: make(map[int64]map[int64]*typeutil.JSONKeySchema),
+	}
+}
+
+func (r *jsonKeySchemaRegistry) observe(collectionID int64, schemas []*datapb.JSONKeySchema) {
+	if r == nil || len(schemas) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, schema := range schemas {
+		keys := make(map[string][]string, len(schema.GetKeys()))
+		for _, key := range schema.GetKeys() {
+			keys[key.GetPath()] = key.GetTypes()
+		}
+		r.merge(collectionID, schema.GetFieldID(), keys, schema.GetTruncated())
+	}
+}
+
+func (r *jsonKeySchemaRegistry) merge(collectionID, fieldID int64, keys map[string][]string, truncated bool) {
+	fields, ok := r.pending[collectionID]
+	if !ok {
+		fields = make(map[int64]*typeutil.JSONKeySchema)
+		r.pending[collectionID] = fields
+	}
+	schema, ok := fields[fieldID]
+	if !ok {
+		// the keys beyond the limit can't be saved either
+		schema = typeutil.NewJSONKeySchema(Params.DataCoordCfg.JSONKeySchemaMaxKeys.GetAsInt())
+		fields[fieldID] = schema
+	}
+	schema.Merge(keys, truncated)
+}
+
+// sync saves the pending keys into the collection properties,
+// the keys failed to save are kept pending for the next sync.
+func (r *jsonKeySchemaRegistry) sync(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[int64]map[int64]*typeutil.JSONKeySchema)
+	r.mu.Unlock()
+
+	for collectionID, fields := range pending {
+		err := r.save(ctx, collectionID, fields)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, merr.ErrCollectionNotFound) {
+			continue
+		}
+		log.Ctx(ctx).Warn("failed to save json key schema", zap.Int64("collectionID", collectionID), zap.Error(err))
+		r.mu.Lock()
+		for fieldID, schema := range fields {
+			r.merge(collectionID, fieldID, schema.Keys(), schema.Truncated())
+		}
+		r.mu.Unlock()
+	}
+}
+
+// save merges the learned keys into the key schema in the collection property.
+// The property is read again at every save, users may edit it with AlterCollection as well.
+func (r *jsonKeySchemaRegistry) save(ctx context.Context, collectionID int64, fields map[int64]*typeutil.JSONKeySchema) error {
+	coll, err := r.broker.DescribeCollectionInternal(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	saved := make(map[string]*JSONFieldKeySchema)
+	for _, kv := range coll.GetProperties() {
+		if kv.GetKey() != common.CollectionJSONKeySchemaKey {
+			continue
+		}
+		if err := json.Unmarshal([]byte(kv.GetValue()), &saved); err != nil {
+			log.Ctx(ctx).Warn("overwrite invalid json key schema", zap.Int64("collectionID", collectionID), zap.Error(err))
+			saved = make(map[string]*JSONFieldKeySchema)
+		}
+	}
+
+	maxKeys := Params.DataCoordCfg.JSONKeySchemaMaxKeys.GetAsInt()
+	updated := make(map[string]*JSONFieldKeySchema, len(saved))
+	changed := false
+	for name, fieldSchema := range saved {
+		updated[name] = fieldSchema
+	}
+	for _, field := range coll.GetSchema().GetFields() {
+		learned, ok := fields[field.GetFieldID()]
+		if !ok {
+			continue
+		}
+		schema := typeutil.NewJSONKeySchema(maxKeys)
+		if fieldSchema, ok := saved[field.GetName()]; ok && fieldSchema != nil {
+			schema.Merge(fieldSchema.Keys, fieldSchema.Truncated)
+		}
+		if schema.Merge(learned.Keys(), learned.Truncated()) {
+			updated[field.GetName()] = &JSONFieldKeySchema{Keys: schema.Keys(), Truncated: schema.Truncated()}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	value, err := json.Marshal(updated)
+	if err != nil {
+		return err
+	}
+	err = r.broker.AlterCollectionProperties(ctx, coll.GetDbName(), coll.GetCollectionName(),
+		[]*commonpb.KeyValuePair{{Key: common.CollectionJSONKeySchemaKey, Value: string(value)}})
+	if err != nil {
+		return err
+	}
+	log.Ctx(ctx).Info("json key schema saved", zap.Int64("collectionID", collectionID), zap.Int("size", len(value)))
+	return nil
+}
@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestJSONKeySchemaRegistry(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	b := broker.NewMockBroker(t)
+	r := newJSONKeySchemaRegistry(b)
+
+	saved := map[string]*JSONFieldKeySchema{
+		"$meta": {Keys: map[string][]string{"/a": {typeutil.JSONTypeInt}}},
+	}
+	value, err := json.Marshal(saved)
+	assert.NoError(t, err)
+	// the property kept by rootcoord
+	property := string(value)
+	b.EXPECT().DescribeCollectionInternal(mock.Anything, int64(1)).RunAndReturn(
+		func(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error) {
+			return &milvuspb.DescribeCollectionResponse{
+				Status:         merr.Success(),
+				DbName:         "db",
+				CollectionName: "coll",
+				Schema: &schemapb.CollectionSchema{
+					Fields: []*schemapb.FieldSchema{
+						{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64},
+						{FieldID: 101, Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+					},
+				},
+				Properties: []*commonpb.KeyValuePair{{Key: common.CollectionJSONKeySchemaKey, Value: property}},
+			}, nil
+		})
+	b.EXPECT().DescribeCollectionInternal(mock.Anything, int64(2)).Return(nil, merr.WrapErrCollectionNotFound(int64(2)))
+
+	var altered map[string]*JSONFieldKeySchema
+	alterFunc := func(ctx context.Context, dbName, collectionName string, properties []*commonpb.KeyValuePair) error {
+		altered = nil
+		assert.Equal(t, common.CollectionJSONKeySchemaKey, properties[0].GetKey())
+		property = properties[0].GetValue()
+		return json.Unmarshal([]byte(properties[0].GetValue()), &altered)
+	}
+	alter := b.EXPECT().AlterCollectionProperties(mock.Anything, "db", "coll", mock.Anything).RunAndReturn(alterFunc)
+
+	// the keys already saved are not saved again
+	r.observe(1, []*datapb.JSONKeySchema{{FieldID: 101, Keys: []*datapb.JSONKey{{Path: "/a", Types: []string{typeutil.JSONTypeInt}}}}})
+	r.observe(2, []*datapb.JSONKeySchema{{FieldID: 101, Keys: []*datapb.JSONKey{{Path: "/x", Types: []string{typeutil.JSONTypeInt}}}}})
+	r.sync(ctx)
+	assert.Nil(t, altered)
+	assert.Empty(t, r.pending)
+
+	r.observe(1, []*datapb.JSONKeySchema{{FieldID: 101, Keys: []*datapb.JSONKey{
+		{Path: "/a", Types: []string{typeutil.JSONTypeString}},
+		{Path: "/b", Types: []string{typeutil.JSONTypeBool}},
+	}}})
+	r.sync(ctx)
+	assert.Equal(t, map[string]*JSONFieldKeySchema{
+		"$meta": {Keys: map[string][]string{
+			"/a": {typeutil.JSONTypeInt, typeutil.JSONTypeString},
+			"/b": {typeutil.JSONTypeBool},
+		}},
+	}, altered)
+
+	// failed keys are kept pending
+	alter.Unset()
+	b.EXPECT().AlterCollectionProperties(mock.Anything, "db", "coll", mock.Anything).Return(errors.New("mock")).Once()
+	r.observe(1, []*datapb.JSONKeySchema{{FieldID: 101, Keys: []*datapb.JSONKey{{Path: "/c", Types: []string{typeutil.JSONTypeInt}}}, Truncated: true}})
+	r.sync(ctx)
+	assert.Len(t, r.pending[1], 1)
+	assert.True(t, r.pending[1][101].Truncated())
+
+	b.EXPECT().AlterCollectionProperties(mock.Anything, "db", "coll", mock.Anything).RunAndReturn(alterFunc).Once()
+	r.sync(ctx)
+	assert.Empty(t, r.pending)
+	assert.True(t, altered["$meta"].Truncated)
+	assert.Len(t, altered["$meta"].Keys, 3)
+
+	// the edits of users are kept
+	property = `{"$meta": {"keys": {"/u": ["string"]}}}`
+	b.EXPECT().AlterCollectionProperties(mock.Anything, "db", "coll", mock.Anything).RunAndReturn(alterFunc).Once()
+	r.observe(1, []*datapb.JSONKeySchema{{FieldID: 101, Keys: []*datapb.JSONKey{{Path: "/d", Types: []string{typeutil.JSONTypeInt}}}}})
+	r.sync(ctx)
+	assert.Equal(t, map[string]*JSONFieldKeySchema{
+		"$meta": {Keys: map[string][]string{
+			"/d": {typeutil.JSONTypeInt},
+			"/u": {typeutil.JSONTypeString},
+		}},
+	}, altered)
+
+	// the pending keys are limited as well
+	paramtable.Get().Save(Params.DataCoordCfg.JSONKeySchemaMaxKeys.Key, "2")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.JSONKeySchemaMaxKeys.Key)
+	r.observe(1, []*datapb.JSONKeySchema{{FieldID: 101, Keys: []*datapb.JSONKey{
+		{Path: "/e", Types: []string{typeutil.JSONTypeInt}},
+		{Path: "/f", Types: []string{typeutil.JSONTypeInt}},
+		{Path: "/g", Types: []string{typeutil.JSONTypeInt}},
+	}}})
+	assert.Equal(t, 2, r.pending[1][101].Len())
+	assert.True(t, r.pending[1][101].Truncated())
+}
@@ -90,6 +90,7 @@ type meta struct {
 	partitionStatsMeta *partitionStatsMeta
 	compactionTaskMeta *compactionTaskMeta
 	statsTaskMeta      *statsTaskMeta
+	backupMeta         *backupMeta
 }
 
 func (m *meta) GetIndexMeta() *indexMeta {
@@ -164,6 +165,11 @@ func newMeta(ctx context.Context, catalog metastore.DataCoordCatalog, chunkManag
 	if err != nil {
 		return nil, err
 	}
+
+	bm, err := newBackupMeta(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
 	mt := &meta{
 		ctx:                ctx,
 		catalog:            catalog,
@@ -176,6 +182,7 @@ func newMeta(ctx context.Context, catalog metastore.DataCoordCatalog, chunkManag
 		partitionStatsMeta: psm,
 		compactionTaskMeta: ctm,
 		statsTaskMeta:      stm,
+		backupMeta:         bm,
 	}
 	err = mt.reloadFromKV()
 	if err != nil {
@@ -422,8 +429,11 @@ func (m *meta) GetQuotaInfo() *metricsinfo.DataCoordQuotaMetrics {
 	// collection id => l0 delta entry count
 	collectionL0RowCounts := make(map[UniqueID]int64)
 
+	collectionIndexSize := make(map[UniqueID]int64)
+	segmentIndexSizes := m.indexMeta.GetSegmentIndexSizes()
+
 	segments := m.segments.GetSegments()
-	var total int64
+	var total, totalIndexSize int64
 	metrics.DataCoordStoredBinlogSize.Reset()
 	metrics.DataCoordSegmentBinLogFileCount.Reset()
 	for _, segment := range segments {
@@ -439,6 +449,10 @@ func (m *meta) GetQuotaInfo() *metricsinfo.DataCoordQuotaMetrics {
 			}
 			partBinlogSize[segment.GetPartitionID()] += segmentSize
 
+			indexSize := segmentIndexSizes[segment.GetID()]
+			totalIndexSize += indexSize
+			collectionIndexSize[segment.GetCollectionID()] += indexSize
+
 			coll, ok := m.collections[segment.GetCollectionID()]
 			if ok {
 				metrics.DataCoordStoredBinlogSize.WithLabelValues(coll.DatabaseName,
@@ -481,6 +495,8 @@ func (m *meta) GetQuotaInfo() *metricsinfo.DataCoordQuotaMetrics {
 	info.TotalBinlogSize = total
 	info.CollectionBinlogSize = collectionBinlogSize
 	info.PartitionsBinlogSize = partitionBinlogSize
+	info.TotalIndexSize = totalIndexSize
+	info.CollectionIndexSize = collectionIndexSize
 	info.CollectionL0RowCount = collectionL0RowCounts
 
 	return info
@@ -1304,6 +1320,21 @@ func (m *meta) GetNumRowsOfPartition(ctx context.Context, collectionID UniqueID,
 	return ret
 }
 
+// GetFlushedNumRowsOfPartitions returns row count of the flushed segments belongs to provided collection & partitions,
+// all the partitions of the collection if partitionIDs is empty. The segments importing are excluded,
+// so the row count changes only when flushed or imported.
+func (m *meta) GetFlushedNumRowsOfPartitions(ctx context.Context, collectionID UniqueID, partitionIDs ...UniqueID) int64 {
+	var ret int64
+	segments := m.SelectSegments(ctx, WithCollection(collectionID), SegmentFilterFunc(func(si *SegmentInfo) bool {
+		return si.GetState() == commonpb.SegmentState_Flushed && !si.GetIsImporting() &&
+			(len(partitionIDs) == 0 || lo.Contains(partitionIDs, si.GetPartitionID()))
+	}))
+	for _, segment := range segments {
+		ret += segment.NumOfRows
+	}
+	return ret
+}
+
 // GetUnFlushedSegments get all segments which state is not `Flushing` nor `Flushed`
 func (m *meta) GetUnFlushedSegments() []*SegmentInfo {
 	return m.SelectSegments(m.ctx, SegmentFilterFunc(func(segment *SegmentInfo) bool {
@@ -1716,6 +1747,34 @@ func (m *meta) UpdateChannelCheckpoint(ctx context.Context, vChannel string, pos
 	return nil
 }
 
+// ResetChannelCheckpoint saves the channel checkpoint even if it moves the checkpoint backward,
+// it is only used to repair the checkpoint.
+func (m *meta) ResetChannelCheckpoint(ctx context.Context, vChannel string, pos *msgpb.MsgPosition) error {
+	if pos == nil || pos.GetMsgID() == nil {
+		return fmt.Errorf("channelCP is nil, vChannel=%s", vChannel)
+	}
+
+	m.channelCPs.Lock()
+	defer m.channelCPs.Unlock()
+
+	err := m.catalog.SaveChannelCheckpoint(ctx, vChannel, pos)
+	if err != nil {
+		return err
+	}
+	oldPosition := m.channelCPs.checkpoints[vChannel]
+	m.channelCPs.checkpoints[vChannel] = pos
+	ts, _ := tsoutil.ParseTS(pos.Timestamp)
+	log.Info("ResetChannelCheckpoint done",
+		zap.String("vChannel", vChannel),
+		zap.Uint64("oldTs", oldPosition.GetTimestamp()),
+		zap.Uint64("ts", pos.GetTimestamp()),
+		zap.ByteString("msgID", pos.GetMsgID()),
+		zap.Time("time", ts))
+	metrics.DataCoordCheckpointUnixSeconds.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), vChannel).
+		Set(float64(ts.Unix()))
+	return nil
+}
+
 // MarkChannelCheckpointDropped set channel checkpoint to MaxUint64 preventing future update
 // and remove the metrics for channel checkpoint lag.
 func (m *meta) MarkChannelCheckpointDropped(ctx context.Context, channel string) error {
@@ -79,6 +79,7 @@ func (suite *MetaReloadSuite) TestReloadFromKV() {
 		suite.catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+		suite.catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
 
 		_, err := newMeta(ctx, suite.catalog, nil)
 		suite.Error(err)
@@ -95,6 +96,7 @@ func (suite *MetaReloadSuite) TestReloadFromKV() {
 		suite.catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+		suite.catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
 
 		_, err := newMeta(ctx, suite.catalog, nil)
 		suite.Error(err)
@@ -108,6 +110,7 @@ func (suite *MetaReloadSuite) TestReloadFromKV() {
 		suite.catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+		suite.catalog.EXPECT().ListBackups(mock.Anything).Return(nil, nil)
 		suite.catalog.EXPECT().ListSegments(mock.Anything).Return([]*datapb.SegmentInfo{
 			{
 				ID:           1,
@@ -732,6 +735,30 @@ func TestGetUnFlushedSegments(t *testing.T) {
 	assert.NotEqualValues(t, commonpb.SegmentState_Flushed, segments[0].State)
 }
 
+func TestGetFlushedNumRowsOfPartitions(t *testing.T) {
+	meta, err := newMemoryMeta()
+	assert.NoError(t, err)
+	segments := []*datapb.SegmentInfo{
+		{ID: 1, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Growing, NumOfRows: 1},
+		{ID: 2, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Flushing, NumOfRows: 10},
+		{ID: 3, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Flushed, NumOfRows: 100},
+		{ID: 4, CollectionID: 1, PartitionID: 11, State: commonpb.SegmentState_Flushed, NumOfRows: 1000},
+		{ID: 5, CollectionID: 1, PartitionID: 11, State: commonpb.SegmentState_Flushed, NumOfRows: 10000, IsImporting: true},
+		{ID: 6, CollectionID: 1, PartitionID: 11, State: commonpb.SegmentState_Dropped, NumOfRows: 100000},
+		{ID: 7, CollectionID: 2, PartitionID: 20, State: commonpb.SegmentState_Flushed, NumOfRows: 1000000},
+	}
+	for _, segment := range segments {
+		err = meta.AddSegment(context.TODO(), NewSegmentInfo(segment))
+		assert.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 1100, meta.GetFlushedNumRowsOfPartitions(context.TODO(), 1))
+	assert.EqualValues(t, 100, meta.GetFlushedNumRowsOfPartitions(context.TODO(), 1, 10))
+	assert.EqualValues(t, 1100, meta.GetFlushedNumRowsOfPartitions(context.TODO(), 1, 10, 11))
+	assert.EqualValues(t, 0, meta.GetFlushedNumRowsOfPartitions(context.TODO(), 1, 20))
+	assert.EqualValues(t, 11111, meta.GetNumRowsOfCollection(1))
+}
+
 func TestUpdateSegmentsInfo(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		meta, err := newMemoryMeta()
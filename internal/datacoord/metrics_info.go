@@ -320,7 +320,10 @@ func (s *Server) getDataNodeMetrics(ctx context.Context, req *milvuspb.GetMetric
 		return infos, err
 	}
 
-	metrics, err := cli.GetMetrics(ctx, req)
+	metrics, err := metricsinfo.RequestRemoteMetrics(ctx, metricsinfo.ConstructComponentName(typeutil.DataNodeRole, node.NodeID()), req,
+		func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+			return cli.GetMetrics(ctx, req)
+		})
 	if err != nil {
 		log.Warn("invalid metrics of DataNode was found",
 			zap.Error(err))
@@ -402,7 +405,10 @@ func getMetrics[T any](s *Server, ctx context.Context, req *milvuspb.GetMetricsR
 			if err != nil {
 				return err
 			}
-			resp, err := cli.GetMetrics(ctx, req)
+			resp, err := metricsinfo.RequestRemoteMetrics(ctx, metricsinfo.ConstructComponentName(typeutil.DataNodeRole, node.NodeID()), req,
+				func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+					return cli.GetMetrics(ctx, req)
+				})
 			if err != nil {
 				log.Warn("failed to get metric from DataNode", zap.Int64("nodeID", node.NodeID()))
 				return err
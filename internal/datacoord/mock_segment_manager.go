@@ -5,6 +5,8 @@ package datacoord
 import (
 	context "context"
 
+	datapb "github.com/milvus-io/milvus/internal/proto/datapb"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -145,6 +147,69 @@ func (_c *MockManager_AllocSegment_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// AllocSegmentInKeyRange provides a mock function with given fields: ctx, collectionID, partitionID, channelName, keyRange, requestRows
+func (_m *MockManager) AllocSegmentInKeyRange(ctx context.Context, collectionID int64, partitionID int64, channelName string, keyRange *datapb.PartitionKeyRange, requestRows int64) ([]*Allocation, error) {
+	ret := _m.Called(ctx, collectionID, partitionID, channelName, keyRange, requestRows)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllocSegmentInKeyRange")
+	}
+
+	var r0 []*Allocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, *datapb.PartitionKeyRange, int64) ([]*Allocation, error)); ok {
+		return rf(ctx, collectionID, partitionID, channelName, keyRange, requestRows)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, *datapb.PartitionKeyRange, int64) []*Allocation); ok {
+		r0 = rf(ctx, collectionID, partitionID, channelName, keyRange, requestRows)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Allocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string, *datapb.PartitionKeyRange, int64) error); ok {
+		r1 = rf(ctx, collectionID, partitionID, channelName, keyRange, requestRows)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_AllocSegmentInKeyRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllocSegmentInKeyRange'
+type MockManager_AllocSegmentInKeyRange_Call struct {
+	*mock.Call
+}
+
+// AllocSegmentInKeyRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collectionID int64
+//   - partitionID int64
+//   - channelName string
+//   - keyRange *datapb.PartitionKeyRange
+//   - requestRows int64
+func (_e *MockManager_Expecter) AllocSegmentInKeyRange(ctx interface{}, collectionID interface{}, partitionID interface{}, channelName interface{}, keyRange interface{}, requestRows interface{}) *MockManager_AllocSegmentInKeyRange_Call {
+	return &MockManager_AllocSegmentInKeyRange_Call{Call: _e.mock.On("AllocSegmentInKeyRange", ctx, collectionID, partitionID, channelName, keyRange, requestRows)}
+}
+
+func (_c *MockManager_AllocSegmentInKeyRange_Call) Run(run func(ctx context.Context, collectionID int64, partitionID int64, channelName string, keyRange *datapb.PartitionKeyRange, requestRows int64)) *MockManager_AllocSegmentInKeyRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(*datapb.PartitionKeyRange), args[5].(int64))
+	})
+	return _c
+}
+
+func (_c *MockManager_AllocSegmentInKeyRange_Call) Return(_a0 []*Allocation, _a1 error) *MockManager_AllocSegmentInKeyRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_AllocSegmentInKeyRange_Call) RunAndReturn(run func(context.Context, int64, int64, string, *datapb.PartitionKeyRange, int64) ([]*Allocation, error)) *MockManager_AllocSegmentInKeyRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropSegment provides a mock function with given fields: ctx, segmentID
 func (_m *MockManager) DropSegment(ctx context.Context, segmentID int64) {
 	_m.Called(ctx, segmentID)
@@ -318,6 +318,10 @@ func (m *mockRootCoordClient) DescribeDatabase(ctx context.Context, in *rootcoor
 	}, nil
 }
 
+func (m *mockRootCoordClient) DescribeDatabases(ctx context.Context, in *rootcoordpb.DescribeDatabasesRequest, opts ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 func (m *mockRootCoordClient) Close() error {
 	// TODO implement me
 	panic("implement me")
@@ -446,6 +450,10 @@ func (m *mockRootCoordClient) AlterDatabase(ctx context.Context, in *rootcoordpb
 	panic("not implemented") // TODO: Implement
 }
 
+func (m *mockRootCoordClient) BatchDDL(ctx context.Context, in *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 func (m *mockRootCoordClient) AlterCollection(ctx context.Context, request *milvuspb.AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	panic("not implemented") // TODO: Implement
 }
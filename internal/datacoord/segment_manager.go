@@ -19,6 +19,7 @@ package datacoord
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -26,10 +27,12 @@ import (
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/decisionjournal"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/lock"
 	"github.com/milvus-io/milvus/pkg/util/retry"
@@ -75,6 +78,9 @@ type Manager interface {
 
 	// Deprecated: AllocSegment allocates rows and record the allocation, will be deprecated after enabling streamingnode.
 	AllocSegment(ctx context.Context, collectionID, partitionID UniqueID, channelName string, requestRows int64) ([]*Allocation, error)
+	// AllocSegmentInKeyRange allocates rows in the segments only holding the rows with the partition keys in the key range.
+	AllocSegmentInKeyRange(ctx context.Context, collectionID, partitionID UniqueID, channelName string,
+		keyRange *datapb.PartitionKeyRange, requestRows int64) ([]*Allocation, error)
 
 	// AllocNewGrowingSegment allocates segment for streaming node.
 	AllocNewGrowingSegment(ctx context.Context, collectionID, partitionID, segmentID UniqueID, channelName string) (*SegmentInfo, error)
@@ -272,11 +278,20 @@ func (s *SegmentManager) maybeResetLastExpireForSegments() error {
 // AllocSegment allocate segment per request collcation, partication, channel and rows
 func (s *SegmentManager) AllocSegment(ctx context.Context, collectionID UniqueID,
 	partitionID UniqueID, channelName string, requestRows int64,
+) ([]*Allocation, error) {
+	return s.AllocSegmentInKeyRange(ctx, collectionID, partitionID, channelName, nil, requestRows)
+}
+
+// AllocSegmentInKeyRange allocates rows in the segments only holding the rows with the partition keys in the key range,
+// the key range is recorded in the new segments.
+func (s *SegmentManager) AllocSegmentInKeyRange(ctx context.Context, collectionID UniqueID,
+	partitionID UniqueID, channelName string, keyRange *datapb.PartitionKeyRange, requestRows int64,
 ) ([]*Allocation, error) {
 	log := log.Ctx(ctx).
 		With(zap.Int64("collectionID", collectionID)).
 		With(zap.Int64("partitionID", partitionID)).
 		With(zap.String("channelName", channelName)).
+		With(zap.Any("keyRange", keyRange)).
 		With(zap.Int64("requestRows", requestRows))
 	_, sp := otel.Tracer(typeutil.DataCoordRole).Start(ctx, "Alloc-Segment")
 	defer sp.End()
@@ -286,7 +301,7 @@ func (s *SegmentManager) AllocSegment(ctx context.Context, collectionID UniqueID
 	// filter segments
 	validSegments := make(map[UniqueID]struct{})
 	invalidSegments := make(map[UniqueID]struct{})
-	segments := make([]*SegmentInfo, 0)
+	growingSegments := make([]*SegmentInfo, 0)
 	for _, segmentID := range s.segments {
 		segment := s.meta.GetHealthySegment(context.TODO(), segmentID)
 		if segment == nil {
@@ -298,7 +313,7 @@ func (s *SegmentManager) AllocSegment(ctx context.Context, collectionID UniqueID
 		if !satisfy(segment, collectionID, partitionID, channelName) || !isGrowing(segment) || segment.GetLevel() == datapb.SegmentLevel_L0 {
 			continue
 		}
-		segments = append(segments, segment)
+		growingSegments = append(growingSegments, segment)
 	}
 
 	if len(invalidSegments) > 0 {
@@ -306,6 +321,26 @@ func (s *SegmentManager) AllocSegment(ctx context.Context, collectionID UniqueID
 	}
 	s.segments = lo.Keys(validSegments)
 
+	inKeyRange := func(keyRange *datapb.PartitionKeyRange) []*SegmentInfo {
+		return lo.Filter(growingSegments, func(segment *SegmentInfo, _ int) bool {
+			return proto.Equal(segment.GetPartitionKeyRange(), keyRange)
+		})
+	}
+	segments := inKeyRange(keyRange)
+	// the key range without growing segment shares the segments of all key ranges
+	// once the growing segments with key range reach the limit, so they are not multiplied by the key ranges
+	if keyRange != nil && len(segments) == 0 {
+		ranged := lo.CountBy(growingSegments, func(segment *SegmentInfo) bool {
+			return segment.GetPartitionKeyRange() != nil
+		})
+		if maxRanged := Params.DataCoordCfg.PartitionKeyAffinityMaxGrowing.GetAsInt(); ranged >= maxRanged {
+			log.Info("growing segments with key range reach the limit, allocate in the segments shared by all key ranges",
+				zap.Int("rangedGrowingSegments", ranged), zap.Int("limit", maxRanged))
+			keyRange = nil
+			segments = inKeyRange(nil)
+		}
+	}
+
 	// Apply allocation policy.
 	maxCountPerSegment, err := s.estimateMaxNumOfRows(collectionID)
 	if err != nil {
@@ -320,7 +355,7 @@ func (s *SegmentManager) AllocSegment(ctx context.Context, collectionID UniqueID
 		return nil, err
 	}
 	for _, allocation := range newSegmentAllocations {
-		segment, err := s.openNewSegment(ctx, collectionID, partitionID, channelName)
+		segment, err := s.openNewSegment(ctx, collectionID, partitionID, channelName, keyRange)
 		if err != nil {
 			log.Error("Failed to open new segment for segment allocation")
 			return nil, err
@@ -366,10 +401,12 @@ func (s *SegmentManager) genExpireTs(ctx context.Context) (Timestamp, error) {
 
 // AllocNewGrowingSegment allocates segment for streaming node.
 func (s *SegmentManager) AllocNewGrowingSegment(ctx context.Context, collectionID, partitionID, segmentID UniqueID, channelName string) (*SegmentInfo, error) {
-	return s.openNewSegmentWithGivenSegmentID(ctx, collectionID, partitionID, segmentID, channelName)
+	return s.openNewSegmentWithGivenSegmentID(ctx, collectionID, partitionID, segmentID, channelName, nil)
 }
 
-func (s *SegmentManager) openNewSegment(ctx context.Context, collectionID UniqueID, partitionID UniqueID, channelName string) (*SegmentInfo, error) {
+func (s *SegmentManager) openNewSegment(ctx context.Context, collectionID UniqueID, partitionID UniqueID, channelName string,
+	keyRange *datapb.PartitionKeyRange,
+) (*SegmentInfo, error) {
 	log := log.Ctx(ctx)
 	ctx, sp := otel.Tracer(typeutil.DataCoordRole).Start(ctx, "open-Segment")
 	defer sp.End()
@@ -378,10 +415,12 @@ func (s *SegmentManager) openNewSegment(ctx context.Context, collectionID Unique
 		log.Error("failed to open new segment while AllocID", zap.Error(err))
 		return nil, err
 	}
-	return s.openNewSegmentWithGivenSegmentID(ctx, collectionID, partitionID, id, channelName)
+	return s.openNewSegmentWithGivenSegmentID(ctx, collectionID, partitionID, id, channelName, keyRange)
 }
 
-func (s *SegmentManager) openNewSegmentWithGivenSegmentID(ctx context.Context, collectionID UniqueID, partitionID UniqueID, segmentID UniqueID, channelName string) (*SegmentInfo, error) {
+func (s *SegmentManager) openNewSegmentWithGivenSegmentID(ctx context.Context, collectionID UniqueID, partitionID UniqueID, segmentID UniqueID, channelName string,
+	keyRange *datapb.PartitionKeyRange,
+) (*SegmentInfo, error) {
 	maxNumOfRows, err := s.estimateMaxNumOfRows(collectionID)
 	if err != nil {
 		log.Error("failed to open new segment while estimateMaxNumOfRows", zap.Error(err))
@@ -389,15 +428,16 @@ func (s *SegmentManager) openNewSegmentWithGivenSegmentID(ctx context.Context, c
 	}
 
 	segmentInfo := &datapb.SegmentInfo{
-		ID:             segmentID,
-		CollectionID:   collectionID,
-		PartitionID:    partitionID,
-		InsertChannel:  channelName,
-		NumOfRows:      0,
-		State:          commonpb.SegmentState_Growing,
-		MaxRowNum:      int64(maxNumOfRows),
-		Level:          datapb.SegmentLevel_L1,
-		LastExpireTime: 0,
+		ID:                segmentID,
+		CollectionID:      collectionID,
+		PartitionID:       partitionID,
+		InsertChannel:     channelName,
+		NumOfRows:         0,
+		State:             commonpb.SegmentState_Growing,
+		MaxRowNum:         int64(maxNumOfRows),
+		Level:             datapb.SegmentLevel_L1,
+		LastExpireTime:    0,
+		PartitionKeyRange: keyRange,
 	}
 	segment := NewSegmentInfo(segmentInfo)
 	if err := s.meta.AddSegment(ctx, segment); err != nil {
@@ -410,6 +450,17 @@ func (s *SegmentManager) openNewSegmentWithGivenSegmentID(ctx context.Context, c
 		zap.Int64("SegmentID", segmentInfo.ID),
 		zap.Int("Rows", maxNumOfRows),
 		zap.String("Channel", segmentInfo.InsertChannel))
+	decisionjournal.Record(typeutil.DataCoordRole, &decisionjournal.Event{
+		Type:         decisionjournal.TypeSegmentAssign,
+		CollectionID: collectionID,
+		PartitionID:  partitionID,
+		Channel:      channelName,
+		SegmentIDs:   []int64{segmentID},
+		Reason:       "no growing segment with enough space to allocate",
+		Detail: map[string]string{
+			"max_rows": strconv.Itoa(maxNumOfRows),
+		},
+	})
 
 	return segment, s.helper.afterCreateSegment(segmentInfo)
 }
@@ -160,6 +160,51 @@ func TestAllocSegment(t *testing.T) {
 		assert.EqualValues(t, 1, len(segmentManager.segments))
 		assert.NotEqual(t, allocations1[0].SegmentID, allocations2[0].SegmentID)
 	})
+
+	t.Run("allocation in key range", func(t *testing.T) {
+		keyRange := &datapb.PartitionKeyRange{Begin: 0, End: 1 << 31}
+		allocations1, err := segmentManager.AllocSegmentInKeyRange(ctx, collID, 100, "c3", keyRange, 100)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(allocations1))
+		segment := meta.GetHealthySegment(ctx, allocations1[0].SegmentID)
+		assert.Equal(t, int64(0), segment.GetPartitionKeyRange().GetBegin())
+		assert.Equal(t, int64(1<<31), segment.GetPartitionKeyRange().GetEnd())
+
+		// the segments are only shared in the same key range
+		allocations2, err := segmentManager.AllocSegmentInKeyRange(ctx, collID, 100, "c3",
+			&datapb.PartitionKeyRange{Begin: 0, End: 1 << 31}, 100)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(allocations2))
+		assert.Equal(t, allocations1[0].SegmentID, allocations2[0].SegmentID)
+
+		allocations3, err := segmentManager.AllocSegmentInKeyRange(ctx, collID, 100, "c3",
+			&datapb.PartitionKeyRange{Begin: 1 << 31, End: 1 << 32}, 100)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(allocations3))
+		assert.NotEqual(t, allocations1[0].SegmentID, allocations3[0].SegmentID)
+
+		allocations4, err := segmentManager.AllocSegment(ctx, collID, 100, "c3", 100)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(allocations4))
+		assert.NotEqual(t, allocations1[0].SegmentID, allocations4[0].SegmentID)
+		assert.NotEqual(t, allocations3[0].SegmentID, allocations4[0].SegmentID)
+		assert.Nil(t, meta.GetHealthySegment(ctx, allocations4[0].SegmentID).GetPartitionKeyRange())
+
+		// the other key ranges share the segment without key range once the growing segments with key range reach the limit
+		Params.Save(Params.DataCoordCfg.PartitionKeyAffinityMaxGrowing.Key, "2")
+		defer Params.Reset(Params.DataCoordCfg.PartitionKeyAffinityMaxGrowing.Key)
+		allocations5, err := segmentManager.AllocSegmentInKeyRange(ctx, collID, 100, "c3",
+			&datapb.PartitionKeyRange{Begin: 1 << 30, End: 1 << 31}, 100)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(allocations5))
+		assert.Equal(t, allocations4[0].SegmentID, allocations5[0].SegmentID)
+
+		// the key ranges with growing segment are not affected
+		allocations6, err := segmentManager.AllocSegmentInKeyRange(ctx, collID, 100, "c3", keyRange, 100)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(allocations6))
+		assert.Equal(t, allocations1[0].SegmentID, allocations6[0].SegmentID)
+	})
 }
 
 func TestLastExpireReset(t *testing.T) {
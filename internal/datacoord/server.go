@@ -51,6 +51,7 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	streamingcoord "github.com/milvus-io/milvus/internal/streamingcoord/server"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/decisionjournal"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
@@ -163,6 +164,8 @@ type Server struct {
 	// manage ways that data coord access other coord
 	broker broker.Broker
 
+	jsonKeySchemas *jsonKeySchemaRegistry
+
 	// streamingcoord server is embedding in datacoord now.
 	streamingCoord *streamingcoord.Server
 
@@ -321,6 +324,7 @@ func (s *Server) Init() error {
 			log.Info("DataCoord switch from standby to active, activating")
 			if err := s.initDataCoord(); err != nil {
 				log.Error("DataCoord init failed", zap.Error(err))
+				decisionjournal.Close(typeutil.DataCoordRole)
 				return err
 			}
 			s.startDataCoord()
@@ -354,6 +358,7 @@ func (s *Server) initDataCoord() error {
 
 	s.broker = broker.NewCoordinatorBroker(s.rootCoordClient)
 	s.allocator = allocator.NewRootCoordAllocator(s.rootCoordClient)
+	s.jsonKeySchemas = newJSONKeySchemaRegistry(s.broker)
 
 	storageCli, err := s.newChunkManagerFactory()
 	if err != nil {
@@ -365,6 +370,12 @@ func (s *Server) initDataCoord() error {
 		return err
 	}
 
+	// the journal is opened only once active, so the events persisted by the former active one are reloaded,
+	// and a standby never flushes its empty journal over them
+	if err = decisionjournal.Open(s.ctx, typeutil.DataCoordRole, s.kv); err != nil {
+		return err
+	}
+
 	// init id allocator after init meta
 	s.idAllocator = globalIDAllocator.NewGlobalIDAllocator("idTimestamp", s.kv)
 	err = s.idAllocator.Initialize()
@@ -510,7 +521,12 @@ func (s *Server) initCluster() error {
 		return nil
 	}
 
-	s.sessionManager = session.NewDataNodeManagerImpl(session.WithDataNodeCreator(s.dataNodeCreator))
+	s.sessionManager = session.NewDataNodeManagerImpl(
+		session.WithDataNodeCreator(s.dataNodeCreator),
+		session.WithFencingEpoch(func() int64 {
+			return s.session.GetEpoch()
+		}),
+	)
 
 	var err error
 	channelManagerOpts := []ChannelmanagerOpt{withCheckerV2()}
@@ -723,7 +739,26 @@ func (s *Server) initIndexNodeManager() {
 func (s *Server) initCompaction() {
 	s.compactionHandler = newCompactionPlanHandler(s.cluster, s.sessionManager, s.meta, s.allocator, s.taskScheduler, s.handler)
 	s.compactionTriggerManager = NewCompactionTriggerManager(s.allocator, s.handler, s.compactionHandler, s.meta)
-	s.compactionTrigger = newCompactionTrigger(s.meta, s.compactionHandler, s.allocator, s.handler, s.indexEngineVersionManager)
+	compactionTrigger := newCompactionTrigger(s.meta, s.compactionHandler, s.allocator, s.handler, s.indexEngineVersionManager)
+	if s.watchClient != nil && s.session != nil {
+		compactionTrigger.deleteFeedback = newDeleteFeedback(s.watchClient, s.liveQueryNodes)
+		compactionTrigger.requestL0Compaction = s.compactionTriggerManager.RequestL0Compaction
+		s.compactionTriggerManager.fanOutPolicy.feedback = newFanOutFeedback(s.watchClient, s.liveQueryNodes)
+	}
+	s.compactionTrigger = compactionTrigger
+}
+
+// liveQueryNodes returns the IDs of the querynodes of which the sessions are alive.
+func (s *Server) liveQueryNodes() (typeutil.UniqueSet, error) {
+	sessions, _, err := s.session.GetSessions(typeutil.QueryNodeRole)
+	if err != nil {
+		return nil, err
+	}
+	nodes := typeutil.NewUniqueSet()
+	for _, session := range sessions {
+		nodes.Insert(session.ServerID)
+	}
+	return nodes, nil
 }
 
 func (s *Server) stopCompaction() {
@@ -761,6 +796,7 @@ func (s *Server) startServerLoop() {
 	s.serverLoopWg.Add(2)
 	s.startWatchService(s.serverLoopCtx)
 	s.startFlushLoop(s.serverLoopCtx)
+	s.startJSONKeySchemaSyncLoop(s.serverLoopCtx)
 	go s.importScheduler.Start()
 	go s.importChecker.Start()
 	s.garbageCollector.start()
@@ -1127,6 +1163,8 @@ func (s *Server) Stop() error {
 
 	s.stopServerLoop()
 	logutil.Logger(s.ctx).Info("datacoord serverloop stopped")
+
+	decisionjournal.Close(typeutil.DataCoordRole)
 	logutil.Logger(s.ctx).Warn("datacoord stop successful")
 	return nil
 }
@@ -1201,6 +1239,11 @@ func (s *Server) registerMetricsRequest() {
 			}
 			return s.meta.indexMeta.GetIndexJSON(collectionID), nil
 		})
+
+	s.metricsRequest.RegisterMetricsRequest(metricsinfo.DecisionJournalKey,
+		func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+			return decisionjournal.ListJSON(typeutil.DataCoordRole, jsonReq)
+		})
 	log.Info("register metrics actions finished")
 }
 
@@ -1244,6 +1287,25 @@ func (s *Server) loadCollectionFromRootCoord(ctx context.Context, collectionID i
 	return nil
 }
 
+// startJSONKeySchemaSyncLoop saves the json key schemas reported by datanodes into the collection properties periodically.
+func (s *Server) startJSONKeySchemaSyncLoop(ctx context.Context) {
+	s.serverLoopWg.Add(1)
+	go func() {
+		defer s.serverLoopWg.Done()
+		ticker := time.NewTicker(Params.DataCoordCfg.JSONKeySchemaSyncInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("json key schema sync loop exit")
+				return
+			case <-ticker.C:
+				s.jsonKeySchemas.sync(ctx)
+			}
+		}
+	}()
+}
+
 func (s *Server) updateBalanceConfigLoop(ctx context.Context) {
 	success := s.updateBalanceConfig()
 	if success {
@@ -218,15 +218,22 @@ func (s *Server) AssignSegmentID(ctx context.Context, req *datapb.AssignSegmentI
 		}
 
 		// Have segment manager allocate and return the segment allocation info.
-		segmentAllocations, err := s.segmentManager.AllocSegment(ctx,
-			r.CollectionID, r.PartitionID, r.ChannelName, int64(r.Count))
+		var segmentAllocations []*Allocation
+		if r.GetPartitionKeyRange() != nil {
+			segmentAllocations, err = s.segmentManager.AllocSegmentInKeyRange(ctx,
+				r.CollectionID, r.PartitionID, r.ChannelName, r.GetPartitionKeyRange(), int64(r.Count))
+		} else {
+			segmentAllocations, err = s.segmentManager.AllocSegment(ctx,
+				r.CollectionID, r.PartitionID, r.ChannelName, int64(r.Count))
+		}
 		if err != nil {
 			log.Warn("failed to alloc segment", zap.Any("request", r), zap.Error(err))
 			assigns = append(assigns, &datapb.SegmentIDAssignment{
-				ChannelName:  r.ChannelName,
-				CollectionID: r.CollectionID,
-				PartitionID:  r.PartitionID,
-				Status:       merr.Status(err),
+				ChannelName:       r.ChannelName,
+				CollectionID:      r.CollectionID,
+				PartitionID:       r.PartitionID,
+				Status:            merr.Status(err),
+				PartitionKeyRange: r.GetPartitionKeyRange(),
 			})
 			continue
 		}
@@ -235,13 +242,14 @@ func (s *Server) AssignSegmentID(ctx context.Context, req *datapb.AssignSegmentI
 
 		for _, allocation := range segmentAllocations {
 			result := &datapb.SegmentIDAssignment{
-				SegID:        allocation.SegmentID,
-				ChannelName:  r.ChannelName,
-				Count:        uint32(allocation.NumOfRows),
-				CollectionID: r.CollectionID,
-				PartitionID:  r.PartitionID,
-				ExpireTime:   allocation.ExpireTime,
-				Status:       merr.Success(),
+				SegID:             allocation.SegmentID,
+				ChannelName:       r.ChannelName,
+				Count:             uint32(allocation.NumOfRows),
+				CollectionID:      r.CollectionID,
+				PartitionID:       r.PartitionID,
+				ExpireTime:        allocation.ExpireTime,
+				Status:            merr.Success(),
+				PartitionKeyRange: r.GetPartitionKeyRange(),
 			}
 			assigns = append(assigns, result)
 		}
@@ -364,10 +372,21 @@ func (s *Server) GetCollectionStatistics(ctx context.Context, req *datapb.GetCol
 		}, nil
 	}
 
+	if err := checkStatisticsConsistency(req.GetConsistency()); err != nil {
+		return &datapb.GetCollectionStatisticsResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
 	resp := &datapb.GetCollectionStatisticsResponse{
 		Status: merr.Success(),
 	}
-	nums := s.meta.GetNumRowsOfCollection(req.CollectionID)
+	var nums int64
+	if req.GetConsistency() == internalpb.StatisticsConsistency_StatisticsFlushed {
+		nums = s.meta.GetFlushedNumRowsOfPartitions(ctx, req.GetCollectionID())
+	} else {
+		nums = s.meta.GetNumRowsOfCollection(req.CollectionID)
+	}
 	resp.Stats = append(resp.Stats, &commonpb.KeyValuePair{Key: "row_count", Value: strconv.FormatInt(nums, 10)})
 	log.Info("success to get collection statistics", zap.Any("response", resp))
 	return resp, nil
@@ -389,19 +408,38 @@ func (s *Server) GetPartitionStatistics(ctx context.Context, req *datapb.GetPart
 			Status: merr.Status(err),
 		}, nil
 	}
-	nums := int64(0)
-	if len(req.GetPartitionIDs()) == 0 {
-		nums = s.meta.GetNumRowsOfCollection(req.CollectionID)
+	if err := checkStatisticsConsistency(req.GetConsistency()); err != nil {
+		return &datapb.GetPartitionStatisticsResponse{
+			Status: merr.Status(err),
+		}, nil
 	}
-	for _, partID := range req.GetPartitionIDs() {
-		num := s.meta.GetNumRowsOfPartition(ctx, req.CollectionID, partID)
-		nums += num
+	nums := int64(0)
+	if req.GetConsistency() == internalpb.StatisticsConsistency_StatisticsFlushed {
+		nums = s.meta.GetFlushedNumRowsOfPartitions(ctx, req.GetCollectionID(), req.GetPartitionIDs()...)
+	} else {
+		if len(req.GetPartitionIDs()) == 0 {
+			nums = s.meta.GetNumRowsOfCollection(req.CollectionID)
+		}
+		for _, partID := range req.GetPartitionIDs() {
+			num := s.meta.GetNumRowsOfPartition(ctx, req.CollectionID, partID)
+			nums += num
+		}
 	}
 	resp.Stats = append(resp.Stats, &commonpb.KeyValuePair{Key: "row_count", Value: strconv.FormatInt(nums, 10)})
 	log.Info("success to get partition statistics", zap.Any("response", resp))
 	return resp, nil
 }
 
+// checkStatisticsConsistency checks the consistency of the statistics requested,
+// datacoord knows the row counts of the growing segments reported by the datanodes only,
+// which are not caught up to any timestamp.
+func checkStatisticsConsistency(consistency internalpb.StatisticsConsistency) error {
+	if consistency == internalpb.StatisticsConsistency_StatisticsIncludeGrowing {
+		return merr.WrapErrParameterInvalidMsg("statistics consistency %s is not supported by datacoord, the collection must be loaded", consistency.String())
+	}
+	return nil
+}
+
 // GetSegmentInfoChannel legacy API, returns segment info statistics channel
 func (s *Server) GetSegmentInfoChannel(ctx context.Context, req *datapb.GetSegmentInfoChannelRequest) (*milvuspb.StringResponse, error) {
 	return &milvuspb.StringResponse{
@@ -567,6 +605,7 @@ func (s *Server) SaveBinlogPaths(ctx context.Context, req *datapb.SaveBinlogPath
 		zap.Any("deltalogs", req.GetDeltalogs()),
 		zap.Any("statslogs", req.GetField2StatslogPaths()),
 	)
+	s.jsonKeySchemas.observe(req.GetCollectionID(), req.GetJsonKeySchemas())
 
 	if req.GetSegLevel() == datapb.SegmentLevel_L0 {
 		metrics.DataCoordSizeStoredL0Segment.WithLabelValues(fmt.Sprint(req.GetCollectionID())).Observe(calculateL0SegmentSize(req.GetField2StatslogPaths()))
@@ -1545,6 +1584,8 @@ func (s *Server) BroadcastAlteredCollection(ctx context.Context, req *datapb.Alt
 		return merr.Status(err), nil
 	}
 
+	s.broker.InvalidateCollection(req.GetCollectionID())
+
 	// get collection info from cache
 	clonedColl := s.meta.GetClonedCollectionInfo(req.CollectionID)
 
@@ -1697,22 +1738,33 @@ func (s *Server) ImportV2(ctx context.Context, in *internalpb.ImportRequestInter
 		log.Info("list binlogs prefixes for import", zap.Any("binlog_prefixes", files))
 	}
 
+	job, err := s.addImportJob(ctx, in, files, timeoutTs)
+	if err != nil {
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+
+	resp.JobID = fmt.Sprint(job.GetJobID())
+	log.Info("add import job done", zap.Int64("jobID", job.GetJobID()), zap.Any("files", files))
+	return resp, nil
+}
+
+// addImportJob adds a pending import job of the files, the files are assigned with file ids.
+func (s *Server) addImportJob(ctx context.Context, in *internalpb.ImportRequestInternal, files []*internalpb.ImportFile, timeoutTs uint64) (*importJob, error) {
 	// Check if the number of jobs exceeds the limit.
 	maxNum := paramtable.Get().DataCoordCfg.MaxImportJobNum.GetAsInt()
 	executingNum := s.importMeta.CountJobBy(ctx, WithoutJobStates(internalpb.ImportJobState_Completed, internalpb.ImportJobState_Failed))
 	if executingNum >= maxNum {
-		resp.Status = merr.Status(merr.WrapErrImportFailed(
+		return nil, merr.WrapErrImportFailed(
 			fmt.Sprintf("The number of jobs has reached the limit, please try again later. " +
 				"If your request is set to only import a single file, " +
-				"please consider importing multiple files in one request for better efficiency.")))
-		return resp, nil
+				"please consider importing multiple files in one request for better efficiency."))
 	}
 
 	// Allocate file ids.
 	idStart, _, err := s.allocator.AllocN(int64(len(files)) + 1)
 	if err != nil {
-		resp.Status = merr.Status(merr.WrapErrImportFailed(fmt.Sprint("alloc id failed, err=%w", err)))
-		return resp, nil
+		return nil, merr.WrapErrImportFailed(fmt.Sprint("alloc id failed, err=%w", err))
 	}
 	files = lo.Map(files, func(importFile *internalpb.ImportFile, i int) *internalpb.ImportFile {
 		importFile.Id = idStart + int64(i) + 1
@@ -1739,13 +1791,10 @@ func (s *Server) ImportV2(ctx context.Context, in *internalpb.ImportRequestInter
 	}
 	err = s.importMeta.AddJob(ctx, job)
 	if err != nil {
-		resp.Status = merr.Status(merr.WrapErrImportFailed(fmt.Sprint("add import job failed, err=%w", err)))
-		return resp, nil
+		return nil, merr.WrapErrImportFailed(fmt.Sprint("add import job failed, err=%w", err))
 	}
 
-	resp.JobID = fmt.Sprint(job.GetJobID())
-	log.Info("add import job done", zap.Int64("jobID", job.GetJobID()), zap.Any("files", files))
-	return resp, nil
+	return job, nil
 }
 
 func (s *Server) GetImportProgress(ctx context.Context, in *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error) {
@@ -1815,3 +1864,288 @@ func (s *Server) ListImports(ctx context.Context, req *internalpb.ListImportsReq
 	}
 	return resp, nil
 }
+
+// CreateBackup snapshots the meta of the collection, the binlogs referenced by the backup
+// are protected from garbage collection until the backup is dropped.
+func (s *Server) CreateBackup(ctx context.Context, req *datapb.CreateBackupRequest) (*datapb.CreateBackupResponse, error) {
+	log := log.Ctx(ctx).With(zap.String("backup", req.GetName()), zap.Int64("collectionID", req.GetCollectionID()))
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.CreateBackupResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetName() == "" {
+		return &datapb.CreateBackupResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("backup name is empty")),
+		}, nil
+	}
+	if s.meta.backupMeta.GetBackup(req.GetName()) != nil {
+		return &datapb.CreateBackupResponse{
+			Status: merr.Status(merr.WrapErrBackupAlreadyExist(req.GetName())),
+		}, nil
+	}
+
+	backup, err := s.buildBackup(ctx, req.GetName(), req.GetCollectionID())
+	if err != nil {
+		log.Warn("failed to build backup", zap.Error(err))
+		return &datapb.CreateBackupResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	// the segments dropped between building and saving the backup are still kept for the drop tolerance,
+	// so they are protected once the backup is saved.
+	if err := s.meta.backupMeta.AddBackup(backup); err != nil {
+		return &datapb.CreateBackupResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	log.Info("create backup done", zap.Uint64("backupTs", backup.GetBackupTs()), zap.Int("segmentNum", len(backup.GetSegments())))
+	return &datapb.CreateBackupResponse{
+		Status: merr.Success(),
+		Backup: backup,
+	}, nil
+}
+
+// RestoreBackup restores the backup into the collection by importing the referenced binlogs, one import job per partition.
+// The collection must be created with the schema and partitions of the backup and not loaded,
+// the load state is checked by the proxy, which datacoord has no access to.
+func (s *Server) RestoreBackup(ctx context.Context, req *datapb.RestoreBackupRequest) (*datapb.RestoreBackupResponse, error) {
+	log := log.Ctx(ctx).With(zap.String("backup", req.GetName()), zap.Int64("collectionID", req.GetCollectionID()))
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.RestoreBackupResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	resp := &datapb.RestoreBackupResponse{
+		Status: merr.Success(),
+	}
+	backup := s.meta.backupMeta.GetBackup(req.GetName())
+	if backup == nil {
+		resp.Status = merr.Status(merr.WrapErrBackupNotFound(req.GetName()))
+		return resp, nil
+	}
+	coll, err := s.handler.GetCollection(ctx, req.GetCollectionID())
+	if err != nil {
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	if coll == nil {
+		resp.Status = merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionID()))
+		return resp, nil
+	}
+	if err := checkRestoreSchema(backup.GetSchema(), coll.Schema); err != nil {
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	partitionNames, err := s.showPartitionNames(ctx, req.GetCollectionID())
+	if err != nil {
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	partitionIDs := lo.Invert(partitionNames)
+
+	files := buildRestoreFiles(s.meta.chunkManager.RootPath(), backup)
+	targets := make(map[int64]int64, len(files))
+	for partitionID := range files {
+		name := backup.GetPartitionNames()[partitionID]
+		target, ok := partitionIDs[name]
+		if !ok {
+			resp.Status = merr.Status(merr.WrapErrPartitionNotFound(name, "partition of the backup not found in the collection"))
+			return resp, nil
+		}
+		targets[partitionID] = target
+	}
+
+	if err := s.restoreIndexes(ctx, backup, req.GetCollectionID()); err != nil {
+		log.Warn("failed to restore indexes", zap.Error(err))
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+
+	for partitionID, partitionFiles := range files {
+		job, err := s.addImportJob(ctx, &internalpb.ImportRequestInternal{
+			DbID:           coll.DatabaseID,
+			CollectionID:   req.GetCollectionID(),
+			CollectionName: coll.Schema.GetName(),
+			PartitionIDs:   []int64{targets[partitionID]},
+			ChannelNames:   coll.VChannelNames,
+			Schema:         coll.Schema,
+			Options:        buildRestoreOptions(backup),
+		}, partitionFiles, math.MaxUint64)
+		if err != nil {
+			log.Warn("failed to add restore job", zap.Int64("partitionID", partitionID), zap.Error(err))
+			resp.Status = merr.Status(err)
+			return resp, nil
+		}
+		resp.JobIDs = append(resp.JobIDs, job.GetJobID())
+	}
+
+	log.Info("restore backup started", zap.Int64s("jobIDs", resp.GetJobIDs()))
+	return resp, nil
+}
+
+func (s *Server) ListBackups(ctx context.Context, req *datapb.ListBackupsRequest) (*datapb.ListBackupsResponse, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.ListBackupsResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	return &datapb.ListBackupsResponse{
+		Status:  merr.Success(),
+		Backups: s.meta.backupMeta.ListBackups(req.GetCollectionID()),
+	}, nil
+}
+
+// DropBackup drops the backup and releases the gc protection of the binlogs it references.
+func (s *Server) DropBackup(ctx context.Context, req *datapb.DropBackupRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	if err := s.meta.backupMeta.DropBackup(req.GetName()); err != nil {
+		return merr.Status(err), nil
+	}
+	log.Ctx(ctx).Info("drop backup done", zap.String("backup", req.GetName()))
+	return merr.Success(), nil
+}
+
+// InspectChannelCheckpoints returns the checkpoints of the channels of the collection with their lag
+// and the consistency issues against the seal positions of the segments.
+func (s *Server) InspectChannelCheckpoints(ctx context.Context, req *datapb.InspectChannelCheckpointsRequest) (*datapb.InspectChannelCheckpointsResponse, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.InspectChannelCheckpointsResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	channels, err := s.getCollectionChannels(ctx, req.GetCollectionID(), req.GetChannel())
+	if err != nil {
+		return &datapb.InspectChannelCheckpointsResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	now := time.Now()
+	checkpoints := make([]*datapb.ChannelCheckpointInfo, 0, len(channels))
+	for _, channel := range channels {
+		checkpoints = append(checkpoints, inspectChannelCheckpoint(ctx, s.meta, req.GetCollectionID(), channel, now))
+	}
+	return &datapb.InspectChannelCheckpointsResponse{
+		Status:      merr.Success(),
+		Checkpoints: checkpoints,
+	}, nil
+}
+
+// RepairChannelCheckpoint resets or advances the checkpoint of the channel, e.g. after mq data loss.
+// The target position is only checked unless confirmed, and it is not applied if it has consistency issues
+// unless forced. It is refused while a datanode watches the channel, which would overwrite the repaired checkpoint,
+// the new checkpoint takes effect when the channel is watched next time.
+func (s *Server) RepairChannelCheckpoint(ctx context.Context, req *datapb.RepairChannelCheckpointRequest) (*datapb.RepairChannelCheckpointResponse, error) {
+	log := log.Ctx(ctx).With(zap.Int64("collectionID", req.GetCollectionID()), zap.String("channel", req.GetChannel()),
+		zap.String("action", req.GetAction().String()))
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.RepairChannelCheckpointResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetChannel() == "" {
+		return &datapb.RepairChannelCheckpointResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("channel is empty")),
+		}, nil
+	}
+	if _, err := s.getCollectionChannels(ctx, req.GetCollectionID(), req.GetChannel()); err != nil {
+		return &datapb.RepairChannelCheckpointResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	info := inspectChannelCheckpoint(ctx, s.meta, req.GetCollectionID(), req.GetChannel(), time.Now())
+	if info.GetCheckpoint().GetTimestamp() == math.MaxUint64 {
+		return &datapb.RepairChannelCheckpointResponse{
+			Status: merr.Status(merr.WrapErrChannelNotAvailable(req.GetChannel(), "channel is dropped")),
+		}, nil
+	}
+
+	var target *msgpb.MsgPosition
+	switch req.GetAction() {
+	case datapb.ChannelCheckpointRepairAction_ResetToFlushed:
+		if info.GetMaxFlushedPosition() == nil {
+			return &datapb.RepairChannelCheckpointResponse{
+				Status: merr.Status(merr.WrapErrParameterInvalidMsg("no flushed segment to reset the checkpoint to")),
+			}, nil
+		}
+		flushed := info.GetMaxFlushedPosition()
+		target = &msgpb.MsgPosition{
+			MsgID:     flushed.GetMsgID(),
+			MsgGroup:  flushed.GetMsgGroup(),
+			Timestamp: flushed.GetTimestamp(),
+		}
+	case datapb.ChannelCheckpointRepairAction_SetPosition:
+		target = req.GetPosition()
+		if len(target.GetMsgID()) == 0 || target.GetTimestamp() == 0 {
+			return &datapb.RepairChannelCheckpointResponse{
+				Status: merr.Status(merr.WrapErrParameterInvalidMsg("target position must have msgID and timestamp")),
+			}, nil
+		}
+		if tsoutil.PhysicalTime(target.GetTimestamp()).After(time.Now()) {
+			return &datapb.RepairChannelCheckpointResponse{
+				Status: merr.Status(merr.WrapErrParameterInvalidMsg("target position ts %d is in the future", target.GetTimestamp())),
+			}, nil
+		}
+	default:
+		return &datapb.RepairChannelCheckpointResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("unknown repair action %s", req.GetAction().String())),
+		}, nil
+	}
+	target.ChannelName = req.GetChannel()
+
+	resp := &datapb.RepairChannelCheckpointResponse{
+		Status:        merr.Success(),
+		OldCheckpoint: info.GetCheckpoint(),
+		NewCheckpoint: target,
+		Issues:        checkRepairTarget(info, target),
+	}
+	if !req.GetConfirm() || (len(resp.GetIssues()) > 0 && !req.GetForce()) {
+		log.Info("channel checkpoint repair not applied", zap.Bool("confirm", req.GetConfirm()), zap.Strings("issues", resp.GetIssues()))
+		return resp, nil
+	}
+
+	if nodeID, err := s.channelManager.FindWatcher(req.GetChannel()); err == nil {
+		err := merr.WrapErrChannelNotAvailable(req.GetChannel(),
+			fmt.Sprintf("channel is watched by datanode %d, the checkpoint can only be repaired when the channel is not watched", nodeID))
+		log.Warn("failed to repair channel checkpoint", zap.Error(err))
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+
+	if err := s.meta.ResetChannelCheckpoint(ctx, req.GetChannel(), target); err != nil {
+		log.Warn("failed to repair channel checkpoint", zap.Error(err))
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	resp.Applied = true
+	log.Info("channel checkpoint repaired",
+		zap.Uint64("oldTs", info.GetCheckpoint().GetTimestamp()),
+		zap.Uint64("newTs", target.GetTimestamp()),
+		zap.Bool("force", req.GetForce()),
+		zap.Strings("issues", resp.GetIssues()))
+	return resp, nil
+}
+
+// getCollectionChannels returns the channels of the collection, or the given channel if it belongs to the collection.
+func (s *Server) getCollectionChannels(ctx context.Context, collectionID int64, channel string) ([]string, error) {
+	coll, err := s.handler.GetCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if coll == nil {
+		return nil, merr.WrapErrCollectionNotFound(collectionID)
+	}
+	if channel == "" {
+		return coll.VChannelNames, nil
+	}
+	if !lo.Contains(coll.VChannelNames, channel) {
+		return nil, merr.WrapErrChannelNotFound(channel, fmt.Sprintf("not a channel of collection %d", collectionID))
+	}
+	return []string{channel}, nil
+}
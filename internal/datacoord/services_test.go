@@ -724,7 +724,9 @@ func TestBroadcastAlteredCollection(t *testing.T) {
 	})
 
 	t.Run("test meta non exist", func(t *testing.T) {
-		s := &Server{meta: &meta{collections: make(map[UniqueID]*collectionInfo, 1)}}
+		b := broker.NewMockBroker(t)
+		b.EXPECT().InvalidateCollection(int64(1)).Return()
+		s := &Server{meta: &meta{collections: make(map[UniqueID]*collectionInfo, 1)}, broker: b}
 		s.stateCode.Store(commonpb.StateCode_Healthy)
 		ctx := context.Background()
 		req := &datapb.AlterCollectionRequest{
@@ -739,9 +741,11 @@ func TestBroadcastAlteredCollection(t *testing.T) {
 	})
 
 	t.Run("test update meta", func(t *testing.T) {
+		b := broker.NewMockBroker(t)
+		b.EXPECT().InvalidateCollection(int64(1)).Return()
 		s := &Server{meta: &meta{collections: map[UniqueID]*collectionInfo{
 			1: {ID: 1},
-		}}}
+		}}, broker: b}
 		s.stateCode.Store(commonpb.StateCode_Healthy)
 		ctx := context.Background()
 		req := &datapb.AlterCollectionRequest{
@@ -34,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/interceptor"
 	"github.com/milvus-io/milvus/pkg/util/lock"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -84,6 +85,9 @@ type DataNodeManagerImpl struct {
 		data map[int64]*Session
 	}
 	sessionCreator DataNodeCreatorFunc
+	// epoch returns the fencing epoch of current DataCoord,
+	// which is carried by the state-changing requests to let DataNodes reject a stale DataCoord.
+	epoch func() int64
 }
 
 // SessionOpt provides a way to set params in SessionManagerImpl
@@ -93,6 +97,10 @@ func WithDataNodeCreator(creator DataNodeCreatorFunc) SessionOpt {
 	return func(c *DataNodeManagerImpl) { c.sessionCreator = creator }
 }
 
+func WithFencingEpoch(epoch func() int64) SessionOpt {
+	return func(c *DataNodeManagerImpl) { c.epoch = epoch }
+}
+
 func defaultSessionCreator() DataNodeCreatorFunc {
 	return func(ctx context.Context, addr string, nodeID int64) (types.DataNodeClient, error) {
 		return grpcdatanodeclient.NewClient(ctx, addr, nodeID)
@@ -180,6 +188,14 @@ func (c *DataNodeManagerImpl) getClient(ctx context.Context, nodeID int64) (type
 	return session.GetOrCreateClient(ctx)
 }
 
+// withEpoch attaches the fencing epoch of DataCoord to the context of state-changing requests.
+func (c *DataNodeManagerImpl) withEpoch(ctx context.Context) context.Context {
+	if c.epoch == nil {
+		return ctx
+	}
+	return interceptor.WithFencingEpoch(ctx, c.epoch())
+}
+
 // Flush is a grpc interface. It will send req to nodeID asynchronously
 func (c *DataNodeManagerImpl) Flush(ctx context.Context, nodeID int64, req *datapb.FlushSegmentsRequest) {
 	go c.execFlush(ctx, nodeID, req)
@@ -195,7 +211,7 @@ func (c *DataNodeManagerImpl) execFlush(ctx context.Context, nodeID int64, req *
 	ctx, cancel := context.WithTimeout(ctx, flushTimeout)
 	defer cancel()
 
-	resp, err := cli.FlushSegments(ctx, req)
+	resp, err := cli.FlushSegments(c.withEpoch(ctx), req)
 	if err := merr.CheckRPCCall(resp, err); err != nil {
 		log.Error("flush call (perhaps partially) failed", zap.Error(err))
 	} else {
@@ -213,7 +229,7 @@ func (c *DataNodeManagerImpl) Compaction(ctx context.Context, nodeID int64, plan
 		return err
 	}
 
-	resp, err := cli.CompactionV2(ctx, plan)
+	resp, err := cli.CompactionV2(c.withEpoch(ctx), plan)
 	if err := merr.CheckRPCCall(resp, err); err != nil {
 		log.Warn("failed to execute compaction", zap.Int64("node", nodeID), zap.Error(err), zap.Int64("planID", plan.GetPlanID()))
 		return err
@@ -239,7 +255,7 @@ func (c *DataNodeManagerImpl) SyncSegments(ctx context.Context, nodeID int64, re
 
 	err = retry.Do(ctx, func() error {
 		// doesn't set timeout
-		resp, err := cli.SyncSegments(ctx, req)
+		resp, err := cli.SyncSegments(c.withEpoch(ctx), req)
 		if err := merr.CheckRPCCall(resp, err); err != nil {
 			log.Warn("failed to sync segments", zap.Error(err))
 			return err
@@ -363,7 +379,7 @@ func (c *DataNodeManagerImpl) FlushChannels(ctx context.Context, nodeID int64, r
 	}
 
 	log.Info("SessionManagerImpl.FlushChannels start")
-	resp, err := cli.FlushChannels(ctx, req)
+	resp, err := cli.FlushChannels(c.withEpoch(ctx), req)
 	err = merr.CheckRPCCall(resp, err)
 	if err != nil {
 		log.Warn("SessionManagerImpl.FlushChannels failed", zap.Error(err))
@@ -382,7 +398,7 @@ func (c *DataNodeManagerImpl) NotifyChannelOperation(ctx context.Context, nodeID
 	}
 	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().DataCoordCfg.ChannelOperationRPCTimeout.GetAsDuration(time.Second))
 	defer cancel()
-	resp, err := cli.NotifyChannelOperation(ctx, req)
+	resp, err := cli.NotifyChannelOperation(c.withEpoch(ctx), req)
 	if err := merr.CheckRPCCall(resp, err); err != nil {
 		log.Warn("Notify channel operations failed", zap.Error(err))
 		return err
@@ -428,7 +444,7 @@ func (c *DataNodeManagerImpl) PreImport(nodeID int64, in *datapb.PreImportReques
 		log.Info("failed to get client", zap.Error(err))
 		return err
 	}
-	status, err := cli.PreImport(ctx, in)
+	status, err := cli.PreImport(c.withEpoch(ctx), in)
 	return merr.CheckRPCCall(status, err)
 }
 
@@ -446,7 +462,7 @@ func (c *DataNodeManagerImpl) ImportV2(nodeID int64, in *datapb.ImportRequest) e
 		log.Info("failed to get client", zap.Error(err))
 		return err
 	}
-	status, err := cli.ImportV2(ctx, in)
+	status, err := cli.ImportV2(c.withEpoch(ctx), in)
 	return merr.CheckRPCCall(status, err)
 }
 
@@ -503,7 +519,7 @@ func (c *DataNodeManagerImpl) DropImport(nodeID int64, in *datapb.DropImportRequ
 		log.Info("failed to get client", zap.Error(err))
 		return err
 	}
-	status, err := cli.DropImport(ctx, in)
+	status, err := cli.DropImport(c.withEpoch(ctx), in)
 	return merr.CheckRPCCall(status, err)
 }
 
@@ -568,7 +584,7 @@ func (c *DataNodeManagerImpl) DropCompactionPlan(nodeID int64, req *datapb.DropC
 		ctx, cancel := context.WithTimeout(context.Background(), paramtable.Get().DataCoordCfg.CompactionRPCTimeout.GetAsDuration(time.Second))
 		defer cancel()
 
-		resp, err := cli.DropCompactionPlan(ctx, req)
+		resp, err := cli.DropCompactionPlan(c.withEpoch(ctx), req)
 		if err := merr.CheckRPCCall(resp, err); err != nil {
 			log.Warn("failed to drop compaction plan", zap.Error(err))
 			return err
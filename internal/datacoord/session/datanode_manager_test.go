@@ -23,11 +23,13 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/interceptor"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/testutils"
@@ -122,6 +124,28 @@ func (s *DataNodeManagerSuite) TestNotifyChannelOperation() {
 	})
 }
 
+func (s *DataNodeManagerSuite) TestFencingEpoch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.m = NewDataNodeManagerImpl(
+		WithDataNodeCreator(func(ctx context.Context, addr string, nodeID int64) (types.DataNodeClient, error) {
+			return s.dn, nil
+		}),
+		WithFencingEpoch(func() int64 { return 10 }),
+	)
+	s.m.AddSession(&NodeInfo{1000, "addr-1", true})
+
+	withEpoch := mock.MatchedBy(func(ctx context.Context) bool {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		return ok && len(md.Get(interceptor.FencingEpochKey)) == 1 && md.Get(interceptor.FencingEpochKey)[0] == "10"
+	})
+	s.dn.EXPECT().NotifyChannelOperation(withEpoch, mock.Anything).Return(merr.Status(nil), nil).Once()
+
+	err := s.m.NotifyChannelOperation(ctx, 1000, &datapb.ChannelOperationsRequest{})
+	s.NoError(err)
+}
+
 func (s *DataNodeManagerSuite) TestCheckCHannelOperationProgress() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -189,6 +189,11 @@ func (it *indexBuildTask) PreCheck(ctx context.Context, dependency *taskSchedule
 		log.Ctx(ctx).Info("index builder get collection info failed", zap.Int64("collectionID", segment.GetCollectionID()), zap.Error(err))
 		return false
 	}
+	if !isCollectionInMaintenanceWindow(collectionInfo, time.Now()) {
+		log.Ctx(ctx).RatedInfo(60, "collection out of maintenance window, delay building index",
+			zap.Int64("taskID", it.taskID), zap.Int64("collectionID", segment.GetCollectionID()))
+		return false
+	}
 
 	schema := collectionInfo.Schema
 	var field *schemapb.FieldSchema
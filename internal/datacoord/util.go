@@ -194,6 +194,19 @@ func getCollectionAutoCompactionEnabled(properties map[string]string) (bool, err
 	return Params.DataCoordCfg.EnableAutoCompaction.GetAsBool(), nil
 }
 
+// isCollectionInMaintenanceWindow returns whether background maintenance (auto compaction, index builds)
+// of the collection is allowed at the given time. An invalid window is ignored rather than
+// blocking maintenance forever.
+func isCollectionInMaintenanceWindow(coll *collectionInfo, now time.Time) bool {
+	windows, err := common.GetCollectionMaintenanceWindows(coll.Properties)
+	if err != nil {
+		log.RatedWarn(60, "collection maintenance window not valid, ignore it",
+			zap.Int64("collectionID", coll.ID), zap.Error(err))
+		return true
+	}
+	return windows.Contains(now)
+}
+
 func GetIndexType(indexParams []*commonpb.KeyValuePair) string {
 	for _, param := range indexParams {
 		if param.Key == common.IndexTypeKey {
@@ -190,6 +190,23 @@ func (suite *UtilSuite) TestGetCollectionAutoCompactionEnabled() {
 	suite.Equal(Params.DataCoordCfg.EnableAutoCompaction.GetAsBool(), enabled)
 }
 
+func (suite *UtilSuite) TestIsCollectionInMaintenanceWindow() {
+	now := time.Date(2024, 1, 1, 3, 0, 0, 0, time.Local)
+
+	coll := &collectionInfo{ID: 1, Properties: map[string]string{}}
+	suite.True(isCollectionInMaintenanceWindow(coll, now))
+
+	coll.Properties[common.CollectionMaintenanceWindowKey] = "01:00-05:00"
+	suite.True(isCollectionInMaintenanceWindow(coll, now))
+
+	coll.Properties[common.CollectionMaintenanceWindowKey] = "sat,sun 01:00-05:00"
+	suite.False(isCollectionInMaintenanceWindow(coll, now))
+
+	// invalid window never blocks maintenance
+	coll.Properties[common.CollectionMaintenanceWindowKey] = "bad_value"
+	suite.True(isCollectionInMaintenanceWindow(coll, now))
+}
+
 func (suite *UtilSuite) TestCalculateL0SegmentSize() {
 	logsize := int64(100)
 	fields := []*datapb.FieldBinlog{{
@@ -0,0 +1,309 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/datanode/compaction"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// dedupSegment is an existing segment the imported primary keys are checked against.
+// The pk stats filter out most of the absent keys, the bloom filter hits of each batch are confirmed
+// by scanning the primary keys and the timestamps of the segment, and the deletes applied to it.
+type dedupSegment struct {
+	segmentID int64
+	stats     []*storage.PkStatistics
+	// the pk binlogs and the timestamp binlogs of the same rows, which are scanned in pairs
+	pkBinlogs []string
+	tsBinlogs []string
+	// the deltalogs of the segment and of the L0 segments of its channel and partition
+	deltalogs []string
+}
+
+// pkDeduplicator checks imported primary keys against the existing segments and the rows imported before
+// by the same task. Only the bloom filter hits of a batch are kept while scanning the binlogs, so the memory is
+// bounded by the batch and a binlog file rather than by the segments. The primary keys imported by the task are
+// kept to skip the duplicates within the import, which are bounded by the rows of the task.
+type pkDeduplicator struct {
+	ctx      context.Context
+	cm       storage.ChunkManager
+	pkField  *schemapb.FieldSchema
+	segments []*dedupSegment
+
+	mu       sync.Mutex
+	imported typeutil.Set[any]
+}
+
+func newPkDeduplicator(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema,
+	segments []*datapb.ImportDedupSegment, l0Segments []*datapb.ImportDedupSegment,
+) (*pkDeduplicator, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	logPaths := func(fieldBinlogs []*datapb.FieldBinlog) []string {
+		paths := make([]string, 0)
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				paths = append(paths, binlog.GetLogPath())
+			}
+		}
+		return paths
+	}
+	dedupSegments := make([]*dedupSegment, 0, len(segments))
+	for _, segment := range segments {
+		segmentStats, err := compaction.LoadStats(ctx, cm, schema, segment.GetSegmentID(), segment.GetStatslogs())
+		if err != nil {
+			return nil, err
+		}
+		pkBinlogs, tsBinlogs := logPaths(segment.GetPkBinlogs()), logPaths(segment.GetTsBinlogs())
+		if len(pkBinlogs) != len(tsBinlogs) {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("segment %d has %d pk binlogs but %d timestamp binlogs",
+				segment.GetSegmentID(), len(pkBinlogs), len(tsBinlogs)))
+		}
+		deltalogs := logPaths(segment.GetDeltalogs())
+		for _, l0Segment := range l0Segments {
+			if l0Segment.GetVchannel() == segment.GetVchannel() &&
+				(l0Segment.GetPartitionID() == common.AllPartitionsID || l0Segment.GetPartitionID() == segment.GetPartitionID()) {
+				deltalogs = append(deltalogs, logPaths(l0Segment.GetDeltalogs())...)
+			}
+		}
+		dedupSegments = append(dedupSegments, &dedupSegment{
+			segmentID: segment.GetSegmentID(),
+			stats:     segmentStats,
+			pkBinlogs: pkBinlogs,
+			tsBinlogs: tsBinlogs,
+			deltalogs: deltalogs,
+		})
+	}
+	return &pkDeduplicator{
+		ctx:      ctx,
+		cm:       cm,
+		pkField:  pkField,
+		segments: dedupSegments,
+		imported: typeutil.NewSet[any](),
+	}, nil
+}
+
+// existing returns the primary keys of the batch which exist in the segments and are not deleted.
+func (d *pkDeduplicator) existing(pks []storage.PrimaryKey) (typeutil.Set[any], error) {
+	existing := typeutil.NewSet[any]()
+	for _, segment := range d.segments {
+		candidates := typeutil.NewSet[any]()
+		for _, pk := range pks {
+			if existing.Contain(pk.GetValue()) {
+				continue
+			}
+			if lo.ContainsBy(segment.stats, func(stat *storage.PkStatistics) bool { return stat.PkExist(pk) }) {
+				candidates.Insert(pk.GetValue())
+			}
+		}
+		if candidates.Len() == 0 {
+			continue
+		}
+		// bloom filter hits, confirm them by the rows of the segment which are not deleted
+		deleted, err := d.loadDeletes(segment, candidates)
+		if err != nil {
+			return nil, err
+		}
+		err = d.scanRows(segment, func(pk any, ts int64) {
+			// a delete removes the rows inserted before it
+			if candidates.Contain(pk) && deleted[pk] <= uint64(ts) {
+				existing.Insert(pk)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// loadDeletes returns the latest delete timestamps of the candidates in the deltalogs of the segment.
+func (d *pkDeduplicator) loadDeletes(segment *dedupSegment, candidates typeutil.Set[any]) (map[any]uint64, error) {
+	deleted := make(map[any]uint64)
+	for _, path := range segment.deltalogs {
+		bytes, err := d.cm.Read(d.ctx, path)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read deltalog %s of segment %d, error: %v", path, segment.segmentID, err))
+		}
+		reader, err := storage.CreateDeltalogReader([]*storage.Blob{{Key: path, Value: bytes}})
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to create reader, deltalog:%s, error:%v", path, err))
+		}
+		for {
+			err = reader.Next()
+			if err != nil {
+				break
+			}
+			dl := reader.Value()
+			pk := dl.Pk.GetValue()
+			if candidates.Contain(pk) && dl.Ts > deleted[pk] {
+				deleted[pk] = dl.Ts
+			}
+		}
+		reader.Close()
+		if err != io.EOF {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read deltalog %s, error: %v", path, err))
+		}
+	}
+	return deleted, nil
+}
+
+// scanRows calls fn with the primary key and the timestamp of each row of the segment, one binlog at a time.
+func (d *pkDeduplicator) scanRows(segment *dedupSegment, fn func(pk any, ts int64)) error {
+	for i, path := range segment.pkBinlogs {
+		pks, err := d.readBinlog(segment, path)
+		if err != nil {
+			return err
+		}
+		tss, err := d.readBinlog(segment, segment.tsBinlogs[i])
+		if err != nil {
+			return err
+		}
+		timestamps, ok := tss.([]int64)
+		if !ok {
+			return merr.WrapErrImportFailed(fmt.Sprintf("unexpected timestamp type %T", tss))
+		}
+		switch values := pks.(type) {
+		case []int64:
+			if len(values) != len(timestamps) {
+				return merr.WrapErrImportFailed(fmt.Sprintf("binlog %s has %d rows but %d timestamps", path, len(values), len(timestamps)))
+			}
+			for j, v := range values {
+				fn(v, timestamps[j])
+			}
+		case []string:
+			if len(values) != len(timestamps) {
+				return merr.WrapErrImportFailed(fmt.Sprintf("binlog %s has %d rows but %d timestamps", path, len(values), len(timestamps)))
+			}
+			for j, v := range values {
+				fn(v, timestamps[j])
+			}
+		default:
+			return merr.WrapErrImportFailed(fmt.Sprintf("unexpected primary key type %T", pks))
+		}
+	}
+	return nil
+}
+
+// readBinlog returns the rows of all the events in the binlog, []int64 or []string.
+func (d *pkDeduplicator) readBinlog(segment *dedupSegment, path string) (any, error) {
+	bytes, err := d.cm.Read(d.ctx, path)
+	if err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read binlog %s of segment %d, error: %v", path, segment.segmentID, err))
+	}
+	reader, err := storage.NewBinlogReader(bytes)
+	if err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to create reader, binlog:%s, error:%v", path, err))
+	}
+	defer reader.Close()
+
+	var int64s []int64
+	var strs []string
+	isString := false
+	for {
+		event, err := reader.NextEventReader()
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to iterate events reader, error: %v", err))
+		}
+		if event == nil {
+			break
+		}
+		rows, _, _, err := event.PayloadReaderInterface.GetDataFromPayload()
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read data, error: %v", err))
+		}
+		switch values := rows.(type) {
+		case []int64:
+			int64s = append(int64s, values...)
+		case []string:
+			isString = true
+			strs = append(strs, values...)
+		default:
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("unexpected binlog data type %T", rows))
+		}
+	}
+	if isString {
+		return strs, nil
+	}
+	return int64s, nil
+}
+
+// Filter returns the rows whose primary keys don't exist in the existing segments and were not imported
+// before by the task, and the number of skipped rows.
+func (d *pkDeduplicator) Filter(schema *schemapb.CollectionSchema, data *storage.InsertData) (*storage.InsertData, int64, error) {
+	pkData, ok := data.Data[d.pkField.GetFieldID()]
+	if !ok {
+		return data, 0, nil
+	}
+	pks := make([]storage.PrimaryKey, 0, pkData.RowNum())
+	for i := 0; i < pkData.RowNum(); i++ {
+		pk, err := storage.GenPrimaryKeyByRawData(pkData.GetRow(i), d.pkField.GetDataType())
+		if err != nil {
+			return nil, 0, err
+		}
+		pks = append(pks, pk)
+	}
+	existing, err := d.existing(pks)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the files of the task are imported concurrently, the first imported row of a pk is kept
+	d.mu.Lock()
+	kept := make([]bool, len(pks))
+	var duplicated int64
+	for i, pk := range pks {
+		if existing.Contain(pk.GetValue()) || d.imported.Contain(pk.GetValue()) {
+			duplicated++
+			continue
+		}
+		d.imported.Insert(pk.GetValue())
+		kept[i] = true
+	}
+	d.mu.Unlock()
+
+	if duplicated == 0 {
+		return data, 0, nil
+	}
+	res, err := storage.NewInsertDataWithFunctionOutputField(typeutil.AppendSystemFields(schema))
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range pks {
+		if !kept[i] {
+			continue
+		}
+		if err = res.Append(data.GetRow(i)); err != nil {
+			return nil, 0, err
+		}
+	}
+	return res, duplicated, nil
+}
@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/bloomfilter"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func Test_PkDeduplicator(t *testing.T) {
+	paramtable.Init()
+
+	pkField := &schemapb.FieldSchema{
+		FieldID:      100,
+		Name:         "pk",
+		IsPrimaryKey: true,
+		DataType:     schemapb.DataType_Int64,
+	}
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			pkField,
+			{
+				FieldID:  101,
+				Name:     "int32",
+				DataType: schemapb.DataType_Int32,
+			},
+		},
+	}
+
+	// the existing segment holds pk 1, 3 and 5 inserted at ts 10, and pk 5 is deleted at ts 20
+	insertCodec := storage.NewInsertCodecWithSchema(&etcdpb.CollectionMeta{ID: 1, Schema: &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+			pkField,
+		},
+	}})
+	blobs, err := insertCodec.Serialize(2, 3, &storage.InsertData{Data: map[int64]storage.FieldData{
+		common.TimeStampField: &storage.Int64FieldData{Data: []int64{10, 10, 10}},
+		100:                   &storage.Int64FieldData{Data: []int64{1, 3, 5}},
+	}})
+	assert.NoError(t, err)
+	deltaBlob, err := storage.NewDeleteCodec().Serialize(1, 2, 3,
+		storage.NewDeleteData([]storage.PrimaryKey{storage.NewInt64PrimaryKey(5)}, []uint64{20}))
+	assert.NoError(t, err)
+	files := map[string][]byte{
+		"ts":    blobs[0].GetValue(),
+		"pk":    blobs[1].GetValue(),
+		"delta": deltaBlob.GetValue(),
+	}
+	cm := mocks.NewChunkManager(t)
+	reads := 0
+	cm.EXPECT().Read(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, path string) ([]byte, error) {
+		reads++
+		if bytes, ok := files[path]; ok {
+			return bytes, nil
+		}
+		return nil, errors.New("mock read error")
+	}).Maybe()
+
+	stats := &storage.PkStatistics{
+		PkFilter: bloomfilter.NewBloomFilterWithType(paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint(),
+			paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(),
+			paramtable.Get().CommonCfg.BloomFilterType.GetValue()),
+	}
+	// pk 7 hits the bloom filter only, like a false positive, and shall not be skipped
+	err = stats.UpdatePKRange(&storage.Int64FieldData{Data: []int64{1, 3, 5, 7}})
+	assert.NoError(t, err)
+	segment := &dedupSegment{
+		segmentID: 3,
+		stats:     []*storage.PkStatistics{stats},
+		pkBinlogs: []string{"pk"},
+		tsBinlogs: []string{"ts"},
+		deltalogs: []string{"delta"},
+	}
+	dedup := &pkDeduplicator{ctx: context.Background(), cm: cm, pkField: pkField,
+		segments: []*dedupSegment{segment}, imported: typeutil.NewSet[any]()}
+
+	data := &storage.InsertData{Data: map[int64]storage.FieldData{
+		100:                   &storage.Int64FieldData{Data: []int64{1, 2, 3, 5, 7, 2}},
+		101:                   &storage.Int32FieldData{Data: []int32{10, 20, 30, 50, 70, 21}},
+		common.RowIDField:     &storage.Int64FieldData{Data: []int64{0, 1, 2, 3, 4, 5}},
+		common.TimeStampField: &storage.Int64FieldData{Data: []int64{1, 1, 1, 1, 1, 1}},
+	}}
+	// 1 and 3 exist, the deleted 5 is imported again, and the second 2 is duplicated within the import
+	res, duplicated, err := dedup.Filter(schema, data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), duplicated)
+	assert.Equal(t, []int64{2, 5, 7}, res.Data[100].(*storage.Int64FieldData).Data)
+	assert.Equal(t, []int32{20, 50, 70}, res.Data[101].(*storage.Int32FieldData).Data)
+	assert.Equal(t, 3, reads)
+
+	// the rows imported by the previous batch are duplicated, no binlog is read without bloom filter hits
+	data = &storage.InsertData{Data: map[int64]storage.FieldData{
+		100:                   &storage.Int64FieldData{Data: []int64{5, 8}},
+		101:                   &storage.Int32FieldData{Data: []int32{51, 80}},
+		common.RowIDField:     &storage.Int64FieldData{Data: []int64{6, 7}},
+		common.TimeStampField: &storage.Int64FieldData{Data: []int64{1, 1}},
+	}}
+	res, duplicated, err = dedup.Filter(schema, data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), duplicated)
+	assert.Equal(t, []int64{8}, res.Data[100].(*storage.Int64FieldData).Data)
+
+	// failed to read the binlogs of the segment
+	segment.pkBinlogs = []string{"missing"}
+	dedup.imported = typeutil.NewSet[any]()
+	_, _, err = dedup.Filter(schema, data)
+	assert.Error(t, err)
+
+	// no existing segment, only the duplicates within the import are skipped
+	dedup = &pkDeduplicator{pkField: pkField, imported: typeutil.NewSet[any]()}
+	data = &storage.InsertData{Data: map[int64]storage.FieldData{
+		100:                   &storage.Int64FieldData{Data: []int64{1, 2, 1}},
+		101:                   &storage.Int32FieldData{Data: []int32{10, 20, 11}},
+		common.RowIDField:     &storage.Int64FieldData{Data: []int64{0, 1, 2}},
+		common.TimeStampField: &storage.Int64FieldData{Data: []int64{1, 1, 1}},
+	}}
+	res, duplicated, err = dedup.Filter(schema, data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), duplicated)
+	assert.Equal(t, []int64{1, 2}, res.Data[100].(*storage.Int64FieldData).Data)
+}
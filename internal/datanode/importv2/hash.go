@@ -22,6 +22,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -54,12 +55,16 @@ func HashData(task Task, rows *storage.InsertData) (HashedData, error) {
 		return nil, err
 	}
 	partKeyField, _ := typeutil.GetPartitionKeyFieldSchema(schema)
+	hashFunction, err := common.PartitionKeyHashFunction(task.GetSchema().GetProperties()...)
+	if err != nil {
+		return nil, err
+	}
 
 	id1 := pkField.GetFieldID()
 	id2 := partKeyField.GetFieldID()
 
 	f1 := hashByVChannel(int64(channelNum), pkField)
-	f2 := hashByPartition(int64(partitionNum), partKeyField)
+	f2 := hashByPartition(int64(partitionNum), partKeyField, hashFunction)
 
 	res, err := newHashedData(schema, channelNum, partitionNum)
 	if err != nil {
@@ -116,6 +121,10 @@ func GetRowsStats(task Task, rows *storage.InsertData) (map[string]*datapb.Parti
 		return nil, err
 	}
 	partKeyField, _ := typeutil.GetPartitionKeyFieldSchema(schema)
+	hashFunction, err := common.PartitionKeyHashFunction(task.GetSchema().GetProperties()...)
+	if err != nil {
+		return nil, err
+	}
 
 	id1 := pkField.GetFieldID()
 	id2 := partKeyField.GetFieldID()
@@ -132,7 +141,7 @@ func GetRowsStats(task Task, rows *storage.InsertData) (map[string]*datapb.Parti
 		id := int64(0)
 		num := int64(channelNum)
 		fn1 := hashByID()
-		fn2 := hashByPartition(int64(partitionNum), partKeyField)
+		fn2 := hashByPartition(int64(partitionNum), partKeyField, hashFunction)
 		rows.Data = lo.PickBy(rows.Data, func(fieldID int64, _ storage.FieldData) bool {
 			return fieldID != pkField.GetFieldID()
 		})
@@ -144,7 +153,7 @@ func GetRowsStats(task Task, rows *storage.InsertData) (map[string]*datapb.Parti
 		}
 	} else {
 		f1 := hashByVChannel(int64(channelNum), pkField)
-		f2 := hashByPartition(int64(partitionNum), partKeyField)
+		f2 := hashByPartition(int64(partitionNum), partKeyField, hashFunction)
 		for i := 0; i < rowNum; i++ {
 			row := rows.GetRow(i)
 			p1, p2 := f1(row[id1]), f2(row[id2])
@@ -235,7 +244,7 @@ func hashByVChannel(channelNum int64, pkField *schemapb.FieldSchema) func(pk any
 	}
 }
 
-func hashByPartition(partitionNum int64, partField *schemapb.FieldSchema) func(key any) int64 {
+func hashByPartition(partitionNum int64, partField *schemapb.FieldSchema, hashFunction string) func(key any) int64 {
 	if partitionNum == 1 {
 		return func(_ any) int64 {
 			return 0
@@ -244,12 +253,12 @@ func hashByPartition(partitionNum int64, partField *schemapb.FieldSchema) func(k
 	switch partField.GetDataType() {
 	case schemapb.DataType_Int64:
 		return func(key any) int64 {
-			hash, _ := typeutil.Hash32Int64(key.(int64))
+			hash := typeutil.HashInt64PartitionKey(key.(int64), hashFunction)
 			return int64(hash) % partitionNum
 		}
 	case schemapb.DataType_VarChar:
 		return func(key any) int64 {
-			hash := typeutil.HashString2Uint32(key.(string))
+			hash := typeutil.HashStringPartitionKey(key.(string), hashFunction)
 			return int64(hash) % partitionNum
 		}
 	default:
@@ -17,11 +17,14 @@
 package importv2
 
 import (
+	"strings"
+
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/util/conc"
 )
 
@@ -114,6 +117,14 @@ func UpdateFileStat(idx int, fileStat *datapb.ImportFileStats) UpdateAction {
 	}
 }
 
+func UpdateDuplicatedRows(file *internalpb.ImportFile, rows int64) UpdateAction {
+	return func(task Task) {
+		if it, ok := task.(*ImportTask); ok {
+			it.duplicatedRows[strings.Join(file.GetPaths(), ",")] = rows
+		}
+	}
+}
+
 func UpdateSegmentInfo(info *datapb.ImportSegmentInfo) UpdateAction {
 	mergeFn := func(current []*datapb.FieldBinlog, new []*datapb.FieldBinlog) []*datapb.FieldBinlog {
 		for _, binlog := range new {
@@ -48,6 +48,9 @@ type ImportTask struct {
 	segmentsInfo map[int64]*datapb.ImportSegmentInfo
 	req          *datapb.ImportRequest
 
+	dedup          *pkDeduplicator
+	duplicatedRows map[string]int64 // file -> rows skipped as duplicated
+
 	allocator  allocator.Interface
 	manager    TaskManager
 	syncMgr    syncmgr.SyncManager
@@ -75,14 +78,15 @@ func NewImportTask(req *datapb.ImportRequest,
 			CollectionID: req.GetCollectionID(),
 			State:        datapb.ImportTaskStateV2_Pending,
 		},
-		ctx:          ctx,
-		cancel:       cancel,
-		segmentsInfo: make(map[int64]*datapb.ImportSegmentInfo),
-		req:          req,
-		allocator:    alloc,
-		manager:      manager,
-		syncMgr:      syncMgr,
-		cm:           cm,
+		ctx:            ctx,
+		cancel:         cancel,
+		segmentsInfo:   make(map[int64]*datapb.ImportSegmentInfo),
+		req:            req,
+		duplicatedRows: make(map[string]int64),
+		allocator:      alloc,
+		manager:        manager,
+		syncMgr:        syncMgr,
+		cm:             cm,
 	}
 	task.metaCaches = NewMetaCache(req)
 	return task
@@ -123,19 +127,28 @@ func (t *ImportTask) GetSegmentsInfo() []*datapb.ImportSegmentInfo {
 	return lo.Values(t.segmentsInfo)
 }
 
+func (t *ImportTask) GetDuplicatedRows() map[string]int64 {
+	return t.duplicatedRows
+}
+
 func (t *ImportTask) Clone() Task {
 	ctx, cancel := context.WithCancel(t.ctx)
 	infos := make(map[int64]*datapb.ImportSegmentInfo)
 	for id, info := range t.segmentsInfo {
 		infos[id] = typeutil.Clone(info)
 	}
+	duplicatedRows := make(map[string]int64, len(t.duplicatedRows))
+	for file, rows := range t.duplicatedRows {
+		duplicatedRows[file] = rows
+	}
 	return &ImportTask{
-		ImportTaskV2: typeutil.Clone(t.ImportTaskV2),
-		ctx:          ctx,
-		cancel:       cancel,
-		segmentsInfo: infos,
-		req:          t.req,
-		metaCaches:   t.metaCaches,
+		ImportTaskV2:   typeutil.Clone(t.ImportTaskV2),
+		ctx:            ctx,
+		cancel:         cancel,
+		segmentsInfo:   infos,
+		req:            t.req,
+		duplicatedRows: duplicatedRows,
+		metaCaches:     t.metaCaches,
 	}
 }
 
@@ -148,6 +161,16 @@ func (t *ImportTask) Execute() []*conc.Future[any] {
 
 	req := t.req
 
+	if mode, _ := importutilv2.GetDedupMode(req.GetOptions()); mode == importutilv2.DedupSkip {
+		dedup, err := newPkDeduplicator(t.ctx, t.cm, t.GetSchema(), req.GetDedupSegments(), req.GetDedupL0Segments())
+		if err != nil {
+			log.Warn("load pk stats for dedup failed", WrapLogFields(t, zap.Error(err))...)
+			t.manager.Update(t.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_Failed), UpdateReason(err.Error()))
+			return []*conc.Future[any]{conc.Go(func() (any, error) { return err, err })}
+		}
+		t.dedup = dedup
+	}
+
 	fn := func(file *internalpb.ImportFile) error {
 		reader, err := importutilv2.NewReader(t.ctx, t.cm, t.GetSchema(), file, req.GetOptions(), bufferSize)
 		if err != nil {
@@ -157,7 +180,7 @@ func (t *ImportTask) Execute() []*conc.Future[any] {
 		}
 		defer reader.Close()
 		start := time.Now()
-		err = t.importFile(reader)
+		err = t.importFile(reader, file)
 		if err != nil {
 			log.Warn("do import failed", WrapLogFields(t, zap.String("file", file.String()), zap.Error(err))...)
 			t.manager.Update(t.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_Failed), UpdateReason(err.Error()))
@@ -180,9 +203,10 @@ func (t *ImportTask) Execute() []*conc.Future[any] {
 	return futures
 }
 
-func (t *ImportTask) importFile(reader importutilv2.Reader) error {
+func (t *ImportTask) importFile(reader importutilv2.Reader, file *internalpb.ImportFile) error {
 	syncFutures := make([]*conc.Future[struct{}], 0)
 	syncTasks := make([]syncmgr.Task, 0)
+	var duplicated int64
 	for {
 		data, err := reader.Read()
 		if err != nil {
@@ -195,6 +219,14 @@ func (t *ImportTask) importFile(reader importutilv2.Reader) error {
 		if err != nil {
 			return err
 		}
+		if t.dedup != nil {
+			var skipped int64
+			data, skipped, err = t.dedup.Filter(t.GetSchema(), data)
+			if err != nil {
+				return err
+			}
+			duplicated += skipped
+		}
 		if !importutilv2.IsBackup(t.req.GetOptions()) {
 			err = RunEmbeddingFunction(t, data)
 			if err != nil {
@@ -216,6 +248,11 @@ func (t *ImportTask) importFile(reader importutilv2.Reader) error {
 	if err != nil {
 		return err
 	}
+	if t.dedup != nil {
+		t.manager.Update(t.GetTaskID(), UpdateDuplicatedRows(file, duplicated))
+		log.Info("skip duplicated rows", WrapLogFields(t, zap.Strings("files", file.GetPaths()),
+			zap.Int64("duplicatedRows", duplicated))...)
+	}
 	for _, syncTask := range syncTasks {
 		segmentInfo, err := NewImportSegmentInfo(syncTask, t.metaCaches)
 		if err != nil {
@@ -525,6 +525,12 @@ func (node *DataNode) QueryImport(ctx context.Context, req *datapb.QueryImportRe
 	}
 	log.RatedInfo(10, "datanode query import", zap.String("state", task.GetState().String()),
 		zap.String("reason", task.GetReason()))
+	var duplicatedRows map[string]int64
+	if it, ok := task.(interface {
+		GetDuplicatedRows() map[string]int64
+	}); ok {
+		duplicatedRows = it.GetDuplicatedRows()
+	}
 	return &datapb.QueryImportResponse{
 		Status: status,
 		TaskID: task.GetTaskID(),
@@ -533,6 +539,7 @@ func (node *DataNode) QueryImport(ctx context.Context, req *datapb.QueryImportRe
 		ImportSegmentsInfo: task.(interface {
 			GetSegmentsInfo() []*datapb.ImportSegmentInfo
 		}).GetSegmentsInfo(),
+		DuplicatedRows: duplicatedRows,
 	}, nil
 }
 
@@ -779,3 +779,39 @@ func (c *Client) ListIndexes(ctx context.Context, in *indexpb.ListIndexesRequest
 		return client.ListIndexes(ctx, in)
 	})
 }
+
+func (c *Client) CreateBackup(ctx context.Context, in *datapb.CreateBackupRequest, opts ...grpc.CallOption) (*datapb.CreateBackupResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.CreateBackupResponse, error) {
+		return client.CreateBackup(ctx, in)
+	})
+}
+
+func (c *Client) RestoreBackup(ctx context.Context, in *datapb.RestoreBackupRequest, opts ...grpc.CallOption) (*datapb.RestoreBackupResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.RestoreBackupResponse, error) {
+		return client.RestoreBackup(ctx, in)
+	})
+}
+
+func (c *Client) ListBackups(ctx context.Context, in *datapb.ListBackupsRequest, opts ...grpc.CallOption) (*datapb.ListBackupsResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.ListBackupsResponse, error) {
+		return client.ListBackups(ctx, in)
+	})
+}
+
+func (c *Client) DropBackup(ctx context.Context, in *datapb.DropBackupRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*commonpb.Status, error) {
+		return client.DropBackup(ctx, in)
+	})
+}
+
+func (c *Client) InspectChannelCheckpoints(ctx context.Context, in *datapb.InspectChannelCheckpointsRequest, opts ...grpc.CallOption) (*datapb.InspectChannelCheckpointsResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.InspectChannelCheckpointsResponse, error) {
+		return client.InspectChannelCheckpoints(ctx, in)
+	})
+}
+
+func (c *Client) RepairChannelCheckpoint(ctx context.Context, in *datapb.RepairChannelCheckpointRequest, opts ...grpc.CallOption) (*datapb.RepairChannelCheckpointResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.RepairChannelCheckpointResponse, error) {
+		return client.RepairChannelCheckpoint(ctx, in)
+	})
+}
@@ -20,7 +20,6 @@ package grpcdatacoord
 import (
 	"context"
 	"sync"
-	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/tikv/client-go/v2/txnkv"
@@ -28,7 +27,6 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/keepalive"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -46,7 +44,6 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/tracer"
 	"github.com/milvus-io/milvus/pkg/util"
-	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/interceptor"
 	"github.com/milvus-io/milvus/pkg/util/logutil"
@@ -65,8 +62,9 @@ type Server struct {
 	grpcWG    sync.WaitGroup
 	dataCoord types.DataCoordComponent
 
-	etcdCli *clientv3.Client
-	tikvCli *txnkv.Client
+	etcdCli        *clientv3.Client
+	releaseEtcdCli func()
+	tikvCli        *txnkv.Client
 
 	grpcErrChan chan error
 	grpcServer  *grpc.Server
@@ -91,6 +89,8 @@ func (s *Server) Prepare() error {
 	listener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().DataCoordGrpcServerCfg.IP),
 		netutil.OptPort(paramtable.Get().DataCoordGrpcServerCfg.Port.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.InternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.InternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("DataCoord fail to create net listener", zap.Error(err))
@@ -103,24 +103,14 @@ func (s *Server) Prepare() error {
 
 func (s *Server) init() error {
 	params := paramtable.Get()
-	etcdConfig := &params.EtcdCfg
-
-	etcdCli, err := etcd.CreateEtcdClient(
-		etcdConfig.UseEmbedEtcd.GetAsBool(),
-		etcdConfig.EtcdEnableAuth.GetAsBool(),
-		etcdConfig.EtcdAuthUserName.GetValue(),
-		etcdConfig.EtcdAuthPassword.GetValue(),
-		etcdConfig.EtcdUseSSL.GetAsBool(),
-		etcdConfig.Endpoints.GetAsStrings(),
-		etcdConfig.EtcdTLSCert.GetValue(),
-		etcdConfig.EtcdTLSKey.GetValue(),
-		etcdConfig.EtcdTLSCACert.GetValue(),
-		etcdConfig.EtcdTLSMinVersion.GetValue())
+
+	etcdCli, releaseEtcdCli, err := coordclient.CreateEtcdClient()
 	if err != nil {
 		log.Debug("DataCoord connect to etcd failed", zap.Error(err))
 		return err
 	}
 	s.etcdCli = etcdCli
+	s.releaseEtcdCli = releaseEtcdCli
 	s.dataCoord.SetEtcdClient(etcdCli)
 	s.dataCoord.SetAddress(s.listener.Address())
 
@@ -165,15 +155,8 @@ func (s *Server) startGrpcLoop() {
 	ctx, cancel := context.WithCancel(s.ctx)
 	defer cancel()
 
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 
 	grpcOpts := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(kaep),
@@ -247,8 +230,8 @@ func (s *Server) Stop() (err error) {
 		logger.Info("Datacoord stopped", zap.Error(err))
 	}()
 
-	if s.etcdCli != nil {
-		defer s.etcdCli.Close()
+	if s.releaseEtcdCli != nil {
+		defer s.releaseEtcdCli()
 	}
 	if s.tikvCli != nil {
 		defer s.tikvCli.Close()
@@ -521,3 +504,27 @@ func (s *Server) ListImports(ctx context.Context, in *internalpb.ListImportsRequ
 func (s *Server) ListIndexes(ctx context.Context, in *indexpb.ListIndexesRequest) (*indexpb.ListIndexesResponse, error) {
 	return s.dataCoord.ListIndexes(ctx, in)
 }
+
+func (s *Server) CreateBackup(ctx context.Context, in *datapb.CreateBackupRequest) (*datapb.CreateBackupResponse, error) {
+	return s.dataCoord.CreateBackup(ctx, in)
+}
+
+func (s *Server) RestoreBackup(ctx context.Context, in *datapb.RestoreBackupRequest) (*datapb.RestoreBackupResponse, error) {
+	return s.dataCoord.RestoreBackup(ctx, in)
+}
+
+func (s *Server) ListBackups(ctx context.Context, in *datapb.ListBackupsRequest) (*datapb.ListBackupsResponse, error) {
+	return s.dataCoord.ListBackups(ctx, in)
+}
+
+func (s *Server) DropBackup(ctx context.Context, in *datapb.DropBackupRequest) (*commonpb.Status, error) {
+	return s.dataCoord.DropBackup(ctx, in)
+}
+
+func (s *Server) InspectChannelCheckpoints(ctx context.Context, in *datapb.InspectChannelCheckpointsRequest) (*datapb.InspectChannelCheckpointsResponse, error) {
+	return s.dataCoord.InspectChannelCheckpoints(ctx, in)
+}
+
+func (s *Server) RepairChannelCheckpoint(ctx context.Context, in *datapb.RepairChannelCheckpointRequest) (*datapb.RepairChannelCheckpointResponse, error) {
+	return s.dataCoord.RepairChannelCheckpoint(ctx, in)
+}
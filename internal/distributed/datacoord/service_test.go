@@ -319,6 +319,38 @@ func Test_NewServer(t *testing.T) {
 		assert.NotNil(t, ret)
 	})
 
+	t.Run("Backup", func(t *testing.T) {
+		mockDataCoord.EXPECT().CreateBackup(mock.Anything, mock.Anything).Return(&datapb.CreateBackupResponse{Status: merr.Success()}, nil)
+		mockDataCoord.EXPECT().RestoreBackup(mock.Anything, mock.Anything).Return(&datapb.RestoreBackupResponse{Status: merr.Success()}, nil)
+		mockDataCoord.EXPECT().ListBackups(mock.Anything, mock.Anything).Return(&datapb.ListBackupsResponse{Status: merr.Success()}, nil)
+		mockDataCoord.EXPECT().DropBackup(mock.Anything, mock.Anything).Return(merr.Success(), nil)
+
+		createResp, err := server.CreateBackup(ctx, &datapb.CreateBackupRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(createResp.GetStatus()))
+		restoreResp, err := server.RestoreBackup(ctx, &datapb.RestoreBackupRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(restoreResp.GetStatus()))
+		listResp, err := server.ListBackups(ctx, &datapb.ListBackupsRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(listResp.GetStatus()))
+		status, err := server.DropBackup(ctx, &datapb.DropBackupRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(status))
+	})
+
+	t.Run("ChannelCheckpoint", func(t *testing.T) {
+		mockDataCoord.EXPECT().InspectChannelCheckpoints(mock.Anything, mock.Anything).Return(&datapb.InspectChannelCheckpointsResponse{Status: merr.Success()}, nil)
+		mockDataCoord.EXPECT().RepairChannelCheckpoint(mock.Anything, mock.Anything).Return(&datapb.RepairChannelCheckpointResponse{Status: merr.Success()}, nil)
+
+		inspectResp, err := server.InspectChannelCheckpoints(ctx, &datapb.InspectChannelCheckpointsRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(inspectResp.GetStatus()))
+		repairResp, err := server.RepairChannelCheckpoint(ctx, &datapb.RepairChannelCheckpointRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(repairResp.GetStatus()))
+	})
+
 	t.Run("ListIndex", func(t *testing.T) {
 		mockDataCoord.EXPECT().ListIndexes(mock.Anything, mock.Anything).Return(&indexpb.ListIndexesResponse{
 			Status: merr.Success(),
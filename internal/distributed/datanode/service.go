@@ -27,7 +27,6 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/keepalive"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -41,6 +40,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/componentutil"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	_ "github.com/milvus-io/milvus/internal/util/grpcclient"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/tracer"
 	"github.com/milvus-io/milvus/pkg/util/etcd"
@@ -50,6 +50,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/netutil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type Server struct {
@@ -94,6 +95,8 @@ func (s *Server) Prepare() error {
 	listener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().DataNodeGrpcServerCfg.IP),
 		netutil.OptHighPriorityToUsePort(paramtable.Get().DataNodeGrpcServerCfg.Port.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.InternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.InternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("DataNode fail to create net listener", zap.Error(err))
@@ -119,15 +122,8 @@ func (s *Server) startGrpc() error {
 func (s *Server) startGrpcLoop() {
 	defer s.grpcWG.Done()
 	Params := &paramtable.Get().DataNodeGrpcServerCfg
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 
 	grpcOpts := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(kaep),
@@ -143,6 +139,8 @@ func (s *Server) startGrpcLoop() {
 				}
 				return s.serverID.Load()
 			}),
+			interceptor.FencingValidationUnaryServerInterceptor(interceptor.NewEpochFencer(
+				sessionutil.NewEtcdEpochStore(s.etcdCli, paramtable.Get().EtcdCfg.MetaRootPath.GetValue(), typeutil.DataCoordRole))),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
 			logutil.StreamTraceLoggerInterceptor,
@@ -20,14 +20,12 @@ import (
 	"context"
 	"strconv"
 	"sync"
-	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/keepalive"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -69,6 +67,8 @@ func (s *Server) Prepare() error {
 	listener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().IndexNodeGrpcServerCfg.IP),
 		netutil.OptHighPriorityToUsePort(paramtable.Get().IndexNodeGrpcServerCfg.Port.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.InternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.InternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("IndexNode fail to create net listener", zap.Error(err))
@@ -104,15 +104,8 @@ func (s *Server) startGrpcLoop() {
 	ctx, cancel := context.WithCancel(s.loopCtx)
 	defer cancel()
 
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 
 	grpcOpts := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(kaep),
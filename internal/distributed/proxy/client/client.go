@@ -233,3 +233,9 @@ func (c *Client) InvalidateShardLeaderCache(ctx context.Context, req *proxypb.In
 		return client.InvalidateShardLeaderCache(ctx, req)
 	})
 }
+
+func (c *Client) PreviewDelete(ctx context.Context, req *proxypb.PreviewDeleteRequest, opts ...grpc.CallOption) (*proxypb.PreviewDeleteResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client proxypb.ProxyClient) (*proxypb.PreviewDeleteResponse, error) {
+		return client.PreviewDelete(ctx, req)
+	})
+}
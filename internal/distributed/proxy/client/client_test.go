@@ -499,3 +499,40 @@ func Test_InvalidateShardLeaderCache(t *testing.T) {
 	_, err = client.InvalidateShardLeaderCache(ctx, &proxypb.InvalidateShardLeaderCacheRequest{})
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
+
+func Test_PreviewDelete(t *testing.T) {
+	paramtable.Init()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, "test", 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+	defer client.Close()
+
+	mockProxy := mocks.NewMockProxyClient(t)
+	mockGrpcClient := mocks.NewMockGrpcClient[proxypb.ProxyClient](t)
+	mockGrpcClient.EXPECT().Close().Return(nil)
+	mockGrpcClient.EXPECT().ReCall(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, f func(proxypb.ProxyClient) (interface{}, error)) (interface{}, error) {
+		return f(mockProxy)
+	})
+	client.(*Client).grpcClient = mockGrpcClient
+
+	// test success
+	mockProxy.EXPECT().PreviewDelete(mock.Anything, mock.Anything).Return(&proxypb.PreviewDeleteResponse{Status: merr.Success()}, nil)
+	_, err = client.PreviewDelete(ctx, &proxypb.PreviewDeleteRequest{})
+	assert.Nil(t, err)
+
+	// test return error code
+	mockProxy.ExpectedCalls = nil
+	mockProxy.EXPECT().PreviewDelete(mock.Anything, mock.Anything).Return(&proxypb.PreviewDeleteResponse{Status: merr.Status(merr.ErrServiceNotReady)}, nil)
+
+	_, err = client.PreviewDelete(ctx, &proxypb.PreviewDeleteRequest{})
+	assert.Nil(t, err)
+
+	// test ctx done
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	time.Sleep(20 * time.Millisecond)
+	_, err = client.PreviewDelete(ctx, &proxypb.PreviewDeleteRequest{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
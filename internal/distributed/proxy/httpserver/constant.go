@@ -49,6 +49,7 @@ const (
 	QueryAction          = "query"
 	GetAction            = "get"
 	DeleteAction         = "delete"
+	PreviewDeleteAction  = "preview_delete"
 	InsertAction         = "insert"
 	UpsertAction         = "upsert"
 	SearchAction         = "search"
@@ -71,6 +72,7 @@ const (
 const (
 	ContextRequest                = "request"
 	ContextUsername               = "username"
+	ContextQueryProgress          = "queryProgress"
 	VectorCollectionsPath         = "/vector/collections"
 	VectorCollectionsCreatePath   = "/vector/collections/create"
 	VectorCollectionsDescribePath = "/vector/collections/describe"
@@ -109,6 +111,7 @@ const (
 	HTTPHeaderAllowInt64     = "Accept-Type-Allow-Int64"
 	HTTPHeaderDBName         = "DB-Name"
 	HTTPHeaderRequestTimeout = "Request-Timeout"
+	HTTPHeaderRequestTags    = "Request-Tags"
 	HTTPDefaultTimeout       = 30 * time.Second
 	HTTPReturnCode           = "code"
 	HTTPReturnMessage        = "message"
@@ -119,6 +122,12 @@ const (
 
 	HTTPReturnHas = "has"
 
+	HTTPHeaderRequestProgress = "Request-Progress"
+	HTTPContentTypeNDJSON     = "application/x-ndjson"
+	HTTPReturnProgress        = "progress"
+
+	HTTPDefaultProgressInterval = 5 * time.Second
+
 	HTTPReturnFieldName             = "name"
 	HTTPReturnFieldID               = "id"
 	HTTPReturnFieldType             = "type"
@@ -42,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
@@ -50,6 +51,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/crypto"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/logutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/requestutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -86,22 +88,28 @@ func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
 	router.POST(DataBaseCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.describeDatabase))))
 	router.POST(DataBaseCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
 	// Query
-	router.POST(EntityCategory+QueryAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+QueryAction, restfulSizeMiddleware(progressMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &QueryReqV2{
 			Limit:        100,
 			OutputFields: []string{DefaultOutputFields},
 		}
-	}, wrapperTraceLog(h.query))), true))
+	}, wrapperTraceLog(h.query)))), true))
 	// Get
-	router.POST(EntityCategory+GetAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+GetAction, restfulSizeMiddleware(progressMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &CollectionIDReq{
 			OutputFields: []string{DefaultOutputFields},
 		}
-	}, wrapperTraceLog(h.get))), true))
+	}, wrapperTraceLog(h.get)))), true))
 	// Delete
 	router.POST(EntityCategory+DeleteAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &CollectionFilterReq{}
 	}, wrapperTraceLog(h.delete))), false))
+	// PreviewDelete
+	router.POST(EntityCategory+PreviewDeleteAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+		return &PreviewDeleteReq{
+			Limit: 10,
+		}
+	}, wrapperTraceLog(h.previewDelete))), false))
 	// Insert
 	router.POST(EntityCategory+InsertAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &CollectionDataReq{}
@@ -111,23 +119,23 @@ func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
 		return &CollectionDataReq{}
 	}, wrapperTraceLog(h.upsert))), false))
 	// Search
-	router.POST(EntityCategory+SearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+SearchAction, restfulSizeMiddleware(progressMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &SearchReqV2{
 			Limit: 100,
 		}
-	}, wrapperTraceLog(h.search))), true))
+	}, wrapperTraceLog(h.search)))), true))
 	// advanced_search, backward compatible uri
-	router.POST(EntityCategory+AdvancedSearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+AdvancedSearchAction, restfulSizeMiddleware(progressMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &HybridSearchReq{
 			Limit: 100,
 		}
-	}, wrapperTraceLog(h.advancedSearch))), true))
+	}, wrapperTraceLog(h.advancedSearch)))), true))
 	// HybridSearch
-	router.POST(EntityCategory+HybridSearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+HybridSearchAction, restfulSizeMiddleware(progressMiddleware(timeoutMiddleware(wrapperPost(func() any {
 		return &HybridSearchReq{
 			Limit: 100,
 		}
-	}, wrapperTraceLog(h.advancedSearch))), true))
+	}, wrapperTraceLog(h.advancedSearch)))), true))
 
 	router.POST(PartitionCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listPartitions))))
 	router.POST(PartitionCategory+HasAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.hasPartitions))))
@@ -231,6 +239,10 @@ func wrapperPost(newReq newReqFunc, v2 handlerFuncV2) gin.HandlerFunc {
 		ctx = proxy.NewContextWithMetadata(ctx, username.(string), dbName)
 		traceID := span.SpanContext().TraceID().String()
 		ctx = log.WithTraceID(ctx, traceID)
+		ctx = logutil.WithRequestTags(ctx, c.Request.Header.Get(HTTPHeaderRequestTags))
+		if progress, ok := c.Get(ContextQueryProgress); ok {
+			ctx = proxy.NewContextWithQueryProgress(ctx, progress.(*proxy.QueryProgress))
+		}
 		c.Keys["traceID"] = traceID
 		log.Ctx(ctx).Debug("high level restful api, read parameters from request body, then start to handle.",
 			zap.Any("url", c.Request.URL.Path))
@@ -654,6 +666,9 @@ func (h *HandlersV2) query(ctx context.Context, c *gin.Context, anyReq any, dbNa
 	if httpReq.Limit > 0 && !matchCountRule(httpReq.OutputFields) {
 		req.QueryParams = append(req.QueryParams, &commonpb.KeyValuePair{Key: ParamLimit, Value: strconv.FormatInt(int64(httpReq.Limit), 10)})
 	}
+	if httpReq.Export != nil {
+		return h.exportQuery(ctx, c, req, httpReq.Export, dbName)
+	}
 	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Query", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
 		return h.proxy.Query(reqCtx, req.(*milvuspb.QueryRequest))
 	})
@@ -678,6 +693,48 @@ func (h *HandlersV2) query(ctx context.Context, c *gin.Context, anyReq any, dbNa
 	return resp, err
 }
 
+// exportQuery writes the results of the query into the object storage page by page and returns the manifest,
+// each page is checked against the rate limit as a query.
+func (h *HandlersV2) exportQuery(ctx context.Context, c *gin.Context, req *milvuspb.QueryRequest, dest *proxy.ResultExportDestination, dbName string) (interface{}, error) {
+	collSchema, err := h.GetCollectionSchema(ctx, c, dbName, req.GetCollectionName())
+	if err != nil {
+		return nil, err
+	}
+	pkField, _ := typeutil.GetPrimaryFieldSchema(collSchema)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Query", func(reqCtx context.Context, req any) (interface{}, error) {
+		return proxy.ExportQueryResults(reqCtx, dest, req.(*milvuspb.QueryRequest), pkField.GetName(),
+			func(pageCtx context.Context, pageReq *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+				if _, err := CheckLimiter(pageCtx, pageReq, h.proxy); err != nil {
+					return nil, err
+				}
+				return h.proxy.Query(pageCtx, pageReq)
+			})
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: resp})
+	}
+	return resp, err
+}
+
+// exportSearch writes the results of the search into the object storage batch by batch and returns the manifest,
+// each batch is checked against the rate limit as a search.
+func (h *HandlersV2) exportSearch(ctx context.Context, c *gin.Context, req *milvuspb.SearchRequest, dest *proxy.ResultExportDestination, collSchema *schemapb.CollectionSchema) (interface{}, error) {
+	pkField, _ := typeutil.GetPrimaryFieldSchema(collSchema)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Search", func(reqCtx context.Context, req any) (interface{}, error) {
+		return proxy.ExportSearchResults(reqCtx, dest, req.(*milvuspb.SearchRequest), pkField.GetName(),
+			func(batchCtx context.Context, batchReq *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+				if _, err := CheckLimiter(batchCtx, batchReq, h.proxy); err != nil {
+					return nil, err
+				}
+				return h.proxy.Search(batchCtx, batchReq)
+			})
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: resp})
+	}
+	return resp, err
+}
+
 func (h *HandlersV2) get(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
 	httpReq := anyReq.(*CollectionIDReq)
 	collSchema, err := h.GetCollectionSchema(ctx, c, dbName, httpReq.CollectionName)
@@ -765,6 +822,44 @@ func (h *HandlersV2) delete(ctx context.Context, c *gin.Context, anyReq any, dbN
 	return resp, err
 }
 
+// previewDelete returns the count and a sample of primary keys of the entities matching the delete filter,
+// without deleting anything.
+func (h *HandlersV2) previewDelete(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*PreviewDeleteReq)
+	req := &proxypb.PreviewDeleteRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		PartitionName:  httpReq.PartitionName,
+		Expr:           httpReq.Filter,
+		Limit:          int64(httpReq.Limit),
+	}
+	req.ExprTemplateValues = generateExpressionTemplate(httpReq.ExprParams)
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Proxy/PreviewDelete", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.PreviewDelete(reqCtx, req.(*proxypb.PreviewDeleteRequest))
+	})
+	if err == nil {
+		previewResp := resp.(*proxypb.PreviewDeleteResponse)
+		var primaryKeys interface{} = []interface{}{}
+		switch ids := previewResp.GetPrimaryKeys().GetIdField().(type) {
+		case *schemapb.IDs_IntId:
+			allowJS, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderAllowInt64))
+			if allowJS {
+				primaryKeys = ids.IntId.GetData()
+			} else {
+				primaryKeys = formatInt64(ids.IntId.GetData())
+			}
+		case *schemapb.IDs_StrId:
+			primaryKeys = ids.StrId.GetData()
+		}
+		HTTPReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode: merr.Code(nil),
+			HTTPReturnData: gin.H{"count": previewResp.GetCount(), "primaryKeys": primaryKeys},
+		})
+	}
+	return resp, err
+}
+
 func (h *HandlersV2) insert(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
 	httpReq := anyReq.(*CollectionDataReq)
 	req := &milvuspb.InsertRequest{
@@ -1033,6 +1128,9 @@ func (h *HandlersV2) search(ctx context.Context, c *gin.Context, anyReq any, dbN
 	req.SearchParams = searchParams
 	req.PlaceholderGroup = placeholderGroup
 	req.ExprTemplateValues = generateExpressionTemplate(httpReq.ExprParams)
+	if httpReq.Export != nil {
+		return h.exportSearch(ctx, c, req, httpReq.Export, collSchema)
+	}
 	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Search", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
 		return h.proxy.Search(reqCtx, req.(*milvuspb.SearchRequest))
 	})
@@ -2311,7 +2409,7 @@ func (h *HandlersV2) createImportJob(ctx context.Context, c *gin.Context, anyReq
 			return nil, err
 		}
 	}
-	resp, err := wrapperProxy(ctx, c, req, false, false, "/milvus.proto.milvus.MilvusService/ImportV2", func(reqCtx context.Context, req any) (interface{}, error) {
+	resp, err := wrapperProxyWithLimit(ctx, c, req, false, false, "/milvus.proto.milvus.MilvusService/ImportV2", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
 		return h.proxy.ImportV2(reqCtx, req.(*internalpb.ImportRequest))
 	})
 	if err == nil {
@@ -2366,6 +2464,9 @@ func (h *HandlersV2) getImportJobProcess(ctx context.Context, c *gin.Context, an
 			detail["state"] = taskProgress.GetState()
 			detail["importedRows"] = taskProgress.GetImportedRows()
 			detail["totalRows"] = taskProgress.GetTotalRows()
+			if taskProgress.GetDuplicatedRows() > 0 {
+				detail["duplicatedRows"] = taskProgress.GetDuplicatedRows()
+			}
 			reason = taskProgress.GetReason()
 			if reason != "" {
 				detail["reason"] = reason
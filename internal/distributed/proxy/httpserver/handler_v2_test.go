@@ -28,6 +28,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tidwall/gjson"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -36,6 +37,7 @@ import (
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/util"
@@ -1778,6 +1780,47 @@ func TestDML(t *testing.T) {
 	validateTestCases(t, testEngine, queryTestCases, false)
 }
 
+func TestPreviewDelete(t *testing.T) {
+	paramtable.Init()
+	// disable rate limit
+	paramtable.Get().Save(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key)
+	mp := mocks.NewMockProxy(t)
+	mp.EXPECT().PreviewDelete(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *proxypb.PreviewDeleteRequest) (*proxypb.PreviewDeleteResponse, error) {
+		assert.Equal(t, "book_id in [1, 2, 3]", req.GetExpr())
+		assert.Equal(t, int64(2), req.GetLimit())
+		return &proxypb.PreviewDeleteResponse{
+			Status: commonSuccessStatus,
+			Count:  3,
+			PrimaryKeys: &schemapb.IDs{
+				IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}},
+			},
+		}, nil
+	}).Once()
+	testEngine := initHTTPServerV2(mp, false)
+
+	bodyReader := bytes.NewReader([]byte(`{"collectionName": "book", "filter": "book_id in [1, 2, 3]", "limit": 2}`))
+	req := httptest.NewRequest(http.MethodPost, versionalV2(EntityCategory, PreviewDeleteAction), bodyReader)
+	w := httptest.NewRecorder()
+	testEngine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	returnBody := &ReturnErrMsg{}
+	err := json.Unmarshal(w.Body.Bytes(), returnBody)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), returnBody.Code)
+	assert.Equal(t, int64(3), gjson.Get(w.Body.String(), "data.count").Int())
+	assert.Equal(t, `["1","2"]`, gjson.Get(w.Body.String(), "data.primaryKeys").Raw)
+
+	bodyReader = bytes.NewReader([]byte(`{"collectionName": "book"}`))
+	req = httptest.NewRequest(http.MethodPost, versionalV2(EntityCategory, PreviewDeleteAction), bodyReader)
+	w = httptest.NewRecorder()
+	testEngine.ServeHTTP(w, req)
+	returnBody = &ReturnErrMsg{}
+	err = json.Unmarshal(w.Body.Bytes(), returnBody)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1802), returnBody.Code)
+}
+
 func TestAllowInt64(t *testing.T) {
 	paramtable.Init()
 	// disable rate limit
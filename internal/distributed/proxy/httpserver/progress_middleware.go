@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// progressWriter buffers the response of the handler,
+// the headers and the status are sent by progressMiddleware ahead of it.
+type progressWriter struct {
+	gin.ResponseWriter
+	mu      sync.Mutex
+	body    bytes.Buffer
+	headers http.Header
+}
+
+func (w *progressWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.body.Write(data)
+}
+
+func (w *progressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *progressWriter) WriteHeader(code int) {}
+
+func (w *progressWriter) Header() http.Header {
+	return w.headers
+}
+
+func (w *progressWriter) Status() int {
+	return http.StatusOK
+}
+
+// progressMiddleware streams the progress frames of a query or search as newline delimited json
+// every proxy.http.progressInterval if the Request-Progress header is true, followed by the response of the handler,
+// so that clients and load balancers don't take the long running requests as idle.
+func progressMiddleware(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enabled, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderRequestProgress))
+		if !enabled {
+			handler(c)
+			return
+		}
+
+		progress := proxy.NewQueryProgress()
+		c.Set(ContextQueryProgress, progress)
+
+		w := c.Writer
+		w.Header().Set("Content-Type", HTTPContentTypeNDJSON)
+		w.WriteHeader(http.StatusOK)
+		w.WriteHeaderNow()
+		w.Flush()
+
+		writeFrame := func(frame []byte) {
+			if _, err := w.Write(append(frame, '\n')); err != nil {
+				log.Warn("high level restful api, failed to write progress frame", zap.Error(err))
+				return
+			}
+			w.Flush()
+		}
+
+		done := make(chan struct{})
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			interval := proxy.Params.HTTPCfg.ProgressInterval.GetAsDuration(time.Second)
+			if interval <= 0 {
+				interval = HTTPDefaultProgressInterval
+			}
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					frame, err := json.Marshal(gin.H{HTTPReturnProgress: progress.Info()})
+					if err != nil {
+						log.Warn("high level restful api, failed to marshal progress frame", zap.Error(err))
+						continue
+					}
+					writeFrame(frame)
+				}
+			}
+		}()
+
+		pw := &progressWriter{ResponseWriter: w, headers: make(http.Header)}
+		func() {
+			c.Writer = pw
+			defer func() {
+				close(done)
+				wg.Wait()
+				c.Writer = w
+			}()
+			handler(c)
+		}()
+
+		pw.mu.Lock()
+		defer pw.mu.Unlock()
+		writeFrame(bytes.TrimRight(pw.body.Bytes(), "\n"))
+	}
+}
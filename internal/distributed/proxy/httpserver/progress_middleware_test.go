@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestProgressMiddleware(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(proxy.Params.HTTPCfg.ProgressInterval.Key, "0.05")
+	defer paramtable.Get().Reset(proxy.Params.HTTPCfg.ProgressInterval.Key)
+
+	testEngine := gin.New()
+	testEngine.POST("/test", progressMiddleware(timeoutMiddleware(func(c *gin.Context) {
+		_, ok := c.Get(ContextQueryProgress)
+		if ok {
+			time.Sleep(200 * time.Millisecond)
+		}
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: 0, HTTPReturnData: ok})
+	})))
+
+	t.Run("without progress", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		w := httptest.NewRecorder()
+		testEngine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"code":0,"data":false}`, w.Body.String())
+	})
+
+	t.Run("with progress", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set(HTTPHeaderRequestProgress, "true")
+		w := httptest.NewRecorder()
+		testEngine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, HTTPContentTypeNDJSON, w.Header().Get("Content-Type"))
+
+		frames := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		assert.Greater(t, len(frames), 1)
+		for _, frame := range frames[:len(frames)-1] {
+			progress := gin.H{}
+			assert.NoError(t, json.Unmarshal([]byte(frame), &progress))
+			assert.Contains(t, progress, HTTPReturnProgress)
+		}
+		assert.Equal(t, `{"code":0,"data":true}`, frames[len(frames)-1])
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 )
 
@@ -142,6 +143,8 @@ type QueryReqV2 struct {
 	Limit          int32                  `json:"limit"`
 	Offset         int32                  `json:"offset"`
 	ExprParams     map[string]interface{} `json:"exprParams"`
+	// Export writes the results into the object storage and returns the manifest if set.
+	Export *proxy.ResultExportDestination `json:"export"`
 }
 
 func (req *QueryReqV2) GetDbName() string { return req.DbName }
@@ -167,6 +170,17 @@ type CollectionFilterReq struct {
 
 func (req *CollectionFilterReq) GetDbName() string { return req.DbName }
 
+type PreviewDeleteReq struct {
+	DbName         string                 `json:"dbName"`
+	CollectionName string                 `json:"collectionName" binding:"required"`
+	PartitionName  string                 `json:"partitionName"`
+	Filter         string                 `json:"filter" binding:"required"`
+	ExprParams     map[string]interface{} `json:"exprParams"`
+	Limit          int32                  `json:"limit"`
+}
+
+func (req *PreviewDeleteReq) GetDbName() string { return req.DbName }
+
 type CollectionDataReq struct {
 	DbName         string                   `json:"dbName"`
 	CollectionName string                   `json:"collectionName" binding:"required"`
@@ -202,6 +216,8 @@ type SearchReqV2 struct {
 	ExprParams       map[string]interface{} `json:"exprParams"`
 	// not use Params any more, just for compatibility
 	Params map[string]float64 `json:"params"`
+	// Export writes the results into the object storage and returns the manifest if set.
+	Export *proxy.ResultExportDestination `json:"export"`
 }
 
 func (req *SearchReqV2) GetDbName() string { return req.DbName }
@@ -43,6 +43,8 @@ func newListenerManager() (l *listenerManager, err error) {
 	externalGrpcListener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().ProxyGrpcServerCfg.IP),
 		netutil.OptPort(paramtable.Get().ProxyGrpcServerCfg.Port.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.ExternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.ExternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("Proxy fail to create external grpc listener", zap.Error(err))
@@ -53,6 +55,8 @@ func newListenerManager() (l *listenerManager, err error) {
 	internalGrpcListener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().ProxyGrpcServerCfg.IP),
 		netutil.OptPort(paramtable.Get().ProxyGrpcServerCfg.InternalPort.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.InternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.InternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("Proxy fail to create internal grpc listener", zap.Error(err))
@@ -145,7 +149,13 @@ func newHTTPListner(l *listenerManager) error {
 
 	var err error
 	l.portShareMode = false
-	l.httpListener, err = netutil.NewListener(netutil.OptIP(Params.IP), netutil.OptPort(httpPort), netutil.OptTLS(tlsConf))
+	l.httpListener, err = netutil.NewListener(
+		netutil.OptIP(Params.IP),
+		netutil.OptPort(httpPort),
+		netutil.OptTLS(tlsConf),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.ExternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.ExternalAllowedCIDRs.GetAsStrings()...),
+	)
 	if err != nil {
 		log.Warn("Proxy server(http) failed to listen on", zap.Error(err))
 		return err
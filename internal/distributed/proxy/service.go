@@ -41,7 +41,6 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -129,15 +128,15 @@ func NewServer(ctx context.Context, factory dependency.Factory) (*Server, error)
 func authenticate(c *gin.Context) {
 	username, password, ok := httpserver.ParseUsernamePassword(c)
 	if ok {
-		if proxy.PasswordVerify(c, username, password) {
-			log.Debug("auth successful", zap.String("username", username))
-			c.Set(httpserver.ContextUsername, username)
+		if user, ok := proxy.VerifyPassword(c, username, password); ok {
+			log.Debug("auth successful", zap.String("username", user))
+			c.Set(httpserver.ContextUsername, user)
 			return
 		}
 	}
 	rawToken := httpserver.GetAuthorization(c)
 	if rawToken != "" && !strings.Contains(rawToken, util.CredentialSeperator) {
-		user, err := proxy.VerifyAPIKey(rawToken)
+		user, err := proxy.VerifyToken(c, rawToken)
 		if err == nil {
 			c.Set(httpserver.ContextUsername, user)
 			return
@@ -218,15 +217,8 @@ func (s *Server) startExternalRPCServer(errChan chan error) {
 func (s *Server) startExternalGrpc(errChan chan error) {
 	defer s.wg.Done()
 	Params := &paramtable.Get().ProxyGrpcServerCfg
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 
 	limiter, err := s.proxy.GetRateLimiter()
 	if err != nil {
@@ -336,15 +328,8 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 func (s *Server) startInternalGrpc(errChan chan error) {
 	defer s.wg.Done()
 	Params := &paramtable.Get().ProxyGrpcServerCfg
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 
 	opts := tracer.GetInterceptorOpts()
 	grpcOpts := []grpc.ServerOption{
@@ -1149,6 +1134,10 @@ func (s *Server) InvalidateShardLeaderCache(ctx context.Context, req *proxypb.In
 	return s.proxy.InvalidateShardLeaderCache(ctx, req)
 }
 
+func (s *Server) PreviewDelete(ctx context.Context, req *proxypb.PreviewDeleteRequest) (*proxypb.PreviewDeleteResponse, error) {
+	return s.proxy.PreviewDelete(ctx, req)
+}
+
 func (s *Server) DescribeDatabase(ctx context.Context, req *milvuspb.DescribeDatabaseRequest) (*milvuspb.DescribeDatabaseResponse, error) {
 	return s.proxy.DescribeDatabase(ctx, req)
 }
@@ -239,6 +239,12 @@ func Test_NewServer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("PreviewDelete", func(t *testing.T) {
+		mockProxy.EXPECT().PreviewDelete(mock.Anything, mock.Anything).Return(nil, nil)
+		_, err := server.PreviewDelete(ctx, nil)
+		assert.NoError(t, err)
+	})
+
 	t.Run("CreateCollection", func(t *testing.T) {
 		mockProxy.EXPECT().CreateCollection(mock.Anything, mock.Anything).Return(nil, nil)
 		_, err := server.CreateCollection(ctx, nil)
@@ -19,7 +19,6 @@ package grpcquerycoord
 import (
 	"context"
 	"sync"
-	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/tikv/client-go/v2/txnkv"
@@ -27,7 +26,6 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/keepalive"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -42,7 +40,6 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/tracer"
 	"github.com/milvus-io/milvus/pkg/util"
-	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/interceptor"
 	"github.com/milvus-io/milvus/pkg/util/logutil"
@@ -67,8 +64,9 @@ type Server struct {
 
 	factory dependency.Factory
 
-	etcdCli *clientv3.Client
-	tikvCli *txnkv.Client
+	etcdCli        *clientv3.Client
+	releaseEtcdCli func()
+	tikvCli        *txnkv.Client
 
 	dataCoord types.DataCoordClient
 	rootCoord types.RootCoordClient
@@ -96,6 +94,8 @@ func (s *Server) Prepare() error {
 	listener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().QueryCoordGrpcServerCfg.IP),
 		netutil.OptPort(paramtable.Get().QueryCoordGrpcServerCfg.Port.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.InternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.InternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("QueryCoord fail to create net listener", zap.Error(err))
@@ -125,24 +125,14 @@ var getTiKVClient = tikv.GetTiKVClient
 // init initializes QueryCoord's grpc service.
 func (s *Server) init() error {
 	params := paramtable.Get()
-	etcdConfig := &params.EtcdCfg
-
-	etcdCli, err := etcd.CreateEtcdClient(
-		etcdConfig.UseEmbedEtcd.GetAsBool(),
-		etcdConfig.EtcdEnableAuth.GetAsBool(),
-		etcdConfig.EtcdAuthUserName.GetValue(),
-		etcdConfig.EtcdAuthPassword.GetValue(),
-		etcdConfig.EtcdUseSSL.GetAsBool(),
-		etcdConfig.Endpoints.GetAsStrings(),
-		etcdConfig.EtcdTLSCert.GetValue(),
-		etcdConfig.EtcdTLSKey.GetValue(),
-		etcdConfig.EtcdTLSCACert.GetValue(),
-		etcdConfig.EtcdTLSMinVersion.GetValue())
+
+	etcdCli, releaseEtcdCli, err := coordclient.CreateEtcdClient()
 	if err != nil {
 		log.Warn("QueryCoord connect to etcd failed", zap.Error(err))
 		return err
 	}
 	s.etcdCli = etcdCli
+	s.releaseEtcdCli = releaseEtcdCli
 	s.SetEtcdClient(etcdCli)
 	s.queryCoord.SetAddress(s.listener.Address())
 
@@ -193,15 +183,8 @@ func (s *Server) init() error {
 func (s *Server) startGrpcLoop() {
 	defer s.grpcWG.Done()
 	Params := &paramtable.Get().QueryCoordGrpcServerCfg
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 	ctx, cancel := context.WithCancel(s.loopCtx)
 	defer cancel()
 
@@ -268,8 +251,8 @@ func (s *Server) Stop() (err error) {
 		logger.Info("QueryCoord stopped", zap.Error(err))
 	}()
 
-	if s.etcdCli != nil {
-		defer s.etcdCli.Close()
+	if s.releaseEtcdCli != nil {
+		defer s.releaseEtcdCli()
 	}
 
 	if s.grpcServer != nil {
@@ -694,6 +694,17 @@ func (c *Client) DescribeDatabase(ctx context.Context, req *rootcoordpb.Describe
 	})
 }
 
+func (c *Client) DescribeDatabases(ctx context.Context, req *rootcoordpb.DescribeDatabasesRequest, opts ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client rootcoordpb.RootCoordClient) (*rootcoordpb.DescribeDatabasesResponse, error) {
+		return client.DescribeDatabases(ctx, req)
+	})
+}
+
 func (c *Client) AlterDatabase(ctx context.Context, request *rootcoordpb.AlterDatabaseRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	request = typeutil.Clone(request)
 	commonpbutil.UpdateMsgBase(
@@ -705,6 +716,17 @@ func (c *Client) AlterDatabase(ctx context.Context, request *rootcoordpb.AlterDa
 	})
 }
 
+func (c *Client) BatchDDL(ctx context.Context, request *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	request = typeutil.Clone(request)
+	commonpbutil.UpdateMsgBase(
+		request.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client rootcoordpb.RootCoordClient) (*commonpb.Status, error) {
+		return client.BatchDDL(ctx, request)
+	})
+}
+
 func (c *Client) BackupRBAC(ctx context.Context, in *milvuspb.BackupRBACMetaRequest, opts ...grpc.CallOption) (*milvuspb.BackupRBACMetaResponse, error) {
 	in = typeutil.Clone(in)
 	commonpbutil.UpdateMsgBase(
@@ -19,7 +19,6 @@ package grpcrootcoord
 import (
 	"context"
 	"sync"
-	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/tikv/client-go/v2/txnkv"
@@ -27,7 +26,6 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/keepalive"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -43,7 +41,6 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/tracer"
 	"github.com/milvus-io/milvus/pkg/util"
-	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/interceptor"
 	"github.com/milvus-io/milvus/pkg/util/logutil"
@@ -66,10 +63,11 @@ type Server struct {
 
 	serverID atomic.Int64
 
-	etcdCli    *clientv3.Client
-	tikvCli    *txnkv.Client
-	dataCoord  types.DataCoordClient
-	queryCoord types.QueryCoordClient
+	etcdCli        *clientv3.Client
+	releaseEtcdCli func()
+	tikvCli        *txnkv.Client
+	dataCoord      types.DataCoordClient
+	queryCoord     types.QueryCoordClient
 
 	newDataCoordClient  func(ctx context.Context) types.DataCoordClient
 	newQueryCoordClient func(ctx context.Context) types.QueryCoordClient
@@ -79,6 +77,11 @@ func (s *Server) DescribeDatabase(ctx context.Context, request *rootcoordpb.Desc
 	return s.rootCoord.DescribeDatabase(ctx, request)
 }
 
+// DescribeDatabases describes all the databases.
+func (s *Server) DescribeDatabases(ctx context.Context, request *rootcoordpb.DescribeDatabasesRequest) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	return s.rootCoord.DescribeDatabases(ctx, request)
+}
+
 func (s *Server) CreateDatabase(ctx context.Context, request *milvuspb.CreateDatabaseRequest) (*commonpb.Status, error) {
 	return s.rootCoord.CreateDatabase(ctx, request)
 }
@@ -95,6 +98,11 @@ func (s *Server) AlterDatabase(ctx context.Context, request *rootcoordpb.AlterDa
 	return s.rootCoord.AlterDatabase(ctx, request)
 }
 
+// BatchDDL creates the collections and the partitions of a database atomically.
+func (s *Server) BatchDDL(ctx context.Context, request *rootcoordpb.BatchDDLRequest) (*commonpb.Status, error) {
+	return s.rootCoord.BatchDDL(ctx, request)
+}
+
 func (s *Server) CheckHealth(ctx context.Context, request *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	return s.rootCoord.CheckHealth(ctx, request)
 }
@@ -145,6 +153,8 @@ func (s *Server) Prepare() error {
 	listener, err := netutil.NewListener(
 		netutil.OptIP(paramtable.Get().RootCoordGrpcServerCfg.IP),
 		netutil.OptPort(paramtable.Get().RootCoordGrpcServerCfg.Port.GetAsInt()),
+		netutil.OptBindIP(paramtable.Get().ListenerCfg.InternalBindIP.GetValue()),
+		netutil.OptAllowedCIDRs(paramtable.Get().ListenerCfg.InternalAllowedCIDRs.GetAsStrings()...),
 	)
 	if err != nil {
 		log.Warn("RootCoord fail to create net listener", zap.Error(err))
@@ -178,25 +188,15 @@ var getTiKVClient = tikv.GetTiKVClient
 
 func (s *Server) init() error {
 	params := paramtable.Get()
-	etcdConfig := &params.EtcdCfg
 	log.Info("init params done..")
 
-	etcdCli, err := etcd.CreateEtcdClient(
-		etcdConfig.UseEmbedEtcd.GetAsBool(),
-		etcdConfig.EtcdEnableAuth.GetAsBool(),
-		etcdConfig.EtcdAuthUserName.GetValue(),
-		etcdConfig.EtcdAuthPassword.GetValue(),
-		etcdConfig.EtcdUseSSL.GetAsBool(),
-		etcdConfig.Endpoints.GetAsStrings(),
-		etcdConfig.EtcdTLSCert.GetValue(),
-		etcdConfig.EtcdTLSKey.GetValue(),
-		etcdConfig.EtcdTLSCACert.GetValue(),
-		etcdConfig.EtcdTLSMinVersion.GetValue())
+	etcdCli, releaseEtcdCli, err := coordclient.CreateEtcdClient()
 	if err != nil {
 		log.Warn("RootCoord connect to etcd failed", zap.Error(err))
 		return err
 	}
 	s.etcdCli = etcdCli
+	s.releaseEtcdCli = releaseEtcdCli
 	s.rootCoord.SetEtcdClient(s.etcdCli)
 	s.rootCoord.SetAddress(s.listener.Address())
 	log.Info("etcd connect done ...")
@@ -250,15 +250,8 @@ func (s *Server) startGrpc() error {
 func (s *Server) startGrpcLoop() {
 	defer s.grpcWG.Done()
 	Params := &paramtable.Get().RootCoordGrpcServerCfg
-	kaep := keepalive.EnforcementPolicy{
-		MinTime:             5 * time.Second, // If a client pings more than once every 5 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-
-	kasp := keepalive.ServerParameters{
-		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
-	}
+	kaep := Params.GetKeepaliveEnforcementPolicy()
+	kasp := Params.GetKeepaliveServerParameters()
 	log.Info("start grpc ", zap.Int("port", s.listener.Port()))
 
 	ctx, cancel := context.WithCancel(s.ctx)
@@ -328,8 +321,8 @@ func (s *Server) Stop() (err error) {
 		logger.Info("Rootcoord stopped", zap.Error(err))
 	}()
 
-	if s.etcdCli != nil {
-		defer s.etcdCli.Close()
+	if s.releaseEtcdCli != nil {
+		defer s.releaseEtcdCli()
 	}
 	if s.tikvCli != nil {
 		defer s.tikvCli.Close()
@@ -64,6 +64,14 @@ func (m *mockCore) AlterDatabase(ctx context.Context, request *rootcoordpb.Alter
 	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
 }
 
+func (m *mockCore) DescribeDatabases(ctx context.Context, request *rootcoordpb.DescribeDatabasesRequest) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	return &rootcoordpb.DescribeDatabasesResponse{Status: merr.Success()}, nil
+}
+
+func (m *mockCore) BatchDDL(ctx context.Context, request *rootcoordpb.BatchDDLRequest) (*commonpb.Status, error) {
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+}
+
 func (m *mockCore) RenameCollection(ctx context.Context, request *milvuspb.RenameCollectionRequest) (*commonpb.Status, error) {
 	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
 }
@@ -215,6 +223,18 @@ func TestRun(t *testing.T) {
 			assert.True(t, merr.Ok(ret))
 		})
 
+		t.Run("BatchDDL", func(t *testing.T) {
+			ret, err := svr.BatchDDL(ctx, nil)
+			assert.Nil(t, err)
+			assert.True(t, merr.Ok(ret))
+		})
+
+		t.Run("DescribeDatabases", func(t *testing.T) {
+			ret, err := svr.DescribeDatabases(ctx, nil)
+			assert.Nil(t, err)
+			assert.True(t, merr.Ok(ret.GetStatus()))
+		})
+
 		err = svr.Stop()
 		assert.NoError(t, err)
 	}
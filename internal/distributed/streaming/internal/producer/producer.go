@@ -2,11 +2,14 @@ package producer
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/distributed/streaming/internal/errs"
@@ -16,6 +19,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/util/lifetime"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/syncutil"
 )
 
@@ -43,6 +47,8 @@ func NewResumableProducer(f factory, opts *ProducerOptions) *ResumableProducer {
 		cond:     syncutil.NewContextCond(&sync.Mutex{}),
 		factory:  f,
 		metrics:  newProducerMetrics(opts.PChannel),
+		// the node id and the start time identify the producer across the cluster and the restarts.
+		uniqueIDPrefix: fmt.Sprintf("%d-%d-", paramtable.GetNodeID(), time.Now().UnixNano()),
 	}
 	go p.resumeLoop()
 	return p
@@ -74,6 +80,11 @@ type ResumableProducer struct {
 	factory factory
 
 	metrics *producerMetrics
+
+	// uniqueIDPrefix and uniqueIDSeq make up the unique id of the messages produced,
+	// which is kept across the retries, so the wal can drop the ones appended twice.
+	uniqueIDPrefix string
+	uniqueIDSeq    atomic.Int64
 }
 
 // Produce produce a new message to log service.
@@ -86,6 +97,9 @@ func (p *ResumableProducer) Produce(ctx context.Context, msg message.MutableMess
 		metricGuard.Finish(err)
 		p.lifetime.Done()
 	}()
+	if msg.UniqueID() == "" {
+		msg = msg.WithUniqueID(p.uniqueIDPrefix + strconv.FormatInt(p.uniqueIDSeq.Inc(), 10))
+	}
 
 	for {
 		// get producer.
@@ -76,6 +76,8 @@ func TestResumableProducer(t *testing.T) {
 
 	msg := mock_message.NewMockMutableMessage(t)
 	msg.EXPECT().EstimateSize().Return(100)
+	msg.EXPECT().UniqueID().Return("")
+	msg.EXPECT().WithUniqueID(mock.Anything).Return(msg)
 	id, err := rp.Produce(context.Background(), msg)
 	assert.NotNil(t, id)
 	assert.NoError(t, err)
@@ -98,6 +98,7 @@ func (b *brokerMetaWriter) UpdateSync(ctx context.Context, pack *SyncTask) error
 		Field2StatslogPaths: statsFieldBinlogs,
 		Field2Bm25LogPaths:  deltaBm25StatsBinlogs,
 		Deltalogs:           deltaFieldBinlogs,
+		JsonKeySchemas:      pack.jsonKeySchemas,
 
 		CheckPoints: checkPoints,
 
@@ -30,6 +30,11 @@ func (t *SyncTask) WithChunkManager(cm storage.ChunkManager) *SyncTask {
 	return t
 }
 
+func (t *SyncTask) withWriteLimiter(limiter *writeLimiter) *SyncTask {
+	t.writeLimiter = limiter
+	return t
+}
+
 func (t *SyncTask) WithAllocator(allocator allocator.Interface) *SyncTask {
 	t.allocator = allocator
 	return t
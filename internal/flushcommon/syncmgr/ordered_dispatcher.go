@@ -0,0 +1,124 @@
+package syncmgr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type Task interface {
+	SegmentID() int64
+	Checkpoint() *msgpb.MsgPosition
+	StartPosition() *msgpb.MsgPosition
+	ChannelName() string
+	Run(context.Context) error
+	HandleError(error)
+	IsFlush() bool
+}
+
+// orderedDispatcher runs the tasks of the same key one by one in submission order,
+// and the tasks of different keys concurrently, limited by the worker pool.
+// Each key queues at most maxPending tasks which are submitted but not done,
+// Submit blocks the caller when the queue is full until a task of the key is done or the context is done,
+// so that a slow storage applies back pressure to the submitter instead of piling up tasks in memory.
+type orderedDispatcher[K comparable] struct {
+	mu         sync.Mutex
+	queues     map[K]*taskQueue
+	maxPending int
+	workerPool *conc.Pool[struct{}]
+}
+
+// taskQueue is the queue of the tasks submitted but not done of a key.
+type taskQueue struct {
+	// the future of the last submitted task
+	tail *conc.Future[struct{}]
+	// the number of tasks submitted but not done, including the ones waiting for a slot
+	pending int
+	// the slots of the queued tasks, nil if the queue is unbounded
+	slots chan struct{}
+}
+
+func newOrderedDispatcher[K comparable](maxParallel int, maxPending int) *orderedDispatcher[K] {
+	return &orderedDispatcher[K]{
+		queues:     make(map[K]*taskQueue),
+		maxPending: maxPending,
+		workerPool: conc.NewPool[struct{}](maxParallel, conc.WithPreAlloc(false)),
+	}
+}
+
+func (d *orderedDispatcher[K]) Submit(ctx context.Context, key K, t Task, callbacks ...func(error) error) *conc.Future[struct{}] {
+	q := d.enqueue(key)
+	if q.slots != nil {
+		select {
+		case q.slots <- struct{}{}:
+		case <-ctx.Done():
+			d.dequeue(key, q, false)
+			return conc.Go(func() (struct{}, error) {
+				return struct{}{}, ctx.Err()
+			})
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	prev := q.tail
+	q.tail = conc.Go(func() (struct{}, error) {
+		defer d.dequeue(key, q, true)
+		if prev != nil {
+			// the failure of the previous task doesn't stop the later ones, which handle it by themselves
+			<-prev.Inner()
+		}
+		return d.workerPool.Submit(func() (struct{}, error) {
+			err := t.Run(ctx)
+
+			for _, callback := range callbacks {
+				err = callback(err)
+			}
+
+			return struct{}{}, err
+		}).Await()
+	})
+	return q.tail
+}
+
+// enqueue counts a task submitted into the queue of the key.
+func (d *orderedDispatcher[K]) enqueue(key K) *taskQueue {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	q, ok := d.queues[key]
+	if !ok {
+		q = &taskQueue{}
+		if d.maxPending > 0 {
+			q.slots = make(chan struct{}, d.maxPending)
+		}
+		d.queues[key] = q
+	}
+	q.pending++
+	d.setQueueDepth(key, q.pending)
+	return q
+}
+
+// dequeue counts a task of the key done, releasing its slot if it got one.
+func (d *orderedDispatcher[K]) dequeue(key K, q *taskQueue, slotted bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if slotted && q.slots != nil {
+		<-q.slots
+	}
+	q.pending--
+	if q.pending == 0 {
+		delete(d.queues, key)
+		metrics.DataNodeSyncQueueDepth.DeleteLabelValues(fmt.Sprint(paramtable.GetNodeID()), fmt.Sprint(key))
+		return
+	}
+	d.setQueueDepth(key, q.pending)
+}
+
+func (d *orderedDispatcher[K]) setQueueDepth(key K, depth int) {
+	metrics.DataNodeSyncQueueDepth.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), fmt.Sprint(key)).Set(float64(depth))
+}
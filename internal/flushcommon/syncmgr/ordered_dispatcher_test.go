@@ -0,0 +1,165 @@
+package syncmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/util/conc"
+)
+
+/*
+type mockTask struct {
+	targetID int64
+	ch       chan struct{}
+	err      error
+}
+
+func (t *mockTask) done() {
+	close(t.ch)
+}
+
+func (t *mockTask) SegmentID() int64                  { panic("no implementation") }
+func (t *mockTask) Checkpoint() *msgpb.MsgPosition    { panic("no implementation") }
+func (t *mockTask) StartPosition() *msgpb.MsgPosition { panic("no implementation") }
+func (t *mockTask) ChannelName() string               { panic("no implementation") }
+
+func (t *mockTask) Run() error {
+	<-t.ch
+	return t.err
+}
+
+func newMockTask(err error) *mockTask {
+	return &mockTask{
+		err: err,
+		ch:  make(chan struct{}),
+	}
+}*/
+
+type OrderedDispatcherSuite struct {
+	suite.Suite
+}
+
+func (s *OrderedDispatcherSuite) TestOrder() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newOrderedDispatcher[int64](2, 0)
+
+	done := make(chan struct{})
+	t1 := NewMockTask(s.T())
+	t1.EXPECT().Run(ctx).Run(func(_ context.Context) {
+		<-done
+	}).Return(nil)
+	t2 := NewMockTask(s.T())
+	t2.EXPECT().Run(ctx).Return(nil)
+
+	f1 := d.Submit(ctx, 1, t1)
+	// submit doesn't block even the previous task of the same key is running
+	f2 := d.Submit(ctx, 1, t2)
+
+	time.Sleep(time.Millisecond * 100)
+	s.False(f2.Done(), "task 2 will never run before task 1 done")
+
+	close(done)
+
+	s.NoError(conc.AwaitAll(f1, f2))
+	s.Eventually(func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return len(d.queues) == 0
+	}, time.Second, time.Millisecond*10)
+}
+
+func (s *OrderedDispatcherSuite) TestPreviousFailed() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newOrderedDispatcher[int64](2, 0)
+
+	t1 := NewMockTask(s.T())
+	t1.EXPECT().Run(ctx).Return(errors.New("mocked"))
+	t2 := NewMockTask(s.T())
+	t2.EXPECT().Run(ctx).Return(nil)
+
+	f1 := d.Submit(ctx, 1, t1)
+	f2 := d.Submit(ctx, 1, t2)
+
+	_, err := f1.Await()
+	s.Error(err)
+	_, err = f2.Await()
+	s.NoError(err)
+}
+
+func (s *OrderedDispatcherSuite) TestCap() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newOrderedDispatcher[int64](1, 0)
+
+	t1 := NewMockTask(s.T())
+	t2 := NewMockTask(s.T())
+
+	done := make(chan struct{})
+	t1.EXPECT().Run(ctx).Run(func(_ context.Context) {
+		<-done
+	}).Return(nil)
+	t2.EXPECT().Run(ctx).Return(nil)
+
+	d.Submit(ctx, 1, t1)
+	f2 := d.Submit(ctx, 2, t2)
+
+	time.Sleep(time.Millisecond * 100)
+	s.False(f2.Done(), "task 2 will never run before task 1 done")
+
+	close(done)
+
+	_, err := f2.Await()
+	s.NoError(err)
+}
+
+func (s *OrderedDispatcherSuite) TestQueueFull() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newOrderedDispatcher[int64](2, 1)
+
+	done := make(chan struct{})
+	t1 := NewMockTask(s.T())
+	t1.EXPECT().Run(ctx).Run(func(_ context.Context) {
+		<-done
+	}).Return(nil)
+	t2 := NewMockTask(s.T())
+	t2.EXPECT().Run(ctx).Return(nil)
+
+	f1 := d.Submit(ctx, 1, t1)
+
+	// submitting into a full queue is canceled with the context
+	cancelCtx, cancelSubmit := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cancelSubmit()
+	_, err := d.Submit(cancelCtx, 1, NewMockTask(s.T())).Await()
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// submitting into a full queue blocks until the previous task done
+	submitted := make(chan *conc.Future[struct{}])
+	go func() {
+		submitted <- d.Submit(ctx, 1, t2)
+	}()
+	select {
+	case <-submitted:
+		s.FailNow("submit shall block when the queue is full")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	close(done)
+	f2 := <-submitted
+	s.NoError(conc.AwaitAll(f1, f2))
+	s.Eventually(func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return len(d.queues) == 0
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestOrderedDispatcher(t *testing.T) {
+	suite.Run(t, new(OrderedDispatcherSuite))
+}
@@ -19,6 +19,7 @@ package syncmgr
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/samber/lo"
@@ -30,11 +31,13 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type storageV1Serializer struct {
@@ -120,6 +123,10 @@ func (s *storageV1Serializer) EncodeBuffer(ctx context.Context, pack *SyncPack)
 		}
 
 		s.metacache.UpdateSegments(metacache.MergeSegmentAction(actions...), metacache.WithSegmentIDs(pack.segmentID))
+
+		if paramtable.Get().DataNodeCfg.JSONKeySchemaEnabled.GetAsBool() {
+			task.jsonKeySchemas = s.learnJSONKeySchemas(ctx, pack)
+		}
 	}
 
 	if pack.isFlush {
@@ -201,6 +208,53 @@ func (s *storageV1Serializer) serializeBinlog(ctx context.Context, pack *SyncPac
 	return result, nil
 }
 
+// learnJSONKeySchemas learns the key paths and value types of the JSON fields from the rows of the pack.
+func (s *storageV1Serializer) learnJSONKeySchemas(ctx context.Context, pack *SyncPack) []*datapb.JSONKeySchema {
+	maxKeys := paramtable.Get().DataNodeCfg.JSONKeySchemaMaxKeys.GetAsInt()
+	var result []*datapb.JSONKeySchema
+	for _, field := range s.schema.GetFields() {
+		if field.GetDataType() != schemapb.DataType_JSON {
+			continue
+		}
+		keySchema := typeutil.NewJSONKeySchema(maxKeys)
+		var invalidRows int
+		for _, chunk := range pack.insertData {
+			fieldData, ok := chunk.Data[field.GetFieldID()].(*storage.JSONFieldData)
+			if !ok {
+				continue
+			}
+			for i, row := range fieldData.Data {
+				if fieldData.Nullable && !fieldData.ValidData[i] {
+					continue
+				}
+				if err := keySchema.Observe(row); err != nil {
+					invalidRows++
+				}
+			}
+		}
+		if invalidRows > 0 {
+			log.Ctx(ctx).Warn("skip invalid json rows when learning json key schema",
+				zap.Int64("segmentID", pack.segmentID),
+				zap.Int64("fieldID", field.GetFieldID()),
+				zap.Int("invalidRows", invalidRows))
+		}
+		if keySchema.Len() == 0 {
+			continue
+		}
+		keys := keySchema.Keys()
+		paths := lo.Keys(keys)
+		sort.Strings(paths)
+		result = append(result, &datapb.JSONKeySchema{
+			FieldID: field.GetFieldID(),
+			Keys: lo.Map(paths, func(path string, _ int) *datapb.JSONKey {
+				return &datapb.JSONKey{Path: path, Types: keys[path]}
+			}),
+			Truncated: keySchema.Truncated(),
+		})
+	}
+	return result
+}
+
 func (s *storageV1Serializer) serializeBM25Stats(pack *SyncPack) (map[int64]*storage.Blob, error) {
 	blobs := make(map[int64]*storage.Blob)
 	for fieldID, stats := range pack.bm25Stats {
@@ -227,7 +281,9 @@ func (s *storageV1Serializer) serializeStatslog(pack *SyncPack) (*storage.Primar
 		rowNum += int64(chunkPKData.RowNum())
 	}
 
-	stats, err := storage.NewPrimaryKeyStats(s.pkField.GetFieldID(), int64(s.pkField.GetDataType()), rowNum)
+	// invalid collection level false positive rate falls back to the global one
+	falsePositive, _ := common.CollectionBloomFilterFalsePositive(s.schema.GetProperties()...)
+	stats, err := storage.NewPrimaryKeyStatsWithFalsePositive(s.pkField.GetFieldID(), int64(s.pkField.GetDataType()), rowNum, falsePositive)
 	if err != nil {
 		return nil, nil, err
 	}
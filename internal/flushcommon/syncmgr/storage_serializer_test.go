@@ -38,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type StorageV1SerializerSuite struct {
@@ -307,6 +308,40 @@ func (s *StorageV1SerializerSuite) TestBadSchema() {
 	s.Error(err)
 }
 
+func (s *StorageV1SerializerSuite) TestLearnJSONKeySchemas() {
+	serializer := &storageV1Serializer{
+		schema: &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 102, Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+				{FieldID: 103, Name: "empty", DataType: schemapb.DataType_JSON, Nullable: true},
+			},
+		},
+	}
+	pack := s.getBasicPack()
+	pack.WithInsertData([]*storage.InsertData{{
+		Data: map[storage.FieldID]storage.FieldData{
+			100: &storage.Int64FieldData{Data: []int64{1, 2, 3}},
+			102: &storage.JSONFieldData{Data: [][]byte{
+				[]byte(`{"a": 1, "b": {"c": "x"}}`),
+				[]byte(`{"a": 1.5}`),
+				[]byte(`invalid`),
+			}},
+			103: &storage.JSONFieldData{Data: [][]byte{nil, nil, nil}, ValidData: []bool{false, false, false}, Nullable: true},
+		},
+	}})
+
+	schemas := serializer.learnJSONKeySchemas(context.Background(), pack)
+	s.Require().Len(schemas, 1)
+	s.EqualValues(102, schemas[0].GetFieldID())
+	s.False(schemas[0].GetTruncated())
+	s.Equal([]*datapb.JSONKey{
+		{Path: "/a", Types: []string{typeutil.JSONTypeDouble, typeutil.JSONTypeInt}},
+		{Path: "/b", Types: []string{typeutil.JSONTypeObject}},
+		{Path: "/b/c", Types: []string{typeutil.JSONTypeString}},
+	}, schemas[0].GetKeys())
+}
+
 func TestStorageV1Serializer(t *testing.T) {
 	suite.Run(t, new(StorageV1SerializerSuite))
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/conc"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/retry"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -53,8 +54,9 @@ type SyncManager interface {
 }
 
 type syncManager struct {
-	*keyLockDispatcher[int64]
+	*orderedDispatcher[string]
 	chunkManager storage.ChunkManager
+	writeLimiter *writeLimiter
 
 	tasks     *typeutil.ConcurrentMap[string, Task]
 	taskStats *expirable.LRU[string, Task]
@@ -63,12 +65,14 @@ type syncManager struct {
 func NewSyncManager(chunkManager storage.ChunkManager) SyncManager {
 	params := paramtable.Get()
 	initPoolSize := params.DataNodeCfg.MaxParallelSyncMgrTasks.GetAsInt()
-	dispatcher := newKeyLockDispatcher[int64](initPoolSize)
-	log.Info("sync manager initialized", zap.Int("initPoolSize", initPoolSize))
+	maxPending := params.DataNodeCfg.MaxPendingSyncTasks.GetAsInt()
+	dispatcher := newOrderedDispatcher[string](initPoolSize, maxPending)
+	log.Info("sync manager initialized", zap.Int("initPoolSize", initPoolSize), zap.Int("maxPending", maxPending))
 
 	syncMgr := &syncManager{
-		keyLockDispatcher: dispatcher,
+		orderedDispatcher: dispatcher,
 		chunkManager:      chunkManager,
+		writeLimiter:      newWriteLimiter(),
 		tasks:             typeutil.NewConcurrentMap[string, Task](),
 		taskStats:         expirable.NewLRU[string, Task](16, nil, time.Minute*15),
 	}
@@ -88,7 +92,7 @@ func (mgr *syncManager) resizeHandler(evt *config.Event) {
 			log.Warn("failed to parse new datanode syncmgr pool size", zap.Error(err))
 			return
 		}
-		err = mgr.keyLockDispatcher.workerPool.Resize(int(size))
+		err = mgr.orderedDispatcher.workerPool.Resize(int(size))
 		if err != nil {
 			log.Warn("failed to resize datanode syncmgr pool size", zap.String("key", evt.Key), zap.String("value", evt.Value), zap.Error(err))
 			return
@@ -101,6 +105,10 @@ func (mgr *syncManager) SyncData(ctx context.Context, task Task, callbacks ...fu
 	switch t := task.(type) {
 	case *SyncTask:
 		t.WithChunkManager(mgr.chunkManager)
+		t.withWriteLimiter(mgr.writeLimiter)
+		if len(t.writeRetryOpts) == 0 {
+			t.WithWriteRetryOptions(retry.Attempts(paramtable.Get().DataNodeCfg.SyncWriteRetryAttempts.GetAsUint()))
+		}
 	}
 
 	return mgr.safeSubmitTask(ctx, task, callbacks...)
@@ -112,11 +120,12 @@ func (mgr *syncManager) safeSubmitTask(ctx context.Context, task Task, callbacks
 	mgr.tasks.Insert(taskKey, task)
 	mgr.taskStats.Add(taskKey, task)
 
-	key := task.SegmentID()
+	// tasks of the same channel are written in order, tasks of different channels are written concurrently
+	key := task.ChannelName()
 	return mgr.submit(ctx, key, task, callbacks...)
 }
 
-func (mgr *syncManager) submit(ctx context.Context, key int64, task Task, callbacks ...func(error) error) *conc.Future[struct{}] {
+func (mgr *syncManager) submit(ctx context.Context, key string, task Task, callbacks ...func(error) error) *conc.Future[struct{}] {
 	handler := func(err error) error {
 		if err == nil {
 			return nil
@@ -125,7 +134,7 @@ func (mgr *syncManager) submit(ctx context.Context, key int64, task Task, callba
 		return err
 	}
 	callbacks = append([]func(error) error{handler}, callbacks...)
-	log.Info("sync mgr sumbit task with key", zap.Int64("key", key))
+	log.Info("sync mgr sumbit task with key", zap.String("key", key), zap.Int64("segmentID", task.SegmentID()))
 	return mgr.Submit(ctx, key, task, callbacks...)
 }
 
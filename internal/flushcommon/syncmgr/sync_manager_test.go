@@ -216,7 +216,7 @@ func (s *SyncManagerSuite) TestResizePool() {
 	syncMgr, ok := manager.(*syncManager)
 	s.Require().True(ok)
 
-	cap := syncMgr.keyLockDispatcher.workerPool.Cap()
+	cap := syncMgr.orderedDispatcher.workerPool.Cap()
 	s.NotZero(cap)
 
 	params := paramtable.Get()
@@ -228,21 +228,21 @@ func (s *SyncManagerSuite) TestResizePool() {
 		HasUpdated: true,
 	})
 
-	s.Equal(cap, syncMgr.keyLockDispatcher.workerPool.Cap())
+	s.Equal(cap, syncMgr.orderedDispatcher.workerPool.Cap())
 
 	syncMgr.resizeHandler(&config.Event{
 		Key:        configKey,
 		Value:      "-1",
 		HasUpdated: true,
 	})
-	s.Equal(cap, syncMgr.keyLockDispatcher.workerPool.Cap())
+	s.Equal(cap, syncMgr.orderedDispatcher.workerPool.Cap())
 
 	syncMgr.resizeHandler(&config.Event{
 		Key:        configKey,
 		Value:      strconv.FormatInt(int64(cap*2), 10),
 		HasUpdated: true,
 	})
-	s.Equal(cap*2, syncMgr.keyLockDispatcher.workerPool.Cap())
+	s.Equal(cap*2, syncMgr.orderedDispatcher.workerPool.Cap())
 }
 
 func (s *SyncManagerSuite) TestUnexpectedError() {
@@ -250,6 +250,7 @@ func (s *SyncManagerSuite) TestUnexpectedError() {
 
 	task := NewMockTask(s.T())
 	task.EXPECT().SegmentID().Return(1000)
+	task.EXPECT().ChannelName().Return(s.channelName)
 	task.EXPECT().Checkpoint().Return(&msgpb.MsgPosition{})
 	task.EXPECT().Run(mock.Anything).Return(merr.WrapErrServiceInternal("mocked")).Once()
 	task.EXPECT().HandleError(mock.Anything)
@@ -264,6 +265,7 @@ func (s *SyncManagerSuite) TestTargetUpdateSameID() {
 
 	task := NewMockTask(s.T())
 	task.EXPECT().SegmentID().Return(1000)
+	task.EXPECT().ChannelName().Return(s.channelName)
 	task.EXPECT().Checkpoint().Return(&msgpb.MsgPosition{})
 	task.EXPECT().Run(mock.Anything).Return(errors.New("mock err")).Once()
 	task.EXPECT().HandleError(mock.Anything)
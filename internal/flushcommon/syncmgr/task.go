@@ -91,12 +91,16 @@ type SyncTask struct {
 	deltaBlob     *storage.Blob
 	deltaRowCount int64
 
+	// key paths and value types learned from the JSON fields
+	jsonKeySchemas []*datapb.JSONKeySchema
+
 	// prefetched log ids
 	ids []int64
 
 	segmentData map[string][]byte
 
 	writeRetryOpts []retry.Option
+	writeLimiter   *writeLimiter
 
 	failureCallback func(err error)
 
@@ -380,7 +384,14 @@ func (t *SyncTask) appendDeltalog(deltalog *datapb.Binlog) {
 
 // writeLogs writes log files (binlog/deltalog/statslog) into storage via chunkManger.
 func (t *SyncTask) writeLogs(ctx context.Context) error {
+	size := 0
+	for _, data := range t.segmentData {
+		size += len(data)
+	}
 	return retry.Handle(ctx, func() (bool, error) {
+		if err := t.writeLimiter.wait(ctx, size); err != nil {
+			return false, err
+		}
 		err := t.chunkManager.MultiWrite(ctx, t.segmentData)
 		if err != nil {
 			return !merr.IsCanceledOrTimeout(err), err
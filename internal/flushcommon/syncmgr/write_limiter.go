@@ -0,0 +1,75 @@
+package syncmgr
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/pkg/config"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// writeLimiter limits the bytes rate of sync tasks writing into the storage globally.
+type writeLimiter struct {
+	limiter *rate.Limiter
+}
+
+func newWriteLimiter() *writeLimiter {
+	params := paramtable.Get()
+	l := &writeLimiter{
+		limiter: rate.NewLimiter(rate.Inf, 0),
+	}
+	l.setRate(params.DataNodeCfg.SyncWriteRateLimit.GetAsFloat())
+	params.Watch(params.DataNodeCfg.SyncWriteRateLimit.Key, config.NewHandler("datanode.syncmgr.writeRateLimit", l.updateHandler))
+	return l
+}
+
+// setRate sets the limit in MB/s, non-positive value means no limit.
+func (l *writeLimiter) setRate(mb float64) {
+	if mb <= 0 {
+		l.limiter.SetLimit(rate.Inf)
+		return
+	}
+	bytes := mb * 1024 * 1024
+	burst := int(bytes)
+	if burst < 1 {
+		burst = 1
+	}
+	l.limiter.SetBurst(burst)
+	l.limiter.SetLimit(rate.Limit(bytes))
+}
+
+func (l *writeLimiter) updateHandler(evt *config.Event) {
+	if !evt.HasUpdated {
+		return
+	}
+	mb, err := strconv.ParseFloat(evt.Value, 64)
+	if err != nil {
+		log.Warn("failed to parse datanode sync write rate limit", zap.String("value", evt.Value), zap.Error(err))
+		return
+	}
+	l.setRate(mb)
+	log.Info("sync mgr write rate limit updated", zap.Float64("MB/s", mb))
+}
+
+// wait blocks until size bytes are allowed to write, or the context is done.
+func (l *writeLimiter) wait(ctx context.Context, size int) error {
+	if l == nil || l.limiter.Limit() == rate.Inf {
+		return nil
+	}
+	burst := l.limiter.Burst()
+	for size > 0 {
+		n := size
+		if n > burst {
+			n = burst
+		}
+		if err := l.limiter.WaitN(ctx, n); err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package syncmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/pkg/config"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestWriteLimiter(t *testing.T) {
+	paramtable.Init()
+	l := newWriteLimiter()
+	assert.Equal(t, rate.Inf, l.limiter.Limit())
+	assert.NoError(t, l.wait(context.Background(), 1<<30))
+
+	// a nil limiter never blocks
+	var nilLimiter *writeLimiter
+	assert.NoError(t, nilLimiter.wait(context.Background(), 1<<30))
+
+	key := paramtable.Get().DataNodeCfg.SyncWriteRateLimit.Key
+	l.updateHandler(&config.Event{Key: key, Value: "abc", HasUpdated: true})
+	assert.Equal(t, rate.Inf, l.limiter.Limit())
+
+	l.updateHandler(&config.Event{Key: key, Value: "1", HasUpdated: true})
+	assert.EqualValues(t, 1024*1024, l.limiter.Limit())
+	assert.Equal(t, 1024*1024, l.limiter.Burst())
+
+	// the first burst is allowed right away, the next one has to wait
+	assert.NoError(t, l.wait(context.Background(), 1024*1024))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, l.wait(ctx, 1024*1024))
+
+	l.updateHandler(&config.Event{Key: key, Value: "0", HasUpdated: true})
+	assert.Equal(t, rate.Inf, l.limiter.Limit())
+	assert.NoError(t, l.wait(context.Background(), 1<<30))
+}
@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const bearerPrefix = "Bearer "
+
+// authMiddleware requires the basic auth or the bearer token configured for the management endpoints.
+// The credentials are read for every request, so their updates take effect at once.
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cfg := &paramtable.Get().ManagementAuthCfg
+		// the health probes are left open for the orchestrators
+		if !cfg.Enabled.GetAsBool() || req.URL.Path == HealthzRouterPath {
+			next.ServeHTTP(w, req)
+			return
+		}
+		if authorized(req, cfg) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		log.RatedWarn(10, "unauthorized management request", zap.String("path", req.URL.Path), zap.String("remote", req.RemoteAddr))
+		w.Header().Set("WWW-Authenticate", `Basic realm="milvus"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"msg": "unauthorized"}`))
+	})
+}
+
+func authorized(req *http.Request, cfg *paramtable.ManagementAuthConfig) bool {
+	if token := cfg.Token.GetValue(); token != "" {
+		header := req.Header.Get("Authorization")
+		if strings.HasPrefix(header, bearerPrefix) && secureEqual(strings.TrimPrefix(header, bearerPrefix), token) {
+			return true
+		}
+	}
+	expectedUsername, expectedPassword := cfg.Username.GetValue(), cfg.Password.GetValue()
+	if expectedUsername == "" || expectedPassword == "" {
+		return false
+	}
+	username, password, ok := req.BasicAuth()
+	// compare both to not tell which one is wrong by the time taken
+	usernameOK := secureEqual(username, expectedUsername)
+	passwordOK := secureEqual(password, expectedPassword)
+	return ok && usernameOK && passwordOK
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	cfg := &params.ManagementAuthCfg
+	defer func() {
+		params.Reset(cfg.Enabled.Key)
+		params.Reset(cfg.Username.Key)
+		params.Reset(cfg.Password.Key)
+		params.Reset(cfg.Token.Key)
+	}()
+
+	handler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(path string, setAuth func(req *http.Request)) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if setAuth != nil {
+			setAuth(req)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+	basicAuth := func(username, password string) func(req *http.Request) {
+		return func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		}
+	}
+	bearer := func(token string) func(req *http.Request) {
+		return func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	// disabled by default
+	assert.Equal(t, http.StatusOK, serve(LogLevelRouterPath, nil))
+
+	// enabled without any credential configured
+	params.Save(cfg.Enabled.Key, "true")
+	assert.Equal(t, http.StatusUnauthorized, serve(LogLevelRouterPath, nil))
+	assert.Equal(t, http.StatusUnauthorized, serve(LogLevelRouterPath, basicAuth("", "")))
+	assert.Equal(t, http.StatusUnauthorized, serve(LogLevelRouterPath, bearer("")))
+	assert.Equal(t, http.StatusOK, serve(HealthzRouterPath, nil))
+
+	params.Save(cfg.Username.Key, "admin")
+	params.Save(cfg.Password.Key, "secret")
+	assert.Equal(t, http.StatusOK, serve(EventLogRouterPath, basicAuth("admin", "secret")))
+	assert.Equal(t, http.StatusUnauthorized, serve(EventLogRouterPath, basicAuth("admin", "wrong")))
+	assert.Equal(t, http.StatusUnauthorized, serve(EventLogRouterPath, basicAuth("other", "secret")))
+	assert.Equal(t, http.StatusUnauthorized, serve(EventLogRouterPath, bearer("secret")))
+
+	params.Save(cfg.Token.Key, "token")
+	assert.Equal(t, http.StatusOK, serve("/debug/pprof/", bearer("token")))
+	assert.Equal(t, http.StatusUnauthorized, serve("/debug/pprof/", bearer("wrong")))
+	assert.Equal(t, http.StatusOK, serve("/debug/pprof/", basicAuth("admin", "secret")))
+
+	// hot update
+	params.Save(cfg.Password.Key, "updated")
+	assert.Equal(t, http.StatusUnauthorized, serve(EventLogRouterPath, basicAuth("admin", "secret")))
+	assert.Equal(t, http.StatusOK, serve(EventLogRouterPath, basicAuth("admin", "updated")))
+	params.Save(cfg.Enabled.Key, "false")
+	assert.Equal(t, http.StatusOK, serve(EventLogRouterPath, nil))
+}
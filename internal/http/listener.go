@@ -0,0 +1,103 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net"
+	"sync"
+)
+
+// sharedListener accepts the connections of a bound address and hands them to the server serving a view of it,
+// so the server restarted on the same address takes over the connections without rebinding.
+type sharedListener struct {
+	net.Listener
+	bindAddr string
+
+	conns chan net.Conn
+	// closed once the listener is closed
+	done      chan struct{}
+	closeOnce sync.Once
+	// closed once the accept loop ends, with err set
+	stopped chan struct{}
+	err     error
+}
+
+func newSharedListener(lis net.Listener, bindAddr string) *sharedListener {
+	l := &sharedListener{
+		Listener: lis,
+		bindAddr: bindAddr,
+		conns:    make(chan net.Conn),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go l.acceptLoop()
+	return l
+}
+
+func (l *sharedListener) acceptLoop() {
+	defer close(l.stopped)
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			l.err = err
+			return
+		}
+		select {
+		case l.conns <- conn:
+		case <-l.done:
+			conn.Close()
+			l.err = net.ErrClosed
+			return
+		}
+	}
+}
+
+func (l *sharedListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.done)
+	})
+	return l.Listener.Close()
+}
+
+// view returns a listener accepting the connections until it's closed, which leaves the shared listener open.
+func (l *sharedListener) view() net.Listener {
+	return &listenerView{sharedListener: l, closed: make(chan struct{})}
+}
+
+type listenerView struct {
+	*sharedListener
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (v *listenerView) Accept() (net.Conn, error) {
+	select {
+	case conn := <-v.conns:
+		return conn, nil
+	case <-v.closed:
+		return nil, net.ErrClosed
+	case <-v.stopped:
+		return nil, v.err
+	}
+}
+
+func (v *listenerView) Close() error {
+	v.closeOnce.Do(func() {
+		close(v.closed)
+	})
+	return nil
+}
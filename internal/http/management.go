@@ -0,0 +1,162 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// ManagementRouter registers the management handlers of a component under /management/<component>/,
+// the requests are validated by the methods, logged, and answered with the json error envelope on failure.
+type ManagementRouter struct {
+	component string
+	prefix    string
+}
+
+func NewManagementRouter(component string) *ManagementRouter {
+	return &ManagementRouter{
+		component: component,
+		prefix:    ManagementRootPath + component + "/",
+	}
+}
+
+// Prefix returns the path prefix of the component.
+func (r *ManagementRouter) Prefix() string {
+	return r.prefix
+}
+
+// Register registers the handler, all the methods are allowed if none is given.
+// It panics if the path is outside the namespace of the component, which is a bug.
+func (r *ManagementRouter) Register(h *Handler, methods ...string) {
+	if !strings.HasPrefix(h.Path, r.prefix) {
+		panic(fmt.Sprintf("management path %s is outside %s", h.Path, r.prefix))
+	}
+	handler := h.Handler
+	if h.HandlerFunc != nil {
+		handler = h.HandlerFunc
+	}
+	Register(&Handler{
+		Path:    h.Path,
+		Handler: r.wrap(handler, methods),
+	})
+}
+
+// RegisterMutating registers the handler changing the cluster, it's refused unless the management auth is enabled,
+// as the management port is covered by neither the authentication nor the rbac of the grpc api.
+// The requests isDryRun returns true for only plan the changes and are served anyway, isDryRun may be nil.
+func (r *ManagementRouter) RegisterMutating(h *Handler, isDryRun func(req *http.Request) bool, methods ...string) {
+	handler := h.Handler
+	if h.HandlerFunc != nil {
+		handler = h.HandlerFunc
+	}
+	r.Register(&Handler{
+		Path: h.Path,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if !paramtable.Get().ManagementAuthCfg.Enabled.GetAsBool() && (isDryRun == nil || !isDryRun(req)) {
+				WriteError(w, http.StatusForbidden, "the request changes the cluster, which requires the management auth to be enabled")
+				return
+			}
+			handler.ServeHTTP(w, req)
+		}),
+	}, methods...)
+}
+
+// IsDryRun returns whether the dry_run form value of the request is true.
+func IsDryRun(req *http.Request) bool {
+	dryRun, err := strconv.ParseBool(req.FormValue("dry_run"))
+	return err == nil && dryRun
+}
+
+func (r *ManagementRouter) wrap(handler http.Handler, methods []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if p := recover(); p != nil {
+				log.Error("management handler panicked", zap.String("component", r.component), zap.String("path", req.URL.Path), zap.Any("panic", p))
+				WriteError(sw, http.StatusInternalServerError, fmt.Sprintf("internal error, %v", p))
+			}
+			log.Info("management request",
+				zap.String("component", r.component),
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.String("remote", req.RemoteAddr),
+				zap.Int("status", sw.status),
+				zap.Duration("duration", time.Since(start)))
+		}()
+
+		if len(methods) > 0 && !containsMethod(methods, req.Method) {
+			sw.Header().Set("Allow", strings.Join(methods, ", "))
+			WriteError(sw, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", req.Method))
+			return
+		}
+		handler.ServeHTTP(sw, req)
+	})
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteError writes the json error envelope of the management api.
+func WriteError(w http.ResponseWriter, status int, msg string) {
+	body, _ := json.Marshal(map[string]string{"msg": msg})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// statusResponseWriter records the status written for the request log.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets the http.ResponseController reach the flusher and the deadlines of the underlying writer.
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,98 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestManagementRouter(t *testing.T) {
+	paramtable.Init()
+	router := NewManagementRouter("testcomponent")
+	assert.Equal(t, "/management/testcomponent/", router.Prefix())
+
+	assert.Panics(t, func() {
+		router.Register(&Handler{Path: "/management/other/path", HandlerFunc: func(w http.ResponseWriter, req *http.Request) {}})
+	})
+
+	router.Register(&Handler{
+		Path: "/management/testcomponent/get",
+		HandlerFunc: func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(`{"msg": "OK"}`))
+		},
+	}, http.MethodGet)
+	router.Register(&Handler{
+		Path: "/management/testcomponent/any",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}),
+	})
+	router.Register(&Handler{
+		Path: "/management/testcomponent/panic",
+		HandlerFunc: func(w http.ResponseWriter, req *http.Request) {
+			panic("oops")
+		},
+	})
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		metricsServer.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	w := serve(http.MethodGet, "/management/testcomponent/get")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"msg": "OK"}`, w.Body.String())
+
+	w = serve(http.MethodPost, "/management/testcomponent/get")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, http.MethodGet, w.Header().Get("Allow"))
+	assert.JSONEq(t, `{"msg": "method POST not allowed"}`, w.Body.String())
+
+	w = serve(http.MethodPost, "/management/testcomponent/any")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	w = serve(http.MethodGet, "/management/testcomponent/panic")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"msg": "internal error, oops"}`, w.Body.String())
+
+	router.RegisterMutating(&Handler{
+		Path: "/management/testcomponent/mutate",
+		HandlerFunc: func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(`{"msg": "OK"}`))
+		},
+	}, IsDryRun, http.MethodPost)
+
+	paramtable.Get().Save(paramtable.Get().ManagementAuthCfg.Enabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().ManagementAuthCfg.Enabled.Key)
+	w = serve(http.MethodPost, "/management/testcomponent/mutate")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serve(http.MethodPost, "/management/testcomponent/mutate?dry_run=true")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(http.MethodGet, "/management/testcomponent/mutate?dry_run=true")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	paramtable.Get().Save(paramtable.Get().ManagementAuthCfg.Enabled.Key, "true")
+	w = serve(http.MethodPost, "/management/testcomponent/mutate")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -39,6 +39,9 @@ const (
 	MetricsDefaultPath = "/metrics_default"
 )
 
+// ManagementRootPath is the root path of the management api, see ManagementRouter.
+const ManagementRootPath = "/management/"
+
 // for every component, register it's own api to trigger stop and check ready
 const (
 	RouteTriggerStopPath     = "/management/stop"
@@ -48,8 +51,17 @@ const (
 
 // proxy management restful api root path
 const (
-	RouteGcPause  = "/management/datacoord/garbage_collection/pause"
-	RouteGcResume = "/management/datacoord/garbage_collection/resume"
+	RouteGcPause      = "/management/datacoord/garbage_collection/pause"
+	RouteGcResume     = "/management/datacoord/garbage_collection/resume"
+	RouteSegmentStats = "/management/datacoord/segment/stats"
+
+	RouteCreateBackup  = "/management/datacoord/backup/create"
+	RouteRestoreBackup = "/management/datacoord/backup/restore"
+	RouteListBackups   = "/management/datacoord/backup/list"
+	RouteDropBackup    = "/management/datacoord/backup/drop"
+
+	RouteInspectChannelCheckpoints = "/management/datacoord/channel_checkpoint/inspect"
+	RouteRepairChannelCheckpoint   = "/management/datacoord/channel_checkpoint/repair"
 
 	RouteSuspendQueryCoordBalance = "/management/querycoord/balance/suspend"
 	RouteResumeQueryCoordBalance  = "/management/querycoord/balance/resume"
@@ -61,6 +73,27 @@ const (
 	RouteListQueryNode              = "/management/querycoord/node/list"
 	RouteGetQueryNodeDistribution   = "/management/querycoord/distribution/get"
 	RouteCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
+
+	RouteResourceGroupDatabases = "/management/querycoord/resource_group/databases"
+
+	RouteIndexAdvise = "/management/proxy/index/advise"
+	RouteUsage       = "/management/proxy/usage"
+
+	RouteExportCollectionSpec = "/management/proxy/collection/spec/export"
+	RouteApplyCollectionSpec  = "/management/proxy/collection/spec/apply"
+
+	RouteBatchCreateCollections = "/management/proxy/collection/batch_create"
+
+	RouteDeleteProgress = "/management/proxy/delete/progress"
+
+	RouteDedupVectors    = "/management/proxy/collection/vectors/dedup"
+	RouteDedupVectorsJob = "/management/proxy/collection/vectors/dedup/job"
+)
+
+// querynode management restful api root path
+const (
+	RouteQueryNodeSegments      = "/management/querynode/segments"
+	RouteQueryNodeSegmentEvents = "/management/querynode/segments/events"
 )
 
 // for WebUI restful api root path
@@ -88,8 +121,14 @@ const (
 	QCResourceGroupPath = "/_qc/resource_group"
 	// QCAllTasksPath is the path to get all tasks in QueryCoord.
 	QCAllTasksPath = "/_qc/tasks"
+	// QCRecoveryProgressPath is the path to get segment recovery progress of querynodes in QueryCoord.
+	QCRecoveryProgressPath = "/_qc/recovery_progress"
 	// QCSegmentsPath is the path to get segments in QueryCoord.
 	QCSegmentsPath = "/_qc/segments"
+	// QCRowCountScrubPath is the path to get the row count scrub report in QueryCoord.
+	QCRowCountScrubPath = "/_qc/row_count_scrub"
+	// QCDecisionJournalPath is the path to get the decision journal in QueryCoord.
+	QCDecisionJournalPath = "/_qc/decision_journal"
 
 	// QNSegmentsPath is the path to get segments in QueryNode.
 	QNSegmentsPath = "/_qn/segments"
@@ -106,6 +145,8 @@ const (
 	DCBuildIndexTasksPath = "/_dc/tasks/build_index"
 	// DCSegmentsPath is the path to get segments in DataCoord.
 	DCSegmentsPath = "/_dc/segments"
+	// DCDecisionJournalPath is the path to get the decision journal in DataCoord.
+	DCDecisionJournalPath = "/_dc/decision_journal"
 
 	// DNSyncTasksPath is the path to get sync tasks in DataNode.
 	DNSyncTasksPath = "/_dn/tasks/sync"
@@ -17,33 +17,47 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
 	"embed"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/config"
 	"github.com/milvus-io/milvus/pkg/eventlog"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/expr"
+	"github.com/milvus-io/milvus/pkg/util/netutil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
 const (
 	DefaultListenPort = "9091"
 	ListenPortEnvKey  = "METRICS_PORT"
+
+	// DefaultShutdownTimeout bounds the time to drain the connections when the server restarts.
+	DefaultShutdownTimeout = 10 * time.Second
 )
 
 var (
 	metricsServer *http.ServeMux
 	server        *http.Server
+	// listener is bound to the address of the server, shared by the servers restarted on the same address
+	listener *sharedListener
+	// serverMu guards the server against the concurrent start and stop.
+	serverMu sync.Mutex
 )
 
 // Embedding all static files of webui folder to binary
@@ -235,25 +249,213 @@ func Register(h *Handler) {
 
 func ServeHTTP() {
 	registerDefaults()
+	watchServerConfig()
+	// enable mutex && block profile, sampling rate 10%
+	runtime.SetMutexProfileFraction(10)
+	runtime.SetBlockProfileRate(10)
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+	if err := startServer(); err != nil {
+		log.Error("handle metrics failed", zap.Error(err))
+	}
+}
+
+// Stop stops the management server, the connections are drained until the ctx is done and closed then.
+func Stop(ctx context.Context) error {
+	serverMu.Lock()
+	defer serverMu.Unlock()
+	return stopServer(ctx)
+}
+
+// Restart restarts the management server, so the changes of the port, the listener and the tls config take effect.
+// The new config is validated and the new address bound before the running server is replaced,
+// which keeps serving on failure. The server restarted on the same address takes over the bound listener.
+func Restart(ctx context.Context) error {
+	serverMu.Lock()
+	defer serverMu.Unlock()
+	if server == nil {
+		return startServer()
+	}
+
+	cfg, err := loadServerConfig()
+	if err != nil {
+		return err
+	}
+	oldServer, oldListener := server, listener
+	if cfg.bindAddr != oldListener.bindAddr {
+		lis, err := net.Listen("tcp", cfg.bindAddr)
+		if err != nil && samePort(cfg.bindAddr, oldListener.bindAddr) {
+			return restartOnPort(ctx, cfg)
+		}
+		if err != nil {
+			return err
+		}
+		listener = newSharedListener(lis, cfg.bindAddr)
+	}
+	serve(cfg)
+
+	// the new server accepts the connections from now on, the old one drains its connections
+	if err := shutdownServer(ctx, oldServer); err != nil {
+		log.Warn("failed to drain the connections of management server", zap.Error(err))
+	}
+	if listener != oldListener {
+		oldListener.Close()
+	}
+	return nil
+}
+
+// restartOnPort replaces the server bound on an overlapping address of the same port, e.g. all the interfaces,
+// which can't be bound together with the new address, so the old server is stopped first.
+// The old address is bound again if the new one fails.
+func restartOnPort(ctx context.Context, cfg *serverConfig) error {
+	oldBindAddr := listener.bindAddr
+	if err := stopServer(ctx); err != nil {
+		log.Warn("failed to drain the connections of management server", zap.Error(err))
+	}
+	lis, err := net.Listen("tcp", cfg.bindAddr)
+	if err != nil {
+		oldLis, rebindErr := net.Listen("tcp", oldBindAddr)
+		if rebindErr != nil {
+			return errors.Wrapf(rebindErr, "failed to bind the old address after %s", err.Error())
+		}
+		listener = newSharedListener(oldLis, oldBindAddr)
+		serve(cfg)
+		return err
+	}
+	listener = newSharedListener(lis, cfg.bindAddr)
+	serve(cfg)
+	return nil
+}
+
+func samePort(addr1, addr2 string) bool {
+	_, port1, err1 := net.SplitHostPort(addr1)
+	_, port2, err2 := net.SplitHostPort(addr2)
+	return err1 == nil && err2 == nil && port1 == port2
+}
+
+type serverConfig struct {
+	addr      string
+	bindAddr  string
+	tlsConfig *tls.Config
+	allowed   []*net.IPNet
+}
+
+func loadServerConfig() (*serverConfig, error) {
+	addr := getHTTPAddr()
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load metrics tls config")
+	}
+	listenerCfg := &paramtable.Get().ListenerCfg
+	allowed, err := netutil.ParseCIDRs(listenerCfg.ManagementAllowedCIDRs.GetAsStrings())
+	if err != nil {
+		return nil, err
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &serverConfig{
+		addr:      addr,
+		bindAddr:  net.JoinHostPort(listenerCfg.ManagementBindIP.GetValue(), port),
+		tlsConfig: tlsConfig,
+		allowed:   allowed,
+	}, nil
+}
+
+func startServer() error {
+	if server != nil {
+		return errors.New("management server is already running")
+	}
+	cfg, err := loadServerConfig()
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", cfg.bindAddr)
+	if err != nil {
+		return err
+	}
+	listener = newSharedListener(lis, cfg.bindAddr)
+	serve(cfg)
+	return nil
+}
+
+// serve starts a server on a view of the current listener, and makes it the current server.
+func serve(cfg *serverConfig) {
+	lis := netutil.NewAllowlistListener(listener.view(), cfg.allowed)
+	log.Info("management listen", zap.String("addr", lis.Addr().String()))
+	srv := &http.Server{Handler: authMiddleware(metricsServer), Addr: cfg.addr, ReadTimeout: 10 * time.Second, TLSConfig: cfg.tlsConfig}
+	server = srv
+
 	go func() {
-		bindAddr := getHTTPAddr()
-		log.Info("management listen", zap.String("addr", bindAddr))
-		server = &http.Server{Handler: metricsServer, Addr: bindAddr, ReadTimeout: 10 * time.Second}
-		// enable mutex && block profile, sampling rate 10%
-		runtime.SetMutexProfileFraction(10)
-		runtime.SetBlockProfileRate(10)
-
-		if err := server.ListenAndServe(); err != nil {
+		var err error
+		if cfg.tlsConfig != nil {
+			// the certificates are served by the tls config
+			err = srv.ServeTLS(lis, "", "")
+		} else {
+			err = srv.Serve(lis)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Error("handle metrics failed", zap.Error(err))
 		}
 	}()
 }
 
+func stopServer(ctx context.Context) error {
+	if server == nil {
+		return nil
+	}
+	srv, lis := server, listener
+	server, listener = nil, nil
+	err := shutdownServer(ctx, srv)
+	lis.Close()
+	return err
+}
+
+func shutdownServer(ctx context.Context, srv *http.Server) error {
+	if err := srv.Shutdown(ctx); err != nil {
+		srv.Close()
+		return err
+	}
+	log.Info("management server stopped", zap.String("addr", srv.Addr))
+	return nil
+}
+
+// watchServerConfig restarts the server once its port, listener or tls config changes.
+func watchServerConfig() {
+	params := paramtable.Get()
+	handler := config.NewHandler("management.server", func(event *config.Event) {
+		log.Info("management server config changed, restart it", zap.String("key", event.Key))
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+		defer cancel()
+		if err := Restart(ctx); err != nil {
+			log.Error("failed to restart management server", zap.Error(err))
+		}
+	})
+	for _, key := range []string{
+		params.CommonCfg.MetricsPort.Key,
+		params.MetricsTLSCfg.MetricsTLSEnabled.Key,
+		params.MetricsTLSCfg.MetricsTLSServerPemPath.Key,
+		params.MetricsTLSCfg.MetricsTLSServerKeyPath.Key,
+		params.MetricsTLSCfg.MetricsTLSCaPemPath.Key,
+		params.ListenerCfg.ManagementBindIP.Key,
+		params.ListenerCfg.ManagementAllowedCIDRs.Key,
+	} {
+		params.Watch(key, handler)
+	}
+}
+
 func getHTTPAddr() string {
 	port := os.Getenv(ListenPortEnvKey)
 	_, err := strconv.Atoi(port)
 	if err != nil {
-		return fmt.Sprintf(":%s", DefaultListenPort)
+		// fallback to the configured port, which may be changed at runtime
+		port = paramtable.Get().CommonCfg.MetricsPort.GetValue()
+		if _, err := strconv.Atoi(port); err != nil {
+			return fmt.Sprintf(":%s", DefaultListenPort)
+		}
+		return fmt.Sprintf(":%s", port)
 	}
 	paramtable.Get().Save(paramtable.Get().CommonCfg.MetricsPort.Key, port)
 
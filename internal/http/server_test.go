@@ -69,6 +69,63 @@ func (suite *HTTPServerTestSuite) TestGetHTTPAddr() {
 	suite.Equal(getHTTPAddr(), ":"+testPort)
 }
 
+func (suite *HTTPServerTestSuite) TestStopAndRestart() {
+	os.Unsetenv(ListenPortEnvKey)
+	paramtable.Get().Reset(paramtable.Get().CommonCfg.MetricsPort.Key)
+	addr := "localhost:" + DefaultListenPort
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	suite.NoError(Restart(ctx))
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	suite.Require().NoError(err)
+	conn.Close()
+
+	suite.NoError(Stop(ctx))
+	suite.NoError(Stop(ctx))
+	_, err = net.DialTimeout("tcp", addr, time.Second)
+	suite.Error(err)
+
+	suite.NoError(Restart(ctx))
+	suite.Error(startServer())
+	conn, err = net.DialTimeout("tcp", addr, time.Second)
+	suite.Require().NoError(err)
+	conn.Close()
+
+	// restarted on the same address, the bound listener is taken over
+	oldListener := listener
+	suite.NoError(Restart(ctx))
+	suite.Same(oldListener, listener)
+	resp, err := http.Get("http://" + addr + HealthzRouterPath)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+
+	// the changed bind ip on the same port is bound once the old listener is closed
+	params := paramtable.Get()
+	params.Save(params.ListenerCfg.ManagementBindIP.Key, "127.0.0.1")
+	suite.NoError(Restart(ctx))
+	suite.NotSame(oldListener, listener)
+	suite.Equal("127.0.0.1:"+DefaultListenPort, listener.bindAddr)
+	resp, err = http.Get("http://127.0.0.1:" + DefaultListenPort + HealthzRouterPath)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	params.Reset(params.ListenerCfg.ManagementBindIP.Key)
+	suite.NoError(Restart(ctx))
+	suite.Equal(":"+DefaultListenPort, listener.bindAddr)
+
+	// the invalid config is rejected, the running server keeps serving
+	params.Save(params.MetricsTLSCfg.MetricsTLSEnabled.Key, "true")
+	params.Save(params.MetricsTLSCfg.MetricsTLSServerPemPath.Key, "/not/exist.pem")
+	defer params.Reset(params.MetricsTLSCfg.MetricsTLSEnabled.Key)
+	defer params.Reset(params.MetricsTLSCfg.MetricsTLSServerPemPath.Key)
+	oldServer := server
+	suite.Error(Restart(ctx))
+	suite.Same(oldServer, server)
+	resp, err = http.Get("http://" + addr + HealthzRouterPath)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+}
+
 func (suite *HTTPServerTestSuite) TestDefaultLogHandler() {
 	log.SetLevel(zap.DebugLevel)
 	suite.Equal(zap.DebugLevel, log.GetLevel())
@@ -0,0 +1,155 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// certReloader serves the certificate and the client CA of the files,
+// they are reloaded on handshakes once any of the files changed.
+type certReloader struct {
+	certPath string
+	keyPath  string
+	caPath   string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+}
+
+func newCertReloader(certPath, keyPath, caPath string) (*certReloader, error) {
+	r := &certReloader{
+		certPath: certPath,
+		keyPath:  keyPath,
+		caPath:   caPath,
+	}
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.reload(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, path := range []string{r.certPath, r.keyPath, r.caPath} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func (r *certReloader) reload(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return err
+	}
+	var clientCAs *x509.CertPool
+	if r.caPath != "" {
+		b, err := os.ReadFile(r.caPath)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(b) {
+			return errors.Newf("failed to append client ca of %s", r.caPath)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTime = modTime
+	return nil
+}
+
+// maybeReload reloads the files if changed, the previous ones are kept if failed to,
+// e.g. the certificate has been written but the key not yet.
+func (r *certReloader) maybeReload() {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		log.Warn("failed to stat metrics tls files", zap.Error(err))
+		return
+	}
+	r.mu.RLock()
+	changed := modTime.After(r.modTime)
+	r.mu.RUnlock()
+	if !changed {
+		return
+	}
+	if err := r.reload(modTime); err != nil {
+		log.Warn("failed to reload metrics tls files, keep the previous ones", zap.Error(err))
+		return
+	}
+	log.Info("metrics tls files reloaded", zap.Time("modTime", modTime))
+}
+
+func (r *certReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.maybeReload()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	config := &tls.Config{
+		Certificates: []tls.Certificate{*r.cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if r.clientCAs != nil {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = r.clientCAs
+	}
+	return config, nil
+}
+
+// newTLSConfig returns the tls config of the metrics server, nil if tls is not enabled.
+func newTLSConfig() (*tls.Config, error) {
+	cfg := &paramtable.Get().MetricsTLSCfg
+	if !cfg.MetricsTLSEnabled.GetAsBool() {
+		return nil, nil
+	}
+	r, err := newCertReloader(cfg.MetricsTLSServerPemPath.GetValue(), cfg.MetricsTLSServerKeyPath.GetValue(), cfg.MetricsTLSCaPemPath.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		GetConfigForClient: r.getConfigForClient,
+		MinVersion:         tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, serial int64, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) write(t *testing.T, certPath, keyPath string) {
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+	if keyPath == "" {
+		return
+	}
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+}
+
+func (c *testCert) tlsCert() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "server.pem")
+	keyPath := filepath.Join(dir, "server.key")
+	caPath := filepath.Join(dir, "ca.pem")
+
+	_, err := newCertReloader(certPath, keyPath, "")
+	assert.Error(t, err)
+
+	ca := newTestCert(t, 1, nil, true)
+	ca.write(t, caPath, "")
+	server1 := newTestCert(t, 2, ca, false)
+	server1.write(t, certPath, keyPath)
+
+	newServer := func(r *certReloader) *httptest.Server {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{GetConfigForClient: r.getConfigForClient}
+		server.StartTLS()
+		return server
+	}
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(ca.cert)
+		return &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: rootCAs, Certificates: certs},
+			DisableKeepAlives: true,
+		}}
+	}
+	servedSerial := func(t *testing.T, client *http.Client, url string) int64 {
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	t.Run("reload", func(t *testing.T) {
+		r, err := newCertReloader(certPath, keyPath, "")
+		require.NoError(t, err)
+		server := newServer(r)
+		defer server.Close()
+		client := newClient()
+		assert.Equal(t, int64(2), servedSerial(t, client, server.URL))
+
+		server2 := newTestCert(t, 3, ca, false)
+		server2.write(t, certPath, keyPath)
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certPath, later, later))
+		assert.Equal(t, int64(3), servedSerial(t, client, server.URL))
+
+		// the previous ones are kept if failed to reload
+		require.NoError(t, os.WriteFile(keyPath, []byte("invalid"), 0o600))
+		later = later.Add(time.Minute)
+		require.NoError(t, os.Chtimes(keyPath, later, later))
+		assert.Equal(t, int64(3), servedSerial(t, client, server.URL))
+		server1.write(t, certPath, keyPath)
+	})
+
+	t.Run("mtls", func(t *testing.T) {
+		r, err := newCertReloader(certPath, keyPath, caPath)
+		require.NoError(t, err)
+		server := newServer(r)
+		defer server.Close()
+
+		_, err = newClient().Get(server.URL)
+		assert.Error(t, err)
+
+		client := newTestCert(t, 4, ca, false)
+		resp, err := newClient(client.tlsCert()).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -3,6 +3,7 @@ package indexnode
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 	"time"
@@ -164,6 +165,11 @@ func (c *mockChunkmgr) MultiRemove(ctx context.Context, filePaths []string) erro
 	return errNotImplErr
 }
 
+func (c *mockChunkmgr) WriteStream(ctx context.Context, filePath string, reader io.Reader) error {
+	// TODO
+	return errNotImplErr
+}
+
 func (c *mockChunkmgr) RemoveWithPrefix(ctx context.Context, prefix string) error {
 	// TODO
 	return errNotImplErr
@@ -82,6 +82,17 @@ func getCurrentIndexVersion(v int32) int32 {
 	return v
 }
 
+// checkBuildIndexVersion returns an error if the index engine of this node can't build the index in the version,
+// which is the min version supported by the querynodes, the index would be unloadable by them otherwise.
+// The version is recorded into the index meta, and validated by the querynodes before loading.
+func checkBuildIndexVersion(v int32) error {
+	cMinimal, cCurrent := int32(C.GetMinimalIndexVersion()), int32(C.GetCurrentIndexVersion())
+	if v < cMinimal {
+		return merr.WrapErrIndexVersion(int64(v), int64(cMinimal), int64(cCurrent), "index engine version requested is older than this node can build")
+	}
+	return nil
+}
+
 type taskKey struct {
 	ClusterID string
 	TaskID    UniqueID
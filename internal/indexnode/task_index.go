@@ -168,7 +168,7 @@ func (it *indexBuildTask) PreExecute(ctx context.Context) error {
 		key, value := kvPair.GetKey(), kvPair.GetValue()
 		// knowhere would report error if encountered the unknown key,
 		// so skip this
-		if key == common.MmapEnabledKey {
+		if key == common.MmapEnabledKey || key == common.IndexResourceGroupsKey {
 			continue
 		}
 		indexParams[key] = value
@@ -197,6 +197,10 @@ func (it *indexBuildTask) PreExecute(ctx context.Context) error {
 	}
 
 	it.req.CurrentIndexVersion = getCurrentIndexVersion(it.req.GetCurrentIndexVersion())
+	if err := checkBuildIndexVersion(it.req.GetCurrentIndexVersion()); err != nil {
+		log.Ctx(ctx).Warn("index engine version not supported", zap.Int64("buildID", it.req.GetBuildID()), zap.Error(err))
+		return err
+	}
 
 	log.Ctx(ctx).Info("Successfully prepare indexBuildTask", zap.Int64("buildID", it.req.GetBuildID()),
 		zap.Int64("collectionID", it.req.GetCollectionID()), zap.Int64("segmentID", it.req.GetSegmentID()),
@@ -252,6 +256,12 @@ func (it *indexBuildTask) Execute(ctx context.Context) error {
 	if vecindexmgr.GetVecIndexMgrInstance().IsVecIndex(indexType) && Params.KnowhereConfig.Enable.GetAsBool() {
 		it.newIndexParams, _ = Params.KnowhereConfig.MergeResourceParams(fieldDataSize, paramtable.BuildStage, it.newIndexParams)
 	}
+	if vecindexmgr.GetVecIndexMgrInstance().IsVecIndex(indexType) {
+		it.newIndexParams = applyBuildLimits(indexType, it.newIndexParams)
+		if !vecindexmgr.GetVecIndexMgrInstance().IsDiskANN(indexType) {
+			it.newIndexParams = applyLowMemoryBuild(indexType, it.req.GetBuildID(), it.newIndexParams)
+		}
+	}
 
 	storageConfig := &indexcgopb.StorageConfig{
 		Address:           it.req.GetStorageConfig().GetAddress(),
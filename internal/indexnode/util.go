@@ -17,10 +17,20 @@
 package indexnode
 
 import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 func estimateFieldDataSize(dim int64, numRows int64, dataType schemapb.DataType) (uint64, error) {
@@ -48,3 +58,56 @@ func mapToKVPairs(m map[string]string) []*commonpb.KeyValuePair {
 	}
 	return kvs
 }
+
+// getBuildLimit returns the limit of the index type in the param group, returns false if not set.
+func getBuildLimit(group *paramtable.ParamGroup, indexType string) (float64, bool) {
+	value := group.GetValue()[strings.ToLower(indexType)]
+	if value == "" {
+		return 0, false
+	}
+	limit, err := strconv.ParseFloat(value, 64)
+	if err != nil || limit <= 0 {
+		log.Warn("invalid index build limit, ignore it", zap.String("key", group.KeyPrefix+indexType), zap.String("value", value))
+		return 0, false
+	}
+	return limit, true
+}
+
+// applyBuildLimits caps the build threads and the dram budget in the index params by the limits of the index type,
+// so a single build can't starve the components co-located.
+func applyBuildLimits(indexType string, indexParams map[string]string) map[string]string {
+	if limit, ok := getBuildLimit(&Params.IndexNodeCfg.BuildCPULimit, indexType); ok {
+		threads := int(math.Ceil(limit))
+		if current, err := strconv.Atoi(indexParams[paramtable.NumBuildThreadKey]); err != nil || current > threads {
+			indexParams[paramtable.NumBuildThreadKey] = strconv.Itoa(threads)
+		}
+	}
+	if limit, ok := getBuildLimit(&Params.IndexNodeCfg.BuildMemoryLimit, indexType); ok {
+		budget := limit / 1024
+		if current, err := strconv.ParseFloat(indexParams[paramtable.BuildDramBudgetKey], 64); err != nil || current > budget {
+			indexParams[paramtable.BuildDramBudgetKey] = fmt.Sprintf("%f", budget)
+		}
+	}
+	return indexParams
+}
+
+// applyLowMemoryBuild makes the index of the index type built from the raw data mmap'd from the local storage
+// rather than held in memory if enabled, so a segment near the node memory size is still buildable.
+func applyLowMemoryBuild(indexType string, buildID int64, indexParams map[string]string) map[string]string {
+	value := Params.IndexNodeCfg.LowMemoryBuild.GetValue()[strings.ToLower(indexType)]
+	if value == "" {
+		return indexParams
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warn("invalid low memory build flag, ignore it", zap.String("key", Params.IndexNodeCfg.LowMemoryBuild.KeyPrefix+indexType), zap.String("value", value))
+		return indexParams
+	}
+	if !enabled {
+		return indexParams
+	}
+	indexParams[paramtable.LowMemoryBuildKey] = "true"
+	indexParams[paramtable.RawDataMmapFilePathKey] = filepath.Join(Params.LocalStorageCfg.Path.GetValue(),
+		typeutil.IndexNodeRole, "low_memory_build", strconv.FormatInt(buildID, 10))
+	return indexParams
+}
@@ -18,9 +18,12 @@ package indexnode
 
 import (
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
 type utilSuite struct {
@@ -37,6 +40,57 @@ func (s *utilSuite) Test_mapToKVPairs() {
 	s.Equal(3, len(mapToKVPairs(indexParams)))
 }
 
+func (s *utilSuite) Test_applyBuildLimits() {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.SaveGroup(map[string]string{
+		params.IndexNodeCfg.BuildCPULimit.KeyPrefix + "HNSW":       "2.5",
+		params.IndexNodeCfg.BuildMemoryLimit.KeyPrefix + "DISKANN": "512",
+		params.IndexNodeCfg.BuildCPULimit.KeyPrefix + "IVF_FLAT":   "invalid",
+	})
+	defer params.SaveGroup(map[string]string{
+		params.IndexNodeCfg.BuildCPULimit.KeyPrefix + "HNSW":       "",
+		params.IndexNodeCfg.BuildMemoryLimit.KeyPrefix + "DISKANN": "",
+		params.IndexNodeCfg.BuildCPULimit.KeyPrefix + "IVF_FLAT":   "",
+	})
+
+	indexParams := applyBuildLimits("HNSW", map[string]string{paramtable.NumBuildThreadKey: "16"})
+	s.Equal("3", indexParams[paramtable.NumBuildThreadKey])
+	indexParams = applyBuildLimits("HNSW", map[string]string{paramtable.NumBuildThreadKey: "1"})
+	s.Equal("1", indexParams[paramtable.NumBuildThreadKey])
+	indexParams = applyBuildLimits("HNSW", map[string]string{})
+	s.Equal("3", indexParams[paramtable.NumBuildThreadKey])
+
+	indexParams = applyBuildLimits("DISKANN", map[string]string{paramtable.BuildDramBudgetKey: "64.000000"})
+	s.Equal("0.500000", indexParams[paramtable.BuildDramBudgetKey])
+	s.NotContains(indexParams, paramtable.NumBuildThreadKey)
+
+	indexParams = applyBuildLimits("IVF_FLAT", map[string]string{paramtable.NumBuildThreadKey: "16"})
+	s.Equal("16", indexParams[paramtable.NumBuildThreadKey])
+}
+
+func (s *utilSuite) Test_applyLowMemoryBuild() {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.SaveGroup(map[string]string{
+		params.IndexNodeCfg.LowMemoryBuild.KeyPrefix + "HNSW":     "true",
+		params.IndexNodeCfg.LowMemoryBuild.KeyPrefix + "IVF_FLAT": "invalid",
+	})
+	defer params.SaveGroup(map[string]string{
+		params.IndexNodeCfg.LowMemoryBuild.KeyPrefix + "HNSW":     "",
+		params.IndexNodeCfg.LowMemoryBuild.KeyPrefix + "IVF_FLAT": "",
+	})
+
+	indexParams := applyLowMemoryBuild("HNSW", 100, map[string]string{})
+	s.Equal("true", indexParams[paramtable.LowMemoryBuildKey])
+	s.True(strings.HasSuffix(indexParams[paramtable.RawDataMmapFilePathKey], "/low_memory_build/100"))
+
+	indexParams = applyLowMemoryBuild("IVF_FLAT", 100, map[string]string{})
+	s.NotContains(indexParams, paramtable.LowMemoryBuildKey)
+	indexParams = applyLowMemoryBuild("IVF_PQ", 100, map[string]string{})
+	s.NotContains(indexParams, paramtable.LowMemoryBuildKey)
+}
+
 func Test_utilSuite(t *testing.T) {
 	suite.Run(t, new(utilSuite))
 }
@@ -31,6 +31,8 @@ type RootCoordCatalog interface {
 	CreatePartition(ctx context.Context, dbID int64, partition *model.Partition, ts typeutil.Timestamp) error
 	DropPartition(ctx context.Context, dbID int64, collectionID typeutil.UniqueID, partitionID typeutil.UniqueID, ts typeutil.Timestamp) error
 	AlterPartition(ctx context.Context, dbID int64, oldPart *model.Partition, newPart *model.Partition, alterType AlterType, ts typeutil.Timestamp) error
+	// AlterCollectionsAndPartitions saves the collections and the partitions in one transaction.
+	AlterCollectionsAndPartitions(ctx context.Context, colls []*model.Collection, partitions []*model.Partition, ts typeutil.Timestamp) error
 
 	CreateAlias(ctx context.Context, alias *model.Alias, ts typeutil.Timestamp) error
 	DropAlias(ctx context.Context, dbID int64, alias string, ts typeutil.Timestamp) error
@@ -179,6 +181,10 @@ type DataCoordCatalog interface {
 	ListStatsTasks(ctx context.Context) ([]*indexpb.StatsTask, error)
 	SaveStatsTask(ctx context.Context, task *indexpb.StatsTask) error
 	DropStatsTask(ctx context.Context, taskID typeutil.UniqueID) error
+
+	ListBackups(ctx context.Context) ([]*datapb.BackupInfo, error)
+	SaveBackup(ctx context.Context, backup *datapb.BackupInfo) error
+	DropBackup(ctx context.Context, name string) error
 }
 
 type QueryCoordCatalog interface {
@@ -199,6 +205,11 @@ type QueryCoordCatalog interface {
 	SaveCollectionTargets(ctx context.Context, target ...*querypb.CollectionTarget) error
 	RemoveCollectionTarget(ctx context.Context, collectionID int64) error
 	GetCollectionTargets(ctx context.Context) (map[int64]*querypb.CollectionTarget, error)
+
+	// ExportSnapshot serializes all the collections, partitions, replicas and resource groups into a snapshot.
+	ExportSnapshot(ctx context.Context) ([]byte, error)
+	// ImportSnapshot saves all the records of the snapshot exported, the existing ones of the same keys are overwritten.
+	ImportSnapshot(ctx context.Context, snapshot []byte) error
 }
 
 // StreamingCoordCataLog is the interface for streamingcoord catalog
@@ -979,3 +979,37 @@ func (kc *Catalog) DropStatsTask(ctx context.Context, taskID typeutil.UniqueID)
 	key := buildStatsTaskKey(taskID)
 	return kc.MetaKv.Remove(ctx, key)
 }
+
+func (kc *Catalog) ListBackups(ctx context.Context) ([]*datapb.BackupInfo, error) {
+	backups := make([]*datapb.BackupInfo, 0)
+
+	applyFn := func(key []byte, value []byte) error {
+		backup := &datapb.BackupInfo{}
+		err := proto.Unmarshal(value, backup)
+		if err != nil {
+			return err
+		}
+		backups = append(backups, backup)
+		return nil
+	}
+
+	err := kc.MetaKv.WalkWithPrefix(ctx, BackupPrefix, kc.paginationSize, applyFn)
+	if err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
+
+func (kc *Catalog) SaveBackup(ctx context.Context, backup *datapb.BackupInfo) error {
+	key := buildBackupKey(backup.GetName())
+	value, err := proto.Marshal(backup)
+	if err != nil {
+		return err
+	}
+	return kc.MetaKv.Save(ctx, key, string(value))
+}
+
+func (kc *Catalog) DropBackup(ctx context.Context, name string) error {
+	key := buildBackupKey(name)
+	return kc.MetaKv.Remove(ctx, key)
+}
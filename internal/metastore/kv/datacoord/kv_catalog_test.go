@@ -1891,3 +1891,58 @@ func Test_StatsTasks(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func Test_Backups(t *testing.T) {
+	kc := &Catalog{}
+	mockErr := errors.New("mock error")
+
+	t.Run("ListBackups", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+
+		backups, err := kc.ListBackups(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, backups)
+
+		backup := &datapb.BackupInfo{
+			Name:         "backup1",
+			CollectionID: 1,
+			Segments: []*datapb.BackupSegment{
+				{SegmentID: 3, PartitionID: 2, Level: datapb.SegmentLevel_L1, NumOfRows: 100},
+			},
+			BackupTs: 1000,
+		}
+		value, err := proto.Marshal(backup)
+		assert.NoError(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, BackupPrefix, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f([]byte("key1"), value)
+		})
+		kc.MetaKv = txn
+
+		backups, err = kc.ListBackups(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(backups))
+		assert.Equal(t, "backup1", backups[0].GetName())
+	})
+
+	t.Run("SaveBackup", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Save(mock.Anything, buildBackupKey("backup1"), mock.Anything).Return(nil)
+		kc.MetaKv = txn
+
+		err := kc.SaveBackup(context.Background(), &datapb.BackupInfo{Name: "backup1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("DropBackup", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Remove(mock.Anything, buildBackupKey("backup1")).Return(mockErr)
+		kc.MetaKv = txn
+
+		err := kc.DropBackup(context.Background(), "backup1")
+		assert.Error(t, err)
+	})
+}
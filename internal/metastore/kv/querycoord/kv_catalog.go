@@ -171,44 +171,44 @@ func (s Catalog) GetReplicas(ctx context.Context) ([]*querypb.Replica, error) {
 }
 
 func (s Catalog) getReplicasFromV1(ctx context.Context) ([]*querypb.Replica, error) {
-	_, replicaValues, err := s.cli.LoadWithPrefix(ctx, ReplicaMetaPrefixV1)
-	if err != nil {
-		return nil, err
-	}
-
-	ret := make([]*querypb.Replica, 0, len(replicaValues))
-	for _, value := range replicaValues {
+	ret := make([]*querypb.Replica, 0)
+	applyFn := func(key []byte, value []byte) error {
 		replicaInfo := milvuspb.ReplicaInfo{}
-		err = proto.Unmarshal([]byte(value), &replicaInfo)
-		if err != nil {
-			return nil, err
+		if err := proto.Unmarshal(value, &replicaInfo); err != nil {
+			return err
 		}
-
 		ret = append(ret, &querypb.Replica{
 			ID:           replicaInfo.GetReplicaID(),
 			CollectionID: replicaInfo.GetCollectionID(),
 			Nodes:        replicaInfo.GetNodeIds(),
 		})
+		return nil
 	}
-	return ret, nil
-}
 
-func (s Catalog) GetResourceGroups(ctx context.Context) ([]*querypb.ResourceGroup, error) {
-	_, rgs, err := s.cli.LoadWithPrefix(ctx, ResourceGroupPrefix)
+	err := s.cli.WalkWithPrefix(ctx, ReplicaMetaPrefixV1, s.paginationSize, applyFn)
 	if err != nil {
 		return nil, err
 	}
 
-	ret := make([]*querypb.ResourceGroup, 0, len(rgs))
-	for _, value := range rgs {
+	return ret, nil
+}
+
+func (s Catalog) GetResourceGroups(ctx context.Context) ([]*querypb.ResourceGroup, error) {
+	ret := make([]*querypb.ResourceGroup, 0)
+	applyFn := func(key []byte, value []byte) error {
 		rg := &querypb.ResourceGroup{}
-		err := proto.Unmarshal([]byte(value), rg)
-		if err != nil {
-			return nil, err
+		if err := proto.Unmarshal(value, rg); err != nil {
+			return err
 		}
-
 		ret = append(ret, rg)
+		return nil
+	}
+
+	err := s.cli.WalkWithPrefix(ctx, ResourceGroupPrefix, s.paginationSize, applyFn)
+	if err != nil {
+		return nil, err
 	}
+
 	return ret, nil
 }
 
@@ -2,12 +2,15 @@ package querycoord
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
 
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/mocks"
@@ -146,6 +149,136 @@ func (suite *CatalogTestSuite) TestReleaseManyPartitions() {
 	suite.Len(partitions, 0)
 }
 
+func (suite *CatalogTestSuite) TestPagination() {
+	ctx := context.Background()
+	suite.catalog.paginationSize = 2
+
+	// use the ids not used by the other cases, which may leave the meta behind
+	ids := []int64{201, 202, 203, 204, 205}
+	for _, id := range ids {
+		suite.catalog.SaveCollection(ctx, &querypb.CollectionLoadInfo{
+			CollectionID: id,
+		}, &querypb.PartitionLoadInfo{
+			CollectionID: id,
+			PartitionID:  id,
+		})
+		suite.catalog.SaveReplica(ctx, &querypb.Replica{
+			CollectionID: id,
+			ID:           id,
+		})
+		suite.catalog.SaveResourceGroup(ctx, &querypb.ResourceGroup{
+			Name: fmt.Sprintf("pagination_rg%d", id),
+		})
+	}
+
+	collections, err := suite.catalog.GetCollections(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Map(collections, func(c *querypb.CollectionLoadInfo, _ int) int64 { return c.GetCollectionID() }), ids)
+
+	partitions, err := suite.catalog.GetPartitions(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Keys(partitions), ids)
+
+	replicas, err := suite.catalog.GetReplicas(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Map(replicas, func(r *querypb.Replica, _ int) int64 { return r.GetID() }), ids)
+
+	groups, err := suite.catalog.GetResourceGroups(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Map(groups, func(rg *querypb.ResourceGroup, _ int) string { return rg.GetName() }),
+		lo.Map(ids, func(id int64, _ int) string { return fmt.Sprintf("pagination_rg%d", id) }))
+
+	for _, id := range ids {
+		suite.catalog.ReleaseCollection(ctx, id)
+		suite.catalog.ReleaseReplicas(ctx, id)
+		suite.catalog.RemoveResourceGroup(ctx, fmt.Sprintf("pagination_rg%d", id))
+	}
+}
+
+func (suite *CatalogTestSuite) TestSnapshot() {
+	ctx := context.Background()
+
+	// use the ids not used by the other cases, which may leave the meta behind
+	ids := []int64{301, 302, 303}
+	rgName := func(id int64) string { return fmt.Sprintf("snapshot_rg%d", id) }
+	for _, id := range ids {
+		suite.catalog.SaveCollection(ctx, &querypb.CollectionLoadInfo{
+			CollectionID:  id,
+			ReplicaNumber: 1,
+		}, &querypb.PartitionLoadInfo{
+			CollectionID: id,
+			PartitionID:  id,
+		})
+		suite.catalog.SaveReplica(ctx, &querypb.Replica{
+			CollectionID:  id,
+			ID:            id,
+			ResourceGroup: rgName(id),
+		})
+		suite.catalog.SaveResourceGroup(ctx, &querypb.ResourceGroup{
+			Name: rgName(id),
+		})
+	}
+
+	snapshot, err := suite.catalog.ExportSnapshot(ctx)
+	suite.NoError(err)
+	for _, id := range ids {
+		suite.catalog.ReleaseCollection(ctx, id)
+		suite.catalog.ReleaseReplicas(ctx, id)
+		suite.catalog.RemoveResourceGroup(ctx, rgName(id))
+	}
+
+	suite.NoError(suite.catalog.ImportSnapshot(ctx, snapshot))
+
+	collections, err := suite.catalog.GetCollections(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Map(collections, func(c *querypb.CollectionLoadInfo, _ int) int64 { return c.GetCollectionID() }), ids)
+
+	partitions, err := suite.catalog.GetPartitions(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Keys(partitions), ids)
+
+	replicas, err := suite.catalog.GetReplicas(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Map(replicas, func(r *querypb.Replica, _ int) string { return r.GetResourceGroup() }),
+		lo.Map(ids, func(id int64, _ int) string { return rgName(id) }))
+
+	groups, err := suite.catalog.GetResourceGroups(ctx)
+	suite.NoError(err)
+	suite.Subset(lo.Map(groups, func(rg *querypb.ResourceGroup, _ int) string { return rg.GetName() }),
+		lo.Map(ids, func(id int64, _ int) string { return rgName(id) }))
+
+	for _, id := range ids {
+		suite.catalog.ReleaseCollection(ctx, id)
+		suite.catalog.ReleaseReplicas(ctx, id)
+		suite.catalog.RemoveResourceGroup(ctx, rgName(id))
+	}
+
+	// test invalid snapshot
+	err = suite.catalog.ImportSnapshot(ctx, []byte("invalid"))
+	suite.ErrorIs(err, ErrInvalidSnapshot)
+
+	newer, err := proto.Marshal(&querypb.CatalogSnapshot{Version: CatalogSnapshotVersion + 1})
+	suite.NoError(err)
+	err = suite.catalog.ImportSnapshot(ctx, newer)
+	suite.ErrorIs(err, ErrInvalidSnapshot)
+
+	// test access meta store failed
+	mockStore := mocks.NewMetaKv(suite.T())
+	mockErr := errors.New("failed to access etcd")
+	mockStore.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+	mockStore.EXPECT().MultiSave(mock.Anything, mock.Anything).Return(mockErr)
+
+	cli := suite.catalog.cli
+	defer func() {
+		suite.catalog.cli = cli
+	}()
+	suite.catalog.cli = mockStore
+	_, err = suite.catalog.ExportSnapshot(ctx)
+	suite.ErrorIs(err, mockErr)
+	err = suite.catalog.ImportSnapshot(ctx, snapshot)
+	suite.ErrorIs(err, mockErr)
+}
+
 func (suite *CatalogTestSuite) TestReplica() {
 	ctx := context.Background()
 	suite.catalog.SaveReplica(ctx, &querypb.Replica{
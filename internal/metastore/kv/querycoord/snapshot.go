@@ -0,0 +1,101 @@
+package querycoord
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+// CatalogSnapshotVersion is the version of the snapshots exported,
+// bump it if the records of the snapshot changed incompatibly.
+const CatalogSnapshotVersion = 1
+
+var ErrInvalidSnapshot = errors.New("invalid catalog snapshot")
+
+// ExportSnapshot serializes all the collections, partitions, replicas and resource groups into a snapshot,
+// the legacy replicas are exported as the current ones.
+func (s Catalog) ExportSnapshot(ctx context.Context) ([]byte, error) {
+	collections, err := s.GetCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := s.GetPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replicas, err := s.GetReplicas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rgs, err := s.GetResourceGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&querypb.CatalogSnapshot{
+		Version:        CatalogSnapshotVersion,
+		CreateTime:     tsoutil.ComposeTSByTime(time.Now(), 0),
+		Collections:    collections,
+		Partitions:     lo.Flatten(lo.Values(partitions)),
+		Replicas:       replicas,
+		ResourceGroups: rgs,
+	})
+}
+
+// ImportSnapshot saves all the records of the snapshot exported, the existing ones of the same keys are overwritten
+// and the others are kept. It should be done before querycoord started, which recovers from the catalog on start only.
+func (s Catalog) ImportSnapshot(ctx context.Context, snapshot []byte) error {
+	info := &querypb.CatalogSnapshot{}
+	if err := proto.Unmarshal(snapshot, info); err != nil {
+		return errors.Wrap(ErrInvalidSnapshot, err.Error())
+	}
+	if info.GetVersion() <= 0 || info.GetVersion() > CatalogSnapshotVersion {
+		return errors.Wrapf(ErrInvalidSnapshot, "unsupported version %d, the latest version is %d", info.GetVersion(), CatalogSnapshotVersion)
+	}
+
+	// save the collections last, which are recovered as loaded once saved
+	kvs, collectionKvs := make(map[string]string), make(map[string]string)
+	save := func(kvs map[string]string, key string, msg proto.Message) error {
+		value, err := proto.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		kvs[key] = string(value)
+		return nil
+	}
+	for _, rg := range info.GetResourceGroups() {
+		if err := save(kvs, encodeResourceGroupKey(rg.GetName()), rg); err != nil {
+			return err
+		}
+	}
+	for _, replica := range info.GetReplicas() {
+		if err := save(kvs, encodeReplicaKey(replica.GetCollectionID(), replica.GetID()), replica); err != nil {
+			return err
+		}
+	}
+	for _, partition := range info.GetPartitions() {
+		if err := save(kvs, EncodePartitionLoadInfoKey(partition.GetCollectionID(), partition.GetPartitionID()), partition); err != nil {
+			return err
+		}
+	}
+	for _, collection := range info.GetCollections() {
+		if err := save(collectionKvs, EncodeCollectionLoadInfoKey(collection.GetCollectionID()), collection); err != nil {
+			return err
+		}
+	}
+
+	for _, kvs := range []map[string]string{kvs, collectionKvs} {
+		for _, keys := range lo.Chunk(lo.Keys(kvs), MetaOpsBatchSize) {
+			if err := s.cli.MultiSave(ctx, lo.PickByKeys(kvs, keys)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
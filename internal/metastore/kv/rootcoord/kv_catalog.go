@@ -683,6 +683,31 @@ func (kc *Catalog) AlterPartition(ctx context.Context, dbID int64, oldPart *mode
 	return fmt.Errorf("altering partition doesn't support %s", alterType.String())
 }
 
+func (kc *Catalog) AlterCollectionsAndPartitions(ctx context.Context, colls []*model.Collection, partitions []*model.Partition, ts typeutil.Timestamp) error {
+	saves := make(map[string]string, len(colls)+len(partitions))
+	for _, coll := range colls {
+		value, err := proto.Marshal(model.MarshalCollectionModel(coll))
+		if err != nil {
+			return err
+		}
+		saves[BuildCollectionKey(coll.DBID, coll.CollectionID)] = string(value)
+	}
+	for _, partition := range partitions {
+		value, err := proto.Marshal(model.MarshalPartitionModel(partition))
+		if err != nil {
+			return err
+		}
+		saves[BuildPartitionKey(partition.CollectionID, partition.PartitionID)] = string(value)
+	}
+
+	// since SnapshotKV may save both snapshot key and the original key if the original key is newest
+	// MaxEtcdTxnNum need to divided by 2
+	if len(saves) > util.MaxEtcdTxnNum/2 {
+		return fmt.Errorf("too many collections and partitions to alter in one transaction: %d, limit: %d", len(saves), util.MaxEtcdTxnNum/2)
+	}
+	return kc.Snapshot.MultiSave(ctx, saves, ts)
+}
+
 func dropPartition(collMeta *pb.CollectionInfo, partitionID typeutil.UniqueID) {
 	if collMeta == nil {
 		return
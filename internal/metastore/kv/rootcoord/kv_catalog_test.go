@@ -1137,6 +1137,49 @@ func TestCatalog_AlterPartition(t *testing.T) {
 	})
 }
 
+func TestCatalog_AlterCollectionsAndPartitions(t *testing.T) {
+	t.Run("normal case", func(t *testing.T) {
+		snapshot := kv.NewMockSnapshotKV()
+		kvs := map[string]string{}
+		snapshot.MultiSaveFunc = func(ctx context.Context, saves map[string]string, ts typeutil.Timestamp) error {
+			for k, v := range saves {
+				kvs[k] = v
+			}
+			return nil
+		}
+		kc := &Catalog{Snapshot: snapshot}
+		colls := []*model.Collection{
+			{CollectionID: 1, DBID: testDb, State: pb.CollectionState_CollectionCreated},
+			{CollectionID: 2, DBID: testDb, State: pb.CollectionState_CollectionCreated},
+		}
+		partitions := []*model.Partition{
+			{PartitionID: 3, CollectionID: 100, State: pb.PartitionState_PartitionCreated},
+		}
+		err := kc.AlterCollectionsAndPartitions(context.Background(), colls, partitions, 0)
+		assert.NoError(t, err)
+		assert.Len(t, kvs, 3)
+
+		var collPb pb.CollectionInfo
+		err = proto.Unmarshal([]byte(kvs[BuildCollectionKey(testDb, 2)]), &collPb)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.CollectionState_CollectionCreated, collPb.GetState())
+		var partPb pb.PartitionInfo
+		err = proto.Unmarshal([]byte(kvs[BuildPartitionKey(100, 3)]), &partPb)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.PartitionState_PartitionCreated, partPb.GetState())
+	})
+
+	t.Run("too many to save at once", func(t *testing.T) {
+		kc := &Catalog{Snapshot: kv.NewMockSnapshotKV()}
+		partitions := make([]*model.Partition, 0, util.MaxEtcdTxnNum)
+		for i := 0; i < util.MaxEtcdTxnNum; i++ {
+			partitions = append(partitions, &model.Partition{PartitionID: int64(i), CollectionID: 100})
+		}
+		err := kc.AlterCollectionsAndPartitions(context.Background(), nil, partitions, 0)
+		assert.Error(t, err)
+	})
+}
+
 type mockSnapshotOpt func(ss *mocks.SnapShotKV)
 
 func newMockSnapshot(t *testing.T, opts ...mockSnapshotOpt) *mocks.SnapShotKV {
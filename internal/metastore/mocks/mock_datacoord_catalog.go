@@ -421,6 +421,53 @@ func (_c *DataCoordCatalog_DropAnalyzeTask_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// DropBackup provides a mock function with given fields: ctx, name
+func (_m *DataCoordCatalog) DropBackup(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropBackup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_DropBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropBackup'
+type DataCoordCatalog_DropBackup_Call struct {
+	*mock.Call
+}
+
+// DropBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *DataCoordCatalog_Expecter) DropBackup(ctx interface{}, name interface{}) *DataCoordCatalog_DropBackup_Call {
+	return &DataCoordCatalog_DropBackup_Call{Call: _e.mock.On("DropBackup", ctx, name)}
+}
+
+func (_c *DataCoordCatalog_DropBackup_Call) Run(run func(ctx context.Context, name string)) *DataCoordCatalog_DropBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropBackup_Call) Return(_a0 error) *DataCoordCatalog_DropBackup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropBackup_Call) RunAndReturn(run func(context.Context, string) error) *DataCoordCatalog_DropBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropChannel provides a mock function with given fields: ctx, channel
 func (_m *DataCoordCatalog) DropChannel(ctx context.Context, channel string) error {
 	ret := _m.Called(ctx, channel)
@@ -1156,6 +1203,64 @@ func (_c *DataCoordCatalog_ListAnalyzeTasks_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// ListBackups provides a mock function with given fields: ctx
+func (_m *DataCoordCatalog) ListBackups(ctx context.Context) ([]*datapb.BackupInfo, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBackups")
+	}
+
+	var r0 []*datapb.BackupInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*datapb.BackupInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*datapb.BackupInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*datapb.BackupInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoordCatalog_ListBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBackups'
+type DataCoordCatalog_ListBackups_Call struct {
+	*mock.Call
+}
+
+// ListBackups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DataCoordCatalog_Expecter) ListBackups(ctx interface{}) *DataCoordCatalog_ListBackups_Call {
+	return &DataCoordCatalog_ListBackups_Call{Call: _e.mock.On("ListBackups", ctx)}
+}
+
+func (_c *DataCoordCatalog_ListBackups_Call) Run(run func(ctx context.Context)) *DataCoordCatalog_ListBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListBackups_Call) Return(_a0 []*datapb.BackupInfo, _a1 error) *DataCoordCatalog_ListBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListBackups_Call) RunAndReturn(run func(context.Context) ([]*datapb.BackupInfo, error)) *DataCoordCatalog_ListBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListChannelCheckpoint provides a mock function with given fields: ctx
 func (_m *DataCoordCatalog) ListChannelCheckpoint(ctx context.Context) (map[string]*msgpb.MsgPosition, error) {
 	ret := _m.Called(ctx)
@@ -1877,6 +1982,53 @@ func (_c *DataCoordCatalog_SaveAnalyzeTask_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// SaveBackup provides a mock function with given fields: ctx, backup
+func (_m *DataCoordCatalog) SaveBackup(ctx context.Context, backup *datapb.BackupInfo) error {
+	ret := _m.Called(ctx, backup)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBackup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.BackupInfo) error); ok {
+		r0 = rf(ctx, backup)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_SaveBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBackup'
+type DataCoordCatalog_SaveBackup_Call struct {
+	*mock.Call
+}
+
+// SaveBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - backup *datapb.BackupInfo
+func (_e *DataCoordCatalog_Expecter) SaveBackup(ctx interface{}, backup interface{}) *DataCoordCatalog_SaveBackup_Call {
+	return &DataCoordCatalog_SaveBackup_Call{Call: _e.mock.On("SaveBackup", ctx, backup)}
+}
+
+func (_c *DataCoordCatalog_SaveBackup_Call) Run(run func(ctx context.Context, backup *datapb.BackupInfo)) *DataCoordCatalog_SaveBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.BackupInfo))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveBackup_Call) Return(_a0 error) *DataCoordCatalog_SaveBackup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveBackup_Call) RunAndReturn(run func(context.Context, *datapb.BackupInfo) error) *DataCoordCatalog_SaveBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveChannelCheckpoint provides a mock function with given fields: ctx, vChannel, pos
 func (_m *DataCoordCatalog) SaveChannelCheckpoint(ctx context.Context, vChannel string, pos *msgpb.MsgPosition) error {
 	ret := _m.Called(ctx, vChannel, pos)
@@ -23,6 +23,64 @@ func (_m *QueryCoordCatalog) EXPECT() *QueryCoordCatalog_Expecter {
 	return &QueryCoordCatalog_Expecter{mock: &_m.Mock}
 }
 
+// ExportSnapshot provides a mock function with given fields: ctx
+func (_m *QueryCoordCatalog) ExportSnapshot(ctx context.Context) ([]byte, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportSnapshot")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]byte, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryCoordCatalog_ExportSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportSnapshot'
+type QueryCoordCatalog_ExportSnapshot_Call struct {
+	*mock.Call
+}
+
+// ExportSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QueryCoordCatalog_Expecter) ExportSnapshot(ctx interface{}) *QueryCoordCatalog_ExportSnapshot_Call {
+	return &QueryCoordCatalog_ExportSnapshot_Call{Call: _e.mock.On("ExportSnapshot", ctx)}
+}
+
+func (_c *QueryCoordCatalog_ExportSnapshot_Call) Run(run func(ctx context.Context)) *QueryCoordCatalog_ExportSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QueryCoordCatalog_ExportSnapshot_Call) Return(_a0 []byte, _a1 error) *QueryCoordCatalog_ExportSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QueryCoordCatalog_ExportSnapshot_Call) RunAndReturn(run func(context.Context) ([]byte, error)) *QueryCoordCatalog_ExportSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCollectionTargets provides a mock function with given fields: ctx
 func (_m *QueryCoordCatalog) GetCollectionTargets(ctx context.Context) (map[int64]*querypb.CollectionTarget, error) {
 	ret := _m.Called(ctx)
@@ -313,6 +371,53 @@ func (_c *QueryCoordCatalog_GetResourceGroups_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ImportSnapshot provides a mock function with given fields: ctx, snapshot
+func (_m *QueryCoordCatalog) ImportSnapshot(ctx context.Context, snapshot []byte) error {
+	ret := _m.Called(ctx, snapshot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// QueryCoordCatalog_ImportSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportSnapshot'
+type QueryCoordCatalog_ImportSnapshot_Call struct {
+	*mock.Call
+}
+
+// ImportSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot []byte
+func (_e *QueryCoordCatalog_Expecter) ImportSnapshot(ctx interface{}, snapshot interface{}) *QueryCoordCatalog_ImportSnapshot_Call {
+	return &QueryCoordCatalog_ImportSnapshot_Call{Call: _e.mock.On("ImportSnapshot", ctx, snapshot)}
+}
+
+func (_c *QueryCoordCatalog_ImportSnapshot_Call) Run(run func(ctx context.Context, snapshot []byte)) *QueryCoordCatalog_ImportSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *QueryCoordCatalog_ImportSnapshot_Call) Return(_a0 error) *QueryCoordCatalog_ImportSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryCoordCatalog_ImportSnapshot_Call) RunAndReturn(run func(context.Context, []byte) error) *QueryCoordCatalog_ImportSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReleaseCollection provides a mock function with given fields: ctx, collection
 func (_m *QueryCoordCatalog) ReleaseCollection(ctx context.Context, collection int64) error {
 	ret := _m.Called(ctx, collection)
@@ -116,6 +116,51 @@ func (_c *RootCoordCatalog_AlterCollection_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// AlterCollectionsAndPartitions provides a mock function with given fields: ctx, colls, partitions, ts
+func (_m *RootCoordCatalog) AlterCollectionsAndPartitions(ctx context.Context, colls []*model.Collection, partitions []*model.Partition, ts uint64) error {
+	ret := _m.Called(ctx, colls, partitions, ts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.Collection, []*model.Partition, uint64) error); ok {
+		r0 = rf(ctx, colls, partitions, ts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RootCoordCatalog_AlterCollectionsAndPartitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlterCollectionsAndPartitions'
+type RootCoordCatalog_AlterCollectionsAndPartitions_Call struct {
+	*mock.Call
+}
+
+// AlterCollectionsAndPartitions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - colls []*model.Collection
+//   - partitions []*model.Partition
+//   - ts uint64
+func (_e *RootCoordCatalog_Expecter) AlterCollectionsAndPartitions(ctx interface{}, colls interface{}, partitions interface{}, ts interface{}) *RootCoordCatalog_AlterCollectionsAndPartitions_Call {
+	return &RootCoordCatalog_AlterCollectionsAndPartitions_Call{Call: _e.mock.On("AlterCollectionsAndPartitions", ctx, colls, partitions, ts)}
+}
+
+func (_c *RootCoordCatalog_AlterCollectionsAndPartitions_Call) Run(run func(ctx context.Context, colls []*model.Collection, partitions []*model.Partition, ts uint64)) *RootCoordCatalog_AlterCollectionsAndPartitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*model.Collection), args[2].([]*model.Partition), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_AlterCollectionsAndPartitions_Call) Return(_a0 error) *RootCoordCatalog_AlterCollectionsAndPartitions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RootCoordCatalog_AlterCollectionsAndPartitions_Call) RunAndReturn(run func(context.Context, []*model.Collection, []*model.Partition, uint64) error) *RootCoordCatalog_AlterCollectionsAndPartitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AlterCredential provides a mock function with given fields: ctx, credential
 func (_m *RootCoordCatalog) AlterCredential(ctx context.Context, credential *model.Credential) error {
 	ret := _m.Called(ctx, credential)
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	io "io"
+
 	mmap "golang.org/x/exp/mmap"
 
 	mock "github.com/stretchr/testify/mock"
@@ -763,6 +765,50 @@ func (_c *ChunkManager_Write_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// WriteStream provides a mock function with given fields: ctx, filePath, reader
+func (_m *ChunkManager) WriteStream(ctx context.Context, filePath string, reader io.Reader) error {
+	ret := _m.Called(ctx, filePath, reader)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) error); ok {
+		r0 = rf(ctx, filePath, reader)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_WriteStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteStream'
+type ChunkManager_WriteStream_Call struct {
+	*mock.Call
+}
+
+// WriteStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - reader io.Reader
+func (_e *ChunkManager_Expecter) WriteStream(ctx interface{}, filePath interface{}, reader interface{}) *ChunkManager_WriteStream_Call {
+	return &ChunkManager_WriteStream_Call{Call: _e.mock.On("WriteStream", ctx, filePath, reader)}
+}
+
+func (_c *ChunkManager_WriteStream_Call) Run(run func(ctx context.Context, filePath string, reader io.Reader)) *ChunkManager_WriteStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_WriteStream_Call) Return(_a0 error) *ChunkManager_WriteStream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChunkManager_WriteStream_Call) RunAndReturn(run func(context.Context, string, io.Reader) error) *ChunkManager_WriteStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewChunkManager creates a new instance of ChunkManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewChunkManager(t interface {
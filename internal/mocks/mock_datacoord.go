@@ -333,6 +333,65 @@ func (_c *MockDataCoord_CheckHealth_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CreateBackup provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) CreateBackup(_a0 context.Context, _a1 *datapb.CreateBackupRequest) (*datapb.CreateBackupResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBackup")
+	}
+
+	var r0 *datapb.CreateBackupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CreateBackupRequest) (*datapb.CreateBackupResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CreateBackupRequest) *datapb.CreateBackupResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.CreateBackupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.CreateBackupRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_CreateBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBackup'
+type MockDataCoord_CreateBackup_Call struct {
+	*mock.Call
+}
+
+// CreateBackup is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.CreateBackupRequest
+func (_e *MockDataCoord_Expecter) CreateBackup(_a0 interface{}, _a1 interface{}) *MockDataCoord_CreateBackup_Call {
+	return &MockDataCoord_CreateBackup_Call{Call: _e.mock.On("CreateBackup", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_CreateBackup_Call) Run(run func(_a0 context.Context, _a1 *datapb.CreateBackupRequest)) *MockDataCoord_CreateBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.CreateBackupRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_CreateBackup_Call) Return(_a0 *datapb.CreateBackupResponse, _a1 error) *MockDataCoord_CreateBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_CreateBackup_Call) RunAndReturn(run func(context.Context, *datapb.CreateBackupRequest) (*datapb.CreateBackupResponse, error)) *MockDataCoord_CreateBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateIndex provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) CreateIndex(_a0 context.Context, _a1 *indexpb.CreateIndexRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -451,6 +510,65 @@ func (_c *MockDataCoord_DescribeIndex_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// DropBackup provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) DropBackup(_a0 context.Context, _a1 *datapb.DropBackupRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropBackup")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.DropBackupRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.DropBackupRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.DropBackupRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_DropBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropBackup'
+type MockDataCoord_DropBackup_Call struct {
+	*mock.Call
+}
+
+// DropBackup is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.DropBackupRequest
+func (_e *MockDataCoord_Expecter) DropBackup(_a0 interface{}, _a1 interface{}) *MockDataCoord_DropBackup_Call {
+	return &MockDataCoord_DropBackup_Call{Call: _e.mock.On("DropBackup", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_DropBackup_Call) Run(run func(_a0 context.Context, _a1 *datapb.DropBackupRequest)) *MockDataCoord_DropBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.DropBackupRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_DropBackup_Call) Return(_a0 *commonpb.Status, _a1 error) *MockDataCoord_DropBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_DropBackup_Call) RunAndReturn(run func(context.Context, *datapb.DropBackupRequest) (*commonpb.Status, error)) *MockDataCoord_DropBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropIndex provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) DropIndex(_a0 context.Context, _a1 *indexpb.DropIndexRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2325,6 +2443,124 @@ func (_c *MockDataCoord_Init_Call) RunAndReturn(run func() error) *MockDataCoord
 	return _c
 }
 
+// InspectChannelCheckpoints provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) InspectChannelCheckpoints(_a0 context.Context, _a1 *datapb.InspectChannelCheckpointsRequest) (*datapb.InspectChannelCheckpointsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InspectChannelCheckpoints")
+	}
+
+	var r0 *datapb.InspectChannelCheckpointsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.InspectChannelCheckpointsRequest) (*datapb.InspectChannelCheckpointsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.InspectChannelCheckpointsRequest) *datapb.InspectChannelCheckpointsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.InspectChannelCheckpointsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.InspectChannelCheckpointsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_InspectChannelCheckpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectChannelCheckpoints'
+type MockDataCoord_InspectChannelCheckpoints_Call struct {
+	*mock.Call
+}
+
+// InspectChannelCheckpoints is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.InspectChannelCheckpointsRequest
+func (_e *MockDataCoord_Expecter) InspectChannelCheckpoints(_a0 interface{}, _a1 interface{}) *MockDataCoord_InspectChannelCheckpoints_Call {
+	return &MockDataCoord_InspectChannelCheckpoints_Call{Call: _e.mock.On("InspectChannelCheckpoints", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_InspectChannelCheckpoints_Call) Run(run func(_a0 context.Context, _a1 *datapb.InspectChannelCheckpointsRequest)) *MockDataCoord_InspectChannelCheckpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.InspectChannelCheckpointsRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_InspectChannelCheckpoints_Call) Return(_a0 *datapb.InspectChannelCheckpointsResponse, _a1 error) *MockDataCoord_InspectChannelCheckpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_InspectChannelCheckpoints_Call) RunAndReturn(run func(context.Context, *datapb.InspectChannelCheckpointsRequest) (*datapb.InspectChannelCheckpointsResponse, error)) *MockDataCoord_InspectChannelCheckpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBackups provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) ListBackups(_a0 context.Context, _a1 *datapb.ListBackupsRequest) (*datapb.ListBackupsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBackups")
+	}
+
+	var r0 *datapb.ListBackupsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ListBackupsRequest) (*datapb.ListBackupsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ListBackupsRequest) *datapb.ListBackupsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.ListBackupsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ListBackupsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_ListBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBackups'
+type MockDataCoord_ListBackups_Call struct {
+	*mock.Call
+}
+
+// ListBackups is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.ListBackupsRequest
+func (_e *MockDataCoord_Expecter) ListBackups(_a0 interface{}, _a1 interface{}) *MockDataCoord_ListBackups_Call {
+	return &MockDataCoord_ListBackups_Call{Call: _e.mock.On("ListBackups", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_ListBackups_Call) Run(run func(_a0 context.Context, _a1 *datapb.ListBackupsRequest)) *MockDataCoord_ListBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ListBackupsRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_ListBackups_Call) Return(_a0 *datapb.ListBackupsResponse, _a1 error) *MockDataCoord_ListBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_ListBackups_Call) RunAndReturn(run func(context.Context, *datapb.ListBackupsRequest) (*datapb.ListBackupsResponse, error)) *MockDataCoord_ListBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListImports provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) ListImports(_a0 context.Context, _a1 *internalpb.ListImportsRequestInternal) (*internalpb.ListImportsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2639,6 +2875,65 @@ func (_c *MockDataCoord_RegisterStreamingCoordGRPCService_Call) RunAndReturn(run
 	return _c
 }
 
+// RepairChannelCheckpoint provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) RepairChannelCheckpoint(_a0 context.Context, _a1 *datapb.RepairChannelCheckpointRequest) (*datapb.RepairChannelCheckpointResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairChannelCheckpoint")
+	}
+
+	var r0 *datapb.RepairChannelCheckpointResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RepairChannelCheckpointRequest) (*datapb.RepairChannelCheckpointResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RepairChannelCheckpointRequest) *datapb.RepairChannelCheckpointResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.RepairChannelCheckpointResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.RepairChannelCheckpointRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_RepairChannelCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairChannelCheckpoint'
+type MockDataCoord_RepairChannelCheckpoint_Call struct {
+	*mock.Call
+}
+
+// RepairChannelCheckpoint is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.RepairChannelCheckpointRequest
+func (_e *MockDataCoord_Expecter) RepairChannelCheckpoint(_a0 interface{}, _a1 interface{}) *MockDataCoord_RepairChannelCheckpoint_Call {
+	return &MockDataCoord_RepairChannelCheckpoint_Call{Call: _e.mock.On("RepairChannelCheckpoint", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_RepairChannelCheckpoint_Call) Run(run func(_a0 context.Context, _a1 *datapb.RepairChannelCheckpointRequest)) *MockDataCoord_RepairChannelCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.RepairChannelCheckpointRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_RepairChannelCheckpoint_Call) Return(_a0 *datapb.RepairChannelCheckpointResponse, _a1 error) *MockDataCoord_RepairChannelCheckpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_RepairChannelCheckpoint_Call) RunAndReturn(run func(context.Context, *datapb.RepairChannelCheckpointRequest) (*datapb.RepairChannelCheckpointResponse, error)) *MockDataCoord_RepairChannelCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReportDataNodeTtMsgs provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) ReportDataNodeTtMsgs(_a0 context.Context, _a1 *datapb.ReportDataNodeTtMsgsRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2698,6 +2993,65 @@ func (_c *MockDataCoord_ReportDataNodeTtMsgs_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// RestoreBackup provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) RestoreBackup(_a0 context.Context, _a1 *datapb.RestoreBackupRequest) (*datapb.RestoreBackupResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBackup")
+	}
+
+	var r0 *datapb.RestoreBackupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RestoreBackupRequest) (*datapb.RestoreBackupResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RestoreBackupRequest) *datapb.RestoreBackupResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.RestoreBackupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.RestoreBackupRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_RestoreBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBackup'
+type MockDataCoord_RestoreBackup_Call struct {
+	*mock.Call
+}
+
+// RestoreBackup is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.RestoreBackupRequest
+func (_e *MockDataCoord_Expecter) RestoreBackup(_a0 interface{}, _a1 interface{}) *MockDataCoord_RestoreBackup_Call {
+	return &MockDataCoord_RestoreBackup_Call{Call: _e.mock.On("RestoreBackup", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_RestoreBackup_Call) Run(run func(_a0 context.Context, _a1 *datapb.RestoreBackupRequest)) *MockDataCoord_RestoreBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.RestoreBackupRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_RestoreBackup_Call) Return(_a0 *datapb.RestoreBackupResponse, _a1 error) *MockDataCoord_RestoreBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_RestoreBackup_Call) RunAndReturn(run func(context.Context, *datapb.RestoreBackupRequest) (*datapb.RestoreBackupResponse, error)) *MockDataCoord_RestoreBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveBinlogPaths provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) SaveBinlogPaths(_a0 context.Context, _a1 *datapb.SaveBinlogPathsRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
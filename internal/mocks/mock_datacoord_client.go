@@ -448,6 +448,80 @@ func (_c *MockDataCoordClient_Close_Call) RunAndReturn(run func() error) *MockDa
 	return _c
 }
 
+// CreateBackup provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) CreateBackup(ctx context.Context, in *datapb.CreateBackupRequest, opts ...grpc.CallOption) (*datapb.CreateBackupResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBackup")
+	}
+
+	var r0 *datapb.CreateBackupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CreateBackupRequest, ...grpc.CallOption) (*datapb.CreateBackupResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CreateBackupRequest, ...grpc.CallOption) *datapb.CreateBackupResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.CreateBackupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.CreateBackupRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_CreateBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBackup'
+type MockDataCoordClient_CreateBackup_Call struct {
+	*mock.Call
+}
+
+// CreateBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.CreateBackupRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) CreateBackup(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_CreateBackup_Call {
+	return &MockDataCoordClient_CreateBackup_Call{Call: _e.mock.On("CreateBackup",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_CreateBackup_Call) Run(run func(ctx context.Context, in *datapb.CreateBackupRequest, opts ...grpc.CallOption)) *MockDataCoordClient_CreateBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.CreateBackupRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_CreateBackup_Call) Return(_a0 *datapb.CreateBackupResponse, _a1 error) *MockDataCoordClient_CreateBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_CreateBackup_Call) RunAndReturn(run func(context.Context, *datapb.CreateBackupRequest, ...grpc.CallOption) (*datapb.CreateBackupResponse, error)) *MockDataCoordClient_CreateBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateIndex provides a mock function with given fields: ctx, in, opts
 func (_m *MockDataCoordClient) CreateIndex(ctx context.Context, in *indexpb.CreateIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -596,6 +670,80 @@ func (_c *MockDataCoordClient_DescribeIndex_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// DropBackup provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) DropBackup(ctx context.Context, in *datapb.DropBackupRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropBackup")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.DropBackupRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.DropBackupRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.DropBackupRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_DropBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropBackup'
+type MockDataCoordClient_DropBackup_Call struct {
+	*mock.Call
+}
+
+// DropBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.DropBackupRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) DropBackup(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_DropBackup_Call {
+	return &MockDataCoordClient_DropBackup_Call{Call: _e.mock.On("DropBackup",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_DropBackup_Call) Run(run func(ctx context.Context, in *datapb.DropBackupRequest, opts ...grpc.CallOption)) *MockDataCoordClient_DropBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.DropBackupRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_DropBackup_Call) Return(_a0 *commonpb.Status, _a1 error) *MockDataCoordClient_DropBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_DropBackup_Call) RunAndReturn(run func(context.Context, *datapb.DropBackupRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockDataCoordClient_DropBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropIndex provides a mock function with given fields: ctx, in, opts
 func (_m *MockDataCoordClient) DropIndex(ctx context.Context, in *indexpb.DropIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -2890,6 +3038,154 @@ func (_c *MockDataCoordClient_ImportV2_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// InspectChannelCheckpoints provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) InspectChannelCheckpoints(ctx context.Context, in *datapb.InspectChannelCheckpointsRequest, opts ...grpc.CallOption) (*datapb.InspectChannelCheckpointsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InspectChannelCheckpoints")
+	}
+
+	var r0 *datapb.InspectChannelCheckpointsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.InspectChannelCheckpointsRequest, ...grpc.CallOption) (*datapb.InspectChannelCheckpointsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.InspectChannelCheckpointsRequest, ...grpc.CallOption) *datapb.InspectChannelCheckpointsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.InspectChannelCheckpointsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.InspectChannelCheckpointsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_InspectChannelCheckpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectChannelCheckpoints'
+type MockDataCoordClient_InspectChannelCheckpoints_Call struct {
+	*mock.Call
+}
+
+// InspectChannelCheckpoints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.InspectChannelCheckpointsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) InspectChannelCheckpoints(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_InspectChannelCheckpoints_Call {
+	return &MockDataCoordClient_InspectChannelCheckpoints_Call{Call: _e.mock.On("InspectChannelCheckpoints",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_InspectChannelCheckpoints_Call) Run(run func(ctx context.Context, in *datapb.InspectChannelCheckpointsRequest, opts ...grpc.CallOption)) *MockDataCoordClient_InspectChannelCheckpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.InspectChannelCheckpointsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_InspectChannelCheckpoints_Call) Return(_a0 *datapb.InspectChannelCheckpointsResponse, _a1 error) *MockDataCoordClient_InspectChannelCheckpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_InspectChannelCheckpoints_Call) RunAndReturn(run func(context.Context, *datapb.InspectChannelCheckpointsRequest, ...grpc.CallOption) (*datapb.InspectChannelCheckpointsResponse, error)) *MockDataCoordClient_InspectChannelCheckpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBackups provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) ListBackups(ctx context.Context, in *datapb.ListBackupsRequest, opts ...grpc.CallOption) (*datapb.ListBackupsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBackups")
+	}
+
+	var r0 *datapb.ListBackupsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ListBackupsRequest, ...grpc.CallOption) (*datapb.ListBackupsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ListBackupsRequest, ...grpc.CallOption) *datapb.ListBackupsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.ListBackupsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ListBackupsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_ListBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBackups'
+type MockDataCoordClient_ListBackups_Call struct {
+	*mock.Call
+}
+
+// ListBackups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.ListBackupsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) ListBackups(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_ListBackups_Call {
+	return &MockDataCoordClient_ListBackups_Call{Call: _e.mock.On("ListBackups",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_ListBackups_Call) Run(run func(ctx context.Context, in *datapb.ListBackupsRequest, opts ...grpc.CallOption)) *MockDataCoordClient_ListBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.ListBackupsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_ListBackups_Call) Return(_a0 *datapb.ListBackupsResponse, _a1 error) *MockDataCoordClient_ListBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_ListBackups_Call) RunAndReturn(run func(context.Context, *datapb.ListBackupsRequest, ...grpc.CallOption) (*datapb.ListBackupsResponse, error)) *MockDataCoordClient_ListBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListImports provides a mock function with given fields: ctx, in, opts
 func (_m *MockDataCoordClient) ListImports(ctx context.Context, in *internalpb.ListImportsRequestInternal, opts ...grpc.CallOption) (*internalpb.ListImportsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -3186,6 +3482,80 @@ func (_c *MockDataCoordClient_MarkSegmentsDropped_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// RepairChannelCheckpoint provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) RepairChannelCheckpoint(ctx context.Context, in *datapb.RepairChannelCheckpointRequest, opts ...grpc.CallOption) (*datapb.RepairChannelCheckpointResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairChannelCheckpoint")
+	}
+
+	var r0 *datapb.RepairChannelCheckpointResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RepairChannelCheckpointRequest, ...grpc.CallOption) (*datapb.RepairChannelCheckpointResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RepairChannelCheckpointRequest, ...grpc.CallOption) *datapb.RepairChannelCheckpointResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.RepairChannelCheckpointResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.RepairChannelCheckpointRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_RepairChannelCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairChannelCheckpoint'
+type MockDataCoordClient_RepairChannelCheckpoint_Call struct {
+	*mock.Call
+}
+
+// RepairChannelCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.RepairChannelCheckpointRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) RepairChannelCheckpoint(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_RepairChannelCheckpoint_Call {
+	return &MockDataCoordClient_RepairChannelCheckpoint_Call{Call: _e.mock.On("RepairChannelCheckpoint",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_RepairChannelCheckpoint_Call) Run(run func(ctx context.Context, in *datapb.RepairChannelCheckpointRequest, opts ...grpc.CallOption)) *MockDataCoordClient_RepairChannelCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.RepairChannelCheckpointRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_RepairChannelCheckpoint_Call) Return(_a0 *datapb.RepairChannelCheckpointResponse, _a1 error) *MockDataCoordClient_RepairChannelCheckpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_RepairChannelCheckpoint_Call) RunAndReturn(run func(context.Context, *datapb.RepairChannelCheckpointRequest, ...grpc.CallOption) (*datapb.RepairChannelCheckpointResponse, error)) *MockDataCoordClient_RepairChannelCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReportDataNodeTtMsgs provides a mock function with given fields: ctx, in, opts
 func (_m *MockDataCoordClient) ReportDataNodeTtMsgs(ctx context.Context, in *datapb.ReportDataNodeTtMsgsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -3260,6 +3630,80 @@ func (_c *MockDataCoordClient_ReportDataNodeTtMsgs_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// RestoreBackup provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) RestoreBackup(ctx context.Context, in *datapb.RestoreBackupRequest, opts ...grpc.CallOption) (*datapb.RestoreBackupResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBackup")
+	}
+
+	var r0 *datapb.RestoreBackupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RestoreBackupRequest, ...grpc.CallOption) (*datapb.RestoreBackupResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.RestoreBackupRequest, ...grpc.CallOption) *datapb.RestoreBackupResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.RestoreBackupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.RestoreBackupRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_RestoreBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBackup'
+type MockDataCoordClient_RestoreBackup_Call struct {
+	*mock.Call
+}
+
+// RestoreBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.RestoreBackupRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) RestoreBackup(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_RestoreBackup_Call {
+	return &MockDataCoordClient_RestoreBackup_Call{Call: _e.mock.On("RestoreBackup",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_RestoreBackup_Call) Run(run func(ctx context.Context, in *datapb.RestoreBackupRequest, opts ...grpc.CallOption)) *MockDataCoordClient_RestoreBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.RestoreBackupRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_RestoreBackup_Call) Return(_a0 *datapb.RestoreBackupResponse, _a1 error) *MockDataCoordClient_RestoreBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_RestoreBackup_Call) RunAndReturn(run func(context.Context, *datapb.RestoreBackupRequest, ...grpc.CallOption) (*datapb.RestoreBackupResponse, error)) *MockDataCoordClient_RestoreBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveBinlogPaths provides a mock function with given fields: ctx, in, opts
 func (_m *MockDataCoordClient) SaveBinlogPaths(ctx context.Context, in *datapb.SaveBinlogPathsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -5255,6 +5255,65 @@ func (_c *MockProxy_OperateUserRole_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// PreviewDelete provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) PreviewDelete(_a0 context.Context, _a1 *proxypb.PreviewDeleteRequest) (*proxypb.PreviewDeleteResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewDelete")
+	}
+
+	var r0 *proxypb.PreviewDeleteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.PreviewDeleteRequest) (*proxypb.PreviewDeleteResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.PreviewDeleteRequest) *proxypb.PreviewDeleteResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.PreviewDeleteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.PreviewDeleteRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_PreviewDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewDelete'
+type MockProxy_PreviewDelete_Call struct {
+	*mock.Call
+}
+
+// PreviewDelete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.PreviewDeleteRequest
+func (_e *MockProxy_Expecter) PreviewDelete(_a0 interface{}, _a1 interface{}) *MockProxy_PreviewDelete_Call {
+	return &MockProxy_PreviewDelete_Call{Call: _e.mock.On("PreviewDelete", _a0, _a1)}
+}
+
+func (_c *MockProxy_PreviewDelete_Call) Run(run func(_a0 context.Context, _a1 *proxypb.PreviewDeleteRequest)) *MockProxy_PreviewDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.PreviewDeleteRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_PreviewDelete_Call) Return(_a0 *proxypb.PreviewDeleteResponse, _a1 error) *MockProxy_PreviewDelete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_PreviewDelete_Call) RunAndReturn(run func(context.Context, *proxypb.PreviewDeleteRequest) (*proxypb.PreviewDeleteResponse, error)) *MockProxy_PreviewDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Query provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) Query(_a0 context.Context, _a1 *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
 	ret := _m.Called(_a0, _a1)
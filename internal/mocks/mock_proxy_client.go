@@ -890,6 +890,80 @@ func (_c *MockProxyClient_ListImports_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// PreviewDelete provides a mock function with given fields: ctx, in, opts
+func (_m *MockProxyClient) PreviewDelete(ctx context.Context, in *proxypb.PreviewDeleteRequest, opts ...grpc.CallOption) (*proxypb.PreviewDeleteResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewDelete")
+	}
+
+	var r0 *proxypb.PreviewDeleteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.PreviewDeleteRequest, ...grpc.CallOption) (*proxypb.PreviewDeleteResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.PreviewDeleteRequest, ...grpc.CallOption) *proxypb.PreviewDeleteResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.PreviewDeleteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.PreviewDeleteRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxyClient_PreviewDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewDelete'
+type MockProxyClient_PreviewDelete_Call struct {
+	*mock.Call
+}
+
+// PreviewDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *proxypb.PreviewDeleteRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProxyClient_Expecter) PreviewDelete(ctx interface{}, in interface{}, opts ...interface{}) *MockProxyClient_PreviewDelete_Call {
+	return &MockProxyClient_PreviewDelete_Call{Call: _e.mock.On("PreviewDelete",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProxyClient_PreviewDelete_Call) Run(run func(ctx context.Context, in *proxypb.PreviewDeleteRequest, opts ...grpc.CallOption)) *MockProxyClient_PreviewDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*proxypb.PreviewDeleteRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockProxyClient_PreviewDelete_Call) Return(_a0 *proxypb.PreviewDeleteResponse, _a1 error) *MockProxyClient_PreviewDelete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxyClient_PreviewDelete_Call) RunAndReturn(run func(context.Context, *proxypb.PreviewDeleteRequest, ...grpc.CallOption) (*proxypb.PreviewDeleteResponse, error)) *MockProxyClient_PreviewDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RefreshPolicyInfoCache provides a mock function with given fields: ctx, in, opts
 func (_m *MockProxyClient) RefreshPolicyInfoCache(ctx context.Context, in *proxypb.RefreshPolicyInfoCacheRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -449,6 +449,65 @@ func (_c *RootCoord_BackupRBAC_Call) RunAndReturn(run func(context.Context, *mil
 	return _c
 }
 
+// BatchDDL provides a mock function with given fields: _a0, _a1
+func (_m *RootCoord) BatchDDL(_a0 context.Context, _a1 *rootcoordpb.BatchDDLRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchDDL")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.BatchDDLRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.BatchDDLRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rootcoordpb.BatchDDLRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoord_BatchDDL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchDDL'
+type RootCoord_BatchDDL_Call struct {
+	*mock.Call
+}
+
+// BatchDDL is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *rootcoordpb.BatchDDLRequest
+func (_e *RootCoord_Expecter) BatchDDL(_a0 interface{}, _a1 interface{}) *RootCoord_BatchDDL_Call {
+	return &RootCoord_BatchDDL_Call{Call: _e.mock.On("BatchDDL", _a0, _a1)}
+}
+
+func (_c *RootCoord_BatchDDL_Call) Run(run func(_a0 context.Context, _a1 *rootcoordpb.BatchDDLRequest)) *RootCoord_BatchDDL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*rootcoordpb.BatchDDLRequest))
+	})
+	return _c
+}
+
+func (_c *RootCoord_BatchDDL_Call) Return(_a0 *commonpb.Status, _a1 error) *RootCoord_BatchDDL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoord_BatchDDL_Call) RunAndReturn(run func(context.Context, *rootcoordpb.BatchDDLRequest) (*commonpb.Status, error)) *RootCoord_BatchDDL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckHealth provides a mock function with given fields: _a0, _a1
 func (_m *RootCoord) CheckHealth(_a0 context.Context, _a1 *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1216,6 +1275,65 @@ func (_c *RootCoord_DescribeDatabase_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DescribeDatabases provides a mock function with given fields: _a0, _a1
+func (_m *RootCoord) DescribeDatabases(_a0 context.Context, _a1 *rootcoordpb.DescribeDatabasesRequest) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeDatabases")
+	}
+
+	var r0 *rootcoordpb.DescribeDatabasesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.DescribeDatabasesRequest) (*rootcoordpb.DescribeDatabasesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.DescribeDatabasesRequest) *rootcoordpb.DescribeDatabasesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rootcoordpb.DescribeDatabasesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rootcoordpb.DescribeDatabasesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoord_DescribeDatabases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeDatabases'
+type RootCoord_DescribeDatabases_Call struct {
+	*mock.Call
+}
+
+// DescribeDatabases is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *rootcoordpb.DescribeDatabasesRequest
+func (_e *RootCoord_Expecter) DescribeDatabases(_a0 interface{}, _a1 interface{}) *RootCoord_DescribeDatabases_Call {
+	return &RootCoord_DescribeDatabases_Call{Call: _e.mock.On("DescribeDatabases", _a0, _a1)}
+}
+
+func (_c *RootCoord_DescribeDatabases_Call) Run(run func(_a0 context.Context, _a1 *rootcoordpb.DescribeDatabasesRequest)) *RootCoord_DescribeDatabases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*rootcoordpb.DescribeDatabasesRequest))
+	})
+	return _c
+}
+
+func (_c *RootCoord_DescribeDatabases_Call) Return(_a0 *rootcoordpb.DescribeDatabasesResponse, _a1 error) *RootCoord_DescribeDatabases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoord_DescribeDatabases_Call) RunAndReturn(run func(context.Context, *rootcoordpb.DescribeDatabasesRequest) (*rootcoordpb.DescribeDatabasesResponse, error)) *RootCoord_DescribeDatabases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropAlias provides a mock function with given fields: _a0, _a1
 func (_m *RootCoord) DropAlias(_a0 context.Context, _a1 *milvuspb.DropAliasRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
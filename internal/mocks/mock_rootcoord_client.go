@@ -551,6 +551,80 @@ func (_c *MockRootCoordClient_BackupRBAC_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// BatchDDL provides a mock function with given fields: ctx, in, opts
+func (_m *MockRootCoordClient) BatchDDL(ctx context.Context, in *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchDDL")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.BatchDDLRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.BatchDDLRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rootcoordpb.BatchDDLRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRootCoordClient_BatchDDL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchDDL'
+type MockRootCoordClient_BatchDDL_Call struct {
+	*mock.Call
+}
+
+// BatchDDL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *rootcoordpb.BatchDDLRequest
+//   - opts ...grpc.CallOption
+func (_e *MockRootCoordClient_Expecter) BatchDDL(ctx interface{}, in interface{}, opts ...interface{}) *MockRootCoordClient_BatchDDL_Call {
+	return &MockRootCoordClient_BatchDDL_Call{Call: _e.mock.On("BatchDDL",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRootCoordClient_BatchDDL_Call) Run(run func(ctx context.Context, in *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption)) *MockRootCoordClient_BatchDDL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*rootcoordpb.BatchDDLRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRootCoordClient_BatchDDL_Call) Return(_a0 *commonpb.Status, _a1 error) *MockRootCoordClient_BatchDDL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRootCoordClient_BatchDDL_Call) RunAndReturn(run func(context.Context, *rootcoordpb.BatchDDLRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockRootCoordClient_BatchDDL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckHealth provides a mock function with given fields: ctx, in, opts
 func (_m *MockRootCoordClient) CheckHealth(ctx context.Context, in *milvuspb.CheckHealthRequest, opts ...grpc.CallOption) (*milvuspb.CheckHealthResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -1558,6 +1632,80 @@ func (_c *MockRootCoordClient_DescribeDatabase_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// DescribeDatabases provides a mock function with given fields: ctx, in, opts
+func (_m *MockRootCoordClient) DescribeDatabases(ctx context.Context, in *rootcoordpb.DescribeDatabasesRequest, opts ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeDatabases")
+	}
+
+	var r0 *rootcoordpb.DescribeDatabasesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.DescribeDatabasesRequest, ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.DescribeDatabasesRequest, ...grpc.CallOption) *rootcoordpb.DescribeDatabasesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rootcoordpb.DescribeDatabasesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rootcoordpb.DescribeDatabasesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRootCoordClient_DescribeDatabases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeDatabases'
+type MockRootCoordClient_DescribeDatabases_Call struct {
+	*mock.Call
+}
+
+// DescribeDatabases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *rootcoordpb.DescribeDatabasesRequest
+//   - opts ...grpc.CallOption
+func (_e *MockRootCoordClient_Expecter) DescribeDatabases(ctx interface{}, in interface{}, opts ...interface{}) *MockRootCoordClient_DescribeDatabases_Call {
+	return &MockRootCoordClient_DescribeDatabases_Call{Call: _e.mock.On("DescribeDatabases",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRootCoordClient_DescribeDatabases_Call) Run(run func(ctx context.Context, in *rootcoordpb.DescribeDatabasesRequest, opts ...grpc.CallOption)) *MockRootCoordClient_DescribeDatabases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*rootcoordpb.DescribeDatabasesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRootCoordClient_DescribeDatabases_Call) Return(_a0 *rootcoordpb.DescribeDatabasesResponse, _a1 error) *MockRootCoordClient_DescribeDatabases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRootCoordClient_DescribeDatabases_Call) RunAndReturn(run func(context.Context, *rootcoordpb.DescribeDatabasesRequest, ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error)) *MockRootCoordClient_DescribeDatabases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropAlias provides a mock function with given fields: ctx, in, opts
 func (_m *MockRootCoordClient) DropAlias(ctx context.Context, in *milvuspb.DropAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
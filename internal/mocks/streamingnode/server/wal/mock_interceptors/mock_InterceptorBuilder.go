@@ -68,6 +68,51 @@ func (_c *MockInterceptorBuilder_Build_Call) RunAndReturn(run func(interceptors.
 	return _c
 }
 
+// Descriptor provides a mock function with given fields:
+func (_m *MockInterceptorBuilder) Descriptor() interceptors.Descriptor {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Descriptor")
+	}
+
+	var r0 interceptors.Descriptor
+	if rf, ok := ret.Get(0).(func() interceptors.Descriptor); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(interceptors.Descriptor)
+	}
+
+	return r0
+}
+
+// MockInterceptorBuilder_Descriptor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Descriptor'
+type MockInterceptorBuilder_Descriptor_Call struct {
+	*mock.Call
+}
+
+// Descriptor is a helper method to define mock.On call
+func (_e *MockInterceptorBuilder_Expecter) Descriptor() *MockInterceptorBuilder_Descriptor_Call {
+	return &MockInterceptorBuilder_Descriptor_Call{Call: _e.mock.On("Descriptor")}
+}
+
+func (_c *MockInterceptorBuilder_Descriptor_Call) Run(run func()) *MockInterceptorBuilder_Descriptor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInterceptorBuilder_Descriptor_Call) Return(_a0 interceptors.Descriptor) *MockInterceptorBuilder_Descriptor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInterceptorBuilder_Descriptor_Call) RunAndReturn(run func() interceptors.Descriptor) *MockInterceptorBuilder_Descriptor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockInterceptorBuilder creates a new instance of MockInterceptorBuilder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockInterceptorBuilder(t interface {
@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/util/authprovider"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// globalAuthProviders authenticates the users managed outside milvus, nil if none is enabled.
+var globalAuthProviders *authprovider.Manager
+
+func initAuthProviders() error {
+	cfg := &Params.AuthProviderCfg
+	var tokenProviders []authprovider.TokenProvider
+	var passwordProviders []authprovider.PasswordProvider
+	if cfg.OIDCEnabled.GetAsBool() {
+		provider, err := authprovider.NewOIDCProvider(authprovider.OIDCConfig{
+			Issuer:        cfg.OIDCIssuer.GetValue(),
+			Audience:      cfg.OIDCAudience.GetValue(),
+			JWKSURI:       cfg.OIDCJWKSURI.GetValue(),
+			UsernameClaim: cfg.OIDCUsernameClaim.GetValue(),
+			GroupsClaim:   cfg.OIDCGroupsClaim.GetValue(),
+		})
+		if err != nil {
+			return err
+		}
+		tokenProviders = append(tokenProviders, provider)
+	}
+	if cfg.LDAPEnabled.GetAsBool() {
+		provider, err := authprovider.NewLDAPProvider(authprovider.LDAPConfig{
+			Address:        cfg.LDAPAddress.GetValue(),
+			UseTLS:         cfg.LDAPUseTLS.GetAsBool(),
+			BindDNTemplate: cfg.LDAPBindDNTemplate.GetValue(),
+			GroupAttribute: cfg.LDAPGroupAttribute.GetValue(),
+		})
+		if err != nil {
+			return err
+		}
+		passwordProviders = append(passwordProviders, provider)
+	}
+	if len(tokenProviders) == 0 && len(passwordProviders) == 0 {
+		globalAuthProviders = nil
+		return nil
+	}
+	globalAuthProviders = authprovider.NewManager(cfg.CacheTTL.GetAsDuration(time.Second),
+		cfg.GroupRoles.GetAsJSONMap(), tokenProviders, passwordProviders)
+	return nil
+}
+
+// checkExternalUser rejects the external users shadowing the built-in ones,
+// which would otherwise gain their privileges.
+func checkExternalUser(ctx context.Context, username string) error {
+	if username == util.UserRoot {
+		return errors.Newf("external user %s collides with the root user", username)
+	}
+	for _, superUser := range Params.CommonCfg.SuperUsers.GetAsStrings() {
+		if username == superUser {
+			return errors.Newf("external user %s collides with a super user", username)
+		}
+	}
+	if globalMetaCache != nil {
+		_, err := globalMetaCache.GetCredentialInfo(ctx, username)
+		if err == nil {
+			return errors.Newf("external user %s collides with a built-in user", username)
+		}
+		// fail closed, the user may still be a built-in one if the credential is not available
+		if !errors.Is(err, merr.ErrIoKeyNotFound) {
+			return errors.Wrapf(err, "fail to check whether external user %s collides with a built-in user", username)
+		}
+	}
+	return nil
+}
+
+// VerifyToken verifies the token by the external providers, and then as an api key.
+// The users of the providers run as the principals qualified by the provider, e.g. oidc/alice.
+func VerifyToken(ctx context.Context, rawToken string) (string, error) {
+	if globalAuthProviders.Enabled() {
+		identity, err := globalAuthProviders.AuthenticateToken(ctx, rawToken)
+		if err == nil {
+			if err := checkExternalUser(ctx, identity.Principal); err != nil {
+				return "", err
+			}
+			return identity.Principal, nil
+		}
+		if !errors.Is(err, authprovider.ErrNotAuthenticated) {
+			log.Ctx(ctx).Warn("fail to authenticate token by the providers", zap.Error(err))
+		}
+	}
+	return VerifyAPIKey(rawToken)
+}
+
+// verifyExternalPassword verifies the password of the user by the external providers,
+// and returns the principal the user runs as, qualified by the provider, e.g. ldap/alice.
+func verifyExternalPassword(ctx context.Context, username, rawPwd string) (string, bool) {
+	if !globalAuthProviders.Enabled() {
+		return "", false
+	}
+	identity, err := globalAuthProviders.AuthenticatePassword(ctx, username, rawPwd)
+	if err != nil {
+		log.Ctx(ctx).Warn("fail to authenticate password by the providers", zap.String("username", username), zap.Error(err))
+		return "", false
+	}
+	if err := checkExternalUser(ctx, identity.Principal); err != nil {
+		log.Ctx(ctx).Warn("reject external user", zap.Error(err))
+		return "", false
+	}
+	return identity.Principal, true
+}
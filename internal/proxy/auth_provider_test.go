@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/authprovider"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type fakeAuthProvider struct{}
+
+func (p *fakeAuthProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeAuthProvider) AuthenticateToken(ctx context.Context, token string) (*authprovider.Identity, error) {
+	if token == "root-token" {
+		return &authprovider.Identity{Username: "root"}, nil
+	}
+	if token == "external-token" {
+		return &authprovider.Identity{Username: "external", Groups: []string{"admins"}}, nil
+	}
+	return nil, authprovider.ErrNotAuthenticated
+}
+
+func (p *fakeAuthProvider) AuthenticatePassword(ctx context.Context, username, password string) (*authprovider.Identity, error) {
+	if password == "external-password" {
+		return &authprovider.Identity{Username: username, Groups: []string{"admins"}}, nil
+	}
+	return nil, authprovider.ErrNotAuthenticated
+}
+
+func TestAuthProviders(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	assert.NoError(t, initAuthProviders())
+	assert.Nil(t, globalAuthProviders)
+	_, ok := verifyExternalPassword(ctx, "external", "external-password")
+	assert.False(t, ok)
+
+	provider := &fakeAuthProvider{}
+	globalAuthProviders = authprovider.NewManager(time.Minute, map[string]string{"admins": "admin"},
+		[]authprovider.TokenProvider{provider}, []authprovider.PasswordProvider{provider})
+	defer func() {
+		globalAuthProviders = nil
+		globalMetaCache = nil
+	}()
+
+	mockCache := NewMockCache(t)
+	mockCache.On("GetCredentialInfo", mock.Anything, mock.Anything).Return(func(ctx context.Context, username string) (*internalpb.CredentialInfo, error) {
+		switch username {
+		case "builtin":
+			return &internalpb.CredentialInfo{Username: username}, nil
+		case "unavailable":
+			return nil, merr.WrapErrServiceNotReady("RootCoord", 1, "Initializing")
+		default:
+			return nil, merr.WrapErrIoKeyNotFound(username)
+		}
+	})
+	mockCache.On("GetUserRole", mock.Anything).Return([]string{"public"})
+	globalMetaCache = mockCache
+
+	t.Run("check external user", func(t *testing.T) {
+		assert.NoError(t, checkExternalUser(ctx, "external"))
+		assert.Error(t, checkExternalUser(ctx, "root"))
+		assert.Error(t, checkExternalUser(ctx, "builtin"))
+		// the built-in users are not available, reject the external user
+		assert.Error(t, checkExternalUser(ctx, "unavailable"))
+
+		paramtable.Get().Save(Params.CommonCfg.SuperUsers.Key, "super")
+		defer paramtable.Get().Reset(Params.CommonCfg.SuperUsers.Key)
+		assert.Error(t, checkExternalUser(ctx, "super"))
+	})
+
+	t.Run("token", func(t *testing.T) {
+		user, err := VerifyToken(ctx, "external-token")
+		assert.NoError(t, err)
+		assert.Equal(t, "fake/external", user)
+
+		roles, err := GetRole("fake/external")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"public", "admin"}, roles)
+		// the password user of the same name does not gain the roles
+		roles, err = GetRole("external")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"public"}, roles)
+
+		// the token user named root runs as another principal
+		user, err = VerifyToken(ctx, "root-token")
+		assert.NoError(t, err)
+		assert.Equal(t, "fake/root", user)
+	})
+
+	t.Run("password", func(t *testing.T) {
+		user, ok := VerifyPassword(ctx, "ldap-user", "external-password")
+		assert.True(t, ok)
+		assert.Equal(t, "fake/ldap-user", user)
+		roles, err := GetRole("fake/ldap-user")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"public", "admin"}, roles)
+		_, ok = VerifyPassword(ctx, "ldap-user", "wrong-password")
+		assert.False(t, ok)
+
+		// the external user named as a built-in one runs as its principal, without the roles of the built-in one
+		user, ok = VerifyPassword(ctx, "builtin", "external-password")
+		assert.True(t, ok)
+		assert.Equal(t, "fake/builtin", user)
+		roles, err = GetRole("builtin")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"public"}, roles)
+
+		// the password users can't pretend to be the token users
+		_, ok = VerifyPassword(ctx, "fake/external", "external-password")
+		assert.False(t, ok)
+	})
+}
@@ -98,7 +98,7 @@ func AuthenticationInterceptor(ctx context.Context) (context.Context, error) {
 			}
 
 			if !strings.Contains(rawToken, util.CredentialSeperator) {
-				user, err := VerifyAPIKey(rawToken)
+				user, err := VerifyToken(ctx, rawToken)
 				if err != nil {
 					log.Warn("fail to verify apikey", zap.Error(err))
 					return nil, status.Error(codes.Unauthenticated, "auth check failure, please check api key is correct")
@@ -110,12 +110,19 @@ func AuthenticationInterceptor(ctx context.Context) (context.Context, error) {
 			} else {
 				// username+password authentication
 				username, password := parseMD(rawToken)
-				if !passwordVerify(ctx, username, password, globalMetaCache) {
+				user, ok := VerifyPassword(ctx, username, password)
+				if !ok {
 					log.Warn("fail to verify password", zap.String("username", username))
 					// NOTE: don't use the merr, because it will cause the wrong retry behavior in the sdk
 					return nil, status.Error(codes.Unauthenticated, "auth check failure, please check username and password are correct")
 				}
-				metrics.UserRPCCounter.WithLabelValues(username).Inc()
+				metrics.UserRPCCounter.WithLabelValues(user).Inc()
+				// the external users run as their principals
+				if user != username {
+					userToken := fmt.Sprintf("%s%s%s", user, util.CredentialSeperator, util.PasswordHolder)
+					md[strings.ToLower(util.HeaderAuthorize)] = []string{crypto.Base64Encode(userToken)}
+					ctx = metadata.NewIncomingContext(ctx, md)
+				}
 			}
 		}
 	}
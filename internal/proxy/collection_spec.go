@@ -0,0 +1,803 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// collectionSpecVersion is the version of the collection spec format.
+const collectionSpecVersion = "v1"
+
+// CollectionSpec is the declarative definition of a collection, including its schema, indexes, properties and load config.
+type CollectionSpec struct {
+	Version            string            `json:"version" yaml:"version"`
+	Database           string            `json:"database,omitempty" yaml:"database,omitempty"`
+	Name               string            `json:"name" yaml:"name"`
+	Description        string            `json:"description,omitempty" yaml:"description,omitempty"`
+	ShardsNum          int32             `json:"shards_num,omitempty" yaml:"shards_num,omitempty"`
+	NumPartitions      int64             `json:"num_partitions,omitempty" yaml:"num_partitions,omitempty"`
+	ConsistencyLevel   string            `json:"consistency_level,omitempty" yaml:"consistency_level,omitempty"`
+	EnableDynamicField bool              `json:"enable_dynamic_field,omitempty" yaml:"enable_dynamic_field,omitempty"`
+	Fields             []*FieldSpec      `json:"fields" yaml:"fields"`
+	Functions          []*FunctionSpec   `json:"functions,omitempty" yaml:"functions,omitempty"`
+	Properties         map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Indexes            []*IndexSpec      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Load               *LoadSpec         `json:"load,omitempty" yaml:"load,omitempty"`
+}
+
+// FieldSpec is the declarative definition of a collection field.
+type FieldSpec struct {
+	Name            string            `json:"name" yaml:"name"`
+	Description     string            `json:"description,omitempty" yaml:"description,omitempty"`
+	DataType        string            `json:"data_type" yaml:"data_type"`
+	ElementType     string            `json:"element_type,omitempty" yaml:"element_type,omitempty"`
+	Params          map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+	IsPrimaryKey    bool              `json:"is_primary_key,omitempty" yaml:"is_primary_key,omitempty"`
+	AutoID          bool              `json:"auto_id,omitempty" yaml:"auto_id,omitempty"`
+	IsPartitionKey  bool              `json:"is_partition_key,omitempty" yaml:"is_partition_key,omitempty"`
+	IsClusteringKey bool              `json:"is_clustering_key,omitempty" yaml:"is_clustering_key,omitempty"`
+	Nullable        bool              `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	DefaultValue    *string           `json:"default_value,omitempty" yaml:"default_value,omitempty"`
+}
+
+// FunctionSpec is the declarative definition of a collection function.
+type FunctionSpec struct {
+	Name         string            `json:"name" yaml:"name"`
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Type         string            `json:"type" yaml:"type"`
+	InputFields  []string          `json:"input_fields" yaml:"input_fields"`
+	OutputFields []string          `json:"output_fields" yaml:"output_fields"`
+	Params       map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// IndexSpec is the declarative definition of an index, the params are the user index params.
+type IndexSpec struct {
+	Name      string            `json:"name" yaml:"name"`
+	FieldName string            `json:"field_name" yaml:"field_name"`
+	Params    map[string]string `json:"params" yaml:"params"`
+}
+
+// LoadSpec is the declarative load config of a collection, the collection is loaded if present.
+type LoadSpec struct {
+	Replicas       int32    `json:"replicas" yaml:"replicas"`
+	ResourceGroups []string `json:"resource_groups,omitempty" yaml:"resource_groups,omitempty"`
+
+	// loading is set on the exported spec if the collection is still being loaded
+	loading bool
+}
+
+// collectionSpecAction is a step to apply a collection spec.
+type collectionSpecAction struct {
+	Description string
+	apply       func(ctx context.Context) error
+}
+
+// ApplyCollectionSpecResult is the steps planned or executed to apply a collection spec.
+type ApplyCollectionSpecResult struct {
+	Database   string   `json:"database,omitempty"`
+	Collection string   `json:"collection"`
+	DryRun     bool     `json:"dry_run"`
+	Actions    []string `json:"actions"`
+}
+
+// exportCollectionSpec describes the collection, its indexes and load config as a collection spec.
+func (node *Proxy) exportCollectionSpec(ctx context.Context, dbName, collectionName string) (*CollectionSpec, error) {
+	collResp, err := node.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err = merr.CheckRPCCall(collResp, err); err != nil {
+		return nil, err
+	}
+	spec, err := newCollectionSpec(dbName, collResp)
+	if err != nil {
+		return nil, err
+	}
+
+	indexResp, err := node.DescribeIndex(ctx, &milvuspb.DescribeIndexRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err = merr.CheckRPCCall(indexResp, err); err != nil && !errors.Is(err, merr.ErrIndexNotFound) {
+		return nil, err
+	}
+	for _, index := range indexResp.GetIndexDescriptions() {
+		spec.Indexes = append(spec.Indexes, &IndexSpec{
+			Name:      index.GetIndexName(),
+			FieldName: index.GetFieldName(),
+			Params:    specParams(index.GetParams()),
+		})
+	}
+
+	loadResp, err := node.GetLoadState(ctx, &milvuspb.GetLoadStateRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err = merr.CheckRPCCall(loadResp, err); err != nil {
+		return nil, err
+	}
+	if loadResp.GetState() != commonpb.LoadState_LoadStateLoaded && loadResp.GetState() != commonpb.LoadState_LoadStateLoading {
+		return spec, nil
+	}
+	replicaResp, err := node.GetReplicas(ctx, &milvuspb.GetReplicasRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err = merr.CheckRPCCall(replicaResp, err); err != nil {
+		return nil, err
+	}
+	resourceGroups := lo.Uniq(lo.Map(replicaResp.GetReplicas(), func(replica *milvuspb.ReplicaInfo, _ int) string {
+		return replica.GetResourceGroupName()
+	}))
+	sort.Strings(resourceGroups)
+	spec.Load = &LoadSpec{
+		Replicas:       int32(len(replicaResp.GetReplicas())),
+		ResourceGroups: resourceGroups,
+		loading:        loadResp.GetState() == commonpb.LoadState_LoadStateLoading,
+	}
+	return spec, nil
+}
+
+// newCollectionSpec converts the collection description into a collection spec without indexes and load config.
+func newCollectionSpec(dbName string, collResp *milvuspb.DescribeCollectionResponse) (*CollectionSpec, error) {
+	schema := collResp.GetSchema()
+	spec := &CollectionSpec{
+		Version:            collectionSpecVersion,
+		Database:           dbName,
+		Name:               schema.GetName(),
+		Description:        schema.GetDescription(),
+		ShardsNum:          collResp.GetShardsNum(),
+		NumPartitions:      collResp.GetNumPartitions(),
+		ConsistencyLevel:   collResp.GetConsistencyLevel().String(),
+		EnableDynamicField: schema.GetEnableDynamicField(),
+		Properties:         specParams(collResp.GetProperties()),
+	}
+	for _, field := range schema.GetFields() {
+		if field.GetIsDynamic() {
+			continue
+		}
+		fieldSpec := &FieldSpec{
+			Name:            field.GetName(),
+			Description:     field.GetDescription(),
+			DataType:        field.GetDataType().String(),
+			Params:          specParams(field.GetTypeParams()),
+			IsPrimaryKey:    field.GetIsPrimaryKey(),
+			AutoID:          field.GetAutoID(),
+			IsPartitionKey:  field.GetIsPartitionKey(),
+			IsClusteringKey: field.GetIsClusteringKey(),
+			Nullable:        field.GetNullable(),
+		}
+		if field.GetDataType() == schemapb.DataType_Array {
+			fieldSpec.ElementType = field.GetElementType().String()
+		}
+		if field.GetDefaultValue() != nil {
+			value, err := formatDefaultValue(field.GetDefaultValue())
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s", field.GetName())
+			}
+			fieldSpec.DefaultValue = &value
+		}
+		spec.Fields = append(spec.Fields, fieldSpec)
+	}
+	for _, function := range schema.GetFunctions() {
+		spec.Functions = append(spec.Functions, &FunctionSpec{
+			Name:         function.GetName(),
+			Description:  function.GetDescription(),
+			Type:         function.GetType().String(),
+			InputFields:  function.GetInputFieldNames(),
+			OutputFields: function.GetOutputFieldNames(),
+			Params:       specParams(function.GetParams()),
+		})
+	}
+	return spec, nil
+}
+
+// validate checks the collection spec could be applied.
+func (spec *CollectionSpec) validate() error {
+	if spec.Version != collectionSpecVersion {
+		return merr.WrapErrParameterInvalid(collectionSpecVersion, spec.Version, "unsupported collection spec version")
+	}
+	if spec.Name == "" {
+		return merr.WrapErrParameterMissing("name")
+	}
+	if spec.ConsistencyLevel != "" {
+		if _, ok := commonpb.ConsistencyLevel_value[spec.ConsistencyLevel]; !ok {
+			return merr.WrapErrParameterInvalidMsg("invalid consistency level %s", spec.ConsistencyLevel)
+		}
+	}
+	fieldNames := typeutil.NewSet[string]()
+	for _, field := range spec.Fields {
+		if fieldNames.Contain(field.Name) {
+			return merr.WrapErrParameterInvalidMsg("duplicated field %s", field.Name)
+		}
+		fieldNames.Insert(field.Name)
+	}
+	indexNames := typeutil.NewSet[string]()
+	for _, index := range spec.Indexes {
+		if index.Name == "" {
+			return merr.WrapErrParameterInvalidMsg("index name of field %s is missing", index.FieldName)
+		}
+		if indexNames.Contain(index.Name) {
+			return merr.WrapErrParameterInvalidMsg("duplicated index %s", index.Name)
+		}
+		if !fieldNames.Contain(index.FieldName) {
+			return merr.WrapErrParameterInvalidMsg("index %s is built on unknown field %s", index.Name, index.FieldName)
+		}
+		indexNames.Insert(index.Name)
+	}
+	if spec.Load != nil && spec.Load.Replicas <= 0 {
+		return merr.WrapErrParameterInvalidMsg("replicas to load must be positive, got %d", spec.Load.Replicas)
+	}
+	return nil
+}
+
+// toSchema converts the collection spec into the collection schema.
+func (spec *CollectionSpec) toSchema() (*schemapb.CollectionSchema, error) {
+	schema := &schemapb.CollectionSchema{
+		Name:               spec.Name,
+		Description:        spec.Description,
+		EnableDynamicField: spec.EnableDynamicField,
+	}
+	for _, fieldSpec := range spec.Fields {
+		dataType, ok := schemapb.DataType_value[fieldSpec.DataType]
+		if !ok {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid data type %s of field %s", fieldSpec.DataType, fieldSpec.Name)
+		}
+		field := &schemapb.FieldSchema{
+			Name:            fieldSpec.Name,
+			Description:     fieldSpec.Description,
+			DataType:        schemapb.DataType(dataType),
+			TypeParams:      funcutil.Map2KeyValuePair(fieldSpec.Params),
+			IsPrimaryKey:    fieldSpec.IsPrimaryKey,
+			AutoID:          fieldSpec.AutoID,
+			IsPartitionKey:  fieldSpec.IsPartitionKey,
+			IsClusteringKey: fieldSpec.IsClusteringKey,
+			Nullable:        fieldSpec.Nullable,
+		}
+		if fieldSpec.ElementType != "" {
+			elementType, ok := schemapb.DataType_value[fieldSpec.ElementType]
+			if !ok {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid element type %s of field %s", fieldSpec.ElementType, fieldSpec.Name)
+			}
+			field.ElementType = schemapb.DataType(elementType)
+		}
+		if fieldSpec.DefaultValue != nil {
+			value, err := parseDefaultValue(*fieldSpec.DefaultValue, field.GetDataType())
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s", fieldSpec.Name)
+			}
+			field.DefaultValue = value
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+	for _, functionSpec := range spec.Functions {
+		functionType, ok := schemapb.FunctionType_value[functionSpec.Type]
+		if !ok {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid type %s of function %s", functionSpec.Type, functionSpec.Name)
+		}
+		schema.Functions = append(schema.Functions, &schemapb.FunctionSchema{
+			Name:             functionSpec.Name,
+			Description:      functionSpec.Description,
+			Type:             schemapb.FunctionType(functionType),
+			InputFieldNames:  functionSpec.InputFields,
+			OutputFieldNames: functionSpec.OutputFields,
+			Params:           funcutil.Map2KeyValuePair(functionSpec.Params),
+		})
+	}
+	return schema, nil
+}
+
+// planCollectionSpec returns the actions to make the collection match the desired spec, current is nil if the collection does not exist.
+// The schema, shards and consistency level of an existing collection are immutable,
+// properties, indexes and load config are only added or updated, the ones absent from the spec are left untouched.
+func (node *Proxy) planCollectionSpec(current, desired *CollectionSpec) ([]*collectionSpecAction, error) {
+	if err := desired.validate(); err != nil {
+		return nil, err
+	}
+	schema, err := desired.toSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []*collectionSpecAction
+	if current == nil {
+		actions = append(actions, node.createCollectionAction(desired, schema))
+	} else {
+		if err := checkImmutableSpec(current, desired); err != nil {
+			return nil, err
+		}
+		if properties := diffProperties(current.Properties, desired.Properties); len(properties) > 0 {
+			actions = append(actions, node.alterCollectionAction(desired, properties))
+		}
+	}
+
+	currentIndexes := make(map[string]*IndexSpec)
+	if current != nil {
+		currentIndexes = lo.SliceToMap(current.Indexes, func(index *IndexSpec) (string, *IndexSpec) {
+			return index.Name, index
+		})
+	}
+	for _, index := range desired.Indexes {
+		currentIndex, ok := currentIndexes[index.Name]
+		if !ok {
+			actions = append(actions, node.createIndexAction(desired, index))
+			continue
+		}
+		if currentIndex.FieldName != index.FieldName {
+			return nil, merr.WrapErrParameterInvalidMsg("index %s is built on field %s, not %s", index.Name, currentIndex.FieldName, index.FieldName)
+		}
+		params := diffProperties(currentIndex.Params, index.Params)
+		for _, key := range []string{common.IndexTypeKey, common.MetricTypeKey} {
+			if _, ok := params[key]; ok {
+				return nil, merr.WrapErrParameterInvalidMsg("%s of index %s cannot be altered, drop the index first", key, index.Name)
+			}
+		}
+		if len(params) > 0 {
+			actions = append(actions, node.alterIndexAction(desired, index.Name, params))
+		}
+	}
+
+	if desired.Load != nil && (current == nil || !loadSpecEqual(current.Load, desired.Load)) {
+		switch {
+		case current == nil || current.Load == nil:
+			actions = append(actions, node.loadCollectionAction(desired))
+		case current.Load.loading:
+			// querycoord rejects changing the load config of a collection being loaded
+			return nil, merr.WrapErrParameterInvalidMsg("collection %s is still being loaded with %d replicas, apply the spec after it is loaded",
+				desired.Name, current.Load.Replicas)
+		default:
+			actions = append(actions, node.updateLoadConfigAction(desired))
+		}
+	}
+	return actions, nil
+}
+
+// checkImmutableSpec checks the desired spec does not change the immutable parts of the existing collection.
+func checkImmutableSpec(current, desired *CollectionSpec) error {
+	if desired.ShardsNum != 0 && desired.ShardsNum != current.ShardsNum {
+		return merr.WrapErrParameterInvalidMsg("shards num cannot be changed from %d to %d", current.ShardsNum, desired.ShardsNum)
+	}
+	if desired.NumPartitions != 0 && desired.NumPartitions != current.NumPartitions {
+		return merr.WrapErrParameterInvalidMsg("num partitions cannot be changed from %d to %d", current.NumPartitions, desired.NumPartitions)
+	}
+	if desired.ConsistencyLevel != "" && desired.ConsistencyLevel != current.ConsistencyLevel {
+		return merr.WrapErrParameterInvalidMsg("consistency level cannot be changed from %s to %s", current.ConsistencyLevel, desired.ConsistencyLevel)
+	}
+	if desired.EnableDynamicField != current.EnableDynamicField {
+		return merr.WrapErrParameterInvalidMsg("dynamic field cannot be changed")
+	}
+	if len(desired.Fields) != len(current.Fields) {
+		return merr.WrapErrParameterInvalidMsg("fields cannot be changed, got %d fields, existing %d", len(desired.Fields), len(current.Fields))
+	}
+	for i, field := range desired.Fields {
+		if !fieldSpecEqual(current.Fields[i], field) {
+			return merr.WrapErrParameterInvalidMsg("field %s cannot be changed", field.Name)
+		}
+	}
+	if len(desired.Functions) != len(current.Functions) {
+		return merr.WrapErrParameterInvalidMsg("functions cannot be changed")
+	}
+	for i, function := range desired.Functions {
+		if !functionSpecEqual(current.Functions[i], function) {
+			return merr.WrapErrParameterInvalidMsg("function %s cannot be changed", function.Name)
+		}
+	}
+	return nil
+}
+
+func fieldSpecEqual(a, b *FieldSpec) bool {
+	return a.Name == b.Name && a.Description == b.Description &&
+		a.DataType == b.DataType && a.ElementType == b.ElementType &&
+		maps.Equal(a.Params, b.Params) &&
+		a.IsPrimaryKey == b.IsPrimaryKey && a.AutoID == b.AutoID &&
+		a.IsPartitionKey == b.IsPartitionKey && a.IsClusteringKey == b.IsClusteringKey &&
+		a.Nullable == b.Nullable &&
+		(a.DefaultValue == nil) == (b.DefaultValue == nil) && lo.FromPtr(a.DefaultValue) == lo.FromPtr(b.DefaultValue)
+}
+
+func functionSpecEqual(a, b *FunctionSpec) bool {
+	return a.Name == b.Name && a.Description == b.Description && a.Type == b.Type &&
+		slices.Equal(a.InputFields, b.InputFields) && slices.Equal(a.OutputFields, b.OutputFields) &&
+		maps.Equal(a.Params, b.Params)
+}
+
+func loadSpecEqual(current, desired *LoadSpec) bool {
+	if current == nil || current.Replicas != desired.Replicas {
+		return false
+	}
+	// resource groups are chosen by querycoord if not specified
+	if len(desired.ResourceGroups) == 0 {
+		return true
+	}
+	resourceGroups := typeutil.NewSet(desired.ResourceGroups...)
+	return resourceGroups.Len() == len(current.ResourceGroups) && resourceGroups.Contain(current.ResourceGroups...)
+}
+
+// diffProperties returns the desired properties which are absent or different in the current ones.
+func diffProperties(current, desired map[string]string) map[string]string {
+	return lo.PickBy(desired, func(key, value string) bool {
+		currentValue, ok := current[key]
+		return !ok || currentValue != value
+	})
+}
+
+func (node *Proxy) createCollectionAction(spec *CollectionSpec, schema *schemapb.CollectionSchema) *collectionSpecAction {
+	return &collectionSpecAction{
+		Description: fmt.Sprintf("create collection %s", spec.Name),
+		apply: func(ctx context.Context) error {
+			req, err := newCreateCollectionRequest(spec, schema)
+			if err != nil {
+				return err
+			}
+			status, err := node.CreateCollection(ctx, req)
+			return merr.CheckRPCCall(status, err)
+		},
+	}
+}
+
+func newCreateCollectionRequest(spec *CollectionSpec, schema *schemapb.CollectionSchema) (*milvuspb.CreateCollectionRequest, error) {
+	schemaBytes, err := proto.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	consistencyLevel := commonpb.ConsistencyLevel_Bounded
+	if spec.ConsistencyLevel != "" {
+		consistencyLevel = commonpb.ConsistencyLevel(commonpb.ConsistencyLevel_value[spec.ConsistencyLevel])
+	}
+	return &milvuspb.CreateCollectionRequest{
+		DbName:           spec.Database,
+		CollectionName:   spec.Name,
+		Schema:           schemaBytes,
+		ShardsNum:        spec.ShardsNum,
+		ConsistencyLevel: consistencyLevel,
+		Properties:       funcutil.Map2KeyValuePair(spec.Properties),
+		NumPartitions:    spec.NumPartitions,
+	}, nil
+}
+
+func (node *Proxy) alterCollectionAction(spec *CollectionSpec, properties map[string]string) *collectionSpecAction {
+	return &collectionSpecAction{
+		Description: fmt.Sprintf("alter properties %s of collection %s", strings.Join(sortedKeys(properties), ","), spec.Name),
+		apply: func(ctx context.Context) error {
+			status, err := node.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+				DbName:         spec.Database,
+				CollectionName: spec.Name,
+				Properties:     funcutil.Map2KeyValuePair(properties),
+			})
+			return merr.CheckRPCCall(status, err)
+		},
+	}
+}
+
+func (node *Proxy) createIndexAction(spec *CollectionSpec, index *IndexSpec) *collectionSpecAction {
+	return &collectionSpecAction{
+		Description: fmt.Sprintf("create index %s on field %s", index.Name, index.FieldName),
+		apply: func(ctx context.Context) error {
+			status, err := node.CreateIndex(ctx, &milvuspb.CreateIndexRequest{
+				DbName:         spec.Database,
+				CollectionName: spec.Name,
+				FieldName:      index.FieldName,
+				IndexName:      index.Name,
+				ExtraParams:    funcutil.Map2KeyValuePair(index.Params),
+			})
+			return merr.CheckRPCCall(status, err)
+		},
+	}
+}
+
+func (node *Proxy) alterIndexAction(spec *CollectionSpec, indexName string, params map[string]string) *collectionSpecAction {
+	return &collectionSpecAction{
+		Description: fmt.Sprintf("alter params %s of index %s", strings.Join(sortedKeys(params), ","), indexName),
+		apply: func(ctx context.Context) error {
+			status, err := node.AlterIndex(ctx, &milvuspb.AlterIndexRequest{
+				DbName:         spec.Database,
+				CollectionName: spec.Name,
+				IndexName:      indexName,
+				ExtraParams:    funcutil.Map2KeyValuePair(params),
+			})
+			return merr.CheckRPCCall(status, err)
+		},
+	}
+}
+
+func (node *Proxy) loadCollectionAction(spec *CollectionSpec) *collectionSpecAction {
+	return &collectionSpecAction{
+		Description: fmt.Sprintf("load collection %s with %d replicas", spec.Name, spec.Load.Replicas),
+		apply: func(ctx context.Context) error {
+			status, err := node.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{
+				DbName:         spec.Database,
+				CollectionName: spec.Name,
+				ReplicaNumber:  spec.Load.Replicas,
+				ResourceGroups: spec.Load.ResourceGroups,
+			})
+			return merr.CheckRPCCall(status, err)
+		},
+	}
+}
+
+// updateLoadConfigAction changes the replicas and resource groups of a loaded collection without releasing it.
+func (node *Proxy) updateLoadConfigAction(spec *CollectionSpec) *collectionSpecAction {
+	return &collectionSpecAction{
+		Description: fmt.Sprintf("update load config of collection %s to %d replicas", spec.Name, spec.Load.Replicas),
+		apply: func(ctx context.Context) error {
+			collectionID, err := globalMetaCache.GetCollectionID(ctx, spec.Database, spec.Name)
+			if err != nil {
+				return err
+			}
+			status, err := node.queryCoord.UpdateLoadConfig(ctx, &querypb.UpdateLoadConfigRequest{
+				Base:           commonpbutil.NewMsgBase(),
+				CollectionIDs:  []int64{collectionID},
+				ReplicaNumber:  spec.Load.Replicas,
+				ResourceGroups: spec.Load.ResourceGroups,
+			})
+			return merr.CheckRPCCall(status, err)
+		},
+	}
+}
+
+// applyCollectionSpec creates or alters the collection to match the spec, only the plan is returned if dryRun.
+// The actions are applied in order without rollback, the ones applied before a failed action are kept.
+// Applying the same spec again resumes from the failed action, as the plan is computed from the current state of the collection.
+func (node *Proxy) applyCollectionSpec(ctx context.Context, spec *CollectionSpec, dryRun bool) (*ApplyCollectionSpecResult, error) {
+	hasResp, err := node.HasCollection(ctx, &milvuspb.HasCollectionRequest{
+		DbName:         spec.Database,
+		CollectionName: spec.Name,
+	})
+	if err = merr.CheckRPCCall(hasResp, err); err != nil {
+		return nil, err
+	}
+	var current *CollectionSpec
+	if hasResp.GetValue() {
+		current, err = node.exportCollectionSpec(ctx, spec.Database, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	actions, err := node.planCollectionSpec(current, spec)
+	if err != nil {
+		return nil, err
+	}
+	result := &ApplyCollectionSpecResult{
+		Database:   spec.Database,
+		Collection: spec.Name,
+		DryRun:     dryRun,
+		Actions: lo.Map(actions, func(action *collectionSpecAction, _ int) string {
+			return action.Description
+		}),
+	}
+	if dryRun {
+		return result, nil
+	}
+	for _, action := range actions {
+		if err := action.apply(ctx); err != nil {
+			return nil, errors.Wrapf(err, "failed to %s", action.Description)
+		}
+	}
+	return result, nil
+}
+
+// BatchCreateSpec is a batch of collections and partitions of a database created by rootcoord atomically.
+type BatchCreateSpec struct {
+	Database    string            `json:"database,omitempty" yaml:"database,omitempty"`
+	Collections []*CollectionSpec `json:"collections,omitempty" yaml:"collections,omitempty"`
+	Partitions  []*PartitionSpec  `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+}
+
+// PartitionSpec is a partition of an existing collection created in a batch.
+type PartitionSpec struct {
+	Collection string `json:"collection" yaml:"collection"`
+	Name       string `json:"name" yaml:"name"`
+}
+
+// BatchCreateResult is the indexes and the load config applied after the batch created.
+type BatchCreateResult struct {
+	Database string   `json:"database,omitempty"`
+	Actions  []string `json:"actions"`
+}
+
+// batchCreate creates the collections and the partitions of the batch atomically by rootcoord,
+// all of them become visible at once, or none of them.
+// The indexes and the load config of the collection specs are applied after the batch created, which is not atomic,
+// the failed ones are applied by retrying the same batch, the created collections, partitions and indexes are skipped.
+func (node *Proxy) batchCreate(ctx context.Context, batch *BatchCreateSpec) (*BatchCreateResult, error) {
+	req := &rootcoordpb.BatchDDLRequest{
+		Base:   commonpbutil.NewMsgBase(commonpbutil.WithSourceID(paramtable.GetNodeID())),
+		DbName: batch.Database,
+	}
+	var actions []*collectionSpecAction
+	for _, spec := range batch.Collections {
+		if spec.Database != "" && spec.Database != batch.Database {
+			return nil, merr.WrapErrParameterInvalidMsg("collection %s is not of the database %s", spec.Name, batch.Database)
+		}
+		spec.Database = batch.Database
+		if err := spec.validate(); err != nil {
+			return nil, errors.Wrapf(err, "collection %s", spec.Name)
+		}
+		schema, err := spec.toSchema()
+		if err != nil {
+			return nil, errors.Wrapf(err, "collection %s", spec.Name)
+		}
+		createReq, err := newCreateCollectionRequest(spec, schema)
+		if err != nil {
+			return nil, err
+		}
+		createReq.Base = commonpbutil.NewMsgBase()
+		// the same checks and defaults as creating a collection alone
+		task := &createCollectionTask{ctx: ctx, CreateCollectionRequest: createReq}
+		if err := task.PreExecute(ctx); err != nil {
+			return nil, errors.Wrapf(err, "collection %s", spec.Name)
+		}
+		req.CreateCollections = append(req.CreateCollections, task.CreateCollectionRequest)
+
+		for _, index := range spec.Indexes {
+			actions = append(actions, node.createIndexAction(spec, index))
+		}
+		if spec.Load != nil {
+			actions = append(actions, node.loadCollectionAction(spec))
+		}
+	}
+	for _, partition := range batch.Partitions {
+		task := &createPartitionTask{
+			ctx: ctx,
+			CreatePartitionRequest: &milvuspb.CreatePartitionRequest{
+				Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_CreatePartition)),
+				DbName:         batch.Database,
+				CollectionName: partition.Collection,
+				PartitionName:  partition.Name,
+			},
+		}
+		if err := task.PreExecute(ctx); err != nil {
+			return nil, errors.Wrapf(err, "partition %s of collection %s", partition.Name, partition.Collection)
+		}
+		req.CreatePartitions = append(req.CreatePartitions, task.CreatePartitionRequest)
+	}
+
+	status, err := node.rootCoord.BatchDDL(ctx, req)
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		return nil, err
+	}
+
+	// the same as creating a partition alone, querycoord loads the new partitions of the loaded collections
+	for _, partition := range batch.Partitions {
+		if err := node.syncNewCreatedPartition(ctx, batch.Database, partition.Collection, partition.Name); err != nil {
+			return nil, errors.Wrapf(err, "failed to sync new partition %s of collection %s", partition.Name, partition.Collection)
+		}
+	}
+	result := &BatchCreateResult{Database: batch.Database, Actions: make([]string, 0, len(actions))}
+	for _, action := range actions {
+		if err := action.apply(ctx); err != nil {
+			return nil, errors.Wrapf(err, "failed to %s", action.Description)
+		}
+		result.Actions = append(result.Actions, action.Description)
+	}
+	return result, nil
+}
+
+func (node *Proxy) syncNewCreatedPartition(ctx context.Context, dbName, collectionName, partitionName string) error {
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, collectionName)
+	if err != nil {
+		return err
+	}
+	partitionID, err := globalMetaCache.GetPartitionID(ctx, dbName, collectionName, partitionName)
+	if err != nil {
+		return err
+	}
+	status, err := node.queryCoord.SyncNewCreatedPartition(ctx, &querypb.SyncNewCreatedPartitionRequest{
+		Base:         commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_ReleasePartitions)),
+		CollectionID: collectionID,
+		PartitionID:  partitionID,
+	})
+	return merr.CheckRPCCall(status, err)
+}
+
+// formatDefaultValue formats the scalar default value of a field as a string.
+func formatDefaultValue(value *schemapb.ValueField) (string, error) {
+	switch data := value.GetData().(type) {
+	case *schemapb.ValueField_BoolData:
+		return strconv.FormatBool(data.BoolData), nil
+	case *schemapb.ValueField_IntData:
+		return strconv.FormatInt(int64(data.IntData), 10), nil
+	case *schemapb.ValueField_LongData:
+		return strconv.FormatInt(data.LongData, 10), nil
+	case *schemapb.ValueField_FloatData:
+		return strconv.FormatFloat(float64(data.FloatData), 'g', -1, 32), nil
+	case *schemapb.ValueField_DoubleData:
+		return strconv.FormatFloat(data.DoubleData, 'g', -1, 64), nil
+	case *schemapb.ValueField_StringData:
+		return data.StringData, nil
+	default:
+		return "", merr.WrapErrParameterInvalidMsg("unsupported default value %v", value)
+	}
+}
+
+// parseDefaultValue parses the default value string of a field with the data type.
+func parseDefaultValue(value string, dataType schemapb.DataType) (*schemapb.ValueField, error) {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		return &schemapb.ValueField{Data: &schemapb.ValueField_BoolData{BoolData: v}}, nil
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return &schemapb.ValueField{Data: &schemapb.ValueField_IntData{IntData: int32(v)}}, nil
+	case schemapb.DataType_Int64:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &schemapb.ValueField{Data: &schemapb.ValueField_LongData{LongData: v}}, nil
+	case schemapb.DataType_Float:
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return nil, err
+		}
+		return &schemapb.ValueField{Data: &schemapb.ValueField_FloatData{FloatData: float32(v)}}, nil
+	case schemapb.DataType_Double:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &schemapb.ValueField{Data: &schemapb.ValueField_DoubleData{DoubleData: v}}, nil
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		return &schemapb.ValueField{Data: &schemapb.ValueField_StringData{StringData: value}}, nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("default value is not supported by %s", dataType.String())
+	}
+}
+
+// specParams converts the key value pairs into the params of a spec, nil if empty to be omitted.
+func specParams(kvs []*commonpb.KeyValuePair) map[string]string {
+	if len(kvs) == 0 {
+		return nil
+	}
+	return funcutil.KeyValuePair2Map(kvs)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := lo.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
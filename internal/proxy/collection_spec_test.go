@@ -0,0 +1,311 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func newTestCollectionSpec(t *testing.T) *CollectionSpec {
+	spec, err := newCollectionSpec("db1", &milvuspb.DescribeCollectionResponse{
+		Schema: &schemapb.CollectionSchema{
+			Name:               "coll",
+			EnableDynamicField: true,
+			Fields: []*schemapb.FieldSchema{
+				{Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true, AutoID: true},
+				{
+					Name: "vec", DataType: schemapb.DataType_FloatVector,
+					TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "128"}},
+				},
+				{
+					Name: "tags", DataType: schemapb.DataType_Array, ElementType: schemapb.DataType_VarChar,
+					TypeParams: []*commonpb.KeyValuePair{{Key: "max_length", Value: "64"}, {Key: "max_capacity", Value: "8"}},
+				},
+				{
+					Name: "price", DataType: schemapb.DataType_Float, Nullable: true,
+					DefaultValue: &schemapb.ValueField{Data: &schemapb.ValueField_FloatData{FloatData: 1.5}},
+				},
+				{Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+			},
+		},
+		ShardsNum:        2,
+		ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+		Properties:       []*commonpb.KeyValuePair{{Key: "collection.ttl.seconds", Value: "3600"}},
+	})
+	require.NoError(t, err)
+	spec.Indexes = []*IndexSpec{
+		{Name: "vec_idx", FieldName: "vec", Params: map[string]string{"index_type": "HNSW", "metric_type": "L2", "M": "16"}},
+	}
+	spec.Load = &LoadSpec{Replicas: 1, ResourceGroups: []string{"__default_resource_group"}}
+	return spec
+}
+
+func TestCollectionSpecSchema(t *testing.T) {
+	spec := newTestCollectionSpec(t)
+	assert.Equal(t, collectionSpecVersion, spec.Version)
+	assert.Equal(t, "Strong", spec.ConsistencyLevel)
+	assert.Len(t, spec.Fields, 4)
+	assert.Equal(t, "VarChar", spec.Fields[2].ElementType)
+	assert.Equal(t, "1.5", lo.FromPtr(spec.Fields[3].DefaultValue))
+
+	schema, err := spec.toSchema()
+	assert.NoError(t, err)
+	assert.Len(t, schema.GetFields(), 4)
+	assert.Equal(t, schemapb.DataType_Array, schema.GetFields()[2].GetDataType())
+	assert.Equal(t, schemapb.DataType_VarChar, schema.GetFields()[2].GetElementType())
+	assert.Equal(t, float32(1.5), schema.GetFields()[3].GetDefaultValue().GetFloatData())
+
+	// the yaml spec is converted back to the same spec
+	bytes, err := yaml.Marshal(spec)
+	assert.NoError(t, err)
+	decoded := &CollectionSpec{}
+	assert.NoError(t, yaml.Unmarshal(bytes, decoded))
+	assert.Equal(t, spec, decoded)
+
+	spec.Fields[0].DataType = "Int128"
+	_, err = spec.toSchema()
+	assert.Error(t, err)
+}
+
+func TestPlanCollectionSpec(t *testing.T) {
+	node := &Proxy{}
+	descriptions := func(actions []*collectionSpecAction) []string {
+		return lo.Map(actions, func(action *collectionSpecAction, _ int) string { return action.Description })
+	}
+
+	t.Run("create", func(t *testing.T) {
+		actions, err := node.planCollectionSpec(nil, newTestCollectionSpec(t))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"create collection coll",
+			"create index vec_idx on field vec",
+			"load collection coll with 1 replicas",
+		}, descriptions(actions))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		actions, err := node.planCollectionSpec(newTestCollectionSpec(t), newTestCollectionSpec(t))
+		assert.NoError(t, err)
+		assert.Empty(t, actions)
+	})
+
+	t.Run("alter", func(t *testing.T) {
+		desired := newTestCollectionSpec(t)
+		desired.Properties["mmap.enabled"] = "true"
+		desired.Indexes[0].Params["mmap.enabled"] = "true"
+		desired.Indexes = append(desired.Indexes, &IndexSpec{Name: "tags_idx", FieldName: "tags", Params: map[string]string{"index_type": "INVERTED"}})
+		desired.Load.Replicas = 2
+		actions, err := node.planCollectionSpec(newTestCollectionSpec(t), desired)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"alter properties mmap.enabled of collection coll",
+			"alter params mmap.enabled of index vec_idx",
+			"create index tags_idx on field tags",
+			"update load config of collection coll to 2 replicas",
+		}, descriptions(actions))
+	})
+
+	t.Run("load", func(t *testing.T) {
+		current := newTestCollectionSpec(t)
+		current.Load = nil
+		actions, err := node.planCollectionSpec(current, newTestCollectionSpec(t))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"load collection coll with 1 replicas"}, descriptions(actions))
+
+		// the load config of a collection being loaded cannot be changed
+		current = newTestCollectionSpec(t)
+		current.Load.loading = true
+		actions, err = node.planCollectionSpec(current, newTestCollectionSpec(t))
+		assert.NoError(t, err)
+		assert.Empty(t, actions)
+		desired := newTestCollectionSpec(t)
+		desired.Load.Replicas = 2
+		_, err = node.planCollectionSpec(current, desired)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("immutable", func(t *testing.T) {
+		desired := newTestCollectionSpec(t)
+		desired.Fields[1].Params["dim"] = "256"
+		_, err := node.planCollectionSpec(newTestCollectionSpec(t), desired)
+		assert.Error(t, err)
+
+		desired = newTestCollectionSpec(t)
+		desired.ShardsNum = 4
+		_, err = node.planCollectionSpec(newTestCollectionSpec(t), desired)
+		assert.Error(t, err)
+
+		desired = newTestCollectionSpec(t)
+		desired.Indexes[0].Params["index_type"] = "IVF_FLAT"
+		_, err = node.planCollectionSpec(newTestCollectionSpec(t), desired)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		desired := newTestCollectionSpec(t)
+		desired.Version = "v0"
+		_, err := node.planCollectionSpec(nil, desired)
+		assert.Error(t, err)
+
+		desired = newTestCollectionSpec(t)
+		desired.Indexes[0].FieldName = "unknown"
+		_, err = node.planCollectionSpec(nil, desired)
+		assert.Error(t, err)
+
+		desired = newTestCollectionSpec(t)
+		desired.Load.Replicas = 0
+		_, err = node.planCollectionSpec(nil, desired)
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyCollectionSpecInvalidRequest(t *testing.T) {
+	node := &Proxy{}
+
+	req, err := http.NewRequest(http.MethodPost, management.RouteApplyCollectionSpec+"?format=yaml", strings.NewReader("version: v0\nname: coll\n"))
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	node.ApplyCollectionSpec(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	req, err = http.NewRequest(http.MethodPost, management.RouteApplyCollectionSpec+"?dry_run=maybe", strings.NewReader("{}"))
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	node.ApplyCollectionSpec(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	req, err = http.NewRequest(http.MethodGet, management.RouteExportCollectionSpec, nil)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	node.ExportCollectionSpec(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestCollectionSpecDefaultValue(t *testing.T) {
+	for _, dataType := range []schemapb.DataType{
+		schemapb.DataType_Bool, schemapb.DataType_Int32, schemapb.DataType_Int64,
+		schemapb.DataType_Double, schemapb.DataType_VarChar,
+	} {
+		text := "1"
+		if dataType == schemapb.DataType_Bool {
+			text = "true"
+		}
+		value, err := parseDefaultValue(text, dataType)
+		assert.NoError(t, err)
+		formatted, err := formatDefaultValue(value)
+		assert.NoError(t, err)
+		assert.Equal(t, text, formatted)
+	}
+
+	_, err := parseDefaultValue("x", schemapb.DataType_Int64)
+	assert.Error(t, err)
+	_, err = parseDefaultValue("x", schemapb.DataType_JSON)
+	assert.Error(t, err)
+}
+
+func TestBatchCreate(t *testing.T) {
+	paramtable.Init()
+	newSpec := func(name string) *CollectionSpec {
+		return &CollectionSpec{
+			Version: collectionSpecVersion,
+			Name:    name,
+			Fields: []*FieldSpec{
+				{Name: "pk", DataType: "Int64", IsPrimaryKey: true},
+				{Name: "vec", DataType: "FloatVector", Params: map[string]string{"dim": "8"}},
+			},
+		}
+	}
+
+	t.Run("normal case", func(t *testing.T) {
+		rc := mocks.NewMockRootCoordClient(t)
+		rc.EXPECT().BatchDDL(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+				assert.Equal(t, "db1", req.GetDbName())
+				assert.Len(t, req.GetCreateCollections(), 2)
+				for _, createReq := range req.GetCreateCollections() {
+					assert.Equal(t, "db1", createReq.GetDbName())
+					assert.Equal(t, commonpb.MsgType_CreateCollection, createReq.GetBase().GetMsgType())
+				}
+				return merr.Success(), nil
+			}).Once()
+		node := &Proxy{rootCoord: rc}
+		result, err := node.batchCreate(context.Background(), &BatchCreateSpec{
+			Database:    "db1",
+			Collections: []*CollectionSpec{newSpec("coll1"), newSpec("coll2")},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "db1", result.Database)
+		assert.Empty(t, result.Actions)
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		node := &Proxy{rootCoord: mocks.NewMockRootCoordClient(t)}
+		spec := newSpec("coll")
+		spec.Database = "db2"
+		_, err := node.batchCreate(context.Background(), &BatchCreateSpec{
+			Database:    "db1",
+			Collections: []*CollectionSpec{spec},
+		})
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		_, err = node.batchCreate(context.Background(), &BatchCreateSpec{
+			Database:   "db1",
+			Partitions: []*PartitionSpec{{Collection: "1coll", Name: "part"}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("failed to batch create", func(t *testing.T) {
+		rc := mocks.NewMockRootCoordClient(t)
+		rc.EXPECT().BatchDDL(mock.Anything, mock.Anything).Return(merr.Status(merr.WrapErrCollectionNumLimitExceeded("db1", 1)), nil).Once()
+		node := &Proxy{rootCoord: rc}
+		_, err := node.batchCreate(context.Background(), &BatchCreateSpec{
+			Database:    "db1",
+			Collections: []*CollectionSpec{newSpec("coll")},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		node := &Proxy{}
+		req, err := http.NewRequest(http.MethodPost, management.RouteBatchCreateCollections, strings.NewReader("{"))
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		node.BatchCreateCollections(recorder, req)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
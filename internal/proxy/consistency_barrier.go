@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/internal/util/streamingutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// useConsistencyBarrier returns whether the strong consistency read waits for a barrier appended into the wal of each shard.
+// Otherwise the read waits for the begin timestamp of the task, which is only visible on the shard after the next time tick.
+func useConsistencyBarrier(consistencyLevel commonpb.ConsistencyLevel, guaranteeTs, mvccTs, beginTs uint64) bool {
+	return consistencyLevel == commonpb.ConsistencyLevel_Strong &&
+		guaranteeTs == beginTs && mvccTs == 0 &&
+		streamingutil.IsStreamingServiceEnabled() &&
+		paramtable.Get().ProxyCfg.StrongConsistencyBarrier.GetAsBool()
+}
+
+// appendConsistencyBarrier appends a barrier message into the wal of the vchannel,
+// the returned time tick is greater than the time tick of all the messages appended into the vchannel before.
+func appendConsistencyBarrier(ctx context.Context, collectionID int64, vchannel string) (uint64, error) {
+	msg, err := message.NewBarrierMessageBuilderV2().
+		WithVChannel(vchannel).
+		WithHeader(&message.BarrierMessageHeader{
+			CollectionId: collectionID,
+		}).
+		WithBody(&message.BarrierMessageBody{}).
+		BuildMutable()
+	if err != nil {
+		return 0, err
+	}
+	result, err := streaming.WAL().RawAppend(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return result.TimeTick, nil
+}
+
+// guaranteeTsByBarrier returns the time tick of the barrier appended into the vchannel as the guarantee timestamp,
+// or the given one if the barrier cannot be appended.
+func guaranteeTsByBarrier(ctx context.Context, collectionID int64, vchannel string, guaranteeTs uint64) uint64 {
+	barrierTs, err := appendConsistencyBarrier(ctx, collectionID, vchannel)
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to append consistency barrier, wait for the begin timestamp instead",
+			zap.Int64("collectionID", collectionID),
+			zap.String("vchannel", vchannel),
+			zap.Error(err))
+		return guaranteeTs
+	}
+	return barrierTs
+}
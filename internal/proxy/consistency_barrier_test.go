@@ -0,0 +1,69 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/internal/mocks/distributed/mock_streaming"
+	"github.com/milvus-io/milvus/internal/util/streamingutil"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestUseConsistencyBarrier(t *testing.T) {
+	paramtable.Init()
+
+	streamingutil.UnsetStreamingServiceEnabled()
+	assert.False(t, useConsistencyBarrier(commonpb.ConsistencyLevel_Strong, 100, 0, 100))
+
+	streamingutil.SetStreamingServiceEnabled()
+	defer streamingutil.UnsetStreamingServiceEnabled()
+	assert.True(t, useConsistencyBarrier(commonpb.ConsistencyLevel_Strong, 100, 0, 100))
+	assert.False(t, useConsistencyBarrier(commonpb.ConsistencyLevel_Bounded, 90, 0, 100))
+	// guarantee timestamp given by the iterator
+	assert.False(t, useConsistencyBarrier(commonpb.ConsistencyLevel_Strong, 50, 50, 100))
+
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.StrongConsistencyBarrier.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.StrongConsistencyBarrier.Key)
+	assert.False(t, useConsistencyBarrier(commonpb.ConsistencyLevel_Strong, 100, 0, 100))
+}
+
+func TestGuaranteeTsByBarrier(t *testing.T) {
+	ctx := context.Background()
+	wal := mock_streaming.NewMockWALAccesser(t)
+	streaming.SetWALForTest(wal)
+
+	wal.EXPECT().RawAppend(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, msg message.MutableMessage, opts ...streaming.AppendOption) (*types.AppendResult, error) {
+			assert.Equal(t, message.MessageTypeBarrier, msg.MessageType())
+			assert.Equal(t, "ch1", msg.VChannel())
+			return &types.AppendResult{TimeTick: 200}, nil
+		}).Once()
+	assert.Equal(t, uint64(200), guaranteeTsByBarrier(ctx, 1, "ch1", 100))
+
+	wal.EXPECT().RawAppend(mock.Anything, mock.Anything).Return(nil, errors.New("mock")).Once()
+	assert.Equal(t, uint64(100), guaranteeTsByBarrier(ctx, 1, "ch1", 100))
+}
@@ -0,0 +1,85 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"sort"
+
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// DeleteProgress is the progress of a running delete by expression.
+type DeleteProgress struct {
+	MsgID      int64  `json:"msg_id"`
+	DbName     string `json:"db_name"`
+	Collection string `json:"collection_name"`
+	Partition  string `json:"partition_name,omitempty"`
+	Expr       string `json:"expr"`
+	// rows matched by the expr on the snapshot, only counted if the affected rows limit is set
+	MatchedRows int64 `json:"matched_rows,omitempty"`
+	DeletedRows int64 `json:"deleted_rows"`
+	// unix milliseconds the delete started
+	StartTime         int64    `json:"start_time"`
+	SnapshotTs        uint64   `json:"snapshot_ts"`
+	CompletedChannels []string `json:"completed_channels,omitempty"`
+}
+
+// deleteProgressTracker tracks the delete by expression running on this proxy.
+type deleteProgressTracker struct {
+	runners *typeutil.ConcurrentMap[int64, *deleteRunner]
+}
+
+var globalDeleteProgress = newDeleteProgressTracker()
+
+func newDeleteProgressTracker() *deleteProgressTracker {
+	return &deleteProgressTracker{
+		runners: typeutil.NewConcurrentMap[int64, *deleteRunner](),
+	}
+}
+
+func (t *deleteProgressTracker) register(dr *deleteRunner) {
+	t.runners.Insert(dr.msgID, dr)
+}
+
+func (t *deleteProgressTracker) unregister(msgID int64) {
+	t.runners.Remove(msgID)
+}
+
+// List returns the progress of the running deletes ordered by start time.
+func (t *deleteProgressTracker) List() []*DeleteProgress {
+	progresses := make([]*DeleteProgress, 0, t.runners.Len())
+	t.runners.Range(func(_ int64, dr *deleteRunner) bool {
+		progresses = append(progresses, &DeleteProgress{
+			MsgID:       dr.msgID,
+			DbName:      dr.req.GetDbName(),
+			Collection:  dr.req.GetCollectionName(),
+			Partition:   dr.req.GetPartitionName(),
+			Expr:        dr.req.GetExpr(),
+			MatchedRows: dr.matchedRows.Load(),
+			DeletedRows: dr.count.Load(),
+			StartTime:   dr.startTime.UnixMilli(),
+			SnapshotTs:  dr.ts,
+			// the delete is resumable from the snapshot ts and the completed channels if interrupted
+			CompletedChannels: dr.completedChannels.Collect(),
+		})
+		return true
+	})
+	sort.Slice(progresses, func(i, j int) bool {
+		return progresses[i].StartTime < progresses[j].StartTime
+	})
+	return progresses
+}
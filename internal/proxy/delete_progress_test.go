@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+func TestDeleteProgress(t *testing.T) {
+	tracker := newDeleteProgressTracker()
+	newRunner := func(msgID int64, startTime time.Time) *deleteRunner {
+		dr := &deleteRunner{
+			msgID:     msgID,
+			ts:        100,
+			startTime: startTime,
+			req: &milvuspb.DeleteRequest{
+				DbName:         "db",
+				CollectionName: "coll",
+				Expr:           "age > 10",
+			},
+		}
+		dr.matchedRows.Store(10)
+		return dr
+	}
+
+	now := time.Now()
+	dr1 := newRunner(1, now)
+	dr2 := newRunner(2, now.Add(-time.Minute))
+	tracker.register(dr1)
+	tracker.register(dr2)
+	dr1.count.Add(4)
+
+	progresses := tracker.List()
+	require.Len(t, progresses, 2)
+	assert.Equal(t, int64(2), progresses[0].MsgID)
+	assert.Equal(t, int64(1), progresses[1].MsgID)
+	assert.Equal(t, int64(10), progresses[1].MatchedRows)
+	assert.Equal(t, int64(4), progresses[1].DeletedRows)
+	assert.Equal(t, "age > 10", progresses[1].Expr)
+
+	tracker.unregister(1)
+	tracker.unregister(2)
+	assert.Empty(t, tracker.List())
+
+	// the running deletes are listed by the management api
+	globalDeleteProgress.register(dr1)
+	defer globalDeleteProgress.unregister(1)
+	req, err := http.NewRequest(http.MethodGet, management.RouteDeleteProgress, nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	(&Proxy{}).ListDeleteProgress(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	listed := make([]*DeleteProgress, 0)
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &listed))
+	require.Len(t, listed, 1)
+	assert.Equal(t, int64(4), listed[0].DeletedRows)
+}
@@ -2656,6 +2656,13 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 		}
 	}
 
+	ackLevel, err := getInsertAckLevel(ctx)
+	if err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
+			metrics.AbandonLabel, request.GetDbName(), request.GetCollectionName()).Inc()
+		return constructFailedResponse(err), nil
+	}
+
 	log.Debug("Enqueue insert request in Proxy")
 
 	if err := node.sched.dmQueue.Enqueue(enqueuedTask); err != nil {
@@ -2691,6 +2698,16 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	// InsertCnt always equals to the number of entities in the request
 	it.result.InsertCnt = int64(request.NumRows)
 
+	if merr.Ok(it.result.GetStatus()) {
+		ackedLevel, err := node.waitInsertAck(ctx, request.GetDbName(), request.GetCollectionName(), request.GetPartitionName(),
+			it.result.GetTimestamp(), ackLevel)
+		if err != nil {
+			log.Warn("insert not acknowledged at the requested level",
+				zap.String("ackLevel", ackLevel), zap.String("ackedLevel", ackedLevel), zap.Error(err))
+		}
+		SetInsertAck(it.result.GetStatus(), ackedLevel, err)
+	}
+
 	rateCol.Add(internalpb.RateType_DMLInsert.String(), float64(it.insertMsg.Size()))
 
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
@@ -2712,6 +2729,7 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	SetReportValue(it.result.GetStatus(), v)
 	if merr.Ok(it.result.GetStatus()) {
 		metrics.ProxyReportValue.WithLabelValues(nodeID, hookutil.OpTypeInsert, request.DbName, username).Add(float64(v))
+		globalUsageMeter.RecordInsert(username, successCnt)
 	}
 	metrics.ProxyInsertVectors.
 		WithLabelValues(nodeID, dbName, collectionName).
@@ -2790,8 +2808,11 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 		metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
 			metrics.FailLabel, request.GetDbName(), request.GetCollectionName()).Inc()
 
+		status := merr.Status(err)
+		dr.setResumeInfo(status)
 		return &milvuspb.MutationResult{
-			Status: merr.Status(err),
+			Status:    status,
+			DeleteCnt: dr.result.GetDeleteCnt(),
 		}, nil
 	}
 
@@ -2826,6 +2847,74 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 	return dr.result, nil
 }
 
+// PreviewDelete counts the entities matched by the delete expr and samples their primary keys without deleting anything,
+// the count and the primary keys are from the same snapshot.
+func (node *Proxy) PreviewDelete(ctx context.Context, request *proxypb.PreviewDeleteRequest) (*proxypb.PreviewDeleteResponse, error) {
+	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-PreviewDelete")
+	defer sp.End()
+	log := log.Ctx(ctx).With(
+		zap.String("role", typeutil.ProxyRole),
+		zap.String("db", request.GetDbName()),
+		zap.String("collection", request.GetCollectionName()),
+		zap.String("partition", request.GetPartitionName()),
+		zap.String("expr", request.GetExpr()),
+	)
+
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.PreviewDeleteResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	limit := request.GetLimit()
+	if limit < 0 {
+		err := merr.WrapErrParameterInvalidMsg("limit of delete preview should be non-negative, got %d", limit)
+		return &proxypb.PreviewDeleteResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	// the sample is bounded by the max query result window
+	if maxLimit := paramtable.Get().QuotaConfig.TopKLimit.GetAsInt64(); limit > maxLimit {
+		limit = maxLimit
+	}
+
+	dr := &deleteRunner{
+		req: &milvuspb.DeleteRequest{
+			Base:               request.GetBase(),
+			DbName:             request.GetDbName(),
+			CollectionName:     request.GetCollectionName(),
+			PartitionName:      request.GetPartitionName(),
+			Expr:               request.GetExpr(),
+			ExprTemplateValues: request.GetExprTemplateValues(),
+		},
+		idAllocator:     node.rowIDAllocator,
+		tsoAllocatorIns: node.tsoAllocator,
+		chMgr:           node.chMgr,
+		lb:              node.lbPolicy,
+	}
+	if err := dr.Init(ctx); err != nil {
+		log.Warn("failed to init delete preview", zap.Error(err))
+		return &proxypb.PreviewDeleteResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	count, primaryKeys, err := dr.preview(ctx, limit)
+	if err != nil {
+		log.Warn("failed to preview delete", zap.Uint64("snapshotTs", dr.ts), zap.Error(err))
+		return &proxypb.PreviewDeleteResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	log.Debug("preview delete done", zap.Uint64("snapshotTs", dr.ts), zap.Int64("count", count))
+	return &proxypb.PreviewDeleteResponse{
+		Status:      merr.Success(),
+		Count:       count,
+		PrimaryKeys: primaryKeys,
+		SnapshotTs:  dr.ts,
+	}, nil
+}
+
 // Upsert upsert records into collection.
 func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest) (*milvuspb.MutationResult, error) {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Upsert")
@@ -2855,6 +2944,15 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method, metrics.TotalLabel, request.GetDbName(), request.GetCollectionName()).Inc()
 
+	ackLevel, err := getInsertAckLevel(ctx)
+	if err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
+			metrics.AbandonLabel, request.GetDbName(), request.GetCollectionName()).Inc()
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
+
 	request.Base = commonpbutil.NewMsgBase(
 		commonpbutil.WithMsgType(commonpb.MsgType_Upsert),
 		commonpbutil.WithSourceID(paramtable.GetNodeID()),
@@ -2942,6 +3040,16 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 	// UpsertCnt always equals to the number of entities in the request
 	it.result.UpsertCnt = int64(request.NumRows)
 
+	if merr.Ok(it.result.GetStatus()) {
+		ackedLevel, err := node.waitInsertAck(ctx, request.GetDbName(), request.GetCollectionName(), request.GetPartitionName(),
+			it.result.GetTimestamp(), ackLevel)
+		if err != nil {
+			log.Warn("upsert not acknowledged at the requested level",
+				zap.String("ackLevel", ackLevel), zap.String("ackedLevel", ackedLevel), zap.Error(err))
+		}
+		SetInsertAck(it.result.GetStatus(), ackedLevel, err)
+	}
+
 	username := GetCurUserFromContextOrDefault(ctx)
 	nodeID := paramtable.GetStringNodeID()
 	dbName := request.DbName
@@ -2957,6 +3065,7 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 	SetReportValue(it.result.GetStatus(), v)
 	if merr.Ok(it.result.GetStatus()) {
 		metrics.ProxyReportValue.WithLabelValues(nodeID, hookutil.OpTypeUpsert, dbName, username).Add(float64(v))
+		globalUsageMeter.RecordInsert(username, it.result.UpsertCnt-int64(len(it.result.ErrIndex)))
 	}
 
 	rateCol.Add(internalpb.RateType_DMLUpsert.String(), float64(it.upsertMsg.InsertMsg.Size()+it.upsertMsg.DeleteMsg.Size()))
@@ -3217,18 +3326,19 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		Add(float64(qt.result.GetResults().GetNumQueries()))
 
 	searchDur := tr.ElapseSpan().Milliseconds()
-	metrics.ProxySQLatency.WithLabelValues(
+	metrics.ObserveWithRequestTags(ctx, metrics.ProxySQLatency.WithLabelValues(
 		nodeID,
 		metrics.SearchLabel,
 		dbName,
 		collectionName,
-	).Observe(float64(searchDur))
+	), float64(searchDur))
 
 	metrics.ProxyCollectionSQLatency.WithLabelValues(
 		nodeID,
 		metrics.SearchLabel,
 		collectionName,
 	).Observe(float64(searchDur))
+	metrics.ProxySearchTypeLatency.WithLabelValues(nodeID, qt.searchType()).Observe(float64(searchDur))
 
 	if qt.result != nil {
 		username := GetCurUserFromContextOrDefault(ctx)
@@ -3244,6 +3354,7 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		SetReportValue(qt.result.GetStatus(), v)
 		if merr.Ok(qt.result.GetStatus()) {
 			metrics.ProxyReportValue.WithLabelValues(nodeID, hookutil.OpTypeSearch, dbName, username).Add(float64(v))
+			globalUsageMeter.RecordSearch(username, qt.result.GetResults().GetNumQueries(), qt.result.GetResults().GetAllSearchCount(), sentSize)
 		}
 	}
 	return qt.result, qt.resultSizeInsufficient, qt.isTopkReduce, qt.isRecallEvaluation, nil
@@ -3432,18 +3543,19 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 		Add(float64(len(request.GetRequests()) * int(qt.SearchRequest.GetNq())))
 
 	searchDur := tr.ElapseSpan().Milliseconds()
-	metrics.ProxySQLatency.WithLabelValues(
+	metrics.ObserveWithRequestTags(ctx, metrics.ProxySQLatency.WithLabelValues(
 		nodeID,
 		metrics.HybridSearchLabel,
 		dbName,
 		collectionName,
-	).Observe(float64(searchDur))
+	), float64(searchDur))
 
 	metrics.ProxyCollectionSQLatency.WithLabelValues(
 		nodeID,
 		metrics.HybridSearchLabel,
 		collectionName,
 	).Observe(float64(searchDur))
+	metrics.ProxySearchTypeLatency.WithLabelValues(nodeID, qt.searchType()).Observe(float64(searchDur))
 
 	if qt.result != nil {
 		sentSize := proto.Size(qt.result)
@@ -3459,6 +3571,7 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 		SetReportValue(qt.result.GetStatus(), v)
 		if merr.Ok(qt.result.GetStatus()) {
 			metrics.ProxyReportValue.WithLabelValues(nodeID, hookutil.OpTypeHybridSearch, dbName, username).Add(float64(v))
+			globalUsageMeter.RecordSearch(username, qt.result.GetResults().GetNumQueries(), qt.result.GetResults().GetAllSearchCount(), sentSize)
 		}
 	}
 	return qt.result, qt.resultSizeInsufficient, qt.isTopkReduce, nil
@@ -3699,12 +3812,12 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 			metrics.QueryLabel,
 		).Observe(float64(span.Milliseconds()))
 
-		metrics.ProxySQLatency.WithLabelValues(
+		metrics.ObserveWithRequestTags(ctx, metrics.ProxySQLatency.WithLabelValues(
 			strconv.FormatInt(paramtable.GetNodeID(), 10),
 			metrics.QueryLabel,
 			request.GetDbName(),
 			request.GetCollectionName(),
-		).Observe(float64(tr.ElapseSpan().Milliseconds()))
+		), float64(tr.ElapseSpan().Milliseconds()))
 
 		metrics.ProxyCollectionSQLatency.WithLabelValues(
 			strconv.FormatInt(paramtable.GetNodeID(), 10),
@@ -3782,16 +3895,18 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 
 	username := GetCurUserFromContextOrDefault(ctx)
 	nodeID := paramtable.GetStringNodeID()
+	sentSize := proto.Size(res)
 	v := hookutil.GetExtension().Report(map[string]any{
 		hookutil.OpTypeKey:          hookutil.OpTypeQuery,
 		hookutil.DatabaseKey:        request.DbName,
 		hookutil.UsernameKey:        username,
-		hookutil.ResultDataSizeKey:  proto.Size(res),
+		hookutil.ResultDataSizeKey:  sentSize,
 		hookutil.RelatedDataSizeKey: qt.totalRelatedDataSize,
 		hookutil.RelatedCntKey:      qt.allQueryCnt,
 	})
 	SetReportValue(res.Status, v)
 	metrics.ProxyReportValue.WithLabelValues(nodeID, hookutil.OpTypeQuery, request.DbName, username).Add(float64(v))
+	globalUsageMeter.RecordQuery(username, qt.allQueryCnt, sentSize)
 	return res, nil
 }
 
@@ -4498,6 +4613,22 @@ func (node *Proxy) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsReque
 		commonpbutil.WithSourceID(paramtable.GetNodeID()),
 	)
 	if metricType == metricsinfo.SystemInfoMetrics {
+		// the coordinators are requested on behalf of the client, each of them returns the delta of its metrics
+		var client *metricsinfo.MetricsClient
+		if metricsinfo.RequestWithCursor(ret) {
+			request, err := metricsinfo.RequestWithoutCursor(ret)
+			if err == nil {
+				client, err = node.metricsSnapshots.Client(ret)
+			}
+			if err != nil {
+				return &milvuspb.GetMetricsResponse{
+					Status: merr.Status(err),
+				}, nil
+			}
+			ctx = metricsinfo.WithMetricsClient(ctx, client)
+			req.Request = request
+		}
+
 		metrics, err := node.metricsCacheManager.GetSystemInfoMetrics()
 		if err != nil {
 			metrics, err = getSystemInfoMetrics(ctx, req, node)
@@ -4512,6 +4643,19 @@ func (node *Proxy) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsReque
 
 		node.metricsCacheManager.UpdateSystemInfoMetrics(metrics)
 
+		if client != nil && err == nil && merr.Ok(metrics.GetStatus()) {
+			delta, err := node.metricsSnapshots.Delta(client, ret, metrics.GetResponse())
+			if err != nil {
+				return &milvuspb.GetMetricsResponse{
+					Status: merr.Status(err),
+				}, nil
+			}
+			return &milvuspb.GetMetricsResponse{
+				Status:        metrics.GetStatus(),
+				Response:      delta,
+				ComponentName: metrics.GetComponentName(),
+			}, nil
+		}
 		return metrics, nil
 	}
 
@@ -5003,6 +5147,7 @@ func (node *Proxy) InvalidateCredentialCache(ctx context.Context, request *proxy
 	if globalMetaCache != nil {
 		globalMetaCache.RemoveCredential(username) // no need to return error, though credential may be not cached
 	}
+	globalAuthProviders.Invalidate(username)
 	log.Debug("complete to invalidate credential cache")
 
 	return merr.Success(), nil
@@ -6523,6 +6668,23 @@ func (node *Proxy) ImportV2(ctx context.Context, req *internalpb.ImportRequest)
 	isL0Import := importutilv2.IsL0Import(req.GetOptions())
 	hasPartitionKey := typeutil.HasPartitionKey(schema.CollectionSchema)
 
+	dedupMode, err := importutilv2.GetDedupMode(req.GetOptions())
+	if err != nil {
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	if dedupMode != importutilv2.DedupNone {
+		pkField, err := typeutil.GetPrimaryFieldSchema(schema.CollectionSchema)
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp, nil
+		}
+		if pkField.GetAutoID() {
+			resp.Status = merr.Status(merr.WrapErrImportFailed("dedup is not supported for collection with autoID primary key"))
+			return resp, nil
+		}
+	}
+
 	var partitionIDs []int64
 	if isBackup {
 		if req.GetPartitionName() == "" {
@@ -6612,12 +6774,26 @@ func (node *Proxy) ImportV2(ctx context.Context, req *internalpb.ImportRequest)
 			}
 		}
 	}
+	importSchema := schema.CollectionSchema
+	if hasPartitionKey {
+		hashFunction, err := getPartitionKeyHashFunction(ctx, req.GetDbName(), req.GetCollectionName())
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp, nil
+		}
+		// datanodes shall hash the partition keys of the imported rows by the same function as the inserts
+		if hashFunction != "" {
+			importSchema = proto.Clone(importSchema).(*schemapb.CollectionSchema)
+			importSchema.Properties = append(importSchema.Properties,
+				&commonpb.KeyValuePair{Key: common.PartitionKeyHashFunctionKey, Value: hashFunction})
+		}
+	}
 	importRequest := &internalpb.ImportRequestInternal{
 		CollectionID:   collectionID,
 		CollectionName: req.GetCollectionName(),
 		PartitionIDs:   partitionIDs,
 		ChannelNames:   channels,
-		Schema:         schema.CollectionSchema,
+		Schema:         importSchema,
 		Files:          req.GetFiles(),
 		Options:        req.GetOptions(),
 	}
@@ -6728,7 +6904,10 @@ func (node *Proxy) RegisterRestRouter(router gin.IRouter) {
 	router.GET(http.QCReplicaPath, getQueryComponentMetrics(node, metricsinfo.ReplicaKey))
 	router.GET(http.QCResourceGroupPath, getQueryComponentMetrics(node, metricsinfo.ResourceGroupKey))
 	router.GET(http.QCAllTasksPath, getQueryComponentMetrics(node, metricsinfo.AllTaskKey))
+	router.GET(http.QCRecoveryProgressPath, getQueryComponentMetrics(node, metricsinfo.RecoveryProgressKey))
 	router.GET(http.QCSegmentsPath, getQueryComponentMetrics(node, metricsinfo.SegmentKey))
+	router.GET(http.QCRowCountScrubPath, getQueryComponentMetrics(node, metricsinfo.RowCountScrubKey))
+	router.GET(http.QCDecisionJournalPath, getQueryComponentMetrics(node, metricsinfo.DecisionJournalKey))
 
 	// QueryNode requests that are forwarded from querycoord
 	router.GET(http.QNSegmentsPath, getQueryComponentMetrics(node, metricsinfo.SegmentKey))
@@ -6741,6 +6920,7 @@ func (node *Proxy) RegisterRestRouter(router gin.IRouter) {
 	router.GET(http.DCBuildIndexTasksPath, getDataComponentMetrics(node, metricsinfo.BuildIndexTaskKey))
 	router.GET(http.IndexListPath, getDataComponentMetrics(node, metricsinfo.IndexKey))
 	router.GET(http.DCSegmentsPath, getDataComponentMetrics(node, metricsinfo.SegmentKey))
+	router.GET(http.DCDecisionJournalPath, getDataComponentMetrics(node, metricsinfo.DecisionJournalKey))
 
 	// Datanode requests that are forwarded from datacoord
 	router.GET(http.DNSyncTasksPath, getDataComponentMetrics(node, metricsinfo.SyncTaskKey))
@@ -1847,6 +1847,7 @@ func TestRegisterRestRouter(t *testing.T) {
 		{path: mhttp.QCTargetPath, statusCode: http.StatusInternalServerError},
 		{path: mhttp.QCDistPath, statusCode: http.StatusInternalServerError},
 		{path: mhttp.QCAllTasksPath, statusCode: http.StatusInternalServerError},
+		{path: mhttp.QCRecoveryProgressPath, statusCode: http.StatusInternalServerError},
 		{path: mhttp.DNSyncTasksPath, statusCode: http.StatusInternalServerError},
 		{path: mhttp.DCCompactionTasksPath, statusCode: http.StatusInternalServerError},
 		{path: mhttp.DCImportTasksPath, statusCode: http.StatusInternalServerError},
@@ -1863,3 +1864,28 @@ func TestRegisterRestRouter(t *testing.T) {
 		})
 	}
 }
+
+func TestProxy_PreviewDelete(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	t.Run("not healthy", func(t *testing.T) {
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Abnormal)
+		resp, err := node.PreviewDelete(ctx, &proxypb.PreviewDeleteRequest{})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrServiceNotReady)
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		resp, err := node.PreviewDelete(ctx, &proxypb.PreviewDeleteRequest{
+			CollectionName: "test_preview_delete",
+			Expr:           "pk < 3",
+			Limit:          -1,
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+	})
+}
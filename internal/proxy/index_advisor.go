@@ -0,0 +1,158 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	// advisorMinRequests is the number of requests required before giving any advice.
+	advisorMinRequests = 100
+	// scalarIndexFilterRatio is the least ratio of requests filtering on an unindexed field to recommend a scalar index.
+	scalarIndexFilterRatio = 0.1
+	// partitionKeyFilterRatio is the least ratio of requests filtering on a field to recommend it as partition key.
+	partitionKeyFilterRatio = 0.8
+	// nprobeRatio is the ratio of nprobe to nlist, beyond which the IVF index is considered too coarse.
+	nprobeRatio = 0.25
+)
+
+const (
+	AdviceScalarIndex  = "scalar_index"
+	AdvicePartitionKey = "partition_key"
+	AdviceIndexParams  = "index_params"
+)
+
+// IndexAdvice is a recommendation on the indexes or schema of a collection derived from its query patterns.
+type IndexAdvice struct {
+	Kind       string `json:"kind"`
+	Field      string `json:"field"`
+	Suggestion string `json:"suggestion"`
+	Reason     string `json:"reason"`
+}
+
+// adviseIndexes recommends scalar indexes, partition key or vector index params changes
+// of the collection based on its observed query patterns.
+func adviseIndexes(schema *schemapb.CollectionSchema, indexes []*indexpb.IndexInfo, stats *QueryPatternStats) []*IndexAdvice {
+	advices := make([]*IndexAdvice, 0)
+	total := stats.SearchCount + stats.QueryCount
+	if total < advisorMinRequests {
+		return advices
+	}
+
+	indexedFields := make(map[int64]*indexpb.IndexInfo)
+	for _, index := range indexes {
+		indexedFields[index.GetFieldID()] = index
+	}
+	hasPartitionKey := typeutil.HasPartitionKey(schema)
+
+	// visit the most filtered fields first
+	filterFields := make([]int64, 0, len(stats.FilterFields))
+	for fieldID := range stats.FilterFields {
+		filterFields = append(filterFields, fieldID)
+	}
+	sort.Slice(filterFields, func(i, j int) bool {
+		return stats.FilterFields[filterFields[i]] > stats.FilterFields[filterFields[j]]
+	})
+
+	partitionKeyAdvised := false
+	for _, fieldID := range filterFields {
+		field := typeutil.GetField(schema, fieldID)
+		if field == nil || field.GetIsPrimaryKey() || typeutil.IsVectorType(field.GetDataType()) || typeutil.IsJSONType(field.GetDataType()) {
+			continue
+		}
+		ratio := float64(stats.FilterFields[fieldID]) / float64(total)
+
+		if _, ok := indexedFields[fieldID]; !ok && ratio >= scalarIndexFilterRatio {
+			advices = append(advices, &IndexAdvice{
+				Kind:       AdviceScalarIndex,
+				Field:      field.GetName(),
+				Suggestion: "create an INVERTED index on the field",
+				Reason:     fmt.Sprintf("%.0f%% of the requests filter on the unindexed field", ratio*100),
+			})
+		}
+
+		isKeyCandidate := field.GetDataType() == schemapb.DataType_Int64 || field.GetDataType() == schemapb.DataType_VarChar
+		if !hasPartitionKey && !partitionKeyAdvised && isKeyCandidate && ratio >= partitionKeyFilterRatio {
+			partitionKeyAdvised = true
+			advices = append(advices, &IndexAdvice{
+				Kind:       AdvicePartitionKey,
+				Field:      field.GetName(),
+				Suggestion: "use the field as partition key, which requires recreating the collection",
+				Reason:     fmt.Sprintf("%.0f%% of the requests filter on the field", ratio*100),
+			})
+		}
+	}
+
+	for fieldID, params := range stats.SearchParams {
+		index, ok := indexedFields[fieldID]
+		if !ok {
+			continue
+		}
+		if advice := adviseVectorIndexParams(schema, index, params); advice != nil {
+			advices = append(advices, advice)
+		}
+	}
+	return advices
+}
+
+// adviseVectorIndexParams checks whether the recall params the searches use are excessive for the index.
+func adviseVectorIndexParams(schema *schemapb.CollectionSchema, index *indexpb.IndexInfo, params map[string]int64) *IndexAdvice {
+	indexType, err := funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, index.GetIndexParams())
+	if err != nil || !strings.HasPrefix(indexType, "IVF") {
+		return nil
+	}
+	nlistStr, err := funcutil.GetAttrByKeyFromRepeatedKV("nlist", index.GetIndexParams())
+	if err != nil {
+		return nil
+	}
+	nlist, err := strconv.ParseFloat(nlistStr, 64)
+	if err != nil || nlist <= 0 {
+		return nil
+	}
+
+	// the most used nprobe of the searches
+	var nprobe float64
+	var maxCount int64
+	for param, count := range params {
+		value, ok := strings.CutPrefix(param, "nprobe=")
+		if !ok || count <= maxCount {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			nprobe, maxCount = parsed, count
+		}
+	}
+	if nprobe < nlist*nprobeRatio {
+		return nil
+	}
+	return &IndexAdvice{
+		Kind:       AdviceIndexParams,
+		Field:      typeutil.GetField(schema, index.GetFieldID()).GetName(),
+		Suggestion: fmt.Sprintf("rebuild the %s index with a larger nlist, or use a graph index such as HNSW", indexType),
+		Reason:     fmt.Sprintf("searches mostly probe %v of the %v clusters", nprobe, nlist),
+	}
+}
@@ -0,0 +1,275 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// The levels the insert is acknowledged at, from the fastest to the most durable.
+const (
+	// InsertAckWAL acknowledges the insert once written into the wal.
+	InsertAckWAL = "wal"
+	// InsertAckGrowing acknowledges the insert once searchable in the growing segments.
+	InsertAckGrowing = "growing"
+	// InsertAckFlush acknowledges the insert once synced into the object storage.
+	InsertAckFlush = "flush"
+
+	insertAckLevelKey = "ack_level"
+	insertAckErrorKey = "ack_error"
+
+	insertAckFlushCheckInterval = 200 * time.Millisecond
+)
+
+// getInsertAckLevel returns the ack level selected by the request header, proxy.insertAckLevel if not set.
+func getInsertAckLevel(ctx context.Context) (string, error) {
+	level := paramtable.Get().ProxyCfg.InsertAckLevel.GetValue()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		values := md[strings.ToLower(util.HeaderInsertAckLevel)]
+		if len(values) > 0 && values[0] != "" {
+			level = values[0]
+		}
+	}
+	switch level = strings.ToLower(level); level {
+	case InsertAckWAL, InsertAckGrowing, InsertAckFlush:
+		return level, nil
+	default:
+		return "", merr.WrapErrParameterInvalid("wal, growing or flush", level, "invalid insert ack level")
+	}
+}
+
+// SetInsertAck records the level the insert is acknowledged at, and the reason if the requested level is not reached.
+func SetInsertAck(status *commonpb.Status, level string, err error) {
+	if status == nil {
+		return
+	}
+	if status.ExtraInfo == nil {
+		status.ExtraInfo = make(map[string]string)
+	}
+	status.ExtraInfo[insertAckLevelKey] = level
+	if err != nil {
+		status.ExtraInfo[insertAckErrorKey] = err.Error()
+	}
+}
+
+// GetInsertAckLevel returns the level recorded by SetInsertAck, empty if absent.
+func GetInsertAckLevel(status *commonpb.Status) string {
+	return status.GetExtraInfo()[insertAckLevelKey]
+}
+
+// waitInsertAck waits for the insert or upsert written into the wal at ts to reach the level,
+// and returns the level reached, with the error if it's lower than the requested one.
+// The mutation is not failed on the error as the data is durable in the wal already,
+// retrying it would insert the entities twice.
+func (node *Proxy) waitInsertAck(ctx context.Context, dbName, collectionName, partitionName string, ts Timestamp, level string) (string, error) {
+	if level == InsertAckWAL {
+		return InsertAckWAL, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().ProxyCfg.InsertAckTimeout.GetAsDuration(time.Second))
+	defer cancel()
+
+	if level == InsertAckGrowing {
+		if err := node.waitInsertSearchable(ctx, dbName, collectionName, partitionName, ts); err != nil {
+			return InsertAckWAL, err
+		}
+		return InsertAckGrowing, nil
+	}
+	if err := node.waitInsertFlushed(ctx, dbName, collectionName, ts); err != nil {
+		return InsertAckWAL, err
+	}
+	return InsertAckFlush, nil
+}
+
+// waitInsertSearchable waits until the delegators consumed the wal to the insert timestamp,
+// by a query guaranteed at the timestamp.
+func (node *Proxy) waitInsertSearchable(ctx context.Context, dbName, collectionName, partitionName string, ts Timestamp) error {
+	req := &milvuspb.QueryRequest{
+		DbName:             dbName,
+		CollectionName:     collectionName,
+		GuaranteeTimestamp: ts,
+		ConsistencyLevel:   commonpb.ConsistencyLevel_Customized,
+		QueryParams: []*commonpb.KeyValuePair{
+			{Key: LimitKey, Value: "1"},
+		},
+	}
+	if partitionName != "" {
+		req.PartitionNames = []string{partitionName}
+	}
+	resp, err := node.Query(ctx, req)
+	return merr.CheckRPCCall(resp, err)
+}
+
+// waitInsertFlushed waits until the checkpoints of all the collection channels pass the insert timestamp,
+// which means the data is synced into the object storage by the regular sync, no flush is triggered.
+func (node *Proxy) waitInsertFlushed(ctx context.Context, dbName, collectionName string, ts Timestamp) error {
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, collectionName)
+	if err != nil {
+		return err
+	}
+	if err := node.checkpointWatcher.wait(ctx, collectionID, ts); err != nil {
+		log.Ctx(ctx).Warn("insert not synced before the ack timeout",
+			zap.String("collection", collectionName), zap.Uint64("ts", ts), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// getCollectionCheckpoint returns the min checkpoint of the collection channels.
+func (node *Proxy) getCollectionCheckpoint(ctx context.Context, collectionID int64) (Timestamp, error) {
+	resp, err := node.dataCoord.InspectChannelCheckpoints(ctx, &datapb.InspectChannelCheckpointsRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return 0, err
+	}
+	if len(resp.GetCheckpoints()) == 0 {
+		return 0, merr.WrapErrCollectionNotFound(collectionID, "no channel of the collection")
+	}
+	checkpoint := resp.GetCheckpoints()[0].GetCheckpoint().GetTimestamp()
+	for _, info := range resp.GetCheckpoints()[1:] {
+		checkpoint = min(checkpoint, info.GetCheckpoint().GetTimestamp())
+	}
+	return checkpoint, nil
+}
+
+// checkpointWatcher polls the checkpoints of the collections the inserts are waiting for.
+// The inserts waiting on the same collection share one poll an interval, which stops once none is waiting,
+// rather than each insert polling the flush state on its own.
+type checkpointWatcher struct {
+	interval time.Duration
+	fetch    func(ctx context.Context, collectionID int64) (Timestamp, error)
+
+	mu      sync.Mutex
+	watches map[int64]*checkpointWatch
+}
+
+// checkpointWatch is the latest checkpoint of a collection polled.
+type checkpointWatch struct {
+	cancel  context.CancelFunc
+	waiters int // protected by the mutex of the watcher
+
+	mu         sync.Mutex
+	checkpoint Timestamp
+	err        error
+	updated    chan struct{} // closed and renewed on every poll
+}
+
+func newCheckpointWatcher(interval time.Duration, fetch func(ctx context.Context, collectionID int64) (Timestamp, error)) *checkpointWatcher {
+	return &checkpointWatcher{
+		interval: interval,
+		fetch:    fetch,
+		watches:  make(map[int64]*checkpointWatch),
+	}
+}
+
+// wait waits until the checkpoint of the collection passes ts, the error of the last poll
+// is returned if it doesn't before the context is done.
+func (w *checkpointWatcher) wait(ctx context.Context, collectionID int64, ts Timestamp) error {
+	watch := w.acquire(collectionID)
+	defer w.release(collectionID, watch)
+	for {
+		checkpoint, updated, err := watch.get()
+		if err == nil && checkpoint >= ts {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+func (w *checkpointWatcher) acquire(collectionID int64) *checkpointWatch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	watch, ok := w.watches[collectionID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		watch = &checkpointWatch{
+			cancel:  cancel,
+			updated: make(chan struct{}),
+		}
+		w.watches[collectionID] = watch
+		go w.poll(ctx, collectionID, watch)
+	}
+	watch.waiters++
+	return watch
+}
+
+func (w *checkpointWatcher) release(collectionID int64, watch *checkpointWatch) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	watch.waiters--
+	if watch.waiters == 0 {
+		delete(w.watches, collectionID)
+		watch.cancel()
+	}
+}
+
+func (w *checkpointWatcher) poll(ctx context.Context, collectionID int64, watch *checkpointWatch) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		checkpoint, err := w.fetch(ctx, collectionID)
+		if ctx.Err() != nil {
+			return
+		}
+		watch.update(checkpoint, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (watch *checkpointWatch) get() (Timestamp, <-chan struct{}, error) {
+	watch.mu.Lock()
+	defer watch.mu.Unlock()
+	return watch.checkpoint, watch.updated, watch.err
+}
+
+func (watch *checkpointWatch) update(checkpoint Timestamp, err error) {
+	watch.mu.Lock()
+	defer watch.mu.Unlock()
+	if err == nil {
+		watch.checkpoint = checkpoint
+	}
+	watch.err = err
+	close(watch.updated)
+	watch.updated = make(chan struct{})
+}
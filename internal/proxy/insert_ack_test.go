@@ -0,0 +1,165 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestGetInsertAckLevel(t *testing.T) {
+	paramtable.Init()
+
+	level, err := getInsertAckLevel(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, InsertAckWAL, level)
+
+	key := paramtable.Get().ProxyCfg.InsertAckLevel.Key
+	paramtable.Get().Save(key, InsertAckGrowing)
+	defer paramtable.Get().Reset(key)
+	level, err = getInsertAckLevel(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, InsertAckGrowing, level)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderInsertAckLevel, "Flush"))
+	level, err = getInsertAckLevel(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, InsertAckFlush, level)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderInsertAckLevel, "disk"))
+	_, err = getInsertAckLevel(ctx)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestSetInsertAck(t *testing.T) {
+	status := merr.Success()
+	SetInsertAck(status, InsertAckFlush, nil)
+	assert.Equal(t, InsertAckFlush, GetInsertAckLevel(status))
+	assert.NotContains(t, status.GetExtraInfo(), insertAckErrorKey)
+
+	SetInsertAck(status, InsertAckWAL, errors.New("timeout"))
+	assert.Equal(t, InsertAckWAL, GetInsertAckLevel(status))
+	assert.Equal(t, "timeout", status.GetExtraInfo()[insertAckErrorKey])
+
+	SetInsertAck(nil, InsertAckWAL, nil)
+	assert.Equal(t, "", GetInsertAckLevel(nil))
+}
+
+func TestWaitInsertAckWAL(t *testing.T) {
+	node := &Proxy{}
+	level, err := node.waitInsertAck(context.Background(), "", "", "", 0, InsertAckWAL)
+	assert.NoError(t, err)
+	assert.Equal(t, InsertAckWAL, level)
+}
+
+func TestWaitInsertFlushed(t *testing.T) {
+	cacheBak := globalMetaCache
+	defer func() { globalMetaCache = cacheBak }()
+	cache := NewMockCache(t)
+	cache.EXPECT().GetCollectionID(mock.Anything, mock.Anything, mock.Anything).Return(UniqueID(1), nil)
+	globalMetaCache = cache
+
+	dc := mocks.NewMockDataCoordClient(t)
+	node := &Proxy{dataCoord: dc}
+	node.checkpointWatcher = newCheckpointWatcher(time.Millisecond, node.getCollectionCheckpoint)
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	// no flush is triggered, the insert waits until the channel checkpoints pass its timestamp
+	ts := uint64(100)
+	calls := atomic.NewUint64(0)
+	dc.EXPECT().InspectChannelCheckpoints(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *datapb.InspectChannelCheckpointsRequest, opts ...grpc.CallOption) (*datapb.InspectChannelCheckpointsResponse, error) {
+			assert.Equal(t, int64(1), req.GetCollectionID())
+			return &datapb.InspectChannelCheckpointsResponse{
+				Status: merr.Success(),
+				Checkpoints: []*datapb.ChannelCheckpointInfo{
+					{Channel: "ch1", Checkpoint: &msgpb.MsgPosition{Timestamp: calls.Inc() * 60}},
+					{Channel: "ch2", Checkpoint: &msgpb.MsgPosition{Timestamp: 200}},
+				},
+			}, nil
+		})
+
+	level, err := node.waitInsertAck(context.Background(), "db", "collection", "", ts, InsertAckFlush)
+	assert.NoError(t, err)
+	assert.Equal(t, InsertAckFlush, level)
+	assert.GreaterOrEqual(t, calls.Load(), uint64(2))
+	// the poll stops once no insert is waiting
+	assert.Eventually(t, func() bool {
+		node.checkpointWatcher.mu.Lock()
+		defer node.checkpointWatcher.mu.Unlock()
+		return len(node.checkpointWatcher.watches) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestCheckpointWatcher(t *testing.T) {
+	var mu sync.Mutex
+	checkpoint := Timestamp(0)
+	polls := 0
+	watcher := newCheckpointWatcher(10*time.Millisecond, func(ctx context.Context, collectionID int64) (Timestamp, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		if checkpoint == 0 {
+			return 0, errors.New("mock")
+		}
+		return checkpoint, nil
+	})
+
+	// the waiters share the poll of the collection
+	ctx := context.Background()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, watcher.wait(ctx, 1, Timestamp(100+i)))
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	checkpoint = 200
+	mu.Unlock()
+	wg.Wait()
+	mu.Lock()
+	assert.Less(t, polls, 20)
+	mu.Unlock()
+
+	// the error of the last poll is returned on timeout
+	mu.Lock()
+	checkpoint = 0
+	mu.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorContains(t, watcher.wait(ctx, 1, 100), "mock")
+}
@@ -17,16 +17,19 @@ package proxy
 
 import (
 	"context"
+	"sort"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/retry"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -43,6 +46,8 @@ type ChannelWorkload struct {
 	nq             int64
 	exec           executeFunc
 	retryTimes     uint
+	// route to the delegators of the replicas in the resource group if any available
+	preferredResourceGroup string
 }
 
 type CollectionWorkLoad struct {
@@ -51,6 +56,8 @@ type CollectionWorkLoad struct {
 	collectionID   int64
 	nq             int64
 	exec           executeFunc
+	// route to the delegators of the replicas in the resource group if any available
+	preferredResourceGroup string
 }
 
 type LBPolicy interface {
@@ -127,6 +134,15 @@ func (lb *LBPolicyImpl) selectNode(ctx context.Context, balancer LBBalancer, wor
 				ret[node.nodeID] = node
 			}
 		}
+		// fall back to the other replicas if no delegator available in the preferred resource group
+		if workload.preferredResourceGroup != "" {
+			preferred := lo.PickBy(ret, func(_ int64, node nodeInfo) bool {
+				return node.resourceGroup == workload.preferredResourceGroup
+			})
+			if len(preferred) > 0 {
+				return preferred
+			}
+		}
 		return ret
 	}
 
@@ -244,14 +260,15 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 		}
 		wg.Go(func() error {
 			return lb.ExecuteWithRetry(ctx, ChannelWorkload{
-				db:             workload.db,
-				collectionName: workload.collectionName,
-				collectionID:   workload.collectionID,
-				channel:        channel,
-				shardLeaders:   nodes,
-				nq:             workload.nq,
-				exec:           workload.exec,
-				retryTimes:     uint(channelRetryTimes),
+				db:                     workload.db,
+				collectionName:         workload.collectionName,
+				collectionID:           workload.collectionID,
+				channel:                channel,
+				shardLeaders:           nodes,
+				nq:                     workload.nq,
+				exec:                   workload.exec,
+				retryTimes:             uint(channelRetryTimes),
+				preferredResourceGroup: workload.preferredResourceGroup,
 			})
 		})
 	}
@@ -259,6 +276,52 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 	return wg.Wait()
 }
 
+// getPreferredResourceGroup returns the resource group which the request prefers to be routed to,
+// e.g. the one in the same availability zone as the client, empty if not specified.
+func getPreferredResourceGroup(params []*commonpb.KeyValuePair) string {
+	rg, _ := funcutil.GetAttrByKeyFromRepeatedKV(PreferredResourceGroupKey, params)
+	return rg
+}
+
+// replicaTopology is the hint of a replica serving the collection, returned by DescribeCollection,
+// so that the clients can prefer the resource group close to them.
+type replicaTopology struct {
+	ReplicaID     int64  `json:"replica_id"`
+	ResourceGroup string `json:"resource_group"`
+	// the shard leader node of each channel
+	ShardLeaders map[string]int64 `json:"shard_leaders"`
+}
+
+// getReplicaTopology groups the shard leaders by the replicas, ordered by the replica id,
+// the leaders returned by the legacy querycoord without replica ids are skipped.
+func getReplicaTopology(shards map[string][]nodeInfo) []replicaTopology {
+	replicas := make(map[int64]*replicaTopology)
+	for channel, leaders := range shards {
+		for _, leader := range leaders {
+			if leader.replicaID == 0 {
+				continue
+			}
+			replica, ok := replicas[leader.replicaID]
+			if !ok {
+				replica = &replicaTopology{
+					ReplicaID:     leader.replicaID,
+					ResourceGroup: leader.resourceGroup,
+					ShardLeaders:  make(map[string]int64),
+				}
+				replicas[leader.replicaID] = replica
+			}
+			replica.ShardLeaders[channel] = leader.nodeID
+		}
+	}
+	ret := lo.MapToSlice(replicas, func(_ int64, replica *replicaTopology) replicaTopology {
+		return *replica
+	})
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ReplicaID < ret[j].ReplicaID
+	})
+	return ret
+}
+
 func (lb *LBPolicyImpl) UpdateCostMetrics(node int64, cost *internalpb.CostAggregation) {
 	lb.getBalancer().UpdateCostMetrics(node, cost)
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/pingcap/log"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/atomic"
@@ -248,6 +249,43 @@ func (s *LBPolicySuite) TestSelectNode() {
 	s.ErrorIs(err, merr.ErrServiceUnavailable)
 }
 
+func (s *LBPolicySuite) TestSelectNodeWithPreferredResourceGroup() {
+	ctx := context.Background()
+	nodes := lo.Map(s.nodes, func(node nodeInfo, i int) nodeInfo {
+		node.resourceGroup = lo.Ternary(i < 2, "rg1", "rg2")
+		return node
+	})
+	workload := ChannelWorkload{
+		db:                     dbName,
+		collectionName:         s.collectionName,
+		collectionID:           s.collectionID,
+		channel:                s.channels[0],
+		shardLeaders:           nodes,
+		nq:                     1,
+		preferredResourceGroup: "rg1",
+	}
+
+	s.lbBalancer.EXPECT().RegisterNodeInfo(mock.Anything)
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, availableNodes []int64, nq int64) (int64, error) {
+			s.ElementsMatch([]int64{1, 2}, availableNodes)
+			return availableNodes[0], nil
+		}).Times(1)
+	targetNode, err := s.lbPolicy.selectNode(ctx, s.lbBalancer, workload, typeutil.NewUniqueSet())
+	s.NoError(err)
+	s.Equal("rg1", targetNode.resourceGroup)
+
+	// fall back to the other resource groups if all nodes in the preferred one excluded
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, availableNodes []int64, nq int64) (int64, error) {
+			s.ElementsMatch([]int64{3, 4, 5}, availableNodes)
+			return availableNodes[0], nil
+		}).Times(1)
+	targetNode, err = s.lbPolicy.selectNode(ctx, s.lbBalancer, workload, typeutil.NewUniqueSet(1, 2))
+	s.NoError(err)
+	s.Equal("rg2", targetNode.resourceGroup)
+}
+
 func (s *LBPolicySuite) TestExecuteWithRetry() {
 	ctx := context.Background()
 
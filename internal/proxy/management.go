@@ -17,18 +17,33 @@
 package proxy
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"strconv"
 	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
+	"gopkg.in/yaml.v3"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	management "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 // this file contains proxy management restful API handler
@@ -36,50 +51,118 @@ var mgrRouteRegisterOnce sync.Once
 
 func RegisterMgrRoute(proxy *Proxy) {
 	mgrRouteRegisterOnce.Do(func() {
-		management.Register(&management.Handler{
+		dataCoordRouter := management.NewManagementRouter(typeutil.DataCoordRole)
+		queryCoordRouter := management.NewManagementRouter(typeutil.QueryCoordRole)
+		proxyRouter := management.NewManagementRouter(typeutil.ProxyRole)
+
+		dataCoordRouter.Register(&management.Handler{
 			Path:        management.RouteGcPause,
 			HandlerFunc: proxy.PauseDatacoordGC,
 		})
-		management.Register(&management.Handler{
+		dataCoordRouter.Register(&management.Handler{
 			Path:        management.RouteGcResume,
 			HandlerFunc: proxy.ResumeDatacoordGC,
 		})
-		management.Register(&management.Handler{
+		dataCoordRouter.Register(&management.Handler{
+			Path:        management.RouteSegmentStats,
+			HandlerFunc: proxy.ShowSegmentStats,
+		})
+		dataCoordRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteCreateBackup,
+			HandlerFunc: proxy.CreateBackup,
+		}, nil)
+		dataCoordRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteRestoreBackup,
+			HandlerFunc: proxy.RestoreBackup,
+		}, nil)
+		dataCoordRouter.Register(&management.Handler{
+			Path:        management.RouteListBackups,
+			HandlerFunc: proxy.ListBackups,
+		})
+		dataCoordRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteDropBackup,
+			HandlerFunc: proxy.DropBackup,
+		}, nil)
+		dataCoordRouter.Register(&management.Handler{
+			Path:        management.RouteInspectChannelCheckpoints,
+			HandlerFunc: proxy.InspectChannelCheckpoints,
+		})
+		dataCoordRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteRepairChannelCheckpoint,
+			HandlerFunc: proxy.RepairChannelCheckpoint,
+		}, isRepairCheckOnly)
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteListQueryNode,
 			HandlerFunc: proxy.ListQueryNode,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteGetQueryNodeDistribution,
 			HandlerFunc: proxy.GetQueryNodeDistribution,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteSuspendQueryCoordBalance,
 			HandlerFunc: proxy.SuspendQueryCoordBalance,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteResumeQueryCoordBalance,
 			HandlerFunc: proxy.ResumeQueryCoordBalance,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteSuspendQueryNode,
 			HandlerFunc: proxy.SuspendQueryNode,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteResumeQueryNode,
 			HandlerFunc: proxy.ResumeQueryNode,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteTransferSegment,
 			HandlerFunc: proxy.TransferSegment,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteTransferChannel,
 			HandlerFunc: proxy.TransferChannel,
 		})
-		management.Register(&management.Handler{
+		queryCoordRouter.Register(&management.Handler{
 			Path:        management.RouteCheckQueryNodeDistribution,
 			HandlerFunc: proxy.CheckQueryNodeDistribution,
 		})
+		queryCoordRouter.Register(&management.Handler{
+			Path:        management.RouteResourceGroupDatabases,
+			HandlerFunc: proxy.GetResourceGroupDatabases,
+		})
+		proxyRouter.Register(&management.Handler{
+			Path:        management.RouteIndexAdvise,
+			HandlerFunc: proxy.AdviseIndex,
+		})
+		proxyRouter.Register(&management.Handler{
+			Path:        management.RouteUsage,
+			HandlerFunc: proxy.GetUsage,
+		})
+		proxyRouter.Register(&management.Handler{
+			Path:        management.RouteExportCollectionSpec,
+			HandlerFunc: proxy.ExportCollectionSpec,
+		})
+		proxyRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteApplyCollectionSpec,
+			HandlerFunc: proxy.ApplyCollectionSpec,
+		}, management.IsDryRun)
+		proxyRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteBatchCreateCollections,
+			HandlerFunc: proxy.BatchCreateCollections,
+		}, nil, http.MethodPost)
+		proxyRouter.Register(&management.Handler{
+			Path:        management.RouteDeleteProgress,
+			HandlerFunc: proxy.ListDeleteProgress,
+		})
+		proxyRouter.RegisterMutating(&management.Handler{
+			Path:        management.RouteDedupVectors,
+			HandlerFunc: proxy.DedupVectors,
+		}, isVectorDedupDryRun, http.MethodPost)
+		proxyRouter.Register(&management.Handler{
+			Path:        management.RouteDedupVectorsJob,
+			HandlerFunc: proxy.GetDedupVectorsJob,
+		})
 	})
 }
 
@@ -126,6 +209,402 @@ func (node *Proxy) ResumeDatacoordGC(w http.ResponseWriter, req *http.Request) {
 	w.Write([]byte(`{"msg": "OK"}`))
 }
 
+// SegmentStats is the statistics of one segment, used to analyze data skew and small segments.
+type SegmentStats struct {
+	SegmentID    int64    `json:"segment_id"`
+	PartitionID  int64    `json:"partition_id"`
+	State        string   `json:"state"`
+	Level        string   `json:"level"`
+	NumRows      int64    `json:"num_rows"`
+	DeletedRows  int64    `json:"deleted_rows"`
+	DeletedRatio float64  `json:"deleted_ratio"`
+	MemorySize   int64    `json:"memory_size"`
+	LogSize      int64    `json:"log_size"`
+	IndexTypes   []string `json:"index_types"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+func buildSegmentStats(info *datapb.SegmentInfo, indexInfo *indexpb.SegmentInfo) *SegmentStats {
+	stats := &SegmentStats{
+		SegmentID:   info.GetID(),
+		PartitionID: info.GetPartitionID(),
+		State:       info.GetState().String(),
+		Level:       info.GetLevel().String(),
+		NumRows:     info.GetNumOfRows(),
+		IndexTypes:  make([]string, 0),
+	}
+	for _, fieldBinlog := range info.GetBinlogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			stats.MemorySize += binlog.GetMemorySize()
+			stats.LogSize += binlog.GetLogSize()
+		}
+	}
+	for _, fieldBinlog := range info.GetDeltalogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			stats.DeletedRows += binlog.GetEntriesNum()
+			stats.MemorySize += binlog.GetMemorySize()
+			stats.LogSize += binlog.GetLogSize()
+		}
+	}
+	if stats.NumRows > 0 {
+		stats.DeletedRatio = float64(stats.DeletedRows) / float64(stats.NumRows)
+	}
+	for _, index := range indexInfo.GetIndexInfos() {
+		indexType, err := funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, index.GetIndexParams())
+		if err == nil {
+			stats.IndexTypes = append(stats.IndexTypes, indexType)
+		}
+	}
+	if ts := info.GetStartPosition().GetTimestamp(); ts > 0 {
+		stats.CreatedAt = tsoutil.PhysicalTime(ts).Format(time.RFC3339)
+	}
+	return stats
+}
+
+// ShowSegmentStats lists per segment statistics of a collection, optionally filtered by partition.
+func (node *Proxy) ShowSegmentStats(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+		return
+	}
+	// -1 means all partitions
+	partitionID := int64(-1)
+	if req.FormValue("partition_id") != "" {
+		partitionID, err = strconv.ParseInt(req.FormValue("partition_id"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+			return
+		}
+	}
+
+	segmentsResp, err := node.dataCoord.GetSegmentsByStates(req.Context(), &datapb.GetSegmentsByStatesRequest{
+		CollectionID: collectionID,
+		PartitionID:  partitionID,
+		States:       []commonpb.SegmentState{commonpb.SegmentState_Growing, commonpb.SegmentState_Sealed, commonpb.SegmentState_Flushing, commonpb.SegmentState_Flushed},
+	})
+	if err = merr.CheckRPCCall(segmentsResp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+		return
+	}
+
+	stats := make([]*SegmentStats, 0, len(segmentsResp.GetSegments()))
+	if len(segmentsResp.GetSegments()) > 0 {
+		infoResp, err := node.dataCoord.GetSegmentInfo(req.Context(), &datapb.GetSegmentInfoRequest{
+			Base:       commonpbutil.NewMsgBase(),
+			SegmentIDs: segmentsResp.GetSegments(),
+		})
+		if err = merr.CheckRPCCall(infoResp, err); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+			return
+		}
+
+		indexResp, err := node.dataCoord.GetIndexInfos(req.Context(), &indexpb.GetIndexInfoRequest{
+			CollectionID: collectionID,
+			SegmentIDs:   segmentsResp.GetSegments(),
+		})
+		if err = merr.CheckRPCCall(indexResp, err); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+			return
+		}
+
+		for _, info := range infoResp.GetInfos() {
+			stats = append(stats, buildSegmentStats(info, indexResp.GetSegmentInfo()[info.GetID()]))
+		}
+	}
+
+	bytes, err := json.Marshal(stats)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to show segment stats, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// CreateBackup snapshots the persisted data of a collection as a named backup.
+// It's refused by the router unless the management auth is enabled, as are restoring and dropping a backup.
+func (node *Proxy) CreateBackup(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to create backup, %s"}`, err.Error())))
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to create backup, %s"}`, err.Error())))
+		return
+	}
+
+	resp, err := node.dataCoord.CreateBackup(req.Context(), &datapb.CreateBackupRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		Name:         req.FormValue("name"),
+		CollectionID: collectionID,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to create backup, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(resp.GetBackup())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to create backup, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// RestoreBackup imports the data of a backup into the given collection, which must not be loaded.
+func (node *Proxy) RestoreBackup(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to restore backup, %s"}`, err.Error())))
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to restore backup, %s"}`, err.Error())))
+		return
+	}
+
+	_, _, err = getCollectionProgress(req.Context(), node.queryCoord, commonpbutil.NewMsgBase(), collectionID)
+	if err == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "failed to restore backup, the collection is loaded, release it first"}`))
+		return
+	}
+	if !errors.Is(err, merr.ErrCollectionNotLoaded) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to restore backup, %s"}`, err.Error())))
+		return
+	}
+
+	resp, err := node.dataCoord.RestoreBackup(req.Context(), &datapb.RestoreBackupRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		Name:         req.FormValue("name"),
+		CollectionID: collectionID,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to restore backup, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(map[string]any{"msg": "OK", "job_ids": resp.GetJobIDs()})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to restore backup, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// ListBackups lists the backups, optionally filtered by collection.
+func (node *Proxy) ListBackups(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list backups, %s"}`, err.Error())))
+		return
+	}
+
+	// 0 means all collections
+	collectionID := int64(0)
+	if req.FormValue("collection_id") != "" {
+		collectionID, err = strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list backups, %s"}`, err.Error())))
+			return
+		}
+	}
+
+	resp, err := node.dataCoord.ListBackups(req.Context(), &datapb.ListBackupsRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		CollectionID: collectionID,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list backups, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(resp.GetBackups())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list backups, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// DropBackup drops a backup, the segments only referenced by it could be garbage collected since then.
+func (node *Proxy) DropBackup(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to drop backup, %s"}`, err.Error())))
+		return
+	}
+
+	resp, err := node.dataCoord.DropBackup(req.Context(), &datapb.DropBackupRequest{
+		Base: commonpbutil.NewMsgBase(),
+		Name: req.FormValue("name"),
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to drop backup, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
+// InspectChannelCheckpoints shows the checkpoints of the channels of a collection with their lag and consistency issues.
+func (node *Proxy) InspectChannelCheckpoints(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to inspect channel checkpoints, %s"}`, err.Error())))
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to inspect channel checkpoints, %s"}`, err.Error())))
+		return
+	}
+
+	resp, err := node.dataCoord.InspectChannelCheckpoints(req.Context(), &datapb.InspectChannelCheckpointsRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		CollectionID: collectionID,
+		Channel:      req.FormValue("channel"),
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to inspect channel checkpoints, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(resp.GetCheckpoints())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to inspect channel checkpoints, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// RepairChannelCheckpoint resets the checkpoint of a channel to the seal position of its flushed segments,
+// or sets it to the given position. The change is only checked unless confirm=true is set,
+// and force=true is required to apply a position with consistency issues.
+// Applying the change is refused by the router unless the management auth is enabled.
+func (node *Proxy) RepairChannelCheckpoint(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to repair channel checkpoint, %s"}`, err.Error())))
+		return
+	}
+
+	request, err := parseRepairChannelCheckpointRequest(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to repair channel checkpoint, %s"}`, err.Error())))
+		return
+	}
+
+	resp, err := node.dataCoord.RepairChannelCheckpoint(req.Context(), request)
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to repair channel checkpoint, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(map[string]any{
+		"applied":        resp.GetApplied(),
+		"issues":         resp.GetIssues(),
+		"old_checkpoint": resp.GetOldCheckpoint(),
+		"new_checkpoint": resp.GetNewCheckpoint(),
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to repair channel checkpoint, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// isRepairCheckOnly returns whether the repair request only checks the change without confirm=true.
+func isRepairCheckOnly(req *http.Request) bool {
+	return req.FormValue("confirm") != "true"
+}
+
+func parseRepairChannelCheckpointRequest(req *http.Request) (*datapb.RepairChannelCheckpointRequest, error) {
+	collectionID, err := strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	request := &datapb.RepairChannelCheckpointRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		CollectionID: collectionID,
+		Channel:      req.FormValue("channel"),
+		Confirm:      !isRepairCheckOnly(req),
+		Force:        req.FormValue("force") == "true",
+	}
+
+	switch req.FormValue("action") {
+	case "reset_to_flushed":
+		request.Action = datapb.ChannelCheckpointRepairAction_ResetToFlushed
+	case "set_position":
+		request.Action = datapb.ChannelCheckpointRepairAction_SetPosition
+		// the msg id is base64 encoded, as the checkpoints are shown by the inspect api
+		msgID, err := base64.StdEncoding.DecodeString(req.FormValue("msg_id"))
+		if err != nil {
+			return nil, err
+		}
+		timestamp, err := strconv.ParseUint(req.FormValue("timestamp"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		request.Position = &msgpb.MsgPosition{
+			ChannelName: req.FormValue("channel"),
+			MsgID:       msgID,
+			Timestamp:   timestamp,
+		}
+	default:
+		return nil, fmt.Errorf("invalid action %q, should be reset_to_flushed or set_position", req.FormValue("action"))
+	}
+	return request, nil
+}
+
 func (node *Proxy) ListQueryNode(w http.ResponseWriter, req *http.Request) {
 	resp, err := node.queryCoord.ListQueryNode(req.Context(), &querypb.ListQueryNodeRequest{
 		Base: commonpbutil.NewMsgBase(),
@@ -484,3 +963,402 @@ func (node *Proxy) CheckQueryNodeDistribution(w http.ResponseWriter, req *http.R
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(`{"msg": "OK"}`))
 }
+
+// ResourceGroupDatabases is the databases bound to a resource group.
+type ResourceGroupDatabases struct {
+	ResourceGroup  string   `json:"resource_group"`
+	BoundDatabases []string `json:"bound_databases"`
+}
+
+// GetResourceGroupDatabases returns the databases bound to the resource group,
+// which DescribeResourceGroup of milvus-proto can't carry.
+func (node *Proxy) GetResourceGroupDatabases(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get databases of resource group, %s"}`, err.Error())))
+		return
+	}
+
+	rgName := req.FormValue("resource_group")
+	if rgName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "failed to get databases of resource group, resource_group is required"}`))
+		return
+	}
+
+	resp, err := node.queryCoord.DescribeResourceGroup(req.Context(), &querypb.DescribeResourceGroupRequest{
+		Base:          commonpbutil.NewMsgBase(),
+		ResourceGroup: rgName,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get databases of resource group, %s"}`, err.Error())))
+		return
+	}
+
+	databases := resp.GetResourceGroup().GetBoundDatabases()
+	if databases == nil {
+		databases = []string{}
+	}
+	bytes, err := json.Marshal(&ResourceGroupDatabases{
+		ResourceGroup:  rgName,
+		BoundDatabases: databases,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get databases of resource group, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// IndexAdviseResult is the observed query patterns of a collection and the advices derived from them.
+type IndexAdviseResult struct {
+	Stats   *QueryPatternStats `json:"stats"`
+	Advices []*IndexAdvice     `json:"advices"`
+}
+
+// AdviseIndex recommends scalar indexes, partition key or index params changes of a collection
+// based on the query patterns persisted by all proxies.
+func (node *Proxy) AdviseIndex(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to advise index, %s"}`, err.Error())))
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(req.FormValue("collection_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to advise index, %s"}`, err.Error())))
+		return
+	}
+
+	if node.queryStatsKV == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"msg": "failed to advise index, query stats storage is not available"}`))
+		return
+	}
+
+	// persist the latest stats of this proxy first
+	globalQueryStats.flush(req.Context(), node.queryStatsKV)
+	stats, err := loadQueryPatternStats(req.Context(), node.queryStatsKV, collectionID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to advise index, %s"}`, err.Error())))
+		return
+	}
+
+	collResp, err := node.rootCoord.DescribeCollection(req.Context(), &milvuspb.DescribeCollectionRequest{
+		Base:         commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection)),
+		CollectionID: collectionID,
+	})
+	if err = merr.CheckRPCCall(collResp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to advise index, %s"}`, err.Error())))
+		return
+	}
+
+	indexResp, err := node.dataCoord.DescribeIndex(req.Context(), &indexpb.DescribeIndexRequest{
+		CollectionID: collectionID,
+	})
+	if err = merr.CheckRPCCall(indexResp, err); err != nil && !errors.Is(err, merr.ErrIndexNotFound) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to advise index, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(&IndexAdviseResult{
+		Stats:   stats,
+		Advices: adviseIndexes(collResp.GetSchema(), indexResp.GetIndexInfos(), stats),
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to advise index, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// GetUsage returns the usage records persisted by all proxies, merged per user and window.
+// Optional form values: user, and start/end in unix seconds filtering the window start within [start, end).
+func (node *Proxy) GetUsage(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get usage, %s"}`, err.Error())))
+		return
+	}
+
+	start, end := int64(0), int64(math.MaxInt64)
+	if value := req.FormValue("start"); value != "" {
+		if start, err = strconv.ParseInt(value, 10, 64); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get usage, %s"}`, err.Error())))
+			return
+		}
+	}
+	if value := req.FormValue("end"); value != "" {
+		if end, err = strconv.ParseInt(value, 10, 64); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get usage, %s"}`, err.Error())))
+			return
+		}
+	}
+
+	if node.queryStatsKV == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"msg": "failed to get usage, usage storage is not available"}`))
+		return
+	}
+
+	// persist the latest usage of this proxy first, export is left to the flush loop
+	globalUsageMeter.flush(req.Context(), node.queryStatsKV, nil)
+	records, err := loadUsageRecords(req.Context(), node.queryStatsKV, req.FormValue("user"), start, end)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get usage, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(records)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get usage, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// ExportCollectionSpec exports the schema, indexes, properties and load config of a collection as a versioned spec.
+// Form values: db_name, collection_name, and format which is json by default or yaml.
+func (node *Proxy) ExportCollectionSpec(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection spec, %s"}`, err.Error())))
+		return
+	}
+
+	collectionName := req.FormValue("collection_name")
+	if collectionName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "failed to export collection spec, collection_name is required"}`))
+		return
+	}
+
+	spec, err := node.exportCollectionSpec(req.Context(), req.FormValue("db_name"), collectionName)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection spec, %s"}`, err.Error())))
+		return
+	}
+
+	var bytes []byte
+	if req.FormValue("format") == "yaml" {
+		bytes, err = yaml.Marshal(spec)
+	} else {
+		bytes, err = json.Marshal(spec)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection spec, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// ApplyCollectionSpec creates the collection in the spec of the request body, or alters the existing one to match the spec.
+// Form values: format of the spec which is json by default or yaml, and dry_run to return the planned actions only.
+// Applying the spec is refused by the router unless the management auth is enabled.
+func (node *Proxy) ApplyCollectionSpec(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+		return
+	}
+
+	dryRun := false
+	if value := req.FormValue("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+			return
+		}
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+		return
+	}
+	spec := &CollectionSpec{}
+	if req.FormValue("format") == "yaml" {
+		err = yaml.Unmarshal(body, spec)
+	} else {
+		err = json.Unmarshal(body, spec)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+		return
+	}
+	if err := spec.validate(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+		return
+	}
+
+	result, err := node.applyCollectionSpec(req.Context(), spec, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to apply collection spec, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// BatchCreateCollections creates the collections and the partitions of a database in the batch spec of the request body
+// atomically, then creates the indexes and loads the collections of the collection specs.
+// Form values: format of the spec which is json by default or yaml.
+// It's refused by the router unless the management auth is enabled.
+func (node *Proxy) BatchCreateCollections(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to batch create collections, %s"}`, err.Error())))
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to batch create collections, %s"}`, err.Error())))
+		return
+	}
+	batch := &BatchCreateSpec{}
+	if req.FormValue("format") == "yaml" {
+		err = yaml.Unmarshal(body, batch)
+	} else {
+		err = json.Unmarshal(body, batch)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to batch create collections, %s"}`, err.Error())))
+		return
+	}
+
+	result, err := node.batchCreate(req.Context(), batch)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, merr.ErrParameterInvalid) || errors.Is(err, merr.ErrParameterMissing) {
+			status = http.StatusBadRequest
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to batch create collections, %s"}`, err.Error())))
+		return
+	}
+
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to batch create collections, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// ListDeleteProgress lists the progress of the delete by expression running on this proxy.
+func (node *Proxy) ListDeleteProgress(w http.ResponseWriter, req *http.Request) {
+	bytes, err := json.Marshal(globalDeleteProgress.List())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list delete progress, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// DedupVectors starts a job scanning the collection for the near-duplicate vectors with the existing index,
+// all but one entity of each cluster are deleted if dry_run is false. The job status is got by GetDedupVectorsJob.
+// Deleting is refused by the router unless the management auth is enabled, and the job runs as the caller in the
+// Milvus-Authorization header if the authorization is enabled.
+func (node *Proxy) DedupVectors(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to dedup vectors, %s"}`, err.Error())))
+		return
+	}
+
+	params, err := parseVectorDedupParams(req.FormValue)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to dedup vectors, %s"}`, err.Error())))
+		return
+	}
+	ctx, err := dedupCallerContext(req, params.dbName)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to dedup vectors, %s"}`, err.Error())))
+		return
+	}
+
+	jobID, err := globalVectorDedupJobs.start(params, func(scanned *atomic.Int64) (*VectorDedupResult, error) {
+		return node.dedupVectors(ctx, params, scanned)
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to dedup vectors, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte(fmt.Sprintf(`{"job_id": %d}`, jobID)))
+}
+
+// GetDedupVectorsJob returns the status of the dedup job of job_id, or of all the dedup jobs of this proxy.
+func (node *Proxy) GetDedupVectorsJob(w http.ResponseWriter, req *http.Request) {
+	var resp any = globalVectorDedupJobs.List()
+	if value := req.URL.Query().Get("job_id"); value != "" {
+		jobID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get dedup job, invalid job_id %s"}`, value)))
+			return
+		}
+		job, ok := globalVectorDedupJobs.Get(jobID)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get dedup job, job %d not found"}`, jobID)))
+			return
+		}
+		resp = job
+	}
+
+	bytes, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get dedup job, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
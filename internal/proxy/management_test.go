@@ -30,9 +30,12 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 )
 
@@ -166,6 +169,254 @@ func (s *ProxyManagementSuite) TestResumeDatacoordGC() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestShowSegmentStats() {
+	s.Run("normal", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().GetSegmentsByStates(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.GetSegmentsByStatesRequest, options ...grpc.CallOption) (*datapb.GetSegmentsByStatesResponse, error) {
+			s.Equal(int64(1), req.GetCollectionID())
+			s.Equal(int64(2), req.GetPartitionID())
+			return &datapb.GetSegmentsByStatesResponse{Status: merr.Success(), Segments: []int64{100}}, nil
+		})
+		s.datacoord.EXPECT().GetSegmentInfo(mock.Anything, mock.Anything).Return(&datapb.GetSegmentInfoResponse{
+			Status: merr.Success(),
+			Infos: []*datapb.SegmentInfo{
+				{
+					ID:          100,
+					PartitionID: 2,
+					NumOfRows:   100,
+					State:       commonpb.SegmentState_Flushed,
+					Binlogs: []*datapb.FieldBinlog{
+						{FieldID: 101, Binlogs: []*datapb.Binlog{{EntriesNum: 100, MemorySize: 1024, LogSize: 512}}},
+					},
+					Deltalogs: []*datapb.FieldBinlog{
+						{Binlogs: []*datapb.Binlog{{EntriesNum: 25, MemorySize: 64, LogSize: 32}}},
+					},
+				},
+			},
+		}, nil)
+		s.datacoord.EXPECT().GetIndexInfos(mock.Anything, mock.Anything).Return(&indexpb.GetIndexInfoResponse{
+			Status: merr.Success(),
+			SegmentInfo: map[int64]*indexpb.SegmentInfo{
+				100: {IndexInfos: []*indexpb.IndexFilePathInfo{
+					{IndexParams: []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: "HNSW"}}},
+				}},
+			},
+		}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteSegmentStats+"?collection_id=1&partition_id=2", nil)
+		s.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+		s.proxy.ShowSegmentStats(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+
+		var stats []*SegmentStats
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &stats))
+		s.Require().Len(stats, 1)
+		s.Equal(int64(25), stats[0].DeletedRows)
+		s.Equal(0.25, stats[0].DeletedRatio)
+		s.Equal(int64(1088), stats[0].MemorySize)
+		s.Equal([]string{"HNSW"}, stats[0].IndexTypes)
+	})
+
+	s.Run("invalid_collection", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteSegmentStats+"?collection_id=abc", nil)
+		s.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+		s.proxy.ShowSegmentStats(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().GetSegmentsByStates(mock.Anything, mock.Anything).Return(nil, errors.New("mock"))
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteSegmentStats+"?collection_id=1", nil)
+		s.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+		s.proxy.ShowSegmentStats(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func (s *ProxyManagementSuite) TestBackup() {
+	s.Run("create", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().CreateBackup(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.CreateBackupRequest, options ...grpc.CallOption) (*datapb.CreateBackupResponse, error) {
+			s.Equal("b1", req.GetName())
+			s.Equal(int64(100), req.GetCollectionID())
+			return &datapb.CreateBackupResponse{
+				Status: merr.Success(),
+				Backup: &datapb.BackupInfo{Name: "b1", CollectionID: 100},
+			}, nil
+		})
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteCreateBackup+"?name=b1&collection_id=100", nil)
+		s.Require().NoError(err)
+		recorder := httptest.NewRecorder()
+		s.proxy.CreateBackup(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+
+		req, err = http.NewRequest(http.MethodGet, management.RouteCreateBackup+"?name=b1&collection_id=abc", nil)
+		s.Require().NoError(err)
+		recorder = httptest.NewRecorder()
+		s.proxy.CreateBackup(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	s.Run("restore", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().RestoreBackup(mock.Anything, mock.Anything).Return(&datapb.RestoreBackupResponse{
+			Status: merr.Success(),
+			JobIDs: []int64{1, 2},
+		}, nil).Once()
+		s.datacoord.EXPECT().RestoreBackup(mock.Anything, mock.Anything).Return(nil, errors.New("mock")).Once()
+		s.querycoord.EXPECT().ShowCollections(mock.Anything, mock.Anything).Return(&querypb.ShowCollectionsResponse{
+			Status: merr.Status(merr.WrapErrCollectionNotLoaded(200)),
+		}, nil).Twice()
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteRestoreBackup+"?name=b1&collection_id=200", nil)
+		s.Require().NoError(err)
+		recorder := httptest.NewRecorder()
+		s.proxy.RestoreBackup(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), "[1,2]")
+
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreBackup(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+
+		// loaded
+		s.querycoord.EXPECT().ShowCollections(mock.Anything, mock.Anything).Return(&querypb.ShowCollectionsResponse{
+			Status:              merr.Success(),
+			CollectionIDs:       []int64{200},
+			InMemoryPercentages: []int64{100},
+		}, nil).Once()
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreBackup(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		s.querycoord.EXPECT().ShowCollections(mock.Anything, mock.Anything).Return(nil, errors.New("mock")).Once()
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreBackup(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	s.Run("list", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().ListBackups(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.ListBackupsRequest, options ...grpc.CallOption) (*datapb.ListBackupsResponse, error) {
+			s.Equal(int64(0), req.GetCollectionID())
+			return &datapb.ListBackupsResponse{
+				Status:  merr.Success(),
+				Backups: []*datapb.BackupInfo{{Name: "b1"}},
+			}, nil
+		})
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteListBackups, nil)
+		s.Require().NoError(err)
+		recorder := httptest.NewRecorder()
+		s.proxy.ListBackups(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), "b1")
+	})
+
+	s.Run("drop", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().DropBackup(mock.Anything, mock.Anything).Return(merr.Status(merr.WrapErrBackupNotFound("b1")), nil)
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteDropBackup+"?name=b1", nil)
+		s.Require().NoError(err)
+		recorder := httptest.NewRecorder()
+		s.proxy.DropBackup(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func (s *ProxyManagementSuite) TestChannelCheckpoint() {
+	s.Run("inspect", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().InspectChannelCheckpoints(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.InspectChannelCheckpointsRequest, options ...grpc.CallOption) (*datapb.InspectChannelCheckpointsResponse, error) {
+			s.Equal(int64(100), req.GetCollectionID())
+			s.Equal("ch1", req.GetChannel())
+			return &datapb.InspectChannelCheckpointsResponse{
+				Status:      merr.Success(),
+				Checkpoints: []*datapb.ChannelCheckpointInfo{{Channel: "ch1", LagMs: 1000}},
+			}, nil
+		})
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteInspectChannelCheckpoints+"?collection_id=100&channel=ch1", nil)
+		s.Require().NoError(err)
+		recorder := httptest.NewRecorder()
+		s.proxy.InspectChannelCheckpoints(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), "ch1")
+
+		req, err = http.NewRequest(http.MethodGet, management.RouteInspectChannelCheckpoints, nil)
+		s.Require().NoError(err)
+		recorder = httptest.NewRecorder()
+		s.proxy.InspectChannelCheckpoints(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	s.Run("repair", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.datacoord.EXPECT().RepairChannelCheckpoint(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.RepairChannelCheckpointRequest, options ...grpc.CallOption) (*datapb.RepairChannelCheckpointResponse, error) {
+			s.Equal(datapb.ChannelCheckpointRepairAction_SetPosition, req.GetAction())
+			s.Equal([]byte("msg"), req.GetPosition().GetMsgID())
+			s.Equal(uint64(1000), req.GetPosition().GetTimestamp())
+			s.True(req.GetConfirm())
+			s.False(req.GetForce())
+			return &datapb.RepairChannelCheckpointResponse{
+				Status: merr.Success(),
+				Issues: []string{"mock issue"},
+			}, nil
+		}).Once()
+
+		req, err := http.NewRequest(http.MethodGet, management.RouteRepairChannelCheckpoint+
+			"?collection_id=100&channel=ch1&action=set_position&msg_id=bXNn&timestamp=1000&confirm=true", nil)
+		s.Require().NoError(err)
+		recorder := httptest.NewRecorder()
+		s.proxy.RepairChannelCheckpoint(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), "mock issue")
+		// only the confirmed repair is refused by the router without the management auth
+		s.False(isRepairCheckOnly(req))
+
+		s.datacoord.EXPECT().RepairChannelCheckpoint(mock.Anything, mock.Anything).Return(nil, errors.New("mock")).Once()
+		req, err = http.NewRequest(http.MethodGet, management.RouteRepairChannelCheckpoint+"?collection_id=100&channel=ch1&action=reset_to_flushed", nil)
+		s.Require().NoError(err)
+		s.True(isRepairCheckOnly(req))
+		recorder = httptest.NewRecorder()
+		s.proxy.RepairChannelCheckpoint(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+
+		for _, query := range []string{
+			"?collection_id=100&channel=ch1&action=unknown",
+			"?collection_id=100&channel=ch1&action=set_position&msg_id=***&timestamp=1000",
+			"?collection_id=100&channel=ch1&action=set_position&msg_id=bXNn&timestamp=abc",
+		} {
+			req, err = http.NewRequest(http.MethodGet, management.RouteRepairChannelCheckpoint+query, nil)
+			s.Require().NoError(err)
+			recorder = httptest.NewRecorder()
+			s.proxy.RepairChannelCheckpoint(recorder, req)
+			s.Equal(http.StatusBadRequest, recorder.Code)
+		}
+	})
+}
+
 func (s *ProxyManagementSuite) TestListQueryNode() {
 	s.Run("normal", func() {
 		s.SetupTest()
@@ -687,6 +938,52 @@ func (s *ProxyManagementSuite) TestCheckQueryNodeDistribution() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestGetResourceGroupDatabases() {
+	s.Run("normal", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.querycoord.EXPECT().DescribeResourceGroup(mock.Anything, mock.Anything).Return(&querypb.DescribeResourceGroupResponse{
+			Status: merr.Success(),
+			ResourceGroup: &querypb.ResourceGroupInfo{
+				Name:           "rg1",
+				BoundDatabases: []string{"db1", "db2"},
+			},
+		}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteResourceGroupDatabases, strings.NewReader("resource_group=rg1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.GetResourceGroupDatabases(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Equal(`{"resource_group":"rg1","bound_databases":["db1","db2"]}`, recorder.Body.String())
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		// test miss requested param
+		req, err := http.NewRequest(http.MethodPost, management.RouteResourceGroupDatabases, strings.NewReader(""))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.GetResourceGroupDatabases(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		s.querycoord.EXPECT().DescribeResourceGroup(mock.Anything, mock.Anything).Return(&querypb.DescribeResourceGroupResponse{
+			Status: merr.Status(merr.ErrResourceGroupNotFound),
+		}, nil)
+		req, err = http.NewRequest(http.MethodPost, management.RouteResourceGroupDatabases, strings.NewReader("resource_group=rg1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.GetResourceGroupDatabases(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
 func TestProxyManagement(t *testing.T) {
 	suite.Run(t, new(ProxyManagementSuite))
 }
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
@@ -71,6 +72,8 @@ type Cache interface {
 	// GetCollectionSchema get collection's schema.
 	GetCollectionSchema(ctx context.Context, database, collectionName string) (*schemaInfo, error)
 	GetShards(ctx context.Context, withCache bool, database, collectionName string, collectionID int64) (map[string][]nodeInfo, error)
+	// PeekShards returns the cached shard leaders of the collection without fetching them, nil if not cached.
+	PeekShards(database, collectionName string) map[string][]nodeInfo
 	DeprecateShardCache(database, collectionName string)
 	InvalidateShardLeaderCache(collections []int64)
 	ListShardLocation() map[int64]nodeInfo
@@ -102,6 +105,11 @@ type collectionInfo struct {
 	createdUtcTimestamp   uint64
 	consistencyLevel      commonpb.ConsistencyLevel
 	partitionKeyIsolation bool
+	filterStrategy        string
+	searchTimeout         time.Duration
+	searchIgnoreGrowing   bool
+	// hash function of the partition keys, empty for the legacy one
+	partitionKeyHashFunction string
 }
 
 type databaseInfo struct {
@@ -452,6 +460,24 @@ func (m *MetaCache) update(ctx context.Context, database, collectionName string,
 	if err != nil {
 		return nil, err
 	}
+	filterStrategy, err := common.CollectionFilterStrategy(collection.Properties...)
+	if err != nil {
+		log.Warn("ignore invalid filter strategy of collection", zap.String("collectionName", collectionName), zap.Error(err))
+	}
+	searchTimeout, err := common.CollectionSearchTimeout(collection.Properties...)
+	if err != nil {
+		log.Warn("ignore invalid search timeout of collection", zap.String("collectionName", collectionName), zap.Error(err))
+	}
+	searchIgnoreGrowing, err := common.CollectionSearchIgnoreGrowing(collection.Properties...)
+	if err != nil {
+		log.Warn("ignore invalid search ignore growing of collection", zap.String("collectionName", collectionName), zap.Error(err))
+	}
+	// the partition key hash function is validated at the creation and immutable,
+	// routing the keys by another one misplaces the data
+	partitionKeyHashFunction, err := common.PartitionKeyHashFunction(collection.Properties...)
+	if err != nil {
+		return nil, err
+	}
 
 	schemaInfo := newSchemaInfoWithLoadFields(collection.Schema, loadFields)
 
@@ -471,6 +497,11 @@ func (m *MetaCache) update(ctx context.Context, database, collectionName string,
 			createdUtcTimestamp:   collection.CreatedUtcTimestamp,
 			consistencyLevel:      collection.ConsistencyLevel,
 			partitionKeyIsolation: isolation,
+			filterStrategy:        filterStrategy,
+			searchTimeout:         searchTimeout,
+			searchIgnoreGrowing:   searchIgnoreGrowing,
+
+			partitionKeyHashFunction: partitionKeyHashFunction,
 		}, nil
 	}
 	_, dbOk := m.collInfo[database]
@@ -486,6 +517,11 @@ func (m *MetaCache) update(ctx context.Context, database, collectionName string,
 		createdUtcTimestamp:   collection.CreatedUtcTimestamp,
 		consistencyLevel:      collection.ConsistencyLevel,
 		partitionKeyIsolation: isolation,
+		filterStrategy:        filterStrategy,
+		searchTimeout:         searchTimeout,
+		searchIgnoreGrowing:   searchIgnoreGrowing,
+
+		partitionKeyHashFunction: partitionKeyHashFunction,
 	}
 
 	log.Ctx(ctx).Info("meta update success", zap.String("database", database), zap.String("collectionName", collectionName),
@@ -890,7 +926,7 @@ func (m *MetaCache) GetCredentialInfo(ctx context.Context, username string) (*in
 			Username: username,
 		}
 		resp, err := m.rootCoord.GetCredential(ctx, req)
-		if err != nil {
+		if err := merr.CheckRPCCall(resp, err); err != nil {
 			return &internalpb.CredentialInfo{}, err
 		}
 		credInfo = &internalpb.CredentialInfo{
@@ -1007,7 +1043,14 @@ func parseShardLeaderList2QueryNode(shardsLeaders []*querypb.ShardLeadersList) m
 		qns := make([]nodeInfo, len(leaders.GetNodeIds()))
 
 		for j := range qns {
-			qns[j] = nodeInfo{leaders.GetNodeIds()[j], leaders.GetNodeAddrs()[j]}
+			qns[j] = nodeInfo{nodeID: leaders.GetNodeIds()[j], address: leaders.GetNodeAddrs()[j]}
+			// the legacy querycoord returns no resource groups
+			if j < len(leaders.GetResourceGroups()) {
+				qns[j].resourceGroup = leaders.GetResourceGroups()[j]
+			}
+			if j < len(leaders.GetReplicaIds()) {
+				qns[j].replicaID = leaders.GetReplicaIds()[j]
+			}
 		}
 
 		shard2QueryNodes[leaders.GetChannelName()] = qns
@@ -1034,6 +1077,16 @@ func (m *MetaCache) ListShardLocation() map[int64]nodeInfo {
 	return shardLeaderInfo
 }
 
+func (m *MetaCache) PeekShards(database, collectionName string) map[string][]nodeInfo {
+	m.leaderMut.RLock()
+	defer m.leaderMut.RUnlock()
+	cacheShardLeaders, ok := m.collLeader[database][collectionName]
+	if !ok {
+		return nil
+	}
+	return lo.Assign(cacheShardLeaders.shardLeaders)
+}
+
 // DeprecateShardCache clear the shard leader cache of a collection
 func (m *MetaCache) DeprecateShardCache(database, collectionName string) {
 	log.Info("deprecate shard cache for collection", zap.String("collectionName", collectionName))
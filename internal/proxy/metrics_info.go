@@ -197,7 +197,9 @@ func getSystemInfoMetrics(
 	go func() {
 		defer wg.Done()
 
-		queryCoordResp, queryCoordErr = node.queryCoord.GetMetrics(ctx, request)
+		queryCoordResp, queryCoordErr = metricsinfo.RequestRemoteMetrics(ctx, typeutil.QueryCoordRole, request, func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+			return node.queryCoord.GetMetrics(ctx, req)
+		})
 		if queryCoordErr != nil {
 			return
 		}
@@ -209,7 +211,9 @@ func getSystemInfoMetrics(
 	go func() {
 		defer wg.Done()
 
-		dataCoordResp, dataCoordErr = node.dataCoord.GetMetrics(ctx, request)
+		dataCoordResp, dataCoordErr = metricsinfo.RequestRemoteMetrics(ctx, typeutil.DataCoordRole, request, func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+			return node.dataCoord.GetMetrics(ctx, req)
+		})
 		if dataCoordErr != nil {
 			return
 		}
@@ -221,7 +225,9 @@ func getSystemInfoMetrics(
 	go func() {
 		defer wg.Done()
 
-		rootCoordResp, rootCoordErr = node.rootCoord.GetMetrics(ctx, request)
+		rootCoordResp, rootCoordErr = metricsinfo.RequestRemoteMetrics(ctx, typeutil.RootCoordRole, request, func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+			return node.rootCoord.GetMetrics(ctx, req)
+		})
 		if rootCoordErr != nil {
 			return
 		}
@@ -1028,6 +1028,55 @@ func (_c *MockCache_ListShardLocation_Call) RunAndReturn(run func() map[int64]no
 	return _c
 }
 
+// PeekShards provides a mock function with given fields: database, collectionName
+func (_m *MockCache) PeekShards(database string, collectionName string) map[string][]nodeInfo {
+	ret := _m.Called(database, collectionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PeekShards")
+	}
+
+	var r0 map[string][]nodeInfo
+	if rf, ok := ret.Get(0).(func(string, string) map[string][]nodeInfo); ok {
+		r0 = rf(database, collectionName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]nodeInfo)
+		}
+	}
+
+	return r0
+}
+
+// MockCache_PeekShards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PeekShards'
+type MockCache_PeekShards_Call struct {
+	*mock.Call
+}
+
+// PeekShards is a helper method to define mock.On call
+//   - database string
+//   - collectionName string
+func (_e *MockCache_Expecter) PeekShards(database interface{}, collectionName interface{}) *MockCache_PeekShards_Call {
+	return &MockCache_PeekShards_Call{Call: _e.mock.On("PeekShards", database, collectionName)}
+}
+
+func (_c *MockCache_PeekShards_Call) Run(run func(database string, collectionName string)) *MockCache_PeekShards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_PeekShards_Call) Return(_a0 map[string][]nodeInfo) *MockCache_PeekShards_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_PeekShards_Call) RunAndReturn(run func(string, string) map[string][]nodeInfo) *MockCache_PeekShards_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RefreshPolicyInfo provides a mock function with given fields: op
 func (_m *MockCache) RefreshPolicyInfo(op typeutil.CacheOp) error {
 	ret := _m.Called(op)
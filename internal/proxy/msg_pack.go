@@ -18,6 +18,7 @@ package proxy
 
 import (
 	"context"
+	"math"
 	"strconv"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -108,6 +110,7 @@ func repackInsertDataByPartition(ctx context.Context,
 	partitionName string,
 	rowOffsets []int,
 	channelName string,
+	keyRange *datapb.PartitionKeyRange,
 	insertMsg *msgstream.InsertMsg,
 	segIDAssigner *segIDAssigner,
 ) ([]msgstream.TsMsg, error) {
@@ -126,7 +129,7 @@ func repackInsertDataByPartition(ctx context.Context,
 		return nil, err
 	}
 	beforeAssign := time.Now()
-	assignedSegmentInfos, err := segIDAssigner.GetSegmentID(insertMsg.CollectionID, partitionID, channelName, uint32(len(rowOffsets)), maxTs)
+	assignedSegmentInfos, err := segIDAssigner.GetSegmentIDInKeyRange(insertMsg.CollectionID, partitionID, channelName, keyRange, uint32(len(rowOffsets)), maxTs)
 	metrics.ProxyAssignSegmentIDLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10)).Observe(float64(time.Since(beforeAssign).Milliseconds()))
 	if err != nil {
 		log.Error("allocate segmentID for insert data failed",
@@ -193,7 +196,7 @@ func repackInsertData(ctx context.Context,
 	channel2RowOffsets := assignChannelsByPK(result.IDs, channelNames, insertMsg)
 	for channel, rowOffsets := range channel2RowOffsets {
 		partitionName := insertMsg.PartitionName
-		msgs, err := repackInsertDataByPartition(ctx, partitionName, rowOffsets, channel, insertMsg, segIDAssigner)
+		msgs, err := repackInsertDataByPartition(ctx, partitionName, rowOffsets, channel, nil, insertMsg, segIDAssigner)
 		if err != nil {
 			log.Warn("repack insert data to msg pack failed",
 				zap.String("collectionName", insertMsg.CollectionName),
@@ -217,6 +220,35 @@ func repackInsertData(ctx context.Context,
 	return msgPack, nil
 }
 
+// partitionKeyGroup is the rows in a partition with the partition keys hashed into the same key range,
+// which share the segments.
+type partitionKeyGroup struct {
+	partitionName string
+	// [0, 0) if the segments are shared by all rows in the partition
+	keyRangeBegin int64
+	keyRangeEnd   int64
+}
+
+func (g partitionKeyGroup) keyRange() *datapb.PartitionKeyRange {
+	if g.keyRangeEnd == 0 {
+		return nil
+	}
+	return &datapb.PartitionKeyRange{Begin: g.keyRangeBegin, End: g.keyRangeEnd}
+}
+
+// partitionKeyRange returns the range [begin, end) containing the hash value,
+// among the n ranges the hash values of the partition keys are split into evenly.
+func partitionKeyRange(hashValue uint32, n int64) (int64, int64) {
+	// keeps the ranges from overflow
+	n = min(n, math.MaxUint16)
+	group := int64(hashValue) * n >> 32
+	// the first hash value of each group
+	first := func(group int64) int64 {
+		return (group<<32 + n - 1) / n
+	}
+	return first(group), first(group + 1)
+}
+
 func repackInsertDataWithPartitionKey(ctx context.Context,
 	channelNames []string,
 	partitionKeys *schemapb.FieldData,
@@ -238,36 +270,46 @@ func repackInsertDataWithPartitionKey(ctx context.Context,
 			zap.Error(err))
 		return nil, err
 	}
-	hashValues, err := typeutil.HashKey2Partitions(partitionKeys, partitionNames)
+	hashFunction, err := getPartitionKeyHashFunction(ctx, insertMsg.GetDbName(), insertMsg.CollectionName)
+	if err != nil {
+		log.Warn("get partition key hash function failed",
+			zap.String("collectionName", insertMsg.CollectionName),
+			zap.Error(err))
+		return nil, err
+	}
+	hashValues, err := typeutil.HashKeys(partitionKeys, hashFunction)
 	if err != nil {
 		log.Warn("has partition keys to partitions failed",
 			zap.String("collectionName", insertMsg.CollectionName),
 			zap.Error(err))
 		return nil, err
 	}
+	affinityGroups := Params.DataCoordCfg.PartitionKeyAffinityGroups.GetAsInt64()
 
 	for channel, rowOffsets := range channel2RowOffsets {
-		partition2RowOffsets := make(map[string][]int)
+		partition2RowOffsets := make(map[partitionKeyGroup][]int)
 		for _, idx := range rowOffsets {
-			partitionName := partitionNames[hashValues[idx]]
-			if _, ok := partition2RowOffsets[partitionName]; !ok {
-				partition2RowOffsets[partitionName] = []int{}
+			group := partitionKeyGroup{
+				partitionName: partitionNames[hashValues[idx]%uint32(len(partitionNames))],
+			}
+			if affinityGroups > 0 {
+				group.keyRangeBegin, group.keyRangeEnd = partitionKeyRange(hashValues[idx], affinityGroups)
 			}
-			partition2RowOffsets[partitionName] = append(partition2RowOffsets[partitionName], idx)
+			partition2RowOffsets[group] = append(partition2RowOffsets[group], idx)
 		}
 
 		errGroup, _ := errgroup.WithContext(ctx)
-		partition2Msgs := typeutil.NewConcurrentMap[string, []msgstream.TsMsg]()
-		for partitionName, offsets := range partition2RowOffsets {
-			partitionName := partitionName
+		partition2Msgs := typeutil.NewConcurrentMap[partitionKeyGroup, []msgstream.TsMsg]()
+		for group, offsets := range partition2RowOffsets {
+			group := group
 			offsets := offsets
 			errGroup.Go(func() error {
-				msgs, err := repackInsertDataByPartition(ctx, partitionName, offsets, channel, insertMsg, segIDAssigner)
+				msgs, err := repackInsertDataByPartition(ctx, group.partitionName, offsets, channel, group.keyRange(), insertMsg, segIDAssigner)
 				if err != nil {
 					return err
 				}
 
-				partition2Msgs.Insert(partitionName, msgs)
+				partition2Msgs.Insert(group, msgs)
 				return nil
 			})
 		}
@@ -281,7 +323,7 @@ func repackInsertDataWithPartitionKey(ctx context.Context,
 			return nil, err
 		}
 
-		partition2Msgs.Range(func(name string, msgs []msgstream.TsMsg) bool {
+		partition2Msgs.Range(func(_ partitionKeyGroup, msgs []msgstream.TsMsg) bool {
 			msgPack.Msgs = append(msgPack.Msgs, msgs...)
 			return true
 		})
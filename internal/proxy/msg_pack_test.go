@@ -18,6 +18,7 @@ package proxy
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -240,3 +241,26 @@ func TestRepackInsertDataWithPartitionKey(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestPartitionKeyRange(t *testing.T) {
+	for _, n := range []int64{1, 3, 16} {
+		begin, _ := partitionKeyRange(0, n)
+		assert.Equal(t, int64(0), begin)
+		_, end := partitionKeyRange(math.MaxUint32, n)
+		assert.Equal(t, int64(1<<32), end)
+		for _, hashValue := range []uint32{0, 12345, math.MaxUint32 / 2, math.MaxUint32} {
+			begin, end := partitionKeyRange(hashValue, n)
+			assert.LessOrEqual(t, begin, int64(hashValue))
+			assert.Greater(t, end, int64(hashValue))
+			// the ranges are adjacent
+			if begin > 0 {
+				_, prevEnd := partitionKeyRange(uint32(begin-1), n)
+				assert.Equal(t, begin, prevEnd)
+			}
+		}
+	}
+
+	begin, end := partitionKeyRange(math.MaxUint32/2, 2)
+	assert.Equal(t, int64(0), begin)
+	assert.Equal(t, int64(1<<31), end)
+}
@@ -0,0 +1,111 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/reduce"
+	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// orderByReducer merges the sorted results of the shards by a k-way merge bounded by offset and limit.
+type orderByReducer struct {
+	*defaultLimitReducer
+}
+
+func newOrderByReducer(ctx context.Context, params *queryParams, req *internalpb.RetrieveRequest, schema *schemapb.CollectionSchema, collectionName string) *orderByReducer {
+	return &orderByReducer{
+		defaultLimitReducer: newDefaultLimitReducer(ctx, params, req, schema, collectionName),
+	}
+}
+
+func (r *orderByReducer) Reduce(results []*internalpb.RetrieveResults) (*milvuspb.QueryResults, error) {
+	res, err := reduceRetrieveResultsInOrder(r.ctx, results, r.params)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := filterSystemFields(r.req.GetOutputFieldsId())
+	if err := typeutil2.FillRetrieveResultIfEmpty(typeutil2.NewMilvusResult(res), filtered, r.schema); err != nil {
+		return nil, fmt.Errorf("failed to fill retrieve results: %s", err.Error())
+	}
+
+	if err := r.afterReduce(res); err != nil {
+		return nil, err
+	}
+
+	// drop the fields retrieved only for ordering
+	res.FieldsData = lo.Filter(res.GetFieldsData(), func(fieldData *schemapb.FieldData, _ int) bool {
+		return !lo.Contains(r.params.orderByOnlyFieldIDs, fieldData.GetFieldId())
+	})
+	return res, nil
+}
+
+func reduceRetrieveResultsInOrder(ctx context.Context, retrieveResults []*internalpb.RetrieveResults, queryParams *queryParams) (*milvuspb.QueryResults, error) {
+	log.Ctx(ctx).Debug("reduceRetrieveResultsInOrder", zap.Int("len(retrieveResults)", len(retrieveResults)))
+	ret := &milvuspb.QueryResults{}
+
+	cursors := make([]*reduce.OrderedCursor, 0, len(retrieveResults))
+	validRetrieveResults := make([]*internalpb.RetrieveResults, 0, len(retrieveResults))
+	for _, r := range retrieveResults {
+		if len(r.GetFieldsData()) == 0 || typeutil.GetSizeOfIDs(r.GetIds()) == 0 {
+			continue
+		}
+		columns, err := reduce.NewOrderByColumns(r.GetIds(), r.GetFieldsData(), queryParams.orderBy)
+		if err != nil {
+			return nil, err
+		}
+		// the results of the shards are sorted and truncated to limit+offset already
+		cursors = append(cursors, reduce.NewOrderedCursor(columns, columns.SequentialIndices(typeutil.Unlimited)))
+		validRetrieveResults = append(validRetrieveResults, r)
+	}
+
+	if len(validRetrieveResults) == 0 {
+		return ret, nil
+	}
+
+	// handle offset
+	for i := int64(0); i < queryParams.offset; i++ {
+		sel := reduce.SelectMinCursor(cursors)
+		if sel == -1 {
+			return ret, nil
+		}
+		cursors[sel].Next()
+	}
+
+	limit := queryParams.limit
+	if limit == typeutil.Unlimited {
+		limit = 0
+		for _, cursor := range cursors {
+			limit += int64(len(cursor.Indices))
+		}
+	}
+	ret.FieldsData = typeutil.PrepareResultFieldData(validRetrieveResults[0].GetFieldsData(), limit)
+	var retSize int64
+	maxOutputSize := paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64()
+	for j := int64(0); j < limit; j++ {
+		sel := reduce.SelectMinCursor(cursors)
+		if sel == -1 {
+			break
+		}
+		retSize += typeutil.AppendFieldData(ret.FieldsData, validRetrieveResults[sel].GetFieldsData(), cursors[sel].Current())
+
+		// limit retrieve result to avoid oom
+		if retSize > maxOutputSize {
+			return nil, fmt.Errorf("query results exceed the maxOutputSize Limit %d", maxOutputSize)
+		}
+
+		cursors[sel].Next()
+	}
+
+	return ret, nil
+}
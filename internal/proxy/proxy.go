@@ -34,13 +34,16 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
+	"github.com/milvus-io/milvus/pkg/kv"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -107,6 +110,7 @@ type Proxy struct {
 	segAssigner    *segIDAssigner
 
 	metricsCacheManager *metricsinfo.MetricsCacheManager
+	metricsSnapshots    *metricsinfo.MetricsSnapshots
 
 	session  *sessionutil.Session
 	shardMgr shardClientMgr
@@ -133,6 +137,12 @@ type Proxy struct {
 	enableComplexDeleteLimit bool
 
 	slowQueries *expirable.LRU[Timestamp, *metricsinfo.SlowQuery]
+
+	// meta storage of the query pattern statistics and usage records
+	queryStatsKV kv.MetaKv
+
+	// the channel checkpoints the inserts acknowledged at the flush level wait for
+	checkpointWatcher *checkpointWatcher
 }
 
 // NewProxy returns a Proxy struct.
@@ -157,6 +167,7 @@ func NewProxy(ctx context.Context, factory dependency.Factory) (*Proxy, error) {
 		replicateStreamManager: replicateStreamManager,
 		slowQueries:            expirable.NewLRU[Timestamp, *metricsinfo.SlowQuery](20, nil, time.Minute*15),
 	}
+	node.checkpointWatcher = newCheckpointWatcher(insertAckFlushCheckInterval, node.getCollectionCheckpoint)
 	node.UpdateStateCode(commonpb.StateCode_Abnormal)
 	expr.Register("proxy", node)
 	hookutil.InitOnceHook()
@@ -293,6 +304,7 @@ func (node *Proxy) Init() error {
 
 	node.enableComplexDeleteLimit = Params.QuotaConfig.ComplexDeleteLimitEnable.GetAsBool()
 	node.metricsCacheManager = metricsinfo.NewMetricsCacheManager()
+	node.metricsSnapshots = metricsinfo.NewMetricsSnapshots()
 	log.Debug("create metrics cache manager done", zap.String("role", typeutil.ProxyRole))
 
 	if err := InitMetaCache(node.ctx, node.rootCoord, node.queryCoord, node.shardMgr); err != nil {
@@ -301,12 +313,73 @@ func (node *Proxy) Init() error {
 	}
 	log.Debug("init meta cache done", zap.String("role", typeutil.ProxyRole))
 
+	if err := initAuthProviders(); err != nil {
+		log.Warn("failed to init auth providers", zap.String("role", typeutil.ProxyRole), zap.Error(err))
+		return err
+	}
+
 	node.enableMaterializedView = Params.CommonCfg.EnableMaterializedView.GetAsBool()
 
+	globalQueryStats = newQueryStatsCollector()
+	globalUsageMeter = newUsageMeter()
+	if node.etcdCli != nil {
+		node.queryStatsKV = etcdkv.NewEtcdKV(node.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue())
+	}
+
 	log.Info("init proxy done", zap.Int64("nodeID", paramtable.GetNodeID()), zap.String("Address", node.address))
 	return nil
 }
 
+// flushQueryStatsLoop starts a goroutine that persists the query pattern statistics periodically.
+func (node *Proxy) flushQueryStatsLoop() {
+	node.wg.Add(1)
+	go func() {
+		defer node.wg.Done()
+
+		ticker := time.NewTicker(Params.ProxyCfg.QueryStatsFlushInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-node.ctx.Done():
+				log.Info("flush query stats loop exit")
+				return
+			case <-ticker.C:
+				globalQueryStats.flush(node.ctx, node.queryStatsKV)
+			}
+		}
+	}()
+}
+
+// flushUsageLoop starts a goroutine that persists the usage records periodically
+// and exports the closed windows into object storage.
+func (node *Proxy) flushUsageLoop() {
+	node.wg.Add(1)
+	go func() {
+		defer node.wg.Done()
+
+		var cm storage.ChunkManager
+		ticker := time.NewTicker(Params.ProxyCfg.UsageMeterFlushInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-node.ctx.Done():
+				log.Info("flush usage loop exit")
+				return
+			case <-ticker.C:
+				if cm == nil && Params.ProxyCfg.UsageMeterEnabled.GetAsBool() {
+					var err error
+					cm, err = node.factory.NewPersistentStorageChunkManager(node.ctx)
+					if err != nil {
+						log.Warn("failed to create chunk manager for usage export", zap.Error(err))
+						continue
+					}
+				}
+				globalUsageMeter.flush(node.ctx, node.queryStatsKV, cm)
+			}
+		}
+	}()
+}
+
 // sendChannelsTimeTickLoop starts a goroutine that synchronizes the time tick information.
 func (node *Proxy) sendChannelsTimeTickLoop() {
 	node.wg.Add(1)
@@ -420,6 +493,11 @@ func (node *Proxy) Start() error {
 		node.sendChannelsTimeTickLoop()
 	}
 
+	if node.queryStatsKV != nil {
+		node.flushQueryStatsLoop()
+		node.flushUsageLoop()
+	}
+
 	// Start callbacks
 	for _, cb := range node.startCallbacks {
 		cb()
@@ -0,0 +1,104 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+type ctxQueryProgressKey struct{}
+
+// QueryProgress tracks the progress of a query or search over its shards,
+// it's reported by the shard requests of the tasks carrying it in the context.
+type QueryProgress struct {
+	mu         sync.Mutex
+	shards     typeutil.Set[string]
+	shardsDone typeutil.Set[string]
+	segments   int64
+	rows       int64
+}
+
+// QueryProgressInfo is a snapshot of the QueryProgress.
+type QueryProgressInfo struct {
+	Shards     int `json:"shards"`
+	ShardsDone int `json:"shardsDone"`
+	// Segments is the number of the sealed segments completed.
+	Segments int64 `json:"segments"`
+	// Rows is the number of the entities retrieved by queries, or searched by searches.
+	Rows int64 `json:"rows"`
+}
+
+func NewQueryProgress() *QueryProgress {
+	return &QueryProgress{
+		shards:     typeutil.NewSet[string](),
+		shardsDone: typeutil.NewSet[string](),
+	}
+}
+
+// NewContextWithQueryProgress returns a context reporting the progress of the queries and searches to p.
+func NewContextWithQueryProgress(ctx context.Context, p *QueryProgress) context.Context {
+	return context.WithValue(ctx, ctxQueryProgressKey{}, p)
+}
+
+// QueryProgressFromContext returns the QueryProgress of the context, nil if not set.
+func QueryProgressFromContext(ctx context.Context) *QueryProgress {
+	p, _ := ctx.Value(ctxQueryProgressKey{}).(*QueryProgress)
+	return p
+}
+
+func (p *QueryProgress) startShard(channel string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shards.Insert(channel)
+}
+
+// gather adds the sealed segments completed and the rows gathered of a shard result.
+func (p *QueryProgress) gather(segments int, rows int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.segments += int64(segments)
+	p.rows += rows
+}
+
+func (p *QueryProgress) finishShard(channel string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shardsDone.Insert(channel)
+}
+
+func (p *QueryProgress) Info() QueryProgressInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return QueryProgressInfo{
+		Shards:     p.shards.Len(),
+		ShardsDone: p.shardsDone.Len(),
+		Segments:   p.segments,
+		Rows:       p.rows,
+	}
+}
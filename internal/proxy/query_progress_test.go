@@ -0,0 +1,52 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProgress(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, QueryProgressFromContext(ctx))
+	// reporting to no progress is a no-op
+	QueryProgressFromContext(ctx).startShard("ch1")
+
+	progress := NewQueryProgress()
+	ctx = NewContextWithQueryProgress(ctx, progress)
+	p := QueryProgressFromContext(ctx)
+	assert.Same(t, progress, p)
+
+	p.startShard("ch1")
+	p.startShard("ch2")
+	p.gather(2, 100)
+	p.finishShard("ch1")
+	// retried on another node
+	p.startShard("ch2")
+	p.gather(3, 50)
+	p.finishShard("ch2")
+
+	assert.Equal(t, QueryProgressInfo{
+		Shards:     2,
+		ShardsDone: 2,
+		Segments:   5,
+		Rows:       150,
+	}, progress.Info())
+}
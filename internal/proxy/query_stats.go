@@ -0,0 +1,301 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// queryStatsPrefix is the meta storage prefix of the persisted query statistics,
+// the key of one snapshot is {queryStatsPrefix}/{proxyID}/{collectionID}.
+const queryStatsPrefix = "proxy/query-stats"
+
+// recallProbeParamKeys are the search params tuning the recall of the index types, only these are recorded.
+var recallProbeParamKeys = []string{"nprobe", "ef", "search_list", "itopk_size", "search_width", "reorder_k", "drop_ratio_search"}
+
+// topKBuckets are the upper bounds of the topK distribution buckets.
+var topKBuckets = []int64{10, 100, 1000, 10000}
+
+// QueryPatternStats is the aggregated statistics of the searches and queries on one collection.
+type QueryPatternStats struct {
+	CollectionID int64 `json:"collection_id"`
+	SearchCount  int64 `json:"search_count"`
+	QueryCount   int64 `json:"query_count"`
+	// field id -> number of requests filtering on the field
+	FilterFields map[int64]int64 `json:"filter_fields"`
+	// topK bucket -> number of searches
+	TopK map[string]int64 `json:"topk"`
+	// metric type -> number of searches
+	MetricTypes map[string]int64 `json:"metric_types"`
+	// anns field id -> "param=value" -> number of searches
+	SearchParams map[int64]map[string]int64 `json:"search_params"`
+	UpdateTime   int64                      `json:"update_time"`
+}
+
+func newQueryPatternStats(collectionID int64) *QueryPatternStats {
+	return &QueryPatternStats{
+		CollectionID: collectionID,
+		FilterFields: make(map[int64]int64),
+		TopK:         make(map[string]int64),
+		MetricTypes:  make(map[string]int64),
+		SearchParams: make(map[int64]map[string]int64),
+	}
+}
+
+func (s *QueryPatternStats) merge(other *QueryPatternStats) {
+	s.SearchCount += other.SearchCount
+	s.QueryCount += other.QueryCount
+	for field, count := range other.FilterFields {
+		s.FilterFields[field] += count
+	}
+	for bucket, count := range other.TopK {
+		s.TopK[bucket] += count
+	}
+	for metricType, count := range other.MetricTypes {
+		s.MetricTypes[metricType] += count
+	}
+	for field, params := range other.SearchParams {
+		if s.SearchParams[field] == nil {
+			s.SearchParams[field] = make(map[string]int64)
+		}
+		for param, count := range params {
+			s.SearchParams[field][param] += count
+		}
+	}
+	if other.UpdateTime > s.UpdateTime {
+		s.UpdateTime = other.UpdateTime
+	}
+}
+
+func (s *QueryPatternStats) clone() *QueryPatternStats {
+	cloned := newQueryPatternStats(s.CollectionID)
+	cloned.merge(s)
+	return cloned
+}
+
+func topKBucket(topK int64) string {
+	lower := int64(1)
+	for _, upper := range topKBuckets {
+		if topK <= upper {
+			return fmt.Sprintf("%d-%d", lower, upper)
+		}
+		lower = upper + 1
+	}
+	return fmt.Sprintf("%d+", lower)
+}
+
+// queryStatsCollector aggregates the query patterns of the requests served by this proxy,
+// and persists them into meta storage periodically.
+type queryStatsCollector struct {
+	mu    sync.Mutex
+	stats map[int64]*QueryPatternStats
+	dirty typeutil.UniqueSet
+}
+
+var globalQueryStats *queryStatsCollector
+
+func newQueryStatsCollector() *queryStatsCollector {
+	return &queryStatsCollector{
+		stats: make(map[int64]*QueryPatternStats),
+		dirty: typeutil.NewUniqueSet(),
+	}
+}
+
+func (c *queryStatsCollector) getOrCreate(collectionID int64) *QueryPatternStats {
+	stats, ok := c.stats[collectionID]
+	if !ok {
+		stats = newQueryPatternStats(collectionID)
+		c.stats[collectionID] = stats
+	}
+	stats.UpdateTime = time.Now().Unix()
+	c.dirty.Insert(collectionID)
+	return stats
+}
+
+// RecordSearch records the pattern of a search, nil collector is a no-op.
+func (c *queryStatsCollector) RecordSearch(collectionID int64, plan *planpb.PlanNode, queryInfo *planpb.QueryInfo) {
+	if c == nil || !paramtable.Get().ProxyCfg.QueryStatsEnabled.GetAsBool() {
+		return
+	}
+	filterFields := collectFilterFieldIDs(plan.GetVectorAnns().GetPredicates())
+	searchParams := parseRecallProbeParams(queryInfo.GetSearchParams())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.getOrCreate(collectionID)
+	stats.SearchCount++
+	for _, field := range filterFields {
+		stats.FilterFields[field]++
+	}
+	stats.TopK[topKBucket(queryInfo.GetTopk())]++
+	if metricType := queryInfo.GetMetricType(); metricType != "" {
+		stats.MetricTypes[metricType]++
+	}
+	annsField := queryInfo.GetQueryFieldId()
+	for _, param := range searchParams {
+		if stats.SearchParams[annsField] == nil {
+			stats.SearchParams[annsField] = make(map[string]int64)
+		}
+		stats.SearchParams[annsField][param]++
+	}
+}
+
+// RecordQuery records the pattern of a query, nil collector is a no-op.
+func (c *queryStatsCollector) RecordQuery(collectionID int64, plan *planpb.PlanNode) {
+	if c == nil || !paramtable.Get().ProxyCfg.QueryStatsEnabled.GetAsBool() {
+		return
+	}
+	filterFields := collectFilterFieldIDs(plan.GetQuery().GetPredicates())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.getOrCreate(collectionID)
+	stats.QueryCount++
+	for _, field := range filterFields {
+		stats.FilterFields[field]++
+	}
+}
+
+// collectFilterFieldIDs returns the ids of the fields referred by the filter expression.
+func collectFilterFieldIDs(expr *planpb.Expr) []int64 {
+	if expr == nil {
+		return nil
+	}
+	fields := typeutil.NewUniqueSet()
+	var walk func(msg protoreflect.Message)
+	walk = func(msg protoreflect.Message) {
+		if info, ok := msg.Interface().(*planpb.ColumnInfo); ok {
+			fields.Insert(info.GetFieldId())
+			return
+		}
+		msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if fd.Message() == nil || fd.IsMap() {
+				return true
+			}
+			if fd.IsList() {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					walk(list.Get(i).Message())
+				}
+				return true
+			}
+			walk(v.Message())
+			return true
+		})
+	}
+	walk(expr.ProtoReflect())
+	return fields.Collect()
+}
+
+// parseRecallProbeParams returns the recall tuning params in search params as "param=value".
+func parseRecallProbeParams(searchParams string) []string {
+	if searchParams == "" {
+		return nil
+	}
+	params := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(searchParams), &params); err != nil {
+		return nil
+	}
+	result := make([]string, 0)
+	for _, key := range recallProbeParamKeys {
+		if value, ok := params[key]; ok {
+			result = append(result, fmt.Sprintf("%s=%v", key, value))
+		}
+	}
+	return result
+}
+
+// flush persists the statistics updated since the last flush, and removes the expired snapshots of all proxies.
+func (c *queryStatsCollector) flush(ctx context.Context, metaKV kv.MetaKv) {
+	c.mu.Lock()
+	updated := make([]*QueryPatternStats, 0, c.dirty.Len())
+	for _, collectionID := range c.dirty.Collect() {
+		updated = append(updated, c.stats[collectionID].clone())
+	}
+	c.dirty.Clear()
+	c.mu.Unlock()
+
+	nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
+	for _, stats := range updated {
+		bytes, err := json.Marshal(stats)
+		if err != nil {
+			continue
+		}
+		key := path.Join(queryStatsPrefix, nodeID, strconv.FormatInt(stats.CollectionID, 10))
+		if err := metaKV.Save(ctx, key, string(bytes)); err != nil {
+			log.Warn("failed to persist query stats", zap.Int64("collectionID", stats.CollectionID), zap.Error(err))
+			c.mu.Lock()
+			c.dirty.Insert(stats.CollectionID)
+			c.mu.Unlock()
+		}
+	}
+
+	keys, values, err := metaKV.LoadWithPrefix(ctx, queryStatsPrefix)
+	if err != nil {
+		log.Warn("failed to load persisted query stats", zap.Error(err))
+		return
+	}
+	for i, value := range values {
+		stats := &QueryPatternStats{}
+		if err := json.Unmarshal([]byte(value), stats); err == nil && !isQueryStatsExpired(stats) {
+			continue
+		}
+		if err := metaKV.Remove(ctx, keys[i]); err != nil {
+			log.Warn("failed to remove expired query stats", zap.String("key", keys[i]), zap.Error(err))
+		}
+	}
+}
+
+func isQueryStatsExpired(stats *QueryPatternStats) bool {
+	retention := paramtable.Get().ProxyCfg.QueryStatsRetention.GetAsDuration(time.Hour)
+	return time.Since(time.Unix(stats.UpdateTime, 0)) > retention
+}
+
+// loadQueryPatternStats merges the persisted statistics of all proxies on the collection.
+func loadQueryPatternStats(ctx context.Context, metaKV kv.MetaKv, collectionID int64) (*QueryPatternStats, error) {
+	_, values, err := metaKV.LoadWithPrefix(ctx, queryStatsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	result := newQueryPatternStats(collectionID)
+	for _, value := range values {
+		stats := &QueryPatternStats{}
+		if err := json.Unmarshal([]byte(value), stats); err != nil {
+			continue
+		}
+		if stats.CollectionID != collectionID || isQueryStatsExpired(stats) {
+			continue
+		}
+		result.merge(stats)
+	}
+	return result, nil
+}
@@ -0,0 +1,147 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func newTestFilterExpr(fieldIDs ...int64) *planpb.Expr {
+	exprs := make([]*planpb.Expr, 0, len(fieldIDs))
+	for _, fieldID := range fieldIDs {
+		exprs = append(exprs, &planpb.Expr{
+			Expr: &planpb.Expr_UnaryRangeExpr{
+				UnaryRangeExpr: &planpb.UnaryRangeExpr{
+					ColumnInfo: &planpb.ColumnInfo{FieldId: fieldID},
+					Op:         planpb.OpType_Equal,
+					Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 1}},
+				},
+			},
+		})
+	}
+	expr := exprs[0]
+	for _, right := range exprs[1:] {
+		expr = &planpb.Expr{
+			Expr: &planpb.Expr_BinaryExpr{
+				BinaryExpr: &planpb.BinaryExpr{Op: planpb.BinaryExpr_LogicalAnd, Left: expr, Right: right},
+			},
+		}
+	}
+	return expr
+}
+
+func TestCollectFilterFieldIDs(t *testing.T) {
+	assert.Empty(t, collectFilterFieldIDs(nil))
+	assert.ElementsMatch(t, []int64{101, 102}, collectFilterFieldIDs(newTestFilterExpr(101, 102, 101)))
+}
+
+func TestQueryStatsCollector(t *testing.T) {
+	paramtable.Init()
+
+	assert.Equal(t, "1-10", topKBucket(10))
+	assert.Equal(t, "101-1000", topKBucket(101))
+	assert.Equal(t, "10001+", topKBucket(20000))
+	assert.ElementsMatch(t, []string{"nprobe=16", "ef=64"}, parseRecallProbeParams(`{"nprobe": 16, "ef": 64, "radius": 1}`))
+	assert.Empty(t, parseRecallProbeParams("invalid"))
+
+	// nil collector is a no-op
+	var nilCollector *queryStatsCollector
+	nilCollector.RecordQuery(1, &planpb.PlanNode{})
+
+	collector := newQueryStatsCollector()
+	searchPlan := &planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{Predicates: newTestFilterExpr(101)},
+		},
+	}
+	collector.RecordSearch(1, searchPlan, &planpb.QueryInfo{
+		Topk:         10,
+		MetricType:   "L2",
+		SearchParams: `{"nprobe": 16}`,
+		QueryFieldId: 100,
+	})
+	queryPlan := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Query{
+			Query: &planpb.QueryPlanNode{Predicates: newTestFilterExpr(101, 102)},
+		},
+	}
+	collector.RecordQuery(1, queryPlan)
+
+	stats := collector.stats[1]
+	assert.EqualValues(t, 1, stats.SearchCount)
+	assert.EqualValues(t, 1, stats.QueryCount)
+	assert.EqualValues(t, 2, stats.FilterFields[101])
+	assert.EqualValues(t, 1, stats.FilterFields[102])
+	assert.EqualValues(t, 1, stats.TopK["1-10"])
+	assert.EqualValues(t, 1, stats.MetricTypes["L2"])
+	assert.EqualValues(t, 1, stats.SearchParams[100]["nprobe=16"])
+	assert.True(t, collector.dirty.Contain(1))
+
+	merged := stats.clone()
+	merged.merge(stats)
+	assert.EqualValues(t, 2, merged.SearchCount)
+	assert.EqualValues(t, 4, merged.FilterFields[101])
+}
+
+func TestAdviseIndexes(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "tenant", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Double},
+			{FieldID: 103, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	indexes := []*indexpb.IndexInfo{
+		{
+			FieldID: 103,
+			IndexParams: []*commonpb.KeyValuePair{
+				{Key: common.IndexTypeKey, Value: "IVF_FLAT"},
+				{Key: "nlist", Value: "64"},
+			},
+		},
+		{FieldID: 102},
+	}
+
+	stats := newQueryPatternStats(1)
+	stats.SearchCount = 10
+	assert.Empty(t, adviseIndexes(schema, indexes, stats))
+
+	stats.SearchCount = 1000
+	stats.FilterFields[100] = 1000
+	stats.FilterFields[101] = 900
+	stats.FilterFields[102] = 500
+	stats.SearchParams[103] = map[string]int64{"nprobe=32": 800, "nprobe=4": 200}
+
+	advices := adviseIndexes(schema, indexes, stats)
+	assert.Len(t, advices, 3)
+	assert.Equal(t, AdviceScalarIndex, advices[0].Kind)
+	assert.Equal(t, "tenant", advices[0].Field)
+	assert.Equal(t, AdvicePartitionKey, advices[1].Kind)
+	assert.Equal(t, "tenant", advices[1].Field)
+	assert.Equal(t, AdviceIndexParams, advices[2].Kind)
+	assert.Equal(t, "vec", advices[2].Field)
+}
@@ -133,6 +133,16 @@ func TestRateLimitInterceptor(t *testing.T) {
 		assert.True(t, len(col2part) == 1)
 		assert.Equal(t, int64(10), col2part[1][0])
 
+		database, col2part, rt, _, err = GetRequestInfo(context.Background(), &internalpb.ImportRequest{
+			CollectionName: "foo",
+			PartitionName:  "p1",
+			DbName:         "db1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, internalpb.RateType_DMLBulkLoad, rt)
+		assert.Equal(t, database, int64(100))
+		assert.Equal(t, int64(10), col2part[1][0])
+
 		database, col2part, rt, size, err = GetRequestInfo(context.Background(), &milvuspb.SearchRequest{
 			Nq: 5,
 			PartitionNames: []string{
@@ -19,5 +19,8 @@ func createMilvusReducer(ctx context.Context, params *queryParams, req *internal
 			collectionName: collectionName,
 		}
 	}
+	if params != nil && len(params.orderBy) > 0 {
+		return newOrderByReducer(ctx, params, req, schema, collectionName)
+	}
 	return newDefaultLimitReducer(ctx, params, req, schema, collectionName)
 }
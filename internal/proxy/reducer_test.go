@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 )
 
@@ -24,6 +25,10 @@ func Test_createMilvusReducer(t *testing.T) {
 	_, ok := r.(*defaultLimitReducer)
 	assert.True(t, ok)
 
+	r = createMilvusReducer(ctx, &queryParams{orderBy: []*internalpb.OrderByField{{FieldID: 101}}}, nil, nil, n, "")
+	_, ok = r.(*orderByReducer)
+	assert.True(t, ok)
+
 	n.Node.(*planpb.PlanNode_Query).Query.IsCount = true
 	r = createMilvusReducer(ctx, nil, nil, nil, n, "")
 	_, ok = r.(*cntReducer)
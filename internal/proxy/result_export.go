@@ -0,0 +1,695 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	resultExportFormat       = "parquet"
+	resultExportDir          = "result_export"
+	resultExportManifestFile = "manifest.json"
+
+	// the extra columns of the exported search results.
+	resultExportQueryIndexColumn = "query_index"
+	resultExportIDColumn         = "id"
+	resultExportDistanceColumn   = "distance"
+)
+
+// ResultExportDestination is where the results are exported into.
+type ResultExportDestination struct {
+	// Bucket is the bucket of the object storage, the bucket of milvus if empty.
+	Bucket string `json:"bucket"`
+	// Path is the prefix of the objects exported.
+	Path string `json:"path"`
+}
+
+// ResultExportFile is a parquet file of the results exported.
+type ResultExportFile struct {
+	Path string `json:"path"`
+	Rows int64  `json:"rows"`
+	Size int64  `json:"size"`
+}
+
+// ResultExportManifest describes the files the results are exported into,
+// it's returned to the caller and written along with the files.
+type ResultExportManifest struct {
+	// ExportID identifies the export, the files are written under the path of the destination joined with it.
+	ExportID string             `json:"exportID"`
+	Bucket   string             `json:"bucket"`
+	Path     string             `json:"path"`
+	Format   string             `json:"format"`
+	Columns  []string           `json:"columns"`
+	Rows     int64              `json:"rows"`
+	Files    []ResultExportFile `json:"files"`
+}
+
+// ExportQueryResults runs the query page by page in the order of the primary key with the query iterator,
+// and streams each page into the parquet files of the export once it's received,
+// so the results are neither held in the proxy nor limited by the max query result window and the grpc message size.
+// All the pages are read at the timestamp of the first one, the limit of the request caps the rows exported if set.
+func ExportQueryResults(ctx context.Context, dest *ResultExportDestination, req *milvuspb.QueryRequest, pkName string,
+	query func(context.Context, *milvuspb.QueryRequest) (*milvuspb.QueryResults, error),
+) (*ResultExportManifest, error) {
+	exporter, err := newResultExporter(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+	return exporter.exportQuery(req, pkName, query)
+}
+
+// ExportSearchResults searches the query vectors of the request in batches,
+// and streams the results of each batch into the parquet files of the export once they're received,
+// with the index of the query vector, the primary key and the distance of each hit ahead of the output fields.
+// Each batch is searched at the consistency level of the request separately.
+func ExportSearchResults(ctx context.Context, dest *ResultExportDestination, req *milvuspb.SearchRequest, pkName string,
+	search func(context.Context, *milvuspb.SearchRequest) (*milvuspb.SearchResults, error),
+) (*ResultExportManifest, error) {
+	exporter, err := newResultExporter(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+	return exporter.exportSearch(req, pkName, search)
+}
+
+// exportSearchResultData converts the search results into the columns exported,
+// the query vectors of the results start from the index of firstQuery among the ones of the request.
+func exportSearchResultData(mem memory.Allocator, data *schemapb.SearchResultData, pkName string, firstQuery int64) ([]arrow.Field, []arrow.Array, error) {
+	fields, arrays, err := exportFieldsData(mem, data.GetFieldsData())
+	if err != nil {
+		return nil, nil, err
+	}
+	hits := lo.SumBy(data.GetTopks(), func(topk int64) int64 { return topk })
+	if int64(len(data.GetScores())) != hits {
+		releaseArrays(arrays)
+		return nil, nil, merr.WrapErrServiceInternal(fmt.Sprintf("search results have %d scores but %d hits", len(data.GetScores()), hits))
+	}
+
+	queryIndexes := array.NewInt64Builder(mem)
+	defer queryIndexes.Release()
+	for i, topk := range data.GetTopks() {
+		for j := int64(0); j < topk; j++ {
+			queryIndexes.Append(firstQuery + int64(i))
+		}
+	}
+	distances := array.NewFloat32Builder(mem)
+	defer distances.Release()
+	distances.AppendValues(data.GetScores(), nil)
+
+	extraFields := []arrow.Field{{Name: resultExportQueryIndexColumn, Type: arrow.PrimitiveTypes.Int64}}
+	extraArrays := []arrow.Array{queryIndexes.NewArray()}
+	if pkName == "" {
+		pkName = resultExportIDColumn
+	}
+	if !lo.ContainsBy(fields, func(f arrow.Field) bool { return f.Name == pkName }) {
+		switch ids := data.GetIds().GetIdField().(type) {
+		case *schemapb.IDs_IntId:
+			b := array.NewInt64Builder(mem)
+			b.AppendValues(ids.IntId.GetData(), nil)
+			extraFields = append(extraFields, arrow.Field{Name: pkName, Type: arrow.PrimitiveTypes.Int64})
+			extraArrays = append(extraArrays, b.NewArray())
+			b.Release()
+		case *schemapb.IDs_StrId:
+			b := array.NewStringBuilder(mem)
+			b.AppendValues(ids.StrId.GetData(), nil)
+			extraFields = append(extraFields, arrow.Field{Name: pkName, Type: arrow.BinaryTypes.String})
+			extraArrays = append(extraArrays, b.NewArray())
+			b.Release()
+		}
+	}
+	extraFields = append(extraFields, arrow.Field{Name: resultExportDistanceColumn, Type: arrow.PrimitiveTypes.Float32})
+	extraArrays = append(extraArrays, distances.NewArray())
+
+	fields = append(extraFields, fields...)
+	arrays = append(extraArrays, arrays...)
+	if err := checkExportColumns(fields, arrays); err != nil {
+		releaseArrays(arrays)
+		return nil, nil, err
+	}
+	return fields, arrays, nil
+}
+
+func exportFieldsData(mem memory.Allocator, fieldsData []*schemapb.FieldData) ([]arrow.Field, []arrow.Array, error) {
+	fields := make([]arrow.Field, 0, len(fieldsData))
+	arrays := make([]arrow.Array, 0, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		field, arr, err := exportFieldData(mem, fieldData)
+		if err != nil {
+			releaseArrays(arrays)
+			return nil, nil, err
+		}
+		fields = append(fields, field)
+		arrays = append(arrays, arr)
+	}
+	if err := checkExportColumns(fields, arrays); err != nil {
+		releaseArrays(arrays)
+		return nil, nil, err
+	}
+	return fields, arrays, nil
+}
+
+// checkExportColumns checks the columns have unique names and the same rows.
+func checkExportColumns(fields []arrow.Field, arrays []arrow.Array) error {
+	names := make(map[string]struct{}, len(fields))
+	for i, field := range fields {
+		if _, ok := names[field.Name]; ok {
+			return merr.WrapErrParameterInvalidMsg("column %s is exported more than once", field.Name)
+		}
+		names[field.Name] = struct{}{}
+		if arrays[i].Len() != arrays[0].Len() {
+			return merr.WrapErrServiceInternal(fmt.Sprintf("column %s has %d rows, but %s has %d rows",
+				field.Name, arrays[i].Len(), fields[0].Name, arrays[0].Len()))
+		}
+	}
+	return nil
+}
+
+// exportFieldData converts the field data into an arrow array.
+// The scalars are converted to the arrow types of the same width, the json to string,
+// the float vectors to fixed size lists, and the other vectors to binaries.
+func exportFieldData(mem memory.Allocator, fieldData *schemapb.FieldData) (arrow.Field, arrow.Array, error) {
+	var (
+		builder  array.Builder
+		appendAt func(i int)
+		dataLen  int
+	)
+	scalars := fieldData.GetScalars()
+	vectors := fieldData.GetVectors()
+	dim := int(vectors.GetDim())
+	switch fieldData.GetType() {
+	case schemapb.DataType_Bool:
+		b, data := array.NewBooleanBuilder(mem), scalars.GetBoolData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	case schemapb.DataType_Int8:
+		b, data := array.NewInt8Builder(mem), scalars.GetIntData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(int8(data[i])) }
+	case schemapb.DataType_Int16:
+		b, data := array.NewInt16Builder(mem), scalars.GetIntData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(int16(data[i])) }
+	case schemapb.DataType_Int32:
+		b, data := array.NewInt32Builder(mem), scalars.GetIntData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	case schemapb.DataType_Int64:
+		b, data := array.NewInt64Builder(mem), scalars.GetLongData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	case schemapb.DataType_Float:
+		b, data := array.NewFloat32Builder(mem), scalars.GetFloatData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	case schemapb.DataType_Double:
+		b, data := array.NewFloat64Builder(mem), scalars.GetDoubleData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		b, data := array.NewStringBuilder(mem), scalars.GetStringData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	case schemapb.DataType_JSON:
+		b, data := array.NewStringBuilder(mem), scalars.GetJsonData().GetData()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(string(data[i])) }
+	case schemapb.DataType_FloatVector:
+		if dim <= 0 {
+			return arrow.Field{}, nil, merr.WrapErrParameterInvalidMsg("invalid dim %d of field %s", dim, fieldData.GetFieldName())
+		}
+		b, data := array.NewFixedSizeListBuilder(mem, int32(dim), arrow.PrimitiveTypes.Float32), vectors.GetFloatVector().GetData()
+		values := b.ValueBuilder().(*array.Float32Builder)
+		builder, dataLen, appendAt = b, len(data)/dim, func(i int) {
+			b.Append(true)
+			values.AppendValues(data[i*dim:(i+1)*dim], nil)
+		}
+	case schemapb.DataType_BinaryVector, schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
+		var data []byte
+		width := dim * 2
+		switch fieldData.GetType() {
+		case schemapb.DataType_BinaryVector:
+			data, width = vectors.GetBinaryVector(), dim/8
+		case schemapb.DataType_Float16Vector:
+			data = vectors.GetFloat16Vector()
+		default:
+			data = vectors.GetBfloat16Vector()
+		}
+		if width <= 0 {
+			return arrow.Field{}, nil, merr.WrapErrParameterInvalidMsg("invalid dim %d of field %s", dim, fieldData.GetFieldName())
+		}
+		b := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: width})
+		builder, dataLen, appendAt = b, len(data)/width, func(i int) { b.Append(data[i*width : (i+1)*width]) }
+	case schemapb.DataType_SparseFloatVector:
+		b, data := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary), vectors.GetSparseFloatVector().GetContents()
+		builder, dataLen, appendAt = b, len(data), func(i int) { b.Append(data[i]) }
+	default:
+		return arrow.Field{}, nil, merr.WrapErrParameterInvalidMsg("field %s of type %s can't be exported",
+			fieldData.GetFieldName(), fieldData.GetType().String())
+	}
+	defer builder.Release()
+
+	// the values of the nulls may be either present or omitted.
+	validData := fieldData.GetValidData()
+	rows := dataLen
+	if len(validData) > 0 {
+		rows = len(validData)
+		if dataLen != rows && dataLen != lo.Count(validData, true) {
+			return arrow.Field{}, nil, merr.WrapErrServiceInternal(fmt.Sprintf("field %s has %d values for %d rows",
+				fieldData.GetFieldName(), dataLen, rows))
+		}
+	}
+	pos := 0
+	for i := 0; i < rows; i++ {
+		if len(validData) > 0 && !validData[i] {
+			builder.AppendNull()
+			if dataLen == rows {
+				pos++
+			}
+			continue
+		}
+		appendAt(pos)
+		pos++
+	}
+	arr := builder.NewArray()
+	return arrow.Field{Name: fieldData.GetFieldName(), Type: arr.DataType(), Nullable: len(validData) > 0}, arr, nil
+}
+
+func releaseArrays(arrays []arrow.Array) {
+	for _, arr := range arrays {
+		arr.Release()
+	}
+}
+
+// resultExporter writes the columns received into the parquet files under the path of an export,
+// each file is streamed into the object storage while it's written,
+// and a new one is started once it has proxy.resultExport.maxRowsPerFile rows.
+type resultExporter struct {
+	ctx      context.Context
+	cm       storage.ChunkManager
+	manifest *ResultExportManifest
+	maxRows  int64
+
+	schema *arrow.Schema
+	file   *resultExportFileWriter
+	// the query vectors exported
+	queries int64
+}
+
+// newResultExporter creates the exporter of a request, the files are written under a path unique to the export,
+// so the exports into the same destination never overwrite each other.
+func newResultExporter(ctx context.Context, dest *ResultExportDestination) (*resultExporter, error) {
+	cm, bucket, prefix, err := resultExportChunkManager(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+	exportID := fmt.Sprintf("%d-%x", time.Now().UnixMilli(), funcutil.GenRandomBytesWithLength(4))
+	return newResultExporterWithChunkManager(ctx, cm, bucket, path.Join(prefix, exportID), exportID), nil
+}
+
+func newResultExporterWithChunkManager(ctx context.Context, cm storage.ChunkManager, bucket, prefix, exportID string) *resultExporter {
+	maxRows := paramtable.Get().ProxyCfg.ResultExportMaxRowsPerFile.GetAsInt64()
+	if maxRows <= 0 {
+		maxRows = math.MaxInt64
+	}
+	return &resultExporter{
+		ctx: ctx,
+		cm:  cm,
+		manifest: &ResultExportManifest{
+			ExportID: exportID,
+			Bucket:   bucket,
+			Path:     prefix,
+			Format:   resultExportFormat,
+		},
+		maxRows: maxRows,
+	}
+}
+
+// resultExportChunkManager returns the chunk manager of the bucket the results are exported into,
+// the bucket and the prefix of the objects in the bucket.
+func resultExportChunkManager(ctx context.Context, dest *ResultExportDestination) (storage.ChunkManager, string, string, error) {
+	params := paramtable.Get()
+	if !params.ProxyCfg.ResultExportEnabled.GetAsBool() {
+		return nil, "", "", merr.WrapErrServiceUnavailable("result export is disabled", params.ProxyCfg.ResultExportEnabled.Key)
+	}
+	if dest == nil || strings.Trim(dest.Path, "/") == "" {
+		return nil, "", "", merr.WrapErrParameterInvalidMsg("the path to export the results into is required")
+	}
+	prefix := path.Clean(strings.Trim(dest.Path, "/"))
+	if prefix == ".." || strings.HasPrefix(prefix, "../") {
+		return nil, "", "", merr.WrapErrParameterInvalidMsg("invalid path %s to export the results into", dest.Path)
+	}
+
+	if dest.Bucket == "" || dest.Bucket == params.MinioCfg.BucketName.GetValue() {
+		cm, err := storage.NewChunkManagerFactoryWithParam(params).NewPersistentStorageChunkManager(ctx)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return cm, params.MinioCfg.BucketName.GetValue(), path.Join(cm.RootPath(), resultExportDir, prefix), nil
+	}
+	if !lo.Contains(params.ProxyCfg.ResultExportAllowedBuckets.GetAsStrings(), dest.Bucket) {
+		return nil, "", "", merr.WrapErrParameterInvalidMsg("bucket %s is not allowed to export the results into", dest.Bucket)
+	}
+	cm, err := storage.NewChunkManagerFactoryWithParam(params,
+		storage.BucketName(dest.Bucket), storage.CreateBucket(false)).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return cm, dest.Bucket, prefix, nil
+}
+
+func (e *resultExporter) exportQuery(req *milvuspb.QueryRequest, pkName string,
+	query func(context.Context, *milvuspb.QueryRequest) (*milvuspb.QueryResults, error),
+) (*ResultExportManifest, error) {
+	limit := int64(-1)
+	params := make([]*commonpb.KeyValuePair, 0, len(req.GetQueryParams()))
+	for _, kv := range req.GetQueryParams() {
+		switch kv.GetKey() {
+		case LimitKey:
+			var err error
+			if limit, err = strconv.ParseInt(kv.GetValue(), 0, 64); err != nil || limit <= 0 {
+				return nil, merr.WrapErrParameterInvalidMsg("%s [%s] is invalid", LimitKey, kv.GetValue())
+			}
+		case OffsetKey:
+			if kv.GetValue() != "0" {
+				return nil, merr.WrapErrParameterInvalidMsg("%s isn't supported by the export", OffsetKey)
+			}
+		case IteratorField, ReduceStopForBestKey:
+		default:
+			params = append(params, kv)
+		}
+	}
+
+	pageSize := Params.QuotaConfig.MaxQueryResultWindow.GetAsInt64()
+	var (
+		lastPK    any
+		sessionTs uint64
+	)
+	for {
+		pageLimit := pageSize
+		if limit > 0 {
+			pageLimit = min(pageLimit, limit-e.manifest.Rows)
+		}
+		pageReq := proto.Clone(req).(*milvuspb.QueryRequest)
+		pageReq.QueryParams = append(append([]*commonpb.KeyValuePair{}, params...),
+			&commonpb.KeyValuePair{Key: LimitKey, Value: strconv.FormatInt(pageLimit, 10)},
+			&commonpb.KeyValuePair{Key: IteratorField, Value: "true"})
+		if lastPK != nil {
+			pageReq.Expr = dedupPageExpr(pkName, lastPK)
+			if expr := strings.TrimSpace(req.GetExpr()); expr != "" {
+				pageReq.Expr = fmt.Sprintf("(%s) and %s", expr, pageReq.Expr)
+			}
+			pageReq.GuaranteeTimestamp = sessionTs
+		}
+		resp, err := query(e.ctx, pageReq)
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			e.abort(err)
+			return nil, err
+		}
+		// the following pages read the snapshot of the first one
+		if sessionTs == 0 {
+			sessionTs = resp.GetSessionTs()
+		}
+
+		pkData, ok := lo.Find(resp.GetFieldsData(), func(fieldData *schemapb.FieldData) bool {
+			return fieldData.GetFieldName() == pkName
+		})
+		// no fields are returned if there are no rows
+		if !ok && len(resp.GetFieldsData()) > 0 {
+			err := merr.WrapErrServiceInternal(fmt.Sprintf("primary key %s is missing in the query results", pkName))
+			e.abort(err)
+			return nil, err
+		}
+		num := typeutil.GetPKSize(pkData)
+		if err := e.writeFieldsData(func(mem memory.Allocator) ([]arrow.Field, []arrow.Array, error) {
+			return exportFieldsData(mem, resp.GetFieldsData())
+		}); err != nil {
+			e.abort(err)
+			return nil, err
+		}
+		if int64(num) < pageLimit || (limit > 0 && e.manifest.Rows >= limit) {
+			break
+		}
+		lastPK = typeutil.GetData(pkData, num-1)
+	}
+	return e.finish()
+}
+
+func (e *resultExporter) exportSearch(req *milvuspb.SearchRequest, pkName string,
+	search func(context.Context, *milvuspb.SearchRequest) (*milvuspb.SearchResults, error),
+) (*ResultExportManifest, error) {
+	placeholderGroup := &commonpb.PlaceholderGroup{}
+	if err := proto.Unmarshal(req.GetPlaceholderGroup(), placeholderGroup); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid placeholder group: %s", err.Error())
+	}
+	if len(placeholderGroup.GetPlaceholders()) != 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("only one placeholder is supported by the export, got %d",
+			len(placeholderGroup.GetPlaceholders()))
+	}
+	placeholder := placeholderGroup.GetPlaceholders()[0]
+	topK := int64(1)
+	if topKStr, err := funcutil.GetAttrByKeyFromRepeatedKV(TopKKey, req.GetSearchParams()); err == nil {
+		if topK, err = strconv.ParseInt(topKStr, 0, 64); err != nil || topK <= 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("%s [%s] is invalid", TopKKey, topKStr)
+		}
+	}
+	// the results of each batch are about the size of a page of the query
+	batchSize := max(1, int(Params.QuotaConfig.MaxQueryResultWindow.GetAsInt64()/topK))
+
+	for _, batch := range lo.Chunk(placeholder.GetValues(), batchSize) {
+		batchReq := proto.Clone(req).(*milvuspb.SearchRequest)
+		batchReq.PlaceholderGroup, _ = proto.Marshal(&commonpb.PlaceholderGroup{
+			Placeholders: []*commonpb.PlaceholderValue{{
+				Tag:    placeholder.GetTag(),
+				Type:   placeholder.GetType(),
+				Values: batch,
+			}},
+		})
+		batchReq.Nq = int64(len(batch))
+		resp, err := search(e.ctx, batchReq)
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			e.abort(err)
+			return nil, err
+		}
+		firstQuery := e.queries
+		if err := e.writeFieldsData(func(mem memory.Allocator) ([]arrow.Field, []arrow.Array, error) {
+			return exportSearchResultData(mem, resp.GetResults(), pkName, firstQuery)
+		}); err != nil {
+			e.abort(err)
+			return nil, err
+		}
+		e.queries += int64(len(batch))
+	}
+	return e.finish()
+}
+
+// writeFieldsData writes the columns converted into the files of the export and releases them.
+func (e *resultExporter) writeFieldsData(convert func(mem memory.Allocator) ([]arrow.Field, []arrow.Array, error)) error {
+	fields, arrays, err := convert(memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	defer releaseArrays(arrays)
+
+	schema := arrow.NewSchema(fields, nil)
+	if e.schema == nil {
+		e.schema = schema
+		e.manifest.Columns = lo.Map(fields, func(f arrow.Field, _ int) string { return f.Name })
+	} else if !e.schema.Equal(schema) {
+		return merr.WrapErrServiceInternal(fmt.Sprintf("the columns of the results changed from %s to %s", e.schema, schema))
+	}
+	rows := int64(0)
+	if len(arrays) > 0 {
+		rows = int64(arrays[0].Len())
+	}
+	record := array.NewRecord(schema, arrays, rows)
+	defer record.Release()
+
+	for start := int64(0); start < rows; {
+		if e.file == nil {
+			if err := e.openFile(); err != nil {
+				return err
+			}
+		}
+		end := min(rows, start+e.maxRows-e.file.rows)
+		if err := e.file.write(record.NewSlice(start, end)); err != nil {
+			return err
+		}
+		start = end
+		if e.file.rows >= e.maxRows {
+			if err := e.closeFile(); err != nil {
+				return err
+			}
+		}
+	}
+	e.manifest.Rows += rows
+	return nil
+}
+
+func (e *resultExporter) openFile() error {
+	if e.schema == nil {
+		e.schema = arrow.NewSchema(nil, nil)
+	}
+	filePath := path.Join(e.manifest.Path, fmt.Sprintf("part-%05d.%s", len(e.manifest.Files), resultExportFormat))
+	file, err := newResultExportFileWriter(e.ctx, e.cm, filePath, e.schema)
+	if err != nil {
+		return err
+	}
+	e.file = file
+	return nil
+}
+
+func (e *resultExporter) closeFile() error {
+	file := e.file
+	e.file = nil
+	if err := file.close(); err != nil {
+		return err
+	}
+	e.manifest.Files = append(e.manifest.Files, ResultExportFile{Path: file.path, Rows: file.rows, Size: file.size})
+	return nil
+}
+
+// finish closes the file being written and writes the manifest,
+// an empty file is written even if there are no rows, for the schema.
+func (e *resultExporter) finish() (*ResultExportManifest, error) {
+	if e.file == nil && len(e.manifest.Files) == 0 {
+		if err := e.openFile(); err != nil {
+			e.abort(err)
+			return nil, err
+		}
+	}
+	if e.file != nil {
+		if err := e.closeFile(); err != nil {
+			e.abort(err)
+			return nil, err
+		}
+	}
+
+	content, err := json.Marshal(e.manifest)
+	if err != nil {
+		e.abort(err)
+		return nil, err
+	}
+	if err := e.cm.Write(e.ctx, path.Join(e.manifest.Path, resultExportManifestFile), content); err != nil {
+		e.abort(err)
+		return nil, err
+	}
+	log.Ctx(e.ctx).Info("results exported", zap.String("bucket", e.manifest.Bucket), zap.String("path", e.manifest.Path),
+		zap.Int64("rows", e.manifest.Rows), zap.Int("files", len(e.manifest.Files)))
+	return e.manifest, nil
+}
+
+// abort stops the file being written and removes the files of the export written.
+func (e *resultExporter) abort(err error) {
+	if e.file != nil {
+		e.file.abort(err)
+		e.file = nil
+	}
+	// the export path is unique to the export, so only the files of it are removed.
+	if err := e.cm.RemoveWithPrefix(context.WithoutCancel(e.ctx), e.manifest.Path+"/"); err != nil {
+		log.Ctx(e.ctx).Warn("failed to remove the files of the export aborted", zap.String("path", e.manifest.Path), zap.Error(err))
+	}
+}
+
+// resultExportFileWriter writes the records into a parquet file,
+// which is streamed into the object storage through a pipe while it's written.
+type resultExportFileWriter struct {
+	path string
+	rows int64
+	size int64
+
+	pipe    *io.PipeWriter
+	writer  *pqarrow.FileWriter
+	written chan error
+}
+
+func newResultExportFileWriter(ctx context.Context, cm storage.ChunkManager, filePath string, schema *arrow.Schema) (*resultExportFileWriter, error) {
+	reader, pipe := io.Pipe()
+	w := &resultExportFileWriter{
+		path:    filePath,
+		pipe:    pipe,
+		written: make(chan error, 1),
+	}
+	go func() {
+		err := cm.WriteStream(ctx, filePath, reader)
+		// fails the writes not read yet if the upload fails
+		reader.CloseWithError(lo.Ternary(err != nil, err, io.ErrClosedPipe))
+		w.written <- err
+	}()
+
+	writer, err := pqarrow.NewFileWriter(schema, &countingWriter{w: pipe, n: &w.size}, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+	if err != nil {
+		w.abort(err)
+		return nil, err
+	}
+	w.writer = writer
+	return w, nil
+}
+
+func (w *resultExportFileWriter) write(record arrow.Record) error {
+	defer record.Release()
+	if err := w.writer.Write(record); err != nil {
+		return err
+	}
+	w.rows += record.NumRows()
+	return nil
+}
+
+// close writes the footer of the parquet file and waits until the file is uploaded.
+func (w *resultExportFileWriter) close() error {
+	if err := w.writer.Close(); err != nil {
+		w.abort(err)
+		return err
+	}
+	w.pipe.Close()
+	return <-w.written
+}
+
+func (w *resultExportFileWriter) abort(err error) {
+	w.pipe.CloseWithError(err)
+	<-w.written
+}
+
+// countingWriter counts the bytes written, it doesn't close the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,358 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestExportFieldData(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	fieldsData := []*schemapb.FieldData{
+		{
+			FieldName: "int8",
+			Type:      schemapb.DataType_Int8,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{1, 2, 3}}},
+			}},
+		},
+		{
+			FieldName: "str",
+			Type:      schemapb.DataType_VarChar,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "", "c"}}},
+			}},
+			ValidData: []bool{true, false, true},
+		},
+		{
+			// the values of the nulls omitted
+			FieldName: "double",
+			Type:      schemapb.DataType_Double,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{0.5, 1.5}}},
+			}},
+			ValidData: []bool{false, true, true},
+		},
+		{
+			FieldName: "json",
+			Type:      schemapb.DataType_JSON,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: [][]byte{[]byte(`{"a":1}`), []byte(`{}`), []byte(`[]`)}}},
+			}},
+		},
+		{
+			FieldName: "vec",
+			Type:      schemapb.DataType_FloatVector,
+			Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+				Dim:  2,
+				Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4, 5, 6}}},
+			}},
+		},
+		{
+			FieldName: "bvec",
+			Type:      schemapb.DataType_BinaryVector,
+			Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+				Dim:  16,
+				Data: &schemapb.VectorField_BinaryVector{BinaryVector: []byte{1, 2, 3, 4, 5, 6}},
+			}},
+		},
+	}
+	fields, arrays, err := exportFieldsData(mem, fieldsData)
+	require.NoError(t, err)
+	defer releaseArrays(arrays)
+
+	assert.Equal(t, []string{"int8", "str", "double", "json", "vec", "bvec"}, []string{
+		fields[0].Name, fields[1].Name, fields[2].Name, fields[3].Name, fields[4].Name, fields[5].Name,
+	})
+	assert.Equal(t, []int8{1, 2, 3}, arrays[0].(*array.Int8).Int8Values())
+
+	str := arrays[1].(*array.String)
+	assert.True(t, fields[1].Nullable)
+	assert.True(t, str.IsNull(1))
+	assert.Equal(t, "c", str.Value(2))
+
+	double := arrays[2].(*array.Float64)
+	assert.True(t, double.IsNull(0))
+	assert.Equal(t, 0.5, double.Value(1))
+	assert.Equal(t, 1.5, double.Value(2))
+
+	assert.Equal(t, `{"a":1}`, arrays[3].(*array.String).Value(0))
+
+	vec := arrays[4].(*array.FixedSizeList)
+	assert.Equal(t, 3, vec.Len())
+	assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, vec.ListValues().(*array.Float32).Float32Values())
+
+	bvec := arrays[5].(*array.FixedSizeBinary)
+	assert.Equal(t, []byte{3, 4}, bvec.Value(1))
+
+	// arrays can't be exported
+	_, _, err = exportFieldsData(mem, []*schemapb.FieldData{{FieldName: "arr", Type: schemapb.DataType_Array}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the rows mismatch
+	_, arrays, err = exportFieldsData(mem, fieldsData[:1:1])
+	assert.NoError(t, err)
+	releaseArrays(arrays)
+	_, _, err = exportFieldsData(mem, append(fieldsData[:1:1], &schemapb.FieldData{
+		FieldName: "long",
+		Type:      schemapb.DataType_Int64,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1}}},
+		}},
+	}))
+	assert.Error(t, err)
+}
+
+func TestExportSearchResultData(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	data := &schemapb.SearchResultData{
+		NumQueries: 2,
+		TopK:       2,
+		Topks:      []int64{2, 1},
+		Scores:     []float32{0.9, 0.8, 0.7},
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a", "b", "c"}}}},
+		FieldsData: []*schemapb.FieldData{
+			{
+				FieldName: "tag",
+				Type:      schemapb.DataType_Int64,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{10, 20, 30}}},
+				}},
+			},
+		},
+	}
+	fields, arrays, err := exportSearchResultData(mem, data, "pk", 4)
+	require.NoError(t, err)
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"query_index", "pk", "distance", "tag"}, names)
+	assert.Equal(t, []int64{4, 4, 5}, arrays[0].(*array.Int64).Int64Values())
+	assert.Equal(t, "b", arrays[1].(*array.String).Value(1))
+	assert.Equal(t, []float32{0.9, 0.8, 0.7}, arrays[2].(*array.Float32).Float32Values())
+	releaseArrays(arrays)
+
+	// the pk is among the output fields already
+	fields, arrays, err = exportSearchResultData(mem, data, "tag", 0)
+	require.NoError(t, err)
+	assert.Len(t, fields, 3)
+	releaseArrays(arrays)
+
+	// the scores mismatch the topks
+	data.Topks = []int64{2, 2}
+	_, _, err = exportSearchResultData(mem, data, "pk", 0)
+	assert.Error(t, err)
+}
+
+func TestResultExportChunkManager(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	_, _, _, err := resultExportChunkManager(ctx, &ResultExportDestination{Path: "a"})
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+
+	key := paramtable.Get().ProxyCfg.ResultExportEnabled.Key
+	paramtable.Get().Save(key, "true")
+	defer paramtable.Get().Reset(key)
+	for _, dest := range []*ResultExportDestination{
+		nil,
+		{Path: "/"},
+		{Path: "../a"},
+		{Bucket: "unknown", Path: "a"},
+	} {
+		_, _, _, err = resultExportChunkManager(ctx, dest)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid, dest)
+	}
+}
+
+func longFieldData(name string, data []int64) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		FieldName: name,
+		Type:      schemapb.DataType_Int64,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}},
+		}},
+	}
+}
+
+func readExportFile(t *testing.T, cm storage.ChunkManager, filePath string) int64 {
+	content, err := cm.Read(context.Background(), filePath)
+	require.NoError(t, err)
+	reader, err := file.NewParquetReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	defer reader.Close()
+	return reader.NumRows()
+}
+
+func TestResultExporterQuery(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QuotaConfig.MaxQueryResultWindow.Key, "2")
+	params.Save(params.ProxyCfg.ResultExportMaxRowsPerFile.Key, "3")
+	defer params.Reset(params.QuotaConfig.MaxQueryResultWindow.Key)
+	defer params.Reset(params.ProxyCfg.ResultExportMaxRowsPerFile.Key)
+
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	pks := []int64{1, 2, 3, 4, 5}
+	var exprs []string
+	query := func(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		exprs = append(exprs, req.GetExpr())
+		limitStr, err := funcutil.GetAttrByKeyFromRepeatedKV(LimitKey, req.GetQueryParams())
+		require.NoError(t, err)
+		limit, err := strconv.Atoi(limitStr)
+		require.NoError(t, err)
+		iterator, err := funcutil.GetAttrByKeyFromRepeatedKV(IteratorField, req.GetQueryParams())
+		require.NoError(t, err)
+		assert.Equal(t, "true", iterator)
+
+		start := 0
+		if req.GetGuaranteeTimestamp() > 0 {
+			assert.EqualValues(t, 100, req.GetGuaranteeTimestamp())
+			start = len(exprs)*2 - 2
+		}
+		page := pks[min(start, len(pks)):min(start+limit, len(pks))]
+		return &milvuspb.QueryResults{
+			Status:     merr.Success(),
+			FieldsData: []*schemapb.FieldData{longFieldData("pk", page)},
+			SessionTs:  100,
+		}, nil
+	}
+
+	exporter := newResultExporterWithChunkManager(ctx, cm, "bucket", path.Join(cm.RootPath(), "export", "1"), "1")
+	manifest, err := exporter.exportQuery(&milvuspb.QueryRequest{Expr: "pk > 0"}, "pk", query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pk > 0", "(pk > 0) and pk > 2", "(pk > 0) and pk > 4"}, exprs)
+	assert.EqualValues(t, 5, manifest.Rows)
+	assert.Equal(t, []string{"pk"}, manifest.Columns)
+	require.Len(t, manifest.Files, 2)
+	assert.EqualValues(t, 3, manifest.Files[0].Rows)
+	assert.EqualValues(t, 2, manifest.Files[1].Rows)
+	for _, f := range manifest.Files {
+		assert.Equal(t, f.Rows, readExportFile(t, cm, f.Path))
+	}
+	exist, err := cm.Exist(ctx, path.Join(manifest.Path, resultExportManifestFile))
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	// the limit caps the rows exported
+	exprs = nil
+	exporter = newResultExporterWithChunkManager(ctx, cm, "bucket", path.Join(cm.RootPath(), "export", "2"), "2")
+	manifest, err = exporter.exportQuery(&milvuspb.QueryRequest{
+		QueryParams: []*commonpb.KeyValuePair{{Key: LimitKey, Value: "3"}},
+	}, "pk", query)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, manifest.Rows)
+	assert.Len(t, exprs, 2)
+
+	// the offset isn't supported
+	exporter = newResultExporterWithChunkManager(ctx, cm, "bucket", path.Join(cm.RootPath(), "export", "3"), "3")
+	_, err = exporter.exportQuery(&milvuspb.QueryRequest{
+		QueryParams: []*commonpb.KeyValuePair{{Key: OffsetKey, Value: "1"}},
+	}, "pk", query)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the files written are removed if the export fails
+	exprs = nil
+	exporter = newResultExporterWithChunkManager(ctx, cm, "bucket", path.Join(cm.RootPath(), "export", "4"), "4")
+	_, err = exporter.exportQuery(&milvuspb.QueryRequest{}, "pk", func(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		if len(exprs) > 1 {
+			return nil, merr.ErrServiceUnavailable
+		}
+		return query(ctx, req)
+	})
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+	files, _, err := storage.ListAllChunkWithPrefix(ctx, cm, path.Join(cm.RootPath(), "export", "4")+"/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestResultExporterSearch(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QuotaConfig.MaxQueryResultWindow.Key, "4")
+	defer params.Reset(params.QuotaConfig.MaxQueryResultWindow.Key)
+
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	placeholderGroup, err := proto.Marshal(&commonpb.PlaceholderGroup{
+		Placeholders: []*commonpb.PlaceholderValue{{
+			Tag:    "$0",
+			Type:   commonpb.PlaceholderType_FloatVector,
+			Values: [][]byte{{1}, {2}, {3}},
+		}},
+	})
+	require.NoError(t, err)
+
+	var batches []int64
+	search := func(ctx context.Context, req *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+		batches = append(batches, req.GetNq())
+		topks := make([]int64, req.GetNq())
+		pks := make([]int64, 0, req.GetNq()*2)
+		for i := range topks {
+			topks[i] = 2
+			pks = append(pks, int64(i), int64(i+10))
+		}
+		return &milvuspb.SearchResults{
+			Status: merr.Success(),
+			Results: &schemapb.SearchResultData{
+				NumQueries: req.GetNq(),
+				TopK:       2,
+				Topks:      topks,
+				Scores:     make([]float32, len(pks)),
+				Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+			},
+		}, nil
+	}
+
+	exporter := newResultExporterWithChunkManager(ctx, cm, "bucket", path.Join(cm.RootPath(), "export"), "1")
+	manifest, err := exporter.exportSearch(&milvuspb.SearchRequest{
+		PlaceholderGroup: placeholderGroup,
+		SearchParams:     []*commonpb.KeyValuePair{{Key: TopKKey, Value: "2"}},
+	}, "pk", search)
+	require.NoError(t, err)
+	// 2 query vectors of topk 2 in each batch
+	assert.Equal(t, []int64{2, 1}, batches)
+	assert.EqualValues(t, 6, manifest.Rows)
+	assert.Equal(t, []string{"query_index", "pk", "distance"}, manifest.Columns)
+	require.Len(t, manifest.Files, 1)
+	assert.EqualValues(t, 6, readExportFile(t, cm, manifest.Files[0].Path))
+}
@@ -1126,10 +1126,18 @@ func (coord *RootCoordMock) DescribeDatabase(ctx context.Context, in *rootcoordp
 	return &rootcoordpb.DescribeDatabaseResponse{}, nil
 }
 
+func (coord *RootCoordMock) DescribeDatabases(ctx context.Context, in *rootcoordpb.DescribeDatabasesRequest, opts ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	return &rootcoordpb.DescribeDatabasesResponse{}, nil
+}
+
 func (coord *RootCoordMock) AlterDatabase(ctx context.Context, in *rootcoordpb.AlterDatabaseRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	return &commonpb.Status{}, nil
 }
 
+func (coord *RootCoordMock) BatchDDL(ctx context.Context, in *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, nil
+}
+
 func (coord *RootCoordMock) BackupRBAC(ctx context.Context, in *milvuspb.BackupRBACMetaRequest, opts ...grpc.CallOption) (*milvuspb.BackupRBACMetaResponse, error) {
 	return &milvuspb.BackupRBACMetaResponse{}, nil
 }
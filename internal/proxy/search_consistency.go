@@ -0,0 +1,187 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// searchConsistencyTimeout bounds the verification search on the other replica,
+// which runs after the original request returns.
+const searchConsistencyTimeout = 30 * time.Second
+
+// searchConsistencyDiff is the difference between the results of one search on two replicas.
+type searchConsistencyDiff struct {
+	// mean over the queries of |ids in both| / max(|ids|)
+	idOverlap float64
+	// max score delta of the ids in both results
+	maxScoreDelta float64
+}
+
+func (d searchConsistencyDiff) mismatch(scoreTolerance float64) bool {
+	return d.idOverlap < 1 || d.maxScoreDelta > scoreTolerance
+}
+
+// diffSearchResultData compares the per query ids and scores of two search results.
+func diffSearchResultData(left, right *schemapb.SearchResultData) searchConsistencyDiff {
+	diff := searchConsistencyDiff{idOverlap: 1}
+	nq := lo.Max([]int{len(left.GetTopks()), len(right.GetTopks())})
+	if nq == 0 {
+		return diff
+	}
+
+	queryScores := func(data *schemapb.SearchResultData, offsets []int64, i int) map[any]float32 {
+		scores := make(map[any]float32)
+		if i >= len(data.GetTopks()) {
+			return scores
+		}
+		for j := offsets[i]; j < offsets[i]+data.GetTopks()[i]; j++ {
+			scores[typeutil.GetPK(data.GetIds(), j)] = data.GetScores()[j]
+		}
+		return scores
+	}
+	leftOffsets, rightOffsets := topKOffsets(left.GetTopks()), topKOffsets(right.GetTopks())
+
+	overlapSum := 0.0
+	for i := 0; i < nq; i++ {
+		leftScores := queryScores(left, leftOffsets, i)
+		rightScores := queryScores(right, rightOffsets, i)
+		total := lo.Max([]int{len(leftScores), len(rightScores)})
+		if total == 0 {
+			overlapSum += 1
+			continue
+		}
+		common := 0
+		for pk, score := range leftScores {
+			rightScore, ok := rightScores[pk]
+			if !ok {
+				continue
+			}
+			common++
+			diff.maxScoreDelta = math.Max(diff.maxScoreDelta, math.Abs(float64(score-rightScore)))
+		}
+		overlapSum += float64(common) / float64(total)
+	}
+	diff.idOverlap = overlapSum / float64(nq)
+	return diff
+}
+
+func topKOffsets(topks []int64) []int64 {
+	offsets := make([]int64, len(topks))
+	for i := 1; i < len(topks); i++ {
+		offsets[i] = offsets[i-1] + topks[i-1]
+	}
+	return offsets
+}
+
+// shouldVerifyConsistency samples the searches to verify against another replica.
+func (t *searchTask) shouldVerifyConsistency() bool {
+	ratio := paramtable.Get().ProxyCfg.SearchConsistencySampleRatio.GetAsFloat()
+	return ratio > 0 && !t.SearchRequest.GetIsAdvanced() && rand.Float64() < ratio
+}
+
+// verifyConsistency searches the channel again on another replica at the mvcc timestamp of the result,
+// and logs and meters the difference of the results.
+func (t *searchTask) verifyConsistency(ctx context.Context, nodeID int64, channel string, req *querypb.SearchRequest, result *internalpb.SearchResults) {
+	mvcc, ok := result.GetChannelsMvcc()[channel]
+	if !ok {
+		return
+	}
+	log := log.Ctx(ctx).With(zap.Int64("collection", t.GetCollectionID()),
+		zap.String("channel", channel),
+		zap.Int64("nodeID", nodeID),
+		zap.Uint64("mvcc", mvcc))
+
+	shardLeaders, err := globalMetaCache.GetShards(ctx, true, t.request.GetDbName(), t.collectionName, t.GetCollectionID())
+	if err != nil {
+		log.Warn("failed to get shard leaders to verify search consistency", zap.Error(err))
+		return
+	}
+	others := lo.Filter(shardLeaders[channel], func(node nodeInfo, _ int) bool {
+		return node.nodeID != nodeID
+	})
+	if len(others) == 0 {
+		return
+	}
+
+	var otherNodeID int64
+	var otherResult *internalpb.SearchResults
+	err = t.lb.ExecuteWithRetry(ctx, ChannelWorkload{
+		db:             t.request.GetDbName(),
+		collectionName: t.collectionName,
+		collectionID:   t.GetCollectionID(),
+		channel:        channel,
+		shardLeaders:   others,
+		nq:             t.GetNq(),
+		exec: func(ctx context.Context, targetID UniqueID, qn types.QueryNodeClient, channel string) error {
+			// shard leader cache could be refreshed to the original replica on failure
+			if targetID == nodeID {
+				return nil
+			}
+			verifyReq := typeutil.Clone(req)
+			verifyReq.GetReq().GetBase().TargetID = targetID
+			verifyReq.GetReq().MvccTimestamp = mvcc
+			if verifyReq.GetReq().GetGuaranteeTimestamp() < mvcc {
+				verifyReq.GetReq().GuaranteeTimestamp = mvcc
+			}
+			resp, err := qn.Search(ctx, verifyReq)
+			if err = merr.CheckRPCCall(resp, err); err != nil {
+				return err
+			}
+			otherNodeID, otherResult = targetID, resp
+			return nil
+		},
+		retryTimes: 1,
+	})
+	if err != nil || otherResult == nil {
+		log.Warn("failed to search on another replica to verify search consistency", zap.Error(err))
+		return
+	}
+
+	results, err := decodeSearchResults(ctx, []*internalpb.SearchResults{result, otherResult})
+	if err != nil || len(results) != 2 {
+		log.Warn("failed to decode search results to verify search consistency", zap.Error(err))
+		return
+	}
+	diff := diffSearchResultData(results[0], results[1])
+
+	nodeIDLabel := paramtable.GetStringNodeID()
+	metrics.ProxySearchConsistencyIDOverlap.WithLabelValues(nodeIDLabel, t.collectionName).Observe(diff.idOverlap)
+	if diff.mismatch(paramtable.Get().ProxyCfg.SearchConsistencyScoreTolerance.GetAsFloat()) {
+		metrics.ProxySearchConsistencyMismatchCount.WithLabelValues(nodeIDLabel, t.collectionName).Inc()
+		log.Warn("search results diverge between replicas",
+			zap.Int64("otherNodeID", otherNodeID),
+			zap.Float64("idOverlap", diff.idOverlap),
+			zap.Float64("maxScoreDelta", diff.maxScoreDelta))
+	}
+}
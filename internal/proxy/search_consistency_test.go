@@ -0,0 +1,59 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+)
+
+func newTestSearchResultData(topks []int64, ids []int64, scores []float32) *schemapb.SearchResultData {
+	return &schemapb.SearchResultData{
+		NumQueries: int64(len(topks)),
+		Topks:      topks,
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		Scores:     scores,
+	}
+}
+
+func TestDiffSearchResultData(t *testing.T) {
+	left := newTestSearchResultData([]int64{2, 2}, []int64{1, 2, 3, 4}, []float32{0.9, 0.8, 0.7, 0.6})
+
+	diff := diffSearchResultData(left, left)
+	assert.Equal(t, 1.0, diff.idOverlap)
+	assert.Equal(t, 0.0, diff.maxScoreDelta)
+	assert.False(t, diff.mismatch(0.00001))
+
+	// second query misses id 4 and score of id 1 differs
+	right := newTestSearchResultData([]int64{2, 2}, []int64{1, 2, 3, 5}, []float32{0.5, 0.8, 0.7, 0.6})
+	diff = diffSearchResultData(left, right)
+	assert.Equal(t, 0.75, diff.idOverlap)
+	assert.InDelta(t, 0.4, diff.maxScoreDelta, 1e-6)
+	assert.True(t, diff.mismatch(0.00001))
+
+	// empty results on both sides are consistent
+	empty := newTestSearchResultData([]int64{0}, nil, nil)
+	diff = diffSearchResultData(empty, empty)
+	assert.Equal(t, 1.0, diff.idOverlap)
+
+	// one side returns less queries
+	diff = diffSearchResultData(left, newTestSearchResultData([]int64{2}, []int64{1, 2}, []float32{0.9, 0.8}))
+	assert.Equal(t, 0.5, diff.idOverlap)
+}
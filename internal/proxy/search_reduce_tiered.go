@@ -0,0 +1,236 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// searchResultCursor points to the next result of a query in a sub search result.
+type searchResultCursor struct {
+	subIdx int
+	idx    int64
+	end    int64
+}
+
+// searchResultHeap pops the cursor of the highest score, or the smaller pk with the same score,
+// the same order as selectHighestScoreIndex.
+type searchResultHeap struct {
+	data    []*schemapb.SearchResultData
+	cursors []searchResultCursor
+}
+
+func (h *searchResultHeap) Len() int {
+	return len(h.cursors)
+}
+
+func (h *searchResultHeap) Less(i, j int) bool {
+	ci, cj := h.cursors[i], h.cursors[j]
+	si, sj := h.data[ci.subIdx].Scores[ci.idx], h.data[cj.subIdx].Scores[cj.idx]
+	if si != sj {
+		return si > sj
+	}
+	return typeutil.ComparePK(typeutil.GetPK(h.data[ci.subIdx].GetIds(), ci.idx), typeutil.GetPK(h.data[cj.subIdx].GetIds(), cj.idx))
+}
+
+func (h *searchResultHeap) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *searchResultHeap) Push(x any) {
+	h.cursors = append(h.cursors, x.(searchResultCursor))
+}
+
+func (h *searchResultHeap) Pop() any {
+	n := len(h.cursors)
+	x := h.cursors[n-1]
+	h.cursors = h.cursors[:n-1]
+	return x
+}
+
+// the heaps are reused by the queries and the merges, the topK of the tiered reduce is large
+// and the merges are frequent
+var searchResultHeapPool = sync.Pool{
+	New: func() any {
+		return &searchResultHeap{}
+	},
+}
+
+// mergeSearchResultData merges the sub search results into the topK results of each query, without applying the offset,
+// the scores are kept as is so the merged result can be merged again.
+func mergeSearchResultData(ctx context.Context, subSearchResultData []*schemapb.SearchResultData, nq int64, topk int64, pkType schemapb.DataType) (*schemapb.SearchResultData, error) {
+	allSearchCount, hitNum, err := checkResultDatas(ctx, subSearchResultData, nq, topk)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := min(int64(hitNum), nq*topk)
+	ret := &milvuspb.SearchResults{
+		Results: &schemapb.SearchResultData{
+			NumQueries:     nq,
+			TopK:           topk,
+			FieldsData:     typeutil.PrepareResultFieldData(subSearchResultData[0].GetFieldsData(), capacity),
+			Scores:         make([]float32, 0, capacity),
+			Ids:            &schemapb.IDs{},
+			Topks:          make([]int64, 0, nq),
+			AllSearchCount: allSearchCount,
+		},
+	}
+	if err := setupIdListForSearchResult(ret, pkType, capacity); err != nil {
+		return nil, err
+	}
+
+	offsets := make([][]int64, len(subSearchResultData))
+	for i, data := range subSearchResultData {
+		if int64(len(data.GetTopks())) != nq {
+			return nil, fmt.Errorf("search result's topks length(%d) mis-match with nq %d", len(data.GetTopks()), nq)
+		}
+		offsets[i] = topKOffsets(data.GetTopks())
+	}
+
+	h := searchResultHeapPool.Get().(*searchResultHeap)
+	defer func() {
+		h.data, h.cursors = nil, h.cursors[:0]
+		searchResultHeapPool.Put(h)
+	}()
+	h.data = subSearchResultData
+
+	maxOutputSize := paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64()
+	var retSize int64
+	for i := int64(0); i < nq; i++ {
+		h.cursors = h.cursors[:0]
+		for j, data := range subSearchResultData {
+			if data.Topks[i] > 0 {
+				h.cursors = append(h.cursors, searchResultCursor{subIdx: j, idx: offsets[j][i], end: offsets[j][i] + data.Topks[i]})
+			}
+		}
+		heap.Init(h)
+
+		var j int64
+		for ; j < topk && h.Len() > 0; j++ {
+			cursor := h.cursors[0]
+			data := subSearchResultData[cursor.subIdx]
+			retSize += typeutil.AppendFieldData(ret.Results.FieldsData, data.GetFieldsData(), cursor.idx)
+			typeutil.CopyPk(ret.Results.Ids, data.GetIds(), int(cursor.idx))
+			ret.Results.Scores = append(ret.Results.Scores, data.Scores[cursor.idx])
+
+			if cursor.idx+1 < cursor.end {
+				h.cursors[0].idx++
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+		ret.Results.Topks = append(ret.Results.Topks, j)
+
+		// limit search result to avoid oom
+		if retSize > maxOutputSize {
+			return nil, fmt.Errorf("search results exceed the maxOutputSize Limit %d", maxOutputSize)
+		}
+	}
+	return ret.Results, nil
+}
+
+// tieredSearchReducer merges the result of each shard into the topK results merged once received,
+// instead of holding the results of all the shards until all received, for the searches of large topK.
+// The results are merged per query node by the delegators already, so the proxy holds the merged results
+// and a shard result at most.
+type tieredSearchReducer struct {
+	mu         sync.Mutex
+	nq         int64
+	topk       int64
+	pkType     schemapb.DataType
+	metricType string
+	merged     *schemapb.SearchResultData
+}
+
+func newTieredSearchReducer(nq int64, topk int64, pkType schemapb.DataType) *tieredSearchReducer {
+	return &tieredSearchReducer{
+		nq:     nq,
+		topk:   topk,
+		pkType: pkType,
+	}
+}
+
+// Add merges the search result of a shard, the merged results are kept if failed.
+func (r *tieredSearchReducer) Add(ctx context.Context, result *internalpb.SearchResults) error {
+	if result.GetSlicedBlob() == nil {
+		return nil
+	}
+	// decode without the lock, the results of the shards could be large
+	data := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(result.GetSlicedBlob(), data); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metricType = result.GetMetricType()
+	if r.merged == nil {
+		if _, _, err := checkResultDatas(ctx, []*schemapb.SearchResultData{data}, r.nq, r.topk); err != nil {
+			return err
+		}
+		r.merged = data
+		return nil
+	}
+
+	merged, err := mergeSearchResultData(ctx, []*schemapb.SearchResultData{r.merged, data}, r.nq, r.topk, r.pkType)
+	if err != nil {
+		return err
+	}
+	r.merged = merged
+	return nil
+}
+
+// Reduce applies the offset to the merged results and returns the final results.
+func (r *tieredSearchReducer) Reduce(ctx context.Context, offset int64) (*milvuspb.SearchResults, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.merged == nil {
+		return fillInEmptyResult(r.nq), nil
+	}
+	return reduceSearchResultDataNoGroupBy(ctx, []*schemapb.SearchResultData{r.merged}, r.nq, r.topk, r.metricType, r.pkType, offset)
+}
+
+// withoutResultData returns the search result without the result data merged by the tiered reducer,
+// with the other info kept for the post execution.
+func withoutResultData(result *internalpb.SearchResults) *internalpb.SearchResults {
+	return &internalpb.SearchResults{
+		Base:               result.GetBase(),
+		Status:             result.GetStatus(),
+		ReqID:              result.GetReqID(),
+		MetricType:         result.GetMetricType(),
+		NumQueries:         result.GetNumQueries(),
+		TopK:               result.GetTopK(),
+		CostAggregation:    result.GetCostAggregation(),
+		ChannelsMvcc:       result.GetChannelsMvcc(),
+		AllSearchCount:     result.GetAllSearchCount(),
+		IsTopkReduce:       result.GetIsTopkReduce(),
+		IsRecallEvaluation: result.GetIsRecallEvaluation(),
+	}
+}
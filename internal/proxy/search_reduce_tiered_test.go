@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// genShardSearchResultData generates the search result of a shard, the scores of each query are descending
+// and some of them are the same to cover the ties.
+func genShardSearchResultData(nq, topk int64, pkStart int64) *schemapb.SearchResultData {
+	data := &schemapb.SearchResultData{
+		NumQueries: nq,
+		TopK:       topk,
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{}}},
+	}
+	pk := pkStart
+	for i := int64(0); i < nq; i++ {
+		n := rand.Int63n(topk + 1)
+		scores := make([]float32, n)
+		for j := range scores {
+			scores[j] = float32(rand.Intn(int(topk)))
+		}
+		sort.Slice(scores, func(i, j int) bool { return scores[i] > scores[j] })
+		for _, score := range scores {
+			data.Scores = append(data.Scores, score)
+			data.Ids.GetIntId().Data = append(data.Ids.GetIntId().Data, pk)
+			pk++
+		}
+		data.Topks = append(data.Topks, n)
+	}
+	return data
+}
+
+func TestTieredSearchReducer(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	nq, topk, offset := int64(3), int64(100), int64(10)
+
+	shards := make([]*schemapb.SearchResultData, 0)
+	reducer := newTieredSearchReducer(nq, topk, schemapb.DataType_Int64)
+	for i := 0; i < 4; i++ {
+		data := genShardSearchResultData(nq, topk, int64(i)*1000)
+		blob, err := proto.Marshal(data)
+		require.NoError(t, err)
+		require.NoError(t, reducer.Add(ctx, &internalpb.SearchResults{
+			MetricType: metric.IP,
+			SlicedBlob: blob,
+		}))
+		shards = append(shards, data)
+	}
+	// the merged results are kept if the result is invalid
+	blob, err := proto.Marshal(genShardSearchResultData(nq+1, topk, 10000))
+	require.NoError(t, err)
+	assert.Error(t, reducer.Add(ctx, &internalpb.SearchResults{MetricType: metric.IP, SlicedBlob: blob}))
+
+	expected, err := reduceSearchResultDataNoGroupBy(ctx, shards, nq, topk, metric.IP, schemapb.DataType_Int64, offset)
+	require.NoError(t, err)
+	actual, err := reducer.Reduce(ctx, offset)
+	require.NoError(t, err)
+	assert.Equal(t, expected.GetResults().GetIds().GetIntId().GetData(), actual.GetResults().GetIds().GetIntId().GetData())
+	assert.Equal(t, expected.GetResults().GetScores(), actual.GetResults().GetScores())
+	assert.Equal(t, expected.GetResults().GetTopks(), actual.GetResults().GetTopks())
+	assert.Equal(t, expected.GetResults().GetTopK(), actual.GetResults().GetTopK())
+}
+
+func TestTieredSearchReducerEmpty(t *testing.T) {
+	paramtable.Init()
+	reducer := newTieredSearchReducer(2, 100, schemapb.DataType_Int64)
+	assert.NoError(t, reducer.Add(context.Background(), &internalpb.SearchResults{}))
+	result, err := reducer.Reduce(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 0}, result.GetResults().GetTopks())
+}
+
+func TestMergeSearchResultData(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	data := []*schemapb.SearchResultData{
+		{
+			NumQueries: 2,
+			TopK:       3,
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"b", "c", "d", "e"}}}},
+			Scores:     []float32{0.9, 0.5, 0.5, 0.8},
+			Topks:      []int64{3, 1},
+		},
+		{
+			NumQueries: 2,
+			TopK:       3,
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a", "f", "g"}}}},
+			Scores:     []float32{0.5, 0.9, 0.7},
+			Topks:      []int64{1, 2},
+		},
+	}
+	merged, err := mergeSearchResultData(ctx, data, 2, 3, schemapb.DataType_VarChar)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), merged.GetTopK())
+	assert.Equal(t, []int64{3, 3}, merged.GetTopks())
+	// the smaller pk first with the same score
+	assert.Equal(t, []string{"b", "a", "c", "f", "e", "g"}, merged.GetIds().GetStrId().GetData())
+	assert.Equal(t, []float32{0.9, 0.5, 0.5, 0.9, 0.8, 0.7}, merged.GetScores())
+
+	_, err = mergeSearchResultData(ctx, data, 2, 4, schemapb.DataType_VarChar)
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/distance"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// searchRefine is the second phase of a two-phase search. The first phase searches the (usually quantized) index
+// for factor times of the requested topk, and the candidates are re-scored with the raw vectors fetched by primary keys,
+// so the quantized index gets near full-precision recall without loading the raw vectors into the index.
+type searchRefine struct {
+	factor int64
+	// topk and offset requested, the candidates are searched without offset
+	topK         int64
+	offset       int64
+	fieldName    string
+	roundDecimal int64
+}
+
+// parseSearchRefine returns nil if refine is not requested by the search params.
+func parseSearchRefine(params []*commonpb.KeyValuePair, schema *schemapb.CollectionSchema, queryInfo *planpb.QueryInfo, offset int64, isIterator bool) (*searchRefine, error) {
+	factorStr, err := funcutil.GetAttrByKeyFromRepeatedKV(RefineFactorKey, params)
+	if err != nil {
+		return nil, nil
+	}
+	factor, err := strconv.ParseInt(factorStr, 0, 64)
+	if err != nil || factor < 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("%s [%s] is invalid, should be a positive integer", RefineFactorKey, factorStr)
+	}
+	if factor == 1 {
+		return nil, nil
+	}
+	if isIterator {
+		return nil, merr.WrapErrParameterInvalidMsg("not allowed to refine when doing iteration")
+	}
+	if queryInfo.GetGroupByFieldId() > 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("not allowed to refine when doing search-group-by")
+	}
+	if strings.Contains(queryInfo.GetSearchParams(), radiusKey) {
+		return nil, merr.WrapErrParameterInvalidMsg("not allowed to refine when doing range-search")
+	}
+	field := typeutil.GetField(schema, queryInfo.GetQueryFieldId())
+	if field.GetDataType() != schemapb.DataType_FloatVector {
+		return nil, merr.WrapErrParameterInvalidMsg("refine is only supported on float vector field, got %s", field.GetDataType().String())
+	}
+	if metricType := queryInfo.GetMetricType(); metricType != "" {
+		if _, err := distance.ValidateMetricType(metricType); err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg("refine is not supported with metric type %s", metricType)
+		}
+	}
+	return &searchRefine{
+		factor:       factor,
+		topK:         queryInfo.GetTopk(),
+		offset:       offset,
+		fieldName:    field.GetName(),
+		roundDecimal: queryInfo.GetRoundDecimal(),
+	}, nil
+}
+
+// candidates returns the number of the candidates to search in the first phase.
+func (r *searchRefine) candidates() int64 {
+	return min(r.topK*r.factor, Params.QuotaConfig.TopKLimit.GetAsInt64())
+}
+
+// refineResults re-scores the reduced candidates with the raw vectors and keeps the requested topk of them.
+func (t *searchTask) refineResults(ctx context.Context, span trace.Span, metricType string) error {
+	_, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "refineResults")
+	defer sp.End()
+
+	result := t.result.GetResults()
+	if typeutil.GetSizeOfIDs(result.GetIds()) == 0 {
+		return nil
+	}
+	placeholderGroup := &commonpb.PlaceholderGroup{}
+	if err := proto.Unmarshal(t.request.GetPlaceholderGroup(), placeholderGroup); err != nil {
+		return err
+	}
+	queries, err := floatVectorsOfPlaceholderGroup(placeholderGroup)
+	if err != nil {
+		return err
+	}
+	fieldsData, err := t.queryByIDs(span, result.GetIds(), []string{t.refine.fieldName})
+	if err != nil {
+		return err
+	}
+	var vectors *schemapb.FieldData
+	for _, fieldData := range fieldsData {
+		if fieldData.GetFieldName() == t.refine.fieldName {
+			vectors = fieldData
+		}
+	}
+	if vectors == nil {
+		return merr.WrapErrServiceInternal(fmt.Sprintf("vectors of field %s to refine not returned", t.refine.fieldName))
+	}
+	t.result.Results, err = refineSearchResultData(result, queries, vectors, metricType,
+		t.refine.offset, t.refine.topK-t.refine.offset, t.refine.roundDecimal)
+	return err
+}
+
+func floatVectorsOfPlaceholderGroup(placeholderGroup *commonpb.PlaceholderGroup) ([][]float32, error) {
+	if len(placeholderGroup.GetPlaceholders()) != 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("refine requires exactly one placeholder")
+	}
+	placeholder := placeholderGroup.GetPlaceholders()[0]
+	if placeholder.GetType() != commonpb.PlaceholderType_FloatVector {
+		return nil, merr.WrapErrParameterInvalidMsg("refine requires float vectors to search, got %s", placeholder.GetType().String())
+	}
+	queries := make([][]float32, 0, len(placeholder.GetValues()))
+	for _, value := range placeholder.GetValues() {
+		if len(value)%4 != 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid float vector of %d bytes", len(value))
+		}
+		vector := make([]float32, len(value)/4)
+		for i := range vector {
+			vector[i] = typeutil.BytesToFloat32(value[i*4 : i*4+4])
+		}
+		queries = append(queries, vector)
+	}
+	return queries, nil
+}
+
+// refineSearchResultData re-scores the candidates of each query with the vectors aligned with the ids of the result,
+// and keeps the results in [offset, offset+limit) of each query ordered by the new scores.
+func refineSearchResultData(result *schemapb.SearchResultData, queries [][]float32, vectors *schemapb.FieldData,
+	metricType string, offset, limit, roundDecimal int64,
+) (*schemapb.SearchResultData, error) {
+	var scorer func(a, b []float32) float32
+	switch strings.ToUpper(metricType) {
+	case metric.L2:
+		scorer = distance.L2Impl
+	case metric.IP:
+		scorer = distance.IPImpl
+	case metric.COSINE:
+		scorer = distance.CosineImpl
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("refine is not supported with metric type %s", metricType)
+	}
+
+	nq := result.GetNumQueries()
+	dim := vectors.GetVectors().GetDim()
+	data := vectors.GetVectors().GetFloatVector().GetData()
+	if int64(len(queries)) != nq || int64(len(result.GetTopks())) != nq {
+		return nil, errors.Newf("nq of refine mismatch, queries: %d, topks: %d, nq: %d", len(queries), len(result.GetTopks()), nq)
+	}
+	if int64(len(data)) != dim*int64(typeutil.GetSizeOfIDs(result.GetIds())) {
+		return nil, errors.Newf("number of vectors to refine mismatch, dim: %d, vectors: %d, ids: %d",
+			dim, len(data), typeutil.GetSizeOfIDs(result.GetIds()))
+	}
+
+	ret := &schemapb.SearchResultData{
+		NumQueries:     nq,
+		FieldsData:     typeutil.PrepareResultFieldData(result.GetFieldsData(), limit),
+		Scores:         make([]float32, 0, nq*limit),
+		Ids:            &schemapb.IDs{},
+		Topks:          make([]int64, 0, nq),
+		OutputFields:   result.GetOutputFields(),
+		AllSearchCount: result.GetAllSearchCount(),
+	}
+	switch result.GetIds().GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		ret.Ids.IdField = &schemapb.IDs_IntId{IntId: &schemapb.LongArray{}}
+	case *schemapb.IDs_StrId:
+		ret.Ids.IdField = &schemapb.IDs_StrId{StrId: &schemapb.StringArray{}}
+	}
+
+	positivelyRelated := metric.PositivelyRelated(metricType)
+	var start int64
+	for i, topk := range result.GetTopks() {
+		if int64(len(queries[i])) != dim {
+			return nil, merr.WrapErrParameterInvalidMsg("dim of the vector to search is %d, expected %d", len(queries[i]), dim)
+		}
+		candidates := make([]int64, topk)
+		scores := make([]float32, topk)
+		for j := range candidates {
+			idx := start + int64(j)
+			candidates[j] = idx
+			scores[j] = scorer(queries[i], data[idx*dim:(idx+1)*dim])
+		}
+		sort.Stable(&refineCandidates{candidates: candidates, scores: scores, positivelyRelated: positivelyRelated})
+
+		begin, end := min(offset, topk), min(offset+limit, topk)
+		for j := begin; j < end; j++ {
+			score := scores[j]
+			if roundDecimal != -1 {
+				multiplier := math.Pow(10.0, float64(roundDecimal))
+				score = float32(math.Floor(float64(score)*multiplier+0.5) / multiplier)
+			}
+			typeutil.CopyPk(ret.Ids, result.GetIds(), int(candidates[j]))
+			typeutil.AppendFieldData(ret.FieldsData, result.GetFieldsData(), candidates[j])
+			ret.Scores = append(ret.Scores, score)
+		}
+		ret.Topks = append(ret.Topks, end-begin)
+		ret.TopK = end - begin
+		start += topk
+	}
+	return ret, nil
+}
+
+type refineCandidates struct {
+	candidates        []int64
+	scores            []float32
+	positivelyRelated bool
+}
+
+func (c *refineCandidates) Len() int {
+	return len(c.candidates)
+}
+
+func (c *refineCandidates) Less(i, j int) bool {
+	if c.positivelyRelated {
+		return c.scores[i] > c.scores[j]
+	}
+	return c.scores[i] < c.scores[j]
+}
+
+func (c *refineCandidates) Swap(i, j int) {
+	c.candidates[i], c.candidates[j] = c.candidates[j], c.candidates[i]
+	c.scores[i], c.scores[j] = c.scores[j], c.scores[i]
+}
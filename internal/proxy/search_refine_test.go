@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+)
+
+func TestParseSearchRefine(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+			{FieldID: 102, Name: "bin", DataType: schemapb.DataType_BinaryVector},
+		},
+	}
+	params := func(factor string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: RefineFactorKey, Value: factor}}
+	}
+	queryInfo := &planpb.QueryInfo{Topk: 15, QueryFieldId: 101, MetricType: metric.L2, RoundDecimal: -1}
+
+	refine, err := parseSearchRefine(nil, schema, queryInfo, 5, false)
+	assert.NoError(t, err)
+	assert.Nil(t, refine)
+	refine, err = parseSearchRefine(params("1"), schema, queryInfo, 5, false)
+	assert.NoError(t, err)
+	assert.Nil(t, refine)
+
+	refine, err = parseSearchRefine(params("4"), schema, queryInfo, 5, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &searchRefine{factor: 4, topK: 15, offset: 5, fieldName: "vec", roundDecimal: -1}, refine)
+
+	for _, factor := range []string{"0", "-1", "abc"} {
+		_, err = parseSearchRefine(params(factor), schema, queryInfo, 0, false)
+		assert.Error(t, err)
+	}
+	_, err = parseSearchRefine(params("4"), schema, queryInfo, 0, true)
+	assert.Error(t, err)
+	_, err = parseSearchRefine(params("4"), schema, &planpb.QueryInfo{Topk: 10, QueryFieldId: 101, GroupByFieldId: 100}, 0, false)
+	assert.Error(t, err)
+	_, err = parseSearchRefine(params("4"), schema, &planpb.QueryInfo{Topk: 10, QueryFieldId: 101, SearchParams: `{"radius": 1}`}, 0, false)
+	assert.Error(t, err)
+	_, err = parseSearchRefine(params("4"), schema, &planpb.QueryInfo{Topk: 10, QueryFieldId: 102}, 0, false)
+	assert.Error(t, err)
+	_, err = parseSearchRefine(params("4"), schema, &planpb.QueryInfo{Topk: 10, QueryFieldId: 101, MetricType: metric.HAMMING}, 0, false)
+	assert.Error(t, err)
+}
+
+func TestRefineSearchResultData(t *testing.T) {
+	// 2 queries with 3 candidates each, in the order of the coarse scores
+	result := &schemapb.SearchResultData{
+		NumQueries: 2,
+		TopK:       3,
+		Topks:      []int64{3, 3},
+		Scores:     []float32{0.1, 0.2, 0.3, 0.1, 0.2, 0.3},
+		Ids: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3, 4, 5, 6}}},
+		},
+		FieldsData: []*schemapb.FieldData{{
+			Type:      schemapb.DataType_Int64,
+			FieldName: "a",
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{10, 20, 30, 40, 50, 60}}},
+			}},
+		}},
+		AllSearchCount: 100,
+	}
+	vectors := &schemapb.FieldData{
+		Type:      schemapb.DataType_FloatVector,
+		FieldName: "vec",
+		Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim: 2,
+			Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{
+				Data: []float32{3, 0, 1, 0, 2, 0, 0, 1, 0, 3, 0, 2},
+			}},
+		}},
+	}
+	queries := [][]float32{{0, 0}, {0, 0}}
+
+	ret, err := refineSearchResultData(result, queries, vectors, metric.L2, 0, 2, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 2}, ret.GetTopks())
+	assert.Equal(t, []int64{2, 3, 4, 6}, ret.GetIds().GetIntId().GetData())
+	assert.Equal(t, []float32{1, 4, 1, 4}, ret.GetScores())
+	assert.Equal(t, []int64{20, 30, 40, 60}, ret.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, int64(100), ret.GetAllSearchCount())
+
+	queries = [][]float32{{1, 0}, {0, 1}}
+	ret, err = refineSearchResultData(result, queries, vectors, metric.IP, 1, 5, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 2}, ret.GetTopks())
+	assert.Equal(t, []int64{3, 2, 6, 4}, ret.GetIds().GetIntId().GetData())
+	assert.Equal(t, []float32{2, 1, 2, 1}, ret.GetScores())
+
+	_, err = refineSearchResultData(result, queries, vectors, metric.HAMMING, 0, 2, -1)
+	assert.Error(t, err)
+	_, err = refineSearchResultData(result, queries[:1], vectors, metric.L2, 0, 2, -1)
+	assert.Error(t, err)
+	_, err = refineSearchResultData(result, [][]float32{{0, 0, 0}, {0, 0, 0}}, vectors, metric.L2, 0, 2, -1)
+	assert.Error(t, err)
+}
@@ -157,6 +157,10 @@ func parseSearchInfo(searchParamsPair []*commonpb.KeyValuePair, schema *schemapb
 	if err != nil {
 		hints = ""
 	}
+	if hints != "" && !common.IsValidFilterStrategy(hints) {
+		return &SearchInfo{planInfo: nil, offset: 0, isIterator: false, parseError: fmt.Errorf("%s [%s] is invalid, should be one of %s, %s or %s",
+			common.HintsKey, hints, common.FilterStrategyIterative, common.FilterStrategyBruteForce, common.FilterStrategyAuto)}
+	}
 
 	roundDecimal, err := strconv.ParseInt(roundDecimalStr, 0, 64)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/allocator"
@@ -52,6 +53,7 @@ type segRequest struct {
 	partitionID UniqueID
 	segInfo     map[UniqueID]uint32
 	channelName string
+	keyRange    *datapb.PartitionKeyRange
 	timestamp   Timestamp
 }
 
@@ -65,6 +67,7 @@ type assignInfo struct {
 	collID         UniqueID
 	partitionID    UniqueID
 	channelName    string
+	keyRange       *datapb.PartitionKeyRange
 	segInfos       *list.List
 	lastInsertTime time.Time
 }
@@ -202,6 +205,11 @@ func (sa *segIDAssigner) pickCanDoFunc() {
 		collID := segRequest.collID
 		partitionID := segRequest.partitionID
 		channelName := segRequest.channelName
+		keyRange := segRequest.keyRange
+		recordKey := channelName
+		if keyRange != nil {
+			recordKey = fmt.Sprintf("%s[%d, %d)", channelName, keyRange.GetBegin(), keyRange.GetEnd())
+		}
 
 		if _, ok := records[collID]; !ok {
 			records[collID] = make(map[UniqueID]map[string]uint32)
@@ -210,18 +218,19 @@ func (sa *segIDAssigner) pickCanDoFunc() {
 			records[collID][partitionID] = make(map[string]uint32)
 		}
 
-		if _, ok := records[collID][partitionID][channelName]; !ok {
-			records[collID][partitionID][channelName] = 0
+		if _, ok := records[collID][partitionID][recordKey]; !ok {
+			records[collID][partitionID][recordKey] = 0
 		}
 
-		records[collID][partitionID][channelName] += segRequest.count
-		assign, err := sa.getAssign(segRequest.collID, segRequest.partitionID, segRequest.channelName)
-		if err != nil || assign.Capacity(segRequest.timestamp) < records[collID][partitionID][channelName] {
+		records[collID][partitionID][recordKey] += segRequest.count
+		assign, err := sa.getAssign(segRequest.collID, segRequest.partitionID, segRequest.channelName, keyRange)
+		if err != nil || assign.Capacity(segRequest.timestamp) < records[collID][partitionID][recordKey] {
 			sa.segReqs = append(sa.segReqs, &datapb.SegmentIDRequest{
-				ChannelName:  channelName,
-				Count:        segRequest.count,
-				CollectionID: collID,
-				PartitionID:  partitionID,
+				ChannelName:       channelName,
+				Count:             segRequest.count,
+				CollectionID:      collID,
+				PartitionID:       partitionID,
+				PartitionKeyRange: keyRange,
 			})
 			newTodoReqs = append(newTodoReqs, req)
 		} else {
@@ -234,7 +243,7 @@ func (sa *segIDAssigner) pickCanDoFunc() {
 	sa.ToDoReqs = newTodoReqs
 }
 
-func (sa *segIDAssigner) getAssign(collID UniqueID, partitionID UniqueID, channelName string) (*assignInfo, error) {
+func (sa *segIDAssigner) getAssign(collID UniqueID, partitionID UniqueID, channelName string, keyRange *datapb.PartitionKeyRange) (*assignInfo, error) {
 	assignInfos, ok := sa.assignInfos[collID]
 	if !ok {
 		return nil, fmt.Errorf("can not find collection %d", collID)
@@ -242,7 +251,7 @@ func (sa *segIDAssigner) getAssign(collID UniqueID, partitionID UniqueID, channe
 
 	for e := assignInfos.Front(); e != nil; e = e.Next() {
 		info := e.Value.(*assignInfo)
-		if info.partitionID != partitionID || info.channelName != channelName {
+		if info.partitionID != partitionID || info.channelName != channelName || !proto.Equal(info.keyRange, keyRange) {
 			continue
 		}
 		return info, nil
@@ -264,7 +273,8 @@ func (sa *segIDAssigner) checkSegReqEqual(req1, req2 *datapb.SegmentIDRequest) b
 	if req1 == req2 {
 		return true
 	}
-	return req1.CollectionID == req2.CollectionID && req1.PartitionID == req2.PartitionID && req1.ChannelName == req2.ChannelName
+	return req1.CollectionID == req2.CollectionID && req1.PartitionID == req2.PartitionID && req1.ChannelName == req2.ChannelName &&
+		proto.Equal(req1.GetPartitionKeyRange(), req2.GetPartitionKeyRange())
 }
 
 func (sa *segIDAssigner) reduceSegReqs() {
@@ -339,7 +349,7 @@ func (sa *segIDAssigner) syncSegments() (bool, error) {
 			success = false
 			continue
 		}
-		assign, err := sa.getAssign(segAssign.CollectionID, segAssign.PartitionID, segAssign.ChannelName)
+		assign, err := sa.getAssign(segAssign.CollectionID, segAssign.PartitionID, segAssign.ChannelName, segAssign.GetPartitionKeyRange())
 		segInfo2 := &segInfo{
 			segID:      segAssign.SegID,
 			count:      segAssign.Count,
@@ -357,6 +367,7 @@ func (sa *segIDAssigner) syncSegments() (bool, error) {
 				collID:      segAssign.CollectionID,
 				partitionID: segAssign.PartitionID,
 				channelName: segAssign.ChannelName,
+				keyRange:    segAssign.GetPartitionKeyRange(),
 				segInfos:    segInfos,
 			}
 			colInfos.PushBack(assign)
@@ -374,7 +385,7 @@ func (sa *segIDAssigner) syncSegments() (bool, error) {
 
 func (sa *segIDAssigner) processFunc(req allocator.Request) error {
 	segRequest := req.(*segRequest)
-	assign, err := sa.getAssign(segRequest.collID, segRequest.partitionID, segRequest.channelName)
+	assign, err := sa.getAssign(segRequest.collID, segRequest.partitionID, segRequest.channelName, segRequest.keyRange)
 	if err != nil {
 		return err
 	}
@@ -384,11 +395,20 @@ func (sa *segIDAssigner) processFunc(req allocator.Request) error {
 }
 
 func (sa *segIDAssigner) GetSegmentID(collID UniqueID, partitionID UniqueID, channelName string, count uint32, ts Timestamp) (map[UniqueID]uint32, error) {
+	return sa.GetSegmentIDInKeyRange(collID, partitionID, channelName, nil, count, ts)
+}
+
+// GetSegmentIDInKeyRange assigns the segments for the rows with the partition keys hashed into the key range,
+// which are only shared by the rows in the same key range.
+func (sa *segIDAssigner) GetSegmentIDInKeyRange(collID UniqueID, partitionID UniqueID, channelName string,
+	keyRange *datapb.PartitionKeyRange, count uint32, ts Timestamp,
+) (map[UniqueID]uint32, error) {
 	req := &segRequest{
 		BaseRequest: allocator.BaseRequest{Done: make(chan error), Valid: false},
 		collID:      collID,
 		partitionID: partitionID,
 		channelName: channelName,
+		keyRange:    keyRange,
 		count:       count,
 		timestamp:   ts,
 	}
@@ -80,6 +80,32 @@ func (mockD *mockDataCoord2) AssignSegmentID(ctx context.Context, req *datapb.As
 	}, nil
 }
 
+type mockKeyRangeDataCoord struct {
+	expireTime Timestamp
+}
+
+func (mockD *mockKeyRangeDataCoord) AssignSegmentID(ctx context.Context, req *datapb.AssignSegmentIDRequest, opts ...grpc.CallOption) (*datapb.AssignSegmentIDResponse, error) {
+	assigns := make([]*datapb.SegmentIDAssignment, 0, len(req.SegmentIDRequests))
+	for _, r := range req.SegmentIDRequests {
+		assigns = append(assigns, &datapb.SegmentIDAssignment{
+			// the segments of the key ranges are different
+			SegID:             r.GetPartitionKeyRange().GetBegin() + 1,
+			ChannelName:       r.ChannelName,
+			Count:             r.Count,
+			CollectionID:      r.CollectionID,
+			PartitionID:       r.PartitionID,
+			ExpireTime:        mockD.expireTime,
+			Status:            merr.Success(),
+			PartitionKeyRange: r.GetPartitionKeyRange(),
+		})
+	}
+
+	return &datapb.AssignSegmentIDResponse{
+		Status:           merr.Success(),
+		SegIDAssignments: assigns,
+	}, nil
+}
+
 func getLastTick1() Timestamp {
 	return 1000
 }
@@ -118,6 +144,31 @@ func TestSegmentAllocator1(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSegmentAllocatorInKeyRange(t *testing.T) {
+	ctx := context.Background()
+	dataCoord := &mockKeyRangeDataCoord{expireTime: Timestamp(1000)}
+	segAllocator, err := newSegIDAssigner(ctx, dataCoord, getLastTick1)
+	assert.NoError(t, err)
+	segAllocator.Start()
+	defer segAllocator.Close()
+
+	ret, err := segAllocator.GetSegmentIDInKeyRange(1, 1, "abc", &datapb.PartitionKeyRange{Begin: 100, End: 200}, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[UniqueID]uint32{101: 10}, ret)
+
+	ret, err = segAllocator.GetSegmentIDInKeyRange(1, 1, "abc", &datapb.PartitionKeyRange{Begin: 200, End: 300}, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[UniqueID]uint32{201: 10}, ret)
+
+	ret, err = segAllocator.GetSegmentID(1, 1, "abc", 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[UniqueID]uint32{1: 10}, ret)
+
+	ret, err = segAllocator.GetSegmentIDInKeyRange(1, 1, "abc", &datapb.PartitionKeyRange{Begin: 100, End: 200}, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[UniqueID]uint32{101: 10}, ret)
+}
+
 var curLastTick2 = Timestamp(200)
 
 var curLastTIck2Lock sync.Mutex
@@ -22,6 +22,9 @@ type queryNodeCreatorFunc func(ctx context.Context, addr string, nodeID int64) (
 type nodeInfo struct {
 	nodeID  UniqueID
 	address string
+	// the resource group and the id of the replica the node serves
+	resourceGroup string
+	replicaID     int64
 }
 
 func (n nodeInfo) String() string {
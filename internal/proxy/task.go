@@ -18,11 +18,13 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
@@ -66,6 +68,10 @@ const (
 	RoundDecimalKey      = "round_decimal"
 	OffsetKey            = "offset"
 	LimitKey             = "limit"
+	OrderByKey           = "order_by"
+	RefineFactorKey      = "refine_factor"
+	// PreferredResourceGroupKey routes the search or query to the replicas in the resource group if any available
+	PreferredResourceGroupKey = "preferred_resource_group"
 
 	InsertTaskName                = "InsertTask"
 	CreateCollectionTaskName      = "CreateCollectionTask"
@@ -259,14 +265,23 @@ func (t *createCollectionTask) validatePartitionKey() error {
 			" because the mustUsePartitionKey config is true")
 	}
 
+	hashFunction, err := common.PartitionKeyHashFunction(t.GetProperties()...)
+	if err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
 	if idx == -1 {
 		if t.GetNumPartitions() != 0 {
 			return fmt.Errorf("num_partitions should only be specified with partition key field enabled")
 		}
+		if hashFunction != "" {
+			return merr.WrapErrParameterInvalidMsg("%s should only be specified with partition key field enabled", common.PartitionKeyHashFunctionKey)
+		}
 	} else {
 		log.Info("create collection with partition key mode",
 			zap.String("collectionName", t.CollectionName),
-			zap.Int64("numDefaultPartitions", t.GetNumPartitions()))
+			zap.Int64("numDefaultPartitions", t.GetNumPartitions()),
+			zap.String("hashFunction", hashFunction))
 	}
 
 	return nil
@@ -307,6 +322,26 @@ func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 	}
 	t.schema.AutoID = false
 
+	if hasReplicaTopologyProp(t.GetProperties()...) {
+		return merr.WrapErrParameterInvalidMsg("%s can not be set", common.CollectionReplicaTopologyKey)
+	}
+
+	if err := common.ValidateCollectionMaintenanceWindows(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if _, err := common.CollectionFilterStrategy(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if _, err := common.CollectionBloomFilterSize(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if _, err := common.CollectionBloomFilterFalsePositive(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
 	if err := validateFunction(t.schema); err != nil {
 		return err
 	}
@@ -694,6 +729,21 @@ func (t *describeCollectionTask) Execute(ctx context.Context) error {
 	t.result.ConsistencyLevel = result.ConsistencyLevel
 	t.result.Aliases = result.Aliases
 	t.result.Properties = result.Properties
+	// the replicas serving the collection, for the clients preferring the resource group close to them,
+	// the shard leaders are cached by the name the requests use
+	collectionName := t.GetCollectionName()
+	if collectionName == "" {
+		collectionName = result.GetSchema().GetName()
+	}
+	if topology := getReplicaTopology(globalMetaCache.PeekShards(t.GetDbName(), collectionName)); len(topology) > 0 {
+		value, err := json.Marshal(topology)
+		if err != nil {
+			return err
+		}
+		t.result.Properties = append(lo.Filter(result.Properties, func(kv *commonpb.KeyValuePair, _ int) bool {
+			return kv.GetKey() != common.CollectionReplicaTopologyKey
+		}), &commonpb.KeyValuePair{Key: common.CollectionReplicaTopologyKey, Value: string(value)})
+	}
 	t.result.DbName = result.GetDbName()
 	t.result.NumPartitions = result.NumPartitions
 	for _, field := range result.Schema.Fields {
@@ -954,6 +1004,24 @@ func hasLazyLoadProp(props ...*commonpb.KeyValuePair) bool {
 	return false
 }
 
+func hasPartitionKeyHashFunctionProp(props ...*commonpb.KeyValuePair) bool {
+	for _, p := range props {
+		if p.GetKey() == common.PartitionKeyHashFunctionKey {
+			return true
+		}
+	}
+	return false
+}
+
+func hasReplicaTopologyProp(props ...*commonpb.KeyValuePair) bool {
+	for _, p := range props {
+		if p.GetKey() == common.CollectionReplicaTopologyKey {
+			return true
+		}
+	}
+	return false
+}
+
 func hasPropInDeletekeys(keys []string) string {
 	for _, key := range keys {
 		if key == common.MmapEnabledKey || key == common.LazyLoadEnableKey {
@@ -1001,6 +1069,31 @@ func (t *alterCollectionTask) PreExecute(ctx context.Context) error {
 
 	t.CollectionID = collectionID
 
+	if err := common.ValidateCollectionMaintenanceWindows(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if _, err := common.CollectionFilterStrategy(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if _, err := common.CollectionBloomFilterSize(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if _, err := common.CollectionBloomFilterFalsePositive(t.GetProperties()...); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+
+	if hasReplicaTopologyProp(t.GetProperties()...) {
+		return merr.WrapErrParameterInvalidMsg("%s can not be set", common.CollectionReplicaTopologyKey)
+	}
+
+	// the data is already routed by the partition key hash function
+	if hasPartitionKeyHashFunctionProp(t.GetProperties()...) || funcutil.SliceContain(t.GetDeleteKeys(), common.PartitionKeyHashFunctionKey) {
+		return merr.WrapErrParameterInvalidMsg("%s can not be altered after the collection is created", common.PartitionKeyHashFunctionKey)
+	}
+
 	if len(t.GetProperties()) > 0 {
 		if hasMmapProp(t.Properties...) || hasLazyLoadProp(t.Properties...) {
 			loaded, err := isCollectionLoaded(ctx, t.queryCoord, t.CollectionID)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"go.opentelemetry.io/otel"
@@ -22,6 +23,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -313,6 +315,16 @@ type deleteRunner struct {
 
 	allQueryCnt atomic.Int64
 	sessionTS   atomic.Uint64
+
+	// rows reserved by produced delete batches, used to enforce the affected rows limit
+	affectedRows atomic.Int64
+	// rows matched by the delete expr on the snapshot, counted only if the affected rows limit is set
+	matchedRows atomic.Int64
+	// channels whose matched rows are counted, so a retried count is never added twice
+	countedChannels typeutil.ConcurrentSet[string]
+	// channels whose matched rows are all deleted, skipped when the delete is resumed
+	completedChannels typeutil.ConcurrentSet[string]
+	startTime         time.Time
 }
 
 func (dr *deleteRunner) Init(ctx context.Context) error {
@@ -388,6 +400,9 @@ func (dr *deleteRunner) Run(ctx context.Context) error {
 	isSimple, pk, numRow := getPrimaryKeysFromPlan(dr.schema.CollectionSchema, plan)
 	if isSimple {
 		// if could get delete.primaryKeys from delete expr
+		if err := dr.reserveAffectedRows(numRow); err != nil {
+			return err
+		}
 		err := dr.simpleDelete(ctx, pk, numRow)
 		if err != nil {
 			return err
@@ -432,29 +447,223 @@ func (dr *deleteRunner) produce(ctx context.Context, primaryKeys *schemapb.IDs)
 	return dt, nil
 }
 
-// getStreamingQueryAndDelteFunc return query function used by LBPolicy
+// getPartitionIDs returns the partitions to delete from, nil means all partitions.
+func (dr *deleteRunner) getPartitionIDs(ctx context.Context, plan *planpb.PlanNode) ([]int64, error) {
+	// optimize query when partitionKey on
+	if dr.partitionKeyMode {
+		expr, err := exprutil.ParseExprFromPlan(plan)
+		if err != nil {
+			return nil, err
+		}
+		partitionKeys := exprutil.ParseKeys(expr, exprutil.PartitionKey)
+		hashedPartitionNames, err := assignPartitionKeys(ctx, dr.req.GetDbName(), dr.req.GetCollectionName(), partitionKeys)
+		if err != nil {
+			return nil, err
+		}
+		return getPartitionIDs(ctx, dr.req.GetDbName(), dr.req.GetCollectionName(), hashedPartitionNames)
+	} else if dr.partitionID != common.InvalidFieldID {
+		return []int64{dr.partitionID}, nil
+	}
+	return nil, nil
+}
+
+// getCountFunc returns the function used by LBPolicy counting the rows matched by the delete plan on the snapshot,
 // make sure it concurrent safe
-func (dr *deleteRunner) getStreamingQueryAndDelteFunc(plan *planpb.PlanNode) executeFunc {
+func (dr *deleteRunner) getCountFunc(plan *planpb.PlanNode) executeFunc {
 	return func(ctx context.Context, nodeID int64, qn types.QueryNodeClient, channel string) error {
-		var partitionIDs []int64
+		// rows of the channels completed before resuming are already deleted
+		if dr.completedChannels.Contain(channel) {
+			return nil
+		}
+		partitionIDs, err := dr.getPartitionIDs(ctx, plan)
+		if err != nil {
+			return err
+		}
 
-		// optimize query when partitionKey on
-		if dr.partitionKeyMode {
-			expr, err := exprutil.ParseExprFromPlan(plan)
-			if err != nil {
-				return err
-			}
-			partitionKeys := exprutil.ParseKeys(expr, exprutil.PartitionKey)
-			hashedPartitionNames, err := assignPartitionKeys(ctx, dr.req.GetDbName(), dr.req.GetCollectionName(), partitionKeys)
-			if err != nil {
-				return err
-			}
-			partitionIDs, err = getPartitionIDs(ctx, dr.req.GetDbName(), dr.req.GetCollectionName(), hashedPartitionNames)
-			if err != nil {
-				return err
-			}
-		} else if dr.partitionID != common.InvalidFieldID {
-			partitionIDs = []int64{dr.partitionID}
+		cntPlan := proto.Clone(plan).(*planpb.PlanNode)
+		cntPlan.GetQuery().IsCount = true
+		cntPlan.OutputFieldIds = nil
+		serializedPlan, err := proto.Marshal(cntPlan)
+		if err != nil {
+			return err
+		}
+
+		result, err := qn.Query(ctx, &querypb.QueryRequest{
+			Req: &internalpb.RetrieveRequest{
+				Base: commonpbutil.NewMsgBase(
+					commonpbutil.WithMsgType(commonpb.MsgType_Retrieve),
+					commonpbutil.WithMsgID(dr.msgID),
+					commonpbutil.WithSourceID(paramtable.GetNodeID()),
+					commonpbutil.WithTargetID(nodeID),
+				),
+				MvccTimestamp:      dr.ts,
+				ReqID:              paramtable.GetNodeID(),
+				CollectionID:       dr.collectionID,
+				PartitionIDs:       partitionIDs,
+				SerializedExprPlan: serializedPlan,
+				IsCount:            true,
+				GuaranteeTimestamp: dr.ts,
+			},
+			DmlChannels: []string{channel},
+			Scope:       querypb.DataScope_All,
+		})
+		if err = merr.CheckRPCCall(result, err); err != nil {
+			log.Ctx(ctx).Warn("count for delete failed", zap.Int64("msgID", dr.msgID), zap.String("channel", channel), zap.Error(err))
+			return err
+		}
+		cnt, err := funcutil.CntOfInternalResult(result)
+		if err != nil {
+			return err
+		}
+		// only the successful attempt of each channel is committed
+		if dr.countedChannels.Insert(channel) {
+			dr.matchedRows.Add(cnt)
+		}
+		return nil
+	}
+}
+
+// checkAffectedRows counts the rows matched by the delete expr on the snapshot before deleting anything,
+// and refuses the delete if they exceed the configured max affected rows.
+func (dr *deleteRunner) checkAffectedRows(ctx context.Context, plan *planpb.PlanNode) error {
+	limit := paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.GetAsInt64()
+	if limit <= 0 {
+		return nil
+	}
+	err := dr.lb.Execute(ctx, CollectionWorkLoad{
+		db:             dr.req.GetDbName(),
+		collectionName: dr.req.GetCollectionName(),
+		collectionID:   dr.collectionID,
+		nq:             1,
+		exec:           dr.getCountFunc(plan),
+	})
+	if err != nil {
+		return err
+	}
+	if matched := dr.matchedRows.Load(); matched > limit {
+		return merr.WrapErrParameterInvalidMsg("delete expr %s matches %d rows, which exceeds %s %d",
+			dr.req.GetExpr(), matched, paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.Key, limit)
+	}
+	return nil
+}
+
+// getSampleFunc returns the function used by LBPolicy querying at most limit primary keys matched by the delete plan on the snapshot,
+// make sure it concurrent safe
+func (dr *deleteRunner) getSampleFunc(plan *planpb.PlanNode, limit int64, samples *typeutil.ConcurrentMap[string, *schemapb.IDs]) executeFunc {
+	return func(ctx context.Context, nodeID int64, qn types.QueryNodeClient, channel string) error {
+		partitionIDs, err := dr.getPartitionIDs(ctx, plan)
+		if err != nil {
+			return err
+		}
+
+		_, outputFieldIDs := translatePkOutputFields(dr.schema.CollectionSchema)
+		samplePlan := proto.Clone(plan).(*planpb.PlanNode)
+		samplePlan.GetQuery().Limit = limit
+		samplePlan.OutputFieldIds = outputFieldIDs
+		serializedPlan, err := proto.Marshal(samplePlan)
+		if err != nil {
+			return err
+		}
+
+		result, err := qn.Query(ctx, &querypb.QueryRequest{
+			Req: &internalpb.RetrieveRequest{
+				Base: commonpbutil.NewMsgBase(
+					commonpbutil.WithMsgType(commonpb.MsgType_Retrieve),
+					commonpbutil.WithMsgID(dr.msgID),
+					commonpbutil.WithSourceID(paramtable.GetNodeID()),
+					commonpbutil.WithTargetID(nodeID),
+				),
+				MvccTimestamp:      dr.ts,
+				ReqID:              paramtable.GetNodeID(),
+				CollectionID:       dr.collectionID,
+				PartitionIDs:       partitionIDs,
+				SerializedExprPlan: serializedPlan,
+				OutputFieldsId:     outputFieldIDs,
+				Limit:              limit,
+				GuaranteeTimestamp: dr.ts,
+			},
+			DmlChannels: []string{channel},
+			Scope:       querypb.DataScope_All,
+		})
+		if err = merr.CheckRPCCall(result, err); err != nil {
+			log.Ctx(ctx).Warn("sample for delete preview failed", zap.Int64("msgID", dr.msgID), zap.String("channel", channel), zap.Error(err))
+			return err
+		}
+		// the sample of a retried attempt replaces the failed one
+		samples.Insert(channel, result.GetIds())
+		return nil
+	}
+}
+
+// preview counts the rows matched by the delete expr and samples at most limit of their primary keys without deleting anything,
+// the count and the sample are evaluated on the same snapshot, so they never disagree.
+func (dr *deleteRunner) preview(ctx context.Context, limit int64) (int64, *schemapb.IDs, error) {
+	plan, err := planparserv2.CreateRetrievePlan(dr.schema.schemaHelper, dr.req.GetExpr(), dr.req.GetExprTemplateValues())
+	if err != nil {
+		return 0, nil, merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("failed to create delete plan: %v", err))
+	}
+	if planparserv2.IsAlwaysTruePlan(plan) {
+		return 0, nil, merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("delete plan can't be empty or always true : %s", dr.req.GetExpr()))
+	}
+
+	dr.msgID, err = dr.idAllocator.AllocOne()
+	if err != nil {
+		return 0, nil, err
+	}
+	dr.ts, err = dr.tsoAllocatorIns.AllocOne(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	err = dr.lb.Execute(ctx, CollectionWorkLoad{
+		db:             dr.req.GetDbName(),
+		collectionName: dr.req.GetCollectionName(),
+		collectionID:   dr.collectionID,
+		nq:             1,
+		exec:           dr.getCountFunc(plan),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	count := dr.matchedRows.Load()
+
+	primaryKeys := &schemapb.IDs{}
+	if count == 0 || limit == 0 {
+		return count, primaryKeys, nil
+	}
+	samples := typeutil.NewConcurrentMap[string, *schemapb.IDs]()
+	err = dr.lb.Execute(ctx, CollectionWorkLoad{
+		db:             dr.req.GetDbName(),
+		collectionName: dr.req.GetCollectionName(),
+		collectionID:   dr.collectionID,
+		nq:             1,
+		exec:           dr.getSampleFunc(plan, limit, samples),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, channel := range dr.vChannels {
+		ids, ok := samples.Get(channel)
+		if !ok {
+			continue
+		}
+		for i := 0; i < typeutil.GetSizeOfIDs(ids) && int64(typeutil.GetSizeOfIDs(primaryKeys)) < limit; i++ {
+			typeutil.AppendIDs(primaryKeys, ids, i)
+		}
+	}
+	return count, primaryKeys, nil
+}
+
+// getStreamingQueryAndDelteFunc return query function used by LBPolicy
+// make sure it concurrent safe
+func (dr *deleteRunner) getStreamingQueryAndDelteFunc(plan *planpb.PlanNode) executeFunc {
+	return func(ctx context.Context, nodeID int64, qn types.QueryNodeClient, channel string) (err error) {
+		if dr.completedChannels.Contain(channel) {
+			return nil
+		}
+		partitionIDs, err := dr.getPartitionIDs(ctx, plan)
+		if err != nil {
+			return err
 		}
 
 		log := log.Ctx(ctx).With(
@@ -488,7 +697,9 @@ func (dr *deleteRunner) getStreamingQueryAndDelteFunc(plan *planpb.PlanNode) exe
 				PartitionIDs:       partitionIDs,
 				SerializedExprPlan: serializedPlan,
 				OutputFieldsId:     outputFieldIDs,
-				GuaranteeTimestamp: parseGuaranteeTsFromConsistency(dr.ts, dr.ts, dr.req.GetConsistencyLevel()),
+				// always wait until the snapshot is complete regardless of the consistency level,
+				// otherwise rows written before the snapshot may be partially missed
+				GuaranteeTimestamp: dr.ts,
 			},
 			DmlChannels: []string{channel},
 			Scope:       querypb.DataScope_All,
@@ -503,10 +714,20 @@ func (dr *deleteRunner) getStreamingQueryAndDelteFunc(plan *planpb.PlanNode) exe
 			return err
 		}
 
+		// the rows reserved and deleted by this attempt are rolled back if it fails,
+		// the retry queries the same snapshot and deletes them again
+		var reserved, deleted atomic.Int64
+		defer func() {
+			if err != nil {
+				dr.affectedRows.Sub(reserved.Load())
+				dr.count.Sub(deleted.Load())
+			}
+		}()
+
 		taskCh := make(chan *deleteTask, 256)
 		var receiveErr error
 		go func() {
-			receiveErr = dr.receiveQueryResult(ctx, client, taskCh, partitionIDs)
+			receiveErr = dr.receiveQueryResult(ctx, client, taskCh, partitionIDs, &reserved)
 			close(taskCh)
 		}()
 		var allQueryCnt int64
@@ -517,7 +738,8 @@ func (dr *deleteRunner) getStreamingQueryAndDelteFunc(plan *planpb.PlanNode) exe
 			if err != nil {
 				return err
 			}
-			dr.count.Add(task.count)
+			deleted.Add(task.count)
+			dr.reportProgress(ctx, task.count)
 			allQueryCnt += task.allQueryCnt
 			if sessionTS < task.sessionTS {
 				sessionTS = task.sessionTS
@@ -530,11 +752,12 @@ func (dr *deleteRunner) getStreamingQueryAndDelteFunc(plan *planpb.PlanNode) exe
 		}
 		dr.allQueryCnt.Add(allQueryCnt)
 		dr.sessionTS.Store(sessionTS)
+		dr.completedChannels.Insert(channel)
 		return nil
 	}
 }
 
-func (dr *deleteRunner) receiveQueryResult(ctx context.Context, client querypb.QueryNode_QueryStreamClient, taskCh chan *deleteTask, partitionIDs []int64) error {
+func (dr *deleteRunner) receiveQueryResult(ctx context.Context, client querypb.QueryNode_QueryStreamClient, taskCh chan *deleteTask, partitionIDs []int64, reserved *atomic.Int64) error {
 	for {
 		result, err := client.Recv()
 		if err != nil {
@@ -551,6 +774,13 @@ func (dr *deleteRunner) receiveQueryResult(ctx context.Context, client querypb.Q
 			return err
 		}
 
+		num := int64(typeutil.GetSizeOfIDs(result.GetIds()))
+		reserved.Add(num)
+		if err := dr.reserveAffectedRows(num); err != nil {
+			log.Warn("query stream for delete stopped because of affected rows limit", zap.Int64("msgID", dr.msgID), zap.Error(err))
+			return err
+		}
+
 		if dr.limiter != nil {
 			err := dr.limiter.Alloc(ctx, dr.dbID, map[int64][]int64{dr.collectionID: partitionIDs}, internalpb.RateType_DMLDelete, proto.Size(result.GetIds()))
 			if err != nil {
@@ -570,6 +800,35 @@ func (dr *deleteRunner) receiveQueryResult(ctx context.Context, client querypb.Q
 	}
 }
 
+// reserveAffectedRows reserves the rows to be deleted by next batch,
+// returns error if the total exceeds the configured max affected rows.
+// The matched rows of complex delete are checked before deleting anything,
+// this guards the batches in case the query returns more rows than counted.
+func (dr *deleteRunner) reserveAffectedRows(num int64) error {
+	total := dr.affectedRows.Add(num)
+	limit := paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.GetAsInt64()
+	if limit > 0 && total > limit {
+		return merr.WrapErrParameterInvalidMsg("delete expr %s affects more than %d rows, which exceeds %s",
+			dr.req.GetExpr(), limit, paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.Key)
+	}
+	return nil
+}
+
+// reportProgress accumulates deleted rows, which are listed by the delete progress management api,
+// and logs the progress of complex delete periodically.
+func (dr *deleteRunner) reportProgress(ctx context.Context, deleted int64) {
+	total := dr.count.Add(deleted)
+	step := paramtable.Get().ProxyCfg.DeleteProgressLogEntries.GetAsInt64()
+	if step <= 0 || total/step == (total-deleted)/step {
+		return
+	}
+	log.Ctx(ctx).Info("complex delete in progress",
+		zap.Int64("msgID", dr.msgID),
+		zap.Int64("collectionID", dr.collectionID),
+		zap.String("expr", dr.req.GetExpr()),
+		zap.Int64("deletedCnt", total))
+}
+
 func (dr *deleteRunner) complexDelete(ctx context.Context, plan *planpb.PlanNode) error {
 	rc := timerecord.NewTimeRecorder("QueryStreamDelete")
 	var err error
@@ -579,10 +838,32 @@ func (dr *deleteRunner) complexDelete(ctx context.Context, plan *planpb.PlanNode
 		return err
 	}
 
+	// all shards and retries evaluate the expr on the same snapshot,
+	// so rows inserted concurrently after it are never affected
 	dr.ts, err = dr.tsoAllocatorIns.AllocOne(ctx)
 	if err != nil {
 		return err
 	}
+	// a resumed delete continues on the snapshot of the failed one, skipping its completed channels
+	resumeTs, completed, err := getDeleteResumeInfo(dr.req.GetBase(), dr.vChannels, dr.ts)
+	if err != nil {
+		return err
+	}
+	if resumeTs > 0 {
+		dr.ts = resumeTs
+		dr.completedChannels.Upsert(completed...)
+		log.Info("resume complex delete", zap.Uint64("snapshotTs", dr.ts), zap.Strings("completedChannels", completed))
+	}
+	SetSnapshotTs(dr.result.GetStatus(), dr.ts)
+
+	dr.startTime = time.Now()
+	globalDeleteProgress.register(dr)
+	defer globalDeleteProgress.unregister(dr.msgID)
+
+	if err = dr.checkAffectedRows(ctx, plan); err != nil {
+		log.Warn("complex delete refused before deleting", zap.Uint64("snapshotTs", dr.ts), zap.Error(err))
+		return err
+	}
 
 	err = dr.lb.Execute(ctx, CollectionWorkLoad{
 		db:             dr.req.GetDbName(),
@@ -596,15 +877,27 @@ func (dr *deleteRunner) complexDelete(ctx context.Context, plan *planpb.PlanNode
 	if err != nil {
 		log.Warn("fail to execute complex delete",
 			zap.Int64("deleteCnt", dr.result.GetDeleteCnt()),
+			zap.Uint64("snapshotTs", dr.ts),
 			zap.Duration("interval", rc.ElapseSpan()),
 			zap.Error(err))
 		return err
 	}
 
-	log.Info("complex delete finished", zap.Int64("deleteCnt", dr.result.GetDeleteCnt()), zap.Duration("interval", rc.ElapseSpan()))
+	log.Info("complex delete finished", zap.Int64("deleteCnt", dr.result.GetDeleteCnt()),
+		zap.Uint64("snapshotTs", dr.ts), zap.Duration("interval", rc.ElapseSpan()))
 	return nil
 }
 
+// setResumeInfo records the snapshot ts and the completed channels of a failed complex delete,
+// passing them back in the msg base properties resumes the delete without rescanning the completed channels.
+func (dr *deleteRunner) setResumeInfo(status *commonpb.Status) {
+	if dr.ts == 0 {
+		return
+	}
+	SetSnapshotTs(status, dr.ts)
+	SetCompletedChannels(status, dr.completedChannels.Collect())
+}
+
 func (dr *deleteRunner) simpleDelete(ctx context.Context, pk *schemapb.IDs, numRow int64) error {
 	log.Debug("get primary keys from expr",
 		zap.Int64("len of primary keys", numRow),
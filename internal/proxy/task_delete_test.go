@@ -3,7 +3,9 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/allocator"
@@ -20,14 +23,79 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
+func Test_getDeleteResumeInfo(t *testing.T) {
+	paramtable.Init()
+	now := tsoutil.ComposeTSByTime(time.Now(), 0)
+	snapshotTs := tsoutil.ComposeTSByTime(time.Now().Add(-time.Minute), 0)
+	vChannels := []string{"ch1", "ch2"}
+	baseOf := func(props map[string]string) *commonpb.MsgBase {
+		return &commonpb.MsgBase{Properties: props}
+	}
+
+	ts, completed, err := getDeleteResumeInfo(nil, vChannels, now)
+	assert.NoError(t, err)
+	assert.Zero(t, ts)
+	assert.Empty(t, completed)
+
+	ts, completed, err = getDeleteResumeInfo(baseOf(map[string]string{
+		snapshotTsKey:        strconv.FormatUint(snapshotTs, 10),
+		completedChannelsKey: "ch1,ch1",
+	}), vChannels, now)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotTs, ts)
+	assert.Equal(t, []string{"ch1"}, completed)
+
+	// the completed channels are not the channels of the collection
+	_, _, err = getDeleteResumeInfo(baseOf(map[string]string{
+		snapshotTsKey:        strconv.FormatUint(snapshotTs, 10),
+		completedChannelsKey: "ch1,ch3",
+	}), vChannels, now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the completed channels without the snapshot
+	_, _, err = getDeleteResumeInfo(baseOf(map[string]string{completedChannelsKey: "ch1"}), vChannels, now)
+	assert.ErrorIs(t, err, merr.ErrParameterMissing)
+
+	// the snapshot in the future
+	_, _, err = getDeleteResumeInfo(baseOf(map[string]string{
+		snapshotTsKey: strconv.FormatUint(now+1, 10),
+	}), vChannels, now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the snapshot too old
+	oldTs := tsoutil.ComposeTSByTime(time.Now().Add(-2*time.Hour), 0)
+	_, _, err = getDeleteResumeInfo(baseOf(map[string]string{
+		snapshotTsKey: strconv.FormatUint(oldTs, 10),
+	}), vChannels, now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// bounded by the gc drop tolerance as well
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.GCDropTolerance.Key, "30")
+	_, _, err = getDeleteResumeInfo(baseOf(map[string]string{
+		snapshotTsKey: strconv.FormatUint(snapshotTs, 10),
+	}), vChannels, now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	paramtable.Get().Reset(paramtable.Get().DataCoordCfg.GCDropTolerance.Key)
+
+	// resuming disabled
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.DeleteResumeMaxAge.Key, "0")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.DeleteResumeMaxAge.Key)
+	_, _, err = getDeleteResumeInfo(baseOf(map[string]string{
+		snapshotTsKey: strconv.FormatUint(snapshotTs, 10),
+	}), vChannels, now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
 func Test_getPrimaryKeysFromPlan(t *testing.T) {
 	collSchema := &schemapb.CollectionSchema{
 		Name:        "test_delete",
@@ -507,6 +575,28 @@ func TestDeleteRunner_Run(t *testing.T) {
 		assert.Error(t, dr.Run(context.Background()))
 	})
 
+	t.Run("simple delete exceeds affected rows limit", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.Key, "2")
+		defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.Key)
+
+		mockMgr := NewMockChannelsMgr(t)
+		dr := deleteRunner{
+			chMgr:        mockMgr,
+			schema:       schema,
+			collectionID: collectionID,
+			partitionID:  partitionID,
+			vChannels:    channels,
+			req: &milvuspb.DeleteRequest{
+				CollectionName: collectionName,
+				DbName:         dbName,
+				Expr:           "pk in [1,2,3]",
+			},
+		}
+		err := dr.Run(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		assert.Equal(t, int64(0), dr.result.GetDeleteCnt())
+	})
+
 	t.Run("simple delete task failed", func(t *testing.T) {
 		mockMgr := NewMockChannelsMgr(t)
 		lb := NewMockLBPolicy(t)
@@ -806,6 +896,72 @@ func TestDeleteRunner_Run(t *testing.T) {
 		qn := mocks.NewMockQueryNodeClient(t)
 		lb := NewMockLBPolicy(t)
 
+		dr := deleteRunner{
+			queue:           queue.dmQueue,
+			chMgr:           mockMgr,
+			schema:          schema,
+			collectionID:    collectionID,
+			partitionID:     partitionID,
+			vChannels:       channels,
+			idAllocator:     idAllocator,
+			tsoAllocatorIns: tsoAllocator,
+			lb:              lb,
+			result: &milvuspb.MutationResult{
+				Status: merr.Success(),
+				IDs: &schemapb.IDs{
+					IdField: nil,
+				},
+			},
+			req: &milvuspb.DeleteRequest{
+				CollectionName:   collectionName,
+				PartitionName:    partitionName,
+				DbName:           dbName,
+				Expr:             "pk < 3",
+				ConsistencyLevel: commonpb.ConsistencyLevel_Eventually,
+			},
+		}
+		stream := msgstream.NewMockMsgStream(t)
+		mockMgr.EXPECT().getOrCreateDmlStream(mock.Anything, mock.Anything).Return(stream, nil)
+		mockMgr.EXPECT().getChannels(collectionID).Return(channels, nil)
+		lb.EXPECT().Execute(mock.Anything, mock.Anything).Call.Return(func(ctx context.Context, workload CollectionWorkLoad) error {
+			return workload.exec(ctx, 1, qn, "")
+		})
+
+		qn.EXPECT().QueryStream(mock.Anything, mock.Anything).Call.Return(
+			func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) querypb.QueryNode_QueryStreamClient {
+				// the expr is evaluated on a complete snapshot regardless of the consistency level
+				assert.Equal(t, in.GetReq().GetMvccTimestamp(), in.GetReq().GetGuaranteeTimestamp())
+				client := streamrpc.NewLocalQueryClient(ctx)
+				server := client.CreateServer()
+
+				server.Send(&internalpb.RetrieveResults{
+					Status: merr.Success(),
+					Ids: &schemapb.IDs{
+						IdField: &schemapb.IDs_IntId{
+							IntId: &schemapb.LongArray{
+								Data: []int64{0, 1, 2},
+							},
+						},
+					},
+				})
+				server.FinishSend(nil)
+				return client
+			}, nil)
+		stream.EXPECT().Produce(mock.Anything, mock.Anything).Return(nil)
+
+		assert.NoError(t, dr.Run(ctx))
+		assert.Equal(t, int64(3), dr.result.DeleteCnt)
+		assert.Equal(t, dr.ts, GetSnapshotTs(dr.result.GetStatus()))
+	})
+
+	t.Run("complex delete retry counted once", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockMgr := NewMockChannelsMgr(t)
+		qn := mocks.NewMockQueryNodeClient(t)
+		lb := NewMockLBPolicy(t)
+
 		dr := deleteRunner{
 			queue:           queue.dmQueue,
 			chMgr:           mockMgr,
@@ -833,11 +989,17 @@ func TestDeleteRunner_Run(t *testing.T) {
 		mockMgr.EXPECT().getOrCreateDmlStream(mock.Anything, mock.Anything).Return(stream, nil)
 		mockMgr.EXPECT().getChannels(collectionID).Return(channels, nil)
 		lb.EXPECT().Execute(mock.Anything, mock.Anything).Call.Return(func(ctx context.Context, workload CollectionWorkLoad) error {
-			return workload.exec(ctx, 1, qn, "")
+			// the first attempt fails after deleting a batch, the retry deletes it again
+			if err := workload.exec(ctx, 1, qn, "ch1"); err == nil {
+				return errors.New("expect the first attempt to fail")
+			}
+			return workload.exec(ctx, 2, qn, "ch1")
 		})
 
+		attempts := 0
 		qn.EXPECT().QueryStream(mock.Anything, mock.Anything).Call.Return(
 			func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) querypb.QueryNode_QueryStreamClient {
+				attempts++
 				client := streamrpc.NewLocalQueryClient(ctx)
 				server := client.CreateServer()
 
@@ -851,13 +1013,202 @@ func TestDeleteRunner_Run(t *testing.T) {
 						},
 					},
 				})
-				server.FinishSend(nil)
+				if attempts == 1 {
+					server.FinishSend(errors.New("mock error"))
+				} else {
+					server.FinishSend(nil)
+				}
 				return client
 			}, nil)
 		stream.EXPECT().Produce(mock.Anything, mock.Anything).Return(nil)
 
 		assert.NoError(t, dr.Run(ctx))
 		assert.Equal(t, int64(3), dr.result.DeleteCnt)
+		assert.Equal(t, int64(3), dr.affectedRows.Load())
+		assert.True(t, dr.completedChannels.Contain("ch1"))
+	})
+
+	t.Run("complex delete resumed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockMgr := NewMockChannelsMgr(t)
+		qn := mocks.NewMockQueryNodeClient(t)
+		lb := NewMockLBPolicy(t)
+
+		resumeTs := tsoutil.ComposeTSByTime(time.Now().Add(-time.Minute), 0)
+		dr := deleteRunner{
+			queue:           queue.dmQueue,
+			chMgr:           mockMgr,
+			schema:          schema,
+			collectionID:    collectionID,
+			partitionID:     partitionID,
+			vChannels:       []string{"ch1", "ch2"},
+			idAllocator:     idAllocator,
+			tsoAllocatorIns: tsoAllocator,
+			lb:              lb,
+			result: &milvuspb.MutationResult{
+				Status: merr.Success(),
+				IDs: &schemapb.IDs{
+					IdField: nil,
+				},
+			},
+			req: &milvuspb.DeleteRequest{
+				Base: &commonpb.MsgBase{
+					Properties: map[string]string{
+						snapshotTsKey:        strconv.FormatUint(resumeTs, 10),
+						completedChannelsKey: "ch1",
+					},
+				},
+				CollectionName: collectionName,
+				PartitionName:  partitionName,
+				DbName:         dbName,
+				Expr:           "pk < 3",
+			},
+		}
+		stream := msgstream.NewMockMsgStream(t)
+		mockMgr.EXPECT().getOrCreateDmlStream(mock.Anything, mock.Anything).Return(stream, nil)
+		mockMgr.EXPECT().getChannels(collectionID).Return(channels, nil)
+		lb.EXPECT().Execute(mock.Anything, mock.Anything).Call.Return(func(ctx context.Context, workload CollectionWorkLoad) error {
+			if err := workload.exec(ctx, 1, qn, "ch1"); err != nil {
+				return err
+			}
+			return workload.exec(ctx, 1, qn, "ch2")
+		})
+
+		// only the uncompleted channel is queried, on the snapshot of the failed delete
+		qn.EXPECT().QueryStream(mock.Anything, mock.Anything).Call.Return(
+			func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) querypb.QueryNode_QueryStreamClient {
+				assert.Equal(t, []string{"ch2"}, in.GetDmlChannels())
+				assert.Equal(t, resumeTs, in.GetReq().GetMvccTimestamp())
+				client := streamrpc.NewLocalQueryClient(ctx)
+				server := client.CreateServer()
+
+				server.Send(&internalpb.RetrieveResults{
+					Status: merr.Success(),
+					Ids: &schemapb.IDs{
+						IdField: &schemapb.IDs_IntId{
+							IntId: &schemapb.LongArray{
+								Data: []int64{2},
+							},
+						},
+					},
+				})
+				server.FinishSend(nil)
+				return client
+			}, nil).Once()
+		stream.EXPECT().Produce(mock.Anything, mock.Anything).Return(nil)
+
+		assert.NoError(t, dr.Run(ctx))
+		assert.Equal(t, int64(1), dr.result.DeleteCnt)
+		assert.Equal(t, resumeTs, GetSnapshotTs(dr.result.GetStatus()))
+
+		status := merr.Status(errors.New("mock error"))
+		dr.setResumeInfo(status)
+		assert.Equal(t, "ch1,ch2", status.GetExtraInfo()[completedChannelsKey])
+	})
+
+	t.Run("complex delete refused by affected rows limit", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.Key, "2")
+		defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.MaxDeleteAffectedRows.Key)
+
+		mockMgr := NewMockChannelsMgr(t)
+		qn := mocks.NewMockQueryNodeClient(t)
+		lb := NewMockLBPolicy(t)
+
+		dr := deleteRunner{
+			queue:           queue.dmQueue,
+			chMgr:           mockMgr,
+			schema:          schema,
+			collectionID:    collectionID,
+			partitionID:     partitionID,
+			vChannels:       channels,
+			idAllocator:     idAllocator,
+			tsoAllocatorIns: tsoAllocator,
+			lb:              lb,
+			result: &milvuspb.MutationResult{
+				Status: merr.Success(),
+				IDs: &schemapb.IDs{
+					IdField: nil,
+				},
+			},
+			req: &milvuspb.DeleteRequest{
+				CollectionName: collectionName,
+				PartitionName:  partitionName,
+				DbName:         dbName,
+				Expr:           "pk < 3",
+			},
+		}
+		lb.EXPECT().Execute(mock.Anything, mock.Anything).Call.Return(func(ctx context.Context, workload CollectionWorkLoad) error {
+			return workload.exec(ctx, 1, qn, "")
+		})
+		qn.EXPECT().Query(mock.Anything, mock.Anything).Call.Return(
+			func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) *internalpb.RetrieveResults {
+				// the matched rows are counted on the delete snapshot
+				assert.True(t, in.GetReq().GetIsCount())
+				assert.Equal(t, in.GetReq().GetMvccTimestamp(), in.GetReq().GetGuaranteeTimestamp())
+				return funcutil.WrapCntToInternalResult(3)
+			}, nil)
+
+		// refused before querying the primary keys to delete
+		err := dr.Run(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		assert.Equal(t, int64(0), dr.result.GetDeleteCnt())
+		assert.Equal(t, int64(3), dr.matchedRows.Load())
+		assert.Empty(t, globalDeleteProgress.List())
+	})
+
+	t.Run("preview delete", func(t *testing.T) {
+		qn := mocks.NewMockQueryNodeClient(t)
+		lb := NewMockLBPolicy(t)
+
+		dr := deleteRunner{
+			schema:          schema,
+			collectionID:    collectionID,
+			partitionID:     partitionID,
+			vChannels:       channels,
+			idAllocator:     idAllocator,
+			tsoAllocatorIns: tsoAllocator,
+			lb:              lb,
+			req: &milvuspb.DeleteRequest{
+				CollectionName: collectionName,
+				PartitionName:  partitionName,
+				DbName:         dbName,
+				Expr:           "pk < 3",
+			},
+		}
+		lb.EXPECT().Execute(mock.Anything, mock.Anything).Call.Return(func(ctx context.Context, workload CollectionWorkLoad) error {
+			return workload.exec(ctx, 1, qn, channels[0])
+		})
+		var snapshotTs uint64
+		qn.EXPECT().Query(mock.Anything, mock.Anything).Call.Return(
+			func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) *internalpb.RetrieveResults {
+				// the count and the sample are evaluated on the same snapshot
+				if snapshotTs == 0 {
+					snapshotTs = in.GetReq().GetMvccTimestamp()
+				}
+				assert.Equal(t, snapshotTs, in.GetReq().GetMvccTimestamp())
+				assert.Equal(t, snapshotTs, in.GetReq().GetGuaranteeTimestamp())
+				if in.GetReq().GetIsCount() {
+					return funcutil.WrapCntToInternalResult(3)
+				}
+				assert.Equal(t, int64(2), in.GetReq().GetLimit())
+				return &internalpb.RetrieveResults{
+					Status: merr.Success(),
+					Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{0, 1}}}},
+				}
+			}, nil)
+
+		count, primaryKeys, err := dr.preview(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, []int64{0, 1}, primaryKeys.GetIntId().GetData())
+		assert.Equal(t, snapshotTs, dr.ts)
+
+		// always true expr is refused as delete does
+		dr.req.Expr = " "
+		_, _, err = dr.preview(context.Background(), 2)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
 	})
 
 	schema.Fields[1].IsPartitionKey = true
@@ -191,6 +191,15 @@ func (cit *createIndexTask) parseIndexParams() error {
 		return err
 	}
 
+	// the resource groups binding is only kept in the user index params, it's not a param to build the index
+	resourceGroups, bindResourceGroups := indexParamsMap[common.IndexResourceGroupsKey]
+	if bindResourceGroups {
+		if !isVecIndex {
+			return merr.WrapErrParameterInvalidMsg("%s is only supported by vector index", common.IndexResourceGroupsKey)
+		}
+		delete(indexParamsMap, common.IndexResourceGroupsKey)
+	}
+
 	specifyIndexType, exist := indexParamsMap[common.IndexTypeKey]
 	if exist && specifyIndexType != "" {
 		if err := indexparamcheck.ValidateMmapIndexParams(specifyIndexType, indexParamsMap); err != nil {
@@ -405,6 +414,10 @@ func (cit *createIndexTask) parseIndexParams() error {
 		cit.newTypeParams = append(cit.newTypeParams, &commonpb.KeyValuePair{Key: k, Value: v})
 	}
 
+	// the user index params may be replaced by autoindex
+	if bindResourceGroups && common.GetIndexResourceGroups(cit.newExtraParams...) == nil {
+		cit.newExtraParams = append(cit.newExtraParams, &commonpb.KeyValuePair{Key: common.IndexResourceGroupsKey, Value: resourceGroups})
+	}
 	return nil
 }
 
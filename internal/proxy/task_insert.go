@@ -217,16 +217,86 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		}
 	}
 
-	if err := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck(), withMaxCapCheck()).
-		Validate(it.insertMsg.GetFieldsData(), schema.schemaHelper, it.insertMsg.NRows()); err != nil {
+	v := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck(), withMaxCapCheck())
+	if Params.ProxyCfg.SkipInvalidVectorRows.GetAsBool() {
+		v.apply(withInvalidVectorRowsSkipped())
+	}
+	if err := v.Validate(it.insertMsg.GetFieldsData(), schema.schemaHelper, it.insertMsg.NRows()); err != nil {
 		return merr.WrapErrAsInputError(err)
 	}
+	if invalidRows := v.InvalidRows(); len(invalidRows) > 0 {
+		if err := it.dropInvalidRows(invalidRows); err != nil {
+			return merr.WrapErrAsInputError(err)
+		}
+		log.Warn("skip invalid vector rows in insert request",
+			zap.Int("skippedRows", len(invalidRows)),
+			zap.String("reasons", formatRowErrors(invalidRows)))
+	}
 
 	log.Debug("Proxy Insert PreExecute done")
 
 	return nil
 }
 
+// dropInvalidRows removes the rows rejected by validation from the insert message,
+// their offsets in the original request are reported as ErrIndex of the result.
+func (it *insertTask) dropInvalidRows(rows []rowError) error {
+	numRows := int(it.insertMsg.NRows())
+	if len(rows) >= numRows {
+		return merr.WrapErrParameterInvalidMsg("all %d rows are invalid: %s", numRows, formatRowErrors(rows))
+	}
+	invalid := typeutil.NewSet[int]()
+	for _, row := range rows {
+		invalid.Insert(row.Index)
+	}
+
+	srcFields := it.insertMsg.GetFieldsData()
+	fieldsData := make([]*schemapb.FieldData, len(srcFields))
+	ids := &schemapb.IDs{}
+	var partitionKeys []*schemapb.FieldData
+	if it.partitionKeys != nil {
+		partitionKeys = make([]*schemapb.FieldData, 1)
+	}
+	keepHashValues := len(it.insertMsg.HashValues) == numRows
+	rowIDs := make([]UniqueID, 0, numRows-len(rows))
+	timestamps := make([]uint64, 0, numRows-len(rows))
+	hashValues := make([]uint32, 0, numRows-len(rows))
+	succIndex := make([]uint32, 0, numRows-len(rows))
+	errIndex := make([]uint32, 0, len(rows))
+	for i := 0; i < numRows; i++ {
+		if invalid.Contain(i) {
+			errIndex = append(errIndex, uint32(i))
+			continue
+		}
+		typeutil.AppendFieldData(fieldsData, srcFields, int64(i))
+		typeutil.AppendIDs(ids, it.result.GetIDs(), i)
+		if partitionKeys != nil {
+			typeutil.AppendFieldData(partitionKeys, []*schemapb.FieldData{it.partitionKeys}, int64(i))
+		}
+		if keepHashValues {
+			hashValues = append(hashValues, it.insertMsg.HashValues[i])
+		}
+		rowIDs = append(rowIDs, it.insertMsg.RowIDs[i])
+		timestamps = append(timestamps, it.insertMsg.Timestamps[i])
+		succIndex = append(succIndex, uint32(i))
+	}
+
+	it.insertMsg.FieldsData = fieldsData
+	it.insertMsg.NumRows = uint64(len(succIndex))
+	it.insertMsg.RowIDs = rowIDs
+	it.insertMsg.Timestamps = timestamps
+	if keepHashValues {
+		it.insertMsg.HashValues = hashValues
+	}
+	if partitionKeys != nil {
+		it.partitionKeys = partitionKeys[0]
+	}
+	it.result.IDs = ids
+	it.result.SuccIndex = succIndex
+	it.result.ErrIndex = errIndex
+	return nil
+}
+
 func (it *insertTask) Execute(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Insert-Execute")
 	defer sp.End()
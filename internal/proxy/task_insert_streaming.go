@@ -153,7 +153,14 @@ func repackInsertDataWithPartitionKeyForStreamingService(
 		partitionIDs[partitionName] = partitionID
 	}
 
-	hashValues, err := typeutil.HashKey2Partitions(partitionKeys, partitionNames)
+	hashFunction, err := getPartitionKeyHashFunction(ctx, insertMsg.GetDbName(), insertMsg.CollectionName)
+	if err != nil {
+		log.Warn("get partition key hash function failed",
+			zap.String("collectionName", insertMsg.CollectionName),
+			zap.Error(err))
+		return nil, err
+	}
+	hashValues, err := typeutil.HashKey2Partitions(partitionKeys, partitionNames, hashFunction)
 	if err != nil {
 		log.Warn("has partition keys to partitions failed",
 			zap.String("collectionName", insertMsg.CollectionName),
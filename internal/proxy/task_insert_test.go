@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -308,3 +309,47 @@ func TestMaxInsertSize(t *testing.T) {
 		assert.ErrorIs(t, err, merr.ErrParameterTooLarge)
 	})
 }
+
+func TestInsertTask_dropInvalidRows(t *testing.T) {
+	newTask := func() *insertTask {
+		return &insertTask{
+			insertMsg: &msgstream.InsertMsg{
+				InsertRequest: &msgpb.InsertRequest{
+					NumRows:    3,
+					RowIDs:     []int64{10, 11, 12},
+					Timestamps: []uint64{100, 100, 100},
+					FieldsData: []*schemapb.FieldData{
+						newScalarFieldData(&schemapb.FieldSchema{Name: "pk", DataType: schemapb.DataType_Int64}, "pk", 3),
+						newFloatVectorFieldData("vec", 3, 2),
+					},
+				},
+			},
+			result: &milvuspb.MutationResult{
+				IDs: &schemapb.IDs{
+					IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}},
+				},
+				SuccIndex: []uint32{0, 1, 2},
+			},
+		}
+	}
+
+	t.Run("drop rows", func(t *testing.T) {
+		it := newTask()
+		err := it.dropInvalidRows([]rowError{{Index: 1, Reason: "nan"}})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, it.insertMsg.NRows())
+		assert.Equal(t, []int64{10, 12}, it.insertMsg.GetRowIDs())
+		assert.Equal(t, []uint64{100, 100}, it.insertMsg.GetTimestamps())
+		assert.Equal(t, []int64{1, 3}, it.result.GetIDs().GetIntId().GetData())
+		assert.Equal(t, []uint32{0, 2}, it.result.GetSuccIndex())
+		assert.Equal(t, []uint32{1}, it.result.GetErrIndex())
+		assert.Len(t, it.insertMsg.GetFieldsData()[0].GetScalars().GetLongData().GetData(), 2)
+		assert.Len(t, it.insertMsg.GetFieldsData()[1].GetVectors().GetFloatVector().GetData(), 4)
+	})
+
+	t.Run("all rows invalid", func(t *testing.T) {
+		it := newTask()
+		err := it.dropInvalidRows([]rowError{{Index: 0}, {Index: 1}, {Index: 2}})
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+}
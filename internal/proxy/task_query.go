@@ -3,12 +3,15 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -53,7 +56,6 @@ type queryTask struct {
 	result         *milvuspb.QueryResults
 	request        *milvuspb.QueryRequest
 	qc             types.QueryCoordClient
-	ids            *schemapb.IDs
 	collectionName string
 	queryParams    *queryParams
 	schema         *schemaInfo
@@ -68,11 +70,15 @@ type queryTask struct {
 	lb               LBPolicy
 	channelsMvcc     map[string]Timestamp
 	fastSkip         bool
+	// wait for the barrier of each shard instead of the begin timestamp for strong consistency
+	consistencyBarrier bool
 
 	reQuery              bool
 	allQueryCnt          int64
 	totalRelatedDataSize int64
 	mustUsePartitionKey  bool
+	// the delegators in the resource group are preferred, empty if not requested
+	preferredResourceGroup string
 }
 
 type queryParams struct {
@@ -80,6 +86,9 @@ type queryParams struct {
 	offset     int64
 	reduceType reduce.IReduceType
 	isIterator bool
+	orderBy    []*internalpb.OrderByField
+	// fields retrieved only for ordering, dropped from the final result
+	orderByOnlyFieldIDs []int64
 }
 
 // translateToOutputFieldIDs translates output fields name to output fields id.
@@ -208,6 +217,52 @@ func parseQueryParams(queryParamsPair []*commonpb.KeyValuePair) (*queryParams, e
 	}, nil
 }
 
+// parseOrderByFields parses the order by fields from queryParamsPair, e.g. "price desc, name",
+// the results are sorted in ascending order if the direction is not specified.
+func parseOrderByFields(queryParamsPair []*commonpb.KeyValuePair, schemaHelper *typeutil.SchemaHelper) ([]*internalpb.OrderByField, error) {
+	orderByStr, err := funcutil.GetAttrByKeyFromRepeatedKV(OrderByKey, queryParamsPair)
+	// if order_by is not provided
+	if err != nil {
+		return nil, nil
+	}
+
+	orderBy := make([]*internalpb.OrderByField, 0)
+	fieldIDs := typeutil.NewSet[int64]()
+	for _, item := range strings.Split(orderByStr, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, merr.WrapErrParameterInvalid("field_name [asc|desc]", item, "value for order_by is invalid")
+		}
+		ascending := true
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				ascending = false
+			default:
+				return nil, merr.WrapErrParameterInvalid("asc or desc", parts[1], "order direction is invalid")
+			}
+		}
+		field, err := schemaHelper.GetFieldFromName(parts[0])
+		if err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg(fmt.Sprintf("order by field %s not exist", parts[0]))
+		}
+		if field.GetIsDynamic() || !reduce.IsOrderByDataTypeSupported(field.GetDataType()) {
+			return nil, merr.WrapErrParameterInvalidMsg(fmt.Sprintf("order by field %s of type %s is not supported",
+				field.GetName(), field.GetDataType().String()))
+		}
+		if fieldIDs.Contain(field.GetFieldID()) {
+			return nil, merr.WrapErrParameterInvalidMsg(fmt.Sprintf("duplicate order by field %s", field.GetName()))
+		}
+		fieldIDs.Insert(field.GetFieldID())
+		orderBy = append(orderBy, &internalpb.OrderByField{
+			FieldID:   field.GetFieldID(),
+			Ascending: ascending,
+		})
+	}
+	return orderBy, nil
+}
+
 func matchCountRule(outputs []string) bool {
 	return len(outputs) == 1 && strings.ToLower(strings.TrimSpace(outputs[0])) == "count(*)"
 }
@@ -241,6 +296,9 @@ func (t *queryTask) createPlan(ctx context.Context) error {
 		var err error
 		t.plan, err = createCntPlan(t.request.GetExpr(), schema.schemaHelper, t.request.GetExprTemplateValues())
 		t.userOutputFields = []string{"count(*)"}
+		if err == nil {
+			globalQueryStats.RecordQuery(t.GetCollectionID(), t.plan)
+		}
 		return err
 	}
 
@@ -250,6 +308,7 @@ func (t *queryTask) createPlan(ctx context.Context) error {
 		if err != nil {
 			return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("failed to create query plan: %v", err))
 		}
+		globalQueryStats.RecordQuery(t.GetCollectionID(), t.plan)
 	}
 
 	t.request.OutputFields, t.userOutputFields, t.userDynamicFields, err = translateOutputFields(t.request.OutputFields, t.schema, true)
@@ -261,6 +320,13 @@ func (t *queryTask) createPlan(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// the order by fields are required to sort the results
+	for _, field := range t.queryParams.orderBy {
+		if !lo.Contains(outputFieldIDs, field.GetFieldID()) {
+			outputFieldIDs = append(outputFieldIDs, field.GetFieldID())
+			t.queryParams.orderByOnlyFieldIDs = append(t.queryParams.orderByOnlyFieldIDs, field.GetFieldID())
+		}
+	}
 	outputFieldIDs = append(outputFieldIDs, common.TimeStampField)
 	t.RetrieveRequest.OutputFieldsId = outputFieldIDs
 	t.plan.OutputFieldIds = outputFieldIDs
@@ -359,6 +425,10 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 		}
 	}
 	t.RetrieveRequest.IgnoreGrowing = ignoreGrowing
+	// the requery of search inherits the preference of the search
+	if rg := getPreferredResourceGroup(t.request.GetQueryParams()); rg != "" {
+		t.preferredResourceGroup = rg
+	}
 
 	queryParams, err := parseQueryParams(t.request.GetQueryParams())
 	if err != nil {
@@ -379,20 +449,26 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 	t.schema = schema
 
-	if t.ids != nil {
-		pkField := ""
-		for _, field := range schema.Fields {
-			if field.IsPrimaryKey {
-				pkField = field.Name
-			}
-		}
-		t.request.Expr = IDs2Expr(pkField, t.ids)
+	t.queryParams.orderBy, err = parseOrderByFields(t.request.GetQueryParams(), schema.schemaHelper)
+	if err != nil {
+		return merr.WrapErrAsInputError(err)
+	}
+	if len(t.queryParams.orderBy) > 0 && t.queryParams.isIterator {
+		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("order by is not supported by query iterator"))
 	}
+	t.RetrieveRequest.OrderByFields = t.queryParams.orderBy
 
 	if err := t.createPlan(ctx); err != nil {
 		return err
 	}
 	t.plan.Node.(*planpb.PlanNode_Query).Query.Limit = t.RetrieveRequest.Limit
+	if len(t.queryParams.orderBy) > 0 {
+		if t.plan.GetQuery().GetIsCount() {
+			return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("count entities with order by is not allowed"))
+		}
+		// each segment returns all the matched rows, which are sorted and truncated to limit on the querynode
+		t.plan.Node.(*planpb.PlanNode_Query).Query.Limit = typeutil.Unlimited
+	}
 
 	if planparserv2.IsAlwaysTruePlan(t.plan) && t.RetrieveRequest.Limit == typeutil.Unlimited {
 		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("empty expression should be used with limit"))
@@ -466,10 +542,14 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 		t.MvccTimestamp = t.request.GetGuaranteeTimestamp()
 		t.GuaranteeTimestamp = t.request.GetGuaranteeTimestamp()
 	}
+	t.consistencyBarrier = useConsistencyBarrier(consistencyLevel, t.GetGuaranteeTimestamp(), t.GetMvccTimestamp(), t.BeginTs())
 
 	deadline, ok := t.TraceCtx().Deadline()
 	if ok {
 		t.TimeoutTimestamp = tsoutil.ComposeTSByTime(deadline, 0)
+	} else if collectionInfo.searchTimeout > 0 {
+		// the collection default timeout, enforced by the shard delegators
+		t.TimeoutTimestamp = tsoutil.ComposeTSByTime(time.Now().Add(collectionInfo.searchTimeout), 0)
 	}
 
 	t.DbID = 0 // TODO
@@ -489,11 +569,12 @@ func (t *queryTask) Execute(ctx context.Context) error {
 
 	t.resultBuf = typeutil.NewConcurrentSet[*internalpb.RetrieveResults]()
 	err := t.lb.Execute(ctx, CollectionWorkLoad{
-		db:             t.request.GetDbName(),
-		collectionID:   t.CollectionID,
-		collectionName: t.collectionName,
-		nq:             1,
-		exec:           t.queryShard,
+		db:                     t.request.GetDbName(),
+		collectionID:           t.CollectionID,
+		collectionName:         t.collectionName,
+		nq:                     1,
+		exec:                   t.queryShard,
+		preferredResourceGroup: t.preferredResourceGroup,
 	})
 	if err != nil {
 		log.Warn("fail to execute query", zap.Error(err))
@@ -545,8 +626,16 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 		return err
 	}
 	t.result.OutputFields = t.userOutputFields
+	if paramtable.Get().ProxyCfg.SchemaOrderedOutputFields.GetAsBool() {
+		t.result.OutputFields = sortOutputFieldsBySchema(t.schema.CollectionSchema, t.result.GetFieldsData(), t.result.GetOutputFields())
+	}
 	metrics.ProxyReduceResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.QueryLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
 
+	if err := checkGrpcResultSize(ctx, t.result); err != nil {
+		log.Warn("query result is too large to send", zap.Error(err))
+		return err
+	}
+
 	if t.queryParams.isIterator && t.request.GetGuaranteeTimestamp() == 0 {
 		// first page for iteration, need to set up sessionTs for iterator
 		t.result.SessionTs = getMaxMvccTsFromChannels(t.channelsMvcc, t.BeginTs())
@@ -555,6 +644,22 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 	return nil
 }
 
+// checkGrpcResultSize checks the result of a grpc request fits in one message of the proxy grpc server,
+// the result is sent to the client in one message even if it is received from querynodes in chunks,
+// so an oversized result fails with a clear error instead of the grpc max message size error.
+func checkGrpcResultSize(ctx context.Context, result proto.Message) error {
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		// not served by grpc, e.g. by the restful api
+		return nil
+	}
+	limit := paramtable.Get().ProxyGrpcServerCfg.ServerMaxSendSize.GetAsInt()
+	if size := proto.Size(result); size > limit {
+		return merr.WrapErrParameterInvalidMsg("result size %d exceeds %s %d, reduce the limit or the output fields, or use the query iterator",
+			size, paramtable.Get().ProxyGrpcServerCfg.ServerMaxSendSize.Key, limit)
+	}
+	return nil
+}
+
 func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.QueryNodeClient, channel string) error {
 	needOverrideMvcc := false
 	mvccTs := t.MvccTimestamp
@@ -566,10 +671,15 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		}
 	}
 
+	progress := QueryProgressFromContext(ctx)
+	progress.startShard(channel)
+
 	retrieveReq := typeutil.Clone(t.RetrieveRequest)
 	retrieveReq.GetBase().TargetID = nodeID
 	if needOverrideMvcc && mvccTs > 0 {
 		retrieveReq.MvccTimestamp = mvccTs
+	} else if t.consistencyBarrier {
+		retrieveReq.GuaranteeTimestamp = guaranteeTsByBarrier(ctx, t.GetCollectionID(), channel, retrieveReq.GetGuaranteeTimestamp())
 	}
 
 	req := &querypb.QueryRequest{
@@ -583,25 +693,86 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		zap.Int64("nodeID", nodeID),
 		zap.String("channel", channel))
 
+	// count results are tiny, no need to stream
+	// ordered results are truncated to limit on the querynode, no need to stream
+	if Params.ProxyCfg.EnableQueryStream.GetAsBool() && !t.plan.GetQuery().GetIsCount() && len(t.GetOrderByFields()) == 0 {
+		if err := t.queryShardStream(ctx, nodeID, qn, req); err != nil {
+			return err
+		}
+		progress.finishShard(channel)
+		return nil
+	}
+
 	result, err := qn.Query(ctx, req)
 	if err != nil {
 		log.Warn("QueryNode query return error", zap.Error(err))
 		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
 		return err
 	}
+	if err := t.checkShardResult(ctx, nodeID, result); err != nil {
+		return err
+	}
+
+	log.Debug("get query result")
+	t.resultBuf.Insert(result)
+	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
+	progress.gather(len(result.GetSealedSegmentIDsRetrieved()), int64(typeutil.GetSizeOfIDs(result.GetIds())))
+	progress.finishShard(channel)
+	return nil
+}
+
+// queryShardStream receives the query result of a shard in chunks,
+// each chunk is bounded by queryNode.queryStreamBatchSize and reduced as a separate result.
+func (t *queryTask) queryShardStream(ctx context.Context, nodeID int64, qn types.QueryNodeClient, req *querypb.QueryRequest) error {
+	log := log.Ctx(ctx).With(zap.Int64("collection", t.GetCollectionID()),
+		zap.Int64("nodeID", nodeID),
+		zap.Strings("channels", req.GetDmlChannels()))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	client, err := qn.QueryStream(ctx, req)
+	if err != nil {
+		log.Warn("QueryNode query stream return error", zap.Error(err))
+		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
+		return err
+	}
+
+	// the chunks are committed to the result buffer only if the stream completes,
+	// the chunks of a failed attempt would be duplicated by the retry of the lb
+	chunks := make([]*internalpb.RetrieveResults, 0)
+	for {
+		result, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				log.Debug("get query stream result", zap.Int("chunks", len(chunks)))
+				for _, chunk := range chunks {
+					t.resultBuf.Insert(chunk)
+				}
+				return nil
+			}
+			log.Warn("QueryNode query stream receive error", zap.Error(err))
+			return err
+		}
+		if err := t.checkShardResult(ctx, nodeID, result); err != nil {
+			return err
+		}
+		chunks = append(chunks, result)
+		t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
+		QueryProgressFromContext(ctx).gather(len(result.GetSealedSegmentIDsRetrieved()), int64(typeutil.GetSizeOfIDs(result.GetIds())))
+	}
+}
+
+func (t *queryTask) checkShardResult(ctx context.Context, nodeID int64, result *internalpb.RetrieveResults) error {
 	if result.GetStatus().GetErrorCode() == commonpb.ErrorCode_NotShardLeader {
-		log.Warn("QueryNode is not shardLeader")
+		log.Ctx(ctx).Warn("QueryNode is not shardLeader", zap.Int64("nodeID", nodeID))
 		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
 		return errInvalidShardLeaders
 	}
 	if result.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
-		log.Warn("QueryNode query result error", zap.Any("errorCode", result.GetStatus().GetErrorCode()), zap.String("reason", result.GetStatus().GetReason()))
+		log.Ctx(ctx).Warn("QueryNode query result error", zap.Int64("nodeID", nodeID),
+			zap.Any("errorCode", result.GetStatus().GetErrorCode()), zap.String("reason", result.GetStatus().GetReason()))
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to Query on QueryNode %d", nodeID)
 	}
-
-	log.Debug("get query result")
-	t.resultBuf.Insert(result)
-	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
 	return nil
 }
 
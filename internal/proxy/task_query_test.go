@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -35,6 +36,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/util/reduce"
+	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
@@ -60,6 +62,10 @@ func TestQueryTask_all(t *testing.T) {
 		hitNum = 10
 	)
 
+	// the querynode mock serves the unary query
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.EnableQueryStream.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.EnableQueryStream.Key)
+
 	qn.EXPECT().GetComponentStates(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 
 	successStatus := commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}
@@ -1289,3 +1295,136 @@ func TestQueryTask_CanSkipAllocTimestamp(t *testing.T) {
 		assert.True(t, skip)
 	})
 }
+
+func TestQueryTask_queryShardStream(t *testing.T) {
+	ctx := context.Background()
+	qn := mocks.NewMockQueryNodeClient(t)
+	lb := NewMockLBPolicy(t)
+	lb.EXPECT().UpdateCostMetrics(mock.Anything, mock.Anything).Return()
+
+	task := &queryTask{
+		lb:        lb,
+		resultBuf: typeutil.NewConcurrentSet[*internalpb.RetrieveResults](),
+	}
+
+	qn.EXPECT().QueryStream(mock.Anything, mock.Anything).Call.Return(
+		func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) querypb.QueryNode_QueryStreamClient {
+			client := streamrpc.NewLocalQueryClient(ctx)
+			server := client.CreateServer()
+			for i := int64(0); i < 3; i++ {
+				server.Send(&internalpb.RetrieveResults{
+					Status: merr.Success(),
+					Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{i}}}},
+				})
+			}
+			server.FinishSend(nil)
+			return client
+		}, nil).Once()
+	err := task.queryShardStream(ctx, 1, qn, &querypb.QueryRequest{DmlChannels: []string{"ch"}})
+	assert.NoError(t, err)
+	// each chunk is reduced as a separate result
+	assert.Len(t, task.resultBuf.Collect(), 3)
+
+	qn.EXPECT().QueryStream(mock.Anything, mock.Anything).Call.Return(
+		func(ctx context.Context, in *querypb.QueryRequest, opts ...grpc.CallOption) querypb.QueryNode_QueryStreamClient {
+			client := streamrpc.NewLocalQueryClient(ctx)
+			server := client.CreateServer()
+			server.Send(&internalpb.RetrieveResults{
+				Status: merr.Status(errors.New("mock error")),
+			})
+			server.FinishSend(nil)
+			return client
+		}, nil).Once()
+	err = task.queryShardStream(ctx, 1, qn, &querypb.QueryRequest{DmlChannels: []string{"ch"}})
+	assert.Error(t, err)
+}
+
+type mockServerTransportStream struct {
+	grpc.ServerTransportStream
+}
+
+func Test_checkGrpcResultSize(t *testing.T) {
+	paramtable.Get().Save(paramtable.Get().ProxyGrpcServerCfg.ServerMaxSendSize.Key, "1024")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyGrpcServerCfg.ServerMaxSendSize.Key)
+
+	result := &milvuspb.QueryResults{
+		FieldsData: []*schemapb.FieldData{{
+			Type: schemapb.DataType_VarChar,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: make([]string, 2048)}},
+			}},
+		}},
+	}
+	grpcCtx := grpc.NewContextWithServerTransportStream(context.Background(), &mockServerTransportStream{})
+	err := checkGrpcResultSize(grpcCtx, result)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	assert.NoError(t, checkGrpcResultSize(grpcCtx, &milvuspb.QueryResults{}))
+
+	// not served by grpc
+	assert.NoError(t, checkGrpcResultSize(context.Background(), result))
+}
+
+func Test_parseOrderByFields(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "price", DataType: schemapb.DataType_Double},
+			{FieldID: 102, Name: "name", DataType: schemapb.DataType_VarChar},
+			{FieldID: 103, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+
+	orderBy, err := parseOrderByFields(nil, helper)
+	assert.NoError(t, err)
+	assert.Empty(t, orderBy)
+
+	orderBy, err = parseOrderByFields([]*commonpb.KeyValuePair{{Key: OrderByKey, Value: "price DESC, name"}}, helper)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(orderBy))
+	assert.Equal(t, int64(101), orderBy[0].GetFieldID())
+	assert.False(t, orderBy[0].GetAscending())
+	assert.Equal(t, int64(102), orderBy[1].GetFieldID())
+	assert.True(t, orderBy[1].GetAscending())
+
+	for _, invalid := range []string{"", "price up", "price asc desc", "not_exist", "vec", "price, price desc"} {
+		_, err = parseOrderByFields([]*commonpb.KeyValuePair{{Key: OrderByKey, Value: invalid}}, helper)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_reduceRetrieveResultsInOrder(t *testing.T) {
+	const priceFieldID = common.StartOfUserFieldID + 1
+	newResult := func(ids []int64, prices []int64) *internalpb.RetrieveResults {
+		return &internalpb.RetrieveResults{
+			Ids: &schemapb.IDs{
+				IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}},
+			},
+			FieldsData: []*schemapb.FieldData{getFieldData("price", priceFieldID, schemapb.DataType_Int64, prices, 1)},
+		}
+	}
+	// the shard results are sorted by price desc
+	r1 := newResult([]int64{1, 3, 5}, []int64{90, 50, 10})
+	r2 := newResult([]int64{2, 4}, []int64{70, 50})
+	params := &queryParams{
+		limit:   3,
+		offset:  1,
+		orderBy: []*internalpb.OrderByField{{FieldID: priceFieldID, Ascending: false}},
+	}
+
+	ret, err := reduceRetrieveResultsInOrder(context.Background(), []*internalpb.RetrieveResults{r1, r2}, params)
+	assert.NoError(t, err)
+	// 90(1) is skipped by offset, ties of price are ordered by pk
+	assert.Equal(t, []int64{70, 50, 50}, ret.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	params.limit = typeutil.Unlimited
+	params.offset = 0
+	ret, err = reduceRetrieveResultsInOrder(context.Background(), []*internalpb.RetrieveResults{r1, r2, {}}, params)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{90, 70, 50, 50, 10}, ret.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	params.orderBy = []*internalpb.OrderByField{{FieldID: 999}}
+	_, err = reduceRetrieveResultsInOrder(context.Background(), []*internalpb.RetrieveResults{r1}, params)
+	assert.Error(t, err)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
@@ -63,6 +65,8 @@ type searchTask struct {
 	partitionKeyMode       bool
 	enableMaterializedView bool
 	mustUsePartitionKey    bool
+	filterStrategy         string
+	searchTimeout          time.Duration
 	resultSizeInsufficient bool
 	isTopkReduce           bool
 	isRecallEvaluation     bool
@@ -86,6 +90,14 @@ type searchTask struct {
 	groupScorer func(group *Group) error
 
 	isIterator bool
+	// refine the candidates of the first phase search with the raw vectors, nil if not requested
+	refine *searchRefine
+	// wait for the barrier of each shard instead of the begin timestamp for strong consistency
+	consistencyBarrier bool
+	// the delegators in the resource group are preferred, empty if not requested
+	preferredResourceGroup string
+	// merges the results of the shards once received for the search of large topK, nil if not used
+	tieredReducer *tieredSearchReducer
 }
 
 func (t *searchTask) CanSkipAllocTimestamp() bool {
@@ -191,7 +203,7 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	}
 	t.SearchRequest.Nq = nq
 
-	var ignoreGrowing bool
+	var ignoreGrowing, ignoreGrowingSet bool
 	// parse common search params
 	for i, kv := range t.request.GetSearchParams() {
 		if kv.GetKey() == IgnoreGrowingKey {
@@ -199,11 +211,13 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 			if err != nil {
 				return errors.New("parse search growing failed")
 			}
+			ignoreGrowingSet = true
 			t.request.SearchParams = append(t.request.GetSearchParams()[:i], t.request.GetSearchParams()[i+1:]...)
 			break
 		}
 	}
 	t.SearchRequest.IgnoreGrowing = ignoreGrowing
+	t.preferredResourceGroup = getPreferredResourceGroup(t.request.GetSearchParams())
 
 	outputFieldIDs, err := getOutputFieldIDs(t.schema, t.request.GetOutputFields())
 	if err != nil {
@@ -218,6 +232,20 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return lo.Contains(t.request.GetOutputFields(), field.GetName()) && typeutil.IsVectorType(field.GetDataType())
 	})
 
+	collectionInfo, err := globalMetaCache.GetCollectionInfo(ctx, t.request.GetDbName(), collectionName, t.CollectionID)
+	if err != nil {
+		log.Warn("Proxy::searchTask::PreExecute failed to GetCollectionInfo from cache",
+			zap.String("collectionName", collectionName), zap.Int64("collectionID", t.CollectionID), zap.Error(err))
+		return err
+	}
+	t.filterStrategy = collectionInfo.filterStrategy
+	t.searchTimeout = collectionInfo.searchTimeout
+	// the growing segments are skipped by the collection default if the request doesn't say,
+	// so the search sees the sealed data only, as stale as the last flush.
+	if !ignoreGrowingSet {
+		t.SearchRequest.IgnoreGrowing = collectionInfo.searchIgnoreGrowing
+	}
+
 	if t.SearchRequest.GetIsAdvanced() {
 		t.requery = len(t.request.OutputFields) > 0
 		err = t.initAdvancedSearchRequest(ctx)
@@ -230,12 +258,6 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
-	collectionInfo, err2 := globalMetaCache.GetCollectionInfo(ctx, t.request.GetDbName(), collectionName, t.CollectionID)
-	if err2 != nil {
-		log.Warn("Proxy::searchTask::PreExecute failed to GetCollectionInfo from cache",
-			zap.String("collectionName", collectionName), zap.Int64("collectionID", t.CollectionID), zap.Error(err2))
-		return err2
-	}
 	guaranteeTs := t.request.GetGuaranteeTimestamp()
 	var consistencyLevel commonpb.ConsistencyLevel
 	useDefaultConsistency := t.request.GetUseDefaultConsistency()
@@ -258,9 +280,13 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		t.MvccTimestamp = t.request.GetGuaranteeTimestamp()
 		t.GuaranteeTimestamp = t.request.GetGuaranteeTimestamp()
 	}
+	t.consistencyBarrier = useConsistencyBarrier(consistencyLevel, t.GetGuaranteeTimestamp(), t.GetMvccTimestamp(), t.BeginTs())
 
 	if deadline, ok := t.TraceCtx().Deadline(); ok {
 		t.SearchRequest.TimeoutTimestamp = tsoutil.ComposeTSByTime(deadline, 0)
+	} else if t.searchTimeout > 0 {
+		// the collection default timeout, enforced by the shard delegators
+		t.SearchRequest.TimeoutTimestamp = tsoutil.ComposeTSByTime(time.Now().Add(t.searchTimeout), 0)
 	}
 
 	// Set username of this search request for feature like task scheduling.
@@ -269,6 +295,13 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	}
 
 	t.resultBuf = typeutil.NewConcurrentSet[*internalpb.SearchResults]()
+	if t.useTieredReduce() {
+		pkField, err := t.schema.GetPkField()
+		if err != nil {
+			return err
+		}
+		t.tieredReducer = newTieredSearchReducer(t.SearchRequest.GetNq(), t.SearchRequest.GetTopk(), pkField.GetDataType())
+	}
 
 	log.Debug("search PreExecute done.",
 		zap.Uint64("guarantee_ts", guaranteeTs),
@@ -278,6 +311,14 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
+// useTieredReduce returns true if the results of the shards are merged once received,
+// only for the searches of large topK without group by.
+func (t *searchTask) useTieredReduce() bool {
+	threshold := paramtable.Get().ProxyCfg.TieredReduceTopK.GetAsInt64()
+	return threshold > 0 && t.SearchRequest.GetTopk() >= threshold &&
+		!t.SearchRequest.GetIsAdvanced() && t.queryInfos[0].GetGroupByFieldId() <= 0
+}
+
 func (t *searchTask) checkNq(ctx context.Context) (int64, error) {
 	var nq int64
 	if t.SearchRequest.GetIsAdvanced() {
@@ -459,6 +500,15 @@ func (t *searchTask) initSearchRequest(ctx context.Context) error {
 	}
 
 	t.isIterator = isIterator
+	t.refine, err = parseSearchRefine(t.request.GetSearchParams(), t.schema.CollectionSchema, queryInfo, offset, isIterator)
+	if err != nil {
+		return err
+	}
+	if t.refine != nil {
+		// the offset is applied after refining the candidates
+		queryInfo.Topk = t.refine.candidates()
+		offset = 0
+	}
 	t.SearchRequest.Offset = offset
 	t.SearchRequest.FieldId = queryInfo.GetQueryFieldId()
 
@@ -519,6 +569,9 @@ func (t *searchTask) tryGeneratePlan(params []*commonpb.KeyValuePair, dsl string
 	if searchInfo.parseError != nil {
 		return nil, nil, 0, false, searchInfo.parseError
 	}
+	if searchInfo.planInfo.GetHints() == "" {
+		searchInfo.planInfo.Hints = t.filterStrategy
+	}
 	annField := typeutil.GetFieldByName(t.schema.CollectionSchema, annsFieldName)
 	if searchInfo.planInfo.GetGroupByFieldId() != -1 && annField.GetDataType() == schemapb.DataType_BinaryVector {
 		return nil, nil, 0, false, errors.New("not support search_group_by operation based on binary vector column")
@@ -535,6 +588,7 @@ func (t *searchTask) tryGeneratePlan(params []*commonpb.KeyValuePair, dsl string
 	log.Debug("create query plan",
 		zap.String("dsl", t.request.Dsl), // may be very large if large term passed.
 		zap.String("anns field", annsFieldName), zap.Any("query info", searchInfo.planInfo))
+	globalQueryStats.RecordSearch(t.GetCollectionID(), plan, searchInfo.planInfo)
 	return plan, searchInfo.planInfo, searchInfo.offset, searchInfo.isIterator, nil
 }
 
@@ -572,11 +626,12 @@ func (t *searchTask) Execute(ctx context.Context) error {
 	defer tr.CtxElapse(ctx, "done")
 
 	err := t.lb.Execute(ctx, CollectionWorkLoad{
-		db:             t.request.GetDbName(),
-		collectionID:   t.SearchRequest.CollectionID,
-		collectionName: t.collectionName,
-		nq:             t.Nq,
-		exec:           t.searchShard,
+		db:                     t.request.GetDbName(),
+		collectionID:           t.SearchRequest.CollectionID,
+		collectionName:         t.collectionName,
+		nq:                     t.Nq,
+		exec:                   t.searchShard,
+		preferredResourceGroup: t.preferredResourceGroup,
 	})
 	if err != nil {
 		log.Warn("search execute failed", zap.Error(err))
@@ -719,14 +774,31 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 			return err
 		}
 	} else {
-		t.result, err = t.reduceResults(t.ctx, toReduceResults, t.SearchRequest.GetNq(), t.SearchRequest.GetTopk(), t.SearchRequest.GetOffset(), t.queryInfos[0], false)
+		if t.tieredReducer != nil {
+			t.result, err = t.tieredReducer.Reduce(t.ctx, t.SearchRequest.GetOffset())
+		} else {
+			t.result, err = t.reduceResults(t.ctx, toReduceResults, t.SearchRequest.GetNq(), t.SearchRequest.GetTopk(), t.SearchRequest.GetOffset(), t.queryInfos[0], false)
+		}
 		if err != nil {
 			return err
 		}
+		if t.refine != nil {
+			metricType := ""
+			if len(toReduceResults) >= 1 {
+				metricType = toReduceResults[0].GetMetricType()
+			}
+			if err := t.refineResults(ctx, sp, metricType); err != nil {
+				log.Warn("failed to refine search results", zap.Error(err))
+				return err
+			}
+		}
 	}
 
 	// reduce done, get final result
 	limit := t.SearchRequest.GetTopk() - t.SearchRequest.GetOffset()
+	if t.refine != nil {
+		limit = t.refine.topK - t.refine.offset
+	}
 	resultSizeInsufficient := false
 	for _, topk := range t.result.Results.Topks {
 		if topk < limit {
@@ -748,6 +820,9 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 		}
 	}
 	t.result.Results.OutputFields = t.userOutputFields
+	if paramtable.Get().ProxyCfg.SchemaOrderedOutputFields.GetAsBool() {
+		t.result.Results.OutputFields = sortOutputFieldsBySchema(t.schema.CollectionSchema, t.result.GetResults().GetFieldsData(), t.result.GetResults().GetOutputFields())
+	}
 	t.result.CollectionName = t.request.GetCollectionName()
 	if t.isIterator && t.request.GetGuaranteeTimestamp() == 0 {
 		// first page for iteration, need to set up sessionTs for iterator
@@ -756,6 +831,11 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 
 	metrics.ProxyReduceResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.SearchLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
 
+	if err := checkGrpcResultSize(ctx, t.result); err != nil {
+		log.Warn("search result is too large to send", zap.Error(err))
+		return err
+	}
+
 	log.Debug("Search post execute done",
 		zap.Int64("collection", t.GetCollectionID()),
 		zap.Int64s("partitionIDs", t.GetPartitionIDs()))
@@ -763,8 +843,14 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 }
 
 func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.QueryNodeClient, channel string) error {
+	progress := QueryProgressFromContext(ctx)
+	progress.startShard(channel)
+
 	searchReq := typeutil.Clone(t.SearchRequest)
 	searchReq.GetBase().TargetID = nodeID
+	if t.consistencyBarrier {
+		searchReq.GuaranteeTimestamp = guaranteeTsByBarrier(ctx, t.GetCollectionID(), channel, searchReq.GetGuaranteeTimestamp())
+	}
 	req := &querypb.SearchRequest{
 		Req:             searchReq,
 		DmlChannels:     []string{channel},
@@ -797,13 +883,51 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to search on QueryNode %d", nodeID)
 	}
 	if t.resultBuf != nil {
-		t.resultBuf.Insert(result)
+		if t.tieredReducer != nil {
+			if err := t.tieredReducer.Add(ctx, result); err != nil {
+				log.Warn("failed to merge search result", zap.Error(err))
+				return err
+			}
+			t.resultBuf.Insert(withoutResultData(result))
+		} else {
+			t.resultBuf.Insert(result)
+		}
 	}
 	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
+	progress.gather(len(result.GetSealedSegmentIDsSearched()), result.GetAllSearchCount())
+	progress.finishShard(channel)
+
+	if t.shouldVerifyConsistency() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), searchConsistencyTimeout)
+			defer cancel()
+			t.verifyConsistency(ctx, nodeID, channel, req, result)
+		}()
+	}
 
 	return nil
 }
 
+// searchType returns the type of the search for the metrics.
+func (t *searchTask) searchType() string {
+	switch {
+	case t.SearchRequest.GetIsAdvanced():
+		return metrics.HybridSearchLabel
+	case t.isIterator:
+		return metrics.IteratorSearchLabel
+	case lo.ContainsBy(t.queryInfos, func(queryInfo *planpb.QueryInfo) bool {
+		return strings.Contains(queryInfo.GetSearchParams(), radiusKey)
+	}):
+		return metrics.RangeSearchLabel
+	case lo.ContainsBy(t.queryInfos, func(queryInfo *planpb.QueryInfo) bool {
+		return queryInfo.GetGroupByFieldId() > 0
+	}):
+		return metrics.GroupBySearchLabel
+	default:
+		return metrics.TopKSearchLabel
+	}
+}
+
 func (t *searchTask) estimateResultSize(nq int64, topK int64) (int64, error) {
 	vectorOutputFields := lo.Filter(t.schema.GetFields(), func(field *schemapb.FieldSchema, _ int) bool {
 		return lo.Contains(t.request.GetOutputFields(), field.GetName()) && typeutil.IsVectorType(field.GetDataType())
@@ -827,6 +951,18 @@ func (t *searchTask) estimateResultSize(nq int64, topK int64) (int64, error) {
 }
 
 func (t *searchTask) Requery(span trace.Span) error {
+	fieldsData, err := t.queryByIDs(span, t.result.GetResults().GetIds(), t.request.GetOutputFields())
+	if err != nil {
+		return err
+	}
+	t.result.Results.FieldsData = lo.Filter(fieldsData, func(fieldData *schemapb.FieldData, i int) bool {
+		return lo.Contains(t.request.GetOutputFields(), fieldData.GetFieldName())
+	})
+	return nil
+}
+
+// queryByIDs queries the output fields of the entities by primary keys, returns the field data in the order of ids.
+func (t *searchTask) queryByIDs(span trace.Span, ids *schemapb.IDs, outputFields []string) ([]*schemapb.FieldData, error) {
 	queryReq := &milvuspb.QueryRequest{
 		Base: &commonpb.MsgBase{
 			MsgType:   commonpb.MsgType_Retrieve,
@@ -837,16 +973,15 @@ func (t *searchTask) Requery(span trace.Span) error {
 		ConsistencyLevel:      t.SearchRequest.GetConsistencyLevel(),
 		NotReturnAllMeta:      t.request.GetNotReturnAllMeta(),
 		Expr:                  "",
-		OutputFields:          t.request.GetOutputFields(),
+		OutputFields:          outputFields,
 		PartitionNames:        t.request.GetPartitionNames(),
 		UseDefaultConsistency: false,
 		GuaranteeTimestamp:    t.SearchRequest.GuaranteeTimestamp,
 	}
 	pkField, err := typeutil.GetPrimaryFieldSchema(t.schema.CollectionSchema)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	ids := t.result.GetResults().GetIds()
 	plan := planparserv2.CreateRequeryPlan(pkField, ids)
 	channelsMvcc := make(map[string]Timestamp)
 	for k, v := range t.queryChannelsTs {
@@ -863,20 +998,21 @@ func (t *searchTask) Requery(span trace.Span) error {
 			ReqID:        paramtable.GetNodeID(),
 			PartitionIDs: t.GetPartitionIDs(), // use search partitionIDs
 		},
-		request:      queryReq,
-		plan:         plan,
-		qc:           t.node.(*Proxy).queryCoord,
-		lb:           t.node.(*Proxy).lbPolicy,
-		channelsMvcc: channelsMvcc,
-		fastSkip:     true,
-		reQuery:      true,
+		request:                queryReq,
+		plan:                   plan,
+		qc:                     t.node.(*Proxy).queryCoord,
+		lb:                     t.node.(*Proxy).lbPolicy,
+		channelsMvcc:           channelsMvcc,
+		fastSkip:               true,
+		reQuery:                true,
+		preferredResourceGroup: t.preferredResourceGroup,
 	}
 	queryResult, err := t.node.(*Proxy).query(t.ctx, qt, span)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if queryResult.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
-		return merr.Error(queryResult.GetStatus())
+		return nil, merr.Error(queryResult.GetStatus())
 	}
 	// Reorganize Results. The order of query result ids will be altered and differ from queried ids.
 	// We should reorganize query results to keep the order of original queried ids. For example:
@@ -897,7 +1033,7 @@ func (t *searchTask) Requery(span trace.Span) error {
 	defer sp.End()
 	pkFieldData, err := typeutil.GetPrimaryFieldData(queryResult.GetFieldsData(), pkField)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	offsets := make(map[any]int)
 	for i := 0; i < typeutil.GetPKSize(pkFieldData); i++ {
@@ -905,20 +1041,16 @@ func (t *searchTask) Requery(span trace.Span) error {
 		offsets[pk] = i
 	}
 
-	t.result.Results.FieldsData = make([]*schemapb.FieldData, len(queryResult.GetFieldsData()))
+	fieldsData := make([]*schemapb.FieldData, len(queryResult.GetFieldsData()))
 	for i := 0; i < typeutil.GetSizeOfIDs(ids); i++ {
 		id := typeutil.GetPK(ids, int64(i))
 		if _, ok := offsets[id]; !ok {
-			return merr.WrapErrInconsistentRequery(fmt.Sprintf("incomplete query result, missing id %s, len(searchIDs) = %d, len(queryIDs) = %d, collection=%d",
+			return nil, merr.WrapErrInconsistentRequery(fmt.Sprintf("incomplete query result, missing id %s, len(searchIDs) = %d, len(queryIDs) = %d, collection=%d",
 				id, typeutil.GetSizeOfIDs(ids), len(offsets), t.GetCollectionID()))
 		}
-		typeutil.AppendFieldData(t.result.Results.FieldsData, queryResult.GetFieldsData(), int64(offsets[id]))
+		typeutil.AppendFieldData(fieldsData, queryResult.GetFieldsData(), int64(offsets[id]))
 	}
-
-	t.result.Results.FieldsData = lo.Filter(t.result.Results.FieldsData, func(fieldData *schemapb.FieldData, i int) bool {
-		return lo.Contains(t.request.GetOutputFields(), fieldData.GetFieldName())
-	})
-	return nil
+	return fieldsData, nil
 }
 
 func (t *searchTask) fillInFieldInfo() {
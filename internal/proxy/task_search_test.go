@@ -42,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/reduce"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metric"
@@ -2482,6 +2483,21 @@ func TestTaskSearch_parseSearchInfo(t *testing.T) {
 		assert.Nil(t, searchInfo.planInfo)
 		assert.ErrorIs(t, searchInfo.parseError, merr.ErrParameterInvalid)
 	})
+	t.Run("check filter strategy hints", func(t *testing.T) {
+		normalParam := getValidSearchParams()
+		normalParam = append(normalParam, &commonpb.KeyValuePair{
+			Key:   common.HintsKey,
+			Value: common.FilterStrategyBruteForce,
+		})
+		searchInfo := parseSearchInfo(normalParam, nil, nil)
+		assert.NoError(t, searchInfo.parseError)
+		assert.Equal(t, common.FilterStrategyBruteForce, searchInfo.planInfo.GetHints())
+
+		resetSearchParamsValue(normalParam, common.HintsKey, "unknown")
+		searchInfo = parseSearchInfo(normalParam, nil, nil)
+		assert.Nil(t, searchInfo.planInfo)
+		assert.Error(t, searchInfo.parseError)
+	})
 	t.Run("check range-search and groupBy", func(t *testing.T) {
 		normalParam := getValidSearchParams()
 		resetSearchParamsValue(normalParam, SearchParamsKey, `{"nprobe": 10, "radius":0.2}`)
@@ -2616,6 +2632,10 @@ func TestSearchTask_Requery(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// the querynode mock serves the unary query
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.EnableQueryStream.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.EnableQueryStream.Key)
+
 	const (
 		dim        = 128
 		rows       = 5
@@ -2956,6 +2976,24 @@ func TestGetPartitionIDs(t *testing.T) {
 	suite.Run(t, new(GetPartitionIDsSuite))
 }
 
+func TestSearchTask_SearchType(t *testing.T) {
+	newTask := func(queryInfos ...*planpb.QueryInfo) *searchTask {
+		return &searchTask{SearchRequest: &internalpb.SearchRequest{}, queryInfos: queryInfos}
+	}
+
+	assert.Equal(t, metrics.TopKSearchLabel, newTask(&planpb.QueryInfo{Topk: 10}).searchType())
+	assert.Equal(t, metrics.RangeSearchLabel, newTask(&planpb.QueryInfo{SearchParams: `{"radius": 0.5, "range_filter": 0.1}`}).searchType())
+	assert.Equal(t, metrics.GroupBySearchLabel, newTask(&planpb.QueryInfo{GroupByFieldId: 101}).searchType())
+
+	task := newTask(&planpb.QueryInfo{SearchParams: `{"radius": 0.5}`})
+	task.isIterator = true
+	assert.Equal(t, metrics.IteratorSearchLabel, task.searchType())
+
+	task = newTask(&planpb.QueryInfo{}, &planpb.QueryInfo{SearchParams: `{"radius": 0.5}`})
+	task.IsAdvanced = true
+	assert.Equal(t, metrics.HybridSearchLabel, task.searchType())
+}
+
 func TestSearchTask_CanSkipAllocTimestamp(t *testing.T) {
 	dbName := "test_query"
 	collName := "test_skip_alloc_timestamp"
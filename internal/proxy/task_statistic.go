@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -17,6 +19,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -121,6 +124,10 @@ func (g *getStatisticsTask) PreExecute(ctx context.Context) error {
 
 	g.GetStatisticsRequest.DbID = 0 // todo
 	g.GetStatisticsRequest.CollectionID = collID
+	g.GetStatisticsRequest.Consistency, err = getStatisticsConsistency(ctx)
+	if err != nil {
+		return err
+	}
 
 	g.TravelTimestamp = g.BeginTs()
 	g.GuaranteeTimestamp = parseGuaranteeTs(g.GuaranteeTimestamp, g.BeginTs())
@@ -137,12 +144,19 @@ func (g *getStatisticsTask) PreExecute(ctx context.Context) error {
 		zap.Int64("collectionID", g.CollectionID),
 	)
 	if err != nil {
+		if g.GetStatisticsRequest.GetConsistency() == internalpb.StatisticsConsistency_StatisticsIncludeGrowing {
+			return err
+		}
 		g.fromDataCoord = true
 		g.unloadedPartitionIDs = partIDs
 		log.Info("checkFullLoaded failed, try get statistics from DataCoord",
 			zap.Error(err))
 		return nil
 	}
+	// datacoord knows nothing about the timestamps of the growing segments
+	if len(unloaded) > 0 && g.GetStatisticsRequest.GetConsistency() == internalpb.StatisticsConsistency_StatisticsIncludeGrowing {
+		return merr.WrapErrCollectionNotFullyLoaded(g.collectionName, "statistics including growing segments require the partitions loaded")
+	}
 	if len(unloaded) > 0 {
 		g.fromDataCoord = true
 		g.unloadedPartitionIDs = unloaded
@@ -235,6 +249,7 @@ func (g *getStatisticsTask) getStatisticsFromDataCoord(ctx context.Context) erro
 		),
 		CollectionID: collID,
 		PartitionIDs: partIDs,
+		Consistency:  g.GetStatisticsRequest.GetConsistency(),
 	}
 
 	result, err := g.dc.GetPartitionStatistics(ctx, req)
@@ -308,6 +323,29 @@ func (g *getStatisticsTask) getStatisticsShard(ctx context.Context, nodeID int64
 	return nil
 }
 
+// getStatisticsConsistency returns the consistency of the statistics selected by the request header,
+// the default one if not set.
+func getStatisticsConsistency(ctx context.Context) (internalpb.StatisticsConsistency, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return internalpb.StatisticsConsistency_StatisticsDefault, nil
+	}
+	values := md[strings.ToLower(util.HeaderStatisticsConsistency)]
+	if len(values) < 1 || values[0] == "" {
+		return internalpb.StatisticsConsistency_StatisticsDefault, nil
+	}
+	switch strings.ToLower(values[0]) {
+	case "default":
+		return internalpb.StatisticsConsistency_StatisticsDefault, nil
+	case "flushed":
+		return internalpb.StatisticsConsistency_StatisticsFlushed, nil
+	case "include_growing":
+		return internalpb.StatisticsConsistency_StatisticsIncludeGrowing, nil
+	default:
+		return internalpb.StatisticsConsistency_StatisticsDefault, merr.WrapErrParameterInvalid("default, flushed or include_growing", values[0], "invalid statistics consistency")
+	}
+}
+
 // checkFullLoaded check if collection / partition was fully loaded into QueryNode
 // return loaded partitions, unloaded partitions and error
 func checkFullLoaded(ctx context.Context, qc types.QueryCoordClient, dbName string, collectionName string, collectionID int64, searchPartitionIDs []UniqueID) ([]UniqueID, []UniqueID, error) {
@@ -648,12 +686,17 @@ func (g *getCollectionStatisticsTask) Execute(ctx context.Context) error {
 		return err
 	}
 	g.collectionID = collID
+	consistency, err := getStatisticsConsistency(ctx)
+	if err != nil {
+		return err
+	}
 	req := &datapb.GetCollectionStatisticsRequest{
 		Base: commonpbutil.UpdateMsgBase(
 			g.Base,
 			commonpbutil.WithMsgType(commonpb.MsgType_GetCollectionStatistics),
 		),
 		CollectionID: collID,
+		Consistency:  consistency,
 	}
 
 	result, err := g.dataCoord.GetCollectionStatistics(ctx, req)
@@ -735,6 +778,10 @@ func (g *getPartitionStatisticsTask) Execute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	consistency, err := getStatisticsConsistency(ctx)
+	if err != nil {
+		return err
+	}
 	req := &datapb.GetPartitionStatisticsRequest{
 		Base: commonpbutil.UpdateMsgBase(
 			g.Base,
@@ -742,6 +789,7 @@ func (g *getPartitionStatisticsTask) Execute(ctx context.Context) error {
 		),
 		CollectionID: collID,
 		PartitionIDs: []int64{partitionID},
+		Consistency:  consistency,
 	}
 
 	result, _ := g.dataCoord.GetPartitionStatistics(ctx, req)
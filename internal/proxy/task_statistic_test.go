@@ -20,8 +20,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -32,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -231,3 +234,27 @@ func (s *StatisticTaskSuite) TestStatisticTask_Success() {
 func TestStatisticTaskSuite(t *testing.T) {
 	suite.Run(t, new(StatisticTaskSuite))
 }
+
+func TestGetStatisticsConsistency(t *testing.T) {
+	consistency, err := getStatisticsConsistency(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, internalpb.StatisticsConsistency_StatisticsDefault, consistency)
+
+	cases := map[string]internalpb.StatisticsConsistency{
+		"":                internalpb.StatisticsConsistency_StatisticsDefault,
+		"default":         internalpb.StatisticsConsistency_StatisticsDefault,
+		"flushed":         internalpb.StatisticsConsistency_StatisticsFlushed,
+		"FLUSHED":         internalpb.StatisticsConsistency_StatisticsFlushed,
+		"include_growing": internalpb.StatisticsConsistency_StatisticsIncludeGrowing,
+	}
+	for value, expected := range cases {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderStatisticsConsistency, value))
+		consistency, err := getStatisticsConsistency(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, consistency, value)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderStatisticsConsistency, "unknown"))
+	_, err = getStatisticsConsistency(ctx)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
@@ -1248,6 +1248,26 @@ func TestDescribeCollectionTask_ShardsNum1(t *testing.T) {
 	assert.Equal(t, commonpb.ErrorCode_Success, task.result.GetStatus().GetErrorCode())
 	assert.Equal(t, shardsNum, task.result.ShardsNum)
 	assert.Equal(t, collectionName, task.result.GetCollectionName())
+	_, err = funcutil.GetAttrByKeyFromRepeatedKV(common.CollectionReplicaTopologyKey, task.result.GetProperties())
+	assert.Error(t, err)
+
+	// the replica topology of the cached shard leaders
+	globalMetaCache.(*MetaCache).collLeader[dbName] = map[string]*shardLeaders{
+		collectionName: {
+			shardLeaders: map[string][]nodeInfo{
+				"ch1": {{nodeID: 1, resourceGroup: "rg1", replicaID: 10}, {nodeID: 2, resourceGroup: "rg2", replicaID: 20}},
+				"ch2": {{nodeID: 3, resourceGroup: "rg1", replicaID: 10}},
+			},
+		},
+	}
+	err = task.Execute(ctx)
+	assert.NoError(t, err)
+	topology, err := funcutil.GetAttrByKeyFromRepeatedKV(common.CollectionReplicaTopologyKey, task.result.GetProperties())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"replica_id": 10, "resource_group": "rg1", "shard_leaders": {"ch1": 1, "ch2": 3}},
+		{"replica_id": 20, "resource_group": "rg2", "shard_leaders": {"ch1": 2}}
+	]`, topology)
 }
 
 func TestDescribeCollectionTask_EnableDynamicSchema(t *testing.T) {
@@ -4048,6 +4068,31 @@ func TestAlterCollectionCheckLoaded(t *testing.T) {
 	assert.Equal(t, merr.Code(merr.ErrCollectionLoaded), merr.Code(err))
 }
 
+func TestAlterCollectionMaintenanceWindow(t *testing.T) {
+	rc := NewRootCoordMock()
+	rc.state.Store(commonpb.StateCode_Healthy)
+	qc := &mocks.MockQueryCoordClient{}
+	InitMetaCache(context.Background(), rc, qc, nil)
+	collectionName := "test_alter_collection_maintenance_window"
+	rc.CreateCollection(context.Background(), &milvuspb.CreateCollectionRequest{
+		Base:           &commonpb.MsgBase{},
+		DbName:         dbName,
+		CollectionName: collectionName,
+		ShardsNum:      1,
+	})
+
+	task := &alterCollectionTask{
+		AlterCollectionRequest: &milvuspb.AlterCollectionRequest{
+			Base:           &commonpb.MsgBase{},
+			CollectionName: collectionName,
+			Properties:     []*commonpb.KeyValuePair{{Key: common.CollectionMaintenanceWindowKey, Value: "mon-fri 25:00-26:00"}},
+		},
+		queryCoord: qc,
+	}
+	err := task.PreExecute(context.Background())
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
 func TestTaskPartitionKeyIsolation(t *testing.T) {
 	rc := NewRootCoordMock()
 	defer rc.Close()
@@ -0,0 +1,263 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// usagePrefix is the meta storage prefix of the persisted usage records,
+// the key of one record is {usagePrefix}/{windowStart}/{proxyID}/{user}.
+const usagePrefix = "proxy/usage"
+
+// usageExportDir is the directory under the object storage root path the closed usage windows are exported to,
+// the records of one proxy in one window are exported as json lines into {usageExportDir}/{windowStart}/{proxyID}.json.
+const usageExportDir = "usage"
+
+// UsageRecord is the usage of one user, which is the owner of the api key for api key authentication,
+// within one metering window.
+type UsageRecord struct {
+	User string `json:"user"`
+	// unix seconds of the window start
+	WindowStart int64 `json:"window_start"`
+	// number of the vectors searched, i.e. nq of the search requests
+	SearchVectors int64 `json:"search_vectors"`
+	ScannedRows   int64 `json:"scanned_rows"`
+	InsertedRows  int64 `json:"inserted_rows"`
+	EgressBytes   int64 `json:"egress_bytes"`
+}
+
+func (r *UsageRecord) merge(other *UsageRecord) {
+	r.SearchVectors += other.SearchVectors
+	r.ScannedRows += other.ScannedRows
+	r.InsertedRows += other.InsertedRows
+	r.EgressBytes += other.EgressBytes
+}
+
+type usageKey struct {
+	user        string
+	windowStart int64
+}
+
+// usageMeter aggregates the usage of the requests served by this proxy per user and window,
+// persists them into meta storage periodically and exports the closed windows into object storage.
+type usageMeter struct {
+	mu      sync.Mutex
+	records map[usageKey]*UsageRecord
+	dirty   map[usageKey]struct{}
+}
+
+var globalUsageMeter *usageMeter
+
+func newUsageMeter() *usageMeter {
+	return &usageMeter{
+		records: make(map[usageKey]*UsageRecord),
+		dirty:   make(map[usageKey]struct{}),
+	}
+}
+
+func usageWindowStart(t time.Time) int64 {
+	window := paramtable.Get().ProxyCfg.UsageMeterWindow.GetAsInt64()
+	if window <= 0 {
+		window = 3600
+	}
+	return t.Unix() / window * window
+}
+
+// Record adds the usage of one request to the current window of the user, nil meter is a no-op.
+func (m *usageMeter) Record(user string, usage UsageRecord) {
+	if m == nil || !paramtable.Get().ProxyCfg.UsageMeterEnabled.GetAsBool() {
+		return
+	}
+	key := usageKey{user: user, windowStart: usageWindowStart(time.Now())}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record, ok := m.records[key]
+	if !ok {
+		record = &UsageRecord{User: user, WindowStart: key.windowStart}
+		m.records[key] = record
+	}
+	record.merge(&usage)
+	m.dirty[key] = struct{}{}
+}
+
+// RecordSearch records the vectors searched, rows scanned and bytes sent by a search.
+func (m *usageMeter) RecordSearch(user string, nq int64, scannedRows int64, egressBytes int) {
+	m.Record(user, UsageRecord{SearchVectors: nq, ScannedRows: scannedRows, EgressBytes: int64(egressBytes)})
+}
+
+// RecordQuery records the rows scanned and bytes sent by a query.
+func (m *usageMeter) RecordQuery(user string, scannedRows int64, egressBytes int) {
+	m.Record(user, UsageRecord{ScannedRows: scannedRows, EgressBytes: int64(egressBytes)})
+}
+
+// RecordInsert records the rows written by an insert or upsert.
+func (m *usageMeter) RecordInsert(user string, insertedRows int64) {
+	m.Record(user, UsageRecord{InsertedRows: insertedRows})
+}
+
+func usageRecordKey(nodeID string, record *UsageRecord) string {
+	return path.Join(usagePrefix, strconv.FormatInt(record.WindowStart, 10), nodeID, record.User)
+}
+
+// flush persists the records updated since the last flush, exports the closed windows into object storage,
+// and removes the expired records of all proxies. Nil chunk manager skips the export and keeps the closed windows.
+func (m *usageMeter) flush(ctx context.Context, metaKV kv.MetaKv, cm storage.ChunkManager) {
+	current := usageWindowStart(time.Now())
+
+	m.mu.Lock()
+	updated := make([]*UsageRecord, 0, len(m.dirty))
+	for key := range m.dirty {
+		record := *m.records[key]
+		updated = append(updated, &record)
+	}
+	m.dirty = make(map[usageKey]struct{})
+	m.mu.Unlock()
+
+	nodeID := paramtable.GetStringNodeID()
+	failed := make(map[usageKey]struct{})
+	for _, record := range updated {
+		value, err := json.Marshal(record)
+		if err == nil {
+			err = metaKV.Save(ctx, usageRecordKey(nodeID, record), string(value))
+		}
+		if err != nil {
+			log.Warn("failed to persist usage record", zap.String("user", record.User), zap.Int64("window", record.WindowStart), zap.Error(err))
+			failed[usageKey{user: record.User, windowStart: record.WindowStart}] = struct{}{}
+		}
+	}
+
+	m.mu.Lock()
+	for key := range failed {
+		m.dirty[key] = struct{}{}
+	}
+	// records of the closed windows are exported once all of them are persisted
+	closed := make(map[int64][]*UsageRecord)
+	for key, record := range m.records {
+		if key.windowStart < current {
+			closed[key.windowStart] = append(closed[key.windowStart], record)
+		}
+	}
+	for key := range m.dirty {
+		delete(closed, key.windowStart)
+	}
+	m.mu.Unlock()
+
+	for windowStart, records := range closed {
+		if cm == nil {
+			break
+		}
+		if err := exportUsageRecords(ctx, cm, nodeID, windowStart, records); err != nil {
+			log.Warn("failed to export usage records", zap.Int64("window", windowStart), zap.Error(err))
+			continue
+		}
+		m.mu.Lock()
+		for _, record := range records {
+			delete(m.records, usageKey{user: record.User, windowStart: windowStart})
+		}
+		m.mu.Unlock()
+	}
+
+	keys, values, err := metaKV.LoadWithPrefix(ctx, usagePrefix)
+	if err != nil {
+		log.Warn("failed to load persisted usage records", zap.Error(err))
+		return
+	}
+	for i, value := range values {
+		record := &UsageRecord{}
+		if err := json.Unmarshal([]byte(value), record); err == nil && !isUsageRecordExpired(record) {
+			continue
+		}
+		if err := metaKV.Remove(ctx, keys[i]); err != nil {
+			log.Warn("failed to remove expired usage record", zap.String("key", keys[i]), zap.Error(err))
+		}
+	}
+}
+
+func exportUsageRecords(ctx context.Context, cm storage.ChunkManager, nodeID string, windowStart int64, records []*UsageRecord) error {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].User < records[j].User
+	})
+	buf := &bytes.Buffer{}
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	filePath := path.Join(cm.RootPath(), usageExportDir, strconv.FormatInt(windowStart, 10), nodeID+".json")
+	return cm.Write(ctx, filePath, buf.Bytes())
+}
+
+func isUsageRecordExpired(record *UsageRecord) bool {
+	retention := paramtable.Get().ProxyCfg.UsageMeterRetention.GetAsDuration(time.Hour)
+	return time.Since(time.Unix(record.WindowStart, 0)) > retention
+}
+
+// loadUsageRecords merges the persisted records of all proxies per user and window,
+// filtered by user if provided and by window start within [start, end).
+func loadUsageRecords(ctx context.Context, metaKV kv.MetaKv, user string, start, end int64) ([]*UsageRecord, error) {
+	_, values, err := metaKV.LoadWithPrefix(ctx, usagePrefix)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[usageKey]*UsageRecord)
+	for _, value := range values {
+		record := &UsageRecord{}
+		if err := json.Unmarshal([]byte(value), record); err != nil {
+			continue
+		}
+		if (user != "" && record.User != user) || record.WindowStart < start || record.WindowStart >= end {
+			continue
+		}
+		key := usageKey{user: record.User, windowStart: record.WindowStart}
+		if result, ok := merged[key]; ok {
+			result.merge(record)
+			continue
+		}
+		merged[key] = record
+	}
+
+	result := make([]*UsageRecord, 0, len(merged))
+	for _, record := range merged {
+		result = append(result, record)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].WindowStart != result[j].WindowStart {
+			return result[i].WindowStart < result[j].WindowStart
+		}
+		return result[i].User < result[j].User
+	})
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/kv/mocks"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func newTestUsageKV(t *testing.T) (*mocks.MetaKv, map[string]string) {
+	values := make(map[string]string)
+	metaKV := mocks.NewMetaKv(t)
+	metaKV.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key, value string) error {
+		values[key] = value
+		return nil
+	}).Maybe()
+	metaKV.EXPECT().Remove(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) error {
+		delete(values, key)
+		return nil
+	}).Maybe()
+	metaKV.EXPECT().LoadWithPrefix(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, prefix string) ([]string, []string, error) {
+		keys, result := make([]string, 0), make([]string, 0)
+		for key, value := range values {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+				result = append(result, value)
+			}
+		}
+		return keys, result, nil
+	}).Maybe()
+	return metaKV, values
+}
+
+func TestUsageMeter(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	// nil meter and disabled meter are no-ops
+	var nilMeter *usageMeter
+	nilMeter.RecordInsert("alice", 1)
+	meter := newUsageMeter()
+	meter.RecordInsert("alice", 1)
+	assert.Empty(t, meter.records)
+
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.UsageMeterEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.UsageMeterEnabled.Key)
+
+	meter.RecordSearch("alice", 2, 100, 64)
+	meter.RecordQuery("alice", 50, 32)
+	meter.RecordInsert("bob", 10)
+	window := usageWindowStart(time.Now())
+	alice := meter.records[usageKey{user: "alice", windowStart: window}]
+	require.NotNil(t, alice)
+	assert.EqualValues(t, 2, alice.SearchVectors)
+	assert.EqualValues(t, 150, alice.ScannedRows)
+	assert.EqualValues(t, 96, alice.EgressBytes)
+
+	// a closed window of another proxy and an expired one
+	metaKV, values := newTestUsageKV(t)
+	closedWindow := window - paramtable.Get().ProxyCfg.UsageMeterWindow.GetAsInt64()
+	values[path.Join(usagePrefix, strconv.FormatInt(closedWindow, 10), "1000", "alice")] = `{"user":"alice","window_start":` + strconv.FormatInt(closedWindow, 10) + `,"inserted_rows":5}`
+	values[path.Join(usagePrefix, "0", "1000", "alice")] = `{"user":"alice","window_start":0,"inserted_rows":5}`
+	meter.records[usageKey{user: "bob", windowStart: closedWindow}] = &UsageRecord{User: "bob", WindowStart: closedWindow, InsertedRows: 3}
+	meter.dirty[usageKey{user: "bob", windowStart: closedWindow}] = struct{}{}
+
+	// without chunk manager the closed window is kept
+	meter.flush(ctx, metaKV, nil)
+	assert.Len(t, values, 4)
+	assert.Len(t, meter.records, 3)
+	assert.Empty(t, meter.dirty)
+
+	records, err := loadUsageRecords(ctx, metaKV, "", 0, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, "alice", records[0].User)
+	assert.EqualValues(t, closedWindow, records[0].WindowStart)
+	assert.Equal(t, "bob", records[1].User)
+	assert.EqualValues(t, 3, records[1].InsertedRows)
+
+	records, err = loadUsageRecords(ctx, metaKV, "alice", window, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.EqualValues(t, 150, records[0].ScannedRows)
+
+	// export the closed window
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	meter.flush(ctx, metaKV, cm)
+	assert.Len(t, meter.records, 2)
+	exported, err := cm.Read(ctx, path.Join(cm.RootPath(), usageExportDir, strconv.FormatInt(closedWindow, 10), paramtable.GetStringNodeID()+".json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), `"user":"bob"`)
+}
@@ -19,6 +19,7 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/internal/parser/planparserv2"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
@@ -78,6 +80,13 @@ const (
 
 	defaultRRFParamsValue = 60
 	maxRRFParamsValue     = 16384
+
+	// snapshotTsKey is the key of status extra info carrying the snapshot timestamp of filtered mutations,
+	// and of the msg base properties of a delete resuming a failed one, see getDeleteResumeInfo
+	snapshotTsKey = "snapshot_ts"
+	// completedChannelsKey is the key of status extra info carrying the channels a failed delete has finished,
+	// and of the msg base properties of a delete resuming a failed one, see getDeleteResumeInfo
+	completedChannelsKey = "completed_channels"
 )
 
 var logger = log.L().WithOptions(zap.Fields(zap.String("role", typeutil.ProxyRole)))
@@ -1196,11 +1205,16 @@ func GetRole(username string) ([]string, error) {
 	if globalMetaCache == nil {
 		return []string{}, merr.WrapErrServiceUnavailable("internal: Milvus Proxy is not ready yet. please wait")
 	}
-	return globalMetaCache.GetUserRole(username), nil
+	return append(globalMetaCache.GetUserRole(username), globalAuthProviders.Roles(username)...), nil
 }
 
-func PasswordVerify(ctx context.Context, username, rawPwd string) bool {
-	return passwordVerify(ctx, username, rawPwd, globalMetaCache)
+// VerifyPassword verifies the password of the built-in user, and then by the external providers.
+// It returns the user to run as, which is the principal qualified by the provider for the external users.
+func VerifyPassword(ctx context.Context, username, rawPwd string) (string, bool) {
+	if passwordVerify(ctx, username, rawPwd, globalMetaCache) {
+		return username, true
+	}
+	return verifyExternalPassword(ctx, username, rawPwd)
 }
 
 func VerifyAPIKey(rawToken string) (string, error) {
@@ -1427,6 +1441,35 @@ func translateOutputFields(outputFields []string, schema *schemaInfo, addPrimary
 	return resultFieldNames, userOutputFields, userDynamicFields, nil
 }
 
+// sortOutputFieldsBySchema sorts the fields data in the order declared by the schema,
+// and returns the output fields in the same order, the keys of the dynamic field are placed at its position.
+func sortOutputFieldsBySchema(schema *schemapb.CollectionSchema, fieldsData []*schemapb.FieldData, outputFields []string) []string {
+	positions := make(map[string]int, len(schema.GetFields()))
+	dynamicPosition := len(schema.GetFields())
+	for i, field := range schema.GetFields() {
+		positions[field.GetName()] = i
+		if field.GetIsDynamic() {
+			dynamicPosition = i
+		}
+	}
+	position := func(name string) int {
+		if pos, ok := positions[name]; ok {
+			return pos
+		}
+		return dynamicPosition
+	}
+
+	sort.SliceStable(fieldsData, func(i, j int) bool {
+		return position(fieldsData[i].GetFieldName()) < position(fieldsData[j].GetFieldName())
+	})
+	sorted := make([]string, len(outputFields))
+	copy(sorted, outputFields)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return position(sorted[i]) < position(sorted[j])
+	})
+	return sorted
+}
+
 func validateIndexName(indexName string) error {
 	indexName = strings.TrimSpace(indexName)
 
@@ -1835,6 +1878,15 @@ func getDefaultPartitionsInPartitionKeyMode(ctx context.Context, dbName string,
 	return partitionNames, nil
 }
 
+// getPartitionKeyHashFunction returns the hash function of the partition keys of the collection.
+func getPartitionKeyHashFunction(ctx context.Context, dbName string, collectionName string) (string, error) {
+	collInfo, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, 0)
+	if err != nil {
+		return "", err
+	}
+	return collInfo.partitionKeyHashFunction, nil
+}
+
 func assignChannelsByPK(pks *schemapb.IDs, channelNames []string, insertMsg *msgstream.InsertMsg) map[string][]int {
 	insertMsg.HashValues = typeutil.HashPK2Channels(pks, channelNames)
 
@@ -1868,7 +1920,12 @@ func assignPartitionKeys(ctx context.Context, dbName string, collName string, ke
 		return nil, err
 	}
 
-	hashedPartitionNames, err := typeutil2.HashKey2Partitions(partitionKeyFieldSchema, keys, partitionNames)
+	hashFunction, err := getPartitionKeyHashFunction(ctx, dbName, collName)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPartitionNames, err := typeutil2.HashKey2Partitions(partitionKeyFieldSchema, keys, partitionNames, hashFunction)
 	return hashedPartitionNames, err
 }
 
@@ -2084,6 +2141,89 @@ func SetReportValue(status *commonpb.Status, value int) {
 	status.ExtraInfo["report_value"] = strconv.Itoa(value)
 }
 
+// SetSnapshotTs records the timestamp of the snapshot a filtered mutation evaluated its expr on.
+func SetSnapshotTs(status *commonpb.Status, ts Timestamp) {
+	if status == nil {
+		return
+	}
+	if status.ExtraInfo == nil {
+		status.ExtraInfo = make(map[string]string)
+	}
+	status.ExtraInfo[snapshotTsKey] = strconv.FormatUint(ts, 10)
+}
+
+// GetSnapshotTs returns the snapshot timestamp recorded by SetSnapshotTs, 0 if absent.
+func GetSnapshotTs(status *commonpb.Status) Timestamp {
+	ts, err := strconv.ParseUint(status.GetExtraInfo()[snapshotTsKey], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return ts
+}
+
+// SetCompletedChannels records the channels a filtered delete has finished.
+func SetCompletedChannels(status *commonpb.Status, channels []string) {
+	if status == nil || len(channels) == 0 {
+		return
+	}
+	if status.ExtraInfo == nil {
+		status.ExtraInfo = make(map[string]string)
+	}
+	sort.Strings(channels)
+	status.ExtraInfo[completedChannelsKey] = strings.Join(channels, ",")
+}
+
+// getDeleteResumeInfo parses the snapshot ts and completed channels a failed delete returned,
+// which the client passes back in the msg base properties to resume the delete.
+//
+// The protocol of resuming a failed delete by expression:
+//   - a failed delete returns the snapshot ts its expr was evaluated on in the status extra info
+//     under snapshotTsKey, and the sorted vchannels it finished under completedChannelsKey,
+//     separated by comma, together with the rows it deleted;
+//   - the client resumes the delete by sending the same request with both of them copied into
+//     the msg base properties under the same keys, the completed channels are optional;
+//   - the resumed delete evaluates the expr on the same snapshot, skipping the completed channels,
+//     and returns them again if it fails again.
+//
+// The snapshot must not be in the future nor older than proxy.deleteResumeMaxAge and
+// dataCoord.gc.dropTolerance, and the completed channels must be the vchannels of the collection.
+func getDeleteResumeInfo(base *commonpb.MsgBase, vChannels []string, now Timestamp) (Timestamp, []string, error) {
+	props := base.GetProperties()
+	tsStr, ok := props[snapshotTsKey]
+	if !ok {
+		if _, ok := props[completedChannelsKey]; ok {
+			return 0, nil, merr.WrapErrParameterMissing(snapshotTsKey, "the snapshot ts is required to resume delete")
+		}
+		return 0, nil, nil
+	}
+	ts, err := strconv.ParseUint(tsStr, 10, 64)
+	if err != nil || ts == 0 {
+		return 0, nil, merr.WrapErrParameterInvalidMsg("invalid %s %s to resume delete", snapshotTsKey, tsStr)
+	}
+	if ts > now {
+		return 0, nil, merr.WrapErrParameterInvalidMsg("snapshot ts %d to resume delete is in the future", ts)
+	}
+	maxAge := paramtable.Get().ProxyCfg.DeleteResumeMaxAge.GetAsDuration(time.Second)
+	if maxAge <= 0 {
+		return 0, nil, merr.WrapErrParameterInvalidMsg("resuming delete is disabled")
+	}
+	if dropTolerance := paramtable.Get().DataCoordCfg.GCDropTolerance.GetAsDuration(time.Second); dropTolerance < maxAge {
+		maxAge = dropTolerance
+	}
+	if age := tsoutil.PhysicalTime(now).Sub(tsoutil.PhysicalTime(ts)); age > maxAge {
+		return 0, nil, merr.WrapErrParameterInvalidMsg("snapshot ts %d to resume delete is %s old, older than %s", ts, age, maxAge)
+	}
+
+	var channels []string
+	if str := props[completedChannelsKey]; str != "" {
+		channels = lo.Uniq(strings.Split(str, ","))
+	}
+	if invalid, _ := lo.Difference(channels, vChannels); len(invalid) > 0 {
+		return 0, nil, merr.WrapErrParameterInvalidMsg("completed channels %v to resume delete are not the channels of the collection", invalid)
+	}
+	return ts, channels, nil
+}
+
 func GetCostValue(status *commonpb.Status) int {
 	if status == nil || status.ExtraInfo == nil {
 		return 0
@@ -2110,12 +2250,18 @@ func GetRequestInfo(ctx context.Context, req interface{}) (int64, map[int64][]in
 	case *milvuspb.ImportRequest:
 		dbID, collToPartIDs, err := getCollectionAndPartitionID(ctx, req.(reqPartName))
 		return dbID, collToPartIDs, internalpb.RateType_DMLBulkLoad, proto.Size(r), err
+	case *internalpb.ImportRequest:
+		dbID, collToPartIDs, err := getCollectionAndPartitionID(ctx, req.(reqPartName))
+		return dbID, collToPartIDs, internalpb.RateType_DMLBulkLoad, proto.Size(r), err
 	case *milvuspb.SearchRequest:
 		dbID, collToPartIDs, err := getCollectionAndPartitionIDs(ctx, req.(reqPartNames))
 		return dbID, collToPartIDs, internalpb.RateType_DQLSearch, int(r.GetNq()), err
 	case *milvuspb.QueryRequest:
 		dbID, collToPartIDs, err := getCollectionAndPartitionIDs(ctx, req.(reqPartNames))
 		return dbID, collToPartIDs, internalpb.RateType_DQLQuery, 1, err // think of the query request's nq as 1
+	case *proxypb.PreviewDeleteRequest:
+		dbID, collToPartIDs, err := getCollectionAndPartitionID(ctx, req.(reqPartName))
+		return dbID, collToPartIDs, internalpb.RateType_DQLQuery, 1, err
 	case *milvuspb.CreateCollectionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
 		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
@@ -2195,6 +2341,10 @@ func GetFailedResponse(req any, err error) any {
 		return &milvuspb.QueryResults{
 			Status: merr.Status(err),
 		}
+	case *proxypb.PreviewDeleteRequest:
+		return &proxypb.PreviewDeleteResponse{
+			Status: merr.Status(err),
+		}
 	case *milvuspb.CreateCollectionRequest, *milvuspb.DropCollectionRequest,
 		*milvuspb.LoadCollectionRequest, *milvuspb.ReleaseCollectionRequest,
 		*milvuspb.CreatePartitionRequest, *milvuspb.DropPartitionRequest,
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
@@ -3241,3 +3242,28 @@ func TestComputeRecall(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSortOutputFieldsBySchema(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+			{FieldID: 102, Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+			{FieldID: 103, Name: "a", DataType: schemapb.DataType_Int64},
+		},
+	}
+	fieldsData := []*schemapb.FieldData{
+		{FieldId: 103, FieldName: "a"},
+		{FieldId: 102, FieldName: "$meta", IsDynamic: true},
+		{FieldId: 101, FieldName: "vec"},
+		{FieldId: 100, FieldName: "pk"},
+	}
+	outputFields := []string{"a", "x", "vec", "pk", "y"}
+
+	sorted := sortOutputFieldsBySchema(schema, fieldsData, outputFields)
+	assert.Equal(t, []string{"pk", "vec", "x", "y", "a"}, sorted)
+	assert.Equal(t, []string{"a", "x", "vec", "pk", "y"}, outputFields)
+	assert.Equal(t, []int64{100, 101, 102, 103}, lo.Map(fieldsData, func(fieldData *schemapb.FieldData, _ int) int64 {
+		return fieldData.GetFieldId()
+	}))
+}
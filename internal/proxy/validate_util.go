@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -23,6 +25,43 @@ type validateUtil struct {
 	checkMaxLen   bool
 	checkOverflow bool
 	checkMaxCap   bool
+
+	// skipInvalidVectorRows collects vector rows failing validation into
+	// invalidRows instead of rejecting the whole batch.
+	skipInvalidVectorRows bool
+	invalidRows           map[int][]string
+}
+
+// rowError describes why a single row of a batch failed validation.
+type rowError struct {
+	Index  int
+	Reason string
+}
+
+// maxReportedRowErrors limits the number of rows listed in an error message.
+const maxReportedRowErrors = 10
+
+func formatRowErrors(rows []rowError) string {
+	parts := make([]string, 0, maxReportedRowErrors+1)
+	for i, row := range rows {
+		if i >= maxReportedRowErrors {
+			parts = append(parts, fmt.Sprintf("and %d more", len(rows)-maxReportedRowErrors))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("row %d: %s", row.Index, row.Reason))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// verifyVectorRows runs verify on each of the numRows rows and returns the failed ones.
+func verifyVectorRows(numRows int, verify func(row int) error) []rowError {
+	var rows []rowError
+	for i := 0; i < numRows; i++ {
+		if err := verify(i); err != nil {
+			rows = append(rows, rowError{Index: i, Reason: err.Error()})
+		}
+	}
+	return rows
 }
 
 type validateOption func(*validateUtil)
@@ -51,6 +90,12 @@ func withMaxCapCheck() validateOption {
 	}
 }
 
+func withInvalidVectorRowsSkipped() validateOption {
+	return func(v *validateUtil) {
+		v.skipInvalidVectorRows = true
+	}
+}
+
 func (v *validateUtil) apply(opts ...validateOption) {
 	for _, opt := range opts {
 		opt(v)
@@ -557,7 +602,16 @@ func (v *validateUtil) checkFloatVectorFieldData(field *schemapb.FieldData, fiel
 	}
 
 	if v.checkNAN {
-		return typeutil.VerifyFloats32(floatArray)
+		if err := typeutil.VerifyFloats32(floatArray); err != nil {
+			dim, dimErr := typeutil.GetDim(fieldSchema)
+			if dimErr != nil || dim <= 0 || len(floatArray)%int(dim) != 0 {
+				return err
+			}
+			rowWidth := int(dim)
+			return v.handleInvalidVectorRows(field, verifyVectorRows(len(floatArray)/rowWidth, func(row int) error {
+				return typeutil.VerifyFloats32(floatArray[row*rowWidth : (row+1)*rowWidth])
+			}), err)
+		}
 	}
 
 	return nil
@@ -570,7 +624,9 @@ func (v *validateUtil) checkFloat16VectorFieldData(field *schemapb.FieldData, fi
 		return merr.WrapErrParameterInvalid("need vector_float16 array", "got nil", msg)
 	}
 	if v.checkNAN {
-		return typeutil.VerifyFloats16(float16VecArray)
+		if err := typeutil.VerifyFloats16(float16VecArray); err != nil {
+			return v.verifyHalfVectorRows(field, fieldSchema, float16VecArray, typeutil.VerifyFloats16, err)
+		}
 	}
 	return nil
 }
@@ -582,11 +638,28 @@ func (v *validateUtil) checkBFloat16VectorFieldData(field *schemapb.FieldData, f
 		return merr.WrapErrParameterInvalid("need vector_bfloat16 array", "got nil", msg)
 	}
 	if v.checkNAN {
-		return typeutil.VerifyBFloats16(bfloat16VecArray)
+		if err := typeutil.VerifyBFloats16(bfloat16VecArray); err != nil {
+			return v.verifyHalfVectorRows(field, fieldSchema, bfloat16VecArray, typeutil.VerifyBFloats16, err)
+		}
 	}
 	return nil
 }
 
+// verifyHalfVectorRows locates the rows of a float16/bfloat16 vector field failing verify.
+// batchErr is returned as is if the data cannot be split into rows.
+func (v *validateUtil) verifyHalfVectorRows(field *schemapb.FieldData, fieldSchema *schemapb.FieldSchema,
+	data []byte, verify func([]byte) error, batchErr error,
+) error {
+	dim, err := typeutil.GetDim(fieldSchema)
+	if err != nil || dim <= 0 || len(data)%int(dim*2) != 0 {
+		return batchErr
+	}
+	rowWidth := int(dim * 2)
+	return v.handleInvalidVectorRows(field, verifyVectorRows(len(data)/rowWidth, func(row int) error {
+		return verify(data[row*rowWidth : (row+1)*rowWidth])
+	}), batchErr)
+}
+
 func (v *validateUtil) checkBinaryVectorFieldData(field *schemapb.FieldData, fieldSchema *schemapb.FieldSchema) error {
 	bVecArray := field.GetVectors().GetBinaryVector()
 	if bVecArray == nil {
@@ -606,7 +679,46 @@ func (v *validateUtil) checkSparseFloatFieldData(field *schemapb.FieldData, fiel
 		msg := fmt.Sprintf("sparse float field '%v' is illegal, array type mismatch", field.GetFieldName())
 		return merr.WrapErrParameterInvalid("need sparse float array", "got nil", msg)
 	}
-	return typeutil.ValidateSparseFloatRows(sparseRows...)
+	if err := typeutil.ValidateSparseFloatRows(sparseRows...); err != nil {
+		return v.handleInvalidVectorRows(field, verifyVectorRows(len(sparseRows), func(row int) error {
+			return typeutil.ValidateSparseFloatRows(sparseRows[row])
+		}), err)
+	}
+	return nil
+}
+
+// handleInvalidVectorRows either records the invalid rows of field to be skipped by the caller,
+// or turns them into an error listing each offending row.
+// batchErr is returned if no single row could be blamed.
+func (v *validateUtil) handleInvalidVectorRows(field *schemapb.FieldData, rows []rowError, batchErr error) error {
+	if len(rows) == 0 {
+		return batchErr
+	}
+	if v.skipInvalidVectorRows {
+		if v.invalidRows == nil {
+			v.invalidRows = make(map[int][]string)
+		}
+		for _, row := range rows {
+			v.invalidRows[row.Index] = append(v.invalidRows[row.Index],
+				fmt.Sprintf("field '%s': %s", field.GetFieldName(), row.Reason))
+		}
+		return nil
+	}
+	return merr.WrapErrParameterInvalidMsg("vector field '%s' has %d invalid rows: %s",
+		field.GetFieldName(), len(rows), formatRowErrors(rows))
+}
+
+// InvalidRows returns the rows skipped by validation ordered by row index,
+// only populated when withInvalidVectorRowsSkipped is applied.
+func (v *validateUtil) InvalidRows() []rowError {
+	rows := make([]rowError, 0, len(v.invalidRows))
+	for idx, reasons := range v.invalidRows {
+		rows = append(rows, rowError{Index: idx, Reason: strings.Join(reasons, ", ")})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Index < rows[j].Index
+	})
+	return rows
 }
 
 func (v *validateUtil) checkVarCharFieldData(field *schemapb.FieldData, fieldSchema *schemapb.FieldSchema) error {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -293,6 +294,82 @@ func Test_validateUtil_checkFloatVectorFieldData(t *testing.T) {
 	})
 }
 
+func Test_validateUtil_invalidVectorRows(t *testing.T) {
+	fieldSchema := &schemapb.FieldSchema{
+		Name:     "vec",
+		DataType: schemapb.DataType_FloatVector,
+		TypeParams: []*commonpb.KeyValuePair{
+			{Key: common.DimKey, Value: "2"},
+		},
+	}
+	floatField := func() *schemapb.FieldData {
+		return &schemapb.FieldData{
+			FieldName: "vec",
+			Type:      schemapb.DataType_FloatVector,
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Dim: 2,
+					Data: &schemapb.VectorField_FloatVector{
+						FloatVector: &schemapb.FloatArray{
+							Data: []float32{1, 2, float32(math.NaN()), 3, 4, 5, 6, float32(math.Inf(1))},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("report rows", func(t *testing.T) {
+		v := newValidateUtil(withNANCheck())
+		err := v.checkFloatVectorFieldData(floatField(), fieldSchema)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		assert.Contains(t, err.Error(), "has 2 invalid rows")
+		assert.Contains(t, err.Error(), "row 1:")
+		assert.Contains(t, err.Error(), "row 3:")
+		assert.Empty(t, v.InvalidRows())
+	})
+
+	t.Run("dim unknown", func(t *testing.T) {
+		v := newValidateUtil(withNANCheck())
+		err := v.checkFloatVectorFieldData(floatField(), nil)
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "invalid rows")
+	})
+
+	t.Run("skip rows", func(t *testing.T) {
+		v := newValidateUtil(withNANCheck(), withInvalidVectorRowsSkipped())
+		err := v.checkFloatVectorFieldData(floatField(), fieldSchema)
+		assert.NoError(t, err)
+
+		sparseField := &schemapb.FieldData{
+			FieldName: "sparse",
+			Type:      schemapb.DataType_SparseFloatVector,
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Data: &schemapb.VectorField_SparseFloatVector{
+						SparseFloatVector: &schemapb.SparseFloatArray{
+							Contents: [][]byte{
+								typeutil.CreateSparseFloatRow([]uint32{1, 2}, []float32{1, 2}),
+								typeutil.CreateSparseFloatRow([]uint32{2, 1}, []float32{1, 2}),
+								typeutil.CreateSparseFloatRow([]uint32{1}, []float32{-1}),
+								typeutil.CreateSparseFloatRow([]uint32{3}, []float32{1}),
+							},
+						},
+					},
+				},
+			},
+		}
+		err = v.checkSparseFloatFieldData(sparseField, nil)
+		assert.NoError(t, err)
+
+		rows := v.InvalidRows()
+		assert.Equal(t, []int{1, 2, 3}, lo.Map(rows, func(row rowError, _ int) int { return row.Index }))
+		assert.Contains(t, rows[0].Reason, "field 'vec'")
+		assert.Contains(t, rows[0].Reason, "field 'sparse'")
+		assert.Contains(t, rows[1].Reason, "negative value")
+	})
+}
+
 func Test_validateUtil_checkFloat16VectorFieldData(t *testing.T) {
 	nb := 5
 	dim := int64(8)
@@ -0,0 +1,616 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/util/crypto"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	dedupDefaultBatchSize   = 256
+	dedupMaxBatchSize       = 4096
+	dedupDefaultTopK        = 16
+	dedupMaxTopK            = 1024
+	dedupDefaultMaxEntities = 1000000
+	dedupDeleteBatchSize    = 1000
+	// dedupMaxFinishedJobs is the number of the finished jobs kept for their status
+	dedupMaxFinishedJobs = 64
+
+	// dedupAuthHeader carries the milvus credentials of the caller, user:password or the api key, optionally
+	// prefixed by "Bearer ". The job is authenticated and authorized with them like the requests of the sdk.
+	dedupAuthHeader = "Milvus-Authorization"
+)
+
+// vectorDedupParams is the scan of a collection for the near-duplicate vectors.
+type vectorDedupParams struct {
+	dbName         string
+	collectionName string
+	partitionNames []string
+	// fieldName is the vector field to compare, the only vector field of the collection if empty
+	fieldName string
+	// threshold is compared with the scores by the metric of the index,
+	// two vectors are duplicated if their score is not worse than it
+	threshold float32
+	// topK is the number of the neighbors checked for each vector
+	topK        int64
+	batchSize   int64
+	maxEntities int64
+	dryRun      bool
+}
+
+func parseVectorDedupParams(form func(key string) string) (*vectorDedupParams, error) {
+	p := &vectorDedupParams{
+		dbName:         form("db_name"),
+		collectionName: form("collection_name"),
+		fieldName:      form("field_name"),
+		topK:           dedupDefaultTopK,
+		batchSize:      dedupDefaultBatchSize,
+		maxEntities:    dedupDefaultMaxEntities,
+		dryRun:         true,
+	}
+	if p.collectionName == "" {
+		return nil, errors.New("collection_name is required")
+	}
+	if value := form("partition_names"); value != "" {
+		p.partitionNames = strings.Split(value, ",")
+	}
+
+	value := form("threshold")
+	if value == "" {
+		return nil, errors.New("threshold is required")
+	}
+	threshold, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid threshold")
+	}
+	p.threshold = float32(threshold)
+
+	for _, param := range []struct {
+		key      string
+		value    *int64
+		maxValue int64
+	}{
+		{"topk", &p.topK, dedupMaxTopK},
+		{"batch_size", &p.batchSize, dedupMaxBatchSize},
+		{"max_entities", &p.maxEntities, 0},
+	} {
+		value := form(param.key)
+		if value == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v <= 0 || (param.maxValue > 0 && v > param.maxValue) {
+			return nil, errors.Newf("invalid %s %s, must be positive and at most %d", param.key, value, param.maxValue)
+		}
+		*param.value = v
+	}
+
+	if value := form("dry_run"); value != "" {
+		if p.dryRun, err = strconv.ParseBool(value); err != nil {
+			return nil, errors.Wrap(err, "invalid dry_run")
+		}
+	}
+	return p, nil
+}
+
+// isVectorDedupDryRun returns whether the dedup request only reports the clusters, which is the default.
+// The invalid requests are passed to the handler to be rejected with the reason.
+func isVectorDedupDryRun(req *http.Request) bool {
+	p, err := parseVectorDedupParams(req.FormValue)
+	return err != nil || p.dryRun
+}
+
+// VectorDedupCluster is a group of the near-duplicate entities, all of which are similar to the kept one.
+type VectorDedupCluster struct {
+	// Keep is the smallest primary key of the cluster, which is not deleted
+	Keep       any   `json:"keep"`
+	Duplicates []any `json:"duplicates"`
+}
+
+// VectorDedupResult is the result of the near-duplicate vector scan.
+type VectorDedupResult struct {
+	FieldName  string `json:"field_name"`
+	MetricType string `json:"metric_type"`
+	Scanned    int64  `json:"scanned"`
+	// Truncated is true if the scan stopped at max_entities before the end of the collection
+	Truncated bool                  `json:"truncated"`
+	Clusters  []*VectorDedupCluster `json:"clusters"`
+	DryRun    bool                  `json:"dry_run"`
+	Deleted   int64                 `json:"deleted"`
+}
+
+// dedupVectors scans the entities in the order of the primary keys, searches the neighbors of each batch
+// with the existing index, and clusters the entities closer than the threshold to the kept one.
+// All but the kept entity of each cluster are deleted unless dry run.
+// The requests are authorized as the caller in ctx, and scanned is updated after each page.
+func (node *Proxy) dedupVectors(ctx context.Context, p *vectorDedupParams, scanned *atomic.Int64) (*VectorDedupResult, error) {
+	describeReq := &milvuspb.DescribeCollectionRequest{
+		DbName:         p.dbName,
+		CollectionName: p.collectionName,
+	}
+	if err := dedupAuthorize(ctx, describeReq); err != nil {
+		return nil, err
+	}
+	collResp, err := node.DescribeCollection(ctx, describeReq)
+	if err = merr.CheckRPCCall(collResp, err); err != nil {
+		return nil, err
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(collResp.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+	vectorField, err := dedupVectorField(collResp.GetSchema(), p.fieldName)
+	if err != nil {
+		return nil, err
+	}
+	metricType, err := node.dedupMetricType(ctx, p, vectorField.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VectorDedupResult{
+		FieldName:  vectorField.GetName(),
+		MetricType: metricType,
+		DryRun:     p.dryRun,
+	}
+	positive := metric.PositivelyRelated(metricType)
+	clusters := newDedupClusters()
+	var lastPK any
+	var sessionTs uint64
+	for {
+		if result.Scanned >= p.maxEntities {
+			result.Truncated = true
+			break
+		}
+		limit := min(p.batchSize, p.maxEntities-result.Scanned)
+		queryReq := &milvuspb.QueryRequest{
+			DbName:             p.dbName,
+			CollectionName:     p.collectionName,
+			PartitionNames:     p.partitionNames,
+			Expr:               dedupPageExpr(pkField.GetName(), lastPK),
+			OutputFields:       []string{pkField.GetName(), vectorField.GetName()},
+			GuaranteeTimestamp: sessionTs,
+			QueryParams: []*commonpb.KeyValuePair{
+				{Key: LimitKey, Value: strconv.FormatInt(limit, 10)},
+				{Key: IteratorField, Value: "true"},
+				{Key: ReduceStopForBestKey, Value: "true"},
+			},
+			UseDefaultConsistency: true,
+		}
+		if err := dedupAuthorize(ctx, queryReq); err != nil {
+			return nil, err
+		}
+		queryResp, err := node.Query(ctx, queryReq)
+		if err = merr.CheckRPCCall(queryResp, err); err != nil {
+			return nil, err
+		}
+		// the following pages and searches read the snapshot of the first page
+		if sessionTs == 0 {
+			sessionTs = queryResp.GetSessionTs()
+		}
+
+		var pkData, vectorData *schemapb.FieldData
+		for _, fieldData := range queryResp.GetFieldsData() {
+			switch fieldData.GetFieldName() {
+			case pkField.GetName():
+				pkData = fieldData
+			case vectorField.GetName():
+				vectorData = fieldData
+			}
+		}
+		num := typeutil.GetPKSize(pkData)
+		if num == 0 || vectorData == nil {
+			break
+		}
+		if err := node.dedupSearchPage(ctx, p, vectorField.GetName(), metricType, sessionTs, pkData, vectorData, num, positive, clusters); err != nil {
+			return nil, err
+		}
+		lastPK = typeutil.GetData(pkData, num-1)
+		result.Scanned += int64(num)
+		scanned.Store(result.Scanned)
+		if int64(num) < limit {
+			break
+		}
+	}
+
+	result.Clusters = clusters.build()
+	if p.dryRun {
+		return result, nil
+	}
+	result.Deleted, err = node.dedupDelete(ctx, p, pkField.GetName(), result.Clusters)
+	return result, err
+}
+
+func dedupVectorField(schema *schemapb.CollectionSchema, fieldName string) (*schemapb.FieldSchema, error) {
+	var vectorFields []*schemapb.FieldSchema
+	for _, field := range schema.GetFields() {
+		if typeutil.IsVectorType(field.GetDataType()) {
+			vectorFields = append(vectorFields, field)
+		}
+	}
+	if fieldName == "" {
+		if len(vectorFields) != 1 {
+			return nil, errors.Newf("field_name is required for the collection of %d vector fields", len(vectorFields))
+		}
+		return vectorFields[0], nil
+	}
+	for _, field := range vectorFields {
+		if field.GetName() == fieldName {
+			return field, nil
+		}
+	}
+	return nil, errors.Newf("vector field %s not found", fieldName)
+}
+
+// dedupMetricType returns the metric type of the index on the field, which the scores are compared by.
+func (node *Proxy) dedupMetricType(ctx context.Context, p *vectorDedupParams, fieldName string) (string, error) {
+	indexReq := &milvuspb.DescribeIndexRequest{
+		DbName:         p.dbName,
+		CollectionName: p.collectionName,
+		FieldName:      fieldName,
+	}
+	if err := dedupAuthorize(ctx, indexReq); err != nil {
+		return "", err
+	}
+	indexResp, err := node.DescribeIndex(ctx, indexReq)
+	if err = merr.CheckRPCCall(indexResp, err); err != nil {
+		return "", err
+	}
+	for _, index := range indexResp.GetIndexDescriptions() {
+		if index.GetFieldName() != fieldName {
+			continue
+		}
+		if metricType, err := funcutil.GetAttrByKeyFromRepeatedKV(MetricTypeKey, index.GetParams()); err == nil {
+			return metricType, nil
+		}
+	}
+	return "", errors.Newf("no metric type found in the index of field %s", fieldName)
+}
+
+// dedupPageExpr filters the entities after the last primary key of the previous page.
+func dedupPageExpr(pkName string, lastPK any) string {
+	switch pk := lastPK.(type) {
+	case int64:
+		return fmt.Sprintf("%s > %d", pkName, pk)
+	case string:
+		return fmt.Sprintf("%s > %s", pkName, strconv.Quote(pk))
+	}
+	return ""
+}
+
+func (node *Proxy) dedupSearchPage(ctx context.Context, p *vectorDedupParams, fieldName, metricType string, sessionTs uint64,
+	pkData, vectorData *schemapb.FieldData, num int, positive bool, clusters *dedupClusters,
+) error {
+	placeholderGroup, err := funcutil.FieldDataToPlaceholderGroupBytes(vectorData)
+	if err != nil {
+		return err
+	}
+	searchReq := &milvuspb.SearchRequest{
+		DbName:           p.dbName,
+		CollectionName:   p.collectionName,
+		PartitionNames:   p.partitionNames,
+		DslType:          commonpb.DslType_BoolExprV1,
+		PlaceholderGroup: placeholderGroup,
+		Nq:               int64(num),
+		SearchParams: []*commonpb.KeyValuePair{
+			{Key: AnnsFieldKey, Value: fieldName},
+			// the vector itself is one of the neighbors
+			{Key: TopKKey, Value: strconv.FormatInt(p.topK+1, 10)},
+			{Key: MetricTypeKey, Value: metricType},
+			{Key: SearchParamsKey, Value: "{}"},
+			{Key: RoundDecimalKey, Value: "-1"},
+		},
+		GuaranteeTimestamp:    sessionTs,
+		UseDefaultConsistency: true,
+	}
+	if err := dedupAuthorize(ctx, searchReq); err != nil {
+		return err
+	}
+	searchResp, err := node.Search(ctx, searchReq)
+	if err = merr.CheckRPCCall(searchResp, err); err != nil {
+		return err
+	}
+
+	results := searchResp.GetResults()
+	offset := int64(0)
+	for i, topk := range results.GetTopks() {
+		if i >= num {
+			break
+		}
+		pk := typeutil.GetData(pkData, i)
+		for j := offset; j < offset+topk; j++ {
+			neighbor := typeutil.GetPK(results.GetIds(), j)
+			if neighbor == nil || neighbor == pk {
+				continue
+			}
+			score := results.GetScores()[j]
+			if (positive && score >= p.threshold) || (!positive && score <= p.threshold) {
+				clusters.link(pk, neighbor)
+			}
+		}
+		offset += topk
+	}
+	return nil
+}
+
+func (node *Proxy) dedupDelete(ctx context.Context, p *vectorDedupParams, pkName string, clusters []*VectorDedupCluster) (int64, error) {
+	var duplicates []any
+	for _, cluster := range clusters {
+		duplicates = append(duplicates, cluster.Duplicates...)
+	}
+
+	deleted := int64(0)
+	for start := 0; start < len(duplicates); start += dedupDeleteBatchSize {
+		ids := &schemapb.IDs{}
+		for _, pk := range duplicates[start:min(start+dedupDeleteBatchSize, len(duplicates))] {
+			typeutil.AppendPKs(ids, pk)
+		}
+		deleteReq := &milvuspb.DeleteRequest{
+			DbName:         p.dbName,
+			CollectionName: p.collectionName,
+			Expr:           IDs2Expr(pkName, ids),
+		}
+		if err := dedupAuthorize(ctx, deleteReq); err != nil {
+			return deleted, err
+		}
+		resp, err := node.Delete(ctx, deleteReq)
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			log.Ctx(ctx).Warn("failed to delete the duplicated entities",
+				zap.String("collection", p.collectionName), zap.Int64("deleted", deleted), zap.Error(err))
+			return deleted, err
+		}
+		deleted += resp.GetDeleteCnt()
+	}
+	return deleted, nil
+}
+
+// dedupClusters records the similar pairs of the primary keys, which are closer than the threshold.
+type dedupClusters struct {
+	neighbors map[any]typeutil.Set[any]
+}
+
+func newDedupClusters() *dedupClusters {
+	return &dedupClusters{neighbors: make(map[any]typeutil.Set[any])}
+}
+
+func (c *dedupClusters) link(a, b any) {
+	for _, pair := range [][2]any{{a, b}, {b, a}} {
+		neighbors, ok := c.neighbors[pair[0]]
+		if !ok {
+			neighbors = typeutil.NewSet[any]()
+			c.neighbors[pair[0]] = neighbors
+		}
+		neighbors.Insert(pair[1])
+	}
+}
+
+// build returns the clusters ordered by their smallest primary keys.
+// The clusters are built around the leaders in the order of the primary keys, the not yet clustered
+// entities similar to the leader itself join its cluster. Similarity is not transitive, so each
+// duplicate is within the threshold of the kept entity rather than connected by a chain of pairs.
+func (c *dedupClusters) build() []*VectorDedupCluster {
+	pks := make([]any, 0, len(c.neighbors))
+	for pk := range c.neighbors {
+		pks = append(pks, pk)
+	}
+	sort.Slice(pks, func(i, j int) bool {
+		return dedupLess(pks[i], pks[j])
+	})
+
+	clustered := typeutil.NewSet[any]()
+	clusters := make([]*VectorDedupCluster, 0)
+	for _, leader := range pks {
+		if clustered.Contain(leader) {
+			continue
+		}
+		duplicates := make([]any, 0)
+		for neighbor := range c.neighbors[leader] {
+			if !clustered.Contain(neighbor) {
+				duplicates = append(duplicates, neighbor)
+			}
+		}
+		if len(duplicates) == 0 {
+			continue
+		}
+		sort.Slice(duplicates, func(i, j int) bool {
+			return dedupLess(duplicates[i], duplicates[j])
+		})
+		clustered.Insert(leader)
+		clustered.Insert(duplicates...)
+		clusters = append(clusters, &VectorDedupCluster{Keep: leader, Duplicates: duplicates})
+	}
+	return clusters
+}
+
+func dedupLess(a, b any) bool {
+	switch a := a.(type) {
+	case int64:
+		return a < b.(int64)
+	case string:
+		return a < b.(string)
+	}
+	return false
+}
+
+// dedupCallerContext returns the context authenticated as the caller by the credentials in dedupAuthHeader
+// if the authorization is enabled. It's detached from the http request, which the job outlives.
+func dedupCallerContext(req *http.Request, dbName string) (context.Context, error) {
+	ctx := NewContextWithMetadata(context.Background(), "", dbName)
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return ctx, nil
+	}
+	token := strings.TrimPrefix(req.Header.Get(dedupAuthHeader), "Bearer ")
+	if token == "" {
+		return nil, merr.WrapErrParameterMissing(dedupAuthHeader, "the header is required if the authorization is enabled")
+	}
+	ctx = contextutil.AppendToIncomingContext(ctx, strings.ToLower(util.HeaderAuthorize), crypto.Base64Encode(token))
+	return AuthenticationInterceptor(ctx)
+}
+
+// dedupAuthorize checks the privilege of the caller for the request, as the grpc interceptor does for the sdk.
+func dedupAuthorize(ctx context.Context, req any) error {
+	_, err := PrivilegeInterceptor(ctx, req)
+	return err
+}
+
+const (
+	VectorDedupJobRunning   = "running"
+	VectorDedupJobCompleted = "completed"
+	VectorDedupJobFailed    = "failed"
+)
+
+// VectorDedupJob is the status of a near-duplicate vector scan running in the background.
+type VectorDedupJob struct {
+	JobID      int64  `json:"job_id"`
+	DbName     string `json:"db_name"`
+	Collection string `json:"collection_name"`
+	State      string `json:"state"`
+	Reason     string `json:"reason,omitempty"`
+	// Scanned is the number of the entities scanned so far
+	Scanned int64 `json:"scanned"`
+	// unix milliseconds the job started and finished
+	StartTime int64              `json:"start_time"`
+	EndTime   int64              `json:"end_time,omitempty"`
+	Result    *VectorDedupResult `json:"result,omitempty"`
+}
+
+type vectorDedupJob struct {
+	status  VectorDedupJob
+	scanned *atomic.Int64
+}
+
+// vectorDedupJobs tracks the dedup jobs of this proxy, the last dedupMaxFinishedJobs finished ones are kept.
+// At most one job runs for a collection at a time, so the concurrent jobs don't delete the same clusters.
+type vectorDedupJobs struct {
+	mu       sync.Mutex
+	nextID   int64
+	jobs     map[int64]*vectorDedupJob
+	finished []int64
+}
+
+var globalVectorDedupJobs = newVectorDedupJobs()
+
+func newVectorDedupJobs() *vectorDedupJobs {
+	return &vectorDedupJobs{
+		nextID: time.Now().UnixMilli(),
+		jobs:   make(map[int64]*vectorDedupJob),
+	}
+}
+
+// start runs the job in the background and returns its id.
+func (j *vectorDedupJobs) start(p *vectorDedupParams, run func(scanned *atomic.Int64) (*VectorDedupResult, error)) (int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, job := range j.jobs {
+		if job.status.State == VectorDedupJobRunning && job.status.DbName == p.dbName && job.status.Collection == p.collectionName {
+			return 0, errors.Newf("dedup job %d is running on the collection", job.status.JobID)
+		}
+	}
+	j.nextID++
+	job := &vectorDedupJob{
+		status: VectorDedupJob{
+			JobID:      j.nextID,
+			DbName:     p.dbName,
+			Collection: p.collectionName,
+			State:      VectorDedupJobRunning,
+			StartTime:  time.Now().UnixMilli(),
+		},
+		scanned: atomic.NewInt64(0),
+	}
+	j.jobs[job.status.JobID] = job
+
+	go func() {
+		result, err := run(job.scanned)
+		j.finish(job, result, err)
+	}()
+	return job.status.JobID, nil
+}
+
+func (j *vectorDedupJobs) finish(job *vectorDedupJob, result *VectorDedupResult, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job.status.State = VectorDedupJobCompleted
+	if err != nil {
+		job.status.State = VectorDedupJobFailed
+		job.status.Reason = err.Error()
+		log.Warn("dedup job failed", zap.Int64("jobID", job.status.JobID),
+			zap.String("collection", job.status.Collection), zap.Error(err))
+	}
+	// the partial result is kept for the deleted count if the delete failed
+	job.status.Result = result
+	job.status.EndTime = time.Now().UnixMilli()
+
+	j.finished = append(j.finished, job.status.JobID)
+	if len(j.finished) > dedupMaxFinishedJobs {
+		delete(j.jobs, j.finished[0])
+		j.finished = j.finished[1:]
+	}
+}
+
+func (j *vectorDedupJobs) snapshot(job *vectorDedupJob) *VectorDedupJob {
+	status := job.status
+	status.Scanned = job.scanned.Load()
+	return &status
+}
+
+// Get returns the status of the job.
+func (j *vectorDedupJobs) Get(jobID int64) (*VectorDedupJob, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[jobID]
+	if !ok {
+		return nil, false
+	}
+	return j.snapshot(job), true
+}
+
+// List returns the status of the jobs ordered by the job ids.
+func (j *vectorDedupJobs) List() []*VectorDedupJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	jobs := make([]*VectorDedupJob, 0, len(j.jobs))
+	for _, job := range j.jobs {
+		jobs = append(jobs, j.snapshot(job))
+	}
+	sort.Slice(jobs, func(a, b int) bool {
+		return jobs[a].JobID < jobs[b].JobID
+	})
+	return jobs
+}
@@ -0,0 +1,239 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestParseVectorDedupParams(t *testing.T) {
+	form := func(values map[string]string) func(string) string {
+		return func(key string) string {
+			return values[key]
+		}
+	}
+
+	p, err := parseVectorDedupParams(form(map[string]string{
+		"collection_name": "coll",
+		"threshold":       "0.95",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "coll", p.collectionName)
+	assert.InDelta(t, 0.95, p.threshold, 1e-6)
+	assert.EqualValues(t, dedupDefaultTopK, p.topK)
+	assert.EqualValues(t, dedupDefaultBatchSize, p.batchSize)
+	assert.True(t, p.dryRun)
+
+	p, err = parseVectorDedupParams(form(map[string]string{
+		"collection_name": "coll",
+		"partition_names": "p1,p2",
+		"threshold":       "0.1",
+		"topk":            "4",
+		"batch_size":      "100",
+		"max_entities":    "1000",
+		"dry_run":         "false",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, p.partitionNames)
+	assert.EqualValues(t, 4, p.topK)
+	assert.EqualValues(t, 100, p.batchSize)
+	assert.EqualValues(t, 1000, p.maxEntities)
+	assert.False(t, p.dryRun)
+
+	for _, values := range []map[string]string{
+		{"threshold": "0.9"},
+		{"collection_name": "coll"},
+		{"collection_name": "coll", "threshold": "abc"},
+		{"collection_name": "coll", "threshold": "0.9", "topk": "0"},
+		{"collection_name": "coll", "threshold": "0.9", "batch_size": "100000"},
+		{"collection_name": "coll", "threshold": "0.9", "dry_run": "maybe"},
+	} {
+		_, err = parseVectorDedupParams(form(values))
+		assert.Error(t, err, values)
+	}
+}
+
+func TestDedupVectorField(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	field, err := dedupVectorField(schema, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "vec", field.GetName())
+	_, err = dedupVectorField(schema, "pk")
+	assert.Error(t, err)
+
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{Name: "vec2", DataType: schemapb.DataType_BinaryVector})
+	_, err = dedupVectorField(schema, "")
+	assert.Error(t, err)
+	field, err = dedupVectorField(schema, "vec2")
+	assert.NoError(t, err)
+	assert.Equal(t, "vec2", field.GetName())
+}
+
+func TestDedupPageExpr(t *testing.T) {
+	assert.Equal(t, "", dedupPageExpr("pk", nil))
+	assert.Equal(t, "pk > 10", dedupPageExpr("pk", int64(10)))
+	assert.Equal(t, `pk > "a\"b"`, dedupPageExpr("pk", `a"b`))
+}
+
+func TestDedupClusters(t *testing.T) {
+	clusters := newDedupClusters()
+	clusters.link(int64(5), int64(3))
+	clusters.link(int64(3), int64(9))
+	clusters.link(int64(7), int64(1))
+	clusters.link(int64(9), int64(5))
+
+	result := clusters.build()
+	assert.Len(t, result, 2)
+	assert.Equal(t, int64(1), result[0].Keep)
+	assert.Equal(t, []any{int64(7)}, result[0].Duplicates)
+	assert.Equal(t, int64(3), result[1].Keep)
+	assert.Equal(t, []any{int64(5), int64(9)}, result[1].Duplicates)
+
+	// a chain of similar pairs, 1 and 3 are not similar and both kept
+	clusters = newDedupClusters()
+	clusters.link(int64(1), int64(2))
+	clusters.link(int64(2), int64(3))
+	clusters.link(int64(3), int64(4))
+	result = clusters.build()
+	assert.Len(t, result, 2)
+	assert.Equal(t, int64(1), result[0].Keep)
+	assert.Equal(t, []any{int64(2)}, result[0].Duplicates)
+	assert.Equal(t, int64(3), result[1].Keep)
+	assert.Equal(t, []any{int64(4)}, result[1].Duplicates)
+
+	clusters = newDedupClusters()
+	clusters.link("b", "a")
+	result = clusters.build()
+	assert.Len(t, result, 1)
+	assert.Equal(t, "a", result[0].Keep)
+	assert.Equal(t, []any{"b"}, result[0].Duplicates)
+}
+
+func TestVectorDedupJobs(t *testing.T) {
+	jobs := newVectorDedupJobs()
+	p := &vectorDedupParams{dbName: "db", collectionName: "coll"}
+
+	release := make(chan struct{})
+	jobID, err := jobs.start(p, func(scanned *atomic.Int64) (*VectorDedupResult, error) {
+		scanned.Store(10)
+		<-release
+		return &VectorDedupResult{Scanned: 20, DryRun: true}, nil
+	})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		job, ok := jobs.Get(jobID)
+		return ok && job.State == VectorDedupJobRunning && job.Scanned == 10
+	}, time.Second, 10*time.Millisecond)
+
+	// one job runs on a collection at a time
+	_, err = jobs.start(p, func(*atomic.Int64) (*VectorDedupResult, error) { return nil, nil })
+	assert.Error(t, err)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		job, _ := jobs.Get(jobID)
+		return job.State == VectorDedupJobCompleted
+	}, time.Second, 10*time.Millisecond)
+	job, _ := jobs.Get(jobID)
+	assert.EqualValues(t, 20, job.Result.Scanned)
+	assert.NotZero(t, job.EndTime)
+
+	failedID, err := jobs.start(p, func(*atomic.Int64) (*VectorDedupResult, error) {
+		return nil, errors.New("mock failure")
+	})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		job, _ := jobs.Get(failedID)
+		return job.State == VectorDedupJobFailed && job.Reason == "mock failure"
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, jobs.List(), 2)
+
+	// the oldest finished jobs are evicted
+	for i := 0; i < dedupMaxFinishedJobs; i++ {
+		id, err := jobs.start(p, func(*atomic.Int64) (*VectorDedupResult, error) { return nil, nil })
+		require.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			job, _ := jobs.Get(id)
+			return job.State == VectorDedupJobCompleted
+		}, time.Second, time.Millisecond)
+	}
+	_, ok := jobs.Get(jobID)
+	assert.False(t, ok)
+	assert.Len(t, jobs.List(), dedupMaxFinishedJobs)
+}
+
+func TestDedupVectorsHandler(t *testing.T) {
+	paramtable.Init()
+	node := &Proxy{}
+
+	// only the deleting requests are refused by the router without the management auth
+	form := url.Values{"collection_name": {"coll"}, "threshold": {"0.9"}, "dry_run": {"false"}}
+	req, err := http.NewRequest(http.MethodPost, management.RouteDedupVectors, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.False(t, isVectorDedupDryRun(req))
+	for _, query := range []string{"collection_name=coll&threshold=0.9", "collection_name=coll&threshold=0.9&dry_run=true", "dry_run=false"} {
+		req, err = http.NewRequest(http.MethodPost, management.RouteDedupVectors+"?"+query, nil)
+		require.NoError(t, err)
+		assert.True(t, isVectorDedupDryRun(req), query)
+	}
+
+	// the caller credentials are required if the authorization is enabled
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+	form.Set("dry_run", "true")
+	req, err = http.NewRequest(http.MethodPost, management.RouteDedupVectors, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	node.DedupVectors(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	req, err = http.NewRequest(http.MethodGet, management.RouteDedupVectorsJob+"?job_id=1", nil)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	node.GetDedupVectorsJob(recorder, req)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	req, err = http.NewRequest(http.MethodGet, management.RouteDedupVectorsJob, nil)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	node.GetDedupVectorsJob(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	jobs := make([]*VectorDedupJob, 0)
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &jobs))
+}
@@ -109,6 +109,12 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 	)
 	var tasks []task.Task
 
+	// the replica only serves the indexes of its resource group
+	indexInfos = utils.SelectIndexesForResourceGroup(indexInfos, replica.GetResourceGroup())
+	indexIDs := typeutil.NewSet(lo.Map(indexInfos, func(info *indexpb.IndexInfo, _ int) int64 {
+		return info.GetIndexID()
+	})...)
+
 	segments := c.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(replica.GetCollectionID()), meta.WithReplica(replica))
 	idSegments := make(map[int64]*meta.Segment)
 
@@ -126,7 +132,15 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 			continue
 		}
 
-		missing := c.checkSegment(segment, indexInfos)
+		missing, switched := c.checkSegment(segment, indexInfos)
+		if len(switched) > 0 {
+			// a loaded vector index can't be replaced in place,
+			// the segment serves the new selected index after the collection is released and loaded again
+			log.RatedInfo(60, "selected index differs from the loaded index, release and load the collection to switch the index",
+				zap.Int64("replicaID", replica.GetID()),
+				zap.Int64("segmentID", segment.GetID()),
+				zap.Int64s("fieldIDs", switched))
+		}
 		if len(missing) > 0 {
 			targets[segment.GetID()] = missing
 			idSegments[segment.GetID()] = segment
@@ -145,6 +159,7 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 			missingFields := typeutil.NewSet(fields...)
 			for _, fieldIndexInfo := range segmentIndexInfo {
 				if missingFields.Contain(fieldIndexInfo.GetFieldID()) &&
+					indexIDs.Contain(fieldIndexInfo.GetIndexID()) &&
 					fieldIndexInfo.GetEnableIndex() &&
 					len(fieldIndexInfo.GetIndexFilePaths()) > 0 {
 					segmentsToUpdate.Insert(segmentID)
@@ -160,20 +175,21 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 	return tasks
 }
 
-func (c *IndexChecker) checkSegment(segment *meta.Segment, indexInfos []*indexpb.IndexInfo) (fieldIDs []int64) {
-	var result []int64
+// checkSegment returns the fields missing the selected index,
+// and the fields whose loaded index is not the selected one.
+func (c *IndexChecker) checkSegment(segment *meta.Segment, indexInfos []*indexpb.IndexInfo) (missing []int64, switched []int64) {
 	for _, indexInfo := range indexInfos {
 		fieldID, indexID := indexInfo.FieldID, indexInfo.IndexID
 		info, ok := segment.IndexInfo[fieldID]
-		if !ok {
-			result = append(result, fieldID)
+		if !ok || !info.GetEnableIndex() {
+			missing = append(missing, fieldID)
 			continue
 		}
-		if indexID != info.GetIndexID() || !info.GetEnableIndex() {
-			result = append(result, fieldID)
+		if indexID != info.GetIndexID() {
+			switched = append(switched, fieldID)
 		}
 	}
-	return result
+	return missing, switched
 }
 
 func (c *IndexChecker) createSegmentUpdateTask(ctx context.Context, segment *meta.Segment, replica *meta.Replica) (task.Task, bool) {
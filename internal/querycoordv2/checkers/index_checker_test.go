@@ -324,6 +324,45 @@ func (suite *IndexCheckerSuite) TestCreateNewIndex() {
 	suite.Equal(tasks[0].Actions()[0].(*task.SegmentAction).Type(), task.ActionTypeUpdate)
 }
 
+func (suite *IndexCheckerSuite) TestSwitchedIndex() {
+	checker := suite.checker
+	ctx := context.Background()
+
+	// meta
+	coll := utils.CreateTestCollection(1, 1)
+	coll.FieldIndexID = map[int64]int64{101: 1000}
+	checker.meta.CollectionManager.PutCollection(ctx, coll)
+	checker.meta.ReplicaManager.Put(ctx, utils.CreateTestReplica(200, 1, []int64{1, 2}))
+	suite.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+		NodeID:   1,
+		Address:  "localhost",
+		Hostname: "localhost",
+	}))
+	checker.meta.ResourceManager.HandleNodeUp(ctx, 1)
+
+	// dist, the segment loaded another index than the selected one
+	segment := utils.CreateTestSegment(1, 1, 2, 1, 1, "test-insert-channel")
+	segment.IndexInfo = map[int64]*querypb.FieldIndexInfo{101: {
+		FieldID:     101,
+		IndexID:     1000,
+		EnableIndex: true,
+	}}
+	checker.dist.SegmentDistManager.Update(1, segment)
+
+	// broker
+	suite.broker.EXPECT().ListIndexes(mock.Anything, int64(1)).Return([]*indexpb.IndexInfo{
+		{
+			FieldID: 101,
+			IndexID: 1001,
+		},
+	}, nil)
+
+	// the loaded index can't be replaced in place, no update task is created
+	tasks := checker.Check(context.Background())
+	suite.Len(tasks, 0)
+	suite.broker.AssertNotCalled(suite.T(), "GetIndexInfo", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestIndexChecker(t *testing.T) {
 	suite.Run(t, new(IndexCheckerSuite))
 }
@@ -160,6 +160,11 @@ func (dh *distHandler) updateSegmentsDistribution(ctx context.Context, resp *que
 			Version:            s.GetVersion(),
 			LastDeltaTimestamp: s.GetLastDeltaTimestamp(),
 			IndexInfo:          s.GetIndexInfo(),
+			AccessCount:        s.GetAccessCount(),
+			WarmingIndexFields: s.GetWarmingIndexFields(),
+			RowCount:           s.GetRowCount(),
+			RealRowCount:       s.GetRealRowCount(),
+			PKDigest:           s.GetPkDigest(),
 		})
 	}
 
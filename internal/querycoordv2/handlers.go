@@ -19,6 +19,7 @@ package querycoordv2
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -255,7 +256,10 @@ func getMetrics[T any](ctx context.Context, s *Server, req *milvuspb.GetMetricsR
 	for _, node := range s.nodeMgr.GetAll() {
 		node := node
 		errorGroup.Go(func() error {
-			resp, err := s.cluster.GetMetrics(ctx, node.ID(), req)
+			resp, err := metricsinfo.RequestRemoteMetrics(ctx, metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, node.ID()), req,
+				func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+					return s.cluster.GetMetrics(ctx, node.ID(), req)
+				})
 			if err := merr.CheckRPCCall(resp, err); err != nil {
 				log.Warn("failed to get metric from QueryNode", zap.Int64("nodeID", node.ID()))
 				return err
@@ -449,7 +453,10 @@ func (s *Server) tryGetNodesMetrics(ctx context.Context, req *milvuspb.GetMetric
 		go func() {
 			defer wg.Done()
 
-			resp, err := s.cluster.GetMetrics(ctx, node.ID(), req)
+			resp, err := metricsinfo.RequestRemoteMetrics(ctx, metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, node.ID()), req,
+				func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+					return s.cluster.GetMetrics(ctx, node.ID(), req)
+				})
 			if err != nil {
 				log.Warn("failed to get metric from QueryNode",
 					zap.Int64("nodeID", node.ID()))
@@ -524,3 +531,49 @@ func (s *Server) fillReplicaInfo(ctx context.Context, replica *meta.Replica, wit
 	info.ShardReplicas = shardReplicas
 	return info
 }
+
+// checkDatabaseResourceGroups checks whether the collection is allowed to be loaded into the resource groups,
+// if the database of the collection is bound to resource groups, only these resource groups are allowed.
+func (s *Server) checkDatabaseResourceGroups(ctx context.Context, collectionID int64, rgs []string) error {
+	if !paramtable.Get().QueryCoordCfg.EnforceDatabaseResourceGroups.GetAsBool() {
+		return nil
+	}
+
+	collection, err := s.broker.DescribeCollection(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	boundRGs, err := utils.GetDatabaseBoundResourceGroups(ctx, s.broker, collection.GetDbName())
+	if err != nil {
+		return err
+	}
+	if len(boundRGs) == 0 {
+		// database isn't bound to any resource group
+		return nil
+	}
+
+	if len(rgs) == 0 {
+		rgs = []string{meta.DefaultResourceGroupName}
+	}
+	if unbound, _ := lo.Difference(rgs, boundRGs); len(unbound) > 0 {
+		return merr.WrapErrParameterInvalidMsg(fmt.Sprintf("database %s is bound to resource groups %v, collection %d can't be loaded into resource groups %v",
+			collection.GetDbName(), boundRGs, collectionID, unbound))
+	}
+	return nil
+}
+
+// getBoundDatabases returns the databases bound to the resource group, in the order of the database names.
+func (s *Server) getBoundDatabases(ctx context.Context, rgName string) ([]string, error) {
+	bindings, err := utils.GetAllDatabaseBoundResourceGroups(ctx, s.broker)
+	if err != nil {
+		return nil, err
+	}
+	databases := make([]string, 0)
+	for dbName, boundRGs := range bindings {
+		if lo.Contains(boundRGs, rgName) {
+			databases = append(databases, dbName)
+		}
+	}
+	sort.Strings(databases)
+	return databases, nil
+}
@@ -95,6 +95,11 @@ func (job *LoadCollectionJob) PreExecute() error {
 		req.ResourceGroups = []string{meta.DefaultResourceGroupName}
 	}
 
+	if err := checkLoadFields(req.GetSchema(), req.GetLoadFields()); err != nil {
+		log.Warn("invalid load field list", zap.Int64s("loadFields", req.GetLoadFields()), zap.Error(err))
+		return err
+	}
+
 	collection := job.meta.GetCollection(job.ctx, req.GetCollectionID())
 	if collection == nil {
 		return nil
@@ -300,6 +305,11 @@ func (job *LoadPartitionJob) PreExecute() error {
 		req.ResourceGroups = []string{meta.DefaultResourceGroupName}
 	}
 
+	if err := checkLoadFields(req.GetSchema(), req.GetLoadFields()); err != nil {
+		log.Warn("invalid load field list", zap.Int64s("loadFields", req.GetLoadFields()), zap.Error(err))
+		return err
+	}
+
 	collection := job.meta.GetCollection(job.ctx, req.GetCollectionID())
 	if collection == nil {
 		return nil
@@ -422,6 +422,40 @@ func (suite *JobSuite) TestLoadCollectionWithReplicas() {
 func (suite *JobSuite) TestLoadCollectionWithLoadFields() {
 	ctx := context.Background()
 
+	suite.Run("invalid_load_fields", func() {
+		schema := &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+				{FieldID: 101},
+				{FieldID: 102},
+			},
+		}
+		for _, loadFields := range [][]int64{
+			{100, 101, 103}, // unknown field
+			{101, 102},      // without primary key
+		} {
+			req := &querypb.LoadCollectionRequest{
+				CollectionID: suite.collections[0],
+				Schema:       schema,
+				LoadFields:   loadFields,
+			}
+			job := NewLoadCollectionJob(
+				ctx,
+				req,
+				suite.dist,
+				suite.meta,
+				suite.broker,
+				suite.targetMgr,
+				suite.targetObserver,
+				suite.collectionObserver,
+				suite.nodeMgr,
+			)
+			suite.scheduler.Add(job)
+			err := job.Wait()
+			suite.ErrorIs(err, merr.ErrParameterInvalid)
+		}
+	})
+
 	suite.Run("init_load", func() {
 		// Test load collection
 		for _, collection := range suite.collections {
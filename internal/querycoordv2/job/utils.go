@@ -23,10 +23,12 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/checkers"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/observers"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -82,3 +84,29 @@ func waitCurrentTargetUpdated(ctx context.Context, targetObserver *observers.Tar
 		return ctx.Err()
 	}
 }
+
+// checkLoadFields checks the load field list only refers to the fields of the collection schema
+// and contains the primary key field, the check is skipped if the request carries no schema.
+func checkLoadFields(schema *schemapb.CollectionSchema, loadFields []int64) error {
+	if schema == nil || len(loadFields) == 0 {
+		return nil
+	}
+
+	fieldIDs := typeutil.NewSet(lo.Map(schema.GetFields(), func(field *schemapb.FieldSchema, _ int) int64 {
+		return field.GetFieldID()
+	})...)
+	for _, fieldID := range loadFields {
+		if !fieldIDs.Contain(fieldID) {
+			return merr.WrapErrParameterInvalidMsg("load field %d not found in collection schema", fieldID)
+		}
+	}
+
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+	if !lo.Contains(loadFields, pkField.GetFieldID()) {
+		return merr.WrapErrParameterInvalidMsg("load field list %v does not contain primary key field %s", loadFields, pkField.GetName())
+	}
+	return nil
+}
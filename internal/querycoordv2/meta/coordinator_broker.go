@@ -27,6 +27,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/coordinator/coordbroker"
 	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
@@ -51,12 +52,18 @@ type Broker interface {
 	GetIndexInfo(ctx context.Context, collectionID UniqueID, segmentIDs ...UniqueID) (map[int64][]*querypb.FieldIndexInfo, error)
 	GetRecoveryInfoV2(ctx context.Context, collectionID UniqueID, partitionIDs ...UniqueID) ([]*datapb.VchannelInfo, []*datapb.SegmentInfo, error)
 	DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error)
+	// DescribeDatabases describes all the databases in one request.
+	DescribeDatabases(ctx context.Context) ([]*rootcoordpb.DescribeDatabaseResponse, error)
 	GetCollectionLoadInfo(ctx context.Context, collectionID UniqueID) ([]string, int64, error)
+	// InvalidateCollection drops the cached collection info, partitions and indexes of the collections.
+	InvalidateCollection(collectionIDs ...UniqueID)
 }
 
 type CoordinatorBroker struct {
 	dataCoord types.DataCoordClient
 	rootCoord types.RootCoordClient
+	// cached collection, partition and index lookups
+	shared coordbroker.Broker
 }
 
 func NewCoordinatorBroker(
@@ -64,46 +71,49 @@ func NewCoordinatorBroker(
 	rootCoord types.RootCoordClient,
 ) *CoordinatorBroker {
 	return &CoordinatorBroker{
-		dataCoord,
-		rootCoord,
+		dataCoord: dataCoord,
+		rootCoord: rootCoord,
+		shared:    coordbroker.NewCoordinatorBroker(rootCoord, dataCoord),
 	}
 }
 
 func (broker *CoordinatorBroker) DescribeCollection(ctx context.Context, collectionID UniqueID) (*milvuspb.DescribeCollectionResponse, error) {
+	return broker.shared.DescribeCollection(ctx, collectionID)
+}
+
+func (broker *CoordinatorBroker) DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
 	defer cancel()
 
-	req := &milvuspb.DescribeCollectionRequest{
+	req := &rootcoordpb.DescribeDatabaseRequest{
 		Base: commonpbutil.NewMsgBase(
 			commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection),
 		),
-		// please do not specify the collection name alone after database feature.
-		CollectionID: collectionID,
+		DbName: dbName,
 	}
-	resp, err := broker.rootCoord.DescribeCollection(ctx, req)
+	resp, err := broker.rootCoord.DescribeDatabase(ctx, req)
 	if err := merr.CheckRPCCall(resp, err); err != nil {
-		log.Ctx(ctx).Warn("failed to get collection schema", zap.Error(err))
+		log.Ctx(ctx).Warn("failed to describe database", zap.Error(err))
 		return nil, err
 	}
 	return resp, nil
 }
 
-func (broker *CoordinatorBroker) DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
+func (broker *CoordinatorBroker) DescribeDatabases(ctx context.Context) ([]*rootcoordpb.DescribeDatabaseResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
 	defer cancel()
 
-	req := &rootcoordpb.DescribeDatabaseRequest{
+	req := &rootcoordpb.DescribeDatabasesRequest{
 		Base: commonpbutil.NewMsgBase(
-			commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection),
+			commonpbutil.WithMsgType(commonpb.MsgType_ListDatabases),
 		),
-		DbName: dbName,
 	}
-	resp, err := broker.rootCoord.DescribeDatabase(ctx, req)
+	resp, err := broker.rootCoord.DescribeDatabases(ctx, req)
 	if err := merr.CheckRPCCall(resp, err); err != nil {
-		log.Ctx(ctx).Warn("failed to describe database", zap.Error(err))
+		log.Ctx(ctx).Warn("failed to describe databases", zap.Error(err))
 		return nil, err
 	}
-	return resp, nil
+	return resp.GetDatabases(), nil
 }
 
 // try to get database level replica_num and resource groups, return (resource_groups, replica_num, error)
@@ -172,23 +182,7 @@ func (broker *CoordinatorBroker) GetCollectionLoadInfo(ctx context.Context, coll
 }
 
 func (broker *CoordinatorBroker) GetPartitions(ctx context.Context, collectionID UniqueID) ([]UniqueID, error) {
-	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
-	defer cancel()
-	log := log.Ctx(ctx).With(zap.Int64("collectionID", collectionID))
-	req := &milvuspb.ShowPartitionsRequest{
-		Base: commonpbutil.NewMsgBase(
-			commonpbutil.WithMsgType(commonpb.MsgType_ShowPartitions),
-		),
-		// please do not specify the collection name alone after database feature.
-		CollectionID: collectionID,
-	}
-	resp, err := broker.rootCoord.ShowPartitions(ctx, req)
-	if err := merr.CheckRPCCall(resp, err); err != nil {
-		log.Warn("failed to get partitions", zap.Error(err))
-		return nil, err
-	}
-
-	return resp.PartitionIDs, nil
+	return broker.shared.ShowPartitions(ctx, collectionID)
 }
 
 func (broker *CoordinatorBroker) GetRecoveryInfo(ctx context.Context, collectionID UniqueID, partitionID UniqueID) ([]*datapb.VchannelInfo, []*datapb.SegmentBinlogs, error) {
@@ -366,51 +360,10 @@ func (broker *CoordinatorBroker) GetIndexInfo(ctx context.Context, collectionID
 	return indexes, nil
 }
 
-func (broker *CoordinatorBroker) describeIndex(ctx context.Context, collectionID UniqueID) ([]*indexpb.IndexInfo, error) {
-	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
-	defer cancel()
-
-	// during rolling upgrade, query coord may connect to datacoord with version 2.2, which will return merr.ErrServiceUnimplemented
-	// we add retry here to retry the request until context done, and if new data coord start up, it will success
-	var resp *indexpb.DescribeIndexResponse
-	var err error
-	retry.Do(ctx, func() error {
-		resp, err = broker.dataCoord.DescribeIndex(ctx, &indexpb.DescribeIndexRequest{
-			CollectionID: collectionID,
-		})
-		if errors.Is(err, merr.ErrServiceUnimplemented) {
-			return err
-		}
-		return nil
-	})
-
-	if err := merr.CheckRPCCall(resp, err); err != nil {
-		log.Error("failed to fetch index meta",
-			zap.Int64("collection", collectionID),
-			zap.Error(err))
-		return nil, err
-	}
-	return resp.GetIndexInfos(), nil
-}
-
 func (broker *CoordinatorBroker) ListIndexes(ctx context.Context, collectionID UniqueID) ([]*indexpb.IndexInfo, error) {
-	log := log.Ctx(ctx).With(zap.Int64("collectionID", collectionID))
-	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
-	defer cancel()
-
-	resp, err := broker.dataCoord.ListIndexes(ctx, &indexpb.ListIndexesRequest{
-		CollectionID: collectionID,
-	})
-
-	err = merr.CheckRPCCall(resp, err)
-	if err != nil {
-		if errors.Is(err, merr.ErrServiceUnimplemented) {
-			log.Warn("datacoord does not implement ListIndex API fallback to DescribeIndex")
-			return broker.describeIndex(ctx, collectionID)
-		}
-		log.Warn("failed to fetch index meta", zap.Error(err))
-		return nil, err
-	}
+	return broker.shared.ListIndexes(ctx, collectionID)
+}
 
-	return resp.GetIndexInfos(), nil
+func (broker *CoordinatorBroker) InvalidateCollection(collectionIDs ...UniqueID) {
+	broker.shared.InvalidateCollection(collectionIDs...)
 }
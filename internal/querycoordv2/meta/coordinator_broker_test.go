@@ -48,6 +48,12 @@ type CoordinatorBrokerRootCoordSuite struct {
 
 func (s *CoordinatorBrokerRootCoordSuite) SetupSuite() {
 	paramtable.Init()
+	// every case mocks its own response
+	paramtable.Get().Save(paramtable.Get().CommonCfg.BrokerCacheTTL.Key, "0")
+}
+
+func (s *CoordinatorBrokerRootCoordSuite) TearDownSuite() {
+	paramtable.Get().Reset(paramtable.Get().CommonCfg.BrokerCacheTTL.Key)
 }
 
 func (s *CoordinatorBrokerRootCoordSuite) SetupTest() {
@@ -141,6 +147,12 @@ type CoordinatorBrokerDataCoordSuite struct {
 
 func (s *CoordinatorBrokerDataCoordSuite) SetupSuite() {
 	paramtable.Init()
+	// every case mocks its own response
+	paramtable.Get().Save(paramtable.Get().CommonCfg.BrokerCacheTTL.Key, "0")
+}
+
+func (s *CoordinatorBrokerDataCoordSuite) TearDownSuite() {
+	paramtable.Get().Reset(paramtable.Get().CommonCfg.BrokerCacheTTL.Key)
 }
 
 func (s *CoordinatorBrokerDataCoordSuite) SetupTest() {
@@ -259,67 +271,6 @@ func (s *CoordinatorBrokerDataCoordSuite) TestGetRecoveryInfoV2() {
 	})
 }
 
-func (s *CoordinatorBrokerDataCoordSuite) TestDescribeIndex() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	collectionID := int64(100)
-
-	s.Run("normal_case", func() {
-		indexIDs := []int64{1, 2}
-		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
-			Return(&indexpb.DescribeIndexResponse{
-				Status: merr.Status(nil),
-				IndexInfos: lo.Map(indexIDs, func(id int64, _ int) *indexpb.IndexInfo {
-					return &indexpb.IndexInfo{IndexID: id}
-				}),
-			}, nil)
-		infos, err := s.broker.describeIndex(ctx, collectionID)
-		s.NoError(err)
-		s.ElementsMatch(indexIDs, lo.Map(infos, func(info *indexpb.IndexInfo, _ int) int64 { return info.GetIndexID() }))
-		s.resetMock()
-	})
-
-	s.Run("datacoord_return_error", func() {
-		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
-			Return(nil, errors.New("mock"))
-
-		_, err := s.broker.describeIndex(ctx, collectionID)
-		s.Error(err)
-		s.resetMock()
-	})
-
-	s.Run("datacoord_return_failure_status", func() {
-		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
-			Return(&indexpb.DescribeIndexResponse{
-				Status: merr.Status(errors.New("mocked")),
-			}, nil)
-
-		_, err := s.broker.describeIndex(ctx, collectionID)
-		s.Error(err)
-		s.resetMock()
-	})
-
-	s.Run("datacoord_return_unimplemented", func() {
-		// mock old version datacoord return unimplemented
-		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
-			Return(nil, merr.ErrServiceUnimplemented).Times(1)
-
-		// mock retry on new version datacoord return success
-		indexIDs := []int64{1, 2}
-		s.datacoord.EXPECT().DescribeIndex(mock.Anything, mock.Anything).
-			Return(&indexpb.DescribeIndexResponse{
-				Status: merr.Status(nil),
-				IndexInfos: lo.Map(indexIDs, func(id int64, _ int) *indexpb.IndexInfo {
-					return &indexpb.IndexInfo{IndexID: id}
-				}),
-			}, nil)
-
-		_, err := s.broker.describeIndex(ctx, collectionID)
-		s.NoError(err)
-		s.resetMock()
-	})
-}
-
 func (s *CoordinatorBrokerDataCoordSuite) TestListIndexes() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -532,6 +483,34 @@ func (s *CoordinatorBrokerRootCoordSuite) TestDescribeDatabase() {
 	})
 }
 
+func (s *CoordinatorBrokerRootCoordSuite) TestDescribeDatabases() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Run("normal_case", func() {
+		s.rootcoord.EXPECT().DescribeDatabases(mock.Anything, mock.Anything).
+			Return(&rootcoordpb.DescribeDatabasesResponse{
+				Status: merr.Success(),
+				Databases: []*rootcoordpb.DescribeDatabaseResponse{
+					{DbName: "default"},
+					{DbName: "fake_db1"},
+				},
+			}, nil)
+		databases, err := s.broker.DescribeDatabases(ctx)
+		s.NoError(err)
+		s.Len(databases, 2)
+		s.Equal("fake_db1", databases[1].GetDbName())
+		s.resetMock()
+	})
+
+	s.Run("rootcoord_return_error", func() {
+		s.rootcoord.EXPECT().DescribeDatabases(mock.Anything, mock.Anything).Return(nil, errors.New("fake error"))
+		_, err := s.broker.DescribeDatabases(ctx)
+		s.Error(err)
+		s.resetMock()
+	})
+}
+
 func (s *CoordinatorBrokerRootCoordSuite) TestGetCollectionLoadInfo() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
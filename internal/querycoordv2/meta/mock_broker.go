@@ -148,6 +148,64 @@ func (_c *MockBroker_DescribeDatabase_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// DescribeDatabases provides a mock function with given fields: ctx
+func (_m *MockBroker) DescribeDatabases(ctx context.Context) ([]*rootcoordpb.DescribeDatabaseResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeDatabases")
+	}
+
+	var r0 []*rootcoordpb.DescribeDatabaseResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*rootcoordpb.DescribeDatabaseResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*rootcoordpb.DescribeDatabaseResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*rootcoordpb.DescribeDatabaseResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBroker_DescribeDatabases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeDatabases'
+type MockBroker_DescribeDatabases_Call struct {
+	*mock.Call
+}
+
+// DescribeDatabases is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBroker_Expecter) DescribeDatabases(ctx interface{}) *MockBroker_DescribeDatabases_Call {
+	return &MockBroker_DescribeDatabases_Call{Call: _e.mock.On("DescribeDatabases", ctx)}
+}
+
+func (_c *MockBroker_DescribeDatabases_Call) Run(run func(ctx context.Context)) *MockBroker_DescribeDatabases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockBroker_DescribeDatabases_Call) Return(_a0 []*rootcoordpb.DescribeDatabaseResponse, _a1 error) *MockBroker_DescribeDatabases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBroker_DescribeDatabases_Call) RunAndReturn(run func(context.Context) ([]*rootcoordpb.DescribeDatabaseResponse, error)) *MockBroker_DescribeDatabases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCollectionLoadInfo provides a mock function with given fields: ctx, collectionID
 func (_m *MockBroker) GetCollectionLoadInfo(ctx context.Context, collectionID int64) ([]string, int64, error) {
 	ret := _m.Called(ctx, collectionID)
@@ -572,6 +630,52 @@ func (_c *MockBroker_GetSegmentInfo_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// InvalidateCollection provides a mock function with given fields: collectionIDs
+func (_m *MockBroker) InvalidateCollection(collectionIDs ...int64) {
+	_va := make([]interface{}, len(collectionIDs))
+	for _i := range collectionIDs {
+		_va[_i] = collectionIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// MockBroker_InvalidateCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateCollection'
+type MockBroker_InvalidateCollection_Call struct {
+	*mock.Call
+}
+
+// InvalidateCollection is a helper method to define mock.On call
+//   - collectionIDs ...int64
+func (_e *MockBroker_Expecter) InvalidateCollection(collectionIDs ...interface{}) *MockBroker_InvalidateCollection_Call {
+	return &MockBroker_InvalidateCollection_Call{Call: _e.mock.On("InvalidateCollection",
+		append([]interface{}{}, collectionIDs...)...)}
+}
+
+func (_c *MockBroker_InvalidateCollection_Call) Run(run func(collectionIDs ...int64)) *MockBroker_InvalidateCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]int64, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(int64)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockBroker_InvalidateCollection_Call) Return() *MockBroker_InvalidateCollection_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBroker_InvalidateCollection_Call) RunAndReturn(run func(...int64)) *MockBroker_InvalidateCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListIndexes provides a mock function with given fields: ctx, collectionID
 func (_m *MockBroker) ListIndexes(ctx context.Context, collectionID int64) ([]*indexpb.IndexInfo, error) {
 	ret := _m.Called(ctx, collectionID)
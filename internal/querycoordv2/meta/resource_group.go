@@ -2,11 +2,14 @@ package meta
 
 import (
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -98,7 +101,15 @@ func (rg *ResourceGroup) GetConfigCloned() *rgpb.ResourceGroupConfig {
 func (rg *ResourceGroup) GetNodes() []int64 {
 	requiredNodeLabels := rg.GetConfig().GetNodeFilter().GetNodeLabels()
 	if len(requiredNodeLabels) == 0 {
-		return rg.nodes.Collect()
+		// read-only nodes may be parked in resource groups without node filter, but never serve them
+		ret := make([]int64, 0, rg.nodes.Len())
+		rg.nodes.Range(func(nodeID int64) bool {
+			if nodeInfo := rg.nodeMgr.Get(nodeID); nodeInfo == nil || !nodeInfo.IsReadOnly() {
+				ret = append(ret, nodeID)
+			}
+			return true
+		})
+		return ret
 	}
 
 	ret := make([]int64, 0)
@@ -193,16 +204,23 @@ func (rg *ResourceGroup) SelectNodeForRG(targetRG *ResourceGroup) int64 {
 
 // return node and priority.
 func (rg *ResourceGroup) AcceptNode(nodeID int64) bool {
+	nodeInfo := rg.nodeMgr.Get(nodeID)
+	requiredNodeLabels := rg.GetConfig().GetNodeFilter().GetNodeLabels()
+	// read-only nodes only serve the resource groups requiring the read-only label explicitly
+	if nodeInfo != nil && nodeInfo.IsReadOnly() && !lo.ContainsBy(requiredNodeLabels, func(label *commonpb.KeyValuePair) bool {
+		return label.GetKey() == sessionutil.LabelReadOnly
+	}) {
+		return false
+	}
+
 	if rg.GetName() == DefaultResourceGroupName {
 		return true
 	}
 
-	nodeInfo := rg.nodeMgr.Get(nodeID)
 	if nodeInfo == nil {
 		return false
 	}
 
-	requiredNodeLabels := rg.GetConfig().GetNodeFilter().GetNodeLabels()
 	if len(requiredNodeLabels) == 0 {
 		return true
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -451,3 +452,41 @@ func TestRGNodeFilter(t *testing.T) {
 	}, nodeMgr)
 	assert.Equal(t, rg.SelectNodeForRG(rg3), int64(-1))
 }
+
+func TestRGReadOnlyNode(t *testing.T) {
+	nodeMgr := session.NewNodeManager()
+	nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+		NodeID: 1,
+	}))
+	nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+		NodeID: 2,
+		Labels: map[string]string{
+			sessionutil.LabelReadOnly: "true",
+		},
+	}))
+
+	defaultRG := NewResourceGroup(DefaultResourceGroupName, newResourceGroupConfig(0, defaultResourceGroupCapacity), nodeMgr)
+	defaultRG.nodes = typeutil.NewSet[int64](1, 2)
+	assert.True(t, defaultRG.AcceptNode(1))
+	assert.False(t, defaultRG.AcceptNode(2))
+	assert.ElementsMatch(t, []int64{1}, defaultRG.GetNodes())
+
+	readOnlyRG := NewResourceGroup("reader", &rgpb.ResourceGroupConfig{
+		Requests: &rgpb.ResourceGroupLimit{
+			NodeNum: 1,
+		},
+		Limits: &rgpb.ResourceGroupLimit{
+			NodeNum: 1,
+		},
+		NodeFilter: &rgpb.ResourceGroupNodeFilter{
+			NodeLabels: []*commonpb.KeyValuePair{
+				{
+					Key:   sessionutil.LabelReadOnly,
+					Value: "true",
+				},
+			},
+		},
+	}, nodeMgr)
+	assert.False(t, readOnlyRG.AcceptNode(1))
+	assert.True(t, readOnlyRG.AcceptNode(2))
+}
@@ -125,6 +125,11 @@ type Segment struct {
 	Version            int64                             // Version is the timestamp of loading segment
 	LastDeltaTimestamp uint64                            // The timestamp of the last delta record
 	IndexInfo          map[int64]*querypb.FieldIndexInfo // index info of loaded segment
+	AccessCount        int64                             // The number of search/query requests served since loaded
+	WarmingIndexFields []int64                           // The fields whose indexes are loading in the background
+	RowCount           int64                             // The number of rows in segcore, the deleted ones included
+	RealRowCount       int64                             // The number of rows in segcore, the deleted ones excluded
+	PKDigest           uint64                            // The digest of the primary key binlogs loaded
 }
 
 func SegmentFromInfo(info *datapb.SegmentInfo) *Segment {
@@ -143,7 +148,7 @@ func newSegmentMetricsFrom(segment *Segment) *metricsinfo.Segment {
 			IndexID:      e.IndexID,
 			BuildID:      e.BuildID,
 			IndexSize:    e.IndexSize,
-			IsLoaded:     true,
+			IsLoaded:     !lo.Contains(segment.WarmingIndexFields, e.FieldID),
 		}
 	})
 	return convertedSegment
@@ -151,9 +156,14 @@ func newSegmentMetricsFrom(segment *Segment) *metricsinfo.Segment {
 
 func (segment *Segment) Clone() *Segment {
 	return &Segment{
-		SegmentInfo: proto.Clone(segment.SegmentInfo).(*datapb.SegmentInfo),
-		Node:        segment.Node,
-		Version:     segment.Version,
+		SegmentInfo:        proto.Clone(segment.SegmentInfo).(*datapb.SegmentInfo),
+		Node:               segment.Node,
+		Version:            segment.Version,
+		AccessCount:        segment.AccessCount,
+		WarmingIndexFields: segment.WarmingIndexFields,
+		RowCount:           segment.RowCount,
+		RealRowCount:       segment.RealRowCount,
+		PKDigest:           segment.PKDigest,
 	}
 }
 
@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// DatabaseResourceGroupObserver moves the replicas out of the resource groups their database isn't bound to,
+// so the replicas loaded before the database binding changes are corrected.
+// Node changes of the moved replicas are executed by the replica observer in background.
+type DatabaseResourceGroupObserver struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	meta   *meta.Meta
+	broker meta.Broker
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func NewDatabaseResourceGroupObserver(meta *meta.Meta, broker meta.Broker) *DatabaseResourceGroupObserver {
+	return &DatabaseResourceGroupObserver{
+		meta:   meta,
+		broker: broker,
+	}
+}
+
+func (ob *DatabaseResourceGroupObserver) Start() {
+	ob.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		ob.cancel = cancel
+
+		ob.wg.Add(1)
+		go ob.schedule(ctx)
+	})
+}
+
+func (ob *DatabaseResourceGroupObserver) Stop() {
+	ob.stopOnce.Do(func() {
+		if ob.cancel != nil {
+			ob.cancel()
+		}
+		ob.wg.Wait()
+	})
+}
+
+func (ob *DatabaseResourceGroupObserver) schedule(ctx context.Context) {
+	defer ob.wg.Done()
+	log.Info("Start database resource group observer")
+
+	ticker := time.NewTicker(params.Params.QueryCoordCfg.CheckResourceGroupInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Close database resource group observer")
+			return
+		case <-ticker.C:
+			if params.Params.QueryCoordCfg.EnforceDatabaseResourceGroups.GetAsBool() {
+				ob.checkAndMoveReplicas(ctx)
+			}
+		}
+	}
+}
+
+func (ob *DatabaseResourceGroupObserver) checkAndMoveReplicas(ctx context.Context) {
+	// the bindings of all the databases, fetched once per round
+	boundRGs, err := utils.GetAllDatabaseBoundResourceGroups(ctx, ob.broker)
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to get resource groups bound to databases", zap.Error(err))
+		return
+	}
+	if len(boundRGs) == 0 {
+		return
+	}
+	for _, collectionID := range ob.meta.CollectionManager.GetAll(ctx) {
+		log := log.Ctx(ctx).With(zap.Int64("collectionID", collectionID))
+		collection, err := ob.broker.DescribeCollection(ctx, collectionID)
+		if err != nil {
+			log.Warn("failed to describe collection", zap.Error(err))
+			continue
+		}
+		rgs := boundRGs[collection.GetDbName()]
+		ob.moveReplicas(ctx, collectionID, lo.Filter(rgs, func(rg string, _ int) bool {
+			return ob.meta.ResourceManager.ContainResourceGroup(ctx, rg)
+		}))
+	}
+}
+
+// moveReplicas moves the replicas of the collection out of the resource groups not in boundRGs,
+// each replica is moved into the bound resource group holding the fewest replicas of the collection.
+func (ob *DatabaseResourceGroupObserver) moveReplicas(ctx context.Context, collectionID int64, boundRGs []string) {
+	if len(boundRGs) == 0 {
+		return
+	}
+	replicas := ob.meta.ReplicaManager.GetByCollection(ctx, collectionID)
+	replicaNum := lo.SliceToMap(boundRGs, func(rg string) (string, int) {
+		return rg, 0
+	})
+	toMove := make([]*meta.Replica, 0)
+	for _, replica := range replicas {
+		if _, ok := replicaNum[replica.GetResourceGroup()]; ok {
+			replicaNum[replica.GetResourceGroup()]++
+		} else {
+			toMove = append(toMove, replica)
+		}
+	}
+
+	for _, replica := range toMove {
+		dstRG := lo.MinBy(boundRGs, func(a, b string) bool {
+			return replicaNum[a] < replicaNum[b]
+		})
+		if err := ob.meta.ReplicaManager.MoveReplica(ctx, dstRG, []*meta.Replica{replica}); err != nil {
+			log.Ctx(ctx).Warn("failed to move replica into resource group bound to database",
+				zap.Int64("collectionID", collectionID),
+				zap.Int64("replicaID", replica.GetID()),
+				zap.String("srcRG", replica.GetResourceGroup()),
+				zap.String("dstRG", dstRG),
+				zap.Error(err))
+			continue
+		}
+		log.Ctx(ctx).Info("move replica into resource group bound to database",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64("replicaID", replica.GetID()),
+			zap.String("srcRG", replica.GetResourceGroup()),
+			zap.String("dstRG", dstRG))
+		replicaNum[dstRG]++
+	}
+}
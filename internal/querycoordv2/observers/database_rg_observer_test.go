@@ -0,0 +1,123 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/etcd"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type DatabaseResourceGroupObserverSuite struct {
+	suite.Suite
+
+	kv       kv.MetaKv
+	meta     *meta.Meta
+	broker   *meta.MockBroker
+	observer *DatabaseResourceGroupObserver
+	ctx      context.Context
+}
+
+func (suite *DatabaseResourceGroupObserverSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *DatabaseResourceGroupObserverSuite) SetupTest() {
+	config := GenerateEtcdConfig()
+	cli, err := etcd.GetEtcdClient(
+		config.UseEmbedEtcd.GetAsBool(),
+		config.EtcdUseSSL.GetAsBool(),
+		config.Endpoints.GetAsStrings(),
+		config.EtcdTLSCert.GetValue(),
+		config.EtcdTLSKey.GetValue(),
+		config.EtcdTLSCACert.GetValue(),
+		config.EtcdTLSMinVersion.GetValue())
+	suite.Require().NoError(err)
+	suite.kv = etcdkv.NewEtcdKV(cli, config.MetaRootPath.GetValue())
+	suite.ctx = context.Background()
+
+	store := querycoord.NewCatalog(suite.kv)
+	suite.meta = meta.NewMeta(RandomIncrementIDAllocator(), store, session.NewNodeManager())
+	suite.broker = meta.NewMockBroker(suite.T())
+	suite.observer = NewDatabaseResourceGroupObserver(suite.meta, suite.broker)
+}
+
+func (suite *DatabaseResourceGroupObserverSuite) TearDownTest() {
+	suite.kv.RemoveWithPrefix(suite.ctx, "")
+	suite.kv.Close()
+}
+
+func (suite *DatabaseResourceGroupObserverSuite) TestMoveReplicas() {
+	ctx := suite.ctx
+	for _, rg := range []string{"rg1", "rg2", "rg3"} {
+		suite.NoError(suite.meta.ResourceManager.AddResourceGroup(ctx, rg, &rgpb.ResourceGroupConfig{}))
+	}
+	suite.NoError(suite.meta.CollectionManager.PutCollection(ctx, utils.CreateTestCollection(1000, 3)))
+	_, err := suite.meta.Spawn(ctx, 1000, map[string]int{"rg1": 1, "rg3": 2}, nil)
+	suite.NoError(err)
+
+	suite.broker.EXPECT().DescribeCollection(mock.Anything, int64(1000)).
+		Return(&milvuspb.DescribeCollectionResponse{DbName: "db1"}, nil)
+	suite.broker.EXPECT().DescribeDatabases(mock.Anything).
+		Return([]*rootcoordpb.DescribeDatabaseResponse{
+			{
+				DbName: "db1",
+				Properties: []*commonpb.KeyValuePair{
+					{Key: common.DatabaseResourceGroups, Value: "rg1,rg2,rg4"},
+				},
+			},
+			// the invalid binding of another database doesn't block the others
+			{
+				DbName: "db2",
+				Properties: []*commonpb.KeyValuePair{
+					{Key: common.DatabaseResourceGroups, Value: ""},
+				},
+			},
+		}, nil)
+
+	suite.observer.checkAndMoveReplicas(ctx)
+
+	// replicas in rg3 are spread over the bound resource groups, rg4 doesn't exist
+	replicas := suite.meta.ReplicaManager.GetByCollection(ctx, 1000)
+	suite.Len(replicas, 3)
+	rgs := make(map[string]int)
+	for _, replica := range replicas {
+		rgs[replica.GetResourceGroup()]++
+	}
+	suite.Equal(map[string]int{"rg1": 2, "rg2": 1}, rgs)
+}
+
+func TestDatabaseResourceGroupObserver(t *testing.T) {
+	suite.Run(t, new(DatabaseResourceGroupObserverSuite))
+}
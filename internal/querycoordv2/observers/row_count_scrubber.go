@@ -0,0 +1,223 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/segmentutil"
+	"github.com/milvus-io/milvus/pkg/eventlog"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// the sources of the row counts compared with the binlogs
+const (
+	rowCountSourceMeta        = "meta"
+	rowCountSourceStats       = "bloom_filter_stats"
+	rowCountSourceSegcore     = "segcore"
+	rowCountSourceSegcoreReal = "segcore_real"
+)
+
+func formatDiscrepancy(d *metricsinfo.RowCountDiscrepancy) string {
+	return fmt.Sprintf("segment %d[%d] row count of %s on node %d is %d, expected %d",
+		d.SegmentID, d.CollectionID, d.Source, d.NodeID, d.Actual, d.Expected)
+}
+
+// RowCountScrubber cross-checks the row counts of the loaded sealed segments periodically,
+// the ones of the datacoord meta, the bloom filter stats and segcore of the querynodes
+// against the binlogs, to catch the silent data loss early.
+type RowCountScrubber struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	dist   *meta.DistributionManager
+	broker meta.Broker
+
+	report *atomic.Pointer[metricsinfo.RowCountScrubReport]
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func NewRowCountScrubber(dist *meta.DistributionManager, broker meta.Broker) *RowCountScrubber {
+	return &RowCountScrubber{
+		dist:   dist,
+		broker: broker,
+		report: atomic.NewPointer[metricsinfo.RowCountScrubReport](nil),
+	}
+}
+
+func (ob *RowCountScrubber) Start() {
+	ob.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		ob.cancel = cancel
+
+		ob.wg.Add(1)
+		go ob.schedule(ctx)
+	})
+}
+
+func (ob *RowCountScrubber) Stop() {
+	ob.stopOnce.Do(func() {
+		if ob.cancel != nil {
+			ob.cancel()
+		}
+		ob.wg.Wait()
+	})
+}
+
+func (ob *RowCountScrubber) schedule(ctx context.Context) {
+	defer ob.wg.Done()
+	log.Info("Start row count scrubber")
+
+	ticker := time.NewTicker(params.Params.QueryCoordCfg.RowCountScrubInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Stop row count scrubber")
+			return
+		case <-ticker.C:
+			if params.Params.QueryCoordCfg.RowCountScrubEnabled.GetAsBool() {
+				ob.scrub(ctx)
+			}
+		}
+	}
+}
+
+func (ob *RowCountScrubber) scrub(ctx context.Context) {
+	// the L0 segments hold the deletes only
+	segments := lo.Filter(ob.dist.SegmentDistManager.GetByFilter(), func(segment *meta.Segment, _ int) bool {
+		return segment.GetLevel() != datapb.SegmentLevel_L0
+	})
+	segmentIDs := lo.Uniq(lo.Map(segments, func(segment *meta.Segment, _ int) int64 {
+		return segment.GetID()
+	}))
+	var infos []*datapb.SegmentInfo
+	if len(segmentIDs) > 0 {
+		var err error
+		infos, err = ob.broker.GetSegmentInfo(ctx, segmentIDs...)
+		if err != nil {
+			log.Warn("failed to get segment info for row count scrubbing", zap.Error(err))
+			return
+		}
+	}
+
+	discrepancies := checkRowCounts(infos, lo.GroupBy(segments, func(segment *meta.Segment) int64 {
+		return segment.GetID()
+	}))
+	for _, d := range discrepancies {
+		log.Warn("row count discrepancy found",
+			zap.Int64("collectionID", d.CollectionID),
+			zap.Int64("segmentID", d.SegmentID),
+			zap.Int64("nodeID", d.NodeID),
+			zap.String("source", d.Source),
+			zap.Int64("expected", d.Expected),
+			zap.Int64("actual", d.Actual))
+		eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Warn, formatDiscrepancy(d)))
+	}
+	log.Info("row count scrubbing done", zap.Int("segmentNum", len(segmentIDs)), zap.Int("discrepancyNum", len(discrepancies)))
+	ob.report.Store(&metricsinfo.RowCountScrubReport{
+		Time:          typeutil.TimestampToString(uint64(time.Now().UnixMilli())),
+		SegmentNum:    len(segmentIDs),
+		Discrepancies: discrepancies,
+	})
+}
+
+// checkRowCounts compares the row counts of the segments with their binlogs,
+// the segments without binlogs in the meta are skipped.
+func checkRowCounts(infos []*datapb.SegmentInfo, loaded map[int64][]*meta.Segment) []*metricsinfo.RowCountDiscrepancy {
+	discrepancies := make([]*metricsinfo.RowCountDiscrepancy, 0)
+	for _, info := range infos {
+		expected := segmentutil.CalcRowCountFromBinLog(info)
+		if expected <= 0 {
+			continue
+		}
+		newDiscrepancy := func(nodeID int64, source string, expected, actual int64) *metricsinfo.RowCountDiscrepancy {
+			return &metricsinfo.RowCountDiscrepancy{
+				CollectionID: info.GetCollectionID(),
+				SegmentID:    info.GetID(),
+				NodeID:       nodeID,
+				Source:       source,
+				Expected:     expected,
+				Actual:       actual,
+			}
+		}
+
+		if info.GetNumOfRows() != expected {
+			discrepancies = append(discrepancies, newDiscrepancy(0, rowCountSourceMeta, expected, info.GetNumOfRows()))
+		}
+		if stats := statsRowCount(info); stats > 0 && stats != expected {
+			discrepancies = append(discrepancies, newDiscrepancy(0, rowCountSourceStats, expected, stats))
+		}
+		for _, segment := range loaded[info.GetID()] {
+			// the row counts are unknown if the data not loaded, e.g. lazy load
+			if segment.RowCount == 0 {
+				continue
+			}
+			if segment.RowCount != expected {
+				discrepancies = append(discrepancies, newDiscrepancy(segment.Node, rowCountSourceSegcore, expected, segment.RowCount))
+			}
+			if segment.RealRowCount > segment.RowCount {
+				discrepancies = append(discrepancies, newDiscrepancy(segment.Node, rowCountSourceSegcoreReal, segment.RowCount, segment.RealRowCount))
+			}
+		}
+	}
+	return discrepancies
+}
+
+// statsRowCount returns the number of rows of the primary key stats logs,
+// the compound stats log holds the stats of all the rows merged if any.
+func statsRowCount(info *datapb.SegmentInfo) int64 {
+	var rowCount int64
+	for _, field := range info.GetStatslogs() {
+		for _, binlog := range field.GetBinlogs() {
+			if binlog.GetLogID() == int64(storage.CompoundStatsType) {
+				return binlog.GetEntriesNum()
+			}
+			rowCount += binlog.GetEntriesNum()
+		}
+	}
+	return rowCount
+}
+
+// GetReportJSON returns the report of the latest round of the row count scrubbing, empty if never done.
+func (ob *RowCountScrubber) GetReportJSON() string {
+	report := ob.report.Load()
+	if report == nil {
+		return ""
+	}
+	bytes, err := json.Marshal(report)
+	if err != nil {
+		log.Warn("failed to marshal row count scrub report", zap.Error(err))
+		return ""
+	}
+	return string(bytes)
+}
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestRowCountScrubber(t *testing.T) {
+	paramtable.Init()
+
+	newInfo := func(segmentID, numOfRows int64, binlogRows []int64, statsRows ...int64) *datapb.SegmentInfo {
+		info := utils.CreateTestSegmentInfo(1, 1, segmentID, "channel")
+		info.NumOfRows = numOfRows
+		binlogs := &datapb.FieldBinlog{FieldID: 100}
+		for _, rows := range binlogRows {
+			binlogs.Binlogs = append(binlogs.Binlogs, &datapb.Binlog{EntriesNum: rows})
+		}
+		info.Binlogs = []*datapb.FieldBinlog{binlogs}
+		stats := &datapb.FieldBinlog{FieldID: 100}
+		for i, rows := range statsRows {
+			stats.Binlogs = append(stats.Binlogs, &datapb.Binlog{LogID: int64(i + 10), EntriesNum: rows})
+		}
+		info.Statslogs = []*datapb.FieldBinlog{stats}
+		return info
+	}
+	withRowCounts := func(segment *meta.Segment, rowCount, realRowCount int64) *meta.Segment {
+		segment.RowCount = rowCount
+		segment.RealRowCount = realRowCount
+		return segment
+	}
+
+	dist := meta.NewDistributionManager()
+	dist.SegmentDistManager.Update(1,
+		withRowCounts(utils.CreateTestSegment(1, 1, 1, 1, 1, "channel"), 100, 90),
+		withRowCounts(utils.CreateTestSegment(1, 1, 2, 1, 1, "channel"), 0, 0),
+		withRowCounts(utils.CreateTestSegment(1, 1, 3, 1, 1, "channel"), 100, 100),
+	)
+	dist.SegmentDistManager.Update(2,
+		withRowCounts(utils.CreateTestSegment(1, 1, 1, 2, 1, "channel"), 80, 90),
+	)
+
+	compound := newInfo(3, 100, []int64{60, 40}, 60, 40, 90)
+	compound.Statslogs[0].Binlogs[2].LogID = int64(storage.CompoundStatsType)
+	broker := meta.NewMockBroker(t)
+	broker.EXPECT().GetSegmentInfo(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*datapb.SegmentInfo{
+		// consistent
+		newInfo(1, 100, []int64{60, 40}, 60, 40),
+		// the segment not loaded, the meta row count differs from the binlogs
+		newInfo(2, 50, []int64{60}),
+		// the compound stats log differs from the binlogs
+		compound,
+	}, nil)
+
+	ob := NewRowCountScrubber(dist, broker)
+	assert.Empty(t, ob.GetReportJSON())
+	ob.scrub(context.Background())
+
+	report := &metricsinfo.RowCountScrubReport{}
+	require.NoError(t, json.Unmarshal([]byte(ob.GetReportJSON()), report))
+	assert.Equal(t, 3, report.SegmentNum)
+	assert.ElementsMatch(t, []*metricsinfo.RowCountDiscrepancy{
+		{CollectionID: 1, SegmentID: 1, NodeID: 2, Source: rowCountSourceSegcore, Expected: 100, Actual: 80},
+		{CollectionID: 1, SegmentID: 1, NodeID: 2, Source: rowCountSourceSegcoreReal, Expected: 80, Actual: 90},
+		{CollectionID: 1, SegmentID: 2, Source: rowCountSourceMeta, Expected: 60, Actual: 50},
+		{CollectionID: 1, SegmentID: 3, Source: rowCountSourceStats, Expected: 100, Actual: 90},
+	}, report.Discrepancies)
+}
@@ -19,6 +19,7 @@ package observers
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/internal/util/decisionjournal"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/lock"
@@ -463,7 +465,7 @@ func (ob *TargetObserver) sync(ctx context.Context, replica *meta.Replica, leade
 			ResourceGroup: replica.GetResourceGroup(),
 		},
 		Version:       time.Now().UnixNano(),
-		IndexInfoList: indexInfo,
+		IndexInfoList: utils.SelectIndexesForResourceGroup(indexInfo, replica.GetResourceGroup()),
 	}
 	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond))
 	defer cancel()
@@ -479,9 +481,34 @@ func (ob *TargetObserver) sync(ctx context.Context, replica *meta.Replica, leade
 		return false
 	}
 
+	recordHandoff(replicaID, leaderView, diffs)
 	return true
 }
 
+// recordHandoff records the readable segments switched on the delegator into the decision journal,
+// the segments recorded are the ones synced and the dropped ones handed off.
+func recordHandoff(replicaID int64, leaderView *meta.LeaderView, diffs []*querypb.SyncAction) {
+	event := &decisionjournal.Event{
+		Type:         decisionjournal.TypeHandoff,
+		CollectionID: leaderView.CollectionID,
+		Channel:      leaderView.Channel,
+		To:           leaderView.ID,
+		Detail: map[string]string{
+			"replica_id": strconv.FormatInt(replicaID, 10),
+		},
+	}
+	for _, diff := range diffs {
+		switch diff.GetType() {
+		case querypb.SyncType_UpdateVersion:
+			event.SegmentIDs = append(event.SegmentIDs, diff.GetDroppedInTarget()...)
+			event.Detail["target_version"] = strconv.FormatInt(diff.GetTargetVersion(), 10)
+		default:
+			event.SegmentIDs = append(event.SegmentIDs, diff.GetSegmentID())
+		}
+	}
+	decisionjournal.Record(typeutil.QueryCoordRole, event)
+}
+
 func (ob *TargetObserver) checkNeedUpdateTargetVersion(ctx context.Context, leaderView *meta.LeaderView, targetVersion int64) *querypb.SyncAction {
 	log.Ctx(ctx).WithRateGroup("qcv2.LeaderObserver", 1, 60)
 	if targetVersion <= leaderView.TargetVersion {
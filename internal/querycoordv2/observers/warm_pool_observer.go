@@ -0,0 +1,320 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// heatDecay is the factor the segment heat of the previous round is decayed by.
+const heatDecay = 0.5
+
+// orphanSegmentGracePeriodFactor is the number of check rounds a segment may stay on a node
+// out of any replica of its collection before being released,
+// which leaves time for an activated standby node to join the replicas.
+const orphanSegmentGracePeriodFactor = 3
+
+type nodeSegment struct {
+	nodeID    int64
+	segmentID int64
+}
+
+// WarmPoolObserver keeps the standby querynodes of the warm pool resource group preloaded
+// with the most accessed segments, so they serve traffic right away once transferred
+// into another resource group.
+type WarmPoolObserver struct {
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	meta      *meta.Meta
+	dist      *meta.DistributionManager
+	targetMgr meta.TargetManagerInterface
+	broker    meta.Broker
+	cluster   session.Cluster
+
+	// segment access heat, decayed every round
+	heat map[int64]float64
+	// total access count of segments seen in the last round
+	accessCount map[int64]int64
+	// the time segments were first found out of any replica of the node
+	orphanSince map[nodeSegment]time.Time
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func NewWarmPoolObserver(
+	meta *meta.Meta,
+	dist *meta.DistributionManager,
+	targetMgr meta.TargetManagerInterface,
+	broker meta.Broker,
+	cluster session.Cluster,
+) *WarmPoolObserver {
+	return &WarmPoolObserver{
+		meta:        meta,
+		dist:        dist,
+		targetMgr:   targetMgr,
+		broker:      broker,
+		cluster:     cluster,
+		heat:        make(map[int64]float64),
+		accessCount: make(map[int64]int64),
+		orphanSince: make(map[nodeSegment]time.Time),
+	}
+}
+
+func (ob *WarmPoolObserver) Start() {
+	ob.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		ob.cancel = cancel
+
+		ob.wg.Add(1)
+		go ob.schedule(ctx)
+	})
+}
+
+func (ob *WarmPoolObserver) Stop() {
+	ob.stopOnce.Do(func() {
+		if ob.cancel != nil {
+			ob.cancel()
+		}
+		ob.wg.Wait()
+	})
+}
+
+func (ob *WarmPoolObserver) schedule(ctx context.Context) {
+	defer ob.wg.Done()
+	log.Info("Start warm pool observer")
+
+	ticker := time.NewTicker(params.Params.QueryCoordCfg.WarmPoolCheckInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Stop warm pool observer")
+			return
+		case <-ticker.C:
+			ob.check(ctx)
+		}
+	}
+}
+
+func (ob *WarmPoolObserver) check(ctx context.Context) {
+	rgName := params.Params.QueryCoordCfg.WarmPoolResourceGroup.GetValue()
+	if rgName == "" || !ob.meta.ResourceManager.ContainResourceGroup(ctx, rgName) {
+		return
+	}
+
+	segments := ob.dist.SegmentDistManager.GetByFilter()
+	ob.updateHeat(segments)
+	hotSegments := ob.getHotSegments(ctx, params.Params.QueryCoordCfg.WarmPoolMaxSegmentsPerNode.GetAsInt())
+
+	nodes, err := ob.meta.ResourceManager.GetNodes(ctx, rgName)
+	if err != nil {
+		log.Warn("failed to get nodes of warm pool", zap.String("resourceGroup", rgName), zap.Error(err))
+		return
+	}
+	standbyNodes := typeutil.NewUniqueSet()
+	for _, node := range nodes {
+		// a node serving replicas is not a standby node, even if it's in the warm pool
+		if len(ob.meta.ReplicaManager.GetByNode(ctx, node)) > 0 {
+			continue
+		}
+		standbyNodes.Insert(node)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, node := range standbyNodes.Collect() {
+		node := node
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ob.warmupNode(ctx, node, hotSegments)
+		}()
+	}
+	wg.Wait()
+
+	ob.releaseOrphanSegments(ctx, segments, standbyNodes)
+}
+
+// updateHeat updates the heat of segments by the number of requests they served since the last round.
+func (ob *WarmPoolObserver) updateHeat(segments []*meta.Segment) {
+	accessCount := make(map[int64]int64)
+	for _, segment := range segments {
+		accessCount[segment.GetID()] += segment.AccessCount
+	}
+
+	heat := make(map[int64]float64, len(accessCount))
+	for segmentID, count := range accessCount {
+		delta := count - ob.accessCount[segmentID]
+		if delta < 0 {
+			// the segment has been reloaded somewhere and the counter restarted
+			delta = count
+		}
+		heat[segmentID] = ob.heat[segmentID]*heatDecay + float64(delta)
+	}
+	ob.heat = heat
+	ob.accessCount = accessCount
+}
+
+// getHotSegments returns at most limit segments of the current target, ordered by heat.
+func (ob *WarmPoolObserver) getHotSegments(ctx context.Context, limit int) []*datapb.SegmentInfo {
+	candidates := make([]int64, 0, len(ob.heat))
+	for segmentID, heat := range ob.heat {
+		if heat > 0 {
+			candidates = append(candidates, segmentID)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return ob.heat[candidates[i]] > ob.heat[candidates[j]]
+	})
+
+	hotSegments := make([]*datapb.SegmentInfo, 0, limit)
+	for _, segmentID := range candidates {
+		if len(hotSegments) >= limit {
+			break
+		}
+		segment := ob.getTargetSegment(ctx, segmentID)
+		// l0 segments are only loaded by delegators
+		if segment == nil || segment.GetLevel() == datapb.SegmentLevel_L0 {
+			continue
+		}
+		hotSegments = append(hotSegments, segment)
+	}
+	return hotSegments
+}
+
+func (ob *WarmPoolObserver) getTargetSegment(ctx context.Context, segmentID int64) *datapb.SegmentInfo {
+	dist := ob.dist.SegmentDistManager.GetByFilter(meta.WithSegmentID(segmentID))
+	if len(dist) == 0 {
+		return nil
+	}
+	return ob.targetMgr.GetSealedSegment(ctx, dist[0].GetCollectionID(), segmentID, meta.CurrentTarget)
+}
+
+// warmupNode loads the hot segments onto the standby node, and releases the segments which are no longer hot.
+func (ob *WarmPoolObserver) warmupNode(ctx context.Context, node int64, hotSegments []*datapb.SegmentInfo) {
+	log := log.Ctx(ctx).With(zap.Int64("nodeID", node))
+	loaded := lo.SliceToMap(ob.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(node)), func(s *meta.Segment) (int64, *meta.Segment) {
+		return s.GetID(), s
+	})
+	hot := lo.SliceToMap(hotSegments, func(s *datapb.SegmentInfo) (int64, struct{}) {
+		return s.GetID(), struct{}{}
+	})
+
+	for segmentID, segment := range loaded {
+		if _, ok := hot[segmentID]; ok {
+			continue
+		}
+		if err := ob.releaseSegment(ctx, node, segment); err != nil {
+			log.Warn("failed to release cold segment on standby node", zap.Int64("segmentID", segmentID), zap.Error(err))
+		}
+	}
+
+	for _, segment := range hotSegments {
+		if _, ok := loaded[segment.GetID()]; ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		req, err := task.PackWarmupSegmentRequest(ctx, ob.broker, ob.meta, ob.targetMgr, segment, node)
+		if err == nil {
+			var status *commonpb.Status
+			status, err = ob.cluster.LoadSegments(ctx, node, req)
+			err = merr.CheckRPCCall(status, err)
+		}
+		if err != nil {
+			// stop warming up the node, it may run out of resource
+			log.Warn("failed to warm up segment on standby node", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+			return
+		}
+		log.Info("warmed up segment on standby node", zap.Int64("collectionID", segment.GetCollectionID()), zap.Int64("segmentID", segment.GetID()))
+	}
+}
+
+// releaseOrphanSegments releases the segments preloaded on former standby nodes
+// which are not served by any replica of the nodes after the grace period.
+func (ob *WarmPoolObserver) releaseOrphanSegments(ctx context.Context, segments []*meta.Segment, standbyNodes typeutil.UniqueSet) {
+	gracePeriod := orphanSegmentGracePeriodFactor * params.Params.QueryCoordCfg.WarmPoolCheckInterval.GetAsDuration(time.Second)
+	orphanSince := make(map[nodeSegment]time.Time)
+	for _, segment := range segments {
+		if standbyNodes.Contain(segment.Node) || !ob.isOrphan(ctx, segment) {
+			continue
+		}
+		key := nodeSegment{nodeID: segment.Node, segmentID: segment.GetID()}
+		since, ok := ob.orphanSince[key]
+		if !ok {
+			since = time.Now()
+		}
+		if time.Since(since) < gracePeriod {
+			orphanSince[key] = since
+			continue
+		}
+		if err := ob.releaseSegment(ctx, segment.Node, segment); err != nil {
+			log.Warn("failed to release orphan segment", zap.Int64("nodeID", segment.Node), zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+			orphanSince[key] = since
+		}
+	}
+	ob.orphanSince = orphanSince
+}
+
+// isOrphan checks whether the segment is neither served by a replica containing its node,
+// nor going to be, i.e. no replica of the collection is in the resource group of its node.
+func (ob *WarmPoolObserver) isOrphan(ctx context.Context, segment *meta.Segment) bool {
+	if ob.meta.ReplicaManager.GetByCollectionAndNode(ctx, segment.GetCollectionID(), segment.Node) != nil {
+		return false
+	}
+	for _, replica := range ob.meta.ReplicaManager.GetByCollection(ctx, segment.GetCollectionID()) {
+		if ob.meta.ResourceManager.ContainsNode(ctx, replica.GetResourceGroup(), segment.Node) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ob *WarmPoolObserver) releaseSegment(ctx context.Context, node int64, segment *meta.Segment) error {
+	req := &querypb.ReleaseSegmentsRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_ReleaseSegments),
+		),
+		NodeID:       node,
+		CollectionID: segment.GetCollectionID(),
+		SegmentIDs:   []int64{segment.GetID()},
+		Scope:        querypb.DataScope_Historical,
+		Shard:        segment.GetInsertChannel(),
+		NeedTransfer: false,
+	}
+	status, err := ob.cluster.ReleaseSegments(ctx, node, req)
+	return merr.CheckRPCCall(status, err)
+}
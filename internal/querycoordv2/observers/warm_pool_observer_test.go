@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package observers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+)
+
+func TestWarmPoolObserverUpdateHeat(t *testing.T) {
+	ob := NewWarmPoolObserver(nil, nil, nil, nil, nil)
+
+	withAccess := func(segment *meta.Segment, count int64) *meta.Segment {
+		segment.AccessCount = count
+		return segment
+	}
+
+	// access counts of the same segment on different nodes are summed up
+	ob.updateHeat([]*meta.Segment{
+		withAccess(utils.CreateTestSegment(1, 1, 1, 1, 1, "channel"), 10),
+		withAccess(utils.CreateTestSegment(1, 1, 1, 2, 1, "channel"), 6),
+		withAccess(utils.CreateTestSegment(1, 1, 2, 1, 1, "channel"), 4),
+	})
+	assert.Equal(t, 16.0, ob.heat[1])
+	assert.Equal(t, 4.0, ob.heat[2])
+
+	// heat decays, only the new accesses are added
+	ob.updateHeat([]*meta.Segment{
+		withAccess(utils.CreateTestSegment(1, 1, 1, 1, 1, "channel"), 12),
+		withAccess(utils.CreateTestSegment(1, 1, 1, 2, 1, "channel"), 6),
+		withAccess(utils.CreateTestSegment(1, 1, 2, 1, 1, "channel"), 4),
+	})
+	assert.Equal(t, 10.0, ob.heat[1])
+	assert.Equal(t, 2.0, ob.heat[2])
+
+	// the counter restarts once the segment reloaded, released segments are forgotten
+	ob.updateHeat([]*meta.Segment{
+		withAccess(utils.CreateTestSegment(1, 1, 1, 3, 1, "channel"), 3),
+	})
+	assert.Equal(t, 8.0, ob.heat[1])
+	_, ok := ob.heat[2]
+	assert.False(t, ok)
+}
@@ -55,6 +55,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/componentutil"
+	"github.com/milvus-io/milvus/internal/util/decisionjournal"
 	"github.com/milvus-io/milvus/internal/util/proxyutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
@@ -119,7 +120,10 @@ type Server struct {
 	targetObserver      *observers.TargetObserver
 	replicaObserver     *observers.ReplicaObserver
 	resourceObserver    *observers.ResourceObserver
+	dbRGObserver        *observers.DatabaseResourceGroupObserver
 	leaderCacheObserver *observers.LeaderCacheObserver
+	warmPoolObserver    *observers.WarmPoolObserver
+	rowCountScrubber    *observers.RowCountScrubber
 
 	getBalancerFunc checkers.GetBalancerFunc
 	balancerMap     map[string]balance.Balance
@@ -200,6 +204,18 @@ func (s *Server) registerMetricsRequest() {
 		return s.taskScheduler.GetTasksJSON(), nil
 	}
 
+	QueryRecoveryProgressAction := func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+		return s.taskScheduler.GetRecoveryProgressJSON(), nil
+	}
+
+	QueryRowCountScrubAction := func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+		return s.rowCountScrubber.GetReportJSON(), nil
+	}
+
+	QueryDecisionJournalAction := func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+		return decisionjournal.ListJSON(typeutil.QueryCoordRole, jsonReq)
+	}
+
 	QueryDistAction := func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
 		return s.dist.GetDistributionJSON(), nil
 	}
@@ -232,6 +248,9 @@ func (s *Server) registerMetricsRequest() {
 	// register actions that requests are processed in querycoord
 	s.metricsRequest.RegisterMetricsRequest(metricsinfo.SystemInfoMetrics, getSystemInfoAction)
 	s.metricsRequest.RegisterMetricsRequest(metricsinfo.AllTaskKey, QueryTasksAction)
+	s.metricsRequest.RegisterMetricsRequest(metricsinfo.RecoveryProgressKey, QueryRecoveryProgressAction)
+	s.metricsRequest.RegisterMetricsRequest(metricsinfo.RowCountScrubKey, QueryRowCountScrubAction)
+	s.metricsRequest.RegisterMetricsRequest(metricsinfo.DecisionJournalKey, QueryDecisionJournalAction)
 	s.metricsRequest.RegisterMetricsRequest(metricsinfo.DistKey, QueryDistAction)
 	s.metricsRequest.RegisterMetricsRequest(metricsinfo.TargetKey, QueryTargetAction)
 	s.metricsRequest.RegisterMetricsRequest(metricsinfo.ReplicaKey, QueryReplicasAction)
@@ -263,6 +282,7 @@ func (s *Server) Init() error {
 			}
 			if err := s.startQueryCoord(); err != nil {
 				log.Error("QueryCoord init failed", zap.Error(err))
+				decisionjournal.Close(typeutil.QueryCoordRole)
 				return err
 			}
 			log.Info("QueryCoord startup success")
@@ -334,7 +354,9 @@ func (s *Server) initQueryCoord() error {
 	}
 	// Init session
 	log.Info("init session")
-	s.cluster = session.NewCluster(s.nodeMgr, s.queryNodeCreator)
+	s.cluster = session.NewCluster(s.nodeMgr, s.queryNodeCreator, func() int64 {
+		return s.session.GetEpoch()
+	})
 
 	// Init schedulers
 	log.Info("init schedulers")
@@ -504,6 +526,18 @@ func (s *Server) initObserver() {
 
 	s.resourceObserver = observers.NewResourceObserver(s.meta)
 
+	s.dbRGObserver = observers.NewDatabaseResourceGroupObserver(s.meta, s.broker)
+
+	s.warmPoolObserver = observers.NewWarmPoolObserver(
+		s.meta,
+		s.dist,
+		s.targetMgr,
+		s.broker,
+		s.cluster,
+	)
+
+	s.rowCountScrubber = observers.NewRowCountScrubber(s.dist, s.broker)
+
 	s.leaderCacheObserver = observers.NewLeaderCacheObserver(
 		s.proxyClientManager,
 	)
@@ -523,6 +557,13 @@ func (s *Server) Start() error {
 }
 
 func (s *Server) startQueryCoord() error {
+	// the journal is opened only once active, so the events persisted by the former active one are reloaded,
+	// and a standby never flushes its empty journal over them
+	if err := decisionjournal.Open(s.ctx, typeutil.QueryCoordRole, s.kv); err != nil {
+		log.Warn("failed to open decision journal", zap.Error(err))
+		return err
+	}
+
 	log.Info("start watcher...")
 	sessions, revision, err := s.session.GetSessions(typeutil.QueryNodeRole)
 	if err != nil {
@@ -584,6 +625,9 @@ func (s *Server) startServerLoop() {
 	s.targetObserver.Start()
 	s.replicaObserver.Start()
 	s.resourceObserver.Start()
+	s.dbRGObserver.Start()
+	s.warmPoolObserver.Start()
+	s.rowCountScrubber.Start()
 
 	log.Info("start task scheduler...")
 	s.taskScheduler.Start()
@@ -635,6 +679,15 @@ func (s *Server) Stop() error {
 	if s.resourceObserver != nil {
 		s.resourceObserver.Stop()
 	}
+	if s.dbRGObserver != nil {
+		s.dbRGObserver.Stop()
+	}
+	if s.warmPoolObserver != nil {
+		s.warmPoolObserver.Stop()
+	}
+	if s.rowCountScrubber != nil {
+		s.rowCountScrubber.Stop()
+	}
 	if s.leaderCacheObserver != nil {
 		s.leaderCacheObserver.Stop()
 	}
@@ -649,6 +702,8 @@ func (s *Server) Stop() error {
 		s.cluster.Stop()
 	}
 
+	decisionjournal.Close(typeutil.QueryCoordRole)
+
 	if s.session != nil {
 		s.session.Stop()
 	}
@@ -215,6 +215,9 @@ func (s *Server) LoadCollection(ctx context.Context, req *querypb.LoadCollection
 		return merr.Status(errors.Wrap(err, msg)), nil
 	}
 
+	// the collection could be altered or have new partitions just before loading
+	s.broker.InvalidateCollection(req.GetCollectionID())
+
 	// If refresh mode is ON.
 	if req.GetRefresh() {
 		err := s.refreshCollection(ctx, req.GetCollectionID())
@@ -252,6 +255,13 @@ func (s *Server) LoadCollection(ctx context.Context, req *querypb.LoadCollection
 		req.ResourceGroups = []string{meta.DefaultResourceGroupName}
 	}
 
+	if err := s.checkDatabaseResourceGroups(ctx, req.GetCollectionID(), req.GetResourceGroups()); err != nil {
+		msg := "failed to load collection"
+		log.Warn(msg, zap.Error(err))
+		metrics.QueryCoordLoadCount.WithLabelValues(metrics.FailLabel).Inc()
+		return merr.Status(errors.Wrap(err, msg)), nil
+	}
+
 	var loadJob job.Job
 	collection := s.meta.GetCollection(ctx, req.GetCollectionID())
 	if collection != nil && collection.GetStatus() == querypb.LoadStatus_Loaded {
@@ -368,6 +378,9 @@ func (s *Server) LoadPartitions(ctx context.Context, req *querypb.LoadPartitions
 		return merr.Status(errors.Wrap(err, msg)), nil
 	}
 
+	// the collection could be altered or have new partitions just before loading
+	s.broker.InvalidateCollection(req.GetCollectionID())
+
 	// If refresh mode is ON.
 	if req.GetRefresh() {
 		err := s.refreshCollection(ctx, req.GetCollectionID())
@@ -397,6 +410,13 @@ func (s *Server) LoadPartitions(ctx context.Context, req *querypb.LoadPartitions
 		}
 	}
 
+	if err := s.checkDatabaseResourceGroups(ctx, req.GetCollectionID(), req.GetResourceGroups()); err != nil {
+		msg := "failed to load partitions"
+		log.Warn(msg, zap.Error(err))
+		metrics.QueryCoordLoadCount.WithLabelValues(metrics.FailLabel).Inc()
+		return merr.Status(errors.Wrap(err, msg)), nil
+	}
+
 	loadJob := job.NewLoadPartitionJob(ctx,
 		req,
 		s.dist,
@@ -592,6 +612,7 @@ func (s *Server) SyncNewCreatedPartition(ctx context.Context, req *querypb.SyncN
 		return merr.Status(err), nil
 	}
 
+	s.broker.InvalidateCollection(req.GetCollectionID())
 	syncJob := job.NewSyncNewCreatedPartitionJob(ctx, req, s.meta, s.broker, s.targetObserver, s.targetMgr)
 	s.jobScheduler.Add(syncJob)
 	err := syncJob.Wait()
@@ -1067,6 +1088,11 @@ func (s *Server) TransferReplica(ctx context.Context, req *querypb.TransferRepli
 			fmt.Sprintf("the target resource group[%s] doesn't exist", req.GetTargetResourceGroup()))), nil
 	}
 
+	if err := s.checkDatabaseResourceGroups(ctx, req.GetCollectionID(), []string{req.GetTargetResourceGroup()}); err != nil {
+		log.Warn("failed to transfer replica between resource group", zap.Error(err))
+		return merr.Status(err), nil
+	}
+
 	// Apply change into replica manager.
 	err := s.meta.TransferReplica(ctx, req.GetCollectionID(), req.GetSourceResourceGroup(), req.GetTargetResourceGroup(), int(req.GetNumReplica()))
 	return merr.Status(err), nil
@@ -1161,6 +1187,16 @@ func (s *Server) DescribeResourceGroup(ctx context.Context, req *querypb.Describ
 		Config:           rg.GetConfig(),
 		Nodes:            nodes,
 	}
+
+	if paramtable.Get().QueryCoordCfg.EnforceDatabaseResourceGroups.GetAsBool() {
+		databases, err := s.getBoundDatabases(ctx, req.GetResourceGroup())
+		if err != nil {
+			log.Warn("failed to get databases bound to resource group", zap.Error(err))
+			resp.Status = merr.Status(err)
+			return resp, nil
+		}
+		resp.ResourceGroup.BoundDatabases = databases
+	}
 	return resp, nil
 }
 
@@ -1209,6 +1245,14 @@ func (s *Server) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadCo
 			continue
 		}
 
+		if rgChanged {
+			if err := s.checkDatabaseResourceGroups(ctx, collectionID, subReq.GetResourceGroups()); err != nil {
+				msg := "failed to update load config"
+				log.Warn(msg, zap.Int64("collectionID", collectionID), zap.Error(err))
+				return merr.Status(errors.Wrap(err, msg)), nil
+			}
+		}
+
 		updateJob := job.NewUpdateLoadConfigJob(
 			ctx,
 			subReq,
@@ -37,6 +37,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
 	"github.com/milvus-io/milvus/internal/querycoordv2/checkers"
 	"github.com/milvus-io/milvus/internal/querycoordv2/dist"
@@ -49,6 +50,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/util/proxyutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/kv"
 	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -222,6 +224,7 @@ func (suite *ServiceSuite) SetupTest() {
 	suite.server.UpdateStateCode(commonpb.StateCode_Healthy)
 
 	suite.broker.EXPECT().GetCollectionLoadInfo(mock.Anything, mock.Anything).Return([]string{meta.DefaultResourceGroupName}, 1, nil).Maybe()
+	suite.broker.EXPECT().InvalidateCollection(mock.Anything).Maybe()
 }
 
 func (suite *ServiceSuite) TestShowCollections() {
@@ -378,6 +381,46 @@ func (suite *ServiceSuite) TestLoadCollection() {
 	suite.Equal(resp.GetCode(), merr.Code(merr.ErrServiceNotReady))
 }
 
+func (suite *ServiceSuite) TestLoadCollectionWithDatabaseResourceGroups() {
+	ctx := context.Background()
+	server := suite.server
+
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.EnforceDatabaseResourceGroups.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.EnforceDatabaseResourceGroups.Key)
+
+	suite.broker.EXPECT().DescribeCollection(mock.Anything, mock.Anything).
+		Return(&milvuspb.DescribeCollectionResponse{DbName: "db1"}, nil)
+	suite.broker.EXPECT().DescribeDatabase(mock.Anything, "db1").
+		Return(&rootcoordpb.DescribeDatabaseResponse{
+			DbName: "db1",
+			Properties: []*commonpb.KeyValuePair{
+				{Key: common.DatabaseResourceGroups, Value: "rg1,rg2"},
+			},
+		}, nil)
+
+	// load into resource group not bound to the database
+	resp, err := server.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+		CollectionID:   suite.collections[0],
+		ReplicaNumber:  1,
+		ResourceGroups: []string{meta.DefaultResourceGroupName},
+	})
+	suite.NoError(err)
+	suite.ErrorIs(merr.Error(resp), merr.ErrParameterInvalid)
+
+	suite.NoError(server.checkDatabaseResourceGroups(ctx, suite.collections[0], []string{"rg2"}))
+	suite.ErrorIs(server.checkDatabaseResourceGroups(ctx, suite.collections[0], []string{"rg1", "rg3"}), merr.ErrParameterInvalid)
+
+	suite.broker.EXPECT().DescribeDatabases(mock.Anything).Return([]*rootcoordpb.DescribeDatabaseResponse{
+		{DbName: "db3", Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseResourceGroups, Value: "rg1"}}},
+		{DbName: "db2"},
+		{DbName: "db4", Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseResourceGroups, Value: ""}}},
+		{DbName: "db1", Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseResourceGroups, Value: "rg1,rg2"}}},
+	}, nil)
+	databases, err := server.getBoundDatabases(ctx, "rg1")
+	suite.NoError(err)
+	suite.Equal([]string{"db1", "db3"}, databases)
+}
+
 func (suite *ServiceSuite) TestResourceGroup() {
 	ctx := context.Background()
 	server := suite.server
@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/interceptor"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -63,6 +64,9 @@ type QueryCluster struct {
 	wg          sync.WaitGroup
 	ch          chan struct{}
 	stopOnce    sync.Once
+	// epoch returns the fencing epoch of current QueryCoord,
+	// which is carried by the state-changing requests to let QueryNodes reject a stale QueryCoord.
+	epoch func() int64
 }
 
 type QueryNodeCreator func(ctx context.Context, addr string, nodeID int64) (types.QueryNodeClient, error)
@@ -71,11 +75,12 @@ func DefaultQueryNodeCreator(ctx context.Context, addr string, nodeID int64) (ty
 	return grpcquerynodeclient.NewClient(ctx, addr, nodeID)
 }
 
-func NewCluster(nodeManager *NodeManager, queryNodeCreator QueryNodeCreator) *QueryCluster {
+func NewCluster(nodeManager *NodeManager, queryNodeCreator QueryNodeCreator, epoch func() int64) *QueryCluster {
 	c := &QueryCluster{
 		clients:     newClients(queryNodeCreator),
 		nodeManager: nodeManager,
 		ch:          make(chan struct{}),
+		epoch:       epoch,
 	}
 	return c
 }
@@ -114,6 +119,7 @@ func (c *QueryCluster) updateLoop() {
 }
 
 func (c *QueryCluster) LoadSegments(ctx context.Context, nodeID int64, req *querypb.LoadSegmentsRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var status *commonpb.Status
 	var err error
 	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
@@ -128,6 +134,7 @@ func (c *QueryCluster) LoadSegments(ctx context.Context, nodeID int64, req *quer
 }
 
 func (c *QueryCluster) WatchDmChannels(ctx context.Context, nodeID int64, req *querypb.WatchDmChannelsRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var status *commonpb.Status
 	var err error
 	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
@@ -142,6 +149,7 @@ func (c *QueryCluster) WatchDmChannels(ctx context.Context, nodeID int64, req *q
 }
 
 func (c *QueryCluster) UnsubDmChannel(ctx context.Context, nodeID int64, req *querypb.UnsubDmChannelRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var status *commonpb.Status
 	var err error
 	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
@@ -156,6 +164,7 @@ func (c *QueryCluster) UnsubDmChannel(ctx context.Context, nodeID int64, req *qu
 }
 
 func (c *QueryCluster) ReleaseSegments(ctx context.Context, nodeID int64, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var status *commonpb.Status
 	var err error
 	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
@@ -170,6 +179,7 @@ func (c *QueryCluster) ReleaseSegments(ctx context.Context, nodeID int64, req *q
 }
 
 func (c *QueryCluster) LoadPartitions(ctx context.Context, nodeID int64, req *querypb.LoadPartitionsRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var status *commonpb.Status
 	var err error
 	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
@@ -184,6 +194,7 @@ func (c *QueryCluster) LoadPartitions(ctx context.Context, nodeID int64, req *qu
 }
 
 func (c *QueryCluster) ReleasePartitions(ctx context.Context, nodeID int64, req *querypb.ReleasePartitionsRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var status *commonpb.Status
 	var err error
 	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
@@ -228,6 +239,7 @@ func (c *QueryCluster) GetMetrics(ctx context.Context, nodeID int64, req *milvus
 }
 
 func (c *QueryCluster) SyncDistribution(ctx context.Context, nodeID int64, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
+	ctx = c.withEpoch(ctx)
 	var (
 		resp *commonpb.Status
 		err  error
@@ -257,6 +269,14 @@ func (c *QueryCluster) GetComponentStates(ctx context.Context, nodeID int64) (*m
 	return resp, err
 }
 
+// withEpoch attaches the fencing epoch of QueryCoord to the context of state-changing requests.
+func (c *QueryCluster) withEpoch(ctx context.Context) context.Context {
+	if c.epoch == nil {
+		return ctx
+	}
+	return interceptor.WithFencingEpoch(ctx, c.epoch())
+}
+
 func (c *QueryCluster) send(ctx context.Context, nodeID int64, fn func(cli types.QueryNodeClient)) error {
 	node := c.nodeManager.Get(nodeID)
 	if node == nil {
@@ -101,7 +101,7 @@ func (suite *ClusterTestSuite) setupCluster() {
 		})
 		suite.nodeManager.Add(node)
 	}
-	suite.cluster = NewCluster(suite.nodeManager, DefaultQueryNodeCreator)
+	suite.cluster = NewCluster(suite.nodeManager, DefaultQueryNodeCreator, nil)
 }
 
 func (suite *ClusterTestSuite) createTestServers() []querypb.QueryNodeServer {
@@ -24,6 +24,7 @@ import (
 	"github.com/blang/semver/v4"
 	"go.uber.org/atomic"
 
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/metrics"
 )
 
@@ -152,6 +153,11 @@ func (n *NodeInfo) Labels() map[string]string {
 	return n.immutableInfo.Labels
 }
 
+// IsReadOnly returns whether the node is a read-only query node, which doesn't subscribe the message queue.
+func (n *NodeInfo) IsReadOnly() bool {
+	return n.immutableInfo.Labels[sessionutil.LabelReadOnly] == "true"
+}
+
 func (n *NodeInfo) SegmentCnt() int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
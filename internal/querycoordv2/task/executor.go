@@ -133,6 +133,11 @@ func (ex *Executor) Execute(task Task, step int) bool {
 	return true
 }
 
+// isExecuting returns whether the current action of the given task is committed and not done yet.
+func (ex *Executor) isExecuting(task Task) bool {
+	return ex.executingTasks.Contain(task.Index())
+}
+
 func (ex *Executor) GetExecutedFlag() <-chan struct{} {
 	return ex.executedFlag
 }
@@ -192,7 +197,7 @@ func (ex *Executor) loadSegment(task *SegmentTask, step int) error {
 		return err
 	}
 
-	loadInfo, indexInfos, err := ex.getLoadInfo(ctx, task.CollectionID(), action.SegmentID, channel)
+	loadInfo, indexInfos, err := ex.getLoadInfo(ctx, task.CollectionID(), action.SegmentID, channel, task.ResourceGroup())
 	if err != nil {
 		return err
 	}
@@ -355,6 +360,7 @@ func (ex *Executor) subscribeChannel(task *ChannelTask, step int) error {
 		log.Warn("fail to get index meta of collection")
 		return err
 	}
+	indexInfo = utils.SelectIndexesForResourceGroup(indexInfo, task.ResourceGroup())
 	loadMeta := packLoadMeta(
 		ex.meta.GetLoadType(ctx, task.CollectionID()),
 		task.CollectionID(),
@@ -546,7 +552,7 @@ func (ex *Executor) setDistribution(task *LeaderTask, step int) error {
 		return err
 	}
 
-	loadInfo, indexInfo, err := ex.getLoadInfo(ctx, task.CollectionID(), action.SegmentID(), channel)
+	loadInfo, indexInfo, err := ex.getLoadInfo(ctx, task.CollectionID(), action.SegmentID(), channel, task.ResourceGroup())
 	if err != nil {
 		return err
 	}
@@ -677,9 +683,14 @@ func (ex *Executor) getMetaInfo(ctx context.Context, task Task) (*milvuspb.Descr
 	return collectionInfo, loadMeta, channel, nil
 }
 
-func (ex *Executor) getLoadInfo(ctx context.Context, collectionID, segmentID int64, channel *meta.DmChannel) (*querypb.SegmentLoadInfo, []*indexpb.IndexInfo, error) {
+func (ex *Executor) getLoadInfo(ctx context.Context, collectionID, segmentID int64, channel *meta.DmChannel, resourceGroup string) (*querypb.SegmentLoadInfo, []*indexpb.IndexInfo, error) {
+	return getSegmentLoadInfo(ctx, ex.broker, collectionID, segmentID, channel, resourceGroup)
+}
+
+// getSegmentLoadInfo returns the load info of the segment with the indexes served by the replicas in the resource group.
+func getSegmentLoadInfo(ctx context.Context, broker meta.Broker, collectionID, segmentID int64, channel *meta.DmChannel, resourceGroup string) (*querypb.SegmentLoadInfo, []*indexpb.IndexInfo, error) {
 	log := log.Ctx(ctx)
-	segmentInfos, err := ex.broker.GetSegmentInfo(ctx, segmentID)
+	segmentInfos, err := broker.GetSegmentInfo(ctx, segmentID)
 	if err != nil || len(segmentInfos) == 0 {
 		log.Warn("failed to get segment info from DataCoord", zap.Error(err))
 		return nil, nil, err
@@ -687,7 +698,7 @@ func (ex *Executor) getLoadInfo(ctx context.Context, collectionID, segmentID int
 	segment := segmentInfos[0]
 	log = log.With(zap.String("level", segment.GetLevel().String()))
 
-	indexes, err := ex.broker.GetIndexInfo(ctx, collectionID, segment.GetID())
+	indexes, err := broker.GetIndexInfo(ctx, collectionID, segment.GetID())
 	if err != nil {
 		if !errors.Is(err, merr.ErrIndexNotFound) {
 			log.Warn("failed to get index of segment", zap.Error(err))
@@ -697,13 +708,16 @@ func (ex *Executor) getLoadInfo(ctx context.Context, collectionID, segmentID int
 	}
 
 	// Get collection index info
-	indexInfos, err := ex.broker.ListIndexes(ctx, collectionID)
+	indexInfos, err := broker.ListIndexes(ctx, collectionID)
 	if err != nil {
 		log.Warn("fail to get index meta of collection", zap.Error(err))
 		return nil, nil, err
 	}
+	// only one index of each field is served by the replica
+	segmentIndexes := utils.FilterSegmentIndexes(indexes[segment.GetID()], indexInfos, resourceGroup)
+	indexInfos = utils.SelectIndexesForResourceGroup(indexInfos, resourceGroup)
 	// update the field index params
-	for _, segmentIndex := range indexes[segment.GetID()] {
+	for _, segmentIndex := range segmentIndexes {
 		index, found := lo.Find(indexInfos, func(indexInfo *indexpb.IndexInfo) bool {
 			return indexInfo.IndexID == segmentIndex.IndexID
 		})
@@ -720,6 +734,6 @@ func (ex *Executor) getLoadInfo(ctx context.Context, collectionID, segmentID int
 		segmentIndex.IndexParams = funcutil.Map2KeyValuePair(params)
 	}
 
-	loadInfo := utils.PackSegmentLoadInfo(segment, channel.GetSeekPosition(), indexes[segment.GetID()])
+	loadInfo := utils.PackSegmentLoadInfo(segment, channel.GetSeekPosition(), segmentIndexes)
 	return loadInfo, indexInfos, nil
 }
@@ -283,6 +283,51 @@ func (_c *MockScheduler_GetExecutedFlag_Call) RunAndReturn(run func(int64) <-cha
 	return _c
 }
 
+// GetRecoveryProgressJSON provides a mock function with given fields:
+func (_m *MockScheduler) GetRecoveryProgressJSON() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecoveryProgressJSON")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockScheduler_GetRecoveryProgressJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecoveryProgressJSON'
+type MockScheduler_GetRecoveryProgressJSON_Call struct {
+	*mock.Call
+}
+
+// GetRecoveryProgressJSON is a helper method to define mock.On call
+func (_e *MockScheduler_Expecter) GetRecoveryProgressJSON() *MockScheduler_GetRecoveryProgressJSON_Call {
+	return &MockScheduler_GetRecoveryProgressJSON_Call{Call: _e.mock.On("GetRecoveryProgressJSON")}
+}
+
+func (_c *MockScheduler_GetRecoveryProgressJSON_Call) Run(run func()) *MockScheduler_GetRecoveryProgressJSON_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockScheduler_GetRecoveryProgressJSON_Call) Return(_a0 string) *MockScheduler_GetRecoveryProgressJSON_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockScheduler_GetRecoveryProgressJSON_Call) RunAndReturn(run func() string) *MockScheduler_GetRecoveryProgressJSON_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSegmentTaskDelta provides a mock function with given fields: nodeID, collectionID
 func (_m *MockScheduler) GetSegmentTaskDelta(nodeID int64, collectionID int64) int {
 	ret := _m.Called(nodeID, collectionID)
@@ -19,6 +19,9 @@ package task
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,13 +35,16 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/internal/util/decisionjournal"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/hardware"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	. "github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -58,6 +64,12 @@ var TaskTypeName = map[Type]string{
 
 type Type int32
 
+const (
+	SegmentRecoveryOrderNone          = "none"
+	SegmentRecoveryOrderSmallestFirst = "smallest_first"
+	SegmentRecoveryOrderHottestFirst  = "hottest_first"
+)
+
 func (t Type) String() string {
 	return TaskTypeName[t]
 }
@@ -147,6 +159,7 @@ type Scheduler interface {
 	GetChannelTaskNum(filters ...TaskFilter) int
 	GetSegmentTaskNum(filters ...TaskFilter) int
 	GetTasksJSON() string
+	GetRecoveryProgressJSON() string
 
 	GetSegmentTaskDelta(nodeID int64, collectionID int64) int
 	GetChannelTaskDelta(nodeID int64, collectionID int64) int
@@ -261,6 +274,11 @@ func (scheduler *taskScheduler) Add(task Task) error {
 	scheduler.rwmutex.Lock()
 	defer scheduler.rwmutex.Unlock()
 
+	return scheduler.add(task)
+}
+
+// add adds the task, must be called with the lock held.
+func (scheduler *taskScheduler) add(task Task) error {
 	err := scheduler.preAdd(task)
 	if err != nil {
 		task.Cancel(err)
@@ -288,9 +306,60 @@ func (scheduler *taskScheduler) Add(task Task) error {
 	scheduler.updateTaskMetrics()
 	log.Ctx(task.Context()).Info("task added", zap.String("task", task.String()))
 	task.RecordStartTs()
+	recordDecision(task)
 	return nil
 }
 
+// recordDecision records the segment or channel placement decided by the task into the decision journal.
+func recordDecision(task Task) {
+	event := &decisionjournal.Event{
+		CollectionID: task.CollectionID(),
+		Reason:       task.GetReason(),
+		Detail: map[string]string{
+			"source":     task.Source().String(),
+			"task_id":    strconv.FormatInt(task.ID(), 10),
+			"replica_id": strconv.FormatInt(task.ReplicaID(), 10),
+		},
+	}
+	for _, action := range task.Actions() {
+		switch action.Type() {
+		case ActionTypeGrow:
+			event.To = action.Node()
+		case ActionTypeReduce:
+			event.From = action.Node()
+		}
+	}
+
+	taskType := GetTaskType(task)
+	switch task := task.(type) {
+	case *SegmentTask:
+		event.Channel = task.Shard()
+		event.SegmentIDs = []int64{task.SegmentID()}
+		switch taskType {
+		case TaskTypeGrow:
+			event.Type = decisionjournal.TypeSegmentAssign
+		case TaskTypeReduce:
+			event.Type = decisionjournal.TypeSegmentRelease
+		}
+	case *ChannelTask:
+		event.Channel = task.Channel()
+		switch taskType {
+		case TaskTypeGrow:
+			event.Type = decisionjournal.TypeChannelAssign
+		case TaskTypeReduce:
+			event.Type = decisionjournal.TypeChannelRelease
+		}
+	}
+	if taskType == TaskTypeMove || task.Source() == utils.BalanceChecker {
+		event.Type = decisionjournal.TypeBalanceMove
+	}
+	if event.Type == "" {
+		// the index updates and the leader view syncs don't change the placement.
+		return
+	}
+	decisionjournal.Record(QueryCoordRole, event)
+}
+
 func (scheduler *taskScheduler) updateTaskMetrics() {
 	segmentGrowNum, segmentReduceNum, segmentMoveNum := 0, 0, 0
 	channelGrowNum, channelReduceNum, channelMoveNum := 0, 0, 0
@@ -671,9 +740,14 @@ func (scheduler *taskScheduler) schedule(node int64) {
 		return true
 	})
 
+	// Commit the tasks recovering the missing segments of this node in the configured order,
+	// the parallel committing below would break the order
+	commmittedNum := atomic.NewInt32(0)
+	recoveryTasks, toProcess := scheduler.splitRecoveryTasks(toProcess, node)
+	commmittedNum.Add(int32(scheduler.processRecoveryTasks(recoveryTasks, node)))
+
 	// The scheduler doesn't limit the number of tasks,
 	// to commit tasks to executors as soon as possible, to reach higher merge possibility
-	commmittedNum := atomic.NewInt32(0)
 	funcutil.ProcessFuncParallel(len(toProcess), hardware.GetCPUNum(), func(idx int) error {
 		if scheduler.process(toProcess[idx]) {
 			commmittedNum.Inc()
@@ -686,6 +760,7 @@ func (scheduler *taskScheduler) schedule(node int64) {
 	}
 
 	log.Info("processed tasks",
+		zap.Int("recoveryTaskNum", len(recoveryTasks)),
 		zap.Int("toProcessNum", len(toProcess)),
 		zap.Int32("committedNum", commmittedNum.Load()),
 		zap.Int("toRemoveNum", len(toRemove)),
@@ -699,6 +774,163 @@ func (scheduler *taskScheduler) schedule(node int64) {
 	)
 }
 
+// isRecoveryTask returns whether the given task loads a missing sealed segment onto the given node,
+// which is the case for all segments of a querynode after it restarts
+func isRecoveryTask(task Task, node int64) bool {
+	segmentTask, ok := task.(*SegmentTask)
+	if !ok || GetTaskType(segmentTask) != TaskTypeGrow || task.Source().String() != utils.SegmentCheckerName {
+		return false
+	}
+	action := segmentTask.Actions()[0].(*SegmentAction)
+	return action.Node() == node && action.GetScope() != querypb.DataScope_Streaming
+}
+
+// splitRecoveryTasks splits the recovery tasks of the given node out of tasks,
+// the recovery tasks are sorted by the configured recovery order
+func (scheduler *taskScheduler) splitRecoveryTasks(tasks []Task, node int64) ([]Task, []Task) {
+	recoveryTasks := make([]Task, 0)
+	others := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		if isRecoveryTask(task, node) {
+			recoveryTasks = append(recoveryTasks, task)
+		} else {
+			others = append(others, task)
+		}
+	}
+	if len(recoveryTasks) == 0 {
+		return recoveryTasks, others
+	}
+
+	priorityCollections := NewSet[int64]()
+	for _, str := range Params.QueryCoordCfg.SegmentRecoveryPriorityCollections.GetAsStrings() {
+		collectionID, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+		if err != nil {
+			log.Warn("invalid segment recovery priority collection", zap.String("collection", str))
+			continue
+		}
+		priorityCollections.Insert(collectionID)
+	}
+
+	var weight func(task *SegmentTask) int64
+	switch order := Params.QueryCoordCfg.SegmentRecoveryOrder.GetValue(); order {
+	case SegmentRecoveryOrderSmallestFirst:
+		weight = func(task *SegmentTask) int64 {
+			segment := scheduler.targetMgr.GetSealedSegment(task.ctx, task.CollectionID(), task.SegmentID(), meta.NextTarget)
+			return segment.GetNumOfRows()
+		}
+	case SegmentRecoveryOrderHottestFirst:
+		weight = func(task *SegmentTask) int64 {
+			// the more requests the other replicas of the segment served, the earlier it gets loaded
+			accessCount := int64(0)
+			for _, segment := range scheduler.distMgr.SegmentDistManager.GetByFilter(meta.WithSegmentID(task.SegmentID())) {
+				accessCount += segment.AccessCount
+			}
+			return -accessCount
+		}
+	case SegmentRecoveryOrderNone, "":
+	default:
+		log.RatedWarn(60, "unknown segment recovery order, ignore it", zap.String("order", order))
+	}
+
+	weights := make(map[int64]int64, len(recoveryTasks))
+	if weight != nil {
+		for _, task := range recoveryTasks {
+			weights[task.ID()] = weight(task.(*SegmentTask))
+		}
+	}
+	sort.SliceStable(recoveryTasks, func(i, j int) bool {
+		iPriority := priorityCollections.Contain(recoveryTasks[i].CollectionID())
+		jPriority := priorityCollections.Contain(recoveryTasks[j].CollectionID())
+		if iPriority != jPriority {
+			return iPriority
+		}
+		if weights[recoveryTasks[i].ID()] != weights[recoveryTasks[j].ID()] {
+			return weights[recoveryTasks[i].ID()] < weights[recoveryTasks[j].ID()]
+		}
+		return recoveryTasks[i].(*SegmentTask).SegmentID() < recoveryTasks[j].(*SegmentTask).SegmentID()
+	})
+	return recoveryTasks, others
+}
+
+// processRecoveryTasks commits the given sorted recovery tasks one by one,
+// at most SegmentRecoveryParallelism recovery tasks are executing on the node at the same time,
+// returns the number of committed tasks
+func (scheduler *taskScheduler) processRecoveryTasks(tasks []Task, node int64) int {
+	if len(tasks) == 0 {
+		return 0
+	}
+	executor, ok := scheduler.executors[node]
+	if !ok {
+		log.Warn("no executor for QueryNode", zap.Int64("nodeID", node))
+		return 0
+	}
+
+	parallelism := Params.QueryCoordCfg.SegmentRecoveryParallelism.GetAsInt()
+	executing := 0
+	for _, task := range tasks {
+		if executor.isExecuting(task) {
+			executing++
+		}
+	}
+
+	committed := 0
+	for _, task := range tasks {
+		if parallelism > 0 && executing >= parallelism {
+			break
+		}
+		if executor.isExecuting(task) {
+			continue
+		}
+		if !scheduler.process(task) {
+			// the executor is full, the rest tasks have to wait for the next round to keep the order
+			break
+		}
+		executing++
+		committed++
+	}
+	return committed
+}
+
+// GetRecoveryProgressJSON returns the JSON string of the segment recovery progress of each querynode.
+func (scheduler *taskScheduler) GetRecoveryProgressJSON() string {
+	scheduler.rwmutex.RLock()
+	defer scheduler.rwmutex.RUnlock()
+
+	progress := make(map[int64]*metricsinfo.SegmentRecoveryProgress)
+	getProgress := func(node int64) *metricsinfo.SegmentRecoveryProgress {
+		if _, ok := progress[node]; !ok {
+			progress[node] = &metricsinfo.SegmentRecoveryProgress{NodeID: node}
+		}
+		return progress[node]
+	}
+	for node, executor := range scheduler.executors {
+		p := getProgress(node)
+		p.LoadedSegmentNum = len(scheduler.distMgr.SegmentDistManager.GetByFilter(meta.WithNodeID(node)))
+		for _, task := range scheduler.segmentTasks {
+			if !isRecoveryTask(task, node) {
+				continue
+			}
+			if executor.isExecuting(task) {
+				p.ExecutingSegmentNum++
+			} else {
+				p.PendingSegmentNum++
+			}
+		}
+	}
+
+	ret := make([]*metricsinfo.SegmentRecoveryProgress, 0, len(progress))
+	for _, p := range progress {
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].NodeID < ret[j].NodeID })
+	bytes, err := json.Marshal(ret)
+	if err != nil {
+		log.Warn("marshal segment recovery progress fail", zap.Error(err))
+		return ""
+	}
+	return string(bytes)
+}
+
 func (scheduler *taskScheduler) isRelated(task Task, node int64) bool {
 	for _, action := range task.Actions() {
 		if action.Node() == node {
@@ -749,6 +981,15 @@ func (scheduler *taskScheduler) preProcess(task Task) bool {
 		if GetTaskType(task) == TaskTypeMove && actions[step].Type() == ActionTypeGrow {
 			var ready bool
 			switch actions[step].(type) {
+			case *SegmentAction:
+				// reject the move if the segment loaded on the target node diverges from the source,
+				// before the source released
+				if err := scheduler.checkSegmentDigest(task.(*SegmentTask)); err != nil {
+					log.Warn("reject the segment move", zap.Error(err))
+					task.Fail(err)
+					return false
+				}
+				ready = true
 			case *ChannelAction:
 				// if balance channel task has finished grow action, block reduce action until
 				// segment distribution has been sync to new delegator, cause new delegator may
@@ -866,9 +1107,12 @@ func (scheduler *taskScheduler) remove(task Task) {
 		log = log.With(zap.Int64("segmentID", task.SegmentID()))
 		if task.Status() == TaskStatusFailed &&
 			task.Err() != nil &&
-			!errors.IsAny(task.Err(), merr.ErrChannelNotFound, merr.ErrServiceRequestLimitExceeded) {
+			!errors.IsAny(task.Err(), merr.ErrChannelNotFound, merr.ErrServiceRequestLimitExceeded, errSegmentDigestMismatch) {
 			scheduler.recordSegmentTaskError(task)
 		}
+		if errors.Is(task.Err(), errSegmentDigestMismatch) {
+			scheduler.releaseMovedSegment(task)
+		}
 
 	case *ChannelTask:
 		index := replicaChannelIndex{task.ReplicaID(), task.Channel()}
@@ -927,6 +1171,59 @@ func (scheduler *taskScheduler) getTaskMetricsLabel(task Task) string {
 	return metrics.UnknownTaskLabel
 }
 
+// checkSegmentDigest compares the digest of the segment loaded by the grow action of the move task
+// with the one on the source node.
+func (scheduler *taskScheduler) checkSegmentDigest(task *SegmentTask) error {
+	if !Params.QueryCoordCfg.CheckSegmentDigest.GetAsBool() {
+		return nil
+	}
+	actions := task.Actions()
+	grow, reduce := actions[0].(*SegmentAction), actions[len(actions)-1].(*SegmentAction)
+	if grow.GetScope() == querypb.DataScope_Streaming {
+		return nil
+	}
+	target := scheduler.distMgr.SegmentDistManager.GetByFilter(meta.WithNodeID(grow.Node()), meta.WithSegmentID(task.SegmentID()))
+	source := scheduler.distMgr.SegmentDistManager.GetByFilter(meta.WithNodeID(reduce.Node()), meta.WithSegmentID(task.SegmentID()))
+	// the source released already, nothing to compare with
+	if len(target) == 0 || len(source) == 0 {
+		return nil
+	}
+	return checkSegmentDigest(source[0], target[0])
+}
+
+// releaseMovedSegment releases the segment loaded on the target node by the move task rejected,
+// the source keeps serving the segment.
+func (scheduler *taskScheduler) releaseMovedSegment(task *SegmentTask) {
+	grow := task.Actions()[0].(*SegmentAction)
+	log := log.Ctx(scheduler.ctx).With(
+		zap.Int64("taskID", task.ID()),
+		zap.Int64("collectionID", task.CollectionID()),
+		zap.Int64("segmentID", task.SegmentID()),
+		zap.Int64("nodeID", grow.Node()),
+	)
+	replica := scheduler.meta.ReplicaManager.Get(scheduler.ctx, task.ReplicaID())
+	if replica == nil {
+		return
+	}
+	release, err := NewSegmentTask(scheduler.ctx,
+		Params.QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond),
+		task.Source(),
+		task.CollectionID(),
+		replica,
+		NewSegmentActionWithScope(grow.Node(), ActionTypeReduce, grow.GetShard(), task.SegmentID(), querypb.DataScope_Historical),
+	)
+	if err != nil {
+		log.Warn("failed to create task to release the segment moved", zap.Error(err))
+		return
+	}
+	release.SetReason("segment digest mismatch")
+	if err := scheduler.add(release); err != nil {
+		log.Warn("failed to add task to release the segment moved", zap.Error(err))
+		return
+	}
+	log.Info("release the segment moved for the digest mismatch")
+}
+
 func (scheduler *taskScheduler) checkStale(task Task) error {
 	log := log.With(
 		zap.Int64("taskID", task.ID()),
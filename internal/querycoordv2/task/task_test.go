@@ -18,6 +18,7 @@ package task
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"strings"
 	"testing"
@@ -1853,6 +1854,58 @@ func (suite *TaskSuite) TestGetTasksJSON() {
 	suite.Equal(2, len(tasks))
 }
 
+func (suite *TaskSuite) TestSegmentRecoveryOrder() {
+	ctx := context.Background()
+	scheduler := suite.newScheduler()
+	scheduler.AddExecutor(3)
+	priorityCollection := suite.collection + 1
+
+	paramtable.Get().Save(Params.QueryCoordCfg.SegmentRecoveryOrder.Key, SegmentRecoveryOrderHottestFirst)
+	paramtable.Get().Save(Params.QueryCoordCfg.SegmentRecoveryPriorityCollections.Key, fmt.Sprint(priorityCollection))
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.SegmentRecoveryOrder.Key)
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.SegmentRecoveryPriorityCollections.Key)
+
+	// segment 1 and 2 are served by the other replica
+	hot := utils.CreateTestSegment(suite.collection, 1, 2, 1, 1, "sub-0")
+	hot.AccessCount = 100
+	warm := utils.CreateTestSegment(suite.collection, 1, 1, 1, 1, "sub-0")
+	warm.AccessCount = 10
+	suite.dist.SegmentDistManager.Update(1, hot, warm)
+
+	newTask := func(collection, segment int64, source Source) Task {
+		task, err := NewSegmentTask(ctx, 10*time.Second, source, collection, suite.replica,
+			NewSegmentAction(3, ActionTypeGrow, "sub-0", segment))
+		suite.NoError(err)
+		suite.NoError(scheduler.Add(task))
+		return task
+	}
+	tasks := []Task{
+		newTask(suite.collection, 1, utils.SegmentChecker),
+		newTask(suite.collection, 2, utils.SegmentChecker),
+		newTask(suite.collection, 3, utils.SegmentChecker),
+		newTask(priorityCollection, 4, utils.SegmentChecker),
+		newTask(suite.collection, 5, utils.BalanceChecker),
+	}
+
+	recoveryTasks, others := scheduler.splitRecoveryTasks(tasks, 3)
+	suite.Len(others, 1)
+	suite.EqualValues(5, others[0].(*SegmentTask).SegmentID())
+	suite.Equal([]int64{4, 2, 1, 3}, lo.Map(recoveryTasks, func(task Task, _ int) int64 { return task.(*SegmentTask).SegmentID() }))
+
+	// no recovery task on the other node
+	recoveryTasks, others = scheduler.splitRecoveryTasks(tasks, 1)
+	suite.Len(recoveryTasks, 0)
+	suite.Len(others, 5)
+
+	var progress []*metricsinfo.SegmentRecoveryProgress
+	suite.NoError(json.Unmarshal([]byte(scheduler.GetRecoveryProgressJSON()), &progress))
+	suite.Len(progress, 1)
+	suite.EqualValues(3, progress[0].NodeID)
+	suite.Equal(4, progress[0].PendingSegmentNum)
+	suite.Equal(0, progress[0].ExecutingSegmentNum)
+	suite.Equal(0, progress[0].LoadedSegmentNum)
+}
+
 func TestTask(t *testing.T) {
 	suite.Run(t, new(TaskSuite))
 }
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -34,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -167,6 +169,72 @@ func packLoadSegmentRequest(
 	}
 }
 
+// PackWarmupSegmentRequest packs the request to preload a sealed segment on a node serving no replica.
+// The node loads the segment by itself without any delegator involved,
+// the node catches up the delta logs flushed later when the segment is loaded by a delegator.
+func PackWarmupSegmentRequest(ctx context.Context,
+	broker meta.Broker,
+	m *meta.Meta,
+	targetMgr meta.TargetManagerInterface,
+	segment *datapb.SegmentInfo,
+	node int64,
+) (*querypb.LoadSegmentsRequest, error) {
+	collectionID := segment.GetCollectionID()
+	collectionInfo, err := broker.DescribeCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := utils.GetPartitions(ctx, targetMgr, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	channel := targetMgr.GetDmChannel(ctx, collectionID, segment.GetInsertChannel(), meta.CurrentTargetFirst)
+	if channel == nil {
+		return nil, merr.WrapErrChannelNotAvailable(segment.GetInsertChannel())
+	}
+	// the node serves no replica, so the default indexes are loaded
+	loadInfo, indexInfos, err := getSegmentLoadInfo(ctx, broker, collectionID, segment.GetID(), channel, "")
+	if err != nil {
+		return nil, err
+	}
+
+	schema := collectionInfo.GetSchema()
+	collectionMmapEnabled, exist := common.IsMmapDataEnabled(collectionInfo.GetProperties()...)
+	for _, field := range schema.GetFields() {
+		if exist {
+			field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{
+				Key:   common.MmapEnabledKey,
+				Value: strconv.FormatBool(collectionMmapEnabled),
+			})
+		}
+	}
+	schema.Properties = mergeCollectonProps(schema.Properties, collectionInfo.GetProperties())
+
+	return &querypb.LoadSegmentsRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_LoadSegments),
+		),
+		Infos:  []*querypb.SegmentLoadInfo{loadInfo},
+		Schema: schema,
+		LoadMeta: packLoadMeta(
+			m.GetLoadType(ctx, collectionID),
+			collectionID,
+			collectionInfo.GetDbName(),
+			"",
+			m.GetLoadFields(ctx, collectionID),
+			partitions...,
+		),
+		CollectionID:   collectionID,
+		DeltaPositions: []*msgpb.MsgPosition{loadInfo.GetDeltaPosition()},
+		DstNodeID:      node,
+		Version:        time.Now().UnixNano(),
+		NeedTransfer:   false,
+		IndexInfoList:  indexInfos,
+		LoadScope:      querypb.LoadScope_Full,
+		Warmup:         true,
+	}, nil
+}
+
 func packReleaseSegmentRequest(task *SegmentTask, action *SegmentAction) *querypb.ReleaseSegmentsRequest {
 	return &querypb.ReleaseSegmentsRequest{
 		Base: commonpbutil.NewMsgBase(
@@ -259,3 +327,33 @@ func packUnsubDmChannelRequest(task *ChannelTask, action Action) *querypb.UnsubD
 		ChannelName:  task.Channel(),
 	}
 }
+
+// errSegmentDigestMismatch means the segment moved diverges from the source, the move is rejected
+var errSegmentDigestMismatch = errors.New("segment digest mismatch")
+
+// checkSegmentDigest compares the digests of the segment loaded on the target node with the source,
+// the row count in segcore, the primary keys and the checksum of the index files of the same index build,
+// the digests unknown on either side are skipped, e.g. the data not loaded.
+func checkSegmentDigest(source, target *meta.Segment) error {
+	if source.RowCount > 0 && target.RowCount > 0 && source.RowCount != target.RowCount {
+		return errors.Wrapf(errSegmentDigestMismatch, "row count %d of node %d, %d of node %d",
+			target.RowCount, target.Node, source.RowCount, source.Node)
+	}
+	if source.PKDigest != 0 && target.PKDigest != 0 && source.PKDigest != target.PKDigest {
+		return errors.Wrapf(errSegmentDigestMismatch, "primary key digest %d of node %d, %d of node %d",
+			target.PKDigest, target.Node, source.PKDigest, source.Node)
+	}
+	for fieldID, targetIndex := range target.IndexInfo {
+		// the index could be rebuilt since the source loaded
+		sourceIndex, ok := source.IndexInfo[fieldID]
+		if !ok || sourceIndex.GetBuildID() != targetIndex.GetBuildID() {
+			continue
+		}
+		if sourceIndex.GetIndexChecksum() != 0 && targetIndex.GetIndexChecksum() != 0 &&
+			sourceIndex.GetIndexChecksum() != targetIndex.GetIndexChecksum() {
+			return errors.Wrapf(errSegmentDigestMismatch, "index files of field %d build %d differ between node %d and node %d",
+				fieldID, targetIndex.GetBuildID(), target.Node, source.Node)
+		}
+	}
+	return nil
+}
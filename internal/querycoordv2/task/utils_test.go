@@ -21,12 +21,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/pkg/common"
 )
 
@@ -144,6 +146,31 @@ func (s *UtilsSuite) TestPackLoadSegmentRequestMmap() {
 	}
 }
 
+func (s *UtilsSuite) TestCheckSegmentDigest() {
+	newSegment := func(node int64, rowCount int64, pkDigest uint64, indexes ...*querypb.FieldIndexInfo) *meta.Segment {
+		return &meta.Segment{
+			Node:      node,
+			RowCount:  rowCount,
+			PKDigest:  pkDigest,
+			IndexInfo: lo.SliceToMap(indexes, func(index *querypb.FieldIndexInfo) (int64, *querypb.FieldIndexInfo) { return index.GetFieldID(), index }),
+		}
+	}
+	index := &querypb.FieldIndexInfo{FieldID: 101, BuildID: 1, IndexFilePaths: []string{"a", "b"}, IndexChecksum: 1024}
+
+	s.NoError(checkSegmentDigest(newSegment(1, 100, 1, index), newSegment(2, 100, 1, index)))
+	// the digests unknown, or the index rebuilt
+	s.NoError(checkSegmentDigest(newSegment(1, 0, 0), newSegment(2, 100, 1, index)))
+	s.NoError(checkSegmentDigest(newSegment(1, 100, 1, index),
+		newSegment(2, 100, 1, &querypb.FieldIndexInfo{FieldID: 101, BuildID: 1, IndexFilePaths: []string{"a", "b"}})))
+	s.NoError(checkSegmentDigest(newSegment(1, 100, 1, index),
+		newSegment(2, 100, 1, &querypb.FieldIndexInfo{FieldID: 101, BuildID: 2, IndexFilePaths: []string{"c"}, IndexChecksum: 512})))
+
+	s.ErrorIs(checkSegmentDigest(newSegment(1, 100, 1), newSegment(2, 99, 1)), errSegmentDigestMismatch)
+	s.ErrorIs(checkSegmentDigest(newSegment(1, 100, 1), newSegment(2, 100, 2)), errSegmentDigestMismatch)
+	s.ErrorIs(checkSegmentDigest(newSegment(1, 100, 1, index),
+		newSegment(2, 100, 1, &querypb.FieldIndexInfo{FieldID: 101, BuildID: 1, IndexFilePaths: []string{"a", "b"}, IndexChecksum: 512})), errSegmentDigestMismatch)
+}
+
 func TestUtils(t *testing.T) {
 	suite.Run(t, new(UtilsSuite))
 }
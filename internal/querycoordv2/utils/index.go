@@ -0,0 +1,80 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// SelectIndexesForResourceGroup returns the indexes served by the replicas in the resource group, one index per field.
+// A field may have multiple indexes, the index bound to the resource group is served if any,
+// otherwise the default index which is not bound to any resource group, or the earliest one if all are bound.
+func SelectIndexesForResourceGroup(indexInfos []*indexpb.IndexInfo, resourceGroup string) []*indexpb.IndexInfo {
+	fieldIndexes := lo.GroupBy(indexInfos, func(info *indexpb.IndexInfo) int64 {
+		return info.GetFieldID()
+	})
+	if len(fieldIndexes) == len(indexInfos) {
+		return indexInfos
+	}
+
+	selected := make([]*indexpb.IndexInfo, 0, len(fieldIndexes))
+	for _, indexes := range fieldIndexes {
+		sort.Slice(indexes, func(i, j int) bool {
+			return indexes[i].GetIndexID() < indexes[j].GetIndexID()
+		})
+		bound, ok := lo.Find(indexes, func(info *indexpb.IndexInfo) bool {
+			return lo.Contains(common.GetIndexResourceGroups(info.GetUserIndexParams()...), resourceGroup)
+		})
+		if !ok {
+			bound, ok = lo.Find(indexes, func(info *indexpb.IndexInfo) bool {
+				return len(common.GetIndexResourceGroups(info.GetUserIndexParams()...)) == 0
+			})
+		}
+		if !ok {
+			bound = indexes[0]
+		}
+		selected = append(selected, bound)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].GetFieldID() < selected[j].GetFieldID()
+	})
+	return selected
+}
+
+// FilterSegmentIndexes removes the segment indexes not served by the replicas in the resource group.
+func FilterSegmentIndexes(segmentIndexes []*querypb.FieldIndexInfo, indexInfos []*indexpb.IndexInfo, resourceGroup string) []*querypb.FieldIndexInfo {
+	selected := SelectIndexesForResourceGroup(indexInfos, resourceGroup)
+	if len(selected) == len(indexInfos) {
+		return segmentIndexes
+	}
+	unselected := typeutil.NewSet(lo.Map(indexInfos, func(info *indexpb.IndexInfo, _ int) int64 {
+		return info.GetIndexID()
+	})...)
+	for _, info := range selected {
+		unselected.Remove(info.GetIndexID())
+	}
+	return lo.Filter(segmentIndexes, func(info *querypb.FieldIndexInfo, _ int) bool {
+		return !unselected.Contain(info.GetIndexID())
+	})
+}
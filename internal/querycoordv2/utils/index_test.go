@@ -0,0 +1,70 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
+)
+
+func TestSelectIndexesForResourceGroup(t *testing.T) {
+	newIndex := func(fieldID, indexID int64, resourceGroups string) *indexpb.IndexInfo {
+		info := &indexpb.IndexInfo{FieldID: fieldID, IndexID: indexID}
+		if resourceGroups != "" {
+			info.UserIndexParams = []*commonpb.KeyValuePair{
+				{Key: common.IndexResourceGroupsKey, Value: resourceGroups},
+			}
+		}
+		return info
+	}
+	indexIDs := func(infos []*indexpb.IndexInfo) []int64 {
+		return lo.Map(infos, func(info *indexpb.IndexInfo, _ int) int64 { return info.GetIndexID() })
+	}
+
+	// one index per field
+	single := []*indexpb.IndexInfo{newIndex(100, 1, ""), newIndex(101, 2, "rg1")}
+	assert.Equal(t, single, SelectIndexesForResourceGroup(single, "rg2"))
+
+	indexInfos := []*indexpb.IndexInfo{
+		newIndex(101, 4, "rg2"),
+		newIndex(100, 1, ""),
+		newIndex(100, 2, "rg1,rg2"),
+		newIndex(101, 3, "rg1"),
+	}
+	assert.Equal(t, []int64{2, 3}, indexIDs(SelectIndexesForResourceGroup(indexInfos, "rg1")))
+	assert.Equal(t, []int64{2, 4}, indexIDs(SelectIndexesForResourceGroup(indexInfos, "rg2")))
+	// the default index, or the earliest one if all are bound
+	assert.Equal(t, []int64{1, 3}, indexIDs(SelectIndexesForResourceGroup(indexInfos, "rg3")))
+
+	segmentIndexes := []*querypb.FieldIndexInfo{
+		{FieldID: 100, IndexID: 1},
+		{FieldID: 100, IndexID: 2},
+		{FieldID: 101, IndexID: 3},
+		{FieldID: 101, IndexID: 4},
+		{FieldID: 102, IndexID: 5},
+	}
+	filtered := FilterSegmentIndexes(segmentIndexes, indexInfos, "rg1")
+	assert.Equal(t, []int64{2, 3, 5}, lo.Map(filtered, func(info *querypb.FieldIndexInfo, _ int) int64 { return info.GetIndexID() }))
+	assert.Equal(t, segmentIndexes, FilterSegmentIndexes(segmentIndexes, single, "rg1"))
+}
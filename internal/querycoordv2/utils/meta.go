@@ -24,7 +24,10 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -237,3 +240,45 @@ func ReassignReplicaToRG(
 
 	return replicaToSpawn, replicaToTransfer, replicasToRelease, nil
 }
+
+// GetDatabaseBoundResourceGroups returns the resource groups the database is bound to by database.resource_groups,
+// nil if the database isn't bound to any resource group.
+func GetDatabaseBoundResourceGroups(ctx context.Context, broker meta.Broker, dbName string) ([]string, error) {
+	db, err := broker.DescribeDatabase(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	return databaseBoundResourceGroups(db)
+}
+
+// GetAllDatabaseBoundResourceGroups returns the resource groups bound to each database, described in one request.
+// The databases not bound to any resource group are omitted,
+// and so are the databases with an invalid binding, which don't fail the others.
+func GetAllDatabaseBoundResourceGroups(ctx context.Context, broker meta.Broker) (map[string][]string, error) {
+	dbs, err := broker.DescribeDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string][]string)
+	for _, db := range dbs {
+		rgs, err := databaseBoundResourceGroups(db)
+		if err != nil {
+			log.Ctx(ctx).Warn("skip the database with invalid resource groups", zap.String("dbName", db.GetDbName()), zap.Error(err))
+			continue
+		}
+		if len(rgs) > 0 {
+			ret[db.GetDbName()] = rgs
+		}
+	}
+	return ret, nil
+}
+
+func databaseBoundResourceGroups(db *rootcoordpb.DescribeDatabaseResponse) ([]string, error) {
+	bound := lo.ContainsBy(db.GetProperties(), func(kv *commonpb.KeyValuePair) bool {
+		return kv.GetKey() == common.DatabaseResourceGroups
+	})
+	if !bound {
+		return nil, nil
+	}
+	return common.DatabaseLevelResourceGroups(db.GetProperties())
+}
@@ -25,14 +25,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
 	etcdKV "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
 	"github.com/milvus-io/milvus/internal/metastore/mocks"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -275,3 +278,53 @@ func TestAddNodesToCollectionsInRG(t *testing.T) {
 	assert.Len(t, m.ReplicaManager.Get(ctx, 3).GetNodes(), 2)
 	assert.Len(t, m.ReplicaManager.Get(ctx, 4).GetNodes(), 2)
 }
+
+func TestGetDatabaseBoundResourceGroups(t *testing.T) {
+	ctx := context.Background()
+	broker := meta.NewMockBroker(t)
+	newDB := func(props ...*commonpb.KeyValuePair) *rootcoordpb.DescribeDatabaseResponse {
+		return &rootcoordpb.DescribeDatabaseResponse{Properties: props}
+	}
+	broker.EXPECT().DescribeDatabase(mock.Anything, "db1").Return(newDB(), nil)
+	broker.EXPECT().DescribeDatabase(mock.Anything, "db2").Return(newDB(&commonpb.KeyValuePair{Key: common.DatabaseResourceGroups, Value: "rg1, rg2"}), nil)
+	broker.EXPECT().DescribeDatabase(mock.Anything, "db3").Return(newDB(&commonpb.KeyValuePair{Key: common.DatabaseResourceGroups, Value: ""}), nil)
+	broker.EXPECT().DescribeDatabase(mock.Anything, "db4").Return(nil, errors.New("mock"))
+
+	rgs, err := GetDatabaseBoundResourceGroups(ctx, broker, "db1")
+	assert.NoError(t, err)
+	assert.Empty(t, rgs)
+
+	rgs, err = GetDatabaseBoundResourceGroups(ctx, broker, "db2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rg1", "rg2"}, rgs)
+
+	// malformed binding
+	_, err = GetDatabaseBoundResourceGroups(ctx, broker, "db3")
+	assert.Error(t, err)
+
+	_, err = GetDatabaseBoundResourceGroups(ctx, broker, "db4")
+	assert.Error(t, err)
+}
+
+func TestGetAllDatabaseBoundResourceGroups(t *testing.T) {
+	ctx := context.Background()
+	broker := meta.NewMockBroker(t)
+	broker.EXPECT().DescribeDatabases(mock.Anything).Return([]*rootcoordpb.DescribeDatabaseResponse{
+		{DbName: "db1"},
+		{DbName: "db2", Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseResourceGroups, Value: "rg1, rg2"}}},
+		// malformed binding is skipped
+		{DbName: "db3", Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseResourceGroups, Value: ""}}},
+		{DbName: "db4", Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseResourceGroups, Value: "rg2"}}},
+	}, nil).Once()
+
+	bindings, err := GetAllDatabaseBoundResourceGroups(ctx, broker)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"db2": {"rg1", "rg2"},
+		"db4": {"rg2"},
+	}, bindings)
+
+	broker.EXPECT().DescribeDatabases(mock.Anything).Return(nil, errors.New("mock")).Once()
+	_, err = GetAllDatabaseBoundResourceGroups(ctx, broker)
+	assert.Error(t, err)
+}
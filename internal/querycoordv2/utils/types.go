@@ -19,6 +19,7 @@ package utils
 import (
 	"time"
 
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -56,6 +57,11 @@ func MergeMetaSegmentIntoSegmentInfo(info *querypb.SegmentInfo, segments ...*met
 
 	for _, segment := range segments {
 		info.NodeIds = append(info.NodeIds, segment.Node)
+		for _, fieldID := range segment.WarmingIndexFields {
+			if !lo.Contains(info.WarmingIndexFields, fieldID) {
+				info.WarmingIndexFields = append(info.WarmingIndexFields, fieldID)
+			}
+		}
 	}
 }
 
@@ -74,22 +80,23 @@ func PackSegmentLoadInfo(segment *datapb.SegmentInfo, channelCheckpoint *msgpb.M
 			zap.Duration("tsLag", tsLag))
 	}
 	loadInfo := &querypb.SegmentLoadInfo{
-		SegmentID:      segment.ID,
-		PartitionID:    segment.PartitionID,
-		CollectionID:   segment.CollectionID,
-		BinlogPaths:    segment.Binlogs,
-		NumOfRows:      segment.NumOfRows,
-		Statslogs:      segment.Statslogs,
-		Deltalogs:      segment.Deltalogs,
-		Bm25Logs:       segment.Bm25Statslogs,
-		InsertChannel:  segment.InsertChannel,
-		IndexInfos:     indexes,
-		StartPosition:  segment.GetStartPosition(),
-		DeltaPosition:  channelCheckpoint,
-		Level:          segment.GetLevel(),
-		StorageVersion: segment.GetStorageVersion(),
-		IsSorted:       segment.GetIsSorted(),
-		TextStatsLogs:  segment.GetTextStatsLogs(),
+		SegmentID:         segment.ID,
+		PartitionID:       segment.PartitionID,
+		CollectionID:      segment.CollectionID,
+		BinlogPaths:       segment.Binlogs,
+		NumOfRows:         segment.NumOfRows,
+		Statslogs:         segment.Statslogs,
+		Deltalogs:         segment.Deltalogs,
+		Bm25Logs:          segment.Bm25Statslogs,
+		InsertChannel:     segment.InsertChannel,
+		IndexInfos:        indexes,
+		StartPosition:     segment.GetStartPosition(),
+		DeltaPosition:     channelCheckpoint,
+		Level:             segment.GetLevel(),
+		StorageVersion:    segment.GetStorageVersion(),
+		IsSorted:          segment.GetIsSorted(),
+		TextStatsLogs:     segment.GetTextStatsLogs(),
+		PartitionKeyRange: segment.GetPartitionKeyRange(),
 	}
 	return loadInfo
 }
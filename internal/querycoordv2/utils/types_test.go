@@ -25,6 +25,8 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
@@ -83,3 +85,23 @@ func Test_packLoadSegmentRequest(t *testing.T) {
 		assert.Equal(t, channel.SeekPosition.Timestamp, req.GetDeltaPosition().GetTimestamp())
 	})
 }
+
+func TestMergeMetaSegmentIntoSegmentInfo(t *testing.T) {
+	segmentInfo := &datapb.SegmentInfo{
+		ID:           1,
+		CollectionID: 100,
+		NumOfRows:    1000,
+	}
+	segments := []*meta.Segment{
+		{SegmentInfo: segmentInfo, Node: 1, WarmingIndexFields: []int64{101}},
+		{SegmentInfo: segmentInfo, Node: 2, WarmingIndexFields: []int64{101, 102}},
+		{SegmentInfo: segmentInfo, Node: 3},
+	}
+
+	info := &querypb.SegmentInfo{}
+	MergeMetaSegmentIntoSegmentInfo(info, segments...)
+	assert.Equal(t, int64(1), info.GetSegmentID())
+	assert.Equal(t, int64(1000), info.GetNumRows())
+	assert.ElementsMatch(t, []int64{1, 2, 3}, info.GetNodeIds())
+	assert.ElementsMatch(t, []int64{101, 102}, info.GetWarmingIndexFields())
+}
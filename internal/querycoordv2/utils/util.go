@@ -140,11 +140,21 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 		readableLeaders = filterDupLeaders(ctx, m.ReplicaManager, readableLeaders)
 		ids := make([]int64, 0, len(leaders))
 		addrs := make([]string, 0, len(leaders))
+		rgs := make([]string, 0, len(leaders))
+		replicaIDs := make([]int64, 0, len(leaders))
 		for _, leader := range readableLeaders {
 			info := nodeMgr.Get(leader.ID)
 			if info != nil {
 				ids = append(ids, info.ID())
 				addrs = append(addrs, info.Addr())
+				var rg string
+				var replicaID int64
+				if replica := m.ReplicaManager.GetByCollectionAndNode(ctx, collectionID, leader.ID); replica != nil {
+					rg = replica.GetResourceGroup()
+					replicaID = replica.GetID()
+				}
+				rgs = append(rgs, rg)
+				replicaIDs = append(replicaIDs, replicaID)
 			}
 		}
 
@@ -160,9 +170,11 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 		}
 
 		ret = append(ret, &querypb.ShardLeadersList{
-			ChannelName: channel.GetChannelName(),
-			NodeIds:     ids,
-			NodeAddrs:   addrs,
+			ChannelName:    channel.GetChannelName(),
+			NodeIds:        ids,
+			NodeAddrs:      addrs,
+			ResourceGroups: rgs,
+			ReplicaIds:     replicaIDs,
 		})
 	}
 
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// circuitBreaker counts the failed requests of a delegator within a window,
+// and rejects the requests for a while once the error rate reaches the threshold,
+// so a failing collection doesn't exhaust the resources shared with the other collections on the node.
+// The zero value is a closed breaker.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    int64
+	failures    int64
+	openUntil   time.Time
+}
+
+// allow returns the service unavailable error if the breaker is open.
+func (cb *circuitBreaker) allow(channel string) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.openUntil.IsZero() {
+		return nil
+	}
+	if now := time.Now(); now.Before(cb.openUntil) {
+		return merr.WrapErrServiceUnavailable("circuit breaker open",
+			fmt.Sprintf("delegator of channel %s rejects requests until %s after too many failures", channel, cb.openUntil.Format(time.RFC3339)))
+	}
+	cb.openUntil = time.Time{}
+	metrics.QueryNodeDelegatorCircuitBreakerOpen.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), channel).Set(0)
+	log.Info("circuit breaker of delegator closed", zap.String("channel", channel))
+	return nil
+}
+
+// record counts the result of the request, the requests canceled by the caller
+// or failed for the invalid input are not counted, they don't indicate the delegator is unhealthy.
+func (cb *circuitBreaker) record(channel string, err error) {
+	params := &paramtable.Get().QueryNodeCfg
+	threshold := params.CircuitBreakerErrorRate.GetAsFloat()
+	if threshold <= 0 || isCallerError(err) {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := time.Now()
+	// requests started before the breaker opened
+	if now.Before(cb.openUntil) {
+		return
+	}
+	if now.Sub(cb.windowStart) >= params.CircuitBreakerWindow.GetAsDuration(time.Second) {
+		cb.windowStart = now
+		cb.requests, cb.failures = 0, 0
+	}
+	cb.requests++
+	if err != nil {
+		cb.failures++
+	}
+	if cb.requests < params.CircuitBreakerMinRequests.GetAsInt64() || float64(cb.failures)/float64(cb.requests) < threshold {
+		return
+	}
+
+	cb.openUntil = now.Add(params.CircuitBreakerOpenDuration.GetAsDuration(time.Second))
+	log.Warn("circuit breaker of delegator opened",
+		zap.String("channel", channel),
+		zap.Int64("requests", cb.requests),
+		zap.Int64("failures", cb.failures),
+		zap.Time("openUntil", cb.openUntil),
+		zap.Error(err))
+	cb.windowStart = cb.openUntil
+	cb.requests, cb.failures = 0, 0
+	metrics.QueryNodeDelegatorCircuitBreakerOpen.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), channel).Set(1)
+}
+
+func isCallerError(err error) bool {
+	return errors.IsAny(err,
+		context.Canceled,
+		merr.ErrParameterInvalid,
+		merr.ErrParameterMissing,
+		merr.ErrParameterTooLarge,
+		merr.ErrCollectionNotFound,
+		merr.ErrPartitionNotFound,
+		merr.ErrFieldNotFound,
+	)
+}
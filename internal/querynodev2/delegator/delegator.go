@@ -82,9 +82,14 @@ type ShardDelegator interface {
 	LoadGrowing(ctx context.Context, infos []*querypb.SegmentLoadInfo, version int64) error
 	LoadSegments(ctx context.Context, req *querypb.LoadSegmentsRequest) error
 	ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest, force bool) error
+	// MarkSegmentsOffline makes the sealed segments go offline until querycoord loads them again,
+	// the delegator is unserviceable meanwhile so that the requests are routed to the other replicas.
+	MarkSegmentsOffline(segmentIDs ...int64)
 	SyncTargetVersion(newVersion int64, partitions []int64, growingInTarget []int64, sealedInTarget []int64, droppedInTarget []int64, checkpoint *msgpb.MsgPosition)
 	GetTargetVersion() int64
 	GetDeleteBufferSize() (entryNum int64, memorySize int64)
+	// GetSearchFanOut returns the searches served since created and the sealed segments they fanned out to.
+	GetSearchFanOut() (searches int64, segments int64)
 
 	// manage exclude segments
 	AddExcludedSegments(excludeInfo map[int64]uint64)
@@ -147,6 +152,14 @@ type shardDelegator struct {
 
 	// current forward policy
 	l0ForwardPolicy string
+
+	// number of search and query requests admitted and not finished yet
+	pendingRequests atomic.Int64
+	breaker         circuitBreaker
+
+	// the searches served and the sealed segments they fanned out to, cumulative
+	searchCount    atomic.Int64
+	searchSegments atomic.Int64
 }
 
 // getLogger returns the zap logger with pre-defined shard attributes.
@@ -282,6 +295,7 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 			PruneSegments(ctx, sd.partitionStats, req.GetReq(), nil, sd.collection.Schema(), sealed,
 				PruneInfo{filterRatio: paramtable.Get().QueryNodeCfg.DefaultSegmentFilterRatio.GetAsFloat()})
 		}()
+		sealed = pruneByPartitionKeys(ctx, sd.collection.Schema(), req.GetReq().GetSerializedExprPlan(), sealed)
 	}
 
 	searchAgainstBM25Field := sd.isBM25Field[req.GetReq().GetFieldId()]
@@ -304,12 +318,19 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 
 	// get final sealedNum after possible segment prune
 	sealedNum := lo.SumBy(sealed, func(item SnapshotItem) int { return len(item.Segments) })
+	sd.searchCount.Inc()
+	sd.searchSegments.Add(int64(sealedNum))
 	log.Debug("search segments...",
 		zap.Int("sealedNum", sealedNum),
 		zap.Int("growingNum", len(growing)),
 	)
 
-	req, err := optimizers.OptimizeSearchParams(ctx, req, sd.queryHook, sealedNum)
+	req, err := optimizers.TuneSearchParamsForRecall(ctx, req, optimizers.GetRecallTuner())
+	if err != nil {
+		log.Warn("failed to tune search params for recall", zap.Error(err))
+		return nil, err
+	}
+	req, err = optimizers.OptimizeSearchParams(ctx, req, sd.queryHook, sealedNum)
 	if err != nil {
 		log.Warn("failed to optimize search params", zap.Error(err))
 		return nil, err
@@ -333,7 +354,7 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 }
 
 // Search preforms search operation on shard.
-func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest) ([]*internalpb.SearchResults, error) {
+func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest) (_ []*internalpb.SearchResults, err error) {
 	log := sd.getLogger(ctx)
 	if err := sd.lifetime.Add(lifetime.IsWorking); err != nil {
 		return nil, err
@@ -347,6 +368,15 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 		return nil, fmt.Errorf("dml channel not match, delegator channel %s, search channels %v", sd.vchannelName, req.GetDmlChannels())
 	}
 
+	done, err := sd.admit(metrics.SearchLabel)
+	if err != nil {
+		log.Warn("delegator rejected search request", zap.Error(err))
+		return nil, err
+	}
+	defer func() { done(err) }()
+	ctx, cancel := withTimeoutTs(ctx, req.GetReq().GetTimeoutTimestamp())
+	defer cancel()
+
 	// wait tsafe
 	waitTr := timerecord.NewTimeRecorder("wait tSafe")
 	tSafe, err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp)
@@ -367,6 +397,9 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 		return nil, err
 	}
 	defer sd.distribution.Unpin(version)
+	metrics.QueryNodeDelegatorWaitLatency.WithLabelValues(
+		fmt.Sprint(paramtable.GetNodeID()), sd.vchannelName, metrics.SearchLabel).
+		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
 
 	if req.GetReq().GetIsAdvanced() {
 		futures := make([]*conc.Future[*internalpb.SearchResults], len(req.GetReq().GetSubReqs()))
@@ -442,7 +475,7 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 	return sd.search(ctx, req, sealed, growing)
 }
 
-func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryRequest, srv streamrpc.QueryStreamServer) error {
+func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryRequest, srv streamrpc.QueryStreamServer) (err error) {
 	log := sd.getLogger(ctx)
 	if !sd.Serviceable() {
 		return errors.New("delegator is not serviceable")
@@ -455,6 +488,15 @@ func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryReq
 		return fmt.Errorf("dml channel not match, delegator channel %s, search channels %v", sd.vchannelName, req.GetDmlChannels())
 	}
 
+	done, err := sd.admit(metrics.QueryLabel)
+	if err != nil {
+		log.Warn("delegator rejected query request", zap.Error(err))
+		return err
+	}
+	defer func() { done(err) }()
+	ctx, cancel := withTimeoutTs(ctx, req.GetReq().GetTimeoutTimestamp())
+	defer cancel()
+
 	// wait tsafe
 	waitTr := timerecord.NewTimeRecorder("wait tSafe")
 	tSafe, err := sd.waitTSafe(ctx, req.Req.GetGuaranteeTimestamp())
@@ -475,10 +517,14 @@ func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryReq
 		return err
 	}
 	defer sd.distribution.Unpin(version)
+	metrics.QueryNodeDelegatorWaitLatency.WithLabelValues(
+		fmt.Sprint(paramtable.GetNodeID()), sd.vchannelName, metrics.QueryLabel).
+		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
 
 	if req.Req.IgnoreGrowing {
 		growing = []SegmentEntry{}
 	}
+	sealed, growing, _ = sd.routeByPrimaryKeys(req.GetReq().GetSerializedExprPlan(), sealed, growing)
 
 	log.Info("query stream segments...",
 		zap.Int("sealedNum", len(sealed)),
@@ -504,7 +550,7 @@ func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryReq
 }
 
 // Query performs query operation on shard.
-func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest) ([]*internalpb.RetrieveResults, error) {
+func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest) (_ []*internalpb.RetrieveResults, err error) {
 	log := sd.getLogger(ctx)
 	if err := sd.lifetime.Add(lifetime.IsWorking); err != nil {
 		return nil, err
@@ -518,6 +564,15 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		return nil, fmt.Errorf("dml channel not match, delegator channel %s, search channels %v", sd.vchannelName, req.GetDmlChannels())
 	}
 
+	done, err := sd.admit(metrics.QueryLabel)
+	if err != nil {
+		log.Warn("delegator rejected query request", zap.Error(err))
+		return nil, err
+	}
+	defer func() { done(err) }()
+	ctx, cancel := withTimeoutTs(ctx, req.GetReq().GetTimeoutTimestamp())
+	defer cancel()
+
 	// wait tsafe
 	waitTr := timerecord.NewTimeRecorder("wait tSafe")
 	tSafe, err := sd.waitTSafe(ctx, req.Req.GetGuaranteeTimestamp())
@@ -538,17 +593,24 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		return nil, err
 	}
 	defer sd.distribution.Unpin(version)
+	metrics.QueryNodeDelegatorWaitLatency.WithLabelValues(
+		fmt.Sprint(paramtable.GetNodeID()), sd.vchannelName, metrics.QueryLabel).
+		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
 
 	if req.Req.IgnoreGrowing {
 		growing = []SegmentEntry{}
 	}
 
-	if paramtable.Get().QueryNodeCfg.EnableSegmentPrune.GetAsBool() {
+	// the query filtering by pk only touches segments whose bloom filters may contain the pks
+	sealed, growing, routed := sd.routeByPrimaryKeys(req.GetReq().GetSerializedExprPlan(), sealed, growing)
+
+	if !routed && paramtable.Get().QueryNodeCfg.EnableSegmentPrune.GetAsBool() {
 		func() {
 			sd.partitionStatsMut.RLock()
 			defer sd.partitionStatsMut.RUnlock()
 			PruneSegments(ctx, sd.partitionStats, nil, req.GetReq(), sd.collection.Schema(), sealed, PruneInfo{paramtable.Get().QueryNodeCfg.DefaultSegmentFilterRatio.GetAsFloat()})
 		}()
+		sealed = pruneByPartitionKeys(ctx, sd.collection.Schema(), req.GetReq().GetSerializedExprPlan(), sealed)
 	}
 
 	sealedNum := lo.SumBy(sealed, func(item SnapshotItem) int { return len(item.Segments) })
@@ -603,6 +665,10 @@ func (sd *shardDelegator) GetStatistics(ctx context.Context, req *querypb.GetSta
 		return nil, merr.WrapErrChannelNotAvailable(sd.vchannelName, "distribution is not serviceable")
 	}
 	defer sd.distribution.Unpin(version)
+	// the growing segments are not flushed yet
+	if req.GetReq().GetConsistency() == internalpb.StatisticsConsistency_StatisticsFlushed {
+		growing = nil
+	}
 
 	tasks, err := organizeSubTask(ctx, req, sealed, growing, sd, func(req *querypb.GetStatisticsRequest, scope querypb.DataScope, segmentIDs []int64, targetID int64) *querypb.GetStatisticsRequest {
 		nodeReq := proto.Clone(req).(*querypb.GetStatisticsRequest)
@@ -632,6 +698,10 @@ func (sd *shardDelegator) GetDeleteBufferSize() (entryNum int64, memorySize int6
 	return sd.deleteBuffer.Size()
 }
 
+func (sd *shardDelegator) GetSearchFanOut() (searches int64, segments int64) {
+	return sd.searchCount.Load(), sd.searchSegments.Load()
+}
+
 type subTask[T any] struct {
 	req      T
 	targetID int64
@@ -741,6 +811,10 @@ func (sd *shardDelegator) waitTSafe(ctx context.Context, ts uint64) (uint64, err
 	ctx, sp := otel.Tracer(typeutil.QueryNodeRole).Start(ctx, "Delegator-waitTSafe")
 	defer sp.End()
 	log := sd.getLogger(ctx)
+	// read-only node never waits, tsafe only advances with the target checkpoint
+	if paramtable.Get().QueryNodeCfg.ReadOnlyEnabled.GetAsBool() {
+		return sd.readOnlyTSafe(ts)
+	}
 	// already safe to search
 	latestTSafe := sd.latestTsafe.Load()
 	if latestTSafe >= ts {
@@ -790,6 +864,24 @@ func (sd *shardDelegator) waitTSafe(ctx context.Context, ts uint64) (uint64, err
 	}
 }
 
+// readOnlyTSafe returns the tsafe of the read-only node if its snapshot meets the guarantee ts.
+// The strong, bounded and session requests have a guarantee ts beyond the snapshot most of the time,
+// they fail without waiting so the proxy retries them on the other replicas.
+// The eventually requests are served as long as the snapshot is within the max staleness.
+func (sd *shardDelegator) readOnlyTSafe(ts uint64) (uint64, error) {
+	latestTSafe := sd.latestTsafe.Load()
+	st, _ := tsoutil.ParseTS(latestTSafe)
+	if latestTSafe < ts {
+		gt, _ := tsoutil.ParseTS(ts)
+		return 0, WrapErrTsLagTooLarge(gt.Sub(st), 0)
+	}
+	maxStaleness := paramtable.Get().QueryNodeCfg.ReadOnlyMaxStaleness.GetAsDuration(time.Second)
+	if staleness := time.Since(st); staleness > maxStaleness {
+		return 0, WrapErrTsLagTooLarge(staleness, maxStaleness)
+	}
+	return latestTSafe, nil
+}
+
 // watchTSafe is the worker function to update serviceable timestamp.
 func (sd *shardDelegator) watchTSafe() {
 	defer sd.lifetime.Done()
@@ -827,6 +919,40 @@ func (sd *shardDelegator) updateTSafe() {
 	sd.tsCond.L.Unlock()
 }
 
+// admit counts the request as pending on the delegator, and fails with the server busy error
+// if there are already too many pending requests, or the service unavailable error if the circuit breaker is open.
+// done must be called with the result once the request finishes.
+func (sd *shardDelegator) admit(queryType string) (done func(err error), err error) {
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	if err := sd.breaker.allow(sd.vchannelName); err != nil {
+		metrics.QueryNodeDelegatorRejectedRequests.WithLabelValues(nodeID, sd.vchannelName, queryType).Inc()
+		return nil, err
+	}
+	pending := sd.pendingRequests.Inc()
+	limit := paramtable.Get().QueryNodeCfg.DelegatorMaxPendingRequests.GetAsInt64()
+	if limit > 0 && pending > limit {
+		sd.pendingRequests.Dec()
+		metrics.QueryNodeDelegatorRejectedRequests.WithLabelValues(nodeID, sd.vchannelName, queryType).Inc()
+		retryAfter := paramtable.Get().QueryNodeCfg.DelegatorBusyRetryAfter.GetAsDuration(time.Millisecond)
+		return nil, merr.WrapErrServiceBusy(retryAfter,
+			fmt.Sprintf("delegator of channel %s has reached the limit of %d pending requests", sd.vchannelName, limit))
+	}
+	metrics.QueryNodeDelegatorPendingRequests.WithLabelValues(nodeID, sd.vchannelName).Set(float64(pending))
+	return func(err error) {
+		metrics.QueryNodeDelegatorPendingRequests.WithLabelValues(nodeID, sd.vchannelName).Set(float64(sd.pendingRequests.Dec()))
+		sd.breaker.record(sd.vchannelName, err)
+	}, nil
+}
+
+// withTimeoutTs applies the timeout timestamp of the request to ctx, which may be earlier than the deadline of ctx
+// if the proxy sets it from the default timeout of the collection.
+func withTimeoutTs(ctx context.Context, timeoutTs uint64) (context.Context, context.CancelFunc) {
+	if timeoutTs == 0 {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, tsoutil.PhysicalTime(timeoutTs))
+}
+
 // Close closes the delegator.
 func (sd *shardDelegator) Close() {
 	sd.lifetime.SetState(lifetime.Stopped)
@@ -834,9 +960,11 @@ func (sd *shardDelegator) Close() {
 	// broadcast to all waitTsafe goroutine to quit
 	sd.tsCond.Broadcast()
 	sd.lifetime.Wait()
+	sd.deleteBuffer.Close()
 
 	metrics.QueryNodeDeleteBufferSize.DeleteLabelValues(fmt.Sprint(paramtable.GetNodeID()), sd.vchannelName)
 	metrics.QueryNodeDeleteBufferRowNum.DeleteLabelValues(fmt.Sprint(paramtable.GetNodeID()), sd.vchannelName)
+	metrics.CleanupQueryNodeDelegatorMetrics(paramtable.GetNodeID(), sd.vchannelName)
 }
 
 // As partition stats is an optimization for search/query which is not mandatory for milvus instance,
@@ -905,6 +1033,18 @@ func NewShardDelegator(ctx context.Context, collectionID UniqueID, replicaID Uni
 
 	sizePerBlock := paramtable.Get().QueryNodeCfg.DeleteBufferBlockSize.GetAsInt64()
 	log.Info("Init delete cache with list delete buffer", zap.Int64("sizePerBlock", sizePerBlock), zap.Time("startTime", tsoutil.PhysicalTime(startTs)))
+	deleteBufferLabels := []string{fmt.Sprint(paramtable.GetNodeID()), channel}
+	deleteBuffer := deletebuffer.NewListDeleteBuffer[*deletebuffer.Item](startTs, sizePerBlock, deleteBufferLabels)
+	if spillSize := paramtable.Get().QueryNodeCfg.DeleteBufferSpillSize.GetAsInt64() * 1024 * 1024; spillSize > 0 {
+		spillDir := path.Join(paramtable.Get().QueryNodeCfg.DeleteBufferSpillDir.GetValue(), fmt.Sprint(paramtable.GetNodeID()), channel)
+		var err error
+		deleteBuffer, err = deletebuffer.NewSpillableListDeleteBuffer(startTs, sizePerBlock, deleteBufferLabels, spillDir, spillSize)
+		if err != nil {
+			log.Warn("failed to init spillable delete buffer", zap.String("dir", spillDir), zap.Error(err))
+			return nil, err
+		}
+		log.Info("delete buffer spills to disk", zap.String("dir", spillDir), zap.Int64("spillSize", spillSize))
+	}
 
 	excludedSegments := NewExcludedSegments(paramtable.Get().QueryNodeCfg.CleanExcludeSegInterval.GetAsDuration(time.Second))
 
@@ -912,17 +1052,16 @@ func NewShardDelegator(ctx context.Context, collectionID UniqueID, replicaID Uni
 	log.Info("shard delegator setup l0 forward policy", zap.String("policy", policy))
 
 	sd := &shardDelegator{
-		collectionID:   collectionID,
-		replicaID:      replicaID,
-		vchannelName:   channel,
-		version:        version,
-		collection:     collection,
-		segmentManager: manager.Segment,
-		workerManager:  workerManager,
-		lifetime:       lifetime.NewLifetime(lifetime.Initializing),
-		distribution:   NewDistribution(),
-		deleteBuffer: deletebuffer.NewListDeleteBuffer[*deletebuffer.Item](startTs, sizePerBlock,
-			[]string{fmt.Sprint(paramtable.GetNodeID()), channel}),
+		collectionID:     collectionID,
+		replicaID:        replicaID,
+		vchannelName:     channel,
+		version:          version,
+		collection:       collection,
+		segmentManager:   manager.Segment,
+		workerManager:    workerManager,
+		lifetime:         lifetime.NewLifetime(lifetime.Initializing),
+		distribution:     NewDistribution(),
+		deleteBuffer:     deleteBuffer,
 		pkOracle:         pkoracle.NewPkOracle(),
 		tsafeManager:     tsafeManager,
 		latestTsafe:      atomic.NewUint64(startTs),
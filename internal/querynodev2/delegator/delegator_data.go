@@ -71,6 +71,9 @@ type InsertData struct {
 	Timestamps   []uint64
 	InsertRecord *segcorepb.InsertRecord
 	BM25Stats    map[int64]*storage.BM25Stats
+	// Upserts maps offsets of upserted rows to the partition scope
+	// of the deletion issued together with them.
+	Upserts map[int]int64
 
 	StartPosition *msgpb.MsgPosition
 	PartitionID   int64
@@ -95,6 +98,7 @@ func (sd *shardDelegator) ProcessInsert(insertRecords map[int64]*InsertData) {
 	method := "ProcessInsert"
 	tr := timerecord.NewTimeRecorder(method)
 	log := sd.getLogger(context.Background())
+	applied := make(map[int64]*InsertData, len(insertRecords))
 	for segmentID, insertData := range insertRecords {
 		growing := sd.segmentManager.GetGrowing(segmentID)
 		newGrowingSegment := false
@@ -171,6 +175,7 @@ func (sd *shardDelegator) ProcessInsert(insertRecords map[int64]*InsertData) {
 		} else if sd.idfOracle != nil {
 			sd.idfOracle.UpdateGrowing(growing.ID(), insertData.BM25Stats)
 		}
+		applied[segmentID] = insertData
 		log.Info("insert into growing segment",
 			zap.Int64("collectionID", growing.Collection()),
 			zap.Int64("segmentID", segmentID),
@@ -178,10 +183,61 @@ func (sd *shardDelegator) ProcessInsert(insertRecords map[int64]*InsertData) {
 			zap.Uint64("maxTimestamp", insertData.Timestamps[len(insertData.Timestamps)-1]),
 		)
 	}
+	sd.updatePkVersions(applied)
 	metrics.QueryNodeProcessCost.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.InsertLabel).
 		Observe(float64(tr.ElapseSpan().Milliseconds()))
 }
 
+// updatePkVersions records the segments holding the latest versions of upserted pks,
+// so that later deletions are not forwarded to segments holding superseded versions only.
+// Rows of one batch are applied in timestamp order per pk, since segments are iterated out of order.
+func (sd *shardDelegator) updatePkVersions(insertRecords map[int64]*InsertData) {
+	type latestRow struct {
+		segmentID int64
+		pk        storage.PrimaryKey
+		ts        uint64
+		upsert    bool
+		scope     int64
+	}
+
+	hasUpsert := lo.SomeBy(lo.Values(insertRecords), func(insertData *InsertData) bool {
+		return len(insertData.Upserts) > 0
+	})
+	if !hasUpsert {
+		for segmentID, insertData := range insertRecords {
+			for i, pk := range insertData.PrimaryKeys {
+				sd.pkOracle.Invalidate(segmentID, pk, insertData.Timestamps[i])
+			}
+		}
+		return
+	}
+
+	latest := make(map[any]latestRow)
+	for segmentID, insertData := range insertRecords {
+		for i, pk := range insertData.PrimaryKeys {
+			ts := insertData.Timestamps[i]
+			if row, ok := latest[pk.GetValue()]; ok && row.ts > ts {
+				continue
+			}
+			scope, upsert := insertData.Upserts[i]
+			latest[pk.GetValue()] = latestRow{
+				segmentID: segmentID,
+				pk:        pk,
+				ts:        ts,
+				upsert:    upsert,
+				scope:     scope,
+			}
+		}
+	}
+	for _, row := range latest {
+		if row.upsert {
+			sd.pkOracle.Supersede(row.segmentID, row.scope, row.pk, row.ts)
+		} else {
+			sd.pkOracle.Invalidate(row.segmentID, row.pk, row.ts)
+		}
+	}
+}
+
 // ProcessDelete handles delete data in delegator.
 // delegator puts deleteData into buffer first,
 // then dispatch data to segments acoording to the result of pkOracle.
@@ -244,8 +300,9 @@ func (sd *shardDelegator) applyBFInParallel(deleteDatas []*DeleteData, pool *con
 			tmpRetIndex := retIdx
 			deleteDataId := didx
 			partitionID := data.PartitionID
+			tss := data.Timestamps
 			future := pool.Submit(func() (any, error) {
-				ret := sd.pkOracle.BatchGet(pks[startIdx:endIdx], pkoracle.WithPartitionID(partitionID))
+				ret := sd.pkOracle.BatchGetLatest(pks[startIdx:endIdx], tss[startIdx:endIdx], pkoracle.WithPartitionID(partitionID))
 				retMap.Insert(tmpRetIndex, &BatchApplyRet{
 					DeleteDataIdx: deleteDataId,
 					StartIdx:      startIdx,
@@ -337,6 +394,12 @@ func (sd *shardDelegator) markSegmentOffline(segmentIDs ...int64) {
 	sd.distribution.AddOfflines(segmentIDs...)
 }
 
+// MarkSegmentsOffline makes the segments go offline, e.g. the ones quarantined by the workers.
+func (sd *shardDelegator) MarkSegmentsOffline(segmentIDs ...int64) {
+	sd.getLogger(context.Background()).Warn("mark segments offline", zap.Int64s("segmentIDs", segmentIDs))
+	sd.markSegmentOffline(segmentIDs...)
+}
+
 // addGrowing add growing segment record for delegator.
 func (sd *shardDelegator) addGrowing(entries ...SegmentEntry) {
 	log := sd.getLogger(context.Background())
@@ -473,11 +536,12 @@ func (sd *shardDelegator) LoadSegments(ctx context.Context, req *querypb.LoadSeg
 
 	entries := lo.Map(req.GetInfos(), func(info *querypb.SegmentLoadInfo, _ int) SegmentEntry {
 		return SegmentEntry{
-			SegmentID:   info.GetSegmentID(),
-			PartitionID: info.GetPartitionID(),
-			NodeID:      req.GetDstNodeID(),
-			Version:     req.GetVersion(),
-			Level:       info.GetLevel(),
+			SegmentID:         info.GetSegmentID(),
+			PartitionID:       info.GetPartitionID(),
+			NodeID:            req.GetDstNodeID(),
+			Version:           req.GetVersion(),
+			Level:             info.GetLevel(),
+			PartitionKeyRange: info.GetPartitionKeyRange(),
 		}
 	})
 	if req.GetInfos()[0].GetLevel() == datapb.SegmentLevel_L0 {
@@ -642,8 +706,7 @@ func (sd *shardDelegator) loadStreamDelete(ctx context.Context,
 		}
 
 		// list buffered delete
-		deleteRecords := sd.deleteBuffer.ListAfter(position.GetTimestamp())
-		for _, entry := range deleteRecords {
+		err := sd.deleteBuffer.RangeAfter(position.GetTimestamp(), func(entry *deletebuffer.Item) error {
 			for _, record := range entry.Data {
 				if record.PartitionID != common.AllPartitionsID && candidate.Partition() != record.PartitionID {
 					continue
@@ -665,6 +728,11 @@ func (sd *shardDelegator) loadStreamDelete(ctx context.Context,
 					}
 				}
 			}
+			return nil
+		})
+		if err != nil {
+			log.Warn("failed to list buffered delete", zap.Error(err))
+			return err
 		}
 		// if delete count not empty, apply
 		if deleteData.RowCount > 0 {
@@ -982,6 +1050,13 @@ func (sd *shardDelegator) SyncTargetVersion(newVersion int64, partitions []int64
 	}
 	sd.distribution.SyncTargetVersion(newVersion, partitions, growingInTarget, sealedInTarget, redundantGrowingIDs)
 	sd.deleteBuffer.TryDiscard(checkpoint.GetTimestamp())
+
+	// read-only node doesn't consume the channel, the snapshot advances to the checkpoint of the new target
+	if paramtable.Get().QueryNodeCfg.ReadOnlyEnabled.GetAsBool() && checkpoint != nil {
+		if err := sd.tsafeManager.Set(sd.vchannelName, checkpoint.GetTimestamp()); err != nil {
+			log.Warn("failed to advance tsafe to target checkpoint", zap.String("channel", sd.vchannelName), zap.Error(err))
+		}
+	}
 }
 
 func (sd *shardDelegator) GetTargetVersion() int64 {
@@ -43,12 +43,14 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/lifetime"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metric"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
 type DelegatorSuite struct {
@@ -294,6 +296,34 @@ func (s *DelegatorSuite) TestGetSegmentInfo() {
 	s.Equal(0, len(growing))
 }
 
+func (s *DelegatorSuite) TestMarkSegmentsOffline() {
+	sd, ok := s.delegator.(*shardDelegator)
+	s.Require().True(ok)
+	s.delegator.SyncDistribution(context.Background(), SegmentEntry{
+		NodeID:      1,
+		SegmentID:   1001,
+		PartitionID: 500,
+		Version:     2001,
+	})
+	s.True(sd.distribution.Serviceable())
+
+	// unknown segments are ignored
+	s.delegator.MarkSegmentsOffline(1002)
+	s.True(sd.distribution.Serviceable())
+
+	s.delegator.MarkSegmentsOffline(1001)
+	s.False(sd.distribution.Serviceable())
+
+	// serviceable again once the segment is loaded again
+	s.delegator.SyncDistribution(context.Background(), SegmentEntry{
+		NodeID:      1,
+		SegmentID:   1001,
+		PartitionID: 500,
+		Version:     2002,
+	})
+	s.True(sd.distribution.Serviceable())
+}
+
 // nodeID 1 => sealed segment 1000, 1001
 // nodeID 1 => growing segment 1004
 // nodeID 2 => sealed segment 1002, 1003
@@ -1080,6 +1110,46 @@ func (s *DelegatorSuite) TestGetStats() {
 		s.Equal(3, len(results))
 	})
 
+	s.Run("flushed_only", func() {
+		defer func() {
+			s.workerManager.ExpectedCalls = nil
+		}()
+		workers := make(map[int64]*cluster.MockWorker)
+		worker1 := &cluster.MockWorker{}
+		worker2 := &cluster.MockWorker{}
+
+		workers[1] = worker1
+		workers[2] = worker2
+
+		worker1.EXPECT().GetStatistics(mock.Anything, mock.AnythingOfType("*querypb.GetStatisticsRequest")).
+			Run(func(_ context.Context, req *querypb.GetStatisticsRequest) {
+				s.Equal(querypb.DataScope_Historical, req.GetScope())
+				s.ElementsMatch([]int64{1000, 1001}, req.GetSegmentIDs())
+			}).Return(&internalpb.GetStatisticsResponse{}, nil)
+		worker2.EXPECT().GetStatistics(mock.Anything, mock.AnythingOfType("*querypb.GetStatisticsRequest")).
+			Run(func(_ context.Context, req *querypb.GetStatisticsRequest) {
+				s.Equal(querypb.DataScope_Historical, req.GetScope())
+				s.ElementsMatch([]int64{1002, 1003}, req.GetSegmentIDs())
+			}).Return(&internalpb.GetStatisticsResponse{}, nil)
+
+		s.workerManager.EXPECT().GetWorker(mock.Anything, mock.AnythingOfType("int64")).Call.Return(func(_ context.Context, nodeID int64) cluster.Worker {
+			return workers[nodeID]
+		}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		results, err := s.delegator.GetStatistics(ctx, &querypb.GetStatisticsRequest{
+			Req: &internalpb.GetStatisticsRequest{
+				Base:        commonpbutil.NewMsgBase(),
+				Consistency: internalpb.StatisticsConsistency_StatisticsFlushed,
+			},
+			DmlChannels: []string{s.vchannelName},
+		})
+
+		s.NoError(err)
+		s.Equal(2, len(results))
+	})
+
 	s.Run("worker_return_error", func() {
 		defer func() {
 			s.workerManager.ExpectedCalls = nil
@@ -1268,6 +1338,36 @@ func TestDelegatorTSafeListenerClosed(t *testing.T) {
 	assert.Equal(t, sd.Stopped(), true)
 }
 
+func TestDelegatorReadOnlyTSafe(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.ReadOnlyEnabled.Key, "true")
+	defer params.Reset(params.QueryNodeCfg.ReadOnlyEnabled.Key)
+
+	now := time.Now()
+	sd := &shardDelegator{
+		latestTsafe: atomic.NewUint64(tsoutil.ComposeTSByTime(now.Add(-time.Minute), 0)),
+	}
+	ctx := context.Background()
+
+	// eventually
+	tSafe, err := sd.waitTSafe(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, sd.latestTsafe.Load(), tSafe)
+
+	// strong and bounded fail without waiting, even if the lag is within the max staleness
+	_, err = sd.waitTSafe(ctx, tsoutil.ComposeTSByTime(now, 0))
+	assert.ErrorIs(t, err, ErrTsLagTooLarge)
+	_, err = sd.waitTSafe(ctx, tsoutil.ComposeTSByTime(now.Add(-5*time.Second), 0))
+	assert.ErrorIs(t, err, ErrTsLagTooLarge)
+
+	// the snapshot is older than the max staleness
+	params.Save(params.QueryNodeCfg.ReadOnlyMaxStaleness.Key, "30")
+	defer params.Reset(params.QueryNodeCfg.ReadOnlyMaxStaleness.Key)
+	_, err = sd.waitTSafe(ctx, 1)
+	assert.ErrorIs(t, err, ErrTsLagTooLarge)
+}
+
 func TestDelegatorSearchBM25InvalidMetricType(t *testing.T) {
 	paramtable.Init()
 	searchReq := &querypb.SearchRequest{
@@ -1287,3 +1387,101 @@ func TestDelegatorSearchBM25InvalidMetricType(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "must use BM25 metric type when searching against BM25 Function output field")
 }
+
+func TestDelegatorAdmit(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.DelegatorMaxPendingRequests.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.DelegatorMaxPendingRequests.Key)
+
+	sd := &shardDelegator{
+		vchannelName: "default_dml_channel",
+	}
+
+	done1, err := sd.admit(metrics.SearchLabel)
+	require.NoError(t, err)
+	done2, err := sd.admit(metrics.QueryLabel)
+	require.NoError(t, err)
+
+	_, err = sd.admit(metrics.SearchLabel)
+	assert.ErrorIs(t, err, merr.ErrServiceBusy)
+	assert.EqualValues(t, 2, sd.pendingRequests.Load())
+
+	done1(nil)
+	done3, err := sd.admit(metrics.SearchLabel)
+	require.NoError(t, err)
+	done2(nil)
+	done3(nil)
+	assert.EqualValues(t, 0, sd.pendingRequests.Load())
+
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.DelegatorMaxPendingRequests.Key, "0")
+	for i := 0; i < 4; i++ {
+		_, err = sd.admit(metrics.SearchLabel)
+		assert.NoError(t, err)
+	}
+}
+
+func TestDelegatorCircuitBreaker(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.CircuitBreakerErrorRate.Key, "0.5")
+	params.Save(params.QueryNodeCfg.CircuitBreakerMinRequests.Key, "4")
+	params.Save(params.QueryNodeCfg.CircuitBreakerOpenDuration.Key, "0.1")
+	defer params.Reset(params.QueryNodeCfg.CircuitBreakerErrorRate.Key)
+	defer params.Reset(params.QueryNodeCfg.CircuitBreakerMinRequests.Key)
+	defer params.Reset(params.QueryNodeCfg.CircuitBreakerOpenDuration.Key)
+
+	sd := &shardDelegator{
+		vchannelName: "default_dml_channel",
+	}
+	finish := func(err error) {
+		done, admitErr := sd.admit(metrics.SearchLabel)
+		require.NoError(t, admitErr)
+		done(err)
+	}
+
+	// canceled requests and invalid inputs are not counted
+	for i := 0; i < 4; i++ {
+		finish(context.Canceled)
+		finish(merr.WrapErrParameterInvalidMsg("invalid expr"))
+		finish(merr.WrapErrFieldNotFound("foo"))
+	}
+	finish(nil)
+	finish(merr.ErrServiceInternal)
+	finish(nil)
+	finish(context.DeadlineExceeded)
+
+	_, err := sd.admit(metrics.SearchLabel)
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+	assert.EqualValues(t, 0, sd.pendingRequests.Load())
+
+	assert.Eventually(t, func() bool {
+		done, err := sd.admit(metrics.SearchLabel)
+		if err != nil {
+			return false
+		}
+		done(nil)
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// disabled
+	params.Save(params.QueryNodeCfg.CircuitBreakerErrorRate.Key, "0")
+	for i := 0; i < 8; i++ {
+		finish(merr.ErrServiceInternal)
+	}
+	_, err = sd.admit(metrics.SearchLabel)
+	assert.NoError(t, err)
+}
+
+func TestWithTimeoutTs(t *testing.T) {
+	ctx, cancel := withTimeoutTs(context.Background(), 0)
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel = withTimeoutTs(context.Background(), tsoutil.ComposeTSByTime(deadline, 0))
+	defer cancel()
+	actual, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, deadline, actual, time.Millisecond)
+}
@@ -34,11 +34,15 @@ type timed interface {
 // DeleteBuffer is the interface for delete buffer.
 type DeleteBuffer[T timed] interface {
 	Put(T)
-	ListAfter(uint64) []T
+	// RangeAfter calls fn with the entries of which ts is not before the provided value in order,
+	// it stops at the first error returned by fn or met reading the entries.
+	RangeAfter(ts uint64, fn func(T) error) error
 	SafeTs() uint64
 	TryDiscard(uint64)
 	// Size returns current size information of delete buffer: entryNum and memory
 	Size() (entryNum, memorySize int64)
+	// Close releases the resources held by the buffer
+	Close()
 }
 
 func NewDoubleCacheDeleteBuffer[T timed](startTs uint64, maxSize int64) DeleteBuffer[T] {
@@ -64,6 +68,9 @@ func (c *doubleCacheBuffer[T]) SafeTs() uint64 {
 func (c *doubleCacheBuffer[T]) TryDiscard(_ uint64) {
 }
 
+func (c *doubleCacheBuffer[T]) Close() {
+}
+
 // Put implements DeleteBuffer.
 func (c *doubleCacheBuffer[T]) Put(entry T) {
 	c.mut.Lock()
@@ -75,10 +82,9 @@ func (c *doubleCacheBuffer[T]) Put(entry T) {
 	}
 }
 
-// ListAfter implements DeleteBuffer.
-func (c *doubleCacheBuffer[T]) ListAfter(ts uint64) []T {
+// RangeAfter implements DeleteBuffer.
+func (c *doubleCacheBuffer[T]) RangeAfter(ts uint64, fn func(T) error) error {
 	c.mut.RLock()
-	defer c.mut.RUnlock()
 	var result []T
 	if c.tail != nil {
 		result = append(result, c.tail.ListAfter(ts)...)
@@ -86,7 +92,14 @@ func (c *doubleCacheBuffer[T]) ListAfter(ts uint64) []T {
 	if c.head != nil {
 		result = append(result, c.head.ListAfter(ts)...)
 	}
-	return result
+	c.mut.RUnlock()
+
+	for _, entry := range result {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *doubleCacheBuffer[T]) Size() (entryNum int64, memorySize int64) {
@@ -113,6 +126,7 @@ func (c *doubleCacheBuffer[T]) evict(newTs uint64, entry T) {
 	c.tail = c.head
 	c.head = &cacheBlock[T]{
 		headTs:   newTs,
+		tailTs:   entry.Timestamp(),
 		maxSize:  c.maxSize / 2,
 		size:     entry.Size(),
 		entryNum: entry.EntryNum(),
@@ -123,12 +137,15 @@ func (c *doubleCacheBuffer[T]) evict(newTs uint64, entry T) {
 
 func newCacheBlock[T timed](ts uint64, maxSize int64, elements ...T) *cacheBlock[T] {
 	var entryNum, memorySize int64
+	tailTs := ts
 	for _, element := range elements {
 		entryNum += element.EntryNum()
 		memorySize += element.Size()
+		tailTs = max(tailTs, element.Timestamp())
 	}
 	return &cacheBlock[T]{
 		headTs:   ts,
+		tailTs:   tailTs,
 		maxSize:  maxSize,
 		data:     elements,
 		entryNum: entryNum,
@@ -137,13 +154,23 @@ func newCacheBlock[T timed](ts uint64, maxSize int64, elements ...T) *cacheBlock
 }
 
 type cacheBlock[T timed] struct {
-	mut      sync.RWMutex
-	headTs   uint64
+	mut    sync.RWMutex
+	headTs uint64
+	// the max ts of the entries, kept after the data is spilled
+	tailTs   uint64
 	entryNum int64
 	size     int64
 	maxSize  int64
 
 	data []T
+	// the path of the file the data spilled to, the data is released once spilled
+	spillPath string
+	// set once the block is discarded from the list delete buffer
+	discarded bool
+}
+
+func (c *cacheBlock[T]) spilled() bool {
+	return c.spillPath != ""
 }
 
 // Cache adds entry into cache item.
@@ -157,6 +184,7 @@ func (c *cacheBlock[T]) Put(entry T) error {
 	}
 
 	c.data = append(c.data, entry)
+	c.tailTs = max(c.tailTs, entry.Timestamp())
 	c.size += entry.Size()
 	c.entryNum += entry.EntryNum()
 	return nil
@@ -20,11 +20,23 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus/internal/storage"
 )
 
+// listAfter collects the entries ranged by RangeAfter.
+func listAfter[T timed](t *testing.T, buffer DeleteBuffer[T], ts uint64) []T {
+	var result []T
+	err := buffer.RangeAfter(ts, func(entry T) error {
+		result = append(result, entry)
+		return nil
+	})
+	require.NoError(t, err)
+	return result
+}
+
 func TestSkipListDeleteBuffer(t *testing.T) {
 	db := NewDeleteBuffer()
 
@@ -74,8 +86,8 @@ func (s *DoubleCacheBufferSuite) TestCache() {
 		},
 	})
 
-	s.Equal(2, len(buffer.ListAfter(11)))
-	s.Equal(1, len(buffer.ListAfter(12)))
+	s.Equal(2, len(listAfter(s.T(), buffer, 11)))
+	s.Equal(1, len(listAfter(s.T(), buffer, 12)))
 }
 
 func (s *DoubleCacheBufferSuite) TestPut() {
@@ -108,8 +120,8 @@ func (s *DoubleCacheBufferSuite) TestPut() {
 		},
 	})
 
-	s.Equal(2, len(buffer.ListAfter(11)))
-	s.Equal(1, len(buffer.ListAfter(12)))
+	s.Equal(2, len(listAfter(s.T(), buffer, 11)))
+	s.Equal(1, len(listAfter(s.T(), buffer, 12)))
 	entryNum, memorySize := buffer.Size()
 	s.EqualValues(2, entryNum)
 	s.EqualValues(234, memorySize)
@@ -128,9 +140,9 @@ func (s *DoubleCacheBufferSuite) TestPut() {
 		},
 	})
 
-	s.Equal(2, len(buffer.ListAfter(11)))
-	s.Equal(2, len(buffer.ListAfter(12)))
-	s.Equal(1, len(buffer.ListAfter(13)))
+	s.Equal(2, len(listAfter(s.T(), buffer, 11)))
+	s.Equal(2, len(listAfter(s.T(), buffer, 12)))
+	s.Equal(1, len(listAfter(s.T(), buffer, 13)))
 	entryNum, memorySize = buffer.Size()
 	s.EqualValues(2, entryNum)
 	s.EqualValues(234, memorySize)
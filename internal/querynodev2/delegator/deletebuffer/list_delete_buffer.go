@@ -20,7 +20,9 @@ import (
 	"sync"
 
 	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 )
 
@@ -44,12 +46,17 @@ type listDeleteBuffer[T timed] struct {
 	safeTs       uint64
 	sizePerBlock int64
 
-	// cached metrics
+	// cached metrics, size is the memory size of the blocks not spilled
 	rowNum int64
 	size   int64
 
 	// metrics labels
 	labels []string
+
+	// the earliest blocks are spilled by spiller once the memory size exceeds spillSize,
+	// nil spiller means never spill
+	spiller   blockSpiller[T]
+	spillSize int64
 }
 
 func (b *listDeleteBuffer[T]) updateMetrics() {
@@ -70,18 +77,76 @@ func (b *listDeleteBuffer[T]) Put(entry T) {
 	// update metrics
 	b.rowNum += entry.EntryNum()
 	b.size += entry.Size()
+	if b.spiller != nil && b.size > b.spillSize {
+		b.spill()
+	}
 	b.updateMetrics()
 }
 
-func (b *listDeleteBuffer[T]) ListAfter(ts uint64) []T {
+// spill spills the earliest blocks until the memory size is within spillSize,
+// the tail block is never spilled since it's being written.
+func (b *listDeleteBuffer[T]) spill() {
+	for _, block := range b.list[:len(b.list)-1] {
+		if b.size <= b.spillSize {
+			return
+		}
+		if block.spilled() || len(block.data) == 0 {
+			continue
+		}
+		path, err := b.spiller.Spill(block.data)
+		if err != nil {
+			log.Warn("failed to spill delete buffer block, keep it in memory",
+				zap.Strings("labels", b.labels), zap.Uint64("headTs", block.headTs), zap.Error(err))
+			return
+		}
+		_, memSize := block.Size()
+		block.spillPath, block.data = path, nil
+		b.size -= memSize
+	}
+}
+
+// RangeAfter implements DeleteBuffer.
+// The blocks of which all the entries are before ts are skipped, and the lock is only held while reading a block,
+// so at most one spilled block is loaded into memory at a time.
+func (b *listDeleteBuffer[T]) RangeAfter(ts uint64, fn func(T) error) error {
+	b.mut.RLock()
+	blocks := make([]*cacheBlock[T], len(b.list))
+	copy(blocks, b.list)
+	b.mut.RUnlock()
+
+	for _, block := range blocks {
+		entries, err := b.listBlockAfter(block, ts)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *listDeleteBuffer[T]) listBlockAfter(block *cacheBlock[T], ts uint64) ([]T, error) {
 	b.mut.RLock()
 	defer b.mut.RUnlock()
 
-	var result []T
-	for _, block := range b.list {
-		result = append(result, block.ListAfter(ts)...)
+	// the entries of the discarded blocks are before the ts of all the segments,
+	// and the ones of the blocks before ts are never listed
+	if block.discarded || block.tailTs < ts {
+		return nil, nil
 	}
-	return result
+	if !block.spilled() {
+		return block.ListAfter(ts), nil
+	}
+	data, err := b.spiller.Load(block.spillPath)
+	if err != nil {
+		log.Warn("failed to load spilled delete buffer block",
+			zap.Strings("labels", b.labels), zap.String("path", block.spillPath), zap.Error(err))
+		return nil, err
+	}
+	return newCacheBlock[T](block.headTs, block.maxSize, data...).ListAfter(ts), nil
 }
 
 func (b *listDeleteBuffer[T]) SafeTs() uint64 {
@@ -107,9 +172,15 @@ func (b *listDeleteBuffer[T]) TryDiscard(ts uint64) {
 
 	if nextHead > 0 {
 		for idx := 0; idx < nextHead; idx++ {
-			rowNum, memSize := b.list[idx].Size()
+			block := b.list[idx]
+			rowNum, memSize := block.Size()
 			b.rowNum -= rowNum
-			b.size -= memSize
+			if block.spilled() {
+				b.removeSpilled(block)
+			} else {
+				b.size -= memSize
+			}
+			block.discarded = true
 			b.list[idx] = nil
 		}
 		b.list = b.list[nextHead:]
@@ -123,3 +194,24 @@ func (b *listDeleteBuffer[T]) Size() (entryNum, memorySize int64) {
 
 	return b.rowNum, b.size
 }
+
+func (b *listDeleteBuffer[T]) Close() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	for _, block := range b.list {
+		if block.spilled() {
+			b.removeSpilled(block)
+		}
+	}
+	if b.spiller != nil {
+		b.spiller.Close()
+	}
+}
+
+func (b *listDeleteBuffer[T]) removeSpilled(block *cacheBlock[T]) {
+	if err := b.spiller.Remove(block.spillPath); err != nil {
+		log.Warn("failed to remove spilled delete buffer block",
+			zap.Strings("labels", b.labels), zap.String("path", block.spillPath), zap.Error(err))
+	}
+}
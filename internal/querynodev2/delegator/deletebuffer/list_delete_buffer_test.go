@@ -17,8 +17,11 @@
 package deletebuffer
 
 import (
+	"os"
+	"path"
 	"testing"
 
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus/internal/storage"
@@ -60,8 +63,8 @@ func (s *ListDeleteBufferSuite) TestCache() {
 		},
 	})
 
-	s.Equal(2, len(buffer.ListAfter(11)))
-	s.Equal(1, len(buffer.ListAfter(12)))
+	s.Equal(2, len(listAfter(s.T(), buffer, 11)))
+	s.Equal(1, len(listAfter(s.T(), buffer, 12)))
 	entryNum, memorySize := buffer.Size()
 	s.EqualValues(0, entryNum)
 	s.EqualValues(192, memorySize)
@@ -97,35 +100,147 @@ func (s *ListDeleteBufferSuite) TestTryDiscard() {
 		},
 	})
 
-	s.Equal(2, len(buffer.ListAfter(10)))
+	s.Equal(2, len(listAfter(s.T(), buffer, 10)))
 	entryNum, memorySize := buffer.Size()
 	s.EqualValues(2, entryNum)
 	s.EqualValues(240, memorySize)
 
 	buffer.TryDiscard(10)
-	s.Equal(2, len(buffer.ListAfter(10)), "equal ts shall not discard block")
+	s.Equal(2, len(listAfter(s.T(), buffer, 10)), "equal ts shall not discard block")
 	entryNum, memorySize = buffer.Size()
 	s.EqualValues(2, entryNum)
 	s.EqualValues(240, memorySize)
 
 	buffer.TryDiscard(9)
-	s.Equal(2, len(buffer.ListAfter(10)), "history ts shall not discard any block")
+	s.Equal(2, len(listAfter(s.T(), buffer, 10)), "history ts shall not discard any block")
 	entryNum, memorySize = buffer.Size()
 	s.EqualValues(2, entryNum)
 	s.EqualValues(240, memorySize)
 
 	buffer.TryDiscard(20)
-	s.Equal(1, len(buffer.ListAfter(10)), "first block shall be discarded")
+	s.Equal(1, len(listAfter(s.T(), buffer, 10)), "first block shall be discarded")
 	entryNum, memorySize = buffer.Size()
 	s.EqualValues(1, entryNum)
 	s.EqualValues(120, memorySize)
 
 	buffer.TryDiscard(20)
-	s.Equal(1, len(buffer.ListAfter(10)), "discard will not happen if there is only one block")
+	s.Equal(1, len(listAfter(s.T(), buffer, 10)), "discard will not happen if there is only one block")
 	s.EqualValues(1, entryNum)
 	s.EqualValues(120, memorySize)
 }
 
+func (s *ListDeleteBufferSuite) TestSpill() {
+	dir := path.Join(s.T().TempDir(), "spill")
+	buffer, err := NewSpillableListDeleteBuffer(10, 1, []string{"1", "dml-1"}, dir, 200)
+	s.Require().NoError(err)
+	for _, ts := range []uint64{10, 20, 30} {
+		buffer.Put(&Item{
+			Ts: ts,
+			Data: []BufferItem{
+				{
+					PartitionID: 200,
+					DeleteData:  *storage.NewDeleteData([]storage.PrimaryKey{storage.NewInt64PrimaryKey(int64(ts))}, []uint64{ts}),
+				},
+			},
+		})
+	}
+
+	// the first two blocks are spilled
+	entryNum, memorySize := buffer.Size()
+	s.EqualValues(3, entryNum)
+	s.EqualValues(120, memorySize)
+	entries, err := os.ReadDir(dir)
+	s.NoError(err)
+	s.Len(entries, 2)
+
+	items := listAfter(s.T(), buffer, 10)
+	s.Require().Len(items, 3)
+	for i, ts := range []uint64{10, 20, 30} {
+		s.Equal(ts, items[i].Ts)
+		s.EqualValues(200, items[i].Data[0].PartitionID)
+		s.Equal(storage.NewInt64PrimaryKey(int64(ts)), items[i].Data[0].DeleteData.Pks[0])
+		s.Equal([]uint64{ts}, items[i].Data[0].DeleteData.Tss)
+	}
+	s.Len(listAfter(s.T(), buffer, 20), 2)
+
+	buffer.TryDiscard(20)
+	entryNum, memorySize = buffer.Size()
+	s.EqualValues(2, entryNum)
+	s.EqualValues(120, memorySize)
+	entries, err = os.ReadDir(dir)
+	s.NoError(err)
+	s.Len(entries, 1)
+
+	buffer.Close()
+	_, err = os.Stat(dir)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *ListDeleteBufferSuite) TestSpillLoadFailed() {
+	buffer, err := NewSpillableListDeleteBuffer(10, 1, []string{"1", "dml-1"}, path.Join(s.T().TempDir(), "spill"), 200)
+	s.Require().NoError(err)
+	defer buffer.Close()
+	for _, ts := range []uint64{10, 20, 30} {
+		buffer.Put(&Item{
+			Ts: ts,
+			Data: []BufferItem{
+				{
+					PartitionID: 200,
+					DeleteData:  *storage.NewDeleteData([]storage.PrimaryKey{storage.NewInt64PrimaryKey(int64(ts))}, []uint64{ts}),
+				},
+			},
+		})
+	}
+	head := buffer.(*listDeleteBuffer[*Item]).list[0]
+	s.Require().True(head.spilled())
+	s.Require().NoError(os.Remove(head.spillPath))
+
+	// the block before ts is skipped without loading
+	s.Len(listAfter(s.T(), buffer, 20), 2)
+
+	err = buffer.RangeAfter(10, func(*Item) error { return nil })
+	s.Error(err)
+
+	// stops at the error returned by fn
+	mockErr := errors.New("mock error")
+	var called int
+	err = buffer.RangeAfter(20, func(*Item) error {
+		called++
+		return mockErr
+	})
+	s.ErrorIs(err, mockErr)
+	s.Equal(1, called)
+}
+
+func (s *ListDeleteBufferSuite) TestSpillerCorrupted() {
+	spiller := &itemSpiller{dir: s.T().TempDir()}
+	file, err := spiller.Spill([]*Item{{
+		Ts: 10,
+		Data: []BufferItem{
+			{
+				PartitionID: 200,
+				DeleteData:  *storage.NewDeleteData([]storage.PrimaryKey{storage.NewVarCharPrimaryKey("pk")}, []uint64{10}),
+			},
+		},
+	}})
+	s.Require().NoError(err)
+	items, err := spiller.Load(file)
+	s.NoError(err)
+	s.Require().Len(items, 1)
+	s.Equal(storage.NewVarCharPrimaryKey("pk"), items[0].Data[0].DeleteData.Pks[0])
+
+	content, err := os.ReadFile(file)
+	s.Require().NoError(err)
+	content[0]++
+	s.Require().NoError(os.WriteFile(file, content, 0o600))
+	_, err = spiller.Load(file)
+	s.Error(err)
+
+	s.NoError(spiller.Remove(file))
+	_, err = spiller.Load(file)
+	s.Error(err)
+}
+
 func TestListDeleteBuffer(t *testing.T) {
 	suite.Run(t, new(ListDeleteBufferSuite))
 }
@@ -0,0 +1,195 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletebuffer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// blockSpiller spills the data of the delete buffer blocks to the files and loads them back.
+type blockSpiller[T timed] interface {
+	// Spill writes the data into a new file, returns the path of it
+	Spill(data []T) (string, error)
+	Load(path string) ([]T, error)
+	Remove(path string) error
+	// Close removes all the files spilled
+	Close()
+}
+
+// NewSpillableListDeleteBuffer returns a list delete buffer, which spills the earliest blocks into the files under dir
+// once the memory size of the deletes buffered exceeds spillSize.
+// The files left in dir are removed, since the deletes are consumed from the checkpoint again after restarting.
+func NewSpillableListDeleteBuffer(startTs uint64, sizePerBlock int64, labels []string, dir string, spillSize int64) (DeleteBuffer[*Item], error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	buffer := &listDeleteBuffer[*Item]{
+		safeTs:       startTs,
+		sizePerBlock: sizePerBlock,
+		list:         []*cacheBlock[*Item]{newCacheBlock[*Item](startTs, sizePerBlock)},
+		labels:       labels,
+		spiller:      &itemSpiller{dir: dir},
+		spillSize:    spillSize,
+	}
+	return buffer, nil
+}
+
+// itemSpiller spills the items into the append-only files, each file holds a block,
+// and ends with the crc32 checksum of the items.
+type itemSpiller struct {
+	dir string
+}
+
+func (s *itemSpiller) Spill(items []*Item) (string, error) {
+	f, err := os.CreateTemp(s.dir, "*.delta")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+
+	checksum := crc32.NewIEEE()
+	w := bufio.NewWriter(io.MultiWriter(f, checksum))
+	err = writeItems(w, items)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = binary.Write(f, binary.LittleEndian, checksum.Sum32())
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+func (s *itemSpiller) Load(path string) ([]*Item, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < 4 {
+		return nil, errors.Newf("spilled delete buffer file %s truncated", path)
+	}
+	payload := content[:len(content)-4]
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(content[len(content)-4:]) {
+		return nil, errors.Newf("spilled delete buffer file %s corrupted", path)
+	}
+	return readItems(bytes.NewReader(payload))
+}
+
+func (s *itemSpiller) Remove(path string) error {
+	return os.Remove(path)
+}
+
+func (s *itemSpiller) Close() {
+	os.RemoveAll(s.dir)
+}
+
+// writeItems writes the items in the format:
+// item number, then for each item: ts, buffer item number, and for each buffer item:
+// partition id, the length and the bytes of the pks marshaled as schemapb.IDs, the tss number and the tss.
+func writeItems(w io.Writer, items []*Item) error {
+	if err := binary.Write(w, binary.LittleEndian, int64(len(items))); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := binary.Write(w, binary.LittleEndian, []int64{int64(item.Ts), int64(len(item.Data))}); err != nil {
+			return err
+		}
+		for _, data := range item.Data {
+			ids, err := proto.Marshal(storage.ParsePrimaryKeys2IDs(data.DeleteData.Pks))
+			if err != nil {
+				return err
+			}
+			if err := binary.Write(w, binary.LittleEndian, []int64{data.PartitionID, int64(len(ids))}); err != nil {
+				return err
+			}
+			if _, err := w.Write(ids); err != nil {
+				return err
+			}
+			if err := binary.Write(w, binary.LittleEndian, int64(len(data.DeleteData.Tss))); err != nil {
+				return err
+			}
+			if err := binary.Write(w, binary.LittleEndian, data.DeleteData.Tss); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readItems(r io.Reader) ([]*Item, error) {
+	var itemNum int64
+	if err := binary.Read(r, binary.LittleEndian, &itemNum); err != nil {
+		return nil, err
+	}
+	items := make([]*Item, 0, itemNum)
+	for i := int64(0); i < itemNum; i++ {
+		header := make([]int64, 2)
+		if err := binary.Read(r, binary.LittleEndian, header); err != nil {
+			return nil, err
+		}
+		ts, dataNum := uint64(header[0]), header[1]
+		item := &Item{Ts: ts, Data: make([]BufferItem, 0, dataNum)}
+		for j := int64(0); j < dataNum; j++ {
+			if err := binary.Read(r, binary.LittleEndian, header); err != nil {
+				return nil, err
+			}
+			partitionID, idsLen := header[0], header[1]
+			buf := make([]byte, idsLen)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return nil, err
+			}
+			ids := &schemapb.IDs{}
+			if err := proto.Unmarshal(buf, ids); err != nil {
+				return nil, err
+			}
+			var tssNum int64
+			if err := binary.Read(r, binary.LittleEndian, &tssNum); err != nil {
+				return nil, err
+			}
+			tss := make([]uint64, tssNum)
+			if err := binary.Read(r, binary.LittleEndian, tss); err != nil {
+				return nil, err
+			}
+			item.Data = append(item.Data, BufferItem{
+				PartitionID: partitionID,
+				DeleteData:  *storage.NewDeleteData(storage.ParseIDs2PrimaryKeys(ids), tss),
+			})
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
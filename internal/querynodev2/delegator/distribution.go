@@ -88,6 +88,8 @@ type SegmentEntry struct {
 	Version       int64
 	TargetVersion int64
 	Level         datapb.SegmentLevel
+	// the sealed segment only holds the rows with the partition keys hashed into the range if set
+	PartitionKeyRange *datapb.PartitionKeyRange
 }
 
 // NewDistribution creates a new distribution instance with all field initialized.
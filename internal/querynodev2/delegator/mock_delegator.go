@@ -241,6 +241,61 @@ func (_c *MockShardDelegator_GetPartitionStatsVersions_Call) RunAndReturn(run fu
 	return _c
 }
 
+// GetSearchFanOut provides a mock function with given fields:
+func (_m *MockShardDelegator) GetSearchFanOut() (int64, int64) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchFanOut")
+	}
+
+	var r0 int64
+	var r1 int64
+	if rf, ok := ret.Get(0).(func() (int64, int64)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() int64); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	return r0, r1
+}
+
+// MockShardDelegator_GetSearchFanOut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchFanOut'
+type MockShardDelegator_GetSearchFanOut_Call struct {
+	*mock.Call
+}
+
+// GetSearchFanOut is a helper method to define mock.On call
+func (_e *MockShardDelegator_Expecter) GetSearchFanOut() *MockShardDelegator_GetSearchFanOut_Call {
+	return &MockShardDelegator_GetSearchFanOut_Call{Call: _e.mock.On("GetSearchFanOut")}
+}
+
+func (_c *MockShardDelegator_GetSearchFanOut_Call) Run(run func()) *MockShardDelegator_GetSearchFanOut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockShardDelegator_GetSearchFanOut_Call) Return(searches int64, segments int64) *MockShardDelegator_GetSearchFanOut_Call {
+	_c.Call.Return(searches, segments)
+	return _c
+}
+
+func (_c *MockShardDelegator_GetSearchFanOut_Call) RunAndReturn(run func() (int64, int64)) *MockShardDelegator_GetSearchFanOut_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSegmentInfo provides a mock function with given fields: readable
 func (_m *MockShardDelegator) GetSegmentInfo(readable bool) ([]SnapshotItem, []SegmentEntry) {
 	ret := _m.Called(readable)
@@ -500,6 +555,52 @@ func (_c *MockShardDelegator_LoadSegments_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// MarkSegmentsOffline provides a mock function with given fields: segmentIDs
+func (_m *MockShardDelegator) MarkSegmentsOffline(segmentIDs ...int64) {
+	_va := make([]interface{}, len(segmentIDs))
+	for _i := range segmentIDs {
+		_va[_i] = segmentIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// MockShardDelegator_MarkSegmentsOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSegmentsOffline'
+type MockShardDelegator_MarkSegmentsOffline_Call struct {
+	*mock.Call
+}
+
+// MarkSegmentsOffline is a helper method to define mock.On call
+//   - segmentIDs ...int64
+func (_e *MockShardDelegator_Expecter) MarkSegmentsOffline(segmentIDs ...interface{}) *MockShardDelegator_MarkSegmentsOffline_Call {
+	return &MockShardDelegator_MarkSegmentsOffline_Call{Call: _e.mock.On("MarkSegmentsOffline",
+		append([]interface{}{}, segmentIDs...)...)}
+}
+
+func (_c *MockShardDelegator_MarkSegmentsOffline_Call) Run(run func(segmentIDs ...int64)) *MockShardDelegator_MarkSegmentsOffline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]int64, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(int64)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockShardDelegator_MarkSegmentsOffline_Call) Return() *MockShardDelegator_MarkSegmentsOffline_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShardDelegator_MarkSegmentsOffline_Call) RunAndReturn(run func(...int64)) *MockShardDelegator_MarkSegmentsOffline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProcessDelete provides a mock function with given fields: deleteData, ts
 func (_m *MockShardDelegator) ProcessDelete(deleteData []*DeleteData, ts uint64) {
 	_m.Called(deleteData, ts)
@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// partitionKeyHashValues returns the hash values of the partition keys the plan is filtered by,
+// the second return value is false if the plan matches the rows of any partition key.
+func partitionKeyHashValues(serializedPlan []byte, hashFunction string) ([]uint32, bool) {
+	if len(serializedPlan) == 0 {
+		return nil, false
+	}
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return nil, false
+	}
+	expr, err := exprutil.ParseExprFromPlan(plan)
+	if err != nil || expr == nil {
+		return nil, false
+	}
+	keys, ok := partitionKeysOf(expr)
+	if !ok {
+		return nil, false
+	}
+
+	// the same hash as the proxy groups the rows by, see typeutil.HashKeys
+	hashValues := make([]uint32, 0, len(keys))
+	for _, key := range keys {
+		switch v := key.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			hashValues = append(hashValues, typeutil.HashInt64PartitionKey(v.Int64Val, hashFunction))
+		case *planpb.GenericValue_StringVal:
+			hashValues = append(hashValues, typeutil.HashStringPartitionKey(v.StringVal, hashFunction))
+		default:
+			return nil, false
+		}
+	}
+	return hashValues, true
+}
+
+// partitionKeysOf returns the partition keys which the rows matched by the expr must have one of,
+// the second return value is false if the rows of any partition key may be matched.
+// Unlike exprutil.ParseKeys, an OR is only constrained if both sides are, so no matched row is missed.
+func partitionKeysOf(expr *planpb.Expr) ([]*planpb.GenericValue, bool) {
+	switch expr := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		if !expr.TermExpr.GetColumnInfo().GetIsPartitionKey() || expr.TermExpr.GetIsInField() {
+			return nil, false
+		}
+		return expr.TermExpr.GetValues(), true
+	case *planpb.Expr_UnaryRangeExpr:
+		if !expr.UnaryRangeExpr.GetColumnInfo().GetIsPartitionKey() || expr.UnaryRangeExpr.GetOp() != planpb.OpType_Equal {
+			return nil, false
+		}
+		return []*planpb.GenericValue{expr.UnaryRangeExpr.GetValue()}, true
+	case *planpb.Expr_BinaryExpr:
+		left, leftOk := partitionKeysOf(expr.BinaryExpr.GetLeft())
+		right, rightOk := partitionKeysOf(expr.BinaryExpr.GetRight())
+		switch expr.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			// the union is a superset of the intersection if both sides are constrained
+			if leftOk || rightOk {
+				return append(left, right...), true
+			}
+		case planpb.BinaryExpr_LogicalOr:
+			if leftOk && rightOk {
+				return append(left, right...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// pruneByPartitionKeys removes the sealed segments whose partition key range recorded at allocation
+// contains none of the hash values of the partition keys the plan is filtered by.
+// Segments without partition key range are kept.
+func pruneByPartitionKeys(ctx context.Context, schema *schemapb.CollectionSchema, serializedPlan []byte, sealed []SnapshotItem) []SnapshotItem {
+	hashFunction, err := common.PartitionKeyHashFunction(schema.GetProperties()...)
+	if err != nil {
+		return sealed
+	}
+	hashValues, ok := partitionKeyHashValues(serializedPlan, hashFunction)
+	if !ok {
+		return sealed
+	}
+
+	total, pruned := 0, 0
+	result := make([]SnapshotItem, 0, len(sealed))
+	for _, item := range sealed {
+		segments := lo.Filter(item.Segments, func(entry SegmentEntry, _ int) bool {
+			keyRange := entry.PartitionKeyRange
+			if keyRange == nil {
+				return true
+			}
+			return lo.ContainsBy(hashValues, func(hashValue uint32) bool {
+				return int64(hashValue) >= keyRange.GetBegin() && int64(hashValue) < keyRange.GetEnd()
+			})
+		})
+		total += len(item.Segments)
+		pruned += len(item.Segments) - len(segments)
+		result = append(result, SnapshotItem{
+			NodeID:   item.NodeID,
+			Segments: segments,
+		})
+	}
+	if pruned > 0 {
+		log.Ctx(ctx).Debug("pruned segments by partition key range",
+			zap.Int("prunedSegmentNum", pruned),
+			zap.Int("totalSegmentNum", total))
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package delegator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/testutil"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestPruneByPartitionKeys(t *testing.T) {
+	schema := testutil.ConstructCollectionSchemaWithKeys("test_partition_key_prune",
+		map[string]schemapb.DataType{
+			"pk":  schemapb.DataType_Int64,
+			"age": schemapb.DataType_Int64,
+			"vec": schemapb.DataType_FloatVector,
+		}, "pk", "age", "", false, 8)
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+
+	serialize := func(exprStr string) []byte {
+		planNode, err := planparserv2.CreateRetrievePlan(schemaHelper, exprStr, nil)
+		assert.NoError(t, err)
+		serializedPlan, err := proto.Marshal(planNode)
+		assert.NoError(t, err)
+		return serializedPlan
+	}
+
+	sealedOf := func(hashValue uint32) []SnapshotItem {
+		return []SnapshotItem{{
+			NodeID: 1,
+			Segments: []SegmentEntry{
+				{SegmentID: 1, PartitionKeyRange: &datapb.PartitionKeyRange{Begin: int64(hashValue), End: int64(hashValue) + 1}},
+				{SegmentID: 2, PartitionKeyRange: &datapb.PartitionKeyRange{Begin: int64(hashValue) + 1, End: int64(hashValue) + 2}},
+				{SegmentID: 3},
+			},
+		}}
+	}
+	segmentIDs := func(sealed []SnapshotItem) []int64 {
+		ids := make([]int64, 0)
+		for _, item := range sealed {
+			for _, entry := range item.Segments {
+				ids = append(ids, entry.SegmentID)
+			}
+		}
+		return ids
+	}
+
+	hashValue := typeutil.HashInt64PartitionKey(156, "")
+	ctx := context.TODO()
+
+	// the segments whose key range holds none of the keys are pruned
+	sealed := pruneByPartitionKeys(ctx, schema, serialize("age == 156"), sealedOf(hashValue))
+	assert.ElementsMatch(t, []int64{1, 3}, segmentIDs(sealed))
+	sealed = pruneByPartitionKeys(ctx, schema, serialize("age in [156] && pk > 10"), sealedOf(hashValue))
+	assert.ElementsMatch(t, []int64{1, 3}, segmentIDs(sealed))
+
+	// an OR with an unconstrained side matches the rows of any partition key
+	sealed = pruneByPartitionKeys(ctx, schema, serialize("age == 156 || pk > 10"), sealedOf(hashValue))
+	assert.ElementsMatch(t, []int64{1, 2, 3}, segmentIDs(sealed))
+	sealed = pruneByPartitionKeys(ctx, schema, serialize("age > 156"), sealedOf(hashValue))
+	assert.ElementsMatch(t, []int64{1, 2, 3}, segmentIDs(sealed))
+
+	// the keys are hashed by the hash function of the collection
+	schema.Properties = []*commonpb.KeyValuePair{{Key: common.PartitionKeyHashFunctionKey, Value: common.PartitionKeyHashXXHash}}
+	xxHashValue := typeutil.HashInt64PartitionKey(156, common.PartitionKeyHashXXHash)
+	sealed = pruneByPartitionKeys(ctx, schema, serialize("age == 156"), sealedOf(xxHashValue))
+	assert.ElementsMatch(t, []int64{1, 3}, segmentIDs(sealed))
+}
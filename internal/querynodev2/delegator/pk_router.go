@@ -0,0 +1,111 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/querynodev2/pkoracle"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// ExtractPrimaryKeys returns the primary keys of a retrieve plan whose predicate
+// is a pure primary key lookup, i.e. `pk in [...]` or `pk == x`.
+// The second return value is false when the plan contains any other predicate.
+func ExtractPrimaryKeys(plan *planpb.PlanNode) ([]storage.PrimaryKey, bool) {
+	query := plan.GetQuery()
+	if query == nil || query.GetIsCount() {
+		return nil, false
+	}
+
+	var values []*planpb.GenericValue
+	switch expr := query.GetPredicates().GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		if !expr.TermExpr.GetColumnInfo().GetIsPrimaryKey() || expr.TermExpr.GetIsInField() {
+			return nil, false
+		}
+		values = expr.TermExpr.GetValues()
+	case *planpb.Expr_UnaryRangeExpr:
+		if !expr.UnaryRangeExpr.GetColumnInfo().GetIsPrimaryKey() || expr.UnaryRangeExpr.GetOp() != planpb.OpType_Equal {
+			return nil, false
+		}
+		values = []*planpb.GenericValue{expr.UnaryRangeExpr.GetValue()}
+	default:
+		return nil, false
+	}
+
+	pks := make([]storage.PrimaryKey, 0, len(values))
+	for _, value := range values {
+		switch v := value.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			pks = append(pks, storage.NewInt64PrimaryKey(v.Int64Val))
+		case *planpb.GenericValue_StringVal:
+			pks = append(pks, storage.NewVarCharPrimaryKey(v.StringVal))
+		default:
+			return nil, false
+		}
+	}
+	return pks, true
+}
+
+// routeByPrimaryKeys narrows the readable segments of a query filtering by pk only
+// to the ones whose bloom filters may contain any of the requested pks.
+// Segments not registered in pkOracle yet are kept, so the result is always a superset
+// of the segments holding the data.
+// It only prunes the segments, the query is still parsed by the proxy
+// and retrieved by the expression on the pruned segments as any other query.
+func (sd *shardDelegator) routeByPrimaryKeys(serializedPlan []byte, sealed []SnapshotItem, growing []SegmentEntry) ([]SnapshotItem, []SegmentEntry, bool) {
+	if !paramtable.Get().QueryNodeCfg.EnableRetrievePKRouting.GetAsBool() || len(serializedPlan) == 0 {
+		return sealed, growing, false
+	}
+
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return sealed, growing, false
+	}
+	pks, ok := ExtractPrimaryKeys(plan)
+	if !ok {
+		return sealed, growing, false
+	}
+
+	hits := sd.pkOracle.BatchGet(pks)
+	mayContain := func(entry SegmentEntry, state commonpb.SegmentState, nodeID int64) bool {
+		if !sd.pkOracle.Exists(pkoracle.NewCandidateKey(entry.SegmentID, entry.PartitionID, state), nodeID) {
+			return true
+		}
+		return lo.Contains(hits[entry.SegmentID], true)
+	}
+
+	routedSealed := make([]SnapshotItem, 0, len(sealed))
+	for _, item := range sealed {
+		segments := lo.Filter(item.Segments, func(entry SegmentEntry, _ int) bool {
+			return mayContain(entry, commonpb.SegmentState_Sealed, item.NodeID)
+		})
+		routedSealed = append(routedSealed, SnapshotItem{
+			NodeID:   item.NodeID,
+			Segments: segments,
+		})
+	}
+	routedGrowing := lo.Filter(growing, func(entry SegmentEntry, _ int) bool {
+		return mayContain(entry, commonpb.SegmentState_Growing, paramtable.GetNodeID())
+	})
+	return routedSealed, routedGrowing, true
+}
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/querynodev2/pkoracle"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type PKRouterSuite struct {
+	suite.Suite
+
+	sd *shardDelegator
+}
+
+func (s *PKRouterSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *PKRouterSuite) SetupTest() {
+	s.sd = &shardDelegator{
+		pkOracle: pkoracle.NewPkOracle(),
+	}
+	register := func(segmentID int64, state commonpb.SegmentState, nodeID int64, pks ...int64) {
+		bfs := pkoracle.NewBloomFilterSet(segmentID, 1, state)
+		primaryKeys := make([]storage.PrimaryKey, 0, len(pks))
+		for _, pk := range pks {
+			primaryKeys = append(primaryKeys, storage.NewInt64PrimaryKey(pk))
+		}
+		bfs.UpdateBloomFilter(primaryKeys)
+		s.sd.pkOracle.Register(bfs, nodeID)
+	}
+	register(100, commonpb.SegmentState_Sealed, 1, 1, 2, 3)
+	register(101, commonpb.SegmentState_Sealed, 2, 1000, 1001)
+	register(200, commonpb.SegmentState_Growing, paramtable.GetNodeID(), 5000, 5001)
+}
+
+func (s *PKRouterSuite) termPlan(pks ...int64) *planpb.PlanNode {
+	values := make([]*planpb.GenericValue, 0, len(pks))
+	for _, pk := range pks {
+		values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: pk}})
+	}
+	return &planpb.PlanNode{
+		Node: &planpb.PlanNode_Query{
+			Query: &planpb.QueryPlanNode{
+				Predicates: &planpb.Expr{
+					Expr: &planpb.Expr_TermExpr{
+						TermExpr: &planpb.TermExpr{
+							ColumnInfo: &planpb.ColumnInfo{FieldId: 100, IsPrimaryKey: true},
+							Values:     values,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *PKRouterSuite) TestExtractPrimaryKeys() {
+	pks, ok := ExtractPrimaryKeys(s.termPlan(1, 2))
+	s.True(ok)
+	s.Len(pks, 2)
+
+	equalPlan := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Query{
+			Query: &planpb.QueryPlanNode{
+				Predicates: &planpb.Expr{
+					Expr: &planpb.Expr_UnaryRangeExpr{
+						UnaryRangeExpr: &planpb.UnaryRangeExpr{
+							ColumnInfo: &planpb.ColumnInfo{FieldId: 100, IsPrimaryKey: true},
+							Op:         planpb.OpType_Equal,
+							Value:      &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: "a"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	pks, ok = ExtractPrimaryKeys(equalPlan)
+	s.True(ok)
+	s.True(pks[0].EQ(storage.NewVarCharPrimaryKey("a")))
+
+	equalPlan.GetQuery().GetPredicates().GetUnaryRangeExpr().Op = planpb.OpType_GreaterThan
+	_, ok = ExtractPrimaryKeys(equalPlan)
+	s.False(ok)
+
+	nonPkPlan := s.termPlan(1)
+	nonPkPlan.GetQuery().GetPredicates().GetTermExpr().GetColumnInfo().IsPrimaryKey = false
+	_, ok = ExtractPrimaryKeys(nonPkPlan)
+	s.False(ok)
+
+	countPlan := s.termPlan(1)
+	countPlan.GetQuery().IsCount = true
+	_, ok = ExtractPrimaryKeys(countPlan)
+	s.False(ok)
+}
+
+func (s *PKRouterSuite) TestRouteByPrimaryKeys() {
+	sealed := []SnapshotItem{
+		{NodeID: 1, Segments: []SegmentEntry{{NodeID: 1, SegmentID: 100, PartitionID: 1}}},
+		{NodeID: 2, Segments: []SegmentEntry{{NodeID: 2, SegmentID: 101, PartitionID: 1}, {NodeID: 2, SegmentID: 102, PartitionID: 1}}},
+	}
+	growing := []SegmentEntry{{NodeID: paramtable.GetNodeID(), SegmentID: 200, PartitionID: 1}}
+
+	plan, err := proto.Marshal(s.termPlan(2))
+	s.Require().NoError(err)
+
+	routedSealed, routedGrowing, routed := s.sd.routeByPrimaryKeys(plan, sealed, growing)
+	s.True(routed)
+	s.Len(routedSealed, 2)
+	s.Equal([]SegmentEntry{{NodeID: 1, SegmentID: 100, PartitionID: 1}}, routedSealed[0].Segments)
+	// segment 102 is not registered in pk oracle, keep it
+	s.Equal([]SegmentEntry{{NodeID: 2, SegmentID: 102, PartitionID: 1}}, routedSealed[1].Segments)
+	s.Empty(routedGrowing)
+
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.EnableRetrievePKRouting.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.EnableRetrievePKRouting.Key)
+	routedSealed, routedGrowing, routed = s.sd.routeByPrimaryKeys(plan, sealed, growing)
+	s.False(routed)
+	s.Equal(sealed, routedSealed)
+	s.Equal(growing, routedGrowing)
+}
+
+func TestPKRouter(t *testing.T) {
+	suite.Run(t, new(PKRouterSuite))
+}
@@ -0,0 +1,72 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/deletefeedback"
+	"github.com/milvus-io/milvus/internal/util/nodefeedback"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// maxReportedSegments caps the segments of a report, the ones of the highest deleted ratio are kept.
+const maxReportedSegments = 1000
+
+// deleteReporter reports the sealed segments of which the deleted ratio exceeds the single compaction threshold
+// to datacoord periodically, so they are compacted without waiting for their deltalogs.
+type deleteReporter = nodefeedback.Reporter[*deletefeedback.SegmentDeletes]
+
+func newDeleteReporter(nodeID int64, manager *segments.Manager, metaKV kv.BaseKV) *deleteReporter {
+	params := &paramtable.Get().QueryNodeCfg
+	return nodefeedback.NewReporter(nodeID, deletefeedback.NewStore(metaKV), &params.DeleteFeedbackEnabled, &params.DeleteFeedbackInterval,
+		func() []*deletefeedback.SegmentDeletes { return collectSegmentDeletes(manager) })
+}
+
+// collectSegmentDeletes returns the sealed segments of which the deleted ratio exceeds the threshold,
+// the lazy loaded ones are skipped as their deleted rows are unknown until loaded.
+func collectSegmentDeletes(manager *segments.Manager) []*deletefeedback.SegmentDeletes {
+	threshold := paramtable.Get().DataCoordCfg.SingleCompactionRatioThreshold.GetAsFloat()
+	ret := make([]*deletefeedback.SegmentDeletes, 0)
+	for _, segment := range manager.Segment.GetBy(segments.WithType(segments.SegmentTypeSealed)) {
+		if segment.Level() == datapb.SegmentLevel_L0 || segment.IsLazyLoad() {
+			continue
+		}
+		if err := segment.PinIfNotReleased(); err != nil {
+			continue
+		}
+		insertCount := segment.InsertCount()
+		deletes := &deletefeedback.SegmentDeletes{
+			SegmentID:    segment.ID(),
+			CollectionID: segment.Collection(),
+			RowCount:     insertCount,
+			DeletedCount: max(insertCount-segment.RowNum(), 0),
+		}
+		segment.Unpin()
+		if deletes.DeletedCount > 0 && deletes.Ratio() >= threshold {
+			ret = append(ret, deletes)
+		}
+	}
+	if len(ret) > maxReportedSegments {
+		sort.Slice(ret, func(i, j int) bool { return ret[i].Ratio() > ret[j].Ratio() })
+		ret = ret[:maxReportedSegments]
+	}
+	return ret
+}
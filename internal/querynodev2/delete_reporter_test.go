@@ -0,0 +1,76 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/deletefeedback"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestDeleteReporter(t *testing.T) {
+	paramtable.Init()
+	newSegment := func(id int64, level datapb.SegmentLevel, insertCount, rowNum int64) segments.Segment {
+		segment := segments.NewMockSegment(t)
+		segment.EXPECT().Level().Return(level)
+		if level == datapb.SegmentLevel_L0 {
+			return segment
+		}
+		segment.EXPECT().IsLazyLoad().Return(false)
+		segment.EXPECT().PinIfNotReleased().Return(nil)
+		segment.EXPECT().Unpin().Return()
+		segment.EXPECT().ID().Return(id)
+		segment.EXPECT().Collection().Return(int64(1001))
+		segment.EXPECT().InsertCount().Return(insertCount)
+		segment.EXPECT().RowNum().Return(rowNum)
+		return segment
+	}
+
+	segmentManager := segments.NewMockSegmentManager(t)
+	segmentManager.EXPECT().GetBy(mock.Anything).Return([]segments.Segment{
+		newSegment(1, datapb.SegmentLevel_L1, 100, 70),
+		newSegment(2, datapb.SegmentLevel_L1, 100, 90),
+		newSegment(3, datapb.SegmentLevel_L0, 0, 0),
+	})
+	metaKV := memkv.NewMemoryKV()
+	reporter := newDeleteReporter(1, &segments.Manager{Segment: segmentManager}, metaKV)
+
+	ctx := context.Background()
+	require.NoError(t, reporter.Report(ctx))
+	require.NoError(t, reporter.Report(ctx))
+	reports, err := deletefeedback.NewStore(metaKV).LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	// the seq advances with every report
+	assert.EqualValues(t, 2, reports[0].Seq)
+	assert.Equal(t, []*deletefeedback.SegmentDeletes{{SegmentID: 1, CollectionID: 1001, RowCount: 100, DeletedCount: 30}}, reports[0].Items)
+
+	// the report is removed on stop.
+	reporter.Stop()
+	reports, err = deletefeedback.NewStore(metaKV).LoadAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+}
@@ -0,0 +1,75 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
+	"github.com/milvus-io/milvus/internal/util/fanoutfeedback"
+	"github.com/milvus-io/milvus/internal/util/nodefeedback"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// fanOutReporter reports the searches served by the shard delegators of the node within each interval,
+// and the sealed segments they fanned out to, to datacoord periodically,
+// so the small segments of the shards searched over too many segments are merged.
+type fanOutReporter = nodefeedback.Reporter[*fanoutfeedback.ShardFanOut]
+
+func newFanOutReporter(nodeID int64, delegators *typeutil.ConcurrentMap[string, delegator.ShardDelegator], metaKV kv.BaseKV) *fanOutReporter {
+	collector := &fanOutCollector{
+		delegators: delegators,
+		last:       make(map[delegator.ShardDelegator]*fanoutfeedback.ShardFanOut),
+	}
+	params := &paramtable.Get().QueryNodeCfg
+	return nodefeedback.NewReporter(nodeID, fanoutfeedback.NewStore(metaKV), &params.FanOutFeedbackEnabled, &params.FanOutFeedbackInterval,
+		collector.collect)
+}
+
+// fanOutCollector computes the fan out of the shards since the last report.
+type fanOutCollector struct {
+	delegators *typeutil.ConcurrentMap[string, delegator.ShardDelegator]
+	// the cumulative fan out of each delegator at the last report, only accessed by the report loop
+	last map[delegator.ShardDelegator]*fanoutfeedback.ShardFanOut
+}
+
+func (c *fanOutCollector) collect() []*fanoutfeedback.ShardFanOut {
+	ret := make([]*fanoutfeedback.ShardFanOut, 0)
+	current := make(map[delegator.ShardDelegator]*fanoutfeedback.ShardFanOut)
+	c.delegators.Range(func(channel string, sd delegator.ShardDelegator) bool {
+		searches, segments := sd.GetSearchFanOut()
+		total := &fanoutfeedback.ShardFanOut{
+			CollectionID: sd.Collection(),
+			Channel:      channel,
+			Searches:     searches,
+			Segments:     segments,
+		}
+		current[sd] = total
+		delta := *total
+		if last, ok := c.last[sd]; ok {
+			delta.Searches -= last.Searches
+			delta.Segments -= last.Segments
+		}
+		if delta.Searches > 0 {
+			ret = append(ret, &delta)
+		}
+		return true
+	})
+	// the delegators released are dropped
+	c.last = current
+	return ret
+}
@@ -0,0 +1,78 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
+	"github.com/milvus-io/milvus/internal/util/fanoutfeedback"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestFanOutReporter(t *testing.T) {
+	paramtable.Init()
+	var searches, segments int64
+	sd := delegator.NewMockShardDelegator(t)
+	sd.EXPECT().Collection().Return(int64(1001))
+	sd.EXPECT().GetSearchFanOut().RunAndReturn(func() (int64, int64) {
+		return searches, segments
+	})
+	idle := delegator.NewMockShardDelegator(t)
+	idle.EXPECT().Collection().Return(int64(1002))
+	idle.EXPECT().GetSearchFanOut().Return(0, 0)
+
+	delegators := typeutil.NewConcurrentMap[string, delegator.ShardDelegator]()
+	delegators.Insert("ch1", sd)
+	delegators.Insert("ch2", idle)
+	metaKV := memkv.NewMemoryKV()
+	reporter := newFanOutReporter(1, delegators, metaKV)
+
+	ctx := context.Background()
+	load := func() *fanoutfeedback.Report {
+		reports, err := fanoutfeedback.NewStore(metaKV).LoadAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		return reports[0]
+	}
+
+	searches, segments = 4, 40
+	require.NoError(t, reporter.Report(ctx))
+	report := load()
+	assert.EqualValues(t, 1, report.Seq)
+	// the shards not searched are not reported
+	assert.Equal(t, []*fanoutfeedback.ShardFanOut{{CollectionID: 1001, Channel: "ch1", Searches: 4, Segments: 40}}, report.Items)
+
+	// only the searches since the last report
+	searches, segments = 6, 50
+	require.NoError(t, reporter.Report(ctx))
+	report = load()
+	assert.EqualValues(t, 2, report.Seq)
+	assert.Equal(t, []*fanoutfeedback.ShardFanOut{{CollectionID: 1001, Channel: "ch1", Searches: 2, Segments: 10}}, report.Items)
+
+	// the report is removed on stop.
+	reporter.Stop()
+	reports, err := fanoutfeedback.NewStore(metaKV).LoadAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+}
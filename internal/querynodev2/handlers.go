@@ -147,6 +147,29 @@ func (node *QueryNode) loadDeltaLogs(ctx context.Context, req *querypb.LoadSegme
 	return merr.Success()
 }
 
+// catchUpWarmedSegments loads the delta logs of the segments in the request which were warmed up by the warm pool,
+// the segments loaded by the delegators already see all the delta logs.
+func (node *QueryNode) catchUpWarmedSegments(ctx context.Context, req *querypb.LoadSegmentsRequest) *commonpb.Status {
+	infos := lo.Filter(req.GetInfos(), func(info *querypb.SegmentLoadInfo, _ int) bool {
+		return node.warmedSegments.Contain(info.GetSegmentID())
+	})
+	if len(infos) == 0 {
+		return merr.Success()
+	}
+
+	status := node.loadDeltaLogs(ctx, &querypb.LoadSegmentsRequest{
+		CollectionID: req.GetCollectionID(),
+		Version:      req.GetVersion(),
+		Infos:        infos,
+	})
+	if merr.Ok(status) {
+		for _, info := range infos {
+			node.warmedSegments.Remove(info.GetSegmentID())
+		}
+	}
+	return status
+}
+
 func (node *QueryNode) loadIndex(ctx context.Context, req *querypb.LoadSegmentsRequest) *commonpb.Status {
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", req.GetCollectionID()),
@@ -255,7 +278,7 @@ func (node *QueryNode) queryChannel(ctx context.Context, req *querypb.QueryReque
 	))
 
 	latency := tr.ElapseSpan()
-	metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.Leader).Observe(float64(latency.Milliseconds()))
+	metrics.ObserveWithRequestTags(ctx, metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.Leader), float64(latency.Milliseconds()))
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.SuccessLabel, metrics.Leader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
 	return resp, nil
 }
@@ -408,7 +431,7 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 
 	// update metric to prometheus
 	latency := tr.ElapseSpan()
-	metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.Leader).Observe(float64(latency.Milliseconds()))
+	metrics.ObserveWithRequestTags(ctx, metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.Leader), float64(latency.Milliseconds()))
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.SuccessLabel, metrics.Leader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
 	metrics.QueryNodeSearchNQ.WithLabelValues(fmt.Sprint(node.GetNodeID())).Observe(float64(req.Req.GetNq()))
 	metrics.QueryNodeSearchTopK.WithLabelValues(fmt.Sprint(node.GetNodeID())).Observe(float64(req.Req.GetTopk()))
@@ -524,3 +547,16 @@ func reduceStatisticResponse(results []*internalpb.GetStatisticsResponse) (*inte
 	}
 	return ret, nil
 }
+
+// markSegmentOffline marks the quarantined segment offline in the shard delegator if it runs on this querynode.
+func (node *QueryNode) markSegmentOffline(segment segments.Segment) {
+	channel := segment.Shard().VirtualName()
+	sd, ok := node.delegators.Get(channel)
+	if !ok {
+		log.Warn("shard delegator of the quarantined segment is not on this querynode",
+			zap.Int64("segmentID", segment.ID()),
+			zap.String("channel", channel))
+		return
+	}
+	sd.MarkSegmentsOffline(segment.ID())
+}
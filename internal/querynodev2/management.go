@@ -0,0 +1,164 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// this file contains querynode management restful API handler
+var mgrRouteRegisterOnce sync.Once
+
+func RegisterMgrRoute(node *QueryNode) {
+	mgrRouteRegisterOnce.Do(func() {
+		router := management.NewManagementRouter(typeutil.QueryNodeRole)
+		router.Register(&management.Handler{
+			Path:        management.RouteQueryNodeSegments,
+			HandlerFunc: node.ListSegmentStats,
+		}, http.MethodGet)
+		router.Register(&management.Handler{
+			Path:        management.RouteQueryNodeSegmentEvents,
+			HandlerFunc: node.ListSegmentEvents,
+		}, http.MethodGet)
+	})
+}
+
+type segmentIndexStats struct {
+	FieldID  int64 `json:"field_id"`
+	IndexID  int64 `json:"index_id"`
+	IsLoaded bool  `json:"is_loaded"`
+}
+
+type segmentStats struct {
+	SegmentID    int64                `json:"segment_id"`
+	CollectionID int64                `json:"collection_id"`
+	PartitionID  int64                `json:"partition_id"`
+	Channel      string               `json:"channel"`
+	Type         string               `json:"type"`
+	Level        string               `json:"level"`
+	RowCount     int64                `json:"row_count"`
+	DeletedCount int64                `json:"deleted_count"`
+	MemSize      int64                `json:"mem_size"`
+	MmapSize     int64                `json:"mmap_size"`
+	Indexes      []*segmentIndexStats `json:"indexes"`
+	IsLazyLoad   bool                 `json:"is_lazy_load"`
+}
+
+// ListSegmentStats lists the statistics of the segments loaded, the segments of a collection if collection_id given.
+func (node *QueryNode) ListSegmentStats(w http.ResponseWriter, req *http.Request) {
+	var filters []segments.SegmentFilter
+	if collection := req.URL.Query().Get("collection_id"); collection != "" {
+		collectionID, err := strconv.ParseInt(collection, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "invalid collection_id, %s"}`, err.Error())))
+			return
+		}
+		filters = append(filters, segments.SegmentFilterFunc(func(segment segments.Segment) bool {
+			return segment.Collection() == collectionID
+		}))
+	}
+
+	stats := make([]*segmentStats, 0)
+	for _, segment := range node.manager.Segment.GetBy(filters...) {
+		if stat, ok := getSegmentStats(segment); ok {
+			stats = append(stats, stat)
+		}
+	}
+
+	bytes, err := json.Marshal(stats)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list segment stats, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+// getSegmentStats returns false if the segment is released.
+func getSegmentStats(segment segments.Segment) (*segmentStats, bool) {
+	if err := segment.PinIfNotReleased(); err != nil {
+		return nil, false
+	}
+	defer segment.Unpin()
+
+	indexes := make([]*segmentIndexStats, 0, len(segment.Indexes()))
+	for _, index := range segment.Indexes() {
+		indexes = append(indexes, &segmentIndexStats{
+			FieldID:  index.IndexInfo.GetFieldID(),
+			IndexID:  index.IndexInfo.GetIndexID(),
+			IsLoaded: index.IsLoaded,
+		})
+	}
+	// the row count excludes the deleted rows, which are unknown until the lazy loaded segment loaded
+	rowCount, deletedCount := segment.InsertCount(), int64(0)
+	if !segment.IsLazyLoad() {
+		rowCount = segment.RowNum()
+		deletedCount = max(segment.InsertCount()-rowCount, 0)
+	}
+	return &segmentStats{
+		SegmentID:    segment.ID(),
+		CollectionID: segment.Collection(),
+		PartitionID:  segment.Partition(),
+		Channel:      segment.Shard().VirtualName(),
+		Type:         segment.Type().String(),
+		Level:        segment.Level().String(),
+		RowCount:     rowCount,
+		DeletedCount: deletedCount,
+		MemSize:      segment.MemSize(),
+		MmapSize:     segment.MmapSize(),
+		Indexes:      indexes,
+		IsLazyLoad:   segment.IsLazyLoad(),
+	}, true
+}
+
+// ListSegmentEvents lists the latest events of creating, loading and releasing the segments,
+// the events of a collection or a segment if collection_id or segment_id given.
+func (node *QueryNode) ListSegmentEvents(w http.ResponseWriter, req *http.Request) {
+	ids := make(map[string]int64)
+	for _, key := range []string{"collection_id", "segment_id"} {
+		value := req.URL.Query().Get(key)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "invalid %s, %s"}`, key, err.Error())))
+			return
+		}
+		ids[key] = id
+	}
+
+	bytes, err := json.Marshal(segments.ListSegmentEvents(ids["collection_id"], ids["segment_id"]))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list segment events, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+)
+
+func TestListSegmentStats(t *testing.T) {
+	channel, err := metautil.ParseChannel("by-dev-rootcoord-dml_0_1001v0", metautil.NewDynChannelMapper())
+	require.NoError(t, err)
+	segment := segments.NewMockSegment(t)
+	segment.EXPECT().PinIfNotReleased().Return(nil)
+	segment.EXPECT().Unpin().Return()
+	segment.EXPECT().ID().Return(int64(1))
+	segment.EXPECT().Collection().Return(int64(1001))
+	segment.EXPECT().Partition().Return(int64(2001))
+	segment.EXPECT().Shard().Return(channel)
+	segment.EXPECT().Type().Return(segments.SegmentTypeSealed)
+	segment.EXPECT().Level().Return(datapb.SegmentLevel_L1)
+	segment.EXPECT().InsertCount().Return(int64(100))
+	segment.EXPECT().RowNum().Return(int64(80))
+	segment.EXPECT().MemSize().Return(int64(1024))
+	segment.EXPECT().MmapSize().Return(int64(512))
+	segment.EXPECT().IsLazyLoad().Return(false)
+	segment.EXPECT().Indexes().Return([]*segments.IndexedFieldInfo{
+		{
+			IndexInfo: &querypb.FieldIndexInfo{FieldID: 101, IndexID: 1001},
+			IsLoaded:  true,
+		},
+	})
+	released := segments.NewMockSegment(t)
+	released.EXPECT().PinIfNotReleased().Return(errors.New("released"))
+
+	segmentManager := segments.NewMockSegmentManager(t)
+	segmentManager.EXPECT().GetBy(mock.Anything).Return([]segments.Segment{segment, released})
+	node := &QueryNode{manager: &segments.Manager{Segment: segmentManager}}
+
+	req, err := http.NewRequest(http.MethodGet, management.RouteQueryNodeSegments+"?collection_id=1001", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	node.ListSegmentStats(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var stats []*segmentStats
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &stats))
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(1), stats[0].SegmentID)
+	assert.Equal(t, "by-dev-rootcoord-dml_0_1001v0", stats[0].Channel)
+	assert.Equal(t, "Sealed", stats[0].Type)
+	assert.Equal(t, int64(80), stats[0].RowCount)
+	assert.Equal(t, int64(20), stats[0].DeletedCount)
+	assert.Equal(t, int64(1024), stats[0].MemSize)
+	assert.Equal(t, []*segmentIndexStats{{FieldID: 101, IndexID: 1001, IsLoaded: true}}, stats[0].Indexes)
+	assert.False(t, stats[0].IsLazyLoad)
+
+	req, err = http.NewRequest(http.MethodGet, management.RouteQueryNodeSegments+"?collection_id=abc", nil)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	node.ListSegmentStats(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestListSegmentEvents(t *testing.T) {
+	node := &QueryNode{}
+	req, err := http.NewRequest(http.MethodGet, management.RouteQueryNodeSegmentEvents+"?collection_id=1001&segment_id=1", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	node.ListSegmentEvents(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var events []*segments.SegmentEvent
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &events))
+	assert.Empty(t, events)
+
+	req, err = http.NewRequest(http.MethodGet, management.RouteQueryNodeSegmentEvents+"?segment_id=abc", nil)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	node.ListSegmentEvents(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -66,6 +66,7 @@ func getQuotaMetrics(node *QueryNode) (*metricsinfo.QueryNodeQuotaMetrics, error
 
 	metrics.QueryNodeNumEntities.Reset()
 	metrics.QueryNodeEntitiesSize.Reset()
+	metrics.QueryNodeEntitiesMmapSize.Reset()
 
 	var totalGrowingSize int64
 	growingSegments := node.manager.Segment.GetBy(segments.WithType(segments.SegmentTypeGrowing))
@@ -111,6 +112,11 @@ func getQuotaMetrics(node *QueryNode) (*metricsinfo.QueryNodeQuotaMetrics, error
 		})
 		metrics.QueryNodeEntitiesSize.WithLabelValues(fmt.Sprint(node.GetNodeID()),
 			fmt.Sprint(collection), segments.SegmentTypeSealed.String()).Set(float64(size))
+		mmapSize := lo.SumBy(segs, func(seg segments.Segment) int64 {
+			return seg.MmapSize()
+		})
+		metrics.QueryNodeEntitiesMmapSize.WithLabelValues(fmt.Sprint(node.GetNodeID()),
+			fmt.Sprint(collection)).Set(float64(mmapSize))
 	}
 	sealedGroupByPartition := lo.GroupBy(sealedSegments, func(seg segments.Segment) int64 {
 		return seg.Partition()
@@ -205,6 +211,7 @@ func getSegmentJSON(node *QueryNode) string {
 			CollectionID:         s.Collection(),
 			PartitionID:          s.Partition(),
 			MemSize:              s.MemSize(),
+			MmapSize:             s.MmapSize(),
 			IndexedFields:        indexes,
 			State:                s.Type().String(),
 			ResourceGroup:        s.ResourceGroup(),
@@ -85,6 +85,39 @@ func (iNode *insertNode) addInsertData(insertDatas map[UniqueID]*delegator.Inser
 		zap.Uint64("timestampMax", msg.EndTimestamp))
 }
 
+// markUpserts marks the inserted rows which come with a deletion of the same pk at the same timestamp,
+// which is how proxy issues upsert.
+func (iNode *insertNode) markUpserts(insertDatas map[UniqueID]*delegator.InsertData, deleteMsgs []*DeleteMsg) {
+	type rowKey struct {
+		pk any
+		ts uint64
+	}
+	if len(deleteMsgs) == 0 {
+		return
+	}
+
+	deleted := make(map[rowKey]int64)
+	for _, msg := range deleteMsgs {
+		pks := storage.ParseIDs2PrimaryKeys(msg.GetPrimaryKeys())
+		for i, pk := range pks {
+			deleted[rowKey{pk: pk.GetValue(), ts: msg.GetTimestamps()[i]}] = msg.GetPartitionID()
+		}
+	}
+
+	for _, iData := range insertDatas {
+		for i, pk := range iData.PrimaryKeys {
+			partitionID, ok := deleted[rowKey{pk: pk.GetValue(), ts: iData.Timestamps[i]}]
+			if !ok {
+				continue
+			}
+			if iData.Upserts == nil {
+				iData.Upserts = make(map[int]int64)
+			}
+			iData.Upserts[i] = partitionID
+		}
+	}
+}
+
 // Insert task
 func (iNode *insertNode) Operate(in Msg) Msg {
 	metrics.QueryNodeWaitProcessingMsgCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.InsertLabel).Dec()
@@ -109,6 +142,7 @@ func (iNode *insertNode) Operate(in Msg) Msg {
 				iNode.addInsertData(nodeMsg.insertDatas, msg, collection)
 			}
 		}
+		iNode.markUpserts(nodeMsg.insertDatas, nodeMsg.deleteMsgs)
 
 		iNode.delegator.ProcessInsert(nodeMsg.insertDatas)
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -129,6 +130,25 @@ func (suite *InsertNodeSuite) TestDataTypeNotSupported() {
 	})
 }
 
+func (suite *InsertNodeSuite) TestMarkUpserts() {
+	insertDatas := map[int64]*delegator.InsertData{
+		4: {
+			PrimaryKeys: []storage.PrimaryKey{
+				storage.NewInt64PrimaryKey(1),
+				storage.NewInt64PrimaryKey(2),
+				storage.NewInt64PrimaryKey(5),
+			},
+			Timestamps: []uint64{0, 10, 0},
+		},
+	}
+	deleteMsgs := []*DeleteMsg{buildDeleteMsg(suite.collectionID, suite.partitionID, suite.channel, 2)}
+
+	node := newInsertNode(suite.collectionID, suite.channel, suite.manager, suite.delegator, 8)
+	node.markUpserts(insertDatas, deleteMsgs)
+	// only the row with the same pk and timestamp is upserted
+	suite.Equal(map[int]int64{0: suite.partitionID}, insertDatas[4].Upserts)
+}
+
 func (suite *InsertNodeSuite) buildInsertNodeMsg(schema *schemapb.CollectionSchema) *insertNodeMsg {
 	nodeMsg := insertNodeMsg{
 		insertMsgs: []*InsertMsg{},
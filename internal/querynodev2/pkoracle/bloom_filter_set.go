@@ -38,8 +38,14 @@ type BloomFilterSet struct {
 	segmentID    int64
 	paritionID   int64
 	segType      commonpb.SegmentState
-	currentStat  *storage.PkStatistics
-	historyStats []*storage.PkStatistics
+	expectedRows uint
+	// bloom filter size and false positive rate set in the collection properties, 0 if not set
+	filterSize    uint
+	falsePositive float64
+	currentStat   *storage.PkStatistics
+	historyStats  []*storage.PkStatistics
+	// max timestamp of the rows loaded from binlogs, 0 if unknown
+	maxTimestamp uint64
 }
 
 // MayPkExist returns whether any bloom filters returns positive.
@@ -89,16 +95,36 @@ func (s *BloomFilterSet) Type() commonpb.SegmentState {
 	return s.segType
 }
 
+// MaxTimestamp returns the max timestamp of the rows loaded from binlogs, 0 if unknown.
+func (s *BloomFilterSet) MaxTimestamp() uint64 {
+	return s.maxTimestamp
+}
+
+// SetMaxTimestamp sets the max timestamp of the rows loaded from binlogs.
+func (s *BloomFilterSet) SetMaxTimestamp(ts uint64) {
+	s.maxTimestamp = ts
+}
+
 // UpdateBloomFilter updates currentStats with provided pks.
 func (s *BloomFilterSet) UpdateBloomFilter(pks []storage.PrimaryKey) {
 	s.statsMutex.Lock()
 	defer s.statsMutex.Unlock()
 
 	if s.currentStat == nil {
+		capacity := paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint()
+		if s.filterSize > 0 {
+			capacity = s.filterSize
+		} else if s.expectedRows > 0 {
+			capacity = s.expectedRows
+		}
+		falsePositive := paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat()
+		if s.falsePositive > 0 {
+			falsePositive = s.falsePositive
+		}
 		s.currentStat = &storage.PkStatistics{
 			PkFilter: bloomfilter.NewBloomFilterWithType(
-				paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint(),
-				paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(),
+				capacity,
+				falsePositive,
 				paramtable.Get().CommonCfg.BloomFilterType.GetValue(),
 			),
 		}
@@ -122,6 +148,17 @@ func (s *BloomFilterSet) UpdateBloomFilter(pks []storage.PrimaryKey) {
 	}
 }
 
+// SetFilterParams sets the size and false positive rate of the current bloom filter,
+// which override bloomFilterSize and maxBloomFalsePositive if positive.
+// It shall be called before the current bloom filter is initialized.
+func (s *BloomFilterSet) SetFilterParams(size uint, falsePositive float64) {
+	s.statsMutex.Lock()
+	defer s.statsMutex.Unlock()
+
+	s.filterSize = size
+	s.falsePositive = falsePositive
+}
+
 // AddHistoricalStats add loaded historical stats.
 func (s *BloomFilterSet) AddHistoricalStats(stats *storage.PkStatistics) {
 	s.statsMutex.Lock()
@@ -140,3 +177,11 @@ func NewBloomFilterSet(segmentID int64, paritionID int64, segType commonpb.Segme
 	// does not need to init current
 	return bfs
 }
+
+// NewBloomFilterSetWithExpectedRows returns a BloomFilterSet,
+// the bloom filter of which is sized by the expected row count instead of bloomFilterSize.
+func NewBloomFilterSetWithExpectedRows(segmentID int64, paritionID int64, segType commonpb.SegmentState, expectedRows uint) *BloomFilterSet {
+	bfs := NewBloomFilterSet(segmentID, paritionID, segType)
+	bfs.expectedRows = expectedRows
+	return bfs
+}
@@ -51,6 +51,37 @@ func TestInt64Pk(t *testing.T) {
 	assert.Equal(t, commonpb.SegmentState_Sealed, bfs.Type())
 }
 
+func TestExpectedRows(t *testing.T) {
+	paramtable.Init()
+	pks := []storage.PrimaryKey{storage.NewInt64PrimaryKey(1)}
+
+	bfs := NewBloomFilterSet(1, 1, commonpb.SegmentState_Growing)
+	bfs.UpdateBloomFilter(pks)
+	sized := NewBloomFilterSetWithExpectedRows(1, 1, commonpb.SegmentState_Growing, 10*paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint())
+	sized.UpdateBloomFilter(pks)
+	assert.Greater(t, sized.currentStat.PkFilter.Cap(), bfs.currentStat.PkFilter.Cap())
+	assert.True(t, sized.MayPkExist(storage.NewLocationsCache(pks[0])))
+}
+
+func TestFilterParams(t *testing.T) {
+	paramtable.Init()
+	pks := []storage.PrimaryKey{storage.NewInt64PrimaryKey(1)}
+
+	bfs := NewBloomFilterSet(1, 1, commonpb.SegmentState_Growing)
+	bfs.UpdateBloomFilter(pks)
+
+	precise := NewBloomFilterSet(1, 1, commonpb.SegmentState_Growing)
+	precise.SetFilterParams(0, paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat()/100)
+	precise.UpdateBloomFilter(pks)
+	assert.Greater(t, precise.currentStat.PkFilter.Cap(), bfs.currentStat.PkFilter.Cap())
+
+	sized := NewBloomFilterSetWithExpectedRows(1, 1, commonpb.SegmentState_Growing, 10*paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint())
+	sized.SetFilterParams(paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint(), 0)
+	sized.UpdateBloomFilter(pks)
+	assert.Equal(t, bfs.currentStat.PkFilter.Cap(), sized.currentStat.PkFilter.Cap())
+	assert.True(t, sized.MayPkExist(storage.NewLocationsCache(pks[0])))
+}
+
 func TestVarCharPk(t *testing.T) {
 	paramtable.Init()
 	batchSize := 100
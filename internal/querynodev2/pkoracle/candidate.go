@@ -34,9 +34,18 @@ type Candidate interface {
 	Type() commonpb.SegmentState
 }
 
+// timestampBounded is implemented by the candidates knowing the max timestamp of their rows,
+// e.g. the sealed segments loaded from binlogs.
+type timestampBounded interface {
+	// MaxTimestamp returns the max timestamp of the rows, 0 if unknown.
+	MaxTimestamp() uint64
+}
+
 type candidateWithWorker struct {
 	Candidate
 	workerID int64
+	// registerSeq orders the registration with the upsert records of pkOracle
+	registerSeq uint64
 }
 
 // CandidateFilter filter type for candidate.
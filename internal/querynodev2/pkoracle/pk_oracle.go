@@ -19,8 +19,14 @@ package pkoracle
 
 import (
 	"fmt"
+	"slices"
+	"sync"
+
+	"go.uber.org/atomic"
 
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -29,6 +35,14 @@ type PkOracle interface {
 	// GetCandidates returns segment candidates of which pk might belongs to.
 	Get(pk storage.PrimaryKey, filters ...CandidateFilter) ([]int64, error)
 	BatchGet(pks []storage.PrimaryKey, filters ...CandidateFilter) map[int64][]bool
+	// BatchGetLatest works like BatchGet for deletions at provided timestamps,
+	// but skips the segments which could only hold versions superseded by upsert.
+	BatchGetLatest(pks []storage.PrimaryKey, tss []uint64, filters ...CandidateFilter) map[int64][]bool
+	// Supersede records that pk is upserted into segment at ts,
+	// which deleted the older versions in partitionID (or all partitions).
+	Supersede(segmentID int64, partitionID int64, pk storage.PrimaryKey, ts uint64)
+	// Invalidate drops the upsert record of pk once it's inserted into another segment at ts.
+	Invalidate(segmentID int64, pk storage.PrimaryKey, ts uint64)
 	// RegisterCandidate adds candidate into pkOracle.
 	Register(candidate Candidate, workerID int64) error
 	// RemoveCandidate removes candidate
@@ -39,9 +53,45 @@ type PkOracle interface {
 
 var _ PkOracle = (*pkOracle)(nil)
 
+// pkVersion records the segment holding the latest version of an upserted pk.
+type pkVersion struct {
+	segmentID int64
+	// partition scope of the deletion issued by upsert
+	partitionID int64
+	ts          uint64
+	// seq orders the record with the registration of the candidates
+	seq uint64
+}
+
+// supersedes returns whether the versions in provided candidate are deleted by the upsert,
+// which requires the rows of the candidate to be older than the upsert. They are if the candidate was
+// registered before the upsert, the newer rows inserted into it would have invalidated the record.
+// The candidates registered later, e.g. the sealed or imported segments loaded after the upsert,
+// never go through Invalidate, and are only skipped if the max timestamp of their rows is before the upsert.
+func (v pkVersion) supersedes(candidate candidateWithWorker) bool {
+	if candidate.ID() == v.segmentID ||
+		(v.partitionID != common.AllPartitionsID && v.partitionID != candidate.Partition()) {
+		return false
+	}
+	if candidate.registerSeq < v.seq {
+		return true
+	}
+	bounded, ok := candidate.Candidate.(timestampBounded)
+	if !ok {
+		return false
+	}
+	maxTs := bounded.MaxTimestamp()
+	return maxTs > 0 && maxTs < v.ts
+}
+
 // pkOracle implementation.
 type pkOracle struct {
 	candidates *typeutil.ConcurrentMap[string, candidateWithWorker]
+	// pk value => latest upserted version, bounded by queryNode.upsertPkVersionCapacity
+	versions *typeutil.ConcurrentMap[any, pkVersion]
+	evicting sync.Mutex
+	// seq increases on each registration and upsert record
+	seq atomic.Uint64
 }
 
 // Get implements PkOracle.
@@ -65,7 +115,35 @@ func (pko *pkOracle) Get(pk storage.PrimaryKey, filters ...CandidateFilter) ([]i
 }
 
 func (pko *pkOracle) BatchGet(pks []storage.PrimaryKey, filters ...CandidateFilter) map[int64][]bool {
+	result, _ := pko.batchGet(pks, filters...)
+	return result
+}
+
+// BatchGetLatest implements PkOracle.
+func (pko *pkOracle) BatchGetLatest(pks []storage.PrimaryKey, tss []uint64, filters ...CandidateFilter) map[int64][]bool {
+	result, candidates := pko.batchGet(pks, filters...)
+	if pko.versions.Len() == 0 {
+		return result
+	}
+
+	for i, pk := range pks {
+		version, ok := pko.versions.Get(pk.GetValue())
+		// deletion at the upsert ts itself shall still reach the older versions
+		if !ok || version.ts >= tss[i] {
+			continue
+		}
+		for segmentID, hits := range result {
+			if hits[i] && version.supersedes(candidates[segmentID]) {
+				hits[i] = false
+			}
+		}
+	}
+	return result
+}
+
+func (pko *pkOracle) batchGet(pks []storage.PrimaryKey, filters ...CandidateFilter) (map[int64][]bool, map[int64]candidateWithWorker) {
 	result := make(map[int64][]bool)
+	candidates := make(map[int64]candidateWithWorker)
 
 	lc := storage.NewBatchLocationsCache(pks)
 	pko.candidates.Range(func(key string, candidate candidateWithWorker) bool {
@@ -77,10 +155,71 @@ func (pko *pkOracle) BatchGet(pks []storage.PrimaryKey, filters ...CandidateFilt
 
 		hits := candidate.BatchPkExist(lc)
 		result[candidate.ID()] = hits
+		candidates[candidate.ID()] = candidate
 		return true
 	})
 
-	return result
+	return result, candidates
+}
+
+// Supersede implements PkOracle.
+func (pko *pkOracle) Supersede(segmentID int64, partitionID int64, pk storage.PrimaryKey, ts uint64) {
+	capacity := paramtable.Get().QueryNodeCfg.UpsertPkVersionCapacity.GetAsInt()
+	if capacity <= 0 {
+		return
+	}
+	defer pko.evictVersions(capacity)
+
+	key := pk.GetValue()
+	if version, ok := pko.versions.Get(key); ok && version.ts > ts {
+		return
+	}
+	pko.versions.Insert(key, pkVersion{
+		segmentID:   segmentID,
+		partitionID: partitionID,
+		ts:          ts,
+		seq:         pko.seq.Inc(),
+	})
+}
+
+// evictVersions drops the oldest upsert records once they exceed the capacity, down to 3/4 of it,
+// the later deletions of these pks are then forwarded to the superseded segments again, which is harmless.
+func (pko *pkOracle) evictVersions(capacity int) {
+	if pko.versions.Len() <= capacity || !pko.evicting.TryLock() {
+		return
+	}
+	defer pko.evicting.Unlock()
+
+	tss := make([]uint64, 0, pko.versions.Len())
+	pko.versions.Range(func(_ any, version pkVersion) bool {
+		tss = append(tss, version.ts)
+		return true
+	})
+	evict := len(tss) - capacity*3/4
+	if evict <= 0 {
+		return
+	}
+	slices.Sort(tss)
+	cutoff := tss[evict-1]
+	pko.versions.Range(func(key any, version pkVersion) bool {
+		if version.ts <= cutoff {
+			pko.versions.Remove(key)
+		}
+		return true
+	})
+}
+
+// Invalidate implements PkOracle.
+func (pko *pkOracle) Invalidate(segmentID int64, pk storage.PrimaryKey, ts uint64) {
+	if pko.versions.Len() == 0 {
+		return
+	}
+	key := pk.GetValue()
+	version, ok := pko.versions.Get(key)
+	if !ok || version.ts > ts || version.segmentID == segmentID {
+		return
+	}
+	pko.versions.Remove(key)
 }
 
 func (pko *pkOracle) candidateKey(candidate Candidate, workerID int64) string {
@@ -90,8 +229,9 @@ func (pko *pkOracle) candidateKey(candidate Candidate, workerID int64) string {
 // Register register candidate
 func (pko *pkOracle) Register(candidate Candidate, workerID int64) error {
 	pko.candidates.Insert(pko.candidateKey(candidate, workerID), candidateWithWorker{
-		Candidate: candidate,
-		workerID:  workerID,
+		Candidate:   candidate,
+		workerID:    workerID,
+		registerSeq: pko.seq.Inc(),
 	})
 
 	return nil
@@ -99,6 +239,7 @@ func (pko *pkOracle) Register(candidate Candidate, workerID int64) error {
 
 // Remove removes candidate from pko.
 func (pko *pkOracle) Remove(filters ...CandidateFilter) error {
+	removed := typeutil.NewSet[int64]()
 	pko.candidates.Range(func(key string, candidate candidateWithWorker) bool {
 		for _, filter := range filters {
 			if !filter(candidate) {
@@ -106,6 +247,23 @@ func (pko *pkOracle) Remove(filters ...CandidateFilter) error {
 			}
 		}
 		pko.candidates.GetAndRemove(pko.candidateKey(candidate, candidate.workerID))
+		removed.Insert(candidate.ID())
+		return true
+	})
+
+	if removed.Len() == 0 || pko.versions.Len() == 0 {
+		return nil
+	}
+	// upsert records are only valid while the holding segment is still a candidate,
+	// e.g. the segment could be compacted into another one
+	pko.candidates.Range(func(key string, candidate candidateWithWorker) bool {
+		removed.Remove(candidate.ID())
+		return true
+	})
+	pko.versions.Range(func(key any, version pkVersion) bool {
+		if removed.Contain(version.segmentID) {
+			pko.versions.Remove(key)
+		}
 		return true
 	})
 
@@ -121,5 +279,6 @@ func (pko *pkOracle) Exists(candidate Candidate, workerID int64) bool {
 func NewPkOracle() PkOracle {
 	return &pkOracle{
 		candidates: typeutil.NewConcurrentMap[string, candidateWithWorker](),
+		versions:   typeutil.NewConcurrentMap[any, pkVersion](),
 	}
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -63,3 +64,124 @@ func TestGet(t *testing.T) {
 		assert.NotContains(t, segmentIDs, int64(1))
 	}
 }
+
+func TestBatchGetLatest(t *testing.T) {
+	paramtable.Init()
+	pko := NewPkOracle()
+
+	pk := storage.NewInt64PrimaryKey(1)
+	older := NewBloomFilterSet(1, 10, commonpb.SegmentState_Sealed)
+	older.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	otherPartition := NewBloomFilterSet(2, 11, commonpb.SegmentState_Sealed)
+	otherPartition.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	newer := NewBloomFilterSet(3, 10, commonpb.SegmentState_Growing)
+	newer.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	pko.Register(older, 1)
+	pko.Register(otherPartition, 1)
+	pko.Register(newer, 1)
+
+	pko.Supersede(3, 10, pk, 100)
+
+	// deletion issued by the upsert itself
+	hits := pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{100})
+	assert.True(t, hits[1][0])
+	assert.True(t, hits[2][0])
+	assert.True(t, hits[3][0])
+
+	// later deletion skips versions superseded in the same partition
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{200})
+	assert.False(t, hits[1][0])
+	assert.True(t, hits[2][0])
+	assert.True(t, hits[3][0])
+
+	// older upsert does not override
+	pko.Supersede(1, 10, pk, 50)
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{200})
+	assert.False(t, hits[1][0])
+
+	// insert into the same segment keeps the record
+	pko.Invalidate(3, pk, 150)
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{200})
+	assert.False(t, hits[1][0])
+
+	// insert into another segment drops the record
+	pko.Invalidate(1, pk, 150)
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{200})
+	assert.True(t, hits[1][0])
+
+	// removing the holding segment drops the record
+	pko.Supersede(3, common.AllPartitionsID, pk, 300)
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{400})
+	assert.False(t, hits[1][0])
+	assert.False(t, hits[2][0])
+	pko.Remove(WithSegmentIDs(3))
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{400})
+	assert.True(t, hits[1][0])
+	assert.True(t, hits[2][0])
+}
+
+func TestBatchGetLatestLateRegistered(t *testing.T) {
+	paramtable.Init()
+	pko := NewPkOracle()
+
+	pk := storage.NewInt64PrimaryKey(1)
+	newer := NewBloomFilterSet(1, 10, commonpb.SegmentState_Growing)
+	newer.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	pko.Register(newer, 1)
+	pko.Supersede(1, 10, pk, 100)
+
+	// the segments loaded after the upsert never go through Invalidate,
+	// they are only skipped if all their rows are older than the upsert
+	imported := NewBloomFilterSet(2, 10, commonpb.SegmentState_Sealed)
+	imported.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	imported.SetMaxTimestamp(150)
+	older := NewBloomFilterSet(3, 10, commonpb.SegmentState_Sealed)
+	older.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	older.SetMaxTimestamp(50)
+	unknown := NewBloomFilterSet(4, 10, commonpb.SegmentState_Sealed)
+	unknown.UpdateBloomFilter([]storage.PrimaryKey{pk})
+	pko.Register(imported, 1)
+	pko.Register(older, 1)
+	pko.Register(unknown, 1)
+
+	hits := pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{200})
+	assert.True(t, hits[1][0])
+	assert.True(t, hits[2][0])
+	assert.False(t, hits[3][0])
+	assert.True(t, hits[4][0])
+
+	// the segments registered before a later upsert are skipped
+	pko.Supersede(1, 10, pk, 300)
+	hits = pko.BatchGetLatest([]storage.PrimaryKey{pk}, []uint64{400})
+	assert.True(t, hits[1][0])
+	assert.False(t, hits[2][0])
+	assert.False(t, hits[3][0])
+	assert.False(t, hits[4][0])
+}
+
+func TestEvictVersions(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.UpsertPkVersionCapacity.Key, "8")
+	defer params.Reset(params.QueryNodeCfg.UpsertPkVersionCapacity.Key)
+
+	pko := NewPkOracle().(*pkOracle)
+	for i := 0; i < 8; i++ {
+		pko.Supersede(1, 10, storage.NewInt64PrimaryKey(int64(i)), uint64(i+1))
+	}
+	assert.Equal(t, 8, pko.versions.Len())
+
+	// the oldest records are evicted down to 3/4 of the capacity
+	pko.Supersede(1, 10, storage.NewInt64PrimaryKey(8), 9)
+	assert.Equal(t, 6, pko.versions.Len())
+	_, ok := pko.versions.Get(int64(0))
+	assert.False(t, ok)
+	_, ok = pko.versions.Get(int64(8))
+	assert.True(t, ok)
+
+	// disabled
+	params.Save(params.QueryNodeCfg.UpsertPkVersionCapacity.Key, "0")
+	pko.Supersede(1, 10, storage.NewInt64PrimaryKey(9), 10)
+	_, ok = pko.versions.Get(int64(9))
+	assert.False(t, ok)
+}
@@ -0,0 +1,350 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/searchutil/optimizers"
+	"github.com/milvus-io/milvus/internal/util/segcore"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// recallCalibrator measures the recall of the vector indexes of the loaded collections periodically.
+// For each float vector field, it picks a sealed segment of the field, samples some vectors of it as queries,
+// and compares the index search results of the candidate search params with the brute force results on the segment.
+// The recall curves are kept in the recall tuner, which is used by the delegators to tune the searches with recall target.
+type recallCalibrator struct {
+	manager *segments.Manager
+	tuner   *optimizers.RecallTuner
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newRecallCalibrator(manager *segments.Manager, tuner *optimizers.RecallTuner) *recallCalibrator {
+	return &recallCalibrator{
+		manager: manager,
+		tuner:   tuner,
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (c *recallCalibrator) Start(ctx context.Context) {
+	c.wg.Add(1)
+	go c.loop(ctx)
+}
+
+func (c *recallCalibrator) Stop() {
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+		c.wg.Wait()
+	})
+}
+
+func (c *recallCalibrator) loop(ctx context.Context) {
+	defer c.wg.Done()
+	interval := paramtable.Get().QueryNodeCfg.RecallTuningInterval.GetAsDuration(time.Second)
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.closeCh:
+			return
+		case <-timer.C:
+			if paramtable.Get().QueryNodeCfg.RecallTuningEnabled.GetAsBool() {
+				c.calibrateAll(ctx)
+			}
+			// the interval is refreshable
+			timer.Reset(paramtable.Get().QueryNodeCfg.RecallTuningInterval.GetAsDuration(time.Second))
+		}
+	}
+}
+
+func (c *recallCalibrator) calibrateAll(ctx context.Context) {
+	loaded := typeutil.NewSet(c.manager.Collection.List()...)
+	for _, collectionID := range c.tuner.Collections() {
+		if !loaded.Contain(collectionID) {
+			c.tuner.Remove(collectionID)
+		}
+	}
+
+	for collectionID := range loaded {
+		collection := c.manager.Collection.Get(collectionID)
+		if collection == nil {
+			continue
+		}
+		for _, field := range collection.Schema().GetFields() {
+			if field.GetDataType() != schemapb.DataType_FloatVector {
+				continue
+			}
+			if err := c.calibrate(ctx, collection, field); err != nil {
+				log.Ctx(ctx).Warn("failed to calibrate search params",
+					zap.Int64("collectionID", collectionID),
+					zap.Int64("fieldID", field.GetFieldID()),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+func (c *recallCalibrator) calibrate(ctx context.Context, collection *segments.Collection, field *schemapb.FieldSchema) error {
+	segment, err := c.pinCalibrationSegment(collection.ID(), field.GetFieldID())
+	if err != nil || segment == nil {
+		return err
+	}
+	defer c.manager.Segment.Unpin([]segments.Segment{segment})
+
+	indexParams := funcutil.KeyValuePair2Map(segment.GetIndex(field.GetFieldID()).IndexInfo.GetIndexParams())
+	paramKey, candidates, ok := optimizers.SearchParamCandidates(indexParams[common.IndexTypeKey], indexParams)
+	if !ok {
+		return nil
+	}
+	metricType := indexParams[common.MetricTypeKey]
+	dim, err := typeutil.GetDim(field)
+	if err != nil {
+		return err
+	}
+
+	pks, vectors, err := c.retrieveVectors(ctx, collection, segment, field)
+	if err != nil {
+		return err
+	}
+	rows := len(vectors) / int(dim)
+	topk := paramtable.Get().QueryNodeCfg.RecallTuningTopK.GetAsInt()
+	if rows < topk {
+		return nil
+	}
+
+	// sample the queries from the vectors of the segment
+	nq := paramtable.Get().QueryNodeCfg.RecallTuningSampleQueries.GetAsInt()
+	if nq > rows {
+		nq = rows
+	}
+	queries := make([][]float32, 0, nq)
+	for _, i := range rand.Perm(rows)[:nq] {
+		queries = append(queries, vectors[i*int(dim):(i+1)*int(dim)])
+	}
+
+	truthIndices, err := optimizers.BruteForceTopK(dim, lo.Flatten(queries), vectors, metricType, topk)
+	if err != nil {
+		return err
+	}
+	truth := make([][]any, len(truthIndices))
+	for i, indices := range truthIndices {
+		truth[i] = make([]any, 0, len(indices))
+		for _, idx := range indices {
+			truth[i] = append(truth[i], typeutil.GetPK(pks, int64(idx)))
+		}
+	}
+
+	calibration := &optimizers.Calibration{
+		ParamKey:   paramKey,
+		UpdateTime: time.Now(),
+	}
+	for _, value := range candidates {
+		results, err := c.searchSegment(ctx, collection, segment, field, queries, metricType, int64(topk), fmt.Sprintf(`{"%s": %d}`, paramKey, value))
+		if err != nil {
+			return err
+		}
+		recall := optimizers.ComputeRecall(truth, results)
+		calibration.Points = append(calibration.Points, optimizers.CalibrationPoint{Value: value, Recall: recall})
+		// larger values make no difference
+		if recall >= 1 {
+			break
+		}
+	}
+	c.tuner.Update(collection.ID(), field.GetFieldID(), calibration)
+
+	log.Ctx(ctx).Info("search params calibrated",
+		zap.Int64("collectionID", collection.ID()),
+		zap.Int64("fieldID", field.GetFieldID()),
+		zap.Int64("segmentID", segment.ID()),
+		zap.String("indexType", indexParams[common.IndexTypeKey]),
+		zap.Any("points", calibration.Points))
+	return nil
+}
+
+// pinCalibrationSegment pins the largest sealed segment with the index and raw data of the field loaded,
+// whose row count is not greater than the limit.
+func (c *recallCalibrator) pinCalibrationSegment(collectionID, fieldID int64) (segments.Segment, error) {
+	maxRows := paramtable.Get().QueryNodeCfg.RecallTuningMaxSegmentRows.GetAsInt64()
+	candidates, err := c.manager.Segment.GetAndPinBy(
+		segments.WithType(segments.SegmentTypeSealed),
+		segments.SegmentFilterFunc(func(segment segments.Segment) bool {
+			index := segment.GetIndex(fieldID)
+			return segment.Collection() == collectionID &&
+				index != nil && index.IsLoaded &&
+				segment.HasRawData(fieldID) &&
+				segment.InsertCount() <= maxRows
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var selected segments.Segment
+	for _, segment := range candidates {
+		if selected == nil || segment.InsertCount() > selected.InsertCount() {
+			selected = segment
+		}
+	}
+	for _, segment := range candidates {
+		if segment != selected {
+			c.manager.Segment.Unpin([]segments.Segment{segment})
+		}
+	}
+	return selected, nil
+}
+
+func (c *recallCalibrator) retrieveVectors(ctx context.Context, collection *segments.Collection, segment segments.Segment, field *schemapb.FieldSchema) (*schemapb.IDs, []float32, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(collection.Schema())
+	if err != nil {
+		return nil, nil, err
+	}
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Query{
+			Query: &planpb.QueryPlanNode{
+				Predicates: &planpb.Expr{Expr: &planpb.Expr_AlwaysTrueExpr{AlwaysTrueExpr: &planpb.AlwaysTrueExpr{}}},
+				Limit:      segment.InsertCount(),
+			},
+		},
+		OutputFieldIds: []int64{pkField.GetFieldID(), field.GetFieldID()},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := segcore.NewRetrievePlan(collection.GetCCollection(), expr, typeutil.MaxTimestamp, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer plan.Delete()
+
+	result, err := segment.Retrieve(ctx, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, fieldData := range result.GetFieldsData() {
+		if fieldData.GetFieldId() == field.GetFieldID() {
+			return result.GetIds(), fieldData.GetVectors().GetFloatVector().GetData(), nil
+		}
+	}
+	return nil, nil, merr.WrapErrFieldNotFound(field.GetName(), "vector field not found in the retrieve result")
+}
+
+// searchSegment searches the segment with the search params and returns the primary keys of the results of each query.
+func (c *recallCalibrator) searchSegment(ctx context.Context, collection *segments.Collection, segment segments.Segment, field *schemapb.FieldSchema,
+	queries [][]float32, metricType string, topk int64, searchParams string,
+) ([][]any, error) {
+	expr, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{
+				VectorType: planpb.VectorType_FloatVector,
+				FieldId:    field.GetFieldID(),
+				QueryInfo: &planpb.QueryInfo{
+					Topk:         topk,
+					MetricType:   metricType,
+					SearchParams: searchParams,
+					RoundDecimal: -1,
+				},
+				PlaceholderTag: "$0",
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	values := make([][]byte, 0, len(queries))
+	for _, query := range queries {
+		values = append(values, typeutil.Float32ArrayToBytes(query))
+	}
+	placeholderGroup, err := proto.Marshal(&commonpb.PlaceholderGroup{
+		Placeholders: []*commonpb.PlaceholderValue{{
+			Tag:    "$0",
+			Type:   commonpb.PlaceholderType_FloatVector,
+			Values: values,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nq := int64(len(queries))
+	searchReq, err := segcore.NewSearchRequest(collection.GetCCollection(), &querypb.SearchRequest{
+		Req: &internalpb.SearchRequest{
+			SerializedExprPlan: expr,
+			MvccTimestamp:      typeutil.MaxTimestamp,
+			Nq:                 nq,
+			Topk:               topk,
+		},
+	}, placeholderGroup)
+	if err != nil {
+		return nil, err
+	}
+	defer searchReq.Delete()
+
+	result, err := segment.Search(ctx, searchReq)
+	if err != nil {
+		return nil, err
+	}
+	defer segments.DeleteSearchResults([]*segments.SearchResult{result})
+
+	blobs, err := segcore.ReduceSearchResultsAndFillData(ctx, searchReq.Plan(), []*segments.SearchResult{result}, 1, []int64{nq}, []int64{topk})
+	if err != nil {
+		return nil, err
+	}
+	defer segcore.DeleteSearchResultDataBlobs(blobs)
+	blob, err := segcore.GetSearchResultDataBlob(ctx, blobs, 0)
+	if err != nil {
+		return nil, err
+	}
+	resultData := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(blob, resultData); err != nil {
+		return nil, err
+	}
+
+	results := make([][]any, nq)
+	var offset int64
+	for i, size := range resultData.GetTopks() {
+		for j := offset; j < offset+size; j++ {
+			results[i] = append(results[i], typeutil.GetPK(resultData.GetIds(), j))
+		}
+		offset += size
+	}
+	return results, nil
+}
@@ -0,0 +1,186 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/util/segcore"
+	"github.com/milvus-io/milvus/pkg/eventlog"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	cgoCallSearch   = "Search"
+	cgoCallRetrieve = "Retrieve"
+	cgoCallLoad     = "Load"
+
+	// the weight of the new sample in the average time of the calls
+	cgoCallAverageWeight = 0.1
+
+	// the bounds of the interval to scan the in-flight calls, which is half of the min stuck time
+	cgoWatchdogMinScanInterval = 10 * time.Millisecond
+	cgoWatchdogMaxScanInterval = time.Second
+)
+
+// the states of an in-flight call, changed by the scanner or the caller whichever comes first
+const (
+	cgoCallRunning int32 = iota
+	cgoCallStuck
+	cgoCallReturned
+)
+
+type cgoCall struct {
+	segment   Segment
+	function  string
+	start     time.Time
+	threshold time.Duration
+	state     atomic.Int32
+	// returned is set by the scanner before marking the call stuck, and closed once the stuck call returns
+	returned chan struct{}
+}
+
+// cgoWatchdog tracks the in-flight segcore calls, and reports the ones running much longer than
+// the average time of the same kind of calls, a hung call in segcore is invisible otherwise.
+// The in-flight calls are scanned periodically by one goroutine, started by the first tracked call,
+// so tracking a call costs no timer.
+type cgoWatchdog struct {
+	// function => average time of the calls not stuck
+	averages *typeutil.ConcurrentMap[string, *atomic.Duration]
+	inflight *typeutil.ConcurrentMap[int64, *cgoCall]
+	nextID   atomic.Int64
+	scanOnce sync.Once
+	onStuck  func(segment Segment, function string, elapsed time.Duration, returned <-chan struct{})
+}
+
+func newCGOWatchdog(onStuck func(segment Segment, function string, elapsed time.Duration, returned <-chan struct{})) *cgoWatchdog {
+	return &cgoWatchdog{
+		averages: typeutil.NewConcurrentMap[string, *atomic.Duration](),
+		inflight: typeutil.NewConcurrentMap[int64, *cgoCall](),
+		onStuck:  onStuck,
+	}
+}
+
+// track starts watching the call of the function on the segment, the returned done must be called after the call returns.
+func (w *cgoWatchdog) track(segment Segment, function string) (done func()) {
+	factor := paramtable.Get().QueryNodeCfg.CGOWatchdogStuckFactor.GetAsFloat()
+	if w == nil || factor <= 0 {
+		return func() {}
+	}
+	w.scanOnce.Do(func() {
+		go w.scanLoop()
+	})
+
+	average, _ := w.averages.GetOrInsert(function, atomic.NewDuration(0))
+	call := &cgoCall{
+		segment:  segment,
+		function: function,
+		start:    time.Now(),
+		threshold: max(time.Duration(float64(average.Load())*factor),
+			paramtable.Get().QueryNodeCfg.CGOWatchdogMinStuckTime.GetAsDuration(time.Second)),
+	}
+	id := w.nextID.Inc()
+	w.inflight.Insert(id, call)
+	return func() {
+		w.inflight.Remove(id)
+		elapsed := time.Since(call.start)
+		if !call.state.CompareAndSwap(cgoCallRunning, cgoCallReturned) {
+			close(call.returned)
+			log.Info("stuck segcore call returned",
+				zap.Int64("collectionID", segment.Collection()),
+				zap.Int64("segmentID", segment.ID()),
+				zap.String("function", function),
+				zap.Duration("elapsed", elapsed))
+			return
+		}
+		// the concurrent updates may be lost, which is fine for an estimation
+		if prev := average.Load(); prev > 0 {
+			elapsed = prev + time.Duration(float64(elapsed-prev)*cgoCallAverageWeight)
+		}
+		average.Store(elapsed)
+	}
+}
+
+func (w *cgoWatchdog) scanLoop() {
+	for {
+		interval := paramtable.Get().QueryNodeCfg.CGOWatchdogMinStuckTime.GetAsDuration(time.Second) / 2
+		time.Sleep(min(max(interval, cgoWatchdogMinScanInterval), cgoWatchdogMaxScanInterval))
+		w.scan()
+	}
+}
+
+// scan reports the in-flight calls running longer than their thresholds, each call is reported once.
+func (w *cgoWatchdog) scan() {
+	now := time.Now()
+	w.inflight.Range(func(id int64, call *cgoCall) bool {
+		elapsed := now.Sub(call.start)
+		if elapsed < call.threshold || call.state.Load() != cgoCallRunning {
+			return true
+		}
+		call.returned = make(chan struct{})
+		if call.state.CompareAndSwap(cgoCallRunning, cgoCallStuck) {
+			w.onStuck(call.segment, call.function, elapsed, call.returned)
+		}
+		return true
+	})
+}
+
+// trackCGOCall starts watching the segcore call of the function on the segment,
+// the returned done must be called after the call returns.
+func (mgr *Manager) trackCGOCall(segment Segment, function string) (done func()) {
+	if mgr == nil {
+		return func() {}
+	}
+	return mgr.watchdog.track(segment, function)
+}
+
+// reportStuckCall reports the segcore call running much longer than expected,
+// the sealed segment is quarantined after the call returns if configured.
+func (mgr *Manager) reportStuckCall(segment Segment, function string, elapsed time.Duration, returned <-chan struct{}) {
+	log := log.With(
+		zap.Int64("collectionID", segment.Collection()),
+		zap.Int64("segmentID", segment.ID()),
+		zap.String("segmentType", segment.Type().String()),
+		zap.String("function", function),
+		zap.Duration("elapsed", elapsed),
+	)
+	log.Warn("segcore call is stuck")
+	metrics.QueryNodeStuckCGOCalls.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), function).Inc()
+	eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Warn,
+		fmt.Sprintf("%s on segment %d[%d] is stuck for %s", function, segment.ID(), segment.Collection(), elapsed)))
+
+	if paramtable.Get().QueryNodeCfg.CGOWatchdogDumpNativeStacks.GetAsBool() {
+		log.Warn("native stacks of the stuck segcore call", zap.String("stacks", segcore.DumpThreadStacks()))
+	}
+	// the segment being loaded is not served yet
+	if function != cgoCallLoad && segment.Type() == SegmentTypeSealed &&
+		paramtable.Get().QueryNodeCfg.CGOWatchdogDegradeSegment.GetAsBool() {
+		// the stuck call still uses the segment, which can't be released until the call returns
+		go func() {
+			<-returned
+			mgr.quarantineSegment(segment)
+		}()
+	}
+}
@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestCGOWatchdog(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.CGOWatchdogStuckFactor.Key, "2")
+	params.Save(params.QueryNodeCfg.CGOWatchdogMinStuckTime.Key, "0.05")
+	defer params.Reset(params.QueryNodeCfg.CGOWatchdogStuckFactor.Key)
+	defer params.Reset(params.QueryNodeCfg.CGOWatchdogMinStuckTime.Key)
+
+	segment := NewMockSegment(t)
+	segment.EXPECT().ID().Return(1).Maybe()
+	segment.EXPECT().Collection().Return(100).Maybe()
+	stuck := atomic.NewString("")
+	stuckReturned := make(chan (<-chan struct{}), 1)
+	w := newCGOWatchdog(func(s Segment, function string, elapsed time.Duration, returned <-chan struct{}) {
+		assert.Equal(t, segment, s)
+		assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+		stuck.Store(function)
+		stuckReturned <- returned
+	})
+
+	// the calls done in time
+	done := w.track(segment, cgoCallSearch)
+	done()
+	average, ok := w.averages.Get(cgoCallSearch)
+	assert.True(t, ok)
+	assert.Greater(t, average.Load(), time.Duration(0))
+	assert.Less(t, average.Load(), 50*time.Millisecond)
+
+	// the stuck call is reported, and not counted in the average
+	prev := average.Load()
+	done = w.track(segment, cgoCallRetrieve)
+	assert.Eventually(t, func() bool {
+		return stuck.Load() == cgoCallRetrieve
+	}, time.Second, 10*time.Millisecond)
+	returned := <-stuckReturned
+	select {
+	case <-returned:
+		t.Fatal("the stuck call is not returned yet")
+	default:
+	}
+	done()
+	<-returned
+	assert.Equal(t, 0, w.inflight.Len())
+	average, _ = w.averages.Get(cgoCallRetrieve)
+	assert.Equal(t, time.Duration(0), average.Load())
+	average, _ = w.averages.Get(cgoCallSearch)
+	assert.Equal(t, prev, average.Load())
+
+	// disabled
+	params.Save(params.QueryNodeCfg.CGOWatchdogStuckFactor.Key, "0")
+	stuck.Store("")
+	done = w.track(segment, cgoCallLoad)
+	time.Sleep(100 * time.Millisecond)
+	done()
+	assert.Empty(t, stuck.Load())
+	_, ok = w.averages.Get(cgoCallLoad)
+	assert.False(t, ok)
+
+	var mgr *Manager
+	mgr.trackCGOCall(segment, cgoCallLoad)()
+}
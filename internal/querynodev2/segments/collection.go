@@ -23,6 +23,7 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
@@ -90,6 +91,9 @@ func (m *collectionManager) PutOrRef(collectionID int64, schema *schemapb.Collec
 
 	if collection, ok := m.collections[collectionID]; ok {
 		// the schema may be changed even the collection is loaded
+		if collection.ccollection != nil && !proto.Equal(collection.Schema(), schema) {
+			collection.ccollection.PurgePlanCache()
+		}
 		collection.schema.Store(schema)
 		collection.Ref(1)
 		return
@@ -0,0 +1,229 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// fieldLoader downloads and decodes the field binlogs of the growing segments concurrently,
+// and inserts the decoded rows into segcore, while LoadMultiFieldData loads all fields in a single segcore call,
+// which waits for the object storage field by field for the wide schemas.
+type fieldLoader struct {
+	cm   storage.ChunkManager
+	pool *conc.Pool[any]
+	// the size of the binlogs downloaded and decoded but not inserted yet is limited by the budget
+	budget     *semaphore.Weighted
+	budgetSize int64
+}
+
+func newFieldLoader(cm storage.ChunkManager, poolSize int) *fieldLoader {
+	budgetSize := paramtable.Get().QueryNodeCfg.ConcurrentFieldLoadMemoryBudget.GetAsInt64() * 1024 * 1024
+	return &fieldLoader{
+		cm:         cm,
+		pool:       conc.NewPool[any](poolSize),
+		budget:     semaphore.NewWeighted(budgetSize),
+		budgetSize: budgetSize,
+	}
+}
+
+// splitBinlogBatches groups the i-th binlogs of all fields into the i-th batch, which holds the same rows,
+// returns false if the binlogs of the fields are not aligned.
+func splitBinlogBatches(fields []*datapb.FieldBinlog) ([][]*datapb.Binlog, bool) {
+	if len(fields) == 0 {
+		return nil, true
+	}
+	batchNum := len(fields[0].GetBinlogs())
+	batches := make([][]*datapb.Binlog, batchNum)
+	for _, field := range fields {
+		if len(field.GetBinlogs()) != batchNum {
+			return nil, false
+		}
+		for i, binlog := range field.GetBinlogs() {
+			batches[i] = append(batches[i], binlog)
+		}
+	}
+	for _, batch := range batches {
+		if lo.ContainsBy(batch, func(binlog *datapb.Binlog) bool {
+			return binlog.GetEntriesNum() != batch[0].GetEntriesNum()
+		}) {
+			return nil, false
+		}
+	}
+	return batches, true
+}
+
+// load loads the field binlogs into the growing segment, falls back to LoadMultiFieldData
+// if the binlogs of the fields are not aligned.
+func (l *fieldLoader) load(ctx context.Context, segment *LocalSegment, fields []*datapb.FieldBinlog) error {
+	log := log.Ctx(ctx).With(
+		zap.Int64("collectionID", segment.Collection()),
+		zap.Int64("segmentID", segment.ID()),
+	)
+	batches, ok := splitBinlogBatches(fields)
+	if !ok {
+		log.Info("field binlogs not aligned, load them in segcore")
+		return segment.LoadMultiFieldData(ctx)
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	for _, batch := range batches {
+		batch := batch
+		// a batch larger than the budget takes the whole budget,
+		// the memory size is missing in the legacy binlogs
+		size := min(lo.SumBy(batch, func(binlog *datapb.Binlog) int64 {
+			return max(binlog.GetMemorySize(), binlog.GetLogSize())
+		}), l.budgetSize)
+		if err := l.budget.Acquire(ctx, size); err != nil {
+			if groupErr := group.Wait(); groupErr != nil {
+				return groupErr
+			}
+			return err
+		}
+		group.Go(func() error {
+			defer l.budget.Release(size)
+			return l.loadBatch(ctx, segment, batch)
+		})
+	}
+	if err := group.Wait(); err != nil {
+		log.Warn("failed to load field binlogs concurrently", zap.Error(err))
+		return err
+	}
+
+	log.Info("load field binlogs concurrently done", zap.Int("batchNum", len(batches)))
+	return nil
+}
+
+// loadBatch downloads the binlogs of a batch in the pool, then inserts the rows into the segment,
+// the arrow records decoded from the binlogs are converted into insert records column by column.
+func (l *fieldLoader) loadBatch(ctx context.Context, segment *LocalSegment, batch []*datapb.Binlog) error {
+	futures := make([]*conc.Future[any], 0, len(batch))
+	for _, binlog := range batch {
+		binlog := binlog
+		futures = append(futures, l.pool.Submit(func() (any, error) {
+			value, err := l.cm.Read(ctx, binlog.GetLogPath())
+			if err != nil {
+				return nil, err
+			}
+			return &storage.Blob{
+				Key:    binlog.GetLogPath(),
+				Value:  value,
+				RowNum: binlog.GetEntriesNum(),
+			}, nil
+		}))
+	}
+
+	blobs := make([]*storage.Blob, 0, len(futures))
+	for _, future := range futures {
+		blob, err := future.Await()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, blob.(*storage.Blob))
+	}
+
+	reader, err := storage.NewCompositeBinlogRecordReader(blobs)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	schema := segment.GetCollection().Schema()
+	for {
+		err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := insertBinlogRecord(ctx, segment, schema, reader.Record()); err != nil {
+			return err
+		}
+	}
+}
+
+// insertBinlogRecord inserts the rows of the record read from the binlogs into the segment,
+// the record is only valid until the next read, so the insert record is built with the data copied.
+func insertBinlogRecord(ctx context.Context, segment *LocalSegment, schema *schemapb.CollectionSchema, rec storage.Record) error {
+	columns := rec.Schema()
+	if _, ok := columns[common.RowIDField]; !ok {
+		return merr.WrapErrFieldNotFound(common.RowIDField, "row id field binlog not found")
+	}
+	if _, ok := columns[common.TimeStampField]; !ok {
+		return merr.WrapErrFieldNotFound(common.TimeStampField, "timestamp field binlog not found")
+	}
+	rowIDs, ok := rec.Column(common.RowIDField).(*array.Int64)
+	if !ok {
+		return merr.WrapErrParameterInvalidMsg("row id field binlog is not int64")
+	}
+	timestamps, ok := rec.Column(common.TimeStampField).(*array.Int64)
+	if !ok {
+		return merr.WrapErrParameterInvalidMsg("timestamp field binlog is not int64")
+	}
+	if rowIDs.Len() != timestamps.Len() {
+		return errors.Newf("row ids and timestamps not aligned, %d != %d", rowIDs.Len(), timestamps.Len())
+	}
+
+	// the binlog record names the columns by field id, which ArrowRecordToInsertRecord matches first
+	insertSchema := &schemapb.CollectionSchema{}
+	fields := make([]arrow.Field, 0, len(columns))
+	arrays := make([]arrow.Array, 0, len(columns))
+	for _, field := range schema.GetFields() {
+		fieldID := field.GetFieldID()
+		if _, ok := columns[fieldID]; !ok || fieldID == common.RowIDField || fieldID == common.TimeStampField {
+			continue
+		}
+		column := rec.Column(fieldID)
+		insertSchema.Fields = append(insertSchema.Fields, field)
+		fields = append(fields, arrow.Field{
+			Name:     strconv.FormatInt(fieldID, 10),
+			Type:     column.DataType(),
+			Nullable: field.GetNullable(),
+		})
+		arrays = append(arrays, column)
+	}
+	arrowRec := array.NewRecord(arrow.NewSchema(fields, nil), arrays, int64(rowIDs.Len()))
+	defer arrowRec.Release()
+
+	record, err := storage.ArrowRecordToInsertRecord(insertSchema, arrowRec)
+	if err != nil {
+		return err
+	}
+	return segment.Insert(ctx, slices.Clone(rowIDs.Int64Values()), lo.Map(timestamps.Int64Values(), func(ts int64, _ int) uint64 {
+		return uint64(ts)
+	}), record)
+}
@@ -0,0 +1,56 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+)
+
+func TestSplitBinlogBatches(t *testing.T) {
+	batches, ok := splitBinlogBatches(nil)
+	assert.True(t, ok)
+	assert.Empty(t, batches)
+
+	fields := []*datapb.FieldBinlog{
+		{FieldID: 0, Binlogs: []*datapb.Binlog{{LogPath: "0/1", EntriesNum: 10}, {LogPath: "0/2", EntriesNum: 5}}},
+		{FieldID: 1, Binlogs: []*datapb.Binlog{{LogPath: "1/1", EntriesNum: 10}, {LogPath: "1/2", EntriesNum: 5}}},
+		{FieldID: 100, Binlogs: []*datapb.Binlog{{LogPath: "100/1", EntriesNum: 10}, {LogPath: "100/2", EntriesNum: 5}}},
+	}
+	batches, ok = splitBinlogBatches(fields)
+	assert.True(t, ok)
+	assert.Len(t, batches, 2)
+	for i, batch := range batches {
+		assert.Len(t, batch, 3)
+		for j, binlog := range batch {
+			assert.Equal(t, fields[j].GetBinlogs()[i], binlog)
+		}
+	}
+
+	// the rows of the binlogs are not aligned
+	fields[2].Binlogs[1].EntriesNum = 4
+	_, ok = splitBinlogBatches(fields)
+	assert.False(t, ok)
+
+	// the number of the binlogs are not aligned
+	fields[2].Binlogs = fields[2].Binlogs[:1]
+	_, ok = splitBinlogBatches(fields)
+	assert.False(t, ok)
+}
@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// splitWarmupIndexes removes the indexes of the vector fields from indexedFieldInfos and returns them
+// if the background index load enabled, the raw data of these fields are loaded instead,
+// so the segment is searchable by brute force before the indexes loaded in the background.
+func splitWarmupIndexes(segment *LocalSegment, schemaHelper *typeutil.SchemaHelper, indexedFieldInfos map[int64]*IndexedFieldInfo) (map[int64]*IndexedFieldInfo, error) {
+	if !paramtable.Get().QueryNodeCfg.BackgroundIndexLoadEnabled.GetAsBool() || segment.IsLazyLoad() {
+		return nil, nil
+	}
+	warmupIndexes := make(map[int64]*IndexedFieldInfo)
+	for fieldID, info := range indexedFieldInfos {
+		field, err := schemaHelper.GetFieldFromID(fieldID)
+		if err != nil {
+			return nil, err
+		}
+		if typeutil.IsVectorType(field.GetDataType()) && len(info.FieldBinlog.GetBinlogs()) > 0 {
+			warmupIndexes[fieldID] = info
+			delete(indexedFieldInfos, fieldID)
+		}
+	}
+	return warmupIndexes, nil
+}
+
+// warmupIndexes loads the indexes into the segment in the background, segcore swaps the raw data
+// with the index once loaded.
+// The index info is reported in the distribution as not loaded before it, so querycoord doesn't load it again,
+// the info is dropped if failed to load the index, then querycoord loads it by the index checker.
+func (loader *segmentLoader) warmupIndexes(segment *LocalSegment, indexes map[int64]*IndexedFieldInfo) {
+	if len(indexes) == 0 {
+		return
+	}
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	collectionID := fmt.Sprint(segment.Collection())
+	for fieldID, info := range indexes {
+		fieldID, info := fieldID, info
+		segment.warmingIndexes.Insert(fieldID)
+		metrics.QueryNodeIndexWarmupNum.WithLabelValues(nodeID, collectionID).Inc()
+		tr := timerecord.NewTimeRecorder("warmupIndex")
+
+		go func() {
+			defer func() {
+				segment.warmingIndexes.Remove(fieldID)
+				metrics.QueryNodeIndexWarmupNum.WithLabelValues(nodeID, collectionID).Dec()
+			}()
+			log := log.With(
+				zap.Int64("collectionID", segment.Collection()),
+				zap.Int64("segmentID", segment.ID()),
+				zap.Int64("fieldID", fieldID),
+				zap.Int64("indexID", info.IndexInfo.GetIndexID()),
+			)
+			ctx := context.Background()
+			if err := loader.indexWarmupLimiter.Acquire(ctx, 1); err != nil {
+				return
+			}
+			defer loader.indexWarmupLimiter.Release(1)
+
+			if err := loader.loadFieldIndex(ctx, segment, info.IndexInfo); err != nil {
+				log.Warn("failed to load index in background, wait for loading it again", zap.Error(err))
+				if old := segment.GetIndex(fieldID); old != nil && !old.IsLoaded {
+					segment.fieldIndexes.Remove(fieldID)
+				}
+				return
+			}
+			metrics.QueryNodeIndexWarmupLatency.WithLabelValues(nodeID).Observe(float64(tr.ElapseSpan().Milliseconds()))
+			log.Info("load index in background done", zap.Duration("duration", tr.ElapseSpan()))
+		}()
+	}
+}
+
+// WarmingIndexFields returns the fields of the segment whose indexes are loading in the background,
+// which are searched by brute force on the raw data until loaded.
+func WarmingIndexFields(segment Segment) []int64 {
+	local, ok := segment.(*LocalSegment)
+	if !ok {
+		return nil
+	}
+	return local.warmingIndexes.Collect()
+}
@@ -74,6 +74,13 @@ type Manager struct {
 	Segment    SegmentManager
 	DiskCache  cache.Cache[int64, Segment]
 	Loader     Loader
+
+	quarantine *segmentQuarantine
+	// markOffline marks the quarantined segment offline in the distribution of its shard delegator
+	markOffline func(segment Segment)
+	watchdog    *cgoWatchdog
+
+	searchCache *searchResultCache
 }
 
 func NewManager() *Manager {
@@ -84,7 +91,10 @@ func NewManager() *Manager {
 	manager := &Manager{
 		Collection: NewCollectionManager(),
 		Segment:    segMgr,
+		quarantine: newSegmentQuarantine(),
 	}
+	manager.watchdog = newCGOWatchdog(manager.reportStuckCall)
+	manager.searchCache = newSearchResultCache()
 
 	manager.DiskCache = cache.NewCacheBuilder[int64, Segment]().WithLazyScavenger(func(key int64) int64 {
 		segment := segMgr.GetWithType(key, SegmentTypeSealed)
@@ -169,6 +179,12 @@ func (mgr *Manager) SetLoader(loader Loader) {
 	mgr.Loader = loader
 }
 
+// SetMarkOffline sets how the quarantined segments are marked offline before being released,
+// so that the requests are no longer routed to them.
+func (mgr *Manager) SetMarkOffline(markOffline func(segment Segment)) {
+	mgr.markOffline = markOffline
+}
+
 type SegmentManager interface {
 	// Put puts the given segments in,
 	// and increases the ref count of the corresponding collection,
@@ -642,13 +658,14 @@ func (mgr *segmentManager) Remove(ctx context.Context, segmentID typeutil.Unique
 	}
 	mgr.mu.Unlock()
 
+	var removed []Segment
 	if growing != nil {
-		mgr.release(ctx, growing)
+		removed = append(removed, growing)
 	}
-
 	if sealed != nil {
-		mgr.release(ctx, sealed)
+		removed = append(removed, sealed)
 	}
+	mgr.releaseSegments(ctx, removed...)
 
 	return removeGrowing, removeSealed
 }
@@ -692,9 +709,7 @@ func (mgr *segmentManager) RemoveBy(ctx context.Context, filters ...SegmentFilte
 	}, filters...)
 	mgr.mu.Unlock()
 
-	for _, s := range removeSegments {
-		mgr.release(ctx, s)
-	}
+	mgr.releaseSegments(ctx, removeSegments...)
 	return removeGrowing, removeSealed
 }
 
@@ -714,12 +729,14 @@ func (mgr *segmentManager) Clear(ctx context.Context) {
 	mgr.secondaryIndex = newSecondarySegmentIndex()
 	mgr.mu.Unlock()
 
+	segments := make([]Segment, 0, len(growingWaitForRelease)+len(sealedWaitForRelease))
 	for _, segment := range growingWaitForRelease {
-		mgr.release(ctx, segment)
+		segments = append(segments, segment)
 	}
 	for _, segment := range sealedWaitForRelease {
-		mgr.release(ctx, segment)
+		segments = append(segments, segment)
 	}
+	mgr.releaseSegments(ctx, segments...)
 }
 
 // registerReleaseCallback registers the callback function when a segment is released.
@@ -728,6 +745,21 @@ func (mgr *segmentManager) registerReleaseCallback(callback func(s Segment)) {
 	mgr.releaseCallback = callback
 }
 
+// releaseSegments releases the removed segments, in background if async release is enabled,
+// the segments are kept in the releasing set until they are released.
+func (mgr *segmentManager) releaseSegments(ctx context.Context, segments ...Segment) {
+	if !paramtable.Get().QueryNodeCfg.SegmentReleaseAsync.GetAsBool() {
+		for _, segment := range segments {
+			mgr.release(ctx, segment)
+		}
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	for _, segment := range segments {
+		go mgr.release(ctx, segment)
+	}
+}
+
 func (mgr *segmentManager) release(ctx context.Context, segment Segment) {
 	if mgr.releaseCallback != nil {
 		mgr.releaseCallback(segment)
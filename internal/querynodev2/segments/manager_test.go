@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
@@ -150,6 +151,23 @@ func (s *ManagerSuite) TestRemoveBy() {
 	}
 }
 
+func (s *ManagerSuite) TestRemoveAsync() {
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SegmentReleaseAsync.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SegmentReleaseAsync.Key)
+
+	// the pinned segment is released after unpinned, without blocking the removal
+	segment := s.segments[0]
+	s.Require().NoError(segment.PinIfNotReleased())
+	s.mgr.Remove(context.Background(), segment.ID(), querypb.DataScope_All)
+	s.Nil(s.mgr.Get(segment.ID()))
+	s.True(s.mgr.Exist(segment.ID(), segment.Type()))
+
+	segment.Unpin()
+	s.Eventually(func() bool {
+		return !s.mgr.Exist(segment.ID(), segment.Type())
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
 func (s *ManagerSuite) TestUpdateBy() {
 	action := IncreaseVersion(1)
 
@@ -37,6 +37,51 @@ func (_m *MockSegment) EXPECT() *MockSegment_Expecter {
 	return &MockSegment_Expecter{mock: &_m.Mock}
 }
 
+// AccessCount provides a mock function with given fields:
+func (_m *MockSegment) AccessCount() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// MockSegment_AccessCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessCount'
+type MockSegment_AccessCount_Call struct {
+	*mock.Call
+}
+
+// AccessCount is a helper method to define mock.On call
+func (_e *MockSegment_Expecter) AccessCount() *MockSegment_AccessCount_Call {
+	return &MockSegment_AccessCount_Call{Call: _e.mock.On("AccessCount")}
+}
+
+func (_c *MockSegment_AccessCount_Call) Run(run func()) *MockSegment_AccessCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSegment_AccessCount_Call) Return(_a0 int64) *MockSegment_AccessCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSegment_AccessCount_Call) RunAndReturn(run func() int64) *MockSegment_AccessCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BatchPkExist provides a mock function with given fields: lc
 func (_m *MockSegment) BatchPkExist(lc *storage.BatchLocationsCache) []bool {
 	ret := _m.Called(lc)
@@ -1008,6 +1053,51 @@ func (_c *MockSegment_MemSize_Call) RunAndReturn(run func() int64) *MockSegment_
 	return _c
 }
 
+// MmapSize provides a mock function with given fields:
+func (_m *MockSegment) MmapSize() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MmapSize")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// MockSegment_MmapSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MmapSize'
+type MockSegment_MmapSize_Call struct {
+	*mock.Call
+}
+
+// MmapSize is a helper method to define mock.On call
+func (_e *MockSegment_Expecter) MmapSize() *MockSegment_MmapSize_Call {
+	return &MockSegment_MmapSize_Call{Call: _e.mock.On("MmapSize")}
+}
+
+func (_c *MockSegment_MmapSize_Call) Run(run func()) *MockSegment_MmapSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSegment_MmapSize_Call) Return(_a0 int64) *MockSegment_MmapSize_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSegment_MmapSize_Call) RunAndReturn(run func() int64) *MockSegment_MmapSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NeedUpdatedVersion provides a mock function with given fields:
 func (_m *MockSegment) NeedUpdatedVersion() int64 {
 	ret := _m.Called()
@@ -0,0 +1,209 @@
+package segments
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/reduce"
+	"github.com/milvus-io/milvus/internal/util/segcore"
+	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// orderByReducer merges the sorted results of the workers by a k-way merge bounded by limit.
+type orderByReducer struct {
+	req    *querypb.QueryRequest
+	schema *schemapb.CollectionSchema
+}
+
+func newOrderByReducer(req *querypb.QueryRequest, schema *schemapb.CollectionSchema) *orderByReducer {
+	return &orderByReducer{
+		req:    req,
+		schema: schema,
+	}
+}
+
+func (r *orderByReducer) Reduce(ctx context.Context, results []*internalpb.RetrieveResults) (*internalpb.RetrieveResults, error) {
+	ret := &internalpb.RetrieveResults{
+		Status: merr.Success(),
+		Ids:    &schemapb.IDs{},
+	}
+	relatedDataSize := int64(0)
+	validResults := make([]*TimestampedRetrieveResult[*internalpb.RetrieveResults], 0, len(results))
+	for _, result := range results {
+		ret.AllRetrieveCount += result.GetAllRetrieveCount()
+		ret.HasMoreResult = ret.HasMoreResult || result.GetHasMoreResult()
+		relatedDataSize += result.GetCostAggregation().GetTotalRelatedDataSize()
+		if len(result.GetFieldsData()) == 0 || typeutil.GetSizeOfIDs(result.GetIds()) == 0 {
+			continue
+		}
+		tr, err := NewTimestampedRetrieveResult(result)
+		if err != nil {
+			return nil, err
+		}
+		validResults = append(validResults, tr)
+	}
+	ret.CostAggregation = mergeRetrieveCost(results, relatedDataSize)
+
+	if len(validResults) > 0 {
+		// the results of the workers are sorted already
+		selections, err := mergeInOrder(ctx, validResults, r.req.GetReq().GetOrderByFields(), r.req.GetReq().GetLimit(), false)
+		if err != nil {
+			return nil, err
+		}
+		ret.FieldsData, err = appendSelections(ret.Ids, validResults, selections)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := typeutil2.FillRetrieveResultIfEmpty(typeutil2.NewInternalResult(ret), r.req.GetReq().GetOutputFieldsId(), r.schema); err != nil {
+		return nil, fmt.Errorf("failed to fill internal retrieve results: %s", err.Error())
+	}
+	return ret, nil
+}
+
+// orderByReducerSegcore sorts the result of each segment, keeps the first limit rows of it,
+// and then merges them by a k-way merge bounded by limit.
+type orderByReducerSegcore struct {
+	req    *querypb.QueryRequest
+	schema *schemapb.CollectionSchema
+}
+
+func newOrderByReducerSegcore(req *querypb.QueryRequest, schema *schemapb.CollectionSchema) *orderByReducerSegcore {
+	return &orderByReducerSegcore{
+		req:    req,
+		schema: schema,
+	}
+}
+
+func (r *orderByReducerSegcore) Reduce(ctx context.Context, results []*segcorepb.RetrieveResults, _ []Segment, _ *segcore.RetrievePlan) (*segcorepb.RetrieveResults, error) {
+	ret := &segcorepb.RetrieveResults{
+		Ids: &schemapb.IDs{},
+	}
+	validResults := make([]*TimestampedRetrieveResult[*segcorepb.RetrieveResults], 0, len(results))
+	for _, result := range results {
+		ret.AllRetrieveCount += result.GetAllRetrieveCount()
+		ret.HasMoreResult = ret.HasMoreResult || result.GetHasMoreResult()
+		if len(result.GetFieldsData()) == 0 || typeutil.GetSizeOfIDs(result.GetIds()) == 0 {
+			continue
+		}
+		tr, err := NewTimestampedRetrieveResult(result)
+		if err != nil {
+			return nil, err
+		}
+		validResults = append(validResults, tr)
+	}
+
+	if len(validResults) > 0 {
+		selections, err := mergeInOrder(ctx, validResults, r.req.GetReq().GetOrderByFields(), r.req.GetReq().GetLimit(), true)
+		if err != nil {
+			return nil, err
+		}
+		ret.FieldsData, err = appendSelections(ret.Ids, validResults, selections)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := typeutil2.FillRetrieveResultIfEmpty(typeutil2.NewSegcoreResults(ret), r.req.GetReq().GetOutputFieldsId(), r.schema); err != nil {
+		return nil, fmt.Errorf("failed to fill segcore retrieve results: %s", err.Error())
+	}
+	return ret, nil
+}
+
+type orderedSelection struct {
+	batchIndex  int
+	resultIndex int64
+	dropped     bool
+}
+
+// mergeInOrder selects the first limit rows of the results in order. If sortInput is true, each result is sorted
+// and truncated to limit first, otherwise the results are expected to be in order already.
+// Rows of the same primary key are deduplicated, the one with the latest timestamp wins and takes its own position.
+func mergeInOrder[T interface {
+	typeutil.ResultWithID
+	GetFieldsData() []*schemapb.FieldData
+}](ctx context.Context, results []*TimestampedRetrieveResult[T], orderBy []*internalpb.OrderByField, limit int64, sortInput bool) ([]orderedSelection, error) {
+	cursors := make([]*reduce.OrderedCursor, 0, len(results))
+	for _, result := range results {
+		columns, err := reduce.NewOrderByColumns(result.GetIds(), result.Result.GetFieldsData(), orderBy)
+		if err != nil {
+			return nil, err
+		}
+		var indices []int64
+		if sortInput {
+			indices = columns.SortedIndices(limit)
+		} else {
+			indices = columns.SequentialIndices(limit)
+		}
+		cursors = append(cursors, reduce.NewOrderedCursor(columns, indices))
+	}
+
+	var (
+		selections []orderedSelection
+		selected   = make(map[any]int)
+		available  int64
+		skipDupCnt int64
+	)
+	for limit == typeutil.Unlimited || available < limit {
+		sel := reduce.SelectMinCursor(cursors)
+		if sel == -1 {
+			break
+		}
+		idx := cursors[sel].Current()
+		cursors[sel].Next()
+
+		pk := typeutil.GetPK(results[sel].GetIds(), idx)
+		ts := results[sel].Timestamps[idx]
+		if prev, ok := selected[pk]; ok {
+			skipDupCnt++
+			prevSel := selections[prev]
+			if ts <= results[prevSel.batchIndex].Timestamps[prevSel.resultIndex] {
+				continue
+			}
+			// the newer version replaces the old one at its own position
+			selections[prev].dropped = true
+			available--
+		}
+		selected[pk] = len(selections)
+		selections = append(selections, orderedSelection{batchIndex: sel, resultIndex: idx})
+		available++
+	}
+
+	if skipDupCnt > 0 {
+		log.Ctx(ctx).Debug("skip duplicated query result while merging results in order", zap.Int64("dupCount", skipDupCnt))
+	}
+	return selections, nil
+}
+
+func appendSelections[T interface {
+	typeutil.ResultWithID
+	GetFieldsData() []*schemapb.FieldData
+}](ids *schemapb.IDs, results []*TimestampedRetrieveResult[T], selections []orderedSelection) ([]*schemapb.FieldData, error) {
+	fieldsData := typeutil.PrepareResultFieldData(results[0].Result.GetFieldsData(), int64(len(selections)))
+	var retSize int64
+	maxOutputSize := paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64()
+	for _, selection := range selections {
+		if selection.dropped {
+			continue
+		}
+		result := results[selection.batchIndex]
+		typeutil.AppendPKs(ids, typeutil.GetPK(result.GetIds(), selection.resultIndex))
+		retSize += typeutil.AppendFieldData(fieldsData, result.Result.GetFieldsData(), selection.resultIndex)
+		// limit retrieve result to avoid oom
+		if retSize > maxOutputSize {
+			return nil, fmt.Errorf("query results exceed the maxOutputSize Limit %d", maxOutputSize)
+		}
+	}
+	return fieldsData, nil
+}
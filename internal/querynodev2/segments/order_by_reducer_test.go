@@ -0,0 +1,114 @@
+package segments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const orderByTestFieldID = common.StartOfUserFieldID + 1
+
+type OrderByReducerSuite struct {
+	suite.Suite
+}
+
+func (suite *OrderByReducerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *OrderByReducerSuite) newFieldsData(prices []int64, timestamps []int64) []*schemapb.FieldData {
+	return []*schemapb.FieldData{
+		{
+			Type:    schemapb.DataType_Int64,
+			FieldId: orderByTestFieldID,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: prices}},
+				},
+			},
+		},
+		{
+			Type:    schemapb.DataType_Int64,
+			FieldId: common.TimeStampField,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: timestamps}},
+				},
+			},
+		},
+	}
+}
+
+func (suite *OrderByReducerSuite) newRequest(limit int64) *querypb.QueryRequest {
+	return &querypb.QueryRequest{
+		Req: &internalpb.RetrieveRequest{
+			Limit:          limit,
+			OutputFieldsId: []int64{orderByTestFieldID, common.TimeStampField},
+			OrderByFields:  []*internalpb.OrderByField{{FieldID: orderByTestFieldID, Ascending: true}},
+		},
+	}
+}
+
+func (suite *OrderByReducerSuite) TestSegcoreReduce() {
+	// segment results are not sorted and pk 3 is duplicated
+	r1 := &segcorepb.RetrieveResults{
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+		Offset:     []int64{0, 1, 2},
+		FieldsData: suite.newFieldsData([]int64{30, 10, 20}, []int64{100, 100, 100}),
+	}
+	r2 := &segcorepb.RetrieveResults{
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{3, 4}}}},
+		Offset:     []int64{0, 1},
+		FieldsData: suite.newFieldsData([]int64{40, 5}, []int64{200, 100}),
+	}
+
+	reducer := newOrderByReducerSegcore(suite.newRequest(3), nil)
+	ret, err := reducer.Reduce(context.Background(), []*segcorepb.RetrieveResults{r1, r2}, nil, nil)
+	suite.NoError(err)
+	suite.Equal([]int64{4, 2, 3}, ret.GetIds().GetIntId().GetData())
+	suite.Equal([]int64{5, 10, 20}, ret.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	// the newer version of pk 3 wins and takes its own position
+	reducer = newOrderByReducerSegcore(suite.newRequest(typeutil.Unlimited), nil)
+	ret, err = reducer.Reduce(context.Background(), []*segcorepb.RetrieveResults{r1, r2}, nil, nil)
+	suite.NoError(err)
+	suite.Equal([]int64{4, 2, 1, 3}, ret.GetIds().GetIntId().GetData())
+	suite.Equal([]int64{5, 10, 30, 40}, ret.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+}
+
+func (suite *OrderByReducerSuite) TestInternalReduce() {
+	r1 := &internalpb.RetrieveResults{
+		Ids:              &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{2, 1}}}},
+		FieldsData:       suite.newFieldsData([]int64{10, 30}, []int64{100, 100}),
+		AllRetrieveCount: 2,
+	}
+	r2 := &internalpb.RetrieveResults{
+		Ids:              &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{4, 3}}}},
+		FieldsData:       suite.newFieldsData([]int64{5, 20}, []int64{100, 100}),
+		AllRetrieveCount: 2,
+	}
+
+	reducer := newOrderByReducer(suite.newRequest(3), nil)
+	ret, err := reducer.Reduce(context.Background(), []*internalpb.RetrieveResults{r1, r2, {}})
+	suite.NoError(err)
+	suite.Equal([]int64{4, 2, 3}, ret.GetIds().GetIntId().GetData())
+	suite.Equal(int64(4), ret.GetAllRetrieveCount())
+
+	// the order by field is missing
+	r1.FieldsData = r1.FieldsData[1:]
+	_, err = reducer.Reduce(context.Background(), []*internalpb.RetrieveResults{r1, r2})
+	suite.Error(err)
+}
+
+func TestOrderByReducer(t *testing.T) {
+	suite.Run(t, new(OrderByReducerSuite))
+}
@@ -208,7 +208,10 @@ func ResizeSQPool(evt *config.Event) {
 		newSize := int(math.Ceil(pt.QueryNodeCfg.MaxReadConcurrency.GetAsFloat() * pt.QueryNodeCfg.CGOPoolSizeRatio.GetAsFloat()))
 		pool := GetSQPool()
 		resizePool(pool, newSize, "SQPool")
-		conc.WarmupPool(pool, runtime.LockOSThread)
+		conc.WarmupPool(pool, func() {
+			runtime.LockOSThread()
+			C.SetThreadName(cgoTagSQ)
+		})
 	}
 }
 
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// brokenDataCodes are the segcore error codes indicating the loaded data of the segment is corrupted,
+// see ErrorCode in EasyAssert.h. The codes which invalid requests may also raise, e.g. UnexpectedError,
+// the default code of the assertions and std::exception, are not counted,
+// neither are the I/O ones like FileReadFailed and MmapError, which are mostly transient
+// and reloading the segment would not help.
+// Only the failures returned as CStatus are counted. The quarantine doesn't isolate the segcore crashes,
+// e.g. SIGSEGV on the corrupted data still aborts the querynode, and querycoord loads the segment on another one.
+var brokenDataCodes = typeutil.NewSet[int32](
+	2024, // DataFormatBroken
+)
+
+// segmentFailures is the broken data failures of a segment counted within the window since the first one.
+type segmentFailures struct {
+	mu    sync.Mutex
+	count int32
+	since time.Time
+}
+
+// segmentQuarantine counts the broken data failures of the sealed segments.
+type segmentQuarantine struct {
+	failures *typeutil.ConcurrentMap[int64, *segmentFailures]
+}
+
+func newSegmentQuarantine() *segmentQuarantine {
+	return &segmentQuarantine{
+		failures: typeutil.NewConcurrentMap[int64, *segmentFailures](),
+	}
+}
+
+// report counts the failure of the segment, returns true if the segment shall be quarantined,
+// which is returned only once for the failures reaching the threshold within the window.
+func (q *segmentQuarantine) report(segmentID int64, err error) bool {
+	threshold := paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.GetAsInt32()
+	if threshold <= 0 || !brokenDataCodes.Contain(merr.Code(err)) {
+		return false
+	}
+	window := paramtable.Get().QueryNodeCfg.SegmentQuarantineWindow.GetAsDuration(time.Second)
+	now := time.Now()
+
+	failures, loaded := q.failures.GetOrInsert(segmentID, &segmentFailures{})
+	if !loaded {
+		q.removeExpired(now, window)
+	}
+	failures.mu.Lock()
+	if failures.count == 0 || now.Sub(failures.since) > window {
+		failures.count = 0
+		failures.since = now
+	}
+	failures.count++
+	reached := failures.count >= threshold
+	failures.mu.Unlock()
+	if !reached {
+		return false
+	}
+	_, ok := q.failures.GetAndRemove(segmentID)
+	return ok
+}
+
+// removeExpired removes the failures out of the window, e.g. the ones of the released segments.
+func (q *segmentQuarantine) removeExpired(now time.Time, window time.Duration) {
+	q.failures.Range(func(segmentID int64, failures *segmentFailures) bool {
+		failures.mu.Lock()
+		expired := failures.count > 0 && now.Sub(failures.since) > window
+		failures.mu.Unlock()
+		if expired {
+			q.failures.Remove(segmentID)
+		}
+		return true
+	})
+}
+
+// reportFailure reports the failure of searching or retrieving the segment,
+// the sealed segment failing with broken data repeatedly is quarantined,
+// so that it no longer fails the requests.
+func (mgr *Manager) reportFailure(ctx context.Context, segment Segment, err error) {
+	if mgr == nil || mgr.quarantine == nil || segment.Type() != SegmentTypeSealed {
+		return
+	}
+	if !mgr.quarantine.report(segment.ID(), err) {
+		return
+	}
+
+	log.Ctx(ctx).Warn("quarantine segment failing with broken data",
+		zap.Int64("collectionID", segment.Collection()),
+		zap.Int64("segmentID", segment.ID()),
+		zap.Error(err))
+	mgr.quarantineSegment(segment)
+}
+
+// quarantineSegment marks the sealed segment offline in the distribution and releases it in background,
+// and querycoord loads it again from storage as a missing segment.
+// The shard delegator is unserviceable until then, so that the requests are routed to the other replicas.
+// If the delegator runs on another querynode, the segment is only released here,
+// and the requests routed to it fail until querycoord syncs the distribution of the delegator.
+func (mgr *Manager) quarantineSegment(segment Segment) {
+	log := log.With(
+		zap.Int64("collectionID", segment.Collection()),
+		zap.Int64("segmentID", segment.ID()),
+	)
+	metrics.QueryNodeQuarantinedSegments.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), fmt.Sprint(segment.Collection())).Inc()
+	// mark the segment offline before releasing it, or the requests still routed to it fail
+	if mgr.markOffline != nil {
+		mgr.markOffline(segment)
+	}
+	// the segment is pinned by the request, release it in background
+	go func() {
+		// the segment may be loaded again in the meantime
+		if mgr.Segment.GetWithType(segment.ID(), SegmentTypeSealed) != segment {
+			return
+		}
+		mgr.Segment.Remove(context.Background(), segment.ID(), querypb.DataScope_Historical)
+		log.Info("quarantined segment released")
+	}()
+}
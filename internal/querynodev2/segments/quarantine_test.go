@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSegmentQuarantine(t *testing.T) {
+	paramtable.Init()
+	brokenErr := merr.SegcoreError(2024, "data format broken")
+
+	t.Run("report", func(t *testing.T) {
+		q := newSegmentQuarantine()
+		// disabled by default
+		for i := 0; i < 5; i++ {
+			assert.False(t, q.report(2, brokenErr))
+		}
+
+		paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.Key, "3")
+		defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.Key)
+		// request errors and transient I/O errors are not counted
+		for i := 0; i < 5; i++ {
+			assert.False(t, q.report(1, merr.SegcoreError(2028, "expr invalid")))
+			assert.False(t, q.report(1, merr.SegcoreError(2001, "unexpected error")))
+			assert.False(t, q.report(1, merr.SegcoreError(2014, "file read failed")))
+		}
+		assert.False(t, q.report(1, brokenErr))
+		assert.False(t, q.report(1, brokenErr))
+		assert.True(t, q.report(1, brokenErr))
+		// counted again from zero after quarantined
+		assert.False(t, q.report(1, brokenErr))
+	})
+
+	t.Run("window", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.Key, "2")
+		defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.Key)
+		paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SegmentQuarantineWindow.Key, "60")
+		defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SegmentQuarantineWindow.Key)
+
+		q := newSegmentQuarantine()
+		assert.False(t, q.report(1, brokenErr))
+		// the failure out of the window is not counted
+		failures, _ := q.failures.Get(1)
+		failures.since = time.Now().Add(-2 * time.Minute)
+		assert.False(t, q.report(1, brokenErr))
+		assert.True(t, q.report(1, brokenErr))
+
+		// the expired failures of other segments are removed
+		assert.False(t, q.report(2, brokenErr))
+		failures, _ = q.failures.Get(2)
+		failures.since = time.Now().Add(-2 * time.Minute)
+		assert.False(t, q.report(3, brokenErr))
+		assert.False(t, q.failures.Contain(2))
+		assert.True(t, q.failures.Contain(3))
+	})
+
+	t.Run("release", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.Key, "1")
+		defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SegmentQuarantineThreshold.Key)
+
+		segment := NewMockSegment(t)
+		segment.EXPECT().ID().Return(1)
+		segment.EXPECT().Collection().Return(100)
+		segment.EXPECT().Type().Return(SegmentTypeSealed)
+		segMgr := NewMockSegmentManager(t)
+		segMgr.EXPECT().GetWithType(int64(1), SegmentTypeSealed).Return(segment)
+		markedOffline := false
+		released := make(chan struct{})
+		segMgr.EXPECT().Remove(mock.Anything, int64(1), querypb.DataScope_Historical).
+			Run(func(_ context.Context, _ int64, _ querypb.DataScope) {
+				// marked offline before released
+				assert.True(t, markedOffline)
+				close(released)
+			}).
+			Return(0, 1)
+		mgr := &Manager{Segment: segMgr, quarantine: newSegmentQuarantine()}
+		mgr.SetMarkOffline(func(s Segment) {
+			assert.Equal(t, segment, s)
+			markedOffline = true
+		})
+
+		mgr.reportFailure(context.Background(), segment, brokenErr)
+		select {
+		case <-released:
+		case <-time.After(time.Second):
+			t.Fatal("quarantined segment is not released")
+		}
+
+		// manager without quarantine ignores failures
+		(&Manager{Segment: segMgr}).reportFailure(context.Background(), segment, brokenErr)
+	})
+}
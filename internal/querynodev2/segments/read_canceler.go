@@ -0,0 +1,78 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// readCanceler cancels the in-flight reads of a segment,
+// so the release of the segment doesn't wait for the slow reads forever.
+// The zero value is ready to use.
+type readCanceler struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func (c *readCanceler) current() (context.Context, context.CancelCauseFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx == nil {
+		c.ctx, c.cancel = context.WithCancelCause(context.Background())
+	}
+	return c.ctx, c.cancel
+}
+
+// withCancel returns the context of a read, which is canceled by the caller or by the release of the segment.
+func (c *readCanceler) withCancel(ctx context.Context) (context.Context, context.CancelFunc) {
+	readsCtx, _ := c.current()
+	ctx, cancel := context.WithCancelCause(ctx)
+	if readsCtx.Err() != nil {
+		cancel(context.Cause(readsCtx))
+		return ctx, func() { cancel(nil) }
+	}
+	stop := context.AfterFunc(readsCtx, func() {
+		cancel(context.Cause(readsCtx))
+	})
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// cancelAfter cancels the reads with the cause once the deadline is reached,
+// returns false if they are canceled when the returned stop is called.
+// The reads started after the cancellation are canceled as well, until reset is called.
+func (c *readCanceler) cancelAfter(deadline time.Duration, cause error) (stop func() bool) {
+	_, cancel := c.current()
+	timer := time.AfterFunc(deadline, func() {
+		cancel(cause)
+	})
+	return timer.Stop
+}
+
+// reset makes the reads started after it not affected by the previous cancellation.
+func (c *readCanceler) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx != nil && c.ctx.Err() != nil {
+		c.ctx, c.cancel = nil, nil
+	}
+}
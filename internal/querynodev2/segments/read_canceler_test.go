@@ -0,0 +1,65 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCanceler(t *testing.T) {
+	var c readCanceler
+	cause := errors.New("releasing")
+
+	// stopped before the deadline
+	ctx, cancel := c.withCancel(context.Background())
+	stop := c.cancelAfter(time.Hour, cause)
+	assert.True(t, stop())
+	assert.NoError(t, ctx.Err())
+	cancel()
+	assert.Error(t, ctx.Err())
+
+	// canceled after the deadline, including the reads started later
+	ctx, cancel = c.withCancel(context.Background())
+	defer cancel()
+	stop = c.cancelAfter(time.Millisecond, cause)
+	assert.Eventually(t, func() bool {
+		return ctx.Err() != nil
+	}, time.Second, time.Millisecond)
+	assert.False(t, stop())
+	assert.ErrorIs(t, context.Cause(ctx), cause)
+	ctx2, cancel2 := c.withCancel(context.Background())
+	defer cancel2()
+	assert.ErrorIs(t, context.Cause(ctx2), cause)
+
+	// the reads started after reset are not canceled
+	c.reset()
+	ctx3, cancel3 := c.withCancel(context.Background())
+	defer cancel3()
+	assert.NoError(t, ctx3.Err())
+
+	// canceled by the caller
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx4, cancel4 := c.withCancel(parent)
+	defer cancel4()
+	cancelParent()
+	assert.ErrorIs(t, ctx4.Err(), context.Canceled)
+}
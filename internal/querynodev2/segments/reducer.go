@@ -23,6 +23,9 @@ func CreateInternalReducer(req *querypb.QueryRequest, schema *schemapb.Collectio
 	if req.GetReq().GetIsCount() {
 		return &cntReducer{}
 	}
+	if len(req.GetReq().GetOrderByFields()) > 0 {
+		return newOrderByReducer(req, schema)
+	}
 	return newDefaultLimitReducer(req, schema)
 }
 
@@ -34,6 +37,9 @@ func CreateSegCoreReducer(req *querypb.QueryRequest, schema *schemapb.Collection
 	if req.GetReq().GetIsCount() {
 		return &cntReducerSegCore{}
 	}
+	if len(req.GetReq().GetOrderByFields()) > 0 {
+		return newOrderByReducerSegcore(req, schema)
+	}
 	return newDefaultLimitReducerSegcore(req, schema, manager)
 }
 
@@ -354,6 +354,11 @@ func MergeInternalRetrieveResult(ctx context.Context, retrieveResults []*interna
 		log.Debug("skip duplicated query result while reducing internal.RetrieveResults", zap.Int64("dupCount", skipDupCnt))
 	}
 
+	ret.CostAggregation = mergeRetrieveCost(retrieveResults, relatedDataSize)
+	return ret, nil
+}
+
+func mergeRetrieveCost(retrieveResults []*internalpb.RetrieveResults, relatedDataSize int64) *internalpb.CostAggregation {
 	requestCosts := lo.FilterMap(retrieveResults, func(result *internalpb.RetrieveResults, _ int) (*internalpb.CostAggregation, bool) {
 		if paramtable.Get().QueryNodeCfg.EnableWorkerSQCostMetrics.GetAsBool() {
 			return result.GetCostAggregation(), true
@@ -365,12 +370,12 @@ func MergeInternalRetrieveResult(ctx context.Context, retrieveResults []*interna
 
 		return nil, false
 	})
-	ret.CostAggregation = mergeRequestCost(requestCosts)
-	if ret.CostAggregation == nil {
-		ret.CostAggregation = &internalpb.CostAggregation{}
+	cost := mergeRequestCost(requestCosts)
+	if cost == nil {
+		cost = &internalpb.CostAggregation{}
 	}
-	ret.CostAggregation.TotalRelatedDataSize = relatedDataSize
-	return ret, nil
+	cost.TotalRelatedDataSize = relatedDataSize
+	return cost
 }
 
 func getTS(i *internalpb.RetrieveResults, idx int64) uint64 {
@@ -530,10 +535,12 @@ func MergeSegcoreRetrieveResults(ctx context.Context, retrieveResults []*segcore
 				var r *segcorepb.RetrieveResults
 				var err error
 				if err := doOnSegment(ctx, manager, validSegments[idx], func(ctx context.Context, segment Segment) error {
+					done := manager.trackCGOCall(segment, cgoCallRetrieve)
 					r, err = segment.RetrieveByOffsets(ctx, &segcore.RetrievePlanWithOffsets{
 						RetrievePlan: plan,
 						Offsets:      theOffsets,
 					})
+					done()
 					return err
 				}); err != nil {
 					return nil, err
@@ -54,7 +54,9 @@ func retrieveOnSegments(ctx context.Context, mgr *Manager, segments []Segment, s
 		}
 		return false
 	}()
-	plan.SetIgnoreNonPk(!anySegIsLazyLoad && len(segments) > 1 && req.GetReq().GetLimit() != typeutil.Unlimited && plan.ShouldIgnoreNonPk())
+	// the order by fields must be retrieved to sort the results of each segment
+	plan.SetIgnoreNonPk(!anySegIsLazyLoad && len(segments) > 1 && req.GetReq().GetLimit() != typeutil.Unlimited &&
+		len(req.GetReq().GetOrderByFields()) == 0 && plan.ShouldIgnoreNonPk())
 
 	label := metrics.SealedSegmentLabel
 	if segType == commonpb.SegmentState_Growing {
@@ -63,8 +65,11 @@ func retrieveOnSegments(ctx context.Context, mgr *Manager, segments []Segment, s
 
 	retriever := func(ctx context.Context, s Segment) error {
 		tr := timerecord.NewTimeRecorder("retrieveOnSegments")
+		done := mgr.trackCGOCall(s, cgoCallRetrieve)
 		result, err := s.Retrieve(ctx, plan)
+		done()
 		if err != nil {
+			mgr.reportFailure(ctx, s, err)
 			return err
 		}
 		resultCh <- RetrieveSegmentResult{
@@ -108,7 +113,12 @@ func retrieveOnSegmentsWithStream(ctx context.Context, mgr *Manager, segments []
 			var result *segcorepb.RetrieveResults
 			err := doOnSegment(ctx, mgr, segment, func(ctx context.Context, segment Segment) error {
 				var err error
+				done := mgr.trackCGOCall(segment, cgoCallRetrieve)
 				result, err = segment.Retrieve(ctx, plan)
+				done()
+				if err != nil {
+					mgr.reportFailure(ctx, segment, err)
+				}
 				return err
 			})
 			if err != nil {
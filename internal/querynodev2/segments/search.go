@@ -45,8 +45,11 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 	searcher := func(ctx context.Context, s Segment) error {
 		// record search time
 		tr := timerecord.NewTimeRecorder("searchOnSegments")
+		done := mgr.trackCGOCall(s, cgoCallSearch)
 		searchResult, err := s.Search(ctx, searchReq)
+		done()
 		if err != nil {
+			mgr.reportFailure(ctx, s, err)
 			return err
 		}
 		resultCh <- searchResult
@@ -130,9 +133,12 @@ func searchSegmentsStreamly(ctx context.Context,
 	searcher := func(ctx context.Context, seg Segment) error {
 		// record search time
 		tr := timerecord.NewTimeRecorder("searchOnSegments")
+		done := mgr.trackCGOCall(seg, cgoCallSearch)
 		searchResult, searchErr := seg.Search(ctx, searchReq)
+		done()
 		searchDuration := tr.RecordSpan().Milliseconds()
 		if searchErr != nil {
+			mgr.reportFailure(ctx, seg, searchErr)
 			return searchErr
 		}
 		reduceMutex.Lock()
@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+// CachedSearchResult is the reduced search result of a search request on the sealed segments.
+type CachedSearchResult struct {
+	MetricType string
+	Blob       []byte
+}
+
+// searchResultCache caches the search results on the sealed segments, so the identical searches
+// against the sealed segments not deleted since skip searching segcore.
+// The key of a result contains the version and the last delta timestamp of each segment searched,
+// so the results are not hit any more once the segments are deleted or loaded again, and age out of the cache.
+type searchResultCache struct {
+	lru *expirable.LRU[string, *CachedSearchResult]
+}
+
+func newSearchResultCache() *searchResultCache {
+	return &searchResultCache{
+		lru: expirable.NewLRU[string, *CachedSearchResult](
+			paramtable.Get().QueryNodeCfg.SearchCacheCapacity.GetAsInt(),
+			nil,
+			paramtable.Get().QueryNodeCfg.SearchCacheTimeBucket.GetAsDuration(time.Second),
+		),
+	}
+}
+
+// SearchCacheKey returns the key of the cached result of the search request on the sealed segments,
+// returns false if the result is not cacheable.
+func (mgr *Manager) SearchCacheKey(req *querypb.SearchRequest) (string, bool) {
+	if mgr == nil || mgr.searchCache == nil || !paramtable.Get().QueryNodeCfg.SearchCacheEnabled.GetAsBool() ||
+		req.GetScope() != querypb.DataScope_Historical || req.GetReq().GetIsAdvanced() || len(req.GetSegmentIDs()) == 0 {
+		return "", false
+	}
+	mvccTs := req.GetReq().GetMvccTimestamp()
+	bucket := paramtable.Get().QueryNodeCfg.SearchCacheTimeBucket.GetAsDuration(time.Second)
+	if mvccTs == 0 || bucket <= 0 {
+		return "", false
+	}
+
+	h := sha256.New()
+	writeInt := func(values ...int64) {
+		binary.Write(h, binary.LittleEndian, int64(len(values)))
+		binary.Write(h, binary.LittleEndian, values)
+	}
+	writeBytes := func(b []byte) {
+		writeInt(int64(len(b)))
+		h.Write(b)
+	}
+	for _, channel := range req.GetDmlChannels() {
+		writeBytes([]byte(channel))
+	}
+	writeBytes([]byte(req.GetReq().GetMetricType()))
+	writeBytes(req.GetReq().GetSerializedExprPlan())
+	writeBytes(req.GetReq().GetPlaceholderGroup())
+	writeInt(req.GetReq().GetCollectionID(), req.GetReq().GetNq(), req.GetReq().GetTopk(), req.GetReq().GetOffset(),
+		req.GetReq().GetFieldId(), req.GetReq().GetGroupByFieldId(), req.GetReq().GetGroupSize())
+	writeInt(req.GetReq().GetOutputFieldsId()...)
+	partitionIDs := slices.Clone(req.GetReq().GetPartitionIDs())
+	slices.Sort(partitionIDs)
+	writeInt(partitionIDs...)
+	// the entities expired by the collection ttl change along with the timestamp
+	writeInt(tsoutil.PhysicalTime(mvccTs).UnixNano() / int64(bucket))
+
+	segmentIDs := slices.Clone(req.GetSegmentIDs())
+	slices.Sort(segmentIDs)
+	for _, segmentID := range segmentIDs {
+		segment := mgr.Segment.GetWithType(segmentID, SegmentTypeSealed)
+		// the result is not the same if the deletes after the search timestamp are applied
+		if segment == nil || segment.IsLazyLoad() || segment.LastDeltaTimestamp() > mvccTs {
+			return "", false
+		}
+		writeInt(segmentID, segment.Version(), int64(segment.LastDeltaTimestamp()))
+	}
+	return string(h.Sum(nil)), true
+}
+
+// GetCachedSearchResult returns the cached result of the key.
+func (mgr *Manager) GetCachedSearchResult(key string) (*CachedSearchResult, bool) {
+	result, ok := mgr.searchCache.lru.Get(key)
+	state := metrics.CacheMissLabel
+	if ok {
+		state = metrics.CacheHitLabel
+	}
+	metrics.QueryNodeSearchCacheCounter.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), state).Inc()
+	return result, ok
+}
+
+// CacheSearchResult caches the result of the key, the result too large is not cached.
+func (mgr *Manager) CacheSearchResult(key string, result *CachedSearchResult) {
+	if int64(len(result.Blob)) > paramtable.Get().QueryNodeCfg.SearchCacheMaxResultSize.GetAsInt64()*1024*1024 {
+		return
+	}
+	mgr.searchCache.lru.Add(key, result)
+}
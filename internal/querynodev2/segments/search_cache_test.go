@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+func TestSearchResultCache(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.SearchCacheEnabled.Key, "true")
+	defer params.Reset(params.QueryNodeCfg.SearchCacheEnabled.Key)
+
+	lastDelta := tsoutil.ComposeTSByTime(time.Now(), 0)
+	segment := NewMockSegment(t)
+	segment.EXPECT().IsLazyLoad().Return(false).Maybe()
+	segment.EXPECT().Version().Return(1).Maybe()
+	segment.EXPECT().LastDeltaTimestamp().RunAndReturn(func() uint64 {
+		return lastDelta
+	}).Maybe()
+	segmentManager := NewMockSegmentManager(t)
+	segmentManager.EXPECT().GetWithType(int64(1), SegmentTypeSealed).Return(segment).Maybe()
+	segmentManager.EXPECT().GetWithType(int64(2), SegmentTypeSealed).Return(nil).Maybe()
+	mgr := &Manager{Segment: segmentManager, searchCache: newSearchResultCache()}
+
+	req := &querypb.SearchRequest{
+		Req: &internalpb.SearchRequest{
+			CollectionID:       100,
+			PartitionIDs:       []int64{10, 11},
+			SerializedExprPlan: []byte("plan"),
+			PlaceholderGroup:   []byte("placeholder"),
+			Nq:                 1,
+			Topk:               10,
+			MvccTimestamp:      lastDelta + 1,
+		},
+		DmlChannels: []string{"ch"},
+		SegmentIDs:  []int64{1},
+		Scope:       querypb.DataScope_Historical,
+	}
+	key, ok := mgr.SearchCacheKey(req)
+	assert.True(t, ok)
+
+	// the same search in the same time bucket
+	other := proto.Clone(req).(*querypb.SearchRequest)
+	other.Req.PartitionIDs = []int64{11, 10}
+	other.Req.MvccTimestamp = lastDelta + 2
+	otherKey, ok := mgr.SearchCacheKey(other)
+	assert.True(t, ok)
+	assert.Equal(t, key, otherKey)
+
+	// different searches
+	other = proto.Clone(req).(*querypb.SearchRequest)
+	other.Req.PlaceholderGroup = []byte("other")
+	otherKey, _ = mgr.SearchCacheKey(other)
+	assert.NotEqual(t, key, otherKey)
+	other = proto.Clone(req).(*querypb.SearchRequest)
+	other.Req.Topk = 20
+	otherKey, _ = mgr.SearchCacheKey(other)
+	assert.NotEqual(t, key, otherKey)
+
+	// not cacheable
+	for _, update := range []func(req *querypb.SearchRequest){
+		func(req *querypb.SearchRequest) { req.Scope = querypb.DataScope_Streaming },
+		func(req *querypb.SearchRequest) { req.Req.IsAdvanced = true },
+		func(req *querypb.SearchRequest) { req.Req.MvccTimestamp = 0 },
+		func(req *querypb.SearchRequest) { req.SegmentIDs = []int64{1, 2} },
+		// the segment is deleted after the search timestamp
+		func(req *querypb.SearchRequest) { req.Req.MvccTimestamp = lastDelta - 1 },
+	} {
+		other = proto.Clone(req).(*querypb.SearchRequest)
+		update(other)
+		_, ok = mgr.SearchCacheKey(other)
+		assert.False(t, ok)
+	}
+
+	_, ok = mgr.GetCachedSearchResult(key)
+	assert.False(t, ok)
+	mgr.CacheSearchResult(key, &CachedSearchResult{MetricType: "L2", Blob: []byte("result")})
+	result, ok := mgr.GetCachedSearchResult(key)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("result"), result.Blob)
+
+	// the segment deleted since
+	lastDelta++
+	newKey, ok := mgr.SearchCacheKey(req)
+	assert.True(t, ok)
+	assert.NotEqual(t, key, newKey)
+	_, ok = mgr.GetCachedSearchResult(newKey)
+	assert.False(t, ok)
+
+	// too large to cache
+	params.Save(params.QueryNodeCfg.SearchCacheMaxResultSize.Key, "0")
+	defer params.Reset(params.QueryNodeCfg.SearchCacheMaxResultSize.Key)
+	mgr.CacheSearchResult(newKey, &CachedSearchResult{MetricType: "L2", Blob: []byte("result")})
+	_, ok = mgr.GetCachedSearchResult(newKey)
+	assert.False(t, ok)
+
+	params.Save(params.QueryNodeCfg.SearchCacheEnabled.Key, "false")
+	_, ok = mgr.SearchCacheKey(req)
+	assert.False(t, ok)
+}
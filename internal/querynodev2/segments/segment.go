@@ -34,6 +34,7 @@ import (
 	"unsafe"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -97,6 +98,7 @@ type baseSegment struct {
 	resourceUsageCache *atomic.Pointer[ResourceUsage]
 
 	needUpdatedVersion *atomic.Int64 // only for lazy load mode update index
+	accessCount        *atomic.Int64 // number of search/query requests served by the segment
 }
 
 func newBaseSegment(collection *Collection, segmentType SegmentType, version int64, loadInfo *querypb.SegmentLoadInfo) (baseSegment, error) {
@@ -109,17 +111,40 @@ func newBaseSegment(collection *Collection, segmentType SegmentType, version int
 		loadInfo:       atomic.NewPointer[querypb.SegmentLoadInfo](loadInfo),
 		version:        atomic.NewInt64(version),
 		segmentType:    segmentType,
-		bloomFilterSet: pkoracle.NewBloomFilterSet(loadInfo.GetSegmentID(), loadInfo.GetPartitionID(), segmentType),
+		bloomFilterSet: newBloomFilterSet(collection, segmentType, loadInfo),
 		bm25Stats:      make(map[int64]*storage.BM25Stats),
 		channel:        channel,
 		isLazyLoad:     isLazyLoad(collection, segmentType),
 
 		resourceUsageCache: atomic.NewPointer[ResourceUsage](nil),
 		needUpdatedVersion: atomic.NewInt64(0),
+		accessCount:        atomic.NewInt64(0),
 	}
 	return bs, nil
 }
 
+// newBloomFilterSet sizes the bloom filter of the growing segment by its estimated max row count if configured,
+// bloomFilterSize is too small for the large segments, and wastes memory for the small ones.
+// The bloom filter size and false positive rate set in the collection properties take precedence.
+func newBloomFilterSet(collection *Collection, segmentType SegmentType, loadInfo *querypb.SegmentLoadInfo) *pkoracle.BloomFilterSet {
+	bfs := pkoracle.NewBloomFilterSet(loadInfo.GetSegmentID(), loadInfo.GetPartitionID(), segmentType)
+	if segmentType == SegmentTypeGrowing && paramtable.Get().CommonCfg.BloomFilterAutoSize.GetAsBool() &&
+		collection.Schema() != nil {
+		sizePerRecord, err := typeutil.EstimateSizePerRecord(collection.Schema())
+		if err == nil && sizePerRecord > 0 {
+			expectedRows := paramtable.Get().DataCoordCfg.SegmentMaxSize.GetAsFloat() * 1024 * 1024 / float64(sizePerRecord)
+			bfs = pkoracle.NewBloomFilterSetWithExpectedRows(loadInfo.GetSegmentID(), loadInfo.GetPartitionID(), segmentType, uint(expectedRows))
+		}
+	}
+
+	props := collection.Schema().GetProperties()
+	// invalid values are rejected by proxy, fall back to the global config if any
+	size, _ := common.CollectionBloomFilterSize(props...)
+	falsePositive, _ := common.CollectionBloomFilterFalsePositive(props...)
+	bfs.SetFilterParams(size, falsePositive)
+	return bfs
+}
+
 // isLazyLoad checks if the segment is lazy load
 func isLazyLoad(collection *Collection, segmentType SegmentType) bool {
 	return segmentType == SegmentTypeSealed && // only sealed segment enable lazy load
@@ -248,6 +273,14 @@ func (s *baseSegment) NeedUpdatedVersion() int64 {
 	return s.needUpdatedVersion.Load()
 }
 
+// AccessCount returns the number of search/query requests served by the segment since loaded.
+func (s *baseSegment) AccessCount() int64 {
+	if s.accessCount == nil {
+		return 0
+	}
+	return s.accessCount.Load()
+}
+
 func (s *baseSegment) SetLoadInfo(loadInfo *querypb.SegmentLoadInfo) {
 	s.loadInfo.Store(loadInfo)
 }
@@ -272,13 +305,21 @@ type LocalSegment struct {
 	csegment segcore.CSegment
 
 	// cached results, to avoid too many CGO calls
-	memSize     *atomic.Int64
-	rowNum      *atomic.Int64
-	insertCount *atomic.Int64
+	memSize       *atomic.Int64
+	mmapSize      *atomic.Int64
+	rowNum        *atomic.Int64
+	insertCount   *atomic.Int64
+	pkDigest      *atomic.Uint64 // valid once pkDigestState is pkDigestComputed
+	pkDigestState *atomic.Int32
 
 	lastDeltaTimestamp *atomic.Uint64
 	fields             *typeutil.ConcurrentMap[int64, *FieldInfo]
 	fieldIndexes       *typeutil.ConcurrentMap[int64, *IndexedFieldInfo]
+	// the fields whose indexes are loading in the background
+	warmingIndexes *typeutil.ConcurrentSet[int64]
+
+	// cancels the in-flight reads after the drain deadline of release
+	reads readCanceler
 }
 
 func NewSegment(ctx context.Context,
@@ -320,7 +361,8 @@ func NewSegment(ctx context.Context,
 	)
 
 	var csegment segcore.CSegment
-	if _, err := GetDynamicPool().Submit(func() (any, error) {
+	start := time.Now()
+	_, err = GetDynamicPool().Submit(func() (any, error) {
 		var err error
 		csegment, err = segcore.CreateCSegment(&segcore.CreateCSegmentRequest{
 			Collection:    collection.ccollection,
@@ -330,7 +372,9 @@ func NewSegment(ctx context.Context,
 			EnableChunked: paramtable.Get().QueryNodeCfg.MultipleChunkedEnable.GetAsBool(),
 		})
 		return nil, err
-	}).Await(); err != nil {
+	}).Await()
+	recordSegmentEvent(SegmentEventNewSegment, &base, nil, start, err)
+	if err != nil {
 		logger.Warn("create segment failed", zap.Error(err))
 		return nil, err
 	}
@@ -344,10 +388,14 @@ func NewSegment(ctx context.Context,
 		lastDeltaTimestamp: atomic.NewUint64(0),
 		fields:             typeutil.NewConcurrentMap[int64, *FieldInfo](),
 		fieldIndexes:       typeutil.NewConcurrentMap[int64, *IndexedFieldInfo](),
+		warmingIndexes:     typeutil.NewConcurrentSet[int64](),
 
-		memSize:     atomic.NewInt64(-1),
-		rowNum:      atomic.NewInt64(-1),
-		insertCount: atomic.NewInt64(0),
+		memSize:       atomic.NewInt64(-1),
+		mmapSize:      atomic.NewInt64(0),
+		rowNum:        atomic.NewInt64(-1),
+		insertCount:   atomic.NewInt64(0),
+		pkDigest:      atomic.NewUint64(0),
+		pkDigestState: atomic.NewInt32(pkDigestNotComputed),
 	}
 
 	if err := segment.initializeSegment(); err != nil {
@@ -431,6 +479,78 @@ func (s *LocalSegment) RowNum() int64 {
 	return rowNum
 }
 
+// SegcoreRowCounts returns the number of rows in segcore of the segment, the deleted ones included and excluded,
+// both are 0 if the data of the segment not loaded.
+func SegcoreRowCounts(segment Segment) (int64, int64) {
+	local, ok := segment.(*LocalSegment)
+	if !ok || !local.ptrLock.RLockIf(state.IsDataLoaded) {
+		return 0, 0
+	}
+	var rowCount int64
+	GetDynamicPool().Submit(func() (any, error) {
+		rowCount = int64(C.GetRowCount(local.ptr))
+		return nil, nil
+	}).Await()
+	local.ptrLock.RUnlock()
+	return rowCount, local.RowNum()
+}
+
+const (
+	pkDigestNotComputed int32 = iota
+	pkDigestComputing
+	pkDigestComputed
+	// the failure is cached, segcore fails the same way for the same segment
+	pkDigestFailed
+)
+
+// PKDigest returns the digest of the primary keys loaded in segcore, to tell whether the replicas of the segment
+// loaded the same primary keys. It's 0 if not computed yet, failed, or queryCoord.checkSegmentDigest is disabled.
+// The digest is never computed on the caller, see ComputePKDigestAsync.
+func PKDigest(segment Segment) uint64 {
+	local, ok := segment.(*LocalSegment)
+	if !ok || !paramtable.Get().QueryCoordCfg.CheckSegmentDigest.GetAsBool() {
+		return 0
+	}
+	if local.pkDigestState.Load() != pkDigestComputed {
+		// the check may be enabled after the segment loaded
+		ComputePKDigestAsync(local)
+		return 0
+	}
+	return local.pkDigest.Load()
+}
+
+// ComputePKDigestAsync computes the digest of the primary keys of the sealed segment in the background
+// if queryCoord.checkSegmentDigest is enabled. It's computed once, the primary keys of a sealed segment
+// never change after loaded.
+func ComputePKDigestAsync(segment Segment) {
+	local, ok := segment.(*LocalSegment)
+	if !ok || local.Type() != SegmentTypeSealed || !paramtable.Get().QueryCoordCfg.CheckSegmentDigest.GetAsBool() {
+		return
+	}
+	if !local.pkDigestState.CompareAndSwap(pkDigestNotComputed, pkDigestComputing) {
+		return
+	}
+	GetDynamicPool().Submit(func() (any, error) {
+		if !local.ptrLock.RLockIf(state.IsDataLoaded) {
+			// e.g. the lazy loaded segment, computed once its data loaded
+			local.pkDigestState.Store(pkDigestNotComputed)
+			return nil, nil
+		}
+		defer local.ptrLock.RUnlock()
+
+		var digest uint64
+		status := C.GetPrimaryKeyDigest(local.ptr, (*C.uint64_t)(unsafe.Pointer(&digest)))
+		if err := HandleCStatus(context.Background(), &status, "GetPrimaryKeyDigest failed",
+			zap.Int64("segmentID", local.ID())); err != nil {
+			local.pkDigestState.Store(pkDigestFailed)
+			return nil, nil
+		}
+		local.pkDigest.Store(digest)
+		local.pkDigestState.Store(pkDigestComputed)
+		return nil, nil
+	})
+}
+
 func (s *LocalSegment) MemSize() int64 {
 	if !s.ptrLock.RLockIf(state.IsNotReleased) {
 		return 0
@@ -448,6 +568,14 @@ func (s *LocalSegment) MemSize() int64 {
 	return memSize
 }
 
+func (s *LocalSegment) MmapSize() int64 {
+	if !s.ptrLock.RLockIf(state.IsNotReleased) {
+		return 0
+	}
+	defer s.ptrLock.RUnlock()
+	return s.mmapSize.Load()
+}
+
 func (s *LocalSegment) LastDeltaTimestamp() uint64 {
 	return s.lastDeltaTimestamp.Load()
 }
@@ -501,6 +629,9 @@ func (s *LocalSegment) Search(ctx context.Context, searchReq *segcore.SearchRequ
 		return nil, merr.WrapErrSegmentNotLoaded(s.ID(), "segment released")
 	}
 	defer s.ptrLock.RUnlock()
+	s.accessCount.Inc()
+	ctx, cancel := s.reads.withCancel(ctx)
+	defer cancel()
 
 	hasIndex := s.ExistIndex(searchReq.SearchFieldID())
 	log = log.With(zap.Bool("withIndex", hasIndex))
@@ -523,6 +654,9 @@ func (s *LocalSegment) retrieve(ctx context.Context, plan *segcore.RetrievePlan,
 		return nil, merr.WrapErrSegmentNotLoaded(s.ID(), "segment released")
 	}
 	defer s.ptrLock.RUnlock()
+	s.accessCount.Inc()
+	ctx, cancel := s.reads.withCancel(ctx)
+	defer cancel()
 
 	log.Debug("begin to retrieve")
 
@@ -570,6 +704,8 @@ func (s *LocalSegment) retrieveByOffsets(ctx context.Context, plan *segcore.Retr
 		return nil, merr.WrapErrSegmentNotLoaded(s.ID(), "segment released")
 	}
 	defer s.ptrLock.RUnlock()
+	ctx, cancel := s.reads.withCancel(ctx)
+	defer cancel()
 
 	log.Debug("begin to retrieve by offsets")
 	tr := timerecord.NewTimeRecorder("cgoRetrieveByOffsets")
@@ -733,6 +869,7 @@ func (s *LocalSegment) LoadMultiFieldData(ctx context.Context) error {
 	}
 
 	var err error
+	start := time.Now()
 	GetLoadPool().Submit(func() (any, error) {
 		start := time.Now()
 		defer func() {
@@ -745,6 +882,9 @@ func (s *LocalSegment) LoadMultiFieldData(ctx context.Context) error {
 		_, err = s.csegment.LoadFieldData(ctx, req)
 		return nil, nil
 	}).Await()
+	recordSegmentEvent(SegmentEventLoadFieldData, &s.baseSegment, lo.Map(fields, func(field *datapb.FieldBinlog, _ int) int64 {
+		return field.GetFieldID()
+	}), start, err)
 	if err != nil {
 		log.Warn("LoadMultiFieldData failed", zap.Error(err))
 		return err
@@ -788,6 +928,7 @@ func (s *LocalSegment) LoadFieldData(ctx context.Context, fieldID int64, rowCoun
 		RowCount: rowCount,
 	}
 
+	start := time.Now()
 	GetLoadPool().Submit(func() (any, error) {
 		start := time.Now()
 		defer func() {
@@ -801,12 +942,19 @@ func (s *LocalSegment) LoadFieldData(ctx context.Context, fieldID int64, rowCoun
 		log.Info("submitted loadFieldData task to load pool")
 		return nil, nil
 	}).Await()
+	recordSegmentEvent(SegmentEventLoadFieldData, &s.baseSegment, []int64{fieldID}, start, err)
 
 	if err != nil {
 		log.Warn("LoadFieldData failed", zap.Error(err))
 		return err
 	}
-	log.Info("load field done")
+	// system fields are always loaded into memory
+	if mmapEnabled && !common.IsSystemField(fieldID) {
+		s.mmapSize.Add(getBinlogDataMemorySize(field))
+	} else {
+		s.memSize.Store(-1)
+	}
+	log.Info("load field done", zap.Bool("mmapEnabled", mmapEnabled))
 	return nil
 }
 
@@ -918,6 +1066,13 @@ func GetCLoadInfoWithFunc(ctx context.Context,
 	indexInfo *querypb.FieldIndexInfo,
 	f func(c *LoadIndexInfo) error,
 ) error {
+	// the index engine version recorded in the index meta by the indexnode is validated right before segcore loads it
+	minimal, current := getIndexEngineVersion()
+	if err := checkIndexEngineVersion(indexInfo, minimal, current); err != nil {
+		log.Ctx(ctx).Warn("failed to load field index", zap.Int64("segmentID", s.GetSegmentID()), zap.Error(err))
+		return err
+	}
+
 	// 1.
 	loadIndexInfo, err := newLoadIndexInfo(ctx)
 	if err != nil {
@@ -928,6 +1083,7 @@ func GetCLoadInfoWithFunc(ctx context.Context,
 	indexParams := funcutil.KeyValuePair2Map(indexInfo.IndexParams)
 	// as Knowhere reports error if encounter an unknown param, we need to delete it
 	delete(indexParams, common.MmapEnabledKey)
+	delete(indexParams, common.IndexResourceGroupsKey)
 
 	// some build params also exist in indexParams, which are useless during loading process
 	if vecindexmgr.GetVecIndexMgrInstance().IsDiskANN(indexParams["index_type"]) {
@@ -1002,7 +1158,10 @@ func (s *LocalSegment) LoadIndex(ctx context.Context, indexInfo *querypb.FieldIn
 		return err
 	}
 
-	return s.innerLoadIndex(ctx, fieldSchema, indexInfo, tr, fieldType)
+	start := time.Now()
+	err = s.innerLoadIndex(ctx, fieldSchema, indexInfo, tr, fieldType)
+	recordSegmentEvent(SegmentEventLoadIndex, &s.baseSegment, []int64{indexInfo.GetFieldID()}, start, err)
+	return err
 }
 
 func (s *LocalSegment) innerLoadIndex(ctx context.Context,
@@ -1250,13 +1409,6 @@ func (s *LocalSegment) Release(ctx context.Context, opts ...releaseOption) {
 	for _, opt := range opts {
 		opt(options)
 	}
-	stateLockGuard := s.startRelease(options.Scope)
-	if stateLockGuard == nil { // release is already done.
-		return
-	}
-	// release will never fail
-	defer stateLockGuard.Done(nil)
-
 	log := log.Ctx(ctx).With(zap.Int64("collectionID", s.Collection()),
 		zap.Int64("partitionID", s.Partition()),
 		zap.Int64("segmentID", s.ID()),
@@ -1264,14 +1416,35 @@ func (s *LocalSegment) Release(ctx context.Context, opts ...releaseOption) {
 		zap.Int64("insertCount", s.InsertCount()),
 	)
 
-	// wait all read ops finished
+	// wait all read ops finished, cancel the ones still running after the drain deadline
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	stopCancel := func() bool { return true }
+	if deadline := paramtable.Get().QueryNodeCfg.SegmentReleaseDrainDeadline.GetAsDuration(time.Second); deadline > 0 {
+		stopCancel = s.reads.cancelAfter(deadline, merr.WrapErrSegmentNotLoaded(s.ID(), "segment is releasing"))
+	}
+	tr := timerecord.NewTimeRecorder("drainSegmentReads")
+	stateLockGuard := s.startRelease(options.Scope)
+	if !stopCancel() {
+		metrics.QueryNodeSegmentReleaseDrainCanceled.WithLabelValues(nodeID, s.segmentType.String()).Inc()
+		log.Warn("in-flight reads canceled after the drain deadline", zap.Duration("drainDuration", tr.ElapseSpan()))
+	}
+	metrics.QueryNodeSegmentReleaseDrainLatency.WithLabelValues(nodeID, s.segmentType.String()).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	if stateLockGuard == nil { // release is already done.
+		return
+	}
+	// release will never fail
+	defer stateLockGuard.Done(nil)
+
 	ptr := s.ptr
 	if options.Scope == ReleaseScopeData {
 		s.ReleaseSegmentData()
+		// the segment may be loaded and read again
+		s.reads.reset()
 		log.Info("release segment data done and the field indexes info has been set lazy load=true")
 		return
 	}
 
+	start := time.Now()
 	GetDynamicPool().Submit(func() (any, error) {
 		C.DeleteSegment(ptr)
 		localDiskUsage, err := segcore.GetLocalUsedSize(context.Background(), paramtable.Get().LocalStorageCfg.Path.GetValue())
@@ -1281,6 +1454,7 @@ func (s *LocalSegment) Release(ctx context.Context, opts ...releaseOption) {
 		}
 		return nil, nil
 	}).Await()
+	recordSegmentEvent(SegmentEventDeleteSegment, &s.baseSegment, nil, start, nil)
 
 	log.Info("delete segment from memory")
 }
@@ -66,9 +66,14 @@ type Segment interface {
 	InsertCount() int64
 	// RowNum returns the number of rows, it's slow, so DO NOT call it in a loop
 	RowNum() int64
+	// MemSize returns the size of the anonymous memory used by the segment
 	MemSize() int64
+	// MmapSize returns the size of the field data mapped from the mmap files, not included in MemSize
+	MmapSize() int64
 	// ResourceUsageEstimate returns the estimated resource usage of the segment
 	ResourceUsageEstimate() ResourceUsage
+	// AccessCount returns the number of search/query requests served by the segment
+	AccessCount() int64
 
 	// Index related
 	GetIndex(fieldID int64) *IndexedFieldInfo
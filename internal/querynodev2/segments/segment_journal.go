@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/eventlog"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	SegmentEventNewSegment    = "NewSegment"
+	SegmentEventLoadFieldData = "LoadFieldData"
+	SegmentEventLoadIndex     = "LoadIndexData"
+	SegmentEventDeleteSegment = "DeleteSegment"
+)
+
+// SegmentEvent is an event of creating, loading or releasing a segment in segcore.
+type SegmentEvent struct {
+	Time         time.Time `json:"time"`
+	Event        string    `json:"event"`
+	CollectionID int64     `json:"collection_id"`
+	SegmentID    int64     `json:"segment_id"`
+	SegmentType  string    `json:"segment_type"`
+	// the fields loaded, empty for the events of the whole segment
+	FieldIDs []int64 `json:"field_ids,omitempty"`
+	// in milliseconds
+	Duration int64  `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (e *SegmentEvent) String() string {
+	s := fmt.Sprintf("%s of segment %d[%d] fields %v done in %dms", e.Event, e.SegmentID, e.CollectionID, e.FieldIDs, e.Duration)
+	if e.Error != "" {
+		s = fmt.Sprintf("%s, error: %s", s, e.Error)
+	}
+	return s
+}
+
+// segmentJournal keeps the latest segment events in a ring buffer, the events are lost after restarting,
+// for debugging the loading and releasing of the segments, e.g. which segment loaded slowly or failed.
+type segmentJournal struct {
+	mu       sync.Mutex
+	capacity int
+	events   []*SegmentEvent
+	// the position of the earliest event, where the next event is put once events is full
+	next int
+}
+
+var journal = &segmentJournal{}
+
+func (j *segmentJournal) record(event *SegmentEvent) {
+	capacity := paramtable.Get().QueryNodeCfg.SegmentJournalCapacity.GetAsInt()
+	if paramtable.Get().QueryNodeCfg.SegmentJournalEventLog.GetAsBool() {
+		level := eventlog.Level_Info
+		if event.Error != "" {
+			level = eventlog.Level_Warn
+		}
+		eventlog.Record(eventlog.NewRawEvt(level, event.String()))
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	// the capacity changed, keep the latest events
+	if capacity != j.capacity {
+		events := j.list()
+		j.events, j.next, j.capacity = events[len(events)-min(len(events), max(capacity, 0)):], 0, capacity
+	}
+	if capacity <= 0 {
+		return
+	}
+	if len(j.events) < capacity {
+		j.events = append(j.events, event)
+		return
+	}
+	j.events[j.next] = event
+	j.next = (j.next + 1) % capacity
+}
+
+// list returns the events from the earliest, must be called with the lock held.
+func (j *segmentJournal) list() []*SegmentEvent {
+	events := make([]*SegmentEvent, 0, len(j.events))
+	events = append(events, j.events[j.next:]...)
+	return append(events, j.events[:j.next]...)
+}
+
+// recordSegmentEvent records the event of the segment started at start.
+func recordSegmentEvent(event string, segment *baseSegment, fieldIDs []int64, start time.Time, err error) {
+	e := &SegmentEvent{
+		Time:         start,
+		Event:        event,
+		CollectionID: segment.Collection(),
+		SegmentID:    segment.ID(),
+		SegmentType:  segment.Type().String(),
+		FieldIDs:     fieldIDs,
+		Duration:     time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	journal.record(e)
+}
+
+// ListSegmentEvents lists the latest segment events from the earliest,
+// the events of the collection or the segment only if the id given.
+func ListSegmentEvents(collectionID, segmentID int64) []*SegmentEvent {
+	journal.mu.Lock()
+	events := journal.list()
+	journal.mu.Unlock()
+
+	ret := make([]*SegmentEvent, 0, len(events))
+	for _, event := range events {
+		if (collectionID == 0 || event.CollectionID == collectionID) &&
+			(segmentID == 0 || event.SegmentID == segmentID) {
+			ret = append(ret, event)
+		}
+	}
+	return ret
+}
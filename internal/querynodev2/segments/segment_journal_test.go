@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSegmentJournal(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.SegmentJournalCapacity.Key, "3")
+	defer params.Reset(params.QueryNodeCfg.SegmentJournalCapacity.Key)
+	journal = &segmentJournal{}
+
+	newSegment := func(collectionID, segmentID int64) *baseSegment {
+		return &baseSegment{
+			segmentType: SegmentTypeSealed,
+			loadInfo: atomic.NewPointer(&querypb.SegmentLoadInfo{
+				CollectionID: collectionID,
+				SegmentID:    segmentID,
+			}),
+		}
+	}
+	start := time.Now()
+	recordSegmentEvent(SegmentEventNewSegment, newSegment(100, 1), nil, start, nil)
+	recordSegmentEvent(SegmentEventLoadFieldData, newSegment(100, 1), []int64{101}, start, nil)
+	recordSegmentEvent(SegmentEventLoadIndex, newSegment(100, 1), []int64{102}, start, errors.New("mock error"))
+	recordSegmentEvent(SegmentEventNewSegment, newSegment(200, 2), nil, start, nil)
+
+	// the earliest event evicted
+	events := ListSegmentEvents(0, 0)
+	assert.Equal(t, []string{SegmentEventLoadFieldData, SegmentEventLoadIndex, SegmentEventNewSegment},
+		lo.Map(events, func(event *SegmentEvent, _ int) string { return event.Event }))
+	assert.Equal(t, "mock error", events[1].Error)
+	assert.Equal(t, "Sealed", events[1].SegmentType)
+	assert.Len(t, ListSegmentEvents(100, 0), 2)
+	assert.Len(t, ListSegmentEvents(0, 2), 1)
+
+	// the latest events kept once the capacity shrunk
+	params.Save(params.QueryNodeCfg.SegmentJournalCapacity.Key, "2")
+	recordSegmentEvent(SegmentEventDeleteSegment, newSegment(200, 2), nil, start, nil)
+	assert.Equal(t, []string{SegmentEventNewSegment, SegmentEventDeleteSegment},
+		lo.Map(ListSegmentEvents(0, 0), func(event *SegmentEvent, _ int) string { return event.Event }))
+
+	// disabled
+	params.Save(params.QueryNodeCfg.SegmentJournalCapacity.Key, "0")
+	recordSegmentEvent(SegmentEventDeleteSegment, newSegment(100, 1), nil, start, nil)
+	assert.Empty(t, ListSegmentEvents(0, 0))
+}
@@ -94,6 +94,10 @@ func (s *L0Segment) MemSize() int64 {
 	})
 }
 
+func (s *L0Segment) MmapSize() int64 {
+	return 0
+}
+
 func (s *L0Segment) LastDeltaTimestamp() uint64 {
 	s.dataGuard.RLock()
 	defer s.dataGuard.RUnlock()
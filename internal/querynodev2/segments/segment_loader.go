@@ -26,9 +26,11 @@ import "C"
 import (
 	"context"
 	"fmt"
+	"hash/crc64"
 	"io"
 	"path"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -39,6 +41,7 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
@@ -170,6 +173,8 @@ func NewLoader(
 	loader := &segmentLoader{
 		manager:                   manager,
 		cm:                        cm,
+		fieldLoader:               newFieldLoader(cm, ioPoolSize),
+		indexWarmupLimiter:        semaphore.NewWeighted(paramtable.Get().QueryNodeCfg.BackgroundIndexLoadConcurrency.GetAsInt64()),
 		loadingSegments:           typeutil.NewConcurrentMap[int64, *loadResult](),
 		committedResourceNotifier: syncutil.NewVersionedNotifier(),
 	}
@@ -204,8 +209,11 @@ func (r *loadResult) SetResult(status loadStatus) {
 
 // segmentLoader is only responsible for loading the field data from binlog
 type segmentLoader struct {
-	manager *Manager
-	cm      storage.ChunkManager
+	manager     *Manager
+	cm          storage.ChunkManager
+	fieldLoader *fieldLoader
+	// limits the number of the indexes loading in the background
+	indexWarmupLimiter *semaphore.Weighted
 
 	mut sync.Mutex
 	// The channel will be closed as the segment loaded
@@ -332,7 +340,10 @@ func (loader *segmentLoader) Load(ctx context.Context,
 			s := segment.(*LocalSegment)
 			// lazy load segment do not load segment at first time.
 			if !s.IsLazyLoad() {
-				if err = loader.LoadSegment(ctx, s, loadInfo); err != nil {
+				done := loader.manager.trackCGOCall(s, cgoCallLoad)
+				err = loader.LoadSegment(ctx, s, loadInfo)
+				done()
+				if err != nil {
 					return errors.Wrap(err, "At LoadSegment")
 				}
 			}
@@ -345,6 +356,8 @@ func (loader *segmentLoader) Load(ctx context.Context,
 		newSegments.GetAndRemove(segmentID)
 		loaded.Insert(segmentID, segment)
 		loader.notifyLoadFinish(loadInfo)
+		// reported to querycoord by the distribution, which shall not wait for it
+		ComputePKDigestAsync(segment)
 
 		metrics.QueryNodeLoadSegmentLatency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Observe(float64(tr.ElapseSpan().Milliseconds()))
 		return nil
@@ -621,6 +634,7 @@ func (loader *segmentLoader) LoadBloomFilterSet(ctx context.Context, collectionI
 		partitionID := loadInfo.PartitionID
 		segmentID := loadInfo.SegmentID
 		bfs := pkoracle.NewBloomFilterSet(segmentID, partitionID, commonpb.SegmentState_Sealed)
+		bfs.SetMaxTimestamp(maxBinlogTimestamp(loadInfo.GetBinlogPaths()))
 
 		log.Info("loading bloom filter for remote...")
 		pkStatsBinlogs, logType := loader.filterPKStatsBinlogs(loadInfo.Statslogs, pkField.GetFieldID())
@@ -648,6 +662,17 @@ func (loader *segmentLoader) LoadBloomFilterSet(ctx context.Context, collectionI
 	return loadedBfs.Collect(), nil
 }
 
+// maxBinlogTimestamp returns the max timestamp of the rows in the binlogs, 0 if unknown.
+func maxBinlogTimestamp(fieldBinlogs []*datapb.FieldBinlog) uint64 {
+	var maxTs uint64
+	for _, fieldBinlog := range fieldBinlogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			maxTs = max(maxTs, binlog.GetTimestampTo())
+		}
+	}
+	return maxTs
+}
+
 func separateIndexAndBinlog(loadInfo *querypb.SegmentLoadInfo) (map[int64]*IndexedFieldInfo, []*datapb.FieldBinlog) {
 	fieldID2IndexInfo := make(map[int64]*querypb.FieldIndexInfo)
 	for _, indexInfo := range loadInfo.IndexInfos {
@@ -752,6 +777,13 @@ func (loader *segmentLoader) loadSealedSegment(ctx context.Context, loadInfo *qu
 	collection := segment.GetCollection()
 	schemaHelper, _ := typeutil.CreateSchemaHelper(collection.Schema())
 	indexedFieldInfos, fieldBinlogs, textIndexes, unindexedTextFields := separateLoadInfoV2(loadInfo, collection.Schema())
+	warmupIndexes, err := splitWarmupIndexes(segment, schemaHelper, indexedFieldInfos)
+	if err != nil {
+		return err
+	}
+	for _, info := range warmupIndexes {
+		fieldBinlogs = append(fieldBinlogs, info.FieldBinlog)
+	}
 	if err := segment.AddFieldDataInfo(ctx, loadInfo.GetNumOfRows(), loadInfo.GetBinlogPaths()); err != nil {
 		return err
 	}
@@ -760,6 +792,7 @@ func (loader *segmentLoader) loadSealedSegment(ctx context.Context, loadInfo *qu
 	tr := timerecord.NewTimeRecorder("segmentLoader.loadSealedSegment")
 	log.Info("Start loading fields...",
 		zap.Int64s("indexedFields", lo.Keys(indexedFieldInfos)),
+		zap.Int64s("warmupIndexedFields", lo.Keys(warmupIndexes)),
 		zap.Int64s("indexed text fields", lo.Keys(textIndexes)),
 		zap.Int64s("unindexed text fields", lo.Keys(unindexedTextFields)),
 	)
@@ -822,6 +855,7 @@ func (loader *segmentLoader) loadSealedSegment(ctx context.Context, loadInfo *qu
 		zap.Duration("patchEntryNumberSpan", patchEntryNumberSpan),
 		zap.Duration("loadTextIndexesSpan", loadTextIndexesSpan),
 	)
+	loader.warmupIndexes(segment, warmupIndexes)
 	return nil
 }
 
@@ -859,6 +893,10 @@ func (loader *segmentLoader) LoadSegment(ctx context.Context,
 		if err := loader.loadSealedSegment(ctx, loadInfo, segment); err != nil {
 			return err
 		}
+	} else if paramtable.Get().QueryNodeCfg.ConcurrentFieldLoadEnabled.GetAsBool() {
+		if err := loader.fieldLoader.load(ctx, segment, loadInfo.GetBinlogPaths()); err != nil {
+			return err
+		}
 	} else {
 		if err := segment.LoadMultiFieldData(ctx); err != nil {
 			return err
@@ -1063,9 +1101,38 @@ func (loader *segmentLoader) loadFieldIndex(ctx context.Context, segment *LocalS
 		return merr.WrapErrCollectionNotLoaded(segment.Collection(), "failed to load field index")
 	}
 
+	// the checksum is compared by querycoord with the other replicas of the segment moved
+	if paramtable.Get().QueryCoordCfg.CheckSegmentDigest.GetAsBool() {
+		checksum, err := loader.indexChecksum(ctx, indexInfo.GetIndexFilePaths())
+		if err != nil {
+			log.Ctx(ctx).Warn("failed to compute the checksum of index files, skip it",
+				zap.Int64("segmentID", segment.ID()), zap.Int64("buildID", indexInfo.GetBuildID()), zap.Error(err))
+		}
+		indexInfo.IndexChecksum = checksum
+	}
+
 	return segment.LoadIndex(ctx, indexInfo, fieldType)
 }
 
+// indexChecksum returns the checksum of the content of the index files, independent of the order of the paths.
+func (loader *segmentLoader) indexChecksum(ctx context.Context, paths []string) (uint64, error) {
+	paths = slices.Clone(paths)
+	slices.Sort(paths)
+	hasher := crc64.New(crc64.MakeTable(crc64.ECMA))
+	for _, indexPath := range paths {
+		reader, err := loader.cm.Reader(ctx, indexPath)
+		if err != nil {
+			return 0, err
+		}
+		_, err = io.Copy(hasher, reader)
+		reader.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return hasher.Sum64(), nil
+}
+
 func (loader *segmentLoader) loadBm25Stats(ctx context.Context, segmentID int64, stats map[int64]*storage.BM25Stats, binlogPaths map[int64][]string) error {
 	log := log.Ctx(ctx).With(
 		zap.Int64("segmentID", segmentID),
@@ -1498,6 +1565,10 @@ func getResourceUsageEstimateOfSegment(schema *schemapb.CollectionSchema, loadIn
 			if !estimateResult.HasRawData && !isVectorType {
 				shouldCalculateDataSize = true
 			}
+			// the raw data is loaded before the index loaded in the background
+			if estimateResult.HasRawData && isVectorType && paramtable.Get().QueryNodeCfg.BackgroundIndexLoadEnabled.GetAsBool() {
+				segmentMemorySize += binlogSize
+			}
 			if !estimateResult.HasRawData && isVectorType {
 				mmapChunkCache := paramtable.Get().QueryNodeCfg.MmapChunkCache.GetAsBool()
 				if mmapChunkCache {
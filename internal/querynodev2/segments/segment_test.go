@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -151,6 +152,27 @@ func (suite *SegmentSuite) TestLoadInfo() {
 	suite.NotNil(suite.growing.LoadInfo())
 }
 
+func (suite *SegmentSuite) TestPKDigest() {
+	params := paramtable.Get()
+	// disabled by default, the digest is not computed
+	suite.Zero(PKDigest(suite.sealed))
+	suite.Equal(pkDigestNotComputed, suite.sealed.(*LocalSegment).pkDigestState.Load())
+
+	params.Save(params.QueryCoordCfg.CheckSegmentDigest.Key, "true")
+	defer params.Reset(params.QueryCoordCfg.CheckSegmentDigest.Key)
+	// computed in the background, the caller doesn't wait for it
+	suite.Eventually(func() bool {
+		return PKDigest(suite.sealed) != 0
+	}, 10*time.Second, 10*time.Millisecond)
+	suite.Equal(pkDigestComputed, suite.sealed.(*LocalSegment).pkDigestState.Load())
+	suite.Zero(PKDigest(suite.growing))
+
+	// the failure is cached and not retried
+	suite.sealed.(*LocalSegment).pkDigestState.Store(pkDigestFailed)
+	suite.Zero(PKDigest(suite.sealed))
+	suite.Equal(pkDigestFailed, suite.sealed.(*LocalSegment).pkDigestState.Load())
+}
+
 func (suite *SegmentSuite) TestResourceUsageEstimate() {
 	// growing segment has resource usage
 	// growing segment can not estimate resource usage
@@ -216,9 +238,16 @@ func (suite *SegmentSuite) TestSegmentReleased() {
 	suite.False(sealed.ptrLock.PinIfNotReleased())
 	suite.EqualValues(0, sealed.RowNum())
 	suite.EqualValues(0, sealed.MemSize())
+	suite.EqualValues(0, sealed.MmapSize())
 	suite.False(sealed.HasRawData(101))
 }
 
+func (suite *SegmentSuite) TestMmapSize() {
+	// the fields are loaded into memory by default
+	suite.EqualValues(0, suite.sealed.MmapSize())
+	suite.EqualValues(0, suite.growing.MmapSize())
+}
+
 func TestSegment(t *testing.T) {
 	suite.Run(t, new(SegmentSuite))
 }
@@ -191,6 +191,18 @@ func getIndexEngineVersion() (minimal, current int32) {
 	return minimal, current
 }
 
+// checkIndexEngineVersion returns an error if the index is built by a newer index engine than this node supports,
+// which happens after a downgrade or in a mixed-version cluster.
+// Indexes older than the minimal version are left to the index engine, which converts them on load.
+func checkIndexEngineVersion(indexInfo *querypb.FieldIndexInfo, minimal, current int32) error {
+	version := indexInfo.GetCurrentIndexVersion()
+	if version > current {
+		return merr.WrapErrIndexVersion(int64(version), int64(minimal), int64(current),
+			fmt.Sprintf("index %d of field %d is built by a newer index engine than supported", indexInfo.GetBuildID(), indexInfo.GetFieldID()))
+	}
+	return nil
+}
+
 // getSegmentMetricLabel returns the label for segment metrics.
 func getSegmentMetricLabel(segment Segment) metricsutil.SegmentLabel {
 	return metricsutil.SegmentLabel{
@@ -10,6 +10,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -187,3 +188,10 @@ func TestIsDataMmmapEnable(t *testing.T) {
 		assert.True(t, enable)
 	})
 }
+
+func TestCheckIndexEngineVersion(t *testing.T) {
+	assert.NoError(t, checkIndexEngineVersion(&querypb.FieldIndexInfo{CurrentIndexVersion: 5}, 2, 5))
+	assert.NoError(t, checkIndexEngineVersion(&querypb.FieldIndexInfo{CurrentIndexVersion: 0}, 2, 5))
+	err := checkIndexEngineVersion(&querypb.FieldIndexInfo{CurrentIndexVersion: 6}, 2, 5)
+	assert.ErrorIs(t, err, merr.ErrIndexVersion)
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	grpcquerynodeclient "github.com/milvus-io/milvus/internal/distributed/querynode/client"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/querynodev2/cluster"
 	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
 	"github.com/milvus-io/milvus/internal/querynodev2/pipeline"
@@ -56,6 +57,7 @@ import (
 	"github.com/milvus-io/milvus/internal/registry"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/cgo"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/initcore"
 	"github.com/milvus-io/milvus/internal/util/searchutil/optimizers"
@@ -108,7 +110,9 @@ type QueryNode struct {
 	subscribingChannels   *typeutil.ConcurrentSet[string]
 	unsubscribingChannels *typeutil.ConcurrentSet[string]
 	delegators            *typeutil.ConcurrentMap[string, delegator.ShardDelegator]
-	serverID              int64
+	// sealed segments preloaded by the warm pool, whose delta logs are not caught up yet
+	warmedSegments *typeutil.ConcurrentSet[int64]
+	serverID       int64
 
 	// segment loader
 	loader segments.Loader
@@ -134,6 +138,11 @@ type QueryNode struct {
 
 	// parameter turning hook
 	queryHook optimizers.QueryHook
+	// recall calibration of the search params
+	recallCalibrator *recallCalibrator
+	// report of the deleted ratios of the sealed segments
+	deleteReporter *deleteReporter
+	fanOutReporter *fanOutReporter
 
 	// record the last modify ts of segment/channel distribution
 	lastModifyLock lock.RWMutex
@@ -340,8 +349,10 @@ func (node *QueryNode) Init() error {
 		}
 
 		schedulePolicy := paramtable.Get().QueryNodeCfg.SchedulePolicyName.GetValue()
+		// the tasks of the collections with the dedicated cgo executors are scheduled apart from the others
 		node.scheduler = scheduler.NewScheduler(
 			schedulePolicy,
+			cgo.IsolatedCollectionThreadNum,
 		)
 
 		log.Info("queryNode init scheduler", zap.String("policy", schedulePolicy))
@@ -370,9 +381,18 @@ func (node *QueryNode) Init() error {
 		node.delegators = typeutil.NewConcurrentMap[string, delegator.ShardDelegator]()
 		node.subscribingChannels = typeutil.NewConcurrentSet[string]()
 		node.unsubscribingChannels = typeutil.NewConcurrentSet[string]()
+		node.warmedSegments = typeutil.NewConcurrentSet[int64]()
 		node.manager = segments.NewManager()
 		node.loader = segments.NewLoader(node.manager, node.chunkManager)
 		node.manager.SetLoader(node.loader)
+		node.manager.SetMarkOffline(node.markSegmentOffline)
+		node.recallCalibrator = newRecallCalibrator(node.manager, optimizers.GetRecallTuner())
+		if node.etcdCli != nil {
+			metaKV := etcdkv.NewEtcdKV(node.etcdCli, paramtable.Get().EtcdCfg.MetaRootPath.GetValue(),
+				etcdkv.WithRequestTimeout(paramtable.Get().ServiceParam.EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+			node.deleteReporter = newDeleteReporter(node.GetNodeID(), node.manager, metaKV)
+			node.fanOutReporter = newFanOutReporter(node.GetNodeID(), node.delegators, metaKV)
+		}
 		node.dispClient = msgdispatcher.NewClient(node.factory, typeutil.QueryNodeRole, node.GetNodeID())
 		// init pipeline manager
 		node.pipelineManager = pipeline.NewManager(node.manager, node.tSafeManager, node.dispClient, node.delegators)
@@ -397,6 +417,13 @@ func (node *QueryNode) Init() error {
 func (node *QueryNode) Start() error {
 	node.startOnce.Do(func() {
 		node.scheduler.Start()
+		node.recallCalibrator.Start(node.ctx)
+		if node.deleteReporter != nil {
+			node.deleteReporter.Start(node.ctx)
+		}
+		if node.fanOutReporter != nil {
+			node.fanOutReporter.Start(node.ctx)
+		}
 
 		paramtable.SetCreateTime(time.Now())
 		paramtable.SetUpdateTime(time.Now())
@@ -409,6 +436,8 @@ func (node *QueryNode) Start() error {
 		mmapChunkCache := paramtable.Get().QueryNodeCfg.MmapChunkCache.GetAsBool()
 
 		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		// register devops api
+		RegisterMgrRoute(node)
 
 		registry.GetInMemoryResolver().RegisterQueryNode(node.GetNodeID(), node)
 		log.Info("query node start successfully",
@@ -493,6 +522,15 @@ func (node *QueryNode) Stop() error {
 		if node.scheduler != nil {
 			node.scheduler.Stop()
 		}
+		if node.recallCalibrator != nil {
+			node.recallCalibrator.Stop()
+		}
+		if node.deleteReporter != nil {
+			node.deleteReporter.Stop()
+		}
+		if node.fanOutReporter != nil {
+			node.fanOutReporter.Stop()
+		}
 		if node.pipelineManager != nil {
 			node.pipelineManager.Close()
 		}
@@ -280,18 +280,6 @@ func (node *QueryNode) WatchDmChannels(ctx context.Context, req *querypb.WatchDm
 		}
 	}()
 
-	pipeline, err := node.pipelineManager.Add(req.GetCollectionID(), channel.GetChannelName())
-	if err != nil {
-		msg := "failed to create pipeline"
-		log.Warn(msg, zap.Error(err))
-		return merr.Status(err), nil
-	}
-	defer func() {
-		if err != nil {
-			node.pipelineManager.Remove(channel.GetChannelName())
-		}
-	}()
-
 	growingInfo := lo.SliceToMap(channel.GetUnflushedSegmentIds(), func(id int64) (int64, uint64) {
 		info := req.GetSegmentInfos()[id]
 		return id, info.GetDmlPosition().GetTimestamp()
@@ -320,6 +308,28 @@ func (node *QueryNode) WatchDmChannels(ctx context.Context, req *querypb.WatchDm
 		log.Warn(msg, zap.Error(err))
 		return merr.Status(err), nil
 	}
+	// read-only node serves the data flushed into object storage only without subscribing the channel,
+	// its tsafe advances with the checkpoint of the target instead
+	if !paramtable.Get().QueryNodeCfg.ReadOnlyEnabled.GetAsBool() {
+		err = node.subscribeDmChannel(ctx, req.GetCollectionID(), channel)
+		if err != nil {
+			log.Warn("failed to subscribe dml channel", zap.Error(err))
+			return merr.Status(err), nil
+		}
+	}
+	// delegator after all steps done
+	delegator.Start()
+	log.Info("watch dml channel success")
+	return merr.Success(), nil
+}
+
+// subscribeDmChannel creates the pipeline of the channel and starts consuming from the seek position.
+func (node *QueryNode) subscribeDmChannel(ctx context.Context, collectionID int64, channel *datapb.VchannelInfo) error {
+	pipeline, err := node.pipelineManager.Add(collectionID, channel.GetChannelName())
+	if err != nil {
+		return err
+	}
+
 	position := &msgpb.MsgPosition{
 		ChannelName: channel.SeekPosition.ChannelName,
 		MsgID:       channel.SeekPosition.MsgID,
@@ -327,20 +337,13 @@ func (node *QueryNode) WatchDmChannels(ctx context.Context, req *querypb.WatchDm
 	}
 	err = pipeline.ConsumeMsgStream(ctx, position)
 	if err != nil {
-		err = merr.WrapErrServiceUnavailable(err.Error(), "InitPipelineFailed")
-		log.Warn(err.Error(),
-			zap.Int64("collectionID", channel.CollectionID),
-			zap.String("channel", channel.ChannelName),
-		)
-		return merr.Status(err), nil
+		node.pipelineManager.Remove(channel.GetChannelName())
+		return merr.WrapErrServiceUnavailable(err.Error(), "InitPipelineFailed")
 	}
 
 	// start pipeline
 	pipeline.Start()
-	// delegator after all steps done
-	delegator.Start()
-	log.Info("watch dml channel success")
-	return merr.Success(), nil
+	return nil
 }
 
 func (node *QueryNode) UnsubDmChannel(ctx context.Context, req *querypb.UnsubDmChannelRequest) (*commonpb.Status, error) {
@@ -366,7 +369,9 @@ func (node *QueryNode) UnsubDmChannel(ctx context.Context, req *querypb.UnsubDmC
 		// close the delegator first to block all coming query/search requests
 		delegator.Close()
 
-		node.pipelineManager.Remove(req.GetChannelName())
+		if !paramtable.Get().QueryNodeCfg.ReadOnlyEnabled.GetAsBool() {
+			node.pipelineManager.Remove(req.GetChannelName())
+		}
 		node.manager.Segment.RemoveBy(ctx, segments.WithChannel(req.GetChannelName()), segments.WithType(segments.SegmentTypeGrowing))
 		_, sealed := node.manager.Segment.RemoveBy(ctx, segments.WithChannel(req.GetChannelName()), segments.WithLevel(datapb.SegmentLevel_L0))
 		node.tSafeManager.Remove(ctx, req.GetChannelName())
@@ -486,6 +491,14 @@ func (node *QueryNode) LoadSegments(ctx context.Context, req *querypb.LoadSegmen
 		return node.loadIndex(ctx, req), nil
 	}
 
+	// segments warmed up on a standby node are skipped by the loader,
+	// and only need to catch up the delta logs flushed after the warmup
+	if !req.GetWarmup() {
+		if status := node.catchUpWarmedSegments(ctx, req); !merr.Ok(status) {
+			return status, nil
+		}
+	}
+
 	// Actual load segment
 	log.Info("start to load segments...")
 	loaded, err := node.loader.Load(ctx,
@@ -499,6 +512,11 @@ func (node *QueryNode) LoadSegments(ctx context.Context, req *querypb.LoadSegmen
 	}
 
 	node.manager.Collection.Ref(req.GetCollectionID(), uint32(len(loaded)))
+	if req.GetWarmup() {
+		for _, s := range loaded {
+			node.warmedSegments.Insert(s.ID())
+		}
+	}
 
 	log.Info("load segments done...",
 		zap.Int64s("segments", lo.Map(loaded, func(s segments.Segment, _ int) int64 { return s.ID() })))
@@ -587,6 +605,7 @@ func (node *QueryNode) ReleaseSegments(ctx context.Context, req *querypb.Release
 	for _, id := range req.GetSegmentIDs() {
 		_, count := node.manager.Segment.Remove(ctx, id, req.GetScope())
 		sealedCount += count
+		node.warmedSegments.Remove(id)
 	}
 	node.manager.Collection.Unref(req.GetCollectionID(), uint32(sealedCount))
 
@@ -638,6 +657,7 @@ func (node *QueryNode) GetSegmentInfo(ctx context.Context, in *querypb.GetSegmen
 			NodeID:       node.GetNodeID(),
 			NodeIds:      []int64{node.GetNodeID()},
 			MemSize:      segment.MemSize(),
+			MmapSize:     segment.MmapSize(),
 			NumRows:      segment.InsertCount(),
 			IndexName:    indexName,
 			IndexID:      indexID,
@@ -724,7 +744,7 @@ func (node *QueryNode) SearchSegments(ctx context.Context, req *querypb.SearchRe
 	))
 
 	latency := tr.ElapseSpan()
-	metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.FromLeader).Observe(float64(latency.Milliseconds()))
+	metrics.ObserveWithRequestTags(ctx, metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.FromLeader), float64(latency.Milliseconds()))
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.SuccessLabel, metrics.FromLeader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
 
 	resp = task.SearchResult()
@@ -867,7 +887,7 @@ func (node *QueryNode) QuerySegments(ctx context.Context, req *querypb.QueryRequ
 
 	// TODO QueryNodeSQLatencyInQueue QueryNodeReduceLatency
 	latency := tr.ElapseSpan()
-	metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.FromLeader).Observe(float64(latency.Milliseconds()))
+	metrics.ObserveWithRequestTags(ctx, metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.FromLeader), float64(latency.Milliseconds()))
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.SuccessLabel, metrics.FromLeader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
 	result := task.Result()
 	result.GetCostAggregation().ResponseTime = latency.Milliseconds()
@@ -1056,7 +1076,7 @@ func (node *QueryNode) QueryStreamSegments(req *querypb.QueryRequest, srv queryp
 
 	// TODO QueryNodeSQLatencyInQueue QueryNodeReduceLatency
 	latency := tr.ElapseSpan()
-	metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.FromLeader).Observe(float64(latency.Milliseconds()))
+	metrics.ObserveWithRequestTags(ctx, metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.FromLeader), float64(latency.Milliseconds()))
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.SuccessLabel, metrics.FromLeader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
 	return nil
 }
@@ -1161,6 +1181,7 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 	sealedSegments := node.manager.Segment.GetBy(segments.WithType(commonpb.SegmentState_Sealed))
 	segmentVersionInfos := make([]*querypb.SegmentVersionInfo, 0, len(sealedSegments))
 	for _, s := range sealedSegments {
+		rowCount, realRowCount := segments.SegcoreRowCounts(s)
 		segmentVersionInfos = append(segmentVersionInfos, &querypb.SegmentVersionInfo{
 			ID:                 s.ID(),
 			Collection:         s.Collection(),
@@ -1170,6 +1191,11 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 			Level:              s.Level(),
 			IsSorted:           s.IsSorted(),
 			LastDeltaTimestamp: s.LastDeltaTimestamp(),
+			AccessCount:        s.AccessCount(),
+			WarmingIndexFields: segments.WarmingIndexFields(s),
+			RowCount:           rowCount,
+			RealRowCount:       realRowCount,
+			PkDigest:           segments.PKDigest(s),
 			IndexInfo: lo.SliceToMap(s.Indexes(), func(info *segments.IndexedFieldInfo) (int64, *querypb.FieldIndexInfo) {
 				return info.IndexInfo.FieldID, info.IndexInfo
 			}),
@@ -50,6 +50,10 @@ func (t *QueryStreamTask) Username() string {
 	return t.req.Req.GetUsername()
 }
 
+func (t *QueryStreamTask) CollectionID() int64 {
+	return t.collection.ID()
+}
+
 func (t *QueryStreamTask) IsGpuIndex() bool {
 	return false
 }
@@ -60,6 +60,10 @@ func (t *QueryTask) Username() string {
 	return t.req.Req.GetUsername()
 }
 
+func (t *QueryTask) CollectionID() int64 {
+	return t.collection.ID()
+}
+
 func (t *QueryTask) IsGpuIndex() bool {
 	return false
 }
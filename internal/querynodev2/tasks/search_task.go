@@ -93,6 +93,10 @@ func (t *SearchTask) GetNodeID() int64 {
 	return t.serverID
 }
 
+func (t *SearchTask) CollectionID() int64 {
+	return t.collection.ID()
+}
+
 func (t *SearchTask) IsGpuIndex() bool {
 	return t.collection.IsGpuIndex()
 }
@@ -141,6 +145,11 @@ func (t *SearchTask) Execute() error {
 	}
 	tr := timerecord.NewTimeRecorderWithTrace(t.ctx, "SearchTask")
 
+	cacheKeys := t.searchCacheKeys()
+	if t.fillCachedResults(cacheKeys, tr) {
+		return nil
+	}
+
 	req := t.req
 	err := t.combinePlaceHolderGroups()
 	if err != nil {
@@ -268,11 +277,74 @@ func (t *SearchTask) Execute() error {
 				TotalRelatedDataSize: relatedDataSize,
 			},
 		}
+		if cacheKeys != nil {
+			t.segmentManager.CacheSearchResult(cacheKeys[i], &segments.CachedSearchResult{MetricType: metricType, Blob: bs})
+		}
 	}
 
 	return nil
 }
 
+// searchCacheKeys returns the cache keys of the results of the task and the tasks merged into it,
+// nil if any of them is not cacheable.
+func (t *SearchTask) searchCacheKeys() []string {
+	keys := make([]string, 0, len(t.originNqs))
+	for i := range t.originNqs {
+		var task *SearchTask
+		if i == 0 {
+			task = t
+		} else {
+			task = t.others[i-1]
+		}
+		key, ok := t.segmentManager.SearchCacheKey(task.req)
+		if !ok {
+			return nil
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// fillCachedResults fills the results of the tasks with the cached ones, returns false if any of them is not cached.
+func (t *SearchTask) fillCachedResults(keys []string, tr *timerecord.TimeRecorder) bool {
+	if keys == nil {
+		return false
+	}
+	cached := make([]*segments.CachedSearchResult, 0, len(keys))
+	for _, key := range keys {
+		result, ok := t.segmentManager.GetCachedSearchResult(key)
+		if !ok {
+			return false
+		}
+		cached = append(cached, result)
+	}
+
+	for i, result := range cached {
+		var task *SearchTask
+		if i == 0 {
+			task = t
+		} else {
+			task = t.others[i-1]
+		}
+		task.result = &internalpb.SearchResults{
+			Base: &commonpb.MsgBase{
+				SourceID: t.GetNodeID(),
+			},
+			Status:         merr.Success(),
+			MetricType:     result.MetricType,
+			NumQueries:     t.originNqs[i],
+			TopK:           t.originTopks[i],
+			SlicedBlob:     result.Blob,
+			SlicedOffset:   1,
+			SlicedNumCount: 1,
+			CostAggregation: &internalpb.CostAggregation{
+				ServiceTime: tr.ElapseSpan().Milliseconds(),
+			},
+		}
+	}
+	return true
+}
+
 func (t *SearchTask) Merge(other *SearchTask) bool {
 	var (
 		nq        = t.nq
@@ -19,6 +19,7 @@ package rootcoord
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
@@ -43,6 +44,14 @@ func (a *alterDatabaseTask) Prepare(ctx context.Context) error {
 		return fmt.Errorf("alter database failed, database name does not exists")
 	}
 
+	for _, kv := range a.Req.GetProperties() {
+		if kv.GetKey() != common.DatabaseDiskQuotaKey {
+			continue
+		}
+		if quota, err := strconv.ParseFloat(kv.GetValue(), 64); err != nil || quota < 0 {
+			return merr.WrapErrParameterInvalidMsg("invalid %s %s, should be a non-negative number in MB", kv.GetKey(), kv.GetValue())
+		}
+	}
 	return nil
 }
 
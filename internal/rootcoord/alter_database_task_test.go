@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 )
 
 func Test_alterDatabaseTask_Prepare(t *testing.T) {
@@ -47,6 +48,21 @@ func Test_alterDatabaseTask_Prepare(t *testing.T) {
 		err := task.Prepare(context.Background())
 		assert.NoError(t, err)
 	})
+
+	t.Run("invalid disk quota", func(t *testing.T) {
+		task := &alterDatabaseTask{
+			Req: &rootcoordpb.AlterDatabaseRequest{
+				DbName:     "cn",
+				Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseDiskQuotaKey, Value: "-1"}},
+			},
+		}
+		err := task.Prepare(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		task.Req.Properties[0].Value = "1024"
+		err = task.Prepare(context.Background())
+		assert.NoError(t, err)
+	})
 }
 
 func Test_alterDatabaseTask_Execute(t *testing.T) {
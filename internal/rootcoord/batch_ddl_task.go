@@ -0,0 +1,274 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/internal/util/streamingutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// batchDDLTask creates the collections and the partitions of a database with the timestamp of the task,
+// all of them become visible at once in the last step, or none of them if any failed.
+type batchDDLTask struct {
+	baseTask
+	Req *rootcoordpb.BatchDDLRequest
+
+	createCollections []*createCollectionTask
+	createPartitions  []*createPartitionTask
+}
+
+func (t *batchDDLTask) validate() error {
+	batchSize := len(t.Req.GetCreateCollections()) + len(t.Req.GetCreatePartitions())
+	if batchSize == 0 {
+		return merr.WrapErrParameterInvalidMsg("no collection or partition to create in the batch")
+	}
+	// all of them are made visible in one meta transaction
+	if batchSize > util.MaxEtcdTxnNum/2 {
+		return merr.WrapErrParameterInvalidMsg("too many collections and partitions in the batch: %d, limit: %d", batchSize, util.MaxEtcdTxnNum/2)
+	}
+
+	collections := typeutil.NewSet[string]()
+	for _, req := range t.Req.GetCreateCollections() {
+		if req.GetDbName() != t.Req.GetDbName() {
+			return merr.WrapErrParameterInvalidMsg("collection %s is not of the database %s", req.GetCollectionName(), t.Req.GetDbName())
+		}
+		if collections.Contain(req.GetCollectionName()) {
+			return merr.WrapErrParameterInvalidMsg("duplicate collection %s in the batch", req.GetCollectionName())
+		}
+		collections.Insert(req.GetCollectionName())
+	}
+
+	partitions := typeutil.NewSet[string]()
+	for _, req := range t.Req.GetCreatePartitions() {
+		if req.GetDbName() != t.Req.GetDbName() {
+			return merr.WrapErrParameterInvalidMsg("collection %s is not of the database %s", req.GetCollectionName(), t.Req.GetDbName())
+		}
+		if collections.Contain(req.GetCollectionName()) {
+			return merr.WrapErrParameterInvalidMsg("partition %s of collection %s created in the same batch, set the partitions in the collection request instead",
+				req.GetPartitionName(), req.GetCollectionName())
+		}
+		key := fmt.Sprintf("%s/%s", req.GetCollectionName(), req.GetPartitionName())
+		if partitions.Contain(key) {
+			return merr.WrapErrParameterInvalidMsg("duplicate partition %s of collection %s in the batch", req.GetPartitionName(), req.GetCollectionName())
+		}
+		partitions.Insert(key)
+	}
+	return nil
+}
+
+func (t *batchDDLTask) Prepare(ctx context.Context) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+
+	for _, req := range t.Req.GetCreateCollections() {
+		sub := &createCollectionTask{
+			baseTask: newBaseTask(ctx, t.core),
+			Req:      req,
+		}
+		sub.SetTs(t.GetTs())
+		if err := sub.Prepare(ctx); err != nil {
+			return err
+		}
+		t.createCollections = append(t.createCollections, sub)
+	}
+	for _, req := range t.Req.GetCreatePartitions() {
+		sub := &createPartitionTask{
+			baseTask: newBaseTask(ctx, t.core),
+			Req:      req,
+		}
+		sub.SetTs(t.GetTs())
+		if err := sub.Prepare(ctx); err != nil {
+			return err
+		}
+		t.createPartitions = append(t.createPartitions, sub)
+	}
+	return t.checkCapacity(ctx)
+}
+
+// checkCapacity checks the capacity with all the collections and the partitions of the batch,
+// the sub tasks check it with their own only.
+func (t *batchDDLTask) checkCapacity(ctx context.Context) error {
+	if len(t.createCollections) > 1 {
+		// take the other collections of the batch as created
+		db2CollIDs := t.core.meta.ListAllAvailCollections(ctx)
+		first := t.createCollections[0]
+		db2CollIDs[first.dbID] = append(db2CollIDs[first.dbID], make([]int64, len(t.createCollections)-1)...)
+		if err := first.checkMaxCollectionsPerDB(db2CollIDs); err != nil {
+			return err
+		}
+
+		totalCollections := 0
+		for _, collIDs := range db2CollIDs {
+			totalCollections += len(collIDs)
+		}
+		maxCollectionNum := Params.QuotaConfig.MaxCollectionNum.GetAsInt()
+		if totalCollections >= maxCollectionNum {
+			log.Warn("unable to create collections because the number of collection has reached the limit", zap.Int("max_collection_num", maxCollectionNum))
+			return merr.WrapErrCollectionNumLimitExceeded(t.Req.GetDbName(), maxCollectionNum)
+		}
+	}
+
+	var addedNum int64
+	for _, sub := range t.createCollections {
+		addedNum += int64(len(sub.partIDs)) * int64(sub.Req.GetShardsNum())
+	}
+	addedNum += int64(len(t.createPartitions))
+	return checkGeneralCapacity(ctx, 0, addedNum, 0, t.core)
+}
+
+func (t *batchDDLTask) Execute(ctx context.Context) error {
+	ts := t.GetTs()
+
+	createCollections := make([]*createCollectionTask, 0, len(t.createCollections))
+	for _, sub := range t.createCollections {
+		existedCollInfo, err := t.core.meta.GetCollectionByName(ctx, sub.Req.GetDbName(), sub.Req.GetCollectionName(), typeutil.MaxTimestamp)
+		if err == nil {
+			if !existedCollInfo.Equal(*sub.genCollectionModel(ts)) {
+				return fmt.Errorf("create duplicate collection with different parameters, collection: %s", sub.Req.GetCollectionName())
+			}
+			// make creating collection idempotent.
+			log.Warn("add duplicate collection", zap.String("collection", sub.Req.GetCollectionName()), zap.Uint64("ts", ts))
+			continue
+		}
+		createCollections = append(createCollections, sub)
+	}
+
+	createPartitions := make([]*createPartitionTask, 0, len(t.createPartitions))
+	partitions := make([]*model.Partition, 0, len(t.createPartitions))
+	addedPartitionNum := make(map[int64]int)
+	for _, sub := range t.createPartitions {
+		existed, err := sub.checkPartition()
+		if err != nil {
+			return err
+		}
+		if existed {
+			continue
+		}
+		// the sub task checks the existing partitions only
+		addedPartitionNum[sub.collMeta.CollectionID]++
+		partitionNum := len(sub.collMeta.Partitions) + addedPartitionNum[sub.collMeta.CollectionID]
+		if maxPartitionNum := Params.RootCoordCfg.MaxPartitionNum.GetAsInt(); partitionNum > maxPartitionNum {
+			return fmt.Errorf("partition number (%d) exceeds max configuration (%d), collection: %s",
+				partitionNum, maxPartitionNum, sub.collMeta.Name)
+		}
+		partition, err := sub.genPartitionModel()
+		if err != nil {
+			return err
+		}
+		createPartitions = append(createPartitions, sub)
+		partitions = append(partitions, partition)
+	}
+	if len(createCollections) == 0 && len(createPartitions) == 0 {
+		return nil
+	}
+
+	startPositions, err := t.addChannelsAndGetStartPositions(ctx, ts, createCollections)
+	if err != nil {
+		for _, sub := range createCollections {
+			t.core.chanTimeTick.removeDmlChannels(sub.channels.physicalChannels...)
+		}
+		return err
+	}
+
+	undoTask := newBaseUndoTask(t.core.stepExecutor)
+	for i, sub := range createCollections {
+		collInfo := sub.genCollectionModel(ts)
+		collInfo.StartPositions = toKeyDataPairs(startPositions[i])
+		sub.addCreateSteps(undoTask, collInfo, ts)
+	}
+	for i, sub := range createPartitions {
+		sub.addCreateSteps(undoTask, partitions[i])
+	}
+	// make them visible at once after all created, all the created ones are removed if any step failed
+	undoTask.AddStep(&markCreatedStep{
+		baseStep: baseStep{core: t.core},
+		collectionIDs: lo.Map(createCollections, func(sub *createCollectionTask, _ int) int64 {
+			return sub.collID
+		}),
+		partitions: partitions,
+		ts:         ts,
+	}, &nullStep{}) // the undo steps of the create steps remove them anyway.
+	return undoTask.Execute(ctx)
+}
+
+// addChannelsAndGetStartPositions appends the create collection messages of all the collections to the wal at once,
+// returns the start positions of the collections in order.
+func (t *batchDDLTask) addChannelsAndGetStartPositions(ctx context.Context, ts uint64, createCollections []*createCollectionTask) ([]map[string][]byte, error) {
+	for _, sub := range createCollections {
+		t.core.chanTimeTick.addDmlChannels(sub.channels.physicalChannels...)
+	}
+
+	startPositions := make([]map[string][]byte, 0, len(createCollections))
+	if !streamingutil.IsStreamingServiceEnabled() {
+		// the message stream broadcasts to the channels of a collection at once
+		for _, sub := range createCollections {
+			positions, err := t.core.chanTimeTick.broadcastMarkDmlChannels(sub.channels.physicalChannels, sub.genCreateCollectionMsg(ctx, ts))
+			if err != nil {
+				return nil, err
+			}
+			startPositions = append(startPositions, positions)
+		}
+		return startPositions, nil
+	}
+
+	msgs := make([]message.MutableMessage, 0)
+	for _, sub := range createCollections {
+		subMsgs, err := sub.genCreateCollectionMessages()
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, subMsgs...)
+	}
+	if len(msgs) == 0 {
+		return startPositions, nil
+	}
+	// the same as createCollectionTask, ts must be set as barrier time tick.
+	resps := streaming.WAL().AppendMessagesWithOption(ctx, streaming.AppendOption{
+		BarrierTimeTick: ts,
+	}, msgs...)
+	if err := resps.UnwrapFirstError(); err != nil {
+		return nil, err
+	}
+	offset := 0
+	for _, sub := range createCollections {
+		n := len(sub.channels.virtualChannels)
+		startPositions = append(startPositions, sub.getStartPositions(resps.Responses[offset:offset+n]))
+		offset += n
+	}
+	return startPositions, nil
+}
+
+func (t *batchDDLTask) GetLockerKey() LockerKey {
+	return NewLockerKeyChain(
+		NewClusterLockerKey(false),
+		NewDatabaseLockerKey(t.Req.GetDbName(), true),
+	)
+}
@@ -0,0 +1,228 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func Test_batchDDLTask_validate(t *testing.T) {
+	t.Run("empty batch", func(t *testing.T) {
+		task := &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{DbName: "db"}}
+		err := task.Prepare(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("database mismatch", func(t *testing.T) {
+		task := &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{
+			DbName: "db",
+			CreateCollections: []*milvuspb.CreateCollectionRequest{
+				{DbName: "other", CollectionName: "coll"},
+			},
+		}}
+		assert.ErrorIs(t, task.validate(), merr.ErrParameterInvalid)
+
+		task = &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{
+			DbName: "db",
+			CreatePartitions: []*milvuspb.CreatePartitionRequest{
+				{DbName: "other", CollectionName: "coll", PartitionName: "part"},
+			},
+		}}
+		assert.ErrorIs(t, task.validate(), merr.ErrParameterInvalid)
+	})
+
+	t.Run("duplicate collection", func(t *testing.T) {
+		task := &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{
+			DbName: "db",
+			CreateCollections: []*milvuspb.CreateCollectionRequest{
+				{DbName: "db", CollectionName: "coll"},
+				{DbName: "db", CollectionName: "coll"},
+			},
+		}}
+		assert.ErrorIs(t, task.validate(), merr.ErrParameterInvalid)
+	})
+
+	t.Run("partition of collection in batch", func(t *testing.T) {
+		task := &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{
+			DbName: "db",
+			CreateCollections: []*milvuspb.CreateCollectionRequest{
+				{DbName: "db", CollectionName: "coll"},
+			},
+			CreatePartitions: []*milvuspb.CreatePartitionRequest{
+				{DbName: "db", CollectionName: "coll", PartitionName: "part"},
+			},
+		}}
+		assert.ErrorIs(t, task.validate(), merr.ErrParameterInvalid)
+	})
+
+	t.Run("duplicate partition", func(t *testing.T) {
+		task := &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{
+			DbName: "db",
+			CreatePartitions: []*milvuspb.CreatePartitionRequest{
+				{DbName: "db", CollectionName: "coll", PartitionName: "part"},
+				{DbName: "db", CollectionName: "coll", PartitionName: "part"},
+			},
+		}}
+		assert.ErrorIs(t, task.validate(), merr.ErrParameterInvalid)
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		req := &rootcoordpb.BatchDDLRequest{DbName: "db"}
+		for i := 0; i <= util.MaxEtcdTxnNum/2; i++ {
+			req.CreatePartitions = append(req.CreatePartitions, &milvuspb.CreatePartitionRequest{
+				DbName: "db", CollectionName: "coll", PartitionName: fmt.Sprintf("part%d", i),
+			})
+		}
+		task := &batchDDLTask{Req: req}
+		assert.ErrorIs(t, task.validate(), merr.ErrParameterInvalid)
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		task := &batchDDLTask{Req: &rootcoordpb.BatchDDLRequest{
+			DbName: "db",
+			CreateCollections: []*milvuspb.CreateCollectionRequest{
+				{DbName: "db", CollectionName: "coll1"},
+				{DbName: "db", CollectionName: "coll2"},
+			},
+			CreatePartitions: []*milvuspb.CreatePartitionRequest{
+				{DbName: "db", CollectionName: "coll3", PartitionName: "part1"},
+				{DbName: "db", CollectionName: "coll3", PartitionName: "part2"},
+				{DbName: "db", CollectionName: "coll4", PartitionName: "part1"},
+			},
+		}}
+		assert.NoError(t, task.validate())
+	})
+}
+
+func Test_batchDDLTask_Prepare(t *testing.T) {
+	t.Run("failed to prepare sub task", func(t *testing.T) {
+		core := newTestCore(withInvalidMeta())
+		task := &batchDDLTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req: &rootcoordpb.BatchDDLRequest{
+				DbName: "db",
+				CreatePartitions: []*milvuspb.CreatePartitionRequest{
+					{
+						Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_CreatePartition},
+						DbName:         "db",
+						CollectionName: "coll",
+						PartitionName:  "part",
+					},
+				},
+			},
+		}
+		err := task.Prepare(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func Test_batchDDLTask_Execute(t *testing.T) {
+	paramtable.Init()
+	newPartitionTask := func(core *Core, coll *model.Collection, partitionName string) *createPartitionTask {
+		task := &createPartitionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			collMeta: coll,
+			Req:      &milvuspb.CreatePartitionRequest{DbName: "db", CollectionName: coll.Name, PartitionName: partitionName},
+		}
+		task.SetTs(100)
+		return task
+	}
+
+	t.Run("partition number counted with the batch", func(t *testing.T) {
+		paramtable.Get().Save(Params.RootCoordCfg.MaxPartitionNum.Key, "2")
+		defer paramtable.Get().Reset(Params.RootCoordCfg.MaxPartitionNum.Key)
+
+		meta := mockrootcoord.NewIMetaTable(t)
+		core := newTestCore(withValidIDAllocator(), withValidProxyManager(), withMeta(meta))
+		coll := &model.Collection{CollectionID: 1, Name: "coll", Partitions: []*model.Partition{{PartitionID: 10, PartitionName: "_default"}}}
+		task := &batchDDLTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &rootcoordpb.BatchDDLRequest{DbName: "db"},
+			createPartitions: []*createPartitionTask{
+				newPartitionTask(core, coll, "part1"),
+				newPartitionTask(core, coll, "part2"),
+			},
+		}
+		assert.Error(t, task.Execute(context.Background()))
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().AddPartition(mock.Anything, mock.Anything).Return(nil).Times(2)
+		// all of them become visible at once
+		meta.EXPECT().MarkCollectionsAndPartitionsCreated(mock.Anything, mock.Anything, mock.Anything, uint64(100)).
+			Run(func(ctx context.Context, collectionIDs []int64, partitions []*model.Partition, ts uint64) {
+				assert.Empty(t, collectionIDs)
+				assert.Len(t, partitions, 2)
+			}).Return(nil).Once()
+		core := newTestCore(withValidIDAllocator(), withValidProxyManager(), withMeta(meta))
+		task := &batchDDLTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &rootcoordpb.BatchDDLRequest{DbName: "db"},
+			createPartitions: []*createPartitionTask{
+				newPartitionTask(core, &model.Collection{CollectionID: 1, Name: "coll1"}, "part"),
+				newPartitionTask(core, &model.Collection{CollectionID: 2, Name: "coll2"}, "part"),
+			},
+		}
+		task.SetTs(100)
+		assert.NoError(t, task.Execute(context.Background()))
+	})
+
+	t.Run("rollback all if failed to make them visible", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().AddPartition(mock.Anything, mock.Anything).Return(nil).Times(2)
+		meta.EXPECT().MarkCollectionsAndPartitionsCreated(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("mock error")).Once()
+		removed := make(chan int64, 2)
+		meta.EXPECT().RemovePartition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, dbID int64, collectionID int64, partitionID int64, ts uint64) {
+				removed <- collectionID
+			}).Return(nil).Times(2)
+		broker := newMockBroker()
+		broker.ReleasePartitionsFunc = func(ctx context.Context, collectionID UniqueID, partitionIDs ...UniqueID) error {
+			return nil
+		}
+		core := newTestCore(withValidIDAllocator(), withValidProxyManager(), withMeta(meta), withBroker(broker))
+		task := &batchDDLTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &rootcoordpb.BatchDDLRequest{DbName: "db"},
+			createPartitions: []*createPartitionTask{
+				newPartitionTask(core, &model.Collection{CollectionID: 1, Name: "coll1"}, "part"),
+				newPartitionTask(core, &model.Collection{CollectionID: 2, Name: "coll2"}, "part"),
+			},
+		}
+		task.SetTs(100)
+		assert.Error(t, task.Execute(context.Background()))
+		// the undo steps run in background
+		assert.ElementsMatch(t, []int64{1, 2}, []int64{<-removed, <-removed})
+	})
+}
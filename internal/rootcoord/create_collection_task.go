@@ -491,8 +491,26 @@ func (t *createCollectionTask) addChannelsAndGetStartPositions(ctx context.Conte
 }
 
 func (t *createCollectionTask) broadcastCreateCollectionMsgIntoStreamingService(ctx context.Context, ts uint64) (map[string][]byte, error) {
+	msgs, err := t.genCreateCollectionMessages()
+	if err != nil {
+		return nil, err
+	}
+	// send the createCollectionMsg into streaming service.
+	// ts is used as initial checkpoint at datacoord,
+	// it must be set as barrier time tick.
+	// The timetick of create message in wal must be greater than ts, to avoid data read loss at read side.
+	resps := streaming.WAL().AppendMessagesWithOption(ctx, streaming.AppendOption{
+		BarrierTimeTick: ts,
+	}, msgs...)
+	if err := resps.UnwrapFirstError(); err != nil {
+		return nil, err
+	}
+	return t.getStartPositions(resps.Responses), nil
+}
+
+// genCreateCollectionMessages dispatches the createCollectionMsg into all vchannel.
+func (t *createCollectionTask) genCreateCollectionMessages() ([]message.MutableMessage, error) {
 	req := t.genCreateCollectionRequest()
-	// dispatch the createCollectionMsg into all vchannel.
 	msgs := make([]message.MutableMessage, 0, len(req.VirtualChannelNames))
 	for _, vchannel := range req.VirtualChannelNames {
 		msg, err := message.NewCreateCollectionMessageBuilderV1().
@@ -508,23 +526,18 @@ func (t *createCollectionTask) broadcastCreateCollectionMsgIntoStreamingService(
 		}
 		msgs = append(msgs, msg)
 	}
-	// send the createCollectionMsg into streaming service.
-	// ts is used as initial checkpoint at datacoord,
-	// it must be set as barrier time tick.
-	// The timetick of create message in wal must be greater than ts, to avoid data read loss at read side.
-	resps := streaming.WAL().AppendMessagesWithOption(ctx, streaming.AppendOption{
-		BarrierTimeTick: ts,
-	}, msgs...)
-	if err := resps.UnwrapFirstError(); err != nil {
-		return nil, err
-	}
+	return msgs, nil
+}
+
+// getStartPositions returns the start positions of the responses of the messages generated by genCreateCollectionMessages.
+func (t *createCollectionTask) getStartPositions(resps []streaming.AppendResponse) map[string][]byte {
 	// make the old message stream serialized id.
 	startPositions := make(map[string][]byte)
-	for idx, resp := range resps.Responses {
+	for idx, resp := range resps {
 		// The key is pchannel here
-		startPositions[req.PhysicalChannelNames[idx]] = adaptor.MustGetMQWrapperIDFromMessage(resp.AppendResult.MessageID).Serialize()
+		startPositions[t.channels.physicalChannels[idx]] = adaptor.MustGetMQWrapperIDFromMessage(resp.AppendResult.MessageID).Serialize()
 	}
-	return startPositions, nil
+	return startPositions
 }
 
 func (t *createCollectionTask) getCreateTs() (uint64, error) {
@@ -540,45 +553,11 @@ func (t *createCollectionTask) getCreateTs() (uint64, error) {
 }
 
 func (t *createCollectionTask) Execute(ctx context.Context) error {
-	collID := t.collID
-	partIDs := t.partIDs
 	ts, err := t.getCreateTs()
 	if err != nil {
 		return err
 	}
-
-	vchanNames := t.channels.virtualChannels
-	chanNames := t.channels.physicalChannels
-
-	partitions := make([]*model.Partition, len(partIDs))
-	for i, partID := range partIDs {
-		partitions[i] = &model.Partition{
-			PartitionID:               partID,
-			PartitionName:             t.partitionNames[i],
-			PartitionCreatedTimestamp: ts,
-			CollectionID:              collID,
-			State:                     pb.PartitionState_PartitionCreated,
-		}
-	}
-
-	collInfo := model.Collection{
-		CollectionID:         collID,
-		DBID:                 t.dbID,
-		Name:                 t.schema.Name,
-		Description:          t.schema.Description,
-		AutoID:               t.schema.AutoID,
-		Fields:               model.UnmarshalFieldModels(t.schema.Fields),
-		Functions:            model.UnmarshalFunctionModels(t.schema.Functions),
-		VirtualChannelNames:  vchanNames,
-		PhysicalChannelNames: chanNames,
-		ShardsNum:            t.Req.ShardsNum,
-		ConsistencyLevel:     t.Req.ConsistencyLevel,
-		CreateTime:           ts,
-		State:                pb.CollectionState_CollectionCreating,
-		Partitions:           partitions,
-		Properties:           t.Req.Properties,
-		EnableDynamicField:   t.schema.EnableDynamicField,
-	}
+	collInfo := t.genCollectionModel(ts)
 
 	// We cannot check the idempotency inside meta table when adding collection, since we'll execute duplicate steps
 	// if add collection successfully due to idempotency check. Some steps may be risky to be duplicate executed if they
@@ -607,31 +586,71 @@ func (t *createCollectionTask) Execute(ctx context.Context) error {
 	collInfo.StartPositions = toKeyDataPairs(startPositions)
 
 	undoTask := newBaseUndoTask(t.core.stepExecutor)
+	t.addCreateSteps(undoTask, collInfo, ts)
+	t.addCommitSteps(undoTask, ts)
+	return undoTask.Execute(ctx)
+}
+
+func (t *createCollectionTask) genCollectionModel(ts uint64) *model.Collection {
+	partitions := make([]*model.Partition, len(t.partIDs))
+	for i, partID := range t.partIDs {
+		partitions[i] = &model.Partition{
+			PartitionID:               partID,
+			PartitionName:             t.partitionNames[i],
+			PartitionCreatedTimestamp: ts,
+			CollectionID:              t.collID,
+			State:                     pb.PartitionState_PartitionCreated,
+		}
+	}
+
+	return &model.Collection{
+		CollectionID:         t.collID,
+		DBID:                 t.dbID,
+		Name:                 t.schema.Name,
+		Description:          t.schema.Description,
+		AutoID:               t.schema.AutoID,
+		Fields:               model.UnmarshalFieldModels(t.schema.Fields),
+		Functions:            model.UnmarshalFunctionModels(t.schema.Functions),
+		VirtualChannelNames:  t.channels.virtualChannels,
+		PhysicalChannelNames: t.channels.physicalChannels,
+		ShardsNum:            t.Req.ShardsNum,
+		ConsistencyLevel:     t.Req.ConsistencyLevel,
+		CreateTime:           ts,
+		State:                pb.CollectionState_CollectionCreating,
+		Partitions:           partitions,
+		Properties:           t.Req.Properties,
+		EnableDynamicField:   t.schema.EnableDynamicField,
+	}
+}
+
+// addCreateSteps adds the steps creating the collection, which is invisible until the commit steps done.
+// The dml channels must be added and the start positions set before.
+func (t *createCollectionTask) addCreateSteps(undoTask *baseUndoTask, collInfo *model.Collection, ts uint64) {
 	undoTask.AddStep(&expireCacheStep{
 		baseStep:        baseStep{core: t.core},
 		dbName:          t.Req.GetDbName(),
 		collectionNames: []string{t.Req.GetCollectionName()},
-		collectionID:    collID,
+		collectionID:    t.collID,
 		ts:              ts,
 		opts:            []proxyutil.ExpireCacheOpt{proxyutil.SetMsgType(commonpb.MsgType_DropCollection)},
 	}, &nullStep{})
 	undoTask.AddStep(&nullStep{}, &removeDmlChannelsStep{
 		baseStep:  baseStep{core: t.core},
-		pChannels: chanNames,
+		pChannels: t.channels.physicalChannels,
 	}) // remove dml channels if any error occurs.
 	undoTask.AddStep(&addCollectionMetaStep{
 		baseStep: baseStep{core: t.core},
-		coll:     &collInfo,
+		coll:     collInfo,
 	}, &deleteCollectionMetaStep{
 		baseStep:     baseStep{core: t.core},
-		collectionID: collID,
+		collectionID: t.collID,
 		// When we undo createCollectionTask, this ts may be less than the ts when unwatch channels.
 		ts: ts,
 	})
 	// serve for this case: watching channels succeed in datacoord but failed due to network failure.
 	undoTask.AddStep(&nullStep{}, &unwatchChannelsStep{
 		baseStep:     baseStep{core: t.core},
-		collectionID: collID,
+		collectionID: t.collID,
 		channels:     t.channels,
 		isSkip:       !Params.CommonCfg.TTMsgEnabled.GetAsBool(),
 	})
@@ -639,26 +658,29 @@ func (t *createCollectionTask) Execute(ctx context.Context) error {
 		baseStep: baseStep{core: t.core},
 		info: &watchInfo{
 			ts:             ts,
-			collectionID:   collID,
+			collectionID:   t.collID,
 			vChannels:      t.channels.virtualChannels,
-			startPositions: toKeyDataPairs(startPositions),
+			startPositions: collInfo.StartPositions,
 			schema: &schemapb.CollectionSchema{
 				Name:        collInfo.Name,
 				Description: collInfo.Description,
 				AutoID:      collInfo.AutoID,
 				Fields:      model.MarshalFieldModels(collInfo.Fields),
 				Functions:   model.MarshalFunctionModels(collInfo.Functions),
+				Properties:  collInfo.Properties,
 			},
 		},
 	}, &nullStep{})
+}
+
+// addCommitSteps adds the steps making the collection visible.
+func (t *createCollectionTask) addCommitSteps(undoTask *baseUndoTask, ts uint64) {
 	undoTask.AddStep(&changeCollectionStateStep{
 		baseStep:     baseStep{core: t.core},
-		collectionID: collID,
+		collectionID: t.collID,
 		state:        pb.CollectionState_CollectionCreated,
 		ts:           ts,
 	}, &nullStep{}) // We'll remove the whole collection anyway.
-
-	return undoTask.Execute(ctx)
 }
 
 func (t *createCollectionTask) GetLockerKey() LockerKey {
@@ -50,34 +50,56 @@ func (t *createPartitionTask) Prepare(ctx context.Context) error {
 }
 
 func (t *createPartitionTask) Execute(ctx context.Context) error {
+	existed, err := t.checkPartition()
+	if err != nil || existed {
+		return err
+	}
+
+	partition, err := t.genPartitionModel()
+	if err != nil {
+		return err
+	}
+
+	undoTask := newBaseUndoTask(t.core.stepExecutor)
+	t.addCreateSteps(undoTask, partition)
+	t.addCommitSteps(undoTask, partition)
+	return undoTask.Execute(ctx)
+}
+
+// checkPartition returns true if the partition existed already, or error if exceeding the max partition number.
+func (t *createPartitionTask) checkPartition() (bool, error) {
 	for _, partition := range t.collMeta.Partitions {
 		if partition.PartitionName == t.Req.GetPartitionName() {
 			log.Warn("add duplicate partition", zap.String("collection", t.Req.GetCollectionName()), zap.String("partition", t.Req.GetPartitionName()), zap.Uint64("ts", t.GetTs()))
-			return nil
+			return true, nil
 		}
 	}
 
 	cfgMaxPartitionNum := Params.RootCoordCfg.MaxPartitionNum.GetAsInt()
 	if len(t.collMeta.Partitions) >= cfgMaxPartitionNum {
-		return fmt.Errorf("partition number (%d) exceeds max configuration (%d), collection: %s",
+		return false, fmt.Errorf("partition number (%d) exceeds max configuration (%d), collection: %s",
 			len(t.collMeta.Partitions), cfgMaxPartitionNum, t.collMeta.Name)
 	}
+	return false, nil
+}
 
+func (t *createPartitionTask) genPartitionModel() (*model.Partition, error) {
 	partID, err := t.core.idAllocator.AllocOne()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	partition := &model.Partition{
+	return &model.Partition{
 		PartitionID:               partID,
 		PartitionName:             t.Req.GetPartitionName(),
 		PartitionCreatedTimestamp: t.GetTs(),
 		Extra:                     nil,
 		CollectionID:              t.collMeta.CollectionID,
 		State:                     pb.PartitionState_PartitionCreating,
-	}
-
-	undoTask := newBaseUndoTask(t.core.stepExecutor)
+	}, nil
+}
 
+// addCreateSteps adds the steps creating the partition, which is invisible until the commit steps done.
+func (t *createPartitionTask) addCreateSteps(undoTask *baseUndoTask, partition *model.Partition) {
 	undoTask.AddStep(&expireCacheStep{
 		baseStep:        baseStep{core: t.core},
 		dbName:          t.Req.GetDbName(),
@@ -111,18 +133,19 @@ func (t *createPartitionTask) Execute(ctx context.Context) error {
 	undoTask.AddStep(&nullStep{}, &releasePartitionsStep{
 		baseStep:     baseStep{core: t.core},
 		collectionID: t.collMeta.CollectionID,
-		partitionIDs: []int64{partID},
+		partitionIDs: []int64{partition.PartitionID},
 	})
+}
 
+// addCommitSteps adds the steps making the partition visible.
+func (t *createPartitionTask) addCommitSteps(undoTask *baseUndoTask, partition *model.Partition) {
 	undoTask.AddStep(&changePartitionStateStep{
 		baseStep:     baseStep{core: t.core},
 		collectionID: t.collMeta.CollectionID,
-		partitionID:  partID,
+		partitionID:  partition.PartitionID,
 		state:        pb.PartitionState_PartitionCreated,
 		ts:           t.GetTs(),
 	}, &nullStep{})
-
-	return undoTask.Execute(ctx)
 }
 
 func (t *createPartitionTask) GetLockerKey() LockerKey {
@@ -62,3 +62,47 @@ func (t *describeDBTask) GetLockerKey() LockerKey {
 		NewDatabaseLockerKey(t.Req.GetDbName(), false),
 	)
 }
+
+// describeDBsTask describes all the databases in one request
+type describeDBsTask struct {
+	baseTask
+	Req *rootcoordpb.DescribeDatabasesRequest
+	Rsp *rootcoordpb.DescribeDatabasesResponse
+}
+
+func (t *describeDBsTask) Prepare(ctx context.Context) error {
+	return nil
+}
+
+// Execute task execution
+func (t *describeDBsTask) Execute(ctx context.Context) error {
+	dbs, err := t.core.meta.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		t.Rsp = &rootcoordpb.DescribeDatabasesResponse{
+			Status: merr.Status(err),
+		}
+		return err
+	}
+
+	databases := make([]*rootcoordpb.DescribeDatabaseResponse, 0, len(dbs))
+	for _, db := range dbs {
+		databases = append(databases, &rootcoordpb.DescribeDatabaseResponse{
+			Status:           merr.Success(),
+			DbID:             db.ID,
+			DbName:           db.Name,
+			CreatedTimestamp: db.CreatedTime,
+			Properties:       db.Properties,
+		})
+	}
+	t.Rsp = &rootcoordpb.DescribeDatabasesResponse{
+		Status:    merr.Success(),
+		Databases: databases,
+	}
+	return nil
+}
+
+func (t *describeDBsTask) GetLockerKey() LockerKey {
+	return NewLockerKeyChain(
+		NewClusterLockerKey(false),
+	)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util"
 )
 
@@ -86,3 +87,47 @@ func Test_describeDatabaseTask_Execute(t *testing.T) {
 		assert.Equal(t, uint64(1), task.Rsp.GetCreatedTimestamp())
 	})
 }
+
+func Test_describeDatabasesTask_Execute(t *testing.T) {
+	t.Run("failed to list databases", func(t *testing.T) {
+		core := newTestCore(withInvalidMeta())
+		task := &describeDBsTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &rootcoordpb.DescribeDatabasesRequest{},
+		}
+		err := task.Execute(context.Background())
+		assert.Error(t, err)
+		assert.NotNil(t, task.Rsp)
+		assert.NotNil(t, task.Rsp.Status)
+	})
+
+	t.Run("describe all databases", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListDatabases(mock.Anything, mock.Anything).
+			Return([]*model.Database{
+				model.NewDefaultDatabase(nil),
+				{
+					Name:        "db1",
+					ID:          100,
+					CreatedTime: 1,
+					Properties: []*commonpb.KeyValuePair{
+						{Key: common.DatabaseResourceGroups, Value: "rg1"},
+					},
+				},
+			}, nil)
+		core := newTestCore(withMeta(meta))
+
+		task := &describeDBsTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &rootcoordpb.DescribeDatabasesRequest{},
+		}
+		err := task.Execute(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, task.Rsp.GetStatus().GetErrorCode())
+		assert.Len(t, task.Rsp.GetDatabases(), 2)
+		assert.Equal(t, util.DefaultDBName, task.Rsp.GetDatabases()[0].GetDbName())
+		assert.Equal(t, "db1", task.Rsp.GetDatabases()[1].GetDbName())
+		assert.Equal(t, int64(100), task.Rsp.GetDatabases()[1].GetDbID())
+		assert.Equal(t, "rg1", task.Rsp.GetDatabases()[1].GetProperties()[0].GetValue())
+	})
+}
@@ -70,6 +70,8 @@ type IMetaTable interface {
 	GetPChannelInfo(ctx context.Context, pchannel string) *rootcoordpb.GetPChannelInfoResponse
 	AddPartition(ctx context.Context, partition *model.Partition) error
 	ChangePartitionState(ctx context.Context, collectionID UniqueID, partitionID UniqueID, state pb.PartitionState, ts Timestamp) error
+	// MarkCollectionsAndPartitionsCreated makes the collections and the partitions of the existing collections visible at once.
+	MarkCollectionsAndPartitionsCreated(ctx context.Context, collectionIDs []UniqueID, partitions []*model.Partition, ts Timestamp) error
 	RemovePartition(ctx context.Context, dbID int64, collectionID UniqueID, partitionID UniqueID, ts Timestamp) error
 	CreateAlias(ctx context.Context, dbName string, alias string, collectionName string, ts Timestamp) error
 	DropAlias(ctx context.Context, dbName string, alias string, ts Timestamp) error
@@ -982,6 +984,66 @@ func (mt *MetaTable) ChangePartitionState(ctx context.Context, collectionID Uniq
 	return fmt.Errorf("partition not exist, collection: %d, partition: %d", collectionID, partitionID)
 }
 
+// MarkCollectionsAndPartitionsCreated changes the state of the collections and the partitions to created
+// in one transaction, so all of them become visible at once, or none of them.
+func (mt *MetaTable) MarkCollectionsAndPartitionsCreated(ctx context.Context, collectionIDs []UniqueID, partitions []*model.Partition, ts Timestamp) error {
+	mt.ddLock.Lock()
+	defer mt.ddLock.Unlock()
+
+	colls := make([]*model.Collection, 0, len(collectionIDs))
+	for _, collectionID := range collectionIDs {
+		coll, ok := mt.collID2Meta[collectionID]
+		if !ok {
+			return merr.WrapErrCollectionNotFound(collectionID)
+		}
+		clone := coll.Clone()
+		clone.State = pb.CollectionState_CollectionCreated
+		colls = append(colls, clone)
+	}
+	parts := make([]*model.Partition, 0, len(partitions))
+	for _, partition := range partitions {
+		coll, ok := mt.collID2Meta[partition.CollectionID]
+		if !ok {
+			return merr.WrapErrCollectionNotFound(partition.CollectionID)
+		}
+		part, ok := lo.Find(coll.Partitions, func(part *model.Partition) bool {
+			return part.PartitionID == partition.PartitionID
+		})
+		if !ok {
+			return fmt.Errorf("partition not exist, collection: %d, partition: %d", partition.CollectionID, partition.PartitionID)
+		}
+		clone := part.Clone()
+		clone.State = pb.PartitionState_PartitionCreated
+		parts = append(parts, clone)
+	}
+
+	ctx1 := contextutil.WithTenantID(ctx, Params.CommonCfg.ClusterName.GetValue())
+	if err := mt.catalog.AlterCollectionsAndPartitions(ctx1, colls, parts, ts); err != nil {
+		return err
+	}
+
+	for _, coll := range colls {
+		mt.collID2Meta[coll.CollectionID] = coll
+		if db, err := mt.getDatabaseByIDInternal(ctx, coll.DBID, typeutil.MaxTimestamp); err == nil {
+			metrics.RootCoordNumOfCollections.WithLabelValues(db.Name).Inc()
+		}
+		metrics.RootCoordNumOfPartitions.WithLabelValues().Add(float64(coll.GetPartitionNum(true)))
+	}
+	for _, part := range parts {
+		coll := mt.collID2Meta[part.CollectionID]
+		for idx := range coll.Partitions {
+			if coll.Partitions[idx].PartitionID == part.PartitionID {
+				coll.Partitions[idx] = part
+			}
+		}
+		metrics.RootCoordNumOfPartitions.WithLabelValues().Inc()
+	}
+
+	log.Ctx(ctx).Info("mark collections and partitions created",
+		zap.Int64s("collections", collectionIDs), zap.Int("partitionNum", len(partitions)), zap.Uint64("ts", ts))
+	return nil
+}
+
 func (mt *MetaTable) RemovePartition(ctx context.Context, dbID int64, collectionID UniqueID, partitionID UniqueID, ts Timestamp) error {
 	mt.ddLock.Lock()
 	defer mt.ddLock.Unlock()
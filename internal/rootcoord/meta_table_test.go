@@ -1756,6 +1756,58 @@ func TestMetaTable_ChangePartitionState(t *testing.T) {
 	})
 }
 
+func TestMetaTable_MarkCollectionsAndPartitionsCreated(t *testing.T) {
+	newMeta := func(catalog *mocks.RootCoordCatalog) *MetaTable {
+		return &MetaTable{
+			catalog: catalog,
+			collID2Meta: map[typeutil.UniqueID]*model.Collection{
+				100: {Name: "creating", CollectionID: 100, State: pb.CollectionState_CollectionCreating},
+				200: {
+					Name: "created", CollectionID: 200, State: pb.CollectionState_CollectionCreated,
+					Partitions: []*model.Partition{
+						{CollectionID: 200, PartitionID: 500, State: pb.PartitionState_PartitionCreating},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("partition not exist", func(t *testing.T) {
+		meta := newMeta(mocks.NewRootCoordCatalog(t))
+		err := meta.MarkCollectionsAndPartitionsCreated(context.TODO(), []int64{100},
+			[]*model.Partition{{CollectionID: 200, PartitionID: 501}}, 1000)
+		assert.Error(t, err)
+		assert.Equal(t, pb.CollectionState_CollectionCreating, meta.collID2Meta[100].State)
+	})
+
+	t.Run("failed to alter", func(t *testing.T) {
+		catalog := mocks.NewRootCoordCatalog(t)
+		catalog.EXPECT().AlterCollectionsAndPartitions(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error mock AlterCollectionsAndPartitions"))
+		meta := newMeta(catalog)
+		err := meta.MarkCollectionsAndPartitionsCreated(context.TODO(), []int64{100},
+			[]*model.Partition{{CollectionID: 200, PartitionID: 500}}, 1000)
+		assert.Error(t, err)
+		assert.Equal(t, pb.CollectionState_CollectionCreating, meta.collID2Meta[100].State)
+		assert.Equal(t, pb.PartitionState_PartitionCreating, meta.collID2Meta[200].Partitions[0].State)
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		catalog := mocks.NewRootCoordCatalog(t)
+		catalog.EXPECT().AlterCollectionsAndPartitions(mock.Anything, mock.Anything, mock.Anything, uint64(1000)).
+			Run(func(ctx context.Context, colls []*model.Collection, partitions []*model.Partition, ts uint64) {
+				assert.Len(t, colls, 1)
+				assert.Len(t, partitions, 1)
+			}).Return(nil).Once()
+		meta := newMeta(catalog)
+		err := meta.MarkCollectionsAndPartitionsCreated(context.TODO(), []int64{100},
+			[]*model.Partition{{CollectionID: 200, PartitionID: 500}}, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.CollectionState_CollectionCreated, meta.collID2Meta[100].State)
+		assert.Equal(t, pb.PartitionState_PartitionCreated, meta.collID2Meta[200].Partitions[0].State)
+	})
+}
+
 func TestMetaTable_CreateDatabase(t *testing.T) {
 	db := model.NewDatabase(1, "exist", pb.DatabaseState_DatabaseCreated, nil)
 	t.Run("database already exist", func(t *testing.T) {
@@ -2340,6 +2340,55 @@ func (_c *IMetaTable_ListUserRole_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// MarkCollectionsAndPartitionsCreated provides a mock function with given fields: ctx, collectionIDs, partitions, ts
+func (_m *IMetaTable) MarkCollectionsAndPartitionsCreated(ctx context.Context, collectionIDs []int64, partitions []*model.Partition, ts uint64) error {
+	ret := _m.Called(ctx, collectionIDs, partitions, ts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkCollectionsAndPartitionsCreated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, []*model.Partition, uint64) error); ok {
+		r0 = rf(ctx, collectionIDs, partitions, ts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMetaTable_MarkCollectionsAndPartitionsCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkCollectionsAndPartitionsCreated'
+type IMetaTable_MarkCollectionsAndPartitionsCreated_Call struct {
+	*mock.Call
+}
+
+// MarkCollectionsAndPartitionsCreated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collectionIDs []int64
+//   - partitions []*model.Partition
+//   - ts uint64
+func (_e *IMetaTable_Expecter) MarkCollectionsAndPartitionsCreated(ctx interface{}, collectionIDs interface{}, partitions interface{}, ts interface{}) *IMetaTable_MarkCollectionsAndPartitionsCreated_Call {
+	return &IMetaTable_MarkCollectionsAndPartitionsCreated_Call{Call: _e.mock.On("MarkCollectionsAndPartitionsCreated", ctx, collectionIDs, partitions, ts)}
+}
+
+func (_c *IMetaTable_MarkCollectionsAndPartitionsCreated_Call) Run(run func(ctx context.Context, collectionIDs []int64, partitions []*model.Partition, ts uint64)) *IMetaTable_MarkCollectionsAndPartitionsCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64), args[2].([]*model.Partition), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *IMetaTable_MarkCollectionsAndPartitionsCreated_Call) Return(_a0 error) *IMetaTable_MarkCollectionsAndPartitionsCreated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMetaTable_MarkCollectionsAndPartitionsCreated_Call) RunAndReturn(run func(context.Context, []int64, []*model.Partition, uint64) error) *IMetaTable_MarkCollectionsAndPartitionsCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OperatePrivilege provides a mock function with given fields: ctx, tenant, entity, operateType
 func (_m *IMetaTable) OperatePrivilege(ctx context.Context, tenant string, entity *milvuspb.GrantEntity, operateType milvuspb.OperatePrivilegeType) error {
 	ret := _m.Called(ctx, tenant, entity, operateType)
@@ -1256,7 +1256,11 @@ func (q *QuotaCenter) checkDiskQuota(denyWritingDBs map[int64]struct{}) error {
 
 	// check disk quota of cluster level
 	totalDiskQuota := Params.QuotaConfig.DiskQuota.GetAsFloat()
+	includeIndexSize := Params.QuotaConfig.DiskQuotaIncludeIndexSize.GetAsBool()
 	total := q.dataCoordMetrics.TotalBinlogSize
+	if includeIndexSize {
+		total += q.dataCoordMetrics.TotalIndexSize
+	}
 	if float64(total) >= totalDiskQuota {
 		log.RatedWarn(10, "cluster disk quota exceeded", zap.Int64("disk usage", total), zap.Float64("disk quota", totalDiskQuota))
 		err := q.forceDenyWriting(commonpb.ErrorCode_DiskQuotaExhausted, true, nil, nil, nil)
@@ -1270,6 +1274,9 @@ func (q *QuotaCenter) checkDiskQuota(denyWritingDBs map[int64]struct{}) error {
 	dbSizeInfo := make(map[int64]int64)
 	collections := make([]int64, 0)
 	for collection, binlogSize := range q.dataCoordMetrics.CollectionBinlogSize {
+		if includeIndexSize {
+			binlogSize += q.dataCoordMetrics.CollectionIndexSize[collection]
+		}
 		collectionProps := q.getCollectionLimitProperties(collection)
 		colDiskQuota := getRateLimitConfig(collectionProps, common.CollectionDiskQuotaKey, collectionDiskQuota)
 		if float64(binlogSize) >= colDiskQuota {
@@ -952,6 +952,20 @@ func TestQuotaCenter(t *testing.T) {
 		quotaCenter.resetAllCurrentRates()
 		quotaCenter.checkDiskQuota(nil)
 		checkLimiter(1)
+
+		// index files are counted into collection disk usage if enabled
+		quotaCenter.dataCoordMetrics = &metricsinfo.DataCoordQuotaMetrics{
+			CollectionBinlogSize: map[int64]int64{1: 20 * 1024 * 1024, 2: 20 * 1024 * 1024, 3: 20 * 1024 * 1024},
+			CollectionIndexSize:  map[int64]int64{1: 20 * 1024 * 1024},
+		}
+		quotaCenter.resetAllCurrentRates()
+		quotaCenter.checkDiskQuota(nil)
+		checkLimiter(1, 2, 3)
+		paramtable.Get().Save(Params.QuotaConfig.DiskQuotaIncludeIndexSize.Key, "true")
+		quotaCenter.resetAllCurrentRates()
+		quotaCenter.checkDiskQuota(nil)
+		checkLimiter(2, 3)
+		paramtable.Get().Reset(Params.QuotaConfig.DiskQuotaIncludeIndexSize.Key)
 		paramtable.Get().Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, colQuotaBackup)
 	})
 
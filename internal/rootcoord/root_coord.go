@@ -1501,6 +1501,46 @@ func (c *Core) AlterDatabase(ctx context.Context, in *rootcoordpb.AlterDatabaseR
 	return merr.Success(), nil
 }
 
+// BatchDDL creates the collections and the partitions of a database atomically
+func (c *Core) BatchDDL(ctx context.Context, in *rootcoordpb.BatchDDLRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	method := "BatchDDL"
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder(method)
+
+	log := log.Ctx(ctx).With(zap.String("role", typeutil.RootCoordRole),
+		zap.String("dbName", in.GetDbName()),
+		zap.Int("createCollectionNum", len(in.GetCreateCollections())),
+		zap.Int("createPartitionNum", len(in.GetCreatePartitions())))
+	log.Info("received request to batch ddl")
+
+	t := &batchDDLTask{
+		baseTask: newBaseTask(ctx, c),
+		Req:      in,
+	}
+
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Info("failed to enqueue request to batch ddl", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	if err := t.WaitToFinish(); err != nil {
+		log.Info("failed to batch ddl", zap.Error(err), zap.Uint64("ts", t.GetTs()))
+		metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	metrics.RootCoordDDLReqLatencyInQueue.WithLabelValues(method).Observe(float64(t.queueDur.Milliseconds()))
+	log.Info("done to batch ddl", zap.Uint64("ts", t.GetTs()))
+	return merr.Success(), nil
+}
+
 // CreatePartition create partition
 func (c *Core) CreatePartition(ctx context.Context, in *milvuspb.CreatePartitionRequest) (*commonpb.Status, error) {
 	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
@@ -3071,6 +3111,41 @@ func (c *Core) DescribeDatabase(ctx context.Context, req *rootcoordpb.DescribeDa
 	return t.Rsp, nil
 }
 
+// DescribeDatabases describes all the databases, with their properties, in one request.
+func (c *Core) DescribeDatabases(ctx context.Context, req *rootcoordpb.DescribeDatabasesRequest) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return &rootcoordpb.DescribeDatabasesResponse{Status: merr.Status(err)}, nil
+	}
+
+	log := log.Ctx(ctx)
+	log.Debug("received request to describe databases")
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("DescribeDatabases", metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder("DescribeDatabases")
+	t := &describeDBsTask{
+		baseTask: newBaseTask(ctx, c),
+		Req:      req,
+	}
+
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Warn("failed to enqueue request to describe databases", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("DescribeDatabases", metrics.FailLabel).Inc()
+		return &rootcoordpb.DescribeDatabasesResponse{Status: merr.Status(err)}, nil
+	}
+
+	if err := t.WaitToFinish(); err != nil {
+		log.Warn("failed to describe databases", zap.Uint64("ts", t.GetTs()), zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("DescribeDatabases", metrics.FailLabel).Inc()
+		return &rootcoordpb.DescribeDatabasesResponse{Status: merr.Status(err)}, nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("DescribeDatabases", metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues("DescribeDatabases").Observe(float64(tr.ElapseSpan().Milliseconds()))
+
+	log.Debug("done to describe databases", zap.Int("num", len(t.Rsp.GetDatabases())))
+	return t.Rsp, nil
+}
+
 func (c *Core) CheckHealth(ctx context.Context, in *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
 		return &milvuspb.CheckHealthResponse{
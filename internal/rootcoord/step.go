@@ -185,6 +185,23 @@ func (s *changeCollectionStateStep) Desc() string {
 		s.collectionID, s.ts, s.state.String())
 }
 
+type markCreatedStep struct {
+	baseStep
+	collectionIDs []UniqueID
+	partitions    []*model.Partition
+	ts            Timestamp
+}
+
+func (s *markCreatedStep) Execute(ctx context.Context) ([]nestedStep, error) {
+	err := s.core.meta.MarkCollectionsAndPartitionsCreated(ctx, s.collectionIDs, s.partitions, s.ts)
+	return nil, err
+}
+
+func (s *markCreatedStep) Desc() string {
+	return fmt.Sprintf("mark collections and partitions created, collections: %v, partition num: %d, ts: %d",
+		s.collectionIDs, len(s.partitions), s.ts)
+}
+
 type expireCacheStep struct {
 	baseStep
 	dbName          string
@@ -13,11 +13,13 @@ type ChunkManagerFactory struct {
 	config            *config
 }
 
-func NewChunkManagerFactoryWithParam(params *paramtable.ComponentParam) *ChunkManagerFactory {
+// NewChunkManagerFactoryWithParam creates the factory of the storage configured,
+// the options given override the ones from the params.
+func NewChunkManagerFactoryWithParam(params *paramtable.ComponentParam, opts ...Option) *ChunkManagerFactory {
 	if params.CommonCfg.StorageType.GetValue() == "local" {
-		return NewChunkManagerFactory("local", RootPath(params.LocalStorageCfg.Path.GetValue()))
+		return NewChunkManagerFactory("local", append([]Option{RootPath(params.LocalStorageCfg.Path.GetValue())}, opts...)...)
 	}
-	return NewChunkManagerFactory(params.CommonCfg.StorageType.GetValue(),
+	return NewChunkManagerFactory(params.CommonCfg.StorageType.GetValue(), append([]Option{
 		RootPath(params.MinioCfg.RootPath.GetValue()),
 		Address(params.MinioCfg.Address.GetValue()),
 		AccessKeyID(params.MinioCfg.AccessKeyID.GetValue()),
@@ -32,7 +34,8 @@ func NewChunkManagerFactoryWithParam(params *paramtable.ComponentParam) *ChunkMa
 		Region(params.MinioCfg.Region.GetValue()),
 		RequestTimeout(params.MinioCfg.RequestTimeoutMs.GetAsInt64()),
 		CreateBucket(true),
-		GcpCredentialJSON(params.MinioCfg.GcpCredentialJSON.GetValue()))
+		GcpCredentialJSON(params.MinioCfg.GcpCredentialJSON.GetValue()),
+	}, opts...)...)
 }
 
 func NewChunkManagerFactory(persistentStorage string, opts ...Option) *ChunkManagerFactory {
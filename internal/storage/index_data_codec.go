@@ -27,9 +27,28 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
+const (
+	// indexFormatVersionKey is the descriptor extra key of the index file format version,
+	// index files written before the key was introduced are of version 0.
+	indexFormatVersionKey = "indexFormatVersion"
+	// CurrentIndexFormatVersion is the format version of index files written by this node.
+	CurrentIndexFormatVersion int64 = 1
+)
+
+// indexFileReader reads the payloads of an index file, one per event
+type indexFileReader func(binlogReader *BinlogReader) (contents [][]byte, err error)
+
+// indexFileReaders are the readers of each supported index file format version
+var indexFileReaders = map[int64]indexFileReader{
+	0: readIndexFileV0,
+	// version 1 only adds the format version into the descriptor extras
+	1: readIndexFileV0,
+}
+
 type IndexFileBinlogCodec struct{}
 
 // NewIndexFileBinlogCodec is constructor for IndexFileBinlogCodec
@@ -176,8 +195,6 @@ func (codec *IndexFileBinlogCodec) DeserializeImpl(blobs []*Blob) (
 				zap.Error(err))
 			return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
 		}
-		dataType := binlogReader.PayloadDataType
-
 		//desc, err := binlogReader.readDescriptorEvent()
 		//if err != nil {
 		//	log.Warn("failed to read descriptor event",
@@ -207,69 +224,98 @@ func (codec *IndexFileBinlogCodec) DeserializeImpl(blobs []*Blob) (
 
 		key := extra["key"].(string)
 
-		for {
-			eventReader, err := binlogReader.NextEventReader()
+		formatVersion, err := getIndexFormatVersion(extra)
+		if err != nil {
+			binlogReader.Close()
+			return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
+		}
+		read, ok := indexFileReaders[formatVersion]
+		if !ok {
+			binlogReader.Close()
+			return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, merr.WrapErrIndexVersion(formatVersion, 0, CurrentIndexFormatVersion,
+				fmt.Sprintf("index file %s is written in a newer format than supported", key))
+		}
+		contents, err := read(binlogReader)
+		if err != nil {
+			binlogReader.Close()
+			return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
+		}
+		for _, content := range contents {
+			if key == IndexParamsKey {
+				_ = json.Unmarshal(content, &indexParams)
+			} else {
+				datas = append(datas, &Blob{Key: key, Value: content})
+			}
+		}
+		binlogReader.Close()
+	}
+
+	return indexBuildID, version, collectionID, partitionID, segmentID, fieldID, indexParams, indexName, indexID, datas, nil
+}
+
+// getIndexFormatVersion returns the format version of the index file from its descriptor extras
+func getIndexFormatVersion(extra map[string]interface{}) (int64, error) {
+	v, ok := extra[indexFormatVersionKey]
+	if !ok {
+		return 0, nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid index format version %v", v)
+	}
+	version, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid index format version")
+	}
+	return version, nil
+}
+
+// readIndexFileV0 reads the payload of each event of the index file.
+func readIndexFileV0(binlogReader *BinlogReader) ([][]byte, error) {
+	dataType := binlogReader.PayloadDataType
+	var results [][]byte
+	for {
+		eventReader, err := binlogReader.NextEventReader()
+		if err != nil {
+			log.Warn("failed to get next event reader",
+				zap.Error(err))
+			return nil, err
+		}
+		if eventReader == nil {
+			break
+		}
+		switch dataType {
+		// just for backward compatibility
+		case schemapb.DataType_Int8:
+			// todo: valid_data may need to check when create index
+			content, _, err := eventReader.GetByteFromPayload()
 			if err != nil {
-				log.Warn("failed to get next event reader",
+				log.Warn("failed to get byte from payload",
 					zap.Error(err))
-				binlogReader.Close()
-				return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
+				eventReader.Close()
+				return nil, err
 			}
-			if eventReader == nil {
-				break
+			results = append(results, content)
+
+		case schemapb.DataType_String:
+			content, _, err := eventReader.GetStringFromPayload()
+			if err != nil {
+				log.Warn("failed to get string from payload", zap.Error(err))
+				eventReader.Close()
+				return nil, err
 			}
-			switch dataType {
-			// just for backward compatibility
-			case schemapb.DataType_Int8:
-				// todo: valid_data may need to check when create index
-				content, _, err := eventReader.GetByteFromPayload()
-				if err != nil {
-					log.Warn("failed to get byte from payload",
-						zap.Error(err))
-					eventReader.Close()
-					binlogReader.Close()
-					return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
-				}
-
-				if key == IndexParamsKey {
-					_ = json.Unmarshal(content, &indexParams)
-				} else {
-					blob := &Blob{Key: key}
-					blob.Value = content
-					datas = append(datas, blob)
-				}
-
-			case schemapb.DataType_String:
-				content, _, err := eventReader.GetStringFromPayload()
-				if err != nil {
-					log.Warn("failed to get string from payload", zap.Error(err))
-					eventReader.Close()
-					binlogReader.Close()
-					return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
-				}
-
-				// make sure there is one string
-				if len(content) != 1 {
-					err := fmt.Errorf("failed to parse index event because content length is not one %d", len(content))
-					eventReader.Close()
-					binlogReader.Close()
-					return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
-				}
-				contentByte := typeutil.UnsafeStr2bytes(content[0])
-				if key == IndexParamsKey {
-					_ = json.Unmarshal(contentByte, &indexParams)
-				} else {
-					blob := &Blob{Key: key}
-					blob.Value = contentByte
-					datas = append(datas, blob)
-				}
+
+			// make sure there is one string
+			if len(content) != 1 {
+				err := fmt.Errorf("failed to parse index event because content length is not one %d", len(content))
+				eventReader.Close()
+				return nil, err
 			}
-			eventReader.Close()
+			results = append(results, typeutil.UnsafeStr2bytes(content[0]))
 		}
-		binlogReader.Close()
+		eventReader.Close()
 	}
-
-	return indexBuildID, version, collectionID, partitionID, segmentID, fieldID, indexParams, indexName, indexID, datas, nil
+	return results, nil
 }
 
 func (codec *IndexFileBinlogCodec) Deserialize(blobs []*Blob) (
@@ -358,6 +404,7 @@ func NewIndexFileBinlogWriter(
 	descriptorEvent.AddExtra("indexName", indexName)
 	descriptorEvent.AddExtra("indexID", fmt.Sprintf("%d", indexID))
 	descriptorEvent.AddExtra("key", key)
+	descriptorEvent.AddExtra(indexFormatVersionKey, fmt.Sprintf("%d", CurrentIndexFormatVersion))
 	w := &IndexFileBinlogWriter{
 		baseBinlogWriter: baseBinlogWriter{
 			descriptorEvent: *descriptorEvent,
@@ -17,12 +17,15 @@
 package storage
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/uniquegenerator"
 )
 
@@ -116,6 +119,57 @@ func TestIndexFileBinlogCodecError(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexFileBinlogCodecFormatVersion(t *testing.T) {
+	codec := NewIndexFileBinlogCodec()
+	serialize := func(formatVersion string, contents ...string) *Blob {
+		if len(contents) == 0 {
+			contents = []string{"content"}
+		}
+		writer := NewIndexFileBinlogWriter(1, 1, 2, 3, 4, 5, "index", 6, "ivf1")
+		defer writer.Close()
+		if formatVersion == "" {
+			delete(writer.descriptorEvent.Extras, indexFormatVersionKey)
+		} else {
+			writer.AddExtra(indexFormatVersionKey, formatVersion)
+		}
+		for _, content := range contents {
+			eventWriter, err := writer.NextIndexFileEventWriter()
+			require.NoError(t, err)
+			defer eventWriter.Close()
+			require.NoError(t, eventWriter.AddOneStringToPayload(content, true))
+			eventWriter.SetEventTimestamp(100, 100)
+		}
+		writer.SetEventTimeStamp(100, 100)
+		writer.AddExtra(originalSizeKey, "7")
+		require.NoError(t, writer.Finish())
+		buffer, err := writer.GetBuffer()
+		require.NoError(t, err)
+		return &Blob{Key: "ivf1", Value: buffer}
+	}
+
+	// files written before the format version is introduced
+	datas, _, _, _, err := codec.Deserialize([]*Blob{serialize("")})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), datas[0].Value)
+
+	datas, _, _, _, err = codec.Deserialize([]*Blob{serialize(fmt.Sprint(CurrentIndexFormatVersion))})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), datas[0].Value)
+
+	// each event of the file is read as a blob
+	datas, _, _, _, err = codec.Deserialize([]*Blob{serialize("", "content1", "content2")})
+	assert.NoError(t, err)
+	assert.Len(t, datas, 2)
+	assert.Equal(t, []byte("content1"), datas[0].Value)
+	assert.Equal(t, []byte("content2"), datas[1].Value)
+
+	_, _, _, _, err = codec.Deserialize([]*Blob{serialize(fmt.Sprint(CurrentIndexFormatVersion + 1))})
+	assert.ErrorIs(t, err, merr.ErrIndexVersion)
+
+	_, _, _, _, err = codec.Deserialize([]*Blob{serialize("invalid")})
+	assert.Error(t, err)
+}
+
 func TestIndexCodec(t *testing.T) {
 	indexCodec := NewIndexCodec()
 	blobs := []*Blob{
@@ -0,0 +1,412 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// ArrowRecordToInsertRecord builds an InsertRecord from the columns of the arrow record column by column.
+// Columns are matched with the schema fields by field id first, which is how binlog records name them, then by field name.
+// The column layout is the same as the binlog records, see serdeMap.
+//
+// The data is copied, the returned InsertRecord doesn't reference rec, which may be released once this returns.
+func ArrowRecordToInsertRecord(schema *schemapb.CollectionSchema, rec arrow.Record) (*segcorepb.InsertRecord, error) {
+	columns := make(map[string]arrow.Array, rec.NumCols())
+	for i, field := range rec.Schema().Fields() {
+		columns[field.Name] = rec.Column(i)
+	}
+
+	insertRecord := &segcorepb.InsertRecord{
+		NumRows:    rec.NumRows(),
+		FieldsData: make([]*schemapb.FieldData, 0, len(schema.GetFields())),
+	}
+	for _, field := range schema.GetFields() {
+		column, ok := columns[strconv.FormatInt(field.GetFieldID(), 10)]
+		if !ok {
+			column, ok = columns[field.GetName()]
+		}
+		if !ok {
+			if !field.GetNullable() {
+				return nil, merr.WrapErrFieldNotFound(field.GetName(), "field not found in arrow record")
+			}
+			dim, _ := typeutil.GetDim(field)
+			column = array.MakeArrayOfNull(memory.DefaultAllocator, serdeMap[field.GetDataType()].arrowType(int(dim)), int(rec.NumRows()))
+			defer column.Release()
+		}
+		fieldData, err := arrowArrayToFieldData(field, column)
+		if err != nil {
+			return nil, err
+		}
+		insertRecord.FieldsData = append(insertRecord.FieldsData, fieldData)
+	}
+	return insertRecord, nil
+}
+
+func arrowArrayToFieldData(field *schemapb.FieldSchema, column arrow.Array) (*schemapb.FieldData, error) {
+	fieldData := &schemapb.FieldData{
+		Type:      field.GetDataType(),
+		FieldName: field.GetName(),
+		FieldId:   field.GetFieldID(),
+	}
+	if field.GetNullable() {
+		fieldData.ValidData = make([]bool, column.Len())
+		for i := range fieldData.ValidData {
+			fieldData.ValidData[i] = column.IsValid(i)
+		}
+	}
+
+	mismatch := func() error {
+		return merr.WrapErrParameterInvalidMsg("arrow type %s doesn't match field %s of type %s",
+			column.DataType().String(), field.GetName(), field.GetDataType().String())
+	}
+	scalars := func(scalar *schemapb.ScalarField) {
+		fieldData.Field = &schemapb.FieldData_Scalars{Scalars: scalar}
+	}
+	vectors := func(dim int64, vector *schemapb.VectorField) {
+		vector.Dim = dim
+		fieldData.Field = &schemapb.FieldData_Vectors{Vectors: vector}
+	}
+
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		arr, ok := column.(*array.Boolean)
+		if !ok {
+			return nil, mismatch()
+		}
+		data := make([]bool, arr.Len())
+		for i := range data {
+			data[i] = arr.Value(i)
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: data}}})
+	case schemapb.DataType_Int8:
+		arr, ok := column.(*array.Int8)
+		if !ok {
+			return nil, mismatch()
+		}
+		data := make([]int32, arr.Len())
+		for i, v := range arr.Int8Values() {
+			data[i] = int32(v)
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}})
+	case schemapb.DataType_Int16:
+		arr, ok := column.(*array.Int16)
+		if !ok {
+			return nil, mismatch()
+		}
+		data := make([]int32, arr.Len())
+		for i, v := range arr.Int16Values() {
+			data[i] = int32(v)
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}})
+	case schemapb.DataType_Int32:
+		arr, ok := column.(*array.Int32)
+		if !ok {
+			return nil, mismatch()
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: slices.Clone(arr.Int32Values())}}})
+	case schemapb.DataType_Int64:
+		arr, ok := column.(*array.Int64)
+		if !ok {
+			return nil, mismatch()
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: slices.Clone(arr.Int64Values())}}})
+	case schemapb.DataType_Float:
+		arr, ok := column.(*array.Float32)
+		if !ok {
+			return nil, mismatch()
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: slices.Clone(arr.Float32Values())}}})
+	case schemapb.DataType_Double:
+		arr, ok := column.(*array.Float64)
+		if !ok {
+			return nil, mismatch()
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: slices.Clone(arr.Float64Values())}}})
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		arr, ok := column.(*array.String)
+		if !ok {
+			return nil, mismatch()
+		}
+		data := make([]string, arr.Len())
+		for i := range data {
+			data[i] = strings.Clone(arr.Value(i))
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}}})
+	case schemapb.DataType_JSON:
+		arr, ok := column.(*array.Binary)
+		if !ok {
+			return nil, mismatch()
+		}
+		data := make([][]byte, arr.Len())
+		for i := range data {
+			data[i] = slices.Clone(arr.Value(i))
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: data}}})
+	case schemapb.DataType_Array:
+		arr, ok := column.(*array.Binary)
+		if !ok {
+			return nil, mismatch()
+		}
+		data := make([]*schemapb.ScalarField, arr.Len())
+		for i := range data {
+			data[i] = &schemapb.ScalarField{}
+			if arr.IsNull(i) {
+				continue
+			}
+			if err := proto.Unmarshal(arr.Value(i), data[i]); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal array of field %s", field.GetName())
+			}
+		}
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_ArrayData{ArrayData: &schemapb.ArrayArray{
+			Data:        data,
+			ElementType: field.GetElementType(),
+		}}})
+	case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector,
+		schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
+		arr, ok := column.(*array.FixedSizeBinary)
+		if !ok {
+			return nil, mismatch()
+		}
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		width := arr.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+		if width != serdeMap[field.GetDataType()].arrowType(int(dim)).(*arrow.FixedSizeBinaryType).ByteWidth {
+			return nil, merr.WrapErrParameterInvalidMsg("byte width %d of arrow column doesn't match the dim %d of field %s",
+				width, dim, field.GetName())
+		}
+		data := make([]byte, 0, arr.Len()*width)
+		for i := 0; i < arr.Len(); i++ {
+			data = append(data, arr.Value(i)...)
+		}
+		switch field.GetDataType() {
+		case schemapb.DataType_FloatVector:
+			vectors(dim, &schemapb.VectorField{Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{
+				Data: arrow.Float32Traits.CastFromBytes(data),
+			}}})
+		case schemapb.DataType_BinaryVector:
+			vectors(dim, &schemapb.VectorField{Data: &schemapb.VectorField_BinaryVector{BinaryVector: data}})
+		case schemapb.DataType_Float16Vector:
+			vectors(dim, &schemapb.VectorField{Data: &schemapb.VectorField_Float16Vector{Float16Vector: data}})
+		case schemapb.DataType_BFloat16Vector:
+			vectors(dim, &schemapb.VectorField{Data: &schemapb.VectorField_Bfloat16Vector{Bfloat16Vector: data}})
+		}
+	case schemapb.DataType_SparseFloatVector:
+		arr, ok := column.(*array.Binary)
+		if !ok {
+			return nil, mismatch()
+		}
+		sparse := &schemapb.SparseFloatArray{Contents: make([][]byte, arr.Len())}
+		for i := range sparse.Contents {
+			sparse.Contents[i] = slices.Clone(arr.Value(i))
+			if dim := typeutil.SparseFloatRowDim(sparse.Contents[i]); dim > sparse.Dim {
+				sparse.Dim = dim
+			}
+		}
+		vectors(sparse.Dim, &schemapb.VectorField{Data: &schemapb.VectorField_SparseFloatVector{SparseFloatVector: sparse}})
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unsupported data type %s of field %s", field.GetDataType().String(), field.GetName())
+	}
+	return fieldData, nil
+}
+
+// InsertRecordToArrowRecord builds an arrow record from the InsertRecord,
+// columns are named by field id and laid out the same as the binlog records, see serdeMap.
+// The caller is responsible for releasing the returned record.
+func InsertRecordToArrowRecord(schema *schemapb.CollectionSchema, insertRecord *segcorepb.InsertRecord) (arrow.Record, error) {
+	fieldsData := make(map[int64]*schemapb.FieldData, len(insertRecord.GetFieldsData()))
+	for _, fieldData := range insertRecord.GetFieldsData() {
+		fieldsData[fieldData.GetFieldId()] = fieldData
+	}
+
+	fields := make([]arrow.Field, 0, len(schema.GetFields()))
+	columns := make([]arrow.Array, 0, len(schema.GetFields()))
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+	for _, field := range schema.GetFields() {
+		fieldData, ok := fieldsData[field.GetFieldID()]
+		if !ok {
+			continue
+		}
+		dim, _ := typeutil.GetDim(field)
+		arrowType := serdeMap[field.GetDataType()].arrowType(int(dim))
+		builder := array.NewBuilder(memory.DefaultAllocator, arrowType)
+		err := appendFieldDataToBuilder(builder, fieldData, int(insertRecord.GetNumRows()))
+		if err == nil && builder.Len() != int(insertRecord.GetNumRows()) {
+			err = merr.WrapErrParameterInvalidMsg("field %s has %d rows, but the insert record has %d rows",
+				field.GetName(), builder.Len(), insertRecord.GetNumRows())
+		}
+		if err != nil {
+			builder.Release()
+			return nil, err
+		}
+		columns = append(columns, builder.NewArray())
+		builder.Release()
+		fields = append(fields, arrow.Field{
+			Name:     strconv.FormatInt(field.GetFieldID(), 10),
+			Type:     arrowType,
+			Nullable: field.GetNullable(),
+		})
+	}
+	return array.NewRecord(arrow.NewSchema(fields, nil), columns, insertRecord.GetNumRows()), nil
+}
+
+func appendFieldDataToBuilder(builder array.Builder, fieldData *schemapb.FieldData, numRows int) error {
+	validData := fieldData.GetValidData()
+	if len(validData) == 0 {
+		validData = nil
+	}
+	mismatch := func() error {
+		return merr.WrapErrParameterInvalidMsg("data type %s of field %d doesn't match arrow type %s",
+			fieldData.GetType().String(), fieldData.GetFieldId(), builder.Type().String())
+	}
+
+	switch fieldData.GetType() {
+	case schemapb.DataType_Bool:
+		b, ok := builder.(*array.BooleanBuilder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetBoolData().GetData(), validData)
+	case schemapb.DataType_Int8:
+		b, ok := builder.(*array.Int8Builder)
+		if !ok {
+			return mismatch()
+		}
+		data := fieldData.GetScalars().GetIntData().GetData()
+		values := make([]int8, len(data))
+		for i, v := range data {
+			values[i] = int8(v)
+		}
+		b.AppendValues(values, validData)
+	case schemapb.DataType_Int16:
+		b, ok := builder.(*array.Int16Builder)
+		if !ok {
+			return mismatch()
+		}
+		data := fieldData.GetScalars().GetIntData().GetData()
+		values := make([]int16, len(data))
+		for i, v := range data {
+			values[i] = int16(v)
+		}
+		b.AppendValues(values, validData)
+	case schemapb.DataType_Int32:
+		b, ok := builder.(*array.Int32Builder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetIntData().GetData(), validData)
+	case schemapb.DataType_Int64:
+		b, ok := builder.(*array.Int64Builder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetLongData().GetData(), validData)
+	case schemapb.DataType_Float:
+		b, ok := builder.(*array.Float32Builder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetFloatData().GetData(), validData)
+	case schemapb.DataType_Double:
+		b, ok := builder.(*array.Float64Builder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetDoubleData().GetData(), validData)
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		b, ok := builder.(*array.StringBuilder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetStringData().GetData(), validData)
+	case schemapb.DataType_JSON:
+		b, ok := builder.(*array.BinaryBuilder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetScalars().GetJsonData().GetData(), validData)
+	case schemapb.DataType_Array:
+		b, ok := builder.(*array.BinaryBuilder)
+		if !ok {
+			return mismatch()
+		}
+		for i, v := range fieldData.GetScalars().GetArrayData().GetData() {
+			if validData != nil && !validData[i] {
+				b.AppendNull()
+				continue
+			}
+			bytes, err := proto.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Append(bytes)
+		}
+	case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector,
+		schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
+		b, ok := builder.(*array.FixedSizeBinaryBuilder)
+		if !ok {
+			return mismatch()
+		}
+		var data []byte
+		switch fieldData.GetType() {
+		case schemapb.DataType_FloatVector:
+			data = arrow.Float32Traits.CastToBytes(fieldData.GetVectors().GetFloatVector().GetData())
+		case schemapb.DataType_BinaryVector:
+			data = fieldData.GetVectors().GetBinaryVector()
+		case schemapb.DataType_Float16Vector:
+			data = fieldData.GetVectors().GetFloat16Vector()
+		case schemapb.DataType_BFloat16Vector:
+			data = fieldData.GetVectors().GetBfloat16Vector()
+		}
+		width := b.Type().(*arrow.FixedSizeBinaryType).ByteWidth
+		if width == 0 || len(data) != width*numRows {
+			return merr.WrapErrParameterInvalidMsg("vector of field %d has %d bytes, expected %d bytes per row for %d rows",
+				fieldData.GetFieldId(), len(data), width, numRows)
+		}
+		for i := 0; i < numRows; i++ {
+			b.Append(data[i*width : (i+1)*width])
+		}
+	case schemapb.DataType_SparseFloatVector:
+		b, ok := builder.(*array.BinaryBuilder)
+		if !ok {
+			return mismatch()
+		}
+		b.AppendValues(fieldData.GetVectors().GetSparseFloatVector().GetContents(), nil)
+	default:
+		return merr.WrapErrParameterInvalidMsg("unsupported data type %s of field %d", fieldData.GetType().String(), fieldData.GetFieldId())
+	}
+	return nil
+}
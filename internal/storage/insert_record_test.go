@@ -0,0 +1,208 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestInsertRecordArrowRoundTrip(t *testing.T) {
+	dimParams := []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "4"}}
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "bool", DataType: schemapb.DataType_Bool},
+			{FieldID: 102, Name: "int8", DataType: schemapb.DataType_Int8},
+			{FieldID: 103, Name: "varchar", DataType: schemapb.DataType_VarChar, Nullable: true},
+			{FieldID: 104, Name: "json", DataType: schemapb.DataType_JSON},
+			{FieldID: 105, Name: "array", DataType: schemapb.DataType_Array, ElementType: schemapb.DataType_Int32},
+			{FieldID: 106, Name: "float_vector", DataType: schemapb.DataType_FloatVector, TypeParams: dimParams},
+			{FieldID: 107, Name: "binary_vector", DataType: schemapb.DataType_BinaryVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "16"}}},
+			{FieldID: 108, Name: "sparse", DataType: schemapb.DataType_SparseFloatVector},
+		},
+	}
+	sparseRows := [][]byte{
+		typeutil.CreateSparseFloatRow([]uint32{1, 10}, []float32{0.1, 0.2}),
+		typeutil.CreateSparseFloatRow([]uint32{20}, []float32{0.3}),
+	}
+	insertRecord := &segcorepb.InsertRecord{
+		NumRows: 2,
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type: schemapb.DataType_Int64, FieldName: "pk", FieldId: 100,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}}}},
+			},
+			{
+				Type: schemapb.DataType_Bool, FieldName: "bool", FieldId: 101,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: []bool{true, false}}}}},
+			},
+			{
+				Type: schemapb.DataType_Int8, FieldName: "int8", FieldId: 102,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{-1, 1}}}}},
+			},
+			{
+				Type: schemapb.DataType_VarChar, FieldName: "varchar", FieldId: 103,
+				Field:     &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", ""}}}}},
+				ValidData: []bool{true, false},
+			},
+			{
+				Type: schemapb.DataType_JSON, FieldName: "json", FieldId: 104,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: [][]byte{[]byte(`{"a":1}`), []byte(`{}`)}}}}},
+			},
+			{
+				Type: schemapb.DataType_Array, FieldName: "array", FieldId: 105,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_ArrayData{ArrayData: &schemapb.ArrayArray{
+					Data: []*schemapb.ScalarField{
+						{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{1, 2}}}},
+						{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{3}}}},
+					},
+					ElementType: schemapb.DataType_Int32,
+				}}}},
+			},
+			{
+				Type: schemapb.DataType_FloatVector, FieldName: "float_vector", FieldId: 106,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{Dim: 4, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4, 5, 6, 7, 8}}}}},
+			},
+			{
+				Type: schemapb.DataType_BinaryVector, FieldName: "binary_vector", FieldId: 107,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{Dim: 16, Data: &schemapb.VectorField_BinaryVector{BinaryVector: []byte{1, 2, 3, 4}}}},
+			},
+			{
+				Type: schemapb.DataType_SparseFloatVector, FieldName: "sparse", FieldId: 108,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{Dim: 21, Data: &schemapb.VectorField_SparseFloatVector{SparseFloatVector: &schemapb.SparseFloatArray{Dim: 21, Contents: sparseRows}}}},
+			},
+		},
+	}
+
+	rec, err := InsertRecordToArrowRecord(schema, insertRecord)
+	require.NoError(t, err)
+	defer rec.Release()
+	assert.EqualValues(t, 2, rec.NumRows())
+	assert.EqualValues(t, 9, rec.NumCols())
+	assert.Equal(t, "100", rec.Schema().Field(0).Name)
+	assert.True(t, rec.Column(3).IsNull(1))
+
+	got, err := ArrowRecordToInsertRecord(schema, rec)
+	require.NoError(t, err)
+	assert.Equal(t, insertRecord.GetNumRows(), got.GetNumRows())
+	require.Len(t, got.GetFieldsData(), len(insertRecord.GetFieldsData()))
+	for i, fieldData := range insertRecord.GetFieldsData() {
+		assert.True(t, proto.Equal(fieldData, got.GetFieldsData()[i]), "field %s", fieldData.GetFieldName())
+	}
+
+	t.Run("mismatched rows", func(t *testing.T) {
+		bad := proto.Clone(insertRecord).(*segcorepb.InsertRecord)
+		bad.NumRows = 3
+		_, err := InsertRecordToArrowRecord(schema, bad)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+}
+
+func TestArrowRecordToInsertRecordByName(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "nullable", DataType: schemapb.DataType_Double, Nullable: true},
+		},
+	}
+
+	builder := array.NewInt64Builder(memory.DefaultAllocator)
+	builder.AppendValues([]int64{1, 2, 3}, nil)
+	column := builder.NewArray()
+	builder.Release()
+	rec := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "pk", Type: arrow.PrimitiveTypes.Int64}}, nil), []arrow.Array{column}, 3)
+	column.Release()
+	defer rec.Release()
+
+	insertRecord, err := ArrowRecordToInsertRecord(schema, rec)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, insertRecord.GetNumRows())
+	assert.Equal(t, []int64{1, 2, 3}, insertRecord.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	// missing nullable field is filled with nulls
+	assert.Equal(t, []bool{false, false, false}, insertRecord.GetFieldsData()[1].GetValidData())
+	assert.Len(t, insertRecord.GetFieldsData()[1].GetScalars().GetDoubleData().GetData(), 3)
+
+	// missing field which is not nullable
+	schema.Fields[1].Nullable = false
+	_, err = ArrowRecordToInsertRecord(schema, rec)
+	assert.ErrorIs(t, err, merr.ErrFieldNotFound)
+
+	// mismatched type
+	schema.Fields[0].DataType = schemapb.DataType_Int32
+	_, err = ArrowRecordToInsertRecord(schema, rec)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestArrowRecordToInsertRecordCopies(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "varchar", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "json", DataType: schemapb.DataType_JSON},
+		},
+	}
+	insertRecord := &segcorepb.InsertRecord{
+		NumRows: 2,
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type: schemapb.DataType_Int64, FieldName: "pk", FieldId: 100,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}}}},
+			},
+			{
+				Type: schemapb.DataType_VarChar, FieldName: "varchar", FieldId: 101,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"ab", "cd"}}}}},
+			},
+			{
+				Type: schemapb.DataType_JSON, FieldName: "json", FieldId: 102,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: [][]byte{[]byte(`{}`), []byte(`[]`)}}}}},
+			},
+		},
+	}
+	rec, err := InsertRecordToArrowRecord(schema, insertRecord)
+	require.NoError(t, err)
+
+	got, err := ArrowRecordToInsertRecord(schema, rec)
+	require.NoError(t, err)
+	// the last buffer holds the values for both the fixed and the variable width columns,
+	// overwrite it as the allocator may do once the record is released
+	for _, column := range rec.Columns() {
+		buffers := column.Data().Buffers()
+		values := buffers[len(buffers)-1].Bytes()
+		for i := range values {
+			values[i] = 0
+		}
+	}
+	rec.Release()
+
+	for i, fieldData := range insertRecord.GetFieldsData() {
+		assert.True(t, proto.Equal(fieldData, got.GetFieldsData()[i]), "field %s", fieldData.GetFieldName())
+	}
+}
@@ -90,6 +90,25 @@ func (lcm *LocalChunkManager) Write(ctx context.Context, filePath string, conten
 	return WriteFile(filePath, content, os.ModePerm)
 }
 
+// WriteStream writes the data read from the reader to local storage.
+func (lcm *LocalChunkManager) WriteStream(ctx context.Context, filePath string, reader io.Reader) error {
+	if err := os.MkdirAll(path.Dir(filePath), os.ModePerm); err != nil {
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	if err := file.Close(); err != nil {
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	return nil
+}
+
 // MultiWrite writes the data to local storage.
 func (lcm *LocalChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	var el error
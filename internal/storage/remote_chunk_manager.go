@@ -162,6 +162,17 @@ func (mcm *RemoteChunkManager) Write(ctx context.Context, filePath string, conte
 	return nil
 }
 
+// WriteStream writes the data read from the reader to the object storage,
+// the object is uploaded in parts as the data is read.
+func (mcm *RemoteChunkManager) WriteStream(ctx context.Context, filePath string, reader io.Reader) error {
+	err := mcm.putObject(ctx, mcm.bucketName, filePath, reader, -1)
+	if err != nil {
+		log.Warn("failed to put object", zap.String("bucket", mcm.bucketName), zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 // MultiWrite saves multiple objects, the path is the key of @kvs.
 // The object value is the value of @kvs.
 func (mcm *RemoteChunkManager) MultiWrite(ctx context.Context, kvs map[string][]byte) error {
@@ -203,9 +203,18 @@ func (stats *PrimaryKeyStats) UpdateMinMax(pk PrimaryKey) {
 }
 
 func NewPrimaryKeyStats(fieldID, pkType, rowNum int64) (*PrimaryKeyStats, error) {
+	return NewPrimaryKeyStatsWithFalsePositive(fieldID, pkType, rowNum, 0)
+}
+
+// NewPrimaryKeyStatsWithFalsePositive works like NewPrimaryKeyStats with the false positive rate of bloom filter provided,
+// maxBloomFalsePositive is used if it's not positive.
+func NewPrimaryKeyStatsWithFalsePositive(fieldID, pkType, rowNum int64, falsePositive float64) (*PrimaryKeyStats, error) {
 	if rowNum <= 0 {
 		return nil, merr.WrapErrParameterInvalidMsg("zero or negative row num", rowNum)
 	}
+	if falsePositive <= 0 {
+		falsePositive = paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat()
+	}
 
 	bfType := paramtable.Get().CommonCfg.BloomFilterType.GetValue()
 	return &PrimaryKeyStats{
@@ -214,7 +223,7 @@ func NewPrimaryKeyStats(fieldID, pkType, rowNum int64) (*PrimaryKeyStats, error)
 		BFType:  bloomfilter.BFTypeFromString(bfType),
 		BF: bloomfilter.NewBloomFilterWithType(
 			uint(rowNum),
-			paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(),
+			falsePositive,
 			bfType),
 	}, nil
 }
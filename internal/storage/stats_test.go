@@ -178,6 +178,22 @@ func TestDeserializeEmptyStats(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewPrimaryKeyStatsWithFalsePositive(t *testing.T) {
+	stat, err := NewPrimaryKeyStats(1, int64(schemapb.DataType_Int64), 100000)
+	assert.NoError(t, err)
+
+	precise, err := NewPrimaryKeyStatsWithFalsePositive(1, int64(schemapb.DataType_Int64), 100000, 0.00001)
+	assert.NoError(t, err)
+	assert.Greater(t, precise.BF.Cap(), stat.BF.Cap())
+
+	fallback, err := NewPrimaryKeyStatsWithFalsePositive(1, int64(schemapb.DataType_Int64), 100000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, stat.BF.Cap(), fallback.BF.Cap())
+
+	_, err = NewPrimaryKeyStatsWithFalsePositive(1, int64(schemapb.DataType_Int64), 0, 0.00001)
+	assert.Error(t, err)
+}
+
 func TestMarshalStats(t *testing.T) {
 	stat, err := NewPrimaryKeyStats(1, int64(schemapb.DataType_Int64), 100000)
 	assert.NoError(t, err)
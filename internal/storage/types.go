@@ -58,6 +58,9 @@ type ChunkManager interface {
 	Size(ctx context.Context, filePath string) (int64, error)
 	// Write writes @content to @filePath.
 	Write(ctx context.Context, filePath string, content []byte) error
+	// WriteStream writes the content read from @reader until EOF to @filePath,
+	// without knowing the size of the content ahead.
+	WriteStream(ctx context.Context, filePath string, reader io.Reader) error
 	// MultiWrite writes multi @content to @filePath.
 	MultiWrite(ctx context.Context, contents map[string][]byte) error
 	// Exist returns true if @filePath exists.
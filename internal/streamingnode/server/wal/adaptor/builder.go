@@ -4,7 +4,10 @@ import (
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/ddl"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/dedup"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/ratelimit"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/redo"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/schemacheck"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick"
 	"github.com/milvus-io/milvus/pkg/streaming/walimpls"
@@ -31,11 +34,18 @@ func (b builderAdaptorImpl) Build() (wal.Opener, error) {
 	if err != nil {
 		return nil, err
 	}
-	// Add all interceptor here.
-	return adaptImplsToOpener(o, []interceptors.InterceptorBuilder{
+	// Add all interceptor here, they are chained in the order declared by their descriptors.
+	builders, err := interceptors.SortInterceptorBuilders([]interceptors.InterceptorBuilder{
+		dedup.NewInterceptorBuilder(),
+		schemacheck.NewInterceptorBuilder(),
+		ratelimit.NewInterceptorBuilder(),
 		redo.NewInterceptorBuilder(),
 		timetick.NewInterceptorBuilder(),
 		segment.NewInterceptorBuilder(),
 		ddl.NewInterceptorBuilder(),
-	}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adaptImplsToOpener(o, builders), nil
 }
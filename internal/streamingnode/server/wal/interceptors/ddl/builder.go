@@ -30,6 +30,11 @@ func NewInterceptorBuilder() interceptors.InterceptorBuilder {
 // interceptorBuilder is a builder to build ddlAppendInterceptor.
 type interceptorBuilder struct{}
 
+// Descriptor implements Builder.
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{Name: "ddl", Stage: interceptors.StageAck}
+}
+
 // Build implements Builder.
 func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
 	interceptor := &ddlAppendInterceptor{
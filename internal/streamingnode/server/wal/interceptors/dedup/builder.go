@@ -0,0 +1,41 @@
+package dedup
+
+import (
+	"container/list"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var _ interceptors.InterceptorBuilder = (*interceptorBuilder)(nil)
+
+// NewInterceptorBuilder creates a new dedup interceptor builder.
+func NewInterceptorBuilder() interceptors.InterceptorBuilder {
+	return &interceptorBuilder{}
+}
+
+// interceptorBuilder is the builder for dedup interceptor.
+type interceptorBuilder struct{}
+
+// Descriptor implements Builder.
+// The dedup is the outermost one, so the duplicated message is dropped before it's rate limited or redo.
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{Name: "dedup", Stage: interceptors.StageRedo, Before: []string{"ratelimit"}}
+}
+
+// Build implements Builder.
+func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
+	constLabel := prometheus.Labels{
+		metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+		metrics.WALChannelLabelName: param.WALImpls.Channel().Name,
+	}
+	return &dedupAppendInterceptor{
+		entries:      make(map[entryKey]*list.Element),
+		order:        list.New(),
+		constLabel:   constLabel,
+		dedupCounter: metrics.WALAppendDeduplicatedTotal.With(constLabel),
+	}
+}
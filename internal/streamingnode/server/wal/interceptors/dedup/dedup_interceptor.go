@@ -0,0 +1,144 @@
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var _ interceptors.Interceptor = (*dedupAppendInterceptor)(nil)
+
+// entryKey identifies the message appended, the unique id is assigned by the producer.
+type entryKey struct {
+	vchannel string
+	uniqueID string
+}
+
+// entry is the message appended or in flight.
+type entry struct {
+	key       entryKey
+	elem      *list.Element
+	createdAt time.Time
+	done      chan struct{}
+
+	// the append result of the message, available after done.
+	msgID  message.MessageID
+	result utility.ExtraAppendResult
+	err    error
+}
+
+// dedupAppendInterceptor is an append interceptor to drop the message appended again by the retry of the producer,
+// the duplicated one gets the append result of the first one.
+// The messages appended are remembered in memory within streaming.walDedup.window and streaming.walDedup.maxEntries,
+// so the retry beyond them or after the wal is reopened is not deduplicated.
+type dedupAppendInterceptor struct {
+	mu      sync.Mutex
+	entries map[entryKey]*list.Element
+	order   *list.List // the entries ordered by the created time, the oldest first.
+
+	constLabel   prometheus.Labels
+	dedupCounter prometheus.Counter
+}
+
+// DoAppend implements AppendInterceptor.
+func (d *dedupAppendInterceptor) DoAppend(ctx context.Context, msg message.MutableMessage, append interceptors.Append) (message.MessageID, error) {
+	uniqueID := msg.UniqueID()
+	if uniqueID == "" || !paramtable.Get().StreamingCfg.WALDedupEnabled.GetAsBool() {
+		return append(ctx, msg)
+	}
+
+	key := entryKey{vchannel: msg.VChannel(), uniqueID: uniqueID}
+	for {
+		e, first := d.getOrRegister(key)
+		if first {
+			return d.appendAndRemember(ctx, e, msg, append)
+		}
+
+		// wait for the first one, which may be still in flight.
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-e.done:
+		}
+		if e.err == nil {
+			d.dedupCounter.Inc()
+			if r := utility.GetExtraAppendResult(ctx); r != nil {
+				*r = e.result
+			}
+			return e.msgID, nil
+		}
+		// the first one is failed and forgotten, append the message again.
+	}
+}
+
+// getOrRegister returns the entry of the key, or registers a new one if not found.
+// Return true if the entry is registered by the call.
+func (d *dedupAppendInterceptor) getOrRegister(key entryKey) (*entry, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.evict(now)
+	if elem, ok := d.entries[key]; ok {
+		return elem.Value.(*entry), false
+	}
+	e := &entry{
+		key:       key,
+		createdAt: now,
+		done:      make(chan struct{}),
+	}
+	e.elem = d.order.PushBack(e)
+	d.entries[key] = e.elem
+	return e, true
+}
+
+// appendAndRemember appends the message and remembers the result into the entry.
+// The entry is forgotten if the append is failed, so the retry is not dropped.
+func (d *dedupAppendInterceptor) appendAndRemember(ctx context.Context, e *entry, msg message.MutableMessage, append interceptors.Append) (message.MessageID, error) {
+	msgID, err := append(ctx, msg)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		e.err = err
+		if elem, ok := d.entries[e.key]; ok && elem == e.elem {
+			d.order.Remove(elem)
+			delete(d.entries, e.key)
+		}
+	} else {
+		e.msgID = msgID
+		if r := utility.GetExtraAppendResult(ctx); r != nil {
+			e.result = *r
+		}
+	}
+	close(e.done)
+	return msgID, err
+}
+
+// evict forgets the entries out of the window or beyond the max entries.
+func (d *dedupAppendInterceptor) evict(now time.Time) {
+	window := paramtable.Get().StreamingCfg.WALDedupWindow.GetAsDurationByParse()
+	maxEntries := paramtable.Get().StreamingCfg.WALDedupMaxEntries.GetAsInt()
+	for front := d.order.Front(); front != nil; front = d.order.Front() {
+		e := front.Value.(*entry)
+		if d.order.Len() < maxEntries && now.Sub(e.createdAt) < window {
+			return
+		}
+		d.order.Remove(front)
+		delete(d.entries, e.key)
+	}
+}
+
+// Close implements BasicInterceptor.
+func (d *dedupAppendInterceptor) Close() {
+	metrics.WALAppendDeduplicatedTotal.Delete(d.constLabel)
+}
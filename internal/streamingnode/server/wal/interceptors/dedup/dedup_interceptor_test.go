@@ -0,0 +1,143 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
+	"github.com/milvus-io/milvus/pkg/mocks/streaming/mock_walimpls"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls/impls/walimplstest"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestDedupInterceptor(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+
+	l := mock_walimpls.NewMockWALImpls(t)
+	l.EXPECT().Channel().Return(types.PChannelInfo{Name: "p1"})
+	interceptor := NewInterceptorBuilder().Build(interceptors.InterceptorBuildParam{WALImpls: l})
+	defer interceptor.Close()
+
+	appended := 0
+	var appendErr error
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		if appendErr != nil {
+			return nil, appendErr
+		}
+		appended++
+		utility.ReplaceAppendResultTimeTick(ctx, uint64(appended))
+		return walimplstest.NewTestMessageID(int64(appended)), nil
+	}
+	newInsert := func(vchannel string, uniqueID string) message.MutableMessage {
+		msg, err := message.NewInsertMessageBuilderV1().
+			WithVChannel(vchannel).
+			WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+			WithBody(&msgpb.InsertRequest{}).
+			BuildMutable()
+		assert.NoError(t, err)
+		if uniqueID != "" {
+			msg = msg.WithUniqueID(uniqueID)
+		}
+		return msg
+	}
+	doAppend := func(msg message.MutableMessage) (message.MessageID, uint64, error) {
+		result := &utility.ExtraAppendResult{}
+		msgID, err := interceptor.DoAppend(utility.WithExtraAppendResult(context.Background(), result), msg, appendOp)
+		return msgID, result.TimeTick, err
+	}
+
+	// the duplicated one gets the result of the first one.
+	msgID, tt, err := doAppend(newInsert("v1", "a"))
+	assert.NoError(t, err)
+	dupMsgID, dupTT, err := doAppend(newInsert("v1", "a"))
+	assert.NoError(t, err)
+	assert.True(t, msgID.EQ(dupMsgID))
+	assert.Equal(t, tt, dupTT)
+	assert.Equal(t, 1, appended)
+
+	// the messages of other vchannels or without unique id are not deduplicated.
+	_, _, err = doAppend(newInsert("v2", "a"))
+	assert.NoError(t, err)
+	_, _, err = doAppend(newInsert("v1", ""))
+	assert.NoError(t, err)
+	_, _, err = doAppend(newInsert("v1", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, appended)
+
+	// the failed one is forgotten.
+	appendErr = errors.New("test")
+	_, _, err = doAppend(newInsert("v1", "b"))
+	assert.Error(t, err)
+	appendErr = nil
+	_, _, err = doAppend(newInsert("v1", "b"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, appended)
+
+	// the oldest ones are forgotten beyond the max entries.
+	params.Save(params.StreamingCfg.WALDedupMaxEntries.Key, "2")
+	defer params.Reset(params.StreamingCfg.WALDedupMaxEntries.Key)
+	_, _, err = doAppend(newInsert("v1", "c"))
+	assert.NoError(t, err)
+	_, _, err = doAppend(newInsert("v1", "a"))
+	assert.NoError(t, err)
+	assert.Equal(t, 7, appended)
+
+	// the messages are not deduplicated if disabled.
+	params.Save(params.StreamingCfg.WALDedupEnabled.Key, "false")
+	defer params.Reset(params.StreamingCfg.WALDedupEnabled.Key)
+	_, _, err = doAppend(newInsert("v1", "a"))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, appended)
+}
+
+func TestDedupInFlight(t *testing.T) {
+	paramtable.Init()
+
+	l := mock_walimpls.NewMockWALImpls(t)
+	l.EXPECT().Channel().Return(types.PChannelInfo{Name: "p1"})
+	interceptor := NewInterceptorBuilder().Build(interceptors.InterceptorBuildParam{WALImpls: l})
+	defer interceptor.Close()
+
+	msg, err := message.NewInsertMessageBuilderV1().
+		WithVChannel("v1").
+		WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.InsertRequest{}).
+		BuildMutable()
+	assert.NoError(t, err)
+	msg = msg.WithUniqueID("a")
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		_, err := interceptor.DoAppend(context.Background(), msg, func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+			close(started)
+			<-finish
+			return walimplstest.NewTestMessageID(1), nil
+		})
+		assert.NoError(t, err)
+	}()
+	<-started
+
+	// the duplicated one waits for the first one in flight.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = interceptor.DoAppend(ctx, msg, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(finish)
+	<-firstDone
+	msgID, err := interceptor.DoAppend(context.Background(), msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, msgID.EQ(walimplstest.NewTestMessageID(1)))
+}
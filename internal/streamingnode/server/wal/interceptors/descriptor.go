@@ -0,0 +1,138 @@
+package interceptors
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Stage is the coarse position of the interceptor in the append chain.
+// The interceptor of the earlier stage wraps the ones of the later stages,
+// so it sees the message before them and the append result after them.
+type Stage int
+
+const (
+	// StageRedo is the outermost stage, which may run the whole rest of the chain again, such as redo.
+	StageRedo Stage = iota + 1
+	// StageTimeTick allocates the timetick and manages the txn of the message, such as timetick.
+	StageTimeTick
+	// StageAssign assigns the message with the timetick allocated, such as segment assignment.
+	StageAssign
+	// StageAck is the innermost stage, which works on the message once appended, such as ddl.
+	StageAck
+)
+
+func (s Stage) valid() bool {
+	return s >= StageRedo && s <= StageAck
+}
+
+// Descriptor declares the position of the interceptor in the append chain.
+type Descriptor struct {
+	// Name is the unique name of the interceptor in the chain.
+	Name string
+	// Stage is the stage the interceptor runs in.
+	Stage Stage
+	// Priority orders the interceptors of the same stage, the lower one wraps the higher ones.
+	Priority int
+	// After is the names of the interceptors that must wrap this one.
+	After []string
+	// Before is the names of the interceptors that this one must wrap.
+	Before []string
+}
+
+// SortInterceptorBuilders sorts the builders into the order of the append chain, the first one is the outermost.
+// The builders are ordered by the stage, then the priority, then the order given,
+// and the After and Before declarations are applied on top.
+// An error is returned if the names are not unique, a declaration refers to an unknown interceptor
+// or conflicts with the stages, or the declarations form a cycle.
+func SortInterceptorBuilders(builders []InterceptorBuilder) ([]InterceptorBuilder, error) {
+	descriptors := make([]Descriptor, len(builders))
+	indexes := make(map[string]int, len(builders))
+	for i, b := range builders {
+		d := b.Descriptor()
+		if d.Name == "" {
+			return nil, errors.Newf("interceptor at %d has no name", i)
+		}
+		if !d.Stage.valid() {
+			return nil, errors.Newf("interceptor %s has invalid stage %d", d.Name, d.Stage)
+		}
+		if _, ok := indexes[d.Name]; ok {
+			return nil, errors.Newf("interceptor %s is declared more than once", d.Name)
+		}
+		descriptors[i] = d
+		indexes[d.Name] = i
+	}
+
+	// edges[i] are the interceptors wrapped by i.
+	edges := make([][]int, len(builders))
+	inDegrees := make([]int, len(builders))
+	addEdge := func(outer, inner int) error {
+		if descriptors[outer].Stage > descriptors[inner].Stage {
+			return errors.Newf("interceptor %s of stage %d cannot wrap %s of the earlier stage %d",
+				descriptors[outer].Name, descriptors[outer].Stage, descriptors[inner].Name, descriptors[inner].Stage)
+		}
+		edges[outer] = append(edges[outer], inner)
+		inDegrees[inner]++
+		return nil
+	}
+	for i, d := range descriptors {
+		for _, name := range d.After {
+			j, ok := indexes[name]
+			if !ok {
+				return nil, errors.Newf("interceptor %s runs after the unknown interceptor %s", d.Name, name)
+			}
+			if err := addEdge(j, i); err != nil {
+				return nil, err
+			}
+		}
+		for _, name := range d.Before {
+			j, ok := indexes[name]
+			if !ok {
+				return nil, errors.Newf("interceptor %s runs before the unknown interceptor %s", d.Name, name)
+			}
+			if err := addEdge(i, j); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	less := func(i, j int) bool {
+		if descriptors[i].Stage != descriptors[j].Stage {
+			return descriptors[i].Stage < descriptors[j].Stage
+		}
+		if descriptors[i].Priority != descriptors[j].Priority {
+			return descriptors[i].Priority < descriptors[j].Priority
+		}
+		return i < j
+	}
+	ready := make([]int, 0, len(builders))
+	for i := range builders {
+		if inDegrees[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	sorted := make([]InterceptorBuilder, 0, len(builders))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool { return less(ready[a], ready[b]) })
+		i := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, builders[i])
+		for _, j := range edges[i] {
+			inDegrees[j]--
+			if inDegrees[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	if len(sorted) != len(builders) {
+		cycle := make([]string, 0)
+		for i, degree := range inDegrees {
+			if degree > 0 {
+				cycle = append(cycle, descriptors[i].Name)
+			}
+		}
+		return nil, errors.Newf("interceptors [%s] declare a cycle", strings.Join(cycle, ", "))
+	}
+	return sorted, nil
+}
@@ -0,0 +1,75 @@
+package interceptors_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/wal/mock_interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+)
+
+func TestSortInterceptorBuilders(t *testing.T) {
+	newBuilder := func(d interceptors.Descriptor) interceptors.InterceptorBuilder {
+		b := mock_interceptors.NewMockInterceptorBuilder(t)
+		b.EXPECT().Descriptor().Return(d).Maybe()
+		return b
+	}
+	names := func(builders []interceptors.InterceptorBuilder) []string {
+		ret := make([]string, 0, len(builders))
+		for _, b := range builders {
+			ret = append(ret, b.Descriptor().Name)
+		}
+		return ret
+	}
+
+	// sorted by the stage, then the priority, then the order given.
+	sorted, err := interceptors.SortInterceptorBuilders([]interceptors.InterceptorBuilder{
+		newBuilder(interceptors.Descriptor{Name: "ddl", Stage: interceptors.StageAck}),
+		newBuilder(interceptors.Descriptor{Name: "segment", Stage: interceptors.StageAssign}),
+		newBuilder(interceptors.Descriptor{Name: "timetick", Stage: interceptors.StageTimeTick, Priority: 1}),
+		newBuilder(interceptors.Descriptor{Name: "txn", Stage: interceptors.StageTimeTick}),
+		newBuilder(interceptors.Descriptor{Name: "redo", Stage: interceptors.StageRedo}),
+		newBuilder(interceptors.Descriptor{Name: "ack", Stage: interceptors.StageAck}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"redo", "txn", "timetick", "segment", "ddl", "ack"}, names(sorted))
+
+	// the declarations reorder the interceptors in the same stage.
+	sorted, err = interceptors.SortInterceptorBuilders([]interceptors.InterceptorBuilder{
+		newBuilder(interceptors.Descriptor{Name: "a", Stage: interceptors.StageAck, After: []string{"c"}}),
+		newBuilder(interceptors.Descriptor{Name: "b", Stage: interceptors.StageAck}),
+		newBuilder(interceptors.Descriptor{Name: "c", Stage: interceptors.StageAck, Priority: 1}),
+		newBuilder(interceptors.Descriptor{Name: "d", Stage: interceptors.StageAck, Priority: 2, Before: []string{"b"}}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "d", "b"}, names(sorted))
+
+	for _, descriptors := range [][]interceptors.Descriptor{
+		// no name
+		{{Stage: interceptors.StageAck}},
+		// invalid stage
+		{{Name: "a"}},
+		// duplicated name
+		{{Name: "a", Stage: interceptors.StageAck}, {Name: "a", Stage: interceptors.StageRedo}},
+		// unknown interceptor
+		{{Name: "a", Stage: interceptors.StageAck, After: []string{"b"}}},
+		{{Name: "a", Stage: interceptors.StageAck, Before: []string{"b"}}},
+		// conflict with the stages
+		{{Name: "a", Stage: interceptors.StageRedo, After: []string{"b"}}, {Name: "b", Stage: interceptors.StageAck}},
+		{{Name: "a", Stage: interceptors.StageAck, Before: []string{"b"}}, {Name: "b", Stage: interceptors.StageTimeTick}},
+		// cycle
+		{
+			{Name: "a", Stage: interceptors.StageAck, After: []string{"c"}},
+			{Name: "b", Stage: interceptors.StageAck, After: []string{"a"}},
+			{Name: "c", Stage: interceptors.StageAck, After: []string{"b"}},
+		},
+	} {
+		builders := make([]interceptors.InterceptorBuilder, 0, len(descriptors))
+		for _, d := range descriptors {
+			builders = append(builders, newBuilder(d))
+		}
+		_, err := interceptors.SortInterceptorBuilders(builders)
+		assert.Error(t, err, descriptors)
+	}
+}
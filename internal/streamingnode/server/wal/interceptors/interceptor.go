@@ -23,6 +23,9 @@ type InterceptorBuildParam struct {
 // 1. InterceptorBuilder is concurrent safe.
 // 2. InterceptorBuilder can used to build a interceptor with cross-wal shared resources.
 type InterceptorBuilder interface {
+	// Descriptor declares the position of the interceptor in the append chain, see SortInterceptorBuilders.
+	Descriptor() Descriptor
+
 	// Build build a interceptor with wal that interceptor will work on.
 	// the wal object will be sent to the interceptor builder when the wal is constructed with all interceptors.
 	Build(param InterceptorBuildParam) Interceptor
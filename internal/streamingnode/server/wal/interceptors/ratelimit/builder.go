@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+)
+
+var _ interceptors.InterceptorBuilder = (*interceptorBuilder)(nil)
+
+// NewInterceptorBuilder creates a new rate limit interceptor builder.
+func NewInterceptorBuilder() interceptors.InterceptorBuilder {
+	return &interceptorBuilder{
+		limiters: newLimiters(),
+	}
+}
+
+// interceptorBuilder is the builder for rate limit interceptor.
+// The limiters are shared by all the wals on the streaming node,
+// so the collection limit works across the pchannels.
+type interceptorBuilder struct {
+	limiters *limiters
+}
+
+// Descriptor implements Builder.
+// The rate limit wraps the redo, so the message waits for the rate limit before the timetick is allocated,
+// and is limited once even if redo.
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{Name: "ratelimit", Stage: interceptors.StageRedo, Before: []string{"redo"}}
+}
+
+// Build implements Builder.
+func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
+	return &rateLimitAppendInterceptor{
+		limiters: b.limiters,
+		metrics:  metricsutil.NewRateLimitMetrics(param.WALImpls.Channel().Name),
+	}
+}
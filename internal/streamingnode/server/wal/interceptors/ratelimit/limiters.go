@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/ratelimitutil"
+)
+
+const (
+	scopeVChannel   = "vchannel"
+	scopeCollection = "collection"
+)
+
+// limiters manages the token buckets of the vchannels and collections.
+type limiters struct {
+	mu          sync.Mutex
+	vchannels   map[string]*vchannelLimiter
+	collections map[int64]*collectionLimiter
+}
+
+type vchannelLimiter struct {
+	bytes *ratelimitutil.Limiter
+	msgs  *ratelimitutil.Limiter
+}
+
+type collectionLimiter struct {
+	insertBytes *ratelimitutil.Limiter
+	deleteBytes *ratelimitutil.Limiter
+	msgs        *ratelimitutil.Limiter
+}
+
+// tokens is the tokens a message takes from a limiter.
+type tokens struct {
+	scope   string
+	limiter *ratelimitutil.Limiter
+	n       int
+}
+
+func newLimiters() *limiters {
+	return &limiters{
+		vchannels:   make(map[string]*vchannelLimiter),
+		collections: make(map[int64]*collectionLimiter),
+	}
+}
+
+// tokensOf returns the tokens the message takes, the limits are refreshed from the params.
+func (l *limiters) tokensOf(vchannel string, collectionID int64, msgType message.MessageType, size int) []tokens {
+	streamingCfg := &paramtable.Get().StreamingCfg
+	quotaCfg := &paramtable.Get().QuotaConfig
+	vchannelBytesRate := streamingCfg.WALRateLimitVChannelMaxBytesRate.GetAsFloat()
+	vchannelMsgRate := streamingCfg.WALRateLimitVChannelMaxMsgRate.GetAsFloat()
+	collectionMsgRate := streamingCfg.WALRateLimitCollectionMaxMsgRate.GetAsFloat()
+	collectionBytesRate := quotaCfg.DMLMaxInsertRatePerCollection.GetAsFloat()
+	if msgType == message.MessageTypeDelete {
+		collectionBytesRate = quotaCfg.DMLMaxDeleteRatePerCollection.GetAsFloat()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v, ok := l.vchannels[vchannel]
+	if !ok {
+		v = &vchannelLimiter{bytes: newLimiter(), msgs: newLimiter()}
+		l.vchannels[vchannel] = v
+	}
+	c, ok := l.collections[collectionID]
+	if !ok {
+		c = &collectionLimiter{insertBytes: newLimiter(), deleteBytes: newLimiter(), msgs: newLimiter()}
+		l.collections[collectionID] = c
+	}
+	collectionBytes := c.insertBytes
+	if msgType == message.MessageTypeDelete {
+		collectionBytes = c.deleteBytes
+	}
+	return []tokens{
+		{scope: scopeVChannel, limiter: setLimit(v.bytes, vchannelBytesRate), n: size},
+		{scope: scopeVChannel, limiter: setLimit(v.msgs, vchannelMsgRate), n: 1},
+		{scope: scopeCollection, limiter: setLimit(collectionBytes, collectionBytesRate), n: size},
+		{scope: scopeCollection, limiter: setLimit(c.msgs, collectionMsgRate), n: 1},
+	}
+}
+
+// remove removes the limiters of the vchannel and collection dropped.
+func (l *limiters) remove(vchannel string, collectionID int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.vchannels, vchannel)
+	delete(l.collections, collectionID)
+}
+
+// tryAcquire takes the tokens from all the limiters or none of them,
+// and returns the scope of the limiter that is exhausted.
+func tryAcquire(ts []tokens, now time.Time) (string, bool) {
+	for i, t := range ts {
+		if !t.limiter.AllowN(now, t.n) {
+			for _, acquired := range ts[:i] {
+				acquired.limiter.Cancel(acquired.n)
+			}
+			return t.scope, false
+		}
+	}
+	return "", true
+}
+
+func newLimiter() *ratelimitutil.Limiter {
+	limiter := ratelimitutil.NewLimiter(ratelimitutil.Inf, 0)
+	limiter.SetLimit(ratelimitutil.Inf)
+	return limiter
+}
+
+func setLimit(limiter *ratelimitutil.Limiter, rate float64) *ratelimitutil.Limiter {
+	if limit := ratelimitutil.Limit(rate); limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	return limiter
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var _ interceptors.Interceptor = (*rateLimitAppendInterceptor)(nil)
+
+// checkInterval is the interval to check the limiters again when the append is throttled.
+const checkInterval = 10 * time.Millisecond
+
+// rateLimitAppendInterceptor is an append interceptor to limit the rate of the insert and delete messages
+// of each vchannel and collection.
+type rateLimitAppendInterceptor struct {
+	limiters *limiters
+	metrics  *metricsutil.RateLimitMetrics
+}
+
+// DoAppend implements AppendInterceptor.
+func (r *rateLimitAppendInterceptor) DoAppend(ctx context.Context, msg message.MutableMessage, append interceptors.Append) (message.MessageID, error) {
+	switch msg.MessageType() {
+	case message.MessageTypeInsert, message.MessageTypeDelete:
+		if paramtable.Get().StreamingCfg.WALRateLimitEnabled.GetAsBool() {
+			if err := r.waitForTokens(ctx, msg); err != nil {
+				return nil, err
+			}
+		}
+	case message.MessageTypeDropCollection:
+		msgID, err := append(ctx, msg)
+		if err != nil {
+			return msgID, err
+		}
+		if dropMsg, err := message.AsMutableDropCollectionMessageV1(msg); err == nil {
+			r.limiters.remove(msg.VChannel(), dropMsg.Header().GetCollectionId())
+		}
+		return msgID, nil
+	}
+	return append(ctx, msg)
+}
+
+// waitForTokens waits until the message is allowed by all the limiters, at most streaming.walRateLimit.maxWait.
+func (r *rateLimitAppendInterceptor) waitForTokens(ctx context.Context, msg message.MutableMessage) error {
+	collectionID, err := collectionIDOf(msg)
+	if err != nil {
+		return err
+	}
+	ts := r.limiters.tokensOf(msg.VChannel(), collectionID, msg.MessageType(), msg.EstimateSize())
+	maxWait := paramtable.Get().StreamingCfg.WALRateLimitMaxWait.GetAsDurationByParse()
+
+	start := time.Now()
+	throttledScope := ""
+	for {
+		now := time.Now()
+		scope, ok := tryAcquire(ts, now)
+		if ok {
+			if throttledScope != "" {
+				r.metrics.Throttle(throttledScope, metricsutil.ThrottleActionDelay)
+				r.metrics.Delayed(now.Sub(start))
+			}
+			return nil
+		}
+		throttledScope = scope
+		if now.Sub(start)+checkInterval > maxWait {
+			r.metrics.Throttle(scope, metricsutil.ThrottleActionReject)
+			// The error is reported to the client rather than retried at once, which would make the throttle worse.
+			return status.NewUnrecoverableError("append message into %s is rejected by the %s rate limit after waiting %s",
+				msg.VChannel(), scope, now.Sub(start))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(checkInterval):
+		}
+	}
+}
+
+// collectionIDOf returns the collection of the insert or delete message.
+func collectionIDOf(msg message.MutableMessage) (int64, error) {
+	if msg.MessageType() == message.MessageTypeInsert {
+		insertMsg, err := message.AsMutableInsertMessageV1(msg)
+		if err != nil {
+			return 0, err
+		}
+		return insertMsg.Header().GetCollectionId(), nil
+	}
+	deleteMsg, err := message.AsMutableDeleteMessageV1(msg)
+	if err != nil {
+		return 0, err
+	}
+	return deleteMsg.Header().GetCollectionId(), nil
+}
+
+// Close implements BasicInterceptor.
+func (r *rateLimitAppendInterceptor) Close() {
+	r.metrics.Close()
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/mocks/streaming/mock_walimpls"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls/impls/walimplstest"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	for key, value := range map[string]string{
+		params.StreamingCfg.WALRateLimitEnabled.Key:              "true",
+		params.StreamingCfg.WALRateLimitCollectionMaxMsgRate.Key: "1",
+		params.StreamingCfg.WALRateLimitMaxWait.Key:              "0s",
+	} {
+		params.Save(key, value)
+		defer params.Reset(key)
+	}
+
+	l := mock_walimpls.NewMockWALImpls(t)
+	l.EXPECT().Channel().Return(types.PChannelInfo{Name: "p1"})
+	interceptor := NewInterceptorBuilder().Build(interceptors.InterceptorBuildParam{WALImpls: l})
+	defer interceptor.Close()
+
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return walimplstest.NewTestMessageID(int64(appended)), nil
+	}
+	newInsert := func(vchannel string, collectionID int64) message.MutableMessage {
+		msg, err := message.NewInsertMessageBuilderV1().
+			WithVChannel(vchannel).
+			WithHeader(&message.InsertMessageHeader{CollectionId: collectionID}).
+			WithBody(&msgpb.InsertRequest{}).
+			BuildMutable()
+		assert.NoError(t, err)
+		return msg
+	}
+	ctx := context.Background()
+
+	// the burst of the collection is taken by the first message,
+	// and the second one is allowed with the tokens in debt.
+	for i := 0; i < 2; i++ {
+		_, err := interceptor.DoAppend(ctx, newInsert("v1", 1), appendOp)
+		assert.NoError(t, err)
+	}
+	_, err := interceptor.DoAppend(ctx, newInsert("v1", 1), appendOp)
+	assert.Error(t, err)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	_, err = interceptor.DoAppend(ctx, newInsert("v2", 1), appendOp)
+	assert.Error(t, err)
+	assert.Equal(t, 2, appended)
+
+	// other collections are not limited.
+	_, err = interceptor.DoAppend(ctx, newInsert("v1", 2), appendOp)
+	assert.NoError(t, err)
+
+	// the limiters are removed with the collection dropped.
+	dropMsg, err := message.NewDropCollectionMessageBuilderV1().
+		WithVChannel("v1").
+		WithHeader(&message.DropCollectionMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.DropCollectionRequest{}).
+		BuildMutable()
+	assert.NoError(t, err)
+	_, err = interceptor.DoAppend(ctx, dropMsg, appendOp)
+	assert.NoError(t, err)
+	_, err = interceptor.DoAppend(ctx, newInsert("v1", 1), appendOp)
+	assert.NoError(t, err)
+
+	// the messages are not limited if disabled.
+	params.Save(params.StreamingCfg.WALRateLimitEnabled.Key, "false")
+	_, err = interceptor.DoAppend(ctx, newInsert("v1", 1), appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, appended)
+}
+
+func TestTryAcquire(t *testing.T) {
+	paramtable.Init()
+	l := newLimiters()
+	ts := l.tokensOf("v1", 1, message.MessageTypeInsert, 100)
+	assert.Len(t, ts, 4)
+
+	exhausted := newLimiter()
+	exhausted.SetLimit(0)
+	_, ok := tryAcquire([]tokens{ts[0], {scope: scopeCollection, limiter: exhausted, n: 1}}, time.Now())
+	assert.False(t, ok)
+	scope, ok := tryAcquire([]tokens{{scope: scopeCollection, limiter: exhausted, n: 1}}, time.Now())
+	assert.False(t, ok)
+	assert.Equal(t, scopeCollection, scope)
+	_, ok = tryAcquire(ts, time.Now())
+	assert.True(t, ok)
+}
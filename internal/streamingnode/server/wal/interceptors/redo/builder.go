@@ -11,6 +11,10 @@ func NewInterceptorBuilder() interceptors.InterceptorBuilder {
 type interceptorBuilder struct{}
 
 // Build creates a new redo interceptor.
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{Name: "redo", Stage: interceptors.StageRedo}
+}
+
 func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
 	return &redoAppendInterceptor{}
 }
@@ -0,0 +1,39 @@
+package schemacheck
+
+import (
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+)
+
+var _ interceptors.InterceptorBuilder = (*interceptorBuilder)(nil)
+
+// NewInterceptorBuilder creates a new schema check interceptor builder.
+func NewInterceptorBuilder() interceptors.InterceptorBuilder {
+	return &interceptorBuilder{
+		schemas: newSchemaCache(describeCollectionSchema),
+	}
+}
+
+// interceptorBuilder is the builder for schema check interceptor.
+// The schemas are shared by all the wals on the streaming node.
+type interceptorBuilder struct {
+	schemas *schemaCache
+}
+
+// Descriptor implements Builder.
+// The schema check runs after the dedup, so the retry of an appended message is not checked again,
+// and before the rate limit, so the invalid message takes no tokens.
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{
+		Name:   "schemacheck",
+		Stage:  interceptors.StageRedo,
+		After:  []string{"dedup"},
+		Before: []string{"ratelimit"},
+	}
+}
+
+// Build implements Builder.
+func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
+	return &schemaCheckAppendInterceptor{
+		schemas: b.schemas,
+	}
+}
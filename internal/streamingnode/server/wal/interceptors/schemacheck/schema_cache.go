@@ -0,0 +1,85 @@
+package schemacheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// describeTimeout is the timeout to describe the collection from the root coordinator.
+const describeTimeout = 5 * time.Second
+
+type describeFunc = func(ctx context.Context, collectionID int64) (*schemapb.CollectionSchema, error)
+
+// schemaCache caches the schemas of the collections written on the streaming node.
+// The schema is cached from the create collection message appended, or described from the root coordinator on the first use,
+// and removed once the drop collection message appended.
+type schemaCache struct {
+	mu       sync.RWMutex
+	schemas  map[int64]*schemapb.CollectionSchema
+	describe describeFunc
+}
+
+func newSchemaCache(describe describeFunc) *schemaCache {
+	return &schemaCache{
+		schemas:  make(map[int64]*schemapb.CollectionSchema),
+		describe: describe,
+	}
+}
+
+// Get returns the schema of the collection.
+func (c *schemaCache) Get(ctx context.Context, collectionID int64) (*schemapb.CollectionSchema, error) {
+	c.mu.RLock()
+	schema, ok := c.schemas[collectionID]
+	c.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err := c.describe(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	c.Put(collectionID, schema)
+	return schema, nil
+}
+
+// Put caches the schema of the collection.
+func (c *schemaCache) Put(collectionID int64, schema *schemapb.CollectionSchema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schemas[collectionID] = schema
+}
+
+// Remove removes the schema of the collection.
+func (c *schemaCache) Remove(collectionID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.schemas, collectionID)
+}
+
+// describeCollectionSchema describes the schema of the collection from the root coordinator.
+func describeCollectionSchema(ctx context.Context, collectionID int64) (*schemapb.CollectionSchema, error) {
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+
+	resp, err := resource.Resource().RootCoordClient().DescribeCollectionInternal(ctx, &milvuspb.DescribeCollectionRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	return resp.GetSchema(), nil
+}
@@ -0,0 +1,100 @@
+package schemacheck
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var _ interceptors.Interceptor = (*schemaCheckAppendInterceptor)(nil)
+
+// schemaCheckAppendInterceptor is an append interceptor to validate the insert messages against the collection schema,
+// so the invalid one is rejected to the producer rather than failing the flush path later.
+type schemaCheckAppendInterceptor struct {
+	schemas *schemaCache
+}
+
+// DoAppend implements AppendInterceptor.
+func (s *schemaCheckAppendInterceptor) DoAppend(ctx context.Context, msg message.MutableMessage, append interceptors.Append) (message.MessageID, error) {
+	switch msg.MessageType() {
+	case message.MessageTypeInsert:
+		if paramtable.Get().StreamingCfg.WALSchemaCheckEnabled.GetAsBool() {
+			if err := s.checkInsert(ctx, msg); err != nil {
+				return nil, err
+			}
+		}
+	case message.MessageTypeCreateCollection:
+		msgID, err := append(ctx, msg)
+		if err != nil {
+			return msgID, err
+		}
+		s.cacheSchema(ctx, msg)
+		return msgID, nil
+	case message.MessageTypeDropCollection:
+		msgID, err := append(ctx, msg)
+		if err != nil {
+			return msgID, err
+		}
+		if dropMsg, err := message.AsMutableDropCollectionMessageV1(msg); err == nil {
+			s.schemas.Remove(dropMsg.Header().GetCollectionId())
+		}
+		return msgID, nil
+	}
+	return append(ctx, msg)
+}
+
+// checkInsert validates the insert message against the schema of its collection.
+// The message is not checked if the schema is not available, the flush path still guards it.
+func (s *schemaCheckAppendInterceptor) checkInsert(ctx context.Context, msg message.MutableMessage) error {
+	insertMsg, err := message.AsMutableInsertMessageV1(msg)
+	if err != nil {
+		return err
+	}
+	body, err := insertMsg.Body()
+	if err != nil {
+		return err
+	}
+	collectionID := insertMsg.Header().GetCollectionId()
+	schema, err := s.schemas.Get(ctx, collectionID)
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to get the collection schema, skip the schema check",
+			zap.String("vchannel", msg.VChannel()), zap.Int64("collectionID", collectionID), zap.Error(err))
+		return nil
+	}
+	if err := validateInsert(schema, body); err != nil {
+		return status.NewUnrecoverableError("insert message of collection %d into %s is invalid: %s",
+			collectionID, msg.VChannel(), err.Error())
+	}
+	return nil
+}
+
+// cacheSchema caches the schema carried by the create collection message.
+func (s *schemaCheckAppendInterceptor) cacheSchema(ctx context.Context, msg message.MutableMessage) {
+	createMsg, err := message.AsMutableCreateCollectionMessageV1(msg)
+	if err != nil {
+		return
+	}
+	body, err := createMsg.Body()
+	if err != nil {
+		return
+	}
+	collectionID := createMsg.Header().GetCollectionId()
+	schema := &schemapb.CollectionSchema{}
+	if err := proto.Unmarshal(body.GetSchema(), schema); err != nil {
+		log.Ctx(ctx).Warn("failed to unmarshal the schema of the created collection",
+			zap.Int64("collectionID", collectionID), zap.Error(err))
+		return
+	}
+	s.schemas.Put(collectionID, schema)
+}
+
+// Close implements BasicInterceptor.
+func (s *schemaCheckAppendInterceptor) Close() {}
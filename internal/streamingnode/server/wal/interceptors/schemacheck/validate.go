@@ -0,0 +1,69 @@
+package schemacheck
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/nullutil"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// validateInsert checks the fields of the insert request against the collection schema,
+// every user field but the function outputs is expected once with the rows, dim and valid data matched.
+func validateInsert(schema *schemapb.CollectionSchema, req *msgpb.InsertRequest) error {
+	numRows := req.GetNumRows()
+	fieldsData := make(map[int64]*schemapb.FieldData, len(req.GetFieldsData()))
+	for _, fieldData := range req.GetFieldsData() {
+		if _, ok := fieldsData[fieldData.GetFieldId()]; ok {
+			return errors.Newf("field %s(%d) is duplicated", fieldData.GetFieldName(), fieldData.GetFieldId())
+		}
+		fieldsData[fieldData.GetFieldId()] = fieldData
+	}
+
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() < common.StartOfUserFieldID || field.GetIsFunctionOutput() {
+			continue
+		}
+		fieldData, ok := fieldsData[field.GetFieldID()]
+		if !ok {
+			return errors.Newf("field %s(%d) is missing", field.GetName(), field.GetFieldID())
+		}
+		delete(fieldsData, field.GetFieldID())
+		if err := validateField(field, fieldData, numRows); err != nil {
+			return errors.Wrapf(err, "field %s(%d)", field.GetName(), field.GetFieldID())
+		}
+	}
+	for fieldID, fieldData := range fieldsData {
+		if fieldID >= common.StartOfUserFieldID {
+			return errors.Newf("field %s(%d) is not in the schema", fieldData.GetFieldName(), fieldID)
+		}
+	}
+	return nil
+}
+
+// validateField checks the field data against the field schema.
+func validateField(field *schemapb.FieldSchema, fieldData *schemapb.FieldData, numRows uint64) error {
+	if fieldData.GetType() != field.GetDataType() {
+		return errors.Newf("data type %s mismatches the schema %s", fieldData.GetType(), field.GetDataType())
+	}
+	if typeutil.IsVectorType(field.GetDataType()) && !typeutil.IsSparseFloatVectorType(field.GetDataType()) {
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return err
+		}
+		if fieldData.GetVectors().GetDim() != dim {
+			return errors.Newf("dim %d mismatches the schema %d", fieldData.GetVectors().GetDim(), dim)
+		}
+	}
+	n, err := funcutil.GetNumRowOfFieldData(fieldData)
+	if err != nil {
+		return err
+	}
+	if n != numRows {
+		return errors.Newf("num rows %d mismatches the message %d", n, numRows)
+	}
+	return nullutil.CheckValidData(fieldData.GetValidData(), field, int(numRows))
+}
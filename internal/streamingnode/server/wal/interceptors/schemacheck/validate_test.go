@@ -0,0 +1,81 @@
+package schemacheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+)
+
+func newTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}}},
+			{FieldID: 102, Name: "tag", DataType: schemapb.DataType_VarChar, Nullable: true},
+			{FieldID: 103, Name: "sparse", DataType: schemapb.DataType_SparseFloatVector, IsFunctionOutput: true},
+		},
+	}
+}
+
+func newTestInsert() *msgpb.InsertRequest {
+	return &msgpb.InsertRequest{
+		NumRows: 2,
+		FieldsData: []*schemapb.FieldData{
+			{
+				FieldId: 100, FieldName: "pk", Type: schemapb.DataType_Int64,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}},
+				}},
+			},
+			{
+				FieldId: 101, FieldName: "vec", Type: schemapb.DataType_FloatVector,
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+					Dim:  2,
+					Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4}}},
+				}},
+			},
+			{
+				FieldId: 102, FieldName: "tag", Type: schemapb.DataType_VarChar,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", ""}}},
+				}},
+				ValidData: []bool{true, false},
+			},
+		},
+	}
+}
+
+func TestValidateInsert(t *testing.T) {
+	schema := newTestSchema()
+	assert.NoError(t, validateInsert(schema, newTestInsert()))
+
+	cases := []struct {
+		name   string
+		modify func(req *msgpb.InsertRequest)
+	}{
+		{"missing field", func(req *msgpb.InsertRequest) { req.FieldsData = req.FieldsData[:2] }},
+		{"duplicated field", func(req *msgpb.InsertRequest) { req.FieldsData = append(req.FieldsData, req.FieldsData[0]) }},
+		{"unknown field", func(req *msgpb.InsertRequest) {
+			req.FieldsData = append(req.FieldsData, &schemapb.FieldData{FieldId: 200, Type: schemapb.DataType_Int64})
+		}},
+		{"type mismatch", func(req *msgpb.InsertRequest) { req.FieldsData[0].Type = schemapb.DataType_Int32 }},
+		{"dim mismatch", func(req *msgpb.InsertRequest) {
+			req.FieldsData[1].GetVectors().Dim = 4
+			req.NumRows = 1
+		}},
+		{"rows mismatch", func(req *msgpb.InsertRequest) { req.NumRows = 3 }},
+		{"valid data of nullable", func(req *msgpb.InsertRequest) { req.FieldsData[2].ValidData = nil }},
+		{"valid data of not nullable", func(req *msgpb.InsertRequest) { req.FieldsData[0].ValidData = []bool{true, true} }},
+	}
+	for _, c := range cases {
+		req := newTestInsert()
+		c.modify(req)
+		assert.Error(t, validateInsert(schema, req), c.name)
+	}
+}
@@ -17,6 +17,10 @@ func NewInterceptorBuilder() interceptors.InterceptorBuilder {
 
 type interceptorBuilder struct{}
 
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{Name: "segment", Stage: interceptors.StageAssign, After: []string{"timetick"}}
+}
+
 func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
 	assignManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
 	ctx, cancel := context.WithCancel(context.Background())
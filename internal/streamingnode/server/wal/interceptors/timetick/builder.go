@@ -19,6 +19,10 @@ func NewInterceptorBuilder() interceptors.InterceptorBuilder {
 type interceptorBuilder struct{}
 
 // Build implements Builder.
+func (b *interceptorBuilder) Descriptor() interceptors.Descriptor {
+	return interceptors.Descriptor{Name: "timetick", Stage: interceptors.StageTimeTick}
+}
+
 func (b *interceptorBuilder) Build(param interceptors.InterceptorBuildParam) interceptors.Interceptor {
 	operator := newTimeTickSyncOperator(param)
 	// initialize operation can be async to avoid block the build operation.
@@ -55,6 +55,13 @@ func (impl *timeTickAppendInterceptor) DoAppend(ctx context.Context, msg message
 				acker.Ack(ack.OptError(err))
 				return
 			}
+			if msg.MessageType() == message.MessageTypeBarrier {
+				// barrier message is not persisted, so it's acked as a sync message,
+				// and the time tick is pushed forward at once to make the barrier visible to the consumers.
+				acker.Ack(ack.OptSync(), ack.OptMessageID(msgID))
+				resource.Resource().TimeTickInspector().TriggerSync(impl.operator.Channel())
+				return
+			}
 			acker.Ack(
 				ack.OptMessageID(msgID),
 				ack.OptTxnSession(txnSession),
@@ -80,6 +87,14 @@ func (impl *timeTickAppendInterceptor) DoAppend(ctx context.Context, msg message
 	case message.MessageTypeTimeTick:
 		// cleanup the expired transaction sessions and the already done transaction.
 		impl.txnManager.CleanupTxnUntil(msg.TimeTick())
+	case message.MessageTypeBarrier:
+		if msg.TxnContext() != nil {
+			return nil, errors.New("barrier message cannot be appended in transaction")
+		}
+		// barrier message only takes a time tick, it's never written into the underlying wal.
+		ctx = utility.WithNotPersisted(ctx, &utility.NotPersistedHint{
+			MessageID: impl.operator.TimeTickNotifier().Get().MessageID,
+		})
 	default:
 		// handle the transaction body message.
 		if msg.TxnContext() != nil {
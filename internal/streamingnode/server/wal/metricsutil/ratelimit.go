@@ -0,0 +1,62 @@
+package metricsutil
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/syncutil"
+)
+
+const (
+	ThrottleActionDelay  = "delay"
+	ThrottleActionReject = "reject"
+)
+
+// NewRateLimitMetrics creates a new rate limit metrics.
+func NewRateLimitMetrics(pchannel string) *RateLimitMetrics {
+	constLabel := prometheus.Labels{
+		metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+		metrics.WALChannelLabelName: pchannel,
+	}
+	return &RateLimitMetrics{
+		mu:               syncutil.ClosableLock{},
+		constLabel:       constLabel,
+		throttleCounter:  metrics.WALAppendThrottleTotal.MustCurryWith(constLabel),
+		throttleDuration: metrics.WALAppendThrottleDurationSeconds.With(constLabel),
+	}
+}
+
+// RateLimitMetrics is the metrics for the rate limit of wal append.
+type RateLimitMetrics struct {
+	mu               syncutil.ClosableLock
+	constLabel       prometheus.Labels
+	throttleCounter  *prometheus.CounterVec
+	throttleDuration prometheus.Observer
+}
+
+// Throttle records the append throttled by the limit of scope.
+func (m *RateLimitMetrics) Throttle(scope string, action string) {
+	if !m.mu.LockIfNotClosed() {
+		return
+	}
+	m.throttleCounter.WithLabelValues(scope, action).Inc()
+	m.mu.Unlock()
+}
+
+// Delayed records the duration the append waited for the rate limit.
+func (m *RateLimitMetrics) Delayed(d time.Duration) {
+	if !m.mu.LockIfNotClosed() {
+		return
+	}
+	m.throttleDuration.Observe(d.Seconds())
+	m.mu.Unlock()
+}
+
+func (m *RateLimitMetrics) Close() {
+	m.mu.Close()
+	metrics.WALAppendThrottleTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALAppendThrottleDurationSeconds.Delete(m.constLabel)
+}
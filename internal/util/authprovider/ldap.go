@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authprovider
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ldapUsernamePlaceholder = "{username}"
+	ldapDefaultTimeout      = 10 * time.Second
+)
+
+type LDAPConfig struct {
+	Address string
+	UseTLS  bool
+	// TLSConfig is used if UseTLS, the system roots are used if nil.
+	TLSConfig *tls.Config
+	// BindDNTemplate is the dn to bind as, ldapUsernamePlaceholder is replaced by the escaped username.
+	BindDNTemplate string
+	// GroupAttribute is the attribute of the user entry listing the dns of the groups, e.g. memberOf,
+	// no group is read if empty.
+	GroupAttribute string
+}
+
+// LDAPProvider authenticates the users by binding to the LDAP server as them,
+// and reads their groups from the attribute of their entries.
+// The groups are the normalized dns, see NormalizeDN.
+type LDAPProvider struct {
+	cfg LDAPConfig
+}
+
+func NewLDAPProvider(cfg LDAPConfig) (*LDAPProvider, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("the address of ldap is required")
+	}
+	if !strings.Contains(cfg.BindDNTemplate, ldapUsernamePlaceholder) {
+		return nil, errors.Newf("the bind dn template of ldap must contain %s", ldapUsernamePlaceholder)
+	}
+	return &LDAPProvider{cfg: cfg}, nil
+}
+
+func (p *LDAPProvider) Name() string {
+	return "ldap"
+}
+
+func (p *LDAPProvider) AuthenticatePassword(ctx context.Context, username, password string) (*Identity, error) {
+	// an empty password is an unauthenticated bind, which succeeds without checking anything
+	if username == "" || password == "" {
+		return nil, errors.Wrap(ErrNotAuthenticated, "empty username or password")
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ldapDefaultTimeout)
+	}
+	conn, err := p.dial(deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetTimeout(time.Until(deadline))
+
+	bindDN := strings.ReplaceAll(p.cfg.BindDNTemplate, ldapUsernamePlaceholder, escapeDN(username))
+	if err := conn.Bind(bindDN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errors.Wrap(ErrNotAuthenticated, err.Error())
+		}
+		return nil, err
+	}
+	identity := &Identity{Username: username}
+	if p.cfg.GroupAttribute == "" {
+		return identity, nil
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(bindDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, int(time.Until(deadline)/time.Second), false,
+		"(objectClass=*)", []string{p.cfg.GroupAttribute}, nil))
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range result.Entries {
+		for _, group := range entry.GetEqualFoldAttributeValues(p.cfg.GroupAttribute) {
+			identity.Groups = append(identity.Groups, NormalizeDN(group))
+		}
+	}
+	return identity, nil
+}
+
+func (p *LDAPProvider) dial(deadline time.Time) (*ldap.Conn, error) {
+	opts := []ldap.DialOpt{ldap.DialWithDialer(&net.Dialer{Deadline: deadline})}
+	scheme := "ldap"
+	if p.cfg.UseTLS {
+		scheme = "ldaps"
+		tlsConfig := p.cfg.TLSConfig
+		if tlsConfig == nil {
+			host, _, _ := net.SplitHostPort(p.cfg.Address)
+			tlsConfig = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+		}
+		opts = append(opts, ldap.DialWithTLSConfig(tlsConfig))
+	}
+	return ldap.DialURL(scheme+"://"+p.cfg.Address, opts...)
+}
+
+// NormalizeDN returns the dn with the attribute types and values in lower case and without the spaces around,
+// so the dns of a group written differently are the same, the value is returned as is if not a dn.
+func NormalizeDN(value string) string {
+	dn, err := ldap.ParseDN(value)
+	if err != nil || len(dn.RDNs) == 0 {
+		return value
+	}
+	rdns := make([]string, 0, len(dn.RDNs))
+	for _, rdn := range dn.RDNs {
+		attrs := make([]string, 0, len(rdn.Attributes))
+		for _, attr := range rdn.Attributes {
+			attrs = append(attrs, strings.ToLower(attr.Type)+"="+escapeDN(strings.ToLower(attr.Value)))
+		}
+		rdns = append(rdns, strings.Join(attrs, "+"))
+	}
+	return strings.Join(rdns, ",")
+}
+
+// escapeDN escapes the value of an attribute of the dn, see RFC 4514.
+func escapeDN(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case strings.IndexByte(`,+"\<>;=`, c) >= 0,
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(value)-1 && c == ' ':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
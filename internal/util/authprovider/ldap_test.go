@@ -0,0 +1,156 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authprovider
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveLDAP serves the bind and search requests of the connections,
+// the users are the passwords and the groups by the dn.
+func serveLDAP(t *testing.T, passwords map[string]string, groups map[string][]string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	constructed := func(class ber.Class, tag ber.Tag, children ...*ber.Packet) *ber.Packet {
+		p := ber.Encode(class, ber.TypeConstructed, tag, nil, "")
+		for _, child := range children {
+			p.AppendChild(child)
+		}
+		return p
+	}
+	str := func(value string) *ber.Packet {
+		return ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "")
+	}
+	ldapResult := func(tag ber.Tag, code int64) *ber.Packet {
+		return constructed(ber.ClassApplication, tag,
+			ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""), str(""), str("diagnostic"))
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var boundDN string
+				for {
+					msg, err := ber.ReadPacket(conn)
+					if err != nil || len(msg.Children) < 2 {
+						return
+					}
+					id, op := msg.Children[0].Value, msg.Children[1]
+					reply := func(op *ber.Packet) {
+						conn.Write(constructed(ber.ClassUniversal, ber.TagSequence,
+							ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""), op).Bytes())
+					}
+					switch op.Tag {
+					case ldap.ApplicationBindRequest:
+						dn, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+						if expected, ok := passwords[dn]; ok && expected == password {
+							boundDN = dn
+							reply(ldapResult(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess))
+						} else {
+							reply(ldapResult(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials))
+						}
+					case ldap.ApplicationSearchRequest:
+						dn := op.Children[0].Data.String()
+						if dn != boundDN {
+							reply(ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights))
+							continue
+						}
+						var values []*ber.Packet
+						for _, group := range groups[dn] {
+							values = append(values, str(group))
+						}
+						reply(constructed(ber.ClassApplication, ldap.ApplicationSearchResultEntry, str(dn),
+							constructed(ber.ClassUniversal, ber.TagSequence,
+								constructed(ber.ClassUniversal, ber.TagSequence, str("cn"), constructed(ber.ClassUniversal, ber.TagSet, str("user"))),
+								constructed(ber.ClassUniversal, ber.TagSequence, str("MemberOf"), constructed(ber.ClassUniversal, ber.TagSet, values...)),
+							)))
+						reply(ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+					case ldap.ApplicationUnbindRequest:
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener
+}
+
+func TestLDAPProvider(t *testing.T) {
+	listener := serveLDAP(t,
+		map[string]string{`uid=user1,dc=milvus`: "password1", `uid=user\,2,dc=milvus`: "password2"},
+		map[string][]string{`uid=user1,dc=milvus`: {"CN=Admins, DC=milvus", "cn=readers,dc=milvus"}},
+	)
+	defer listener.Close()
+
+	_, err := NewLDAPProvider(LDAPConfig{BindDNTemplate: "uid={username},dc=milvus"})
+	assert.Error(t, err)
+	_, err = NewLDAPProvider(LDAPConfig{Address: listener.Addr().String(), BindDNTemplate: "uid=user,dc=milvus"})
+	assert.Error(t, err)
+
+	provider, err := NewLDAPProvider(LDAPConfig{
+		Address:        listener.Addr().String(),
+		BindDNTemplate: "uid={username},dc=milvus",
+		GroupAttribute: "memberOf",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ldap", provider.Name())
+	ctx := context.Background()
+
+	identity, err := provider.AuthenticatePassword(ctx, "user1", "password1")
+	require.NoError(t, err)
+	assert.Equal(t, "user1", identity.Username)
+	assert.Equal(t, []string{"cn=admins,dc=milvus", "cn=readers,dc=milvus"}, identity.Groups)
+
+	identity, err = provider.AuthenticatePassword(ctx, "user,2", "password2")
+	require.NoError(t, err)
+	assert.Empty(t, identity.Groups)
+
+	_, err = provider.AuthenticatePassword(ctx, "user1", "password2")
+	assert.ErrorIs(t, err, ErrNotAuthenticated)
+	_, err = provider.AuthenticatePassword(ctx, "user1", "")
+	assert.ErrorIs(t, err, ErrNotAuthenticated)
+
+	provider.cfg.Address = "127.0.0.1:0"
+	_, err = provider.AuthenticatePassword(ctx, "user1", "password1")
+	assert.Error(t, err)
+}
+
+func TestEscapeDN(t *testing.T) {
+	assert.Equal(t, "user", escapeDN("user"))
+	assert.Equal(t, `a\,b\+c\=d`, escapeDN("a,b+c=d"))
+	assert.Equal(t, `\#user\ `, escapeDN("#user "))
+	assert.Equal(t, `\ a b`, escapeDN(" a b"))
+	assert.Equal(t, `a\00`, escapeDN("a\x00"))
+}
+
+func TestNormalizeDN(t *testing.T) {
+	assert.Equal(t, "cn=admins,ou=groups,dc=milvus", NormalizeDN("CN=Admins, OU=Groups,DC=Milvus"))
+	assert.Equal(t, `cn=a\,b+uid=c,dc=milvus`, NormalizeDN(`cn=A\,B+UID=c,dc=milvus`))
+	// not a dn
+	assert.Equal(t, "admins", NormalizeDN("admins"))
+}
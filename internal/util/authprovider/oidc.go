@@ -0,0 +1,296 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+)
+
+const (
+	// jwksRefreshInterval is the interval to refresh the keys,
+	// the keys are refreshed ahead of it for an unknown key id, at most once per jwksMinRefreshInterval.
+	jwksRefreshInterval    = time.Hour
+	jwksMinRefreshInterval = time.Minute
+	// clockSkew is the leeway of the expiration and the not before time.
+	clockSkew = time.Minute
+)
+
+type OIDCConfig struct {
+	Issuer   string
+	Audience string
+	// JWKSURI is discovered from the openid configuration of the issuer if empty.
+	JWKSURI string
+	// UsernameClaim is the claim identifying the user within the issuer, which shall be unique and stable, sub by default.
+	UsernameClaim string
+	GroupsClaim   string
+}
+
+// OIDCProvider authenticates the OIDC id tokens signed by the keys of the issuer,
+// the tokens are verified by golang-jwt, and the keys are fetched from the jwks of the issuer.
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+
+	// refresh dedups the concurrent fetches of the keys, which are done without holding mu
+	refresh conc.Singleflight[map[string]crypto.PublicKey]
+	jwksURI string
+}
+
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("the issuer and the audience of oidc are required")
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "sub"
+	}
+	return &OIDCProvider{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		jwksURI: cfg.JWKSURI,
+	}, nil
+}
+
+func (p *OIDCProvider) Name() string {
+	return "oidc"
+}
+
+// signingMethods are the algorithms of the asymmetric keys published by the issuers,
+// the symmetric ones and none are never accepted.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+func (p *OIDCProvider) AuthenticateToken(ctx context.Context, token string) (*Identity, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	// the failure to fetch the keys is not a rejection of the token
+	var keyErr error
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		var key crypto.PublicKey
+		key, keyErr = p.getKey(ctx, kid)
+		return key, keyErr
+	})
+	if err != nil {
+		if keyErr != nil && !errors.Is(keyErr, ErrNotAuthenticated) {
+			return nil, keyErr
+		}
+		return nil, errors.Wrap(ErrNotAuthenticated, err.Error())
+	}
+	return p.identityOf(claims)
+}
+
+// identityOf returns the identity of the verified claims.
+func (p *OIDCProvider) identityOf(claims jwt.MapClaims) (*Identity, error) {
+	username, _ := claims[p.cfg.UsernameClaim].(string)
+	if username == "" {
+		return nil, errors.Wrapf(ErrNotAuthenticated, "no username claim %s", p.cfg.UsernameClaim)
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, errors.Wrap(ErrNotAuthenticated, "no expiration")
+	}
+	identity := &Identity{Username: username, ExpireAt: exp.Time}
+	switch groups := claims[p.cfg.GroupsClaim].(type) {
+	case string:
+		identity.Groups = []string{groups}
+	case []any:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, g)
+			}
+		}
+	}
+	return identity, nil
+}
+
+// getKey returns the key of the id, the keys are refreshed if expired or the id is unknown.
+func (p *OIDCProvider) getKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	age := time.Since(p.fetchedAt)
+	p.mu.Unlock()
+
+	if (ok && age < jwksRefreshInterval) || (!ok && age < jwksMinRefreshInterval) {
+		if !ok {
+			return nil, errors.Wrapf(ErrNotAuthenticated, "unknown key id %s", kid)
+		}
+		return key, nil
+	}
+
+	keys, err, _ := p.refresh.Do("jwks", func() (map[string]crypto.PublicKey, error) {
+		keys, err := p.fetchKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.keys = keys
+		p.fetchedAt = time.Now()
+		return keys, nil
+	})
+	if err != nil {
+		log.Warn("failed to fetch the oidc keys", zap.String("issuer", p.cfg.Issuer), zap.Error(err))
+		if ok {
+			// keep serving with the previous keys
+			return key, nil
+		}
+		return nil, err
+	}
+	key, ok = keys[kid]
+	if !ok {
+		return nil, errors.Wrapf(ErrNotAuthenticated, "unknown key id %s", kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (p *OIDCProvider) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if p.jwksURI == "" {
+		discovery := struct {
+			JWKSURI string `json:"jwks_uri"`
+		}{}
+		if err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, err
+		}
+		if discovery.JWKSURI == "" {
+			return nil, errors.New("no jwks_uri in the openid configuration")
+		}
+		p.jwksURI = discovery.JWKSURI
+	}
+
+	jwks := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	if err := p.getJSON(ctx, p.jwksURI, &jwks); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			log.Warn("skip the invalid oidc key", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("get %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	encode := func(v any) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := encode(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encode(claims)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest.Sum(nil))
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOIDCProvider(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	b64 := func(b []byte) string {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	jwksRequests := 0
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		jwksRequests++
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+		}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	issuer = server.URL
+
+	_, err = NewOIDCProvider(OIDCConfig{Issuer: issuer})
+	assert.Error(t, err)
+	provider, err := NewOIDCProvider(OIDCConfig{Issuer: issuer, Audience: "milvus", GroupsClaim: "groups"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	claims := func(modify func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"iss":    issuer,
+			"aud":    []string{"other", "milvus"},
+			"sub":    "user1",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"admins", "readers"},
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	t.Run("rsa", func(t *testing.T) {
+		identity, err := provider.AuthenticateToken(ctx, signJWT(t, "RS256", "rsa", rsaKey, claims(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, "user1", identity.Username)
+		assert.Equal(t, []string{"admins", "readers"}, identity.Groups)
+		assert.False(t, identity.ExpireAt.IsZero())
+	})
+
+	t.Run("ecdsa", func(t *testing.T) {
+		identity, err := provider.AuthenticateToken(ctx, signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]any) {
+			c["aud"] = "milvus"
+			c["groups"] = "admins"
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admins"}, identity.Groups)
+		assert.Equal(t, 1, jwksRequests)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, token := range map[string]string{
+			"not jwt":      "api-key",
+			"wrong key":    signJWT(t, "RS256", "rsa", otherKey, claims(nil)),
+			"alg mismatch": signJWT(t, "ES256", "rsa", ecKey, claims(nil)),
+			"unknown kid":  signJWT(t, "RS256", "unknown", rsaKey, claims(nil)),
+			"issuer":       signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["iss"] = "other" })),
+			"audience":     signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["aud"] = "other" })),
+			"expired":      signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() })),
+			"no exp":       signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { delete(c, "exp") })),
+			"not before":   signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["nbf"] = time.Now().Add(time.Hour).Unix() })),
+			"no username":  signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { delete(c, "sub") })),
+		} {
+			_, err := provider.AuthenticateToken(ctx, token)
+			assert.ErrorIs(t, err, ErrNotAuthenticated, name)
+		}
+		// the unknown key id refreshes the keys once in jwksMinRefreshInterval
+		assert.Equal(t, 1, jwksRequests)
+	})
+}
@@ -0,0 +1,280 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authprovider authenticates the users not built in milvus by the external identity providers,
+// and maps their groups to the milvus roles.
+package authprovider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+var (
+	// ErrNoProvider is returned if no provider accepts the kind of the credential.
+	ErrNoProvider = errors.New("no auth provider")
+	// ErrNotAuthenticated is returned if the credential is rejected by the provider.
+	ErrNotAuthenticated = errors.New("not authenticated")
+)
+
+const (
+	// maxCacheEntries bounds the cache, the expired ones are removed once reached.
+	maxCacheEntries = 10000
+	// principalSeparator separates the provider and the username of the principals,
+	// which is never in the names of the built-in users, and is rejected in the usernames of the password users.
+	principalSeparator = "/"
+	// cacheKeySize is the size of the random key the cache keys are hashed with.
+	cacheKeySize = 32
+)
+
+// Identity is a user authenticated by a provider.
+type Identity struct {
+	// Username is the name of the user within the provider.
+	Username string
+	Groups   []string
+	// ExpireAt is the time the credential expires at, zero if never.
+	ExpireAt time.Time
+
+	// Provider is the name of the provider authenticated the user, set by the manager.
+	Provider string
+	// Principal is the name the user runs as in milvus, set by the manager, which the roles are keyed by.
+	// The users are named by the provider and the username, e.g. oidc/alice or ldap/alice,
+	// so they never collide with the built-in users, including the ones created later, or the users of other providers.
+	Principal string
+}
+
+// TokenProvider authenticates the users by the tokens, e.g. the OIDC id tokens.
+type TokenProvider interface {
+	Name() string
+	AuthenticateToken(ctx context.Context, token string) (*Identity, error)
+}
+
+// PasswordProvider authenticates the users by the username and password, e.g. LDAP.
+type PasswordProvider interface {
+	Name() string
+	AuthenticatePassword(ctx context.Context, username, password string) (*Identity, error)
+}
+
+type cacheEntry struct {
+	identity *Identity
+	expireAt time.Time
+}
+
+// Manager authenticates the users by the providers and caches the identities for ttl,
+// so the providers are not called on every request, and the users revoked by the providers
+// are rejected after ttl at most.
+// The groups are mapped to the roles by their names, the dns of the LDAP groups are compared normalized.
+type Manager struct {
+	tokenProviders    []TokenProvider
+	passwordProviders []PasswordProvider
+	groupRoles        map[string][]string
+	ttl               time.Duration
+	// cacheKeySecret keys the hmac of the cached credentials, random per process,
+	// so the cache keys can't be cracked offline for the passwords.
+	cacheKeySecret []byte
+
+	mu    sync.RWMutex
+	cache map[string]*cacheEntry
+	// the roles of the users by the principal, by the latest identities authenticated
+	roles map[string]*cacheEntry
+}
+
+// NewManager returns a manager with the roles of the groups in the format of `role1,role2`.
+func NewManager(ttl time.Duration, groupRoles map[string]string, tokenProviders []TokenProvider, passwordProviders []PasswordProvider) *Manager {
+	m := &Manager{
+		tokenProviders:    tokenProviders,
+		passwordProviders: passwordProviders,
+		groupRoles:        make(map[string][]string),
+		ttl:               ttl,
+		cacheKeySecret:    make([]byte, cacheKeySize),
+		cache:             make(map[string]*cacheEntry),
+		roles:             make(map[string]*cacheEntry),
+	}
+	if _, err := rand.Read(m.cacheKeySecret); err != nil {
+		// never happens on the supported platforms
+		panic(errors.Wrap(err, "failed to generate the cache key secret"))
+	}
+	for group, roles := range groupRoles {
+		group = NormalizeDN(group)
+		for _, role := range strings.Split(roles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				m.groupRoles[group] = append(m.groupRoles[group], role)
+			}
+		}
+	}
+	return m
+}
+
+// Enabled returns whether any provider is configured.
+func (m *Manager) Enabled() bool {
+	return m != nil && (len(m.tokenProviders) > 0 || len(m.passwordProviders) > 0)
+}
+
+// AuthenticateToken authenticates the token by the token providers in order.
+func (m *Manager) AuthenticateToken(ctx context.Context, token string) (*Identity, error) {
+	if m == nil || len(m.tokenProviders) == 0 {
+		return nil, ErrNoProvider
+	}
+	key := m.cacheKey("token", token)
+	if identity := m.getCached(key); identity != nil {
+		return identity, nil
+	}
+	var errs error
+	for _, provider := range m.tokenProviders {
+		identity, err := provider.AuthenticateToken(ctx, token)
+		if err != nil {
+			errs = errors.CombineErrors(errs, errors.Wrap(err, provider.Name()))
+			continue
+		}
+		identity = qualify(identity, provider.Name(), provider.Name()+principalSeparator+identity.Username)
+		m.put(key, identity)
+		return identity, nil
+	}
+	return nil, errs
+}
+
+// AuthenticatePassword authenticates the username and password by the password providers in order.
+func (m *Manager) AuthenticatePassword(ctx context.Context, username, password string) (*Identity, error) {
+	if m == nil || len(m.passwordProviders) == 0 {
+		return nil, ErrNoProvider
+	}
+	if strings.Contains(username, principalSeparator) {
+		return nil, errors.Wrapf(ErrNotAuthenticated, "username %s contains %s", username, principalSeparator)
+	}
+	key := m.cacheKey("password", username, password)
+	if identity := m.getCached(key); identity != nil {
+		return identity, nil
+	}
+	var errs error
+	for _, provider := range m.passwordProviders {
+		identity, err := provider.AuthenticatePassword(ctx, username, password)
+		if err != nil {
+			errs = errors.CombineErrors(errs, errors.Wrap(err, provider.Name()))
+			continue
+		}
+		identity = qualify(identity, provider.Name(), provider.Name()+principalSeparator+username)
+		m.put(key, identity)
+		return identity, nil
+	}
+	return nil, errs
+}
+
+// Roles returns the roles mapped from the groups of the principal authenticated lately.
+func (m *Manager) Roles(principal string) []string {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.roles[principal]
+	if !ok || time.Now().After(entry.expireAt) {
+		return nil
+	}
+	return m.rolesOf(entry.identity)
+}
+
+// Invalidate removes the cached identities of the principal, the user is authenticated by the providers again.
+func (m *Manager) Invalidate(principal string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, entry := range m.cache {
+		if entry.identity.Principal == principal {
+			delete(m.cache, key)
+		}
+	}
+	delete(m.roles, principal)
+}
+
+// qualify returns a copy of the identity authenticated by the provider as the principal.
+func qualify(identity *Identity, provider, principal string) *Identity {
+	qualified := *identity
+	qualified.Provider = provider
+	qualified.Principal = principal
+	return &qualified
+}
+
+func (m *Manager) rolesOf(identity *Identity) []string {
+	roles := make([]string, 0)
+	for _, group := range identity.Groups {
+		roles = append(roles, m.groupRoles[group]...)
+	}
+	return roles
+}
+
+func (m *Manager) getCached(key string) *Identity {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.cache[key]
+	if !ok || time.Now().After(entry.expireAt) {
+		return nil
+	}
+	return entry.identity
+}
+
+func (m *Manager) put(key string, identity *Identity) {
+	expireAt := time.Now().Add(m.ttl)
+	if !identity.ExpireAt.IsZero() && identity.ExpireAt.Before(expireAt) {
+		expireAt = identity.ExpireAt
+	}
+	entry := &cacheEntry{identity: identity, expireAt: expireAt}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.cache) >= maxCacheEntries {
+		now := time.Now()
+		for key, entry := range m.cache {
+			if now.After(entry.expireAt) {
+				delete(m.cache, key)
+			}
+		}
+		for principal, entry := range m.roles {
+			if now.After(entry.expireAt) {
+				delete(m.roles, principal)
+			}
+		}
+		if len(m.cache) >= maxCacheEntries {
+			log.Warn("too many identities of the auth providers cached, drop all", zap.Int("num", len(m.cache)))
+			m.cache = make(map[string]*cacheEntry)
+		}
+	}
+	m.cache[key] = entry
+	m.roles[identity.Principal] = entry
+}
+
+// cacheKey hashes the credential with the secret of the manager, so the credentials are not kept in memory.
+func (m *Manager) cacheKey(kind string, credential ...string) string {
+	h := hmac.New(sha256.New, m.cacheKeySecret)
+	h.Write([]byte(kind))
+	for _, c := range credential {
+		h.Write([]byte{0})
+		h.Write([]byte(c))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
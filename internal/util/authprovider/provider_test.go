@@ -0,0 +1,152 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockProvider struct {
+	identities map[string]*Identity
+	calls      int
+}
+
+func (p *mockProvider) Name() string {
+	return "mock"
+}
+
+func (p *mockProvider) AuthenticateToken(ctx context.Context, token string) (*Identity, error) {
+	p.calls++
+	if identity, ok := p.identities[token]; ok {
+		return identity, nil
+	}
+	return nil, ErrNotAuthenticated
+}
+
+func (p *mockProvider) AuthenticatePassword(ctx context.Context, username, password string) (*Identity, error) {
+	return p.AuthenticateToken(ctx, username+":"+password)
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no provider", func(t *testing.T) {
+		var m *Manager
+		assert.False(t, m.Enabled())
+		_, err := m.AuthenticateToken(ctx, "token")
+		assert.ErrorIs(t, err, ErrNoProvider)
+		assert.Empty(t, m.Roles("user"))
+
+		m = NewManager(time.Minute, nil, nil, nil)
+		assert.False(t, m.Enabled())
+		_, err = m.AuthenticatePassword(ctx, "user", "password")
+		assert.ErrorIs(t, err, ErrNoProvider)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		provider := &mockProvider{identities: map[string]*Identity{
+			"token1": {Username: "user1", Groups: []string{"admins", "others"}},
+		}}
+		m := NewManager(time.Minute, map[string]string{"admins": "admin, reader", "readers": "reader"}, []TokenProvider{provider}, nil)
+		assert.True(t, m.Enabled())
+
+		identity, err := m.AuthenticateToken(ctx, "token1")
+		assert.NoError(t, err)
+		assert.Equal(t, "user1", identity.Username)
+		assert.Equal(t, "mock", identity.Provider)
+		assert.Equal(t, "mock/user1", identity.Principal)
+		assert.ElementsMatch(t, []string{"admin", "reader"}, m.Roles("mock/user1"))
+		assert.Empty(t, m.Roles("user1"))
+
+		// cached
+		_, err = m.AuthenticateToken(ctx, "token1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, provider.calls)
+
+		_, err = m.AuthenticateToken(ctx, "token2")
+		assert.ErrorIs(t, err, ErrNotAuthenticated)
+		assert.Empty(t, m.Roles("mock/user2"))
+
+		// revoked
+		m.Invalidate("mock/user1")
+		assert.Empty(t, m.Roles("mock/user1"))
+		_, err = m.AuthenticateToken(ctx, "token1")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, provider.calls)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		provider := &mockProvider{identities: map[string]*Identity{
+			"token1": {Username: "user1", Groups: []string{"admins"}, ExpireAt: time.Now().Add(-time.Second)},
+		}}
+		m := NewManager(time.Minute, map[string]string{"admins": "admin"}, []TokenProvider{provider}, nil)
+		_, err := m.AuthenticateToken(ctx, "token1")
+		assert.NoError(t, err)
+		assert.Empty(t, m.Roles("mock/user1"))
+		_, err = m.AuthenticateToken(ctx, "token1")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, provider.calls)
+	})
+
+	t.Run("password", func(t *testing.T) {
+		provider1 := &mockProvider{}
+		provider2 := &mockProvider{identities: map[string]*Identity{
+			"user1:password1": {Username: "user1", Groups: []string{"readers"}},
+		}}
+		m := NewManager(time.Minute, map[string]string{"readers": "reader"}, nil, []PasswordProvider{provider1, provider2})
+		identity, err := m.AuthenticatePassword(ctx, "user1", "password1")
+		assert.NoError(t, err)
+		assert.Equal(t, "user1", identity.Username)
+		// qualified by the provider, the built-in user of the same name does not gain the roles
+		assert.Equal(t, "mock/user1", identity.Principal)
+		assert.Equal(t, []string{"reader"}, m.Roles("mock/user1"))
+		assert.Empty(t, m.Roles("user1"))
+
+		_, err = m.AuthenticatePassword(ctx, "user1", "password2")
+		assert.True(t, errors.Is(err, ErrNotAuthenticated))
+		// the names of the token users are rejected
+		_, err = m.AuthenticatePassword(ctx, "mock/user1", "password1")
+		assert.True(t, errors.Is(err, ErrNotAuthenticated))
+	})
+
+	t.Run("group dn", func(t *testing.T) {
+		provider := &mockProvider{identities: map[string]*Identity{
+			"user1:password1": {Username: "user1", Groups: []string{NormalizeDN("CN=Admins, OU=Groups,DC=milvus")}},
+		}}
+		m := NewManager(time.Minute, map[string]string{"cn=admins,ou=groups,dc=milvus": "admin", "admins": "reader"},
+			nil, []PasswordProvider{provider})
+		_, err := m.AuthenticatePassword(ctx, "user1", "password1")
+		assert.NoError(t, err)
+		// the dn is matched normalized, not by the name of the group
+		assert.Equal(t, []string{"admin"}, m.Roles("mock/user1"))
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	m1 := NewManager(time.Minute, nil, nil, nil)
+	m2 := NewManager(time.Minute, nil, nil, nil)
+	assert.Equal(t, m1.cacheKey("password", "user", "password"), m1.cacheKey("password", "user", "password"))
+	assert.NotEqual(t, m1.cacheKey("password", "user", "password"), m1.cacheKey("password", "user", "password2"))
+	assert.NotEqual(t, m1.cacheKey("password", "user", "password"), m1.cacheKey("token", "user", "password"))
+	// keyed by a random secret, the keys of the same credential differ between managers
+	assert.NotEqual(t, m1.cacheKey("password", "user", "password"), m2.cacheKey("password", "user", "password"))
+}
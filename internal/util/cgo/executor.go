@@ -9,6 +9,9 @@ import "C"
 
 import (
 	"math"
+	"strconv"
+	"strings"
+	"sync"
 
 	"go.uber.org/zap"
 
@@ -33,4 +36,75 @@ func initExecutor() {
 	}
 	pt.Watch(pt.QueryNodeCfg.MaxReadConcurrency.Key, config.NewHandler("cgo."+pt.QueryNodeCfg.MaxReadConcurrency.Key, resetThreadNum))
 	pt.Watch(pt.QueryNodeCfg.CGOPoolSizeRatio.Key, config.NewHandler("cgo."+pt.QueryNodeCfg.CGOPoolSizeRatio.Key, resetThreadNum))
+
+	resetIsolatedExecutors()
+	pt.Watch(pt.QueryNodeCfg.IsolatedCollectionThreadNum.Key, config.NewHandler("cgo."+pt.QueryNodeCfg.IsolatedCollectionThreadNum.Key, func(evt *config.Event) {
+		if evt.HasUpdated {
+			resetIsolatedExecutors()
+		}
+	}))
+}
+
+var (
+	isolatedMu sync.Mutex
+	// isolatedThreadNum is the thread num of the dedicated executors applied, by collection id
+	isolatedThreadNum = make(map[int64]int)
+)
+
+// resetIsolatedExecutors applies queryNode.segcore.isolatedCollectionThreadNum to the executors of the collections,
+// the collections removed go back to the global executor.
+func resetIsolatedExecutors() {
+	threadNums := parseIsolatedCollectionThreadNum(paramtable.Get().QueryNodeCfg.IsolatedCollectionThreadNum.GetAsStrings())
+
+	isolatedMu.Lock()
+	defer isolatedMu.Unlock()
+	for collectionID := range isolatedThreadNum {
+		if _, ok := threadNums[collectionID]; !ok {
+			log.Info("reset cgo thread num of collection to the global executor", zap.Int64("collectionID", collectionID))
+			C.executor_set_collection_thread_num(C.int64_t(collectionID), 0)
+		}
+	}
+	for collectionID, threadNum := range threadNums {
+		if isolatedThreadNum[collectionID] != threadNum {
+			log.Info("reset cgo thread num of collection", zap.Int64("collectionID", collectionID), zap.Int("thread_num", threadNum))
+			C.executor_set_collection_thread_num(C.int64_t(collectionID), C.int(threadNum))
+		}
+	}
+	isolatedThreadNum = threadNums
+}
+
+// IsolatedCollectionThreadNum returns the thread num of the dedicated executor of the collection,
+// 0 if the collection runs on the global executor.
+func IsolatedCollectionThreadNum(collectionID int64) int {
+	isolatedMu.Lock()
+	defer isolatedMu.Unlock()
+	return isolatedThreadNum[collectionID]
+}
+
+// parseIsolatedCollectionThreadNum parses the <collection id>:<thread num> values, the invalid ones are skipped.
+func parseIsolatedCollectionThreadNum(values []string) map[int64]int {
+	threadNums := make(map[int64]int)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		idStr, numStr, ok := strings.Cut(value, ":")
+		if !ok {
+			log.Warn("skip the invalid isolated collection thread num", zap.String("value", value))
+			continue
+		}
+		collectionID, err := strconv.ParseInt(strings.TrimSpace(idStr), 10, 64)
+		if err != nil {
+			log.Warn("skip the invalid isolated collection thread num", zap.String("value", value), zap.Error(err))
+			continue
+		}
+		threadNum, err := strconv.Atoi(strings.TrimSpace(numStr))
+		if err != nil || threadNum <= 0 {
+			log.Warn("skip the invalid isolated collection thread num", zap.String("value", value), zap.Error(err))
+			continue
+		}
+		threadNums[collectionID] = threadNum
+	}
+	return threadNums
 }
@@ -0,0 +1,13 @@
+package cgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIsolatedCollectionThreadNum(t *testing.T) {
+	assert.Empty(t, parseIsolatedCollectionThreadNum(nil))
+	assert.Equal(t, map[int64]int{100: 8, 101: 4}, parseIsolatedCollectionThreadNum([]string{"100:8", " 101 : 4 ", ""}))
+	assert.Equal(t, map[int64]int{102: 2}, parseIsolatedCollectionThreadNum([]string{"abc:1", "100", "101:0", "103:x", "102:2"}))
+}
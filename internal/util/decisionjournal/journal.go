@@ -0,0 +1,311 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decisionjournal records the significant decisions of the coordinators,
+// like segment assignments, balance moves, compaction triggers and handoffs,
+// into a bounded journal persisted in the meta storage,
+// so the reason a segment moved can be reconstructed after the fact.
+package decisionjournal
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const journalPrefix = "decision-journal"
+
+// Event types of the decisions.
+const (
+	TypeSegmentAssign     = "segment_assign"
+	TypeSegmentRelease    = "segment_release"
+	TypeChannelAssign     = "channel_assign"
+	TypeChannelRelease    = "channel_release"
+	TypeBalanceMove       = "balance_move"
+	TypeCompactionTrigger = "compaction_trigger"
+	TypeHandoff           = "handoff"
+)
+
+// Event is a decision made by a coordinator.
+type Event struct {
+	ID           int64             `json:"id"`
+	Time         time.Time         `json:"time"`
+	Component    string            `json:"component"`
+	Type         string            `json:"type"`
+	CollectionID int64             `json:"collection_id,omitempty"`
+	PartitionID  int64             `json:"partition_id,omitempty"`
+	Channel      string            `json:"channel,omitempty"`
+	SegmentIDs   []int64           `json:"segment_ids,omitempty"`
+	From         int64             `json:"from,omitempty"`
+	To           int64             `json:"to,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+	Detail       map[string]string `json:"detail,omitempty"`
+}
+
+// Journal is a bounded journal of the decisions of a component,
+// the oldest events are evicted once the capacity exceeded.
+// The events are persisted by a background loop,
+// so the ones recorded within the last flush interval may be lost on crash.
+type Journal struct {
+	component string
+	kv        kv.TxnKV
+	capacity  int
+
+	mu     sync.RWMutex
+	events []*Event
+	nextID int64
+	// ids in [persistedFrom, persistedTo) are in the meta storage.
+	persistedFrom int64
+	persistedTo   int64
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewJournal creates a journal of the component and loads its events persisted before.
+func NewJournal(ctx context.Context, component string, txnKV kv.TxnKV, capacity int) (*Journal, error) {
+	j := &Journal{
+		component: component,
+		kv:        txnKV,
+		capacity:  capacity,
+		closeCh:   make(chan struct{}),
+	}
+	_, values, err := txnKV.LoadWithPrefix(ctx, j.prefix())
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(values))
+	for _, value := range values {
+		event := &Event{}
+		if err := json.Unmarshal([]byte(value), event); err != nil {
+			log.Ctx(ctx).Warn("skip the malformed decision event", zap.String("component", component), zap.Error(err))
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, k int) bool { return events[i].ID < events[k].ID })
+	if len(events) > 0 {
+		j.persistedFrom = events[0].ID
+		j.persistedTo = events[len(events)-1].ID + 1
+		j.nextID = j.persistedTo
+	}
+	if len(events) > capacity {
+		// the evicted ones are removed on the next flush.
+		events = events[len(events)-capacity:]
+	}
+	j.events = events
+	return j, nil
+}
+
+func (j *Journal) prefix() string {
+	return path.Join(journalPrefix, j.component)
+}
+
+func (j *Journal) key(id int64) string {
+	return path.Join(j.prefix(), fmt.Sprintf("%020d", id))
+}
+
+// Record appends the event into the journal,
+// the event should not be modified after recorded.
+func (j *Journal) Record(event *Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	event.ID = j.nextID
+	j.nextID++
+	event.Component = j.component
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	j.events = append(j.events, event)
+	if len(j.events) > j.capacity {
+		j.events[0] = nil
+		j.events = j.events[1:]
+	}
+}
+
+// Filter filters the events listed, the zero fields match all.
+type Filter struct {
+	CollectionID int64
+	SegmentID    int64
+	Channel      string
+	NodeID       int64
+	Type         string
+	Since        time.Time
+	Until        time.Time
+	// Limit keeps the latest matched ones if positive.
+	Limit int
+}
+
+func (f *Filter) match(event *Event) bool {
+	if f.CollectionID != 0 && event.CollectionID != f.CollectionID {
+		return false
+	}
+	if f.SegmentID != 0 && !containsID(event.SegmentIDs, f.SegmentID) {
+		return false
+	}
+	if f.Channel != "" && event.Channel != f.Channel {
+		return false
+	}
+	if f.NodeID != 0 && event.From != f.NodeID && event.To != f.NodeID {
+		return false
+	}
+	if f.Type != "" && event.Type != f.Type {
+		return false
+	}
+	if !f.Since.IsZero() && event.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && event.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the events matched in the order recorded.
+func (j *Journal) List(filter Filter) []*Event {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	ret := make([]*Event, 0)
+	for i := len(j.events) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(ret) >= filter.Limit {
+			break
+		}
+		if filter.match(j.events[i]) {
+			ret = append(ret, j.events[i])
+		}
+	}
+	for i, k := 0, len(ret)-1; i < k; i, k = i+1, k-1 {
+		ret[i], ret[k] = ret[k], ret[i]
+	}
+	return ret
+}
+
+// Flush persists the events recorded and removes the evicted ones from the meta storage.
+func (j *Journal) Flush(ctx context.Context) error {
+	j.mu.RLock()
+	first := j.nextID
+	if len(j.events) > 0 {
+		first = j.events[0].ID
+	}
+	persistedFrom, persistedTo := j.persistedFrom, j.persistedTo
+	saves := make([]*Event, 0)
+	for _, event := range j.events {
+		if event.ID >= persistedTo {
+			saves = append(saves, event)
+		}
+	}
+	j.mu.RUnlock()
+
+	// remove the evicted ones first, so the journal in the meta storage keeps bounded.
+	for persistedFrom < min(first, persistedTo) {
+		end := min(first, persistedTo, persistedFrom+util.MaxEtcdTxnNum/2)
+		removals := make([]string, 0, end-persistedFrom)
+		for id := persistedFrom; id < end; id++ {
+			removals = append(removals, j.key(id))
+		}
+		if err := j.kv.MultiRemove(ctx, removals); err != nil {
+			return err
+		}
+		persistedFrom = end
+		j.setPersisted(persistedFrom, persistedTo)
+	}
+	if persistedFrom >= persistedTo {
+		persistedFrom, persistedTo = first, first
+	}
+
+	for len(saves) > 0 {
+		batch := saves[:min(len(saves), util.MaxEtcdTxnNum/2)]
+		kvs := make(map[string]string, len(batch))
+		for _, event := range batch {
+			bs, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			kvs[j.key(event.ID)] = string(bs)
+		}
+		if err := j.kv.MultiSave(ctx, kvs); err != nil {
+			return err
+		}
+		saves = saves[len(batch):]
+		persistedTo = batch[len(batch)-1].ID + 1
+		j.setPersisted(persistedFrom, persistedTo)
+	}
+	return nil
+}
+
+func (j *Journal) setPersisted(from, to int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.persistedFrom, j.persistedTo = from, to
+}
+
+// Start starts the background loop persisting the journal.
+func (j *Journal) Start() {
+	j.wg.Add(1)
+	go j.flushLoop()
+}
+
+func (j *Journal) flushLoop() {
+	defer j.wg.Done()
+	interval := paramtable.Get().CommonCfg.DecisionJournalFlushInterval.GetAsDurationByParse()
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-j.closeCh:
+			return
+		case <-timer.C:
+			if err := j.Flush(context.Background()); err != nil {
+				log.Warn("failed to persist the decision journal", zap.String("component", j.component), zap.Error(err))
+			}
+			timer.Reset(paramtable.Get().CommonCfg.DecisionJournalFlushInterval.GetAsDurationByParse())
+		}
+	}
+}
+
+// Close stops the background loop and persists the events left.
+func (j *Journal) Close() {
+	j.closeOnce.Do(func() {
+		close(j.closeCh)
+		j.wg.Wait()
+		if err := j.Flush(context.Background()); err != nil {
+			log.Warn("failed to persist the decision journal on close", zap.String("component", j.component), zap.Error(err))
+		}
+	})
+}
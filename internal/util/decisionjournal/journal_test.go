@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisionjournal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestJournal(t *testing.T) {
+	ctx := context.Background()
+	kv := memkv.NewMemoryKV()
+
+	j, err := NewJournal(ctx, "querycoord", kv, 3)
+	require.NoError(t, err)
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		j.Record(&Event{
+			Time:         now.Add(time.Duration(i) * time.Minute),
+			Type:         TypeBalanceMove,
+			CollectionID: int64(i % 2),
+			SegmentIDs:   []int64{int64(i)},
+			From:         1,
+			To:           2,
+		})
+	}
+
+	// the oldest one is evicted before persisted.
+	events := j.List(Filter{})
+	assert.Len(t, events, 3)
+	assert.EqualValues(t, 1, events[0].ID)
+	assert.Equal(t, "querycoord", events[0].Component)
+
+	assert.Len(t, j.List(Filter{CollectionID: 1}), 2)
+	assert.Len(t, j.List(Filter{SegmentID: 2}), 1)
+	assert.Len(t, j.List(Filter{NodeID: 2}), 3)
+	assert.Len(t, j.List(Filter{NodeID: 3}), 0)
+	assert.Len(t, j.List(Filter{Type: TypeHandoff}), 0)
+	assert.Len(t, j.List(Filter{Since: now.Add(2 * time.Minute)}), 2)
+	assert.Len(t, j.List(Filter{Until: now.Add(2 * time.Minute)}), 2)
+	events = j.List(Filter{Limit: 2})
+	assert.Len(t, events, 2)
+	assert.EqualValues(t, 2, events[0].ID)
+	assert.EqualValues(t, 3, events[1].ID)
+
+	require.NoError(t, j.Flush(ctx))
+	keys, _, err := kv.LoadWithPrefix(ctx, journalPrefix)
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+
+	// the evicted ones are removed from the meta storage.
+	j.Record(&Event{Type: TypeHandoff})
+	j.Record(&Event{Type: TypeHandoff})
+	require.NoError(t, j.Flush(ctx))
+	keys, _, err = kv.LoadWithPrefix(ctx, journalPrefix)
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+
+	// the journal is recovered with a smaller capacity.
+	j, err = NewJournal(ctx, "querycoord", kv, 2)
+	require.NoError(t, err)
+	events = j.List(Filter{})
+	assert.Len(t, events, 2)
+	assert.EqualValues(t, 4, events[0].ID)
+	assert.Equal(t, TypeHandoff, events[1].Type)
+	j.Record(&Event{Type: TypeSegmentAssign})
+	assert.EqualValues(t, 6, j.List(Filter{Type: TypeSegmentAssign})[0].ID)
+	require.NoError(t, j.Flush(ctx))
+	keys, _, err = kv.LoadWithPrefix(ctx, journalPrefix)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestRegistry(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	kv := memkv.NewMemoryKV()
+
+	Record("datacoord", &Event{Type: TypeCompactionTrigger})
+	_, err := ListJSON("datacoord", gjson.Result{})
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+
+	require.NoError(t, Open(ctx, "datacoord", kv))
+	Record("datacoord", &Event{Type: TypeCompactionTrigger, CollectionID: 1, SegmentIDs: []int64{1, 2}})
+	Record("datacoord", &Event{Type: TypeSegmentAssign, CollectionID: 1, SegmentIDs: []int64{3}})
+
+	ret, err := ListJSON("datacoord", gjson.Parse(`{"collection_id": "1", "segment_id": "2"}`))
+	require.NoError(t, err)
+	assert.Equal(t, TypeCompactionTrigger, gjson.Get(ret, "0.type").String())
+	assert.EqualValues(t, 1, gjson.Get(ret, "#").Int())
+
+	_, err = ListJSON("datacoord", gjson.Parse(`{"since": "yesterday"}`))
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the events left are persisted on close.
+	Close("datacoord")
+	assert.Nil(t, Get("datacoord"))
+	keys, _, err := kv.LoadWithPrefix(ctx, journalPrefix)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
@@ -0,0 +1,137 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisionjournal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// Request parameters of the journal query.
+const (
+	ParamCollectionID = "collection_id"
+	ParamSegmentID    = "segment_id"
+	ParamChannel      = "channel"
+	ParamNodeID       = "node_id"
+	ParamType         = "type"
+	ParamSince        = "since"
+	ParamUntil        = "until"
+	ParamLimit        = "limit"
+)
+
+var (
+	journalsMu sync.RWMutex
+	journals   = make(map[string]*Journal)
+)
+
+// Open opens the journal of the component and registers it,
+// nothing is recorded for the component if the journal is disabled.
+// It shall be called once the coordinator becomes active, not in standby.
+func Open(ctx context.Context, component string, txnKV kv.TxnKV) error {
+	capacity := paramtable.Get().CommonCfg.DecisionJournalCapacity.GetAsInt()
+	if capacity <= 0 {
+		return nil
+	}
+	j, err := NewJournal(ctx, component, txnKV, capacity)
+	if err != nil {
+		return err
+	}
+	j.Start()
+
+	journalsMu.Lock()
+	defer journalsMu.Unlock()
+	if old, ok := journals[component]; ok {
+		old.Close()
+	}
+	journals[component] = j
+	return nil
+}
+
+// Close closes the journal of the component and unregisters it.
+func Close(component string) {
+	journalsMu.Lock()
+	j, ok := journals[component]
+	delete(journals, component)
+	journalsMu.Unlock()
+	if ok {
+		j.Close()
+	}
+}
+
+// Get returns the journal of the component, nil if not opened.
+func Get(component string) *Journal {
+	journalsMu.RLock()
+	defer journalsMu.RUnlock()
+	return journals[component]
+}
+
+// Record records the event into the journal of the component if opened.
+func Record(component string, event *Event) {
+	if j := Get(component); j != nil {
+		j.Record(event)
+	}
+}
+
+// ListJSON returns the events of the component matched by the request parameters in json.
+func ListJSON(component string, jsonReq gjson.Result) (string, error) {
+	j := Get(component)
+	if j == nil {
+		return "", merr.WrapErrServiceUnavailable("decision journal disabled or the coordinator is not active")
+	}
+	filter, err := ParseFilter(jsonReq)
+	if err != nil {
+		return "", err
+	}
+	bs, err := json.Marshal(j.List(filter))
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// ParseFilter parses the filter from the request parameters,
+// the time ones are in RFC3339.
+func ParseFilter(jsonReq gjson.Result) (Filter, error) {
+	filter := Filter{
+		CollectionID: jsonReq.Get(ParamCollectionID).Int(),
+		SegmentID:    jsonReq.Get(ParamSegmentID).Int(),
+		Channel:      jsonReq.Get(ParamChannel).String(),
+		NodeID:       jsonReq.Get(ParamNodeID).Int(),
+		Type:         jsonReq.Get(ParamType).String(),
+		Limit:        int(jsonReq.Get(ParamLimit).Int()),
+	}
+	for key, t := range map[string]*time.Time{ParamSince: &filter.Since, ParamUntil: &filter.Until} {
+		v := jsonReq.Get(key)
+		if !v.Exists() {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v.String())
+		if err != nil {
+			return Filter{}, merr.WrapErrParameterInvalidMsg("invalid %s: %s", key, err.Error())
+		}
+		*t = parsed
+	}
+	return filter, nil
+}
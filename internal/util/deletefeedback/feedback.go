@@ -0,0 +1,56 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deletefeedback carries the deleted ratios of the sealed segments observed by the querynodes to datacoord,
+// so the segments with too many deleted rows are compacted without waiting for their deltalogs.
+// The reports are carried by nodefeedback.
+package deletefeedback
+
+import (
+	"github.com/milvus-io/milvus/internal/util/nodefeedback"
+	"github.com/milvus-io/milvus/pkg/kv"
+)
+
+const feedbackPrefix = "delete-feedback"
+
+// SegmentDeletes is the deleted rows of a sealed segment observed by a querynode.
+type SegmentDeletes struct {
+	SegmentID    int64 `json:"segment_id"`
+	CollectionID int64 `json:"collection_id"`
+	// RowCount is the number of the rows inserted, including the deleted ones.
+	RowCount     int64 `json:"row_count"`
+	DeletedCount int64 `json:"deleted_count"`
+}
+
+// Ratio returns the fraction of the rows deleted.
+func (s *SegmentDeletes) Ratio() float64 {
+	if s.RowCount <= 0 {
+		return 0
+	}
+	return float64(s.DeletedCount) / float64(s.RowCount)
+}
+
+type (
+	// Report is the deleted rows of the segments reported by a querynode.
+	Report = nodefeedback.Report[*SegmentDeletes]
+	// Store is the store of the delete reports.
+	Store = nodefeedback.Store[*SegmentDeletes]
+)
+
+// NewStore returns the store of the delete reports.
+func NewStore(metaKV kv.BaseKV) *Store {
+	return nodefeedback.NewStore[*SegmentDeletes](metaKV, feedbackPrefix)
+}
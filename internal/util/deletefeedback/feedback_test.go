@@ -0,0 +1,43 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletefeedback
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+)
+
+func TestFeedback(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(memkv.NewMemoryKV())
+
+	require.NoError(t, store.Save(ctx, &Report{
+		NodeID: 1,
+		Seq:    1,
+		Items:  []*SegmentDeletes{{SegmentID: 100, CollectionID: 10, RowCount: 10, DeletedCount: 3}},
+	}))
+	reports, err := store.LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.InDelta(t, 0.3, reports[0].Items[0].Ratio(), 1e-9)
+	assert.Zero(t, (&SegmentDeletes{}).Ratio())
+}
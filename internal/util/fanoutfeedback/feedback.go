@@ -0,0 +1,56 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fanoutfeedback carries the search fan-out of the shards observed by the querynodes to datacoord,
+// so the small segments of the shards that searches fan out to too many segments are merged.
+// The reports are carried by nodefeedback.
+package fanoutfeedback
+
+import (
+	"github.com/milvus-io/milvus/internal/util/nodefeedback"
+	"github.com/milvus-io/milvus/pkg/kv"
+)
+
+const feedbackPrefix = "fanout-feedback"
+
+// ShardFanOut is the searches served by a shard delegator within a report interval,
+// and the sealed segments they fanned out to after the segment pruning.
+type ShardFanOut struct {
+	CollectionID int64  `json:"collection_id"`
+	Channel      string `json:"channel"`
+	Searches     int64  `json:"searches"`
+	Segments     int64  `json:"segments"`
+}
+
+// Average returns the average sealed segments a search fanned out to.
+func (s *ShardFanOut) Average() float64 {
+	if s.Searches <= 0 {
+		return 0
+	}
+	return float64(s.Segments) / float64(s.Searches)
+}
+
+type (
+	// Report is the search fan-out of the shards reported by a querynode.
+	Report = nodefeedback.Report[*ShardFanOut]
+	// Store is the store of the fan-out reports.
+	Store = nodefeedback.Store[*ShardFanOut]
+)
+
+// NewStore returns the store of the fan-out reports.
+func NewStore(metaKV kv.BaseKV) *Store {
+	return nodefeedback.NewStore[*ShardFanOut](metaKV, feedbackPrefix)
+}
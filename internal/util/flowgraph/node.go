@@ -25,6 +25,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
 )
 
@@ -80,6 +82,9 @@ func (nodeCtxManager *nodeCtxManager) Start() {
 	// limit the goroutines in other node to prevent huge goroutines numbers
 	nodeCtxManager.closeWg.Add(1)
 	curNode := nodeCtxManager.inputNodeCtx
+	for node := curNode; node != nil; node = node.downstream {
+		node.metrics = metrics.NewFlowGraphNodeMetrics(paramtable.GetNodeID(), paramtable.GetRole(), node.node.Name())
+	}
 	// tt checker start
 	if enableTtChecker {
 		manager := timerecord.GetCheckerManger("data-fgNode", nodeCtxTtInterval, func(list []string) {
@@ -94,6 +99,12 @@ func (nodeCtxManager *nodeCtxManager) Start() {
 	go nodeCtxManager.workNodeStart()
 }
 
+// workNodeStart drives the whole flowgraph in one goroutine: it pulls a message from the input node,
+// then runs it through the downstream nodes one by one before pulling the next one.
+// So each node queues at most one message, and a slow node stops the input node from pulling,
+// the messages then wait in the bounded buffer of the msg dispatcher, which drops the lagging target on timeout.
+// The occupancy of that buffer and the time blocked on it are recorded by the msg dispatcher,
+// only the operate latency is recorded per node.
 func (nodeCtxManager *nodeCtxManager) workNodeStart() {
 	defer nodeCtxManager.closeWg.Done()
 	for {
@@ -127,7 +138,9 @@ func (nodeCtxManager *nodeCtxManager) workNodeStart() {
 					nodeCtxManager.lastAccessTime.Store(time.Now())
 				}
 
+				operateStart := time.Now()
 				output = n.Operate(input)
+				curNode.metrics.ObserveOperate(operateStart)
 				curNode.blockMutex.RUnlock()
 				// the output decide whether the node should be closed.
 				if isCloseMsg(output) {
@@ -138,7 +151,8 @@ func (nodeCtxManager *nodeCtxManager) workNodeStart() {
 						close(curNode.inputChannel)
 					}
 				}
-				// deliver to all following flow graph node.
+				// deliver to all following flow graph node,
+				// never blocks as the downstream node is run by this goroutine right after.
 				if curNode.downstream != nil {
 					curNode.downstream.inputChannel <- output
 				}
@@ -163,6 +177,7 @@ type nodeCtx struct {
 	inputChannel chan []Msg
 	downstream   *nodeCtx
 	checker      *timerecord.Checker
+	metrics      *metrics.FlowGraphNodeMetrics
 
 	blockMutex sync.RWMutex
 }
@@ -201,6 +216,7 @@ func (nodeCtx *nodeCtx) Close() {
 			if nodeCtx.checker != nil {
 				nodeCtx.checker.Close()
 			}
+			nodeCtx.metrics.Cleanup()
 			log.Debug("flow graph node closed", zap.String("nodeName", nodeCtx.node.Name()))
 			nodeCtx = nodeCtx.downstream
 		}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -38,7 +39,9 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/config"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/tracer"
 	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/crypto"
@@ -55,11 +58,62 @@ type GrpcComponent interface {
 	GetComponentStates(ctx context.Context, in *milvuspb.GetComponentStatesRequest, opts ...grpc.CallOption) (*milvuspb.ComponentStates, error)
 }
 
+// clientConfigVersion is bumped when the hot reloadable grpc client configs change,
+// clients built with an older version are rebuilt lazily on the next call.
+var (
+	clientConfigVersion  atomic.Int64
+	watchedClientConfigs sync.Map
+)
+
+// watchClientConfig registers the watcher of the hot reloadable configs once for each key.
+func watchClientConfig(cfg *paramtable.GrpcClientConfig) {
+	for _, key := range []string{cfg.KeepAliveTime.Key, cfg.KeepAliveTimeout.Key, cfg.ConnectionPoolSize.Key} {
+		if _, loaded := watchedClientConfigs.LoadOrStore(key, struct{}{}); loaded {
+			continue
+		}
+		paramtable.Get().Watch(key, config.NewHandler("grpcclient."+key, func(event *config.Event) {
+			if event.HasUpdated {
+				log.Info("grpc client config changed, connections will be rebuilt",
+					zap.String("key", event.Key), zap.String("value", event.Value))
+				clientConfigVersion.Inc()
+			}
+		}))
+	}
+}
+
+type pooledConn[T GrpcComponent] struct {
+	client T
+	conn   *grpc.ClientConn
+}
+
 // clientConnWrapper is the wrapper for client & conn.
 type clientConnWrapper[T GrpcComponent] struct {
 	client T
 	conn   *grpc.ClientConn
-	mut    sync.RWMutex
+	// pooled holds the extra connections when the connection pool size is larger than 1,
+	// requests are dispatched to client and pooled in round robin.
+	pooled        []pooledConn[T]
+	next          atomic.Uint64
+	configVersion int64
+	mut           sync.RWMutex
+}
+
+func (c *clientConnWrapper[T]) getClient() T {
+	if len(c.pooled) == 0 {
+		return c.client
+	}
+	idx := c.next.Inc() % uint64(len(c.pooled)+1)
+	if idx == 0 {
+		return c.client
+	}
+	return c.pooled[idx-1].client
+}
+
+func (c *clientConnWrapper[T]) connCount() int {
+	if c.conn == nil {
+		return 0
+	}
+	return len(c.pooled) + 1
 }
 
 func (c *clientConnWrapper[T]) Pin() {
@@ -74,7 +128,11 @@ func (c *clientConnWrapper[T]) Close() error {
 	if c.conn != nil {
 		c.mut.Lock()
 		defer c.mut.Unlock()
-		return c.conn.Close()
+		err := c.conn.Close()
+		for _, pooled := range c.pooled {
+			err = errors.CombineErrors(err, pooled.conn.Close())
+		}
+		return err
 	}
 	return nil
 }
@@ -113,16 +171,21 @@ type ClientBase[T interface {
 	// conn                   *grpc.ClientConn
 	grpcClientMtx sync.RWMutex
 	role          string
-	isNode        bool // pre-calculated is node flag
+	isNode        bool   // pre-calculated is node flag
+	metricRole    string // role without node id, used as metrics label
+
+	// config is used to reload the hot reloadable configs on reconnecting, nil if not built from config
+	config *paramtable.GrpcClientConfig
 
 	ClientMaxSendSize      int
 	ClientMaxRecvSize      int
 	CompressionEnabled     bool
 	RetryServiceNameConfig string
 
-	DialTimeout      time.Duration
-	KeepAliveTime    time.Duration
-	KeepAliveTimeout time.Duration
+	DialTimeout        time.Duration
+	KeepAliveTime      time.Duration
+	KeepAliveTimeout   time.Duration
+	ConnectionPoolSize int
 
 	MaxAttempts    int
 	InitialBackoff float64
@@ -146,12 +209,15 @@ func NewClientBase[T interface {
 	GetComponentStates(ctx context.Context, in *milvuspb.GetComponentStatesRequest, opts ...grpc.CallOption) (*milvuspb.ComponentStates, error)
 }](config *paramtable.GrpcClientConfig, serviceName string,
 ) *ClientBase[T] {
+	watchClientConfig(config)
 	return &ClientBase[T]{
+		config:                  config,
 		ClientMaxRecvSize:       config.ClientMaxRecvSize.GetAsInt(),
 		ClientMaxSendSize:       config.ClientMaxSendSize.GetAsInt(),
 		DialTimeout:             config.DialTimeout.GetAsDuration(time.Millisecond),
 		KeepAliveTime:           config.KeepAliveTime.GetAsDuration(time.Millisecond),
 		KeepAliveTimeout:        config.KeepAliveTimeout.GetAsDuration(time.Millisecond),
+		ConnectionPoolSize:      config.ConnectionPoolSize.GetAsInt(),
 		RetryServiceNameConfig:  serviceName,
 		MaxAttempts:             config.MaxAttempts.GetAsInt(),
 		InitialBackoff:          config.InitialBackoff.GetAsFloat(),
@@ -166,6 +232,7 @@ func NewClientBase[T interface {
 // SetRole sets role of client
 func (c *ClientBase[T]) SetRole(role string) {
 	c.role = role
+	c.metricRole = strings.SplitN(role, "-", 2)[0]
 	if strings.HasPrefix(role, typeutil.DataNodeRole) ||
 		strings.HasPrefix(role, typeutil.IndexNodeRole) ||
 		strings.HasPrefix(role, typeutil.QueryNodeRole) ||
@@ -210,7 +277,7 @@ func (c *ClientBase[T]) SetNewGrpcClientFunc(f func(cc *grpc.ClientConn) T) {
 func (c *ClientBase[T]) GetGrpcClient(ctx context.Context) (*clientConnWrapper[T], error) {
 	c.grpcClientMtx.RLock()
 
-	if !generic.IsZero(c.grpcClient) {
+	if !generic.IsZero(c.grpcClient) && !c.isStale(c.grpcClient) {
 		defer c.grpcClientMtx.RUnlock()
 		return c.grpcClient, nil
 	}
@@ -220,7 +287,11 @@ func (c *ClientBase[T]) GetGrpcClient(ctx context.Context) (*clientConnWrapper[T
 	defer c.grpcClientMtx.Unlock()
 
 	if !generic.IsZero(c.grpcClient) {
-		return c.grpcClient, nil
+		if !c.isStale(c.grpcClient) {
+			return c.grpcClient, nil
+		}
+		log.Ctx(ctx).Info("grpc client config changed, rebuild connection", zap.String("role", c.role))
+		c.closeClientLocked("config_changed")
 	}
 
 	err := c.connect(ctx)
@@ -246,14 +317,31 @@ func (c *ClientBase[T]) resetConnection(wrapper *clientConnWrapper[T], forceRese
 	if c.grpcClient != wrapper {
 		return
 	}
+	reason := "error"
+	if forceReset {
+		reason = "force"
+	}
+	c.closeClientLocked(reason)
+	c.lastReset.Store(time.Now())
+}
+
+// isStale returns whether the client was built before the latest hot reloadable configs change.
+func (c *ClientBase[T]) isStale(wrapper *clientConnWrapper[T]) bool {
+	return c.config != nil && wrapper.configVersion < clientConfigVersion.Load()
+}
+
+// closeClientLocked closes the current client in background, grpcClientMtx must be held.
+func (c *ClientBase[T]) closeClientLocked(reason string) {
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.GrpcClientConnections.WithLabelValues(nodeID, c.metricRole).Sub(float64(c.grpcClient.connCount()))
+	metrics.GrpcClientConnectionResets.WithLabelValues(nodeID, c.metricRole, reason).Inc()
 	// wrapper close may block waiting pending request finish
 	go func(w *clientConnWrapper[T], addr string) {
 		w.Close()
-		log.Info("previous client closed", zap.String("role", c.role), zap.String("addr", c.addr.Load()))
+		log.Info("previous client closed", zap.String("role", c.role), zap.String("addr", addr))
 	}(c.grpcClient, c.addr.Load())
 	c.addr.Store("")
 	c.grpcClient = nil
-	c.lastReset.Store(time.Now())
 }
 
 func (c *ClientBase[T]) connect(ctx context.Context) error {
@@ -263,111 +351,96 @@ func (c *ClientBase[T]) connect(ctx context.Context) error {
 		return err
 	}
 
-	dialContext, cancel := context.WithTimeout(ctx, c.DialTimeout)
+	configVersion := clientConfigVersion.Load()
+	if c.config != nil {
+		c.KeepAliveTime = c.config.KeepAliveTime.GetAsDuration(time.Millisecond)
+		c.KeepAliveTimeout = c.config.KeepAliveTimeout.GetAsDuration(time.Millisecond)
+		c.ConnectionPoolSize = c.config.ConnectionPoolSize.GetAsInt()
+	}
 
-	var conn *grpc.ClientConn
 	compress := None
 	if c.CompressionEnabled {
 		compress = Zstd
 	}
+	creds := insecure.NewCredentials()
 	if c.encryption {
 		log.Debug("Running in internalTLS mode with encryption enabled")
-		conn, err = grpc.DialContext(
-			dialContext,
-			addr,
-			// #nosec G402
-			grpc.WithTransportCredentials(credentials.NewTLS(
-				&tls.Config{
-					RootCAs:    c.cpInternalTLS,
-					ServerName: c.internalTLSServerName,
-				},
-			)),
-			grpc.WithBlock(),
-			grpc.WithDefaultCallOptions(
-				grpc.MaxCallRecvMsgSize(c.ClientMaxRecvSize),
-				grpc.MaxCallSendMsgSize(c.ClientMaxSendSize),
-				grpc.UseCompressor(compress),
-			),
-			grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(
-				interceptor.ClusterInjectionUnaryClientInterceptor(),
-				interceptor.ServerIDInjectionUnaryClientInterceptor(c.GetNodeID()),
-			)),
-			grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(
-				interceptor.ClusterInjectionStreamClientInterceptor(),
-				interceptor.ServerIDInjectionStreamClientInterceptor(c.GetNodeID()),
-			)),
-			grpc.WithKeepaliveParams(keepalive.ClientParameters{
-				Time:                c.KeepAliveTime,
-				Timeout:             c.KeepAliveTimeout,
-				PermitWithoutStream: true,
-			}),
-			grpc.WithConnectParams(grpc.ConnectParams{
-				Backoff: backoff.Config{
-					BaseDelay:  100 * time.Millisecond,
-					Multiplier: 1.6,
-					Jitter:     0.2,
-					MaxDelay:   3 * time.Second,
-				},
-				MinConnectTimeout: c.DialTimeout,
-			}),
-			grpc.WithPerRPCCredentials(&Token{Value: crypto.Base64Encode(util.MemberCredID)}),
-			grpc.FailOnNonTempDialError(true),
-			grpc.WithReturnConnectionError(),
-			grpc.WithDisableRetry(),
-			grpc.WithStatsHandler(tracer.GetDynamicOtelGrpcClientStatsHandler()),
-		)
-	} else {
-		conn, err = grpc.DialContext(
-			dialContext,
-			addr,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithBlock(),
-			grpc.WithDefaultCallOptions(
-				grpc.MaxCallRecvMsgSize(c.ClientMaxRecvSize),
-				grpc.MaxCallSendMsgSize(c.ClientMaxSendSize),
-				grpc.UseCompressor(compress),
-			),
-			grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(
-				interceptor.ClusterInjectionUnaryClientInterceptor(),
-				interceptor.ServerIDInjectionUnaryClientInterceptor(c.GetNodeID()),
-			)),
-			grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(
-				interceptor.ClusterInjectionStreamClientInterceptor(),
-				interceptor.ServerIDInjectionStreamClientInterceptor(c.GetNodeID()),
-			)),
-			grpc.WithKeepaliveParams(keepalive.ClientParameters{
-				Time:                c.KeepAliveTime,
-				Timeout:             c.KeepAliveTimeout,
-				PermitWithoutStream: true,
-			}),
-			grpc.WithConnectParams(grpc.ConnectParams{
-				Backoff: backoff.Config{
-					BaseDelay:  100 * time.Millisecond,
-					Multiplier: 1.6,
-					Jitter:     0.2,
-					MaxDelay:   3 * time.Second,
-				},
-				MinConnectTimeout: c.DialTimeout,
-			}),
-			grpc.WithPerRPCCredentials(&Token{Value: crypto.Base64Encode(util.MemberCredID)}),
-			grpc.FailOnNonTempDialError(true),
-			grpc.WithReturnConnectionError(),
-			grpc.WithDisableRetry(),
-			grpc.WithStatsHandler(tracer.GetDynamicOtelGrpcClientStatsHandler()),
-		)
+		// #nosec G402
+		creds = credentials.NewTLS(&tls.Config{
+			RootCAs:    c.cpInternalTLS,
+			ServerName: c.internalTLSServerName,
+		})
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(c.ClientMaxRecvSize),
+			grpc.MaxCallSendMsgSize(c.ClientMaxSendSize),
+			grpc.UseCompressor(compress),
+		),
+		grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(
+			interceptor.ClusterInjectionUnaryClientInterceptor(),
+			interceptor.ServerIDInjectionUnaryClientInterceptor(c.GetNodeID()),
+		)),
+		grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(
+			interceptor.ClusterInjectionStreamClientInterceptor(),
+			interceptor.ServerIDInjectionStreamClientInterceptor(c.GetNodeID()),
+		)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.KeepAliveTime,
+			Timeout:             c.KeepAliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  100 * time.Millisecond,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   3 * time.Second,
+			},
+			MinConnectTimeout: c.DialTimeout,
+		}),
+		grpc.WithPerRPCCredentials(&Token{Value: crypto.Base64Encode(util.MemberCredID)}),
+		grpc.FailOnNonTempDialError(true),
+		grpc.WithReturnConnectionError(),
+		grpc.WithDisableRetry(),
+		grpc.WithStatsHandler(tracer.GetDynamicOtelGrpcClientStatsHandler()),
 	}
 
-	cancel()
-	if err != nil {
-		return wrapErrConnect(addr, err)
+	poolSize := c.ConnectionPoolSize
+	if poolSize < 1 {
+		poolSize = 1
+	}
+	conns := make([]*grpc.ClientConn, 0, poolSize)
+	for i := 0; i < poolSize; i++ {
+		dialContext, cancel := context.WithTimeout(ctx, c.DialTimeout)
+		conn, err := grpc.DialContext(dialContext, addr, opts...)
+		cancel()
+		if err != nil {
+			for _, conn := range conns {
+				conn.Close()
+			}
+			return wrapErrConnect(addr, err)
+		}
+		conns = append(conns, conn)
 	}
 
 	c.addr.Store(addr)
 	c.ctxCounter.Store(0)
-	c.grpcClient = &clientConnWrapper[T]{
-		client: c.newGrpcClient(conn),
-		conn:   conn,
+	wrapper := &clientConnWrapper[T]{
+		client:        c.newGrpcClient(conns[0]),
+		conn:          conns[0],
+		configVersion: configVersion,
+	}
+	for _, conn := range conns[1:] {
+		wrapper.pooled = append(wrapper.pooled, pooledConn[T]{
+			client: c.newGrpcClient(conn),
+			conn:   conn,
+		})
 	}
+	c.grpcClient = wrapper
+	metrics.GrpcClientConnections.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), c.metricRole).Add(float64(len(conns)))
 	return nil
 }
 
@@ -498,7 +571,7 @@ func (c *ClientBase[T]) call(ctx context.Context, caller func(client T) (any, er
 
 		wrapper.Pin()
 		var err error
-		ret, err = caller(wrapper.client)
+		ret, err = caller(wrapper.getClient())
 		wrapper.Unpin()
 
 		if err != nil {
@@ -594,6 +667,7 @@ func (c *ClientBase[T]) Close() error {
 	c.grpcClientMtx.Lock()
 	defer c.grpcClientMtx.Unlock()
 	if c.grpcClient != nil {
+		metrics.GrpcClientConnections.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), c.metricRole).Sub(float64(c.grpcClient.connCount()))
 		return c.grpcClient.Close()
 	}
 	return nil
@@ -588,3 +588,33 @@ func TestVerifySession(t *testing.T) {
 	err = base.verifySession(ctx)
 	assert.ErrorIs(t, err, merr.ErrNodeNotFound)
 }
+
+func TestClientConnWrapperPool(t *testing.T) {
+	first, second := &mockClient{}, &mockClient{}
+	wrapper := &clientConnWrapper[*mockClient]{client: first}
+	assert.Same(t, first, wrapper.getClient())
+	assert.Equal(t, 0, wrapper.connCount())
+
+	wrapper.pooled = []pooledConn[*mockClient]{{client: second}}
+	clients := []*mockClient{wrapper.getClient(), wrapper.getClient()}
+	assert.ElementsMatch(t, []*mockClient{first, second}, clients)
+}
+
+func TestClientBase_ConfigChanged(t *testing.T) {
+	base := NewClientBase[*mockClient](&paramtable.Get().DataNodeGrpcClientCfg, "")
+	base.SetRole(typeutil.DataNodeRole)
+	base.grpcClient = &clientConnWrapper[*mockClient]{client: &mockClient{}, configVersion: clientConfigVersion.Load()}
+	assert.False(t, base.isStale(base.grpcClient))
+
+	// mock the config change event
+	clientConfigVersion.Inc()
+	assert.True(t, base.isStale(base.grpcClient))
+
+	// stale client is dropped and rebuilt on the next call
+	base.SetGetAddrFunc(func() (string, error) {
+		return "", errors.New("mocked address error")
+	})
+	_, err := base.GetGrpcClient(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, base.grpcClient)
+}
@@ -37,7 +37,7 @@ type reader struct {
 	schema *schemapb.CollectionSchema
 
 	fileSize   *atomic.Int64
-	deleteData *storage.DeleteData
+	deletes    map[any]int64      // pk -> max ts of the deletes
 	insertLogs map[int64][]string // fieldID -> binlogs
 
 	readIdx int
@@ -72,10 +72,6 @@ func (r *reader) init(paths []string, tsStart, tsEnd uint64) error {
 	if len(paths) == 0 {
 		return merr.WrapErrImportFailed("no insert binlogs to import")
 	}
-	if len(paths) > 2 {
-		return merr.WrapErrImportFailed(fmt.Sprintf("too many input paths for binlog import. "+
-			"Valid paths length should be one or two, but got paths:%s", paths))
-	}
 	insertLogs, err := listInsertLogs(r.ctx, r.cm, paths[0])
 	if err != nil {
 		return err
@@ -86,17 +82,24 @@ func (r *reader) init(paths []string, tsStart, tsEnd uint64) error {
 	}
 	r.insertLogs = insertLogs
 
-	if len(paths) < 2 {
+	// The rest paths are delta prefixes, restoring a backup passes the prefixes
+	// of the l0 segments as well, so that their deletes are applied to the rows.
+	deltaLogs := make([]string, 0)
+	for _, deltaPrefix := range paths[1:] {
+		logs, _, err := storage.ListAllChunkWithPrefix(context.Background(), r.cm, deltaPrefix, true)
+		if err != nil {
+			return err
+		}
+		deltaLogs = append(deltaLogs, logs...)
+	}
+	if len(deltaLogs) == 0 {
 		return nil
 	}
-	deltaLogs, _, err := storage.ListAllChunkWithPrefix(context.Background(), r.cm, paths[1], true)
+	minPK, maxPK, err := r.readPKRange()
 	if err != nil {
 		return err
 	}
-	if len(deltaLogs) == 0 {
-		return nil
-	}
-	r.deleteData, err = r.readDelete(deltaLogs, tsStart, tsEnd)
+	r.deletes, err = r.readDelete(deltaLogs, tsStart, tsEnd, minPK, maxPK)
 	if err != nil {
 		return err
 	}
@@ -109,8 +112,45 @@ func (r *reader) init(paths []string, tsStart, tsEnd uint64) error {
 	return nil
 }
 
-func (r *reader) readDelete(deltaLogs []string, tsStart, tsEnd uint64) (*storage.DeleteData, error) {
-	deleteData := storage.NewDeleteData(nil, nil)
+// readPKRange returns the min and max primary keys of the rows to import, nil if there is no row.
+func (r *reader) readPKRange() (minPK, maxPK storage.PrimaryKey, err error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(r.schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, path := range r.insertLogs[pkField.GetFieldID()] {
+		fr, err := newFieldReader(r.ctx, r.cm, pkField, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		fieldData, err := fr.Next()
+		fr.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := 0; i < fieldData.RowNum(); i++ {
+			pk, err := storage.GenPrimaryKeyByRawData(fieldData.GetRow(i), pkField.GetDataType())
+			if err != nil {
+				return nil, nil, err
+			}
+			if minPK == nil || pk.LT(minPK) {
+				minPK = pk
+			}
+			if maxPK == nil || pk.GT(maxPK) {
+				maxPK = pk
+			}
+		}
+	}
+	return minPK, maxPK, nil
+}
+
+// readDelete reads the deletes in the time range on the primary keys in [minPK, maxPK],
+// the others never apply to the rows, e.g. most deletes of the l0 segments restored with the backup.
+func (r *reader) readDelete(deltaLogs []string, tsStart, tsEnd uint64, minPK, maxPK storage.PrimaryKey) (map[any]int64, error) {
+	deletes := make(map[any]int64)
+	if minPK == nil {
+		return deletes, nil
+	}
 	for _, path := range deltaLogs {
 		reader, err := newBinlogReader(r.ctx, r.cm, path)
 		if err != nil {
@@ -128,13 +168,16 @@ func (r *reader) readDelete(deltaLogs []string, tsStart, tsEnd uint64) (*storage
 				if err != nil {
 					return nil, err
 				}
-				if dl.Ts >= tsStart && dl.Ts <= tsEnd {
-					deleteData.Append(dl.Pk, dl.Ts)
+				if dl.Ts < tsStart || dl.Ts > tsEnd || dl.Pk.LT(minPK) || dl.Pk.GT(maxPK) {
+					continue
+				}
+				if ts, ok := deletes[dl.Pk.GetValue()]; !ok || int64(dl.Ts) > ts {
+					deletes[dl.Pk.GetValue()] = int64(dl.Ts)
 				}
 			}
 		}
 	}
-	return deleteData, nil
+	return deletes, nil
 }
 
 func (r *reader) Read() (*storage.InsertData, error) {
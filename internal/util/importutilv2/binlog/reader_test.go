@@ -191,7 +191,7 @@ func createDeltaBuf(t *testing.T, deletePKs []storage.PrimaryKey, deleteTss []in
 	return blob.Value
 }
 
-func (suite *ReaderSuite) run(dataType schemapb.DataType, elemType schemapb.DataType, nullable bool) {
+func (suite *ReaderSuite) run(dataType schemapb.DataType, elemType schemapb.DataType, nullable bool) *reader {
 	const (
 		insertPrefix = "mock-insert-binlog-prefix"
 		deltaPrefix  = "mock-delta-binlog-prefix"
@@ -334,6 +334,7 @@ OUTER:
 			}
 		}
 	}
+	return reader
 }
 
 func (suite *ReaderSuite) TestReadScalarFields() {
@@ -392,6 +393,25 @@ func (suite *ReaderSuite) TestWithTSRangeAndDelete() {
 	suite.run(schemapb.DataType_Int32, schemapb.DataType_None, false)
 }
 
+func (suite *ReaderSuite) TestDeleteOutOfRange() {
+	suite.numRows = 10
+	suite.tsStart = 2
+	suite.tsEnd = 8
+	suite.deletePKs = []storage.PrimaryKey{
+		storage.NewInt64PrimaryKey(-1),
+		storage.NewInt64PrimaryKey(4),
+		storage.NewInt64PrimaryKey(4),
+		storage.NewInt64PrimaryKey(6),
+		storage.NewInt64PrimaryKey(100),
+	}
+	suite.deleteTss = []int64{
+		8, 5, 8, 9, 8,
+	}
+	reader := suite.run(schemapb.DataType_Int32, schemapb.DataType_None, false)
+	// only the deletes on the primary keys of the rows in the time range are kept
+	suite.Equal(map[any]int64{int64(4): 8}, reader.deletes)
+}
+
 func (suite *ReaderSuite) TestStringPK() {
 	suite.pkDataType = schemapb.DataType_VarChar
 	suite.numRows = 10
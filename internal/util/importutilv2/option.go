@@ -43,6 +43,25 @@ const (
 
 	// CSVNullKey specifies the null key used when importing CSV files.
 	CSVNullKey = "nullkey"
+
+	// Dedup specifies how to handle imported rows whose primary key already exists in the collection.
+	Dedup = "dedup"
+)
+
+type DedupMode string
+
+const (
+	// DedupNone imports all rows, which is the default behavior.
+	DedupNone DedupMode = ""
+
+	// DedupSkip skips rows whose primary key exists in the collection and is not deleted, or is imported
+	// before by the same import task. The segment pk stats filter the candidates, and a bloom filter hit
+	// is confirmed by the primary keys of the segment and the deletes applied to it.
+	DedupSkip DedupMode = "skip"
+
+	// DedupUpsert converts the rows whose primary key exists in the collection into upserts,
+	// which requires deleting the existing rows at the import ts and is not supported yet.
+	DedupUpsert DedupMode = "upsert"
 )
 
 // Options for backup-restore mode.
@@ -137,6 +156,27 @@ func SkipDiskQuotaCheck(options Options) bool {
 	return true
 }
 
+// GetDedupMode returns the de-duplication mode of the import.
+func GetDedupMode(options Options) (DedupMode, error) {
+	mode, err := funcutil.GetAttrByKeyFromRepeatedKV(Dedup, options)
+	if err != nil {
+		return DedupNone, nil
+	}
+	switch DedupMode(strings.ToLower(mode)) {
+	case DedupNone:
+		return DedupNone, nil
+	case DedupSkip:
+		if IsBackup(options) || IsL0Import(options) {
+			return DedupNone, merr.WrapErrImportFailed("dedup is not supported in backup-restore mode")
+		}
+		return DedupSkip, nil
+	case DedupUpsert:
+		return DedupNone, merr.WrapErrImportFailed(fmt.Sprintf("dedup mode %s is not supported by import yet, use %s instead", DedupUpsert, DedupSkip))
+	default:
+		return DedupNone, merr.WrapErrImportFailed(fmt.Sprintf("unsupported dedup mode: %s, only %s is supported", mode, DedupSkip))
+	}
+}
+
 func GetCSVSep(options Options) (rune, error) {
 	sep, err := funcutil.GetAttrByKeyFromRepeatedKV(CSVSep, options)
 	unsupportedSep := []rune{0, '\n', '\r', '"', 0xFFFD}
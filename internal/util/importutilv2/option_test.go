@@ -51,3 +51,22 @@ func TestOption_GetTimeout(t *testing.T) {
 	_, err = GetTimeoutTs(options)
 	assert.Error(t, err)
 }
+
+func TestOption_GetDedupMode(t *testing.T) {
+	mode, err := GetDedupMode([]*commonpb.KeyValuePair{})
+	assert.NoError(t, err)
+	assert.Equal(t, DedupNone, mode)
+
+	mode, err = GetDedupMode([]*commonpb.KeyValuePair{{Key: Dedup, Value: "Skip"}})
+	assert.NoError(t, err)
+	assert.Equal(t, DedupSkip, mode)
+
+	_, err = GetDedupMode([]*commonpb.KeyValuePair{{Key: Dedup, Value: "upsert"}})
+	assert.ErrorContains(t, err, "not supported by import yet")
+
+	_, err = GetDedupMode([]*commonpb.KeyValuePair{{Key: Dedup, Value: "unknown"}})
+	assert.Error(t, err)
+
+	_, err = GetDedupMode([]*commonpb.KeyValuePair{{Key: Dedup, Value: "skip"}, {Key: BackupFlag, Value: "true"}})
+	assert.Error(t, err)
+}
@@ -41,6 +41,10 @@ func (m *GrpcRootCoordClient) DescribeDatabase(ctx context.Context, in *rootcoor
 	return &rootcoordpb.DescribeDatabaseResponse{}, m.Err
 }
 
+func (m *GrpcRootCoordClient) DescribeDatabases(ctx context.Context, in *rootcoordpb.DescribeDatabasesRequest, opts ...grpc.CallOption) (*rootcoordpb.DescribeDatabasesResponse, error) {
+	return &rootcoordpb.DescribeDatabasesResponse{}, m.Err
+}
+
 func (m *GrpcRootCoordClient) CreateDatabase(ctx context.Context, in *milvuspb.CreateDatabaseRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	return &commonpb.Status{}, m.Err
 }
@@ -270,6 +274,10 @@ func (m *GrpcRootCoordClient) AlterDatabase(ctx context.Context, in *rootcoordpb
 	return &commonpb.Status{}, m.Err
 }
 
+func (m *GrpcRootCoordClient) BatchDDL(ctx context.Context, in *rootcoordpb.BatchDDLRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
 func (m *GrpcRootCoordClient) BackupRBAC(ctx context.Context, in *milvuspb.BackupRBACMetaRequest, opts ...grpc.CallOption) (*milvuspb.BackupRBACMetaResponse, error) {
 	return &milvuspb.BackupRBACMetaResponse{}, m.Err
 }
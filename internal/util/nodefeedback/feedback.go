@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodefeedback carries the periodic reports of the querynodes to datacoord through the meta storage,
+// e.g. the deleted ratios of the sealed segments, or the search fan-out of the shards.
+// Each querynode saves its report of a kind under its own key, replacing the previous one, which datacoord reads periodically.
+// No clocks are compared across the nodes: datacoord ignores the reports of the querynodes whose sessions are gone,
+// and the ones whose sequence number stops advancing.
+// The kinds of feedback only define their items, see deletefeedback and fanoutfeedback.
+package nodefeedback
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// Report is the items of a kind of feedback reported by a querynode.
+type Report[T any] struct {
+	NodeID int64 `json:"node_id"`
+	// Seq increases with every report of the node.
+	Seq   int64 `json:"seq"`
+	Items []T   `json:"items"`
+}
+
+// Store saves and loads the reports of a kind of feedback under its prefix.
+type Store[T any] struct {
+	kv     kv.BaseKV
+	prefix string
+}
+
+// NewStore returns the store of the reports under the prefix.
+func NewStore[T any](metaKV kv.BaseKV, prefix string) *Store[T] {
+	return &Store[T]{
+		kv:     metaKV,
+		prefix: prefix,
+	}
+}
+
+func (s *Store[T]) key(nodeID int64) string {
+	return path.Join(s.prefix, fmt.Sprint(nodeID))
+}
+
+// Save saves the report of the querynode, replacing the previous one.
+func (s *Store[T]) Save(ctx context.Context, report *Report[T]) error {
+	bs, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return s.kv.Save(ctx, s.key(report.NodeID), string(bs))
+}
+
+// Remove removes the report of the querynode.
+func (s *Store[T]) Remove(ctx context.Context, nodeID int64) error {
+	return s.kv.Remove(ctx, s.key(nodeID))
+}
+
+// LoadAll loads the reports of all the querynodes, the malformed ones are skipped.
+func (s *Store[T]) LoadAll(ctx context.Context) ([]*Report[T], error) {
+	_, values, err := s.kv.LoadWithPrefix(ctx, s.prefix+"/")
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*Report[T], 0, len(values))
+	for _, value := range values {
+		report := &Report[T]{}
+		if err := json.Unmarshal([]byte(value), report); err != nil {
+			log.Ctx(ctx).Warn("skip the malformed feedback", zap.String("prefix", s.prefix), zap.Error(err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
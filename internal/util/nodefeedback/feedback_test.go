@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefeedback
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	metaKV := memkv.NewMemoryKV()
+	store := NewStore[int64](metaKV, "test-feedback")
+
+	require.NoError(t, store.Save(ctx, &Report[int64]{NodeID: 1, Seq: 1, Items: []int64{100}}))
+	require.NoError(t, metaKV.Save(ctx, store.key(3), "invalid"))
+	// the reports of other kinds are not loaded
+	require.NoError(t, NewStore[int64](metaKV, "other-feedback").Save(ctx, &Report[int64]{NodeID: 2, Seq: 1}))
+
+	reports, err := store.LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, &Report[int64]{NodeID: 1, Seq: 1, Items: []int64{100}}, reports[0])
+
+	require.NoError(t, store.Remove(ctx, 1))
+	reports, err = store.LoadAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func TestReporter(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	store := NewStore[int64](memkv.NewMemoryKV(), "test-feedback")
+	var items []int64
+	params := &paramtable.Get().QueryNodeCfg
+	reporter := NewReporter(1, store, &params.DeleteFeedbackEnabled, &params.DeleteFeedbackInterval, func() []int64 {
+		return items
+	})
+
+	items = []int64{1}
+	require.NoError(t, reporter.Report(ctx))
+	items = []int64{2}
+	require.NoError(t, reporter.Report(ctx))
+	reports, err := store.LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	// the seq advances with every report
+	assert.Equal(t, &Report[int64]{NodeID: 1, Seq: 2, Items: []int64{2}}, reports[0])
+
+	// the report is removed on stop.
+	reporter.Start(ctx)
+	reporter.Stop()
+	reports, err = store.LoadAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func TestReceiver(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore[int64](memkv.NewMemoryKV(), "test-feedback")
+	live := typeutil.NewUniqueSet(1, 2)
+	receiver := NewReceiver(store, func() (typeutil.UniqueSet, error) { return live, nil })
+
+	save := func(nodeID, seq int64) {
+		require.NoError(t, store.Save(ctx, &Report[int64]{NodeID: nodeID, Seq: seq}))
+	}
+	refresh := func() []int64 {
+		reports, err := receiver.Refresh(ctx)
+		require.NoError(t, err)
+		nodeIDs := make([]int64, 0, len(reports))
+		for _, report := range reports {
+			nodeIDs = append(nodeIDs, report.NodeID)
+		}
+		return nodeIDs
+	}
+
+	save(1, 1)
+	save(2, 1)
+	assert.ElementsMatch(t, []int64{1, 2}, refresh())
+
+	// the report of which the seq stops advancing is ignored
+	save(2, 2)
+	assert.ElementsMatch(t, []int64{1, 2}, refresh())
+	assert.ElementsMatch(t, []int64{1, 2}, refresh())
+	assert.ElementsMatch(t, []int64{2}, refresh())
+	save(1, 2)
+	assert.ElementsMatch(t, []int64{1}, refresh())
+
+	// the report of the querynode gone is removed
+	live = typeutil.NewUniqueSet(2)
+	save(2, 3)
+	assert.ElementsMatch(t, []int64{2}, refresh())
+	reports, err := store.LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.EqualValues(t, 2, reports[0].NodeID)
+}
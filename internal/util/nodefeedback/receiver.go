@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefeedback
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// maxIdleRefreshes is the refreshes the seq of a report stays the same before it is ignored,
+// the querynode is likely stuck or partitioned from the meta storage.
+const maxIdleRefreshes = 3
+
+// Receiver reads the reports of a kind of feedback on datacoord.
+type Receiver[T any] struct {
+	store *Store[T]
+	// liveNodes returns the IDs of the querynodes of which the sessions are alive
+	liveNodes func() (typeutil.UniqueSet, error)
+
+	mu sync.Mutex
+	// the last seq of the report of each node, and the refreshes it has not advanced
+	seqs      map[int64]int64
+	idleTimes map[int64]int
+}
+
+// NewReceiver returns the receiver of the reports in the store.
+func NewReceiver[T any](store *Store[T], liveNodes func() (typeutil.UniqueSet, error)) *Receiver[T] {
+	return &Receiver[T]{
+		store:     store,
+		liveNodes: liveNodes,
+		seqs:      make(map[int64]int64),
+		idleTimes: make(map[int64]int),
+	}
+}
+
+// Refresh returns the reports still in effect,
+// the reports of the querynodes gone are removed, and the ones whose seq stops advancing are skipped.
+func (r *Receiver[T]) Refresh(ctx context.Context) ([]*Report[T], error) {
+	reports, err := r.store.LoadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live, err := r.liveNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]*Report[T], 0, len(reports))
+	seqs := make(map[int64]int64, len(reports))
+	idleTimes := make(map[int64]int, len(reports))
+	for _, report := range reports {
+		if !live.Contain(report.NodeID) {
+			if err := r.store.Remove(ctx, report.NodeID); err != nil {
+				log.Ctx(ctx).Warn("failed to remove the feedback of the querynode gone",
+					zap.String("prefix", r.store.prefix), zap.Int64("nodeID", report.NodeID), zap.Error(err))
+			}
+			continue
+		}
+		seqs[report.NodeID] = report.Seq
+		if last, ok := r.seqs[report.NodeID]; ok && last == report.Seq {
+			idleTimes[report.NodeID] = r.idleTimes[report.NodeID] + 1
+		}
+		if idleTimes[report.NodeID] >= maxIdleRefreshes {
+			continue
+		}
+		ret = append(ret, report)
+	}
+	r.seqs = seqs
+	r.idleTimes = idleTimes
+	return ret, nil
+}
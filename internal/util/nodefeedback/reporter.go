@@ -0,0 +1,105 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefeedback
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// Reporter saves the items collected by the querynode periodically if enabled,
+// and removes the report on stop so datacoord no longer acts on it.
+type Reporter[T any] struct {
+	nodeID   int64
+	store    *Store[T]
+	enabled  *paramtable.ParamItem
+	interval *paramtable.ParamItem
+	collect  func() []T
+	// seq of the last report, only accessed by the report loop
+	seq int64
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewReporter returns the reporter of the node, the interval is in seconds, both the params are refreshable.
+func NewReporter[T any](nodeID int64, store *Store[T], enabled, interval *paramtable.ParamItem, collect func() []T) *Reporter[T] {
+	return &Reporter[T]{
+		nodeID:   nodeID,
+		store:    store,
+		enabled:  enabled,
+		interval: interval,
+		collect:  collect,
+		closeCh:  make(chan struct{}),
+	}
+}
+
+// Start starts the report loop.
+func (r *Reporter[T]) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go r.loop(ctx)
+}
+
+// Stop stops the report loop and removes the report of the node.
+func (r *Reporter[T]) Stop() {
+	r.closeOnce.Do(func() {
+		close(r.closeCh)
+		r.wg.Wait()
+		if err := r.store.Remove(context.Background(), r.nodeID); err != nil {
+			log.Warn("failed to remove the feedback", zap.String("prefix", r.store.prefix), zap.Error(err))
+		}
+	})
+}
+
+func (r *Reporter[T]) loop(ctx context.Context) {
+	defer r.wg.Done()
+	timer := time.NewTimer(r.interval.GetAsDuration(time.Second))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.closeCh:
+			return
+		case <-timer.C:
+			if r.enabled.GetAsBool() {
+				if err := r.Report(ctx); err != nil {
+					log.Ctx(ctx).Warn("failed to report the feedback", zap.String("prefix", r.store.prefix), zap.Error(err))
+				}
+			}
+			// the interval is refreshable
+			timer.Reset(r.interval.GetAsDuration(time.Second))
+		}
+	}
+}
+
+// Report collects the items and saves them with the next seq.
+func (r *Reporter[T]) Report(ctx context.Context) error {
+	r.seq++
+	return r.store.Save(ctx, &Report[T]{
+		NodeID: r.nodeID,
+		Seq:    r.seq,
+		Items:  r.collect(),
+	})
+}
@@ -17,6 +17,8 @@
 package pipeline
 
 import (
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
 )
 
@@ -32,12 +34,14 @@ type nodeCtx struct {
 
 	Next    *nodeCtx
 	Checker *timerecord.Checker
+	Metrics *metrics.FlowGraphNodeMetrics
 }
 
 func NewNodeCtx(node Node) *nodeCtx {
 	return &nodeCtx{
 		node:         node,
 		InputChannel: make(chan Msg, node.MaxQueueLength()),
+		Metrics:      metrics.NewFlowGraphNodeMetrics(paramtable.GetNodeID(), paramtable.GetRole(), node.Name()),
 	}
 }
 
@@ -73,6 +73,7 @@ func (p *pipeline) Close() {
 		if node.Checker != nil {
 			node.Checker.Close()
 		}
+		node.Metrics.Cleanup()
 	}
 }
 
@@ -88,7 +89,9 @@ func (p *pipeline) process() {
 		}
 
 		input := <-curNode.InputChannel
+		operateStart := time.Now()
 		output := curNode.node.Operate(input)
+		curNode.Metrics.ObserveOperate(operateStart)
 		if curNode.Checker != nil {
 			curNode.Checker.Check()
 		}
@@ -29,12 +29,14 @@ import (
 	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/common"
 	"github.com/milvus-io/milvus/pkg/mq/msgdispatcher"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/streaming/util/message/adaptor"
 	"github.com/milvus-io/milvus/pkg/streaming/util/options"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
@@ -57,8 +59,12 @@ type streamPipeline struct {
 	closeOnce sync.Once
 
 	lastAccessTime *atomic.Time
+	// the metrics of the input consumed from the scanner, which are recorded by the msg dispatcher otherwise
+	inputMetrics *metrics.FlowGraphNodeMetrics
 }
 
+// work pulls the messages from the input and processes each one through all the nodes before pulling the next one,
+// so a slow node applies back pressure to the bounded buffer of the msg dispatcher or scanner.
 func (p *streamPipeline) work() {
 	defer p.closeWg.Done()
 	for {
@@ -100,7 +106,8 @@ func (p *streamPipeline) ConsumeMsgStream(ctx context.Context, position *msgpb.M
 			zap.Any("startFromMessageID", startFrom),
 			zap.Uint64("timestamp", position.GetTimestamp()),
 		)
-		handler := adaptor.NewMsgPackAdaptorHandler()
+		p.inputMetrics = metrics.NewFlowGraphNodeMetrics(paramtable.GetNodeID(), paramtable.GetRole(), fmt.Sprintf("input-%s", p.vChannel))
+		handler := adaptor.NewMsgPackAdaptorHandlerWithMetrics(p.inputMetrics)
 		p.scanner = streaming.WAL().Read(ctx, streaming.ReadOption{
 			VChannel:      position.GetChannelName(),
 			DeliverPolicy: options.DeliverPolicyStartFrom(startFrom),
@@ -156,6 +163,7 @@ func (p *streamPipeline) Close() {
 		}
 		p.dispatcher.Deregister(p.vChannel)
 		p.pipeline.Close()
+		p.inputMetrics.Cleanup()
 	})
 }
 
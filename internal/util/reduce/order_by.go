@@ -0,0 +1,201 @@
+package reduce
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// IsOrderByDataTypeSupported returns whether the results could be ordered by the field of the data type.
+func IsOrderByDataTypeSupported(dataType schemapb.DataType) bool {
+	switch dataType {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32,
+		schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double,
+		schemapb.DataType_String, schemapb.DataType_VarChar:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderByColumns holds the columns of a retrieve result which the rows are ordered by.
+// Rows are compared by the order by fields first, then by the primary key in ascending order,
+// null values are always placed last.
+type OrderByColumns struct {
+	ids       *schemapb.IDs
+	columns   []*schemapb.FieldData
+	ascending []bool
+}
+
+func NewOrderByColumns(ids *schemapb.IDs, fieldsData []*schemapb.FieldData, orderBy []*internalpb.OrderByField) (*OrderByColumns, error) {
+	columns := make([]*schemapb.FieldData, 0, len(orderBy))
+	ascending := make([]bool, 0, len(orderBy))
+	for _, field := range orderBy {
+		var column *schemapb.FieldData
+		for _, fieldData := range fieldsData {
+			if fieldData.GetFieldId() == field.GetFieldID() {
+				column = fieldData
+				break
+			}
+		}
+		if column == nil {
+			return nil, merr.WrapErrServiceInternal(fmt.Sprintf("order by field %d not found in the retrieve result", field.GetFieldID()))
+		}
+		if !IsOrderByDataTypeSupported(column.GetType()) {
+			return nil, merr.WrapErrParameterInvalidMsg(fmt.Sprintf("order by field of type %s is not supported", column.GetType().String()))
+		}
+		columns = append(columns, column)
+		ascending = append(ascending, field.GetAscending())
+	}
+	return &OrderByColumns{
+		ids:       ids,
+		columns:   columns,
+		ascending: ascending,
+	}, nil
+}
+
+// Size returns the number of rows.
+func (c *OrderByColumns) Size() int {
+	return typeutil.GetSizeOfIDs(c.ids)
+}
+
+// Compare compares the i-th row of c with the j-th row of other.
+func (c *OrderByColumns) Compare(i int64, other *OrderByColumns, j int64) int {
+	for k, column := range c.columns {
+		if ret := compareColumn(column, i, other.columns[k], j, c.ascending[k]); ret != 0 {
+			return ret
+		}
+	}
+	return comparePK(c.ids, i, other.ids, j)
+}
+
+// SortedIndices returns the row indices in order, only the first limit rows are kept unless limit is unlimited.
+func (c *OrderByColumns) SortedIndices(limit int64) []int64 {
+	indices := make([]int64, c.Size())
+	for i := range indices {
+		indices[i] = int64(i)
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return c.Compare(indices[a], c, indices[b]) < 0
+	})
+	if limit != typeutil.Unlimited && int64(len(indices)) > limit {
+		indices = indices[:limit]
+	}
+	return indices
+}
+
+// SequentialIndices returns the row indices of the result which is already in order,
+// only the first limit rows are kept unless limit is unlimited.
+func (c *OrderByColumns) SequentialIndices(limit int64) []int64 {
+	size := int64(c.Size())
+	if limit != typeutil.Unlimited && size > limit {
+		size = limit
+	}
+	indices := make([]int64, size)
+	for i := range indices {
+		indices[i] = int64(i)
+	}
+	return indices
+}
+
+func isNull(column *schemapb.FieldData, i int64) bool {
+	validData := column.GetValidData()
+	return len(validData) > 0 && !validData[i]
+}
+
+func compareColumn(a *schemapb.FieldData, i int64, b *schemapb.FieldData, j int64, ascending bool) int {
+	aNull, bNull := isNull(a, i), isNull(b, j)
+	switch {
+	case aNull && bNull:
+		return 0
+	case aNull:
+		return 1
+	case bNull:
+		return -1
+	}
+
+	var ret int
+	switch a.GetType() {
+	case schemapb.DataType_Bool:
+		x, y := a.GetScalars().GetBoolData().GetData()[i], b.GetScalars().GetBoolData().GetData()[j]
+		switch {
+		case x == y:
+			ret = 0
+		case !x:
+			ret = -1
+		default:
+			ret = 1
+		}
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		ret = cmp.Compare(a.GetScalars().GetIntData().GetData()[i], b.GetScalars().GetIntData().GetData()[j])
+	case schemapb.DataType_Int64:
+		ret = cmp.Compare(a.GetScalars().GetLongData().GetData()[i], b.GetScalars().GetLongData().GetData()[j])
+	case schemapb.DataType_Float:
+		ret = cmp.Compare(a.GetScalars().GetFloatData().GetData()[i], b.GetScalars().GetFloatData().GetData()[j])
+	case schemapb.DataType_Double:
+		ret = cmp.Compare(a.GetScalars().GetDoubleData().GetData()[i], b.GetScalars().GetDoubleData().GetData()[j])
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		ret = cmp.Compare(a.GetScalars().GetStringData().GetData()[i], b.GetScalars().GetStringData().GetData()[j])
+	}
+	if !ascending {
+		ret = -ret
+	}
+	return ret
+}
+
+func comparePK(a *schemapb.IDs, i int64, b *schemapb.IDs, j int64) int {
+	switch a.GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		return cmp.Compare(a.GetIntId().GetData()[i], b.GetIntId().GetData()[j])
+	case *schemapb.IDs_StrId:
+		return cmp.Compare(a.GetStrId().GetData()[i], b.GetStrId().GetData()[j])
+	default:
+		return 0
+	}
+}
+
+// OrderedCursor walks the rows of a result in order.
+type OrderedCursor struct {
+	Columns *OrderByColumns
+	Indices []int64
+	pos     int
+}
+
+func NewOrderedCursor(columns *OrderByColumns, indices []int64) *OrderedCursor {
+	return &OrderedCursor{
+		Columns: columns,
+		Indices: indices,
+	}
+}
+
+// Current returns the index of the current row, -1 if the cursor is drained.
+func (c *OrderedCursor) Current() int64 {
+	if c.pos >= len(c.Indices) {
+		return -1
+	}
+	return c.Indices[c.pos]
+}
+
+func (c *OrderedCursor) Next() {
+	c.pos++
+}
+
+// SelectMinCursor returns the position of the cursor whose current row goes first, -1 if all cursors are drained.
+// The cursors are compared one by one, the number of results to merge is small.
+func SelectMinCursor(cursors []*OrderedCursor) int {
+	sel := -1
+	for i, cursor := range cursors {
+		if cursor.Current() == -1 {
+			continue
+		}
+		if sel == -1 || cursor.Columns.Compare(cursor.Current(), cursors[sel].Columns, cursors[sel].Current()) < 0 {
+			sel = i
+		}
+	}
+	return sel
+}
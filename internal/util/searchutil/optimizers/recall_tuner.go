@@ -0,0 +1,273 @@
+package optimizers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/distance"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// RecallTargetKey is the search param asking the query node to choose the index search param for the recall target.
+const RecallTargetKey = "recall_target"
+
+// CalibrationPoint is the recall measured with the index search param set to Value.
+type CalibrationPoint struct {
+	Value  int64
+	Recall float64
+}
+
+// Calibration is the recall curve of the vector index of a field, the points are sorted by value.
+type Calibration struct {
+	ParamKey   string
+	Points     []CalibrationPoint
+	UpdateTime time.Time
+}
+
+// Pick returns the smallest param value meeting the recall target, or the largest one if none meets it.
+func (c *Calibration) Pick(recallTarget float64) int64 {
+	for _, point := range c.Points {
+		if point.Recall >= recallTarget {
+			return point.Value
+		}
+	}
+	return c.Points[len(c.Points)-1].Value
+}
+
+// RecallTuner keeps the calibrated recall curves of the vector indexes of the loaded collections.
+type RecallTuner struct {
+	mu           sync.RWMutex
+	calibrations map[int64]map[int64]*Calibration // collectionID -> fieldID -> calibration
+}
+
+var (
+	recallTuner     *RecallTuner
+	recallTunerOnce sync.Once
+)
+
+func NewRecallTuner() *RecallTuner {
+	return &RecallTuner{
+		calibrations: make(map[int64]map[int64]*Calibration),
+	}
+}
+
+// GetRecallTuner returns the recall tuner of the query node.
+func GetRecallTuner() *RecallTuner {
+	recallTunerOnce.Do(func() {
+		recallTuner = NewRecallTuner()
+	})
+	return recallTuner
+}
+
+func (t *RecallTuner) Update(collectionID, fieldID int64, calibration *Calibration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(calibration.Points) == 0 {
+		return
+	}
+	sort.Slice(calibration.Points, func(i, j int) bool {
+		return calibration.Points[i].Value < calibration.Points[j].Value
+	})
+	if _, ok := t.calibrations[collectionID]; !ok {
+		t.calibrations[collectionID] = make(map[int64]*Calibration)
+	}
+	t.calibrations[collectionID][fieldID] = calibration
+}
+
+func (t *RecallTuner) Get(collectionID, fieldID int64) *Calibration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.calibrations[collectionID][fieldID]
+}
+
+func (t *RecallTuner) Remove(collectionID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.calibrations, collectionID)
+}
+
+// Collections returns the ids of the collections having calibrations.
+func (t *RecallTuner) Collections() []int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	collectionIDs := make([]int64, 0, len(t.calibrations))
+	for collectionID := range t.calibrations {
+		collectionIDs = append(collectionIDs, collectionID)
+	}
+	return collectionIDs
+}
+
+// TuneSearchParamsForRecall replaces the index search param of the search carrying a recall target
+// with the smallest calibrated value meeting the target. The recall target is removed from the search params,
+// which are kept as they are if the field is not calibrated yet.
+func TuneSearchParamsForRecall(ctx context.Context, req *querypb.SearchRequest, tuner *RecallTuner) (*querypb.SearchRequest, error) {
+	serializedPlan := req.GetReq().GetSerializedExprPlan()
+	if tuner == nil || !bytes.Contains(serializedPlan, []byte(RecallTargetKey)) {
+		return req, nil
+	}
+
+	collectionID := req.GetReq().GetCollectionID()
+	log := log.Ctx(ctx).With(zap.Int64("collection", collectionID))
+
+	plan := planpb.PlanNode{}
+	err := proto.Unmarshal(serializedPlan, &plan)
+	if err != nil {
+		log.Warn("failed to unmarshal plan", zap.Error(err))
+		return nil, merr.WrapErrParameterInvalid("valid serialized search plan", "no unmarshalable one", err.Error())
+	}
+	if plan.GetVectorAnns() == nil {
+		return req, nil
+	}
+
+	queryInfo := plan.GetVectorAnns().GetQueryInfo()
+	params := make(map[string]any)
+	decoder := json.NewDecoder(strings.NewReader(queryInfo.GetSearchParams()))
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		return req, nil
+	}
+	value, ok := params[RecallTargetKey]
+	if !ok {
+		return req, nil
+	}
+	recallTarget, err := parseRecallTarget(value)
+	if err != nil {
+		return nil, err
+	}
+	delete(params, RecallTargetKey)
+
+	fieldID := plan.GetVectorAnns().GetFieldId()
+	if calibration := tuner.Get(collectionID, fieldID); calibration != nil {
+		paramValue := calibration.Pick(recallTarget)
+		// the candidate list size of graph indexes must not be less than topk
+		if calibration.ParamKey != "nprobe" && paramValue < queryInfo.GetTopk() {
+			paramValue = queryInfo.GetTopk()
+		}
+		params[calibration.ParamKey] = paramValue
+		log.Debug("tuned search params for recall target",
+			zap.Int64("fieldID", fieldID),
+			zap.Float64("recallTarget", recallTarget),
+			zap.String("paramKey", calibration.ParamKey),
+			zap.Int64("paramValue", paramValue))
+	}
+
+	searchParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalid("marshalable search params", "search params with marshal error", err.Error())
+	}
+	queryInfo.SearchParams = string(searchParams)
+	serializedExprPlan, err := proto.Marshal(&plan)
+	if err != nil {
+		log.Warn("failed to marshal tuned plan", zap.Error(err))
+		return nil, merr.WrapErrParameterInvalid("marshalable search plan", "plan with marshal error", err.Error())
+	}
+	req.Req.SerializedExprPlan = serializedExprPlan
+	return req, nil
+}
+
+func parseRecallTarget(value any) (float64, error) {
+	var str string
+	switch v := value.(type) {
+	case json.Number:
+		str = v.String()
+	case string:
+		str = v
+	default:
+		return 0, merr.WrapErrParameterInvalid("recall target in (0, 1]", fmt.Sprint(value))
+	}
+	recallTarget, err := strconv.ParseFloat(str, 64)
+	if err != nil || recallTarget <= 0 || recallTarget > 1 {
+		return 0, merr.WrapErrParameterInvalid("recall target in (0, 1]", str)
+	}
+	return recallTarget, nil
+}
+
+// SearchParamCandidates returns the index search param to calibrate and its candidate values by the index type,
+// ok is false if the index type is not supported.
+func SearchParamCandidates(indexType string, indexParams map[string]string) (paramKey string, candidates []int64, ok bool) {
+	switch strings.ToUpper(indexType) {
+	case "HNSW":
+		return "ef", []int64{16, 32, 64, 128, 256, 512}, true
+	case "DISKANN":
+		return "search_list", []int64{16, 32, 64, 128, 256, 512}, true
+	case "IVF_FLAT", "IVF_SQ8", "IVF_PQ", "SCANN":
+		nlist, err := strconv.ParseInt(indexParams["nlist"], 10, 64)
+		if err != nil || nlist <= 0 {
+			nlist = 128
+		}
+		for nprobe := int64(1); nprobe < nlist; nprobe *= 2 {
+			candidates = append(candidates, nprobe)
+		}
+		return "nprobe", append(candidates, nlist), true
+	default:
+		return "", nil, false
+	}
+}
+
+// BruteForceTopK returns the row indices of the exact topk nearest vectors of each query.
+func BruteForceTopK(dim int64, queries, vectors []float32, metricType string, topk int) ([][]int, error) {
+	distances, err := distance.CalcFloatDistance(dim, queries, vectors, metricType)
+	if err != nil {
+		return nil, err
+	}
+	nq, rows := len(queries)/int(dim), len(vectors)/int(dim)
+	ascending := strings.ToUpper(metricType) == distance.L2
+	results := make([][]int, nq)
+	for i := 0; i < nq; i++ {
+		dist := distances[i*rows : (i+1)*rows]
+		indices := make([]int, rows)
+		for j := range indices {
+			indices[j] = j
+		}
+		sort.SliceStable(indices, func(a, b int) bool {
+			if ascending {
+				return dist[indices[a]] < dist[indices[b]]
+			}
+			return dist[indices[a]] > dist[indices[b]]
+		})
+		if len(indices) > topk {
+			indices = indices[:topk]
+		}
+		results[i] = indices
+	}
+	return results, nil
+}
+
+// ComputeRecall returns the average ratio of the ground truth found in the results of each query.
+func ComputeRecall[T comparable](groundTruth, results [][]T) float64 {
+	if len(groundTruth) == 0 {
+		return 0
+	}
+	var total float64
+	for i, truth := range groundTruth {
+		if len(truth) == 0 {
+			total++
+			continue
+		}
+		found := make(map[T]struct{}, len(results[i]))
+		for _, id := range results[i] {
+			found[id] = struct{}{}
+		}
+		hit := 0
+		for _, id := range truth {
+			if _, ok := found[id]; ok {
+				hit++
+			}
+		}
+		total += float64(hit) / float64(len(truth))
+	}
+	return total / float64(len(groundTruth))
+}
@@ -0,0 +1,141 @@
+package optimizers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+type RecallTunerSuite struct {
+	suite.Suite
+}
+
+func (suite *RecallTunerSuite) newRequest(searchParams string) *querypb.SearchRequest {
+	plan := &planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{
+				FieldId: 101,
+				QueryInfo: &planpb.QueryInfo{
+					Topk:         20,
+					SearchParams: searchParams,
+				},
+			},
+		},
+	}
+	bs, err := proto.Marshal(plan)
+	suite.Require().NoError(err)
+	return &querypb.SearchRequest{
+		Req: &internalpb.SearchRequest{
+			CollectionID:       1,
+			SerializedExprPlan: bs,
+		},
+	}
+}
+
+func (suite *RecallTunerSuite) getSearchParams(req *querypb.SearchRequest) string {
+	plan := &planpb.PlanNode{}
+	suite.Require().NoError(proto.Unmarshal(req.GetReq().GetSerializedExprPlan(), plan))
+	return plan.GetVectorAnns().GetQueryInfo().GetSearchParams()
+}
+
+func (suite *RecallTunerSuite) TestTuneSearchParams() {
+	ctx := context.Background()
+	tuner := NewRecallTuner()
+	tuner.Update(1, 101, &Calibration{
+		ParamKey: "ef",
+		Points: []CalibrationPoint{
+			{Value: 64, Recall: 0.95},
+			{Value: 16, Recall: 0.8},
+			{Value: 32, Recall: 0.9},
+		},
+	})
+
+	suite.Run("no_recall_target", func() {
+		req := suite.newRequest(`{"ef": 100}`)
+		ret, err := TuneSearchParamsForRecall(ctx, req, tuner)
+		suite.NoError(err)
+		suite.Equal(`{"ef": 100}`, suite.getSearchParams(ret))
+	})
+
+	suite.Run("tuned", func() {
+		req := suite.newRequest(`{"ef": 100, "recall_target": 0.9}`)
+		ret, err := TuneSearchParamsForRecall(ctx, req, tuner)
+		suite.NoError(err)
+		suite.Equal(`{"ef":32}`, suite.getSearchParams(ret))
+
+		req = suite.newRequest(`{"recall_target": "0.99"}`)
+		ret, err = TuneSearchParamsForRecall(ctx, req, tuner)
+		suite.NoError(err)
+		suite.Equal(`{"ef":64}`, suite.getSearchParams(ret))
+
+		// ef is not less than topk
+		req = suite.newRequest(`{"recall_target": 0.5}`)
+		ret, err = TuneSearchParamsForRecall(ctx, req, tuner)
+		suite.NoError(err)
+		suite.Equal(`{"ef":20}`, suite.getSearchParams(ret))
+	})
+
+	suite.Run("not_calibrated", func() {
+		req := suite.newRequest(`{"nprobe": 8, "recall_target": 0.9}`)
+		req.Req.CollectionID = 2
+		ret, err := TuneSearchParamsForRecall(ctx, req, tuner)
+		suite.NoError(err)
+		suite.Equal(`{"nprobe":8}`, suite.getSearchParams(ret))
+	})
+
+	suite.Run("invalid_recall_target", func() {
+		for _, params := range []string{`{"recall_target": 0}`, `{"recall_target": 1.5}`, `{"recall_target": "high"}`, `{"recall_target": true}`} {
+			_, err := TuneSearchParamsForRecall(ctx, suite.newRequest(params), tuner)
+			suite.ErrorIs(err, merr.ErrParameterInvalid)
+		}
+	})
+
+	tuner.Remove(1)
+	suite.Nil(tuner.Get(1, 101))
+	suite.Empty(tuner.Collections())
+}
+
+func (suite *RecallTunerSuite) TestSearchParamCandidates() {
+	key, candidates, ok := SearchParamCandidates("HNSW", nil)
+	suite.True(ok)
+	suite.Equal("ef", key)
+	suite.NotEmpty(candidates)
+
+	key, candidates, ok = SearchParamCandidates("IVF_FLAT", map[string]string{"nlist": "48"})
+	suite.True(ok)
+	suite.Equal("nprobe", key)
+	suite.Equal([]int64{1, 2, 4, 8, 16, 32, 48}, candidates)
+
+	_, _, ok = SearchParamCandidates("FLAT", nil)
+	suite.False(ok)
+}
+
+func (suite *RecallTunerSuite) TestBruteForceAndRecall() {
+	vectors := []float32{0, 0, 1, 1, 2, 2, 3, 3}
+	queries := []float32{0.1, 0.1, 2.9, 2.9}
+
+	truth, err := BruteForceTopK(2, queries, vectors, "L2", 2)
+	suite.NoError(err)
+	suite.Equal([][]int{{0, 1}, {3, 2}}, truth)
+
+	truth, err = BruteForceTopK(2, queries, vectors, "IP", 1)
+	suite.NoError(err)
+	suite.Equal([][]int{{3}, {3}}, truth)
+
+	_, err = BruteForceTopK(2, queries, vectors, "HAMMING", 1)
+	suite.Error(err)
+
+	suite.Equal(0.75, ComputeRecall([][]int{{0, 1}, {3, 2}}, [][]int{{0, 1}, {3, 1}}))
+	suite.Equal(float64(0), ComputeRecall[int](nil, nil))
+}
+
+func TestRecallTuner(t *testing.T) {
+	suite.Run(t, new(RecallTunerSuite))
+}
@@ -21,17 +21,26 @@ const (
 )
 
 // newScheduler create a scheduler with given schedule policy.
-func newScheduler(policy schedulePolicy) Scheduler {
+func newScheduler(policy schedulePolicy) *scheduler {
 	maxReadConcurrency := paramtable.Get().QueryNodeCfg.MaxReadConcurrency.GetAsInt()
-	maxReceiveChanSize := paramtable.Get().QueryNodeCfg.MaxReceiveChanSize.GetAsInt()
 	log.Info("query node use concurrent safe scheduler", zap.Int("max_concurrency", maxReadConcurrency))
+	return newSchedulerWithPool(policy,
+		conc.NewPool[any](maxReadConcurrency, conc.WithPreAlloc(true)),
+		conc.NewPool[any](paramtable.Get().QueryNodeCfg.MaxGpuReadConcurrency.GetAsInt(), conc.WithPreAlloc(true)),
+		&schedulerCounter{},
+	)
+}
+
+// newSchedulerWithPool create a scheduler executing the tasks in the given pools,
+// the schedulers sharing the counter limit and report the waiting tasks of them all.
+func newSchedulerWithPool(policy schedulePolicy, pool *conc.Pool[any], gpuPool *conc.Pool[any], counter *schedulerCounter) *scheduler {
 	return &scheduler{
 		policy:           policy,
-		receiveChan:      make(chan addTaskReq, maxReceiveChanSize),
+		receiveChan:      make(chan addTaskReq, paramtable.Get().QueryNodeCfg.MaxReceiveChanSize.GetAsInt()),
 		execChan:         make(chan Task),
-		pool:             conc.NewPool[any](maxReadConcurrency, conc.WithPreAlloc(true)),
-		gpuPool:          conc.NewPool[any](paramtable.Get().QueryNodeCfg.MaxGpuReadConcurrency.GetAsInt(), conc.WithPreAlloc(true)),
-		schedulerCounter: schedulerCounter{},
+		pool:             pool,
+		gpuPool:          gpuPool,
+		schedulerCounter: counter,
 		lifetime:         lifetime.NewLifetime(lifetime.Initializing),
 	}
 }
@@ -54,7 +63,7 @@ type scheduler struct {
 	// lifetime controls scheduler State & make sure all requests accepted will be processed
 	lifetime lifetime.Lifetime[lifetime.State]
 
-	schedulerCounter
+	*schedulerCounter
 }
 
 // Add a new task into scheduler,
@@ -117,7 +117,7 @@ func (s *SchedulerSuite) TestConsumeRecvChan() {
 			receiveChan:      ch,
 			execChan:         make(chan Task),
 			pool:             conc.NewPool[any](10, conc.WithPreAlloc(true)),
-			schedulerCounter: schedulerCounter{},
+			schedulerCounter: &schedulerCounter{},
 			lifetime:         lifetime.NewLifetime(lifetime.Initializing),
 		}
 
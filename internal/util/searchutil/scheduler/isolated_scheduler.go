@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+)
+
+var _ Scheduler = &isolatedScheduler{}
+
+// isolatedScheduler schedules the tasks of the isolated collections in their own schedulers,
+// whose concurrency matches the dedicated cgo executors of the collections,
+// so they neither queue behind nor share the read concurrency with the tasks of the other collections.
+// The tasks of the other collections are scheduled by the shared scheduler.
+type isolatedScheduler struct {
+	shared            *scheduler
+	policyName        string
+	isolatedThreadNum func(collectionID int64) int
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	// the schedulers are kept once created, the collection goes back to the shared scheduler if it's not isolated anymore
+	schedulers map[int64]*scheduler
+}
+
+func newIsolatedScheduler(shared *scheduler, policyName string, isolatedThreadNum func(collectionID int64) int) *isolatedScheduler {
+	return &isolatedScheduler{
+		shared:            shared,
+		policyName:        policyName,
+		isolatedThreadNum: isolatedThreadNum,
+		schedulers:        make(map[int64]*scheduler),
+	}
+}
+
+// Add a new task into the scheduler of the collection of the task.
+func (s *isolatedScheduler) Add(task Task) error {
+	if sched := s.getScheduler(task.CollectionID()); sched != nil {
+		return sched.Add(task)
+	}
+	return s.shared.Add(task)
+}
+
+// getScheduler returns the scheduler of the isolated collection, creates it if not exist,
+// nil if the collection is not isolated or the scheduler is not working.
+func (s *isolatedScheduler) getScheduler(collectionID int64) *scheduler {
+	threadNum := s.isolatedThreadNum(collectionID)
+	if threadNum <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started || s.stopped {
+		return nil
+	}
+	sched, ok := s.schedulers[collectionID]
+	if !ok {
+		log.Info("create the isolated scheduler of collection", zap.Int64("collectionID", collectionID), zap.Int("concurrency", threadNum))
+		sched = newSchedulerWithPool(newSchedulePolicy(s.policyName), conc.NewPool[any](threadNum), s.shared.gpuPool, s.shared.schedulerCounter)
+		sched.Start()
+		s.schedulers[collectionID] = sched
+	} else if sched.pool.Cap() != threadNum {
+		log.Info("resize the isolated scheduler of collection", zap.Int64("collectionID", collectionID), zap.Int("concurrency", threadNum))
+		if err := sched.pool.Resize(threadNum); err != nil {
+			log.Warn("failed to resize the isolated scheduler", zap.Int64("collectionID", collectionID), zap.Error(err))
+		}
+	}
+	return sched
+}
+
+func (s *isolatedScheduler) Start() {
+	s.shared.Start()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = true
+}
+
+func (s *isolatedScheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+
+	// the schedulers map is not changed once stopped
+	for _, sched := range s.schedulers {
+		sched.Stop()
+	}
+	s.shared.Stop()
+}
+
+// GetWaitingTaskTotalNQ returns the waiting NQ of all the schedulers, they share the counter.
+func (s *isolatedScheduler) GetWaitingTaskTotalNQ() int64 {
+	return s.shared.GetWaitingTaskTotalNQ()
+}
+
+// GetWaitingTaskTotal returns the waiting tasks of all the schedulers, they share the counter.
+func (s *isolatedScheduler) GetWaitingTaskTotal() int64 {
+	return s.shared.GetWaitingTaskTotal()
+}
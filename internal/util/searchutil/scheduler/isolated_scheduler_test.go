@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestIsolatedScheduler(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.MaxReadConcurrency.Key, "1")
+	defer params.Reset(params.QueryNodeCfg.MaxReadConcurrency.Key)
+
+	threadNums := map[int64]int{1: 2}
+	s := NewScheduler(schedulePolicyNameFIFO, func(collectionID int64) int {
+		return threadNums[collectionID]
+	}).(*isolatedScheduler)
+	s.Start()
+	defer s.Stop()
+
+	// occupy the shared scheduler
+	block := make(chan struct{})
+	blocking := newMockTask(mockTaskConfig{
+		collectionID: 2,
+		executeCost:  time.Millisecond,
+		execution: func(ctx context.Context) error {
+			<-block
+			return nil
+		},
+	})
+	assert.NoError(t, s.Add(blocking))
+
+	// the isolated collection doesn't queue behind the shared scheduler
+	isolated := newMockTask(mockTaskConfig{collectionID: 1, executeCost: time.Millisecond})
+	assert.NoError(t, s.Add(isolated))
+	assert.NoError(t, isolated.Wait())
+	assert.Len(t, s.schedulers, 1)
+	assert.Equal(t, 2, s.schedulers[1].pool.Cap())
+
+	shared := newMockTask(mockTaskConfig{collectionID: 2, executeCost: time.Millisecond})
+	assert.NoError(t, s.Add(shared))
+	close(block)
+	assert.NoError(t, blocking.Wait())
+	assert.NoError(t, shared.Wait())
+
+	// resized
+	threadNums[1] = 4
+	task := newMockTask(mockTaskConfig{collectionID: 1, executeCost: time.Millisecond})
+	assert.NoError(t, s.Add(task))
+	assert.NoError(t, task.Wait())
+	assert.Equal(t, 4, s.schedulers[1].pool.Cap())
+
+	// back to the shared scheduler
+	delete(threadNums, 1)
+	task = newMockTask(mockTaskConfig{collectionID: 1, executeCost: time.Millisecond})
+	assert.NoError(t, s.Add(task))
+	assert.NoError(t, task.Wait())
+	assert.EqualValues(t, 0, s.GetWaitingTaskTotal())
+}
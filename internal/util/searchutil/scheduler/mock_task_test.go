@@ -15,12 +15,13 @@ var (
 )
 
 type mockTaskConfig struct {
-	ctx         context.Context
-	mergeAble   bool
-	nq          int64
-	username    string
-	executeCost time.Duration
-	execution   func(ctx context.Context) error
+	ctx          context.Context
+	mergeAble    bool
+	nq           int64
+	username     string
+	collectionID int64
+	executeCost  time.Duration
+	execution    func(ctx context.Context) error
 }
 
 func newMockTask(c mockTaskConfig) Task {
@@ -34,26 +35,28 @@ func newMockTask(c mockTaskConfig) Task {
 		c.executeCost = time.Duration((rand.Int31n(4) + 1) * int32(time.Second))
 	}
 	return &MockTask{
-		ctx:         c.ctx,
-		executeCost: c.executeCost,
-		notifier:    make(chan error, 1),
-		mergeAble:   c.mergeAble,
-		nq:          c.nq,
-		username:    c.username,
-		execution:   c.execution,
-		tr:          timerecord.NewTimeRecorderWithTrace(c.ctx, "searchTask"),
+		ctx:          c.ctx,
+		executeCost:  c.executeCost,
+		notifier:     make(chan error, 1),
+		mergeAble:    c.mergeAble,
+		nq:           c.nq,
+		username:     c.username,
+		collectionID: c.collectionID,
+		execution:    c.execution,
+		tr:           timerecord.NewTimeRecorderWithTrace(c.ctx, "searchTask"),
 	}
 }
 
 type MockTask struct {
-	ctx         context.Context
-	executeCost time.Duration
-	notifier    chan error
-	mergeAble   bool
-	nq          int64
-	username    string
-	execution   func(ctx context.Context) error
-	tr          *timerecord.TimeRecorder
+	ctx          context.Context
+	executeCost  time.Duration
+	notifier     chan error
+	mergeAble    bool
+	nq           int64
+	username     string
+	collectionID int64
+	execution    func(ctx context.Context) error
+	tr           *timerecord.TimeRecorder
 }
 
 // QueryTypeMetricLabel Return Metric label for metric label.
@@ -65,6 +68,10 @@ func (t *MockTask) Username() string {
 	return t.username
 }
 
+func (t *MockTask) CollectionID() int64 {
+	return t.collectionID
+}
+
 func (t *MockTask) IsGpuIndex() bool {
 	return false
 }
@@ -7,19 +7,24 @@ const (
 	schedulePolicyNameUserTaskPolling = "user-task-polling"
 )
 
-// NewScheduler create a scheduler by policyName.
-func NewScheduler(policyName string) Scheduler {
+// NewScheduler create a scheduler by policyName,
+// isolatedThreadNum returns the concurrency of the collection scheduled apart from the others, 0 if it's not.
+func NewScheduler(policyName string, isolatedThreadNum func(collectionID int64) int) Scheduler {
+	shared := newScheduler(newSchedulePolicy(policyName))
+	if isolatedThreadNum == nil {
+		return shared
+	}
+	return newIsolatedScheduler(shared, policyName, isolatedThreadNum)
+}
+
+func newSchedulePolicy(policyName string) schedulePolicy {
 	switch policyName {
 	case "":
 		fallthrough
 	case schedulePolicyNameFIFO:
-		return newScheduler(
-			newFIFOPolicy(),
-		)
+		return newFIFOPolicy()
 	case schedulePolicyNameUserTaskPolling:
-		return newScheduler(
-			newUserTaskPollingPolicy(),
-		)
+		return newUserTaskPollingPolicy()
 	default:
 		panic("invalid schedule task policy")
 	}
@@ -87,6 +92,9 @@ type Task interface {
 	// Return whether the task would be running on GPU.
 	IsGpuIndex() bool
 
+	// Return the collection which task is belong to.
+	CollectionID() int64
+
 	// PreExecute the task, only call once.
 	PreExecute() error
 
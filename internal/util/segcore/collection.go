@@ -16,6 +16,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
 // CreateCCollectionRequest is a request to create a CCollection.
@@ -47,6 +48,7 @@ func CreateCCollection(req *CreateCCollectionRequest) (*CCollection, error) {
 		ptr:          ptr,
 		schema:       req.Schema,
 		indexMeta:    req.IndexMeta,
+		plans:        newPlanCache(paramtable.Get().QueryNodeCfg.PlanCacheCapacity.GetAsInt()),
 	}, nil
 }
 
@@ -57,6 +59,7 @@ type CCollection struct {
 	collectionID int64
 	schema       *schemapb.CollectionSchema
 	indexMeta    *segcorepb.CollectionIndexMeta
+	plans        *planCache
 }
 
 // ID returns the collection ID.
@@ -77,8 +80,16 @@ func (c *CCollection) IndexMeta() *segcorepb.CollectionIndexMeta {
 	return c.indexMeta
 }
 
+// PurgePlanCache deletes the plans cached, which are bound to the schema of the collection.
+func (c *CCollection) PurgePlanCache() {
+	if c.plans != nil {
+		c.plans.purge()
+	}
+}
+
 // Release releases the underlying collection
 func (c *CCollection) Release() {
+	c.PurgePlanCache()
 	C.DeleteCollection(c.ptr)
 	c.ptr = nil
 }
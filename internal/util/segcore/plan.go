@@ -40,15 +40,37 @@ import (
 // SearchPlan is a wrapper of the underlying C-structure C.CSearchPlan
 type SearchPlan struct {
 	cSearchPlan C.CSearchPlan
+	// the plan shared by the requests if cached
+	cache  *planCache
+	cached *cachedPlan
 }
 
 func createSearchPlanByExpr(col *CCollection, expr []byte) (*SearchPlan, error) {
-	var cPlan C.CSearchPlan
-	status := C.CreateSearchPlanByExpr(col.rawPointer(), unsafe.Pointer(&expr[0]), (C.int64_t)(len(expr)), &cPlan)
-	if err := ConsumeCStatusIntoError(&status); err != nil {
-		return nil, errors.Wrap(err, "Create Plan by expr failed")
+	create := func() (C.CSearchPlan, error) {
+		var cPlan C.CSearchPlan
+		status := C.CreateSearchPlanByExpr(col.rawPointer(), unsafe.Pointer(&expr[0]), (C.int64_t)(len(expr)), &cPlan)
+		if err := ConsumeCStatusIntoError(&status); err != nil {
+			return nil, errors.Wrap(err, "Create Plan by expr failed")
+		}
+		return cPlan, nil
+	}
+
+	cache := planCacheOf(col)
+	if cache == nil {
+		cPlan, err := create()
+		if err != nil {
+			return nil, err
+		}
+		return &SearchPlan{cSearchPlan: cPlan}, nil
 	}
-	return &SearchPlan{cSearchPlan: cPlan}, nil
+	cached, err := cache.acquire(searchPlanKeyPrefix+string(expr), func() (*cachedPlan, error) {
+		cPlan, err := create()
+		return &cachedPlan{search: cPlan}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SearchPlan{cSearchPlan: cached.search, cache: cache, cached: cached}, nil
 }
 
 func (plan *SearchPlan) GetTopK() int64 {
@@ -70,6 +92,10 @@ func (plan *SearchPlan) GetMetricType() string {
 }
 
 func (plan *SearchPlan) delete() {
+	if plan.cached != nil {
+		plan.cache.release(plan.cached)
+		return
+	}
 	C.DeleteSearchPlan(plan.cSearchPlan)
 }
 
@@ -152,24 +178,47 @@ type RetrievePlan struct {
 	msgID         int64 // only used to debug.
 	maxLimitSize  int64
 	ignoreNonPk   bool
+	// the plan shared by the requests if cached
+	cache  *planCache
+	cached *cachedPlan
 }
 
 func NewRetrievePlan(col *CCollection, expr []byte, timestamp typeutil.Timestamp, msgID int64) (*RetrievePlan, error) {
 	if col.rawPointer() == nil {
 		return nil, errors.New("collection is released")
 	}
-	var cPlan C.CRetrievePlan
-	status := C.CreateRetrievePlanByExpr(col.rawPointer(), unsafe.Pointer(&expr[0]), (C.int64_t)(len(expr)), &cPlan)
-	if err := ConsumeCStatusIntoError(&status); err != nil {
-		return nil, errors.Wrap(err, "Create retrieve plan by expr failed")
-	}
-	maxLimitSize := paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64()
-	return &RetrievePlan{
-		cRetrievePlan: cPlan,
-		Timestamp:     timestamp,
-		msgID:         msgID,
-		maxLimitSize:  maxLimitSize,
-	}, nil
+	create := func() (C.CRetrievePlan, error) {
+		var cPlan C.CRetrievePlan
+		status := C.CreateRetrievePlanByExpr(col.rawPointer(), unsafe.Pointer(&expr[0]), (C.int64_t)(len(expr)), &cPlan)
+		if err := ConsumeCStatusIntoError(&status); err != nil {
+			return nil, errors.Wrap(err, "Create retrieve plan by expr failed")
+		}
+		return cPlan, nil
+	}
+
+	plan := &RetrievePlan{
+		Timestamp:    timestamp,
+		msgID:        msgID,
+		maxLimitSize: paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64(),
+	}
+	cache := planCacheOf(col)
+	if cache == nil {
+		cPlan, err := create()
+		if err != nil {
+			return nil, err
+		}
+		plan.cRetrievePlan = cPlan
+		return plan, nil
+	}
+	cached, err := cache.acquire(retrievePlanKeyPrefix+string(expr), func() (*cachedPlan, error) {
+		cPlan, err := create()
+		return &cachedPlan{retrieve: cPlan}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	plan.cRetrievePlan, plan.cache, plan.cached = cached.retrieve, cache, cached
+	return plan, nil
 }
 
 func (plan *RetrievePlan) ShouldIgnoreNonPk() bool {
@@ -189,5 +238,9 @@ func (plan *RetrievePlan) MsgID() int64 {
 }
 
 func (plan *RetrievePlan) Delete() {
+	if plan.cached != nil {
+		plan.cache.release(plan.cached)
+		return
+	}
 	C.DeleteRetrievePlan(plan.cRetrievePlan)
 }
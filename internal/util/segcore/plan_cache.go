@@ -0,0 +1,138 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segcore
+
+/*
+#cgo pkg-config: milvus_core
+
+#include "segcore/plan_c.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	searchPlanKeyPrefix   = "s"
+	retrievePlanKeyPrefix = "r"
+)
+
+// planCache caches the search and retrieve plans created from the serialized plans of a collection,
+// so the requests with the same plan, e.g. the searches with the same filter and different vectors,
+// skip parsing and creating the plan in segcore.
+// The cached plans are shared by the concurrent requests and reference counted,
+// a plan evicted is deleted once the last request using it done.
+// The plans are bound to the schema of the CCollection, so the cache is purged once the schema changed.
+type planCache struct {
+	mu  sync.Mutex
+	lru *simplelru.LRU[string, *cachedPlan]
+}
+
+type cachedPlan struct {
+	search   C.CSearchPlan
+	retrieve C.CRetrievePlan
+	refs     int
+	evicted  bool
+}
+
+func (plan *cachedPlan) delete() {
+	if plan.search != nil {
+		C.DeleteSearchPlan(plan.search)
+	}
+	if plan.retrieve != nil {
+		C.DeleteRetrievePlan(plan.retrieve)
+	}
+}
+
+// newPlanCache returns nil if the capacity is not positive.
+func newPlanCache(capacity int) *planCache {
+	if capacity <= 0 {
+		return nil
+	}
+	lru, _ := simplelru.NewLRU[string, *cachedPlan](capacity, func(_ string, plan *cachedPlan) {
+		plan.evicted = true
+		if plan.refs == 0 {
+			plan.delete()
+		}
+	})
+	return &planCache{lru: lru}
+}
+
+// acquire returns the cached plan of the key, or the plan created by create and caches it,
+// the plan returned must be released after used.
+func (c *planCache) acquire(key string, create func() (*cachedPlan, error)) (*cachedPlan, error) {
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	c.mu.Lock()
+	if plan, ok := c.lru.Get(key); ok {
+		plan.refs++
+		c.mu.Unlock()
+		metrics.QueryNodePlanCacheCounter.WithLabelValues(nodeID, metrics.CacheHitLabel).Inc()
+		return plan, nil
+	}
+	c.mu.Unlock()
+	metrics.QueryNodePlanCacheCounter.WithLabelValues(nodeID, metrics.CacheMissLabel).Inc()
+
+	// create the plan without the lock, the cgo call may be slow
+	plan, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the same plan created and cached concurrently
+	if cached, ok := c.lru.Peek(key); ok {
+		plan.delete()
+		cached.refs++
+		return cached, nil
+	}
+	plan.refs++
+	c.lru.Add(key, plan)
+	return plan, nil
+}
+
+// release releases the plan acquired, deletes it if evicted and not used by any request.
+func (c *planCache) release(plan *cachedPlan) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	plan.refs--
+	if plan.refs == 0 && plan.evicted {
+		plan.delete()
+	}
+}
+
+// purge evicts all the cached plans.
+func (c *planCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Purge()
+}
+
+// planCacheOf returns the plan cache of the collection, nil if the plan cache disabled.
+func planCacheOf(col *CCollection) *planCache {
+	if col.plans == nil || !paramtable.Get().QueryNodeCfg.PlanCacheEnabled.GetAsBool() {
+		return nil
+	}
+	return col.plans
+}
@@ -17,8 +17,10 @@
 package segcore_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/proto"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/util/segcore"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -81,6 +84,31 @@ func (suite *PlanSuite) TestQueryPlanCollectionReleased() {
 	suite.Error(err)
 }
 
+func (suite *PlanSuite) TestRetrievePlanCache() {
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.PlanCacheEnabled.Key, "true")
+	defer params.Reset(params.QueryNodeCfg.PlanCacheEnabled.Key)
+
+	hits := metrics.QueryNodePlanCacheCounter.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.CacheHitLabel)
+	hitCount := testutil.ToFloat64(hits)
+	plan1, err := mock_segcore.GenSimpleRetrievePlan(suite.collection)
+	suite.NoError(err)
+	plan2, err := mock_segcore.GenSimpleRetrievePlan(suite.collection)
+	suite.NoError(err)
+	suite.Equal(hitCount+1, testutil.ToFloat64(hits))
+
+	plan1.Delete()
+	// the plan purged is still usable until the last request done
+	suite.collection.PurgePlanCache()
+	suite.True(plan2.ShouldIgnoreNonPk())
+	plan2.Delete()
+
+	plan3, err := mock_segcore.GenSimpleRetrievePlan(suite.collection)
+	suite.NoError(err)
+	suite.Equal(hitCount+1, testutil.ToFloat64(hits))
+	plan3.Delete()
+}
+
 func TestPlan(t *testing.T) {
 	paramtable.Init()
 	suite.Run(t, new(PlanSuite))
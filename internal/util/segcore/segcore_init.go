@@ -3,11 +3,14 @@ package segcore
 /*
 #cgo pkg-config: milvus_core
 
+#include <stdlib.h>
 #include "segcore/segcore_init_c.h"
 
 */
 import "C"
 
+import "unsafe"
+
 // IndexEngineInfo contains all the information about the index engine.
 type IndexEngineInfo struct {
 	MinIndexVersion     int32
@@ -22,3 +25,11 @@ func GetIndexEngineInfo() IndexEngineInfo {
 		CurrentIndexVersion: int32(cCurrent),
 	}
 }
+
+// DumpThreadStacks returns the native stacks of the threads running the segcore calls, i.e. the threads of the cgo pools,
+// it's for debugging the stuck segcore calls, which are invisible in the goroutine stacks.
+func DumpThreadStacks() string {
+	cStacks := C.SegcoreDumpThreadStacks()
+	defer C.free(unsafe.Pointer(cStacks))
+	return C.GoString(cStacks)
+}
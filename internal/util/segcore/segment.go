@@ -69,13 +69,15 @@ func CreateCSegment(req *CreateCSegmentRequest) (CSegment, error) {
 	if err := ConsumeCStatusIntoError(&status); err != nil {
 		return nil, err
 	}
-	return &cSegmentImpl{id: req.SegmentID, ptr: ptr}, nil
+	return &cSegmentImpl{id: req.SegmentID, collectionID: req.Collection.ID(), ptr: ptr}, nil
 }
 
 // cSegmentImpl is a wrapper for cSegmentImplInterface.
 type cSegmentImpl struct {
-	id  int64
-	ptr C.CSegmentInterface
+	id int64
+	// collectionID selects the cgo executor the searches and queries run on
+	collectionID int64
+	ptr          C.CSegmentInterface
 }
 
 // ID returns the ID of the segment.
@@ -120,6 +122,7 @@ func (s *cSegmentImpl) Search(ctx context.Context, searchReq *SearchRequest) (*S
 				searchReq.plan.cSearchPlan,
 				searchReq.cPlaceholderGroup,
 				C.uint64_t(searchReq.mvccTimestamp),
+				C.int64_t(s.collectionID),
 			))
 		},
 		cgo.WithName("search"),
@@ -148,6 +151,7 @@ func (s *cSegmentImpl) Retrieve(ctx context.Context, plan *RetrievePlan) (*Retri
 				C.uint64_t(plan.Timestamp),
 				C.int64_t(plan.maxLimitSize),
 				C.bool(plan.ignoreNonPk),
+				C.int64_t(s.collectionID),
 			))
 		},
 		cgo.WithName("retrieve"),
@@ -180,6 +184,7 @@ func (s *cSegmentImpl) RetrieveByOffsets(ctx context.Context, plan *RetrievePlan
 				plan.cRetrievePlan,
 				(*C.int64_t)(unsafe.Pointer(&plan.Offsets[0])),
 				C.int64_t(len(plan.Offsets)),
+				C.int64_t(s.collectionID),
 			))
 		},
 		cgo.WithName("retrieve-by-offsets"),
@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessionutil
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const fencingEpochPrefix = "fencing"
+
+// EtcdEpochStore saves the highest fencing epoch of a coordinator role in etcd.
+// The epoch is shared by all the worker nodes, so a node restarted after a leadership change
+// rejects the stale coordinator even before it sees a request from the new one.
+type EtcdEpochStore struct {
+	client *clientv3.Client
+	key    string
+}
+
+// NewEtcdEpochStore creates the epoch store of the coordinator role under the meta root.
+func NewEtcdEpochStore(client *clientv3.Client, metaRoot string, role string) *EtcdEpochStore {
+	return &EtcdEpochStore{
+		client: client,
+		key:    path.Join(metaRoot, fencingEpochPrefix, role),
+	}
+}
+
+// Load returns the saved epoch, 0 if none is saved.
+func (s *EtcdEpochStore) Load(ctx context.Context) (int64, error) {
+	epoch, _, err := s.get(ctx)
+	return epoch, err
+}
+
+// Save saves the epoch unless a higher one is saved already, returns the highest epoch saved.
+func (s *EtcdEpochStore) Save(ctx context.Context, epoch int64) (int64, error) {
+	for {
+		saved, revision, err := s.get(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if saved >= epoch {
+			return saved, nil
+		}
+		// the mod revision of a missing key is 0
+		resp, err := s.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(s.key), "=", revision)).
+			Then(clientv3.OpPut(s.key, strconv.FormatInt(epoch, 10))).
+			Commit()
+		if err != nil {
+			return 0, err
+		}
+		if resp.Succeeded {
+			return epoch, nil
+		}
+	}
+}
+
+func (s *EtcdEpochStore) get(ctx context.Context) (int64, int64, error) {
+	resp, err := s.client.Get(ctx, s.key)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, 0, nil
+	}
+	epoch, err := strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid fencing epoch at %s", s.key)
+	}
+	return epoch, resp.Kvs[0].ModRevision, nil
+}
@@ -148,6 +148,47 @@ func (_c *MockSession_GetAddress_Call) RunAndReturn(run func() string) *MockSess
 	return _c
 }
 
+// GetEpoch provides a mock function with given fields:
+func (_m *MockSession) GetEpoch() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// MockSession_GetEpoch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEpoch'
+type MockSession_GetEpoch_Call struct {
+	*mock.Call
+}
+
+// GetEpoch is a helper method to define mock.On call
+func (_e *MockSession_Expecter) GetEpoch() *MockSession_GetEpoch_Call {
+	return &MockSession_GetEpoch_Call{Call: _e.mock.On("GetEpoch")}
+}
+
+func (_c *MockSession_GetEpoch_Call) Run(run func()) *MockSession_GetEpoch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSession_GetEpoch_Call) Return(_a0 int64) *MockSession_GetEpoch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSession_GetEpoch_Call) RunAndReturn(run func() int64) *MockSession_GetEpoch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetServerID provides a mock function with given fields:
 func (_m *MockSession) GetServerID() int64 {
 	ret := _m.Called()
@@ -49,5 +49,6 @@ type SessionInterface interface {
 
 	GetAddress() string
 	GetServerID() int64
+	GetEpoch() int64
 	IsTriggerKill() bool
 }
@@ -50,6 +50,8 @@ const (
 	// DefaultIDKey default id key for Session
 	DefaultIDKey         = "id"
 	SupportedLabelPrefix = "MILVUS_SERVER_LABEL_"
+	// LabelReadOnly is the server label of the read-only query nodes
+	LabelReadOnly = "READ_ONLY"
 )
 
 // SessionEventType session event type
@@ -153,6 +155,9 @@ type Session struct {
 	isStandby           atomic.Value
 	enableActiveStandBy bool
 	activeKey           string
+	// epoch is the etcd revision at which this session took the leadership of an exclusive service,
+	// it's used as the fencing epoch to reject the requests from a stale coordinator.
+	epoch atomic.Int64
 
 	sessionTTL        int64
 	sessionRetryTimes int64
@@ -351,6 +356,9 @@ func GetServerLabelsFromEnv(role string) map[string]string {
 				ret[label] = value
 			}
 		}
+		if paramtable.Get().QueryNodeCfg.ReadOnlyEnabled.GetAsBool() {
+			ret[LabelReadOnly] = "true"
+		}
 	}
 	return ret
 }
@@ -489,6 +497,9 @@ func (s *Session) registerService() (<-chan *clientv3.LeaseKeepAliveResponse, er
 			s.handleRestart(completeKey)
 			return fmt.Errorf("function CompareAndSwap error for compare is false for key: %s", s.ServerName)
 		}
+		if s.Exclusive && !s.enableActiveStandBy {
+			s.epoch.Store(txnResp.Header.GetRevision())
+		}
 		log.Info("put session key into etcd", zap.String("key", completeKey), zap.String("value", string(sessionJSON)))
 
 		keepAliveCtx, keepAliveCancel := context.WithCancel(context.Background())
@@ -1026,6 +1037,12 @@ func (s *Session) updateStandby(b bool) {
 	s.isStandby.Store(b)
 }
 
+// GetEpoch returns the fencing epoch of the session, which is the etcd revision
+// at which the session became the leader, 0 if it's not the leader yet.
+func (s *Session) GetEpoch() int64 {
+	return s.epoch.Load()
+}
+
 func (s *Session) safeCloseLiveCh() {
 	s.liveChOnce.Do(func() {
 		close(s.liveCh)
@@ -1073,6 +1090,7 @@ func (s *Session) ProcessActiveStandBy(activateFunc func() error) error {
 		}
 		doRegistered := txnResp.Succeeded
 		if doRegistered {
+			s.epoch.Store(txnResp.Header.GetRevision())
 			log.Info(fmt.Sprintf("register ACTIVE %s", s.ServerName))
 		} else {
 			log.Info(fmt.Sprintf("ACTIVE %s has already been registered", s.ServerName))
@@ -1183,6 +1201,7 @@ func (s *Session) ForceActiveStandby(activateFunc func() error) error {
 			return errors.New(msg)
 		}
 
+		s.epoch.Store(resp.Header.GetRevision())
 		log.Info(fmt.Sprintf("force register ACTIVE %s", s.ServerName))
 		return nil
 	}
@@ -629,6 +629,41 @@ func TestSessionWithVersionRange(t *testing.T) {
 	suite.Run(t, new(SessionWithVersionSuite))
 }
 
+func TestEtcdEpochStore(t *testing.T) {
+	ctx := context.Background()
+	paramtable.Init()
+	params := paramtable.Get()
+	endpoints := params.EtcdCfg.Endpoints.GetValue()
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+
+	etcdCli, err := etcd.GetRemoteEtcdClient(strings.Split(endpoints, ","))
+	require.NoError(t, err)
+	etcdKV := etcdkv.NewEtcdKV(etcdCli, metaRoot)
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix(ctx, "")
+
+	store := NewEtcdEpochStore(etcdCli, metaRoot, typeutil.QueryCoordRole)
+	epoch, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), epoch)
+
+	epoch, err = store.Save(ctx, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), epoch)
+	// a lower epoch doesn't overwrite the saved one
+	epoch, err = store.Save(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), epoch)
+
+	// shared by the stores of the same role, and survives the restart
+	epoch, err = NewEtcdEpochStore(etcdCli, metaRoot, typeutil.QueryCoordRole).Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), epoch)
+	epoch, err = NewEtcdEpochStore(etcdCli, metaRoot, typeutil.DataCoordRole).Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), epoch)
+}
+
 func TestSessionProcessActiveStandBy(t *testing.T) {
 	ctx := context.TODO()
 	// initial etcd
@@ -671,6 +706,7 @@ func TestSessionProcessActiveStandBy(t *testing.T) {
 		s1.cancelKeepAlive()
 	})
 	assert.False(t, s1.isStandby.Load().(bool))
+	assert.Greater(t, s1.GetEpoch(), int64(0))
 
 	// register session 2, will be standby
 	ctx2 := context.Background()
@@ -685,6 +721,7 @@ func TestSessionProcessActiveStandBy(t *testing.T) {
 		return nil
 	})
 	assert.True(t, s2.isStandby.Load().(bool))
+	assert.Equal(t, int64(0), s2.GetEpoch())
 
 	// assert.True(t, s2.watchingPrimaryKeyLock)
 	// stop session 1, session 2 will take over primary service
@@ -710,6 +747,7 @@ func TestSessionProcessActiveStandBy(t *testing.T) {
 	wg.Wait()
 	log.Debug("session s2 wait done")
 	assert.False(t, s2.isStandby.Load().(bool))
+	assert.Greater(t, s2.GetEpoch(), s1.GetEpoch())
 	s2.Stop()
 }
 
@@ -977,6 +1015,7 @@ func (s *SessionSuite) TestForceActiveWithLeaseID() {
 	s.NotNil(sess)
 	s.Equal(sess.Address, "normal2")
 	s.Equal(sess.ServerID, sess2.ServerID)
+	s.Greater(sess2.GetEpoch(), sess1.GetEpoch())
 }
 
 func (s *SessionSuite) TestForceActiveWithDelete() {
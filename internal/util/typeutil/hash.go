@@ -8,15 +8,15 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
-// HashKey2Partitions hash partition keys to partitions
-func HashKey2Partitions(fieldSchema *schemapb.FieldSchema, keys []*planpb.GenericValue, partitionNames []string) ([]string, error) {
+// HashKey2Partitions hash partition keys to partitions by the hash function, see common.PartitionKeyHashFunctionKey
+func HashKey2Partitions(fieldSchema *schemapb.FieldSchema, keys []*planpb.GenericValue, partitionNames []string, hashFunction string) ([]string, error) {
 	selectedPartitions := make(map[string]struct{})
 	numPartitions := uint32(len(partitionNames))
 	switch fieldSchema.GetDataType() {
 	case schemapb.DataType_Int64:
 		for _, key := range keys {
 			if int64Val, ok := key.GetVal().(*planpb.GenericValue_Int64Val); ok {
-				value, _ := typeutil.Hash32Int64(int64Val.Int64Val)
+				value := typeutil.HashInt64PartitionKey(int64Val.Int64Val, hashFunction)
 				partitionName := partitionNames[value%numPartitions]
 				selectedPartitions[partitionName] = struct{}{}
 			} else {
@@ -26,7 +26,7 @@ func HashKey2Partitions(fieldSchema *schemapb.FieldSchema, keys []*planpb.Generi
 	case schemapb.DataType_VarChar:
 		for _, key := range keys {
 			if stringVal, ok := key.GetVal().(*planpb.GenericValue_StringVal); ok {
-				value := typeutil.HashString2Uint32(stringVal.StringVal)
+				value := typeutil.HashStringPartitionKey(stringVal.StringVal, hashFunction)
 				partitionName := partitionNames[value%numPartitions]
 				selectedPartitions[partitionName] = struct{}{}
 			} else {
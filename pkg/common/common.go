@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
@@ -142,6 +143,17 @@ const (
 	HintsKey                  = "hints"
 )
 
+// Filter strategies of search, set in hints of a search request or in the
+// collection.search.filterStrategy collection property
+const (
+	// FilterStrategyIterative searches the index first and filters the candidates iteratively
+	FilterStrategyIterative = "iterative_filter"
+	// FilterStrategyBruteForce filters first and searches the remaining raw vectors by brute force
+	FilterStrategyBruteForce = "brute_force"
+	// FilterStrategyAuto filters first and chooses brute force per segment when few rows remain
+	FilterStrategyAuto = "auto"
+)
+
 // Doc-in-doc-out
 const (
 	EnableAnalyzerKey = `enable_analyzer`
@@ -153,6 +165,28 @@ const (
 const (
 	CollectionTTLConfigKey      = "collection.ttl.seconds"
 	CollectionAutoCompactionKey = "collection.autocompaction.enabled"
+	// CollectionMaintenanceWindowKey restricts automatic compaction and index builds to the given windows,
+	// see ParseMaintenanceWindows for the format
+	CollectionMaintenanceWindowKey = "collection.maintenance.window"
+	// CollectionSearchFilterStrategyKey is the default filter strategy of searches without hints
+	CollectionSearchFilterStrategyKey = "collection.search.filterStrategy"
+	// CollectionSearchTimeoutKey is the default timeout in milliseconds of searches and queries without deadline
+	CollectionSearchTimeoutKey = "collection.search.timeout.ms"
+	// CollectionSearchIgnoreGrowingKey skips the growing segments in the searches without ignore_growing set,
+	// only the sealed data is searched then
+	CollectionSearchIgnoreGrowingKey = "collection.search.ignoreGrowing"
+	// CollectionBloomFilterSizeKey is the capacity of the pk bloom filters of the growing segments,
+	// overrides common.bloomFilterSize
+	CollectionBloomFilterSizeKey = "collection.bloomFilter.size"
+	// CollectionBloomFilterFalsePositiveKey is the false positive rate of the pk bloom filters,
+	// overrides common.maxBloomFalsePositive
+	CollectionBloomFilterFalsePositiveKey = "collection.bloomFilter.falsePositive"
+	// CollectionJSONKeySchemaKey is the key paths and value types of the JSON fields learned from the flushed rows,
+	// maintained by datacoord
+	CollectionJSONKeySchemaKey = "collection.json.keySchema"
+	// CollectionReplicaTopologyKey is the replicas serving the loaded collection, their resource groups and shard leaders in JSON,
+	// returned by DescribeCollection once the proxy has cached the shard leaders, it can't be set by the users
+	CollectionReplicaTopologyKey = "collection.replica.topology"
 
 	// rate limit
 	CollectionInsertRateMaxKey   = "collection.insertRate.max.mb"
@@ -191,6 +225,20 @@ const (
 	PartitionKeyIsolationKey   = "partitionkey.isolation"
 	FieldSkipLoadKey           = "field.skipLoad"
 	IndexOffsetCacheEnabledKey = "indexoffsetcache.enabled"
+	// PartitionKeyHashFunctionKey is the hash function mapping the partition keys to the partitions,
+	// it can only be set at the collection creation, see PartitionKeyHashFunction.
+	PartitionKeyHashFunctionKey = "partitionkey.hashFunction"
+	// IndexResourceGroupsKey binds an index to the resource groups, whose replicas serve it instead of the default index of the field.
+	// A replica keeps serving the index it loaded until the collection is released and loaded again.
+	IndexResourceGroupsKey = "index.resource_groups"
+)
+
+// partition key hash functions
+const (
+	// PartitionKeyHashMurmur3 hashes both the int64 and varchar partition keys by murmur3
+	PartitionKeyHashMurmur3 = "murmur3"
+	// PartitionKeyHashXXHash hashes both the int64 and varchar partition keys by xxhash64
+	PartitionKeyHashXXHash = "xxhash"
 )
 
 const (
@@ -222,6 +270,22 @@ func IsMmapIndexEnabled(kvs ...*commonpb.KeyValuePair) (bool, bool) {
 	return false, false
 }
 
+// GetIndexResourceGroups returns the resource groups the index is bound to, nil if the index is the default one of the field.
+func GetIndexResourceGroups(kvs ...*commonpb.KeyValuePair) []string {
+	for _, kv := range kvs {
+		if kv.Key == IndexResourceGroupsKey {
+			var rgs []string
+			for _, rg := range strings.Split(kv.Value, ",") {
+				if rg = strings.TrimSpace(rg); rg != "" {
+					rgs = append(rgs, rg)
+				}
+			}
+			return rgs
+		}
+	}
+	return nil
+}
+
 func GetIndexType(indexParams []*commonpb.KeyValuePair) string {
 	for _, param := range indexParams {
 		if param.Key == IndexTypeKey {
@@ -289,6 +353,108 @@ func IsPartitionKeyIsolationPropEnabled(props map[string]string) (bool, error) {
 	return iso, nil
 }
 
+// PartitionKeyHashFunction returns the partition key hash function set in the collection properties,
+// empty if not set, which means the legacy scheme: murmur3 for the int64 keys and crc32 for the varchar keys.
+func PartitionKeyHashFunction(kvs ...*commonpb.KeyValuePair) (string, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == PartitionKeyHashFunctionKey {
+			switch kv.GetValue() {
+			case PartitionKeyHashMurmur3, PartitionKeyHashXXHash:
+				return kv.GetValue(), nil
+			default:
+				return "", fmt.Errorf("invalid %s [%s], should be %s or %s", PartitionKeyHashFunctionKey, kv.GetValue(),
+					PartitionKeyHashMurmur3, PartitionKeyHashXXHash)
+			}
+		}
+	}
+	return "", nil
+}
+
+// IsValidFilterStrategy returns whether strategy is a known filter strategy of search.
+func IsValidFilterStrategy(strategy string) bool {
+	switch strategy {
+	case FilterStrategyIterative, FilterStrategyBruteForce, FilterStrategyAuto:
+		return true
+	default:
+		return false
+	}
+}
+
+// CollectionFilterStrategy returns the default filter strategy of search set in the collection properties,
+// empty if not set.
+func CollectionFilterStrategy(kvs ...*commonpb.KeyValuePair) (string, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionSearchFilterStrategyKey {
+			if !IsValidFilterStrategy(kv.GetValue()) {
+				return "", fmt.Errorf("invalid %s [%s], should be one of %s, %s or %s", CollectionSearchFilterStrategyKey, kv.GetValue(),
+					FilterStrategyIterative, FilterStrategyBruteForce, FilterStrategyAuto)
+			}
+			return kv.GetValue(), nil
+		}
+	}
+	return "", nil
+}
+
+// CollectionSearchTimeout returns the default timeout of search and query set in the collection properties,
+// 0 if not set.
+func CollectionSearchTimeout(kvs ...*commonpb.KeyValuePair) (time.Duration, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionSearchTimeoutKey {
+			ms, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+			if err != nil || ms < 0 {
+				return 0, fmt.Errorf("invalid %s [%s], should be a non-negative integer", CollectionSearchTimeoutKey, kv.GetValue())
+			}
+			return time.Duration(ms) * time.Millisecond, nil
+		}
+	}
+	return 0, nil
+}
+
+// CollectionSearchIgnoreGrowing returns whether the growing segments are skipped by default in the search,
+// set in the collection properties, false if not set.
+func CollectionSearchIgnoreGrowing(kvs ...*commonpb.KeyValuePair) (bool, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionSearchIgnoreGrowingKey {
+			ignoreGrowing, err := strconv.ParseBool(kv.GetValue())
+			if err != nil {
+				return false, fmt.Errorf("invalid %s [%s], should be a boolean", CollectionSearchIgnoreGrowingKey, kv.GetValue())
+			}
+			return ignoreGrowing, nil
+		}
+	}
+	return false, nil
+}
+
+// CollectionBloomFilterSize returns the capacity of the pk bloom filters set in the collection properties,
+// 0 if not set.
+func CollectionBloomFilterSize(kvs ...*commonpb.KeyValuePair) (uint, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionBloomFilterSizeKey {
+			size, err := strconv.ParseUint(kv.GetValue(), 10, 64)
+			if err != nil || size == 0 {
+				return 0, fmt.Errorf("invalid %s [%s], should be a positive integer", CollectionBloomFilterSizeKey, kv.GetValue())
+			}
+			return uint(size), nil
+		}
+	}
+	return 0, nil
+}
+
+// CollectionBloomFilterFalsePositive returns the false positive rate of the pk bloom filters set in the collection properties,
+// 0 if not set.
+func CollectionBloomFilterFalsePositive(kvs ...*commonpb.KeyValuePair) (float64, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionBloomFilterFalsePositiveKey {
+			fp, err := strconv.ParseFloat(kv.GetValue(), 64)
+			if err != nil || fp <= 0 || fp >= 1 {
+				return 0, fmt.Errorf("invalid %s [%s], should be a float in (0, 1)", CollectionBloomFilterFalsePositiveKey, kv.GetValue())
+			}
+			return fp, nil
+		}
+	}
+	return 0, nil
+}
+
 const (
 	// LatestVerision is the magic number for watch latest revision
 	LatestRevision = int64(-1)
@@ -3,6 +3,7 @@ package common
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -177,3 +178,109 @@ func TestShouldFieldBeLoaded(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectionFilterStrategy(t *testing.T) {
+	type testCase struct {
+		tag          string
+		input        []*commonpb.KeyValuePair
+		expectOutput string
+		expectError  bool
+	}
+
+	testcases := []testCase{
+		{tag: "no_params", expectOutput: ""},
+		{tag: "iterative", input: []*commonpb.KeyValuePair{{Key: CollectionSearchFilterStrategyKey, Value: FilterStrategyIterative}}, expectOutput: FilterStrategyIterative},
+		{tag: "brute_force", input: []*commonpb.KeyValuePair{{Key: CollectionSearchFilterStrategyKey, Value: FilterStrategyBruteForce}}, expectOutput: FilterStrategyBruteForce},
+		{tag: "auto", input: []*commonpb.KeyValuePair{{Key: CollectionSearchFilterStrategyKey, Value: FilterStrategyAuto}}, expectOutput: FilterStrategyAuto},
+		{tag: "bad_value", input: []*commonpb.KeyValuePair{{Key: CollectionSearchFilterStrategyKey, Value: "abc"}}, expectError: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.tag, func(t *testing.T) {
+			result, err := CollectionFilterStrategy(tc.input...)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectOutput, result)
+			}
+		})
+	}
+}
+
+func TestCollectionSearchTimeout(t *testing.T) {
+	timeout, err := CollectionSearchTimeout()
+	assert.NoError(t, err)
+	assert.Zero(t, timeout)
+
+	timeout, err = CollectionSearchTimeout(&commonpb.KeyValuePair{Key: CollectionSearchTimeoutKey, Value: "1500"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, timeout)
+
+	_, err = CollectionSearchTimeout(&commonpb.KeyValuePair{Key: CollectionSearchTimeoutKey, Value: "-1"})
+	assert.Error(t, err)
+	_, err = CollectionSearchTimeout(&commonpb.KeyValuePair{Key: CollectionSearchTimeoutKey, Value: "1s"})
+	assert.Error(t, err)
+}
+
+func TestCollectionSearchIgnoreGrowing(t *testing.T) {
+	ignoreGrowing, err := CollectionSearchIgnoreGrowing()
+	assert.NoError(t, err)
+	assert.False(t, ignoreGrowing)
+
+	ignoreGrowing, err = CollectionSearchIgnoreGrowing(&commonpb.KeyValuePair{Key: CollectionSearchIgnoreGrowingKey, Value: "true"})
+	assert.NoError(t, err)
+	assert.True(t, ignoreGrowing)
+
+	_, err = CollectionSearchIgnoreGrowing(&commonpb.KeyValuePair{Key: CollectionSearchIgnoreGrowingKey, Value: "yes"})
+	assert.Error(t, err)
+}
+
+func TestCollectionBloomFilterParams(t *testing.T) {
+	size, err := CollectionBloomFilterSize()
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), size)
+
+	size, err = CollectionBloomFilterSize(&commonpb.KeyValuePair{Key: CollectionBloomFilterSizeKey, Value: "200000"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(200000), size)
+
+	_, err = CollectionBloomFilterSize(&commonpb.KeyValuePair{Key: CollectionBloomFilterSizeKey, Value: "0"})
+	assert.Error(t, err)
+
+	fp, err := CollectionBloomFilterFalsePositive()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), fp)
+
+	fp, err = CollectionBloomFilterFalsePositive(&commonpb.KeyValuePair{Key: CollectionBloomFilterFalsePositiveKey, Value: "0.0001"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0001, fp)
+
+	_, err = CollectionBloomFilterFalsePositive(&commonpb.KeyValuePair{Key: CollectionBloomFilterFalsePositiveKey, Value: "1"})
+	assert.Error(t, err)
+
+	_, err = CollectionBloomFilterFalsePositive(&commonpb.KeyValuePair{Key: CollectionBloomFilterFalsePositiveKey, Value: "abc"})
+	assert.Error(t, err)
+}
+
+func TestPartitionKeyHashFunction(t *testing.T) {
+	hashFunction, err := PartitionKeyHashFunction()
+	assert.NoError(t, err)
+	assert.Empty(t, hashFunction)
+
+	hashFunction, err = PartitionKeyHashFunction(&commonpb.KeyValuePair{Key: PartitionKeyHashFunctionKey, Value: PartitionKeyHashXXHash})
+	assert.NoError(t, err)
+	assert.Equal(t, PartitionKeyHashXXHash, hashFunction)
+
+	_, err = PartitionKeyHashFunction(&commonpb.KeyValuePair{Key: PartitionKeyHashFunctionKey, Value: "crc32"})
+	assert.Error(t, err)
+}
+
+func TestGetIndexResourceGroups(t *testing.T) {
+	assert.Nil(t, GetIndexResourceGroups(&commonpb.KeyValuePair{Key: IndexTypeKey, Value: "HNSW"}))
+	assert.Nil(t, GetIndexResourceGroups(&commonpb.KeyValuePair{Key: IndexResourceGroupsKey, Value: " , "}))
+	assert.Equal(t, []string{"rg1", "rg2"}, GetIndexResourceGroups(
+		&commonpb.KeyValuePair{Key: IndexTypeKey, Value: "DISKANN"},
+		&commonpb.KeyValuePair{Key: IndexResourceGroupsKey, Value: "rg1, rg2"},
+	))
+}
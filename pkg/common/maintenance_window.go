@@ -0,0 +1,184 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// MaintenanceWindow is a daily time range during which background maintenance is allowed.
+// A range whose end is not after its start crosses midnight, the days refer to the day it starts.
+type MaintenanceWindow struct {
+	days  [7]bool
+	start int // minutes since midnight
+	end   int // minutes since midnight
+}
+
+// MaintenanceWindows is the parsed value of CollectionMaintenanceWindowKey.
+// An empty list puts no restriction on maintenance.
+type MaintenanceWindows []MaintenanceWindow
+
+// ParseMaintenanceWindows parses windows separated by ';', each in the form of "[days] HH:MM-HH:MM".
+// Days is an optional comma separated list of weekdays or weekday ranges, e.g. "mon-fri,sun",
+// every day is allowed if omitted. For example: "mon-fri 01:00-05:00; sat,sun 22:00-06:00".
+func ParseMaintenanceWindows(value string) (MaintenanceWindows, error) {
+	var windows MaintenanceWindows
+	for _, spec := range strings.Split(value, ";") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		window, err := parseMaintenanceWindow(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maintenance window %q", spec)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+func parseMaintenanceWindow(spec string) (MaintenanceWindow, error) {
+	var window MaintenanceWindow
+	fields := strings.Fields(spec)
+	var timeRange string
+	switch len(fields) {
+	case 1:
+		for i := range window.days {
+			window.days[i] = true
+		}
+		timeRange = fields[0]
+	case 2:
+		if err := parseWeekdays(fields[0], &window.days); err != nil {
+			return window, err
+		}
+		timeRange = fields[1]
+	default:
+		return window, errors.New("expect \"[days] HH:MM-HH:MM\"")
+	}
+
+	bounds := strings.Split(timeRange, "-")
+	if len(bounds) != 2 {
+		return window, fmt.Errorf("invalid time range %q", timeRange)
+	}
+	var err error
+	if window.start, err = parseMinuteOfDay(bounds[0]); err != nil {
+		return window, err
+	}
+	if window.end, err = parseMinuteOfDay(bounds[1]); err != nil {
+		return window, err
+	}
+	return window, nil
+}
+
+func parseWeekdays(spec string, days *[7]bool) error {
+	lookup := func(name string) (time.Weekday, error) {
+		day, ok := weekdayNames[strings.ToLower(name)]
+		if !ok {
+			return 0, fmt.Errorf("invalid weekday %q", name)
+		}
+		return day, nil
+	}
+	for _, item := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(item, "-")
+		first, err := lookup(from)
+		if err != nil {
+			return err
+		}
+		last := first
+		if isRange {
+			if last, err = lookup(to); err != nil {
+				return err
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expect HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains returns whether t falls into the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	today := t.Weekday()
+	if w.start < w.end {
+		return w.days[today] && minute >= w.start && minute < w.end
+	}
+	// the window crosses midnight, or lasts the whole day if start equals end
+	yesterday := (today + 6) % 7
+	return (w.days[today] && minute >= w.start) || (w.days[yesterday] && minute < w.end)
+}
+
+// Contains returns whether t falls into any of the windows, always true if there is none.
+func (ws MaintenanceWindows) Contains(t time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCollectionMaintenanceWindows returns the maintenance windows set in collection properties.
+func GetCollectionMaintenanceWindows(props map[string]string) (MaintenanceWindows, error) {
+	value, ok := props[CollectionMaintenanceWindowKey]
+	if !ok {
+		return nil, nil
+	}
+	return ParseMaintenanceWindows(value)
+}
+
+// ValidateCollectionMaintenanceWindows checks the maintenance windows in kvs if set.
+func ValidateCollectionMaintenanceWindows(kvs ...*commonpb.KeyValuePair) error {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionMaintenanceWindowKey {
+			_, err := ParseMaintenanceWindows(kv.GetValue())
+			return err
+		}
+	}
+	return nil
+}
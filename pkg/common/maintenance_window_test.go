@@ -0,0 +1,76 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+)
+
+func TestParseMaintenanceWindows(t *testing.T) {
+	// 2024-01-01 is a Monday
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.Local)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		windows, err := ParseMaintenanceWindows("")
+		assert.NoError(t, err)
+		assert.Empty(t, windows)
+		assert.True(t, windows.Contains(at(1, 12, 0)))
+	})
+
+	t.Run("every day", func(t *testing.T) {
+		windows, err := ParseMaintenanceWindows("01:00-05:30")
+		assert.NoError(t, err)
+		assert.True(t, windows.Contains(at(1, 1, 0)))
+		assert.True(t, windows.Contains(at(6, 5, 29)))
+		assert.False(t, windows.Contains(at(1, 5, 30)))
+		assert.False(t, windows.Contains(at(1, 0, 59)))
+	})
+
+	t.Run("weekdays and overnight", func(t *testing.T) {
+		windows, err := ParseMaintenanceWindows("mon-fri 01:00-05:00; Sat,Sun 22:00-06:00")
+		assert.NoError(t, err)
+		assert.Len(t, windows, 2)
+		assert.True(t, windows.Contains(at(3, 2, 0)))
+		assert.False(t, windows.Contains(at(6, 2, 0)))
+		// saturday night into sunday morning
+		assert.True(t, windows.Contains(at(6, 23, 0)))
+		assert.True(t, windows.Contains(at(7, 5, 0)))
+		// sunday night into monday morning
+		assert.True(t, windows.Contains(at(8, 5, 59)))
+		assert.False(t, windows.Contains(at(8, 6, 0)))
+		assert.False(t, windows.Contains(at(5, 23, 0)))
+	})
+
+	t.Run("wrapped weekday range", func(t *testing.T) {
+		windows, err := ParseMaintenanceWindows("fri-mon 00:00-00:00")
+		assert.NoError(t, err)
+		assert.True(t, windows.Contains(at(1, 12, 0)))
+		assert.False(t, windows.Contains(at(2, 12, 0)))
+		assert.True(t, windows.Contains(at(7, 12, 0)))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, value := range []string{"1:00", "25:00-26:00", "mon 01:00", "funday 01:00-02:00", "mon tue 01:00-02:00"} {
+			_, err := ParseMaintenanceWindows(value)
+			assert.Error(t, err, value)
+		}
+	})
+}
+
+func TestCollectionMaintenanceWindows(t *testing.T) {
+	windows, err := GetCollectionMaintenanceWindows(map[string]string{})
+	assert.NoError(t, err)
+	assert.Empty(t, windows)
+
+	windows, err = GetCollectionMaintenanceWindows(map[string]string{CollectionMaintenanceWindowKey: "02:00-03:00"})
+	assert.NoError(t, err)
+	assert.Len(t, windows, 1)
+
+	assert.NoError(t, ValidateCollectionMaintenanceWindows(&commonpb.KeyValuePair{Key: CollectionTTLConfigKey, Value: "10"}))
+	assert.Error(t, ValidateCollectionMaintenanceWindows(&commonpb.KeyValuePair{Key: CollectionMaintenanceWindowKey, Value: "02:00"}))
+}
@@ -17,10 +17,18 @@
 package metrics
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	// #nosec
 	_ "net/http/pprof"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 )
 
 const (
@@ -34,6 +42,12 @@ const (
 
 	HybridSearchLabel = "hybrid_search"
 
+	// search types
+	TopKSearchLabel     = "topk"
+	RangeSearchLabel    = "range"
+	GroupBySearchLabel  = "group_by"
+	IteratorSearchLabel = "iterator"
+
 	InsertLabel    = "insert"
 	DeleteLabel    = "delete"
 	UpsertLabel    = "upsert"
@@ -96,6 +110,7 @@ const (
 	channelNameLabelName     = "channel_name"
 	functionLabelName        = "function_name"
 	queryTypeLabelName       = "query_type"
+	searchTypeLabelName      = "search_type"
 	collectionName           = "collection_name"
 	databaseLabelName        = "db_name"
 	resourceGroupLabelName   = "rg"
@@ -123,6 +138,9 @@ const (
 	pathLabelName            = "path"
 	cgoNameLabelName         = `cgo_name`
 	cgoTypeLabelName         = `cgo_type`
+	flowGraphNodeLabelName   = "flowgraph_node"
+	targetRoleLabelName      = "target_role"
+	reasonLabelName          = "reason"
 
 	// entities label
 	LoadedLabel         = "loaded"
@@ -162,6 +180,49 @@ var (
 			lockOp,
 		})
 
+	// FlowGraphNodeOperateLatency records the time cost of each flowgraph/pipeline node operating a message.
+	FlowGraphNodeOperateLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Name:      "flowgraph_node_operate_latency",
+			Help:      "latency of flowgraph node operating a message",
+			Buckets:   buckets,
+		}, []string{nodeIDLabelName, roleNameLabelName, flowGraphNodeLabelName})
+
+	// FlowGraphNodeBlockedLatency records the time the producer is blocked on sending to the input channel
+	// of a flowgraph/pipeline, which is the backpressure from the flowgraph/pipeline.
+	FlowGraphNodeBlockedLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Name:      "flowgraph_node_blocked_latency",
+			Help:      "latency of sending to the input channel of flowgraph blocked by the flowgraph",
+			Buckets:   buckets,
+		}, []string{nodeIDLabelName, roleNameLabelName, flowGraphNodeLabelName})
+
+	// FlowGraphNodeQueueLength records the pending messages in the input channel of each flowgraph/pipeline.
+	FlowGraphNodeQueueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Name:      "flowgraph_node_queue_length",
+			Help:      "number of pending messages in the input channel of flowgraph",
+		}, []string{nodeIDLabelName, roleNameLabelName, flowGraphNodeLabelName})
+
+	// GrpcClientConnections records the grpc connections held by the clients to each kind of target.
+	GrpcClientConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Name:      "grpc_client_connections",
+			Help:      "number of active grpc client connections",
+		}, []string{nodeIDLabelName, targetRoleLabelName})
+
+	// GrpcClientConnectionResets records the resets of grpc client connections and the reason.
+	GrpcClientConnectionResets = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Name:      "grpc_client_connection_resets_total",
+			Help:      "number of grpc client connection resets",
+		}, []string{nodeIDLabelName, targetRoleLabelName, reasonLabelName})
+
 	metricRegisterer prometheus.Registerer
 )
 
@@ -182,5 +243,110 @@ func Register(r prometheus.Registerer) {
 	r.MustRegister(BuildInfo)
 	r.MustRegister(RuntimeInfo)
 	r.MustRegister(ThreadNum)
+	r.MustRegister(FlowGraphNodeOperateLatency)
+	r.MustRegister(FlowGraphNodeBlockedLatency)
+	r.MustRegister(FlowGraphNodeQueueLength)
+	r.MustRegister(GrpcClientConnections)
+	r.MustRegister(GrpcClientConnectionResets)
 	metricRegisterer = r
 }
+
+// FlowGraphNodeMetrics instruments a stage of a flowgraph, or the input channel of it,
+// all methods are no-op on a nil receiver.
+// The stages run in one goroutine, so the blocked latency and queue length are only recorded for the input,
+// and they are created on the first record, not to export the meaningless series of the stages.
+type FlowGraphNodeMetrics struct {
+	labels prometheus.Labels
+
+	operateLatency prometheus.Observer
+}
+
+func NewFlowGraphNodeMetrics(nodeID int64, role string, node string) *FlowGraphNodeMetrics {
+	labels := prometheus.Labels{
+		nodeIDLabelName:        fmt.Sprint(nodeID),
+		roleNameLabelName:      role,
+		flowGraphNodeLabelName: node,
+	}
+	return &FlowGraphNodeMetrics{
+		labels:         labels,
+		operateLatency: FlowGraphNodeOperateLatency.With(labels),
+	}
+}
+
+// ObserveOperate records the time cost of operating a message since start.
+func (m *FlowGraphNodeMetrics) ObserveOperate(start time.Time) {
+	if m != nil {
+		m.operateLatency.Observe(float64(time.Since(start).Milliseconds()))
+	}
+}
+
+// ObserveBlocked records the time blocked on sending to the input channel since start.
+func (m *FlowGraphNodeMetrics) ObserveBlocked(start time.Time) {
+	if m != nil {
+		FlowGraphNodeBlockedLatency.With(m.labels).Observe(float64(time.Since(start).Milliseconds()))
+	}
+}
+
+// SetQueueLength records the pending messages in the input channel.
+func (m *FlowGraphNodeMetrics) SetQueueLength(length int) {
+	if m != nil {
+		FlowGraphNodeQueueLength.With(m.labels).Set(float64(length))
+	}
+}
+
+// Cleanup removes the metrics of a closed node.
+func (m *FlowGraphNodeMetrics) Cleanup() {
+	if m != nil {
+		FlowGraphNodeOperateLatency.Delete(m.labels)
+		FlowGraphNodeBlockedLatency.Delete(m.labels)
+		FlowGraphNodeQueueLength.Delete(m.labels)
+	}
+}
+
+// ObserveWithRequestTags observes the value, the request tags in ctx are attached as exemplar labels if possible,
+// so that the latency could be attributed to the workload which issues the request.
+func ObserveWithRequestTags(ctx context.Context, observer prometheus.Observer, value float64) {
+	if labels := requestTagsExemplar(ctx); labels != nil {
+		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(value, labels)
+			return
+		}
+	}
+	observer.Observe(value)
+}
+
+// requestTagsExemplar converts the request tags in ctx to exemplar labels. The tags with invalid label names
+// are skipped, and nil is returned if the labels exceed the exemplar limit, since prometheus panics on it.
+func requestTagsExemplar(ctx context.Context) prometheus.Labels {
+	tags := contextutil.RequestTags(ctx)
+	if tags == "" {
+		return nil
+	}
+	labels := make(prometheus.Labels)
+	runes := 0
+	for key, value := range contextutil.ParseRequestTags(tags) {
+		if !isValidExemplarLabelName(key) || !utf8.ValidString(value) {
+			continue
+		}
+		labels[key] = value
+		runes += utf8.RuneCountInString(key) + utf8.RuneCountInString(value)
+	}
+	if len(labels) == 0 || runes > prometheus.ExemplarMaxRunes {
+		return nil
+	}
+	return labels
+}
+
+// isValidExemplarLabelName follows the prometheus label name rule [a-zA-Z_][a-zA-Z0-9_]*,
+// the names starting with "__" are reserved.
+func isValidExemplarLabelName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "__") {
+		return false
+	}
+	for i, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || (c >= '0' && c <= '9' && i > 0)) {
+			return false
+		}
+	}
+	return true
+}
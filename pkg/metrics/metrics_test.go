@@ -17,11 +17,17 @@
 package metrics
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 )
 
 func TestRegisterMetrics(t *testing.T) {
@@ -138,3 +144,46 @@ func TestDeletePartialMatch(t *testing.T) {
 	}
 	assert.Equal(t, 0, getMetricsCount())
 }
+
+func TestFlowGraphNodeMetrics(t *testing.T) {
+	var nilMetrics *FlowGraphNodeMetrics
+	assert.NotPanics(t, func() {
+		nilMetrics.ObserveOperate(time.Now())
+		nilMetrics.ObserveBlocked(time.Now())
+		nilMetrics.SetQueueLength(1)
+		nilMetrics.Cleanup()
+	})
+
+	m := NewFlowGraphNodeMetrics(1, "querynode", "filterNode-ch")
+	m.ObserveOperate(time.Now())
+	// the series of the input are not exported before recorded
+	assert.Equal(t, 0, testutil.CollectAndCount(FlowGraphNodeQueueLength))
+	assert.Equal(t, 0, testutil.CollectAndCount(FlowGraphNodeBlockedLatency))
+
+	m.SetQueueLength(3)
+	m.ObserveOperate(time.Now())
+	m.ObserveBlocked(time.Now())
+	assert.Equal(t, float64(3), testutil.ToFloat64(FlowGraphNodeQueueLength.With(m.labels)))
+
+	m.Cleanup()
+	assert.Equal(t, 0, testutil.CollectAndCount(FlowGraphNodeQueueLength))
+}
+
+func TestObserveWithRequestTags(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, requestTagsExemplar(ctx))
+
+	ctx = contextutil.WithRequestTags(ctx, "job=nightly-recs,0invalid=x,__reserved=y")
+	assert.Equal(t, prometheus.Labels{"job": "nightly-recs"}, requestTagsExemplar(ctx))
+
+	tooLong := contextutil.WithRequestTags(context.Background(), "job="+strings.Repeat("a", prometheus.ExemplarMaxRunes))
+	assert.Nil(t, requestTagsExemplar(tooLong))
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_latency"})
+	assert.NotPanics(t, func() {
+		ObserveWithRequestTags(ctx, histogram, 1)
+		ObserveWithRequestTags(tooLong, histogram, 2)
+		ObserveWithRequestTags(context.Background(), histogram, 3)
+	})
+	assert.Equal(t, 1, testutil.CollectAndCount(histogram))
+}
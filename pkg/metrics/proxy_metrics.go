@@ -89,6 +89,16 @@ var (
 			Buckets:   buckets,
 		}, []string{nodeIDLabelName, queryTypeLabelName, collectionName})
 
+	// ProxySearchTypeLatency record the latency of search successfully, per search type.
+	ProxySearchTypeLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "search_type_latency",
+			Help:      "latency of search successfully, per search type, such as topk, range, group_by and iterator",
+			Buckets:   buckets,
+		}, []string{nodeIDLabelName, searchTypeLabelName})
+
 	// ProxyMutationLatency record the latency that mutate successfully.
 	ProxyMutationLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -417,6 +427,26 @@ var (
 			Name:      "recall_search_cnt",
 			Help:      "counter of recall search",
 		}, []string{nodeIDLabelName, queryTypeLabelName, collectionName})
+
+	// ProxySearchConsistencyIDOverlap records the overlap ratio of the result ids
+	// when a sampled search is verified against another replica
+	ProxySearchConsistencyIDOverlap = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "search_consistency_id_overlap",
+			Help:      "overlap ratio of the result ids between replicas of verified searches",
+			Buckets:   []float64{0.5, 0.8, 0.9, 0.95, 0.99, 1},
+		}, []string{nodeIDLabelName, collectionName})
+
+	// ProxySearchConsistencyMismatchCount records the verified searches whose results diverge between replicas
+	ProxySearchConsistencyMismatchCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "search_consistency_mismatch_cnt",
+			Help:      "counter of verified searches whose results diverge between replicas",
+		}, []string{nodeIDLabelName, collectionName})
 )
 
 // RegisterProxy registers Proxy metrics
@@ -429,6 +459,7 @@ func RegisterProxy(registry *prometheus.Registry) {
 
 	registry.MustRegister(ProxySQLatency)
 	registry.MustRegister(ProxyCollectionSQLatency)
+	registry.MustRegister(ProxySearchTypeLatency)
 	registry.MustRegister(ProxyMutationLatency)
 	registry.MustRegister(ProxyCollectionMutationLatency)
 
@@ -478,6 +509,8 @@ func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxyRetrySearchCount)
 	registry.MustRegister(ProxyRetrySearchResultInsufficientCount)
 	registry.MustRegister(ProxyRecallSearchCount)
+	registry.MustRegister(ProxySearchConsistencyIDOverlap)
+	registry.MustRegister(ProxySearchConsistencyMismatchCount)
 
 	RegisterStreamingServiceClient(registry)
 }
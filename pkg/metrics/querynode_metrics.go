@@ -459,6 +459,17 @@ var (
 			segmentStateLabelName,
 		})
 
+	QueryNodeEntitiesMmapSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "entity_mmap_size",
+			Help:      "size of the sealed entities mapped from the mmap files, clustered by collection",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+		})
+
 	QueryNodeLevelZeroSize = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: milvusNamespace,
@@ -580,6 +591,28 @@ var (
 			nodeIDLabelName,
 		})
 
+	QueryNodeIndexWarmupNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "index_warmup_num",
+			Help:      "number of the vector indexes of the searchable sealed segments loading in the background, clustered by collection",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+		})
+
+	QueryNodeIndexWarmupLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "index_warmup_latency",
+			Help:      "latency of loading a vector index in the background after the segment searchable, in milliseconds",
+			Buckets:   longTaskBuckets, // unit milliseconds
+		}, []string{
+			nodeIDLabelName,
+		})
+
 	// QueryNodeSegmentAccessTotal records the total number of search or query segments accessed.
 	QueryNodeSegmentAccessTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -792,6 +825,121 @@ var (
 		},
 	)
 
+	QueryNodeDelegatorPendingRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "delegator_pending_requests",
+			Help:      "number of search and query requests admitted by the delegator and not finished yet",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+		},
+	)
+
+	QueryNodeDelegatorWaitLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "delegator_wait_latency",
+			Help:      "latency of search or query waiting in the delegator before dispatched to workers",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodeDelegatorRejectedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "delegator_rejected_requests",
+			Help:      "count of search and query requests rejected by the delegator because of too many pending requests or the open circuit breaker",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodeDelegatorCircuitBreakerOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "delegator_circuit_breaker_open",
+			Help:      "whether the circuit breaker of the delegator is open, 1 for open and 0 for closed",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+		})
+
+	QueryNodeQuarantinedSegments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "quarantined_segments",
+			Help:      "count of sealed segments released because of repeated segcore failures or stuck segcore calls",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+		})
+
+	QueryNodeSegmentReleaseDrainLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "segment_release_drain_latency",
+			Help:      "latency of the release of a segment waiting for the in-flight reads on it",
+			Buckets:   longTaskBuckets, // unit milliseconds
+		}, []string{
+			nodeIDLabelName,
+			segmentStateLabelName,
+		})
+
+	QueryNodeSegmentReleaseDrainCanceled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "segment_release_drain_canceled",
+			Help:      "count of segment releases canceling the in-flight reads after the drain deadline",
+		}, []string{
+			nodeIDLabelName,
+			segmentStateLabelName,
+		})
+
+	QueryNodeStuckCGOCalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "stuck_cgo_calls",
+			Help:      "count of segcore calls running longer than expected, reported by the cgo watchdog",
+		}, []string{
+			nodeIDLabelName,
+			functionLabelName,
+		})
+
+	QueryNodeSearchCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "search_cache_count",
+			Help:      "count of the search result cache hits/miss",
+		}, []string{
+			nodeIDLabelName,
+			cacheStateLabelName,
+		})
+
+	QueryNodePlanCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "plan_cache_count",
+			Help:      "count of the segcore plan cache hits/miss",
+		}, []string{
+			nodeIDLabelName,
+			cacheStateLabelName,
+		})
+
 	QueryNodeCGOCallLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: milvusNamespace,
@@ -835,6 +983,7 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeNumFlowGraphs)
 	registry.MustRegister(QueryNodeNumEntities)
 	registry.MustRegister(QueryNodeEntitiesSize)
+	registry.MustRegister(QueryNodeEntitiesMmapSize)
 	registry.MustRegister(QueryNodeLevelZeroSize)
 	registry.MustRegister(QueryNodeConsumeCounter)
 	registry.MustRegister(QueryNodeExecuteCounter)
@@ -851,6 +1000,8 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(StoppingBalanceSegmentNum)
 	registry.MustRegister(QueryNodeLoadSegmentConcurrency)
 	registry.MustRegister(QueryNodeLoadIndexLatency)
+	registry.MustRegister(QueryNodeIndexWarmupNum)
+	registry.MustRegister(QueryNodeIndexWarmupLatency)
 	registry.MustRegister(QueryNodeSegmentAccessTotal)
 	registry.MustRegister(QueryNodeSegmentAccessDuration)
 	registry.MustRegister(QueryNodeSegmentAccessGlobalDuration)
@@ -873,6 +1024,16 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeSearchHitSegmentNum)
 	registry.MustRegister(QueryNodeDeleteBufferSize)
 	registry.MustRegister(QueryNodeDeleteBufferRowNum)
+	registry.MustRegister(QueryNodeDelegatorPendingRequests)
+	registry.MustRegister(QueryNodeDelegatorWaitLatency)
+	registry.MustRegister(QueryNodeDelegatorRejectedRequests)
+	registry.MustRegister(QueryNodeDelegatorCircuitBreakerOpen)
+	registry.MustRegister(QueryNodeQuarantinedSegments)
+	registry.MustRegister(QueryNodeSegmentReleaseDrainLatency)
+	registry.MustRegister(QueryNodeSegmentReleaseDrainCanceled)
+	registry.MustRegister(QueryNodeStuckCGOCalls)
+	registry.MustRegister(QueryNodeSearchCacheCounter)
+	registry.MustRegister(QueryNodePlanCacheCounter)
 	registry.MustRegister(QueryNodeCGOCallLatency)
 	// Add cgo metrics
 	RegisterCGOMetrics(registry)
@@ -880,6 +1041,18 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	RegisterStreamingServiceClient(registry)
 }
 
+// CleanupQueryNodeDelegatorMetrics removes the admission metrics of the delegator of channel.
+func CleanupQueryNodeDelegatorMetrics(nodeID int64, channel string) {
+	labels := prometheus.Labels{
+		nodeIDLabelName:      fmt.Sprint(nodeID),
+		channelNameLabelName: channel,
+	}
+	QueryNodeDelegatorPendingRequests.DeletePartialMatch(labels)
+	QueryNodeDelegatorWaitLatency.DeletePartialMatch(labels)
+	QueryNodeDelegatorRejectedRequests.DeletePartialMatch(labels)
+	QueryNodeDelegatorCircuitBreakerOpen.DeletePartialMatch(labels)
+}
+
 func CleanupQueryNodeCollectionMetrics(nodeID int64, collectionID int64) {
 	nodeIDLabel := fmt.Sprint(nodeID)
 	collectionIDLabel := fmt.Sprint(collectionID)
@@ -957,6 +1130,20 @@ func CleanupQueryNodeCollectionMetrics(nodeID int64, collectionID int64) {
 				collectionIDLabelName: collectionIDLabel,
 			})
 
+	QueryNodeEntitiesMmapSize.
+		DeletePartialMatch(
+			prometheus.Labels{
+				nodeIDLabelName:       nodeIDLabel,
+				collectionIDLabelName: collectionIDLabel,
+			})
+
+	QueryNodeIndexWarmupNum.
+		DeletePartialMatch(
+			prometheus.Labels{
+				nodeIDLabelName:       nodeIDLabel,
+				collectionIDLabelName: collectionIDLabel,
+			})
+
 	QueryNodeLevelZeroSize.
 		DeletePartialMatch(
 			prometheus.Labels{
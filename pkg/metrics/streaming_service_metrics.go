@@ -27,6 +27,8 @@ const (
 	WALChannelTermLabelName           = "term"
 	WALNameLabelName                  = "wal_name"
 	WALTxnTypeLabelName               = "txn_type"
+	WALThrottleScopeLabelName         = "scope"
+	WALThrottleActionLabelName        = "action"
 	StatusLabelName                   = statusLabelName
 	StreamingNodeLabelName            = "streaming_node"
 	NodeIDLabelName                   = nodeIDLabelName
@@ -229,6 +231,22 @@ var (
 		Buckets: secondsBuckets,
 	}, WALChannelLabelName, StatusLabelName)
 
+	WALAppendThrottleTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "append_throttle_total",
+		Help: "Total of append message delayed or rejected by the rate limit of wal",
+	}, WALChannelLabelName, WALThrottleScopeLabelName, WALThrottleActionLabelName)
+
+	WALAppendThrottleDurationSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "append_throttle_duration_seconds",
+		Help:    "Duration of append message delayed by the rate limit of wal",
+		Buckets: secondsBuckets,
+	}, WALChannelLabelName)
+
+	WALAppendDeduplicatedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "append_deduplicated_total",
+		Help: "Total of append message dropped as the duplicate appended again by the retry of producer",
+	}, WALChannelLabelName)
+
 	// Scanner Related Metrics
 	WALScannerTotal = newWALGaugeVec(prometheus.GaugeOpts{
 		Name: "scanner_total",
@@ -337,6 +355,9 @@ func registerWAL(registry *prometheus.Registry) {
 	registry.MustRegister(WALAppendMessageTotal)
 	registry.MustRegister(WALAppendMessageDurationSeconds)
 	registry.MustRegister(WALImplsAppendMessageDurationSeconds)
+	registry.MustRegister(WALAppendThrottleTotal)
+	registry.MustRegister(WALAppendThrottleDurationSeconds)
+	registry.MustRegister(WALAppendDeduplicatedTotal)
 	registry.MustRegister(WALScannerTotal)
 	registry.MustRegister(WALScanMessageBytes)
 	registry.MustRegister(WALScanMessageTotal)
@@ -389,6 +389,51 @@ func (_c *MockMutableMessage_TxnContext_Call) RunAndReturn(run func() *message.T
 	return _c
 }
 
+// UniqueID provides a mock function with given fields:
+func (_m *MockMutableMessage) UniqueID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UniqueID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockMutableMessage_UniqueID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UniqueID'
+type MockMutableMessage_UniqueID_Call struct {
+	*mock.Call
+}
+
+// UniqueID is a helper method to define mock.On call
+func (_e *MockMutableMessage_Expecter) UniqueID() *MockMutableMessage_UniqueID_Call {
+	return &MockMutableMessage_UniqueID_Call{Call: _e.mock.On("UniqueID")}
+}
+
+func (_c *MockMutableMessage_UniqueID_Call) Run(run func()) *MockMutableMessage_UniqueID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMutableMessage_UniqueID_Call) Return(_a0 string) *MockMutableMessage_UniqueID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMutableMessage_UniqueID_Call) RunAndReturn(run func() string) *MockMutableMessage_UniqueID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // VChannel provides a mock function with given fields:
 func (_m *MockMutableMessage) VChannel() string {
 	ret := _m.Called()
@@ -718,6 +763,54 @@ func (_c *MockMutableMessage_WithTxnContext_Call) RunAndReturn(run func(message.
 	return _c
 }
 
+// WithUniqueID provides a mock function with given fields: id
+func (_m *MockMutableMessage) WithUniqueID(id string) message.MutableMessage {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithUniqueID")
+	}
+
+	var r0 message.MutableMessage
+	if rf, ok := ret.Get(0).(func(string) message.MutableMessage); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(message.MutableMessage)
+		}
+	}
+
+	return r0
+}
+
+// MockMutableMessage_WithUniqueID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithUniqueID'
+type MockMutableMessage_WithUniqueID_Call struct {
+	*mock.Call
+}
+
+// WithUniqueID is a helper method to define mock.On call
+//   - id string
+func (_e *MockMutableMessage_Expecter) WithUniqueID(id interface{}) *MockMutableMessage_WithUniqueID_Call {
+	return &MockMutableMessage_WithUniqueID_Call{Call: _e.mock.On("WithUniqueID", id)}
+}
+
+func (_c *MockMutableMessage_WithUniqueID_Call) Run(run func(id string)) *MockMutableMessage_WithUniqueID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMutableMessage_WithUniqueID_Call) Return(_a0 message.MutableMessage) *MockMutableMessage_WithUniqueID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMutableMessage_WithUniqueID_Call) RunAndReturn(run func(string) message.MutableMessage) *MockMutableMessage_WithUniqueID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithWALTerm provides a mock function with given fields: term
 func (_m *MockMutableMessage) WithWALTerm(term int64) message.MutableMessage {
 	ret := _m.Called(term)
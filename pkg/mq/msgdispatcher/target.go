@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/lifetime"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
@@ -40,6 +41,8 @@ type target struct {
 	timer     *time.Timer
 
 	cancelCh lifetime.SafeChan
+	// metrics of the channel consumed by the flowgraph or pipeline of the vchannel
+	metrics *metrics.FlowGraphNodeMetrics
 }
 
 func newTarget(vchannel string, pos *Pos) *target {
@@ -51,6 +54,7 @@ func newTarget(vchannel string, pos *Pos) *target {
 		cancelCh: lifetime.NewSafeChan(),
 		maxLag:   maxTolerantLag,
 		timer:    time.NewTimer(maxTolerantLag),
+		metrics:  metrics.NewFlowGraphNodeMetrics(paramtable.GetNodeID(), paramtable.GetRole(), fmt.Sprintf("input-%s", vchannel)),
 	}
 	t.closed = false
 	return t
@@ -64,6 +68,7 @@ func (t *target) close() {
 		t.closed = true
 		t.timer.Stop()
 		close(t.ch)
+		t.metrics.Cleanup()
 	})
 }
 
@@ -81,6 +86,11 @@ func (t *target) send(pack *MsgPack) error {
 		}
 	}
 	t.timer.Reset(t.maxLag)
+	// the messages not consumed yet, and the time blocked on the full channel,
+	// which is the back pressure from the flowgraph or pipeline consuming it
+	t.metrics.SetQueueLength(len(t.ch))
+	start := time.Now()
+	defer t.metrics.ObserveBlocked(start)
 	select {
 	case <-t.cancelCh.CloseCh():
 		log.Info("target closed", zap.String("vchannel", t.vchannel))
@@ -1,14 +1,18 @@
 package msgdispatcher
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
@@ -28,3 +32,27 @@ func TestSendTimeout(t *testing.T) {
 	}
 	assert.Equal(t, counter, 0)
 }
+
+func TestSendMetrics(t *testing.T) {
+	paramtable.Get().Save(paramtable.Get().MQCfg.TargetBufSize.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().MQCfg.TargetBufSize.Key)
+	target := newTarget("test_metrics", &msgpb.MsgPosition{})
+	labels := prometheus.Labels{
+		"node_id":        fmt.Sprint(paramtable.GetNodeID()),
+		"role_name":      paramtable.GetRole(),
+		"flowgraph_node": "input-test_metrics",
+	}
+
+	assert.NoError(t, target.send(&msgstream.MsgPack{}))
+	assert.NoError(t, target.send(&msgstream.MsgPack{}))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.FlowGraphNodeQueueLength.With(labels)))
+
+	// blocked until the consumer pulls
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		<-target.ch
+	}()
+	assert.NoError(t, target.send(&msgstream.MsgPack{}))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.FlowGraphNodeQueueLength.With(labels)))
+	target.close()
+}
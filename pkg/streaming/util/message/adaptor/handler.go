@@ -2,10 +2,12 @@ package adaptor
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -18,9 +20,19 @@ func NewMsgPackAdaptorHandler() *MsgPackAdaptorHandler {
 	}
 }
 
+// NewMsgPackAdaptorHandlerWithMetrics create a new message pack adaptor handler,
+// which records the pending msgPacks and the time blocked on sending them to the consumer into metrics.
+func NewMsgPackAdaptorHandlerWithMetrics(metrics *metrics.FlowGraphNodeMetrics) *MsgPackAdaptorHandler {
+	return &MsgPackAdaptorHandler{
+		base:    NewBaseMsgPackAdaptorHandler(),
+		metrics: metrics,
+	}
+}
+
 // MsgPackAdaptorHandler is the handler for message pack.
 type MsgPackAdaptorHandler struct {
-	base *BaseMsgPackAdaptorHandler
+	base    *BaseMsgPackAdaptorHandler
+	metrics *metrics.FlowGraphNodeMetrics
 }
 
 // Chan is the channel for message.
@@ -31,6 +43,9 @@ func (m *MsgPackAdaptorHandler) Chan() <-chan *msgstream.MsgPack {
 // Handle is the callback for handling message.
 func (m *MsgPackAdaptorHandler) Handle(ctx context.Context, msg message.ImmutableMessage) (bool, error) {
 	m.base.GenerateMsgPack(msg)
+	m.metrics.SetQueueLength(m.base.PendingMsgPack.Len())
+	start := time.Now()
+	defer m.metrics.ObserveBlocked(start)
 	for m.base.PendingMsgPack.Len() > 0 {
 		select {
 		case <-ctx.Done():
@@ -78,6 +78,14 @@ type MutableMessage interface {
 	// !!! preserved for streaming system internal usage, don't call it outside of streaming system.
 	WithTxnContext(txnCtx TxnContext) MutableMessage
 
+	// UniqueID returns the unique id of current message assigned by the producer.
+	// Return "" if not assigned.
+	UniqueID() string
+
+	// WithUniqueID sets the unique id of current message, the wal drops the message appended again with the same unique id.
+	// !!! preserved for streaming system internal usage, don't call it outside of streaming system.
+	WithUniqueID(id string) MutableMessage
+
 	// IntoImmutableMessage converts the mutable message to immutable message.
 	IntoImmutableMessage(msgID MessageID) ImmutableMessage
 }
@@ -93,6 +93,21 @@ func (m *messageImpl) WithTxnContext(txnCtx TxnContext) MutableMessage {
 	return m
 }
 
+// UniqueID returns the unique id of current message assigned by the producer.
+func (m *messageImpl) UniqueID() string {
+	value, _ := m.properties.Get(messageUniqueID)
+	return value
+}
+
+// WithUniqueID sets the unique id of current message.
+func (m *messageImpl) WithUniqueID(id string) MutableMessage {
+	if m.properties.Exist(messageUniqueID) {
+		panic("unique id already set in properties of message")
+	}
+	m.properties.Set(messageUniqueID, id)
+	return m
+}
+
 // IntoImmutableMessage converts current message to immutable message.
 func (m *messageImpl) IntoImmutableMessage(id MessageID) ImmutableMessage {
 	return &immutableMessageImpl{
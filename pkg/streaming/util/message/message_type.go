@@ -16,6 +16,7 @@ const (
 	MessageTypeCreateSegment    MessageType = MessageType(messagespb.MessageType_CreateSegment)
 	MessageTypeFlush            MessageType = MessageType(messagespb.MessageType_Flush)
 	MessageTypeManualFlush      MessageType = MessageType(messagespb.MessageType_ManualFlush)
+	MessageTypeBarrier          MessageType = MessageType(messagespb.MessageType_Barrier)
 	MessageTypeCreateCollection MessageType = MessageType(messagespb.MessageType_CreateCollection)
 	MessageTypeDropCollection   MessageType = MessageType(messagespb.MessageType_DropCollection)
 	MessageTypeCreatePartition  MessageType = MessageType(messagespb.MessageType_CreatePartition)
@@ -34,6 +35,7 @@ var messageTypeName = map[MessageType]string{
 	MessageTypeFlush:            "FLUSH",
 	MessageTypeCreateSegment:    "CREATE_SEGMENT",
 	MessageTypeManualFlush:      "MANUAL_FLUSH",
+	MessageTypeBarrier:          "BARRIER",
 	MessageTypeCreateCollection: "CREATE_COLLECTION",
 	MessageTypeDropCollection:   "DROP_COLLECTION",
 	MessageTypeCreatePartition:  "CREATE_PARTITION",
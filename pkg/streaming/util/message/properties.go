@@ -12,6 +12,7 @@ const (
 	messageVChannel                         = "_vc"  // message virtual channel.
 	messageHeader                           = "_h"   // specialized message header.
 	messageTxnContext                       = "_tx"  // transaction context.
+	messageUniqueID                         = "_uid" // unique id of the message assigned by the producer, kept across the retries.
 )
 
 var (
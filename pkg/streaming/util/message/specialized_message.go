@@ -24,6 +24,7 @@ type (
 	FlushMessageHeader            = messagespb.FlushMessageHeader
 	CreateSegmentMessageHeader    = messagespb.CreateSegmentMessageHeader
 	ManualFlushMessageHeader      = messagespb.ManualFlushMessageHeader
+	BarrierMessageHeader          = messagespb.BarrierMessageHeader
 	BeginTxnMessageHeader         = messagespb.BeginTxnMessageHeader
 	CommitTxnMessageHeader        = messagespb.CommitTxnMessageHeader
 	RollbackTxnMessageHeader      = messagespb.RollbackTxnMessageHeader
@@ -34,6 +35,7 @@ type (
 	FlushMessageBody         = messagespb.FlushMessageBody
 	CreateSegmentMessageBody = messagespb.CreateSegmentMessageBody
 	ManualFlushMessageBody   = messagespb.ManualFlushMessageBody
+	BarrierMessageBody       = messagespb.BarrierMessageBody
 	BeginTxnMessageBody      = messagespb.BeginTxnMessageBody
 	CommitTxnMessageBody     = messagespb.CommitTxnMessageBody
 	RollbackTxnMessageBody   = messagespb.RollbackTxnMessageBody
@@ -56,6 +58,7 @@ var messageTypeMap = map[reflect.Type]MessageType{
 	reflect.TypeOf(&CreateSegmentMessageHeader{}):    MessageTypeCreateSegment,
 	reflect.TypeOf(&FlushMessageHeader{}):            MessageTypeFlush,
 	reflect.TypeOf(&ManualFlushMessageHeader{}):      MessageTypeManualFlush,
+	reflect.TypeOf(&BarrierMessageHeader{}):          MessageTypeBarrier,
 	reflect.TypeOf(&BeginTxnMessageHeader{}):         MessageTypeBeginTxn,
 	reflect.TypeOf(&CommitTxnMessageHeader{}):        MessageTypeCommitTxn,
 	reflect.TypeOf(&RollbackTxnMessageHeader{}):      MessageTypeRollbackTxn,
@@ -68,6 +68,11 @@ const (
 
 	HeaderUserAgent = "user-agent"
 	HeaderDBName    = "dbName"
+	// HeaderStatisticsConsistency selects the segments the row count statistics computed on,
+	// "flushed" or "include_growing", see internalpb.StatisticsConsistency
+	HeaderStatisticsConsistency = "statistics-consistency"
+	// HeaderInsertAckLevel selects the level the insert is acknowledged at, "wal", "growing" or "flush"
+	HeaderInsertAckLevel = "insert-ack-level"
 
 	RoleConfigPrivileges = "privileges"
 	RoleConfigObjectType = "object_type"
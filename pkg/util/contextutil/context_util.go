@@ -48,6 +48,47 @@ func TenantID(ctx context.Context) string {
 	return ""
 }
 
+// RequestTagsKey is the rpc metadata key of the request tags.
+// The tags are free-form "key=value" pairs separated by comma, e.g. "job=nightly-recs,team=recs",
+// they are propagated along the request to attribute the workload in logs and metrics.
+const RequestTagsKey = "request_tags"
+
+type ctxRequestTagsKey struct{}
+
+// WithRequestTags creates a new context that has request tags injected.
+func WithRequestTags(ctx context.Context, tags string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, ctxRequestTagsKey{}, tags)
+}
+
+// RequestTags tries to retrieve the request tags from the given context.
+// If it doesn't exist, an empty string is returned.
+func RequestTags(ctx context.Context) string {
+	if tags, ok := ctx.Value(ctxRequestTagsKey{}).(string); ok {
+		return tags
+	}
+	return ""
+}
+
+// ParseRequestTags parses the request tags into key value pairs, the malformed items are ignored.
+func ParseRequestTags(tags string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range strings.Split(tags, ",") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if key == "" || value == "" {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
 func AppendToIncomingContext(ctx context.Context, kv ...string) context.Context {
 	if len(kv)%2 == 1 {
 		panic(fmt.Sprintf("metadata: AppendToOutgoingContext got an odd number of input pairs for metadata: %d", len(kv)))
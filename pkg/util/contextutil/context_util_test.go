@@ -48,6 +48,19 @@ func TestAppendToIncomingContext(t *testing.T) {
 	})
 }
 
+func TestRequestTags(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", RequestTags(ctx))
+	ctx = WithRequestTags(ctx, "job=nightly-recs")
+	assert.Equal(t, "job=nightly-recs", RequestTags(ctx))
+
+	assert.Equal(t, map[string]string{
+		"job":  "nightly-recs",
+		"team": "recs",
+	}, ParseRequestTags(" job = nightly-recs,team=recs,invalid,=empty_key,empty_value="))
+	assert.Empty(t, ParseRequestTags(""))
+}
+
 func TestGetCurUserFromContext(t *testing.T) {
 	_, err := GetCurUserFromContext(context.Background())
 	assert.Error(t, err)
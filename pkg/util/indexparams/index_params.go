@@ -55,6 +55,7 @@ var configableIndexParams = typeutil.NewSet[string]()
 func init() {
 	configableIndexParams.Insert(common.MmapEnabledKey)
 	configableIndexParams.Insert(common.IndexOffsetCacheEnabledKey)
+	configableIndexParams.Insert(common.IndexResourceGroupsKey)
 }
 
 func IsConfigableIndexParam(key string) bool {
@@ -0,0 +1,145 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+const FencingEpochKey = "FencingEpoch"
+
+// WithFencingEpoch attaches the fencing epoch of the coordinator to the outgoing context.
+// A non-positive epoch means the coordinator has no leadership yet, the context is returned as is.
+func WithFencingEpoch(ctx context.Context, epoch int64) context.Context {
+	if epoch <= 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, FencingEpochKey, fmt.Sprint(epoch))
+}
+
+// EpochStore persists the fencing epoch, so that the fence survives the restart of the worker node.
+type EpochStore interface {
+	// Load returns the saved epoch, 0 if none is saved.
+	Load(ctx context.Context) (int64, error)
+	// Save saves the epoch unless a higher one is saved already, returns the highest epoch saved.
+	Save(ctx context.Context, epoch int64) (int64, error)
+}
+
+// EpochFencer keeps the highest fencing epoch seen by a worker node.
+type EpochFencer struct {
+	store EpochStore
+	// mu serializes the loading and the raising of the epoch
+	mu     sync.Mutex
+	loaded atomic.Bool
+	epoch  atomic.Int64
+}
+
+// NewEpochFencer creates a new EpochFencer, the epoch is kept in memory only if the store is nil.
+func NewEpochFencer(store EpochStore) *EpochFencer {
+	return &EpochFencer{store: store}
+}
+
+// Check accepts the epoch if it is not lower than the highest epoch seen so far,
+// a request carrying a lower epoch comes from a coordinator which has lost its leadership.
+// A higher epoch is saved into the store before it's accepted.
+func (f *EpochFencer) Check(ctx context.Context, epoch int64) error {
+	if err := f.load(ctx); err != nil {
+		return err
+	}
+	if current := f.epoch.Load(); epoch < current {
+		return merr.WrapErrNodeEpochStale(epoch, current, "request from stale coordinator rejected")
+	} else if epoch == current {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current := f.epoch.Load()
+	if epoch > current && f.store != nil {
+		saved, err := f.store.Save(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		current = saved
+	}
+	if epoch < current {
+		f.epoch.Store(current)
+		return merr.WrapErrNodeEpochStale(epoch, current, "request from stale coordinator rejected")
+	}
+	f.epoch.Store(epoch)
+	return nil
+}
+
+func (f *EpochFencer) load(ctx context.Context) error {
+	if f.store == nil || f.loaded.Load() {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loaded.Load() {
+		return nil
+	}
+	epoch, err := f.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if epoch > f.epoch.Load() {
+		f.epoch.Store(epoch)
+	}
+	f.loaded.Store(true)
+	return nil
+}
+
+// Epoch returns the highest fencing epoch seen so far.
+func (f *EpochFencer) Epoch() int64 {
+	return f.epoch.Load()
+}
+
+// FencingValidationUnaryServerInterceptor returns a new unary server interceptor that
+// rejects the requests carrying a fencing epoch lower than the highest one seen by the server.
+// The requests without the epoch are accepted for the compatibility with the old coordinators,
+// the ones with a malformed epoch are rejected, as they may come from a stale or corrupted caller.
+func FencingValidationUnaryServerInterceptor(fencer *EpochFencer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+		values := md.Get(FencingEpochKey)
+		if len(values) == 0 {
+			return handler(ctx, req)
+		}
+		// only the positive epochs are attached by WithFencingEpoch
+		epoch, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil || epoch <= 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("malformed fencing epoch %q", values[0])
+		}
+		if err := fencer.Check(ctx, epoch); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
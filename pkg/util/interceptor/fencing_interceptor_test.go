@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestFencingInterceptor(t *testing.T) {
+	t.Run("test WithFencingEpoch", func(t *testing.T) {
+		ctx := WithFencingEpoch(context.Background(), 0)
+		_, ok := metadata.FromOutgoingContext(ctx)
+		assert.False(t, ok)
+
+		ctx = WithFencingEpoch(context.Background(), 10)
+		md, ok := metadata.FromOutgoingContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "10", md.Get(FencingEpochKey)[0])
+	})
+
+	t.Run("test EpochFencer", func(t *testing.T) {
+		ctx := context.Background()
+		fencer := NewEpochFencer(nil)
+		assert.NoError(t, fencer.Check(ctx, 10))
+		assert.NoError(t, fencer.Check(ctx, 10))
+		assert.NoError(t, fencer.Check(ctx, 20))
+		assert.Equal(t, int64(20), fencer.Epoch())
+		assert.ErrorIs(t, fencer.Check(ctx, 10), merr.ErrNodeEpochStale)
+		assert.Equal(t, int64(20), fencer.Epoch())
+	})
+
+	t.Run("test EpochFencer with store", func(t *testing.T) {
+		ctx := context.Background()
+		store := &memEpochStore{epoch: 20}
+		fencer := NewEpochFencer(store)
+		// the epoch saved before the restart is loaded
+		assert.ErrorIs(t, fencer.Check(ctx, 10), merr.ErrNodeEpochStale)
+		assert.NoError(t, fencer.Check(ctx, 20))
+		assert.NoError(t, fencer.Check(ctx, 30))
+		assert.Equal(t, int64(30), store.epoch)
+
+		// a higher epoch saved by another node
+		store.epoch = 40
+		assert.ErrorIs(t, fencer.Check(ctx, 35), merr.ErrNodeEpochStale)
+		assert.Equal(t, int64(40), fencer.Epoch())
+
+		// the epoch is not accepted if it can't be saved
+		store.err = errors.New("mock")
+		assert.Error(t, fencer.Check(ctx, 50))
+		assert.Equal(t, int64(40), fencer.Epoch())
+		assert.NoError(t, fencer.Check(ctx, 40))
+
+		store = &memEpochStore{err: errors.New("mock")}
+		fencer = NewEpochFencer(store)
+		assert.Error(t, fencer.Check(ctx, 10))
+		store.err = nil
+		assert.NoError(t, fencer.Check(ctx, 10))
+	})
+
+	t.Run("test FencingValidationUnaryServerInterceptor", func(t *testing.T) {
+		method := "MockMethod"
+		req := &milvuspb.InsertRequest{}
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+		serverInfo := &grpc.UnaryServerInfo{FullMethod: method}
+		fencer := NewEpochFencer(nil)
+		interceptor := FencingValidationUnaryServerInterceptor(fencer)
+
+		// no md in context
+		_, err := interceptor(context.Background(), req, serverInfo, handler)
+		assert.NoError(t, err)
+
+		// no epoch in md
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(make(map[string]string)))
+		_, err = interceptor(ctx, req, serverInfo, handler)
+		assert.NoError(t, err)
+
+		// malformed epoch
+		for _, value := range []string{"abc", "", "0", "-1", "1.5"} {
+			ctx = metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{FencingEpochKey: value}))
+			_, err = interceptor(ctx, req, serverInfo, handler)
+			assert.ErrorIs(t, err, merr.ErrParameterInvalid, value)
+		}
+		assert.Equal(t, int64(0), fencer.Epoch())
+
+		// newer epoch
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{FencingEpochKey: "20"}))
+		_, err = interceptor(ctx, req, serverInfo, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), fencer.Epoch())
+
+		// stale epoch
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{FencingEpochKey: "10"}))
+		_, err = interceptor(ctx, req, serverInfo, handler)
+		assert.ErrorIs(t, err, merr.ErrNodeEpochStale)
+	})
+}
+
+type memEpochStore struct {
+	epoch int64
+	err   error
+}
+
+func (s *memEpochStore) Load(ctx context.Context) (int64, error) {
+	return s.epoch, s.err
+}
+
+func (s *memEpochStore) Save(ctx context.Context, epoch int64) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.epoch = max(s.epoch, epoch)
+	return s.epoch, nil
+}
@@ -5,11 +5,13 @@ import (
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 )
 
 const (
@@ -69,6 +71,11 @@ func withLevelAndTrace(ctx context.Context) context.Context {
 			// inject traceid from client for info/debug/warn/error logs
 			newctx = log.WithTraceID(newctx, requestID[0])
 		}
+		// request tags
+		tags := md.Get(contextutil.RequestTagsKey)
+		if len(tags) >= 1 {
+			newctx = WithRequestTags(newctx, tags[0])
+		}
 	}
 	if !traceID.IsValid() {
 		traceID = trace.SpanContextFromContext(newctx).TraceID()
@@ -78,3 +85,14 @@ func withLevelAndTrace(ctx context.Context) context.Context {
 	}
 	return newctx
 }
+
+// WithRequestTags attaches the request tags to the ctx, the tags are logged with the ctx logger
+// and passed to the downstream rpc calls.
+func WithRequestTags(ctx context.Context, tags string) context.Context {
+	if tags == "" {
+		return ctx
+	}
+	ctx = contextutil.WithRequestTags(ctx, tags)
+	ctx = metadata.AppendToOutgoingContext(ctx, contextutil.RequestTagsKey, tags)
+	return log.WithFields(ctx, zap.String("requestTags", tags))
+}
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 )
 
 func TestCtxWithLevelAndTrace(t *testing.T) {
@@ -59,6 +60,18 @@ func TestCtxWithLevelAndTrace(t *testing.T) {
 		expectedctx = log.WithTraceID(expectedctx, md.Get(clientRequestIDKey)[0])
 		assert.Equal(t, log.Ctx(expectedctx), log.Ctx(newctx))
 	})
+
+	t.Run("request tags", func(t *testing.T) {
+		md := metadata.New(map[string]string{
+			contextutil.RequestTagsKey: "job=nightly-recs",
+		})
+		ctx := metadata.NewIncomingContext(context.TODO(), md)
+		newctx := withLevelAndTrace(ctx)
+		md, ok := metadata.FromOutgoingContext(newctx)
+		assert.True(t, ok)
+		assert.Equal(t, "job=nightly-recs", md.Get(contextutil.RequestTagsKey)[0])
+		assert.Equal(t, "job=nightly-recs", contextutil.RequestTags(newctx))
+	})
 }
 
 func withMetaData(ctx context.Context, level zapcore.Level) context.Context {
@@ -60,6 +60,7 @@ var (
 	ErrServiceUnimplemented        = newMilvusError("service unimplemented", 10, false)
 	ErrServiceTimeTickLongDelay    = newMilvusError("time tick long delay", 11, false)
 	ErrServiceResourceInsufficient = newMilvusError("service resource insufficient", 12, true)
+	ErrServiceBusy                 = newMilvusError("server busy", 13, true)
 
 	// Collection related
 	ErrCollectionNotFound                      = newMilvusError("collection not found", 100, false)
@@ -111,6 +112,7 @@ var (
 	ErrIndexNotSupported = newMilvusError("index type not supported", 701, false)
 	ErrIndexDuplicate    = newMilvusError("index duplicates", 702, false)
 	ErrTaskDuplicate     = newMilvusError("task duplicates", 703, false)
+	ErrIndexVersion      = newMilvusError("index version not supported", 704, false)
 
 	// Database related
 	ErrDatabaseNotFound         = newMilvusError("database not found", 800, false)
@@ -124,6 +126,7 @@ var (
 	ErrNodeNotMatch        = newMilvusError("node not match", 904, false)
 	ErrNodeNotAvailable    = newMilvusError("node not available", 905, false)
 	ErrNodeStateUnexpected = newMilvusError("node state unexpected", 906, false)
+	ErrNodeEpochStale      = newMilvusError("fencing epoch stale", 907, false)
 
 	// IO related
 	ErrIoKeyNotFound = newMilvusError("key not found", 1000, false)
@@ -217,6 +220,10 @@ var (
 
 	ErrDataNodeSlotExhausted = newMilvusError("datanode slot exhausted", 2401, false)
 
+	// Backup related
+	ErrBackupNotFound     = newMilvusError("backup not found", 2500, false)
+	ErrBackupAlreadyExist = newMilvusError("backup already exist", 2501, false)
+
 	// General
 	ErrOperationNotSupported = newMilvusError("unsupported operation", 3000, false)
 )
@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/suite"
@@ -80,6 +81,7 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrServiceDiskLimitExceeded(110, 100, "DLE"), ErrServiceDiskLimitExceeded)
 	s.ErrorIs(WrapErrNodeNotMatch(0, 1, "SIM"), ErrNodeNotMatch)
 	s.ErrorIs(WrapErrServiceUnimplemented(errors.New("mock grpc err")), ErrServiceUnimplemented)
+	s.ErrorIs(WrapErrServiceBusy(100*time.Millisecond, "too many pending requests"), ErrServiceBusy)
 
 	// Collection related
 	s.ErrorIs(WrapErrCollectionNotFound("test_collection", "failed to get collection"), ErrCollectionNotFound)
@@ -122,12 +124,14 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrIndexNotFoundForCollection("milvus_hello", "failed to get collection index"), ErrIndexNotFound)
 	s.ErrorIs(WrapErrIndexNotFoundForSegments([]int64{100}, "failed to get collection index"), ErrIndexNotFound)
 	s.ErrorIs(WrapErrIndexNotSupported("wsnh", "failed to create index"), ErrIndexNotSupported)
+	s.ErrorIs(WrapErrIndexVersion(6, 0, 5, "index is newer than supported"), ErrIndexVersion)
 
 	// Node related
 	s.ErrorIs(WrapErrNodeNotFound(1, "failed to get node"), ErrNodeNotFound)
 	s.ErrorIs(WrapErrNodeOffline(1, "failed to access node"), ErrNodeOffline)
 	s.ErrorIs(WrapErrNodeLack(3, 1, "need more nodes"), ErrNodeLack)
 	s.ErrorIs(WrapErrNodeStateUnexpected(1, "Stopping", "failed to suspend node"), ErrNodeStateUnexpected)
+	s.ErrorIs(WrapErrNodeEpochStale(1, 2, "SIM"), ErrNodeEpochStale)
 
 	// IO related
 	s.ErrorIs(WrapErrIoKeyNotFound("test_key", "failed to read"), ErrIoKeyNotFound)
@@ -157,6 +161,10 @@ func (s *ErrSuite) TestWrap() {
 
 	// Search/Query related
 	s.ErrorIs(WrapErrInconsistentRequery("unknown"), ErrInconsistentRequery)
+
+	// Backup related
+	s.ErrorIs(WrapErrBackupNotFound("backup1", "failed to restore"), ErrBackupNotFound)
+	s.ErrorIs(WrapErrBackupAlreadyExist("backup1"), ErrBackupAlreadyExist)
 }
 
 func (s *ErrSuite) TestOldCode() {
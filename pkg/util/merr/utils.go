@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
@@ -423,6 +424,15 @@ func WrapErrServiceRateLimit(rate float64, msg ...string) error {
 	return err
 }
 
+// WrapErrServiceBusy wraps ErrServiceBusy with the suggested backoff before retrying
+func WrapErrServiceBusy(retryAfter time.Duration, msg ...string) error {
+	err := wrapFields(ErrServiceBusy, value("retryAfter", retryAfter))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrServiceQuotaExceeded(reason string, msg ...string) error {
 	err := wrapFields(ErrServiceQuotaExceeded, value("reason", reason))
 	if len(msg) > 0 {
@@ -817,6 +827,19 @@ func WrapErrIndexDuplicate(indexName string, msg ...string) error {
 	return err
 }
 
+// WrapErrIndexVersion wraps ErrIndexVersion with the version of the index and the range this node supports
+func WrapErrIndexVersion(version, minimal, current int64, msg ...string) error {
+	err := wrapFields(ErrIndexVersion,
+		value("version", version),
+		value("minimal", minimal),
+		value("current", current),
+	)
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrTaskDuplicate(taskType string, msg ...string) error {
 	err := wrapFields(ErrTaskDuplicate, value("taskType", taskType))
 	if len(msg) > 0 {
@@ -888,6 +911,17 @@ func WrapErrNodeNotMatch(expectedNodeID, actualNodeID int64, msg ...string) erro
 	return err
 }
 
+func WrapErrNodeEpochStale(epoch, currentEpoch int64, msg ...string) error {
+	err := wrapFields(ErrNodeEpochStale,
+		value("epoch", epoch),
+		value("currentEpoch", currentEpoch),
+	)
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 // IO related
 func WrapErrIoKeyNotFound(key string, msg ...string) error {
 	err := wrapFields(ErrIoKeyNotFound, value("key", key))
@@ -1106,6 +1140,22 @@ func WrapErrImportFailed(msg ...string) error {
 	return err
 }
 
+func WrapErrBackupNotFound(name string, msg ...string) error {
+	err := wrapFields(ErrBackupNotFound, value("backup", name))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+func WrapErrBackupAlreadyExist(name string, msg ...string) error {
+	err := wrapFields(ErrBackupAlreadyExist, value("backup", name))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrInconsistentRequery(msg ...string) error {
 	err := error(ErrInconsistentRequery)
 	if len(msg) > 0 {
@@ -66,6 +66,17 @@ func BuildDeltaLogPath(rootPath string, collectionID, partitionID, segmentID, lo
 	return path.Join(rootPath, common.SegmentDeltaLogPath, k)
 }
 
+// BuildCollectionLogPrefixes returns the object key prefixes of all the insert, delta, stats and bm25 logs of the collection.
+func BuildCollectionLogPrefixes(rootPath string, collectionID typeutil.UniqueID) []string {
+	logPaths := []string{common.SegmentInsertLogPath, common.SegmentDeltaLogPath, common.SegmentStatslogPath, common.SegmentBm25LogPath}
+	prefixes := make([]string, 0, len(logPaths))
+	for _, logPath := range logPaths {
+		// the trailing separator keeps the prefix from matching the collections with longer ids
+		prefixes = append(prefixes, path.Join(rootPath, logPath, strconv.FormatInt(collectionID, 10))+pathSep)
+	}
+	return prefixes
+}
+
 func GetSegmentIDFromDeltaLogPath(logPath string) typeutil.UniqueID {
 	return getSegmentIDFromPath(logPath, 2)
 }
@@ -18,8 +18,11 @@ package metautil
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -138,3 +141,14 @@ func TestParseInsertLogPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildCollectionLogPrefixes(t *testing.T) {
+	assert.Equal(t, []string{
+		"files/insert_log/1/",
+		"files/delta_log/1/",
+		"files/stats_log/1/",
+		"files/bm25_stats/1/",
+	}, BuildCollectionLogPrefixes("files", 1))
+	assert.True(t, strings.HasPrefix(BuildInsertLogPath("files", 1, 2, 3, 4, 5), BuildCollectionLogPrefixes("files", 1)[0]))
+	assert.False(t, strings.HasPrefix(BuildInsertLogPath("files", 10, 2, 3, 4, 5), BuildCollectionLogPrefixes("files", 1)[0]))
+}
@@ -0,0 +1,349 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"github.com/tidwall/gjson"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+)
+
+const (
+	// MetricRequestParamCursorKey requests the delta of the metrics since the cursor returned by the previous request,
+	// 0 requests the full metrics with a cursor for the next request.
+	MetricRequestParamCursorKey = "cursor"
+
+	// maxMetricsClients is the number of clients whose snapshots are kept, the least recently polling one is evicted,
+	// the request with an evicted or unknown cursor gets the full metrics.
+	maxMetricsClients = 64
+	// maxClientSnapshots is the number of the recent snapshots kept per client,
+	// so the poll retried with the previous cursor still gets a delta.
+	maxClientSnapshots = 2
+)
+
+// DeltaResponse is the response of the metrics request with cursor.
+type DeltaResponse struct {
+	// Cursor to request the delta since this response
+	Cursor int64 `json:"cursor"`
+	// Delta is true if Data is the JSON merge patch (RFC 7386) to the metrics of the requested cursor,
+	// false if Data is the full metrics.
+	Delta bool            `json:"delta"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// MetricsClient is a client polling the metrics with cursors. It keeps the recent snapshots returned to the client,
+// and the cursors of the metrics requested from the other components on behalf of the client,
+// so every component computes the delta of the metrics it owns.
+type MetricsClient struct {
+	// the request without cursor, the cursors are valid only for the same request
+	key string
+
+	mu        sync.Mutex
+	snapshots map[int64]any
+	order     []int64
+	remotes   map[string]*remoteMetrics
+}
+
+func (c *MetricsClient) remote(component string) *remoteMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.remotes == nil {
+		c.remotes = make(map[string]*remoteMetrics)
+	}
+	r, ok := c.remotes[component]
+	if !ok {
+		r = &remoteMetrics{}
+		c.remotes[component] = r
+	}
+	return r
+}
+
+// MetricsSnapshots keeps the clients polling the metrics with cursors,
+// so the clients polling frequently only receive the changes of the metrics.
+type MetricsSnapshots struct {
+	mu      sync.Mutex
+	next    int64
+	cursors map[int64]*MetricsClient
+	// the clients ordered by the last poll, the most recent one last
+	clients []*MetricsClient
+}
+
+func NewMetricsSnapshots() *MetricsSnapshots {
+	return &MetricsSnapshots{
+		// cursors issued before a restart are unlikely to be valid after it
+		next:    time.Now().UnixNano(),
+		cursors: make(map[int64]*MetricsClient),
+	}
+}
+
+// RequestWithCursor returns whether the metrics request asks for delta response.
+func RequestWithCursor(jsonReq gjson.Result) bool {
+	return jsonReq.Get(MetricRequestParamCursorKey).Exists()
+}
+
+// Client returns the client which was issued the cursor of the request,
+// a new client if the cursor is unknown or was issued to a different request.
+func (s *MetricsSnapshots) Client(jsonReq gjson.Result) (*MetricsClient, error) {
+	key, err := RequestWithoutCursor(jsonReq)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if client, ok := s.cursors[jsonReq.Get(MetricRequestParamCursorKey).Int()]; ok && client.key == key {
+		return client, nil
+	}
+	client := &MetricsClient{key: key, snapshots: make(map[int64]any)}
+	s.touch(client)
+	return client, nil
+}
+
+// touch makes the client the most recently polling one, and evicts the least recently polling one beyond the limit,
+// must be called with the lock held.
+func (s *MetricsSnapshots) touch(client *MetricsClient) {
+	if i := lo.IndexOf(s.clients, client); i >= 0 {
+		s.clients = append(s.clients[:i:i], s.clients[i+1:]...)
+	}
+	s.clients = append(s.clients, client)
+	if len(s.clients) > maxMetricsClients {
+		evicted := s.clients[0]
+		s.clients = s.clients[1:]
+		evicted.mu.Lock()
+		for _, cursor := range evicted.order {
+			delete(s.cursors, cursor)
+		}
+		evicted.order = nil
+		evicted.snapshots = make(map[int64]any)
+		evicted.mu.Unlock()
+	}
+}
+
+// Delta records the metrics as the latest snapshot of the client,
+// and returns the delta response since the snapshot of the requested cursor,
+// the full metrics is returned if the snapshot is unknown to the client.
+func (s *MetricsSnapshots) Delta(client *MetricsClient, jsonReq gjson.Result, metrics string) (string, error) {
+	value, err := decodeJSON([]byte(metrics))
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.next++
+	cursor := s.next
+	s.cursors[cursor] = client
+	s.touch(client)
+	client.mu.Lock()
+	prev, hasPrev := client.snapshots[jsonReq.Get(MetricRequestParamCursorKey).Int()]
+	client.snapshots[cursor] = value
+	client.order = append(client.order, cursor)
+	if len(client.order) > maxClientSnapshots {
+		delete(client.snapshots, client.order[0])
+		delete(s.cursors, client.order[0])
+		client.order = client.order[1:]
+	}
+	client.mu.Unlock()
+	s.mu.Unlock()
+
+	resp := &DeltaResponse{Cursor: cursor, Data: json.RawMessage(metrics)}
+	if hasPrev {
+		patch, err := json.Marshal(mergePatch(prev, value))
+		if err != nil {
+			return "", err
+		}
+		resp.Delta = true
+		resp.Data = patch
+	}
+	ret, err := json.Marshal(resp)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+type metricsClientKey struct{}
+
+// WithMetricsClient returns the context requesting the metrics of the other components on behalf of the client.
+func WithMetricsClient(ctx context.Context, client *MetricsClient) context.Context {
+	return context.WithValue(ctx, metricsClientKey{}, client)
+}
+
+// RequestRemoteMetrics requests the metrics of the component by call. If the ctx is on behalf of a polling client,
+// the request carries the cursor the component returned to the client last time, so the component only sends the delta,
+// which is applied to the metrics received before. The response carries the full metrics anyway.
+func RequestRemoteMetrics(ctx context.Context, component string, req *milvuspb.GetMetricsRequest,
+	call func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error),
+) (*milvuspb.GetMetricsResponse, error) {
+	client, ok := ctx.Value(metricsClientKey{}).(*MetricsClient)
+	if !ok {
+		return call(req)
+	}
+	remote := client.remote(component)
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+
+	request, err := requestWithCursor(req.GetRequest(), remote.cursor)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := call(&milvuspb.GetMetricsRequest{Base: req.GetBase(), Request: request})
+	if err != nil || resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success || resp.GetStatus().GetCode() != 0 {
+		// the caller handles the failure as usual
+		remote.reset()
+		return resp, err
+	}
+	metrics, err := remote.apply(resp.GetResponse())
+	if err != nil {
+		remote.reset()
+		return nil, err
+	}
+	resp.Response = metrics
+	return resp, nil
+}
+
+// remoteMetrics is the metrics received from a component on behalf of a client.
+type remoteMetrics struct {
+	mu     sync.Mutex
+	cursor int64
+	value  any
+}
+
+func (r *remoteMetrics) reset() {
+	r.cursor, r.value = 0, nil
+}
+
+// apply applies the delta response to the metrics received before, and returns the full metrics.
+func (r *remoteMetrics) apply(response string) (string, error) {
+	ret := gjson.Parse(response)
+	if !ret.Get(MetricRequestParamCursorKey).Exists() || !ret.Get("data").Exists() {
+		// the component doesn't support delta, e.g. during rolling upgrade
+		r.reset()
+		return response, nil
+	}
+	resp := &DeltaResponse{}
+	if err := json.Unmarshal([]byte(response), resp); err != nil {
+		return "", err
+	}
+	value, err := decodeJSON(resp.Data)
+	if err != nil {
+		return "", err
+	}
+	if resp.Delta {
+		if r.value == nil {
+			return "", errors.New("metrics delta received without the metrics it applies to")
+		}
+		value = applyMergePatch(r.value, value)
+	}
+	r.cursor, r.value = resp.Cursor, value
+	metrics, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(metrics), nil
+}
+
+// RequestWithoutCursor returns the metrics request with the cursor removed, which is forwarded to the other components,
+// and identifies the request regardless of the cursor.
+func RequestWithoutCursor(jsonReq gjson.Result) (string, error) {
+	params := make(map[string]json.RawMessage)
+	for key, value := range jsonReq.Map() {
+		if key != MetricRequestParamCursorKey {
+			params[key] = json.RawMessage(value.Raw)
+		}
+	}
+	// keys of map are sorted by json
+	req, err := json.Marshal(params)
+	return string(req), err
+}
+
+func requestWithCursor(request string, cursor int64) (string, error) {
+	params := make(map[string]any)
+	for key, value := range gjson.Parse(request).Map() {
+		params[key] = json.RawMessage(value.Raw)
+	}
+	params[MetricRequestParamCursorKey] = cursor
+	req, err := json.Marshal(params)
+	return string(req), err
+}
+
+// decodeJSON decodes the JSON value, keeping the numbers as they are, e.g. the int64 IDs.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// mergePatch computes the JSON merge patch transforming from into to,
+// the null values of objects can't be told from removed keys by merge patch.
+func mergePatch(from, to any) any {
+	fromObj, ok1 := from.(map[string]any)
+	toObj, ok2 := to.(map[string]any)
+	if !ok1 || !ok2 {
+		return to
+	}
+	patch := make(map[string]any)
+	for key, toValue := range toObj {
+		fromValue, ok := fromObj[key]
+		if !ok {
+			patch[key] = toValue
+			continue
+		}
+		if reflect.DeepEqual(fromValue, toValue) {
+			continue
+		}
+		patch[key] = mergePatch(fromValue, toValue)
+	}
+	for key := range fromObj {
+		if _, ok := toObj[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
+
+// applyMergePatch applies the JSON merge patch to target, the target may be modified.
+func applyMergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = applyMergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
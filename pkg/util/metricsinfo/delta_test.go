@@ -0,0 +1,171 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsinfo
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+)
+
+func Test_mergePatch(t *testing.T) {
+	from := map[string]any{"a": 1.0, "b": map[string]any{"c": "x", "d": []any{1.0}}, "e": true}
+	to := map[string]any{"a": 1.0, "b": map[string]any{"c": "y", "d": []any{1.0}}, "f": "new"}
+	patch := mergePatch(from, to)
+	assert.Equal(t, map[string]any{"b": map[string]any{"c": "y"}, "e": nil, "f": "new"}, patch)
+	assert.Equal(t, map[string]any{}, mergePatch(to, to))
+	assert.Equal(t, []any{2.0}, mergePatch([]any{1.0}, []any{2.0}))
+
+	assert.Equal(t, to, applyMergePatch(from, patch))
+	assert.Equal(t, []any{2.0}, applyMergePatch(map[string]any{}, []any{2.0}))
+}
+
+func TestMetricsSnapshots(t *testing.T) {
+	s := NewMetricsSnapshots()
+	delta := func(req string, metrics string) *DeltaResponse {
+		jsonReq := gjson.Parse(req)
+		client, err := s.Client(jsonReq)
+		require.NoError(t, err)
+		ret, err := s.Delta(client, jsonReq, metrics)
+		require.NoError(t, err)
+		resp := &DeltaResponse{}
+		require.NoError(t, json.Unmarshal([]byte(ret), resp))
+		return resp
+	}
+
+	full := delta(`{"metric_type": "system_info", "cursor": 0}`, `{"nodes": {"1": "up", "2": "up"}, "id": 449865458924101633}`)
+	assert.False(t, full.Delta)
+	assert.JSONEq(t, `{"nodes": {"1": "up", "2": "up"}, "id": 449865458924101633}`, string(full.Data))
+
+	resp := delta(`{"metric_type": "system_info", "cursor": `+fmtInt(full.Cursor)+`}`, `{"nodes": {"1": "up", "2": "down"}, "id": 449865458924101634}`)
+	assert.True(t, resp.Delta)
+	assert.Greater(t, resp.Cursor, full.Cursor)
+	// the int64 values are kept as they are
+	assert.JSONEq(t, `{"nodes": {"2": "down"}, "id": 449865458924101634}`, string(resp.Data))
+
+	// the poll retried with the previous cursor
+	retried := delta(`{"metric_type": "system_info", "cursor": `+fmtInt(full.Cursor)+`}`, `{"nodes": {"1": "up", "2": "down"}, "id": 449865458924101634}`)
+	assert.True(t, retried.Delta)
+
+	// cursor issued to another request
+	resp = delta(`{"metric_type": "system_info", "verbose": true, "cursor": `+fmtInt(resp.Cursor)+`}`, `{"nodes": {}}`)
+	assert.False(t, resp.Delta)
+
+	// the other clients don't evict the snapshots of a client, until the client is the least recently polling one
+	last := retried.Cursor
+	for i := 0; i < maxMetricsClients-2; i++ {
+		delta(`{"metric_type": "system_info", "cursor": 0}`, `{}`)
+	}
+	resp = delta(`{"metric_type": "system_info", "cursor": `+fmtInt(last)+`}`, `{}`)
+	assert.True(t, resp.Delta)
+	last = resp.Cursor
+	for i := 0; i < maxMetricsClients; i++ {
+		delta(`{"metric_type": "system_info", "cursor": 0}`, `{}`)
+	}
+	resp = delta(`{"metric_type": "system_info", "cursor": `+fmtInt(last)+`}`, `{}`)
+	assert.False(t, resp.Delta)
+
+	client, err := s.Client(gjson.Parse(`{"cursor": 0}`))
+	require.NoError(t, err)
+	_, err = s.Delta(client, gjson.Parse(`{"cursor": 0}`), "not json")
+	assert.Error(t, err)
+}
+
+func TestRequestRemoteMetrics(t *testing.T) {
+	// the component computing the delta of its metrics
+	source := NewMetricsSnapshots()
+	metrics := `{"nodes": {"1": "up", "2": "up"}}`
+	requested := 0
+	call := func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		requested++
+		jsonReq := gjson.Parse(req.GetRequest())
+		client, err := source.Client(jsonReq)
+		require.NoError(t, err)
+		ret, err := source.Delta(client, jsonReq, metrics)
+		require.NoError(t, err)
+		return &milvuspb.GetMetricsResponse{Status: &commonpb.Status{}, Response: ret}, nil
+	}
+	req := &milvuspb.GetMetricsRequest{Request: `{"metric_type": "system_info"}`}
+
+	// not on behalf of a client
+	resp, err := RequestRemoteMetrics(context.Background(), "querycoord", req, func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		assert.False(t, RequestWithCursor(gjson.Parse(req.GetRequest())))
+		return &milvuspb.GetMetricsResponse{Status: &commonpb.Status{}, Response: metrics}, nil
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, metrics, resp.GetResponse())
+
+	client, err := NewMetricsSnapshots().Client(gjson.Parse(`{"metric_type": "system_info", "cursor": 0}`))
+	require.NoError(t, err)
+	ctx := WithMetricsClient(context.Background(), client)
+	resp, err = RequestRemoteMetrics(ctx, "querycoord", req, call)
+	require.NoError(t, err)
+	assert.JSONEq(t, metrics, resp.GetResponse())
+
+	metrics = `{"nodes": {"1": "up", "2": "down", "3": "up"}}`
+	resp, err = RequestRemoteMetrics(ctx, "querycoord", req, func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		resp, err := call(req)
+		// only the delta is sent by the component
+		assert.Contains(t, resp.GetResponse(), `"delta":true`)
+		return resp, err
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, metrics, resp.GetResponse())
+	assert.Equal(t, 2, requested)
+
+	// the component without delta support
+	resp, err = RequestRemoteMetrics(ctx, "querycoord", req, func(req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		return &milvuspb.GetMetricsResponse{Status: &commonpb.Status{}, Response: `{"nodes": {}}`}, nil
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nodes": {}}`, resp.GetResponse())
+}
+
+func TestExecuteMetricsRequestWithCursor(t *testing.T) {
+	mr := NewMetricsRequest()
+	mr.RegisterMetricsRequest(SystemInfoMetrics, func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+		// the cursor is not forwarded
+		assert.False(t, RequestWithCursor(jsonReq))
+		assert.False(t, RequestWithCursor(gjson.Parse(req.GetRequest())))
+		assert.Equal(t, int64(100), jsonReq.Get(MetricRequestParamCollectionIDKey).Int())
+		return `{"collections": 1}`, nil
+	})
+
+	req := &milvuspb.GetMetricsRequest{Request: `{"metric_type": "system_info", "collection_id": 100, "cursor": 0}`}
+	ret, err := mr.ExecuteMetricsRequest(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"metric_type": "system_info", "collection_id": 100, "cursor": 0}`, req.GetRequest())
+	resp := &DeltaResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(ret), resp))
+	assert.False(t, resp.Delta)
+
+	ret, err = mr.ExecuteMetricsRequest(context.Background(), &milvuspb.GetMetricsRequest{Request: `{"metric_type": "system_info", "collection_id": 100}`})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"collections": 1}`, ret)
+}
+
+func fmtInt(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
@@ -60,6 +61,15 @@ const (
 	// AllTaskKey request for get all tasks on the querycoord
 	AllTaskKey = "tasks_all"
 
+	// RecoveryProgressKey request for get segment recovery progress of querynodes on the querycoord
+	RecoveryProgressKey = "recovery_progress"
+
+	// RowCountScrubKey request for get the row count scrub report on the querycoord
+	RowCountScrubKey = "row_count_scrub"
+
+	// DecisionJournalKey request for get the decision journal from the datacoord/querycoord
+	DecisionJournalKey = "decision_journal"
+
 	// ReplicaKey request for get replica on the querycoord
 	ReplicaKey = "replica"
 
@@ -103,11 +113,13 @@ type MetricsRequestAction func(ctx context.Context, req *milvuspb.GetMetricsRequ
 type MetricsRequest struct {
 	metricsReqType2Action map[string]MetricsRequestAction
 	lock                  sync.Mutex
+	snapshots             *MetricsSnapshots
 }
 
 func NewMetricsRequest() *MetricsRequest {
 	return &MetricsRequest{
 		metricsReqType2Action: make(map[string]MetricsRequestAction),
+		snapshots:             NewMetricsSnapshots(),
 	}
 }
 
@@ -140,12 +152,32 @@ func (mr *MetricsRequest) ExecuteMetricsRequest(ctx context.Context, req *milvus
 	}
 	mr.lock.Unlock()
 
-	actionRet, err := action(ctx, req, jsonReq)
+	// the action requests the other components on behalf of the client,
+	// and the delta of the metrics is computed by the component owning the metrics
+	var client *MetricsClient
+	if RequestWithCursor(jsonReq) {
+		request, err := RequestWithoutCursor(jsonReq)
+		if err != nil {
+			return "", err
+		}
+		client, err = mr.snapshots.Client(jsonReq)
+		if err != nil {
+			return "", err
+		}
+		ctx = WithMetricsClient(ctx, client)
+		req = proto.Clone(req).(*milvuspb.GetMetricsRequest)
+		req.Request = request
+	}
+
+	actionRet, err := action(ctx, req, gjson.Parse(req.Request))
 	if err != nil {
 		msg := fmt.Sprintf("failed to execute %s", reqType)
 		log.Warn(msg, zap.Error(err))
 		return "", err
 	}
+	if client != nil {
+		return mr.snapshots.Delta(client, jsonReq, actionRet)
+	}
 	return actionRet, nil
 }
 
@@ -132,6 +132,7 @@ type Segment struct {
 	ResourceGroup        string          `json:"resource_group,omitempty"`
 	LoadedInsertRowCount int64           `json:"loaded_insert_row_count,omitempty,string"` // inert row count for growing segment that excludes the deleted row count in QueryNode
 	MemSize              int64           `json:"mem_size,omitempty,string"`                // memory size of segment in QueryNode
+	MmapSize             int64           `json:"mmap_size,omitempty,string"`               // size of the mmapped field data of segment in QueryNode
 
 	// flush related
 	FlushedRows    int64 `json:"flushed_rows,omitempty,string"`
@@ -167,6 +168,34 @@ type QueryCoordTask struct {
 	Reason       string   `json:"reason,omitempty"`
 }
 
+// SegmentRecoveryProgress is the progress of loading the missing segments of a querynode
+type SegmentRecoveryProgress struct {
+	NodeID              int64 `json:"node_id"`
+	LoadedSegmentNum    int   `json:"loaded_segment_num"`
+	ExecutingSegmentNum int   `json:"executing_segment_num"`
+	PendingSegmentNum   int   `json:"pending_segment_num"`
+}
+
+// RowCountDiscrepancy is a row count of a sealed segment not matching the one of its binlogs
+type RowCountDiscrepancy struct {
+	CollectionID int64 `json:"collection_id,omitempty,string"`
+	SegmentID    int64 `json:"segment_id,omitempty,string"`
+	// the querynode the segment loaded on, 0 for the row counts of datacoord
+	NodeID int64  `json:"node_id,omitempty"`
+	Source string `json:"source,omitempty"`
+	// the row count of the binlogs, but the segcore row count for the segcore_real source,
+	// which the real row count is expected not to exceed
+	Expected int64 `json:"expected"`
+	Actual   int64 `json:"actual"`
+}
+
+// RowCountScrubReport is the result of the latest round of the row count scrubbing on the querycoord
+type RowCountScrubReport struct {
+	Time          string                 `json:"time,omitempty"`
+	SegmentNum    int                    `json:"segment_num"`
+	Discrepancies []*RowCountDiscrepancy `json:"discrepancies"`
+}
+
 type LeaderView struct {
 	LeaderID           int64      `json:"leader_id,omitempty,string"`
 	CollectionID       int64      `json:"collection_id,omitempty,string"`
@@ -74,6 +74,9 @@ type DataCoordQuotaMetrics struct {
 	TotalBinlogSize      int64
 	CollectionBinlogSize map[int64]int64
 	PartitionsBinlogSize map[int64]map[int64]int64
+	// index files of the healthy segments
+	TotalIndexSize      int64
+	CollectionIndexSize map[int64]int64
 	// l0 segments
 	CollectionL0RowCount map[int64]int64
 }
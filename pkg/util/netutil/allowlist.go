@@ -0,0 +1,82 @@
+package netutil
+
+import (
+	"net"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// ParseCIDRs parses the CIDRs, a bare IP address is taken as the CIDR of itself only.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var ret []*net.IPNet
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, errors.Newf("invalid ip address %s in the allowlist", cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			ret = append(ret, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cidr %s in the allowlist", cidr)
+		}
+		ret = append(ret, ipNet)
+	}
+	return ret, nil
+}
+
+// NewAllowlistListener wraps the listener to close the connections from outside the allowed networks,
+// the listener is returned as is if no network is given.
+func NewAllowlistListener(lis net.Listener, allowed []*net.IPNet) net.Listener {
+	if len(allowed) == 0 {
+		return lis
+	}
+	return &allowlistListener{Listener: lis, allowed: allowed}
+}
+
+type allowlistListener struct {
+	net.Listener
+	allowed []*net.IPNet
+}
+
+func (l *allowlistListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.allow(conn.RemoteAddr()) {
+			return conn, nil
+		}
+		log.RatedWarn(10, "reject the connection from outside the allowlist",
+			zap.String("listener", l.Addr().String()), zap.String("remote", conn.RemoteAddr().String()))
+		conn.Close()
+	}
+}
+
+func (l *allowlistListener) allow(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, ipNet := range l.allowed {
+		if ipNet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 )
@@ -11,13 +12,14 @@ import (
 // NewListener creates a new listener that listens on the specified network and IP address.
 func NewListener(opts ...Opt) (*NetListener, error) {
 	config := getNetListenerConfig(opts...)
-	if config.tlsConfig != nil {
-		return newTLSListener(config.tlsConfig, opts...)
+	allowed, err := ParseCIDRs(config.allowedCIDRs)
+	if err != nil {
+		return nil, err
 	}
 
 	// Use the highPriorityToUsePort if it is set.
 	if config.highPriorityToUsePort != 0 {
-		if lis, err := net.Listen(config.net, fmt.Sprintf(":%d", config.highPriorityToUsePort)); err == nil {
+		if lis, err := config.listen(config.highPriorityToUsePort, allowed); err == nil {
 			return &NetListener{
 				Listener: lis,
 				port:     config.highPriorityToUsePort,
@@ -26,7 +28,7 @@ func NewListener(opts ...Opt) (*NetListener, error) {
 		}
 	}
 	// Otherwise use the port number specified by the user.
-	lis, err := net.Listen(config.net, fmt.Sprintf(":%d", config.port))
+	lis, err := config.listen(config.port, allowed)
 	if err != nil {
 		return nil, err
 	}
@@ -37,29 +39,18 @@ func NewListener(opts ...Opt) (*NetListener, error) {
 	}, nil
 }
 
-// newTLSListener creates a new listener that listens on the specified network and IP address with TLS.
-func newTLSListener(c *tls.Config, opts ...Opt) (*NetListener, error) {
-	config := getNetListenerConfig(opts...)
-	// Use the highPriorityToUsePort if it is set.
-	if config.highPriorityToUsePort != 0 {
-		if lis, err := tls.Listen(config.net, fmt.Sprintf(":%d", config.highPriorityToUsePort), c); err == nil {
-			return &NetListener{
-				Listener: lis,
-				port:     config.highPriorityToUsePort,
-				address:  fmt.Sprintf("%s:%d", config.ip, config.highPriorityToUsePort),
-			}, nil
-		}
-	}
-	// Otherwise use the port number specified by the user.
-	lis, err := tls.Listen(config.net, fmt.Sprintf(":%d", config.port), c)
+// listen listens on the port of the bind ip, with the allowlist and the tls applied.
+func (c *netListenerConfig) listen(port int, allowed []*net.IPNet) (net.Listener, error) {
+	lis, err := net.Listen(c.net, net.JoinHostPort(c.bindIP, strconv.Itoa(port)))
 	if err != nil {
 		return nil, err
 	}
-	return &NetListener{
-		Listener: lis,
-		port:     lis.Addr().(*net.TCPAddr).Port,
-		address:  fmt.Sprintf("%s:%d", config.ip, lis.Addr().(*net.TCPAddr).Port),
-	}, nil
+	// the allowlist goes first, so the rejected connections are closed before the tls handshake
+	lis = NewAllowlistListener(lis, allowed)
+	if c.tlsConfig != nil {
+		lis = tls.NewListener(lis, c.tlsConfig)
+	}
+	return lis, nil
 }
 
 // NetListener is a wrapper around a net.Listener that provides additional functionality.
@@ -83,9 +74,11 @@ func (nl *NetListener) Address() string {
 type netListenerConfig struct {
 	net                   string
 	ip                    string
+	bindIP                string
 	highPriorityToUsePort int
 	port                  int
 	tlsConfig             *tls.Config
+	allowedCIDRs          []string
 }
 
 // getNetListenerConfig returns a netListenerConfig with the default values.
@@ -145,3 +138,17 @@ func OptTLS(c *tls.Config) Opt {
 		nlc.tlsConfig = c
 	}
 }
+
+// OptBindIP sets the IP address of the interface to listen on, all the interfaces are listened on if empty.
+func OptBindIP(ip string) Opt {
+	return func(nlc *netListenerConfig) {
+		nlc.bindIP = ip
+	}
+}
+
+// OptAllowedCIDRs sets the CIDRs allowed to connect, all the connections are accepted if empty.
+func OptAllowedCIDRs(cidrs ...string) Opt {
+	return func(nlc *netListenerConfig) {
+		nlc.allowedCIDRs = cidrs
+	}
+}
@@ -2,7 +2,10 @@ package netutil
 
 import (
 	"fmt"
+	"io"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -36,3 +39,59 @@ func TestListener(t *testing.T) {
 	l3.Close()
 	l.Close()
 }
+
+func TestListenerAllowlist(t *testing.T) {
+	_, err := NewListener(OptAllowedCIDRs("10.0.0.0/33"))
+	assert.Error(t, err)
+	_, err = NewListener(OptAllowedCIDRs("localhost"))
+	assert.Error(t, err)
+
+	accept := func(l net.Listener) error {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		return err
+	}
+	serve := func(l net.Listener) {
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte{1})
+				conn.Close()
+			}
+		}()
+	}
+
+	allowed, err := NewListener(OptBindIP("127.0.0.1"), OptAllowedCIDRs("127.0.0.0/8", "::1"))
+	assert.NoError(t, err)
+	defer allowed.Close()
+	assert.Equal(t, "127.0.0.1", allowed.Addr().(*net.TCPAddr).IP.String())
+	serve(allowed)
+	assert.NoError(t, accept(allowed))
+
+	rejected, err := NewListener(OptBindIP("127.0.0.1"), OptAllowedCIDRs("10.0.0.0/8", "192.168.1.1"))
+	assert.NoError(t, err)
+	defer rejected.Close()
+	serve(rejected)
+	assert.ErrorIs(t, accept(rejected), io.EOF)
+}
+
+func TestParseCIDRs(t *testing.T) {
+	cidrs, err := ParseCIDRs([]string{"10.0.0.0/8", " 192.168.1.1 ", "", "::1"})
+	assert.NoError(t, err)
+	assert.Len(t, cidrs, 3)
+	assert.True(t, cidrs[1].Contains(net.ParseIP("192.168.1.1")))
+	assert.False(t, cidrs[1].Contains(net.ParseIP("192.168.1.2")))
+	assert.True(t, cidrs[2].Contains(net.ParseIP("::1")))
+
+	cidrs, err = ParseCIDRs(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, cidrs)
+}
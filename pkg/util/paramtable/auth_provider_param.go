@@ -0,0 +1,145 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+type AuthProviderConfig struct {
+	CacheTTL   ParamItem `refreshable:"false"`
+	GroupRoles ParamItem `refreshable:"false"`
+
+	OIDCEnabled       ParamItem `refreshable:"false"`
+	OIDCIssuer        ParamItem `refreshable:"false"`
+	OIDCAudience      ParamItem `refreshable:"false"`
+	OIDCJWKSURI       ParamItem `refreshable:"false"`
+	OIDCUsernameClaim ParamItem `refreshable:"false"`
+	OIDCGroupsClaim   ParamItem `refreshable:"false"`
+
+	LDAPEnabled        ParamItem `refreshable:"false"`
+	LDAPAddress        ParamItem `refreshable:"false"`
+	LDAPUseTLS         ParamItem `refreshable:"false"`
+	LDAPBindDNTemplate ParamItem `refreshable:"false"`
+	LDAPGroupAttribute ParamItem `refreshable:"false"`
+}
+
+func (p *AuthProviderConfig) Init(base *BaseTable) {
+	p.CacheTTL = ParamItem{
+		Key:          "common.security.authProviders.cacheTTL",
+		Version:      "2.5.0",
+		DefaultValue: "300",
+		Doc:          "Seconds to cache the users authenticated by the external providers, the users disabled externally are rejected after it at most",
+		Export:       true,
+	}
+	p.CacheTTL.Init(base.mgr)
+
+	p.GroupRoles = ParamItem{
+		Key:     "common.security.authProviders.groupRoles",
+		Version: "2.5.0",
+		Doc:     `The roles of the external groups in json, the roles of a group are separated by comma. The groups are the names of the OIDC groups claim and the dns of the LDAP groups, the dns are compared case-insensitively, e.g. {"admins": "admin", "cn=analysts,ou=groups,dc=example,dc=com": "reader,writer"}`,
+		Export:  true,
+	}
+	p.GroupRoles.Init(base.mgr)
+
+	p.OIDCEnabled = ParamItem{
+		Key:          "common.security.authProviders.oidc.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Whether to authenticate the users with the OIDC id tokens, passed as the token of the sdk. The users of the tokens run as oidc/<username>",
+		Export:       true,
+	}
+	p.OIDCEnabled.Init(base.mgr)
+
+	p.OIDCIssuer = ParamItem{
+		Key:     "common.security.authProviders.oidc.issuer",
+		Version: "2.5.0",
+		Doc:     "The issuer of the tokens, the keys are discovered from its openid configuration if jwksURI is not set",
+		Export:  true,
+	}
+	p.OIDCIssuer.Init(base.mgr)
+
+	p.OIDCAudience = ParamItem{
+		Key:     "common.security.authProviders.oidc.audience",
+		Version: "2.5.0",
+		Doc:     "The audience the tokens must be issued to, usually the client id",
+		Export:  true,
+	}
+	p.OIDCAudience.Init(base.mgr)
+
+	p.OIDCJWKSURI = ParamItem{
+		Key:     "common.security.authProviders.oidc.jwksURI",
+		Version: "2.5.0",
+		Export:  true,
+	}
+	p.OIDCJWKSURI.Init(base.mgr)
+
+	p.OIDCUsernameClaim = ParamItem{
+		Key:          "common.security.authProviders.oidc.usernameClaim",
+		Version:      "2.5.0",
+		DefaultValue: "sub",
+		Doc:          "The claim of the username, it must be unique and never reassigned by the issuer",
+		Export:       true,
+	}
+	p.OIDCUsernameClaim.Init(base.mgr)
+
+	p.OIDCGroupsClaim = ParamItem{
+		Key:          "common.security.authProviders.oidc.groupsClaim",
+		Version:      "2.5.0",
+		DefaultValue: "groups",
+		Export:       true,
+	}
+	p.OIDCGroupsClaim.Init(base.mgr)
+
+	p.LDAPEnabled = ParamItem{
+		Key:          "common.security.authProviders.ldap.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Whether to authenticate the users not built in by binding to the LDAP server with their username and password. The users run as ldap/<username>",
+		Export:       true,
+	}
+	p.LDAPEnabled.Init(base.mgr)
+
+	p.LDAPAddress = ParamItem{
+		Key:     "common.security.authProviders.ldap.address",
+		Version: "2.5.0",
+		Doc:     "host:port of the LDAP server",
+		Export:  true,
+	}
+	p.LDAPAddress.Init(base.mgr)
+
+	p.LDAPUseTLS = ParamItem{
+		Key:          "common.security.authProviders.ldap.useTLS",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Export:       true,
+	}
+	p.LDAPUseTLS.Init(base.mgr)
+
+	p.LDAPBindDNTemplate = ParamItem{
+		Key:     "common.security.authProviders.ldap.bindDNTemplate",
+		Version: "2.5.0",
+		Doc:     "The dn to bind as, {username} is replaced by the escaped username, e.g. uid={username},ou=people,dc=example,dc=com",
+		Export:  true,
+	}
+	p.LDAPBindDNTemplate.Init(base.mgr)
+
+	p.LDAPGroupAttribute = ParamItem{
+		Key:          "common.security.authProviders.ldap.groupAttribute",
+		Version:      "2.5.0",
+		DefaultValue: "memberOf",
+		Doc:          "The attribute of the user entry listing the dns of the groups, no group is read if empty",
+		Export:       true,
+	}
+	p.LDAPGroupAttribute.Init(base.mgr)
+}
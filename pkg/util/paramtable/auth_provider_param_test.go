@@ -0,0 +1,43 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthProviderConfig_Init(t *testing.T) {
+	params := ComponentParam{}
+	params.Init(NewBaseTable(SkipRemote(true)))
+	cfg := &params.AuthProviderCfg
+	assert.Equal(t, 300*time.Second, cfg.CacheTTL.GetAsDuration(time.Second))
+	assert.Empty(t, cfg.GroupRoles.GetAsJSONMap())
+
+	assert.False(t, cfg.OIDCEnabled.GetAsBool())
+	assert.Equal(t, "sub", cfg.OIDCUsernameClaim.GetValue())
+	assert.Equal(t, "groups", cfg.OIDCGroupsClaim.GetValue())
+
+	assert.False(t, cfg.LDAPEnabled.GetAsBool())
+	assert.True(t, cfg.LDAPUseTLS.GetAsBool())
+	assert.Equal(t, "memberOf", cfg.LDAPGroupAttribute.GetValue())
+
+	params.Save(cfg.GroupRoles.Key, `{"admins": "admin", "analysts": "reader,writer"}`)
+	assert.Equal(t, map[string]string{"admins": "admin", "analysts": "reader,writer"}, cfg.GroupRoles.GetAsJSONMap())
+}
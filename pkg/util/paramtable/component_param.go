@@ -84,6 +84,11 @@ type ComponentParam struct {
 	StreamingCfg   streamingConfig
 
 	InternalTLSCfg InternalTLSConfig
+	MetricsTLSCfg  MetricsTLSConfig
+
+	AuthProviderCfg   AuthProviderConfig
+	ManagementAuthCfg ManagementAuthConfig
+	ListenerCfg       ListenerConfig
 
 	RootCoordGrpcServerCfg     GrpcServerConfig
 	ProxyGrpcServerCfg         GrpcServerConfig
@@ -142,6 +147,11 @@ func (p *ComponentParam) init(bt *BaseTable) {
 	p.KnowhereConfig.init(bt)
 
 	p.InternalTLSCfg.Init(bt)
+	p.MetricsTLSCfg.Init(bt)
+
+	p.AuthProviderCfg.Init(bt)
+	p.ManagementAuthCfg.Init(bt)
+	p.ListenerCfg.Init(bt)
 
 	p.RootCoordGrpcServerCfg.Init("rootCoord", bt)
 	p.ProxyGrpcServerCfg.Init("proxy", bt)
@@ -265,6 +275,7 @@ type commonConfig struct {
 	TraceLogMode              ParamItem `refreshable:"true"`
 	BloomFilterSize           ParamItem `refreshable:"true"`
 	BloomFilterType           ParamItem `refreshable:"true"`
+	BloomFilterAutoSize       ParamItem `refreshable:"true"`
 	MaxBloomFalsePositive     ParamItem `refreshable:"true"`
 	BloomFilterApplyBatchSize ParamItem `refreshable:"true"`
 	PanicWhenPluginFail       ParamItem `refreshable:"false"`
@@ -286,6 +297,12 @@ type commonConfig struct {
 
 	// Local RPC enabled for milvus internal communication when mix or standalone mode.
 	LocalRPCEnabled ParamItem `refreshable:"false"`
+
+	BrokerCacheTTL      ParamItem `refreshable:"false"`
+	BrokerCacheCapacity ParamItem `refreshable:"false"`
+
+	DecisionJournalCapacity      ParamItem `refreshable:"false"`
+	DecisionJournalFlushInterval ParamItem `refreshable:"true"`
 }
 
 func (p *commonConfig) init(base *BaseTable) {
@@ -811,6 +828,16 @@ This helps Milvus-CDC synchronize incremental data`,
 	}
 	p.BloomFilterType.Init(base.mgr)
 
+	p.BloomFilterAutoSize = ParamItem{
+		Key:          "common.bloomFilterAutoSize",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `size the bloom filters of the growing segments in querynode by the max row count of the segments
+estimated by the schema and dataCoord.segment.maxSize, instead of bloomFilterSize`,
+		Export: true,
+	}
+	p.BloomFilterAutoSize.Init(base.mgr)
+
 	p.MaxBloomFalsePositive = ParamItem{
 		Key:          "common.maxBloomFalsePositive",
 		Version:      "2.3.2",
@@ -936,6 +963,44 @@ This helps Milvus-CDC synchronize incremental data`,
 		Export:       true,
 	}
 	p.LocalRPCEnabled.Init(base.mgr)
+
+	p.BrokerCacheTTL = ParamItem{
+		Key:          "common.brokerCache.ttl",
+		Version:      "2.5.0",
+		DefaultValue: "5",
+		Doc: `time to live in seconds of the collection schema, partitions and index info the coordinators look up from each other,
+0 disables the cache while the concurrent lookups are still merged`,
+		Export: true,
+	}
+	p.BrokerCacheTTL.Init(base.mgr)
+
+	p.BrokerCacheCapacity = ParamItem{
+		Key:          "common.brokerCache.capacity",
+		Version:      "2.5.0",
+		DefaultValue: "4096",
+		Doc:          "max number of the collections cached per kind of coordinator lookup",
+		Export:       true,
+	}
+	p.BrokerCacheCapacity.Init(base.mgr)
+
+	p.DecisionJournalCapacity = ParamItem{
+		Key:          "common.decisionJournal.capacity",
+		Version:      "2.5.0",
+		DefaultValue: "10000",
+		Doc: `max number of the decisions, like segment assignments, balance moves, compaction triggers and handoffs,
+each coordinator keeps in its persistent journal, the oldest ones are evicted once exceeded, 0 disables the journal`,
+		Export: true,
+	}
+	p.DecisionJournalCapacity.Init(base.mgr)
+
+	p.DecisionJournalFlushInterval = ParamItem{
+		Key:          "common.decisionJournal.flushInterval",
+		Version:      "2.5.0",
+		DefaultValue: "5s",
+		Doc:          "interval to persist the recorded decisions to the meta storage",
+		Export:       true,
+	}
+	p.DecisionJournalFlushInterval.Init(base.mgr)
 }
 
 type gpuConfig struct {
@@ -1296,6 +1361,37 @@ type proxyConfig struct {
 
 	SlowQuerySpanInSeconds ParamItem `refreshable:"true"`
 	QueryNodePoolingSize   ParamItem `refreshable:"false"`
+
+	MaxDeleteAffectedRows    ParamItem `refreshable:"true"`
+	DeleteProgressLogEntries ParamItem `refreshable:"true"`
+	DeleteResumeMaxAge       ParamItem `refreshable:"true"`
+	EnableQueryStream        ParamItem `refreshable:"true"`
+	SkipInvalidVectorRows    ParamItem `refreshable:"true"`
+
+	QueryStatsEnabled       ParamItem `refreshable:"true"`
+	QueryStatsFlushInterval ParamItem `refreshable:"false"`
+	QueryStatsRetention     ParamItem `refreshable:"true"`
+
+	UsageMeterEnabled       ParamItem `refreshable:"true"`
+	UsageMeterWindow        ParamItem `refreshable:"false"`
+	UsageMeterFlushInterval ParamItem `refreshable:"false"`
+	UsageMeterRetention     ParamItem `refreshable:"true"`
+
+	SearchConsistencySampleRatio    ParamItem `refreshable:"true"`
+	SearchConsistencyScoreTolerance ParamItem `refreshable:"true"`
+
+	StrongConsistencyBarrier ParamItem `refreshable:"true"`
+
+	SchemaOrderedOutputFields ParamItem `refreshable:"true"`
+
+	TieredReduceTopK ParamItem `refreshable:"true"`
+
+	InsertAckLevel   ParamItem `refreshable:"true"`
+	InsertAckTimeout ParamItem `refreshable:"true"`
+
+	ResultExportEnabled        ParamItem `refreshable:"true"`
+	ResultExportAllowedBuckets ParamItem `refreshable:"true"`
+	ResultExportMaxRowsPerFile ParamItem `refreshable:"true"`
 }
 
 func (p *proxyConfig) init(base *BaseTable) {
@@ -1749,6 +1845,227 @@ please adjust in embedded Milvus: false`,
 		Export:       true,
 	}
 	p.QueryNodePoolingSize.Init(base.mgr)
+
+	p.MaxDeleteAffectedRows = ParamItem{
+		Key:          "proxy.maxDeleteAffectedRows",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `The maximum number of rows a single delete by expression is allowed to delete.
+The delete is refused before deleting anything if the expression matches more rows than the limit,
+which costs a count query on the delete snapshot. 0 means no limit.`,
+		Export: true,
+	}
+	p.MaxDeleteAffectedRows.Init(base.mgr)
+
+	p.DeleteProgressLogEntries = ParamItem{
+		Key:          "proxy.deleteProgressLogEntries",
+		Version:      "2.5.0",
+		DefaultValue: "100000",
+		Doc:          "delete by expression reports its progress every time this many more rows are deleted",
+		Export:       true,
+	}
+	p.DeleteProgressLogEntries.Init(base.mgr)
+
+	p.DeleteResumeMaxAge = ParamItem{
+		Key:          "proxy.deleteResumeMaxAge",
+		Version:      "2.5.0",
+		DefaultValue: "3600",
+		Doc: `The maximum age in seconds of the snapshot a failed delete by expression is resumed on.
+The resume is refused if the snapshot is older, or older than dataCoord.gc.dropTolerance,
+as the segments of the snapshot may be compacted and garbage collected. 0 disables resuming.`,
+		Export: true,
+	}
+	p.DeleteResumeMaxAge.Init(base.mgr)
+
+	p.EnableQueryStream = ParamItem{
+		Key:          "proxy.enableQueryStream",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc: `Whether to receive query results from querynodes by server streaming.
+Results are sent in chunks bounded by queryNode.queryStreamBatchSize, so wide output fields
+no longer fail with grpc max message size errors between querynode and proxy.
+The reduced result is still sent to the client in one message, a result larger than
+proxy.grpc.serverMaxSendSize fails with an error asking to narrow the query or use the query iterator.
+Streaming the results to the clients needs a server streaming query in the public milvus proto, which is not supported.`,
+		Export: true,
+	}
+	p.EnableQueryStream.Init(base.mgr)
+
+	p.SkipInvalidVectorRows = ParamItem{
+		Key:          "proxy.skipInvalidVectorRows",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether insert accepts the valid rows of a batch when some vector rows are invalid (NaN/Inf values,
+malformed sparse rows). Rejected rows are reported by index in the err_index of the insert result.
+If false, the whole batch is rejected and the error lists the offending rows.`,
+		Export: true,
+	}
+	p.SkipInvalidVectorRows.Init(base.mgr)
+
+	p.QueryStatsEnabled = ParamItem{
+		Key:          "proxy.queryStats.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc: `Whether to record aggregated statistics of search and query patterns per collection,
+e.g. filtered fields, topK and search params, which the index advisor recommends indexes from.`,
+		Export: true,
+	}
+	p.QueryStatsEnabled.Init(base.mgr)
+
+	p.QueryStatsFlushInterval = ParamItem{
+		Key:          "proxy.queryStats.flushInterval",
+		Version:      "2.5.0",
+		DefaultValue: "300",
+		Doc:          "The interval in seconds to persist the query statistics into meta storage.",
+		Export:       true,
+	}
+	p.QueryStatsFlushInterval.Init(base.mgr)
+
+	p.QueryStatsRetention = ParamItem{
+		Key:          "proxy.queryStats.retention",
+		Version:      "2.5.0",
+		DefaultValue: "168",
+		Doc:          "The hours the persisted query statistics of a proxy are kept after its last update.",
+		Export:       true,
+	}
+	p.QueryStatsRetention.Init(base.mgr)
+
+	p.UsageMeterEnabled = ParamItem{
+		Key:          "proxy.usageMeter.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether to meter the usage per user (the owner of the api key), i.e. vectors searched, rows scanned,
+rows inserted and bytes sent. The records are queryable through the management api and the closed windows
+are exported into object storage under the usage directory for billing.`,
+		Export: true,
+	}
+	p.UsageMeterEnabled.Init(base.mgr)
+
+	p.UsageMeterWindow = ParamItem{
+		Key:          "proxy.usageMeter.window",
+		Version:      "2.5.0",
+		DefaultValue: "3600",
+		Doc:          "The length in seconds of the window the usage is aggregated in.",
+		Export:       true,
+	}
+	p.UsageMeterWindow.Init(base.mgr)
+
+	p.UsageMeterFlushInterval = ParamItem{
+		Key:          "proxy.usageMeter.flushInterval",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "The interval in seconds to persist the usage records into meta storage and export the closed windows.",
+		Export:       true,
+	}
+	p.UsageMeterFlushInterval.Init(base.mgr)
+
+	p.UsageMeterRetention = ParamItem{
+		Key:          "proxy.usageMeter.retention",
+		Version:      "2.5.0",
+		DefaultValue: "720",
+		Doc:          "The hours the usage records are kept in meta storage for the management api.",
+		Export:       true,
+	}
+	p.UsageMeterRetention.Init(base.mgr)
+
+	p.SearchConsistencySampleRatio = ParamItem{
+		Key:          "proxy.searchConsistency.sampleRatio",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `Debug mode, the fraction of searches verified against another replica of the same channel at the same mvcc timestamp.
+The differences of result ids and scores are logged and metered, which helps detect replica divergence. 0 means disabled.`,
+		Export: true,
+	}
+	p.SearchConsistencySampleRatio.Init(base.mgr)
+
+	p.SearchConsistencyScoreTolerance = ParamItem{
+		Key:          "proxy.searchConsistency.scoreTolerance",
+		Version:      "2.5.0",
+		DefaultValue: "0.00001",
+		Doc:          "The max score delta of the same id between replicas before the verified search is reported as mismatch.",
+		Export:       true,
+	}
+	p.SearchConsistencyScoreTolerance.Init(base.mgr)
+
+	p.StrongConsistencyBarrier = ParamItem{
+		Key:          "proxy.strongConsistencyBarrier",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc: `Whether the strong consistency search and query append a barrier message into the wal of each shard,
+and wait for the barrier instead of the latest timestamp. Only works when the streaming service is enabled.`,
+		Export: true,
+	}
+	p.StrongConsistencyBarrier.Init(base.mgr)
+
+	p.SchemaOrderedOutputFields = ParamItem{
+		Key:          "proxy.schemaOrderedOutputFields",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether the fields data and the output fields of the search and query results are in the order declared
+by the collection schema, instead of the order of the output fields requested.`,
+		Export: true,
+	}
+	p.SchemaOrderedOutputFields.Init(base.mgr)
+
+	p.TieredReduceTopK = ParamItem{
+		Key:          "proxy.tieredReduceTopK",
+		Version:      "2.5.0",
+		DefaultValue: "10000",
+		Doc: `The min topK of the search to merge the results of the shards once received, instead of after all received,
+so the proxy holds the topK results merged and one shard result at most. 0 to disable.`,
+		Export: true,
+	}
+	p.TieredReduceTopK.Init(base.mgr)
+
+	p.InsertAckLevel = ParamItem{
+		Key:          "proxy.insertAckLevel",
+		Version:      "2.5.0",
+		DefaultValue: "wal",
+		Doc: `The default level the insert and upsert are acknowledged at, overridden by the insert-ack-level header of the request.
+wal: once written into the wal; growing: once searchable in the growing segments, the collection must be loaded;
+flush: once synced into the object storage by the regular sync, no flush is triggered.`,
+		Export: true,
+	}
+	p.InsertAckLevel.Init(base.mgr)
+
+	p.InsertAckTimeout = ParamItem{
+		Key:          "proxy.insertAckTimeout",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "The max seconds the insert waits for the growing or flush acknowledgement, the insert is reported at the level reached on timeout.",
+		Export:       true,
+	}
+	p.InsertAckTimeout.Init(base.mgr)
+
+	p.ResultExportEnabled = ParamItem{
+		Key:          "proxy.resultExport.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether the restful query and search can export the results as parquet files into object storage
+and return the manifest, instead of sending the results in the response.
+The results are streamed page by page under the path of the request joined with an id unique to the export.`,
+		Export: true,
+	}
+	p.ResultExportEnabled.Init(base.mgr)
+
+	p.ResultExportAllowedBuckets = ParamItem{
+		Key:          "proxy.resultExport.allowedBuckets",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc: `comma separated buckets of the object storage the results can be exported into besides the bucket of milvus,
+they are written with the credentials of milvus`,
+		Export: true,
+	}
+	p.ResultExportAllowedBuckets.Init(base.mgr)
+
+	p.ResultExportMaxRowsPerFile = ParamItem{
+		Key:          "proxy.resultExport.maxRowsPerFile",
+		Version:      "2.5.0",
+		DefaultValue: "100000",
+		Doc:          "The max rows of each parquet file the results are exported into.",
+		Export:       true,
+	}
+	p.ResultExportMaxRowsPerFile.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -1832,6 +2149,20 @@ type queryCoordConfig struct {
 	UpdateCollectionLoadStatusInterval ParamItem `refreshable:"false"`
 	ClusterLevelLoadReplicaNumber      ParamItem `refreshable:"true"`
 	ClusterLevelLoadResourceGroups     ParamItem `refreshable:"true"`
+	EnforceDatabaseResourceGroups      ParamItem `refreshable:"true"`
+
+	WarmPoolResourceGroup      ParamItem `refreshable:"true"`
+	WarmPoolMaxSegmentsPerNode ParamItem `refreshable:"true"`
+	WarmPoolCheckInterval      ParamItem `refreshable:"false"`
+
+	SegmentRecoveryOrder               ParamItem `refreshable:"true"`
+	SegmentRecoveryPriorityCollections ParamItem `refreshable:"true"`
+	SegmentRecoveryParallelism         ParamItem `refreshable:"true"`
+
+	RowCountScrubEnabled  ParamItem `refreshable:"true"`
+	RowCountScrubInterval ParamItem `refreshable:"false"`
+
+	CheckSegmentDigest ParamItem `refreshable:"true"`
 }
 
 func (p *queryCoordConfig) init(base *BaseTable) {
@@ -2406,6 +2737,104 @@ If this parameter is set false, Milvus simply searches the growing segments with
 		Export:       false,
 	}
 	p.ClusterLevelLoadResourceGroups.Init(base.mgr)
+
+	p.EnforceDatabaseResourceGroups = ParamItem{
+		Key:          "queryCoord.enforceDatabaseResourceGroups",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `whether to restrict collections of a database bound to resource groups by database.resource_groups
+to be loaded into these resource groups only, load, transfer replica and update load config requests
+targeting other resource groups are rejected, and the loaded replicas out of the bound resource groups are moved into them`,
+		Export: true,
+	}
+	p.EnforceDatabaseResourceGroups.Init(base.mgr)
+
+	p.WarmPoolResourceGroup = ParamItem{
+		Key:          "queryCoord.warmPool.resourceGroup",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc: `resource group holding standby querynodes, empty means the warm pool is disabled.
+Nodes in the group serve no replica, but preload the most accessed segments,
+so that they serve traffic right away once transferred into another resource group`,
+		Export: true,
+	}
+	p.WarmPoolResourceGroup.Init(base.mgr)
+
+	p.WarmPoolMaxSegmentsPerNode = ParamItem{
+		Key:          "queryCoord.warmPool.maxSegmentsPerNode",
+		Version:      "2.5.0",
+		DefaultValue: "128",
+		Doc:          "the max number of segments preloaded on each standby querynode",
+		Export:       true,
+	}
+	p.WarmPoolMaxSegmentsPerNode.Init(base.mgr)
+
+	p.WarmPoolCheckInterval = ParamItem{
+		Key:          "queryCoord.warmPool.checkInterval",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "the interval in seconds to refresh segment access heat and the segments preloaded on standby querynodes",
+		Export:       true,
+	}
+	p.WarmPoolCheckInterval.Init(base.mgr)
+
+	p.SegmentRecoveryOrder = ParamItem{
+		Key:          "queryCoord.segmentRecovery.order",
+		Version:      "2.5.0",
+		DefaultValue: "none",
+		Doc: `the order to load the missing segments of a querynode, e.g. after it restarts, options: [none, smallest_first, hottest_first].
+smallest_first loads the segments with fewer rows first, hottest_first loads the segments serving more requests on other replicas first`,
+		Export: true,
+	}
+	p.SegmentRecoveryOrder.Init(base.mgr)
+
+	p.SegmentRecoveryPriorityCollections = ParamItem{
+		Key:          "queryCoord.segmentRecovery.priorityCollections",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "comma separated collection ids, whose missing segments are loaded before the other collections'",
+		Export:       true,
+	}
+	p.SegmentRecoveryPriorityCollections.Init(base.mgr)
+
+	p.SegmentRecoveryParallelism = ParamItem{
+		Key:          "queryCoord.segmentRecovery.parallelism",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc:          "the max number of missing segments loaded concurrently on each querynode, 0 means only limited by taskExecutionCap",
+		Export:       true,
+	}
+	p.SegmentRecoveryParallelism.Init(base.mgr)
+
+	p.RowCountScrubEnabled = ParamItem{
+		Key:          "queryCoord.rowCountScrub.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `whether to cross-check the row counts of the loaded segments periodically, among the datacoord meta,
+the binlogs, the bloom filter stats and segcore of the querynodes, the discrepancies are reported to the event log`,
+		Export: true,
+	}
+	p.RowCountScrubEnabled.Init(base.mgr)
+
+	p.RowCountScrubInterval = ParamItem{
+		Key:          "queryCoord.rowCountScrub.interval",
+		Version:      "2.5.0",
+		DefaultValue: "3600",
+		Doc:          "the interval in seconds to cross-check the row counts of the loaded segments",
+		Export:       true,
+	}
+	p.RowCountScrubInterval.Init(base.mgr)
+
+	p.CheckSegmentDigest = ParamItem{
+		Key:          "queryCoord.checkSegmentDigest",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `whether to compare the digest of the segment moved by balance with the source before releasing the source,
+the row count, the primary keys and the checksum of the index files, the move is rejected and the copy moved is released on mismatch,
+querynodes read the index files once more to compute the checksum when enabled`,
+		Export: true,
+	}
+	p.CheckSegmentDigest.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -2429,6 +2858,8 @@ type queryNodeConfig struct {
 
 	KnowhereScoreConsistency ParamItem `refreshable:"false"`
 
+	IsolatedCollectionThreadNum ParamItem `refreshable:"true"`
+
 	// memory limit
 	LoadMemoryUsageFactor               ParamItem `refreshable:"true"`
 	OverloadedMemoryThresholdPercentage ParamItem `refreshable:"false"`
@@ -2480,6 +2911,8 @@ type queryNodeConfig struct {
 	// delete buffer
 	MaxSegmentDeleteBuffer ParamItem `refreshable:"false"`
 	DeleteBufferBlockSize  ParamItem `refreshable:"false"`
+	DeleteBufferSpillSize  ParamItem `refreshable:"false"`
+	DeleteBufferSpillDir   ParamItem `refreshable:"false"`
 
 	// delta forward
 	LevelZeroForwardPolicy      ParamItem `refreshable:"true"`
@@ -2514,6 +2947,68 @@ type queryNodeConfig struct {
 	QueryStreamBatchSize                    ParamItem `refreshable:"false"`
 	QueryStreamMaxBatchSize                 ParamItem `refreshable:"false"`
 	BloomFilterApplyParallelFactor          ParamItem `refreshable:"true"`
+	EnableRetrievePKRouting                 ParamItem `refreshable:"true"`
+
+	// read-only mode
+	ReadOnlyEnabled      ParamItem `refreshable:"false"`
+	ReadOnlyMaxStaleness ParamItem `refreshable:"true"`
+
+	// delegator admission
+	DelegatorMaxPendingRequests ParamItem `refreshable:"true"`
+	DelegatorBusyRetryAfter     ParamItem `refreshable:"true"`
+
+	// delegator circuit breaker
+	CircuitBreakerErrorRate    ParamItem `refreshable:"true"`
+	CircuitBreakerMinRequests  ParamItem `refreshable:"true"`
+	CircuitBreakerWindow       ParamItem `refreshable:"true"`
+	CircuitBreakerOpenDuration ParamItem `refreshable:"true"`
+
+	// recall tuning
+	RecallTuningEnabled        ParamItem `refreshable:"true"`
+	RecallTuningInterval       ParamItem `refreshable:"true"`
+	RecallTuningSampleQueries  ParamItem `refreshable:"true"`
+	RecallTuningTopK           ParamItem `refreshable:"true"`
+	RecallTuningMaxSegmentRows ParamItem `refreshable:"true"`
+
+	// segment quarantine
+	SegmentQuarantineThreshold ParamItem `refreshable:"true"`
+	SegmentQuarantineWindow    ParamItem `refreshable:"true"`
+
+	UpsertPkVersionCapacity ParamItem `refreshable:"true"`
+
+	// segment release
+	SegmentReleaseAsync         ParamItem `refreshable:"true"`
+	SegmentReleaseDrainDeadline ParamItem `refreshable:"true"`
+
+	// cgo watchdog
+	CGOWatchdogStuckFactor      ParamItem `refreshable:"true"`
+	CGOWatchdogMinStuckTime     ParamItem `refreshable:"true"`
+	CGOWatchdogDumpNativeStacks ParamItem `refreshable:"true"`
+	CGOWatchdogDegradeSegment   ParamItem `refreshable:"true"`
+
+	// search result cache
+	SearchCacheEnabled       ParamItem `refreshable:"true"`
+	SearchCacheCapacity      ParamItem `refreshable:"false"`
+	SearchCacheTimeBucket    ParamItem `refreshable:"true"`
+	SearchCacheMaxResultSize ParamItem `refreshable:"true"`
+
+	// concurrent field loading of growing segments
+	ConcurrentFieldLoadEnabled      ParamItem `refreshable:"true"`
+	ConcurrentFieldLoadMemoryBudget ParamItem `refreshable:"false"`
+
+	BackgroundIndexLoadEnabled     ParamItem `refreshable:"true"`
+	BackgroundIndexLoadConcurrency ParamItem `refreshable:"false"`
+
+	PlanCacheEnabled  ParamItem `refreshable:"true"`
+	PlanCacheCapacity ParamItem `refreshable:"false"`
+
+	SegmentJournalCapacity ParamItem `refreshable:"true"`
+	SegmentJournalEventLog ParamItem `refreshable:"true"`
+
+	DeleteFeedbackEnabled  ParamItem `refreshable:"true"`
+	DeleteFeedbackInterval ParamItem `refreshable:"true"`
+	FanOutFeedbackEnabled  ParamItem `refreshable:"true"`
+	FanOutFeedbackInterval ParamItem `refreshable:"true"`
 
 	// worker
 	WorkerPoolingSize ParamItem `refreshable:"false"`
@@ -2609,6 +3104,18 @@ This defaults to true, indicating that Milvus creates temporary index for growin
 
 	p.KnowhereScoreConsistency.Init(base.mgr)
 
+	p.IsolatedCollectionThreadNum = ParamItem{
+		Key:          "queryNode.segcore.isolatedCollectionThreadNum",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc: `comma separated <collection id>:<thread num> of the high priority collections on this querynode,
+whose searches and queries are scheduled with the concurrency of <thread num> and run in their dedicated cgo thread pools,
+instead of queuing behind the other collections'.
+The index searches inside knowhere still share the global knowhere search thread pool with the other collections.`,
+		Export: true,
+	}
+	p.IsolatedCollectionThreadNum.Init(base.mgr)
+
 	p.InterimIndexNlist = ParamItem{
 		Key:          "queryNode.segcore.interimIndex.nlist",
 		Version:      "2.0.0",
@@ -3070,6 +3577,30 @@ Max read concurrency must greater than or equal to 1, and less than or equal to
 	}
 	p.DeleteBufferBlockSize.Init(base.mgr)
 
+	p.DeleteBufferSpillSize = ParamItem{
+		Key:          "queryNode.deleteBufferSpill.memoryThreshold",
+		Version:      "2.5.0",
+		Doc:          "The size in MB of the deletes kept in memory by the delete buffer of a delegator, the earliest blocks beyond it are spilled to the local disk, 0 means never spill",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.DeleteBufferSpillSize.Init(base.mgr)
+
+	p.DeleteBufferSpillDir = ParamItem{
+		Key:          "queryNode.deleteBufferSpill.dir",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "The folder that storing the spilled delete buffer blocks, localStorage.path/delete_buffer by default",
+		Formatter: func(v string) string {
+			if len(v) == 0 {
+				return path.Join(base.Get("localStorage.path"), "delete_buffer")
+			}
+			return v
+		},
+		Export: true,
+	}
+	p.DeleteBufferSpillDir.Init(base.mgr)
+
 	p.LevelZeroForwardPolicy = ParamItem{
 		Key:          "queryNode.levelZeroForwardPolicy",
 		Version:      "2.4.12",
@@ -3236,29 +3767,408 @@ user-task-polling:
 	}
 	p.BloomFilterApplyParallelFactor.Init(base.mgr)
 
-	p.WorkerPoolingSize = ParamItem{
-		Key:          "queryNode.workerPooling.size",
-		Version:      "2.4.7",
-		Doc:          "the size for worker querynode client pool",
-		DefaultValue: "10",
+	p.EnableRetrievePKRouting = ParamItem{
+		Key:          "queryNode.enableRetrievePKRouting",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc:          "prune the segments of the queries filtering by pk only, e.g. pk in [...], with the pk bloom filters on shard delegator",
 		Export:       true,
 	}
-	p.WorkerPoolingSize.Init(base.mgr)
-}
+	p.EnableRetrievePKRouting.Init(base.mgr)
 
-// /////////////////////////////////////////////////////////////////////////////
-// --- datacoord ---
-type dataCoordConfig struct {
-	// --- CHANNEL ---
-	WatchTimeoutInterval             ParamItem `refreshable:"false"`
-	LegacyVersionWithoutRPCWatch     ParamItem `refreshable:"false"`
-	ChannelBalanceSilentDuration     ParamItem `refreshable:"true"`
-	ChannelBalanceInterval           ParamItem `refreshable:"true"`
-	ChannelCheckInterval             ParamItem `refreshable:"true"`
-	ChannelOperationRPCTimeout       ParamItem `refreshable:"true"`
-	MaxConcurrentChannelTaskNumPerDN ParamItem `refreshable:"true"`
+	p.ReadOnlyEnabled = ParamItem{
+		Key:          "queryNode.readOnly.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Run the query node as a read-only reader, which serves the segments loaded from object storage only
+and never subscribes the message queue. Rows become visible once they are flushed and the target is updated.
+The node registers with server label READ_ONLY=true, and only joins resource groups whose node filter requires this label.`,
+		Export: true,
+	}
+	p.ReadOnlyEnabled.Init(base.mgr)
 
-	// --- SEGMENTS ---
+	p.ReadOnlyMaxStaleness = ParamItem{
+		Key:          "queryNode.readOnly.maxStaleness",
+		Version:      "2.5.0",
+		DefaultValue: "600",
+		Doc: `The max age in seconds of the snapshot a read-only query node serves, requests fail once the snapshot is older.
+Requests with a guarantee timestamp beyond the snapshot, the strong, bounded and session ones, always fail on read-only nodes
+and are retried on the other replicas.`,
+		Export: true,
+	}
+	p.ReadOnlyMaxStaleness.Init(base.mgr)
+
+	p.DelegatorMaxPendingRequests = ParamItem{
+		Key:          "queryNode.delegator.maxPendingRequests",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `The max number of search and query requests a shard delegator handles at the same time,
+requests beyond it fail with the server busy error instead of waiting. 0 means no limit, which is the default.`,
+		Export: true,
+	}
+	p.DelegatorMaxPendingRequests.Init(base.mgr)
+
+	p.DelegatorBusyRetryAfter = ParamItem{
+		Key:          "queryNode.delegator.busyRetryAfter",
+		Version:      "2.5.0",
+		DefaultValue: "100",
+		Doc:          "the backoff in milliseconds suggested to clients by the server busy error of a shard delegator",
+		Export:       true,
+	}
+	p.DelegatorBusyRetryAfter.Init(base.mgr)
+
+	p.CircuitBreakerErrorRate = ParamItem{
+		Key:          "queryNode.delegator.circuitBreaker.errorRate",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `The ratio of failed search and query requests within a window which opens the circuit breaker of a shard delegator,
+the opened breaker rejects the requests with the service unavailable error, protecting the other collections sharing the node.
+0 disables the circuit breaker, which is the default.`,
+		Export: true,
+	}
+	p.CircuitBreakerErrorRate.Init(base.mgr)
+
+	p.CircuitBreakerMinRequests = ParamItem{
+		Key:          "queryNode.delegator.circuitBreaker.minRequests",
+		Version:      "2.5.0",
+		DefaultValue: "20",
+		Doc:          "the min number of requests within a window to open the circuit breaker",
+		Export:       true,
+	}
+	p.CircuitBreakerMinRequests.Init(base.mgr)
+
+	p.CircuitBreakerWindow = ParamItem{
+		Key:          "queryNode.delegator.circuitBreaker.window",
+		Version:      "2.5.0",
+		DefaultValue: "10",
+		Doc:          "the window in seconds to count the failed requests",
+		Export:       true,
+	}
+	p.CircuitBreakerWindow.Init(base.mgr)
+
+	p.CircuitBreakerOpenDuration = ParamItem{
+		Key:          "queryNode.delegator.circuitBreaker.openDuration",
+		Version:      "2.5.0",
+		DefaultValue: "30",
+		Doc:          "the duration in seconds the opened circuit breaker rejects requests before letting them through again",
+		Export:       true,
+	}
+	p.CircuitBreakerOpenDuration.Init(base.mgr)
+
+	p.RecallTuningEnabled = ParamItem{
+		Key:          "queryNode.recallTuning.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Enable the background calibration of the vector index search params. Searches with recall_target in
+their search params then use the smallest calibrated ef, nprobe or search_list meeting the target.`,
+		Export: true,
+	}
+	p.RecallTuningEnabled.Init(base.mgr)
+
+	p.RecallTuningInterval = ParamItem{
+		Key:          "queryNode.recallTuning.interval",
+		Version:      "2.5.0",
+		DefaultValue: "600",
+		Doc:          "the interval in seconds to calibrate the search params of the loaded collections",
+		Export:       true,
+	}
+	p.RecallTuningInterval.Init(base.mgr)
+
+	p.RecallTuningSampleQueries = ParamItem{
+		Key:          "queryNode.recallTuning.sampleQueries",
+		Version:      "2.5.0",
+		DefaultValue: "100",
+		Doc:          "the number of vectors sampled from a segment as the queries to measure recall",
+		Export:       true,
+	}
+	p.RecallTuningSampleQueries.Init(base.mgr)
+
+	p.RecallTuningTopK = ParamItem{
+		Key:          "queryNode.recallTuning.topk",
+		Version:      "2.5.0",
+		DefaultValue: "10",
+		Doc:          "the topk to measure recall with",
+		Export:       true,
+	}
+	p.RecallTuningTopK.Init(base.mgr)
+
+	p.RecallTuningMaxSegmentRows = ParamItem{
+		Key:          "queryNode.recallTuning.maxSegmentRows",
+		Version:      "2.5.0",
+		DefaultValue: "50000",
+		Doc:          "the max row count of the sealed segment used for calibration, the brute force search runs on all of its rows",
+		Export:       true,
+	}
+	p.RecallTuningMaxSegmentRows.Init(base.mgr)
+
+	p.SegmentQuarantineThreshold = ParamItem{
+		Key:          "queryNode.segmentQuarantine.threshold",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `The number of segcore failures indicating corrupted data of a sealed segment, i.e. broken data format,
+within the quarantine window, after which the querynode releases the segment, and querycoord loads it again from storage.
+The transient I/O failures, e.g. file read failures, are not counted. 0 disables the quarantine.
+The segment is marked offline in its shard delegator before released, so that the requests are routed to the other replicas.
+Only the failures returned by segcore are counted, the crashes on the corrupted data, e.g. SIGSEGV, still abort the querynode.`,
+		Export: true,
+	}
+	p.SegmentQuarantineThreshold.Init(base.mgr)
+
+	p.SegmentQuarantineWindow = ParamItem{
+		Key:          "queryNode.segmentQuarantine.window",
+		Version:      "2.5.0",
+		DefaultValue: "600",
+		Doc: `The window in seconds the broken data failures of a sealed segment are counted in,
+the failures are counted again from zero once the window since the first one elapsed.`,
+		Export: true,
+	}
+	p.SegmentQuarantineWindow.Init(base.mgr)
+
+	p.UpsertPkVersionCapacity = ParamItem{
+		Key:          "queryNode.upsertPkVersionCapacity",
+		Version:      "2.5.0",
+		DefaultValue: "100000",
+		Doc: `The max number of the upserted pks each shard delegator records the latest segment of, the later deletions
+of these pks are not forwarded to the segments holding their superseded versions. The oldest records are dropped
+beyond it, 0 disables the records.`,
+		Export: true,
+	}
+	p.UpsertPkVersionCapacity.Init(base.mgr)
+
+	p.SegmentReleaseAsync = ParamItem{
+		Key:          "queryNode.segmentRelease.async",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Release the removed segments in background, so releasing a collection, a partition or a channel
+doesn't block until the in-flight searches and queries on its segments finish.`,
+		Export: true,
+	}
+	p.SegmentReleaseAsync.Init(base.mgr)
+
+	p.SegmentReleaseDrainDeadline = ParamItem{
+		Key:          "queryNode.segmentRelease.drainDeadline",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `The max time in seconds the release of a segment waits for the in-flight searches and queries on it,
+the ones still running are canceled after it. 0 means waiting until they finish.`,
+		Export: true,
+	}
+	p.SegmentReleaseDrainDeadline.Init(base.mgr)
+
+	p.CGOWatchdogStuckFactor = ParamItem{
+		Key:          "queryNode.cgoWatchdog.stuckFactor",
+		Version:      "2.5.0",
+		DefaultValue: "20",
+		Doc: `A search, query or load of a segment in segcore is considered stuck once it runs longer than
+the factor times the average time of the same kind of calls. 0 disables the watchdog.`,
+		Export: true,
+	}
+	p.CGOWatchdogStuckFactor.Init(base.mgr)
+
+	p.CGOWatchdogMinStuckTime = ParamItem{
+		Key:          "queryNode.cgoWatchdog.minStuckTime",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "The min time in seconds a segcore call runs before it's considered stuck",
+		Export:       true,
+	}
+	p.CGOWatchdogMinStuckTime.Init(base.mgr)
+
+	p.CGOWatchdogDumpNativeStacks = ParamItem{
+		Key:          "queryNode.cgoWatchdog.dumpNativeStacks",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Log the native stacks of the threads running the segcore calls when a segcore call is stuck",
+		Export:       true,
+	}
+	p.CGOWatchdogDumpNativeStacks.Init(base.mgr)
+
+	p.CGOWatchdogDegradeSegment = ParamItem{
+		Key:          "queryNode.cgoWatchdog.degradeSegment",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Release the sealed segment with a stuck search or query in background once the stuck call returns,
+the segment is in use by the call until then, and querycoord loads it again as a missing segment.`,
+		Export: true,
+	}
+	p.CGOWatchdogDegradeSegment.Init(base.mgr)
+
+	p.SearchCacheEnabled = ParamItem{
+		Key:          "queryNode.searchCache.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Cache the search results on the sealed segments, so the identical searches against the sealed segments
+not deleted since skip searching segcore.`,
+		Export: true,
+	}
+	p.SearchCacheEnabled.Init(base.mgr)
+
+	p.SearchCacheCapacity = ParamItem{
+		Key:          "queryNode.searchCache.capacity",
+		Version:      "2.5.0",
+		DefaultValue: "1024",
+		Doc:          "The max number of the search results cached",
+		Export:       true,
+	}
+	p.SearchCacheCapacity.Init(base.mgr)
+
+	p.SearchCacheTimeBucket = ParamItem{
+		Key:          "queryNode.searchCache.timeBucket",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc: `The width in seconds of the buckets of the search timestamps, the searches in the same bucket share the cached results,
+which expire after it, so the entities expired by the collection ttl are returned for at most the width.`,
+		Export: true,
+	}
+	p.SearchCacheTimeBucket.Init(base.mgr)
+
+	p.SearchCacheMaxResultSize = ParamItem{
+		Key:          "queryNode.searchCache.maxResultSize",
+		Version:      "2.5.0",
+		DefaultValue: "1",
+		Doc:          "The max size in MB of a search result to cache",
+		Export:       true,
+	}
+	p.SearchCacheMaxResultSize.Init(base.mgr)
+
+	p.ConcurrentFieldLoadEnabled = ParamItem{
+		Key:          "queryNode.concurrentFieldLoad.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Download and decode the field binlogs of the growing segments concurrently in the ioPoolSize goroutines,
+then insert the decoded rows into segcore, instead of loading all fields in a single segcore call.`,
+		Export: true,
+	}
+	p.ConcurrentFieldLoadEnabled.Init(base.mgr)
+
+	p.ConcurrentFieldLoadMemoryBudget = ParamItem{
+		Key:          "queryNode.concurrentFieldLoad.memoryBudget",
+		Version:      "2.5.0",
+		DefaultValue: "512",
+		Doc:          "The max size in MB of the field binlogs downloaded and decoded but not inserted into segcore yet",
+		Export:       true,
+	}
+	p.ConcurrentFieldLoadMemoryBudget.Init(base.mgr)
+
+	p.BackgroundIndexLoadEnabled = ParamItem{
+		Key:          "queryNode.backgroundIndexLoad.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Load the raw data of the indexed vector fields of the sealed segments, so the segments are searchable by brute force at once,
+and load the vector indexes in the background, which replace the raw data once loaded.
+It takes the memory of both the raw data and the index until the index loaded.`,
+		Export: true,
+	}
+	p.BackgroundIndexLoadEnabled.Init(base.mgr)
+
+	p.BackgroundIndexLoadConcurrency = ParamItem{
+		Key:          "queryNode.backgroundIndexLoad.concurrency",
+		Version:      "2.5.0",
+		DefaultValue: "2",
+		Doc:          "The max number of the vector indexes loaded in the background concurrently",
+		Export:       true,
+	}
+	p.BackgroundIndexLoadConcurrency.Init(base.mgr)
+
+	p.PlanCacheEnabled = ParamItem{
+		Key:          "queryNode.planCache.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Cache the segcore plans created from the serialized search and query plans per collection,
+so the requests with the same plan, e.g. the searches with the same filter and different vectors, skip creating the plan.`,
+		Export: true,
+	}
+	p.PlanCacheEnabled.Init(base.mgr)
+
+	p.PlanCacheCapacity = ParamItem{
+		Key:          "queryNode.planCache.capacity",
+		Version:      "2.5.0",
+		DefaultValue: "256",
+		Doc:          "The max number of the plans cached per collection",
+		Export:       true,
+	}
+	p.PlanCacheCapacity.Init(base.mgr)
+
+	p.SegmentJournalCapacity = ParamItem{
+		Key:          "queryNode.segmentJournal.capacity",
+		Version:      "2.5.0",
+		DefaultValue: "1024",
+		Doc: `The max number of the latest segment events kept by the journal, the creating, loading and releasing of the segments,
+which are listed by the management API /management/querynode/segments/events. 0 disables the journal.`,
+		Export: true,
+	}
+	p.SegmentJournalCapacity.Init(base.mgr)
+
+	p.SegmentJournalEventLog = ParamItem{
+		Key:          "queryNode.segmentJournal.eventLog",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Publish the segment events to the event log as well",
+		Export:       true,
+	}
+	p.SegmentJournalEventLog.Init(base.mgr)
+
+	p.DeleteFeedbackEnabled = ParamItem{
+		Key:          "queryNode.deleteFeedback.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc: `Report the sealed segments of which the deleted ratio exceeds dataCoord.compaction.single.ratio.threshold to datacoord,
+so the L0 compaction of their channels applying the deletes into their deltalogs, then their compaction, are triggered early`,
+		Export: true,
+	}
+	p.DeleteFeedbackEnabled.Init(base.mgr)
+
+	p.DeleteFeedbackInterval = ParamItem{
+		Key:          "queryNode.deleteFeedback.interval",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "the interval in seconds to report the deleted ratios, datacoord ignores the reports not refreshed within 3 intervals and the ones of the querynodes gone",
+		Export:       true,
+	}
+	p.DeleteFeedbackInterval.Init(base.mgr)
+
+	p.FanOutFeedbackEnabled = ParamItem{
+		Key:          "queryNode.fanOutFeedback.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc: `Report the searches served by each shard and the sealed segments they fanned out to to datacoord,
+which merges the small segments of the shards by them, see dataCoord.compaction.fanOut`,
+		Export: true,
+	}
+	p.FanOutFeedbackEnabled.Init(base.mgr)
+
+	p.FanOutFeedbackInterval = ParamItem{
+		Key:          "queryNode.fanOutFeedback.interval",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "the interval in seconds to report the search fan out, datacoord ignores the reports not refreshed within 3 intervals and the ones of the querynodes gone",
+		Export:       true,
+	}
+	p.FanOutFeedbackInterval.Init(base.mgr)
+
+	p.WorkerPoolingSize = ParamItem{
+		Key:          "queryNode.workerPooling.size",
+		Version:      "2.4.7",
+		Doc:          "the size for worker querynode client pool",
+		DefaultValue: "10",
+		Export:       true,
+	}
+	p.WorkerPoolingSize.Init(base.mgr)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// --- datacoord ---
+type dataCoordConfig struct {
+	// --- CHANNEL ---
+	WatchTimeoutInterval             ParamItem `refreshable:"false"`
+	LegacyVersionWithoutRPCWatch     ParamItem `refreshable:"false"`
+	ChannelBalanceSilentDuration     ParamItem `refreshable:"true"`
+	ChannelBalanceInterval           ParamItem `refreshable:"true"`
+	ChannelCheckInterval             ParamItem `refreshable:"true"`
+	ChannelOperationRPCTimeout       ParamItem `refreshable:"true"`
+	MaxConcurrentChannelTaskNumPerDN ParamItem `refreshable:"true"`
+
+	// --- SEGMENTS ---
 	SegmentMaxSize                 ParamItem `refreshable:"false"`
 	DiskSegmentMaxSize             ParamItem `refreshable:"true"`
 	SegmentSealProportion          ParamItem `refreshable:"false"`
@@ -3269,6 +4179,8 @@ type dataCoordConfig struct {
 	SegmentMaxIdleTime             ParamItem `refreshable:"false"`
 	SegmentMinSizeFromIdleToSealed ParamItem `refreshable:"false"`
 	SegmentMaxBinlogFileNumber     ParamItem `refreshable:"false"`
+	PartitionKeyAffinityGroups     ParamItem `refreshable:"true"`
+	PartitionKeyAffinityMaxGrowing ParamItem `refreshable:"true"`
 	GrowingSegmentsMemSizeInMB     ParamItem `refreshable:"true"`
 	AutoUpgradeSegmentIndex        ParamItem `refreshable:"true"`
 	SegmentFlushInterval           ParamItem `refreshable:"true"`
@@ -3299,6 +4211,10 @@ type dataCoordConfig struct {
 	SingleCompactionDeltaLogMaxSize   ParamItem `refreshable:"true"`
 	SingleCompactionExpiredLogMaxSize ParamItem `refreshable:"true"`
 	SingleCompactionDeltalogMaxNum    ParamItem `refreshable:"true"`
+	DeleteFeedbackCompactionEnable    ParamItem `refreshable:"true"`
+
+	FanOutCompactionEnable              ParamItem `refreshable:"true"`
+	FanOutCompactionMaxSegmentsPerShard ParamItem `refreshable:"true"`
 
 	ChannelCheckpointMaxLag ParamItem `refreshable:"true"`
 	SyncSegmentsInterval    ParamItem `refreshable:"false"`
@@ -3333,6 +4249,7 @@ type dataCoordConfig struct {
 	GCDropTolerance         ParamItem `refreshable:"false"`
 	GCRemoveConcurrent      ParamItem `refreshable:"false"`
 	GCScanIntervalInHour    ParamItem `refreshable:"false"`
+	GCFastDropCollection    ParamItem `refreshable:"true"`
 	EnableActiveStandby     ParamItem `refreshable:"false"`
 
 	BindIndexNodeMode          ParamItem `refreshable:"false"`
@@ -3368,6 +4285,9 @@ type dataCoordConfig struct {
 
 	EnableStatsTask   ParamItem `refreshable:"true"`
 	TaskCheckInterval ParamItem `refreshable:"true"`
+
+	JSONKeySchemaSyncInterval ParamItem `refreshable:"true"`
+	JSONKeySchemaMaxKeys      ParamItem `refreshable:"true"`
 }
 
 func (p *dataCoordConfig) init(base *BaseTable) {
@@ -3527,6 +4447,28 @@ the segment will be sealed if the number of binlog file reaches to max value.`,
 	}
 	p.SegmentMaxBinlogFileNumber.Init(base.mgr)
 
+	p.PartitionKeyAffinityGroups = ParamItem{
+		Key:          "dataCoord.segment.partitionKeyAffinityGroups",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `The number of the groups the hash values of the partition keys are split into evenly in a partition,
+the rows inserted with the partition keys in different groups are allocated to different segments,
+so the queries filtered by the partition keys touch fewer segments. 0 means disabled.`,
+		Export: true,
+	}
+	p.PartitionKeyAffinityGroups.Init(base.mgr)
+
+	p.PartitionKeyAffinityMaxGrowing = ParamItem{
+		Key:          "dataCoord.segment.partitionKeyAffinityMaxGrowing",
+		Version:      "2.5.0",
+		DefaultValue: "8",
+		Doc: `The max number of the growing segments holding the rows of a partition key group in a partition and a channel,
+the rows of the other groups are allocated to the segments shared by all groups once it's reached,
+so the growing segments are not multiplied by the partitionKeyAffinityGroups.`,
+		Export: true,
+	}
+	p.PartitionKeyAffinityMaxGrowing.Init(base.mgr)
+
 	p.GrowingSegmentsMemSizeInMB = ParamItem{
 		Key:          "dataCoord.sealPolicy.channel.growingSegmentsMemSize",
 		Version:      "2.4.6",
@@ -3707,6 +4649,33 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.SingleCompactionDeltalogMaxNum.Init(base.mgr)
 
+	p.DeleteFeedbackCompactionEnable = ParamItem{
+		Key:          "dataCoord.compaction.deleteFeedback.enable",
+		Version:      "2.5.0",
+		DefaultValue: "true",
+		Doc:          "Enable triggering the compaction of the segments by the deleted ratios reported by the querynodes, see queryNode.deleteFeedback",
+		Export:       true,
+	}
+	p.DeleteFeedbackCompactionEnable.Init(base.mgr)
+
+	p.FanOutCompactionEnable = ParamItem{
+		Key:          "dataCoord.compaction.fanOut.enable",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Enable merging small segments of a shard when the number of segments every search fans out to exceeds the threshold",
+		Export:       true,
+	}
+	p.FanOutCompactionEnable.Init(base.mgr)
+
+	p.FanOutCompactionMaxSegmentsPerShard = ParamItem{
+		Key:          "dataCoord.compaction.fanOut.maxSegmentsPerShard",
+		Version:      "2.5.0",
+		DefaultValue: "64",
+		Doc:          "The average number of sealed segments the searches on a shard fan out to, reported by the querynodes, above which small segments of the shard are merged",
+		Export:       true,
+	}
+	p.FanOutCompactionMaxSegmentsPerShard.Init(base.mgr)
+
 	p.GlobalCompactionInterval = ParamItem{
 		Key:          "dataCoord.compaction.global.interval",
 		Version:      "2.0.0",
@@ -3974,6 +4943,16 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.GCRemoveConcurrent.Init(base.mgr)
 
+	p.GCFastDropCollection = ParamItem{
+		Key:          "dataCoord.gc.fastDropCollection",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Remove the binlogs of a dropped collection by removing its object key prefixes instead of the binlogs of each segment,
+the segment meta is dropped after the next gc verifies that nothing is left under the prefixes.`,
+		Export: true,
+	}
+	p.GCFastDropCollection.Init(base.mgr)
+
 	p.EnableActiveStandby = ParamItem{
 		Key:          "dataCoord.enableActiveStandby",
 		Version:      "2.0.0",
@@ -4234,6 +5213,25 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 		Export:       false,
 	}
 	p.TaskCheckInterval.Init(base.mgr)
+
+	p.JSONKeySchemaSyncInterval = ParamItem{
+		Key:          "dataCoord.jsonKeySchema.syncInterval",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc: `The interval in seconds to save the JSON key schemas reported by datanodes into the collection property
+collection.json.keySchema, which is returned by DescribeCollection.`,
+		Export: true,
+	}
+	p.JSONKeySchemaSyncInterval.Init(base.mgr)
+
+	p.JSONKeySchemaMaxKeys = ParamItem{
+		Key:          "dataCoord.jsonKeySchema.maxKeys",
+		Version:      "2.5.0",
+		DefaultValue: "1000",
+		Doc:          "the max number of key paths saved for each JSON field of a collection, the keys beyond it are dropped",
+		Export:       true,
+	}
+	p.JSONKeySchemaMaxKeys.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -4243,6 +5241,9 @@ type dataNodeConfig struct {
 	FlowGraphMaxParallelism ParamItem `refreshable:"false"`
 	MaxParallelSyncTaskNum  ParamItem `refreshable:"false"`
 	MaxParallelSyncMgrTasks ParamItem `refreshable:"true"`
+	SyncWriteRateLimit      ParamItem `refreshable:"true"`
+	SyncWriteRetryAttempts  ParamItem `refreshable:"true"`
+	MaxPendingSyncTasks     ParamItem `refreshable:"false"`
 
 	// skip mode
 	FlowGraphSkipModeEnable   ParamItem `refreshable:"true"`
@@ -4309,6 +5310,10 @@ type dataNodeConfig struct {
 	BloomFilterApplyParallelFactor ParamItem `refreshable:"true"`
 
 	DeltalogFormat ParamItem `refreshable:"false"`
+
+	// json key schema
+	JSONKeySchemaEnabled ParamItem `refreshable:"true"`
+	JSONKeySchemaMaxKeys ParamItem `refreshable:"true"`
 }
 
 func (p *dataNodeConfig) init(base *BaseTable) {
@@ -4386,6 +5391,33 @@ func (p *dataNodeConfig) init(base *BaseTable) {
 	}
 	p.MaxParallelSyncMgrTasks.Init(base.mgr)
 
+	p.SyncWriteRateLimit = ParamItem{
+		Key:          "dataNode.dataSync.writeRateLimit",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc:          "The max bytes rate in MB/s of sync tasks writing binlogs into object storage globally, 0 means no limit",
+		Export:       true,
+	}
+	p.SyncWriteRateLimit.Init(base.mgr)
+
+	p.MaxPendingSyncTasks = ParamItem{
+		Key:          "dataNode.dataSync.maxPendingSyncTasks",
+		Version:      "2.5.0",
+		DefaultValue: "16",
+		Doc:          "The max number of sync tasks submitted but not done of each channel, submitting more tasks blocks until one is done, 0 means no limit",
+		Export:       true,
+	}
+	p.MaxPendingSyncTasks.Init(base.mgr)
+
+	p.SyncWriteRetryAttempts = ParamItem{
+		Key:          "dataNode.dataSync.writeRetryAttempts",
+		Version:      "2.5.0",
+		DefaultValue: "10",
+		Doc:          "The max attempts of a sync task writing binlogs into object storage before it fails",
+		Export:       true,
+	}
+	p.SyncWriteRetryAttempts.Init(base.mgr)
+
 	p.FlushInsertBufferSize = ParamItem{
 		Key:          "dataNode.segment.insertBufSize",
 		Version:      "2.0.0",
@@ -4701,6 +5733,25 @@ if this parameter <= 0, will set it as 10`,
 		Export:       true,
 	}
 	p.DeltalogFormat.Init(base.mgr)
+
+	p.JSONKeySchemaEnabled = ParamItem{
+		Key:          "dataNode.jsonKeySchema.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether to learn the key paths and value types of the JSON and dynamic fields from the flushed rows,
+the learned keys are reported to datacoord and returned by DescribeCollection.`,
+		Export: true,
+	}
+	p.JSONKeySchemaEnabled.Init(base.mgr)
+
+	p.JSONKeySchemaMaxKeys = ParamItem{
+		Key:          "dataNode.jsonKeySchema.maxKeys",
+		Version:      "2.5.0",
+		DefaultValue: "1000",
+		Doc:          "the max number of key paths learned for each JSON field from the rows of a sync, the keys beyond it are dropped",
+		Export:       true,
+	}
+	p.JSONKeySchemaMaxKeys.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -4713,6 +5764,10 @@ type indexNodeConfig struct {
 	MaxDiskUsagePercentage ParamItem `refreshable:"true"`
 
 	GracefulStopTimeout ParamItem `refreshable:"true"`
+
+	BuildCPULimit    ParamGroup `refreshable:"true"`
+	BuildMemoryLimit ParamGroup `refreshable:"true"`
+	LowMemoryBuild   ParamGroup `refreshable:"true"`
 }
 
 func (p *indexNodeConfig) init(base *BaseTable) {
@@ -4724,6 +5779,30 @@ func (p *indexNodeConfig) init(base *BaseTable) {
 	}
 	p.BuildParallel.Init(base.mgr)
 
+	p.BuildCPULimit = ParamGroup{
+		KeyPrefix: "indexNode.buildLimit.cpu.",
+		Version:   "2.5.0",
+		Export:    true,
+		Doc:       "The max number of the cores used to build an index of the index type, such as HNSW: 4, not limited if not set",
+	}
+	p.BuildCPULimit.Init(base.mgr)
+
+	p.BuildMemoryLimit = ParamGroup{
+		KeyPrefix: "indexNode.buildLimit.memory.",
+		Version:   "2.5.0",
+		Export:    true,
+		Doc:       "The max memory in MB used to build an index of the index type, such as DISKANN: 4096, not limited if not set",
+	}
+	p.BuildMemoryLimit.Init(base.mgr)
+
+	p.LowMemoryBuild = ParamGroup{
+		KeyPrefix: "indexNode.lowMemoryBuild.",
+		Version:   "2.5.0",
+		Export:    true,
+		Doc:       "Whether to build an in-memory vector index of the index type from the raw data mmap'd from the local storage, such as HNSW: true, which lowers the peak memory of the build at the cost of the local disk io, disabled if not set",
+	}
+	p.LowMemoryBuild.Init(base.mgr)
+
 	p.EnableDisk = ParamItem{
 		Key:          "indexNode.enableDisk",
 		Version:      "2.2.0",
@@ -4787,6 +5866,21 @@ type streamingConfig struct {
 
 	// txn
 	TxnDefaultKeepaliveTimeout ParamItem `refreshable:"true"`
+
+	// rate limit
+	WALRateLimitEnabled              ParamItem `refreshable:"true"`
+	WALRateLimitVChannelMaxBytesRate ParamItem `refreshable:"true"`
+	WALRateLimitVChannelMaxMsgRate   ParamItem `refreshable:"true"`
+	WALRateLimitCollectionMaxMsgRate ParamItem `refreshable:"true"`
+	WALRateLimitMaxWait              ParamItem `refreshable:"true"`
+
+	// dedup
+	WALDedupEnabled    ParamItem `refreshable:"true"`
+	WALDedupWindow     ParamItem `refreshable:"true"`
+	WALDedupMaxEntries ParamItem `refreshable:"true"`
+
+	// schema check
+	WALSchemaCheckEnabled ParamItem `refreshable:"true"`
 }
 
 func (p *streamingConfig) init(base *BaseTable) {
@@ -4827,6 +5921,108 @@ It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDura
 		Export:       true,
 	}
 	p.TxnDefaultKeepaliveTimeout.Init(base.mgr)
+
+	// rate limit
+	p.WALRateLimitEnabled = ParamItem{
+		Key:     "streaming.walRateLimit.enabled",
+		Version: "2.5.0",
+		Doc: `Whether to limit the rate of the insert and delete messages appended into the wal, false by default.
+The bytes rate of each collection follows quotaAndLimits.dml.insertRate.collection.max and quotaAndLimits.dml.deleteRate.collection.max`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALRateLimitEnabled.Init(base.mgr)
+	p.WALRateLimitVChannelMaxBytesRate = ParamItem{
+		Key:          "streaming.walRateLimit.vchannel.maxBytesRate",
+		Version:      "2.5.0",
+		Doc:          "The max MB/s of the messages appended into each vchannel, -1 means no limit",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			rate := getAsFloat(v)
+			if rate < 0 {
+				return fmt.Sprintf("%f", defaultMax)
+			}
+			return fmt.Sprintf("%f", megaBytes2Bytes(rate))
+		},
+		Export: true,
+	}
+	p.WALRateLimitVChannelMaxBytesRate.Init(base.mgr)
+	p.WALRateLimitVChannelMaxMsgRate = ParamItem{
+		Key:          "streaming.walRateLimit.vchannel.maxMsgRate",
+		Version:      "2.5.0",
+		Doc:          "The max messages per second appended into each vchannel, -1 means no limit",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return fmt.Sprintf("%f", defaultMax)
+			}
+			return v
+		},
+		Export: true,
+	}
+	p.WALRateLimitVChannelMaxMsgRate.Init(base.mgr)
+	p.WALRateLimitCollectionMaxMsgRate = ParamItem{
+		Key:          "streaming.walRateLimit.collection.maxMsgRate",
+		Version:      "2.5.0",
+		Doc:          "The max messages per second appended into each collection on the streaming node, -1 means no limit",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return fmt.Sprintf("%f", defaultMax)
+			}
+			return v
+		},
+		Export: true,
+	}
+	p.WALRateLimitCollectionMaxMsgRate.Init(base.mgr)
+	p.WALRateLimitMaxWait = ParamItem{
+		Key:     "streaming.walRateLimit.maxWait",
+		Version: "2.5.0",
+		Doc: `The max duration the append waits for the rate limit, 1s by default, the append is rejected beyond it.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1s",
+		Export:       true,
+	}
+	p.WALRateLimitMaxWait.Init(base.mgr)
+
+	// dedup
+	p.WALDedupEnabled = ParamItem{
+		Key:     "streaming.walDedup.enabled",
+		Version: "2.5.0",
+		Doc: `Whether to drop the messages appended again by the retries of the producer, true by default.
+The duplicated message gets the append result of the first one, so the retry after a network flap doesn't insert twice`,
+		DefaultValue: "true",
+		Export:       true,
+	}
+	p.WALDedupEnabled.Init(base.mgr)
+	p.WALDedupWindow = ParamItem{
+		Key:     "streaming.walDedup.window",
+		Version: "2.5.0",
+		Doc: `The duration the appended messages are remembered to drop their duplicates, 5m by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5m",
+		Export:       true,
+	}
+	p.WALDedupWindow.Init(base.mgr)
+	p.WALDedupMaxEntries = ParamItem{
+		Key:          "streaming.walDedup.maxEntries",
+		Version:      "2.5.0",
+		Doc:          "The max number of the appended messages remembered by each wal, the oldest ones are forgotten beyond it even within the window",
+		DefaultValue: "100000",
+		Export:       true,
+	}
+	p.WALDedupMaxEntries.Init(base.mgr)
+
+	// schema check
+	p.WALSchemaCheckEnabled = ParamItem{
+		Key:     "streaming.walSchemaCheck.enabled",
+		Version: "2.5.0",
+		Doc: `Whether to validate the insert messages against the collection schema before appended into the wal, true by default.
+The invalid one is rejected with the field at fault, rather than failing the flush of its segment later`,
+		DefaultValue: "true",
+		Export:       true,
+	}
+	p.WALSchemaCheckEnabled.Init(base.mgr)
 }
 
 type runtimeConfig struct {
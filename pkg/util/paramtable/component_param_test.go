@@ -17,6 +17,7 @@
 package paramtable
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -138,6 +139,11 @@ func TestComponentParam(t *testing.T) {
 		assert.False(t, params.CommonCfg.LocalRPCEnabled.GetAsBool())
 		params.Save("common.localRPCEnabled", "true")
 		assert.True(t, params.CommonCfg.LocalRPCEnabled.GetAsBool())
+
+		assert.Equal(t, 5*time.Second, params.CommonCfg.BrokerCacheTTL.GetAsDuration(time.Second))
+		assert.Equal(t, 4096, params.CommonCfg.BrokerCacheCapacity.GetAsInt())
+		assert.Equal(t, 10000, params.CommonCfg.DecisionJournalCapacity.GetAsInt())
+		assert.Equal(t, 5*time.Second, params.CommonCfg.DecisionJournalFlushInterval.GetAsDurationByParse())
 	})
 
 	t.Run("test rootCoordConfig", func(t *testing.T) {
@@ -201,6 +207,28 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, Params.CostMetricsExpireTime.GetAsInt(), 1000)
 		assert.Equal(t, Params.RetryTimesOnReplica.GetAsInt(), 2)
 		assert.EqualValues(t, Params.HealthCheckTimeout.GetAsInt64(), 3000)
+		assert.Equal(t, int64(0), Params.MaxDeleteAffectedRows.GetAsInt64())
+		assert.Equal(t, int64(100000), Params.DeleteProgressLogEntries.GetAsInt64())
+		assert.Equal(t, 3600*time.Second, Params.DeleteResumeMaxAge.GetAsDuration(time.Second))
+		assert.True(t, Params.EnableQueryStream.GetAsBool())
+		assert.False(t, Params.SkipInvalidVectorRows.GetAsBool())
+		assert.True(t, Params.QueryStatsEnabled.GetAsBool())
+		assert.Equal(t, 300*time.Second, Params.QueryStatsFlushInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 168*time.Hour, Params.QueryStatsRetention.GetAsDuration(time.Hour))
+		assert.False(t, Params.UsageMeterEnabled.GetAsBool())
+		assert.Equal(t, int64(3600), Params.UsageMeterWindow.GetAsInt64())
+		assert.Equal(t, 60*time.Second, Params.UsageMeterFlushInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 720*time.Hour, Params.UsageMeterRetention.GetAsDuration(time.Hour))
+		assert.Equal(t, 0.0, Params.SearchConsistencySampleRatio.GetAsFloat())
+		assert.Equal(t, 0.00001, Params.SearchConsistencyScoreTolerance.GetAsFloat())
+		assert.True(t, Params.StrongConsistencyBarrier.GetAsBool())
+		assert.False(t, Params.SchemaOrderedOutputFields.GetAsBool())
+		assert.Equal(t, int64(10000), Params.TieredReduceTopK.GetAsInt64())
+		assert.Equal(t, "wal", Params.InsertAckLevel.GetValue())
+		assert.Equal(t, 60*time.Second, Params.InsertAckTimeout.GetAsDuration(time.Second))
+		assert.False(t, Params.ResultExportEnabled.GetAsBool())
+		assert.Empty(t, Params.ResultExportAllowedBuckets.GetAsStrings())
+		assert.Equal(t, int64(100000), Params.ResultExportMaxRowsPerFile.GetAsInt64())
 
 		params.Save("proxy.gracefulStopTimeout", "100")
 		assert.Equal(t, 100*time.Second, Params.GracefulStopTimeout.GetAsDuration(time.Second))
@@ -373,6 +401,21 @@ func TestComponentParam(t *testing.T) {
 
 		assert.Equal(t, 0, Params.ClusterLevelLoadReplicaNumber.GetAsInt())
 		assert.Len(t, Params.ClusterLevelLoadResourceGroups.GetAsStrings(), 0)
+		assert.False(t, Params.EnforceDatabaseResourceGroups.GetAsBool())
+		assert.Equal(t, "", Params.WarmPoolResourceGroup.GetValue())
+		assert.Equal(t, 128, Params.WarmPoolMaxSegmentsPerNode.GetAsInt())
+		assert.Equal(t, 60*time.Second, Params.WarmPoolCheckInterval.GetAsDuration(time.Second))
+
+		assert.Equal(t, "none", Params.SegmentRecoveryOrder.GetValue())
+		assert.Empty(t, Params.SegmentRecoveryPriorityCollections.GetAsStrings())
+		assert.Equal(t, 0, Params.SegmentRecoveryParallelism.GetAsInt())
+		params.Save("queryCoord.segmentRecovery.priorityCollections", "100,200")
+		assert.Equal(t, []string{"100", "200"}, Params.SegmentRecoveryPriorityCollections.GetAsStrings())
+		params.Reset("queryCoord.segmentRecovery.priorityCollections")
+
+		assert.False(t, Params.RowCountScrubEnabled.GetAsBool())
+		assert.Equal(t, time.Hour, Params.RowCountScrubInterval.GetAsDuration(time.Second))
+		assert.False(t, Params.CheckSegmentDigest.GetAsBool())
 
 		assert.Equal(t, 10, Params.CollectionChannelCountFactor.GetAsInt())
 	})
@@ -428,6 +471,11 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, true, Params.KnowhereScoreConsistency.GetAsBool())
 		params.Save("queryNode.segcore.knowhereScoreConsistency", "false")
 
+		assert.Empty(t, Params.IsolatedCollectionThreadNum.GetAsStrings())
+		params.Save("queryNode.segcore.isolatedCollectionThreadNum", "100:8,101:4")
+		assert.Equal(t, []string{"100:8", "101:4"}, Params.IsolatedCollectionThreadNum.GetAsStrings())
+		params.Reset("queryNode.segcore.isolatedCollectionThreadNum")
+
 		nlist = Params.InterimIndexNlist.GetAsInt64()
 		assert.Equal(t, int64(128), nlist)
 
@@ -476,6 +524,47 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 3*time.Second, Params.LazyLoadRequestResourceRetryInterval.GetAsDuration(time.Millisecond))
 
 		assert.Equal(t, 4, Params.BloomFilterApplyParallelFactor.GetAsInt())
+		assert.True(t, Params.EnableRetrievePKRouting.GetAsBool())
+		assert.False(t, Params.ReadOnlyEnabled.GetAsBool())
+		assert.Equal(t, 600*time.Second, Params.ReadOnlyMaxStaleness.GetAsDuration(time.Second))
+		assert.Equal(t, 0, Params.DelegatorMaxPendingRequests.GetAsInt())
+		assert.Equal(t, 100*time.Millisecond, Params.DelegatorBusyRetryAfter.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 0.0, Params.CircuitBreakerErrorRate.GetAsFloat())
+		assert.Equal(t, 20, Params.CircuitBreakerMinRequests.GetAsInt())
+		assert.Equal(t, 10*time.Second, Params.CircuitBreakerWindow.GetAsDuration(time.Second))
+		assert.Equal(t, 30*time.Second, Params.CircuitBreakerOpenDuration.GetAsDuration(time.Second))
+		assert.False(t, Params.RecallTuningEnabled.GetAsBool())
+		assert.Equal(t, 600*time.Second, Params.RecallTuningInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 100, Params.RecallTuningSampleQueries.GetAsInt())
+		assert.Equal(t, 10, Params.RecallTuningTopK.GetAsInt())
+		assert.Equal(t, int64(50000), Params.RecallTuningMaxSegmentRows.GetAsInt64())
+		assert.Equal(t, 0, Params.SegmentQuarantineThreshold.GetAsInt())
+		assert.Equal(t, 600*time.Second, Params.SegmentQuarantineWindow.GetAsDuration(time.Second))
+		assert.Equal(t, 100000, Params.UpsertPkVersionCapacity.GetAsInt())
+		assert.Equal(t, false, Params.SegmentReleaseAsync.GetAsBool())
+		assert.Equal(t, time.Duration(0), Params.SegmentReleaseDrainDeadline.GetAsDuration(time.Second))
+		assert.Equal(t, 20.0, Params.CGOWatchdogStuckFactor.GetAsFloat())
+		assert.Equal(t, time.Minute, Params.CGOWatchdogMinStuckTime.GetAsDuration(time.Second))
+		assert.False(t, Params.CGOWatchdogDumpNativeStacks.GetAsBool())
+		assert.False(t, Params.CGOWatchdogDegradeSegment.GetAsBool())
+		assert.False(t, Params.SearchCacheEnabled.GetAsBool())
+		assert.Equal(t, 1024, Params.SearchCacheCapacity.GetAsInt())
+		assert.Equal(t, time.Minute, Params.SearchCacheTimeBucket.GetAsDuration(time.Second))
+		assert.Equal(t, 1, Params.SearchCacheMaxResultSize.GetAsInt())
+		assert.False(t, Params.ConcurrentFieldLoadEnabled.GetAsBool())
+		assert.Equal(t, 512, Params.ConcurrentFieldLoadMemoryBudget.GetAsInt())
+		assert.False(t, Params.BackgroundIndexLoadEnabled.GetAsBool())
+		assert.Equal(t, 2, Params.BackgroundIndexLoadConcurrency.GetAsInt())
+		assert.False(t, Params.PlanCacheEnabled.GetAsBool())
+		assert.Equal(t, 256, Params.PlanCacheCapacity.GetAsInt())
+		assert.Equal(t, 1024, Params.SegmentJournalCapacity.GetAsInt())
+		assert.False(t, Params.SegmentJournalEventLog.GetAsBool())
+		assert.True(t, Params.DeleteFeedbackEnabled.GetAsBool())
+		assert.Equal(t, time.Minute, Params.DeleteFeedbackInterval.GetAsDuration(time.Second))
+		assert.True(t, Params.FanOutFeedbackEnabled.GetAsBool())
+		assert.Equal(t, time.Minute, Params.FanOutFeedbackInterval.GetAsDuration(time.Second))
+		assert.Equal(t, int64(0), Params.DeleteBufferSpillSize.GetAsInt64())
+		assert.Equal(t, "/var/lib/milvus/data/delete_buffer", Params.DeleteBufferSpillDir.GetValue())
 		assert.Equal(t, "/var/lib/milvus/data/mmap", Params.MmapDirPath.GetValue())
 
 		assert.Equal(t, true, Params.MmapChunkCache.GetAsBool())
@@ -485,13 +574,19 @@ func TestComponentParam(t *testing.T) {
 		Params := &params.DataCoordCfg
 		assert.Equal(t, 24*60*60*time.Second, Params.SegmentMaxLifetime.GetAsDuration(time.Second))
 		assert.True(t, Params.EnableGarbageCollection.GetAsBool())
+		assert.False(t, Params.GCFastDropCollection.GetAsBool())
 		assert.Equal(t, Params.EnableActiveStandby.GetAsBool(), false)
 		t.Logf("dataCoord EnableActiveStandby = %t", Params.EnableActiveStandby.GetAsBool())
 		assert.Equal(t, int64(4096), Params.GrowingSegmentsMemSizeInMB.GetAsInt64())
+		assert.Equal(t, 0, Params.PartitionKeyAffinityGroups.GetAsInt())
+		assert.Equal(t, 8, Params.PartitionKeyAffinityMaxGrowing.GetAsInt())
 
 		assert.Equal(t, true, Params.AutoBalance.GetAsBool())
 		assert.Equal(t, 10, Params.CheckAutoBalanceConfigInterval.GetAsInt())
 		assert.Equal(t, false, Params.AutoUpgradeSegmentIndex.GetAsBool())
+		assert.True(t, Params.DeleteFeedbackCompactionEnable.GetAsBool())
+		assert.False(t, Params.FanOutCompactionEnable.GetAsBool())
+		assert.Equal(t, 64, Params.FanOutCompactionMaxSegmentsPerShard.GetAsInt())
 		assert.Equal(t, 2, Params.FilesPerPreImportTask.GetAsInt())
 		assert.Equal(t, 10800*time.Second, Params.ImportTaskRetention.GetAsDuration(time.Second))
 		assert.Equal(t, 6144, Params.MaxSizeInMBPerImportTask.GetAsInt())
@@ -533,6 +628,8 @@ func TestComponentParam(t *testing.T) {
 		params.Save("datacoord.scheduler.taskSlowThreshold", "1000")
 		assert.Equal(t, 1000*time.Second, Params.TaskSlowThreshold.GetAsDuration(time.Second))
 		assert.Equal(t, 32, Params.MaxConcurrentChannelTaskNumPerDN.GetAsInt())
+		assert.Equal(t, 60*time.Second, Params.JSONKeySchemaSyncInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 1000, Params.JSONKeySchemaMaxKeys.GetAsInt())
 	})
 
 	t.Run("test dataNodeConfig", func(t *testing.T) {
@@ -561,6 +658,12 @@ func TestComponentParam(t *testing.T) {
 		maxParallelSyncTaskNum := Params.MaxParallelSyncTaskNum.GetAsInt()
 		t.Logf("maxParallelSyncTaskNum: %d", maxParallelSyncTaskNum)
 
+		assert.Equal(t, 0.0, Params.SyncWriteRateLimit.GetAsFloat())
+		assert.Equal(t, 10, Params.SyncWriteRetryAttempts.GetAsInt())
+		assert.Equal(t, 16, Params.MaxPendingSyncTasks.GetAsInt())
+		assert.False(t, Params.JSONKeySchemaEnabled.GetAsBool())
+		assert.Equal(t, 1000, Params.JSONKeySchemaMaxKeys.GetAsInt())
+
 		size := Params.FlushInsertBufferSize.GetAsInt()
 		t.Logf("FlushInsertBufferSize: %d", size)
 
@@ -604,6 +707,15 @@ func TestComponentParam(t *testing.T) {
 
 		params.Save("indexnode.gracefulStopTimeout", "100")
 		assert.Equal(t, 100*time.Second, Params.GracefulStopTimeout.GetAsDuration(time.Second))
+
+		assert.Empty(t, Params.BuildCPULimit.GetValue())
+		params.SaveGroup(map[string]string{Params.BuildCPULimit.KeyPrefix + "HNSW": "4"})
+		assert.Equal(t, map[string]string{"hnsw": "4"}, Params.BuildCPULimit.GetValue())
+		params.SaveGroup(map[string]string{Params.BuildMemoryLimit.KeyPrefix + "DISKANN": "4096"})
+		assert.Equal(t, map[string]string{"diskann": "4096"}, Params.BuildMemoryLimit.GetValue())
+		assert.Empty(t, Params.LowMemoryBuild.GetValue())
+		params.SaveGroup(map[string]string{Params.LowMemoryBuild.KeyPrefix + "HNSW": "true"})
+		assert.Equal(t, map[string]string{"hnsw": "true"}, Params.LowMemoryBuild.GetValue())
 	})
 
 	t.Run("test streamingConfig", func(t *testing.T) {
@@ -619,6 +731,20 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 50*time.Second, params.StreamingCfg.WALBalancerBackoffInitialInterval.GetAsDurationByParse())
 		assert.Equal(t, 3.5, params.StreamingCfg.WALBalancerBackoffMultiplier.GetAsFloat())
 		assert.Equal(t, 3500*time.Millisecond, params.StreamingCfg.TxnDefaultKeepaliveTimeout.GetAsDurationByParse())
+
+		assert.False(t, params.StreamingCfg.WALRateLimitEnabled.GetAsBool())
+		assert.Equal(t, math.MaxFloat64, params.StreamingCfg.WALRateLimitVChannelMaxBytesRate.GetAsFloat())
+		assert.Equal(t, math.MaxFloat64, params.StreamingCfg.WALRateLimitVChannelMaxMsgRate.GetAsFloat())
+		assert.Equal(t, math.MaxFloat64, params.StreamingCfg.WALRateLimitCollectionMaxMsgRate.GetAsFloat())
+		assert.Equal(t, time.Second, params.StreamingCfg.WALRateLimitMaxWait.GetAsDurationByParse())
+		params.Save(params.StreamingCfg.WALRateLimitVChannelMaxBytesRate.Key, "2")
+		params.Save(params.StreamingCfg.WALRateLimitVChannelMaxMsgRate.Key, "100")
+		assert.Equal(t, 2.0*1024*1024, params.StreamingCfg.WALRateLimitVChannelMaxBytesRate.GetAsFloat())
+		assert.Equal(t, 100.0, params.StreamingCfg.WALRateLimitVChannelMaxMsgRate.GetAsFloat())
+		assert.True(t, params.StreamingCfg.WALDedupEnabled.GetAsBool())
+		assert.Equal(t, 5*time.Minute, params.StreamingCfg.WALDedupWindow.GetAsDurationByParse())
+		assert.Equal(t, 100000, params.StreamingCfg.WALDedupMaxEntries.GetAsInt())
+		assert.True(t, params.StreamingCfg.WALSchemaCheckEnabled.GetAsBool())
 	})
 
 	t.Run("channel config priority", func(t *testing.T) {
@@ -667,6 +793,7 @@ func TestCachedParam(t *testing.T) {
 	assert.Equal(t, uint(100000), params.CommonCfg.BloomFilterSize.GetAsUint())
 	assert.Equal(t, uint(100000), params.CommonCfg.BloomFilterSize.GetAsUint())
 	assert.Equal(t, "BlockedBloomFilter", params.CommonCfg.BloomFilterType.GetValue())
+	assert.False(t, params.CommonCfg.BloomFilterAutoSize.GetAsBool())
 
 	assert.Equal(t, uint64(8388608), params.ServiceParam.MQCfg.PursuitBufferSize.GetAsUint64())
 	assert.Equal(t, uint64(8388608), params.ServiceParam.MQCfg.PursuitBufferSize.GetAsUint64())
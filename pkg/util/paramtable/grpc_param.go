@@ -18,6 +18,7 @@ package paramtable
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 
@@ -149,6 +150,13 @@ type GrpcServerConfig struct {
 	ServerMaxRecvSize ParamItem `refreshable:"false"`
 
 	GracefulStopTimeout ParamItem `refreshable:"true"`
+
+	ServerKeepAliveTime         ParamItem `refreshable:"false"`
+	ServerKeepAliveTimeout      ParamItem `refreshable:"false"`
+	ServerKeepAliveMinTime      ParamItem `refreshable:"false"`
+	ServerMaxConnectionIdle     ParamItem `refreshable:"false"`
+	ServerMaxConnectionAge      ParamItem `refreshable:"false"`
+	ServerMaxConnectionAgeGrace ParamItem `refreshable:"false"`
 }
 
 func (p *GrpcServerConfig) Init(domain string, base *BaseTable) {
@@ -208,6 +216,91 @@ func (p *GrpcServerConfig) Init(domain string, base *BaseTable) {
 		Export:       true,
 	}
 	p.GracefulStopTimeout.Init(base.mgr)
+
+	p.ServerKeepAliveTime = ParamItem{
+		Key:          "grpc.server.keepAliveTime",
+		Version:      "2.5.0",
+		DefaultValue: "60000",
+		Doc:          "ms, ping the client if the connection is idle for this duration to ensure it's still alive",
+		Export:       true,
+	}
+	p.ServerKeepAliveTime.Init(base.mgr)
+
+	p.ServerKeepAliveTimeout = ParamItem{
+		Key:          "grpc.server.keepAliveTimeout",
+		Version:      "2.5.0",
+		DefaultValue: "10000",
+		Doc:          "ms, wait for the ping ack for this duration before closing the connection",
+		Export:       true,
+	}
+	p.ServerKeepAliveTimeout.Init(base.mgr)
+
+	p.ServerKeepAliveMinTime = ParamItem{
+		Key:          "grpc.server.keepAliveMinTime",
+		Version:      "2.5.0",
+		DefaultValue: "5000",
+		Doc: `ms, the minimum interval clients are allowed to send keepalive pings,
+connections of clients pinging more frequently are closed. Should not exceed grpc.client.keepAliveTime`,
+		Export: true,
+	}
+	p.ServerKeepAliveMinTime.Init(base.mgr)
+
+	p.ServerMaxConnectionIdle = ParamItem{
+		Key:          "grpc.server.maxConnectionIdle",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc:          "ms, close the connections idle for this duration gracefully, 0 means never",
+		Export:       true,
+	}
+	p.ServerMaxConnectionIdle.Init(base.mgr)
+
+	p.ServerMaxConnectionAge = ParamItem{
+		Key:          "grpc.server.maxConnectionAge",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `ms, close the connections living for this duration gracefully, 0 means never.
+Set it below the connection lifetime limit of load balancers between components,
+so connections are rotated before being reset by the load balancers`,
+		Export: true,
+	}
+	p.ServerMaxConnectionAge.Init(base.mgr)
+
+	p.ServerMaxConnectionAgeGrace = ParamItem{
+		Key:          "grpc.server.maxConnectionAgeGrace",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc:          "ms, the time pending RPCs are allowed to complete on connections closed for max age, 0 means forever",
+		Export:       true,
+	}
+	p.ServerMaxConnectionAgeGrace.Init(base.mgr)
+}
+
+// infiniteIfZero returns the duration of the param in milliseconds, 0 or negative means infinity.
+func infiniteIfZero(p *ParamItem) time.Duration {
+	d := p.GetAsDuration(time.Millisecond)
+	if d <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return d
+}
+
+// GetKeepaliveEnforcementPolicy returns the keepalive enforcement policy of grpc server from config.
+func (p *GrpcServerConfig) GetKeepaliveEnforcementPolicy() keepalive.EnforcementPolicy {
+	return keepalive.EnforcementPolicy{
+		MinTime:             p.ServerKeepAliveMinTime.GetAsDuration(time.Millisecond),
+		PermitWithoutStream: true, // Allow pings even when there are no active streams
+	}
+}
+
+// GetKeepaliveServerParameters returns the keepalive parameters of grpc server from config.
+func (p *GrpcServerConfig) GetKeepaliveServerParameters() keepalive.ServerParameters {
+	return keepalive.ServerParameters{
+		MaxConnectionIdle:     infiniteIfZero(&p.ServerMaxConnectionIdle),
+		MaxConnectionAge:      infiniteIfZero(&p.ServerMaxConnectionAge),
+		MaxConnectionAgeGrace: infiniteIfZero(&p.ServerMaxConnectionAgeGrace),
+		Time:                  p.ServerKeepAliveTime.GetAsDuration(time.Millisecond),
+		Timeout:               p.ServerKeepAliveTimeout.GetAsDuration(time.Millisecond),
+	}
 }
 
 // GrpcClientConfig is configuration for grpc client.
@@ -219,9 +312,10 @@ type GrpcClientConfig struct {
 	ClientMaxSendSize ParamItem `refreshable:"false"`
 	ClientMaxRecvSize ParamItem `refreshable:"false"`
 
-	DialTimeout      ParamItem `refreshable:"false"`
-	KeepAliveTime    ParamItem `refreshable:"false"`
-	KeepAliveTimeout ParamItem `refreshable:"false"`
+	DialTimeout        ParamItem `refreshable:"false"`
+	KeepAliveTime      ParamItem `refreshable:"true"`
+	KeepAliveTimeout   ParamItem `refreshable:"true"`
+	ConnectionPoolSize ParamItem `refreshable:"true"`
 
 	MaxAttempts             ParamItem `refreshable:"false"`
 	InitialBackoff          ParamItem `refreshable:"false"`
@@ -495,6 +589,22 @@ func (p *GrpcClientConfig) Init(domain string, base *BaseTable) {
 		Export: true,
 	}
 	p.MaxCancelError.Init(base.mgr)
+
+	p.ConnectionPoolSize = ParamItem{
+		Key:          p.Domain + ".grpc.clientConnectionPoolSize",
+		Version:      "2.5.0",
+		DefaultValue: "1",
+		Formatter: func(v string) string {
+			size, err := strconv.Atoi(v)
+			if err != nil || size < 1 {
+				return "1"
+			}
+			return v
+		},
+		Doc:    "The number of connections the clients open to each " + domain + ", requests are spread over them round robin",
+		Export: true,
+	}
+	p.ConnectionPoolSize.Init(base.mgr)
 }
 
 // GetDialOptionsFromConfig returns grpc dial options from config.
@@ -582,3 +692,64 @@ func (p *InternalTLSConfig) Init(base *BaseTable) {
 	}
 	p.InternalTLSSNI.Init(base.mgr)
 }
+
+// ListenerConfig configures the interfaces listened on and the networks allowed to connect,
+// for the deployments without a service mesh.
+type ListenerConfig struct {
+	ExternalBindIP         ParamItem `refreshable:"false"`
+	ExternalAllowedCIDRs   ParamItem `refreshable:"false"`
+	InternalBindIP         ParamItem `refreshable:"false"`
+	InternalAllowedCIDRs   ParamItem `refreshable:"false"`
+	ManagementBindIP       ParamItem `refreshable:"true"`
+	ManagementAllowedCIDRs ParamItem `refreshable:"true"`
+}
+
+func (p *ListenerConfig) Init(base *BaseTable) {
+	p.ExternalBindIP = ParamItem{
+		Key:     "common.security.listeners.external.bindIP",
+		Version: "2.5.0",
+		Doc:     "The ip of the interface the proxy grpc and restful servers listen on, all the interfaces if empty",
+		Export:  true,
+	}
+	p.ExternalBindIP.Init(base.mgr)
+
+	p.ExternalAllowedCIDRs = ParamItem{
+		Key:     "common.security.listeners.external.allowedCIDRs",
+		Version: "2.5.0",
+		Doc:     "The comma separated cidrs or ips allowed to connect to the proxy grpc and restful servers, all if empty",
+		Export:  true,
+	}
+	p.ExternalAllowedCIDRs.Init(base.mgr)
+
+	p.InternalBindIP = ParamItem{
+		Key:     "common.security.listeners.internal.bindIP",
+		Version: "2.5.0",
+		Doc:     "The ip of the interface the internal grpc servers of the components listen on, all the interfaces if empty",
+		Export:  true,
+	}
+	p.InternalBindIP.Init(base.mgr)
+
+	p.InternalAllowedCIDRs = ParamItem{
+		Key:     "common.security.listeners.internal.allowedCIDRs",
+		Version: "2.5.0",
+		Doc:     "The comma separated cidrs or ips allowed to connect to the internal grpc servers, all if empty, the cidrs of all the components must be included",
+		Export:  true,
+	}
+	p.InternalAllowedCIDRs.Init(base.mgr)
+
+	p.ManagementBindIP = ParamItem{
+		Key:     "common.security.listeners.management.bindIP",
+		Version: "2.5.0",
+		Doc:     "The ip of the interface the metrics and management server listens on, all the interfaces if empty. The server is restarted on the new ip once it changes",
+		Export:  true,
+	}
+	p.ManagementBindIP.Init(base.mgr)
+
+	p.ManagementAllowedCIDRs = ParamItem{
+		Key:     "common.security.listeners.management.allowedCIDRs",
+		Version: "2.5.0",
+		Doc:     "The comma separated cidrs or ips allowed to connect to the metrics and management server, all if empty. The server is restarted with the new cidrs once they change",
+		Export:  true,
+	}
+	p.ManagementAllowedCIDRs.Init(base.mgr)
+}
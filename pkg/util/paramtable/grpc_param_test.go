@@ -17,6 +17,7 @@
 package paramtable
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -69,6 +70,21 @@ func TestGrpcServerParams(t *testing.T) {
 
 	base.Save(serverConfig.GracefulStopTimeout.Key, "1")
 	assert.Equal(t, serverConfig.GracefulStopTimeout.GetAsInt(), 1)
+
+	kaep := serverConfig.GetKeepaliveEnforcementPolicy()
+	assert.Equal(t, 5*time.Second, kaep.MinTime)
+	assert.True(t, kaep.PermitWithoutStream)
+	kasp := serverConfig.GetKeepaliveServerParameters()
+	assert.Equal(t, 60*time.Second, kasp.Time)
+	assert.Equal(t, 10*time.Second, kasp.Timeout)
+	assert.Equal(t, time.Duration(math.MaxInt64), kasp.MaxConnectionAge)
+
+	base.Save(serverConfig.ServerMaxConnectionAge.Key, "300000")
+	base.Save(serverConfig.ServerMaxConnectionAgeGrace.Key, "10000")
+	kasp = serverConfig.GetKeepaliveServerParameters()
+	assert.Equal(t, 5*time.Minute, kasp.MaxConnectionAge)
+	assert.Equal(t, 10*time.Second, kasp.MaxConnectionAgeGrace)
+	assert.Equal(t, time.Duration(math.MaxInt64), kasp.MaxConnectionIdle)
 }
 
 func TestGrpcClientParams(t *testing.T) {
@@ -169,6 +185,12 @@ func TestGrpcClientParams(t *testing.T) {
 	base.Save("grpc.client.maxCancelError", "64")
 	assert.Equal(t, clientConfig.MaxCancelError.GetValue(), "64")
 
+	assert.Equal(t, 1, clientConfig.ConnectionPoolSize.GetAsInt())
+	base.Save(role+".grpc.clientConnectionPoolSize", "0")
+	assert.Equal(t, 1, clientConfig.ConnectionPoolSize.GetAsInt())
+	base.Save(role+".grpc.clientConnectionPoolSize", "4")
+	assert.Equal(t, 4, clientConfig.ConnectionPoolSize.GetAsInt())
+
 	base.Save("common.security.tlsMode", "1")
 	base.Save("tls.serverPemPath", "/pem")
 	base.Save("tls.serverKeyPath", "/key")
@@ -196,3 +218,20 @@ func TestInternalTLSParams(t *testing.T) {
 	assert.Equal(t, internalTLSCfg.InternalTLSCaPemPath.GetValue(), "/ca")
 	assert.Equal(t, internalTLSCfg.InternalTLSSNI.GetValue(), "localhost")
 }
+
+func TestListenerParams(t *testing.T) {
+	base := ComponentParam{}
+	base.Init(NewBaseTable(SkipRemote(true)))
+	cfg := &base.ListenerCfg
+	assert.Equal(t, "", cfg.ExternalBindIP.GetValue())
+	assert.Empty(t, cfg.ExternalAllowedCIDRs.GetAsStrings())
+	assert.Equal(t, "", cfg.InternalBindIP.GetValue())
+	assert.Empty(t, cfg.InternalAllowedCIDRs.GetAsStrings())
+	assert.Equal(t, "", cfg.ManagementBindIP.GetValue())
+	assert.Empty(t, cfg.ManagementAllowedCIDRs.GetAsStrings())
+
+	base.Save(cfg.InternalBindIP.Key, "10.0.0.1")
+	base.Save(cfg.InternalAllowedCIDRs.Key, "10.0.0.0/8, 192.168.0.1")
+	assert.Equal(t, "10.0.0.1", cfg.InternalBindIP.GetValue())
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.1"}, cfg.InternalAllowedCIDRs.GetAsStrings())
+}
@@ -23,6 +23,7 @@ type httpConfig struct {
 	AcceptTypeAllowInt64 ParamItem `refreshable:"true"`
 	EnablePprof          ParamItem `refreshable:"false"`
 	RequestTimeoutMs     ParamItem `refreshable:"false"`
+	ProgressInterval     ParamItem `refreshable:"true"`
 }
 
 func (p *httpConfig) init(base *BaseTable) {
@@ -71,4 +72,94 @@ func (p *httpConfig) init(base *BaseTable) {
 		Export:       true,
 	}
 	p.EnablePprof.Init(base.mgr)
+
+	p.ProgressInterval = ParamItem{
+		Key:          "proxy.http.progressInterval",
+		DefaultValue: "5",
+		Version:      "2.5.0",
+		Doc:          "high-level restful api, interval in seconds of the progress frames streamed for queries and searches requested with the Request-Progress header",
+		Export:       true,
+	}
+	p.ProgressInterval.Init(base.mgr)
+}
+
+type MetricsTLSConfig struct {
+	MetricsTLSEnabled       ParamItem `refreshable:"false"`
+	MetricsTLSServerPemPath ParamItem `refreshable:"false"`
+	MetricsTLSServerKeyPath ParamItem `refreshable:"false"`
+	MetricsTLSCaPemPath     ParamItem `refreshable:"false"`
+}
+
+func (p *MetricsTLSConfig) Init(base *BaseTable) {
+	p.MetricsTLSEnabled = ParamItem{
+		Key:          "common.security.metricsTLSEnabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Whether to serve the metrics and management port with tls, the certificates are reloaded once the files changed",
+		Export:       true,
+	}
+	p.MetricsTLSEnabled.Init(base.mgr)
+
+	p.MetricsTLSServerPemPath = ParamItem{
+		Key:     "metricstls.serverPemPath",
+		Version: "2.5.0",
+		Export:  true,
+	}
+	p.MetricsTLSServerPemPath.Init(base.mgr)
+
+	p.MetricsTLSServerKeyPath = ParamItem{
+		Key:     "metricstls.serverKeyPath",
+		Version: "2.5.0",
+		Export:  true,
+	}
+	p.MetricsTLSServerKeyPath.Init(base.mgr)
+
+	p.MetricsTLSCaPemPath = ParamItem{
+		Key:     "metricstls.caPemPath",
+		Version: "2.5.0",
+		Doc:     "The CA to verify the client certificates with, the client certificates are required if set (mTLS)",
+		Export:  true,
+	}
+	p.MetricsTLSCaPemPath.Init(base.mgr)
+}
+
+type ManagementAuthConfig struct {
+	Enabled  ParamItem `refreshable:"true"`
+	Username ParamItem `refreshable:"true"`
+	Password ParamItem `refreshable:"true"`
+	Token    ParamItem `refreshable:"true"`
+}
+
+func (p *ManagementAuthConfig) Init(base *BaseTable) {
+	p.Enabled = ParamItem{
+		Key:          "common.security.managementAuth.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc:          "Whether to require the credentials for the endpoints on the metrics and management port except healthz. The management requests changing the cluster, e.g. restoring a backup or applying a collection spec, are refused while it's disabled",
+		Export:       true,
+	}
+	p.Enabled.Init(base.mgr)
+
+	p.Username = ParamItem{
+		Key:     "common.security.managementAuth.username",
+		Version: "2.5.0",
+		Doc:     "The username of the basic authentication, the basic authentication is disabled if the username or the password is empty",
+		Export:  true,
+	}
+	p.Username.Init(base.mgr)
+
+	p.Password = ParamItem{
+		Key:     "common.security.managementAuth.password",
+		Version: "2.5.0",
+		Export:  true,
+	}
+	p.Password.Init(base.mgr)
+
+	p.Token = ParamItem{
+		Key:     "common.security.managementAuth.token",
+		Version: "2.5.0",
+		Doc:     "The bearer token accepted besides the basic authentication, disabled if empty",
+		Export:  true,
+	}
+	p.Token.Init(base.mgr)
 }
@@ -18,6 +18,7 @@ package paramtable
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,4 +32,30 @@ func TestHTTPConfig_Init(t *testing.T) {
 	assert.Equal(t, cfg.Port.GetValue(), "")
 	assert.Equal(t, cfg.AcceptTypeAllowInt64.GetValue(), "true")
 	assert.Equal(t, cfg.EnablePprof.GetAsBool(), true)
+	assert.Equal(t, cfg.ProgressInterval.GetAsDuration(time.Second), 5*time.Second)
+}
+
+func TestMetricsTLSConfig_Init(t *testing.T) {
+	params := ComponentParam{}
+	params.Init(NewBaseTable(SkipRemote(true)))
+	cfg := &params.MetricsTLSCfg
+	assert.False(t, cfg.MetricsTLSEnabled.GetAsBool())
+	assert.Equal(t, "configs/cert/server.pem", cfg.MetricsTLSServerPemPath.GetValue())
+	assert.Equal(t, "configs/cert/server.key", cfg.MetricsTLSServerKeyPath.GetValue())
+	assert.Equal(t, "", cfg.MetricsTLSCaPemPath.GetValue())
+}
+
+func TestManagementAuthConfig_Init(t *testing.T) {
+	params := ComponentParam{}
+	params.Init(NewBaseTable(SkipRemote(true)))
+	cfg := &params.ManagementAuthCfg
+	assert.False(t, cfg.Enabled.GetAsBool())
+	assert.Equal(t, "", cfg.Username.GetValue())
+	assert.Equal(t, "", cfg.Password.GetValue())
+	assert.Equal(t, "", cfg.Token.GetValue())
+
+	params.Save(cfg.Enabled.Key, "true")
+	params.Save(cfg.Token.Key, "token")
+	assert.True(t, cfg.Enabled.GetAsBool())
+	assert.Equal(t, "token", cfg.Token.GetValue())
 }
@@ -24,6 +24,9 @@ const (
 	BuildDramBudgetKey = "build_dram_budget_gb"
 	NumBuildThreadKey  = "num_build_thread"
 	VecFieldSizeKey    = "vec_field_size_gb"
+
+	LowMemoryBuildKey      = "low_memory_build"
+	RawDataMmapFilePathKey = "raw_data_mmap_filepath"
 )
 
 func (p *knowhereConfig) init(base *BaseTable) {
@@ -152,6 +152,7 @@ type quotaConfig struct {
 	DiskQuotaPerDB                        ParamItem `refreshable:"true"`
 	DiskQuotaPerCollection                ParamItem `refreshable:"true"`
 	DiskQuotaPerPartition                 ParamItem `refreshable:"true"`
+	DiskQuotaIncludeIndexSize             ParamItem `refreshable:"true"`
 	L0SegmentRowCountProtectionEnabled    ParamItem `refreshable:"true"`
 	L0SegmentRowCountLowWaterLevel        ParamItem `refreshable:"true"`
 	L0SegmentRowCountHighWaterLevel       ParamItem `refreshable:"true"`
@@ -1895,6 +1896,16 @@ but the rate will not be lower than minRateRatio * dmlRate.`,
 	}
 	p.DiskQuotaPerPartition.Init(base.mgr)
 
+	p.DiskQuotaIncludeIndexSize = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.diskProtection.includeIndexSize",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether the index files are counted into the disk usage of the cluster, databases and collections,
+by default only the binlog files are counted. Partition disk usage never counts the index files.`,
+		Export: true,
+	}
+	p.DiskQuotaIncludeIndexSize.Init(base.mgr)
+
 	p.L0SegmentRowCountProtectionEnabled = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.l0SegmentsRowCountProtection.enabled",
 		Version:      "2.4.7",
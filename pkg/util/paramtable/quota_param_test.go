@@ -201,6 +201,7 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, true, qc.DiskProtectionEnabled.GetAsBool())
 		assert.Equal(t, defaultMax, qc.DiskQuota.GetAsFloat())
 		assert.Equal(t, defaultMax, qc.DiskQuotaPerCollection.GetAsFloat())
+		assert.False(t, qc.DiskQuotaIncludeIndexSize.GetAsBool())
 	})
 
 	t.Run("test limit reading", func(t *testing.T) {
@@ -493,7 +493,8 @@ func (p *MetaStoreConfig) Init(base *BaseTable) {
 		Key:          "metastore.paginationSize",
 		Version:      "2.5.1",
 		DefaultValue: "10000",
-		Doc:          `limits the number of results to return from metastore.`,
+		Doc:          `limits the number of results to return from metastore in one request, the meta is loaded page by page on recovery to avoid exceeding the message size limit of etcd.`,
+		Export:       true,
 	}
 	p.PaginationSize.Init(base.mgr)
 
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"unsafe"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/cockroachdb/errors"
 	"github.com/spaolacci/murmur3"
 
@@ -110,10 +111,48 @@ func HashPK2Channels(primaryKeys *schemapb.IDs, shardNames []string) []uint32 {
 	return hashValues
 }
 
+// HashInt64PartitionKey hashes an int64 partition key by the hash function, see common.PartitionKeyHashFunctionKey
+func HashInt64PartitionKey(v int64, hashFunction string) uint32 {
+	switch hashFunction {
+	case common.PartitionKeyHashXXHash:
+		b := make([]byte, 8)
+		common.Endian.PutUint64(b, uint64(v))
+		return uint32(xxhash.Sum64(b)) & 0x7fffffff
+	default:
+		value, _ := Hash32Int64(v)
+		return value
+	}
+}
+
+// HashStringPartitionKey hashes a varchar partition key by the hash function, see common.PartitionKeyHashFunctionKey
+func HashStringPartitionKey(v string, hashFunction string) uint32 {
+	switch hashFunction {
+	case common.PartitionKeyHashMurmur3:
+		value, _ := Hash32Bytes([]byte(v))
+		return value
+	case common.PartitionKeyHashXXHash:
+		return uint32(xxhash.Sum64String(v)) & 0x7fffffff
+	default:
+		return HashString2Uint32(v)
+	}
+}
+
 // HashKey2Partitions hash partition keys to partitions
-func HashKey2Partitions(keys *schemapb.FieldData, partitionNames []string) ([]uint32, error) {
-	var hashValues []uint32
+func HashKey2Partitions(keys *schemapb.FieldData, partitionNames []string, hashFunction string) ([]uint32, error) {
+	hashValues, err := HashKeys(keys, hashFunction)
+	if err != nil {
+		return nil, err
+	}
 	numPartitions := uint32(len(partitionNames))
+	for i := range hashValues {
+		hashValues[i] %= numPartitions
+	}
+	return hashValues, nil
+}
+
+// HashKeys returns the hash values of the partition keys
+func HashKeys(keys *schemapb.FieldData, hashFunction string) ([]uint32, error) {
+	var hashValues []uint32
 	switch keys.Field.(type) {
 	case *schemapb.FieldData_Scalars:
 		scalarField := keys.GetScalars()
@@ -121,14 +160,12 @@ func HashKey2Partitions(keys *schemapb.FieldData, partitionNames []string) ([]ui
 		case *schemapb.ScalarField_LongData:
 			longKeys := scalarField.GetLongData().Data
 			for _, key := range longKeys {
-				value, _ := Hash32Int64(key)
-				hashValues = append(hashValues, value%numPartitions)
+				hashValues = append(hashValues, HashInt64PartitionKey(key, hashFunction))
 			}
 		case *schemapb.ScalarField_StringData:
 			stringKeys := scalarField.GetStringData().Data
 			for _, key := range stringKeys {
-				value := HashString2Uint32(key)
-				hashValues = append(hashValues, value%numPartitions)
+				hashValues = append(hashValues, HashStringPartitionKey(key, hashFunction))
 			}
 		default:
 			return nil, errors.New("currently only support DataType Int64 or VarChar as partition key Field")
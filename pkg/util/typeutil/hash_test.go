@@ -18,12 +18,14 @@ package typeutil
 
 import (
 	"log"
+	"math"
 	"testing"
 	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
 )
 
 func TestUint64(t *testing.T) {
@@ -167,6 +169,57 @@ func TestHashPK2Channels(t *testing.T) {
 	assert.Equal(t, ret[1], ret[2])
 }
 
+func TestHashKeys(t *testing.T) {
+	keys := &schemapb.FieldData{
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{
+					StringData: &schemapb.StringArray{Data: []string{"abcdef", "milvus", "abcdef"}},
+				},
+			},
+		},
+	}
+	hashValues, err := HashKeys(keys, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1267612143, HashString2Uint32("milvus"), 1267612143}, hashValues)
+
+	partitions, err := HashKey2Partitions(keys, []string{"p0", "p1", "p2"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1267612143 % 3, HashString2Uint32("milvus") % 3, 1267612143 % 3}, partitions)
+
+	_, err = HashKeys(&schemapb.FieldData{Field: &schemapb.FieldData_Vectors{}}, "")
+	assert.Error(t, err)
+}
+
+func TestHashPartitionKey(t *testing.T) {
+	legacy, _ := Hash32Int64(100)
+	assert.Equal(t, legacy, HashInt64PartitionKey(100, ""))
+	assert.Equal(t, legacy, HashInt64PartitionKey(100, common.PartitionKeyHashMurmur3))
+	assert.Equal(t, HashString2Uint32("milvus"), HashStringPartitionKey("milvus", ""))
+	murmur, _ := Hash32Bytes([]byte("milvus"))
+	assert.Equal(t, murmur, HashStringPartitionKey("milvus", common.PartitionKeyHashMurmur3))
+
+	assert.Equal(t, HashInt64PartitionKey(100, common.PartitionKeyHashXXHash), HashInt64PartitionKey(100, common.PartitionKeyHashXXHash))
+	assert.NotEqual(t, legacy, HashInt64PartitionKey(100, common.PartitionKeyHashXXHash))
+	assert.NotEqual(t, murmur, HashStringPartitionKey("milvus", common.PartitionKeyHashXXHash))
+
+	keys := &schemapb.FieldData{
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{
+					LongData: &schemapb.LongArray{Data: []int64{1, 2, 3}},
+				},
+			},
+		},
+	}
+	hashValues, err := HashKeys(keys, common.PartitionKeyHashXXHash)
+	assert.NoError(t, err)
+	for i, key := range []int64{1, 2, 3} {
+		assert.Equal(t, HashInt64PartitionKey(key, common.PartitionKeyHashXXHash), hashValues[i])
+		assert.LessOrEqual(t, hashValues[i], uint32(math.MaxInt32))
+	}
+}
+
 func TestRearrangePartitionsForPartitionKey(t *testing.T) {
 	// invalid partition name
 	partitions := map[string]int64{
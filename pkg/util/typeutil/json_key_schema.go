@@ -0,0 +1,153 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// The value types recorded by JSONKeySchema.
+const (
+	JSONTypeObject = "object"
+	JSONTypeArray  = "array"
+	JSONTypeString = "string"
+	JSONTypeInt    = "int"
+	JSONTypeDouble = "double"
+	JSONTypeBool   = "bool"
+	JSONTypeNull   = "null"
+)
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONKeySchema records the key paths and the types of the values appearing in JSON documents.
+// The paths are JSON pointers (RFC 6901), e.g. /a/b for {"a": {"b": 1}}, the elements of arrays are not recorded.
+// At most maxKeys paths are recorded, the new paths beyond it are dropped and the schema is marked truncated.
+type JSONKeySchema struct {
+	maxKeys   int
+	keys      map[string]Set[string]
+	truncated bool
+}
+
+func NewJSONKeySchema(maxKeys int) *JSONKeySchema {
+	return &JSONKeySchema{
+		maxKeys: maxKeys,
+		keys:    make(map[string]Set[string]),
+	}
+}
+
+// Observe records the keys of the JSON document, which is ignored if it's not an object.
+func (s *JSONKeySchema) Observe(doc []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	if obj, ok := value.(map[string]any); ok {
+		s.observeObject("", obj)
+	}
+	return nil
+}
+
+func (s *JSONKeySchema) observeObject(prefix string, obj map[string]any) {
+	for key, value := range obj {
+		path := prefix + "/" + jsonPointerEscaper.Replace(key)
+		s.Add(path, jsonValueType(value))
+		if child, ok := value.(map[string]any); ok {
+			s.observeObject(path, child)
+		}
+	}
+}
+
+func jsonValueType(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		return JSONTypeObject
+	case []any:
+		return JSONTypeArray
+	case string:
+		return JSONTypeString
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return JSONTypeDouble
+		}
+		return JSONTypeInt
+	case bool:
+		return JSONTypeBool
+	default:
+		return JSONTypeNull
+	}
+}
+
+// Add records the types of the path, returns true if anything new is recorded.
+func (s *JSONKeySchema) Add(path string, types ...string) bool {
+	set, ok := s.keys[path]
+	if !ok {
+		if s.maxKeys > 0 && len(s.keys) >= s.maxKeys {
+			s.truncated = true
+			return false
+		}
+		set = NewSet[string]()
+		s.keys[path] = set
+	}
+	changed := !ok
+	for _, t := range types {
+		if !set.Contain(t) {
+			set.Insert(t)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Merge records the keys of another schema, returns true if anything new is recorded
+// or the schema becomes truncated.
+func (s *JSONKeySchema) Merge(keys map[string][]string, truncated bool) bool {
+	changed := truncated && !s.truncated
+	s.truncated = s.truncated || truncated
+	for path, types := range keys {
+		wasTruncated := s.truncated
+		if s.Add(path, types...) {
+			changed = true
+		}
+		changed = changed || s.truncated != wasTruncated
+	}
+	return changed
+}
+
+// Keys returns the recorded paths and their sorted types.
+func (s *JSONKeySchema) Keys() map[string][]string {
+	keys := make(map[string][]string, len(s.keys))
+	for path, set := range s.keys {
+		types := set.Collect()
+		sort.Strings(types)
+		keys[path] = types
+	}
+	return keys
+}
+
+func (s *JSONKeySchema) Len() int {
+	return len(s.keys)
+}
+
+// Truncated returns true if any path is dropped because of the limit of keys.
+func (s *JSONKeySchema) Truncated() bool {
+	return s.truncated
+}
@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONKeySchema(t *testing.T) {
+	s := NewJSONKeySchema(0)
+	assert.NoError(t, s.Observe([]byte(`{"a": 1, "b": {"c": "x", "d/e": [1, 2]}, "f": null}`)))
+	assert.NoError(t, s.Observe([]byte(`{"a": 1.5, "b": true, "g~": 1e3}`)))
+	assert.NoError(t, s.Observe([]byte(`[1, 2]`)))
+	assert.Error(t, s.Observe([]byte(`{"a":`)))
+
+	assert.Equal(t, map[string][]string{
+		"/a":      {JSONTypeDouble, JSONTypeInt},
+		"/b":      {JSONTypeBool, JSONTypeObject},
+		"/b/c":    {JSONTypeString},
+		"/b/d~1e": {JSONTypeArray},
+		"/f":      {JSONTypeNull},
+		"/g~0":    {JSONTypeDouble},
+	}, s.Keys())
+	assert.False(t, s.Truncated())
+
+	assert.False(t, s.Merge(map[string][]string{"/a": {JSONTypeInt}}, false))
+	assert.True(t, s.Merge(map[string][]string{"/a": {JSONTypeString}}, false))
+	assert.Equal(t, []string{JSONTypeDouble, JSONTypeInt, JSONTypeString}, s.Keys()["/a"])
+	assert.True(t, s.Merge(nil, true))
+	assert.True(t, s.Truncated())
+	assert.False(t, s.Merge(nil, true))
+}
+
+func TestJSONKeySchemaMaxKeys(t *testing.T) {
+	s := NewJSONKeySchema(2)
+	assert.NoError(t, s.Observe([]byte(`{"a": 1, "b": 2, "c": 3}`)))
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Truncated())
+
+	// types of recorded keys are still added
+	for path := range s.Keys() {
+		assert.True(t, s.Add(path, JSONTypeString))
+	}
+	assert.False(t, s.Add("/d", JSONTypeInt))
+	assert.Equal(t, 2, s.Len())
+
+	// dropping keys makes a schema truncated
+	other := NewJSONKeySchema(1)
+	assert.True(t, other.Merge(map[string][]string{"/a": {JSONTypeInt}, "/b": {JSONTypeInt}}, false))
+	assert.Equal(t, 1, other.Len())
+	assert.True(t, other.Truncated())
+}
@@ -48,6 +48,8 @@ const (
 	IndexNodeRole = "indexnode"
 	// MixtureRole is a constant represents Mixture running modtoe
 	MixtureRole = "mixture"
+	// MixCoordRole is a constant represents running rootcoord, datacoord and querycoord in one process
+	MixCoordRole = "mixcoord"
 	// StreamingCoord is a constant represent StreamingCoord
 	StreamingCoordRole = "streamingcoord"
 	// StreamingNode is a constant represent StreamingNode
@@ -58,6 +60,7 @@ var (
 	serverTypeSet = NewSet(
 		StandaloneRole,
 		RootCoordRole,
+		MixCoordRole,
 		ProxyRole,
 		QueryCoordRole,
 		QueryNodeRole,